@@ -11,6 +11,7 @@ import (
 	"errors"
 	"log/slog"
 	"os"
+	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +29,57 @@ type HwMgrAdaptorIntf interface {
 	GetResources(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourceInfo, int, error)
 }
 
+// HwMgrAdaptorInfoIntf is implemented by adaptors that can report details of their version and backend
+type HwMgrAdaptorInfoIntf interface {
+	GetAdaptorInfo(ctx context.Context) invserver.AdaptorInfo
+}
+
+// HwMgrAdaptorStatusIntf is implemented by adaptors that are disabled while their backend is unavailable, such as
+// when the CRDs of the backend are not installed
+type HwMgrAdaptorStatusIntf interface {
+	// DisabledReason returns the reason the adaptor is disabled, or an empty string if it is enabled
+	DisabledReason() string
+}
+
+// BackendAllocation is hardware allocated in the backend of a hardware manager
+type BackendAllocation struct {
+	HwMgrNodeId string
+	HwMgrNodeNs string
+	// Group is what the hardware is allocated to in the backend, such as a Dell resource group, if known
+	Group string
+	// LeaseExpiry is when the lease held on the allocation expires, if it has one
+	LeaseExpiry *time.Time
+}
+
+// HwMgrAdaptorAllocationsIntf is implemented by adaptors that can list the hardware allocated in their backend, to
+// be compared with the Nodes of the hardware manager
+type HwMgrAdaptorAllocationsIntf interface {
+	GetBackendAllocations(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]BackendAllocation, error)
+}
+
+// HwMgrAdaptorLeaseIntf is implemented by adaptors that hold leases on their backend allocations, so that hardware left
+// allocated without a Node, such as after a crash, is released once its lease expires
+type HwMgrAdaptorLeaseIntf interface {
+	// RenewLeases renews the leases of the hardware allocated to the NodePool, returning how long until they are next
+	// due for renewal, or zero if leases are not enabled for the hardware manager
+	RenewLeases(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (time.Duration, error)
+	// ReleaseExpiredAllocation releases hardware allocated without a Node whose lease has expired
+	ReleaseExpiredAllocation(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, allocation BackendAllocation) error
+}
+
+// HwMgrAdaptorInventoryCacheIntf is implemented by adaptors that cache the inventory queried from their backend
+type HwMgrAdaptorInventoryCacheIntf interface {
+	// RefreshInventory queries the inventory from the backend, replacing the cached inventory, and returns the number
+	// of resource pools and resources queried
+	RefreshInventory(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) (int, int, error)
+}
+
+// HwMgrAdaptorSelfTestIntf is implemented by adaptors that can run non-destructive checks of their backend as part
+// of a HardwareManager self-test, such as authentication, in addition to the inventory checks run for all adaptors
+type HwMgrAdaptorSelfTestIntf interface {
+	SelfTest(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) []pluginv1alpha1.SelfTestCheck
+}
+
 // Define the HwMgrAdaptor structures
 type HwMgrAdaptorConfig struct {
 	client.Client
@@ -0,0 +1,106 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptorinterface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// HwMgrAdaptorLifecycleIntf is implemented by adaptors that run background loops, such as pollers and watchers. The
+// adaptors are started once the manager has started its caches, and stopped in the reverse order when it shuts down.
+type HwMgrAdaptorLifecycleIntf interface {
+	// Start starts the background loops of the adaptor, returning once they are started. The context is cancelled
+	// when the manager shuts down.
+	Start(ctx context.Context) error
+	// Stop stops the background loops of the adaptor, waiting for them to return until the context is done
+	Stop(ctx context.Context) error
+}
+
+// BackgroundLoop is a loop run in the background by an adaptor until its context is cancelled
+type BackgroundLoop struct {
+	Name string
+	// LeaderOnly defers the loop until the replica is elected leader
+	LeaderOnly bool
+	Run        func(ctx context.Context) error
+}
+
+// BackgroundLoops runs the background loops of an adaptor, from its Start hook to its Stop hook. The zero value is
+// ready to use.
+type BackgroundLoops struct {
+	Logger *slog.Logger
+	// Elected is closed once the replica is elected leader, such as the Elected channel of the manager
+	Elected <-chan struct{}
+
+	mu      sync.Mutex
+	loops   []BackgroundLoop
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// Add adds a loop, to be run from the next Start
+func (b *BackgroundLoops) Add(loop BackgroundLoop) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.loops = append(b.loops, loop)
+}
+
+// Start runs each of the loops in its own goroutine
+func (b *BackgroundLoops) Start(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cancel != nil {
+		return errors.New("background loops already started")
+	}
+
+	ctx, b.cancel = context.WithCancel(ctx)
+	for _, loop := range b.loops {
+		b.running.Add(1)
+		go func() {
+			defer b.running.Done()
+			if loop.LeaderOnly {
+				select {
+				case <-ctx.Done():
+					return
+				case <-b.Elected:
+				}
+			}
+			if err := loop.Run(ctx); err != nil && b.Logger != nil {
+				b.Logger.ErrorContext(ctx, "Background loop failed", slog.String("loop", loop.Name),
+					slog.String("error", err.Error()))
+			}
+		}()
+	}
+	return nil
+}
+
+// Stop cancels the loops and waits for them to return until the context is done
+func (b *BackgroundLoops) Stop(ctx context.Context) error {
+	b.mu.Lock()
+	cancel := b.cancel
+	b.cancel = nil
+	b.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for background loops to stop: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,75 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptorinterface
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBackgroundLoops(t *testing.T) {
+	elected := make(chan struct{})
+	loops := &BackgroundLoops{Elected: elected}
+
+	started := make(chan string, 2)
+	run := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			started <- name
+			<-ctx.Done()
+			return nil
+		}
+	}
+	loops.Add(BackgroundLoop{Name: "all", Run: run("all")})
+	loops.Add(BackgroundLoop{Name: "leader", LeaderOnly: true, Run: run("leader")})
+
+	if err := loops.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := loops.Start(context.Background()); err == nil {
+		t.Errorf("expected an error starting the loops twice")
+	}
+
+	if name := <-started; name != "all" {
+		t.Fatalf("expected the leader-only loop to wait for the election, got %s", name)
+	}
+	select {
+	case name := <-started:
+		t.Fatalf("unexpected start of %s before the election", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(elected)
+	if name := <-started; name != "leader" {
+		t.Fatalf("expected the leader-only loop to start once elected, got %s", name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := loops.Stop(ctx); err != nil {
+		t.Errorf("unexpected error stopping the loops: %v", err)
+	}
+}
+
+func TestBackgroundLoopsStopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	loops := &BackgroundLoops{}
+	loops.Add(BackgroundLoop{Name: "stuck", Run: func(_ context.Context) error {
+		<-release
+		return nil
+	}})
+	if err := loops.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := loops.Stop(ctx); err == nil {
+		t.Errorf("expected an error for a loop that does not stop")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+)
+
+// adaptorStopTimeout bounds how long the adaptors may take to stop their background loops on shutdown
+const adaptorStopTimeout = 30 * time.Second
+
+// adaptorLifecycle starts the adaptors that run background loops once the manager has started, in the order of their
+// IDs, and stops them in the reverse order when the manager shuts down. It runs on every replica, leaving the adaptors
+// to defer their leader-only loops until the replica is elected.
+type adaptorLifecycle struct {
+	controller *HwMgrAdaptorController
+}
+
+func (l *adaptorLifecycle) NeedLeaderElection() bool {
+	return false
+}
+
+func (l *adaptorLifecycle) Start(ctx context.Context) error {
+	c := l.controller
+	ids := c.lifecycleAdaptorIDs()
+
+	for i, id := range ids {
+		if err := c.adaptors[id].(adaptorinterface.HwMgrAdaptorLifecycleIntf).Start(ctx); err != nil {
+			c.stopAdaptors(ids[:i])
+			return fmt.Errorf("failed to start adaptor %s: %w", id, err)
+		}
+		c.Logger.InfoContext(ctx, "Adaptor started", slog.String("id", id))
+	}
+
+	<-ctx.Done()
+	c.stopAdaptors(ids)
+	return nil
+}
+
+// lifecycleAdaptorIDs returns the IDs of the adaptors with lifecycle hooks, in the order they are started
+func (c *HwMgrAdaptorController) lifecycleAdaptorIDs() []string {
+	var ids []string
+	for id, adaptor := range c.adaptors {
+		if _, ok := adaptor.(adaptorinterface.HwMgrAdaptorLifecycleIntf); ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// stopAdaptors stops the adaptors in the reverse order they were started. Failures are logged, so that each adaptor
+// is given the chance to stop.
+func (c *HwMgrAdaptorController) stopAdaptors(ids []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), adaptorStopTimeout)
+	defer cancel()
+
+	for i := len(ids) - 1; i >= 0; i-- {
+		if err := c.adaptors[ids[i]].(adaptorinterface.HwMgrAdaptorLifecycleIntf).Stop(ctx); err != nil {
+			c.Logger.ErrorContext(ctx, "Failed to stop adaptor", slog.String("id", ids[i]),
+				slog.String("error", err.Error()))
+			continue
+		}
+		c.Logger.InfoContext(ctx, "Adaptor stopped", slog.String("id", ids[i]))
+	}
+}
+
+// setupAdaptorLifecycle registers the starting and stopping of the adaptors with the manager
+func (c *HwMgrAdaptorController) setupAdaptorLifecycle(mgr ctrl.Manager) error {
+	if err := mgr.Add(&adaptorLifecycle{controller: c}); err != nil {
+		return fmt.Errorf("failed to add adaptor lifecycle runnable: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+)
+
+// newLifecycleAdaptors registers a fake adaptor for each ID, recording the order of the Start and Stop calls
+func newLifecycleAdaptors(c *HwMgrAdaptorController, ids ...string) (map[string]*testsupport.FakeAdaptor, func() []string) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(call string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	}
+
+	fakes := make(map[string]*testsupport.FakeAdaptor)
+	for _, id := range ids {
+		fake := testsupport.NewFakeAdaptor()
+		fake.StartFunc = func(_ context.Context) error {
+			record("Start " + id)
+			return fake.StartErr
+		}
+		fake.StopFunc = func(_ context.Context) error {
+			record("Stop " + id)
+			return fake.StopErr
+		}
+		c.RegisterAdaptor(id, fake)
+		fakes[id] = fake
+	}
+	return fakes, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(calls)
+	}
+}
+
+func TestAdaptorLifecycle(t *testing.T) {
+	c := &HwMgrAdaptorController{Logger: slog.Default()}
+	_, calls := newLifecycleAdaptors(c, Metal3AdaptorID, DellHwMgrAdaptorID, LoopbackAdaptorID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- (&adaptorLifecycle{controller: c}).Start(ctx)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(calls()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"Start " + DellHwMgrAdaptorID, "Start " + LoopbackAdaptorID, "Start " + Metal3AdaptorID,
+		"Stop " + Metal3AdaptorID, "Stop " + LoopbackAdaptorID, "Stop " + DellHwMgrAdaptorID,
+	}
+	if !slices.Equal(calls(), expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls())
+	}
+}
+
+func TestAdaptorLifecycleStartFailure(t *testing.T) {
+	c := &HwMgrAdaptorController{Logger: slog.Default()}
+	fakes, calls := newLifecycleAdaptors(c, DellHwMgrAdaptorID, LoopbackAdaptorID, Metal3AdaptorID)
+	fakes[LoopbackAdaptorID].StartErr = errors.New("backend unreachable")
+
+	if err := (&adaptorLifecycle{controller: c}).Start(context.Background()); err == nil {
+		t.Fatalf("expected the start failure to be returned")
+	}
+
+	// The adaptors started before the failure are stopped, and the remaining adaptors are not started
+	expected := []string{"Start " + DellHwMgrAdaptorID, "Start " + LoopbackAdaptorID, "Stop " + DellHwMgrAdaptorID}
+	if !slices.Equal(calls(), expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls())
+	}
+}
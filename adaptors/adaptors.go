@@ -11,6 +11,9 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
@@ -20,23 +23,27 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
 	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/version"
 
 	// Import the adaptors
 	dellhwmgr "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr"
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/loopback"
 	metal3 "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/metal3"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/supermicro"
 )
 
 // Supported adaptor IDs
 const (
-	LoopbackAdaptorID  = "loopback"
-	DellHwMgrAdaptorID = "dell-hwmgr"
-	Metal3AdaptorID    = "metal3"
+	LoopbackAdaptorID   = "loopback"
+	DellHwMgrAdaptorID  = "dell-hwmgr"
+	Metal3AdaptorID     = "metal3"
+	SupermicroAdaptorID = "supermicro"
 )
 
 // HwMgrAdaptorController
@@ -46,7 +53,16 @@ type HwMgrAdaptorController struct {
 	Scheme          *runtime.Scheme
 	Logger          *slog.Logger
 	Namespace       string
-	adaptors        map[string]adaptorinterface.HwMgrAdaptorIntf
+	// NodePoolEvents triggers reconciles of the NodePools from the changes of their hardware seen by the adaptors
+	NodePoolEvents chan<- event.GenericEvent
+	// Clock is the source of time of the reports, metrics and status timestamps of the controller, the real clock if unset
+	Clock          utils.Clock
+	adaptors       map[string]adaptorinterface.HwMgrAdaptorIntf
+	inventoryReady atomic.Bool
+}
+
+func (c *HwMgrAdaptorController) clock() utils.Clock {
+	return utils.ClockOrReal(c.Clock)
 }
 
 func (c *HwMgrAdaptorController) SetupWithManager(mgr ctrl.Manager) error {
@@ -54,7 +70,10 @@ func (c *HwMgrAdaptorController) SetupWithManager(mgr ctrl.Manager) error {
 	c.adaptors = make(map[string]adaptorinterface.HwMgrAdaptorIntf)
 	c.adaptors[LoopbackAdaptorID] = loopback.NewAdaptor(c.Client, c.NoncachedClient, c.Scheme, c.Logger, c.Namespace)
 	c.adaptors[DellHwMgrAdaptorID] = dellhwmgr.NewAdaptor(c.Client, c.NoncachedClient, c.Scheme, c.Logger, c.Namespace)
-	c.adaptors[Metal3AdaptorID] = metal3.NewAdaptor(c.Client, c.NoncachedClient, c.Scheme, c.Logger, c.Namespace)
+	metal3Adaptor := metal3.NewAdaptor(c.Client, c.NoncachedClient, c.Scheme, c.Logger, c.Namespace)
+	metal3Adaptor.NodePoolEvents = c.NodePoolEvents
+	c.adaptors[Metal3AdaptorID] = metal3Adaptor
+	c.adaptors[SupermicroAdaptorID] = supermicro.NewAdaptor(c.Client, c.NoncachedClient, c.Scheme, c.Logger, c.Namespace)
 
 	for id, adaptor := range c.adaptors {
 		if err := adaptor.SetupAdaptor(mgr); err != nil {
@@ -62,9 +81,46 @@ func (c *HwMgrAdaptorController) SetupWithManager(mgr ctrl.Manager) error {
 		}
 	}
 
+	if err := c.setupAdaptorLifecycle(mgr); err != nil {
+		return err
+	}
+
+	if err := c.setupInventoryWarmup(mgr); err != nil {
+		return err
+	}
+
+	if err := c.setupAllocationReconciler(mgr); err != nil {
+		return err
+	}
+
+	if err := c.setupSelfTest(mgr); err != nil {
+		return err
+	}
+
+	if err := c.setupLifecycleMetrics(mgr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// RegisterAdaptor registers an adaptor implementation for the given adaptor ID, replacing any existing one. This
+// allows tests to substitute a fake adaptor, such as testsupport.FakeAdaptor.
+func (c *HwMgrAdaptorController) RegisterAdaptor(id string, adaptor adaptorinterface.HwMgrAdaptorIntf) {
+	if c.adaptors == nil {
+		c.adaptors = make(map[string]adaptorinterface.HwMgrAdaptorIntf)
+	}
+	c.adaptors[id] = adaptor
+}
+
+// adaptorDisabledReason returns the reason an adaptor is disabled, or an empty string if it is enabled
+func adaptorDisabledReason(adaptor adaptorinterface.HwMgrAdaptorIntf) string {
+	if status, ok := adaptor.(adaptorinterface.HwMgrAdaptorStatusIntf); ok {
+		return status.DisabledReason()
+	}
+	return ""
+}
+
 func (c *HwMgrAdaptorController) getHwMgr(ctx context.Context, hwMgrId string) (*pluginv1alpha1.HardwareManager, int, error) {
 	name := types.NamespacedName{
 		Name:      hwMgrId,
@@ -89,6 +145,10 @@ func (c *HwMgrAdaptorController) getHwMgr(ctx context.Context, hwMgrId string) (
 		}
 	case pluginv1alpha1.SupportedAdaptors.Metal3:
 		c.Logger.InfoContext(ctx, "HardwareManager", slog.String("name", hwmgr.Name))
+	case pluginv1alpha1.SupportedAdaptors.Supermicro:
+		if hwmgr.Spec.SupermicroData == nil {
+			return nil, http.StatusServiceUnavailable, fmt.Errorf("required config data missing from HardwareManager: name=%s", hwmgr.Name)
+		}
 	default:
 		return nil, http.StatusServiceUnavailable, fmt.Errorf("unsupported adaptorId (%s) HardwareManager: name=%s", hwmgr.Spec.AdaptorID, hwmgr.Name)
 	}
@@ -99,6 +159,38 @@ func (c *HwMgrAdaptorController) getHwMgr(ctx context.Context, hwMgrId string) (
 // HandleNodePool calls the applicable adaptor handler to process the NodePool CR
 func (c *HwMgrAdaptorController) HandleNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
 	ctx = logging.AppendCtx(ctx, slog.String("hwmgr", nodepool.Spec.HwMgrId))
+
+	traceId, err := c.ensureTraceId(ctx, nodepool)
+	if err != nil {
+		return utils.RequeueWithShortInterval(), err
+	}
+	if traceId != "" {
+		ctx = logging.AppendCtx(ctx, slog.String("traceId", traceId))
+	}
+
+	backends, err := utils.GetNodePoolBackends(nodepool)
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "invalid NodePool extensions", slog.String("error", err.Error()))
+
+		if err := utils.UpdateNodePoolStatusCondition(ctx, c.Client, nodepool,
+			hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse, err.Error()); err != nil {
+			return utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+
+		return utils.DoNotRequeue(), nil
+	}
+
+	// A NodePool allocated from several HardwareManagers is processed through a member NodePool for each
+	if len(backends) > 1 {
+		result, err := c.handleMultiBackendNodePool(ctx, nodepool, backends)
+		c.updateStatusSummaries(ctx, nodepool)
+		return result, err
+	}
+	if result, released, err := c.releaseMemberNodePools(ctx, nodepool); !released {
+		return result, err
+	}
+
 	hwmgr, _, err := c.getHwMgr(ctx, nodepool.Spec.HwMgrId)
 	if err != nil {
 		c.Logger.ErrorContext(ctx, "failed to get adaptor instance", slog.String("error", err.Error()))
@@ -130,7 +222,18 @@ func (c *HwMgrAdaptorController) HandleNodePool(ctx context.Context, nodepool *h
 		return utils.DoNotRequeue(), nil
 	}
 
-	result, err := adaptor.HandleNodePool(ctx, hwmgr, nodepool)
+	// The NodePool is left as is while the adaptor is disabled, and processed once it is enabled again
+	if reason := adaptorDisabledReason(adaptor); reason != "" {
+		c.Logger.WarnContext(ctx, "adaptor is disabled, deferring NodePool", slog.String("adaptorID", adaptorID),
+			slog.String("reason", reason))
+		return utils.RequeueWithLongInterval(), nil
+	}
+
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationAllocate)
+	defer cancel()
+
+	result, err := adaptor.HandleNodePool(opCtx, hwmgr, nodepool)
+	c.updateStatusSummaries(ctx, nodepool)
 	if err != nil {
 		return result, fmt.Errorf("failed HandleNodePool for adaptorID %s: %w", adaptorID, err)
 	}
@@ -142,11 +245,57 @@ func (c *HwMgrAdaptorController) HandleNodePool(ctx context.Context, nodepool *h
 		}
 	}
 
-	return result, nil
+	return c.renewLeases(ctx, adaptor, hwmgr, nodepool, result), nil
+}
+
+// renewLeases renews the leases of the hardware allocated to the NodePool, if the adaptor holds leases, and ensures
+// the NodePool is requeued before they are next due for renewal. Failures are logged rather than returned, as the
+// lease is only renewed once half of it has elapsed, leaving time for a later attempt to succeed. The renewal has its
+// own allocation timeout, as the handling of the NodePool may have used up its own.
+func (c *HwMgrAdaptorController) renewLeases(ctx context.Context, adaptor adaptorinterface.HwMgrAdaptorIntf,
+	hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool, result ctrl.Result) ctrl.Result {
+	leaser, ok := adaptor.(adaptorinterface.HwMgrAdaptorLeaseIntf)
+	if !ok {
+		return result
+	}
+
+	renewCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationAllocate)
+	defer cancel()
+
+	renewIn, err := leaser.RenewLeases(renewCtx, hwmgr, nodepool)
+	if err != nil {
+		c.Logger.WarnContext(ctx, "Unable to renew allocation leases", slog.String("nodepool", nodepool.Name),
+			slog.String("error", err.Error()))
+	}
+	return requeueForRenewal(result, renewIn)
+}
+
+// requeueForRenewal shortens the requeue interval of the result so that leases are renewed in time
+func requeueForRenewal(result ctrl.Result, renewIn time.Duration) ctrl.Result {
+	if renewIn <= 0 || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+	if result.RequeueAfter == 0 || result.RequeueAfter > renewIn {
+		result.RequeueAfter = renewIn
+	}
+	return result
 }
 
 // HandleNodePool calls the applicable adaptor handler to process the NodePool CR deletion
 func (c *HwMgrAdaptorController) HandleNodePoolDeletion(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+	// Members are deleted first, including those left from a previous spec of the NodePool
+	remaining, err := c.deleteMemberNodePools(ctx, nodepool, nil)
+	if err != nil || remaining > 0 {
+		return false, err
+	}
+	backends, err := utils.GetNodePoolBackends(nodepool)
+	if err != nil {
+		return false, fmt.Errorf("failed to get hardware managers of NodePool %s: %w", nodepool.Name, err)
+	}
+	if len(backends) > 1 {
+		return true, nil
+	}
+
 	hwmgr, _, err := c.getHwMgr(ctx, nodepool.Spec.HwMgrId)
 	if err != nil {
 		return false, fmt.Errorf("failed to get HardwareManager CR (%s): %w", nodepool.Spec.HwMgrId, err)
@@ -161,7 +310,17 @@ func (c *HwMgrAdaptorController) HandleNodePoolDeletion(ctx context.Context, nod
 		return true, nil
 	}
 
-	completed, err := adaptor.HandleNodePoolDeletion(ctx, hwmgr, nodepool)
+	if reason := adaptorDisabledReason(adaptor); reason != "" {
+		return false, fmt.Errorf("unable to release NodePool, adaptor %s is disabled: %s", adaptorID, reason)
+	}
+
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationRelease)
+	defer cancel()
+
+	completed, err := adaptor.HandleNodePoolDeletion(opCtx, hwmgr, nodepool)
+	if !completed {
+		c.updateStatusSummaries(ctx, nodepool)
+	}
 	if err != nil {
 		return false, fmt.Errorf("failed HandleNodePoolDeletion for adaptorID %s: %w", adaptorID, err)
 	}
@@ -169,38 +328,48 @@ func (c *HwMgrAdaptorController) HandleNodePoolDeletion(ctx context.Context, nod
 	return completed, nil
 }
 
-// HandleNodePool calls the applicable adaptor handler to process the NodePool CR deletion
-func (c *HwMgrAdaptorController) GetResourcePools(ctx context.Context, request invserver.GetResourcePoolsRequestObject) (invserver.GetResourcePoolsResponseObject, error) {
+// updateStatusSummaries refreshes the status summary metadata of the NodePool and its Nodes. Failures are logged
+// rather than returned, as the summary is informational only.
+func (c *HwMgrAdaptorController) updateStatusSummaries(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) {
+	current := &hwmgmtv1alpha1.NodePool{}
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(nodepool), current); err != nil {
+		c.Logger.DebugContext(ctx, "unable to get NodePool for status summary", slog.String("error", err.Error()))
+		return
+	}
+	if err := utils.UpdateNodePoolStatusSummary(ctx, c.Client, current, c.clock()); err != nil {
+		c.Logger.WarnContext(ctx, "failed to update NodePool status summary", slog.String("error", err.Error()))
+	}
 
-	hwmgr, statusCode, err := c.getHwMgr(ctx, request.HwMgrId)
+	nodelist, err := utils.GetChildNodes(ctx, c.Logger, c.Client, current)
 	if err != nil {
-		if statusCode == http.StatusNotFound {
-			return invserver.GetResourcePools404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
-				Status: statusCode,
-				Detail: fmt.Sprintf("Hardware Manager %s not found", request.HwMgrId),
-			}), fmt.Errorf("hardware manager %s not found: %w", request.HwMgrId, err)
-		} else {
-			return invserver.GetResourcePools503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
-				Status: statusCode,
-				Detail: fmt.Sprintf("Hardware Manager %s unavailable: %s", request.HwMgrId, err.Error()),
-			}), fmt.Errorf("unable to get hardware manager %s: %w", request.HwMgrId, err)
+		c.Logger.WarnContext(ctx, "failed to get child nodes for status summary", slog.String("error", err.Error()))
+		return
+	}
+	for i := range nodelist.Items {
+		if err := utils.UpdateNodeStatusSummary(ctx, c.Client, &nodelist.Items[i], c.clock()); err != nil {
+			c.Logger.WarnContext(ctx, "failed to update Node status summary", slog.String("error", err.Error()))
 		}
 	}
+}
 
-	adaptorID := string(hwmgr.Spec.AdaptorID)
-
-	// Validate the specified adaptor ID
-	adaptor, exists := c.adaptors[adaptorID]
-	if !exists {
-		// We should never get here, as the adaptor ID is validated in getHwMgr
-		c.Logger.ErrorContext(ctx, "unsupported adaptor ID", slog.String("adaptorID", adaptorID))
-		return invserver.GetResourcePools500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
-			Status: statusCode,
-			Detail: fmt.Sprintf("Hardware Manager %s specifies invalid adaptorId: %s", request.HwMgrId, adaptorID),
-		}), fmt.Errorf("hardware manager %s species invalid adaptorId: %s", request.HwMgrId, adaptorID)
+// HandleNodePool calls the applicable adaptor handler to process the NodePool CR deletion
+func (c *HwMgrAdaptorController) GetResourcePools(ctx context.Context, request invserver.GetResourcePoolsRequestObject) (invserver.GetResourcePoolsResponseObject, error) {
+	hwmgr, adaptor, problem, err := c.getInventoryAdaptor(ctx, request.HwMgrId)
+	if problem != nil {
+		switch problem.Status {
+		case http.StatusNotFound:
+			return invserver.GetResourcePools404ApplicationProblemPlusJSONResponse(*problem), err
+		case http.StatusServiceUnavailable:
+			return invserver.GetResourcePools503ApplicationProblemPlusJSONResponse(*problem), err
+		default:
+			return invserver.GetResourcePools500ApplicationProblemPlusJSONResponse(*problem), err
+		}
 	}
 
-	resp, statusCode, err := adaptor.GetResourcePools(ctx, hwmgr)
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
+
+	resp, statusCode, err := adaptor.GetResourcePools(opCtx, hwmgr)
 	if err != nil {
 		c.Logger.ErrorContext(ctx, "unable to get resource pools from hardware manager", slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
 		return invserver.GetResourcePools500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
@@ -214,36 +383,22 @@ func (c *HwMgrAdaptorController) GetResourcePools(ctx context.Context, request i
 
 // HandleNodePool calls the applicable adaptor handler to process the NodePool CR deletion
 func (c *HwMgrAdaptorController) GetResources(ctx context.Context, request invserver.GetResourcesRequestObject) (invserver.GetResourcesResponseObject, error) {
-
-	hwmgr, statusCode, err := c.getHwMgr(ctx, request.HwMgrId)
-	if err != nil {
-		if statusCode == http.StatusNotFound {
-			return invserver.GetResources404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
-				Status: statusCode,
-				Detail: fmt.Sprintf("Hardware Manager %s not found", request.HwMgrId),
-			}), fmt.Errorf("hardware manager %s not found: %w", request.HwMgrId, err)
-		} else {
-			return invserver.GetResources503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
-				Status: statusCode,
-				Detail: fmt.Sprintf("Hardware Manager %s unavailable: %s", request.HwMgrId, err.Error()),
-			}), fmt.Errorf("unable to get hardware manager %s: %w", request.HwMgrId, err)
+	hwmgr, adaptor, problem, err := c.getInventoryAdaptor(ctx, request.HwMgrId)
+	if problem != nil {
+		switch problem.Status {
+		case http.StatusNotFound:
+			return invserver.GetResources404ApplicationProblemPlusJSONResponse(*problem), err
+		case http.StatusServiceUnavailable:
+			return invserver.GetResources503ApplicationProblemPlusJSONResponse(*problem), err
+		default:
+			return invserver.GetResources500ApplicationProblemPlusJSONResponse(*problem), err
 		}
 	}
 
-	adaptorID := string(hwmgr.Spec.AdaptorID)
-
-	// Validate the specified adaptor ID
-	adaptor, exists := c.adaptors[adaptorID]
-	if !exists {
-		// We should never get here, as the adaptor ID is validated in getHwMgr
-		c.Logger.ErrorContext(ctx, "unsupported adaptor ID", slog.String("adaptorID", adaptorID))
-		return invserver.GetResources500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
-			Status: statusCode,
-			Detail: fmt.Sprintf("Hardware Manager %s specifies invalid adaptorId: %s", request.HwMgrId, adaptorID),
-		}), fmt.Errorf("hardware manager %s species invalid adaptorId: %s", request.HwMgrId, adaptorID)
-	}
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
 
-	resp, statusCode, err := adaptor.GetResources(ctx, hwmgr)
+	resp, statusCode, err := adaptor.GetResources(opCtx, hwmgr)
 	if err != nil {
 		c.Logger.ErrorContext(ctx, "unable to get resources from hardware manager", slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
 		return invserver.GetResources500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
@@ -254,3 +409,37 @@ func (c *HwMgrAdaptorController) GetResources(ctx context.Context, request invse
 
 	return invserver.GetResources200JSONResponse(resp), nil
 }
+
+// GetPluginInfo reports the plugin version, along with the version and backend details of each adaptor. Disabled
+// adaptors are reported with the reason they are disabled.
+func (c *HwMgrAdaptorController) GetPluginInfo(ctx context.Context, _ invserver.GetPluginInfoRequestObject) (invserver.GetPluginInfoResponseObject, error) {
+	ids := make([]string, 0, len(c.adaptors))
+	for id := range c.adaptors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	adaptorInfo := make([]invserver.AdaptorInfo, 0, len(ids))
+	for _, id := range ids {
+		info := invserver.AdaptorInfo{Version: version.Version}
+		reason := adaptorDisabledReason(c.adaptors[id])
+		if reporter, ok := c.adaptors[id].(adaptorinterface.HwMgrAdaptorInfoIntf); ok && reason == "" {
+			info = reporter.GetAdaptorInfo(ctx)
+		}
+		info.AdaptorId = id
+		info.Enabled = reason == ""
+		if reason != "" {
+			info.StatusMessage = &reason
+		}
+		adaptorInfo = append(adaptorInfo, info)
+	}
+
+	gitSHA := version.GetGitSHA()
+	goVersion := version.GoVersion()
+	return invserver.GetPluginInfo200JSONResponse(invserver.PluginInfo{
+		Version:   version.Version,
+		GitSha:    &gitSHA,
+		GoVersion: &goVersion,
+		Adaptors:  adaptorInfo,
+	}), nil
+}
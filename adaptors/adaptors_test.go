@@ -0,0 +1,124 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// hwmgrClient serves a single HardwareManager for Get calls
+type hwmgrClient struct {
+	client.Client
+	hwmgr *pluginv1alpha1.HardwareManager
+}
+
+func (c *hwmgrClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	if key.Name != c.hwmgr.Name {
+		return errors.New("not found")
+	}
+	c.hwmgr.DeepCopyInto(obj.(*pluginv1alpha1.HardwareManager))
+	return nil
+}
+
+func TestGetResourcesWithFakeAdaptor(t *testing.T) {
+	fake := testsupport.NewFakeAdaptor()
+	fake.Resources = []invserver.ResourceInfo{{ResourceId: "node-1"}}
+
+	c := &HwMgrAdaptorController{
+		Client: &hwmgrClient{hwmgr: &pluginv1alpha1.HardwareManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1"},
+			Spec:       pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+		}},
+		Logger: slog.Default(),
+	}
+	c.RegisterAdaptor(LoopbackAdaptorID, fake)
+
+	// The inventory is unavailable until warmed up
+	resp, _ := c.GetResources(context.Background(), invserver.GetResourcesRequestObject{HwMgrId: "hwmgr-1"})
+	if _, ok := resp.(invserver.GetResources503ApplicationProblemPlusJSONResponse); !ok {
+		t.Fatalf("expected 503 response before warm-up, got %T", resp)
+	}
+
+	c.MarkInventoryReady()
+	resp, err := c.GetResources(context.Background(), invserver.GetResourcesRequestObject{HwMgrId: "hwmgr-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resources, ok := resp.(invserver.GetResources200JSONResponse)
+	if !ok || len(resources) != 1 || resources[0].ResourceId != "node-1" {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+	if fake.CallCount("GetResources") != 1 {
+		t.Errorf("expected one GetResources call, got %v", fake.Calls())
+	}
+
+	fake.ResourcesStatusCode = http.StatusInternalServerError
+	fake.ResourcesErr = errors.New("backend unavailable")
+	resp, _ = c.GetResources(context.Background(), invserver.GetResourcesRequestObject{HwMgrId: "hwmgr-1"})
+	if _, ok := resp.(invserver.GetResources500ApplicationProblemPlusJSONResponse); !ok {
+		t.Errorf("expected 500 response on adaptor failure, got %T", resp)
+	}
+}
+
+func TestDisabledAdaptor(t *testing.T) {
+	fake := testsupport.NewFakeAdaptor()
+	fake.Disabled = "BareMetalHost CRD is not installed"
+
+	c := &HwMgrAdaptorController{
+		Client: &hwmgrClient{hwmgr: &pluginv1alpha1.HardwareManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1"},
+			Spec:       pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+		}},
+		Logger: slog.Default(),
+	}
+	c.RegisterAdaptor(LoopbackAdaptorID, fake)
+	c.MarkInventoryReady()
+
+	resp, err := c.GetResources(context.Background(), invserver.GetResourcesRequestObject{HwMgrId: "hwmgr-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(invserver.GetResources503ApplicationProblemPlusJSONResponse); !ok {
+		t.Errorf("expected 503 response from a disabled adaptor, got %T", resp)
+	}
+	sites, err := c.GetSites(context.Background(), invserver.GetSitesRequestObject{HwMgrId: "hwmgr-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := sites.(invserver.GetSites503ApplicationProblemPlusJSONResponse); !ok {
+		t.Errorf("expected 503 sites response from a disabled adaptor, got %T", sites)
+	}
+	if fake.CallCount("GetResources") != 0 || fake.CallCount("GetResourcePools") != 0 {
+		t.Errorf("expected no calls to a disabled adaptor, got %v", fake.Calls())
+	}
+
+	resp2, _ := c.GetPluginInfo(context.Background(), invserver.GetPluginInfoRequestObject{})
+	info, ok := resp2.(invserver.GetPluginInfo200JSONResponse)
+	if !ok || len(info.Adaptors) != 1 {
+		t.Fatalf("unexpected response: %#v", resp2)
+	}
+	if adaptor := info.Adaptors[0]; adaptor.Enabled || adaptor.StatusMessage == nil || *adaptor.StatusMessage != fake.Disabled {
+		t.Errorf("expected the adaptor to be reported as disabled, got %+v", adaptor)
+	}
+
+	fake.Disabled = ""
+	resp2, _ = c.GetPluginInfo(context.Background(), invserver.GetPluginInfoRequestObject{})
+	if adaptor := resp2.(invserver.GetPluginInfo200JSONResponse).Adaptors[0]; !adaptor.Enabled || adaptor.StatusMessage != nil {
+		t.Errorf("expected the adaptor to be reported as enabled, got %+v", adaptor)
+	}
+}
@@ -0,0 +1,404 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// The Nodes of each hardware manager are periodically compared with the hardware allocated in its backend, to catch
+// hardware allocated without a Node, such as after a failed release, or Nodes whose hardware is no longer allocated.
+// As both are expected to differ briefly while a NodePool is provisioned or released, only discrepancies found by two
+// consecutive comparisons are reported in the InventoryConsistent condition and the discrepancy metric.
+const (
+	allocationReconcileInterval = 10 * time.Minute
+
+	// maxReportedDiscrepancies bounds the number of discrepancies listed in the condition message
+	maxReportedDiscrepancies = 3
+)
+
+var errAllocationsNotSupported = errors.New("adaptor does not report backend allocations")
+
+// allocationKey identifies hardware by its backend namespace and identifier
+func allocationKey(hwMgrNodeNs, hwMgrNodeId string) string {
+	return hwMgrNodeNs + "/" + hwMgrNodeId
+}
+
+// discrepancyKey identifies a discrepancy across comparisons
+func discrepancyKey(discrepancy invserver.AllocationDiscrepancy) string {
+	ns := ""
+	if discrepancy.HwMgrNodeNs != nil {
+		ns = *discrepancy.HwMgrNodeNs
+	}
+	return string(discrepancy.Type) + "/" + allocationKey(ns, discrepancy.HwMgrNodeId)
+}
+
+// withoutNodeKey is the key of the discrepancy reported for a backend allocation without a Node
+func withoutNodeKey(allocation adaptorinterface.BackendAllocation) string {
+	return string(invserver.BackendAllocationWithoutNode) + "/" + allocationKey(allocation.HwMgrNodeNs, allocation.HwMgrNodeId)
+}
+
+// compareAllocations compares the Nodes of a hardware manager with the hardware allocated in its backend. Nodes being
+// deleted are skipped, along with their hardware, as it may already be released. Replaced nodes are skipped, as their
+// hardware was released and may since be allocated to another node.
+func compareAllocations(hwMgrId string, nodes []hwmgmtv1alpha1.Node, allocations []adaptorinterface.BackendAllocation,
+	now time.Time) invserver.AllocationReport {
+	report := invserver.AllocationReport{
+		HwMgrId:         hwMgrId,
+		GeneratedAt:     now.UTC(),
+		AllocationCount: len(allocations),
+		Discrepancies:   []invserver.AllocationDiscrepancy{},
+	}
+
+	allocated := make(map[string]bool, len(allocations))
+	for _, allocation := range allocations {
+		allocated[allocationKey(allocation.HwMgrNodeNs, allocation.HwMgrNodeId)] = true
+	}
+
+	matched := make(map[string]bool, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Spec.HwMgrId != hwMgrId || utils.IsNodeReplaced(node) {
+			continue
+		}
+		key := allocationKey(node.Spec.HwMgrNodeNs, node.Spec.HwMgrNodeId)
+		matched[key] = true
+		if node.DeletionTimestamp != nil {
+			continue
+		}
+
+		report.NodeCount++
+		if !allocated[key] {
+			discrepancy := invserver.AllocationDiscrepancy{
+				Type:        invserver.NodeWithoutBackendAllocation,
+				HwMgrNodeId: node.Spec.HwMgrNodeId,
+				NodeName:    &node.Name,
+				NodePool:    &node.Spec.NodePool,
+			}
+			if node.Spec.HwMgrNodeNs != "" {
+				discrepancy.HwMgrNodeNs = &node.Spec.HwMgrNodeNs
+			}
+			report.Discrepancies = append(report.Discrepancies, discrepancy)
+		}
+	}
+
+	for _, allocation := range allocations {
+		if matched[allocationKey(allocation.HwMgrNodeNs, allocation.HwMgrNodeId)] {
+			continue
+		}
+		discrepancy := invserver.AllocationDiscrepancy{
+			Type:        invserver.BackendAllocationWithoutNode,
+			HwMgrNodeId: allocation.HwMgrNodeId,
+		}
+		if allocation.HwMgrNodeNs != "" {
+			discrepancy.HwMgrNodeNs = &allocation.HwMgrNodeNs
+		}
+		if allocation.Group != "" {
+			discrepancy.NodePool = &allocation.Group
+		}
+		report.Discrepancies = append(report.Discrepancies, discrepancy)
+	}
+
+	sort.Slice(report.Discrepancies, func(i, j int) bool {
+		return discrepancyKey(report.Discrepancies[i]) < discrepancyKey(report.Discrepancies[j])
+	})
+	return report
+}
+
+// persistingDiscrepancies returns the discrepancies of the report that were also found by the previous comparison
+func persistingDiscrepancies(report invserver.AllocationReport, previous map[string]bool) []invserver.AllocationDiscrepancy {
+	var persisting []invserver.AllocationDiscrepancy
+	for _, discrepancy := range report.Discrepancies {
+		if previous[discrepancyKey(discrepancy)] {
+			persisting = append(persisting, discrepancy)
+		}
+	}
+	return persisting
+}
+
+// describeDiscrepancy summarizes a discrepancy for the condition message
+func describeDiscrepancy(discrepancy invserver.AllocationDiscrepancy) string {
+	if discrepancy.Type == invserver.NodeWithoutBackendAllocation && discrepancy.NodeName != nil {
+		return fmt.Sprintf("node %s has no backend allocation for %s", *discrepancy.NodeName, discrepancy.HwMgrNodeId)
+	}
+	return fmt.Sprintf("%s is allocated in the backend without a node", discrepancy.HwMgrNodeId)
+}
+
+// expiredAllocations returns the backend allocations reported without a Node whose lease expired before now
+func expiredAllocations(report invserver.AllocationReport, allocations []adaptorinterface.BackendAllocation,
+	now time.Time) []adaptorinterface.BackendAllocation {
+	withoutNode := make(map[string]bool)
+	for _, discrepancy := range report.Discrepancies {
+		if discrepancy.Type == invserver.BackendAllocationWithoutNode {
+			withoutNode[discrepancyKey(discrepancy)] = true
+		}
+	}
+
+	var expired []adaptorinterface.BackendAllocation
+	for _, allocation := range allocations {
+		if allocation.LeaseExpiry == nil || !allocation.LeaseExpiry.Before(now) {
+			continue
+		}
+		if withoutNode[withoutNodeKey(allocation)] {
+			expired = append(expired, allocation)
+		}
+	}
+	return expired
+}
+
+// dropDiscrepancy removes the discrepancy for the released hardware from the report
+func dropDiscrepancy(report *invserver.AllocationReport, released adaptorinterface.BackendAllocation) {
+	report.Discrepancies = slices.DeleteFunc(report.Discrepancies, func(discrepancy invserver.AllocationDiscrepancy) bool {
+		return discrepancyKey(discrepancy) == withoutNodeKey(released)
+	})
+	report.AllocationCount--
+}
+
+// buildAllocationReport compares the Nodes of the hardware manager with the hardware allocated in its backend,
+// returning the report along with the backend allocations
+func (c *HwMgrAdaptorController) buildAllocationReport(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) (
+	*invserver.AllocationReport, []adaptorinterface.BackendAllocation, error) {
+	adaptor, exists := c.adaptors[string(hwmgr.Spec.AdaptorID)]
+	if !exists {
+		return nil, nil, fmt.Errorf("hardware manager %s specifies invalid adaptorId: %s", hwmgr.Name, hwmgr.Spec.AdaptorID)
+	}
+	reporter, ok := adaptor.(adaptorinterface.HwMgrAdaptorAllocationsIntf)
+	if !ok {
+		return nil, nil, errAllocationsNotSupported
+	}
+
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
+
+	allocations, err := reporter.GetBackendAllocations(opCtx, hwmgr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get backend allocations for %s: %w", hwmgr.Name, err)
+	}
+
+	nodes := &hwmgmtv1alpha1.NodeList{}
+	if err := c.Client.List(ctx, nodes, client.InNamespace(c.Namespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	report := compareAllocations(hwmgr.Name, nodes.Items, allocations, c.clock().Now())
+	return &report, allocations, nil
+}
+
+// GetAllocationReport compares the Nodes of the hardware manager with the hardware allocated in its backend
+func (c *HwMgrAdaptorController) GetAllocationReport(ctx context.Context, request invserver.GetAllocationReportRequestObject) (invserver.GetAllocationReportResponseObject, error) {
+	if !c.IsInventoryReady() {
+		return invserver.GetAllocationReport503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: "Inventory is not yet available, warm-up in progress",
+		}), nil
+	}
+
+	hwmgr, statusCode, err := c.getHwMgr(ctx, request.HwMgrId)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return invserver.GetAllocationReport404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+				Status: statusCode,
+				Detail: fmt.Sprintf("Hardware Manager %s not found", request.HwMgrId),
+			}), fmt.Errorf("hardware manager %s not found: %w", request.HwMgrId, err)
+		}
+		return invserver.GetAllocationReport503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: fmt.Sprintf("Hardware Manager %s unavailable: %s", request.HwMgrId, err.Error()),
+		}), fmt.Errorf("unable to get hardware manager %s: %w", request.HwMgrId, err)
+	}
+
+	report, _, err := c.buildAllocationReport(ctx, hwmgr)
+	switch {
+	case errors.Is(err, errAllocationsNotSupported):
+		return invserver.GetAllocationReport501ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusNotImplemented,
+			Detail: fmt.Sprintf("Hardware Manager %s adaptor %s does not report backend allocations", request.HwMgrId, hwmgr.Spec.AdaptorID),
+		}), nil
+	case typederrors.IsUnavailableError(err):
+		return invserver.GetAllocationReport503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: fmt.Sprintf("Hardware Manager %s unavailable: %s", request.HwMgrId, err.Error()),
+		}), err
+	case err != nil:
+		c.Logger.ErrorContext(ctx, "unable to build allocation report", slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
+		return invserver.GetAllocationReport500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Allocation report failed for %s: %s", request.HwMgrId, err.Error()),
+		}), err
+	}
+
+	return invserver.GetAllocationReport200JSONResponse(*report), nil
+}
+
+// allocationReconciler periodically compares the Nodes of each hardware manager with its backend allocations. It
+// only runs on the leader, as it updates the HardwareManager status.
+type allocationReconciler struct {
+	controller *HwMgrAdaptorController
+	interval   time.Duration
+	// previous holds the keys of the discrepancies found by the previous comparison of each hardware manager
+	previous map[string]map[string]bool
+}
+
+func (r *allocationReconciler) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *allocationReconciler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.reconcile(ctx)
+		}
+	}
+}
+
+func (r *allocationReconciler) reconcile(ctx context.Context) {
+	c := r.controller
+
+	hwmgrs := &pluginv1alpha1.HardwareManagerList{}
+	if err := c.Client.List(ctx, hwmgrs, client.InNamespace(c.Namespace)); err != nil {
+		c.Logger.WarnContext(ctx, "Unable to list hardware managers for allocation reconciliation", slog.String("error", err.Error()))
+		return
+	}
+
+	current := make(map[string]map[string]bool, len(hwmgrs.Items))
+	for i := range hwmgrs.Items {
+		hwmgr := &hwmgrs.Items[i]
+		report, allocations, err := c.buildAllocationReport(ctx, hwmgr)
+		if err != nil {
+			if !errors.Is(err, errAllocationsNotSupported) {
+				c.Logger.WarnContext(ctx, "Unable to compare nodes with backend allocations",
+					slog.String("hwmgr", hwmgr.Name), slog.String("error", err.Error()))
+				// Keep the previous result, so that a failed comparison does not reset the grace period
+				current[hwmgr.Name] = r.previous[hwmgr.Name]
+			}
+			continue
+		}
+		c.releaseExpiredAllocations(ctx, hwmgr, report, allocations)
+
+		keys := make(map[string]bool, len(report.Discrepancies))
+		for _, discrepancy := range report.Discrepancies {
+			keys[discrepancyKey(discrepancy)] = true
+		}
+		current[hwmgr.Name] = keys
+
+		persisting := persistingDiscrepancies(*report, r.previous[hwmgr.Name])
+		recordAllocationDiscrepancies(hwmgr.Name, persisting)
+		if err := c.setInventoryConsistentCondition(ctx, hwmgr.Name, persisting); err != nil {
+			c.Logger.WarnContext(ctx, "Unable to update InventoryConsistent condition",
+				slog.String("hwmgr", hwmgr.Name), slog.String("error", err.Error()))
+		}
+		if len(persisting) > 0 {
+			c.Logger.WarnContext(ctx, "Nodes differ from backend allocations",
+				slog.String("hwmgr", hwmgr.Name), slog.Int("discrepancies", len(persisting)))
+		}
+	}
+
+	for name := range r.previous {
+		if _, exists := current[name]; !exists {
+			forgetAllocationDiscrepancies(name)
+		}
+	}
+	r.previous = current
+}
+
+// releaseExpiredAllocations releases the hardware allocated without a Node whose lease has expired, if the adaptor
+// holds leases, and removes it from the report
+func (c *HwMgrAdaptorController) releaseExpiredAllocations(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	report *invserver.AllocationReport, allocations []adaptorinterface.BackendAllocation) {
+	leaser, ok := c.adaptors[string(hwmgr.Spec.AdaptorID)].(adaptorinterface.HwMgrAdaptorLeaseIntf)
+	if !ok {
+		return
+	}
+
+	for _, allocation := range expiredAllocations(*report, allocations, c.clock().Now()) {
+		opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationRelease)
+		err := leaser.ReleaseExpiredAllocation(opCtx, hwmgr, allocation)
+		cancel()
+		if err != nil {
+			c.Logger.WarnContext(ctx, "Unable to release allocation with expired lease", slog.String("hwmgr", hwmgr.Name),
+				slog.String("hwMgrNodeId", allocation.HwMgrNodeId), slog.String("error", err.Error()))
+			continue
+		}
+		c.Logger.InfoContext(ctx, "Released allocation with expired lease", slog.String("hwmgr", hwmgr.Name),
+			slog.String("hwMgrNodeId", allocation.HwMgrNodeId), slog.Time("leaseExpiry", *allocation.LeaseExpiry))
+		dropDiscrepancy(report, allocation)
+	}
+}
+
+// setInventoryConsistentCondition reports the discrepancies between the Nodes and backend allocations of a hardware
+// manager in its InventoryConsistent condition
+func (c *HwMgrAdaptorController) setInventoryConsistentCondition(ctx context.Context, hwmgrName string,
+	discrepancies []invserver.AllocationDiscrepancy) error {
+	status := metav1.ConditionTrue
+	reason := pluginv1alpha1.ConditionReasons.Completed
+	message := "Nodes match the hardware allocated in the backend"
+	if len(discrepancies) > 0 {
+		status = metav1.ConditionFalse
+		reason = pluginv1alpha1.ConditionReasons.Failed
+
+		descriptions := make([]string, 0, maxReportedDiscrepancies)
+		for _, discrepancy := range discrepancies[:min(len(discrepancies), maxReportedDiscrepancies)] {
+			descriptions = append(descriptions, describeDiscrepancy(discrepancy))
+		}
+		message = fmt.Sprintf("%d discrepancies between nodes and backend allocations: %s", len(discrepancies),
+			strings.Join(descriptions, "; "))
+		if len(discrepancies) > maxReportedDiscrepancies {
+			message += "; see the allocation report for the full list"
+		}
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		hwmgr := &pluginv1alpha1.HardwareManager{}
+		if err := c.Client.Get(ctx, types.NamespacedName{Name: hwmgrName, Namespace: c.Namespace}, hwmgr); err != nil {
+			return err // nolint: wrapcheck
+		}
+		if !utils.SetStatusCondition(&hwmgr.Status.Conditions,
+			string(pluginv1alpha1.ConditionTypes.InventoryConsistent), string(reason), status, message) {
+			return nil
+		}
+		return c.Client.Status().Update(ctx, hwmgr) // nolint: wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update hardware manager %s status: %w", hwmgrName, err)
+	}
+	return nil
+}
+
+// setupAllocationReconciler registers the periodic comparison of Nodes with backend allocations with the manager
+func (c *HwMgrAdaptorController) setupAllocationReconciler(mgr ctrl.Manager) error {
+	if err := mgr.Add(&allocationReconciler{controller: c, interval: allocationReconcileInterval}); err != nil {
+		return fmt.Errorf("failed to add allocation reconciler runnable: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// nodesClient extends hwmgrClient to serve a fixed list of Nodes
+type nodesClient struct {
+	hwmgrClient
+	nodes []hwmgmtv1alpha1.Node
+}
+
+func (c *nodesClient) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	list.(*hwmgmtv1alpha1.NodeList).Items = c.nodes
+	return nil
+}
+
+func testNode(name, hwMgrId, hwMgrNodeNs, hwMgrNodeId string) hwmgmtv1alpha1.Node {
+	return hwmgmtv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: hwmgmtv1alpha1.NodeSpec{
+			NodePool:    "np-1",
+			HwMgrId:     hwMgrId,
+			HwMgrNodeNs: hwMgrNodeNs,
+			HwMgrNodeId: hwMgrNodeId,
+		},
+	}
+}
+
+func TestCompareAllocations(t *testing.T) {
+	deleting := testNode("node-4", "hwmgr-1", "ns", "bmh-4")
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	replaced := testNode("node-6", "hwmgr-1", "ns", "bmh-6")
+	replaced.Annotations = map[string]string{utils.NodeReplacedAnnotation: "BareMetalHost failed"}
+
+	nodes := []hwmgmtv1alpha1.Node{
+		testNode("node-1", "hwmgr-1", "ns", "bmh-1"),
+		testNode("node-2", "hwmgr-1", "ns", "bmh-2"),
+		testNode("node-3", "hwmgr-2", "ns", "bmh-3"),
+		deleting,
+		replaced,
+	}
+	allocations := []adaptorinterface.BackendAllocation{
+		{HwMgrNodeNs: "ns", HwMgrNodeId: "bmh-1"},
+		{HwMgrNodeNs: "ns", HwMgrNodeId: "bmh-5", Group: "np-2"},
+	}
+
+	report := compareAllocations("hwmgr-1", nodes, allocations, time.Now())
+	if report.NodeCount != 2 || report.AllocationCount != 2 {
+		t.Errorf("unexpected counts: %d nodes, %d allocations", report.NodeCount, report.AllocationCount)
+	}
+	if len(report.Discrepancies) != 2 {
+		t.Fatalf("expected 2 discrepancies, got %#v", report.Discrepancies)
+	}
+
+	orphan := report.Discrepancies[0]
+	if orphan.Type != invserver.BackendAllocationWithoutNode || orphan.HwMgrNodeId != "bmh-5" ||
+		orphan.NodePool == nil || *orphan.NodePool != "np-2" {
+		t.Errorf("unexpected discrepancy: %#v", orphan)
+	}
+	missing := report.Discrepancies[1]
+	if missing.Type != invserver.NodeWithoutBackendAllocation || missing.HwMgrNodeId != "bmh-2" ||
+		missing.NodeName == nil || *missing.NodeName != "node-2" {
+		t.Errorf("unexpected discrepancy: %#v", missing)
+	}
+}
+
+func TestPersistingDiscrepancies(t *testing.T) {
+	report := compareAllocations("hwmgr-1", nil, []adaptorinterface.BackendAllocation{
+		{HwMgrNodeId: "node-a"},
+		{HwMgrNodeId: "node-b"},
+	}, time.Now())
+
+	if persisting := persistingDiscrepancies(report, nil); len(persisting) != 0 {
+		t.Errorf("expected no persisting discrepancies on first comparison, got %#v", persisting)
+	}
+
+	previous := map[string]bool{discrepancyKey(report.Discrepancies[1]): true}
+	persisting := persistingDiscrepancies(report, previous)
+	if len(persisting) != 1 || persisting[0].HwMgrNodeId != "node-b" {
+		t.Errorf("unexpected persisting discrepancies: %#v", persisting)
+	}
+}
+
+func TestExpiredAllocations(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+	valid := now.Add(time.Hour)
+
+	nodes := []hwmgmtv1alpha1.Node{testNode("node-1", "hwmgr-1", "ns", "bmh-1")}
+	allocations := []adaptorinterface.BackendAllocation{
+		{HwMgrNodeNs: "ns", HwMgrNodeId: "bmh-1", LeaseExpiry: &expired},
+		{HwMgrNodeNs: "ns", HwMgrNodeId: "bmh-2", LeaseExpiry: &expired},
+		{HwMgrNodeNs: "ns", HwMgrNodeId: "bmh-3", LeaseExpiry: &valid},
+		{HwMgrNodeNs: "ns", HwMgrNodeId: "bmh-4"},
+	}
+
+	report := compareAllocations("hwmgr-1", nodes, allocations, now)
+	released := expiredAllocations(report, allocations, now)
+	if len(released) != 1 || released[0].HwMgrNodeId != "bmh-2" {
+		t.Fatalf("expected only the expired allocation without a node, got %#v", released)
+	}
+
+	dropDiscrepancy(&report, released[0])
+	if len(report.Discrepancies) != 2 || report.AllocationCount != 3 {
+		t.Errorf("expected the released allocation to be dropped from the report, got %#v", report)
+	}
+}
+
+func TestRequeueForRenewal(t *testing.T) {
+	renewIn := 5 * time.Minute
+	for _, tc := range []struct {
+		result   ctrl.Result
+		expected ctrl.Result
+	}{
+		{ctrl.Result{}, ctrl.Result{RequeueAfter: renewIn}},
+		{ctrl.Result{RequeueAfter: time.Hour}, ctrl.Result{RequeueAfter: renewIn}},
+		{ctrl.Result{RequeueAfter: time.Minute}, ctrl.Result{RequeueAfter: time.Minute}},
+		{ctrl.Result{Requeue: true}, ctrl.Result{Requeue: true}},
+	} {
+		if result := requeueForRenewal(tc.result, renewIn); result != tc.expected {
+			t.Errorf("expected %#v for %#v, got %#v", tc.expected, tc.result, result)
+		}
+	}
+	if result := requeueForRenewal(ctrl.Result{}, 0); result != (ctrl.Result{}) {
+		t.Errorf("expected the result to be unchanged without leases, got %#v", result)
+	}
+}
+
+// leaseAdaptor extends the fake adaptor with leases, recording the context of the renewals
+type leaseAdaptor struct {
+	*testsupport.FakeAdaptor
+	renewCtx context.Context
+}
+
+func (a *leaseAdaptor) RenewLeases(ctx context.Context, _ *pluginv1alpha1.HardwareManager,
+	_ *hwmgmtv1alpha1.NodePool) (time.Duration, error) {
+	a.renewCtx = ctx
+	return time.Minute, ctx.Err()
+}
+
+func (a *leaseAdaptor) ReleaseExpiredAllocation(_ context.Context, _ *pluginv1alpha1.HardwareManager,
+	_ adaptorinterface.BackendAllocation) error {
+	return nil
+}
+
+func TestRenewLeases(t *testing.T) {
+	c := &HwMgrAdaptorController{Logger: slog.Default()}
+	adaptor := &leaseAdaptor{FakeAdaptor: testsupport.NewFakeAdaptor()}
+	hwmgr := &pluginv1alpha1.HardwareManager{Spec: pluginv1alpha1.HardwareManagerSpec{
+		Timeouts: &pluginv1alpha1.OperationTimeouts{Allocate: &metav1.Duration{Duration: time.Hour}},
+	}}
+
+	// The renewal has its own budget, rather than what is left of the handling of the NodePool
+	result := c.renewLeases(context.Background(), adaptor, hwmgr, &hwmgmtv1alpha1.NodePool{}, ctrl.Result{})
+	if result.RequeueAfter != time.Minute {
+		t.Errorf("expected a requeue for the renewal, got %#v", result)
+	}
+	deadline, bounded := adaptor.renewCtx.Deadline()
+	if !bounded || time.Until(deadline) <= 59*time.Minute {
+		t.Errorf("expected the renewal to be bounded by the allocation timeout, got deadline %s", deadline)
+	}
+	if adaptor.renewCtx.Err() == nil {
+		t.Errorf("expected the context of the renewal to be released once done")
+	}
+}
+
+func TestGetAllocationReportWithFakeAdaptor(t *testing.T) {
+	fake := testsupport.NewFakeAdaptor()
+	fake.Allocations = []adaptorinterface.BackendAllocation{{HwMgrNodeId: "node-1"}}
+
+	c := &HwMgrAdaptorController{
+		Client: &nodesClient{
+			hwmgrClient: hwmgrClient{hwmgr: &pluginv1alpha1.HardwareManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1"},
+				Spec:       pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+			}},
+			nodes: []hwmgmtv1alpha1.Node{testNode("node-1", "hwmgr-1", "", "node-1")},
+		},
+		Logger: slog.Default(),
+	}
+	c.RegisterAdaptor(LoopbackAdaptorID, fake)
+	c.MarkInventoryReady()
+
+	resp, err := c.GetAllocationReport(context.Background(), invserver.GetAllocationReportRequestObject{HwMgrId: "hwmgr-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report, ok := resp.(invserver.GetAllocationReport200JSONResponse)
+	if !ok || report.NodeCount != 1 || len(report.Discrepancies) != 0 {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+}
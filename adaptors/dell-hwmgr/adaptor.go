@@ -12,11 +12,13 @@ import (
 	"log/slog"
 	"net/http"
 
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/controller"
-	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/version"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +36,12 @@ type Adaptor struct {
 	Logger          *slog.Logger
 	Namespace       string
 	AdaptorID       pluginv1alpha1.HardwareManagerAdaptorID
+	snapshots       *inventorySnapshots
+	// loops holds the background loops of the adaptor, run from Start to Stop
+	loops adaptorinterface.BackgroundLoops
+	// Clock is the source of time of the timeouts, job polling and status timestamps of the adaptor, the real
+	// clock if not set
+	Clock utils.Clock
 }
 
 func NewAdaptor(client client.Client, noncachedClient client.Reader, scheme *runtime.Scheme, logger *slog.Logger, namespace string) *Adaptor {
@@ -43,9 +51,15 @@ func NewAdaptor(client client.Client, noncachedClient client.Reader, scheme *run
 		Scheme:          scheme,
 		Logger:          logger.With(slog.String("adaptor", "dell-hwmgr")),
 		Namespace:       namespace,
+		snapshots:       newInventorySnapshots(),
 	}
 }
 
+// clock returns the clock of the adaptor
+func (a *Adaptor) clock() utils.Clock {
+	return utils.ClockOrReal(a.Clock)
+}
+
 // SetupAdaptor sets up the Dell Hardware Manager Adaptor
 func (a *Adaptor) SetupAdaptor(mgr ctrl.Manager) error {
 	a.Logger.Info("SetupAdaptor called for DellHwMgr")
@@ -55,13 +69,35 @@ func (a *Adaptor) SetupAdaptor(mgr ctrl.Manager) error {
 		Scheme:    a.Scheme,
 		Logger:    a.Logger,
 		Namespace: a.Namespace,
+		Clock:     a.Clock,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to setup dell-hwmgr adaptor: %w", err)
 	}
 
+	if err := (&powerActionReconciler{Adaptor: a}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup dell-hwmgr adaptor: %w", err)
+	}
+
+	a.loops.Logger = a.Logger
+	a.loops.Elected = mgr.Elected()
+	a.loops.Add(adaptorinterface.BackgroundLoop{
+		Name: "inventory-resync",
+		Run:  a.runInventoryResyncs,
+	})
+
 	return nil
 }
 
+// Start starts the background loops of the Dell adaptor
+func (a *Adaptor) Start(ctx context.Context) error {
+	return a.loops.Start(ctx)
+}
+
+// Stop stops the background loops of the Dell adaptor
+func (a *Adaptor) Stop(ctx context.Context) error {
+	return a.loops.Stop(ctx)
+}
+
 type fsmAction int
 
 const (
@@ -71,6 +107,15 @@ const (
 	NodePoolFSMNoop
 )
 
+// GetAdaptorInfo reports the adaptor version. The Dell hardware manager does not expose its version or firmware
+// inventory, so only the backend name is known.
+func (a *Adaptor) GetAdaptorInfo(_ context.Context) invserver.AdaptorInfo {
+	return invserver.AdaptorInfo{
+		Version: version.Version,
+		Backend: &invserver.BackendInfo{Name: "dell-hwmgr"},
+	}
+}
+
 func (a *Adaptor) determineAction(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) fsmAction {
 	if len(nodepool.Status.Conditions) == 0 {
 		a.Logger.InfoContext(ctx, "Handling Create NodePool request")
@@ -106,58 +151,101 @@ func (a *Adaptor) determineAction(ctx context.Context, nodepool *hwmgmtv1alpha1.
 func (a *Adaptor) HandleNodePool(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
 	result := utils.DoNotRequeue()
 
-	hwmgrClient, clientErr := hwmgrclient.NewClientWithResponses(ctx, a.Logger, a.Client, hwmgr)
+	hwmgrClient, clientErr := hwmgrclient.NewClientWithClock(ctx, a.Logger, a.Client, hwmgr, a.Clock)
 	if clientErr != nil {
 		// TODO: Improve client error handling to distinguish between connectivity errors, auth, etc
 		a.Logger.InfoContext(ctx, "NewClientWithResponses error", slog.String("error", clientErr.Error()))
-		return result, fmt.Errorf("failed to setup hwmgr client: %w", clientErr)
+		return a.requeueIfUnavailable(ctx, hwmgr, result, fmt.Errorf("failed to setup hwmgr client: %w", clientErr))
 	}
 
+	var err error
 	switch a.determineAction(ctx, nodepool) {
 	case NodePoolFSMCreate:
-		return a.HandleNodePoolCreate(ctx, hwmgrClient, hwmgr, nodepool)
+		result, err = a.HandleNodePoolCreate(ctx, hwmgrClient, hwmgr, nodepool)
 	case NodePoolFSMProcessing:
-		return a.HandleNodePoolProcessing(ctx, hwmgrClient, hwmgr, nodepool)
+		result, err = a.HandleNodePoolProcessing(ctx, hwmgrClient, hwmgr, nodepool)
 	case NodePoolFSMSpecChanged:
-		return a.HandleNodePoolSpecChanged(ctx, hwmgrClient, hwmgr, nodepool)
+		result, err = a.HandleNodePoolSpecChanged(ctx, hwmgrClient, hwmgr, nodepool)
 	case NodePoolFSMNoop:
 		// Nothing to do
-		return result, nil
 	}
 
-	return result, nil
+	return a.requeueIfUnavailable(ctx, hwmgr, result, err)
+}
+
+// requeueIfUnavailable requeues the NodePool for when the circuit breaker of the hardware manager next allows a
+// request, rather than failing, if the error is due to the hardware manager being unavailable
+func (a *Adaptor) requeueIfUnavailable(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, result ctrl.Result, err error) (ctrl.Result, error) {
+	if err == nil || !typederrors.IsUnavailableError(err) {
+		return result, err
+	}
+	circuit := hwmgrclient.GetCircuitStatus(hwmgr)
+	a.Logger.InfoContext(ctx, "Hardware manager unavailable, requeueing", slog.String("error", err.Error()),
+		slog.Duration("retryAfter", circuit.RetryAfter))
+	return utils.RequeueWithCustomInterval(circuit.RetryAfter), nil
 }
 
 func (a *Adaptor) HandleNodePoolDeletion(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
 	a.Logger.InfoContext(ctx, "Finalizing nodepool")
 
-	hwmgrClient, clientErr := hwmgrclient.NewClientWithResponses(ctx, a.Logger, a.Client, hwmgr)
+	hwmgrClient, clientErr := hwmgrclient.NewClientWithClock(ctx, a.Logger, a.Client, hwmgr, a.Clock)
 	if clientErr != nil {
 		// TODO: Improve client error handling to distinguish between connectivity errors, auth, etc
 		a.Logger.InfoContext(ctx, "NewClientWithResponses error", slog.String("error", clientErr.Error()))
 		return false, fmt.Errorf("failed to setup hwmgr client: %w", clientErr)
 	}
 
-	if exists, err := hwmgrClient.ResourceGroupExists(ctx, nodepool); err != nil {
+	// Jobs still in flight, such as the creation of the resource group, are cancelled before it is checked for
+	if cancelled, err := a.cancelInFlightJobs(ctx, hwmgrClient, hwmgr, nodepool); err != nil || !cancelled {
+		return false, err
+	}
+
+	exists, err := hwmgrClient.ResourceGroupExists(ctx, nodepool)
+	if err != nil {
 		return false, fmt.Errorf("resource group existence check failed for cloudID=%s: err: %w", nodepool.Spec.CloudID, err)
-	} else if !exists {
+	}
+
+	if exists {
+		if decommissioned, err := a.decommissionNodePool(ctx, hwmgr, nodepool); err != nil || !decommissioned {
+			return false, err
+		}
+	}
+
+	// The nodes added by scale-outs have resource groups of their own, deleted along with that of the NodePool
+	if released, err := a.releaseScaleOutGroups(ctx, hwmgrClient, hwmgr, nodepool); err != nil || !released {
+		if err != nil {
+			a.failDecommissionRelease(ctx, nodepool, err)
+			return false, fmt.Errorf("failed to release scale-out resource groups of nodepool %s: %w", nodepool.Name, err)
+		}
+		return false, nil
+	}
+
+	if !exists {
 		// The resource group doesn't exist, so there's nothing to delete
 		a.Logger.InfoContext(ctx, "Resource Group no longer exists on hardware manager")
-		return true, nil
+		return a.releaseBMCSecrets(ctx, nodepool)
 	}
 
 	completed, err := a.ReleaseNodePool(ctx, hwmgrClient, hwmgr, nodepool)
 	if err != nil {
+		a.failDecommissionRelease(ctx, nodepool, err)
 		return false, fmt.Errorf("failed to release nodepool %s: %w", nodepool.Name, err)
 	}
+	if !completed {
+		return false, nil
+	}
 
-	return completed, nil
+	if err := a.completeDecommission(ctx, nodepool); err != nil {
+		return false, err
+	}
+	return a.releaseBMCSecrets(ctx, nodepool)
 }
 
-func (a *Adaptor) GetResourcePools(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourcePoolInfo, int, error) {
+// queryResourcePools gets the resource pools from the hardware manager
+func (a *Adaptor) queryResourcePools(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourcePoolInfo, int, error) {
 	var resp []invserver.ResourcePoolInfo
 
-	client, err := hwmgrclient.NewClientWithResponses(ctx, a.Logger, a.Client, hwmgr)
+	client, err := hwmgrclient.NewClientWithClock(ctx, a.Logger, a.Client, hwmgr, a.Clock)
 	if err != nil {
 		// TODO: Expose status errors from client
 		a.Logger.InfoContext(ctx, "NewClientWithResponses error", slog.String("error", err.Error()))
@@ -181,10 +269,11 @@ func (a *Adaptor) GetResourcePools(ctx context.Context, hwmgr *pluginv1alpha1.Ha
 	return resp, http.StatusOK, nil
 }
 
-func (a *Adaptor) GetResources(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourceInfo, int, error) {
+// queryResources gets the resources from the hardware manager
+func (a *Adaptor) queryResources(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourceInfo, int, error) {
 	var resp []invserver.ResourceInfo
 
-	client, err := hwmgrclient.NewClientWithResponses(ctx, a.Logger, a.Client, hwmgr)
+	client, err := hwmgrclient.NewClientWithClock(ctx, a.Logger, a.Client, hwmgr, a.Clock)
 	if err != nil {
 		// TODO: Expose status errors from client
 		a.Logger.InfoContext(ctx, "NewClientWithResponses error", slog.String("error", err.Error()))
@@ -203,15 +292,12 @@ func (a *Adaptor) GetResources(ctx context.Context, hwmgr *pluginv1alpha1.Hardwa
 		return resp, http.StatusInternalServerError, fmt.Errorf("unable to query server inventory: %w", err)
 	}
 
+	index := indexServers(servers)
 	for _, resource := range *resources.Resources {
-		var server *hwmgrapi.ApiprotoServer
-		for _, iter := range *servers.Servers {
-			if resource.Name == nil || iter.Metadata.Name == nil || *resource.Name != *iter.Metadata.Name {
-				continue
-			}
-			server = &iter
+		if resource.Name == nil {
+			continue
 		}
-
+		server := index[*resource.Name]
 		if server == nil {
 			a.Logger.InfoContext(ctx, "Unable to find server info for resource. Skipping",
 				slog.String("resource-name", *resource.Name))
@@ -0,0 +1,72 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// GetBackendAllocations lists the resources allocated to the resource groups created by the plugin
+func (a *Adaptor) GetBackendAllocations(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]adaptorinterface.BackendAllocation, error) {
+	hwmgrClient, err := hwmgrclient.NewClientWithClock(ctx, a.Logger, a.Client, hwmgr, a.Clock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup hwmgr client: %w", err)
+	}
+
+	groups, err := hwmgrClient.GetResourceGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource groups: %w", err)
+	}
+	if groups == nil || groups.ResourceGroups == nil {
+		return nil, nil
+	}
+
+	var allocations []adaptorinterface.BackendAllocation
+	for _, group := range *groups.ResourceGroups {
+		if group.Id == nil || !strings.HasPrefix(*group.Id, hwmgrclient.ResourceGroupIdPrefix) {
+			continue
+		}
+		// The resources of a group are only reported when querying the group itself
+		rg, err := hwmgrClient.GetResourceGroupFromId(ctx, *group.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource group %s: %w", *group.Id, err)
+		}
+		allocations = append(allocations, resourceGroupAllocations(*group.Id, rg)...)
+	}
+	return allocations, nil
+}
+
+// resourceGroupAllocations returns the resources allocated to a resource group
+func resourceGroupAllocations(rgId string, rg *hwmgrapi.RhprotoResourceGroupObjectGetResponseBody) []adaptorinterface.BackendAllocation {
+	if rg == nil || rg.ResourceSelectors == nil {
+		return nil
+	}
+
+	var allocations []adaptorinterface.BackendAllocation
+	for _, selector := range *rg.ResourceSelectors {
+		if selector.Resources == nil {
+			continue
+		}
+		for _, resource := range *selector.Resources {
+			if resource.Id == nil {
+				continue
+			}
+			allocations = append(allocations, adaptorinterface.BackendAllocation{
+				HwMgrNodeId: *resource.Id,
+				Group:       rgId,
+			})
+		}
+	}
+	return allocations
+}
@@ -0,0 +1,136 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// getProfileBiosAttributes returns the BIOS attributes of the named hardware profile, along with the Dell
+// SetBootOrderFqdd attributes setting its boot order. A resource profile defined only on the hardware manager has no
+// HardwareProfile, and so no BIOS attributes to apply. A boot order that Dell servers do not support, or that conflicts
+// with the BIOS attributes of the profile, is refused as an input error.
+func (a *Adaptor) getProfileBiosAttributes(ctx context.Context, name string) (map[string]intstr.IntOrString, error) {
+	profile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, name, a.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to resolve hardware profile %s: %w", name, err)
+	}
+	attributes, err := utils.ProfileBiosAttributes(profile.Spec, utils.VendorDell)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hardware profile %s: %w", name, err)
+	}
+	return attributes, nil
+}
+
+// getNodeBiosAttributes looks up the current BIOS attributes of the server of a node in the server inventory of the
+// reconcile
+func (a *Adaptor) getNodeBiosAttributes(ctx context.Context, hwmgrClient *hwmgrclient.HardwareManagerClient,
+	servers *serverInventory, node *hwmgmtv1alpha1.Node) (map[string]interface{}, error) {
+	resource, err := hwmgrClient.GetResource(ctx, node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource for node %s: %w", node.Name, err)
+	}
+	if resource == nil || resource.Resource == nil || resource.Resource.Name == nil {
+		return nil, fmt.Errorf("resource %s has no name", node.Spec.HwMgrNodeId)
+	}
+
+	name := *resource.Resource.Name
+	server, err := servers.lookup(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BIOS attributes for node %s: %w", node.Name, err)
+	}
+	if server == nil {
+		return nil, fmt.Errorf("server %s not found in server inventory", name)
+	}
+	if server.Status == nil || server.Status.Bios == nil || server.Status.Bios.Attributes == nil {
+		return nil, fmt.Errorf("server %s does not report BIOS attributes", name)
+	}
+	attributes, err := hwmgrclient.BiosAttributesToMap(server.Status.Bios.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BIOS attributes for node %s: %w", node.Name, err)
+	}
+	return attributes, nil
+}
+
+// verifyProfileBiosAttributes verifies the BIOS attributes of the hardware profile of a node once its resource profile
+// update has completed. The hardware manager API only updates the resource profile of a resource, so the attributes,
+// including those setting the boot order, must be set by the resource profile, and are checked against the server
+// inventory. It returns done once the
+// attributes have been verified, or if the profile sets none. Attributes that differ from the profile fail the update.
+func (a *Adaptor) verifyProfileBiosAttributes(
+	ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	servers *serverInventory,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	node *hwmgmtv1alpha1.Node) (ctrl.Result, bool, error) {
+
+	desired, err := a.getProfileBiosAttributes(ctx, node.Spec.HwProfile)
+	if err != nil {
+		if typederrors.IsInputError(err) {
+			return ctrl.Result{}, false, a.failBiosUpdate(ctx, nodepool, node, err.Error())
+		}
+		return utils.RequeueWithShortInterval(), false, err
+	}
+	if len(desired) == 0 {
+		return ctrl.Result{}, true, nil
+	}
+
+	current, err := a.getNodeBiosAttributes(ctx, hwmgrClient, servers, node)
+	if err != nil {
+		return utils.RequeueWithShortInterval(), false, err
+	}
+
+	if len(utils.PendingBiosAttributes(desired, current)) > 0 {
+		return ctrl.Result{}, false, a.failBiosUpdate(ctx, nodepool, node,
+			fmt.Sprintf("BIOS attributes were not applied by resource profile %s: %s", node.Spec.HwProfile,
+				strings.Join(utils.BiosAttributeMismatches(desired, current), "; ")))
+	}
+
+	a.Logger.InfoContext(ctx, "BIOS attributes verified", slog.String("nodename", node.Name))
+	return ctrl.Result{}, true, nil
+}
+
+// failBiosUpdate reports the failure to apply the BIOS attributes of the hardware profile of a node, returning the
+// error for the reconciler
+func (a *Adaptor) failBiosUpdate(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, node *hwmgmtv1alpha1.Node,
+	message string) error {
+	a.Logger.InfoContext(ctx, "BIOS attributes update failed", slog.String("nodename", node.Name), slog.String("reason", message))
+
+	if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+		hwmgmtv1alpha1.Configured,
+		hwmgmtv1alpha1.Failed,
+		metav1.ConditionFalse,
+		fmt.Sprintf("Profile update failed on node %s: %s", node.Name, message)); err != nil {
+		return fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse, string(hwmgmtv1alpha1.Failed),
+		fmt.Sprintf("Profile update to %s failed: %s", node.Spec.HwProfile, message)); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.FinishNodeOperation(ctx, a.Client, node.Name, node.Namespace, utils.NodeOperationFailed, message, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to record operation outcome", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+
+	return fmt.Errorf("BIOS attributes update failed for node %s: %s", node.Name, message)
+}
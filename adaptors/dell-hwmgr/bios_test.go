@@ -0,0 +1,88 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// profileClient serves HardwareProfiles from a map, keyed by name
+type profileClient struct {
+	client.Client
+	profiles map[string]pluginv1alpha1.HardwareProfileSpec
+}
+
+func (c *profileClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	spec, exists := c.profiles[key.Name]
+	if !exists {
+		return errors.NewNotFound(schema.GroupResource{Resource: "hardwareprofiles"}, key.Name)
+	}
+	profile := obj.(*pluginv1alpha1.HardwareProfile)
+	profile.Name = key.Name
+	profile.Namespace = key.Namespace
+	profile.Spec = *spec.DeepCopy()
+	return nil
+}
+
+func TestGetProfileBiosAttributes(t *testing.T) {
+	bios := pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{"ProcCStates": intstr.FromString("Disabled")}}
+	c := &profileClient{profiles: map[string]pluginv1alpha1.HardwareProfileSpec{
+		"bios": {Bios: bios},
+		"boot-order": {Bios: bios, BootOrder: &pluginv1alpha1.BootOrder{
+			Devices:   []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceDisk},
+			DellFqdds: map[pluginv1alpha1.BootDevice]string{pluginv1alpha1.BootDeviceDisk: "NonRAID.Slot.3-1"},
+		}},
+		"conflict": {
+			Bios: pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{
+				"SetBootOrderFqdd1": intstr.FromString("NIC.PxeDevice.1-1")}},
+			BootOrder: &pluginv1alpha1.BootOrder{Devices: []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceDisk}},
+		},
+	}}
+	a := NewAdaptor(c, c, nil, slog.Default(), "hwmgr")
+	ctx := context.Background()
+
+	attributes, err := a.getProfileBiosAttributes(ctx, "missing")
+	if err != nil || attributes != nil {
+		t.Errorf("expected no attributes for a resource profile without HardwareProfile, got %v, %v", attributes, err)
+	}
+
+	attributes, err = a.getProfileBiosAttributes(ctx, "bios")
+	if err != nil || !maps.Equal(attributes, bios.Attributes) {
+		t.Errorf("expected the BIOS attributes of the profile, got %v, %v", attributes, err)
+	}
+
+	// The boot order is applied through the Dell attributes, along with the BIOS attributes of the profile
+	attributes, err = a.getProfileBiosAttributes(ctx, "boot-order")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]intstr.IntOrString{
+		"ProcCStates":       intstr.FromString("Disabled"),
+		"SetBootOrderFqdd1": intstr.FromString("NonRAID.Slot.3-1"),
+		"SetBootOrderFqdd2": intstr.FromString("NIC.PxeDevice.1-1"),
+		"SetBootOrderFqdd3": intstr.FromString("Optical.iDRACVirtual.1-1"),
+		"SetBootOrderFqdd4": intstr.FromString(""),
+	}
+	if !maps.Equal(attributes, expected) {
+		t.Errorf("expected %v, got %v", expected, attributes)
+	}
+
+	if _, err := a.getProfileBiosAttributes(ctx, "conflict"); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for a boot order conflicting with the BIOS attributes, got %v", err)
+	}
+}
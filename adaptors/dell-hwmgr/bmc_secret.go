@@ -0,0 +1,128 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+type BMCCredentials struct {
+	Username string `json:"bmc_username"`
+	Password string `json:"bmc_password"`
+}
+
+// decodeBMCCredentials decodes the LOM credentials held in a hardware manager secret. The value is a JSON document,
+// which may be base64-encoded. The credentials themselves are never included in the returned errors.
+func decodeBMCCredentials(secret *hwmgrapi.RhprotoSecret) (BMCCredentials, error) {
+	creds := BMCCredentials{}
+	if secret == nil || secret.Value == nil || strings.TrimSpace(*secret.Value) == "" {
+		return creds, fmt.Errorf("secret has no value")
+	}
+
+	value := []byte(strings.TrimSpace(*secret.Value))
+	if !json.Valid(value) {
+		decoded, err := base64.StdEncoding.DecodeString(string(value))
+		if err != nil || !json.Valid(decoded) {
+			return creds, fmt.Errorf("secret value is neither JSON nor base64-encoded JSON")
+		}
+		value = decoded
+	}
+
+	if err := json.Unmarshal(value, &creds); err != nil {
+		return creds, fmt.Errorf("secret value has an invalid format")
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return creds, fmt.Errorf("secret value is missing the BMC username or password")
+	}
+	return creds, nil
+}
+
+// CreateBMCSecret creates or updates the bmc-secret for a node, with the LOM credentials from the hardware manager
+func (a *Adaptor) CreateBMCSecret(
+	ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	nodename string,
+	key types.NamespacedName,
+	resource hwmgrapi.RhprotoResource) error {
+	a.Logger.InfoContext(ctx, "Creating bmc-secret")
+
+	remoteSecretKey := *resource.ResourceAttribute.Compute.Lom.Password
+	remoteSecret, err := hwmgrClient.GetSecret(ctx, remoteSecretKey)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve BMC credentials (%s): %w", remoteSecretKey, err)
+	}
+	if remoteSecret == nil {
+		return fmt.Errorf("failed to retrieve BMC credentials (%s): empty response", remoteSecretKey)
+	}
+
+	creds, err := decodeBMCCredentials(remoteSecret.Secret)
+	if err != nil {
+		return fmt.Errorf("unable to parse BMC credentials (%s): %w", remoteSecretKey, err)
+	}
+
+	bmcSecret := utils.NewBMCSecret(key, nodepool, nodename, []byte(creds.Username), []byte(creds.Password))
+
+	if err = utils.CreateOrUpdateK8sCR(ctx, a.Client, bmcSecret, nil, utils.UPDATE); err != nil {
+		return fmt.Errorf("failed to create bmc-secret for node %s: %w", nodename, err)
+	}
+
+	return nil
+}
+
+// deleteBMCSecret deletes the bmc-secret of a node that failed allocation or was released by a scale-in. Failures are
+// only logged, as the secret is also removed when the NodePool is released.
+func (a *Adaptor) deleteBMCSecret(ctx context.Context, key types.NamespacedName) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	}
+	if err := a.Client.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+		a.Logger.WarnContext(ctx, "Failed to delete bmc-secret", slog.String("secret", key.String()), slog.String("error", err.Error()))
+	}
+}
+
+// getNodeBMCSecretKey returns the namespace and name of the BMC secret of a node, as reported by the node, so that the
+// secret is found after the BMC secret policy of the hardware manager changes. A node that does not report its secret yet
+// gets the key of the current policy.
+func (a *Adaptor) getNodeBMCSecretKey(hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool,
+	node *hwmgmtv1alpha1.Node) (types.NamespacedName, error) {
+	if key, ok := utils.GetNodeBMCSecretKey(node, a.Namespace); ok {
+		return key, nil
+	}
+	return utils.GetBMCSecretKey(hwmgr, nodepool, node.Name, a.Namespace)
+}
+
+// releaseBMCSecrets deletes the bmc-secrets of the nodes of a released NodePool, returning whether the release is
+// complete. The secrets are owned by the NodePool, but are removed here so that the credentials do not outlive the
+// release of the hardware while the NodePool is held by other finalizers, and secrets created outside the namespace
+// of the NodePool are not owned by it.
+func (a *Adaptor) releaseBMCSecrets(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+	if err := utils.DeleteBMCSecrets(ctx, a.Client, nodepool, a.Namespace); err != nil {
+		return false, err
+	}
+	a.Logger.InfoContext(ctx, "Deleted bmc-secrets of released nodepool")
+	return true, nil
+}
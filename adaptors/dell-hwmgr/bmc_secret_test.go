@@ -0,0 +1,51 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+)
+
+func TestDecodeBMCCredentials(t *testing.T) {
+	secret := func(value string) *hwmgrapi.RhprotoSecret {
+		return &hwmgrapi.RhprotoSecret{Value: &value}
+	}
+	raw := `{"bmc_username": "root", "bmc_password": "s3cret"}`
+
+	for _, value := range []string{raw, base64.StdEncoding.EncodeToString([]byte(raw))} {
+		creds, err := decodeBMCCredentials(secret(value))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.Username != "root" || creds.Password != "s3cret" {
+			t.Errorf("unexpected credentials for %s", value)
+		}
+	}
+
+	tests := []*hwmgrapi.RhprotoSecret{
+		nil,
+		{},
+		secret(""),
+		secret("not-json"),
+		secret(`{"bmc_username": "root"}`),
+		secret(`["root", "s3cret"]`),
+	}
+	for _, test := range tests {
+		if _, err := decodeBMCCredentials(test); err == nil {
+			t.Errorf("expected error for %+v", test)
+		}
+	}
+
+	// The credentials must not be leaked through errors
+	if _, err := decodeBMCCredentials(secret(`{"bmc_password": "s3cret"}`)); err == nil || strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// JobCancellationAnnotation records the cancellation of the jobs in flight when a NodePool is deleted, as JSON
+const JobCancellationAnnotation = "hwmgr-plugin.oran.openshift.io/job-cancellation"
+
+// cancellationState is the progress of the cancellation of the jobs in flight, as recorded on the NodePool
+type cancellationState struct {
+	StartTime time.Time `json:"startTime"`
+	// Jobs are the jobs being aborted, by the kind and name of the CR that started them
+	Jobs map[string]string `json:"jobs,omitempty"`
+	// Done is set once the jobs have stopped, or the wait for them has timed out
+	Done bool `json:"done,omitempty"`
+}
+
+// getInFlightJobs returns the jobs recorded on a NodePool and its nodes, by the kind and name of the CR that started
+// them: the creation of the resource group, the creation of the resource groups of a scale-out, and the updates of the
+// resources
+func getInFlightJobs(nodepool *hwmgmtv1alpha1.NodePool, nodes []hwmgmtv1alpha1.Node) map[string]string {
+	jobs := make(map[string]string)
+	if jobId := utils.GetJobId(nodepool); jobId != "" {
+		jobs["nodepool/"+nodepool.Name] = jobId
+	}
+	if state, err := getScaleOutState(nodepool); err == nil && state != nil {
+		for _, group := range state.Groups {
+			if group.JobId != "" {
+				jobs["resourcegroup/"+group.Id] = group.JobId
+			}
+		}
+	}
+	for i := range nodes {
+		if jobId := utils.GetJobId(&nodes[i]); jobId != "" {
+			jobs["node/"+nodes[i].Name] = jobId
+		}
+	}
+	return jobs
+}
+
+// getCancellationState returns the progress of the cancellation recorded on the NodePool, or nil if not started
+func getCancellationState(nodepool *hwmgmtv1alpha1.NodePool) (*cancellationState, error) {
+	value, exists := nodepool.GetAnnotations()[JobCancellationAnnotation]
+	if !exists {
+		return nil, nil
+	}
+	state := &cancellationState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", JobCancellationAnnotation, err)
+	}
+	return state, nil
+}
+
+// setCancellationState records the progress of the cancellation on the NodePool
+func setCancellationState(nodepool *hwmgmtv1alpha1.NodePool, state *cancellationState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cancellation state: %w", err)
+	}
+	annotations := nodepool.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[JobCancellationAnnotation] = string(value)
+	nodepool.SetAnnotations(annotations)
+	return nil
+}
+
+// cancelInFlightJobs aborts the jobs still running on the hardware manager for a deleted NodePool, such as the creation
+// of its resource group, returning true once they have stopped. The abort is best-effort: a failed abort request, or a
+// failure to query a job, is logged and the job is waited for like any other. The wait is bounded by the
+// jobCancellation timeout, after which the NodePool is released regardless, so that a job that cannot be aborted does
+// not block the deletion.
+func (a *Adaptor) cancelInFlightJobs(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+
+	state, err := getCancellationState(nodepool)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "Restarting job cancellation", slog.String("error", err.Error()))
+		state = nil
+	}
+	if state != nil && state.Done {
+		return true, nil
+	}
+
+	if state == nil {
+		nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+		if err != nil {
+			return false, fmt.Errorf("failed to get child nodes for NodePool %s: %w", nodepool.Name, err)
+		}
+		state = &cancellationState{StartTime: a.clock().Now().UTC(), Jobs: getInFlightJobs(nodepool, nodelist.Items)}
+		if len(state.Jobs) == 0 {
+			return true, nil
+		}
+
+		for _, owner := range sortedJobOwners(state.Jobs) {
+			if err := hwmgrClient.AbortJob(ctx, state.Jobs[owner]); err != nil {
+				a.Logger.WarnContext(ctx, "Failed to request job cancellation, waiting for the job to finish",
+					slog.String("owner", owner), slog.String("jobId", state.Jobs[owner]), slog.String("error", err.Error()))
+				continue
+			}
+			a.Logger.InfoContext(ctx, "Requested job cancellation", slog.String("owner", owner),
+				slog.String("jobId", state.Jobs[owner]))
+		}
+		if err := a.updateCancellationState(ctx, nodepool, state); err != nil {
+			return false, err
+		}
+		return false, a.updateDecommissionCondition(ctx, nodepool, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse,
+			fmt.Sprintf("Cancelling %d in-flight jobs", len(state.Jobs)))
+	}
+
+	tracker := newJobTracker(hwmgr, utils.OperationJobCancellation, a.clock())
+	var pending []string
+	for _, owner := range sortedJobOwners(state.Jobs) {
+		progress, err := tracker.check(ctx, hwmgrClient, state.Jobs[owner], state.StartTime, true)
+		if err != nil {
+			a.Logger.WarnContext(ctx, "Failed to check cancelled job", slog.String("owner", owner),
+				slog.String("jobId", state.Jobs[owner]), slog.String("error", err.Error()))
+			pending = append(pending, owner)
+			continue
+		}
+		if progress.Status == hwmgrclient.JobStatusInProgress {
+			pending = append(pending, owner)
+		}
+	}
+
+	if len(pending) > 0 {
+		if !tracker.timedOut(state.StartTime, true) {
+			return false, nil
+		}
+		message := fmt.Sprintf("Cancellation of jobs of %s timed out after %s, releasing regardless",
+			strings.Join(pending, ","), tracker.timeout)
+		a.Logger.WarnContext(ctx, message)
+		if err := a.updateDecommissionCondition(ctx, nodepool, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse,
+			message); err != nil {
+			return false, err
+		}
+	} else {
+		a.Logger.InfoContext(ctx, "Cancelled in-flight jobs", slog.Int("jobs", len(state.Jobs)))
+	}
+
+	state.Done = true
+	return true, a.updateCancellationState(ctx, nodepool, state)
+}
+
+// sortedJobOwners returns the owners of the jobs, sorted
+func sortedJobOwners(jobs map[string]string) []string {
+	owners := make([]string, 0, len(jobs))
+	for owner := range jobs {
+		owners = append(owners, owner)
+	}
+	slices.Sort(owners)
+	return owners
+}
+
+// updateCancellationState records the progress of the cancellation on the NodePool
+func (a *Adaptor) updateCancellationState(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	state *cancellationState) error {
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	if err := setCancellationState(nodepool, state); err != nil {
+		return err
+	}
+	if err := a.Client.Patch(ctx, nodepool, patch); err != nil {
+		return fmt.Errorf("failed to patch cancellation state of nodepool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
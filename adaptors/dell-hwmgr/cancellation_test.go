@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+func TestGetInFlightJobs(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Name = "np1"
+	nodes := make([]hwmgmtv1alpha1.Node, 3)
+	for i, name := range []string{"node-1", "node-2", "node-3"} {
+		nodes[i].Name = name
+	}
+
+	if jobs := getInFlightJobs(nodepool, nodes); len(jobs) != 0 {
+		t.Errorf("expected no jobs in flight, got %v", jobs)
+	}
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	utils.SetJobId(nodepool, "job-1", clock)
+	utils.SetJobId(&nodes[1], "job-2", clock)
+	expected := map[string]string{"nodepool/np1": "job-1", "node/node-2": "job-2"}
+	if jobs := getInFlightJobs(nodepool, nodes); !maps.Equal(jobs, expected) {
+		t.Errorf("expected %v, got %v", expected, jobs)
+	}
+
+	if err := setScaleOutState(nodepool, &scaleOutState{Groups: []scaleOutGroup{
+		{Id: "rg-1", JobId: "job-3", Created: true}, {Id: "rg-2", Created: true}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected["resourcegroup/rg-1"] = "job-3"
+	if jobs := getInFlightJobs(nodepool, nodes); !maps.Equal(jobs, expected) {
+		t.Errorf("expected %v, got %v", expected, jobs)
+	}
+}
+
+func TestCancellationState(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	if state, err := getCancellationState(nodepool); err != nil || state != nil {
+		t.Fatalf("expected no cancellation state, got %v, %v", state, err)
+	}
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	expected := &cancellationState{StartTime: start, Jobs: map[string]string{"nodepool/np1": "job-1"}, Done: true}
+	if err := setCancellationState(nodepool, expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state, err := getCancellationState(nodepool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.StartTime.Equal(start) || !state.Done || !maps.Equal(state.Jobs, expected.Jobs) {
+		t.Errorf("expected %+v, got %+v", expected, state)
+	}
+
+	nodepool.SetAnnotations(map[string]string{JobCancellationAnnotation: "{"})
+	if _, err := getCancellationState(nodepool); err == nil {
+		t.Errorf("expected an error for an invalid cancellation state")
+	}
+}
+
+func TestSortedJobOwners(t *testing.T) {
+	owners := sortedJobOwners(map[string]string{"node/node-2": "job-2", "nodepool/np1": "job-1", "node/node-1": "job-3"})
+	expected := []string{"node/node-1", "node/node-2", "nodepool/np1"}
+	for i := range expected {
+		if owners[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, owners)
+		}
+	}
+}
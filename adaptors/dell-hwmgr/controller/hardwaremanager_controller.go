@@ -15,6 +15,7 @@ import (
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,11 +34,18 @@ type HardwareManagerReconciler struct {
 	Logger    *slog.Logger
 	Namespace string
 	AdaptorID pluginv1alpha1.HardwareManagerAdaptorID
+	Clock     utils.Clock
+}
+
+// clock returns the clock of the reconciler
+func (r *HardwareManagerReconciler) clock() utils.Clock {
+	return utils.ClockOrReal(r.Clock)
 }
 
 //+kubebuilder:rbac:groups=hwmgr-plugin.oran.openshift.io,resources=hardwaremanagers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=hwmgr-plugin.oran.openshift.io,resources=hardwaremanagers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=hwmgr-plugin.oran.openshift.io,resources=hardwaremanagers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -88,6 +96,22 @@ func (r *HardwareManagerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return
 	}
 
+	if template := hwmgr.Spec.DellData.HostnameTemplate; template != nil && *template != "" {
+		if templateErr := utils.ValidateHostnameTemplate(*template); templateErr != nil {
+			if updateErr := utils.UpdateHardwareManagerStatusCondition(ctx, r.Client, hwmgr,
+				pluginv1alpha1.ConditionTypes.Validation,
+				pluginv1alpha1.ConditionReasons.Failed,
+				metav1.ConditionFalse,
+				templateErr.Error()); updateErr != nil {
+				err = fmt.Errorf("failed to update status for hardware manager (%s) with validation failure: %w", hwmgr.Name, updateErr)
+				return
+			}
+			r.Logger.ErrorContext(ctx, "HardwareManager CR has an invalid hostname template", slog.String("name", hwmgr.Name),
+				slog.String("error", templateErr.Error()))
+			return
+		}
+	}
+
 	result = utils.RequeueWithLongInterval()
 
 	r.Logger.InfoContext(ctx, "Validating client connection", slog.String("apiUrl", hwmgr.Spec.DellData.ApiUrl))
@@ -95,6 +119,14 @@ func (r *HardwareManagerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	client, clientErr := hwmgrclient.NewClientWithResponses(ctx, r.Logger, r.Client, hwmgr)
 	if clientErr != nil {
 		r.Logger.InfoContext(ctx, "NewClientWithResponses error", slog.String("error", clientErr.Error()))
+		r.setBackendStatus(hwmgr, &result)
+		if typederrors.IsUnavailableError(clientErr) {
+			// The hardware manager was not queried, so the Validation condition is left as is
+			if updateErr := utils.UpdateK8sCRStatus(ctx, r.Client, hwmgr); updateErr != nil {
+				err = fmt.Errorf("failed to update status for hardware manager (%s) with degraded condition: %w", hwmgr.Name, updateErr)
+			}
+			return
+		}
 		if updateErr := utils.UpdateHardwareManagerStatusCondition(ctx, r.Client, hwmgr,
 			pluginv1alpha1.ConditionTypes.Validation,
 			pluginv1alpha1.ConditionReasons.Failed,
@@ -110,6 +142,7 @@ func (r *HardwareManagerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	pools, clientErr := client.GetResourcePools(ctx)
 	if clientErr != nil {
 		r.Logger.InfoContext(ctx, "GetResourcePools error", slog.String("error", clientErr.Error()))
+		r.setBackendStatus(hwmgr, &result)
 		if updateErr := utils.UpdateHardwareManagerStatusCondition(ctx, r.Client, hwmgr,
 			pluginv1alpha1.ConditionTypes.Validation,
 			pluginv1alpha1.ConditionReasons.Failed,
@@ -144,6 +177,7 @@ func (r *HardwareManagerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	r.setBackendStatus(hwmgr, &result)
 	if updateErr := utils.UpdateHardwareManagerStatusCondition(ctx, r.Client, hwmgr,
 		pluginv1alpha1.ConditionTypes.Validation,
 		pluginv1alpha1.ConditionReasons.Completed,
@@ -156,6 +190,31 @@ func (r *HardwareManagerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	return
 }
 
+// setBackendStatus reflects the circuit breaker of the hardware manager in the Degraded condition, along with the last
+// failed requests to the hardware manager, to be written with the next status update. While the breaker is open, the
+// reconcile is requeued for when the next request to the hardware manager is allowed, so that the condition is cleared
+// once it recovers.
+func (r *HardwareManagerReconciler) setBackendStatus(hwmgr *pluginv1alpha1.HardwareManager, result *ctrl.Result) {
+	hwmgr.Status.LastErrors = utils.GetSouthboundErrors(hwmgr, r.clock())
+
+	circuit := hwmgrclient.GetCircuitStatus(hwmgr)
+	if !circuit.Open {
+		utils.SetStatusCondition(&hwmgr.Status.Conditions,
+			string(pluginv1alpha1.ConditionTypes.Degraded),
+			string(pluginv1alpha1.ConditionReasons.Completed),
+			metav1.ConditionFalse,
+			"Hardware manager available")
+		return
+	}
+
+	utils.SetStatusCondition(&hwmgr.Status.Conditions,
+		string(pluginv1alpha1.ConditionTypes.Degraded),
+		string(pluginv1alpha1.ConditionReasons.Failed),
+		metav1.ConditionTrue,
+		fmt.Sprintf("Hardware manager unavailable after %d consecutive failures: %s", circuit.Failures, circuit.LastError))
+	*result = utils.RequeueWithCustomInterval(circuit.RetryAfter)
+}
+
 func filterEvents(adaptorID pluginv1alpha1.HardwareManagerAdaptorID) predicate.Predicate {
 	return predicate.NewPredicateFuncs(func(object client.Object) bool {
 		hwmgr := object.(*pluginv1alpha1.HardwareManager)
@@ -0,0 +1,380 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/redfish"
+)
+
+// DecommissionAnnotation records the progress of the decommissioning of a deleted NodePool, as JSON
+const DecommissionAnnotation = "hwmgr-plugin.oran.openshift.io/decommission"
+
+// NodePoolConditionDecommissioned is the NodePool condition reporting the progress of the decommissioning of a deleted
+// NodePool, with the step in progress and the step that failed or timed out
+const NodePoolConditionDecommissioned hwmgmtv1alpha1.ConditionType = "Decommissioned"
+
+// defaultPowerOffTimeout is the default timeout of the power off of the resources of a NodePool
+const defaultPowerOffTimeout = 10 * time.Minute
+
+// decommissionStep is a step of the decommissioning of a deleted NodePool
+type decommissionStep string
+
+const (
+	decommissionPowerOff decommissionStep = "PowerOff"
+	// decommissionRelease deletes the resource group, with the deletion job recorded in the deletion job annotations
+	decommissionRelease decommissionStep = "ResourceGroupDeletion"
+)
+
+// decommissionStepDescriptions describe the steps in condition messages
+var decommissionStepDescriptions = map[decommissionStep]string{
+	decommissionPowerOff: "Power off",
+	decommissionRelease:  "Resource group deletion",
+}
+
+// decommissionState is the progress of the decommissioning, as recorded on the NodePool
+type decommissionState struct {
+	Step      decommissionStep `json:"step"`
+	StartTime time.Time        `json:"startTime"`
+	// Started is set once the step has been requested on the nodes
+	Started bool `json:"started,omitempty"`
+	// Nodes are the nodes the step is in progress on
+	Nodes []string `json:"nodes,omitempty"`
+	// Failures are the failures of the previous steps, which are reported without blocking the deletion
+	Failures []string `json:"failures,omitempty"`
+}
+
+// getDecommissionSteps returns the steps of the decommissioning for the HardwareManager, in order
+func getDecommissionSteps(hwmgr *pluginv1alpha1.HardwareManager) []decommissionStep {
+	var steps []decommissionStep
+	if hwmgr.Spec.DellData != nil && hwmgr.Spec.DellData.Decommission != nil && hwmgr.Spec.DellData.Decommission.PowerOff {
+		steps = append(steps, decommissionPowerOff)
+	}
+	return append(steps, decommissionRelease)
+}
+
+// nextDecommissionStep returns the step following the given one, or the first step if none
+func nextDecommissionStep(steps []decommissionStep, step decommissionStep) decommissionStep {
+	index := slices.Index(steps, step)
+	if index < 0 || index+1 >= len(steps) {
+		return steps[0]
+	}
+	return steps[index+1]
+}
+
+// getDecommissionStepTimeout returns the timeout of a decommissioning step. A zero value means no limit.
+func getDecommissionStepTimeout(hwmgr *pluginv1alpha1.HardwareManager, step decommissionStep) time.Duration {
+	var config *pluginv1alpha1.DecommissionConfig
+	if hwmgr.Spec.DellData != nil {
+		config = hwmgr.Spec.DellData.Decommission
+	}
+
+	switch step {
+	case decommissionPowerOff:
+		if config != nil && config.PowerOffTimeout != nil {
+			return config.PowerOffTimeout.Duration
+		}
+		return defaultPowerOffTimeout
+	case decommissionRelease:
+		return utils.GetOperationTimeout(hwmgr, utils.OperationResourceGroupJob)
+	}
+	return 0
+}
+
+// getDecommissionState returns the progress of the decommissioning recorded on the NodePool, or nil if not started
+func getDecommissionState(nodepool *hwmgmtv1alpha1.NodePool) (*decommissionState, error) {
+	value, exists := nodepool.GetAnnotations()[DecommissionAnnotation]
+	if !exists {
+		return nil, nil
+	}
+	state := &decommissionState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", DecommissionAnnotation, err)
+	}
+	return state, nil
+}
+
+// setDecommissionState records the progress of the decommissioning on the NodePool, or clears it if nil
+func setDecommissionState(nodepool *hwmgmtv1alpha1.NodePool, state *decommissionState) error {
+	annotations := nodepool.GetAnnotations()
+	if state == nil {
+		delete(annotations, DecommissionAnnotation)
+		return nil
+	}
+
+	value, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal decommission state: %w", err)
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[DecommissionAnnotation] = string(value)
+	nodepool.SetAnnotations(annotations)
+	return nil
+}
+
+// decommissionMessage returns a condition message, followed by the failures of the decommissioning steps
+func decommissionMessage(state *decommissionState, message string) string {
+	if state == nil || len(state.Failures) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s; %s", message, strings.Join(state.Failures, "; "))
+}
+
+// decommissionNodePool runs the decommissioning steps that precede the deletion of the resource group of a NodePool,
+// returning true once the resource group can be deleted. A step that fails or times out is logged and reported in the
+// Decommissioned condition, and the decommissioning continues with the next step, so that the finalizer of the
+// NodePool is never held by a resource that cannot be decommissioned.
+func (a *Adaptor) decommissionNodePool(ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+
+	state, err := getDecommissionState(nodepool)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "Restarting decommission", slog.String("error", err.Error()))
+		state = nil
+	}
+
+	steps := getDecommissionSteps(hwmgr)
+	if state != nil && state.Step == decommissionRelease {
+		return true, nil
+	}
+	if state == nil || !slices.Contains(steps, state.Step) {
+		state = &decommissionState{Step: steps[0], StartTime: a.clock().Now().UTC()}
+	}
+
+	for state.Step != decommissionRelease {
+		var done bool
+		if !state.Started {
+			done, err = a.startPowerOff(ctx, hwmgr, nodepool, state)
+		} else {
+			done, err = a.checkPowerOff(ctx, hwmgr, nodepool, state)
+		}
+		if err != nil || !done {
+			return false, err
+		}
+		state = &decommissionState{
+			Step:      nextDecommissionStep(steps, state.Step),
+			StartTime: a.clock().Now().UTC(),
+			Failures:  state.Failures,
+		}
+	}
+
+	if err := a.updateDecommissionState(ctx, nodepool, state); err != nil {
+		return false, err
+	}
+	if err := a.updateDecommissionCondition(ctx, nodepool, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse,
+		decommissionMessage(state, "Deleting resource group")); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// recordDecommissionFailure logs the failure of a step, and records it in the state to be reported in the
+// Decommissioned condition
+func (a *Adaptor) recordDecommissionFailure(ctx context.Context, state *decommissionState, message string) {
+	a.Logger.WarnContext(ctx, "Decommission step failed, continuing", slog.String("step", string(state.Step)),
+		slog.String("reason", message))
+	state.Failures = append(state.Failures, message)
+}
+
+// bmcRedfishSystem returns a client of the BMC of a node and the path of its Redfish system
+func (a *Adaptor) bmcRedfishSystem(ctx context.Context, httpClient *http.Client, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool, node *hwmgmtv1alpha1.Node) (*redfish.Client, string, error) {
+	if node.Status.BMC == nil || node.Status.BMC.Address == "" {
+		return nil, "", fmt.Errorf("node has no BMC address")
+	}
+
+	bmcSecret, err := a.getNodeBMCSecretKey(hwmgr, nodepool, node)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get bmc-secret name: %w", err)
+	}
+	creds, err := a.getBMCCredentials(ctx, bmcSecret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rf, err := redfish.NewClient(httpClient, node.Status.BMC.Address, creds)
+	if err != nil {
+		return nil, "", err // nolint: wrapcheck
+	}
+	systemPath, err := rf.SystemPath(ctx, node.Status.BMC.Address)
+	if err != nil {
+		return nil, "", err // nolint: wrapcheck
+	}
+	return rf, systemPath, nil
+}
+
+// newBMCHTTPClient returns the client for the Redfish requests sent to the BMCs of the HardwareManager
+func (a *Adaptor) newBMCHTTPClient(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) (*http.Client, error) {
+	tr, err := hwmgrclient.NewTransport(ctx, a.Client, hwmgr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transport for BMC requests: %w", err)
+	}
+	return &http.Client{Transport: redfish.NewTransport(tr, hwmgr, a.clock())}, nil
+}
+
+// startPowerOff requests the power off of each node of the NodePool with a BMC, recording the nodes in the state. A
+// node whose power off cannot be requested is reported as a failure. It returns true if no node is powering off.
+func (a *Adaptor) startPowerOff(ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	state *decommissionState) (bool, error) {
+
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return false, fmt.Errorf("failed to get child nodes for NodePool %s: %w", nodepool.Name, err)
+	}
+	httpClient, err := a.newBMCHTTPClient(ctx, hwmgr)
+	if err != nil {
+		return false, err
+	}
+
+	var nodes []string
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		if node.Spec.HwMgrNodeId == "" {
+			continue
+		}
+
+		rf, systemPath, err := a.bmcRedfishSystem(ctx, httpClient, hwmgr, nodepool, node)
+		if err == nil {
+			err = rf.Reset(ctx, systemPath, "ForceOff")
+		}
+		if err != nil {
+			a.recordDecommissionFailure(ctx, state, fmt.Sprintf("power off of node %s failed: %s", node.Name, err.Error()))
+			continue
+		}
+		nodes = append(nodes, node.Name)
+	}
+
+	a.Logger.InfoContext(ctx, "Started decommission step", slog.String("step", string(state.Step)), slog.Int("nodes", len(nodes)))
+	if len(nodes) == 0 {
+		return true, nil
+	}
+
+	state.Started = true
+	state.Nodes = nodes
+	state.StartTime = a.clock().Now().UTC()
+	if err := a.updateDecommissionState(ctx, nodepool, state); err != nil {
+		return false, err
+	}
+	return false, a.updateDecommissionCondition(ctx, nodepool, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse,
+		decommissionMessage(state, fmt.Sprintf("%s of %d nodes in progress", decommissionStepDescriptions[state.Step], len(nodes))))
+}
+
+// checkPowerOff checks the power state of the nodes being powered off, returning true once all are off or the step has
+// timed out. Nodes that are not off by the timeout are reported as a failure.
+func (a *Adaptor) checkPowerOff(ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	state *decommissionState) (bool, error) {
+
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return false, fmt.Errorf("failed to get child nodes for NodePool %s: %w", nodepool.Name, err)
+	}
+	httpClient, err := a.newBMCHTTPClient(ctx, hwmgr)
+	if err != nil {
+		return false, err
+	}
+
+	var pending []string
+	for _, nodename := range state.Nodes {
+		index := slices.IndexFunc(nodelist.Items, func(node hwmgmtv1alpha1.Node) bool { return node.Name == nodename })
+		if index < 0 {
+			continue
+		}
+
+		rf, systemPath, err := a.bmcRedfishSystem(ctx, httpClient, hwmgr, nodepool, &nodelist.Items[index])
+		var powerState string
+		if err == nil {
+			powerState, err = rf.PowerState(ctx, systemPath)
+		}
+		if err != nil {
+			a.Logger.InfoContext(ctx, "Unable to get power state", slog.String("node", nodename), slog.String("error", err.Error()))
+		}
+		if !strings.EqualFold(powerState, redfish.PowerStateOff) {
+			pending = append(pending, nodename)
+		}
+	}
+
+	if len(pending) == 0 {
+		a.Logger.InfoContext(ctx, "Completed decommission step", slog.String("step", string(state.Step)))
+		return true, nil
+	}
+
+	timeout := getDecommissionStepTimeout(hwmgr, state.Step)
+	if timeout > 0 && a.clock().Since(state.StartTime) > timeout {
+		a.recordDecommissionFailure(ctx, state, fmt.Sprintf("%s timed out after %s on nodes %s",
+			strings.ToLower(decommissionStepDescriptions[state.Step]), timeout, strings.Join(pending, ",")))
+		return true, nil
+	}
+	return false, nil
+}
+
+// completeDecommission reports the completion of the decommissioning, with the failures of its steps
+func (a *Adaptor) completeDecommission(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) error {
+	state, err := getDecommissionState(nodepool)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "Unable to get decommission state", slog.String("error", err.Error()))
+	}
+	return a.updateDecommissionCondition(ctx, nodepool, hwmgmtv1alpha1.Completed, metav1.ConditionTrue,
+		decommissionMessage(state, "Decommissioned"))
+}
+
+// failDecommissionRelease reports the failure of the resource group deletion
+func (a *Adaptor) failDecommissionRelease(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, releaseErr error) {
+	reason := hwmgmtv1alpha1.Failed
+	if errors.Is(releaseErr, errJobTimedOut) {
+		reason = hwmgmtv1alpha1.TimedOut
+	}
+	if err := a.updateDecommissionCondition(ctx, nodepool, reason, metav1.ConditionFalse,
+		fmt.Sprintf("%s failed: %s", decommissionStepDescriptions[decommissionRelease], releaseErr.Error())); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to update decommission condition", slog.String("error", err.Error()))
+	}
+}
+
+// updateDecommissionState records the progress of the decommissioning on the NodePool
+func (a *Adaptor) updateDecommissionState(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	state *decommissionState) error {
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	if err := setDecommissionState(nodepool, state); err != nil {
+		return err
+	}
+	if err := a.Client.Patch(ctx, nodepool, patch); err != nil {
+		return fmt.Errorf("failed to patch decommission state of nodepool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
+
+// updateDecommissionCondition updates the Decommissioned condition of the NodePool
+func (a *Adaptor) updateDecommissionCondition(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	reason hwmgmtv1alpha1.ConditionReason, status metav1.ConditionStatus, message string) error {
+	if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+		NodePoolConditionDecommissioned, reason, status, message); err != nil {
+		return fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
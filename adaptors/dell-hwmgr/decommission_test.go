@@ -0,0 +1,126 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func TestGetDecommissionSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *pluginv1alpha1.DecommissionConfig
+		expected []decommissionStep
+	}{
+		{
+			name:     "default",
+			expected: []decommissionStep{decommissionRelease},
+		},
+		{
+			name:     "power off",
+			config:   &pluginv1alpha1.DecommissionConfig{PowerOff: true},
+			expected: []decommissionStep{decommissionPowerOff, decommissionRelease},
+		},
+	}
+	for _, tt := range tests {
+		hwmgr := &pluginv1alpha1.HardwareManager{
+			Spec: pluginv1alpha1.HardwareManagerSpec{
+				AdaptorID: pluginv1alpha1.SupportedAdaptors.Dell,
+				DellData:  &pluginv1alpha1.DellData{Decommission: tt.config},
+			},
+		}
+		if steps := getDecommissionSteps(hwmgr); !slices.Equal(steps, tt.expected) {
+			t.Errorf("%s: expected steps %v, got %v", tt.name, tt.expected, steps)
+		}
+	}
+}
+
+func TestNextDecommissionStep(t *testing.T) {
+	steps := []decommissionStep{decommissionPowerOff, decommissionRelease}
+	if step := nextDecommissionStep(steps, decommissionPowerOff); step != decommissionRelease {
+		t.Errorf("expected the resource group deletion to follow the power off, got %s", step)
+	}
+	if step := nextDecommissionStep(steps, ""); step != decommissionPowerOff {
+		t.Errorf("expected the first step, got %s", step)
+	}
+}
+
+func TestGetDecommissionStepTimeout(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{
+		Spec: pluginv1alpha1.HardwareManagerSpec{
+			AdaptorID: pluginv1alpha1.SupportedAdaptors.Dell,
+			DellData: &pluginv1alpha1.DellData{
+				Timeouts: &pluginv1alpha1.OperationTimeouts{ResourceGroupJob: &metav1.Duration{Duration: time.Hour}},
+			},
+		},
+	}
+	if timeout := getDecommissionStepTimeout(hwmgr, decommissionPowerOff); timeout != defaultPowerOffTimeout {
+		t.Errorf("expected the default power off timeout, got %s", timeout)
+	}
+	if timeout := getDecommissionStepTimeout(hwmgr, decommissionRelease); timeout != time.Hour {
+		t.Errorf("expected the resource group job timeout, got %s", timeout)
+	}
+
+	hwmgr.Spec.DellData.Decommission = &pluginv1alpha1.DecommissionConfig{
+		PowerOffTimeout: &metav1.Duration{Duration: time.Minute},
+	}
+	if timeout := getDecommissionStepTimeout(hwmgr, decommissionPowerOff); timeout != time.Minute {
+		t.Errorf("expected the configured power off timeout, got %s", timeout)
+	}
+}
+
+func TestDecommissionState(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	if state, err := getDecommissionState(nodepool); err != nil || state != nil {
+		t.Fatalf("expected no decommission state, got %v, %v", state, err)
+	}
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	expected := &decommissionState{Step: decommissionPowerOff, StartTime: start, Started: true, Nodes: []string{"node-1"}}
+	if err := setDecommissionState(nodepool, expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state, err := getDecommissionState(nodepool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Step != expected.Step || !state.StartTime.Equal(start) || !state.Started || !slices.Equal(state.Nodes, expected.Nodes) {
+		t.Errorf("expected %+v, got %+v", expected, state)
+	}
+
+	if err := setDecommissionState(nodepool, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, exists := nodepool.GetAnnotations()[DecommissionAnnotation]; exists {
+		t.Errorf("expected the decommission state to be cleared")
+	}
+
+	nodepool.SetAnnotations(map[string]string{DecommissionAnnotation: "{"})
+	if _, err := getDecommissionState(nodepool); err == nil {
+		t.Errorf("expected an error for an invalid decommission state")
+	}
+}
+
+func TestDecommissionMessage(t *testing.T) {
+	if message := decommissionMessage(nil, "Decommissioned"); message != "Decommissioned" {
+		t.Errorf("unexpected message without state: %s", message)
+	}
+
+	state := &decommissionState{Failures: []string{"power off of node node-1 failed: no BMC address",
+		"power off timed out after 10m0s on nodes node-2"}}
+	expected := "Decommissioned; power off of node node-1 failed: no BMC address; power off timed out after 10m0s on nodes node-2"
+	if message := decommissionMessage(state, "Decommissioned"); message != expected {
+		t.Errorf("expected %q, got %q", expected, message)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// Repeated failures to reach a hardware manager, such as connection errors or 5xx responses, open a circuit breaker
+// shared by all clients of the HardwareManager. While open, requests fail immediately with an UnavailableError rather
+// than each reconcile retrying against the hardware manager. Once the backoff has passed, a single trial request is let
+// through, closing the breaker if it succeeds, or doubling the backoff if it fails.
+const (
+	circuitFailureThreshold = 3
+	circuitInitialBackoff   = 10 * time.Second
+	circuitMaxBackoff       = 5 * time.Minute
+)
+
+// CircuitStatus is the state of the circuit breaker of a HardwareManager
+type CircuitStatus struct {
+	Open       bool
+	Failures   int
+	RetryAfter time.Duration
+	LastError  string
+}
+
+// circuitBreaker tracks the consecutive failures of requests to a hardware manager
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	backoff   time.Duration
+	openUntil time.Time
+	trial     bool
+	lastError string
+	now       func() time.Time
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{now: time.Now}
+}
+
+// allow checks whether a request may be sent, returning the time until the next trial request otherwise
+func (b *circuitBreaker) allow() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < circuitFailureThreshold {
+		return true, 0
+	}
+	if remaining := b.openUntil.Sub(b.now()); remaining > 0 {
+		return false, remaining
+	}
+	if b.trial {
+		// Another request is already checking whether the hardware manager has recovered
+		return false, circuitInitialBackoff
+	}
+	b.trial = true
+	return true, 0
+}
+
+// record updates the breaker with the outcome of a request
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if err == nil {
+		b.failures = 0
+		b.backoff = 0
+		b.lastError = ""
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	if b.failures < circuitFailureThreshold {
+		return
+	}
+	b.backoff = min(max(2*b.backoff, circuitInitialBackoff), circuitMaxBackoff)
+	b.openUntil = b.now().Add(b.backoff)
+}
+
+// abandon ends a trial request without an outcome, such as when the request is cancelled
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+func (b *circuitBreaker) status() CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := CircuitStatus{
+		Open:      b.failures >= circuitFailureThreshold,
+		Failures:  b.failures,
+		LastError: b.lastError,
+	}
+	if status.Open {
+		status.RetryAfter = max(b.openUntil.Sub(b.now()), time.Second)
+	}
+	return status
+}
+
+var (
+	breakersMu sync.Mutex
+	breakers   = make(map[types.UID]*circuitBreaker)
+)
+
+// breakerFor returns the circuit breaker of a HardwareManager
+func breakerFor(uid types.UID) *circuitBreaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	breaker, exists := breakers[uid]
+	if !exists {
+		breaker = newCircuitBreaker()
+		breakers[uid] = breaker
+	}
+	return breaker
+}
+
+// GetCircuitStatus returns the state of the circuit breaker of a HardwareManager
+func GetCircuitStatus(hwmgr *pluginv1alpha1.HardwareManager) CircuitStatus {
+	return breakerFor(hwmgr.UID).status()
+}
+
+// circuitBreakerTransport fails requests immediately while the circuit breaker of the hardware manager is open
+type circuitBreakerTransport struct {
+	base    http.RoundTripper
+	breaker *circuitBreaker
+	name    string
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if allowed, retryAfter := t.breaker.allow(); !allowed {
+		status := t.breaker.status()
+		return nil, typederrors.NewUnavailableError(nil,
+			"hardware manager %s unavailable after %d consecutive failures, retrying in %s: %s",
+			t.name, status.Failures, retryAfter.Round(time.Second), status.LastError)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// Cancelled by the caller, which says nothing about the hardware manager
+		t.breaker.abandon()
+	case err != nil:
+		t.breaker.record(err)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		t.breaker.record(fmt.Errorf("%s %s failed with status %s", req.Method, req.URL.Path, resp.Status))
+	default:
+		t.breaker.record(nil)
+	}
+	return resp, err // nolint: wrapcheck
+}
@@ -0,0 +1,118 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker()
+	breaker.now = func() time.Time { return now }
+
+	failure := errors.New("connection refused")
+	for i := 0; i < circuitFailureThreshold; i++ {
+		if allowed, _ := breaker.allow(); !allowed {
+			t.Fatalf("expected request %d to be allowed before the threshold", i)
+		}
+		breaker.record(failure)
+	}
+	if status := breaker.status(); !status.Open || status.RetryAfter != circuitInitialBackoff {
+		t.Fatalf("expected breaker to open for %s, got %+v", circuitInitialBackoff, status)
+	}
+	if allowed, _ := breaker.allow(); allowed {
+		t.Errorf("expected request to be rejected while open")
+	}
+
+	// A single trial request is allowed once the backoff has passed, and its failure doubles the backoff
+	now = now.Add(circuitInitialBackoff)
+	if allowed, _ := breaker.allow(); !allowed {
+		t.Fatalf("expected trial request after the backoff")
+	}
+	if allowed, _ := breaker.allow(); allowed {
+		t.Errorf("expected a single trial request")
+	}
+	breaker.record(failure)
+	if status := breaker.status(); status.RetryAfter != 2*circuitInitialBackoff {
+		t.Errorf("expected backoff to double, got %+v", status)
+	}
+
+	// The backoff is capped
+	for i := 0; i < 10; i++ {
+		now = now.Add(circuitMaxBackoff)
+		breaker.allow()
+		breaker.record(failure)
+	}
+	if status := breaker.status(); status.RetryAfter != circuitMaxBackoff {
+		t.Errorf("expected backoff to be capped at %s, got %+v", circuitMaxBackoff, status)
+	}
+
+	// A successful trial request closes the breaker
+	now = now.Add(circuitMaxBackoff)
+	if allowed, _ := breaker.allow(); !allowed {
+		t.Fatalf("expected trial request after the backoff")
+	}
+	breaker.record(nil)
+	if status := breaker.status(); status.Open || status.Failures != 0 {
+		t.Errorf("expected breaker to close, got %+v", status)
+	}
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	statusCode := http.StatusServiceUnavailable
+	calls := 0
+	transport := &circuitBreakerTransport{
+		base: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: statusCode, Status: http.StatusText(statusCode), Body: http.NoBody}, nil
+		}),
+		breaker: newCircuitBreaker(),
+		name:    "dell-1",
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://hwmgr.example.com/v1/resources", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("unexpected error before the threshold: %v", err)
+		}
+	}
+	if _, err := transport.RoundTrip(req); !typederrors.IsUnavailableError(err) {
+		t.Errorf("expected UnavailableError while open, got %v", err)
+	}
+	if calls != circuitFailureThreshold {
+		t.Errorf("expected %d requests to reach the hardware manager, got %d", circuitFailureThreshold, calls)
+	}
+
+	// Client errors do not count as failures
+	statusCode = http.StatusNotFound
+	breaker := newCircuitBreaker()
+	transport.breaker = breaker
+	for i := 0; i < 2*circuitFailureThreshold; i++ {
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if status := breaker.status(); status.Open || status.Failures != 0 {
+		t.Errorf("expected 4xx responses not to count as failures, got %+v", status)
+	}
+}
@@ -12,23 +12,43 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
 
-	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
 	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
 	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	RoleKey       = "role"
 	DefaultTenant = "default_tenant"
+
+	// CPUArchitectureKey is the resource label used to select servers of the requested CPU architecture
+	CPUArchitectureKey = "cpuArchitecture"
+	// RackKey is the resource label used to select servers located in the requested rack
+	RackKey = "rack"
 )
 
+// ResourceFilterLabels returns the resource labels selecting the servers of the rack and tags requested by the
+// resource filters of a node group
+func ResourceFilterLabels(filters pluginv1alpha1.ResourceFilters) map[string]string {
+	labels := make(map[string]string, len(filters.Tags)+1)
+	for key, value := range filters.Tags {
+		labels[key] = value
+	}
+	if filters.Rack != "" {
+		labels[RackKey] = filters.Rack
+	}
+	return labels
+}
+
 type JobStatus int
 
 const (
@@ -78,9 +98,19 @@ func DecodeRespDefault(body []byte) (resp RespDefault, err error) {
 type HardwareManagerClient struct {
 	rtclient    client.Client
 	HwmgrClient *hwmgrapi.ClientWithResponses
+	// tokenClient requests tokens from the hardware manager. Unlike HwmgrClient, it does not set the bearer token on
+	// its requests, as getting that token could otherwise end up requesting a token again.
+	tokenClient *hwmgrapi.ClientWithResponses
 	Logger      *slog.Logger
 	Namespace   string
 	hwmgr       *pluginv1alpha1.HardwareManager
+	httpClient  *http.Client
+	// Clock is the source of time of the token cache and recorded backend errors, the real clock if unset
+	Clock utils.Clock
+}
+
+func (c *HardwareManagerClient) clock() utils.Clock {
+	return utils.ClockOrReal(c.Clock)
 }
 
 // GetTenant gets the tenant parameter from the hwmgr configuration
@@ -94,24 +124,31 @@ func (c *HardwareManagerClient) GetTenant() string {
 
 // GetToken sends a request to the hardware manager to request an authentication token
 func (c *HardwareManagerClient) GetToken(ctx context.Context) (string, error) {
+	token, _, err := c.requestToken(ctx)
+	return token, err
+}
+
+// requestToken sends a request to the hardware manager to request an authentication token, returning the token and
+// its lifetime
+func (c *HardwareManagerClient) requestToken(ctx context.Context) (string, time.Duration, error) {
 	clientSecrets, err := utils.GetSecret(ctx, c.rtclient, c.hwmgr.Spec.DellData.AuthSecret, c.Namespace)
 	if err != nil {
-		return "", fmt.Errorf("failed to get client secret: %w", err)
+		return "", 0, fmt.Errorf("failed to get client secret: %w", err)
 	}
 
 	clientId, err := utils.GetSecretField(clientSecrets, "client-id")
 	if err != nil {
-		return "", fmt.Errorf("failed to get client-id from secret: %s, %w", c.hwmgr.Spec.DellData.AuthSecret, err)
+		return "", 0, fmt.Errorf("failed to get client-id from secret: %s, %w", c.hwmgr.Spec.DellData.AuthSecret, err)
 	}
 
 	username, err := utils.GetSecretField(clientSecrets, corev1.BasicAuthUsernameKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s from secret: %s, %w", corev1.BasicAuthUsernameKey, c.hwmgr.Spec.DellData.AuthSecret, err)
+		return "", 0, fmt.Errorf("failed to get %s from secret: %s, %w", corev1.BasicAuthUsernameKey, c.hwmgr.Spec.DellData.AuthSecret, err)
 	}
 
 	password, err := utils.GetSecretField(clientSecrets, corev1.BasicAuthPasswordKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s from secret: %s, %w", corev1.BasicAuthPasswordKey, c.hwmgr.Spec.DellData.AuthSecret, err)
+		return "", 0, fmt.Errorf("failed to get %s from secret: %s, %w", corev1.BasicAuthPasswordKey, c.hwmgr.Spec.DellData.AuthSecret, err)
 	}
 
 	grant_type := string(pluginv1alpha1.OAuthGrantTypes.Password)
@@ -123,25 +160,51 @@ func (c *HardwareManagerClient) GetToken(ctx context.Context) (string, error) {
 		GrantType: &grant_type,
 	}
 
-	tokenrsp, err := c.HwmgrClient.GetTokenWithResponse(ctx, req)
+	tokenrsp, err := c.tokenClient.GetTokenWithResponse(ctx, req)
 	if err != nil {
-		return "", typederrors.NewTokenError(err, "failed to get token: response: %v", tokenrsp)
+		return "", 0, typederrors.NewTokenError(err, "failed to get token: response: %v", tokenrsp)
 	}
 
 	if tokenrsp.StatusCode() != http.StatusOK {
-		return "", typederrors.NewTokenError(nil, "token request failed with status %s (%d), message=%s",
+		return "", 0, typederrors.NewTokenError(nil, "token request failed with status %s (%d), message=%s",
 			tokenrsp.Status(), tokenrsp.StatusCode(), string(tokenrsp.Body))
 	}
 
 	var tokenData hwmgrapi.RhprotoGetTokenResponseBody
 	if err := json.Unmarshal(tokenrsp.Body, &tokenData); err != nil {
-		return "", typederrors.NewTokenError(err, "failed to parse token: response: %v", tokenrsp)
+		return "", 0, typederrors.NewTokenError(err, "failed to parse token: response: %v", tokenrsp)
 	}
 
 	if tokenData.AccessToken == nil {
-		return "", typederrors.NewTokenError(nil, "failed to get token: access_token field empty: %v", tokenrsp)
+		return "", 0, typederrors.NewTokenError(nil, "failed to get token: access_token field empty: %v", tokenrsp)
+	}
+
+	lifetime := defaultTokenLifetime
+	if tokenData.ExpiresIn != nil && *tokenData.ExpiresIn > 0 {
+		lifetime = time.Duration(*tokenData.ExpiresIn) * time.Second
+	}
+	return *tokenData.AccessToken, lifetime, nil
+}
+
+// NewTransport creates an HTTP transport with the TLS and proxy settings of the HardwareManager
+func NewTransport(ctx context.Context, rtclient client.Client, hwmgr *pluginv1alpha1.HardwareManager) (http.RoundTripper, error) {
+	config, insecureSkipTLSVerify, err := getTLSSettings(ctx, rtclient, hwmgr)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := getProxySettings(hwmgr)
+	if err != nil {
+		return nil, err
+	}
+	config.Proxy = proxy.Proxy
+
+	tr, err := utils.GetTransportWithCaBundle(config, insecureSkipTLSVerify, utils.IsHardwareManagerLogMessagesEnabled(hwmgr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get http transport: %w", err)
 	}
-	return *tokenData.AccessToken, nil
+
+	return tr, nil
 }
 
 // NewClientWithResponses creates an authenticated client connected to the hardware manager
@@ -150,62 +213,63 @@ func NewClientWithResponses(
 	logger *slog.Logger,
 	rtclient client.Client,
 	hwmgr *pluginv1alpha1.HardwareManager) (*HardwareManagerClient, error) {
+	return NewClientWithClock(ctx, logger, rtclient, hwmgr, nil)
+}
+
+// NewClientWithClock creates an authenticated client connected to the hardware manager, with the given source of time
+func NewClientWithClock(
+	ctx context.Context,
+	logger *slog.Logger,
+	rtclient client.Client,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	clock utils.Clock) (*HardwareManagerClient, error) {
 
 	hwmgrClient := HardwareManagerClient{
 		rtclient:  rtclient,
 		Logger:    logger,
 		Namespace: hwmgr.Namespace,
 		hwmgr:     hwmgr,
+		Clock:     clock,
 	}
 
-	// If the HardwareManager CR includes certificates, get the bundle to add to the client
-	var caBundle string
-	if hwmgr.Spec.DellData.CaBundleName != nil {
-		cm, err := utils.GetConfigmap(ctx, rtclient, *hwmgr.Spec.DellData.CaBundleName, hwmgr.Namespace)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get configmap: %w", err)
-		}
+	tr, err := NewTransport(ctx, rtclient, hwmgr)
+	if err != nil {
+		return nil, err
+	}
 
-		caBundle, err = utils.GetConfigMapField(cm, "ca-bundle.pem")
+	if hwmgr.Spec.DellData.Relay != nil {
+		token, err := getRelayToken(ctx, rtclient, hwmgr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get certificate bundle from configmap: %w", err)
+			return nil, err
 		}
+		tr = &relayTokenTransport{base: tr, token: token}
 	}
 
-	config := utils.OAuthClientConfig{
-		CaBundle: []byte(caBundle),
-	}
-
-	tr, err := utils.GetTransportWithCaBundle(config, hwmgr.Spec.DellData.InsecureSkipTLSVerify, utils.IsHardwareManagerLogMessagesEnabled(hwmgr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get http transport: %w", err)
-	}
-
-	httpClient := &http.Client{Transport: tr}
+	tr = &metricsTransport{base: tr, name: hwmgr.Name}
+	tr = utils.NewSouthboundErrorTransport(tr, hwmgr, hwmgrClient.clock())
+	tr = utils.NewRateLimitTransport(tr, hwmgr)
+	tr = &circuitBreakerTransport{base: tr, breaker: breakerFor(hwmgr.UID), name: hwmgr.Name}
+	httpClient := &http.Client{Transport: &tokenInvalidatingTransport{base: tr, client: &hwmgrClient}}
+	hwmgrClient.httpClient = httpClient
 
-	// Create the hwmgrapi client, along with a bearer token
-	hwmgrClient.HwmgrClient, err = hwmgrapi.NewClientWithResponses(
-		hwmgr.Spec.DellData.ApiUrl,
+	// Create the hwmgrapi client used to request tokens
+	hwmgrClient.tokenClient, err = hwmgrapi.NewClientWithResponses(
+		apiURL(hwmgr),
 		hwmgrapi.WithHTTPClient(httpClient))
 	if err != nil {
-		return nil, fmt.Errorf("failed to setup client to %s: %w", hwmgr.Spec.DellData.ApiUrl, err)
+		return nil, fmt.Errorf("failed to setup client to %s: %w", apiURL(hwmgr), err)
 	}
 
-	token, err := hwmgrClient.GetToken(ctx)
-	if err != nil {
+	// Get the token up front, so that authentication failures are reported on client creation
+	if _, err := hwmgrClient.GetSharedToken(ctx); err != nil {
 		return nil, fmt.Errorf("failed to get token for %s: %w", hwmgr.Name, err)
 	}
 
-	bearerAuth, err := securityprovider.NewSecurityProviderBearerToken(token)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create security provider for %s: %w", hwmgr.Name, err)
-	}
-
 	// Create a new client with an intercept to add the bearer token
 	hwmgrClient.HwmgrClient, err = hwmgrapi.NewClientWithResponses(
-		hwmgr.Spec.DellData.ApiUrl,
+		apiURL(hwmgr),
 		hwmgrapi.WithHTTPClient(httpClient),
-		hwmgrapi.WithRequestEditorFn(bearerAuth.Intercept))
+		hwmgrapi.WithRequestEditorFn(hwmgrClient.bearerToken))
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup auth client for %s: %w", hwmgr.Name, err)
 	}
@@ -267,14 +331,29 @@ func (c *HardwareManagerClient) GetResourceGroups(ctx context.Context) (*hwmgrap
 	return response.JSON200, nil
 }
 
+// ResourceGroupIdPrefix is the prefix of the identifiers of the resource groups created by the plugin
+const ResourceGroupIdPrefix = "rhplugin-rg-"
+
 // ResourceGroupIdFromNodePool returns the resource group identifier corresponding to the specified nodepool
 func ResourceGroupIdFromNodePool(nodepool *hwmgmtv1alpha1.NodePool) string {
-	return fmt.Sprintf("rhplugin-rg-%s", nodepool.Spec.CloudID)
+	return ResourceGroupIdPrefix + nodepool.Spec.CloudID
+}
+
+// ScaleOutResourceGroupId returns the identifier of a resource group allocating a node added to the nodepool by a
+// scale-out, with the given unique suffix
+func ScaleOutResourceGroupId(nodepool *hwmgmtv1alpha1.NodePool, suffix string) string {
+	return ResourceGroupIdFromNodePool(nodepool) + "-" + suffix
 }
 
 // ResourceGroupFromNodePool transforms data from a nodepool object to a CreateResourceGroupJSONRequestBody instance
 func (c *HardwareManagerClient) ResourceGroupFromNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) *hwmgrapi.CreateResourceGroupJSONRequestBody {
-	rgId := ResourceGroupIdFromNodePool(nodepool)
+	return c.resourceGroupRequest(ctx, nodepool, ResourceGroupIdFromNodePool(nodepool), nodepool.Spec.NodeGroup)
+}
+
+// resourceGroupRequest returns the request creating a resource group with the given identifier, with a resource
+// selector for each of the nodegroups of the nodepool
+func (c *HardwareManagerClient) resourceGroupRequest(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, rgId string,
+	nodegroups []hwmgmtv1alpha1.NodeGroup) *hwmgrapi.CreateResourceGroupJSONRequestBody {
 	tenant := c.GetTenant()
 	resourceTypeId := utils.GetResourceTypeId(nodepool)
 	description := "Resource Group managed by O-Cloud Hardware Manager Plugin"
@@ -282,7 +361,7 @@ func (c *HardwareManagerClient) ResourceGroupFromNodePool(ctx context.Context, n
 	roleKey := RoleKey
 
 	resourceSelectors := make(map[string]hwmgrapi.RhprotoResourceSelectorRequest)
-	for _, nodegroup := range nodepool.Spec.NodeGroup {
+	for _, nodegroup := range nodegroups {
 		inclusions := []hwmgrapi.RhprotoResourceSelectorFilterIncludeLabel{
 			{
 				Key:   &roleKey,
@@ -299,11 +378,22 @@ func (c *HardwareManagerClient) ResourceGroupFromNodePool(ctx context.Context, n
 				}
 			}
 		}
+		if arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodegroup.NodePoolData.Name); err == nil && arch != "" {
+			archKey := CPUArchitectureKey
+			inclusions = append(inclusions, hwmgrapi.RhprotoResourceSelectorFilterIncludeLabel{Key: &archKey, Value: &arch})
+		}
+		if extensions, err := utils.GetNodePoolExtensions(nodepool); err == nil {
+			for key, value := range ResourceFilterLabels(extensions.GetResourceFilters(nodegroup.NodePoolData.Name)) {
+				inclusions = append(inclusions, hwmgrapi.RhprotoResourceSelectorFilterIncludeLabel{Key: &key, Value: &value})
+			}
+		}
 
+		// Each nodegroup requests the resources with its own hardware profile
 		rpId := nodepool.Status.SelectedPools[nodegroup.NodePoolData.Name]
+		hwProfile := nodegroup.NodePoolData.HwProfile
 		resourceSelectors[nodegroup.NodePoolData.Name] = hwmgrapi.RhprotoResourceSelectorRequest{
 			RpId:              &rpId,
-			ResourceProfileId: &nodegroup.NodePoolData.HwProfile,
+			ResourceProfileId: &hwProfile,
 			NumResources:      &nodegroup.Size,
 			Filters: &hwmgrapi.RhprotoResourceSelectorFilter{
 				Include: &hwmgrapi.RhprotoResourceSelectorFilterInclude{
@@ -358,9 +448,13 @@ func (c *HardwareManagerClient) ResourceGroupFromNodePool(ctx context.Context, n
 	return &rg
 }
 
+// ResourceGroupExists checks whether the resource group of the nodepool exists
 func (c *HardwareManagerClient) ResourceGroupExists(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
-	rg := c.ResourceGroupFromNodePool(ctx, nodepool)
-	rgId := *rg.ResourceGroup.Id
+	return c.ResourceGroupIdExists(ctx, ResourceGroupIdFromNodePool(nodepool))
+}
+
+// ResourceGroupIdExists checks whether the resource group with the given identifier exists
+func (c *HardwareManagerClient) ResourceGroupIdExists(ctx context.Context, rgId string) (bool, error) {
 	tenant := c.GetTenant()
 
 	// First check whether the resource group already exists
@@ -402,27 +496,63 @@ func (c *HardwareManagerClient) CreateResourceGroup(ctx context.Context, nodepoo
 	return *rgResponse.JSON200.Jobid, nil
 }
 
+// CreateScaleOutResourceGroup sends a request to the hardware manager to create a resource group with the given
+// identifier, allocating a single resource for the named nodegroup of the nodepool. It returns the jobId.
+func (c *HardwareManagerClient) CreateScaleOutResourceGroup(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	rgId, nodegroupName string) (string, error) {
+	var nodegroups []hwmgmtv1alpha1.NodeGroup
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		if nodegroup.NodePoolData.Name == nodegroupName {
+			nodegroup.Size = 1
+			nodegroups = append(nodegroups, nodegroup)
+		}
+	}
+	if len(nodegroups) == 0 {
+		return "", fmt.Errorf("nodegroup %s not found in nodepool %s", nodegroupName, nodepool.Name)
+	}
+	rg := c.resourceGroupRequest(ctx, nodepool, rgId, nodegroups)
+
+	rgResponse, err := c.HwmgrClient.CreateResourceGroupWithResponse(ctx, c.GetTenant(), *rg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create resource group %s, api failure: response: %v, err: %w", rgId, rgResponse, err)
+	}
+
+	if rgResponse.StatusCode() != http.StatusOK || rgResponse.JSON200 == nil || rgResponse.JSON200.Jobid == nil {
+		return "", fmt.Errorf("failed to create resource group %s, bad status: %s, code: %d, message=%s",
+			rgId, rgResponse.Status(), rgResponse.StatusCode(), string(rgResponse.Body))
+	}
+
+	return *rgResponse.JSON200.Jobid, nil
+}
+
 // CheckJobStatus queries the hardware manager for the status of a job
 func (c *HardwareManagerClient) CheckJobStatus(ctx context.Context, jobId string) (JobStatus, string, error) {
-	failReason := ""
+	status, failure, err := c.CheckJobFailure(ctx, jobId)
+	return status, failure.Reason, err
+}
+
+// CheckJobFailure queries the hardware manager for the status of a job, along with the details reported for a failed
+// job
+func (c *HardwareManagerClient) CheckJobFailure(ctx context.Context, jobId string) (JobStatus, utils.JobFailure, error) {
+	failure := utils.JobFailure{JobId: jobId}
 	tenant := c.GetTenant()
 	response, err := c.HwmgrClient.VerifyRequestStatusWithResponse(ctx, tenant, jobId)
 	if err != nil {
-		return JobStatusUnknown, failReason, fmt.Errorf("failed to query for job status: id: %s, response: %v, err: %w", jobId, response, err)
+		return JobStatusUnknown, failure, fmt.Errorf("failed to query for job status: id: %s, response: %v, err: %w", jobId, response, err)
 	}
 
 	if response.StatusCode() != http.StatusOK {
 		details, err := DecodeRespDefault(response.Body)
 		if err != nil {
-			return JobStatusUnknown, failReason, fmt.Errorf("failed to decode response, StatusCode=%d: %w", response.StatusCode(), err)
+			return JobStatusUnknown, failure, fmt.Errorf("failed to decode response, StatusCode=%d: %w", response.StatusCode(), err)
 		}
 
 		if details.Details[0].Metadata.HTTPErrorCode == "404" {
 			// Job no longer exists
-			return JobStatusNotExist, failReason, nil
+			return JobStatusNotExist, failure, nil
 		}
 
-		return JobStatusUnknown, failReason,
+		return JobStatusUnknown, failure,
 			fmt.Errorf("job query failed for %s: Reason='%s', ManagedServiceError='%s', Resolution='%s'",
 				jobId,
 				details.Details[0].Reason,
@@ -433,43 +563,49 @@ func (c *HardwareManagerClient) CheckJobStatus(ctx context.Context, jobId string
 	status := response.JSON200
 	if status == nil || status.Brief == nil || status.Brief.Status == nil {
 		c.Logger.InfoContext(ctx, "Job progress check missing data", slog.Any("status", status))
-		return JobStatusUnknown, failReason, fmt.Errorf("job progress check missing data, jobId=%s: %w", jobId, err)
+		return JobStatusUnknown, failure, fmt.Errorf("job progress check missing data, jobId=%s: %w", jobId, err)
 	}
 
 	// Process the status response
 	switch *status.Brief.Status {
 	case "started":
 		c.Logger.InfoContext(ctx, "Job has started")
-		return JobStatusInProgress, failReason, nil
+		return JobStatusInProgress, failure, nil
 	case "pending":
 		c.Logger.InfoContext(ctx, "Job is pending")
-		return JobStatusInProgress, failReason, nil
+		return JobStatusInProgress, failure, nil
 	case "completed":
 		c.Logger.InfoContext(ctx, "Job has completed")
 	case "failed":
-		if status.Brief.FailReason != nil {
-			failReason = *status.Brief.FailReason
-		} else {
-			failReason = "unknown"
-		}
-		c.Logger.InfoContext(ctx, "Job has failed", slog.String("message", string(response.Body)), slog.String("failReason", failReason))
-		return JobStatusFailed, failReason, nil
+		failure = decodeJobFailure(jobId, status.Brief.FailReason)
+		c.Logger.InfoContext(ctx, "Job has failed", slog.String("message", string(response.Body)), slog.String("failReason", failure.Reason))
+		return JobStatusFailed, failure, nil
 	default:
-		if status.Brief.FailReason != nil {
-			failReason = *status.Brief.FailReason
-		} else {
-			failReason = "unknown"
-		}
-		c.Logger.InfoContext(ctx, "Job status is unknown", slog.String("message", string(response.Body)), slog.String("failReason", failReason))
-		return JobStatusUnknown, failReason, nil
+		failure = decodeJobFailure(jobId, status.Brief.FailReason)
+		c.Logger.InfoContext(ctx, "Job status is unknown", slog.String("message", string(response.Body)), slog.String("failReason", failure.Reason))
+		return JobStatusUnknown, failure, nil
 	}
 
-	return JobStatusCompleted, failReason, nil
+	return JobStatusCompleted, failure, nil
+}
+
+// decodeJobFailure returns the failure of a job from the fail reason of its brief status, the only failure detail the
+// job status model reports
+func decodeJobFailure(jobId string, failReason *string) utils.JobFailure {
+	failure := utils.JobFailure{JobId: jobId, Reason: "unknown"}
+	if failReason != nil && *failReason != "" {
+		failure.Reason = *failReason
+	}
+	return failure
 }
 
 // DeleteResourceGroup asks the hardware manager to delete the resource group associated with the specified nodepool
 func (c *HardwareManagerClient) DeleteResourceGroup(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (string, error) {
-	rgId := ResourceGroupIdFromNodePool(nodepool)
+	return c.DeleteResourceGroupFromId(ctx, ResourceGroupIdFromNodePool(nodepool))
+}
+
+// DeleteResourceGroupFromId asks the hardware manager to delete the resource group with the given identifier
+func (c *HardwareManagerClient) DeleteResourceGroupFromId(ctx context.Context, rgId string) (string, error) {
 	tenant := c.GetTenant()
 
 	response, err := c.HwmgrClient.DeleteResourceGroupWithResponse(ctx, tenant, rgId)
@@ -480,55 +616,117 @@ func (c *HardwareManagerClient) DeleteResourceGroup(ctx context.Context, nodepoo
 	return *response.JSON200.Jobid, nil
 }
 
-// GetResourcePools queries the hardware manager to get the resource pool list
+// GetResourcePools queries the hardware manager to get the resource pool list, fetching all pages
 func (c *HardwareManagerClient) GetResourcePools(ctx context.Context) (*hwmgrapi.ApiprotoResourcePoolsResp, error) {
 	tenant := c.GetTenant()
-	body := hwmgrapi.GetResourcePoolsJSONRequestBody{}
-	response, err := c.HwmgrClient.GetResourcePoolsWithResponse(ctx, tenant, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get resource pools: response: %v, err: %w", response, err)
-	}
+	pools, err := fetchAllPages(ctx, listPageSize, func(pool hwmgrapi.ApiprotoResourcePool) *string { return pool.Id },
+		func(ctx context.Context, index int) ([]hwmgrapi.ApiprotoResourcePool, int64, error) {
+			body := hwmgrapi.GetResourcePoolsJSONRequestBody{
+				Pagination: &hwmgrapi.ApiprotoPagination{
+					Offset: ptr.To(int64(index * listPageSize)),
+					Limit:  ptr.To(int64(listPageSize)),
+				},
+			}
+			response, err := c.HwmgrClient.GetResourcePoolsWithResponse(ctx, tenant, body)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to get resource pools: response: %v, err: %w", response, err)
+			}
 
-	if response.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("resource pool get failed with status %s (%d), message=%s",
-			response.Status(), response.StatusCode(), string(response.Body))
+			if response.StatusCode() != http.StatusOK {
+				return nil, 0, fmt.Errorf("resource pool get failed with status %s (%d), message=%s",
+					response.Status(), response.StatusCode(), string(response.Body))
+			}
+
+			if response.JSON200 == nil || response.JSON200.ResourcePools == nil {
+				return nil, -1, nil
+			}
+			return *response.JSON200.ResourcePools, paginationTotal(response.JSON200.Pagination), nil
+		})
+	if err != nil {
+		return nil, err
 	}
 
-	return response.JSON200, nil
+	return &hwmgrapi.ApiprotoResourcePoolsResp{ResourcePools: &pools}, nil
 }
 
-// GetServersInventory queries the hardware manager to get the server inventory
+// GetServersInventory queries the hardware manager to get the server inventory, fetching all pages
 func (c *HardwareManagerClient) GetServersInventory(ctx context.Context) (*hwmgrapi.ApiprotoGetServersInventoryResp, error) {
 	tenant := c.GetTenant()
-	params := hwmgrapi.GetServersInventoryParams{}
-	response, err := c.HwmgrClient.GetServersInventoryWithResponse(ctx, tenant, &params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get servers inventory: response: %v, err: %w", response, err)
-	}
+	serverName := func(server hwmgrapi.ApiprotoServer) *string {
+		if server.Metadata == nil {
+			return nil
+		}
+		return server.Metadata.Name
+	}
+	servers, err := fetchAllPages(ctx, listPageSize, serverName,
+		func(ctx context.Context, index int) ([]hwmgrapi.ApiprotoServer, int64, error) {
+			// Server inventory pages are numbered from 1
+			params := hwmgrapi.GetServersInventoryParams{
+				PageNumber: ptr.To(strconv.Itoa(index + 1)),
+				PageSize:   ptr.To(strconv.Itoa(listPageSize)),
+			}
+			response, err := c.HwmgrClient.GetServersInventoryWithResponse(ctx, tenant, &params)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to get servers inventory: response: %v, err: %w", response, err)
+			}
 
-	if response.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("server inventory get failed with status %s (%d), message=%s",
-			response.Status(), response.StatusCode(), string(response.Body))
+			if response.StatusCode() != http.StatusOK {
+				return nil, 0, fmt.Errorf("server inventory get failed with status %s (%d), message=%s",
+					response.Status(), response.StatusCode(), string(response.Body))
+			}
+
+			if response.JSON200 == nil || response.JSON200.Servers == nil {
+				return nil, -1, nil
+			}
+			total := int64(-1)
+			if response.JSON200.ServerCount != nil {
+				if count, err := strconv.ParseInt(*response.JSON200.ServerCount, 10, 64); err == nil {
+					total = count
+				}
+			}
+			return *response.JSON200.Servers, total, nil
+		})
+	if err != nil {
+		return nil, err
 	}
 
-	return response.JSON200, nil
+	return &hwmgrapi.ApiprotoGetServersInventoryResp{
+		Servers:     &servers,
+		ServerCount: ptr.To(strconv.Itoa(len(servers))),
+	}, nil
 }
 
-// GetResources queries the hardware manager to get the resources list
+// GetResources queries the hardware manager to get the resources list, fetching all pages
 func (c *HardwareManagerClient) GetResources(ctx context.Context) (*hwmgrapi.ApiprotoGetResourcesResp, error) {
 	tenant := c.GetTenant()
-	body := hwmgrapi.GetResourcesJSONRequestBody{}
-	response, err := c.HwmgrClient.GetResourcesWithResponse(ctx, tenant, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get resources: response: %v, err: %w", response, err)
-	}
+	resources, err := fetchAllPages(ctx, listPageSize, func(resource hwmgrapi.ApiprotoResource) *string { return resource.Id },
+		func(ctx context.Context, index int) ([]hwmgrapi.ApiprotoResource, int64, error) {
+			body := hwmgrapi.GetResourcesJSONRequestBody{
+				Pagination: &hwmgrapi.ApiprotoPagination{
+					Offset: ptr.To(int64(index * listPageSize)),
+					Limit:  ptr.To(int64(listPageSize)),
+				},
+			}
+			response, err := c.HwmgrClient.GetResourcesWithResponse(ctx, tenant, body)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to get resources: response: %v, err: %w", response, err)
+			}
 
-	if response.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("resources get failed with status %s (%d), message=%s",
-			response.Status(), response.StatusCode(), string(response.Body))
+			if response.StatusCode() != http.StatusOK {
+				return nil, 0, fmt.Errorf("resources get failed with status %s (%d), message=%s",
+					response.Status(), response.StatusCode(), string(response.Body))
+			}
+
+			if response.JSON200 == nil || response.JSON200.Resources == nil {
+				return nil, -1, nil
+			}
+			return *response.JSON200.Resources, paginationTotal(response.JSON200.Pagination), nil
+		})
+	if err != nil {
+		return nil, err
 	}
 
-	return response.JSON200, nil
+	return &hwmgrapi.ApiprotoGetResourcesResp{Resources: &resources}, nil
 }
 
 // GetSecret queries the hardware manager to get the Secret data
@@ -579,6 +777,12 @@ func (c *HardwareManagerClient) ValidateResourceGroup(
 					return fmt.Errorf("missing resource pool id for node %s\n expected: %s",
 						nodegroupName, rpId)
 				}
+				// Ensure resource profile id match, if reported
+				hwProfile := nodegroup.NodePoolData.HwProfile
+				if resource.ResourceProfileId != nil && *resource.ResourceProfileId != "" && *resource.ResourceProfileId != hwProfile {
+					return fmt.Errorf("invalid resource profile id for node %s\n expected: %s found: %s",
+						nodegroupName, hwProfile, *resource.ResourceProfileId)
+				}
 			} else {
 				return fmt.Errorf("validation failed, %s node does not exist in resource group", nodegroupName)
 			}
@@ -634,3 +838,19 @@ func (c *HardwareManagerClient) UpdateResourceProfile(ctx context.Context, node
 
 	return *response.JSON200.Response.Jobid, nil
 }
+
+// BiosAttributesToMap converts the BIOS attributes reported by the server inventory to a map keyed by attribute name,
+// omitting the attributes that are not reported
+func BiosAttributesToMap(attributes *hwmgrapi.ApiprotoBIOSAttributes) (map[string]interface{}, error) {
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal BIOS attributes: %w", err)
+	}
+
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal BIOS attributes: %w", err)
+	}
+
+	return result, nil
+}
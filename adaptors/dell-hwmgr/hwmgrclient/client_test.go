@@ -0,0 +1,92 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/utils/ptr"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+func TestBiosAttributesToMap(t *testing.T) {
+	attributes := &hwmgrapi.ApiprotoBIOSAttributes{
+		BootMode:            ptr.To("Uefi"),
+		AcPwrRcvryUserDelay: ptr.To(int32(60)),
+		WorkloadProfile:     ptr.To("TelcoOptimizedProfile"),
+	}
+
+	result, err := BiosAttributesToMap(attributes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"BootMode":            "Uefi",
+		"AcPwrRcvryUserDelay": float64(60),
+		"WorkloadProfile":     "TelcoOptimizedProfile",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestDecodeJobFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		failReason *string
+		expected   utils.JobFailure
+	}{
+		{name: "no fail reason", expected: utils.JobFailure{JobId: "job-1", Reason: "unknown"}},
+		{name: "empty fail reason", failReason: ptr.To(""), expected: utils.JobFailure{JobId: "job-1", Reason: "unknown"}},
+		{name: "fail reason", failReason: ptr.To("not enough free resources"),
+			expected: utils.JobFailure{JobId: "job-1", Reason: "not enough free resources"}},
+	}
+	for _, tt := range tests {
+		if failure := decodeJobFailure("job-1", tt.failReason); !reflect.DeepEqual(failure, tt.expected) {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.expected, failure)
+		}
+	}
+}
+
+func TestValidateResourceGroupProfiles(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Spec.NodeGroup = []hwmgmtv1alpha1.NodeGroup{
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller", HwProfile: "controller-profile"}, Size: 1},
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker", HwProfile: "worker-profile"}, Size: 2},
+	}
+	nodepool.Status.SelectedPools = map[string]string{"controller": "pool-1", "worker": "pool-1"}
+
+	resourceGroup := func(controllerProfile, workerProfile *string) hwmgrapi.RhprotoResourceGroupObjectGetResponseBody {
+		return hwmgrapi.RhprotoResourceGroupObjectGetResponseBody{
+			ResourceSelectors: &map[string]hwmgrapi.RhprotoResourceSelectorGetResponse{
+				"controller": {NumResources: ptr.To(float32(1)), RpId: ptr.To("pool-1"), ResourceProfileId: controllerProfile},
+				"worker":     {NumResources: ptr.To(float32(2)), RpId: ptr.To("pool-1"), ResourceProfileId: workerProfile},
+			},
+		}
+	}
+
+	c := &HardwareManagerClient{}
+	if err := c.ValidateResourceGroup(context.Background(), nodepool,
+		resourceGroup(ptr.To("controller-profile"), ptr.To("worker-profile"))); err != nil {
+		t.Errorf("expected distinct profiles per nodegroup to be valid, got %v", err)
+	}
+	if err := c.ValidateResourceGroup(context.Background(), nodepool, resourceGroup(nil, ptr.To(""))); err != nil {
+		t.Errorf("expected unreported profiles to be valid, got %v", err)
+	}
+	err := c.ValidateResourceGroup(context.Background(), nodepool,
+		resourceGroup(ptr.To("controller-profile"), ptr.To("controller-profile")))
+	if err == nil || !strings.Contains(err.Error(), "invalid resource profile id for node worker") {
+		t.Errorf("expected a profile mismatch for the worker nodegroup, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// AbortJob requests the hardware manager to abort a job in progress. The hardware manager API does not document a job
+// abort operation, so the request is built here, sharing the transport and token of the generated client, and any
+// response other than a success is returned as an error. Callers must treat the abort as best-effort, and wait for the
+// job to finish or time out regardless.
+func (c *HardwareManagerClient) AbortJob(ctx context.Context, jobId string) error {
+	jobURL, err := url.JoinPath(apiURL(c.hwmgr), "v1", "tenants", url.PathEscape(c.GetTenant()), "jobs", url.PathEscape(jobId))
+	if err != nil {
+		return fmt.Errorf("failed to build job URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, jobURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create job abort request: %w", err)
+	}
+	if err := c.bearerToken(ctx, req); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to abort job %s: %w", jobId, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("job %s abort failed with status %s (%d), message=%s", jobId, resp.Status, resp.StatusCode, string(body))
+}
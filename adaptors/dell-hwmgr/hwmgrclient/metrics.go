@@ -0,0 +1,96 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "hwmgr_plugin_dell_api_requests_total",
+	Help: "Number of requests sent to Dell hardware managers, by endpoint and status code",
+}, []string{"hwmgr", "method", "endpoint", "code"})
+
+var apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "hwmgr_plugin_dell_api_request_duration_seconds",
+	Help:    "Latency of the requests sent to Dell hardware managers, by endpoint",
+	Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+}, []string{"hwmgr", "method", "endpoint"})
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRequestDuration)
+}
+
+// apiPathSegments are the literal path segments of the hardware manager API. Any other segment is an identifier,
+// replaced in the endpoint label to keep its cardinality bounded.
+var apiPathSegments = map[string]bool{
+	"v1": true, "identity": true, "token": true, "create": true, "inventory": true, "search": true,
+	"locations": true, "resourcepools": true, "resources": true, "retention-policy": true, "servers": true,
+	"sites": true, "jobs": true, "resourcegroups": true, "deployments": true, "resourcesubscriptions": true,
+	"subscribe": true, "unsubscribe": true, "secrets": true,
+}
+
+// endpointLabel returns the path template of a request to the hardware manager, such as
+// /v1/tenants/{tenant}/resources/{id}, dropping any prefix of the API URL
+func endpointLabel(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	start := -1
+	for i, segment := range segments {
+		if segment == "v1" || segment == "identity" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return "other"
+	}
+
+	template := make([]string, 0, len(segments)-start)
+	for i := start; i < len(segments); i++ {
+		segment := segments[i]
+		switch {
+		case segment == "tenant" || segment == "tenants":
+			template = append(template, segment)
+			if i+1 < len(segments) {
+				template = append(template, "{tenant}")
+				i++
+			}
+		case apiPathSegments[segment]:
+			template = append(template, segment)
+		default:
+			template = append(template, "{id}")
+		}
+	}
+	return "/" + strings.Join(template, "/")
+}
+
+// metricsTransport records the count, status code and latency of the requests sent to the hardware manager
+type metricsTransport struct {
+	base http.RoundTripper
+	name string
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointLabel(req.URL.Path)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	apiRequestDuration.WithLabelValues(t.name, req.Method, endpoint).Observe(time.Since(start).Seconds())
+
+	// Requests that got no response are counted with the "error" code
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	apiRequests.WithLabelValues(t.name, req.Method, endpoint, code).Inc()
+	return resp, err // nolint: wrapcheck
+}
@@ -0,0 +1,52 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestEndpointLabel(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/v1/tenants/acme/resources/4c1f/deployments":                "/v1/tenants/{tenant}/resources/{id}/deployments",
+		"/api/v1/tenants/acme/resourcegroups":                        "/v1/tenants/{tenant}/resourcegroups",
+		"/v1/tenants/acme/resourcesubscriptions/resources/subscribe": "/v1/tenants/{tenant}/resourcesubscriptions/resources/subscribe",
+		"/v1/tenants/acme/search/resourcepools/pool-1":               "/v1/tenants/{tenant}/search/resourcepools/{id}",
+		"/identity/v1/tenant/acme/token/create":                      "/identity/v1/tenant/{tenant}/token/create",
+		"/v1/tenants/acme/jobs/7d2e5b0a-9a7f-4a52-8c1e-3f0b2d6c4e11": "/v1/tenants/{tenant}/jobs/{id}",
+		"/healthz": "other",
+	} {
+		if label := endpointLabel(path); label != expected {
+			t.Errorf("%s: expected %s, got %s", path, expected, label)
+		}
+	}
+}
+
+type fakeRoundTripper struct {
+	resp *http.Response
+	err  error
+}
+
+func (f *fakeRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return f.resp, f.err
+}
+
+func TestMetricsTransport(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://hwmgr.example.com/v1/tenants/acme/resources/r-1", nil)
+
+	ok := &metricsTransport{base: &fakeRoundTripper{resp: &http.Response{StatusCode: http.StatusOK}}, name: "dell-1"}
+	if resp, err := ok.RoundTrip(req); err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the response to be returned, got %v, %v", resp, err)
+	}
+
+	failed := &metricsTransport{base: &fakeRoundTripper{err: errors.New("connection refused")}, name: "dell-1"}
+	if _, err := failed.RoundTrip(req); err == nil {
+		t.Errorf("expected the transport error to be returned")
+	}
+}
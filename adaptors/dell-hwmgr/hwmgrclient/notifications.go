@@ -0,0 +1,76 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+)
+
+// SubscribeResources adds the resources to the resource subscription, so that the hardware manager notifies the
+// plugin of their state changes and of the completion of their jobs
+func (c *HardwareManagerClient) SubscribeResources(ctx context.Context, subscriptionId string, resourceIds []string) error {
+	if len(resourceIds) == 0 {
+		return nil
+	}
+	body := hwmgrapi.SubscribeResourcesJSONRequestBody{
+		Id:        &subscriptionId,
+		Resources: &resourceIds,
+	}
+	response, err := c.HwmgrClient.SubscribeResourcesWithResponse(ctx, c.GetTenant(), body)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe resources to %s: response: %v, err: %w", subscriptionId, response, err)
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf("resource subscription %s failed with status %s (%d), message=%s",
+			subscriptionId, response.Status(), response.StatusCode(), string(response.Body))
+	}
+
+	return nil
+}
+
+// GetResourceSubscription checks that the resource subscription is registered with the hardware manager
+func (c *HardwareManagerClient) GetResourceSubscription(ctx context.Context, subscriptionId string) error {
+	response, err := c.HwmgrClient.GetResourceSubscriptionWithResponse(ctx, c.GetTenant(), subscriptionId)
+	if err != nil {
+		return fmt.Errorf("failed to get resource subscription %s: response: %v, err: %w", subscriptionId, response, err)
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf("resource subscription %s query failed with status %s (%d), message=%s",
+			subscriptionId, response.Status(), response.StatusCode(), string(response.Body))
+	}
+
+	return nil
+}
+
+// UnsubscribeResources removes the resources from the resource subscription
+func (c *HardwareManagerClient) UnsubscribeResources(ctx context.Context, subscriptionId string, resourceIds []string) error {
+	if len(resourceIds) == 0 {
+		return nil
+	}
+	body := hwmgrapi.UnsubscribeResourcesJSONRequestBody{
+		Id:        &subscriptionId,
+		Resources: &resourceIds,
+	}
+	response, err := c.HwmgrClient.UnsubscribeResourcesWithResponse(ctx, c.GetTenant(), body)
+	if err != nil {
+		return fmt.Errorf("failed to unsubscribe resources from %s: response: %v, err: %w", subscriptionId, response, err)
+	}
+
+	// The resources may already have been removed, such as after a retried release
+	if response.StatusCode() != http.StatusOK && response.StatusCode() != http.StatusNotFound {
+		return fmt.Errorf("resource unsubscription %s failed with status %s (%d), message=%s",
+			subscriptionId, response.Status(), response.StatusCode(), string(response.Body))
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"fmt"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+)
+
+// Listings of resource pools, resources and servers are fetched a page at a time, so that inventories with thousands
+// of servers are fully enumerated without relying on the hardware manager to return them in a single response.
+const (
+	listPageSize = 500
+
+	// maxListPages bounds the number of pages fetched for a listing, in case the hardware manager keeps returning full
+	// pages
+	maxListPages = 1000
+)
+
+// fetchAllPages calls fetch for each page of a listing, with the zero-based index of the page, returning the items of
+// all pages. fetch returns the items of the page along with the total number of items reported by the hardware
+// manager, or -1 when not reported. The last page is the first to hold fewer or more items than the page size, the
+// latter meaning the hardware manager ignored the requested page size and returned everything, or the page reaching
+// the reported total.
+//
+// Items are identified by id, so that a hardware manager ignoring the requested offset cannot cause an endless listing
+// of the same items: items already fetched are dropped, and the listing ends at the first page holding no new items.
+// Items without an identifier are always kept.
+func fetchAllPages[T any](ctx context.Context, pageSize int, id func(item T) *string,
+	fetch func(ctx context.Context, index int) ([]T, int64, error)) ([]T, error) {
+	items := []T{}
+	seen := make(map[string]bool)
+	fetched := int64(0)
+	for index := 0; index < maxListPages; index++ {
+		page, total, err := fetch(ctx, index)
+		if err != nil {
+			return nil, err
+		}
+		fetched += int64(len(page))
+
+		added := 0
+		for _, item := range page {
+			if key := id(item); key != nil {
+				if seen[*key] {
+					continue
+				}
+				seen[*key] = true
+			}
+			items = append(items, item)
+			added++
+		}
+
+		if len(page) != pageSize || added == 0 || (total >= 0 && fetched >= total) {
+			return items, nil
+		}
+	}
+	return nil, fmt.Errorf("listing exceeded %d pages of %d items", maxListPages, pageSize)
+}
+
+// paginationTotal returns the total number of items reported in the pagination of a response, or -1
+func paginationTotal(pagination *hwmgrapi.ApiprotoPagination) int64 {
+	if pagination == nil || pagination.Total == nil {
+		return -1
+	}
+	return *pagination.Total
+}
@@ -0,0 +1,98 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func itemId(item string) *string {
+	return &item
+}
+
+// pageItems returns the items of a page of a listing of the given size, identified by their position
+func pageItems(index, pageSize, items int) []string {
+	page := []string{}
+	for i := index * pageSize; i < min(items, (index+1)*pageSize); i++ {
+		page = append(page, strconv.Itoa(i))
+	}
+	return page
+}
+
+func TestFetchAllPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    int
+		total    int64
+		pageSize int
+		expected int
+	}{
+		{name: "partial last page", items: 25, total: -1, pageSize: 10, expected: 3},
+		{name: "full last page without total", items: 20, total: -1, pageSize: 10, expected: 3},
+		{name: "full last page with total", items: 20, total: 20, pageSize: 10, expected: 2},
+		{name: "empty listing", items: 0, total: 0, pageSize: 10, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			items, err := fetchAllPages(context.Background(), tt.pageSize, itemId, func(_ context.Context, index int) ([]string, int64, error) {
+				calls++
+				return pageItems(index, tt.pageSize, tt.items), tt.total, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if calls != tt.expected {
+				t.Errorf("expected %d pages to be fetched, got %d", tt.expected, calls)
+			}
+			if len(items) != tt.items {
+				t.Errorf("expected %d items, got %d", tt.items, len(items))
+			}
+		})
+	}
+}
+
+func TestFetchAllPagesIgnoredPagination(t *testing.T) {
+	// A hardware manager ignoring the page size returns everything in the first page
+	calls := 0
+	items, err := fetchAllPages(context.Background(), 10, itemId, func(_ context.Context, _ int) ([]string, int64, error) {
+		calls++
+		return pageItems(0, 25, 25), -1, nil
+	})
+	if err != nil || calls != 1 || len(items) != 25 {
+		t.Errorf("expected a single page of 25 items, got %d calls, %d items, err=%v", calls, len(items), err)
+	}
+
+	// A hardware manager ignoring the offset returns the first page again, ending the listing
+	calls = 0
+	items, err = fetchAllPages(context.Background(), 10, itemId, func(_ context.Context, _ int) ([]string, int64, error) {
+		calls++
+		return pageItems(0, 10, 100), -1, nil
+	})
+	if err != nil || calls != 2 || len(items) != 10 {
+		t.Errorf("expected the listing to end on the repeated page, got %d calls, %d items, err=%v", calls, len(items), err)
+	}
+
+	// Overlapping pages keep the new items only
+	items, err = fetchAllPages(context.Background(), 10, itemId, func(_ context.Context, index int) ([]string, int64, error) {
+		return pageItems(0, 5*(index+1)+5, 15)[max(0, 5*index-5):], 15, nil
+	})
+	if err != nil || len(items) != 15 {
+		t.Errorf("expected 15 distinct items, got %v, err=%v", items, err)
+	}
+
+	// A hardware manager returning new full pages forever is bounded
+	_, err = fetchAllPages(context.Background(), 10, itemId, func(_ context.Context, index int) ([]string, int64, error) {
+		return pageItems(index, 10, (index+2)*10), -1, nil
+	})
+	if err == nil {
+		t.Errorf("expected an error when the listing never ends")
+	}
+}
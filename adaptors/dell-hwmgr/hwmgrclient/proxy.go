@@ -0,0 +1,136 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// proxySettings holds the parsed proxy settings used to reach a hardware manager
+type proxySettings struct {
+	httpProxy  *url.URL
+	httpsProxy *url.URL
+	noProxy    []string
+}
+
+// getEnv returns the value of the first set environment variable, checking the upper case name before the lower case
+func getEnv(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return os.Getenv(strings.ToLower(name))
+}
+
+// parseProxyURL parses a proxy URL, defaulting to the http scheme when none is given
+func parseProxyURL(value string) (*url.URL, error) {
+	if value == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(value)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		// Accept a bare host:port, as done for the environment variables
+		if proxyURL, err = url.Parse("http://" + value); err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", value, err)
+		}
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: unsupported scheme %s", value, proxyURL.Scheme)
+	}
+	return proxyURL, nil
+}
+
+// getProxySettings returns the proxy settings of the HardwareManager, with any unset setting taken from the environment
+func getProxySettings(hwmgr *pluginv1alpha1.HardwareManager) (*proxySettings, error) {
+	var config pluginv1alpha1.ProxyConfig
+	if hwmgr.Spec.DellData.Proxy != nil {
+		config = *hwmgr.Spec.DellData.Proxy
+	}
+	if config.HTTPProxy == "" {
+		config.HTTPProxy = getEnv("HTTP_PROXY")
+	}
+	if config.HTTPSProxy == "" {
+		config.HTTPSProxy = getEnv("HTTPS_PROXY")
+	}
+	if config.NoProxy == "" {
+		config.NoProxy = getEnv("NO_PROXY")
+	}
+
+	var settings proxySettings
+	var err error
+	if settings.httpProxy, err = parseProxyURL(config.HTTPProxy); err != nil {
+		return nil, err
+	}
+	if settings.httpsProxy, err = parseProxyURL(config.HTTPSProxy); err != nil {
+		return nil, err
+	}
+	for _, entry := range strings.Split(config.NoProxy, ",") {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			settings.noProxy = append(settings.noProxy, entry)
+		}
+	}
+	return &settings, nil
+}
+
+// bypass returns true if the host, without its port, is reached directly. Loopback addresses are never proxied.
+func (s *proxySettings) bypass(host string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	for _, entry := range s.noProxy {
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		// Ignore any port in the entry, as the proxy is selected by host
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		if ip != nil {
+			if entryIP := net.ParseIP(entry); entryIP != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		// A domain matches itself and its subdomains, with or without a leading dot
+		domain := strings.TrimPrefix(strings.TrimPrefix(entry, "*"), ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// Proxy returns the proxy to use for the request, or nil if it is sent directly
+func (s *proxySettings) Proxy(req *http.Request) (*url.URL, error) {
+	if s.bypass(req.URL.Hostname()) {
+		return nil, nil
+	}
+	if req.URL.Scheme == "https" {
+		return s.httpsProxy, nil
+	}
+	return s.httpProxy, nil
+}
@@ -0,0 +1,55 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/relay"
+)
+
+// relayTokenKey is the key of the relay auth secret holding the token
+const relayTokenKey = "token"
+
+// apiURL returns the URL that requests to the hardware manager are sent to, which is the relay agent when configured
+func apiURL(hwmgr *pluginv1alpha1.HardwareManager) string {
+	if hwmgr.Spec.DellData.Relay != nil {
+		return hwmgr.Spec.DellData.Relay.URL
+	}
+	return hwmgr.Spec.DellData.ApiUrl
+}
+
+// getRelayToken gets the token authenticating the plugin to the relay agent
+func getRelayToken(ctx context.Context, rtclient client.Client, hwmgr *pluginv1alpha1.HardwareManager) (string, error) {
+	secret, err := utils.GetSecret(ctx, rtclient, hwmgr.Spec.DellData.Relay.AuthSecret, hwmgr.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get relay secret: %w", err)
+	}
+	token, err := utils.GetSecretField(secret, relayTokenKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s from relay secret: %s, %w", relayTokenKey, hwmgr.Spec.DellData.Relay.AuthSecret, err)
+	}
+	return token, nil
+}
+
+// relayTokenTransport authenticates requests to the relay agent
+type relayTokenTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t *relayTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(relay.TokenHeader, t.token)
+	return t.base.RoundTrip(req) // nolint: wrapcheck
+}
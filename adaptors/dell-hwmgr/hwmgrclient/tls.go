@@ -0,0 +1,119 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// defaultCaBundleKey is the key holding the CA bundle in the referenced config map or secret, unless overridden
+const defaultCaBundleKey = "ca-bundle.pem"
+
+// getTLSSettings returns the CA bundle and client certificate to use when connecting to the hardware manager, along
+// with whether TLS verification is skipped. The tls section takes precedence over the caBundleName and
+// insecureSkipTLSVerify fields, which are used when it is unset.
+func getTLSSettings(ctx context.Context, rtclient client.Client, hwmgr *pluginv1alpha1.HardwareManager) (
+	utils.OAuthClientConfig, bool, error) {
+	var config utils.OAuthClientConfig
+
+	tlsConfig := hwmgr.Spec.DellData.TLS
+	if tlsConfig == nil {
+		if hwmgr.Spec.DellData.CaBundleName != nil {
+			caBundle, err := getCaBundle(ctx, rtclient, hwmgr.Namespace, &pluginv1alpha1.CaBundleReference{
+				ConfigMapName: hwmgr.Spec.DellData.CaBundleName,
+			})
+			if err != nil {
+				return config, false, err
+			}
+			config.CaBundle = caBundle
+		}
+		return config, hwmgr.Spec.DellData.InsecureSkipTLSVerify, nil
+	}
+
+	if tlsConfig.CaBundle != nil {
+		caBundle, err := getCaBundle(ctx, rtclient, hwmgr.Namespace, tlsConfig.CaBundle)
+		if err != nil {
+			return config, false, err
+		}
+		config.CaBundle = caBundle
+	}
+
+	if tlsConfig.ClientCertificateSecret != nil {
+		cert, err := getClientCertificate(ctx, rtclient, hwmgr.Namespace, *tlsConfig.ClientCertificateSecret)
+		if err != nil {
+			return config, false, err
+		}
+		config.ClientCert = cert
+	}
+
+	return config, tlsConfig.InsecureSkipVerify, nil
+}
+
+// getCaBundle gets the CA bundle from the referenced config map or secret
+func getCaBundle(ctx context.Context, rtclient client.Client, namespace string, ref *pluginv1alpha1.CaBundleReference) ([]byte, error) {
+	key := ref.Key
+	if key == "" {
+		key = defaultCaBundleKey
+	}
+
+	switch {
+	case ref.ConfigMapName != nil && ref.SecretName != nil:
+		return nil, fmt.Errorf("only one of configMapName and secretName may be set for the CA bundle")
+	case ref.ConfigMapName != nil:
+		cm, err := utils.GetConfigmap(ctx, rtclient, *ref.ConfigMapName, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap: %w", err)
+		}
+		caBundle, err := utils.GetConfigMapField(cm, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get certificate bundle from configmap: %w", err)
+		}
+		return []byte(caBundle), nil
+	case ref.SecretName != nil:
+		secret, err := utils.GetSecret(ctx, rtclient, *ref.SecretName, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CA bundle secret: %w", err)
+		}
+		caBundle, err := utils.GetSecretField(secret, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get certificate bundle from secret: %w", err)
+		}
+		return []byte(caBundle), nil
+	default:
+		return nil, fmt.Errorf("one of configMapName or secretName must be set for the CA bundle")
+	}
+}
+
+// getClientCertificate loads the client certificate and key from a kubernetes.io/tls secret
+func getClientCertificate(ctx context.Context, rtclient client.Client, namespace, name string) (*tls.Certificate, error) {
+	secret, err := utils.GetSecret(ctx, rtclient, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client certificate secret: %w", err)
+	}
+	certPEM, err := utils.GetSecretField(secret, corev1.TLSCertKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client certificate: %w", err)
+	}
+	keyPEM, err := utils.GetSecretField(secret, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client certificate key: %w", err)
+	}
+
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate from secret %s: %w", name, err)
+	}
+	return &cert, nil
+}
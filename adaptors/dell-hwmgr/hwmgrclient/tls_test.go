@@ -0,0 +1,119 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// objectsClient serves a fixed set of config maps and secrets by name
+type objectsClient struct {
+	client.Client
+	configMaps map[string]map[string]string
+	secrets    map[string]map[string][]byte
+}
+
+func (c *objectsClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		if data, exists := c.configMaps[key.Name]; exists {
+			o.Name, o.Data = key.Name, data
+			return nil
+		}
+	case *corev1.Secret:
+		if data, exists := c.secrets[key.Name]; exists {
+			o.Name, o.Data = key.Name, data
+			return nil
+		}
+	}
+	return errors.NewNotFound(schema.GroupResource{}, key.Name)
+}
+
+// testCertificate returns a self-signed certificate and key in PEM format
+func testCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "oran-hwmgr-plugin"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestGetTLSSettings(t *testing.T) {
+	certPEM, keyPEM := testCertificate(t)
+	rtclient := &objectsClient{
+		configMaps: map[string]map[string]string{"legacy-ca": {defaultCaBundleKey: "legacy"}},
+		secrets: map[string]map[string][]byte{
+			"private-ca":  {"ca.crt": certPEM},
+			"client-cert": {corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		},
+	}
+	hwmgr := &pluginv1alpha1.HardwareManager{Spec: pluginv1alpha1.HardwareManagerSpec{
+		DellData: &pluginv1alpha1.DellData{CaBundleName: ptr.To("legacy-ca"), InsecureSkipTLSVerify: true},
+	}}
+
+	config, insecure, err := getTLSSettings(context.Background(), rtclient, hwmgr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(config.CaBundle) != "legacy" || config.ClientCert != nil || !insecure {
+		t.Errorf("expected the legacy settings to be used without a tls section, got %+v, %v", config, insecure)
+	}
+
+	hwmgr.Spec.DellData.TLS = &pluginv1alpha1.TLSConfig{
+		CaBundle:                &pluginv1alpha1.CaBundleReference{SecretName: ptr.To("private-ca"), Key: "ca.crt"},
+		ClientCertificateSecret: ptr.To("client-cert"),
+	}
+	config, insecure, err = getTLSSettings(context.Background(), rtclient, hwmgr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(config.CaBundle) != string(certPEM) || config.ClientCert == nil || insecure {
+		t.Errorf("expected the tls section to take precedence, got %+v, %v", config, insecure)
+	}
+
+	hwmgr.Spec.DellData.TLS.CaBundle.ConfigMapName = ptr.To("legacy-ca")
+	if _, _, err := getTLSSettings(context.Background(), rtclient, hwmgr); err == nil {
+		t.Errorf("expected an error when both a config map and secret are referenced")
+	}
+
+	hwmgr.Spec.DellData.TLS = &pluginv1alpha1.TLSConfig{ClientCertificateSecret: ptr.To("private-ca")}
+	if _, _, err := getTLSSettings(context.Background(), rtclient, hwmgr); err == nil {
+		t.Errorf("expected an error when the client certificate secret has no key")
+	}
+}
@@ -0,0 +1,316 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/relay"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// In multi-replica deployments, each replica serves the inventory API and so needs a token for the hardware manager.
+// As requesting a new token may invalidate the session of a token held by another replica, the token is shared
+// through a Secret, and a Lease ensures that only one replica at a time requests a new token. A replica taking over
+// after a failover can then reuse the cached token immediately, as long as it is still valid.
+const (
+	tokenCacheTokenKey      = "token"
+	tokenCacheExpiryKey     = "expiry"
+	tokenCacheGenerationKey = "generation"
+
+	// tokenLeaseDuration bounds how long a replica may hold the lease while requesting a token
+	tokenLeaseDuration = 30 * time.Second
+	// tokenExpiryMargin is subtracted from the token lifetime, so that a token is not used just before it expires
+	tokenExpiryMargin = 60 * time.Second
+	// tokenWaitInterval is the polling interval while waiting for another replica to refresh the token
+	tokenWaitInterval = time.Second
+	// defaultTokenLifetime is assumed if the hardware manager does not report the token lifetime
+	defaultTokenLifetime = 5 * time.Minute
+)
+
+// cachedToken is a token held in memory by this replica, along with the HardwareManager generation it was requested for
+type cachedToken struct {
+	token      string
+	expiry     time.Time
+	generation int64
+}
+
+// localTokenCache holds the shared token of each HardwareManager in memory, keyed by the HardwareManager UID, so that
+// clients created on every reconcile do not read the shared Secret for each request
+type localTokenCache struct {
+	mu     sync.Mutex
+	tokens map[types.UID]cachedToken
+}
+
+func newLocalTokenCache() *localTokenCache {
+	return &localTokenCache{tokens: make(map[types.UID]cachedToken)}
+}
+
+var localTokens = newLocalTokenCache()
+
+// get returns the token cached for a HardwareManager, if it is not about to expire and was requested for the current
+// generation of the HardwareManager at the given time
+func (l *localTokenCache) get(uid types.UID, generation int64, now time.Time) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cached, exists := l.tokens[uid]
+	if !exists || cached.generation != generation || now.Add(tokenExpiryMargin).After(cached.expiry) {
+		return "", false
+	}
+	return cached.token, true
+}
+
+func (l *localTokenCache) set(uid types.UID, generation int64, token string, expiry time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens[uid] = cachedToken{token: token, expiry: expiry, generation: generation}
+}
+
+// invalidate drops the token cached for a HardwareManager, if it is still the given token
+func (l *localTokenCache) invalidate(uid types.UID, token string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cached, exists := l.tokens[uid]; exists && cached.token == token {
+		delete(l.tokens, uid)
+	}
+}
+
+// tokenCacheName returns the name of the Secret and Lease used to share the token of a HardwareManager
+func (c *HardwareManagerClient) tokenCacheName() string {
+	return fmt.Sprintf("%s-token-cache", c.hwmgr.Name)
+}
+
+// tokenHolderIdentity identifies this replica as the holder of the token lease
+func tokenHolderIdentity() string {
+	if name := os.Getenv("MY_POD_NAME"); name != "" {
+		return name
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// getCachedToken returns the shared token, if one exists that is not about to expire. A token requested for an older
+// generation of the HardwareManager is ignored, as the endpoint or credentials may have changed since.
+func (c *HardwareManagerClient) getCachedToken(ctx context.Context) (string, bool) {
+	if token, ok := localTokens.get(c.hwmgr.UID, c.hwmgr.Generation, c.clock().Now()); ok {
+		return token, true
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.rtclient.Get(ctx, types.NamespacedName{Name: c.tokenCacheName(), Namespace: c.Namespace}, secret); err != nil {
+		return "", false
+	}
+
+	token := string(secret.Data[tokenCacheTokenKey])
+	expiry, err := time.Parse(time.RFC3339, string(secret.Data[tokenCacheExpiryKey]))
+	if token == "" || err != nil || c.clock().Now().Add(tokenExpiryMargin).After(expiry) {
+		return "", false
+	}
+	if string(secret.Data[tokenCacheGenerationKey]) != strconv.FormatInt(c.hwmgr.Generation, 10) {
+		c.Logger.InfoContext(ctx, "Ignoring token cached for a previous HardwareManager generation")
+		return "", false
+	}
+	localTokens.set(c.hwmgr.UID, c.hwmgr.Generation, token, expiry)
+	return token, true
+}
+
+// storeToken saves the token in the shared Secret, owned by the HardwareManager
+func (c *HardwareManagerClient) storeToken(ctx context.Context, token string, expiry time.Time) error {
+	localTokens.set(c.hwmgr.UID, c.hwmgr.Generation, token, expiry)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.tokenCacheName(),
+			Namespace: c.Namespace,
+		},
+		Data: map[string][]byte{
+			tokenCacheTokenKey:      []byte(token),
+			tokenCacheExpiryKey:     []byte(expiry.UTC().Format(time.RFC3339)),
+			tokenCacheGenerationKey: []byte(strconv.FormatInt(c.hwmgr.Generation, 10)),
+		},
+	}
+
+	if err := utils.CreateOrUpdateK8sCR(ctx, c.rtclient, secret, c.hwmgr, utils.UPDATE); err != nil {
+		return fmt.Errorf("failed to store token for %s: %w", c.hwmgr.Name, err)
+	}
+	return nil
+}
+
+// invalidateCachedToken removes the shared token, if it is still the given token, so that a new one is requested
+func (c *HardwareManagerClient) invalidateCachedToken(ctx context.Context, token string) {
+	localTokens.invalidate(c.hwmgr.UID, token)
+	if cached, ok := c.getCachedToken(ctx); !ok || cached != token {
+		return
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: c.tokenCacheName(), Namespace: c.Namespace},
+	}
+	if err := c.rtclient.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+		c.Logger.WarnContext(ctx, "Failed to invalidate cached token", slog.String("error", err.Error()))
+	}
+}
+
+// acquireTokenLease attempts to take the token lease, returning the current holder if held by another replica
+func (c *HardwareManagerClient) acquireTokenLease(ctx context.Context) (bool, string, error) {
+	identity := tokenHolderIdentity()
+	now := metav1.NewMicroTime(c.clock().Now())
+	leaseSpec := coordinationv1.LeaseSpec{
+		HolderIdentity:       &identity,
+		LeaseDurationSeconds: ptr.To(int32(tokenLeaseDuration.Seconds())),
+		AcquireTime:          &now,
+		RenewTime:            &now,
+	}
+
+	lease := &coordinationv1.Lease{}
+	err := c.rtclient.Get(ctx, types.NamespacedName{Name: c.tokenCacheName(), Namespace: c.Namespace}, lease)
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: c.tokenCacheName(), Namespace: c.Namespace},
+			Spec:       leaseSpec,
+		}
+		if err := c.rtclient.Create(ctx, lease); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return false, "", nil
+			}
+			return false, "", fmt.Errorf("failed to create token lease: %w", err)
+		}
+		return true, identity, nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get token lease: %w", err)
+	}
+
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder != "" && holder != identity && lease.Spec.RenewTime != nil && lease.Spec.LeaseDurationSeconds != nil &&
+		c.clock().Since(lease.Spec.RenewTime.Time) < time.Duration(*lease.Spec.LeaseDurationSeconds)*time.Second {
+		return false, holder, nil
+	}
+
+	// The lease is free or expired. The update fails with a conflict if another replica took it in the meantime.
+	lease.Spec = leaseSpec
+	if err := c.rtclient.Update(ctx, lease); err != nil {
+		if errors.IsConflict(err) {
+			return false, "", nil
+		}
+		return false, "", fmt.Errorf("failed to update token lease: %w", err)
+	}
+	return true, identity, nil
+}
+
+// releaseTokenLease releases the token lease held by this replica
+func (c *HardwareManagerClient) releaseTokenLease(ctx context.Context) {
+	lease := &coordinationv1.Lease{}
+	if err := c.rtclient.Get(ctx, types.NamespacedName{Name: c.tokenCacheName(), Namespace: c.Namespace}, lease); err != nil {
+		return
+	}
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != tokenHolderIdentity() {
+		return
+	}
+
+	lease.Spec.HolderIdentity = nil
+	if err := c.rtclient.Update(ctx, lease); err != nil {
+		c.Logger.InfoContext(ctx, "Failed to release token lease", slog.String("error", err.Error()))
+	}
+}
+
+// GetSharedToken returns the token shared by all replicas, requesting a new one from the hardware manager if the
+// cached token is missing or within tokenExpiryMargin of its expiry, so that it is refreshed before it expires. While another replica is requesting a token, it waits for that token
+// rather than requesting one of its own.
+func (c *HardwareManagerClient) GetSharedToken(ctx context.Context) (string, error) {
+	if token, ok := c.getCachedToken(ctx); ok {
+		return token, nil
+	}
+
+	deadline := c.clock().Now().Add(tokenLeaseDuration)
+	for {
+		acquired, holder, err := c.acquireTokenLease(ctx)
+		if err != nil {
+			return "", err
+		}
+		if acquired {
+			break
+		}
+
+		if c.clock().Now().After(deadline) {
+			return "", typederrors.NewRetriableError(nil, "timed out waiting for token refresh by %s", holder)
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("interrupted waiting for token refresh by %s: %w", holder, ctx.Err())
+		case <-c.clock().After(tokenWaitInterval):
+		}
+		if token, ok := c.getCachedToken(ctx); ok {
+			return token, nil
+		}
+	}
+	defer c.releaseTokenLease(ctx)
+
+	// Another replica may have refreshed the token before the lease was acquired
+	if token, ok := c.getCachedToken(ctx); ok {
+		return token, nil
+	}
+
+	token, lifetime, err := c.requestToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := c.storeToken(ctx, token, c.clock().Now().Add(lifetime)); err != nil {
+		// The token is still usable by this replica
+		c.Logger.WarnContext(ctx, "Failed to share token", slog.String("error", err.Error()))
+	}
+	return token, nil
+}
+
+// tokenInvalidatingTransport drops the shared token when the hardware manager rejects it, so that the next client
+// requests a new one rather than reusing a token whose session has ended. A rejection by the relay agent, if any, is
+// about the relay token, and leaves the token of the hardware manager in place.
+type tokenInvalidatingTransport struct {
+	base   http.RoundTripper
+	client *HardwareManagerClient
+}
+
+func (t *tokenInvalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		if resp.Header.Get(relay.ErrorHeader) != "" {
+			t.client.Logger.WarnContext(req.Context(), "Request rejected by the relay agent, check the relay token",
+				slog.String("hwmgr", t.client.hwmgr.Name))
+		} else if token, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); found {
+			t.client.invalidateCachedToken(req.Context(), token)
+		}
+	}
+	return resp, err // nolint: wrapcheck
+}
+
+// bearerToken sets the Authorization header of each request to the shared token, so that a client used for a long
+// operation picks up a refreshed token rather than sending one that has expired since the client was created
+func (c *HardwareManagerClient) bearerToken(ctx context.Context, req *http.Request) error {
+	token, err := c.GetSharedToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get token for %s: %w", c.hwmgr.Name, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
@@ -0,0 +1,259 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwmgrclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/relay"
+)
+
+func TestLocalTokenCache(t *testing.T) {
+	now := time.Now()
+	cache := newLocalTokenCache()
+
+	if _, ok := cache.get("uid", 1, now); ok {
+		t.Fatalf("expected no token in an empty cache")
+	}
+
+	cache.set("uid", 1, "token-1", now.Add(5*time.Minute))
+	if token, ok := cache.get("uid", 1, now); !ok || token != "token-1" {
+		t.Errorf("expected cached token, got %q (found=%t)", token, ok)
+	}
+	if _, ok := cache.get("uid", 2, now); ok {
+		t.Errorf("expected token of a previous generation to be ignored")
+	}
+	if _, ok := cache.get("other", 1, now); ok {
+		t.Errorf("expected no token for another HardwareManager")
+	}
+
+	// The token is refreshed ahead of its expiry
+	now = now.Add(5*time.Minute - tokenExpiryMargin + time.Second)
+	if _, ok := cache.get("uid", 1, now); ok {
+		t.Errorf("expected token about to expire to be ignored")
+	}
+
+	cache.set("uid", 1, "token-2", now.Add(5*time.Minute))
+	cache.invalidate("uid", "token-1")
+	if token, ok := cache.get("uid", 1, now); !ok || token != "token-2" {
+		t.Errorf("expected invalidation of a previous token to keep the current one, got %q (found=%t)", token, ok)
+	}
+	cache.invalidate("uid", "token-2")
+	if _, ok := cache.get("uid", 1, now); ok {
+		t.Errorf("expected invalidated token to be dropped")
+	}
+}
+
+// memoryClient stores the objects it is given in memory, keyed by type and name
+type memoryClient struct {
+	client.Client
+	scheme  *runtime.Scheme
+	objects map[string]client.Object
+}
+
+func newMemoryClient(t *testing.T, objects ...client.Object) *memoryClient {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	if err := pluginv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := &memoryClient{scheme: scheme, objects: make(map[string]client.Object)}
+	for _, obj := range objects {
+		c.objects[memoryKey(obj, obj.GetName())] = obj
+	}
+	return c
+}
+
+func memoryKey(obj client.Object, name string) string {
+	return fmt.Sprintf("%T/%s", obj, name)
+}
+
+func (c *memoryClient) Scheme() *runtime.Scheme {
+	return c.scheme
+}
+
+func (c *memoryClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	stored, exists := c.objects[memoryKey(obj, key.Name)]
+	if !exists {
+		return errors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(stored.DeepCopyObject()).Elem())
+	return nil
+}
+
+func (c *memoryClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	if _, exists := c.objects[memoryKey(obj, obj.GetName())]; exists {
+		return errors.NewAlreadyExists(schema.GroupResource{}, obj.GetName())
+	}
+	c.objects[memoryKey(obj, obj.GetName())] = obj.DeepCopyObject().(client.Object)
+	return nil
+}
+
+func (c *memoryClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.objects[memoryKey(obj, obj.GetName())] = obj.DeepCopyObject().(client.Object)
+	return nil
+}
+
+func (c *memoryClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	delete(c.objects, memoryKey(obj, obj.GetName()))
+	return nil
+}
+
+func TestAcquireTokenLeaseExpiry(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := clocktesting.NewFakeClock(start)
+	renewed := metav1.NewMicroTime(start)
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1-token-cache", Namespace: "hwmgr"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("other-replica"),
+			LeaseDurationSeconds: ptr.To(int32(tokenLeaseDuration.Seconds())),
+			RenewTime:            &renewed,
+		},
+	}
+	c := &HardwareManagerClient{
+		rtclient:  newMemoryClient(t, lease),
+		Logger:    slog.Default(),
+		Namespace: "hwmgr",
+		hwmgr:     &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1"}},
+		Clock:     clock,
+	}
+
+	clock.Step(tokenLeaseDuration - time.Second)
+	if acquired, holder, err := c.acquireTokenLease(context.Background()); err != nil || acquired || holder != "other-replica" {
+		t.Errorf("expected the lease to be held by the other replica, got %t, %q, %v", acquired, holder, err)
+	}
+
+	clock.Step(2 * time.Second)
+	if acquired, _, err := c.acquireTokenLease(context.Background()); err != nil || !acquired {
+		t.Errorf("expected the expired lease to be taken over, got %t, %v", acquired, err)
+	}
+}
+
+func TestRequestWithColdTokenCache(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/token/create") {
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("expected token request without a bearer token")
+			}
+			count := tokenRequests.Add(1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":300}`, count)
+			return
+		}
+		if auth := r.Header.Get("Authorization"); !strings.HasPrefix(auth, "Bearer token-") {
+			t.Errorf("expected request with a bearer token, got %q", auth)
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	hwmgr := &pluginv1alpha1.HardwareManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "dell-1", Namespace: "hwmgr", UID: "cold-cache-uid"},
+		Spec: pluginv1alpha1.HardwareManagerSpec{
+			AdaptorID: pluginv1alpha1.SupportedAdaptors.Dell,
+			DellData: &pluginv1alpha1.DellData{AuthSecret: "dell-auth", ApiUrl: server.URL,
+				InsecureSkipTLSVerify: true},
+		},
+	}
+	rtclient := newMemoryClient(t, hwmgr, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "dell-auth", Namespace: "hwmgr"},
+		Data: map[string][]byte{
+			"client-id":                 []byte("client"),
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte("password"),
+		},
+	})
+
+	ctx := context.Background()
+	hwmgrClient, err := NewClientWithResponses(ctx, slog.Default(), rtclient, hwmgr)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	// Drop the token from both caches, so that the next request has to get a new one
+	localTokens.invalidate(hwmgr.UID, "token-1")
+	delete(rtclient.objects, memoryKey(&corev1.Secret{}, hwmgrClient.tokenCacheName()))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := hwmgrClient.GetResourceGroups(ctx); err != nil {
+		t.Fatalf("request with a cold token cache failed: %v", err)
+	}
+	if count := tokenRequests.Load(); count != 2 {
+		t.Errorf("expected 2 token requests, got %d", count)
+	}
+}
+
+func TestTokenInvalidatingTransport(t *testing.T) {
+	testcases := []struct {
+		name        string
+		status      int
+		relayError  bool
+		invalidated bool
+	}{
+		{name: "accepted", status: http.StatusOK},
+		{name: "rejected by the hardware manager", status: http.StatusUnauthorized, invalidated: true},
+		{name: "rejected by the relay", status: http.StatusUnauthorized, relayError: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			hwmgr := &pluginv1alpha1.HardwareManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "dell-1", Namespace: "hwmgr", UID: types.UID("invalidating-" + tc.name)},
+			}
+			c := &HardwareManagerClient{rtclient: newMemoryClient(t, hwmgr), Logger: slog.Default(), Namespace: "hwmgr", hwmgr: hwmgr}
+			localTokens.set(hwmgr.UID, hwmgr.Generation, "token-1", time.Now().Add(time.Hour))
+			defer localTokens.invalidate(hwmgr.UID, "token-1")
+
+			transport := &tokenInvalidatingTransport{
+				base: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+					resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+					if tc.relayError {
+						resp.Header.Set(relay.ErrorHeader, "unauthorized")
+					}
+					return resp, nil
+				}),
+				client: c,
+			}
+			req := httptest.NewRequest(http.MethodGet, "https://hwmgr.example.com/v1/tenants/default/resources", nil)
+			req.Header.Set("Authorization", "Bearer token-1")
+			if _, err := transport.RoundTrip(req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, cached := localTokens.get(hwmgr.UID, hwmgr.Generation, time.Now())
+			if cached == tc.invalidated {
+				t.Errorf("expected invalidated=%t, got cached=%t", tc.invalidated, cached)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
@@ -168,6 +169,25 @@ func getResourceInfoProcessors(server *hwmgrapi.ApiprotoServer) []invserver.Proc
 	return processors
 }
 
+// getResourceInfoCpuArchitecture returns the architecture of the first processor, preferring the instruction set as
+// the Redfish processor architecture does not distinguish 32 and 64 bit variants
+func getResourceInfoCpuArchitecture(server *hwmgrapi.ApiprotoServer) *string {
+	if server == nil || server.Status == nil || server.Status.Processors == nil {
+		return nil
+	}
+	for _, processor := range *server.Status.Processors {
+		for _, value := range []*string{processor.InstructionSet, processor.ProcessorArchitecture} {
+			if value == nil {
+				continue
+			}
+			if arch := utils.NormalizeCPUArchitecture(*value); arch != "" {
+				return &arch
+			}
+		}
+	}
+	return nil
+}
+
 func getResourceInfoResourceId(resource hwmgrapi.ApiprotoResource) string {
 	if resource.Res == nil || resource.Res.Id == nil {
 		return ""
@@ -227,6 +247,7 @@ func getResourceInfoVendor(server *hwmgrapi.ApiprotoServer) string {
 func getResourceInfo(resource hwmgrapi.ApiprotoResource, server *hwmgrapi.ApiprotoServer) invserver.ResourceInfo {
 	return invserver.ResourceInfo{
 		AdminState:       getResourceInfoAdminState(resource),
+		CpuArchitecture:  getResourceInfoCpuArchitecture(server),
 		Description:      getResourceInfoDescription(resource),
 		GlobalAssetId:    getResourceInfoGlobalAssetId(resource),
 		Groups:           getResourceInfoGroups(resource),
@@ -346,9 +367,13 @@ func findMatchingPool(
 	allocatedServers []string,
 	resources *hwmgrapi.ApiprotoGetResourcesResp,
 	resourceSelectors map[string]string,
+	site string,
 	numServers int) string {
 
 	for _, pool := range *pools.ResourcePools {
+		if !poolInSite(pool, site) {
+			continue
+		}
 		freeServers := findFreeServersInPool(allocatedServers, resources, resourceSelectors, *pool.Id)
 		if len(freeServers) >= numServers {
 			return *pool.Id
@@ -358,17 +383,17 @@ func findMatchingPool(
 	return ""
 }
 
-func poolExists(
+func findPool(
 	pools *hwmgrapi.ApiprotoResourcePoolsResp,
-	pool string) bool {
+	pool string) *hwmgrapi.ApiprotoResourcePool {
 
 	for _, iter := range *pools.ResourcePools {
 		if iter.Id != nil && *iter.Id == pool {
-			return true
+			return &iter
 		}
 	}
 
-	return false
+	return nil
 }
 
 // FindResourcePoolId checks the hardware manager inventory to find a pool with free resources that match the criteria
@@ -417,13 +442,26 @@ func (a *Adaptor) FindResourcePoolIds(
 			}
 		}
 
+		// Constrain the servers by the rack and tags, and the pools by the site, requested in the extensions
+		filters, err := getResourceFilters(nodepool, nodegroup.NodePoolData.Name)
+		if err != nil {
+			return typederrors.NewNonRetriableError(err, "invalid resource filters for nodegroup: %s", nodegroup.NodePoolData.Name)
+		}
+		for key, value := range hwmgrclient.ResourceFilterLabels(filters) {
+			resourceSelectors[key] = value
+		}
+
 		if nodegroup.NodePoolData.ResourcePoolId != "" {
 			// There's a pool specified in the nodegroup, so use it
 
 			// Check whether the pool exists on hardware manager
-			if !poolExists(pools, nodegroup.NodePoolData.ResourcePoolId) {
+			pool := findPool(pools, nodegroup.NodePoolData.ResourcePoolId)
+			if pool == nil {
 				return typederrors.NewNonRetriableError(nil, "pool specified in nodegroup does not exist on hardware manager, nodegroup: %s", nodegroup.NodePoolData.Name)
 			}
+			if !poolInSite(*pool, filters.Site) {
+				return typederrors.NewNonRetriableError(nil, "pool specified in nodegroup is not in site %s, nodegroup: %s", filters.Site, nodegroup.NodePoolData.Name)
+			}
 
 			if nodegroup.Size > 0 {
 				// Check whether there are free servers that match the specified criteria
@@ -436,8 +474,11 @@ func (a *Adaptor) FindResourcePoolIds(
 			nodepool.Status.SelectedPools[nodegroup.NodePoolData.Name] = nodegroup.NodePoolData.ResourcePoolId
 			a.Logger.InfoContext(ctx, "Setting pool from nodegroup", slog.String("pool", nodepool.Status.SelectedPools[nodegroup.NodePoolData.Name]))
 		} else {
-			matchingPool := findMatchingPool(pools, allocatedServers, resources, resourceSelectors, nodegroup.Size)
+			matchingPool := findMatchingPool(pools, allocatedServers, resources, resourceSelectors, filters.Site, nodegroup.Size)
 			if matchingPool == "" {
+				if described := describeResourceFilters(filters); described != "" {
+					return typederrors.NewNonRetriableError(nil, "unable to find pool matching criteria: resourceSelector: %s, %s", nodegroup.NodePoolData.ResourceSelector, described)
+				}
 				return typederrors.NewNonRetriableError(nil, "unable to find pool matching criteria: resourceSelector: %s", nodegroup.NodePoolData.ResourceSelector)
 			}
 
@@ -454,3 +495,48 @@ func (a *Adaptor) FindResourcePoolIds(
 	}
 	return nil
 }
+
+// indexServers indexes the servers of the server inventory by name
+func indexServers(servers *hwmgrapi.ApiprotoGetServersInventoryResp) map[string]*hwmgrapi.ApiprotoServer {
+	index := make(map[string]*hwmgrapi.ApiprotoServer)
+	if servers == nil || servers.Servers == nil {
+		return index
+	}
+	for i := range *servers.Servers {
+		server := &(*servers.Servers)[i]
+		if server.Metadata != nil && server.Metadata.Name != nil {
+			index[*server.Metadata.Name] = server
+		}
+	}
+	return index
+}
+
+// serverInventory is the server inventory of the hardware manager for a single reconcile. The inventory holds every
+// server, so it is fetched at most once, on the first lookup, and shared by the nodes handled by the reconcile.
+type serverInventory struct {
+	hwmgrClient *hwmgrclient.HardwareManagerClient
+
+	once    sync.Once
+	servers map[string]*hwmgrapi.ApiprotoServer
+	err     error
+}
+
+func newServerInventory(hwmgrClient *hwmgrclient.HardwareManagerClient) *serverInventory {
+	return &serverInventory{hwmgrClient: hwmgrClient}
+}
+
+// lookup returns the named server, or nil if it is not in the inventory
+func (s *serverInventory) lookup(ctx context.Context, name string) (*hwmgrapi.ApiprotoServer, error) {
+	s.once.Do(func() {
+		servers, err := s.hwmgrClient.GetServersInventory(ctx)
+		if err != nil {
+			s.err = fmt.Errorf("failed to get server inventory: %w", err)
+			return
+		}
+		s.servers = indexServers(servers)
+	})
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.servers[name], nil
+}
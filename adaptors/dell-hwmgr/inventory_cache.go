@@ -0,0 +1,137 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// When the hardware manager sets an InventoryCacheTTL, the inventory queried live is served from the snapshot until
+// the TTL expires, so that bursts of inventory API requests do not each query the hardware manager. The resource
+// pools and resources expire separately, and a change to the HardwareManager invalidates both. Concurrent live
+// queries of the same inventory share a single query to the hardware manager, whether or not caching is enabled.
+const (
+	resourcePoolsQuery = "resourcePools"
+	resourcesQuery     = "resources"
+)
+
+// inventoryFetch records when the inventory of a hardware manager was last queried live, for the generation of the
+// HardwareManager it was queried with
+type inventoryFetch struct {
+	generation int64
+	pools      time.Time
+	resources  time.Time
+}
+
+// inventoryQueryResult is the result of a live inventory query, shared by the concurrent callers
+type inventoryQueryResult[T any] struct {
+	items      []T
+	statusCode int
+}
+
+// inventoryCacheTTL returns how long the inventory of the hardware manager is cached, or zero if it is not cached
+func inventoryCacheTTL(hwmgr *pluginv1alpha1.HardwareManager) time.Duration {
+	if hwmgr.Spec.DellData == nil || hwmgr.Spec.DellData.InventoryCacheTTL == nil {
+		return 0
+	}
+	return hwmgr.Spec.DellData.InventoryCacheTTL.Duration
+}
+
+// inventoryQueryKey identifies the live queries of one kind of inventory of a hardware manager
+func inventoryQueryKey(kind string, hwmgr *pluginv1alpha1.HardwareManager) string {
+	return fmt.Sprintf("%s/%s", kind, hwmgr.Name)
+}
+
+// sharedInventoryQuery runs the query, unless a query with the same key is already in progress, in which case it waits
+// for and returns the result of that query. The query is shared by callers with different contexts, so it runs with
+// the values of the context of the caller that started it, but without its cancellation, bounded by the inventory
+// query timeout of the hardware manager instead. A caller whose context is done returns without waiting for it.
+func sharedInventoryQuery[T any](ctx context.Context, group *singleflight.Group, key string,
+	hwmgr *pluginv1alpha1.HardwareManager, query func(ctx context.Context) ([]T, int, error)) ([]T, int, error) {
+	results := group.DoChan(key, func() (interface{}, error) {
+		queryCtx, cancel := utils.WithOperationTimeout(context.WithoutCancel(ctx), hwmgr, utils.OperationInventoryQuery)
+		defer cancel()
+		items, statusCode, err := query(queryCtx)
+		return inventoryQueryResult[T]{items: items, statusCode: statusCode}, err
+	})
+
+	select {
+	case result := <-results:
+		shared := result.Val.(inventoryQueryResult[T])
+		return shared.items, shared.statusCode, result.Err // nolint: wrapcheck
+	case <-ctx.Done():
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("inventory query %s abandoned: %w", key, ctx.Err())
+	}
+}
+
+// recordInventoryFetch records a successful live query of the inventory of the hardware manager
+func (s *inventorySnapshots) recordInventoryFetch(hwmgr *pluginv1alpha1.HardwareManager, update func(*inventoryFetch)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fetch := s.fetched[hwmgr.Name]
+	if fetch.generation != hwmgr.Generation {
+		fetch = inventoryFetch{generation: hwmgr.Generation}
+	}
+	update(&fetch)
+	s.fetched[hwmgr.Name] = fetch
+}
+
+// cachedInventory returns the snapshot of the hardware manager if the inventory selected by fetched was queried live
+// within the TTL. It must be called with the lock held.
+func (s *inventorySnapshots) cachedInventory(hwmgr *pluginv1alpha1.HardwareManager, now time.Time,
+	fetched func(inventoryFetch) time.Time) *inventorySnapshot {
+	ttl := inventoryCacheTTL(hwmgr)
+	if ttl <= 0 {
+		return nil
+	}
+	fetch, exists := s.fetched[hwmgr.Name]
+	if !exists || fetch.generation != hwmgr.Generation || fetched(fetch).IsZero() || now.Sub(fetched(fetch)) >= ttl {
+		return nil
+	}
+	return s.current[hwmgr.Name]
+}
+
+// cachedResourcePools returns the resource pools of the hardware manager if they were queried live within the TTL
+func (s *inventorySnapshots) cachedResourcePools(hwmgr *pluginv1alpha1.HardwareManager, now time.Time) (*inventorySnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := s.cachedInventory(hwmgr, now, func(fetch inventoryFetch) time.Time { return fetch.pools })
+	return snapshot, snapshot != nil
+}
+
+// cachedResources returns the resources of the hardware manager if they were queried live within the TTL
+func (s *inventorySnapshots) cachedResources(hwmgr *pluginv1alpha1.HardwareManager, now time.Time) (*inventorySnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := s.cachedInventory(hwmgr, now, func(fetch inventoryFetch) time.Time { return fetch.resources })
+	return snapshot, snapshot != nil
+}
+
+// RefreshInventory queries the resource pools and resources from the hardware manager, replacing the cached inventory
+func (a *Adaptor) RefreshInventory(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) (int, int, error) {
+	// A query already in progress may have started before the changes the refresh is expected to pick up
+	a.snapshots.queries.Forget(inventoryQueryKey(resourcePoolsQuery, hwmgr))
+	a.snapshots.queries.Forget(inventoryQueryKey(resourcesQuery, hwmgr))
+
+	pools, _, err := a.getLiveResourcePools(ctx, hwmgr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to refresh resource pools: %w", err)
+	}
+	resources, _, err := a.getLiveResources(ctx, hwmgr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to refresh resources: %w", err)
+	}
+	return len(pools), len(resources), nil
+}
@@ -0,0 +1,141 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestInventoryCacheTTL(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "dell-1", Generation: 1},
+		Spec: pluginv1alpha1.HardwareManagerSpec{DellData: &pluginv1alpha1.DellData{
+			InventoryCacheTTL: &metav1.Duration{Duration: time.Minute},
+		}},
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	s := newInventorySnapshots()
+	s.current[hwmgr.Name] = &inventorySnapshot{ResourcePools: []invserver.ResourcePoolInfo{{ResourcePoolId: "pool-1"}}}
+	if _, cached := s.cachedResourcePools(hwmgr, now); cached {
+		t.Errorf("expected pools not queried live to not be cached")
+	}
+
+	s.recordInventoryFetch(hwmgr, func(fetch *inventoryFetch) { fetch.pools = now })
+	if snapshot, cached := s.cachedResourcePools(hwmgr, now.Add(30*time.Second)); !cached || len(snapshot.ResourcePools) != 1 {
+		t.Errorf("expected pools within the TTL to be cached")
+	}
+	if _, cached := s.cachedResources(hwmgr, now.Add(30*time.Second)); cached {
+		t.Errorf("expected resources not queried live to not be cached")
+	}
+	if _, cached := s.cachedResourcePools(hwmgr, now.Add(time.Minute)); cached {
+		t.Errorf("expected pools past the TTL to not be cached")
+	}
+
+	changed := hwmgr.DeepCopy()
+	changed.Generation = 2
+	if _, cached := s.cachedResourcePools(changed, now); cached {
+		t.Errorf("expected a change to the hardware manager to invalidate the cache")
+	}
+
+	disabled := hwmgr.DeepCopy()
+	disabled.Spec.DellData.InventoryCacheTTL = nil
+	if _, cached := s.cachedResourcePools(disabled, now); cached {
+		t.Errorf("expected the cache to be disabled without a TTL")
+	}
+}
+
+func TestSharedInventoryQuery(t *testing.T) {
+	var (
+		group   singleflight.Group
+		queries atomic.Int32
+		wg      sync.WaitGroup
+	)
+	release := make(chan struct{})
+	hwmgr := &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{Name: "dell-1"}}
+	query := func(_ context.Context) ([]invserver.ResourceInfo, int, error) {
+		queries.Add(1)
+		<-release
+		return []invserver.ResourceInfo{{ResourceId: "r1"}}, 200, nil
+	}
+
+	results := make([][]invserver.ResourceInfo, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, _ = sharedInventoryQuery(context.Background(), &group, "resources/dell-1", hwmgr, query)
+		}()
+	}
+	// Let the callers join the query in progress before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if count := queries.Load(); count != 1 {
+		t.Errorf("expected concurrent callers to share a single query, got %d queries", count)
+	}
+	for i, result := range results {
+		if len(result) != 1 || result[0].ResourceId != "r1" {
+			t.Errorf("unexpected result for caller %d: %v", i, result)
+		}
+	}
+}
+
+func TestSharedInventoryQueryCancellation(t *testing.T) {
+	var group singleflight.Group
+	hwmgr := &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{Name: "dell-1"}}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	queryErr := make(chan error, 1)
+	query := func(ctx context.Context) ([]invserver.ResourceInfo, int, error) {
+		close(started)
+		<-release
+		queryErr <- ctx.Err()
+		return []invserver.ResourceInfo{{ResourceId: "r1"}}, 200, nil
+	}
+
+	// The caller that started the query gives up on it
+	ctx, cancel := context.WithCancel(context.Background())
+	abandoned := make(chan error, 1)
+	go func() {
+		_, _, err := sharedInventoryQuery(ctx, &group, "resources/dell-1", hwmgr, query)
+		abandoned <- err
+	}()
+	<-started
+	cancel()
+	if err := <-abandoned; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled caller to return, got %v", err)
+	}
+
+	// The query continues for the callers that joined it
+	shared := make(chan []invserver.ResourceInfo, 1)
+	go func() {
+		resources, _, _ := sharedInventoryQuery(context.Background(), &group, "resources/dell-1", hwmgr, query)
+		shared <- resources
+	}()
+	// Let the caller join the query in progress before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	if err := <-queryErr; err != nil {
+		t.Errorf("expected the query to outlive the caller that started it, got %v", err)
+	}
+	if resources := <-shared; len(resources) != 1 || resources[0].ResourceId != "r1" {
+		t.Errorf("unexpected result for the joined caller: %v", resources)
+	}
+}
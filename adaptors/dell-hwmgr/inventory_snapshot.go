@@ -0,0 +1,341 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// The last-known inventory of each hardware manager is persisted to a ConfigMap, so that after a restart, such as
+// during an upgrade, the inventory API can serve it immediately, flagged as stale, while the inventory is resynced
+// from the hardware manager in the background. Once resynced, queries go to the hardware manager as usual, with each
+// successful query refreshing the snapshot.
+const (
+	inventorySnapshotKey = "snapshot.json.gz"
+
+	// inventorySnapshotMaxSize keeps the snapshot within the ConfigMap size limit
+	inventorySnapshotMaxSize = 900 * 1024
+	// inventorySnapshotPersistInterval bounds how often an unchanged snapshot is rewritten to refresh its timestamp
+	inventorySnapshotPersistInterval = 5 * time.Minute
+)
+
+// inventorySnapshot is the persisted inventory of a hardware manager
+type inventorySnapshot struct {
+	Timestamp     time.Time                    `json:"timestamp"`
+	ResourcePools []invserver.ResourcePoolInfo `json:"resourcePools"`
+	Resources     []invserver.ResourceInfo     `json:"resources"`
+}
+
+// inventorySnapshots tracks the snapshot and resync state of each hardware manager
+type inventorySnapshots struct {
+	mu sync.Mutex
+	// current is the last-known inventory of each hardware manager, loaded from the ConfigMap or from live queries
+	current map[string]*inventorySnapshot
+	// persisted is when the snapshot of each hardware manager was last written
+	persisted map[string]time.Time
+	// synced records the hardware managers whose inventory has been queried live since the adaptor started
+	synced map[string]bool
+	// resyncing records the hardware managers with a background resync requested or in progress
+	resyncing map[string]bool
+	// pending holds the hardware managers whose resync is requested, to be run by the resync loop
+	pending map[string]*pluginv1alpha1.HardwareManager
+	// resync wakes up the resync loop when a resync is requested
+	resync chan struct{}
+	// fetched records when the inventory of each hardware manager was last queried live, for the inventory cache
+	fetched map[string]inventoryFetch
+	// queries coalesces concurrent live queries of the same inventory
+	queries singleflight.Group
+}
+
+func newInventorySnapshots() *inventorySnapshots {
+	return &inventorySnapshots{
+		current:   make(map[string]*inventorySnapshot),
+		persisted: make(map[string]time.Time),
+		synced:    make(map[string]bool),
+		resyncing: make(map[string]bool),
+		pending:   make(map[string]*pluginv1alpha1.HardwareManager),
+		resync:    make(chan struct{}, 1),
+		fetched:   make(map[string]inventoryFetch),
+	}
+}
+
+// inventorySnapshotName returns the name of the ConfigMap holding the inventory snapshot of a hardware manager
+func inventorySnapshotName(hwmgr *pluginv1alpha1.HardwareManager) string {
+	return fmt.Sprintf("%s-inventory-snapshot", hwmgr.Name)
+}
+
+// encodeInventorySnapshot serializes the snapshot as gzip-compressed JSON
+func encodeInventorySnapshot(snapshot *inventorySnapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to encode inventory snapshot: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress inventory snapshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeInventorySnapshot parses a snapshot serialized by encodeInventorySnapshot
+func decodeInventorySnapshot(data []byte) (*inventorySnapshot, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress inventory snapshot: %w", err)
+	}
+	defer gz.Close() // nolint: errcheck
+
+	snapshot := &inventorySnapshot{}
+	if err := json.NewDecoder(io.LimitReader(gz, 64*inventorySnapshotMaxSize)).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode inventory snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// loadInventorySnapshot returns the last-known inventory of the hardware manager, reading the persisted snapshot if
+// not yet loaded. It returns nil if there is no usable snapshot.
+func (a *Adaptor) loadInventorySnapshot(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) *inventorySnapshot {
+	a.snapshots.mu.Lock()
+	snapshot := a.snapshots.current[hwmgr.Name]
+	a.snapshots.mu.Unlock()
+	if snapshot != nil {
+		return snapshot
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := a.Client.Get(ctx, types.NamespacedName{Name: inventorySnapshotName(hwmgr), Namespace: a.Namespace}, cm); err != nil {
+		return nil
+	}
+	snapshot, err := decodeInventorySnapshot(cm.BinaryData[inventorySnapshotKey])
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Ignoring invalid inventory snapshot", slog.String("hwmgr", hwmgr.Name),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	a.snapshots.mu.Lock()
+	defer a.snapshots.mu.Unlock()
+	if current := a.snapshots.current[hwmgr.Name]; current != nil {
+		// A live query completed in the meantime
+		return current
+	}
+	a.snapshots.current[hwmgr.Name] = snapshot
+	a.snapshots.persisted[hwmgr.Name] = snapshot.Timestamp
+	return snapshot
+}
+
+// updateInventorySnapshot records the result of a successful live query, which ends the serving of the snapshot, and
+// persists the snapshot if it changed, or if it was last written more than inventorySnapshotPersistInterval ago
+func (a *Adaptor) updateInventorySnapshot(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	update func(*inventorySnapshot) bool) {
+	a.snapshots.mu.Lock()
+	current := a.snapshots.current[hwmgr.Name]
+	snapshot := &inventorySnapshot{}
+	if current != nil {
+		*snapshot = *current
+	}
+	changed := update(snapshot)
+	snapshot.Timestamp = a.clock().Now()
+	a.snapshots.current[hwmgr.Name] = snapshot
+	a.snapshots.synced[hwmgr.Name] = true
+	persist := changed || a.clock().Since(a.snapshots.persisted[hwmgr.Name]) > inventorySnapshotPersistInterval
+	if persist {
+		a.snapshots.persisted[hwmgr.Name] = snapshot.Timestamp
+	}
+	a.snapshots.mu.Unlock()
+
+	if !persist {
+		return
+	}
+
+	data, err := encodeInventorySnapshot(snapshot)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Failed to persist inventory snapshot", slog.String("error", err.Error()))
+		return
+	}
+	if len(data) > inventorySnapshotMaxSize {
+		a.Logger.WarnContext(ctx, "Inventory snapshot exceeds the maximum size, skipping persistence",
+			slog.Int("size", len(data)))
+		return
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inventorySnapshotName(hwmgr),
+			Namespace: a.Namespace,
+		},
+		BinaryData: map[string][]byte{inventorySnapshotKey: data},
+	}
+	if err := utils.CreateOrUpdateK8sCR(ctx, a.Client, cm, hwmgr, utils.UPDATE); err != nil {
+		// The snapshot is only used after a restart, so a failure to persist it does not fail the query
+		a.Logger.WarnContext(ctx, "Failed to persist inventory snapshot", slog.String("error", err.Error()))
+	}
+}
+
+// isInventorySynced returns true if the inventory of the hardware manager has been queried live since startup
+func (a *Adaptor) isInventorySynced(hwmgr *pluginv1alpha1.HardwareManager) bool {
+	a.snapshots.mu.Lock()
+	defer a.snapshots.mu.Unlock()
+	return a.snapshots.synced[hwmgr.Name]
+}
+
+// startInventoryResync requests a resync of the inventory of the hardware manager from the resync loop, if not already
+// in progress
+func (a *Adaptor) startInventoryResync(hwmgr *pluginv1alpha1.HardwareManager) {
+	a.snapshots.mu.Lock()
+	defer a.snapshots.mu.Unlock()
+	if a.snapshots.synced[hwmgr.Name] || a.snapshots.resyncing[hwmgr.Name] {
+		return
+	}
+	a.snapshots.resyncing[hwmgr.Name] = true
+	a.snapshots.pending[hwmgr.Name] = hwmgr.DeepCopy()
+
+	select {
+	case a.snapshots.resync <- struct{}{}:
+	default:
+		// The resync loop is already woken up, and picks up all pending resyncs
+	}
+}
+
+// takeInventoryResyncs returns the requested resyncs, sorted by hardware manager
+func (a *Adaptor) takeInventoryResyncs() []*pluginv1alpha1.HardwareManager {
+	a.snapshots.mu.Lock()
+	defer a.snapshots.mu.Unlock()
+	hwmgrs := make([]*pluginv1alpha1.HardwareManager, 0, len(a.snapshots.pending))
+	for _, hwmgr := range a.snapshots.pending {
+		hwmgrs = append(hwmgrs, hwmgr)
+	}
+	clear(a.snapshots.pending)
+	sort.Slice(hwmgrs, func(i, j int) bool { return hwmgrs[i].Name < hwmgrs[j].Name })
+	return hwmgrs
+}
+
+// runInventoryResyncs is the background loop running the resyncs requested by startInventoryResync, until its context
+// is cancelled
+func (a *Adaptor) runInventoryResyncs(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-a.snapshots.resync:
+		}
+		for _, hwmgr := range a.takeInventoryResyncs() {
+			a.resyncInventory(ctx, hwmgr)
+		}
+	}
+}
+
+// resyncInventory queries the inventory of the hardware manager live, under the inventory query timeout
+func (a *Adaptor) resyncInventory(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) {
+	ctx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
+
+	a.Logger.InfoContext(ctx, "Resyncing inventory", slog.String("hwmgr", hwmgr.Name))
+	_, _, poolsErr := a.getLiveResourcePools(ctx, hwmgr)
+	_, _, resourcesErr := a.getLiveResources(ctx, hwmgr)
+
+	a.snapshots.mu.Lock()
+	defer a.snapshots.mu.Unlock()
+	a.snapshots.resyncing[hwmgr.Name] = false
+	if poolsErr != nil || resourcesErr != nil {
+		// The snapshot continues to be served for the failed queries, and the next query retries the resync
+		a.Logger.WarnContext(ctx, "Inventory resync failed", slog.String("hwmgr", hwmgr.Name),
+			slog.Any("poolsError", poolsErr), slog.Any("resourcesError", resourcesErr))
+		return
+	}
+	a.Logger.InfoContext(ctx, "Inventory resync completed", slog.String("hwmgr", hwmgr.Name))
+}
+
+// getLiveResourcePools queries the resource pools from the hardware manager, recording them in the snapshot. Concurrent
+// callers share a single query.
+func (a *Adaptor) getLiveResourcePools(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourcePoolInfo, int, error) {
+	return sharedInventoryQuery(ctx, &a.snapshots.queries, inventoryQueryKey(resourcePoolsQuery, hwmgr), hwmgr,
+		func(ctx context.Context) ([]invserver.ResourcePoolInfo, int, error) {
+			fetched := a.clock().Now()
+			pools, statusCode, err := a.queryResourcePools(ctx, hwmgr)
+			if err == nil {
+				a.updateInventorySnapshot(ctx, hwmgr, func(snapshot *inventorySnapshot) bool {
+					changed := !reflect.DeepEqual(snapshot.ResourcePools, pools)
+					snapshot.ResourcePools = pools
+					return changed
+				})
+				a.snapshots.recordInventoryFetch(hwmgr, func(fetch *inventoryFetch) { fetch.pools = fetched })
+			}
+			return pools, statusCode, err
+		})
+}
+
+// getLiveResources queries the resources from the hardware manager, recording them in the snapshot. Concurrent callers
+// share a single query.
+func (a *Adaptor) getLiveResources(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourceInfo, int, error) {
+	return sharedInventoryQuery(ctx, &a.snapshots.queries, inventoryQueryKey(resourcesQuery, hwmgr), hwmgr,
+		func(ctx context.Context) ([]invserver.ResourceInfo, int, error) {
+			fetched := a.clock().Now()
+			resources, statusCode, err := a.queryResources(ctx, hwmgr)
+			if err == nil {
+				a.updateInventorySnapshot(ctx, hwmgr, func(snapshot *inventorySnapshot) bool {
+					changed := !reflect.DeepEqual(snapshot.Resources, resources)
+					snapshot.Resources = resources
+					return changed
+				})
+				a.snapshots.recordInventoryFetch(hwmgr, func(fetch *inventoryFetch) { fetch.resources = fetched })
+			}
+			return resources, statusCode, err
+		})
+}
+
+// GetResourcePools returns the resource pools of the hardware manager, serving the snapshot until the inventory has
+// been resynced after startup, and while the pools queried live are within the inventory cache TTL
+func (a *Adaptor) GetResourcePools(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourcePoolInfo, int, error) {
+	if snapshot, cached := a.snapshots.cachedResourcePools(hwmgr, a.clock().Now()); cached {
+		return snapshot.ResourcePools, http.StatusOK, nil
+	}
+	if !a.isInventorySynced(hwmgr) {
+		if snapshot := a.loadInventorySnapshot(ctx, hwmgr); snapshot != nil && snapshot.ResourcePools != nil {
+			a.startInventoryResync(hwmgr)
+			utils.MarkInventoryStale(ctx, snapshot.Timestamp)
+			return snapshot.ResourcePools, http.StatusOK, nil
+		}
+	}
+	return a.getLiveResourcePools(ctx, hwmgr)
+}
+
+// GetResources returns the resources of the hardware manager, serving the snapshot until the inventory has been
+// resynced after startup, and while the resources queried live are within the inventory cache TTL
+func (a *Adaptor) GetResources(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourceInfo, int, error) {
+	if snapshot, cached := a.snapshots.cachedResources(hwmgr, a.clock().Now()); cached {
+		return snapshot.Resources, http.StatusOK, nil
+	}
+	if !a.isInventorySynced(hwmgr) {
+		if snapshot := a.loadInventorySnapshot(ctx, hwmgr); snapshot != nil && snapshot.Resources != nil {
+			a.startInventoryResync(hwmgr)
+			utils.MarkInventoryStale(ctx, snapshot.Timestamp)
+			return snapshot.Resources, http.StatusOK, nil
+		}
+	}
+	return a.getLiveResources(ctx, hwmgr)
+}
@@ -0,0 +1,87 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestInventorySnapshotRoundTrip(t *testing.T) {
+	site := "site-1"
+	snapshot := &inventorySnapshot{
+		Timestamp:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		ResourcePools: []invserver.ResourcePoolInfo{{ResourcePoolId: "pool-1", Name: "pool-1", SiteId: &site}},
+		Resources:     []invserver.ResourceInfo{{ResourceId: "r1", ResourcePoolId: "pool-1", Vendor: "Dell Inc."}},
+	}
+
+	data, err := encodeInventorySnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error encoding snapshot: %v", err)
+	}
+	decoded, err := decodeInventorySnapshot(data)
+	if err != nil {
+		t.Fatalf("unexpected error decoding snapshot: %v", err)
+	}
+	if !decoded.Timestamp.Equal(snapshot.Timestamp) || len(decoded.ResourcePools) != 1 ||
+		*decoded.ResourcePools[0].SiteId != site || len(decoded.Resources) != 1 || decoded.Resources[0].Vendor != "Dell Inc." {
+		t.Errorf("decoded snapshot does not match: %+v", decoded)
+	}
+
+	if _, err := decodeInventorySnapshot([]byte("not gzip")); err == nil {
+		t.Errorf("expected error decoding invalid snapshot")
+	}
+}
+
+func TestStartInventoryResync(t *testing.T) {
+	a := &Adaptor{snapshots: newInventorySnapshots()}
+	hwmgr := func(name string) *pluginv1alpha1.HardwareManager {
+		return &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	// A resync is requested once per hardware manager, and skipped once synced
+	a.snapshots.synced["dell-0"] = true
+	for _, name := range []string{"dell-2", "dell-1", "dell-2", "dell-0"} {
+		a.startInventoryResync(hwmgr(name))
+	}
+	if len(a.snapshots.resync) != 1 {
+		t.Errorf("expected the resync loop to be woken up once, got %d", len(a.snapshots.resync))
+	}
+	resyncs := a.takeInventoryResyncs()
+	if len(resyncs) != 2 || resyncs[0].Name != "dell-1" || resyncs[1].Name != "dell-2" {
+		t.Errorf("expected resyncs of dell-1 and dell-2, got %v", resyncs)
+	}
+	if len(a.takeInventoryResyncs()) != 0 {
+		t.Errorf("expected no resyncs left")
+	}
+
+	// A resync in progress is not requested again
+	a.startInventoryResync(hwmgr("dell-1"))
+	if len(a.takeInventoryResyncs()) != 0 {
+		t.Errorf("expected the resync in progress not to be requested again")
+	}
+
+	// The resync loop returns once its context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error)
+	go func() { done <- a.runInventoryResyncs(ctx) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the resync loop to return on cancellation")
+	}
+}
@@ -0,0 +1,39 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"testing"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+)
+
+func TestIndexServers(t *testing.T) {
+	name := func(s string) *hwmgrapi.ApiprotoObjectMeta { return &hwmgrapi.ApiprotoObjectMeta{Name: &s} }
+	manufacturer := "Dell Inc."
+	servers := &hwmgrapi.ApiprotoGetServersInventoryResp{
+		Servers: &[]hwmgrapi.ApiprotoServer{
+			{Metadata: name("server-1"), Status: &hwmgrapi.ApiprotoServerStatus{Manufacturer: &manufacturer}},
+			{Metadata: name("server-2")},
+			{},
+		},
+	}
+
+	index := indexServers(servers)
+	if len(index) != 2 {
+		t.Fatalf("expected 2 indexed servers, got %d", len(index))
+	}
+	if getResourceInfoVendor(index["server-1"]) != manufacturer {
+		t.Errorf("unexpected server-1: %+v", index["server-1"])
+	}
+	if index["server-3"] != nil {
+		t.Errorf("unexpected server-3: %+v", index["server-3"])
+	}
+	if len(indexServers(nil)) != 0 || len(indexServers(&hwmgrapi.ApiprotoGetServersInventoryResp{})) != 0 {
+		t.Errorf("expected an empty index without servers")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// defaultJobPollInterval is the interval at which jobs are polled, unless set in the HardwareManager
+const defaultJobPollInterval = 15 * time.Second
+
+// defaultFallbackPollInterval is the interval at which jobs are polled when notifications are enabled, unless set in
+// the HardwareManager
+const defaultFallbackPollInterval = 5 * time.Minute
+
+// errJobTimedOut reports a job still running after the timeout of its operation
+var errJobTimedOut = errors.New("job timed out")
+
+// jobTracker polls the jobs run by the hardware manager. The identifier and start time of each job are recorded in
+// annotations of the CR that started it, so tracking resumes after a restart of the plugin. A job still running after
+// the timeout of its operation is reported as timed out.
+type jobTracker struct {
+	pollInterval time.Duration
+	timeout      time.Duration
+	clock        utils.Clock
+}
+
+// jobProgress is the outcome of a job status check
+type jobProgress struct {
+	Status     hwmgrclient.JobStatus
+	FailReason string
+	// Failure holds the details reported for a failed job
+	Failure utils.JobFailure
+	// TimedOut is set for a job still in progress after the timeout of its operation
+	TimedOut bool
+}
+
+// newJobTracker returns a tracker for the jobs of an operation of the HardwareManager, timed with the given clock
+func newJobTracker(hwmgr *pluginv1alpha1.HardwareManager, op utils.Operation, clock utils.Clock) *jobTracker {
+	return &jobTracker{
+		pollInterval: getJobPollInterval(hwmgr),
+		timeout:      utils.GetOperationTimeout(hwmgr, op),
+		clock:        clock,
+	}
+}
+
+// getJobPollInterval returns the interval at which jobs are polled. With notifications enabled, polling is only a
+// fallback for lost notifications, and the fallback interval is used instead.
+func getJobPollInterval(hwmgr *pluginv1alpha1.HardwareManager) time.Duration {
+	dellData := hwmgr.Spec.DellData
+	if dellData == nil {
+		return defaultJobPollInterval
+	}
+	if dellData.Notifications != nil {
+		if dellData.Notifications.FallbackPollInterval != nil && dellData.Notifications.FallbackPollInterval.Duration > 0 {
+			return dellData.Notifications.FallbackPollInterval.Duration
+		}
+		return defaultFallbackPollInterval
+	}
+	if dellData.JobPollInterval != nil && dellData.JobPollInterval.Duration > 0 {
+		return dellData.JobPollInterval.Duration
+	}
+	return defaultJobPollInterval
+}
+
+// check queries the hardware manager for the status of a job started at the given time, if known
+func (t *jobTracker) check(ctx context.Context, hwmgrClient *hwmgrclient.HardwareManagerClient,
+	jobId string, start time.Time, startKnown bool) (jobProgress, error) {
+	status, failure, err := hwmgrClient.CheckJobFailure(ctx, jobId)
+	progress := jobProgress{Status: status, FailReason: failure.Reason, Failure: failure}
+	if err != nil {
+		return progress, fmt.Errorf("failed to check job progress, jobId=%s: %w", jobId, err)
+	}
+
+	if status == hwmgrclient.JobStatusInProgress {
+		progress.TimedOut = t.timedOut(start, startKnown)
+	}
+	return progress, nil
+}
+
+// timedOut checks whether a job started at the given time has exceeded the timeout
+func (t *jobTracker) timedOut(start time.Time, startKnown bool) bool {
+	return t.timeout > 0 && startKnown && t.clock.Since(start) > t.timeout
+}
+
+// requeue returns the result for polling the job again
+func (t *jobTracker) requeue() ctrl.Result {
+	return utils.RequeueWithCustomInterval(t.pollInterval)
+}
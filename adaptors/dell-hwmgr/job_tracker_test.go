@@ -0,0 +1,96 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+func TestJobTracker(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{
+		Spec: pluginv1alpha1.HardwareManagerSpec{
+			AdaptorID: pluginv1alpha1.SupportedAdaptors.Dell,
+			DellData:  &pluginv1alpha1.DellData{},
+		},
+	}
+
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := clocktesting.NewFakeClock(start)
+	tracker := newJobTracker(hwmgr, utils.OperationResourceGroupJob, clock)
+	if tracker.pollInterval != defaultJobPollInterval || tracker.timeout != 0 {
+		t.Errorf("unexpected defaults: poll interval %s, timeout %s", tracker.pollInterval, tracker.timeout)
+	}
+	if tracker.timedOut(start.Add(-24*time.Hour), true) {
+		t.Error("expected no timeout without a limit")
+	}
+
+	hwmgr.Spec.DellData.JobPollInterval = &metav1.Duration{Duration: time.Minute}
+	hwmgr.Spec.DellData.Timeouts = &pluginv1alpha1.OperationTimeouts{
+		ResourceGroupJob: &metav1.Duration{Duration: 30 * time.Minute},
+	}
+	tracker = newJobTracker(hwmgr, utils.OperationResourceGroupJob, clock)
+	if result := tracker.requeue(); result.RequeueAfter != time.Minute {
+		t.Errorf("expected requeue after %s, got %s", time.Minute, result.RequeueAfter)
+	}
+
+	tests := []struct {
+		name       string
+		elapsed    time.Duration
+		startKnown bool
+		expected   bool
+	}{
+		{name: "within timeout", elapsed: 10 * time.Minute, startKnown: true, expected: false},
+		{name: "past timeout", elapsed: 31 * time.Minute, startKnown: true, expected: true},
+		{name: "unknown start", elapsed: 31 * time.Minute, startKnown: false, expected: false},
+	}
+	for _, tt := range tests {
+		clock.SetTime(start)
+		nodepool := &hwmgmtv1alpha1.NodePool{}
+		utils.SetJobId(nodepool, "job-1", clock)
+		jobStart, found := utils.GetJobStartTime(nodepool)
+		if !found || !jobStart.Equal(start) {
+			t.Fatalf("%s: expected the job start time %s from the clock, got %s", tt.name, start, jobStart)
+		}
+
+		clock.SetTime(start.Add(tt.elapsed))
+		if timedOut := tracker.timedOut(jobStart, tt.startKnown); timedOut != tt.expected {
+			t.Errorf("%s: expected timed out %t, got %t", tt.name, tt.expected, timedOut)
+		}
+	}
+}
+
+func TestGetJobPollInterval(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{
+		Spec: pluginv1alpha1.HardwareManagerSpec{
+			AdaptorID: pluginv1alpha1.SupportedAdaptors.Dell,
+			DellData: &pluginv1alpha1.DellData{
+				JobPollInterval: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	if interval := getJobPollInterval(hwmgr); interval != time.Minute {
+		t.Errorf("expected the job poll interval, got %s", interval)
+	}
+
+	hwmgr.Spec.DellData.Notifications = &pluginv1alpha1.NotificationConfig{SubscriptionId: "sub-1"}
+	if interval := getJobPollInterval(hwmgr); interval != defaultFallbackPollInterval {
+		t.Errorf("expected the default fallback poll interval with notifications, got %s", interval)
+	}
+
+	hwmgr.Spec.DellData.Notifications.FallbackPollInterval = &metav1.Duration{Duration: 10 * time.Minute}
+	if interval := getJobPollInterval(hwmgr); interval != 10*time.Minute {
+		t.Errorf("expected the fallback poll interval, got %s", interval)
+	}
+}
@@ -11,13 +11,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -51,38 +52,76 @@ type ExtensionInterface struct {
 	Ports []ExtensionPort `json:"ports,omitempty"`
 }
 
-type BMCCredentials struct {
-	Username string `json:"bmc_username"`
-	Password string `json:"bmc_password"`
-}
-
-func bmcSecretName(nodename string) string {
-	return fmt.Sprintf("%s-bmc-secret", nodename)
-}
-
-// AllocateNode processes a NodePool CR, allocating a free node for each specified nodegroup as needed
+// AllocateNode processes a NodePool CR, allocating a free node for each specified nodegroup as needed. The nodename
+// of a Node left by a previous partial allocation is reused, so that the allocation converges on that Node. The hwprofile
+// is the hardware profile of the nodegroup, and the index is the index of the node in its nodegroup, available to the
+// hostname template and recorded on the Node. The pass holds the server inventory of the reconcile, for the labels of
+// the node, and the hostnames already used in the NodePool.
 func (a *Adaptor) AllocateNode(
 	ctx context.Context,
 	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	pass *allocationPass,
+	hwmgr *pluginv1alpha1.HardwareManager,
 	nodepool *hwmgmtv1alpha1.NodePool,
 	resource hwmgrapi.RhprotoResource,
-	nodegroupName string) (string, error) {
-	nodename := utils.GenerateNodeName()
+	nodegroupName string,
+	hwprofile string,
+	nodename string,
+	index int) (string, error) {
+	newNode := nodename == ""
+	if newNode {
+		nodename = utils.GenerateNodeName()
+	}
 	ctx = logging.AppendCtx(ctx, slog.String("nodename", nodename))
 
+	if hwprofile == "" {
+		return "", fmt.Errorf("no hardware profile for nodegroup %s", nodegroupName)
+	}
+
 	if err := a.ValidateNodeConfig(ctx, resource); err != nil {
 		return "", fmt.Errorf("failed to validate resource configuration: %w", err)
 	}
 
-	if err := a.CreateBMCSecret(ctx, hwmgrClient, nodepool, nodename, resource); err != nil {
+	bmcSecret, err := utils.GetBMCSecretKey(hwmgr, nodepool, nodename, a.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get bmc-secret name for node %s: %w", nodename, err)
+	}
+
+	if err := a.CreateBMCSecret(ctx, hwmgrClient, nodepool, nodename, bmcSecret, resource); err != nil {
 		return "", fmt.Errorf("failed to create bmc-secret when allocating node %s: %w", nodename, err)
 	}
 
-	if err := a.CreateNode(ctx, nodepool, nodename, resource, nodegroupName); err != nil {
+	info, err := a.getNodeSelectionInfo(ctx, pass.servers, nodepool, resource, nodegroupName)
+	if err != nil {
+		if newNode {
+			a.deleteBMCSecret(ctx, bmcSecret)
+		}
+		return "", fmt.Errorf("failed to get node labels (%s): %w", *resource.Id, err)
+	}
+
+	hostname, err := a.getNodeHostname(hwmgr, nodepool, nodename, resource, nodegroupName, info, index)
+	if err != nil {
+		if newNode {
+			a.deleteBMCSecret(ctx, bmcSecret)
+		}
+		return "", fmt.Errorf("failed to get hostname (%s): %w", *resource.Id, err)
+	}
+	if err := pass.hostnames.claim(hostname, nodename); err != nil {
+		if newNode {
+			a.deleteBMCSecret(ctx, bmcSecret)
+		}
+		return "", fmt.Errorf("failed to get hostname (%s): %w", *resource.Id, err)
+	}
+
+	if err := a.CreateNode(ctx, nodepool, nodename, resource, nodegroupName, hwprofile, index, info.Labels(), bmcSecret); err != nil {
+		// A generated node name is not reused, so the secret would otherwise be left until the NodePool is deleted
+		if newNode {
+			a.deleteBMCSecret(ctx, bmcSecret)
+		}
 		return "", fmt.Errorf("failed to create allocated node (%s): %w", *resource.Id, err)
 	}
 
-	if err := a.SetInitialNodeStatus(ctx, nodename, resource); err != nil {
+	if err := a.SetInitialNodeStatus(ctx, hwmgr, nodename, hostname, bmcSecret, resource); err != nil {
 		return nodename, fmt.Errorf("failed to update node status (%s): %w", *resource.Id, err)
 	}
 
@@ -171,6 +210,11 @@ func (a *Adaptor) getNodeInterfaces(resource hwmgrapi.RhprotoResource) ([]*hwmgm
 		}
 	}
 
+	// Reject malformed or duplicate MACs before they are consumed by cluster network configs
+	if err := utils.NormalizeInterfaceMACs(interfaces); err != nil {
+		return nil, fmt.Errorf("resource structure contains invalid nic data: %w", err)
+	}
+
 	return interfaces, nil
 }
 
@@ -185,7 +229,7 @@ func (a *Adaptor) ValidateNodeConfig(ctx context.Context, resource hwmgrapi.Rhpr
 		return fmt.Errorf("resource structure missing required resource attribute field")
 	}
 
-	if _, err := a.parseExtensionInterfaces(resource); err != nil {
+	if _, err := a.getNodeInterfaces(resource); err != nil {
 		return fmt.Errorf("invalid interface list: %w", err)
 	}
 
@@ -196,102 +240,102 @@ func (a *Adaptor) ValidateNodeConfig(ctx context.Context, resource hwmgrapi.Rhpr
 	return nil
 }
 
-// CreateBMCSecret creates the bmc-secret for a node
-func (a *Adaptor) CreateBMCSecret(
+// getNodeSelectionInfo returns the attributes of the resource to be published as labels on the allocated Node. The
+// vendor and model are only reported by the server inventory, so they are left unset if the server cannot be found.
+func (a *Adaptor) getNodeSelectionInfo(
 	ctx context.Context,
-	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	servers *serverInventory,
 	nodepool *hwmgmtv1alpha1.NodePool,
-	nodename string,
-	resource hwmgrapi.RhprotoResource) error {
-	a.Logger.InfoContext(ctx, "Creating bmc-secret")
-
-	remoteSecretKey := *resource.ResourceAttribute.Compute.Lom.Password
-	remoteSecret, err := hwmgrClient.GetSecret(ctx, remoteSecretKey)
+	resource hwmgrapi.RhprotoResource,
+	nodegroupName string) (utils.NodeSelectionInfo, error) {
+	// The server has been selected by the hardware manager using the requested architecture, if any
+	arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodegroupName)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve BMC credentials (%s): %w", remoteSecretKey, err)
+		return utils.NodeSelectionInfo{}, fmt.Errorf("failed to get CPU architecture for nodegroup %s: %w", nodegroupName, err)
 	}
 
-	creds := BMCCredentials{}
-	if err := json.Unmarshal([]byte(*remoteSecret.Secret.Value), &creds); err != nil {
-		return fmt.Errorf("unable to parse BMC credentials (%s)", remoteSecretKey)
+	info := utils.NodeSelectionInfo{
+		SiteID:          nodepool.Spec.Site,
+		CPUArchitecture: arch,
 	}
-
-	secretName := bmcSecretName(nodename)
-
-	blockDeletion := true
-	bmcSecret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
-			Namespace: a.Namespace,
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion:         nodepool.APIVersion,
-				Kind:               nodepool.Kind,
-				Name:               nodepool.Name,
-				UID:                nodepool.UID,
-				BlockOwnerDeletion: &blockDeletion,
-			}},
-		},
-		Data: map[string][]byte{
-			"username": []byte(creds.Username),
-			"password": []byte(creds.Password),
-		},
-	}
-
-	if err = utils.CreateOrUpdateK8sCR(ctx, a.Client, bmcSecret, nil, utils.UPDATE); err != nil {
-		return fmt.Errorf("failed to create bmc-secret for node %s: %w", nodename, err)
+	if resource.SiteId != nil && *resource.SiteId != "" {
+		info.SiteID = *resource.SiteId
 	}
-
-	return nil
-}
-
-// CreateNode creates a Node CR with specified attributes
-func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, nodename string, resource hwmgrapi.RhprotoResource, nodegroupName string) error {
-	// TODO: remove this casuistic when the hwprofile returned by the Dell hwmgr is not empty (not supported yet)
-	//
-	var hwprofile string
-	isHwProfileEmpty := resource.ResourceProfileID == nil || *resource.ResourceProfileID == ""
-	if isHwProfileEmpty {
-		found := false
+	if resource.ResourcePoolId != nil && *resource.ResourcePoolId != "" {
+		info.ResourcePoolID = *resource.ResourcePoolId
+	} else {
 		for _, ng := range nodepool.Spec.NodeGroup {
 			if ng.NodePoolData.Name == nodegroupName {
-				hwprofile = ng.NodePoolData.HwProfile
-				found = true
+				info.ResourcePoolID = ng.NodePoolData.ResourcePoolId
 				break
 			}
 		}
-		if !found {
-			return fmt.Errorf("failed to assign hwprofile from the nodepool cr for nodegroup name:%s",
-				nodegroupName)
-		}
-	} else {
-		hwprofile = *resource.ResourceProfileID
 	}
 
+	if resource.Name == nil {
+		return info, nil
+	}
+	server, err := servers.lookup(ctx, *resource.Name)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "Unable to query server inventory for node labels", slog.String("error", err.Error()))
+		return info, nil
+	}
+	if server != nil {
+		info.Vendor = getResourceInfoVendor(server)
+		info.Model = getResourceInfoModel(server)
+	}
+	return info, nil
+}
+
+// getNodeHostname renders the hostname of an allocated node from the hostname template of the NodePool or hardware
+// manager, defaulting to the name of the resource
+func (a *Adaptor) getNodeHostname(
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	nodename string,
+	resource hwmgrapi.RhprotoResource,
+	nodegroupName string,
+	info utils.NodeSelectionInfo,
+	index int) (string, error) {
+	var defaultTemplate *string
+	if hwmgr.Spec.DellData != nil {
+		defaultTemplate = hwmgr.Spec.DellData.HostnameTemplate
+	}
+	text, err := utils.GetHostnameTemplate(nodepool, defaultTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	data := utils.HostnameTemplateData{
+		NodePool:     nodepool.Name,
+		NodeGroup:    nodegroupName,
+		NodeName:     nodename,
+		Site:         info.SiteID,
+		ResourcePool: info.ResourcePoolID,
+		Index:        index,
+	}
+	if resource.Name != nil {
+		data.ResourceName = *resource.Name
+	}
+	return utils.RenderHostname(text, data)
+}
+
+// CreateNode creates a Node CR with specified attributes, recording its index in its nodegroup and reporting the
+// namespace of its BMC secret
+func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, nodename string, resource hwmgrapi.RhprotoResource,
+	nodegroupName, hwprofile string, index int, labels map[string]string, bmcSecret types.NamespacedName) error {
 	a.Logger.InfoContext(ctx, "Creating node")
 
-	blockDeletion := true
-	node := &hwmgmtv1alpha1.Node{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      nodename,
-			Namespace: a.Namespace,
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion:         nodepool.APIVersion,
-				Kind:               nodepool.Kind,
-				Name:               nodepool.Name,
-				UID:                nodepool.UID,
-				BlockOwnerDeletion: &blockDeletion,
-			}},
-		},
-		Spec: hwmgmtv1alpha1.NodeSpec{
-			NodePool:    nodepool.Name,
-			GroupName:   nodegroupName,
-			HwProfile:   hwprofile,
-			HwMgrId:     nodepool.Spec.HwMgrId,
-			HwMgrNodeId: *resource.Id,
-		},
-	}
-
-	if err := a.Client.Create(ctx, node); err != nil {
+	node := utils.NewNode(nodepool, a.Namespace, nodename, labels, hwmgmtv1alpha1.NodeSpec{
+		NodePool:    nodepool.Name,
+		GroupName:   nodegroupName,
+		HwProfile:   hwprofile,
+		HwMgrId:     nodepool.Spec.HwMgrId,
+		HwMgrNodeId: *resource.Id,
+	})
+	node.SetAnnotations(map[string]string{HostnameIndexAnnotation: strconv.Itoa(index)})
+	utils.SetNodeBMCSecretNamespace(node, bmcSecret)
+	if err := utils.ApplyNode(ctx, a.Client, nodepool, node); err != nil {
 		return fmt.Errorf("failed to create Node: %w", err)
 	}
 
@@ -299,7 +343,8 @@ func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodeP
 }
 
 // SetInitialNodeStatus updates a Node CR status field with additional node information from the RhprotoResource
-func (a *Adaptor) SetInitialNodeStatus(ctx context.Context, nodename string, resource hwmgrapi.RhprotoResource) error {
+func (a *Adaptor) SetInitialNodeStatus(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodename, hostname string,
+	bmcSecret types.NamespacedName, resource hwmgrapi.RhprotoResource) error {
 	a.Logger.InfoContext(ctx, "Updating node")
 
 	node := &hwmgmtv1alpha1.Node{}
@@ -316,8 +361,8 @@ func (a *Adaptor) SetInitialNodeStatus(ctx context.Context, nodename string, res
 	}
 
 	node.Status.BMC = &hwmgmtv1alpha1.BMC{
-		Address:         virtualMediaUrl,
-		CredentialsName: bmcSecretName(nodename),
+		Address:         a.resolveBMCAddress(ctx, hwmgr, bmcSecret, virtualMediaUrl),
+		CredentialsName: bmcSecret.Name,
 	}
 
 	var parseErr error
@@ -331,6 +376,7 @@ func (a *Adaptor) SetInitialNodeStatus(ctx context.Context, nodename string, res
 		metav1.ConditionTrue,
 		"Provisioned")
 
+	node.Status.Hostname = hostname
 	node.Status.HwProfile = node.Spec.HwProfile
 
 	if err := utils.UpdateK8sCRStatus(ctx, a.Client, node); err != nil {
@@ -0,0 +1,305 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// defaultAllocationConcurrency is the number of nodes allocated concurrently when allocationConcurrency is not set
+const defaultAllocationConcurrency = 8
+
+// HostnameIndexAnnotation records the index of a node in its nodegroup, available to the hostname template
+const HostnameIndexAnnotation = "hwmgr-plugin.oran.openshift.io/hostname-index"
+
+// nodeAllocation is a resource of the resource group to allocate as a node
+type nodeAllocation struct {
+	Resource      hwmgrapi.RhprotoResource
+	NodegroupName string
+	// HwProfile is the hardware profile of the nodegroup
+	HwProfile string
+	// Nodename is the name of the Node left by an interrupted allocation, if any
+	Nodename string
+	// Index is the index of the node in its nodegroup, available to the hostname template
+	Index int
+}
+
+// nodeAllocationFailure is a resource that could not be allocated
+type nodeAllocationFailure struct {
+	Resource string
+	Err      error
+}
+
+// getAllocationConcurrency returns the number of nodes to allocate concurrently
+func getAllocationConcurrency(hwmgr *pluginv1alpha1.HardwareManager) int {
+	if hwmgr.Spec.DellData != nil && hwmgr.Spec.DellData.AllocationConcurrency != nil && *hwmgr.Spec.DellData.AllocationConcurrency > 0 {
+		return *hwmgr.Spec.DellData.AllocationConcurrency
+	}
+	return defaultAllocationConcurrency
+}
+
+// nodegroupHwProfile returns the hardware profile of the nodegroup: the resource profile of its resource selector, as
+// requested from the nodegroup and reported by the hardware manager, or the hwProfile of the nodegroup otherwise. The
+// profile is per nodegroup, so that the controller and worker nodes of a NodePool can have distinct profiles.
+func nodegroupHwProfile(nodepool *hwmgmtv1alpha1.NodePool, nodegroupName string,
+	resourceSelector hwmgrapi.RhprotoResourceSelectorGetResponse) string {
+	if resourceSelector.ResourceProfileId != nil && *resourceSelector.ResourceProfileId != "" {
+		return *resourceSelector.ResourceProfileId
+	}
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		if nodegroup.NodePoolData.Name == nodegroupName {
+			return nodegroup.NodePoolData.HwProfile
+		}
+	}
+	return ""
+}
+
+// getNodeAllocations returns the resources of the resource group to allocate as nodes, by nodegroup name and resource
+// ID. Resources whose Node is already recorded in the NodePool are skipped, while the Node of an interrupted allocation
+// is reused along with its index. The other resources take the lowest indexes free in their nodegroup.
+func (a *Adaptor) getNodeAllocations(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	rg *hwmgrapi.RhprotoResourceGroupObjectGetResponseBody, nodelist hwmgmtv1alpha1.NodeList,
+	indexes hostnameIndexes) []nodeAllocation {
+	if rg.ResourceSelectors == nil {
+		return nil
+	}
+
+	nodegroupNames := make([]string, 0, len(*rg.ResourceSelectors))
+	for nodegroupName := range *rg.ResourceSelectors {
+		nodegroupNames = append(nodegroupNames, nodegroupName)
+	}
+	sort.Strings(nodegroupNames)
+
+	var allocations []nodeAllocation
+	for _, nodegroupName := range nodegroupNames {
+		resourceSelector := (*rg.ResourceSelectors)[nodegroupName]
+		if resourceSelector.Resources == nil {
+			continue
+		}
+		hwprofile := nodegroupHwProfile(nodepool, nodegroupName, resourceSelector)
+
+		// The hardware manager does not report the resources in a stable order
+		resources := slices.Clone(*resourceSelector.Resources)
+		slices.SortFunc(resources, func(x, y hwmgrapi.RhprotoResource) int { return strings.Compare(*x.Id, *y.Id) })
+
+		var groupAllocations []nodeAllocation
+		for _, resource := range resources {
+			nodename := utils.FindNodeInList(nodelist, nodepool.Spec.HwMgrId, *resource.Id)
+			index := -1
+			if nodename != "" {
+				// Node CR exists
+				if slices.Contains(nodepool.Status.Properties.NodeNames, nodename) {
+					a.Logger.InfoContext(ctx, "Node is already added",
+						slog.String("nodename", nodename),
+						slog.String("nodeId", *resource.Id))
+					continue
+				}
+				// The allocation was interrupted before the node was recorded, so resume it with the existing Node
+				a.Logger.InfoContext(ctx, "Node previously allocated, but not in nodepool properties, resuming allocation",
+					slog.String("nodename", nodename),
+					slog.String("nodeId", *resource.Id))
+				if node := findNode(nodelist, nodename); node != nil {
+					if recorded, ok := getHostnameIndex(node); ok {
+						index = recorded
+					}
+				}
+			}
+			groupAllocations = append(groupAllocations, nodeAllocation{
+				Resource:      resource,
+				NodegroupName: nodegroupName,
+				HwProfile:     hwprofile,
+				Nodename:      nodename,
+				Index:         index,
+			})
+		}
+		for i := range groupAllocations {
+			if groupAllocations[i].Index < 0 {
+				groupAllocations[i].Index = indexes.next(nodegroupName)
+			}
+		}
+		allocations = append(allocations, groupAllocations...)
+	}
+	return allocations
+}
+
+// findNode returns the named node of the list, or nil if it is not in the list
+func findNode(nodelist hwmgmtv1alpha1.NodeList, nodename string) *hwmgmtv1alpha1.Node {
+	for i := range nodelist.Items {
+		if nodelist.Items[i].Name == nodename {
+			return &nodelist.Items[i]
+		}
+	}
+	return nil
+}
+
+// getHostnameIndex returns the index recorded on a node, if any
+func getHostnameIndex(node *hwmgmtv1alpha1.Node) (int, bool) {
+	value, exists := node.GetAnnotations()[HostnameIndexAnnotation]
+	if !exists {
+		return 0, false
+	}
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+// hostnameIndexes tracks the indexes used by the nodes of each nodegroup of a NodePool. The index of a node is
+// recorded on its Node, so that it does not depend on the order of the resources reported by the hardware manager nor
+// on the nodes released since, and a new node takes the lowest index free in its nodegroup.
+type hostnameIndexes map[string]map[int]bool
+
+// newHostnameIndexes returns the indexes recorded on the nodes of the NodePool
+func newHostnameIndexes(nodepool *hwmgmtv1alpha1.NodePool, nodelist hwmgmtv1alpha1.NodeList) hostnameIndexes {
+	indexes := make(hostnameIndexes)
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		if node.Spec.NodePool != nodepool.Name {
+			continue
+		}
+		if index, ok := getHostnameIndex(node); ok {
+			indexes.use(node.Spec.GroupName, index)
+		}
+	}
+	return indexes
+}
+
+func (h hostnameIndexes) use(nodegroupName string, index int) {
+	if h[nodegroupName] == nil {
+		h[nodegroupName] = make(map[int]bool)
+	}
+	h[nodegroupName][index] = true
+}
+
+// next takes the lowest index free in the nodegroup
+func (h hostnameIndexes) next(nodegroupName string) int {
+	index := 0
+	for h[nodegroupName][index] {
+		index++
+	}
+	h.use(nodegroupName, index)
+	return index
+}
+
+// allocationHostnames tracks the hostnames of the nodes of a NodePool during an allocation, so that a hostname
+// template rendering the same hostname for two nodes fails the allocation of the second one
+type allocationHostnames struct {
+	mu sync.Mutex
+	// used maps each hostname to its node
+	used map[string]string
+}
+
+// newAllocationHostnames returns the hostnames of the nodes of the NodePool
+func newAllocationHostnames(nodepool *hwmgmtv1alpha1.NodePool, nodelist hwmgmtv1alpha1.NodeList) *allocationHostnames {
+	hostnames := &allocationHostnames{used: make(map[string]string)}
+	for _, node := range nodelist.Items {
+		if node.Spec.NodePool == nodepool.Name && node.Status.Hostname != "" {
+			hostnames.used[node.Status.Hostname] = node.Name
+		}
+	}
+	return hostnames
+}
+
+// claim records the hostname of a node, failing if another node of the NodePool has it
+func (h *allocationHostnames) claim(hostname, nodename string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if owner, exists := h.used[hostname]; exists && owner != nodename {
+		return typederrors.NewInputError("hostname %s of node %s is already used by node %s", hostname, nodename, owner)
+	}
+	h.used[hostname] = nodename
+	return nil
+}
+
+// allocationPass is the state shared by the nodes allocated by a reconcile
+type allocationPass struct {
+	servers   *serverInventory
+	hostnames *allocationHostnames
+}
+
+func newAllocationPass(hwmgrClient *hwmgrclient.HardwareManagerClient, nodepool *hwmgmtv1alpha1.NodePool,
+	nodelist hwmgmtv1alpha1.NodeList) *allocationPass {
+	return &allocationPass{
+		servers:   newServerInventory(hwmgrClient),
+		hostnames: newAllocationHostnames(nodepool, nodelist),
+	}
+}
+
+// allocateNodes allocates the nodes with a bounded number of concurrent workers. It returns the names of the allocated
+// nodes and the failed allocations, both in the order of the allocations.
+func (a *Adaptor) allocateNodes(
+	ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	pass *allocationPass,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	allocations []nodeAllocation) ([]string, []nodeAllocationFailure) {
+
+	nodenames := make([]string, len(allocations))
+	errs := make([]error, len(allocations))
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, getAllocationConcurrency(hwmgr))
+	for i, allocation := range allocations {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, allocation nodeAllocation) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			nodenames[i], errs[i] = a.AllocateNode(ctx, hwmgrClient, pass, hwmgr, nodepool, allocation.Resource,
+				allocation.NodegroupName, allocation.HwProfile, allocation.Nodename, allocation.Index)
+		}(i, allocation)
+	}
+	wg.Wait()
+
+	var allocated []string
+	var failures []nodeAllocationFailure
+	for i, allocation := range allocations {
+		if errs[i] != nil {
+			failures = append(failures, nodeAllocationFailure{Resource: resourceDisplayName(allocation.Resource), Err: errs[i]})
+			continue
+		}
+		allocated = append(allocated, nodenames[i])
+	}
+	return allocated, failures
+}
+
+// allocationFailureMessage aggregates the failed allocations for the NodePool condition
+func allocationFailureMessage(failures []nodeAllocationFailure, total int) string {
+	descriptions := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", failure.Resource, failure.Err.Error()))
+	}
+	return fmt.Sprintf("Failed to allocate %d of %d nodes: %s", len(failures), total, strings.Join(descriptions, "; "))
+}
+
+// resourceDisplayName returns the name of a resource, or its ID if it has no name
+func resourceDisplayName(resource hwmgrapi.RhprotoResource) string {
+	if resource.Name != nil && *resource.Name != "" {
+		return *resource.Name
+	}
+	if resource.Id != nil {
+		return *resource.Id
+	}
+	return ""
+}
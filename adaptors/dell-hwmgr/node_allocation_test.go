@@ -0,0 +1,172 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestGetNodeAllocations(t *testing.T) {
+	resource := func(id string) hwmgrapi.RhprotoResource {
+		return hwmgrapi.RhprotoResource{Id: &id, Name: &id}
+	}
+	resources := func(ids ...string) hwmgrapi.RhprotoResourceSelectorGetResponse {
+		var list []hwmgrapi.RhprotoResource
+		for _, id := range ids {
+			list = append(list, resource(id))
+		}
+		return hwmgrapi.RhprotoResourceSelectorGetResponse{Resources: &list}
+	}
+	// The hardware manager does not report the resources in a stable order
+	rg := &hwmgrapi.RhprotoResourceGroupObjectGetResponseBody{
+		ResourceSelectors: &map[string]hwmgrapi.RhprotoResourceSelectorGetResponse{
+			"worker":     resources("w-3", "w-1", "w-2"),
+			"controller": resources("c-1"),
+		},
+	}
+
+	nodepool := &hwmgmtv1alpha1.NodePool{Spec: hwmgmtv1alpha1.NodePoolSpec{HwMgrId: "dell-1"}}
+	nodepool.Name = "np1"
+	nodepool.Spec.NodeGroup = []hwmgmtv1alpha1.NodeGroup{
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller", HwProfile: "controller-profile"}, Size: 1},
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker", HwProfile: "worker-profile"}, Size: 3},
+	}
+	nodepool.Status.Properties.NodeNames = []string{"node-c-1"}
+	nodelist := hwmgmtv1alpha1.NodeList{}
+	for _, node := range []struct{ name, id, group, index string }{
+		{"node-c-1", "c-1", "controller", "0"}, {"node-w-2", "w-2", "worker", "1"},
+	} {
+		item := hwmgmtv1alpha1.Node{}
+		item.Name = node.name
+		item.Annotations = map[string]string{HostnameIndexAnnotation: node.index}
+		item.Spec.NodePool = nodepool.Name
+		item.Spec.GroupName = node.group
+		item.Spec.HwMgrId = "dell-1"
+		item.Spec.HwMgrNodeId = node.id
+		nodelist.Items = append(nodelist.Items, item)
+	}
+
+	a := &Adaptor{Logger: slog.Default()}
+	allocations := a.getNodeAllocations(context.Background(), nodepool, rg, nodelist, newHostnameIndexes(nodepool, nodelist))
+	if len(allocations) != 3 {
+		t.Fatalf("expected the unrecorded worker resources to be allocated, got %+v", allocations)
+	}
+	// The interrupted allocation keeps its index, and the new nodes take the free ones in the order of the resource IDs
+	for i, expected := range []struct {
+		id, nodename string
+		index        int
+	}{{"w-1", "", 0}, {"w-2", "node-w-2", 1}, {"w-3", "", 2}} {
+		allocation := allocations[i]
+		if *allocation.Resource.Id != expected.id || allocation.Nodename != expected.nodename ||
+			allocation.Index != expected.index || allocation.NodegroupName != "worker" ||
+			allocation.HwProfile != "worker-profile" {
+			t.Errorf("unexpected allocation %d: %+v", i, allocation)
+		}
+	}
+}
+
+func TestHostnameIndexes(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Name = "np1"
+	nodelist := hwmgmtv1alpha1.NodeList{}
+	for _, node := range []struct{ nodepool, index string }{{"np1", "0"}, {"np1", "2"}, {"np2", "1"}, {"np1", "invalid"}} {
+		item := hwmgmtv1alpha1.Node{}
+		item.Annotations = map[string]string{HostnameIndexAnnotation: node.index}
+		item.Spec.NodePool = node.nodepool
+		item.Spec.GroupName = "worker"
+		nodelist.Items = append(nodelist.Items, item)
+	}
+
+	// The indexes released by a scale-in are reused, and those of other NodePools are ignored
+	indexes := newHostnameIndexes(nodepool, nodelist)
+	for _, expected := range []int{1, 3, 4} {
+		if index := indexes.next("worker"); index != expected {
+			t.Errorf("expected index %d, got %d", expected, index)
+		}
+	}
+	if index := indexes.next("controller"); index != 0 {
+		t.Errorf("expected index 0 for a new node group, got %d", index)
+	}
+}
+
+func TestAllocationHostnames(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Name = "np1"
+	nodelist := hwmgmtv1alpha1.NodeList{Items: []hwmgmtv1alpha1.Node{{}}}
+	nodelist.Items[0].Name = "node-1"
+	nodelist.Items[0].Spec.NodePool = nodepool.Name
+	nodelist.Items[0].Status.Hostname = "worker-0"
+
+	hostnames := newAllocationHostnames(nodepool, nodelist)
+	if err := hostnames.claim("worker-0", "node-1"); err != nil {
+		t.Errorf("expected a node to keep its hostname, got %v", err)
+	}
+	if err := hostnames.claim("worker-0", "node-2"); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for a duplicate hostname, got %v", err)
+	}
+	if err := hostnames.claim("worker-1", "node-2"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNodegroupHwProfile(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Spec.NodeGroup = []hwmgmtv1alpha1.NodeGroup{
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller", HwProfile: "controller-profile"}, Size: 1},
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker", HwProfile: "worker-profile"}, Size: 2},
+	}
+	profile := func(id string) hwmgrapi.RhprotoResourceSelectorGetResponse {
+		return hwmgrapi.RhprotoResourceSelectorGetResponse{ResourceProfileId: &id}
+	}
+
+	for _, tc := range []struct {
+		name, nodegroup, expected string
+		selector                  hwmgrapi.RhprotoResourceSelectorGetResponse
+	}{
+		{"selector profile", "controller", "reported-profile", profile("reported-profile")},
+		{"controller nodegroup", "controller", "controller-profile", hwmgrapi.RhprotoResourceSelectorGetResponse{}},
+		{"worker nodegroup", "worker", "worker-profile", profile("")},
+		{"unknown nodegroup", "storage", "", hwmgrapi.RhprotoResourceSelectorGetResponse{}},
+	} {
+		if hwprofile := nodegroupHwProfile(nodepool, tc.nodegroup, tc.selector); hwprofile != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.expected, hwprofile)
+		}
+	}
+}
+
+func TestGetAllocationConcurrency(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if concurrency := getAllocationConcurrency(hwmgr); concurrency != defaultAllocationConcurrency {
+		t.Errorf("expected the default concurrency, got %d", concurrency)
+	}
+
+	concurrency := 2
+	hwmgr.Spec.DellData = &pluginv1alpha1.DellData{AllocationConcurrency: &concurrency}
+	if result := getAllocationConcurrency(hwmgr); result != 2 {
+		t.Errorf("expected the configured concurrency, got %d", result)
+	}
+}
+
+func TestAllocationFailureMessage(t *testing.T) {
+	message := allocationFailureMessage([]nodeAllocationFailure{
+		{Resource: "w-1", Err: errors.New("bmc unreachable")},
+		{Resource: "w-3", Err: errors.New("invalid interface list")},
+	}, 5)
+	expected := "Failed to allocate 2 of 5 nodes: w-1: bmc unreachable; w-3: invalid interface list"
+	if message != expected {
+		t.Errorf("unexpected message: %s", message)
+	}
+}
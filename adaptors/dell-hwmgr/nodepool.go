@@ -11,7 +11,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
-	"slices"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -28,6 +27,10 @@ import (
 // ValidateNodePool performs basic validation of the nodepool data
 func (a *Adaptor) ValidateNodePool(nodepool *hwmgmtv1alpha1.NodePool) error {
 	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		// The hardware profile is requested per nodegroup, so each nodegroup with nodes needs its own
+		if nodegroup.Size > 0 && nodegroup.NodePoolData.HwProfile == "" {
+			return fmt.Errorf("nodegroup %s has no hwProfile", nodegroup.NodePoolData.Name)
+		}
 		if nodegroup.NodePoolData.ResourceSelector != "" {
 			// Validate that the resourceSelector is parsable
 			selectors := make(map[string]string)
@@ -37,6 +40,14 @@ func (a *Adaptor) ValidateNodePool(nodepool *hwmgmtv1alpha1.NodePool) error {
 		}
 	}
 
+	if err := utils.ValidateNodePoolExtensions(nodepool); err != nil {
+		return fmt.Errorf("invalid extensions: %w", err)
+	}
+
+	if err := validateResourceFilters(nodepool); err != nil {
+		return fmt.Errorf("invalid resource filters: %w", err)
+	}
+
 	return nil
 }
 
@@ -78,6 +89,9 @@ func (a *Adaptor) HandleNodePoolCreate(
 		return utils.DoNotRequeue(), nil
 	}
 
+	// Subscribe the resources the NodePool is allocated from before its resource group job starts
+	a.subscribeNodePool(ctx, hwmgrClient, hwmgr, nodepool)
+
 	if err := a.ProcessNewNodePool(ctx, hwmgrClient, hwmgr, nodepool); err != nil {
 		a.Logger.InfoContext(ctx, "failed ProcessNewNodePool", slog.String("err", err.Error()))
 		conditionReason = hwmgmtv1alpha1.Failed
@@ -118,7 +132,7 @@ func (a *Adaptor) ProcessNewNodePool(ctx context.Context,
 	}
 
 	// Add the jobId in an annotation
-	utils.SetJobId(nodepool, jobId)
+	utils.SetJobId(nodepool, jobId, a.clock())
 
 	if err := utils.CreateOrUpdateK8sCR(ctx, a.Client, nodepool, nil, utils.PATCH); err != nil {
 		return fmt.Errorf("failed to annotate nodepool %s: %w", nodepool.Name, err)
@@ -146,21 +160,39 @@ func (a *Adaptor) HandleNodePoolProcessing(
 	ctx = logging.AppendCtx(ctx, slog.String("jobId", jobId))
 
 	// Query the hardware manager for the job status
-	status, failReason, err := hwmgrClient.CheckJobStatus(ctx, jobId)
+	tracker := newJobTracker(hwmgr, utils.OperationResourceGroupJob, a.clock())
+	start, startKnown := utils.GetJobStartTime(nodepool)
+	progress, err := tracker.check(ctx, hwmgrClient, jobId, start, startKnown)
 	if err != nil {
 		a.Logger.InfoContext(ctx, "Resource group check failed", slog.String("error", err.Error()))
-		return result, fmt.Errorf("failed to check job progress, jobId=%s: %w", jobId, err)
+		return result, err
 	}
+	status, failReason := progress.Status, progress.FailReason
 
 	// Process the status response
 	switch status {
 	case hwmgrclient.JobStatusInProgress:
-		return utils.RequeueWithShortInterval(), nil
+		if progress.TimedOut {
+			a.Logger.InfoContext(ctx, "Resource group creation job timed out", slog.Duration("timeout", tracker.timeout))
+			if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+				hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
+				fmt.Sprintf("Resource group creation job %s timed out after %s", jobId, tracker.timeout)); err != nil {
+				return utils.RequeueWithMediumInterval(),
+					fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+			}
+			return result, fmt.Errorf("resource group creation job timed out, jobId=%s, timeout=%s", jobId, tracker.timeout)
+		}
+		return tracker.requeue(), nil
 	case hwmgrclient.JobStatusFailed:
+		failure := progress.Failure
+		failure.Operation = "ResourceGroupCreation"
 		a.Logger.InfoContext(ctx, "Resource group creation failed", slog.String("failReason", failReason))
+		if err := utils.UpdateNodePoolJobFailure(ctx, a.Client, nodepool, &failure); err != nil {
+			return utils.RequeueWithMediumInterval(), err
+		}
 		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
 			hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
-			fmt.Sprintf("Resource group creation failed: %s", failReason)); err != nil {
+			fmt.Sprintf("Resource group creation job %s failed: %s", jobId, failure)); err != nil {
 			return utils.RequeueWithMediumInterval(),
 				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
 		}
@@ -210,45 +242,30 @@ func (a *Adaptor) HandleNodePoolProcessing(
 	}
 
 	// Create the Node CRs corresponding to the allocated resources
-	for nodegroupName, resourceSelector := range *rg.ResourceSelectors {
-		for _, node := range *resourceSelector.Resources {
-			nodename := utils.FindNodeInList(nodelist, nodepool.Spec.HwMgrId, *node.Id)
-			if nodename != "" {
-				// Node CR exists
-				if slices.Contains(nodepool.Status.Properties.NodeNames, nodename) {
-					a.Logger.InfoContext(ctx, "Node is already added",
-						slog.String("nodename", nodename),
-						slog.String("nodeId", *node.Id))
-					continue
-				} else {
-					// TODO: Validate that the CR is current. For now, fail, as something went wrong
-					a.Logger.InfoContext(ctx, "Node previously allocated, but not in nodepool properties",
-						slog.String("nodename", nodename),
-						slog.String("nodeId", *node.Id))
-					if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
-						hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
-						fmt.Sprintf("Failed with partially allocated node: %s, %s", nodename, *node.Id)); err != nil {
-						return utils.RequeueWithMediumInterval(),
-							fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
-					}
-
-					return utils.DoNotRequeue(), nil
-				}
-			}
-			if nodename, err := a.AllocateNode(ctx, hwmgrClient, nodepool, node, nodegroupName); err != nil {
-				a.Logger.InfoContext(ctx, "Failed allocating node", slog.String("err", err.Error()))
-				if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
-					hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
-					fmt.Sprintf("Failed to allocate node (%s): %s", *node.Name, err.Error())); err != nil {
-					return utils.RequeueWithMediumInterval(),
-						fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
-				}
+	allocations := a.getNodeAllocations(ctx, nodepool, rg, nodelist, newHostnameIndexes(nodepool, nodelist))
+	allocated, failures := a.allocateNodes(ctx, hwmgrClient, newAllocationPass(hwmgrClient, nodepool, nodelist), hwmgr,
+		nodepool, allocations)
+	nodepool.Status.Properties.NodeNames = append(nodepool.Status.Properties.NodeNames, allocated...)
+
+	if len(failures) > 0 {
+		for _, failure := range failures {
+			a.Logger.InfoContext(ctx, "Failed allocating node", slog.String("resource", failure.Resource),
+				slog.String("err", failure.Err.Error()))
+		}
 
-				return utils.DoNotRequeue(), nil
-			} else {
-				nodepool.Status.Properties.NodeNames = append(nodepool.Status.Properties.NodeNames, nodename)
-			}
+		// Record the allocated nodes, so that they are not allocated again
+		if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
+			return utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+			hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
+			allocationFailureMessage(failures, len(allocations))); err != nil {
+			return utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
 		}
+
+		return utils.DoNotRequeue(), nil
 	}
 
 	// Update the NodePool CR
@@ -266,6 +283,7 @@ func (a *Adaptor) HandleNodePoolProcessing(
 	}
 
 	utils.ClearJobId(nodepool)
+	utils.ClearJobFailure(nodepool)
 	if err := utils.CreateOrUpdateK8sCR(ctx, a.Client, nodepool, nil, utils.PATCH); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to clear annotation from nodepool %s: %w", nodepool.Name, err)
 	}
@@ -284,7 +302,10 @@ func (a *Adaptor) CheckDeletionJobStatus(ctx context.Context,
 
 	a.Logger.InfoContext(ctx, "Checking deletion job status")
 
-	status, failReason, err := hwmgrClient.CheckJobStatus(ctx, jobId)
+	tracker := newJobTracker(hwmgr, utils.OperationResourceGroupJob, a.clock())
+	start, startKnown := utils.GetDeletionJobStartTime(nodepool)
+	progress, err := tracker.check(ctx, hwmgrClient, jobId, start, startKnown)
+	status, failReason := progress.Status, progress.FailReason
 	if err != nil {
 		a.Logger.InfoContext(ctx, "Deletion job progress check failed", slog.String("error", err.Error()))
 		//TODO: This should return false. Returning true for debug testing. Do not merge like this
@@ -295,6 +316,10 @@ func (a *Adaptor) CheckDeletionJobStatus(ctx context.Context,
 	// Process the status response
 	switch status {
 	case hwmgrclient.JobStatusInProgress:
+		if progress.TimedOut {
+			a.Logger.ErrorContext(ctx, "Deletion job timed out", slog.Duration("timeout", tracker.timeout))
+			return false, fmt.Errorf("deletion %w, jobId=%s, timeout=%s", errJobTimedOut, jobId, tracker.timeout)
+		}
 		a.Logger.InfoContext(ctx, "Deletion job is in progress")
 		return false, nil
 	case hwmgrclient.JobStatusFailed:
@@ -325,6 +350,11 @@ func (a *Adaptor) ReleaseNodePool(ctx context.Context,
 		if err != nil {
 			return false, fmt.Errorf("failed CheckDeletionJobStatus: %w", err)
 		}
+		if completed {
+			// The resources are only removed from the subscription once released, so that the notifications of the
+			// deletion job are still received
+			a.unsubscribeNodePool(ctx, hwmgrClient, hwmgr, nodepool)
+		}
 		return completed, nil
 	}
 
@@ -347,7 +377,7 @@ func (a *Adaptor) ReleaseNodePool(ctx context.Context,
 	a.Logger.InfoContext(ctx, "Annotating CR with deletion jobId", slog.String("annotating-ResourceVersion", refreshedNodepool.ResourceVersion))
 
 	// Add the jobId in an annotation
-	utils.SetDeletionJobId(refreshedNodepool, jobId)
+	utils.SetDeletionJobId(refreshedNodepool, jobId, a.clock())
 	if err := utils.CreateOrUpdateK8sCR(ctx, a.Client, refreshedNodepool, nil, utils.PATCH); err != nil {
 		return false, fmt.Errorf("failed to annotate nodepool %s: %w", refreshedNodepool.Name, err)
 	}
@@ -359,6 +389,7 @@ func (a *Adaptor) ReleaseNodePool(ctx context.Context,
 func (a *Adaptor) handleNodePoolConfiguring(
 	ctx context.Context,
 	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
 	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
 
 	var result ctrl.Result
@@ -382,27 +413,66 @@ func (a *Adaptor) handleNodePoolConfiguring(
 		}
 
 		// Query the hardware manager for the job status
-		status, failReason, err := hwmgrClient.CheckJobStatus(ctx, jobId)
+		tracker := newJobTracker(hwmgr, utils.OperationFirmwareJob, a.clock())
+		start, startKnown := utils.GetJobStartTime(node)
+		progress, err := tracker.check(ctx, hwmgrClient, jobId, start, startKnown)
 		if err != nil {
 			a.Logger.InfoContext(ctx, "Profile update job progress check failed", slog.String("error", err.Error()))
-			return result, fmt.Errorf("failed to check profile update job progress, jobId=%s: %w", jobId, err)
+			return result, fmt.Errorf("failed to check profile update job progress: %w", err)
 		}
+		status, failReason := progress.Status, progress.FailReason
 
 		// Process the status response
 		switch status {
 		case hwmgrclient.JobStatusInProgress:
-			return utils.RequeueWithShortInterval(), nil
+			if progress.TimedOut {
+				timeout := tracker.timeout
+				a.Logger.InfoContext(ctx, "Profile update job timed out", slog.String("jobId", jobId), slog.Duration("timeout", timeout))
+				if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+					hwmgmtv1alpha1.Configured,
+					hwmgmtv1alpha1.Failed,
+					metav1.ConditionFalse,
+					fmt.Sprintf("Profile update timed out after %s on node %s", timeout, node.Name)); err != nil {
+					return utils.RequeueWithMediumInterval(),
+						fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+				}
+				if err := utils.FailNodeProfileUpdate(ctx, a.Client, node,
+					fmt.Sprintf("Profile update to %s timed out after %s, jobId=%s", node.Spec.HwProfile, timeout, jobId)); err != nil {
+					a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+				}
+				if err := utils.FinishNodeOperation(ctx, a.Client, node.Name, node.Namespace, utils.NodeOperationTimedOut,
+					fmt.Sprintf("Timed out after %s", timeout), a.clock()); err != nil {
+					a.Logger.ErrorContext(ctx, "failed to record operation outcome", slog.String("node", node.Name), slog.String("error", err.Error()))
+				}
+				return result, fmt.Errorf("profile update job timed out, jobId=%s, timeout=%s", jobId, timeout)
+			}
+			return tracker.requeue(), nil
 		case hwmgrclient.JobStatusFailed:
-			a.Logger.InfoContext(ctx, "Profile update creation failed", slog.String("failReason", failReason))
+			failure := progress.Failure
+			failure.Operation = "ProfileUpdate"
+			if len(failure.ResourceIds) == 0 {
+				failure.ResourceIds = []string{node.Spec.HwMgrNodeId}
+			}
+			a.Logger.InfoContext(ctx, "Profile update creation failed", slog.String("failReason", failReason),
+				slog.Any("resourceIds", failure.ResourceIds))
+			if err := utils.UpdateNodePoolJobFailure(ctx, a.Client, nodepool, &failure); err != nil {
+				return utils.RequeueWithMediumInterval(), err
+			}
 			if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
 				hwmgmtv1alpha1.Configured,
 				hwmgmtv1alpha1.Failed,
 				metav1.ConditionFalse,
-				fmt.Sprintf("Profile update creation failed: %s", failReason)); err != nil {
+				fmt.Sprintf("Profile update of node %s failed: %s", node.Name, failure)); err != nil {
 				return utils.RequeueWithMediumInterval(),
 					fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
 			}
-			// TODO: Mark the config change as failed
+			if err := utils.FailNodeProfileUpdate(ctx, a.Client, node,
+				fmt.Sprintf("Profile update to %s failed: %s", node.Spec.HwProfile, failure)); err != nil {
+				a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+			}
+			if err := utils.FinishNodeOperation(ctx, a.Client, node.Name, node.Namespace, utils.NodeOperationFailed, failReason, a.clock()); err != nil {
+				a.Logger.ErrorContext(ctx, "failed to record operation outcome", slog.String("node", node.Name), slog.String("error", err.Error()))
+			}
 			return result, fmt.Errorf("profile update creation failed, jobId=%s: %s", jobId, failReason)
 		case hwmgrclient.JobStatusCompleted:
 			a.Logger.InfoContext(ctx, "Profile update job has completed")
@@ -414,14 +484,20 @@ func (a *Adaptor) handleNodePoolConfiguring(
 			return result, fmt.Errorf("failed to check profile update job progress, jobId=%s: %s", jobId, failReason)
 		}
 
+		// The BIOS attributes of the hardware profile are verified once its resource profile is applied
+		if biosResult, done, err := a.verifyProfileBiosAttributes(ctx, hwmgrClient, newServerInventory(hwmgrClient),
+			nodepool, node); !done {
+			return biosResult, err
+		}
+
 		// Node update is complete
 		a.Logger.InfoContext(ctx, "Node update complete", slog.String("nodename", node.Name))
-		node.Status.HwProfile = node.Spec.HwProfile
-		if err := utils.UpdateK8sCRStatus(ctx, a.Client, node); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to update status for node %s: %w", node.Name, err)
+		if err := utils.CompleteNodeProfileUpdate(ctx, a.Client, node); err != nil {
+			return ctrl.Result{}, err // nolint: wrapcheck
 		}
 
 		utils.ClearJobId(node)
+		utils.EndNodeOperation(node, utils.NodeOperationSucceeded, "", a.clock())
 		if err := utils.CreateOrUpdateK8sCR(ctx, a.Client, node, nil, utils.PATCH); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to clear annotation from node %s: %w", node.Name, err)
 		}
@@ -440,6 +516,12 @@ func (a *Adaptor) handleNodePoolConfiguring(
 			continue
 		}
 
+		if hasPowerAction(node) {
+			// The power action of the node runs first, so that the profile update job is not started concurrently
+			a.Logger.InfoContext(ctx, "Profile update waiting for power action", slog.String("nodename", node.Name))
+			return utils.RequeueWithMediumInterval(), nil
+		}
+
 		a.Logger.InfoContext(ctx, "Issuing profile update to node",
 			slog.String("hwMgrNodeId", node.Spec.HwMgrNodeId),
 			slog.String("curHwProfile", node.Spec.HwProfile),
@@ -456,32 +538,28 @@ func (a *Adaptor) handleNodePoolConfiguring(
 			slog.String("jobId", jobId),
 		)
 
-		// Copy the current node object for patching
-		patch := client.MergeFrom(node.DeepCopy())
-
-		// Set the new profile in the spec
-		node.Spec.HwProfile = newHwProfile
-
-		// Record the jobId in an annotation
-		utils.SetJobId(node, jobId)
-
-		if err = a.Client.Patch(ctx, node, patch); err != nil {
-			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to patch Node %s in namespace %s: %w", node.Name, node.Namespace, err)
+		if err := utils.RequestNodeProfileUpdate(ctx, a.Client, node, newHwProfile, jobId, a.clock()); err != nil {
+			return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
 		}
 
 		// Requeue to check update progress
 		return utils.RequeueWithMediumInterval(), nil
 	}
 
+	// Cordoned nodes are left with their current profile, holding back the update until they are uncordoned
+	if held, err := utils.HoldUpdateForCordonedNodes(ctx, a.Client, a.Logger, nodepool, nodelist); err != nil {
+		return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
+	} else if held {
+		return utils.RequeueWithMediumInterval(), nil
+	}
+
 	// All nodes have been updated
 	a.Logger.InfoContext(ctx, "All nodes have been updated to new profile")
-	if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
-		hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.ConfigApplied, metav1.ConditionTrue, string(hwmgmtv1alpha1.ConfigSuccess)); err != nil {
-		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	if err := utils.UpdateNodePoolJobFailure(ctx, a.Client, nodepool, nil); err != nil {
+		return utils.RequeueWithShortInterval(), err
 	}
-	// Update the Node Pool hwMgrPlugin status
-	if err = utils.UpdateNodePoolPluginStatus(ctx, a.Client, nodepool); err != nil {
-		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update hwMgrPlugin observedGeneration Status: %w", err)
+	if err := utils.CompleteNodePoolConfiguration(ctx, a.Client, nodepool); err != nil {
+		return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
 	}
 
 	return result, nil
@@ -505,5 +583,17 @@ func (a *Adaptor) HandleNodePoolSpecChanged(
 			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
 	}
 
-	return a.handleNodePoolConfiguring(ctx, hwmgrClient, nodepool)
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+	if scaledOut, result, err := a.scaleOutNodePool(ctx, hwmgrClient, hwmgr, nodepool, nodelist); err != nil || !scaledOut {
+		return result, err
+	}
+
+	if scaledIn, result, err := a.scaleInNodePool(ctx, hwmgrClient, hwmgr, nodepool, nodelist); err != nil || !scaledIn {
+		return result, err
+	}
+
+	return a.handleNodePoolConfiguring(ctx, hwmgrClient, hwmgr, nodepool)
 }
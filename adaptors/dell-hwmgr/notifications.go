@@ -0,0 +1,113 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"log/slog"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// nodeResourceIds returns the hardware manager resource identifiers of the nodes
+func nodeResourceIds(nodelist *hwmgmtv1alpha1.NodeList) []string {
+	resourceIds := make([]string, 0, len(nodelist.Items))
+	for _, node := range nodelist.Items {
+		if node.Spec.HwMgrNodeId != "" {
+			resourceIds = append(resourceIds, node.Spec.HwMgrNodeId)
+		}
+	}
+	return resourceIds
+}
+
+// poolResourceIds returns the identifiers of the resources of the pools selected for the NodePool
+func poolResourceIds(nodepool *hwmgmtv1alpha1.NodePool, resources []hwmgrapi.ApiprotoResource) []string {
+	pools := make(map[string]bool, len(nodepool.Status.SelectedPools))
+	for _, pool := range nodepool.Status.SelectedPools {
+		pools[pool] = true
+	}
+
+	var resourceIds []string
+	for _, resource := range resources {
+		if resource.Id != nil && resource.ResourcePoolId != nil && pools[*resource.ResourcePoolId] {
+			resourceIds = append(resourceIds, *resource.Id)
+		}
+	}
+	return resourceIds
+}
+
+// subscribeNodePool adds the resources of the pools selected for a new NodePool to the resource subscription of the
+// HardwareManager, so that the notifications about the resources the hardware manager allocates to it are received
+// from the start of its resource group job. Failures are logged rather than returned, as jobs are still polled at the
+// fallback interval.
+func (a *Adaptor) subscribeNodePool(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) {
+
+	if hwmgr.Spec.DellData.Notifications == nil {
+		return
+	}
+
+	resources, err := hwmgrClient.GetResources(ctx)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to get resources for notification subscription", slog.String("error", err.Error()))
+		return
+	}
+	a.updateNotificationSubscription(ctx, hwmgrClient, hwmgr, poolResourceIds(nodepool, *resources.Resources), false)
+}
+
+// unsubscribeNodePool removes the resources allocated to a released NodePool from the resource subscription of the
+// HardwareManager. Failures are logged rather than returned, so that the release is not held up.
+func (a *Adaptor) unsubscribeNodePool(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) {
+
+	if hwmgr.Spec.DellData.Notifications == nil {
+		return
+	}
+
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to get nodes for notification subscription", slog.String("error", err.Error()))
+		return
+	}
+	a.updateNotificationSubscription(ctx, hwmgrClient, hwmgr, nodeResourceIds(nodelist), true)
+}
+
+// updateNotificationSubscription adds the resources to the resource subscription of the HardwareManager, or removes
+// them if unsubscribe is set. Failures are logged rather than returned, as jobs are still polled at the fallback
+// interval.
+func (a *Adaptor) updateNotificationSubscription(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	resourceIds []string,
+	unsubscribe bool) {
+
+	subscriptionId := hwmgr.Spec.DellData.Notifications.SubscriptionId
+
+	var err error
+	if unsubscribe {
+		err = hwmgrClient.UnsubscribeResources(ctx, subscriptionId, resourceIds)
+	} else {
+		err = hwmgrClient.SubscribeResources(ctx, subscriptionId, resourceIds)
+	}
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Failed to update notification subscription, relying on polling",
+			slog.String("subscriptionId", subscriptionId), slog.Bool("unsubscribe", unsubscribe),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	a.Logger.InfoContext(ctx, "Updated notification subscription", slog.String("subscriptionId", subscriptionId),
+		slog.Bool("unsubscribe", unsubscribe), slog.Int("resources", len(resourceIds)))
+}
@@ -0,0 +1,339 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/redfish"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+const (
+	// PowerActionAnnotation requests a power action on the server of a Node: reboot, off or on. It is removed once the
+	// action has completed or failed, with the outcome reported by the PowerAction condition of the Node.
+	PowerActionAnnotation = "hwmgr-plugin.oran.openshift.io/power-action"
+	// PowerActionStateAnnotation records the progress of the power action of a Node, as JSON
+	PowerActionStateAnnotation = "hwmgr-plugin.oran.openshift.io/power-action-state"
+)
+
+// NodeConditionPowerAction is the Node condition reporting the progress and outcome of a power action
+const NodeConditionPowerAction = "PowerAction"
+
+// NodeOperationPowerAction is the type of the node operation running a power action
+const NodeOperationPowerAction = "power-action"
+
+// powerActionTimeout bounds how long a server may take to reach the power state of a power action
+const powerActionTimeout = 10 * time.Minute
+
+// powerAction is a power action, requested from the BMC of the server through the Redfish ComputerSystem.Reset action
+type powerAction struct {
+	// resetType is the Redfish reset type requested
+	resetType string
+	// powerState is the power state of the server once the action is done, or empty if it cannot be observed
+	powerState string
+}
+
+// powerActions are the supported power actions
+var powerActions = map[string]powerAction{
+	"reboot": {resetType: "GracefulRestart"},
+	"off":    {resetType: "GracefulShutdown", powerState: redfish.PowerStateOff},
+	"on":     {resetType: "On", powerState: redfish.PowerStateOn},
+}
+
+// powerActionState is the progress of a power action, as recorded on the Node
+type powerActionState struct {
+	Action    string    `json:"action"`
+	StartTime time.Time `json:"startTime"`
+	// Issued is set once the BMC has accepted the reset. A state recorded without it is an action whose request may
+	// have been interrupted.
+	Issued bool `json:"issued,omitempty"`
+}
+
+// getPowerAction returns a supported power action
+func getPowerAction(action string) (powerAction, error) {
+	result, exists := powerActions[action]
+	if !exists {
+		return powerAction{}, typederrors.NewInputError("unsupported %s annotation value: %q, expected one of reboot, off, on",
+			PowerActionAnnotation, action)
+	}
+	return result, nil
+}
+
+// getPowerActionState returns the progress of the power action recorded on the Node, or nil if not started
+func getPowerActionState(node *hwmgmtv1alpha1.Node) (*powerActionState, error) {
+	value, exists := node.GetAnnotations()[PowerActionStateAnnotation]
+	if !exists {
+		return nil, nil
+	}
+	state := &powerActionState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", PowerActionStateAnnotation, err)
+	}
+	return state, nil
+}
+
+// setPowerActionState records the progress of the power action on the Node
+func setPowerActionState(node *hwmgmtv1alpha1.Node, state *powerActionState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal power action state: %w", err)
+	}
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[PowerActionStateAnnotation] = string(value)
+	return nil
+}
+
+// hasPowerAction checks whether a power action is requested on the object
+func hasPowerAction(object client.Object) bool {
+	_, exists := object.GetAnnotations()[PowerActionAnnotation]
+	return exists
+}
+
+// powerActionReconciler runs the power actions requested on the Nodes allocated from Dell hardware managers
+type powerActionReconciler struct {
+	*Adaptor
+}
+
+// Reconcile starts the power action requested on the Node, or checks its progress
+func (r *powerActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	node := &hwmgmtv1alpha1.Node{}
+	if err := r.Client.Get(ctx, req.NamespacedName, node); err != nil {
+		if errors.IsNotFound(err) {
+			return utils.DoNotRequeue(), nil
+		}
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to get Node %s: %w", req.Name, err)
+	}
+
+	action, requested := node.GetAnnotations()[PowerActionAnnotation]
+	if !requested || node.Spec.HwMgrId == "" {
+		return utils.DoNotRequeue(), nil
+	}
+
+	ctx = logging.AppendCtx(ctx, slog.String("node", node.Name))
+
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: node.Spec.HwMgrId, Namespace: r.Namespace}, hwmgr); err != nil {
+		if errors.IsNotFound(err) {
+			return utils.DoNotRequeue(), nil
+		}
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to get HardwareManager %s: %w", node.Spec.HwMgrId, err)
+	}
+	if hwmgr.Spec.AdaptorID != pluginv1alpha1.SupportedAdaptors.Dell {
+		return utils.DoNotRequeue(), nil
+	}
+
+	state, err := getPowerActionState(node)
+	if err != nil {
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.Failed, err.Error())
+	}
+	if state == nil {
+		return r.startPowerAction(ctx, hwmgr, node, action)
+	}
+	return r.checkPowerAction(ctx, hwmgr, node, state)
+}
+
+// startPowerAction records the power action on the Node, once no job is running on it, and issues it. The action is
+// recorded before it is issued, with a patch conditioned on the resource version of the Node, so that a job started
+// on the Node in the meantime is seen on the next attempt, and an interrupted action is never issued twice.
+func (r *powerActionReconciler) startPowerAction(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	node *hwmgmtv1alpha1.Node, action string) (ctrl.Result, error) {
+	if _, err := getPowerAction(action); err != nil {
+		r.Logger.InfoContext(ctx, "Rejecting power action", slog.String("error", err.Error()))
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.InvalidInput, err.Error())
+	}
+
+	if utils.GetJobId(node) != "" {
+		// The node is being configured, so the power action waits for the job to complete
+		if err := utils.SetNodeConditionStatus(ctx, r.Client, node.Name, node.Namespace,
+			NodeConditionPowerAction, metav1.ConditionFalse, string(hwmgmtv1alpha1.InProgress),
+			fmt.Sprintf("Power action %s waiting for job %s to complete", action, utils.GetJobId(node))); err != nil {
+			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update node status (%s): %w", node.Name, err)
+		}
+		return utils.RequeueWithMediumInterval(), nil
+	}
+
+	state := &powerActionState{Action: action, StartTime: r.clock().Now().UTC()}
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if err := setPowerActionState(node, state); err != nil {
+		return utils.RequeueWithShortInterval(), err
+	}
+	utils.StartNodeOperation(node, NodeOperationPowerAction, "", r.clock())
+	if err := r.Client.Patch(ctx, node, patch); err != nil {
+		if errors.IsConflict(err) {
+			return utils.RequeueImmediately(), nil
+		}
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to patch Node %s in namespace %s: %w", node.Name, node.Namespace, err)
+	}
+
+	return r.issuePowerAction(ctx, hwmgr, node, state)
+}
+
+// issuePowerAction requests the reset from the BMC of the server. An action with an observable power state is only
+// requested if the server is not already in that state, so that it can be safely issued again.
+func (r *powerActionReconciler) issuePowerAction(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	node *hwmgmtv1alpha1.Node, state *powerActionState) (ctrl.Result, error) {
+	action, err := getPowerAction(state.Action)
+	if err != nil {
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.InvalidInput, err.Error())
+	}
+
+	rf, systemPath, err := r.nodeRedfishSystem(ctx, hwmgr, node)
+	if err != nil {
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.Failed,
+			fmt.Sprintf("Power action %s failed: %s", state.Action, err.Error()))
+	}
+
+	if action.powerState != "" {
+		if powerState, err := rf.PowerState(ctx, systemPath); err == nil &&
+			strings.EqualFold(powerState, action.powerState) {
+			r.Logger.InfoContext(ctx, "Server already in the requested power state", slog.String("action", state.Action))
+			return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionTrue, hwmgmtv1alpha1.Completed,
+				fmt.Sprintf("Power action %s completed", state.Action))
+		}
+	}
+
+	r.Logger.InfoContext(ctx, "Issuing power action to node",
+		slog.String("action", state.Action),
+		slog.String("resetType", action.resetType))
+
+	if err := rf.Reset(ctx, systemPath, action.resetType); err != nil {
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.Failed,
+			fmt.Sprintf("Power action %s failed: %s", state.Action, err.Error()))
+	}
+	if action.powerState == "" {
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionTrue, hwmgmtv1alpha1.Completed,
+			fmt.Sprintf("Power action %s completed", state.Action))
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	state.Issued = true
+	if err := setPowerActionState(node, state); err != nil {
+		return utils.RequeueWithShortInterval(), err
+	}
+	if err := r.Client.Patch(ctx, node, patch); err != nil {
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to patch Node %s in namespace %s: %w", node.Name, node.Namespace, err)
+	}
+
+	if err := utils.SetNodeConditionStatus(ctx, r.Client, node.Name, node.Namespace,
+		NodeConditionPowerAction, metav1.ConditionFalse, string(hwmgmtv1alpha1.InProgress),
+		fmt.Sprintf("Power action %s in progress", state.Action)); err != nil {
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update node status (%s): %w", node.Name, err)
+	}
+
+	return utils.RequeueWithCustomInterval(getJobPollInterval(hwmgr)), nil
+}
+
+// checkPowerAction checks the progress of the recorded power action, reporting its outcome once the server has reached
+// its power state. An action recorded but not confirmed as issued is issued again if its power state can be checked,
+// while an interrupted reboot is reported as failed rather than risk rebooting the server twice.
+func (r *powerActionReconciler) checkPowerAction(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	node *hwmgmtv1alpha1.Node, state *powerActionState) (ctrl.Result, error) {
+	action, err := getPowerAction(state.Action)
+	if err != nil {
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.InvalidInput, err.Error())
+	}
+
+	if !state.Issued {
+		if action.powerState == "" {
+			return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.Failed,
+				fmt.Sprintf("Power action %s was interrupted and may not have been issued", state.Action))
+		}
+		return r.issuePowerAction(ctx, hwmgr, node, state)
+	}
+
+	rf, systemPath, err := r.nodeRedfishSystem(ctx, hwmgr, node)
+	if err == nil {
+		var powerState string
+		if powerState, err = rf.PowerState(ctx, systemPath); err == nil &&
+			strings.EqualFold(powerState, action.powerState) {
+			r.Logger.InfoContext(ctx, "Power action completed", slog.String("action", state.Action))
+			return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionTrue, hwmgmtv1alpha1.Completed,
+				fmt.Sprintf("Power action %s completed", state.Action))
+		}
+	}
+	if err != nil {
+		r.Logger.InfoContext(ctx, "Unable to get power state", slog.String("error", err.Error()))
+	}
+
+	if r.clock().Since(state.StartTime) > powerActionTimeout {
+		return utils.DoNotRequeue(), r.finishPowerAction(ctx, node, metav1.ConditionFalse, hwmgmtv1alpha1.TimedOut,
+			fmt.Sprintf("Power action %s timed out after %s", state.Action, powerActionTimeout))
+	}
+	return utils.RequeueWithCustomInterval(getJobPollInterval(hwmgr)), nil
+}
+
+// nodeRedfishSystem returns a client of the BMC of the server of a Node and the path of its Redfish system
+func (r *powerActionReconciler) nodeRedfishSystem(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	node *hwmgmtv1alpha1.Node) (*redfish.Client, string, error) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: node.Spec.NodePool, Namespace: node.Namespace}, nodepool); err != nil {
+		return nil, "", fmt.Errorf("failed to get NodePool %s: %w", node.Spec.NodePool, err)
+	}
+	httpClient, err := r.newBMCHTTPClient(ctx, hwmgr)
+	if err != nil {
+		return nil, "", err
+	}
+	return r.bmcRedfishSystem(ctx, httpClient, hwmgr, nodepool, node)
+}
+
+// finishPowerAction reports the outcome of the power action of the node, and removes its annotations so that a new
+// action can be requested
+func (r *powerActionReconciler) finishPowerAction(ctx context.Context, node *hwmgmtv1alpha1.Node,
+	status metav1.ConditionStatus, reason hwmgmtv1alpha1.ConditionReason, message string) error {
+	patch := client.MergeFrom(node.DeepCopy())
+	if _, started := node.Annotations[PowerActionStateAnnotation]; started {
+		outcome := utils.NodeOperationSucceeded
+		if status != metav1.ConditionTrue {
+			outcome = utils.NodeOperationFailed
+		}
+		utils.EndNodeOperation(node, outcome, message, r.clock())
+	}
+	delete(node.Annotations, PowerActionAnnotation)
+	delete(node.Annotations, PowerActionStateAnnotation)
+	if err := r.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to patch Node %s in namespace %s: %w", node.Name, node.Namespace, err)
+	}
+
+	if err := utils.SetNodeConditionStatus(ctx, r.Client, node.Name, node.Namespace,
+		NodeConditionPowerAction, status, string(reason), message); err != nil {
+		return fmt.Errorf("failed to update node status (%s): %w", node.Name, err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the power action controller with the Manager
+func (r *powerActionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("dell-power-action").
+		For(&hwmgmtv1alpha1.Node{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(hasPowerAction),
+			predicate.AnnotationChangedPredicate{})).
+		Complete(r); err != nil {
+		return fmt.Errorf("failed to setup power action controller: %w", err)
+	}
+	return nil
+}
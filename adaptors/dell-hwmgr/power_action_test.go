@@ -0,0 +1,79 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestGetPowerAction(t *testing.T) {
+	tests := []struct {
+		action   string
+		expected powerAction
+	}{
+		{action: "reboot", expected: powerAction{resetType: "GracefulRestart"}},
+		{action: "off", expected: powerAction{resetType: "GracefulShutdown", powerState: "Off"}},
+		{action: "on", expected: powerAction{resetType: "On", powerState: "On"}},
+	}
+	for _, tt := range tests {
+		action, err := getPowerAction(tt.action)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.action, err)
+		}
+		if action != tt.expected {
+			t.Errorf("%s: expected %+v, got %+v", tt.action, tt.expected, action)
+		}
+	}
+
+	for _, action := range []string{"", "Reboot", "cycle"} {
+		if _, err := getPowerAction(action); !typederrors.IsInputError(err) {
+			t.Errorf("%q: expected an input error, got %v", action, err)
+		}
+	}
+}
+
+func TestPowerActionState(t *testing.T) {
+	node := &hwmgmtv1alpha1.Node{}
+	if state, err := getPowerActionState(node); err != nil || state != nil {
+		t.Fatalf("expected no power action state, got %v, %v", state, err)
+	}
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := setPowerActionState(node, &powerActionState{Action: "off", StartTime: start}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state, err := getPowerActionState(node)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.Action != "off" || !state.StartTime.Equal(start) || state.Issued {
+		t.Errorf("expected an off action recorded as not yet issued, got %+v", state)
+	}
+
+	node.Annotations[PowerActionStateAnnotation] = "{"
+	if _, err := getPowerActionState(node); err == nil {
+		t.Errorf("expected an error for an invalid power action state")
+	}
+}
+
+func TestHasPowerAction(t *testing.T) {
+	node := &hwmgmtv1alpha1.Node{}
+	if hasPowerAction(node) {
+		t.Errorf("expected no power action on a node without annotations")
+	}
+
+	node.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{PowerActionAnnotation: "reboot"}}
+	if !hasPowerAction(node) {
+		t.Errorf("expected a power action")
+	}
+}
@@ -0,0 +1,73 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/redfish"
+)
+
+// getBMCCredentials reads the credentials of a node from its bmc-secret
+func (a *Adaptor) getBMCCredentials(ctx context.Context, bmcSecret types.NamespacedName) (redfish.Credentials, error) {
+	secret := &corev1.Secret{}
+	if err := a.Client.Get(ctx, bmcSecret, secret); err != nil {
+		return redfish.Credentials{}, fmt.Errorf("failed to get bmc-secret %s: %w", bmcSecret, err)
+	}
+	return redfish.CredentialsFromSecret(secret), nil
+}
+
+// resolveBMCAddress completes the BMC address reported by the hardware manager with the Redfish system path, either
+// from the HardwareManager override or discovered from the BMC. If the path cannot be discovered, the address is used
+// as reported, as it may still be usable.
+func (a *Adaptor) resolveBMCAddress(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, bmcSecret types.NamespacedName, address string) string {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to parse BMC address")
+		return address
+	}
+
+	if hwmgr.Spec.DellData != nil && hwmgr.Spec.DellData.RedfishSystemPath != nil && *hwmgr.Spec.DellData.RedfishSystemPath != "" {
+		return redfish.WithSystemPath(parsed, *hwmgr.Spec.DellData.RedfishSystemPath)
+	}
+
+	if redfish.HasSystemPath(parsed) {
+		return address
+	}
+
+	creds, err := a.getBMCCredentials(ctx, bmcSecret)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to discover Redfish system path", slog.String("error", err.Error()))
+		return address
+	}
+	httpClient, err := a.newBMCHTTPClient(ctx, hwmgr)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to discover Redfish system path", slog.String("error", err.Error()))
+		return address
+	}
+	rf, err := redfish.NewClient(httpClient, address, creds)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to discover Redfish system path", slog.String("error", err.Error()))
+		return address
+	}
+
+	systemPath, err := rf.DiscoverSystemPath(ctx)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to discover Redfish system path", slog.String("error", err.Error()))
+		return address
+	}
+
+	a.Logger.InfoContext(ctx, "Discovered Redfish system path", slog.String("systemPath", systemPath))
+	return redfish.WithSystemPath(parsed, systemPath)
+}
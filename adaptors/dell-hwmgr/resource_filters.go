@@ -0,0 +1,100 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// reservedFilterTags are the resource labels set by the plugin itself, which cannot be requested as tags
+var reservedFilterTags = []string{hwmgrclient.RoleKey, hwmgrclient.CPUArchitectureKey, hwmgrclient.RackKey}
+
+// getResourceFilters returns the site, rack and tags requested for the node group by the NodePool extensions
+func getResourceFilters(nodepool *hwmgmtv1alpha1.NodePool, groupName string) (pluginv1alpha1.ResourceFilters, error) {
+	extensions, err := utils.GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return pluginv1alpha1.ResourceFilters{}, err
+	}
+	return extensions.GetResourceFilters(groupName), nil
+}
+
+// validateResourceFilters checks that the resource filters of each node group do not use a reserved tag, and agree
+// with the resource selector of the node group and the site of the NodePool
+func validateResourceFilters(nodepool *hwmgmtv1alpha1.NodePool) error {
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		groupName := nodegroup.NodePoolData.Name
+		filters, err := getResourceFilters(nodepool, groupName)
+		if err != nil {
+			return err
+		}
+
+		if filters.Site != "" && nodepool.Spec.Site != "" && filters.Site != nodepool.Spec.Site {
+			return fmt.Errorf("site %s requested for node group %s conflicts with the site of the NodePool: %s",
+				filters.Site, groupName, nodepool.Spec.Site)
+		}
+
+		for _, key := range reservedFilterTags {
+			if _, exists := filters.Tags[key]; exists {
+				return fmt.Errorf("tag %s requested for node group %s is reserved", key, groupName)
+			}
+		}
+
+		selectors := make(map[string]string)
+		if nodegroup.NodePoolData.ResourceSelector != "" {
+			if err := json.Unmarshal([]byte(nodegroup.NodePoolData.ResourceSelector), &selectors); err != nil {
+				return fmt.Errorf("unable to parse resourceSelector: %s", nodegroup.NodePoolData.ResourceSelector)
+			}
+		}
+		labels := hwmgrclient.ResourceFilterLabels(filters)
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if value, exists := selectors[key]; exists && value != labels[key] {
+				return fmt.Errorf("%s=%s requested for node group %s conflicts with its resourceSelector: %s=%s",
+					key, labels[key], groupName, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// describeResourceFilters formats the resource filters for messages, omitting the unset ones
+func describeResourceFilters(filters pluginv1alpha1.ResourceFilters) string {
+	var parts []string
+	if filters.Site != "" {
+		parts = append(parts, "site: "+filters.Site)
+	}
+	if filters.Rack != "" {
+		parts = append(parts, "rack: "+filters.Rack)
+	}
+	if len(filters.Tags) > 0 {
+		tags := make([]string, 0, len(filters.Tags))
+		for key, value := range filters.Tags {
+			tags = append(tags, key+"="+value)
+		}
+		sort.Strings(tags)
+		parts = append(parts, "tags: "+strings.Join(tags, ","))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// poolInSite checks whether the resource pool belongs to the site, if one is requested
+func poolInSite(pool hwmgrapi.ApiprotoResourcePool, site string) bool {
+	return site == "" || (pool.SiteId != nil && *pool.SiteId == site)
+}
@@ -0,0 +1,101 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"testing"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateResourceFilters(t *testing.T) {
+	newNodePool := func(site, resourceSelector string, extensions map[string]string) *hwmgmtv1alpha1.NodePool {
+		return &hwmgmtv1alpha1.NodePool{
+			Spec: hwmgmtv1alpha1.NodePoolSpec{
+				LocationSpec: hwmgmtv1alpha1.LocationSpec{Site: site},
+				NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+					{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker", ResourceSelector: resourceSelector}},
+				},
+				Extensions: extensions,
+			},
+		}
+	}
+
+	testcases := []struct {
+		name     string
+		nodepool *hwmgmtv1alpha1.NodePool
+		valid    bool
+	}{
+		{
+			name:     "no filters",
+			nodepool: newNodePool("", "", nil),
+			valid:    true,
+		},
+		{
+			name: "consistent filters",
+			nodepool: newNodePool("site-a", `{"model":"r740"}`, map[string]string{
+				"site": "site-a", "worker.rack": "r1", "tags": "model=r740,zone=east",
+			}),
+			valid: true,
+		},
+		{
+			name:     "site conflicting with the NodePool",
+			nodepool: newNodePool("site-a", "", map[string]string{"worker.site": "site-b"}),
+		},
+		{
+			name:     "reserved tag",
+			nodepool: newNodePool("", "", map[string]string{"tags": "role=worker"}),
+		},
+		{
+			name:     "tag conflicting with the resource selector",
+			nodepool: newNodePool("", `{"model":"r740"}`, map[string]string{"worker.tags": "model=r650"}),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateResourceFilters(tc.nodepool)
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestFindMatchingPoolInSite(t *testing.T) {
+	pools := &hwmgrapi.ApiprotoResourcePoolsResp{
+		ResourcePools: &[]hwmgrapi.ApiprotoResourcePool{
+			{Id: ptr.To("pool-a"), SiteId: ptr.To("site-a")},
+			{Id: ptr.To("pool-b"), SiteId: ptr.To("site-b")},
+		},
+	}
+	labels := []hwmgrapi.ApiprotoLabel{{Key: ptr.To("rack"), Value: ptr.To("r1")}}
+	resources := &hwmgrapi.ApiprotoGetResourcesResp{
+		Resources: &[]hwmgrapi.ApiprotoResource{
+			{Id: ptr.To("server-a"), ResourcePoolId: ptr.To("pool-a")},
+			{Id: ptr.To("server-b"), ResourcePoolId: ptr.To("pool-b"), Labels: &labels},
+		},
+	}
+
+	if pool := findMatchingPool(pools, nil, resources, nil, "", 1); pool != "pool-a" {
+		t.Errorf("expected pool-a without filters, got %q", pool)
+	}
+	if pool := findMatchingPool(pools, nil, resources, nil, "site-b", 1); pool != "pool-b" {
+		t.Errorf("expected pool-b in site-b, got %q", pool)
+	}
+	if pool := findMatchingPool(pools, nil, resources, map[string]string{"rack": "r1"}, "", 1); pool != "pool-b" {
+		t.Errorf("expected pool-b for rack r1, got %q", pool)
+	}
+	if pool := findMatchingPool(pools, nil, resources, map[string]string{"rack": "r1"}, "site-a", 1); pool != "" {
+		t.Errorf("expected no pool for rack r1 in site-a, got %q", pool)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// ScaleInCandidateAnnotation marks a node to release first when the size of its node group is decreased
+const ScaleInCandidateAnnotation = "hwmgr-plugin.oran.openshift.io/scale-in-candidate"
+
+// ScaleInAnnotation marks a node selected for release by a scale-in in progress
+const ScaleInAnnotation = "hwmgr-plugin.oran.openshift.io/scale-in"
+
+// nodeGroupScaleIn is a node group whose requested size is below its allocated nodes
+type nodeGroupScaleIn struct {
+	Name      string
+	Allocated int
+	Requested int
+}
+
+// findScaleIn returns the node groups of the NodePool that request fewer nodes than are allocated to them
+func findScaleIn(nodepool *hwmgmtv1alpha1.NodePool, nodelist *hwmgmtv1alpha1.NodeList) []nodeGroupScaleIn {
+	allocated := make(map[string]int)
+	for _, node := range nodelist.Items {
+		allocated[node.Spec.GroupName]++
+	}
+
+	var scaleIn []nodeGroupScaleIn
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		name := nodegroup.NodePoolData.Name
+		if nodegroup.Size < allocated[name] {
+			scaleIn = append(scaleIn, nodeGroupScaleIn{Name: name, Allocated: allocated[name], Requested: nodegroup.Size})
+		}
+	}
+	return scaleIn
+}
+
+// scaleInMessage describes the node groups to scale in
+func scaleInMessage(scaleIn []nodeGroupScaleIn) string {
+	descriptions := make([]string, 0, len(scaleIn))
+	for _, group := range scaleIn {
+		descriptions = append(descriptions, fmt.Sprintf("%s from %d to %d nodes", group.Name, group.Allocated, group.Requested))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// getScaleInPolicy returns the order in which the nodes of a node group are released
+func getScaleInPolicy(hwmgr *pluginv1alpha1.HardwareManager) pluginv1alpha1.ScaleInPolicy {
+	if hwmgr.Spec.DellData != nil && hwmgr.Spec.DellData.ScaleInPolicy != "" {
+		return hwmgr.Spec.DellData.ScaleInPolicy
+	}
+	return pluginv1alpha1.ScaleInPolicies.NewestFirst
+}
+
+// selectScaleInNodes returns the nodes to release from the node groups to scale in. Only the nodes added by a scale-out
+// can be released, as each has a resource group of its own, while resources cannot be released from the resource group
+// of the NodePool. The candidates annotated for scale-in come first, then the others in the order of the policy. A node
+// group without enough nodes that can be released is reported as a failure.
+func selectScaleInNodes(nodelist *hwmgmtv1alpha1.NodeList, scaleIn []nodeGroupScaleIn,
+	policy pluginv1alpha1.ScaleInPolicy) ([]*hwmgmtv1alpha1.Node, []string) {
+	var selected []*hwmgmtv1alpha1.Node
+	var failures []string
+	for _, group := range scaleIn {
+		var candidates []*hwmgmtv1alpha1.Node
+		for i := range nodelist.Items {
+			node := &nodelist.Items[i]
+			if node.Spec.GroupName == group.Name && node.GetAnnotations()[ResourceGroupAnnotation] != "" {
+				candidates = append(candidates, node)
+			}
+		}
+
+		count := group.Allocated - group.Requested
+		if len(candidates) < count {
+			failures = append(failures, fmt.Sprintf("%s has %d nodes added by a scale-out to release %d from", group.Name,
+				len(candidates), count))
+			continue
+		}
+
+		slices.SortStableFunc(candidates, func(a, b *hwmgmtv1alpha1.Node) int {
+			_, aMarked := a.GetAnnotations()[ScaleInCandidateAnnotation]
+			_, bMarked := b.GetAnnotations()[ScaleInCandidateAnnotation]
+			if aMarked != bMarked {
+				if aMarked {
+					return -1
+				}
+				return 1
+			}
+			order := a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+			if order == 0 {
+				order = strings.Compare(a.Name, b.Name)
+			}
+			if policy == pluginv1alpha1.ScaleInPolicies.NewestFirst {
+				return -order
+			}
+			return order
+		})
+		selected = append(selected, candidates[:count]...)
+	}
+	return selected, failures
+}
+
+// scaleInNodePool releases the nodes removed from the node groups of the NodePool, returning true once they are
+// released. The nodes to release are selected and marked first, so that the selection holds across passes. Their
+// resource groups are then deleted, after which their BMC secrets and Node CRs are deleted.
+func (a *Adaptor) scaleInNodePool(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	nodelist *hwmgmtv1alpha1.NodeList) (bool, ctrl.Result, error) {
+
+	var nodes []*hwmgmtv1alpha1.Node
+	for i := range nodelist.Items {
+		if _, marked := nodelist.Items[i].GetAnnotations()[ScaleInAnnotation]; marked {
+			nodes = append(nodes, &nodelist.Items[i])
+		}
+	}
+
+	if len(nodes) == 0 {
+		scaleIn := findScaleIn(nodepool, nodelist)
+		if len(scaleIn) == 0 {
+			return true, ctrl.Result{}, nil
+		}
+
+		var failures []string
+		nodes, failures = selectScaleInNodes(nodelist, scaleIn, getScaleInPolicy(hwmgr))
+		if len(failures) > 0 {
+			message := "Scale-in failed, as resources cannot be released from the resource group of the NodePool: " +
+				strings.Join(failures, "; ")
+			a.Logger.InfoContext(ctx, message)
+			if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+				hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.Failed, metav1.ConditionFalse, message); err != nil {
+				return false, utils.RequeueWithMediumInterval(),
+					fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+			}
+			return false, utils.DoNotRequeue(), nil
+		}
+
+		a.Logger.InfoContext(ctx, "Scaling in NodePool", slog.String("nodeGroups", scaleInMessage(scaleIn)))
+		for _, node := range nodes {
+			if err := a.markScaleInNode(ctx, node); err != nil {
+				return false, utils.RequeueWithShortInterval(), err
+			}
+		}
+		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+			hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse,
+			"Scaling in "+scaleInMessage(scaleIn)); err != nil {
+			return false, utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.GetAnnotations()[ResourceGroupAnnotation])
+	}
+	released, err := a.releaseResourceGroups(ctx, hwmgrClient, hwmgr, nodepool, ids)
+	if err != nil {
+		message := "Scale-in failed: " + err.Error()
+		a.Logger.InfoContext(ctx, message)
+		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+			hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.Failed, metav1.ConditionFalse, message); err != nil {
+			return false, utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+		return false, utils.RequeueWithMediumInterval(), nil
+	}
+	if !released {
+		return false, newJobTracker(hwmgr, utils.OperationResourceGroupJob, a.clock()).requeue(), nil
+	}
+
+	// The resources are released. Remove their nodes from the NodePool, before deleting them, so that a node deleted
+	// by an interrupted pass is not left in the NodePool.
+	nodepool.Status.Properties.NodeNames = slices.DeleteFunc(nodepool.Status.Properties.NodeNames, func(name string) bool {
+		return slices.ContainsFunc(nodes, func(node *hwmgmtv1alpha1.Node) bool { return node.Name == name })
+	})
+	if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
+		return false, utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	for _, node := range nodes {
+		if err := a.deleteReleasedNode(ctx, hwmgr, nodepool, node); err != nil {
+			return false, utils.RequeueWithShortInterval(), err
+		}
+	}
+
+	a.Logger.InfoContext(ctx, "NodePool scale-in is complete", slog.Int("nodes", len(nodes)))
+	return true, ctrl.Result{}, nil
+}
+
+// markScaleInNode marks a node selected for release
+func (a *Adaptor) markScaleInNode(ctx context.Context, node *hwmgmtv1alpha1.Node) error {
+	patch := client.MergeFrom(node.DeepCopy())
+	annotations := node.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ScaleInAnnotation] = "true"
+	node.SetAnnotations(annotations)
+	if err := a.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to mark node %s for scale-in: %w", node.Name, err)
+	}
+	return nil
+}
+
+// deleteReleasedNode deletes the BMC secret and the Node CR of a released node
+func (a *Adaptor) deleteReleasedNode(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool, node *hwmgmtv1alpha1.Node) error {
+	bmcSecret, err := a.getNodeBMCSecretKey(hwmgr, nodepool, node)
+	if err != nil {
+		return fmt.Errorf("failed to get bmc-secret name for node %s: %w", node.Name, err)
+	}
+	a.deleteBMCSecret(ctx, bmcSecret)
+
+	if err := a.Client.Delete(ctx, node); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete released node %s: %w", node.Name, err)
+	}
+	a.Logger.InfoContext(ctx, "Deleted released node", slog.String("nodename", node.Name),
+		slog.String("nodeId", node.Spec.HwMgrNodeId))
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func TestFindScaleIn(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	for name, size := range map[string]int{"controller": 3, "worker": 1} {
+		nodegroup := hwmgmtv1alpha1.NodeGroup{Size: size}
+		nodegroup.NodePoolData.Name = name
+		nodepool.Spec.NodeGroup = append(nodepool.Spec.NodeGroup, nodegroup)
+	}
+
+	nodelist := &hwmgmtv1alpha1.NodeList{}
+	for _, group := range []string{"controller", "controller", "controller", "worker", "worker", "worker"} {
+		node := hwmgmtv1alpha1.Node{}
+		node.Spec.GroupName = group
+		nodelist.Items = append(nodelist.Items, node)
+	}
+
+	scaleIn := findScaleIn(nodepool, nodelist)
+	if len(scaleIn) != 1 || scaleIn[0] != (nodeGroupScaleIn{Name: "worker", Allocated: 3, Requested: 1}) {
+		t.Fatalf("expected the worker group to scale in, got %v", scaleIn)
+	}
+	if message := scaleInMessage(scaleIn); message != "worker from 3 to 1 nodes" {
+		t.Errorf("unexpected message: %s", message)
+	}
+
+	nodelist.Items = nodelist.Items[:4]
+	if scaleIn := findScaleIn(nodepool, nodelist); len(scaleIn) != 0 {
+		t.Errorf("expected no scale in, got %v", scaleIn)
+	}
+}
+
+func TestSelectScaleInNodes(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	newNode := func(name, group string, age time.Duration, annotations map[string]string) hwmgmtv1alpha1.Node {
+		node := hwmgmtv1alpha1.Node{}
+		node.Name = name
+		node.Spec.GroupName = group
+		node.CreationTimestamp = metav1.NewTime(start.Add(-age))
+		node.SetAnnotations(annotations)
+		return node
+	}
+	scaledOut := func(rgId string) map[string]string {
+		return map[string]string{ResourceGroupAnnotation: rgId}
+	}
+
+	nodelist := &hwmgmtv1alpha1.NodeList{Items: []hwmgmtv1alpha1.Node{
+		newNode("initial", "worker", 3*time.Hour, nil),
+		newNode("oldest", "worker", 2*time.Hour, scaledOut("rg-1")),
+		newNode("newest", "worker", 0, scaledOut("rg-2")),
+		newNode("middle", "worker", time.Hour, scaledOut("rg-3")),
+		newNode("controller", "controller", 0, scaledOut("rg-4")),
+	}}
+	names := func(nodes []*hwmgmtv1alpha1.Node) []string {
+		var names []string
+		for _, node := range nodes {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+	scaleIn := []nodeGroupScaleIn{{Name: "worker", Allocated: 4, Requested: 2}}
+
+	testcases := []struct {
+		name     string
+		policy   pluginv1alpha1.ScaleInPolicy
+		expected []string
+	}{
+		{name: "newest first", policy: pluginv1alpha1.ScaleInPolicies.NewestFirst, expected: []string{"newest", "middle"}},
+		{name: "oldest first", policy: pluginv1alpha1.ScaleInPolicies.OldestFirst, expected: []string{"oldest", "middle"}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			nodes, failures := selectScaleInNodes(nodelist, scaleIn, tc.policy)
+			if len(failures) != 0 || !slices.Equal(names(nodes), tc.expected) {
+				t.Errorf("expected %v, got %v, %v", tc.expected, names(nodes), failures)
+			}
+		})
+	}
+
+	// Annotated candidates are released first, regardless of the policy
+	nodelist.Items[1].Annotations[ScaleInCandidateAnnotation] = ""
+	nodes, _ := selectScaleInNodes(nodelist, scaleIn, pluginv1alpha1.ScaleInPolicies.NewestFirst)
+	if expected := []string{"oldest", "newest"}; !slices.Equal(names(nodes), expected) {
+		t.Errorf("expected the candidate first, %v, got %v", expected, names(nodes))
+	}
+
+	// The nodes of the resource group of the NodePool cannot be released
+	nodes, failures := selectScaleInNodes(nodelist, []nodeGroupScaleIn{{Name: "worker", Allocated: 4, Requested: 0}},
+		pluginv1alpha1.ScaleInPolicies.NewestFirst)
+	if len(nodes) != 0 || len(failures) != 1 {
+		t.Errorf("expected a failure to release the initial node, got %v, %v", names(nodes), failures)
+	}
+}
+
+func TestGetScaleInPolicy(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if policy := getScaleInPolicy(hwmgr); policy != pluginv1alpha1.ScaleInPolicies.NewestFirst {
+		t.Errorf("expected NewestFirst by default, got %s", policy)
+	}
+	hwmgr.Spec.DellData = &pluginv1alpha1.DellData{ScaleInPolicy: pluginv1alpha1.ScaleInPolicies.OldestFirst}
+	if policy := getScaleInPolicy(hwmgr); policy != pluginv1alpha1.ScaleInPolicies.OldestFirst {
+		t.Errorf("expected OldestFirst, got %s", policy)
+	}
+}
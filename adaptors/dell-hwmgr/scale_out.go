@@ -0,0 +1,519 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// ScaleOutStateAnnotation records the resource groups being created to scale out a NodePool, as JSON
+const ScaleOutStateAnnotation = "hwmgr-plugin.oran.openshift.io/scale-out-state"
+
+// ResourceGroupAnnotation records the resource group allocating a node added by a scale-out. The nodes allocated with
+// the NodePool belong to its own resource group, and do not have it.
+const ResourceGroupAnnotation = "hwmgr-plugin.oran.openshift.io/resource-group"
+
+// ResourceGroupReleaseAnnotation records the deletion of the resource groups of the nodes added by a scale-out, as JSON
+const ResourceGroupReleaseAnnotation = "hwmgr-plugin.oran.openshift.io/resource-group-release"
+
+// nodeGroupScaleOut is a node group whose requested size exceeds its allocated nodes
+type nodeGroupScaleOut struct {
+	Name      string
+	Allocated int
+	Requested int
+}
+
+// scaleOutGroup is a resource group created to allocate a node added by a scale-out
+type scaleOutGroup struct {
+	Id        string `json:"id"`
+	NodeGroup string `json:"nodeGroup"`
+	JobId     string `json:"jobId,omitempty"`
+	// Created is set once the creation of the resource group is requested
+	Created bool `json:"created,omitempty"`
+}
+
+// done returns true once the resource group is created
+func (g scaleOutGroup) done() bool {
+	return g.Created && g.JobId == ""
+}
+
+// scaleOutState is the progress of a scale-out, as recorded on the NodePool
+type scaleOutState struct {
+	StartTime time.Time       `json:"startTime"`
+	Groups    []scaleOutGroup `json:"groups"`
+	// Failures are the resource groups that could not be created
+	Failures []string `json:"failures,omitempty"`
+}
+
+// resourceGroupRelease is the progress of the deletion of resource groups, as recorded on the NodePool
+type resourceGroupRelease struct {
+	StartTime time.Time `json:"startTime"`
+	// Jobs are the deletion jobs, by resource group. An empty job is a resource group already deleted.
+	Jobs map[string]string `json:"jobs"`
+}
+
+// findScaleOut returns the node groups of the NodePool that request more nodes than are allocated to them
+func findScaleOut(nodepool *hwmgmtv1alpha1.NodePool, nodelist *hwmgmtv1alpha1.NodeList) []nodeGroupScaleOut {
+	allocated := make(map[string]int)
+	for _, node := range nodelist.Items {
+		allocated[node.Spec.GroupName]++
+	}
+
+	var scaleOut []nodeGroupScaleOut
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		name := nodegroup.NodePoolData.Name
+		if nodegroup.Size > allocated[name] {
+			scaleOut = append(scaleOut, nodeGroupScaleOut{Name: name, Allocated: allocated[name], Requested: nodegroup.Size})
+		}
+	}
+	return scaleOut
+}
+
+// scaleOutMessage describes the node groups to scale out
+func scaleOutMessage(scaleOut []nodeGroupScaleOut) string {
+	descriptions := make([]string, 0, len(scaleOut))
+	for _, group := range scaleOut {
+		descriptions = append(descriptions, fmt.Sprintf("%s from %d to %d nodes", group.Name, group.Allocated, group.Requested))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// newScaleOutState returns the resource groups to create for a scale-out, one for each node to add, so that each node
+// can later be released on its own
+func newScaleOutState(nodepool *hwmgmtv1alpha1.NodePool, scaleOut []nodeGroupScaleOut, now time.Time) *scaleOutState {
+	state := &scaleOutState{StartTime: now}
+	for _, group := range scaleOut {
+		for i := group.Allocated; i < group.Requested; i++ {
+			state.Groups = append(state.Groups, scaleOutGroup{
+				Id:        hwmgrclient.ScaleOutResourceGroupId(nodepool, uuid.NewString()[:8]),
+				NodeGroup: group.Name,
+			})
+		}
+	}
+	return state
+}
+
+// getScaleOutState returns the progress of the scale-out recorded on the NodePool, or nil if none is in progress
+func getScaleOutState(nodepool *hwmgmtv1alpha1.NodePool) (*scaleOutState, error) {
+	value, exists := nodepool.GetAnnotations()[ScaleOutStateAnnotation]
+	if !exists {
+		return nil, nil
+	}
+	state := &scaleOutState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", ScaleOutStateAnnotation, err)
+	}
+	return state, nil
+}
+
+// setScaleOutState records the progress of the scale-out on the NodePool, clearing it if nil
+func setScaleOutState(nodepool *hwmgmtv1alpha1.NodePool, state *scaleOutState) error {
+	return setJSONAnnotation(nodepool, ScaleOutStateAnnotation, state)
+}
+
+// setJSONAnnotation sets an annotation to the JSON encoding of the value, or removes it if the value is nil
+func setJSONAnnotation[T any](object client.Object, annotation string, value *T) error {
+	annotations := object.GetAnnotations()
+	if value == nil {
+		delete(annotations, annotation)
+		object.SetAnnotations(annotations)
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s annotation: %w", annotation, err)
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[annotation] = string(data)
+	object.SetAnnotations(annotations)
+	return nil
+}
+
+// scaleOutNodePool allocates the nodes added to the node groups of the NodePool, returning true once they are
+// allocated. As the hardware manager API does not support updating a resource group, each node is allocated by a
+// resource group of its own, recorded on the Node so that it can be released on its own by a scale-in, and along
+// with the NodePool.
+func (a *Adaptor) scaleOutNodePool(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	nodelist *hwmgmtv1alpha1.NodeList) (bool, ctrl.Result, error) {
+
+	state, err := getScaleOutState(nodepool)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if state == nil {
+		scaleOut := findScaleOut(nodepool, nodelist)
+		if len(scaleOut) == 0 {
+			return true, ctrl.Result{}, nil
+		}
+
+		// The resource groups are recorded before they are created, so that they are tracked, and deleted with the
+		// NodePool. The optimistic lock ensures that two passes do not both start a scale-out.
+		a.Logger.InfoContext(ctx, "Scaling out NodePool", slog.String("nodeGroups", scaleOutMessage(scaleOut)))
+		state = newScaleOutState(nodepool, scaleOut, a.clock().Now().UTC())
+		patch := client.MergeFromWithOptions(nodepool.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if err := setScaleOutState(nodepool, state); err != nil {
+			return false, utils.RequeueWithShortInterval(), err
+		}
+		if err := a.Client.Patch(ctx, nodepool, patch); err != nil {
+			if errors.IsConflict(err) {
+				return false, utils.RequeueImmediately(), nil
+			}
+			return false, utils.RequeueWithShortInterval(),
+				fmt.Errorf("failed to patch scale-out state of nodepool %s: %w", nodepool.Name, err)
+		}
+		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+			hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse,
+			"Scaling out "+scaleOutMessage(scaleOut)); err != nil {
+			return false, utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+	}
+
+	tracker := newJobTracker(hwmgr, utils.OperationResourceGroupJob, a.clock())
+	updated := a.createScaleOutGroups(ctx, hwmgrClient, nodepool, state)
+	if a.checkScaleOutGroups(ctx, hwmgrClient, tracker, state) {
+		updated = true
+	}
+	if updated {
+		if err := a.updateScaleOutState(ctx, nodepool, state); err != nil {
+			return false, utils.RequeueWithShortInterval(), err
+		}
+	}
+
+	for _, group := range state.Groups {
+		if !group.done() {
+			return false, tracker.requeue(), nil
+		}
+	}
+
+	// The resource groups are created. Allocate their resources as nodes.
+	remaining, failures := a.allocateScaleOutNodes(ctx, hwmgrClient, hwmgr, nodepool, nodelist, state)
+	failures = append(state.Failures, failures...)
+	if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
+		return false, utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+
+	// The resource groups whose nodes failed allocation are kept, so that the allocation is retried
+	var next *scaleOutState
+	if len(remaining) > 0 {
+		next = &scaleOutState{StartTime: state.StartTime, Groups: remaining}
+	}
+	if err := a.updateScaleOutState(ctx, nodepool, next); err != nil {
+		return false, utils.RequeueWithShortInterval(), err
+	}
+
+	if len(failures) > 0 {
+		message := "Scale-out failed: " + strings.Join(failures, "; ")
+		a.Logger.InfoContext(ctx, message)
+		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+			hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.Failed, metav1.ConditionFalse, message); err != nil {
+			return false, utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+		if next != nil {
+			return false, utils.RequeueWithMediumInterval(), nil
+		}
+		return false, utils.DoNotRequeue(), nil
+	}
+
+	a.Logger.InfoContext(ctx, "NodePool scale-out is complete", slog.Int("nodes", len(state.Groups)))
+	return true, ctrl.Result{}, nil
+}
+
+// createScaleOutGroups requests the creation of the resource groups of the scale-out not yet created, returning true
+// if the state is updated. A resource group found to exist already was created by an interrupted pass.
+func (a *Adaptor) createScaleOutGroups(ctx context.Context, hwmgrClient *hwmgrclient.HardwareManagerClient,
+	nodepool *hwmgmtv1alpha1.NodePool, state *scaleOutState) bool {
+	updated := false
+	for i := range state.Groups {
+		group := &state.Groups[i]
+		if group.Created {
+			continue
+		}
+
+		exists, err := hwmgrClient.ResourceGroupIdExists(ctx, group.Id)
+		if err != nil {
+			a.Logger.InfoContext(ctx, "Resource group existence check failed", slog.String("resourceGroup", group.Id),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if !exists {
+			jobId, err := hwmgrClient.CreateScaleOutResourceGroup(ctx, nodepool, group.Id, group.NodeGroup)
+			if err != nil {
+				a.Logger.InfoContext(ctx, "Failed to create scale-out resource group", slog.String("resourceGroup", group.Id),
+					slog.String("error", err.Error()))
+				continue
+			}
+			group.JobId = jobId
+		}
+		group.Created = true
+		updated = true
+	}
+	return updated
+}
+
+// checkScaleOutGroups checks the jobs creating the resource groups of the scale-out, returning true if the state is
+// updated. A resource group whose creation fails or times out is recorded as a failure, and dropped from the scale-out
+// once its deletion is requested.
+func (a *Adaptor) checkScaleOutGroups(ctx context.Context, hwmgrClient *hwmgrclient.HardwareManagerClient,
+	tracker *jobTracker, state *scaleOutState) bool {
+	updated := false
+	groups := state.Groups[:0]
+	for _, group := range state.Groups {
+		if group.JobId == "" {
+			groups = append(groups, group)
+			continue
+		}
+
+		progress, err := tracker.check(ctx, hwmgrClient, group.JobId, state.StartTime, true)
+		var failure string
+		switch {
+		case err != nil:
+			a.Logger.InfoContext(ctx, "Scale-out job check failed", slog.String("resourceGroup", group.Id),
+				slog.String("error", err.Error()))
+		case progress.Status == hwmgrclient.JobStatusCompleted:
+			group.JobId = ""
+			updated = true
+		case progress.Status == hwmgrclient.JobStatusFailed:
+			failure = fmt.Sprintf("resource group %s for nodegroup %s: %s", group.Id, group.NodeGroup, progress.Failure)
+		case progress.Status == hwmgrclient.JobStatusNotExist:
+			failure = fmt.Sprintf("resource group %s for nodegroup %s: job %s no longer exists", group.Id, group.NodeGroup, group.JobId)
+		case progress.TimedOut:
+			failure = fmt.Sprintf("resource group %s for nodegroup %s: job %s timed out after %s", group.Id, group.NodeGroup,
+				group.JobId, tracker.timeout)
+		}
+
+		if failure != "" {
+			if _, err := hwmgrClient.DeleteResourceGroupFromId(ctx, group.Id); err != nil {
+				// Keep tracking the resource group, so that it is deleted with the NodePool
+				a.Logger.InfoContext(ctx, "Failed to delete failed scale-out resource group",
+					slog.String("resourceGroup", group.Id), slog.String("error", err.Error()))
+				groups = append(groups, group)
+				continue
+			}
+			state.Failures = append(state.Failures, failure)
+			updated = true
+			continue
+		}
+		groups = append(groups, group)
+	}
+	state.Groups = groups
+	return updated
+}
+
+// allocateScaleOutNodes creates the Node CRs corresponding to the resources of the scale-out resource groups, recording
+// their resource group on each. It returns the resource groups whose nodes failed allocation, and the failures. A new
+// node takes the lowest index free in its node group, available to the hostname template.
+func (a *Adaptor) allocateScaleOutNodes(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	nodelist *hwmgmtv1alpha1.NodeList,
+	state *scaleOutState) ([]scaleOutGroup, []string) {
+
+	indexes := newHostnameIndexes(nodepool, *nodelist)
+	pass := newAllocationPass(hwmgrClient, nodepool, *nodelist)
+	var remaining []scaleOutGroup
+	var failures []string
+	for _, group := range state.Groups {
+		rg, err := hwmgrClient.GetResourceGroupFromId(ctx, group.Id)
+		if err != nil {
+			remaining = append(remaining, group)
+			failures = append(failures, fmt.Sprintf("resource group %s: %s", group.Id, err.Error()))
+			continue
+		}
+
+		allocations := a.getNodeAllocations(ctx, nodepool, rg, *nodelist, indexes)
+		allocated, allocationFailures := a.allocateNodes(ctx, hwmgrClient, pass, hwmgr, nodepool, allocations)
+		failed := len(allocationFailures) > 0
+		for _, failure := range allocationFailures {
+			failures = append(failures, fmt.Sprintf("%s: %s", failure.Resource, failure.Err.Error()))
+		}
+		for _, nodename := range allocated {
+			if err := a.setNodeResourceGroup(ctx, nodename, group.Id); err != nil {
+				failed = true
+				failures = append(failures, err.Error())
+				continue
+			}
+			nodepool.Status.Properties.NodeNames = append(nodepool.Status.Properties.NodeNames, nodename)
+		}
+		if failed {
+			remaining = append(remaining, group)
+		}
+	}
+	return remaining, failures
+}
+
+// setNodeResourceGroup records on a Node the resource group allocating it
+func (a *Adaptor) setNodeResourceGroup(ctx context.Context, nodename, rgId string) error {
+	node := &hwmgmtv1alpha1.Node{}
+	if err := a.Client.Get(ctx, client.ObjectKey{Name: nodename, Namespace: a.Namespace}, node); err != nil {
+		return fmt.Errorf("failed to get node %s: %w", nodename, err)
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	annotations := node.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ResourceGroupAnnotation] = rgId
+	node.SetAnnotations(annotations)
+	if err := a.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to record resource group of node %s: %w", nodename, err)
+	}
+	return nil
+}
+
+// updateScaleOutState records the progress of the scale-out on the NodePool, clearing it if nil
+func (a *Adaptor) updateScaleOutState(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, state *scaleOutState) error {
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	if err := setScaleOutState(nodepool, state); err != nil {
+		return err
+	}
+	if err := a.Client.Patch(ctx, nodepool, patch); err != nil {
+		return fmt.Errorf("failed to patch scale-out state of nodepool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
+
+// getScaleOutResourceGroups returns the resource groups created by the scale-outs of a NodePool: those of its nodes, and
+// those of a scale-out in progress
+func getScaleOutResourceGroups(nodepool *hwmgmtv1alpha1.NodePool, nodes []hwmgmtv1alpha1.Node) []string {
+	var ids []string
+	for _, node := range nodes {
+		if id := node.GetAnnotations()[ResourceGroupAnnotation]; id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if state, err := getScaleOutState(nodepool); err == nil && state != nil {
+		for _, group := range state.Groups {
+			if !slices.Contains(ids, group.Id) {
+				ids = append(ids, group.Id)
+			}
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// releaseScaleOutGroups deletes the resource groups created by the scale-outs of a deleted NodePool, returning true
+// once they are deleted
+func (a *Adaptor) releaseScaleOutGroups(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return false, fmt.Errorf("failed to get child nodes for NodePool %s: %w", nodepool.Name, err)
+	}
+	return a.releaseResourceGroups(ctx, hwmgrClient, hwmgr, nodepool, getScaleOutResourceGroups(nodepool, nodelist.Items))
+}
+
+// releaseResourceGroups deletes the given resource groups, tracking the deletion jobs on the NodePool, and returns true
+// once they are deleted. A failed or timed out deletion is reported as an error, and requested again on the next pass.
+func (a *Adaptor) releaseResourceGroups(ctx context.Context,
+	hwmgrClient *hwmgrclient.HardwareManagerClient,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	ids []string) (bool, error) {
+	if len(ids) == 0 {
+		return true, nil
+	}
+
+	release := &resourceGroupRelease{}
+	if value, exists := nodepool.GetAnnotations()[ResourceGroupReleaseAnnotation]; exists {
+		if err := json.Unmarshal([]byte(value), release); err != nil {
+			a.Logger.InfoContext(ctx, "Restarting resource group release", slog.String("error", err.Error()))
+			release = &resourceGroupRelease{}
+		}
+	}
+	if release.Jobs == nil {
+		release = &resourceGroupRelease{StartTime: a.clock().Now().UTC(), Jobs: make(map[string]string)}
+	}
+
+	tracker := newJobTracker(hwmgr, utils.OperationResourceGroupJob, a.clock())
+	var failures []string
+	pending := false
+	for _, id := range ids {
+		jobId, requested := release.Jobs[id]
+		if !requested {
+			exists, err := hwmgrClient.ResourceGroupIdExists(ctx, id)
+			if err != nil {
+				return false, fmt.Errorf("resource group existence check failed for %s: %w", id, err)
+			}
+			if exists {
+				if jobId, err = hwmgrClient.DeleteResourceGroupFromId(ctx, id); err != nil {
+					return false, fmt.Errorf("failed to delete resource group %s: %w", id, err)
+				}
+				a.Logger.InfoContext(ctx, "Deleting resource group", slog.String("resourceGroup", id), slog.String("jobId", jobId))
+			}
+			release.Jobs[id] = jobId
+		}
+		if jobId == "" {
+			continue
+		}
+
+		progress, err := tracker.check(ctx, hwmgrClient, jobId, release.StartTime, true)
+		switch {
+		case err != nil:
+			a.Logger.InfoContext(ctx, "Resource group deletion check failed", slog.String("resourceGroup", id),
+				slog.String("error", err.Error()))
+			pending = true
+		case progress.Status == hwmgrclient.JobStatusCompleted || progress.Status == hwmgrclient.JobStatusNotExist:
+			release.Jobs[id] = ""
+		case progress.Status == hwmgrclient.JobStatusFailed:
+			failures = append(failures, fmt.Sprintf("%s: %s", id, progress.Failure))
+			delete(release.Jobs, id)
+		case progress.TimedOut:
+			failures = append(failures, fmt.Sprintf("%s: %s, jobId=%s, timeout=%s", id, errJobTimedOut, jobId, tracker.timeout))
+			delete(release.Jobs, id)
+		default:
+			pending = true
+		}
+	}
+
+	if !pending && len(failures) == 0 {
+		release = nil
+	} else if len(failures) > 0 {
+		// The failed deletions are requested again, and timed from the new request
+		release.StartTime = a.clock().Now().UTC()
+	}
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	if err := setJSONAnnotation(nodepool, ResourceGroupReleaseAnnotation, release); err != nil {
+		return false, err
+	}
+	if err := a.Client.Patch(ctx, nodepool, patch); err != nil {
+		return false, fmt.Errorf("failed to patch resource group release of nodepool %s: %w", nodepool.Name, err)
+	}
+
+	if len(failures) > 0 {
+		return false, fmt.Errorf("failed to delete resource groups: %s", strings.Join(failures, "; "))
+	}
+	return release == nil, nil
+}
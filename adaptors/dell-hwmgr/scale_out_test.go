@@ -0,0 +1,129 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestFindScaleOut(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	for name, size := range map[string]int{"controller": 3, "worker": 2} {
+		nodegroup := hwmgmtv1alpha1.NodeGroup{Size: size}
+		nodegroup.NodePoolData.Name = name
+		nodepool.Spec.NodeGroup = append(nodepool.Spec.NodeGroup, nodegroup)
+	}
+
+	nodelist := &hwmgmtv1alpha1.NodeList{}
+	for _, group := range []string{"controller", "controller", "controller", "worker"} {
+		node := hwmgmtv1alpha1.Node{}
+		node.Spec.GroupName = group
+		nodelist.Items = append(nodelist.Items, node)
+	}
+
+	scaleOut := findScaleOut(nodepool, nodelist)
+	if len(scaleOut) != 1 || scaleOut[0] != (nodeGroupScaleOut{Name: "worker", Allocated: 1, Requested: 2}) {
+		t.Fatalf("expected the worker group to scale out, got %v", scaleOut)
+	}
+	if message := scaleOutMessage(scaleOut); message != "worker from 1 to 2 nodes" {
+		t.Errorf("unexpected message: %s", message)
+	}
+
+	node := hwmgmtv1alpha1.Node{}
+	node.Spec.GroupName = "worker"
+	nodelist.Items = append(nodelist.Items, node)
+	if scaleOut := findScaleOut(nodepool, nodelist); len(scaleOut) != 0 {
+		t.Errorf("expected no scale out, got %v", scaleOut)
+	}
+}
+
+func TestNewScaleOutState(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Spec.CloudID = "cloud-1"
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	state := newScaleOutState(nodepool, []nodeGroupScaleOut{
+		{Name: "controller", Allocated: 3, Requested: 4},
+		{Name: "worker", Allocated: 1, Requested: 3},
+	}, start)
+
+	if len(state.Groups) != 3 || !state.StartTime.Equal(start) {
+		t.Fatalf("expected a resource group for each of the 3 nodes to add, got %+v", state)
+	}
+	var nodegroups []string
+	for _, group := range state.Groups {
+		if !strings.HasPrefix(group.Id, "rhplugin-rg-cloud-1-") || group.Created || group.done() {
+			t.Errorf("unexpected resource group %+v", group)
+		}
+		nodegroups = append(nodegroups, group.NodeGroup)
+	}
+	if !slices.Equal(nodegroups, []string{"controller", "worker", "worker"}) {
+		t.Errorf("unexpected nodegroups %v", nodegroups)
+	}
+	if state.Groups[1].Id == state.Groups[2].Id {
+		t.Errorf("expected distinct resource groups, got %s twice", state.Groups[1].Id)
+	}
+}
+
+func TestScaleOutState(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	if state, err := getScaleOutState(nodepool); err != nil || state != nil {
+		t.Fatalf("expected no scale-out state, got %v, %v", state, err)
+	}
+
+	expected := &scaleOutState{
+		StartTime: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Groups: []scaleOutGroup{
+			{Id: "rg-1", NodeGroup: "worker", JobId: "job-1", Created: true},
+			{Id: "rg-2", NodeGroup: "worker", Created: true},
+		},
+	}
+	if err := setScaleOutState(nodepool, expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state, err := getScaleOutState(nodepool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.StartTime.Equal(expected.StartTime) || !slices.Equal(state.Groups, expected.Groups) {
+		t.Errorf("expected %+v, got %+v", expected, state)
+	}
+	if state.Groups[0].done() || !state.Groups[1].done() {
+		t.Errorf("expected only the resource group without a job to be done")
+	}
+
+	if err := setScaleOutState(nodepool, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, exists := nodepool.GetAnnotations()[ScaleOutStateAnnotation]; exists {
+		t.Errorf("expected the scale-out state to be cleared")
+	}
+
+	nodepool.SetAnnotations(map[string]string{ScaleOutStateAnnotation: "{"})
+	if _, err := getScaleOutState(nodepool); err == nil {
+		t.Errorf("expected an error for an invalid scale-out state")
+	}
+}
+
+func TestGetScaleOutResourceGroups(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodes := make([]hwmgmtv1alpha1.Node, 3)
+	nodes[1].SetAnnotations(map[string]string{ResourceGroupAnnotation: "rg-2"})
+	nodes[2].SetAnnotations(map[string]string{ResourceGroupAnnotation: "rg-1"})
+	if err := setScaleOutState(nodepool, &scaleOutState{Groups: []scaleOutGroup{{Id: "rg-3"}, {Id: "rg-1"}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"rg-1", "rg-2", "rg-3"}
+	if ids := getScaleOutResourceGroups(nodepool, nodes); !slices.Equal(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
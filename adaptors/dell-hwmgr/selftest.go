@@ -0,0 +1,76 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// Names of the self-test checks of the Dell adaptor
+const (
+	SelfTestCheckAuthentication           = "Authentication"
+	SelfTestCheckNotificationSubscription = "NotificationSubscription"
+)
+
+// SelfTest authenticates with the hardware manager and, if notifications are enabled, checks that the resource
+// subscription is registered. The inventory checks are run for all adaptors.
+func (a *Adaptor) SelfTest(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) []pluginv1alpha1.SelfTestCheck {
+	if hwmgr.Spec.DellData == nil {
+		return []pluginv1alpha1.SelfTestCheck{{
+			Name:    SelfTestCheckAuthentication,
+			Result:  pluginv1alpha1.SelfTestResults.Failed,
+			Message: "Required config data missing from HardwareManager",
+		}}
+	}
+
+	hwmgrClient, err := hwmgrclient.NewClientWithClock(ctx, a.Logger, a.Client, hwmgr, a.Clock)
+	if err != nil {
+		return []pluginv1alpha1.SelfTestCheck{
+			{
+				Name:    SelfTestCheckAuthentication,
+				Result:  pluginv1alpha1.SelfTestResults.Failed,
+				Message: err.Error(),
+			},
+			{
+				Name:    SelfTestCheckNotificationSubscription,
+				Result:  pluginv1alpha1.SelfTestResults.Skipped,
+				Message: "Authentication failed",
+			},
+		}
+	}
+
+	checks := []pluginv1alpha1.SelfTestCheck{{
+		Name:    SelfTestCheckAuthentication,
+		Result:  pluginv1alpha1.SelfTestResults.Passed,
+		Message: fmt.Sprintf("Authenticated with tenant %s", hwmgrClient.GetTenant()),
+	}}
+
+	notifications := hwmgr.Spec.DellData.Notifications
+	if notifications == nil {
+		return append(checks, pluginv1alpha1.SelfTestCheck{
+			Name:    SelfTestCheckNotificationSubscription,
+			Result:  pluginv1alpha1.SelfTestResults.Skipped,
+			Message: "Notifications are not enabled",
+		})
+	}
+	if err := hwmgrClient.GetResourceSubscription(ctx, notifications.SubscriptionId); err != nil {
+		return append(checks, pluginv1alpha1.SelfTestCheck{
+			Name:    SelfTestCheckNotificationSubscription,
+			Result:  pluginv1alpha1.SelfTestResults.Failed,
+			Message: err.Error(),
+		})
+	}
+	return append(checks, pluginv1alpha1.SelfTestCheck{
+		Name:    SelfTestCheckNotificationSubscription,
+		Result:  pluginv1alpha1.SelfTestResults.Passed,
+		Message: fmt.Sprintf("Resource subscription %s is registered", notifications.SubscriptionId),
+	})
+}
@@ -0,0 +1,96 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// RefreshInventory queries the inventory of the hardware manager from its backend, replacing the inventory cached by
+// its adaptor
+func (c *HwMgrAdaptorController) RefreshInventory(ctx context.Context, request invserver.RefreshInventoryRequestObject) (invserver.RefreshInventoryResponseObject, error) {
+	if !c.IsInventoryReady() {
+		return invserver.RefreshInventory503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: "Inventory is not yet available, warm-up in progress",
+		}), nil
+	}
+
+	hwmgr, statusCode, err := c.getHwMgr(ctx, request.HwMgrId)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return invserver.RefreshInventory404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+				Status: statusCode,
+				Detail: fmt.Sprintf("Hardware Manager %s not found", request.HwMgrId),
+			}), fmt.Errorf("hardware manager %s not found: %w", request.HwMgrId, err)
+		}
+		return invserver.RefreshInventory503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: fmt.Sprintf("Hardware Manager %s unavailable: %s", request.HwMgrId, err.Error()),
+		}), fmt.Errorf("unable to get hardware manager %s: %w", request.HwMgrId, err)
+	}
+
+	adaptorID := string(hwmgr.Spec.AdaptorID)
+	adaptor, exists := c.adaptors[adaptorID]
+	if !exists {
+		// We should never get here, as the adaptor ID is validated in getHwMgr
+		return invserver.RefreshInventory500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Hardware Manager %s specifies invalid adaptorId: %s", request.HwMgrId, adaptorID),
+		}), fmt.Errorf("hardware manager %s specifies invalid adaptorId: %s", request.HwMgrId, adaptorID)
+	}
+
+	if reason := adaptorDisabledReason(adaptor); reason != "" {
+		return invserver.RefreshInventory503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: fmt.Sprintf("Adaptor %s of Hardware Manager %s is disabled: %s", adaptorID, request.HwMgrId, reason),
+		}), nil
+	}
+
+	cache, ok := adaptor.(adaptorinterface.HwMgrAdaptorInventoryCacheIntf)
+	if !ok {
+		return invserver.RefreshInventory501ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusNotImplemented,
+			Detail: fmt.Sprintf("Hardware Manager %s adaptor %s does not cache its inventory", request.HwMgrId, adaptorID),
+		}), nil
+	}
+
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
+
+	pools, resources, err := cache.RefreshInventory(opCtx, hwmgr)
+	switch {
+	case typederrors.IsUnavailableError(err):
+		return invserver.RefreshInventory503ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: fmt.Sprintf("Hardware Manager %s unavailable: %s", request.HwMgrId, err.Error()),
+		}), err
+	case err != nil:
+		c.Logger.ErrorContext(ctx, "unable to refresh inventory", slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
+		return invserver.RefreshInventory500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Inventory refresh failed for %s: %s", request.HwMgrId, err.Error()),
+		}), err
+	}
+
+	c.Logger.InfoContext(ctx, "Refreshed inventory", slog.String("hwMgrId", request.HwMgrId),
+		slog.Int("resourcePools", pools), slog.Int("resources", resources))
+	return invserver.RefreshInventory200JSONResponse(invserver.InventoryRefreshResult{
+		HwMgrId:           request.HwMgrId,
+		RefreshedAt:       c.clock().Now(),
+		ResourcePoolCount: pools,
+		ResourceCount:     resources,
+	}), nil
+}
@@ -0,0 +1,62 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestRefreshInventoryWithFakeAdaptor(t *testing.T) {
+	fake := testsupport.NewFakeAdaptor()
+	fake.ResourcePools = []invserver.ResourcePoolInfo{{ResourcePoolId: "pool-1"}}
+	fake.Resources = []invserver.ResourceInfo{{ResourceId: "node-1"}, {ResourceId: "node-2"}}
+
+	c := &HwMgrAdaptorController{
+		Client: &hwmgrClient{hwmgr: &pluginv1alpha1.HardwareManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1"},
+			Spec:       pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+		}},
+		Logger: slog.Default(),
+		Clock:  clocktesting.NewFakeClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+	c.RegisterAdaptor(LoopbackAdaptorID, fake)
+	c.MarkInventoryReady()
+	ctx := context.Background()
+
+	resp, err := c.RefreshInventory(ctx, invserver.RefreshInventoryRequestObject{HwMgrId: "hwmgr-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, ok := resp.(invserver.RefreshInventory200JSONResponse)
+	if !ok || result.HwMgrId != "hwmgr-1" || result.ResourcePoolCount != 1 || result.ResourceCount != 2 ||
+		!result.RefreshedAt.Equal(c.Clock.Now()) {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+
+	fake.RefreshInventoryErr = typederrors.NewUnavailableError(errors.New("timeout"), "hardware manager unreachable")
+	resp, _ = c.RefreshInventory(ctx, invserver.RefreshInventoryRequestObject{HwMgrId: "hwmgr-1"})
+	if _, ok := resp.(invserver.RefreshInventory503ApplicationProblemPlusJSONResponse); !ok {
+		t.Errorf("expected an unavailable backend to be reported, got %#v", resp)
+	}
+
+	resp, _ = c.RefreshInventory(ctx, invserver.RefreshInventoryRequestObject{HwMgrId: "unknown"})
+	if _, ok := resp.(invserver.RefreshInventory404ApplicationProblemPlusJSONResponse); !ok {
+		t.Errorf("expected an unknown hardware manager to be reported, got %#v", resp)
+	}
+}
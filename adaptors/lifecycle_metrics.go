@@ -0,0 +1,325 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// TraceIdAnnotation holds the ID of the trace of the request that created a NodePool, either as a trace ID or as a
+// W3C traceparent header. It is set by the producer of the NodePool or, failing that, generated when the NodePool is
+// first handled. It is attached as an exemplar to the lifecycle metrics of the NodePool, and to the logs of its
+// processing.
+const TraceIdAnnotation = "hwmgr-plugin.oran.openshift.io/trace-id"
+
+// operationAgeInterval is the interval at which the age of the oldest NodePool operations in progress is recorded
+const operationAgeInterval = 30 * time.Second
+
+// NodePool operations whose age is recorded while in progress
+const (
+	operationProvisioning  = "provisioning"
+	operationConfiguration = "configuration"
+	operationRelease       = "release"
+)
+
+// maxTraceIdLength bounds the trace IDs used as exemplars, as the labels of an exemplar are limited to 128 characters
+const maxTraceIdLength = 64
+
+// nodePoolSizeBuckets are the upper bounds of the NodePool size buckets of the provisioning metric
+var nodePoolSizeBuckets = []struct {
+	max   int
+	label string
+}{
+	{1, "1"},
+	{5, "2-5"},
+	{20, "6-20"},
+	{50, "21-50"},
+}
+
+// lifecycleMetrics records the durations of the lifecycle stages of the NodePools from their changes, as seen by the
+// NodePool informer. Durations are derived from the timestamps stored in the NodePools, rather than from the time the
+// changes are seen, so they are not skewed by controller restarts. It also periodically records the age of the oldest
+// operations in progress, so that stuck operations can be alerted on. It only runs on the leader, so that each
+// transition is recorded once.
+type lifecycleMetrics struct {
+	mgr   ctrl.Manager
+	clock utils.Clock
+}
+
+func (m *lifecycleMetrics) NeedLeaderElection() bool {
+	return true
+}
+
+func (m *lifecycleMetrics) Start(ctx context.Context) error {
+	informer, err := m.mgr.GetCache().GetInformer(ctx, &hwmgmtv1alpha1.NodePool{})
+	if err != nil {
+		return fmt.Errorf("failed to get NodePool informer: %w", err)
+	}
+
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNodePool, oldOk := oldObj.(*hwmgmtv1alpha1.NodePool)
+			newNodePool, newOk := newObj.(*hwmgmtv1alpha1.NodePool)
+			if oldOk && newOk {
+				recordNodePoolTransitions(oldNodePool, newNodePool)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if nodepool, ok := obj.(*hwmgmtv1alpha1.NodePool); ok {
+				recordNodePoolRelease(nodepool, m.clock.Now())
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add NodePool event handler: %w", err)
+	}
+
+	ticker := m.clock.NewTicker(operationAgeInterval)
+	defer ticker.Stop()
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-ticker.C():
+			nodepools := &hwmgmtv1alpha1.NodePoolList{}
+			if err := m.mgr.GetCache().List(ctx, nodepools); err != nil {
+				continue
+			}
+			recordOperationAges(nodepools.Items, m.clock.Now())
+		}
+	}
+	nodePoolOldestOperationAge.Reset()
+
+	if err := informer.RemoveEventHandler(registration); err != nil {
+		return fmt.Errorf("failed to remove NodePool event handler: %w", err)
+	}
+	return nil
+}
+
+// setupLifecycleMetrics registers the recording of the NodePool lifecycle metrics with the manager
+func (c *HwMgrAdaptorController) setupLifecycleMetrics(mgr ctrl.Manager) error {
+	if err := mgr.Add(&lifecycleMetrics{mgr: mgr, clock: c.clock()}); err != nil {
+		return fmt.Errorf("failed to add lifecycle metrics runnable: %w", err)
+	}
+	return nil
+}
+
+// nodePoolSize returns the total number of nodes requested by the NodePool
+func nodePoolSize(nodepool *hwmgmtv1alpha1.NodePool) int {
+	size := 0
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		size += nodegroup.Size
+	}
+	return size
+}
+
+// nodePoolSizeBucket returns the label of the size bucket of the NodePool
+func nodePoolSizeBucket(size int) string {
+	for _, bucket := range nodePoolSizeBuckets {
+		if size <= bucket.max {
+			return bucket.label
+		}
+	}
+	return fmt.Sprintf("%d+", nodePoolSizeBuckets[len(nodePoolSizeBuckets)-1].max+1)
+}
+
+// getTraceId returns the trace ID of the NodePool, extracting it from a W3C traceparent header if needed, or an
+// empty string if it has none or it is too long to be used as an exemplar
+func getTraceId(nodepool *hwmgmtv1alpha1.NodePool) string {
+	traceId := strings.TrimSpace(nodepool.GetAnnotations()[TraceIdAnnotation])
+	// A traceparent header is formatted as <version>-<trace-id>-<parent-id>-<flags>
+	if fields := strings.Split(traceId, "-"); len(fields) == 4 {
+		traceId = fields[1]
+	}
+	if len(traceId) > maxTraceIdLength {
+		return ""
+	}
+	return traceId
+}
+
+// newTraceId returns a random trace ID, formatted as the 32 hexadecimal digits of a W3C trace ID
+func newTraceId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate trace ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// ensureTraceId sets a generated trace ID on a NodePool that was created without one, returning its trace ID. The
+// member NodePools of a NodePool spanning hardware managers are left as is, as they are not recorded.
+func (c *HwMgrAdaptorController) ensureTraceId(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (string, error) {
+	if _, exists := nodepool.GetAnnotations()[TraceIdAnnotation]; exists || utils.GetParentNodePool(nodepool) != "" {
+		return getTraceId(nodepool), nil
+	}
+
+	traceId, err := newTraceId()
+	if err != nil {
+		return "", err
+	}
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	annotations := nodepool.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[TraceIdAnnotation] = traceId
+	nodepool.SetAnnotations(annotations)
+	if err := c.Client.Patch(ctx, nodepool, patch); err != nil {
+		return "", fmt.Errorf("failed to set trace ID of NodePool %s: %w", nodepool.Name, err)
+	}
+	return traceId, nil
+}
+
+// recordNodePoolTransitions records the provisioning and configuration durations of a NodePool whose Provisioned or
+// Configured condition became true. The member NodePools of a NodePool spanning hardware managers are not recorded,
+// so that each request is counted once.
+func recordNodePoolTransitions(oldNodePool, newNodePool *hwmgmtv1alpha1.NodePool) {
+	if utils.GetParentNodePool(newNodePool) != "" {
+		return
+	}
+	hwMgrId := newNodePool.Spec.HwMgrId
+	traceId := getTraceId(newNodePool)
+
+	if duration, provisioned := getProvisioningDuration(oldNodePool, newNodePool); provisioned {
+		observeLifecycleDuration(
+			nodePoolProvisioningDuration.WithLabelValues(hwMgrId, nodePoolSizeBucket(nodePoolSize(newNodePool))),
+			duration, traceId)
+	}
+	if duration, configured := getConfigurationDuration(oldNodePool, newNodePool); configured {
+		observeLifecycleDuration(nodePoolConfigurationDuration.WithLabelValues(hwMgrId), duration, traceId)
+	}
+	if reason, failed := getProvisioningFailure(oldNodePool, newNodePool); failed {
+		nodePoolProvisioningFailures.WithLabelValues(hwMgrId, reason).Inc()
+	}
+}
+
+// isProvisioningFailed checks whether the Provisioned condition reports a failed or timed out provisioning
+func isProvisioningFailed(condition *metav1.Condition) bool {
+	return condition != nil && condition.Status == metav1.ConditionFalse &&
+		(condition.Reason == string(hwmgmtv1alpha1.Failed) || condition.Reason == string(hwmgmtv1alpha1.TimedOut))
+}
+
+// getProvisioningFailure returns the reason of the provisioning failure of the NodePool, if its Provisioned condition
+// became failed or timed out
+func getProvisioningFailure(oldNodePool, newNodePool *hwmgmtv1alpha1.NodePool) (string, bool) {
+	oldProvisioned := meta.FindStatusCondition(oldNodePool.Status.Conditions, string(hwmgmtv1alpha1.Provisioned))
+	newProvisioned := meta.FindStatusCondition(newNodePool.Status.Conditions, string(hwmgmtv1alpha1.Provisioned))
+	if !isProvisioningFailed(newProvisioned) ||
+		(isProvisioningFailed(oldProvisioned) && oldProvisioned.Reason == newProvisioned.Reason) {
+		return "", false
+	}
+	return newProvisioned.Reason, true
+}
+
+// getOperationStart returns the operation in progress on the NodePool and the time it started, if any. A NodePool
+// being deleted is releasing its hardware, a NodePool neither provisioned nor failed is being provisioned, and a
+// NodePool whose Configured condition is in progress is being configured.
+func getOperationStart(nodepool *hwmgmtv1alpha1.NodePool) (string, time.Time, bool) {
+	if nodepool.DeletionTimestamp != nil {
+		return operationRelease, nodepool.DeletionTimestamp.Time, true
+	}
+	provisioned := meta.FindStatusCondition(nodepool.Status.Conditions, string(hwmgmtv1alpha1.Provisioned))
+	if !isConditionTrue(provisioned) {
+		if isProvisioningFailed(provisioned) || (provisioned != nil &&
+			provisioned.Reason == string(hwmgmtv1alpha1.InvalidInput)) {
+			return "", time.Time{}, false
+		}
+		return operationProvisioning, nodepool.CreationTimestamp.Time, true
+	}
+	configured := meta.FindStatusCondition(nodepool.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+	if configured != nil && configured.Status == metav1.ConditionFalse &&
+		configured.Reason == string(hwmgmtv1alpha1.InProgress) {
+		return operationConfiguration, configured.LastTransitionTime.Time, true
+	}
+	return "", time.Time{}, false
+}
+
+// getOldestOperationAges returns the age of the oldest operation in progress, by hardware manager and operation. The
+// member NodePools of a NodePool spanning hardware managers are not counted, as their parent is.
+func getOldestOperationAges(nodepools []hwmgmtv1alpha1.NodePool, now time.Time) map[[2]string]time.Duration {
+	ages := make(map[[2]string]time.Duration)
+	for i := range nodepools {
+		nodepool := &nodepools[i]
+		if utils.GetParentNodePool(nodepool) != "" {
+			continue
+		}
+		operation, start, inProgress := getOperationStart(nodepool)
+		if !inProgress {
+			continue
+		}
+		key := [2]string{nodepool.Spec.HwMgrId, operation}
+		if age := now.Sub(start); age > ages[key] {
+			ages[key] = age
+		}
+	}
+	return ages
+}
+
+// recordOperationAges sets the age of the oldest NodePool operations in progress, dropping the operations no longer in
+// progress
+func recordOperationAges(nodepools []hwmgmtv1alpha1.NodePool, now time.Time) {
+	nodePoolOldestOperationAge.Reset()
+	for key, age := range getOldestOperationAges(nodepools, now) {
+		nodePoolOldestOperationAge.WithLabelValues(key[0], key[1]).Set(age.Seconds())
+	}
+}
+
+// getProvisioningDuration returns the time from the creation of the NodePool to its provisioning, if its Provisioned
+// condition became true
+func getProvisioningDuration(oldNodePool, newNodePool *hwmgmtv1alpha1.NodePool) (time.Duration, bool) {
+	oldProvisioned := meta.FindStatusCondition(oldNodePool.Status.Conditions, string(hwmgmtv1alpha1.Provisioned))
+	newProvisioned := meta.FindStatusCondition(newNodePool.Status.Conditions, string(hwmgmtv1alpha1.Provisioned))
+	if !isConditionTrue(newProvisioned) || isConditionTrue(oldProvisioned) {
+		return 0, false
+	}
+	return newProvisioned.LastTransitionTime.Sub(newNodePool.CreationTimestamp.Time), true
+}
+
+// getConfigurationDuration returns the duration of the day-2 configuration of the NodePool, if its Configured
+// condition became true after being in progress. The configuration starts when the condition is set in progress, at
+// its last transition time.
+func getConfigurationDuration(oldNodePool, newNodePool *hwmgmtv1alpha1.NodePool) (time.Duration, bool) {
+	oldConfigured := meta.FindStatusCondition(oldNodePool.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+	newConfigured := meta.FindStatusCondition(newNodePool.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+	if !isConditionTrue(newConfigured) || oldConfigured == nil || oldConfigured.Status != metav1.ConditionFalse ||
+		oldConfigured.Reason != string(hwmgmtv1alpha1.InProgress) {
+		return 0, false
+	}
+	return newConfigured.LastTransitionTime.Sub(oldConfigured.LastTransitionTime.Time), true
+}
+
+// recordNodePoolRelease records the release duration of a deleted NodePool, from its deletion request to the removal
+// of its finalizer, once its hardware was released
+func recordNodePoolRelease(nodepool *hwmgmtv1alpha1.NodePool, now time.Time) {
+	if utils.GetParentNodePool(nodepool) != "" || nodepool.DeletionTimestamp == nil {
+		return
+	}
+	observeLifecycleDuration(nodePoolReleaseDuration.WithLabelValues(nodepool.Spec.HwMgrId),
+		now.Sub(nodepool.DeletionTimestamp.Time), getTraceId(nodepool))
+}
+
+// isConditionTrue checks whether the condition is set and true
+func isConditionTrue(condition *metav1.Condition) bool {
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
@@ -0,0 +1,159 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestNodePoolSizeBucket(t *testing.T) {
+	testcases := map[int]string{0: "1", 1: "1", 2: "2-5", 20: "6-20", 50: "21-50", 51: "51+"}
+	for size, expected := range testcases {
+		if bucket := nodePoolSizeBucket(size); bucket != expected {
+			t.Errorf("expected bucket %s for size %d, got %s", expected, size, bucket)
+		}
+	}
+}
+
+func TestGetTraceId(t *testing.T) {
+	testcases := map[string]string{
+		"":                                 "",
+		"4bf92f3577b34da6a3ce929d0e0e4736": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": "4bf92f3577b34da6a3ce929d0e0e4736",
+		strings.Repeat("a", maxTraceIdLength+1):                   "",
+	}
+	for annotation, expected := range testcases {
+		nodepool := &hwmgmtv1alpha1.NodePool{}
+		nodepool.SetAnnotations(map[string]string{TraceIdAnnotation: annotation})
+		if traceId := getTraceId(nodepool); traceId != expected {
+			t.Errorf("expected trace ID %q for %q, got %q", expected, annotation, traceId)
+		}
+	}
+}
+
+func TestGetLifecycleDurations(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	condition := func(conditionType hwmgmtv1alpha1.ConditionType, status metav1.ConditionStatus,
+		reason hwmgmtv1alpha1.ConditionReason, at time.Duration) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(conditionType),
+			Status:             status,
+			Reason:             string(reason),
+			LastTransitionTime: metav1.NewTime(created.Add(at)),
+		}
+	}
+	nodepool := func(conditions ...metav1.Condition) *hwmgmtv1alpha1.NodePool {
+		return &hwmgmtv1alpha1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status:     hwmgmtv1alpha1.NodePoolStatus{Conditions: conditions},
+		}
+	}
+
+	provisioning := nodepool(condition(hwmgmtv1alpha1.Provisioned, metav1.ConditionFalse, hwmgmtv1alpha1.InProgress, 0))
+	provisioned := nodepool(condition(hwmgmtv1alpha1.Provisioned, metav1.ConditionTrue, hwmgmtv1alpha1.Completed, 20*time.Minute))
+	if duration, ok := getProvisioningDuration(provisioning, provisioned); !ok || duration != 20*time.Minute {
+		t.Errorf("expected a provisioning duration of 20m, got %s, %t", duration, ok)
+	}
+	if _, ok := getProvisioningDuration(provisioned, provisioned); ok {
+		t.Errorf("expected no provisioning duration for an already provisioned NodePool")
+	}
+
+	configuring := nodepool(condition(hwmgmtv1alpha1.Configured, metav1.ConditionFalse, hwmgmtv1alpha1.InProgress, time.Hour))
+	configured := nodepool(condition(hwmgmtv1alpha1.Configured, metav1.ConditionTrue, hwmgmtv1alpha1.ConfigApplied, 90*time.Minute))
+	if duration, ok := getConfigurationDuration(configuring, configured); !ok || duration != 30*time.Minute {
+		t.Errorf("expected a configuration duration of 30m, got %s, %t", duration, ok)
+	}
+	failed := nodepool(condition(hwmgmtv1alpha1.Configured, metav1.ConditionFalse, hwmgmtv1alpha1.Failed, time.Hour))
+	if _, ok := getConfigurationDuration(failed, configured); ok {
+		t.Errorf("expected no configuration duration after a failed configuration")
+	}
+}
+
+func TestGetProvisioningFailure(t *testing.T) {
+	provisioned := func(status metav1.ConditionStatus, reason hwmgmtv1alpha1.ConditionReason) *hwmgmtv1alpha1.NodePool {
+		return &hwmgmtv1alpha1.NodePool{Status: hwmgmtv1alpha1.NodePoolStatus{Conditions: []metav1.Condition{
+			{Type: string(hwmgmtv1alpha1.Provisioned), Status: status, Reason: string(reason)},
+		}}}
+	}
+
+	inProgress := provisioned(metav1.ConditionFalse, hwmgmtv1alpha1.InProgress)
+	timedOut := provisioned(metav1.ConditionFalse, hwmgmtv1alpha1.TimedOut)
+	if reason, ok := getProvisioningFailure(inProgress, timedOut); !ok || reason != string(hwmgmtv1alpha1.TimedOut) {
+		t.Errorf("expected a TimedOut failure, got %q, %t", reason, ok)
+	}
+	if _, ok := getProvisioningFailure(timedOut, timedOut); ok {
+		t.Errorf("expected an already failed NodePool not to be counted again")
+	}
+	if _, ok := getProvisioningFailure(inProgress, provisioned(metav1.ConditionTrue, hwmgmtv1alpha1.Completed)); ok {
+		t.Errorf("expected no failure for a provisioned NodePool")
+	}
+}
+
+func TestGetOldestOperationAges(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	nodepool := func(hwMgrId string, created time.Duration, conditions ...metav1.Condition) hwmgmtv1alpha1.NodePool {
+		return hwmgmtv1alpha1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-created))},
+			Spec:       hwmgmtv1alpha1.NodePoolSpec{HwMgrId: hwMgrId},
+			Status:     hwmgmtv1alpha1.NodePoolStatus{Conditions: conditions},
+		}
+	}
+	provisioned := metav1.Condition{Type: string(hwmgmtv1alpha1.Provisioned), Status: metav1.ConditionTrue}
+	failed := metav1.Condition{Type: string(hwmgmtv1alpha1.Provisioned), Status: metav1.ConditionFalse,
+		Reason: string(hwmgmtv1alpha1.Failed)}
+	configuring := metav1.Condition{Type: string(hwmgmtv1alpha1.Configured), Status: metav1.ConditionFalse,
+		Reason: string(hwmgmtv1alpha1.InProgress), LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Minute))}
+
+	deleting := nodepool("hwmgr-a", 5*time.Hour, provisioned)
+	deleting.DeletionTimestamp = &metav1.Time{Time: now.Add(-time.Minute)}
+
+	nodepools := []hwmgmtv1alpha1.NodePool{
+		nodepool("hwmgr-a", time.Hour),
+		nodepool("hwmgr-a", 2*time.Hour),
+		nodepool("hwmgr-a", 3*time.Hour, failed),
+		nodepool("hwmgr-a", 4*time.Hour, provisioned),
+		nodepool("hwmgr-b", 4*time.Hour, provisioned, configuring),
+		deleting,
+	}
+	expected := map[[2]string]time.Duration{
+		{"hwmgr-a", operationProvisioning}:  2 * time.Hour,
+		{"hwmgr-b", operationConfiguration}: 10 * time.Minute,
+		{"hwmgr-a", operationRelease}:       time.Minute,
+	}
+	if ages := getOldestOperationAges(nodepools, now); !reflect.DeepEqual(ages, expected) {
+		t.Errorf("expected %v, got %v", expected, ages)
+	}
+}
+
+func TestEnsureTraceId(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np1"}}
+	c := newNodePoolsClient(nodepool)
+	controller := &HwMgrAdaptorController{Client: c}
+
+	traceId, err := controller.ensureTraceId(context.Background(), nodepool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(traceId) != 32 || c.nodepools["np1"].Annotations[TraceIdAnnotation] != traceId {
+		t.Errorf("expected a generated trace ID to be set, got %q, %v", traceId, c.nodepools["np1"].Annotations)
+	}
+
+	// The trace ID set by the producer of the NodePool is kept
+	nodepool.Annotations[TraceIdAnnotation] = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if traceId, err := controller.ensureTraceId(context.Background(), nodepool); err != nil ||
+		traceId != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the trace ID of the producer, got %q, %v", traceId, err)
+	}
+}
@@ -167,8 +167,13 @@ func (a *Adaptor) GetResources(ctx context.Context, hwmgr *pluginv1alpha1.Hardwa
 
 	for name, server := range resources.Nodes {
 		powerState := invserver.ResourceInfoPowerState("ON")
+		var cpuArchitecture *string
+		if arch := server.cpuArchitecture(); arch != "" {
+			cpuArchitecture = &arch
+		}
 		resp = append(resp, invserver.ResourceInfo{
 			AdminState:       invserver.ResourceInfoAdminState(server.AdminState),
+			CpuArchitecture:  cpuArchitecture,
 			Description:      server.Description,
 			GlobalAssetId:    &server.GlobalAssetID,
 			Groups:           nil,
@@ -75,8 +75,31 @@ const (
 	cmName         = "loopback-adaptor-nodelist"
 )
 
-// getFreeNodesInPool compares the parsed configmap data to get the list of free nodes for a given resource pool
-func getFreeNodesInPool(resources cmResources, allocations cmAllocations, poolID string) (freenodes []string) {
+// cpuArchitecture returns the normalized CPU architecture of the node, if known
+func (n cmNodeInfo) cpuArchitecture() string {
+	for _, processor := range n.Processors {
+		if arch := utils.NormalizeCPUArchitecture(processor.Architecture); arch != "" {
+			return arch
+		}
+	}
+	return ""
+}
+
+// nodeSelectionInfo returns the attributes of the node to be published as labels on the allocated Node. The loopback
+// nodes have no site of their own, so the site requested by the NodePool is used.
+func (n cmNodeInfo) nodeSelectionInfo(site string) utils.NodeSelectionInfo {
+	return utils.NodeSelectionInfo{
+		SiteID:          site,
+		ResourcePoolID:  n.ResourcePoolID,
+		Vendor:          n.Vendor,
+		Model:           n.Model,
+		CPUArchitecture: n.cpuArchitecture(),
+	}
+}
+
+// getFreeNodesInPool compares the parsed configmap data to get the list of free nodes for a given resource pool,
+// limited to the given CPU architecture, if set
+func getFreeNodesInPool(resources cmResources, allocations cmAllocations, poolID, arch string) (freenodes []string) {
 	inuse := make(map[string]bool)
 	for _, cloud := range allocations.Clouds {
 		for groupname := range cloud.Nodegroups {
@@ -88,7 +111,7 @@ func getFreeNodesInPool(resources cmResources, allocations cmAllocations, poolID
 
 	for nodeId, node := range resources.Nodes {
 		// Check if the node belongs to the specified resource pool
-		if node.ResourcePoolID == poolID {
+		if node.ResourcePoolID == poolID && (arch == "" || node.cpuArchitecture() == arch) {
 			// Only add to the freenodes if not in use
 			if _, used := inuse[nodeId]; !used {
 				freenodes = append(freenodes, nodeId)
@@ -13,9 +13,9 @@ import (
 	"log/slog"
 	"time"
 
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -23,7 +23,7 @@ import (
 )
 
 // AllocateNode processes a NodePool CR, allocating a free node for each specified nodegroup as needed
-func (a *Adaptor) AllocateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) error {
+func (a *Adaptor) AllocateNode(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) error {
 	cloudID := nodepool.Spec.CloudID
 
 	// Inject a delay before allocating node
@@ -57,7 +57,11 @@ func (a *Adaptor) AllocateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.Nod
 			continue
 		}
 
-		freenodes := getFreeNodesInPool(resources, allocations, nodegroup.NodePoolData.ResourcePoolId)
+		arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodegroup.NodePoolData.Name)
+		if err != nil {
+			return err
+		}
+		freenodes := getFreeNodesInPool(resources, allocations, nodegroup.NodePoolData.ResourcePoolId, arch)
 		if remaining > len(freenodes) {
 			return fmt.Errorf("not enough free resources remaining in resource pool %s", nodegroup.NodePoolData.ResourcePoolId)
 		}
@@ -72,7 +76,12 @@ func (a *Adaptor) AllocateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.Nod
 			return fmt.Errorf("unable to find nodeinfo for %s", nodeId)
 		}
 
-		if err := a.CreateBMCSecret(ctx, nodepool, nodename, nodeinfo.BMC.UsernameBase64, nodeinfo.BMC.PasswordBase64); err != nil {
+		bmcSecret, err := utils.GetBMCSecretKey(hwmgr, nodepool, nodename, a.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get bmc-secret name for node %s: %w", nodename, err)
+		}
+
+		if err := a.CreateBMCSecret(ctx, nodepool, nodename, bmcSecret, nodeinfo.BMC.UsernameBase64, nodeinfo.BMC.PasswordBase64); err != nil {
 			return fmt.Errorf("failed to create bmc-secret when allocating node %s, nodeId %s: %w", nodename, nodeId, err)
 		}
 
@@ -88,11 +97,12 @@ func (a *Adaptor) AllocateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.Nod
 			return fmt.Errorf("failed to update configmap: %w", err)
 		}
 
-		if err := a.CreateNode(ctx, nodepool, cloudID, nodename, nodeId, nodegroup.NodePoolData.Name, nodegroup.NodePoolData.HwProfile); err != nil {
+		if err := a.CreateNode(ctx, nodepool, cloudID, nodename, nodeId, nodegroup.NodePoolData.Name, nodegroup.NodePoolData.HwProfile,
+			nodeinfo.nodeSelectionInfo(nodepool.Spec.Site).Labels(), bmcSecret); err != nil {
 			return fmt.Errorf("failed to create allocated node (%s): %w", nodename, err)
 		}
 
-		if err := a.UpdateNodeStatus(ctx, nodename, nodeinfo, nodegroup.NodePoolData.HwProfile); err != nil {
+		if err := a.UpdateNodeStatus(ctx, nodename, bmcSecret.Name, nodeinfo, nodegroup.NodePoolData.HwProfile); err != nil {
 			return fmt.Errorf("failed to update node status (%s): %w", nodename, err)
 		}
 	}
@@ -100,16 +110,11 @@ func (a *Adaptor) AllocateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.Nod
 	return nil
 }
 
-func bmcSecretName(nodename string) string {
-	return fmt.Sprintf("%s-bmc-secret", nodename)
-}
-
 // CreateBMCSecret creates the bmc-secret for a node
-func (a *Adaptor) CreateBMCSecret(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, nodename, usernameBase64, passwordBase64 string) error {
+func (a *Adaptor) CreateBMCSecret(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, nodename string, key types.NamespacedName,
+	usernameBase64, passwordBase64 string) error {
 	a.Logger.InfoContext(ctx, "Creating bmc-secret:", slog.String("nodename", nodename))
 
-	secretName := bmcSecretName(nodename)
-
 	username, err := base64.StdEncoding.DecodeString(usernameBase64)
 	if err != nil {
 		return fmt.Errorf("failed to decode usernameBase64 string (%s) for node %s: %w", usernameBase64, nodename, err)
@@ -120,24 +125,7 @@ func (a *Adaptor) CreateBMCSecret(ctx context.Context, nodepool *hwmgmtv1alpha1.
 		return fmt.Errorf("failed to decode usernameBase64 string (%s) for node %s: %w", passwordBase64, nodename, err)
 	}
 
-	blockDeletion := true
-	bmcSecret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
-			Namespace: a.Namespace,
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion:         nodepool.APIVersion,
-				Kind:               nodepool.Kind,
-				Name:               nodepool.Name,
-				UID:                nodepool.UID,
-				BlockOwnerDeletion: &blockDeletion,
-			}},
-		},
-		Data: map[string][]byte{
-			"username": username,
-			"password": password,
-		},
-	}
+	bmcSecret := utils.NewBMCSecret(key, nodepool, nodename, username, password)
 
 	if err = utils.CreateOrUpdateK8sCR(ctx, a.Client, bmcSecret, nil, utils.UPDATE); err != nil {
 		return fmt.Errorf("failed to create bmc-secret for node %s: %w", nodename, err)
@@ -146,36 +134,23 @@ func (a *Adaptor) CreateBMCSecret(ctx context.Context, nodepool *hwmgmtv1alpha1.
 	return nil
 }
 
-// CreateNode creates a Node CR with specified attributes
-func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, cloudID, nodename, nodeId, groupname, hwprofile string) error {
+// CreateNode creates a Node CR with specified attributes, reporting the namespace of its BMC secret
+func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, cloudID, nodename, nodeId, groupname, hwprofile string,
+	labels map[string]string, bmcSecret types.NamespacedName) error {
 	a.Logger.InfoContext(ctx, "Creating node",
 		slog.String("nodegroup name", groupname),
 		slog.String("nodename", nodename),
 		slog.String("nodeId", nodeId))
 
-	blockDeletion := true
-	node := &hwmgmtv1alpha1.Node{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      nodename,
-			Namespace: a.Namespace,
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion:         nodepool.APIVersion,
-				Kind:               nodepool.Kind,
-				Name:               nodepool.Name,
-				UID:                nodepool.UID,
-				BlockOwnerDeletion: &blockDeletion,
-			}},
-		},
-		Spec: hwmgmtv1alpha1.NodeSpec{
-			NodePool:    cloudID,
-			GroupName:   groupname,
-			HwProfile:   hwprofile,
-			HwMgrId:     nodepool.Spec.HwMgrId,
-			HwMgrNodeId: nodeId,
-		},
-	}
-
-	if err := a.Client.Create(ctx, node); err != nil {
+	node := utils.NewNode(nodepool, a.Namespace, nodename, labels, hwmgmtv1alpha1.NodeSpec{
+		NodePool:    cloudID,
+		GroupName:   groupname,
+		HwProfile:   hwprofile,
+		HwMgrId:     nodepool.Spec.HwMgrId,
+		HwMgrNodeId: nodeId,
+	})
+	utils.SetNodeBMCSecretNamespace(node, bmcSecret)
+	if err := utils.ApplyNode(ctx, a.Client, nodepool, node); err != nil {
 		return fmt.Errorf("failed to create Node: %w", err)
 	}
 
@@ -183,7 +158,7 @@ func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodeP
 }
 
 // UpdateNodeStatus updates a Node CR status field with additional node information from the nodelist configmap
-func (a *Adaptor) UpdateNodeStatus(ctx context.Context, nodename string, info cmNodeInfo, hwprofile string) error {
+func (a *Adaptor) UpdateNodeStatus(ctx context.Context, nodename, credentialsName string, info cmNodeInfo, hwprofile string) error {
 	a.Logger.InfoContext(ctx, "Updating node", slog.String("nodename", nodename))
 
 	node := &hwmgmtv1alpha1.Node{}
@@ -199,7 +174,7 @@ func (a *Adaptor) UpdateNodeStatus(ctx context.Context, nodename string, info cm
 		slog.Any("info", info))
 	node.Status.BMC = &hwmgmtv1alpha1.BMC{
 		Address:         info.BMC.Address,
-		CredentialsName: bmcSecretName(nodename),
+		CredentialsName: credentialsName,
 	}
 	node.Status.Interfaces = info.Interfaces
 
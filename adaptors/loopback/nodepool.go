@@ -44,7 +44,7 @@ func (a *Adaptor) CheckNodePoolProgress(
 			slog.String("nodegroup name", nodegroup.NodePoolData.Name),
 		)
 
-		if err = a.AllocateNode(ctx, nodepool); err != nil {
+		if err = a.AllocateNode(ctx, hwmgr, nodepool); err != nil {
 			err = fmt.Errorf("failed to allocate node: %w", err)
 			return
 		}
@@ -122,7 +122,7 @@ func (a *Adaptor) HandleNodePoolProcessing(
 		result = utils.DoNotRequeue()
 	} else {
 		a.Logger.InfoContext(ctx, "NodePool request in progress")
-		result = utils.RequeueWithShortInterval()
+		result = utils.RequeueWhileInProgress(nodepool)
 	}
 
 	return result, nil
@@ -172,11 +172,17 @@ func (a *Adaptor) handleNodePoolConfiguring(
 	}
 
 	// Stage 1: Initiate upgrades by updating node.Spec.HwProfile as necessary
+	var cordoned hwmgmtv1alpha1.NodeList
 	for _, name := range allocatedNodes {
 		node, err := utils.GetNode(ctx, a.Logger, a.Client, a.Namespace, name)
 		if err != nil {
 			return utils.RequeueWithShortInterval(), err
 		}
+		// Cordoned nodes are left with their current profile until they are uncordoned
+		if utils.IsNodeCordoned(node) {
+			cordoned.Items = append(cordoned.Items, *node)
+			continue
+		}
 		// Check each node against each nodegroup in the node pool spec
 		for _, nodegroup := range nodepool.Spec.NodeGroup {
 			if node.Spec.GroupName != nodegroup.NodePoolData.Name || node.Spec.HwProfile == nodegroup.NodePoolData.HwProfile {
@@ -204,15 +210,19 @@ func (a *Adaptor) handleNodePoolConfiguring(
 		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to check upgrade status for nodes: %w", err)
 	}
 
-	// Update NodePool status if all nodes are upgraded
+	// Hold the NodePool update while cordoned nodes have a stale profile
 	if len(nodesStillUpgrading) == 0 {
-		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
-			hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.ConfigApplied, metav1.ConditionTrue, string(hwmgmtv1alpha1.ConfigSuccess)); err != nil {
-			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		if held, err := utils.HoldUpdateForCordonedNodes(ctx, a.Client, a.Logger, nodepool, &cordoned); err != nil {
+			return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
+		} else if held {
+			return utils.RequeueWithMediumInterval(), nil
 		}
-		// Update the Node Pool hwMgrPlugin status
-		if err = utils.UpdateNodePoolPluginStatus(ctx, a.Client, nodepool); err != nil {
-			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update hwMgrPlugin observedGeneration Status: %w", err)
+	}
+
+	// Update NodePool status if all nodes are upgraded
+	if len(nodesStillUpgrading) == 0 {
+		if err := utils.CompleteNodePoolConfiguration(ctx, a.Client, nodepool); err != nil {
+			return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
 		}
 	} else {
 		// Requeue if there are still nodes upgrading
@@ -259,7 +269,11 @@ func (a *Adaptor) ProcessNewNodePool(ctx context.Context,
 	}
 
 	for _, nodegroup := range nodepool.Spec.NodeGroup {
-		freenodes := getFreeNodesInPool(resources, allocations, nodegroup.NodePoolData.ResourcePoolId)
+		arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodegroup.NodePoolData.Name)
+		if err != nil {
+			return err
+		}
+		freenodes := getFreeNodesInPool(resources, allocations, nodegroup.NodePoolData.ResourcePoolId, arch)
 		if nodegroup.Size > len(freenodes) {
 			return fmt.Errorf("not enough free resources in resource pool %s: freenodes=%d", nodegroup.NodePoolData.ResourcePoolId, len(freenodes))
 		}
@@ -302,7 +316,11 @@ func (a *Adaptor) IsNodePoolFullyAllocated(ctx context.Context,
 			continue
 		}
 
-		freenodes := getFreeNodesInPool(resources, allocations, nodegroup.NodePoolData.ResourcePoolId)
+		arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodegroup.NodePoolData.Name)
+		if err != nil {
+			return false, err
+		}
+		freenodes := getFreeNodesInPool(resources, allocations, nodegroup.NodePoolData.ResourcePoolId, arch)
 		if remaining > len(freenodes) {
 			return false, fmt.Errorf("not enough free resources remaining in resource pool %s", nodegroup.NodePoolData.ResourcePoolId)
 		}
@@ -325,6 +343,11 @@ func (a *Adaptor) ReleaseNodePool(ctx context.Context,
 		slog.String("cloudID", cloudID),
 	)
 
+	// The bmc-secrets are owned by the NodePool, unless created in the cluster namespace
+	if err := utils.DeleteBMCSecrets(ctx, a.Client, nodepool, a.Namespace); err != nil {
+		return err
+	}
+
 	cm, _, allocations, err := a.GetCurrentResources(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to get current resources: %w", err)
@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync/atomic"
 
 	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/metal3/controller"
 	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
@@ -23,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 type Adaptor struct {
@@ -32,6 +35,15 @@ type Adaptor struct {
 	Logger          *slog.Logger
 	Namespace       string
 	AdaptorID       pluginv1alpha1.HardwareManagerAdaptorID
+	// NodePoolEvents triggers reconciles of the NodePools whose BMHs transition, if set
+	NodePoolEvents chan<- event.GenericEvent
+	// disabledReason holds the reason the adaptor is disabled, or nil if it is enabled
+	disabledReason atomic.Pointer[string]
+	// loops holds the background loops of the adaptor, run from Start to Stop
+	loops adaptorinterface.BackgroundLoops
+	// Clock is the source of time of the timeouts, grace periods and status timestamps of the adaptor, the real
+	// clock if not set
+	Clock utils.Clock
 }
 
 func NewAdaptor(client client.Client, noncachedClient client.Reader, scheme *runtime.Scheme, logger *slog.Logger, namespace string) *Adaptor {
@@ -44,10 +56,33 @@ func NewAdaptor(client client.Client, noncachedClient client.Reader, scheme *run
 	}
 }
 
+// clock returns the clock of the adaptor
+func (a *Adaptor) clock() utils.Clock {
+	return utils.ClockOrReal(a.Clock)
+}
+
 // SetupAdaptor sets up the metal3 adaptor
 func (a *Adaptor) SetupAdaptor(mgr ctrl.Manager) error {
 	a.Logger.Info("SetupAdaptor called for metal3")
 
+	// The BareMetalHost CRD is optional, so the adaptor is disabled rather than failing if it is not installed, and
+	// enabled once it is
+	a.checkCRDs(context.Background(), mgr.GetRESTMapper())
+	a.loops.Logger = a.Logger
+	a.loops.Elected = mgr.Elected()
+	a.loops.Add(adaptorinterface.BackgroundLoop{
+		Name: "crd-watcher",
+		Run:  (&crdWatcher{adaptor: a, mapper: mgr.GetRESTMapper()}).run,
+	})
+
+	if a.NodePoolEvents != nil {
+		a.loops.Add(adaptorinterface.BackgroundLoop{
+			Name:       "bmh-watcher",
+			LeaderOnly: true,
+			Run:        (&bmhWatcher{adaptor: a, cache: mgr.GetCache()}).run,
+		})
+	}
+
 	if err := (&controller.HardwareManagerReconciler{
 		Client:    a.Client,
 		Scheme:    a.Scheme,
@@ -57,9 +92,27 @@ func (a *Adaptor) SetupAdaptor(mgr ctrl.Manager) error {
 		return fmt.Errorf("unable to setup metal3 adaptor: %w", err)
 	}
 
+	if err := (&controller.HardwareProfileReconciler{
+		Client: a.Client,
+		Logger: a.Logger,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup metal3 adaptor: %w", err)
+	}
+
 	return nil
 }
 
+// Start starts the background loops of the metal3 adaptor: the CRD watcher, and the BMH watcher once the replica is
+// elected leader
+func (a *Adaptor) Start(ctx context.Context) error {
+	return a.loops.Start(ctx)
+}
+
+// Stop stops the background loops of the metal3 adaptor
+func (a *Adaptor) Stop(ctx context.Context) error {
+	return a.loops.Stop(ctx)
+}
+
 // Metal3 Adaptor FSM
 type fsmAction int
 
@@ -68,6 +121,8 @@ const (
 	NodePoolFSMProcessing
 	NodePoolFSMSpecChanged
 	NodePoolFSMNoop
+	NodePoolFSMFailed
+	NodePoolFSMProvisioned
 )
 
 func (a *Adaptor) determineAction(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) fsmAction {
@@ -87,12 +142,12 @@ func (a *Adaptor) determineAction(ctx context.Context, nodepool *hwmgmtv1alpha1.
 				return NodePoolFSMSpecChanged
 			}
 			a.Logger.InfoContext(ctx, "NodePool request in Provisioned state")
-			return NodePoolFSMNoop
+			return NodePoolFSMProvisioned
 		}
 
 		if provisionedCondition.Reason == string(hwmgmtv1alpha1.Failed) {
 			a.Logger.InfoContext(ctx, "NodePool request in Failed state")
-			return NodePoolFSMNoop
+			return NodePoolFSMFailed
 		}
 
 		return NodePoolFSMProcessing
@@ -114,6 +169,10 @@ func (a *Adaptor) HandleNodePool(ctx context.Context, hwmgr *pluginv1alpha1.Hard
 	case NodePoolFSMNoop:
 		// Nothing to do
 		return result, nil
+	case NodePoolFSMFailed:
+		return a.HandleNodePoolFailed(ctx, hwmgr, nodepool)
+	case NodePoolFSMProvisioned:
+		return a.HandleNodePoolScaling(ctx, hwmgr, nodepool)
 	}
 
 	return result, nil
@@ -122,6 +181,10 @@ func (a *Adaptor) HandleNodePool(ctx context.Context, hwmgr *pluginv1alpha1.Hard
 func (a *Adaptor) HandleNodePoolDeletion(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
 	a.Logger.InfoContext(ctx, "Finalizing nodepool")
 
+	if cancelled, err := a.cancelInFlightOperations(ctx, hwmgr, nodepool); err != nil || !cancelled {
+		return false, err
+	}
+
 	if err := a.ReleaseNodePool(ctx, hwmgr, nodepool); err != nil {
 		return false, fmt.Errorf("failed to release nodepool %s: %w", nodepool.Name, err)
 	}
@@ -133,21 +196,20 @@ func (a *Adaptor) GetResourcePools(ctx context.Context, hwmgr *pluginv1alpha1.Ha
 	var resp []invserver.ResourcePoolInfo
 
 	var bmhList metal3v1alpha1.BareMetalHostList
-	var opts []client.ListOption
-
-	if err := a.Client.List(ctx, &bmhList, opts...); err != nil {
+	if err := a.listBMHs(ctx, hwmgr, &bmhList); err != nil {
 		return resp, http.StatusInternalServerError, fmt.Errorf("failed to get bmh list: %w", err)
 	}
 
+	// Keyed by pool, as a site may have several pools
 	pools := make(map[string]string)
 
 	for _, bmh := range bmhList.Items {
 		if includeInInventory(bmh) {
-			pools[bmh.Labels[LabelSiteID]] = bmh.Labels[LabelResourcePoolID]
+			pools[bmh.Labels[LabelResourcePoolID]] = bmh.Labels[LabelSiteID]
 		}
 	}
 
-	for siteId, poolID := range pools {
+	for poolID, siteId := range pools {
 		resp = append(resp, invserver.ResourcePoolInfo{
 			ResourcePoolId: poolID,
 			Description:    poolID,
@@ -163,9 +225,7 @@ func (a *Adaptor) GetResources(ctx context.Context, hwmgr *pluginv1alpha1.Hardwa
 	var resp []invserver.ResourceInfo
 
 	var bmhList metal3v1alpha1.BareMetalHostList
-	var opts []client.ListOption
-
-	if err := a.Client.List(ctx, &bmhList, opts...); err != nil {
+	if err := a.listBMHs(ctx, hwmgr, &bmhList); err != nil {
 		return resp, http.StatusInternalServerError, fmt.Errorf("failed to get bmh list: %w", err)
 	}
 
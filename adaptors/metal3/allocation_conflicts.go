@@ -0,0 +1,198 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// A new NodePool is checked against the hardware already claimed by other NodePools, so that contention is reported
+// when the NodePool is created rather than discovered as an allocation failure. A NodePool whose node groups match too
+// few free hosts is rejected with the NodePools holding the hosts it matches, and a NodePool whose free hosts are also
+// targeted by the pending allocations of other NodePools is accepted with a warning naming them.
+
+// countHostClaims counts the hosts claimed by each NodePool, from the NodePool of the Node backed by each host
+func countHostClaims(bmhs []metal3v1alpha1.BareMetalHost, owners map[client.ObjectKey]string) map[string]int {
+	claims := make(map[string]int)
+	for i := range bmhs {
+		owner := owners[client.ObjectKeyFromObject(&bmhs[i])]
+		if owner == "" {
+			continue
+		}
+		claims[owner]++
+	}
+	return claims
+}
+
+// describeHostClaims lists the NodePools claiming hosts, with their number of hosts, sorted by NodePool name
+func describeHostClaims(claims map[string]int) string {
+	names := make([]string, 0, len(claims))
+	for name := range claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	descriptions := make([]string, 0, len(names))
+	for _, name := range names {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%d)", name, claims[name]))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// contendedHosts returns the number of hosts of a node group that the pending allocation of another node group may
+// take, bounded by both the number of hosts it still needs and the number of hosts the two groups have in common
+func contendedHosts(candidates, otherCandidates []metal3v1alpha1.BareMetalHost, pending int) int {
+	shared := make(map[client.ObjectKey]bool, len(candidates))
+	for i := range candidates {
+		shared[client.ObjectKeyFromObject(&candidates[i])] = true
+	}
+	overlap := 0
+	for i := range otherCandidates {
+		if shared[client.ObjectKeyFromObject(&otherCandidates[i])] {
+			overlap++
+		}
+	}
+	return min(overlap, pending)
+}
+
+// getNodeGroupCandidates returns the free hosts matching the criteria and CPU architecture of a node group
+func (a *Adaptor) getNodeGroupCandidates(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool, nodeGroup hwmgmtv1alpha1.NodeGroup) ([]metal3v1alpha1.BareMetalHost, error) {
+	bmhList, err := a.FetchBMHList(ctx, hwmgr, nodepool.Spec.Site, nodeGroup.NodePoolData, UnallocatedBMHs, "",
+		adoptExternallyProvisioned(hwmgr))
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch BMHs for nodegroup=%s: %w", nodeGroup.NodePoolData.Name, err)
+	}
+	arch, _ := utils.GetRequestedCPUArchitecture(nodepool, nodeGroup.NodePoolData.Name)
+	return filterBMHsByCPUArchitecture(bmhList.Items, arch), nil
+}
+
+// getHostClaims counts the allocated hosts matching the criteria of a node group, by the NodePool claiming them
+func (a *Adaptor) getHostClaims(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool,
+	nodeGroup hwmgmtv1alpha1.NodeGroup) (map[string]int, error) {
+	matchingLabels, err := bmhMatchingLabels(nodepool.Spec.Site, nodeGroup.NodePoolData)
+	if err != nil {
+		return nil, err
+	}
+	matchingLabels[BmhAllocatedLabel] = ValueTrue
+
+	var bmhList metal3v1alpha1.BareMetalHostList
+	if err := a.listBMHs(ctx, hwmgr, &bmhList, matchingLabels); err != nil {
+		return nil, fmt.Errorf("failed to list allocated BMHs: %w", err)
+	}
+	arch, _ := utils.GetRequestedCPUArchitecture(nodepool, nodeGroup.NodePoolData.Name)
+	bmhs := filterBMHsByCPUArchitecture(bmhList.Items, arch)
+
+	var nodes hwmgmtv1alpha1.NodeList
+	if err := a.Client.List(ctx, &nodes, client.InNamespace(a.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	owners := make(map[client.ObjectKey]string, len(nodes.Items))
+	for _, node := range nodes.Items {
+		if utils.IsNodeReplaced(&node) {
+			continue
+		}
+		owners[client.ObjectKey{Namespace: node.Spec.HwMgrNodeNs, Name: node.Spec.HwMgrNodeId}] = node.Spec.NodePool
+	}
+
+	return countHostClaims(bmhs, owners), nil
+}
+
+// insufficientResourcesError reports the node groups of a new NodePool matching too few free hosts, along with the
+// NodePools claiming the hosts they match
+func (a *Adaptor) insufficientResourcesError(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool,
+	nodeGroup hwmgmtv1alpha1.NodeGroup, free, required int) error {
+	message := fmt.Sprintf("not enough free resources matching nodegroup=%s criteria: freenodes=%d, required=%d",
+		nodeGroup.NodePoolData.Name, free, required)
+
+	claims, err := a.getHostClaims(ctx, hwmgr, nodepool, nodeGroup)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "Unable to determine the claims on matching hosts", slog.String("error", err.Error()))
+		return fmt.Errorf("%s", message)
+	}
+	if len(claims) > 0 {
+		message += ", matching hosts claimed by nodepools " + describeHostClaims(claims)
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// checkAllocationContention returns a warning naming the other NodePools of the hardware manager whose pending
+// allocations target the free hosts of a new NodePool, if together they need more hosts than are free
+func (a *Adaptor) checkAllocationContention(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (string, error) {
+	sizes, err := utils.GetEffectiveNodeGroupSizes(nodepool)
+	if err != nil {
+		return "", err
+	}
+
+	nodepools := &hwmgmtv1alpha1.NodePoolList{}
+	if err := a.Client.List(ctx, nodepools, client.InNamespace(nodepool.Namespace)); err != nil {
+		return "", fmt.Errorf("failed to list nodepools: %w", err)
+	}
+
+	var warnings []string
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		size := sizes[nodeGroup.NodePoolData.Name]
+		if size == 0 {
+			continue
+		}
+		candidates, err := a.getNodeGroupCandidates(ctx, hwmgr, nodepool, nodeGroup)
+		if err != nil {
+			return "", err
+		}
+
+		contention := make(map[string]int)
+		demand := 0
+		for i := range nodepools.Items {
+			other := &nodepools.Items[i]
+			if other.Name == nodepool.Name || other.Spec.HwMgrId != nodepool.Spec.HwMgrId ||
+				other.GetDeletionTimestamp() != nil ||
+				meta.IsStatusConditionTrue(other.Status.Conditions, string(hwmgmtv1alpha1.Provisioned)) {
+				continue
+			}
+			otherSizes, err := utils.GetEffectiveNodeGroupSizes(other)
+			if err != nil {
+				continue // The other NodePool is rejected on its own
+			}
+			for _, otherGroup := range other.Spec.NodeGroup {
+				pending := otherSizes[otherGroup.NodePoolData.Name] -
+					a.countNodesInGroup(ctx, other.Status.Properties.NodeNames, otherGroup.NodePoolData.Name)
+				if pending <= 0 {
+					continue
+				}
+				otherCandidates, err := a.getNodeGroupCandidates(ctx, hwmgr, other, otherGroup)
+				if err != nil {
+					continue
+				}
+				if contended := contendedHosts(candidates, otherCandidates, pending); contended > 0 {
+					contention[other.Name] += contended
+					demand += contended
+				}
+			}
+		}
+
+		if len(candidates) < size+demand {
+			warnings = append(warnings, fmt.Sprintf(
+				"nodegroup=%s needs %d of %d free hosts, also targeted by pending allocations of nodepools %s",
+				nodeGroup.NodePoolData.Name, size, len(candidates), describeHostClaims(contention)))
+		}
+	}
+
+	return strings.Join(warnings, "; "), nil
+}
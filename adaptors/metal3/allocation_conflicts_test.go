@@ -0,0 +1,59 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestHostClaims(t *testing.T) {
+	bmhs := []metal3v1alpha1.BareMetalHost{
+		newTestBMH("a1", "site-a"),
+		newTestBMH("a2", "site-a"),
+		newTestBMH("a3", "site-a"),
+		newTestBMH("a4", "site-a"),
+	}
+	owners := map[client.ObjectKey]string{
+		{Name: "a1"}: "np-b",
+		{Name: "a2"}: "np-a",
+		{Name: "a3"}: "np-b",
+	}
+
+	claims := countHostClaims(bmhs, owners)
+	if len(claims) != 2 || claims["np-a"] != 1 || claims["np-b"] != 2 {
+		t.Errorf("unexpected claims: %v", claims)
+	}
+	if description := describeHostClaims(claims); description != "np-a (1), np-b (2)" {
+		t.Errorf("unexpected description: %s", description)
+	}
+}
+
+func TestContendedHosts(t *testing.T) {
+	candidates := []metal3v1alpha1.BareMetalHost{
+		newTestBMH("a1", "site-a"),
+		newTestBMH("a2", "site-a"),
+		newTestBMH("a3", "site-a"),
+	}
+	other := []metal3v1alpha1.BareMetalHost{
+		newTestBMH("a2", "site-a"),
+		newTestBMH("a3", "site-a"),
+		newTestBMH("b1", "site-b"),
+	}
+
+	if contended := contendedHosts(candidates, other, 5); contended != 2 {
+		t.Errorf("expected the shared hosts to be contended, got %d", contended)
+	}
+	if contended := contendedHosts(candidates, other, 1); contended != 1 {
+		t.Errorf("expected contention bounded by the pending allocation, got %d", contended)
+	}
+	if contended := contendedHosts(candidates, []metal3v1alpha1.BareMetalHost{newTestBMH("b1", "site-b")}, 5); contended != 0 {
+		t.Errorf("expected no contention without shared hosts, got %d", contended)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// GetBackendAllocations lists the BareMetalHosts labelled as allocated within the scope of the hardware manager. As
+// BareMetalHosts are not assigned to a hardware manager, hosts backing the Nodes of another hardware manager are left
+// out.
+func (a *Adaptor) GetBackendAllocations(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]adaptorinterface.BackendAllocation, error) {
+	var bmhList metal3v1alpha1.BareMetalHostList
+	if err := a.listBMHs(ctx, hwmgr, &bmhList, client.MatchingLabels{BmhAllocatedLabel: ValueTrue}); err != nil {
+		return nil, fmt.Errorf("failed to list allocated BMHs: %w", err)
+	}
+
+	var nodes hwmgmtv1alpha1.NodeList
+	if err := a.Client.List(ctx, &nodes, client.InNamespace(a.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	otherHwMgr := make(map[client.ObjectKey]bool)
+	for _, node := range nodes.Items {
+		if node.Spec.HwMgrId != hwmgr.Name && !utils.IsNodeReplaced(&node) {
+			otherHwMgr[client.ObjectKey{Namespace: node.Spec.HwMgrNodeNs, Name: node.Spec.HwMgrNodeId}] = true
+		}
+	}
+
+	allocations := make([]adaptorinterface.BackendAllocation, 0, len(bmhList.Items))
+	for _, bmh := range bmhList.Items {
+		if otherHwMgr[client.ObjectKeyFromObject(&bmh)] {
+			continue
+		}
+		allocations = append(allocations, adaptorinterface.BackendAllocation{
+			HwMgrNodeId: bmh.Name,
+			HwMgrNodeNs: bmh.Namespace,
+			LeaseExpiry: getLeaseExpiry(&bmh),
+		})
+	}
+	return allocations, nil
+}
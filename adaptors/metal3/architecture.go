@@ -0,0 +1,36 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// getBMHCPUArchitecture returns the CPU architecture reported by the inspection of the BMH, if known
+func getBMHCPUArchitecture(bmh metal3v1alpha1.BareMetalHost) string {
+	if bmh.Status.HardwareDetails == nil {
+		return ""
+	}
+	return utils.NormalizeCPUArchitecture(bmh.Status.HardwareDetails.CPU.Arch)
+}
+
+// filterBMHsByCPUArchitecture returns the BMHs with the given CPU architecture, keeping the order of the original
+// list. BMHs that have not been inspected are excluded, as their architecture is unknown.
+func filterBMHsByCPUArchitecture(bmhs []metal3v1alpha1.BareMetalHost, arch string) []metal3v1alpha1.BareMetalHost {
+	if arch == "" {
+		return bmhs
+	}
+
+	var filtered []metal3v1alpha1.BareMetalHost
+	for _, bmh := range bmhs {
+		if getBMHCPUArchitecture(bmh) == arch {
+			filtered = append(filtered, bmh)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,58 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+func TestFilterBMHsByCPUArchitecture(t *testing.T) {
+	newBMH := func(name, arch string) metal3v1alpha1.BareMetalHost {
+		bmh := newTestBMH(name, "site-a")
+		if arch != "" {
+			bmh.Status.HardwareDetails = &metal3v1alpha1.HardwareDetails{CPU: metal3v1alpha1.CPU{Arch: arch}}
+		}
+		return bmh
+	}
+	bmhs := []metal3v1alpha1.BareMetalHost{
+		newBMH("x1", "x86_64"),
+		newBMH("a1", "aarch64"),
+		newBMH("u1", ""),
+		newBMH("a2", "arm64"),
+	}
+
+	tests := []struct {
+		arch     string
+		expected []string
+	}{
+		{arch: "", expected: []string{"x1", "a1", "u1", "a2"}},
+		{arch: utils.CPUArchitectureX86_64, expected: []string{"x1"}},
+		{arch: utils.CPUArchitectureAArch64, expected: []string{"a1", "a2"}},
+	}
+
+	for _, tt := range tests {
+		filtered := filterBMHsByCPUArchitecture(bmhs, tt.arch)
+		var names []string
+		for _, bmh := range filtered {
+			names = append(names, bmh.Name)
+		}
+		if len(names) != len(tt.expected) {
+			t.Errorf("arch=%q: expected %v, got %v", tt.arch, tt.expected, names)
+			continue
+		}
+		for i := range names {
+			if names[i] != tt.expected[i] {
+				t.Errorf("arch=%q: expected %v, got %v", tt.arch, tt.expected, names)
+				break
+			}
+		}
+	}
+}
@@ -42,17 +42,26 @@ const (
 	BmhRebootAnnotation            = "reboot.metal3.io"
 	BiosUpdateNeededAnnotation     = "hwmgr-plugin.oran.openshift.io/bios-update-needed"
 	FirmwareUpdateNeededAnnotation = "hwmgr-plugin.oran.openshift.io/firmware-update-needed"
+	RAIDUpdateNeededAnnotation     = "hwmgr-plugin.oran.openshift.io/raid-update-needed"
 	BmhAllocatedLabel              = "hwmgr-plugin.oran.openshift.io/allocated"
 	NodeNameAnnotation             = "hwmgr-plugin.oran.openshift.io/node-name"
+	BmhNodePoolAnnotation          = "hwmgr-plugin.oran.openshift.io/nodepool"
 	Metal3Finalizer                = "preprovisioningimage.metal3.io"
 	UpdateReasonBIOSSettings       = "bios-settings-update"
 	UpdateReasonFirmware           = "firmware-update"
+	UpdateReasonRAID               = "raid-update"
 	ValueTrue                      = "true"
 	MetaTypeLabel                  = "label"
 	MetaTypeAnnotation             = "annotation"
 	OpAdd                          = "add"
 	OpRemove                       = "remove"
 	BmhServicingErr                = "BMH Servicing Error"
+	BiosSettingsValidationPending  = "Waiting for BIOS settings to be validated against the firmware schema"
+	BiosSettingsApplying           = "Applying BIOS settings"
+	BiosSettingsRejected           = "BIOS settings rejected"
+	FirmwareVerificationFailed     = "Firmware verification failed"
+	BiosSettingsVerificationFailed = "BIOS settings verification failed"
+	RAIDConfigurationFailed        = "RAID configuration failed"
 )
 
 // Struct definitions for the nodelist configmap
@@ -142,16 +151,9 @@ func (a *Adaptor) updateBMHMetaWithRetry(
 	})
 }
 
-// FetchBMHList retrieves BareMetalHosts filtered by site ID, allocation status, and optional namespace.
-func (a *Adaptor) FetchBMHList(
-	ctx context.Context,
-	site string,
-	nodePoolData hwmgmtv1alpha1.NodePoolData,
-	allocationStatus BMHAllocationStatus,
-	namespace string) (metal3v1alpha1.BareMetalHostList, error) {
-
-	var bmhList metal3v1alpha1.BareMetalHostList
-	opts := []client.ListOption{}
+// bmhMatchingLabels returns the labels of the BareMetalHosts matching the site, resource pool and resource selector of
+// a node group
+func bmhMatchingLabels(site string, nodePoolData hwmgmtv1alpha1.NodePoolData) (client.MatchingLabels, error) {
 	matchingLabels := make(client.MatchingLabels)
 
 	// Add site ID filter if provided
@@ -168,7 +170,7 @@ func (a *Adaptor) FetchBMHList(
 		resourceSelectors := make(map[string]string)
 
 		if err := json.Unmarshal([]byte(nodePoolData.ResourceSelector), &resourceSelectors); err != nil {
-			return bmhList, fmt.Errorf("unable to parse resourceSelector: %s: %w", nodePoolData.ResourceSelector, err)
+			return nil, fmt.Errorf("unable to parse resourceSelector: %s: %w", nodePoolData.ResourceSelector, err)
 		}
 
 		for key, value := range resourceSelectors {
@@ -181,6 +183,28 @@ func (a *Adaptor) FetchBMHList(
 		}
 	}
 
+	return matchingLabels, nil
+}
+
+// FetchBMHList retrieves the BareMetalHosts within the scope of the hardware manager, filtered by site ID, allocation
+// status, and optional namespace. Only hosts in the "Available" state are returned, along with externally provisioned
+// hosts if includeExternallyProvisioned is set.
+func (a *Adaptor) FetchBMHList(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	site string,
+	nodePoolData hwmgmtv1alpha1.NodePoolData,
+	allocationStatus BMHAllocationStatus,
+	namespace string,
+	includeExternallyProvisioned bool) (metal3v1alpha1.BareMetalHostList, error) {
+
+	var bmhList metal3v1alpha1.BareMetalHostList
+	opts := []client.ListOption{}
+	matchingLabels, err := bmhMatchingLabels(site, nodePoolData)
+	if err != nil {
+		return bmhList, err
+	}
+
 	// Add namespace filter if provided
 	if namespace != "" {
 		opts = append(opts, client.InNamespace(namespace))
@@ -216,7 +240,7 @@ func (a *Adaptor) FetchBMHList(
 	opts = append(opts, matchingLabels)
 
 	// Fetch BMHs based on filters
-	if err := a.Client.List(ctx, &bmhList, opts...); err != nil {
+	if err := a.listBMHs(ctx, hwmgr, &bmhList, opts...); err != nil {
 		return bmhList, fmt.Errorf("failed to get BMH list: %w", err)
 	}
 
@@ -227,15 +251,21 @@ func (a *Adaptor) FetchBMHList(
 		return bmhList, nil
 	}
 
-	// we only care about the ones in "available" state
-	return filterAvailableBMHs(bmhList), nil
+	// we only care about the ones in "available" state, or adoptable externally provisioned ones
+	return filterAvailableBMHs(bmhList, includeExternallyProvisioned), nil
 }
 
-// filterAvailableBMHs filters out BareMetalHosts that are not in the "Available" provisioning state.
-func filterAvailableBMHs(bmhList metal3v1alpha1.BareMetalHostList) metal3v1alpha1.BareMetalHostList {
+// filterAvailableBMHs filters out BareMetalHosts that are not in the "Available" provisioning state, keeping those in
+// the "ExternallyProvisioned" state if includeExternallyProvisioned is set. Hosts in error are filtered out until they
+// recover, and hosts released by the replacement of their node until they are repaired.
+func filterAvailableBMHs(bmhList metal3v1alpha1.BareMetalHostList, includeExternallyProvisioned bool) metal3v1alpha1.BareMetalHostList {
 	var filteredBMHs metal3v1alpha1.BareMetalHostList
 	for _, bmh := range bmhList.Items {
-		if bmh.Status.Provisioning.State == metal3v1alpha1.StateAvailable {
+		if isBMHFailed(&bmh) || isBMHMarkedFailed(&bmh) {
+			continue
+		}
+		if bmh.Status.Provisioning.State == metal3v1alpha1.StateAvailable ||
+			(includeExternallyProvisioned && isExternallyProvisioned(&bmh)) {
 			filteredBMHs.Items = append(filteredBMHs.Items, bmh)
 		}
 	}
@@ -253,18 +283,41 @@ func (a *Adaptor) GroupBMHsByResourcePool(unallocatedBMHs metal3v1alpha1.BareMet
 	return grouped
 }
 
-func (a *Adaptor) buildInterfacesFromBMH(nodepool *hwmgmtv1alpha1.NodePool, bmh metal3v1alpha1.BareMetalHost) []*hwmgmtv1alpha1.Interface {
+func (a *Adaptor) buildInterfacesFromBMH(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, bmh metal3v1alpha1.BareMetalHost) []*hwmgmtv1alpha1.Interface {
 	var interfaces []*hwmgmtv1alpha1.Interface
 
+	bootMAC := ""
+	if bmh.Spec.BootMACAddress != "" {
+		var err error
+		if bootMAC, err = utils.NormalizeMACAddress(bmh.Spec.BootMACAddress); err != nil {
+			a.Logger.WarnContext(ctx, "BMH has invalid boot MAC address", slog.String("bmh", bmh.Name), slog.String("error", err.Error()))
+		}
+	}
+
+	seen := make(map[string]bool)
 	for _, nic := range bmh.Status.HardwareDetails.NIC {
 		label := ""
 
-		if strings.EqualFold(nic.MAC, bmh.Spec.BootMACAddress) {
+		// Skip malformed or duplicate MACs, rather than passing them on to cluster network configs
+		mac, err := utils.NormalizeMACAddress(nic.MAC)
+		if err != nil {
+			a.Logger.WarnContext(ctx, "Skipping BMH NIC with invalid MAC address", slog.String("bmh", bmh.Name),
+				slog.String("nic", nic.Name), slog.String("error", err.Error()))
+			continue
+		}
+		if seen[mac] {
+			a.Logger.WarnContext(ctx, "Skipping BMH NIC with duplicate MAC address", slog.String("bmh", bmh.Name),
+				slog.String("nic", nic.Name), slog.String("mac", mac))
+			continue
+		}
+		seen[mac] = true
+
+		if mac == bootMAC {
 			// For the boot interface, use the label from the bootInterfaceLabel annotation on the nodepool CR
 			label = nodepool.Annotations[hwmgmtv1alpha1.BootInterfaceLabelAnnotation]
 		} else {
 			// Interface labels with MACs use - instead of :
-			hyphenatedMac := strings.ReplaceAll(nic.MAC, ":", "-")
+			hyphenatedMac := strings.ReplaceAll(mac, ":", "-")
 
 			// Process interface labels
 			for fullLabel, value := range bmh.Labels {
@@ -283,7 +336,7 @@ func (a *Adaptor) buildInterfacesFromBMH(nodepool *hwmgmtv1alpha1.NodePool, bmh
 
 		interfaces = append(interfaces, &hwmgmtv1alpha1.Interface{
 			Name:       nic.Name,
-			MACAddress: nic.MAC,
+			MACAddress: mac,
 			Label:      label,
 		})
 	}
@@ -311,9 +364,11 @@ func (a *Adaptor) isBMHAllocated(bmh *metal3v1alpha1.BareMetalHost) bool {
 	return false
 }
 
+// clearBMHNetworkData clears the preprovisioning network data of the BMH, deleting the secret rendered for it from
+// the network data template of its NodePool, if any
 func (a *Adaptor) clearBMHNetworkData(ctx context.Context, name types.NamespacedName) error {
 	// nolint:wrapcheck
-	return retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+	if err := retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
 		updatedBmh := &metal3v1alpha1.BareMetalHost{}
 
 		if err := a.Get(ctx, name, updatedBmh); err != nil {
@@ -324,7 +379,10 @@ func (a *Adaptor) clearBMHNetworkData(ctx context.Context, name types.Namespaced
 			return a.Client.Update(ctx, updatedBmh)
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	return a.deleteRenderedNetworkData(ctx, name)
 }
 
 func (a *Adaptor) applyPreChangeAnnotation(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) error {
@@ -409,10 +467,16 @@ func (a *Adaptor) removePreChangeAnnotation(ctx context.Context, bmh *metal3v1al
 	return nil
 }
 
-func (a *Adaptor) processHwProfileWithHandledError(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost,
-	nodeName, nodeNamepace, profileName string, postInstall bool) (bool, error) {
+func (a *Adaptor) processHwProfileWithHandledError(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	bmh *metal3v1alpha1.BareMetalHost, nodeName, nodeNamepace, profileName string, postInstall bool) (bool, error) {
 
-	updateRequired, err := a.processHwProfile(ctx, bmh, profileName, postInstall)
+	// The configuration of a cordoned node is left as is: none of the HostFirmwareSettings, HostFirmwareComponents,
+	// RAID or boot order of its BMH is changed
+	if err := utils.CheckNodeNotCordoned(ctx, a.NoncachedClient, nodeName, nodeNamepace); err != nil {
+		return false, err // nolint: wrapcheck
+	}
+
+	updateRequired, err := a.processHwProfile(ctx, hwmgr, bmh, profileName, postInstall)
 	contType := string(hwmgmtv1alpha1.Provisioned)
 	if postInstall {
 		contType = string(hwmgmtv1alpha1.Configured)
@@ -438,7 +502,8 @@ func (a *Adaptor) processHwProfileWithHandledError(ctx context.Context, bmh *met
 	return updateRequired, nil
 }
 
-func (a *Adaptor) processHwProfile(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost, profileName string, postInstall bool) (bool, error) {
+func (a *Adaptor) processHwProfile(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, bmh *metal3v1alpha1.BareMetalHost,
+	profileName string, postInstall bool) (bool, error) {
 
 	var err error
 	name := types.NamespacedName{
@@ -446,20 +511,54 @@ func (a *Adaptor) processHwProfile(ctx context.Context, bmh *metal3v1alpha1.Bare
 		Namespace: a.Namespace,
 	}
 
-	hwProfile := &pluginv1alpha1.HardwareProfile{}
-	if err := a.Client.Get(ctx, name, hwProfile); err != nil {
-		return false, fmt.Errorf("unable to find HardwareProfile CR (%s): %w", profileName, err)
+	if isExternallyProvisioned(bmh) {
+		// Adopted hosts are used as is
+		a.Logger.InfoContext(ctx, "Skipping hardware profile for externally provisioned BMH",
+			slog.String("bmh", bmh.Name), slog.String("profile", profileName))
+		return false, nil
+	}
+
+	hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, name.Name, name.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", profileName, err)
 	}
 
-	// Check if BIOS update is required
+	// Check if BIOS update is required, the boot order being set through the BIOS attributes
+	biosAttributes, err := getProfileBiosAttributes(bmh, hwProfile.Spec)
+	if err != nil {
+		return false, err // nolint: wrapcheck
+	}
 	biosUpdateRequired := false
-	if hwProfile.Spec.Bios.Attributes != nil {
-		biosUpdateRequired, err = a.IsBiosUpdateRequired(ctx, bmh, hwProfile.Spec.Bios)
+	if biosAttributes != nil {
+		biosUpdateRequired, err = a.IsBiosUpdateRequired(ctx, bmh, pluginv1alpha1.Bios{Attributes: biosAttributes})
 		if err != nil {
 			return false, err
 		}
 	}
 
+	// RAID is configured while the BMH is prepared, which only happens before provisioning
+	raidUpdateRequired := false
+	if hwProfile.Spec.RAID != nil {
+		if postInstall {
+			a.Logger.InfoContext(ctx, "Skipping RAID configuration of provisioned BMH",
+				slog.String("bmh", bmh.Name), slog.String("profile", profileName))
+		} else if raidUpdateRequired, err = a.IsRAIDUpdateRequired(bmh, hwProfile.Spec.RAID); err != nil {
+			return false, err
+		}
+	}
+
+	// Refuse firmware artifacts that fail signature verification before they are handed to the BMH. They are verified
+	// for every update, as the BMC downloads them again when applying them.
+	firmwarePending, err := a.isFirmwareUpdatePending(ctx, bmh, hwProfile.Spec)
+	if err != nil {
+		return false, err
+	}
+	if firmwarePending {
+		if err := utils.VerifyFirmwareArtifacts(ctx, a.Client, hwmgr, profileName, hwProfile.Spec); err != nil {
+			return false, err // nolint: wrapcheck
+		}
+	}
+
 	// Check if firmware update is required
 	firmwareUpdateRequired, err := a.IsFirmwareUpdateRequired(ctx, bmh, hwProfile.Spec)
 	if err != nil {
@@ -467,7 +566,7 @@ func (a *Adaptor) processHwProfile(ctx context.Context, bmh *metal3v1alpha1.Bare
 	}
 
 	// If nothing is required, return early
-	if !biosUpdateRequired && !firmwareUpdateRequired {
+	if !biosUpdateRequired && !firmwareUpdateRequired && !raidUpdateRequired {
 		return false, nil
 	}
 
@@ -492,6 +591,16 @@ func (a *Adaptor) processHwProfile(ctx context.Context, bmh *metal3v1alpha1.Bare
 		}
 	}
 
+	// if RAID update is required, request it in the RAID spec and annotate BMH
+	if raidUpdateRequired {
+		if err := a.setBMHRAIDConfig(ctx, bmhName, hwProfile.Spec.RAID); err != nil {
+			return true, err
+		}
+		if err := a.updateBMHMetaWithRetry(ctx, bmhName, MetaTypeAnnotation, RAIDUpdateNeededAnnotation, ValueTrue, OpAdd); err != nil {
+			return true, fmt.Errorf("failed to annotate BMH %s/%s: %w", bmh.Namespace, bmh.Name, err)
+		}
+	}
+
 	return true, nil
 }
 
@@ -518,6 +627,7 @@ func (a *Adaptor) annotateNodeConfigInProgress(ctx context.Context, nodeName, re
 	}
 
 	utils.SetConfigAnnotation(node, reason)
+	utils.StartNodeOperation(node, reason, "", a.clock())
 
 	// Update the Node object
 	if err := a.Client.Update(ctx, node); err != nil {
@@ -552,6 +662,7 @@ func (a *Adaptor) handleTransitionNodes(ctx context.Context, nodelist *hwmgmtv1a
 		}{
 			{BiosUpdateNeededAnnotation, UpdateReasonBIOSSettings, "BIOS settings"},
 			{FirmwareUpdateNeededAnnotation, UpdateReasonFirmware, "firmware"},
+			{RAIDUpdateNeededAnnotation, UpdateReasonRAID, "RAID"},
 		}
 
 		// Process each update case for the current BMH.
@@ -560,6 +671,14 @@ func (a *Adaptor) handleTransitionNodes(ctx context.Context, nodelist *hwmgmtv1a
 				continue
 			}
 
+			// The BIOS settings are only applied once validated against the firmware schema of the host
+			if uc.AnnotationKey == BiosUpdateNeededAnnotation {
+				validated, err := a.waitForFirmwareSettingsValidation(ctx, &node, bmh, postInstall)
+				if err != nil || !validated {
+					return true, err
+				}
+			}
+
 			if err := a.processBMHUpdateCase(ctx, &node, bmh, uc, postInstall); err != nil {
 				return true, err
 			}
@@ -704,7 +823,8 @@ func (a *Adaptor) processBMHUpdateCase(ctx context.Context, node *hwmgmtv1alpha1
 	return nil
 }
 
-func (a *Adaptor) handleBMHCompletion(ctx context.Context, nodelist *hwmgmtv1alpha1.NodeList) (bool, error) {
+func (a *Adaptor) handleBMHCompletion(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodelist *hwmgmtv1alpha1.NodeList) (bool, error) {
 
 	a.Logger.InfoContext(ctx, "Checking for node with config in progress")
 	node := utils.FindNodeInProgress(nodelist)
@@ -726,6 +846,9 @@ func (a *Adaptor) handleBMHCompletion(ctx context.Context, nodelist *hwmgmtv1alp
 		// BMH entered an error state
 		if bmh.Status.OperationalStatus == metal3v1alpha1.OperationalStatusError {
 			errMessage := fmt.Errorf("bmh %s/%s in an error state %s", bmh.Namespace, bmh.Name, bmh.Status.Provisioning.State)
+			if failure := raidConfigFailure(bmh); failure != "" {
+				errMessage = fmt.Errorf("bmh %s/%s %s", bmh.Namespace, bmh.Name, failure)
+			}
 			if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
 				string(hwmgmtv1alpha1.Provisioned), metav1.ConditionFalse,
 				string(hwmgmtv1alpha1.Failed), errMessage.Error()); err != nil {
@@ -737,17 +860,47 @@ func (a *Adaptor) handleBMHCompletion(ctx context.Context, nodelist *hwmgmtv1alp
 		return true, nil
 	}
 
+	// Provisioning is held until the BMH reports the requested RAID configuration as applied
+	if bmh.Spec.RAID != nil && !isRAIDConfigApplied(bmh, bmh.Spec.RAID) {
+		a.Logger.InfoContext(ctx, "Waiting for RAID configuration to be applied", slog.String("BMH", bmh.Name))
+		return true, nil
+	}
+
+	// Provisioning is held until the HostFirmwareSettings report the requested BIOS settings
+	failure, pending, err := a.verifyFirmwareSettings(ctx, hwmgr, node, bmh)
+	if err != nil {
+		return false, err
+	}
+	if pending {
+		a.Logger.InfoContext(ctx, "Waiting for BIOS settings to be reported", slog.String("BMH", bmh.Name))
+		return true, nil
+	}
+	if failure != "" {
+		message := fmt.Sprintf("%s: %s", BiosSettingsVerificationFailed, failure)
+		if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+			string(hwmgmtv1alpha1.Provisioned), metav1.ConditionFalse,
+			string(hwmgmtv1alpha1.Failed), message); err != nil {
+			a.Logger.ErrorContext(ctx, "failed to set node condition status",
+				slog.String("Node", node.Name), slog.String("error", err.Error()))
+		}
+		return false, fmt.Errorf("bmh %s/%s %s", bmh.Namespace, bmh.Name, message)
+	}
+
 	// Apply post-config updates and finalize the process
 	if err := a.ApplyPostConfigUpdates(ctx, types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}, node); err != nil {
 		return false, fmt.Errorf("failed to apply post config update on node %s: %w", node.Name, err)
 	}
+	if err := a.recordAppliedConfig(ctx, node.Name, node.Namespace, node.Spec.HwProfile); err != nil {
+		return false, err
+	}
 
 	return false, nil // update is now complete
 }
 
-func (a *Adaptor) checkForPendingUpdate(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+func (a *Adaptor) checkForPendingUpdate(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
 	// check if there are any pending work
-	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
 	if err != nil {
 		return false, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
 	}
@@ -764,7 +917,7 @@ func (a *Adaptor) checkForPendingUpdate(ctx context.Context, nodepool *hwmgmtv1a
 	}
 
 	// Check if configuration is completed
-	updating, err = a.handleBMHCompletion(ctx, nodelist)
+	updating, err = a.handleBMHCompletion(ctx, hwmgr, nodelist)
 	if err != nil {
 		return updating, err
 	}
@@ -796,10 +949,14 @@ func (a *Adaptor) markBMHAllocated(ctx context.Context, bmh *metal3v1alpha1.Bare
 	return a.updateBMHMetaWithRetry(ctx, name, MetaTypeLabel, BmhAllocatedLabel, ValueTrue, OpAdd)
 }
 
-// unmarkBMHAllocated removes the "allocated" label from a BareMetalHost if it exists.
+// unmarkBMHAllocated removes the "allocated" label, and the NodePool the BMH was allocated to, from a BareMetalHost if
+// they exist.
 func (a *Adaptor) unmarkBMHAllocated(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) error {
 	name := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
-	return a.updateBMHMetaWithRetry(ctx, name, MetaTypeLabel, BmhAllocatedLabel, "", OpRemove)
+	if err := a.updateBMHMetaWithRetry(ctx, name, MetaTypeLabel, BmhAllocatedLabel, "", OpRemove); err != nil {
+		return err
+	}
+	return a.updateBMHMetaWithRetry(ctx, name, MetaTypeAnnotation, BmhNodePoolAnnotation, "", OpRemove)
 }
 
 // removeMetal3Finalizer removes the Metal3 finalizer from the corresponding PreprovisioningImage resource.
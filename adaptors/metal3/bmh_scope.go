@@ -0,0 +1,108 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// BMHCacheOptions returns the options restricting the BMHs held by the informer cache of the manager to the namespaces
+// and label selector, or nil if neither is set. Hosts outside the cache are not seen by any hardware manager, whatever
+// its bmhScope, which can only narrow the cached hosts further.
+func BMHCacheOptions(namespaces []string, selector string) (*cache.ByObject, error) {
+	if len(namespaces) == 0 && selector == "" {
+		return nil, nil
+	}
+
+	byObject := &cache.ByObject{}
+	if len(namespaces) > 0 {
+		byObject.Namespaces = make(map[string]cache.Config)
+		for _, namespace := range namespaces {
+			byObject.Namespaces[namespace] = cache.Config{}
+		}
+	}
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BMH cache selector %q: %w", selector, err)
+		}
+		byObject.Label = parsed
+	}
+	return byObject, nil
+}
+
+// getBMHScope returns the namespaces and label selector the BMHs of the hardware manager are restricted to. No
+// namespaces and a nil selector mean all BMHs.
+func getBMHScope(hwmgr *pluginv1alpha1.HardwareManager) ([]string, labels.Selector, error) {
+	if hwmgr == nil || hwmgr.Spec.Metal3Data == nil || hwmgr.Spec.Metal3Data.BMHScope == nil {
+		return nil, nil, nil
+	}
+	scope := hwmgr.Spec.Metal3Data.BMHScope
+	if scope.Selector == nil {
+		return scope.Namespaces, nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(scope.Selector)
+	if err != nil {
+		return nil, nil, typederrors.NewInputError("invalid bmhScope selector of HardwareManager %s: %s",
+			hwmgr.Name, err.Error())
+	}
+	return scope.Namespaces, selector, nil
+}
+
+// scopeBMHListOptions restricts the options of a BMH listing to the namespaces and label selector of the scope of the
+// hardware manager, returning the namespaces to list. A listing in a namespace outside the scope lists no namespace.
+func scopeBMHListOptions(listOpts *client.ListOptions, namespaces []string, selector labels.Selector) []string {
+	if selector != nil {
+		requirements, _ := selector.Requirements()
+		if listOpts.LabelSelector == nil {
+			listOpts.LabelSelector = labels.NewSelector()
+		}
+		listOpts.LabelSelector = listOpts.LabelSelector.Add(requirements...)
+	}
+
+	switch {
+	case listOpts.Namespace != "" && len(namespaces) > 0 && !slices.Contains(namespaces, listOpts.Namespace):
+		return []string{}
+	case listOpts.Namespace != "" || len(namespaces) == 0:
+		return []string{listOpts.Namespace}
+	}
+	return namespaces
+}
+
+// listBMHs lists the BMHs within the scope of the hardware manager matching the options, listing each of the namespaces
+// of the scope in turn
+func (a *Adaptor) listBMHs(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, bmhList *metal3v1alpha1.BareMetalHostList,
+	opts ...client.ListOption) error {
+	namespaces, selector, err := getBMHScope(hwmgr)
+	if err != nil {
+		return err
+	}
+
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	for _, namespace := range scopeBMHListOptions(listOpts, namespaces, selector) {
+		var list metal3v1alpha1.BareMetalHostList
+		namespaceOpts := *listOpts
+		namespaceOpts.Namespace = namespace
+		if err := a.Client.List(ctx, &list, &namespaceOpts); err != nil {
+			return fmt.Errorf("failed to list BMHs: %w", err)
+		}
+		bmhList.Items = append(bmhList.Items, list.Items...)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestGetBMHScope(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if namespaces, selector, err := getBMHScope(hwmgr); err != nil || namespaces != nil || selector != nil {
+		t.Errorf("expected no scope, got %v, %v, %v", namespaces, selector, err)
+	}
+
+	hwmgr.Spec.Metal3Data = &pluginv1alpha1.Metal3Data{BMHScope: &pluginv1alpha1.BMHScope{
+		Namespaces: []string{"edge-a"},
+		Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"example.com/owner": "ran"}},
+	}}
+	namespaces, selector, err := getBMHScope(hwmgr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(namespaces, []string{"edge-a"}) || selector.String() != "example.com/owner=ran" {
+		t.Errorf("unexpected scope %v, %s", namespaces, selector)
+	}
+
+	hwmgr.Spec.Metal3Data.BMHScope.Selector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "example.com/owner", Operator: "Near"}},
+	}
+	if _, _, err := getBMHScope(hwmgr); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for an invalid selector, got %v", err)
+	}
+}
+
+func TestScopeBMHListOptions(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set{"example.com/owner": "ran"})
+
+	tests := []struct {
+		name               string
+		opts               []client.ListOption
+		namespaces         []string
+		selector           labels.Selector
+		expectedNamespaces []string
+		expectedSelector   string
+	}{
+		{name: "no scope",
+			opts:               []client.ListOption{client.MatchingLabels{BmhAllocatedLabel: ValueTrue}},
+			expectedNamespaces: []string{""},
+			expectedSelector:   BmhAllocatedLabel + "=true"},
+		{name: "selector added to the listing labels",
+			opts:               []client.ListOption{client.MatchingLabels{BmhAllocatedLabel: ValueTrue}},
+			selector:           selector,
+			expectedNamespaces: []string{""},
+			expectedSelector:   "example.com/owner=ran," + BmhAllocatedLabel + "=true"},
+		{name: "namespaces of the scope",
+			namespaces:         []string{"edge-a", "edge-b"},
+			selector:           selector,
+			expectedNamespaces: []string{"edge-a", "edge-b"},
+			expectedSelector:   "example.com/owner=ran"},
+		{name: "namespace within the scope",
+			opts:               []client.ListOption{client.InNamespace("edge-b")},
+			namespaces:         []string{"edge-a", "edge-b"},
+			expectedNamespaces: []string{"edge-b"}},
+		{name: "namespace outside the scope",
+			opts:               []client.ListOption{client.InNamespace("edge-c")},
+			namespaces:         []string{"edge-a", "edge-b"},
+			expectedNamespaces: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listOpts := &client.ListOptions{}
+			listOpts.ApplyOptions(tt.opts)
+			namespaces := scopeBMHListOptions(listOpts, tt.namespaces, tt.selector)
+			if !slices.Equal(namespaces, tt.expectedNamespaces) {
+				t.Errorf("expected namespaces %q, got %q", tt.expectedNamespaces, namespaces)
+			}
+			if tt.expectedSelector == "" {
+				if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Empty() {
+					t.Errorf("expected no label selector, got %s", listOpts.LabelSelector)
+				}
+			} else if listOpts.LabelSelector.String() != tt.expectedSelector {
+				t.Errorf("expected label selector %s, got %s", tt.expectedSelector, listOpts.LabelSelector)
+			}
+		})
+	}
+}
+
+func TestBMHCacheOptions(t *testing.T) {
+	if byObject, err := BMHCacheOptions(nil, ""); err != nil || byObject != nil {
+		t.Errorf("expected no cache options, got %v, %v", byObject, err)
+	}
+
+	byObject, err := BMHCacheOptions([]string{"edge-a", "edge-b"}, "example.com/owner=ran")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(byObject.Namespaces) != 2 || byObject.Label.String() != "example.com/owner=ran" {
+		t.Errorf("unexpected cache options %v, %s", byObject.Namespaces, byObject.Label)
+	}
+
+	if _, err := BMHCacheOptions(nil, "example.com/owner in"); err == nil {
+		t.Error("expected an error for an invalid selector")
+	}
+}
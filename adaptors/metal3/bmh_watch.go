@@ -0,0 +1,152 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// The NodePools in progress are requeued periodically, which delays the report of the transitions of their BMHs, such
+// as the end of their provisioning or servicing. The BMHs are also watched, so that a transition triggers a reconcile
+// of the NodePool the BMH is allocated to right away. The NodePool is recorded on the BMH at allocation, and found from
+// the Node of the BMH for hosts allocated before it was recorded.
+
+// bmhWatchCheckInterval is the interval at which the watcher checks whether the BMH informer must be (re)registered, as
+// the BareMetalHost CRD may be installed after startup, and the informer may be restarted by the cache watchdog
+const bmhWatchCheckInterval = time.Minute
+
+// bmhTransitioned checks whether a change of a BMH may change the status of its NodePool
+func bmhTransitioned(oldBMH, newBMH *metal3v1alpha1.BareMetalHost) bool {
+	return oldBMH.Status.Provisioning.State != newBMH.Status.Provisioning.State ||
+		oldBMH.Status.OperationalStatus != newBMH.Status.OperationalStatus ||
+		oldBMH.Status.ErrorType != newBMH.Status.ErrorType ||
+		oldBMH.Status.PoweredOn != newBMH.Status.PoweredOn
+}
+
+// getBMHNodePool returns the name of the NodePool the BMH is allocated to, or an empty string if it is not allocated
+func (a *Adaptor) getBMHNodePool(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) (string, error) {
+	if nodepool := bmh.Annotations[BmhNodePoolAnnotation]; nodepool != "" {
+		return nodepool, nil
+	}
+	if !a.isBMHAllocated(bmh) {
+		return "", nil
+	}
+
+	var nodes hwmgmtv1alpha1.NodeList
+	if err := a.Client.List(ctx, &nodes, client.InNamespace(a.Namespace),
+		client.MatchingFields{utils.NodeSpecHwMgrNodeIdKey: bmh.Name}); err != nil {
+		return "", fmt.Errorf("failed to list nodes of BMH %s/%s: %w", bmh.Namespace, bmh.Name, err)
+	}
+	for _, node := range nodes.Items {
+		if node.Spec.HwMgrNodeNs == bmh.Namespace && !utils.IsNodeReplaced(&node) {
+			return node.Spec.NodePool, nil
+		}
+	}
+	return "", nil
+}
+
+// enqueueBMHNodePool triggers a reconcile of the NodePool the BMH is allocated to, if any. The send does not block: a
+// dropped event is covered by the periodic requeue of the NodePool.
+func (a *Adaptor) enqueueBMHNodePool(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) {
+	name, err := a.getBMHNodePool(ctx, bmh)
+	if err != nil {
+		a.Logger.WarnContext(ctx, "Unable to find the NodePool of BMH", slog.String("bmh", bmh.Name),
+			slog.String("error", err.Error()))
+		return
+	}
+	if name == "" {
+		return
+	}
+
+	nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: a.Namespace}}
+	select {
+	case a.NodePoolEvents <- event.GenericEvent{Object: nodepool}:
+		a.Logger.DebugContext(ctx, "BMH transitioned, requeueing NodePool", slog.String("bmh", bmh.Name),
+			slog.String("nodepool", name))
+	default:
+		a.Logger.WarnContext(ctx, "NodePool event queue is full, dropping BMH transition",
+			slog.String("bmh", bmh.Name), slog.String("nodepool", name))
+	}
+}
+
+// bmhWatcher triggers reconciles of the NodePools whose BMHs transition. It only runs on the leader, along with the
+// NodePool controller.
+type bmhWatcher struct {
+	adaptor *Adaptor
+	cache   cache.Cache
+}
+
+func (w *bmhWatcher) run(ctx context.Context) error {
+	ticker := w.adaptor.clock().NewTicker(bmhWatchCheckInterval)
+	defer ticker.Stop()
+
+	var informer cache.Informer
+	var registration toolscache.ResourceEventHandlerRegistration
+	for {
+		if (informer == nil || informer.IsStopped()) && w.adaptor.DisabledReason() == "" {
+			var err error
+			if informer, registration, err = w.register(ctx); err != nil {
+				w.adaptor.Logger.WarnContext(ctx, "Unable to watch BMHs, relying on NodePool requeues",
+					slog.String("error", err.Error()))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if informer != nil && !informer.IsStopped() {
+				if err := informer.RemoveEventHandler(registration); err != nil {
+					return fmt.Errorf("failed to remove BMH event handler: %w", err)
+				}
+			}
+			return nil
+		case <-ticker.C():
+		}
+	}
+}
+
+// register adds the BMH event handler to the BMH informer
+func (w *bmhWatcher) register(ctx context.Context) (cache.Informer, toolscache.ResourceEventHandlerRegistration, error) {
+	informer, err := w.cache.GetInformer(ctx, &metal3v1alpha1.BareMetalHost{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get BMH informer: %w", err)
+	}
+
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldBMH, oldOk := oldObj.(*metal3v1alpha1.BareMetalHost)
+			newBMH, newOk := newObj.(*metal3v1alpha1.BareMetalHost)
+			if oldOk && newOk && bmhTransitioned(oldBMH, newBMH) {
+				w.adaptor.enqueueBMHNodePool(ctx, newBMH)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if bmh, ok := obj.(*metal3v1alpha1.BareMetalHost); ok {
+				w.adaptor.enqueueBMHNodePool(ctx, bmh)
+			}
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add BMH event handler: %w", err)
+	}
+	return informer, registration, nil
+}
@@ -0,0 +1,68 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestBmhTransitioned(t *testing.T) {
+	bmh := &metal3v1alpha1.BareMetalHost{}
+	bmh.Status.Provisioning.State = metal3v1alpha1.StatePreparing
+
+	unchanged := bmh.DeepCopy()
+	unchanged.Annotations = map[string]string{"example.com/other": "value"}
+	if bmhTransitioned(bmh, unchanged) {
+		t.Errorf("expected a metadata change not to be a transition")
+	}
+
+	provisioned := bmh.DeepCopy()
+	provisioned.Status.Provisioning.State = metal3v1alpha1.StateAvailable
+	if !bmhTransitioned(bmh, provisioned) {
+		t.Errorf("expected a provisioning state change to be a transition")
+	}
+
+	failed := bmh.DeepCopy()
+	failed.Status.ErrorType = metal3v1alpha1.ServicingError
+	if !bmhTransitioned(bmh, failed) {
+		t.Errorf("expected an error to be a transition")
+	}
+}
+
+func TestEnqueueBMHNodePool(t *testing.T) {
+	events := make(chan event.GenericEvent, 1)
+	a := &Adaptor{Logger: slog.Default(), Namespace: "oran-hwmgr-plugin", NodePoolEvents: events}
+
+	free := &metal3v1alpha1.BareMetalHost{}
+	free.Name = "host-0"
+	a.enqueueBMHNodePool(context.Background(), free)
+	if len(events) != 0 {
+		t.Fatalf("expected no event for an unallocated BMH")
+	}
+
+	allocated := free.DeepCopy()
+	allocated.Annotations = map[string]string{BmhNodePoolAnnotation: "np1"}
+	a.enqueueBMHNodePool(context.Background(), allocated)
+	if len(events) != 1 {
+		t.Fatalf("expected an event for the NodePool of the BMH")
+	}
+
+	// The send does not block once the queue is full
+	a.enqueueBMHNodePool(context.Background(), allocated)
+	evt := <-events
+	if evt.Object.GetName() != "np1" || evt.Object.GetNamespace() != "oran-hwmgr-plugin" {
+		t.Errorf("unexpected NodePool %s/%s", evt.Object.GetNamespace(), evt.Object.GetName())
+	}
+	if len(events) != 0 {
+		t.Errorf("expected the event to be dropped when the queue is full")
+	}
+}
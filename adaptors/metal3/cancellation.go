@@ -0,0 +1,106 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// When a NodePool is deleted while its hosts are being configured, the updates requested on the BMHs that the
+// baremetal-operator has not yet started are withdrawn, and the hosts are only released once the operations already
+// started by the baremetal-operator have finished, so that a released host is not reconfigured for a NodePool that no
+// longer exists. The wait is bounded by the jobCancellation timeout, counted from the deletion of the NodePool, after
+// which the hosts are released regardless.
+
+// pendingOperationAnnotations are the BMH annotations requesting operations not yet started by the baremetal-operator
+var pendingOperationAnnotations = []string{
+	BiosUpdateNeededAnnotation,
+	FirmwareUpdateNeededAnnotation,
+	RAIDUpdateNeededAnnotation,
+	BmhRebootAnnotation,
+}
+
+// isBMHOperationInFlight checks whether the baremetal-operator is running an operation on the BMH, preparing it before
+// provisioning or servicing it after
+func isBMHOperationInFlight(bmh *metal3v1alpha1.BareMetalHost) bool {
+	return bmh.Status.Provisioning.State == metal3v1alpha1.StatePreparing ||
+		bmh.Status.OperationalStatus == metal3v1alpha1.OperationalStatusServicing
+}
+
+// cancellationTimedOut checks whether the wait for the operations in flight on the hosts of a NodePool deleted at the
+// given time has exceeded the timeout. A zero timeout means no limit.
+func cancellationTimedOut(deletionTimestamp time.Time, timeout time.Duration, now time.Time) bool {
+	return timeout > 0 && now.Sub(deletionTimestamp) > timeout
+}
+
+// withdrawPendingOperations removes the annotations requesting operations on the BMH that have not yet started
+func (a *Adaptor) withdrawPendingOperations(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) error {
+	bmhName := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
+	for _, annotation := range pendingOperationAnnotations {
+		if _, exists := bmh.Annotations[annotation]; !exists {
+			continue
+		}
+		if err := a.updateBMHMetaWithRetry(ctx, bmhName, MetaTypeAnnotation, annotation, "", OpRemove); err != nil {
+			return fmt.Errorf("failed to remove annotation %s from BMH %s: %w", annotation, bmh.Name, err)
+		}
+		a.Logger.InfoContext(ctx, "Withdrew pending operation from BMH", slog.String("BMH", bmh.Name),
+			slog.String("annotation", annotation))
+	}
+	return nil
+}
+
+// cancelInFlightOperations withdraws the pending operations on the hosts of a deleted NodePool, returning true once no
+// operation is in flight on its hosts, or once the jobCancellation timeout has passed
+func (a *Adaptor) cancelInFlightOperations(ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
+	if err != nil {
+		return false, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	var inFlight []string
+	for _, node := range nodelist.Items {
+		bmh, err := a.getBMHForNode(ctx, &node)
+		if err != nil {
+			return false, fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
+		}
+		if err := a.withdrawPendingOperations(ctx, bmh); err != nil {
+			return false, err
+		}
+		if isBMHOperationInFlight(bmh) {
+			inFlight = append(inFlight, bmh.Name)
+		}
+	}
+
+	if len(inFlight) == 0 {
+		return true, nil
+	}
+
+	timeout := utils.GetOperationTimeout(hwmgr, utils.OperationJobCancellation)
+	if nodepool.DeletionTimestamp != nil && cancellationTimedOut(nodepool.DeletionTimestamp.Time, timeout, a.clock().Now()) {
+		a.Logger.WarnContext(ctx, "Operations still in flight after the cancellation timeout, releasing hosts regardless",
+			slog.String("BMHs", strings.Join(inFlight, ",")), slog.Duration("timeout", timeout))
+		return true, nil
+	}
+
+	a.Logger.InfoContext(ctx, "Waiting for operations in flight before releasing hosts",
+		slog.String("BMHs", strings.Join(inFlight, ",")))
+	return false, nil
+}
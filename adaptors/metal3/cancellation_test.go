@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+)
+
+func TestIsBMHOperationInFlight(t *testing.T) {
+	testcases := []struct {
+		name     string
+		state    metal3v1alpha1.ProvisioningState
+		status   metal3v1alpha1.OperationalStatus
+		inFlight bool
+	}{
+		{name: "available", state: metal3v1alpha1.StateAvailable, status: metal3v1alpha1.OperationalStatusOK},
+		{name: "preparing", state: metal3v1alpha1.StatePreparing, status: metal3v1alpha1.OperationalStatusOK, inFlight: true},
+		{name: "servicing", state: metal3v1alpha1.StateProvisioned, status: metal3v1alpha1.OperationalStatusServicing, inFlight: true},
+		{name: "provisioned", state: metal3v1alpha1.StateProvisioned, status: metal3v1alpha1.OperationalStatusOK},
+	}
+
+	for _, tc := range testcases {
+		bmh := &metal3v1alpha1.BareMetalHost{}
+		bmh.Status.Provisioning.State = tc.state
+		bmh.Status.OperationalStatus = tc.status
+		if actual := isBMHOperationInFlight(bmh); actual != tc.inFlight {
+			t.Errorf("%s: expected in flight %t, got %t", tc.name, tc.inFlight, actual)
+		}
+	}
+}
+
+func TestCancellationTimedOut(t *testing.T) {
+	deleted := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if cancellationTimedOut(deleted, 10*time.Minute, deleted.Add(5*time.Minute)) {
+		t.Errorf("expected the cancellation not to time out within the timeout")
+	}
+	if !cancellationTimedOut(deleted, 10*time.Minute, deleted.Add(11*time.Minute)) {
+		t.Errorf("expected the cancellation to time out after the timeout")
+	}
+	if cancellationTimedOut(deleted, 0, deleted.Add(24*time.Hour)) {
+		t.Errorf("expected no timeout without a limit")
+	}
+}
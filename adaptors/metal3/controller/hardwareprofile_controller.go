@@ -0,0 +1,123 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// HardwareProfileReconciler resolves the effective spec of a HardwareProfile from its chain of base profiles, and
+// publishes it in the profile status
+type HardwareProfileReconciler struct {
+	client.Client
+	Logger *slog.Logger
+}
+
+// Reconcile resolves the effective spec of the HardwareProfile
+func (r *HardwareProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	result = utils.DoNotRequeue()
+
+	hwProfile := &pluginv1alpha1.HardwareProfile{}
+	if err = r.Client.Get(ctx, req.NamespacedName, hwProfile); err != nil {
+		if errors.IsNotFound(err) {
+			err = nil
+		}
+		return
+	}
+
+	ctx = logging.AppendCtx(ctx, slog.String("hwProfile", hwProfile.Name))
+
+	patch := client.MergeFrom(hwProfile.DeepCopy())
+	original := hwProfile.Status.DeepCopy()
+
+	resolved, chain, resolveErr := utils.ResolveHardwareProfile(ctx, r.Client, hwProfile.Name, hwProfile.Namespace)
+	switch {
+	case resolveErr == nil:
+		hwProfile.Status.EffectiveSpec = &resolved.Spec
+		hwProfile.Status.ProfileChain = chain
+		utils.SetStatusCondition(&hwProfile.Status.Conditions,
+			string(pluginv1alpha1.ConditionTypes.Validation),
+			string(pluginv1alpha1.ConditionReasons.Completed),
+			metav1.ConditionTrue,
+			"Resolved")
+	case typederrors.IsInputError(resolveErr):
+		r.Logger.InfoContext(ctx, "Unable to resolve HardwareProfile", slog.String("error", resolveErr.Error()))
+		hwProfile.Status.EffectiveSpec = nil
+		hwProfile.Status.ProfileChain = nil
+		utils.SetStatusCondition(&hwProfile.Status.Conditions,
+			string(pluginv1alpha1.ConditionTypes.Validation),
+			string(pluginv1alpha1.ConditionReasons.Failed),
+			metav1.ConditionFalse,
+			resolveErr.Error())
+	default:
+		err = fmt.Errorf("failed to resolve HardwareProfile %s: %w", hwProfile.Name, resolveErr)
+		return
+	}
+	hwProfile.Status.ObservedGeneration = hwProfile.Generation
+
+	if equality.Semantic.DeepEqual(original, &hwProfile.Status) {
+		return
+	}
+
+	if err = r.Client.Status().Patch(ctx, hwProfile, patch); err != nil {
+		err = fmt.Errorf("failed to update status for HardwareProfile %s: %w", hwProfile.Name, err)
+		return
+	}
+
+	r.Logger.InfoContext(ctx, "Resolved HardwareProfile", slog.Any("profileChain", hwProfile.Status.ProfileChain))
+	return
+}
+
+// mapBaseProfileToDerived enqueues the profiles that inherit from another profile when a profile changes, as their
+// effective spec may depend on it
+func (r *HardwareProfileReconciler) mapBaseProfileToDerived(ctx context.Context, obj client.Object) []reconcile.Request {
+	profiles := &pluginv1alpha1.HardwareProfileList{}
+	if err := r.Client.List(ctx, profiles, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Logger.ErrorContext(ctx, "Unable to list HardwareProfiles", slog.String("error", err.Error()))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, profile := range profiles.Items {
+		if profile.Spec.BaseProfile != "" && profile.Name != obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&profile)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HardwareProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("hardwareprofile").
+		For(&pluginv1alpha1.HardwareProfile{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&pluginv1alpha1.HardwareProfile{},
+			handler.EnqueueRequestsFromMapFunc(r.mapBaseProfileToDerived),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r); err != nil {
+		return fmt.Errorf("failed to setup HardwareProfile controller: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// crdCheckInterval is the interval at which a disabled adaptor checks whether the BareMetalHost CRD has been installed
+const crdCheckInterval = time.Minute
+
+// bmhGroupVersionKind identifies the BareMetalHost CRD, without which the adaptor is disabled
+var bmhGroupVersionKind = metal3v1alpha1.GroupVersion.WithKind("BareMetalHost")
+
+// DisabledReason returns the reason the adaptor is disabled, or an empty string if it is enabled
+func (a *Adaptor) DisabledReason() string {
+	if reason := a.disabledReason.Load(); reason != nil {
+		return *reason
+	}
+	return ""
+}
+
+// crdsDisabledReason checks whether the BareMetalHost CRD is served by the API server, returning the reason the
+// adaptor is to be disabled if it is not
+func crdsDisabledReason(mapper meta.RESTMapper, gvk schema.GroupVersionKind) string {
+	if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return fmt.Sprintf("%s CRD is not installed", gvk.Kind)
+		}
+		return fmt.Sprintf("unable to check for %s CRD: %s", gvk.Kind, err.Error())
+	}
+	return ""
+}
+
+// setDisabledReason disables the adaptor for the given reason, or enables it if the reason is empty
+func (a *Adaptor) setDisabledReason(reason string) {
+	if reason == "" {
+		a.disabledReason.Store(nil)
+		return
+	}
+	a.disabledReason.Store(&reason)
+}
+
+// checkCRDs enables or disables the adaptor depending on whether the BareMetalHost CRD is installed
+func (a *Adaptor) checkCRDs(ctx context.Context, mapper meta.RESTMapper) {
+	previous := a.DisabledReason()
+	reason := crdsDisabledReason(mapper, bmhGroupVersionKind)
+	a.setDisabledReason(reason)
+
+	switch {
+	case reason == previous:
+		return
+	case reason != "":
+		a.Logger.WarnContext(ctx, "metal3 adaptor disabled", slog.String("reason", reason))
+	default:
+		a.Logger.InfoContext(ctx, "metal3 adaptor enabled")
+	}
+}
+
+// crdWatcher re-enables the adaptor once the BareMetalHost CRD is installed. It runs on every replica, as the
+// inventory API is served regardless of leader election.
+type crdWatcher struct {
+	adaptor *Adaptor
+	mapper  meta.RESTMapper
+}
+
+func (w *crdWatcher) run(ctx context.Context) error {
+	ticker := w.adaptor.clock().NewTicker(crdCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+			if w.adaptor.DisabledReason() != "" {
+				w.adaptor.checkCRDs(ctx, w.mapper)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCheckCRDs(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{metal3v1alpha1.GroupVersion})
+	a := &Adaptor{Logger: slog.Default()}
+
+	a.checkCRDs(context.Background(), mapper)
+	if reason := a.DisabledReason(); reason != "BareMetalHost CRD is not installed" {
+		t.Errorf("expected the adaptor to be disabled without the CRD, got %q", reason)
+	}
+
+	mapper.Add(bmhGroupVersionKind, meta.RESTScopeNamespace)
+	a.checkCRDs(context.Background(), mapper)
+	if reason := a.DisabledReason(); reason != "" {
+		t.Errorf("expected the adaptor to be enabled once the CRD is installed, got %q", reason)
+	}
+}
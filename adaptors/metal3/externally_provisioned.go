@@ -0,0 +1,59 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// adoptExternallyProvisioned returns true if the HardwareManager allows externally provisioned hosts to be allocated
+func adoptExternallyProvisioned(hwmgr *pluginv1alpha1.HardwareManager) bool {
+	return hwmgr != nil && hwmgr.Spec.Metal3Data != nil && hwmgr.Spec.Metal3Data.ExternallyProvisioned != nil &&
+		hwmgr.Spec.Metal3Data.ExternallyProvisioned.Adopt
+}
+
+// isExternallyProvisioned returns true if the BMH was provisioned outside of metal3. Such hosts are used as is when
+// adopted into a NodePool.
+func isExternallyProvisioned(bmh *metal3v1alpha1.BareMetalHost) bool {
+	return bmh.Status.Provisioning.State == metal3v1alpha1.StateExternallyProvisioned
+}
+
+// disableBMHCleaning ensures that automated cleaning is disabled for the BMH, so that the disks of an adopted host are
+// never wiped, even if the host is later deprovisioned
+func (a *Adaptor) disableBMHCleaning(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) error {
+	if bmh.Spec.AutomatedCleaningMode == metal3v1alpha1.CleaningModeDisabled {
+		return nil
+	}
+
+	name := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
+	// nolint:wrapcheck
+	return retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+		updatedBmh := &metal3v1alpha1.BareMetalHost{}
+		if err := a.Client.Get(ctx, name, updatedBmh); err != nil {
+			return fmt.Errorf("failed to fetch BMH %s/%s: %w", name.Namespace, name.Name, err)
+		}
+		if updatedBmh.Spec.AutomatedCleaningMode == metal3v1alpha1.CleaningModeDisabled {
+			return nil
+		}
+
+		updatedBmh.Spec.AutomatedCleaningMode = metal3v1alpha1.CleaningModeDisabled
+		if err := a.Client.Update(ctx, updatedBmh); err != nil {
+			return err
+		}
+		a.Logger.InfoContext(ctx, "Disabled automated cleaning for externally provisioned BMH", slog.String("bmh", name.String()))
+		return nil
+	})
+}
@@ -0,0 +1,65 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func TestFilterAvailableBMHs(t *testing.T) {
+	bmhList := metal3v1alpha1.BareMetalHostList{}
+	for name, state := range map[string]metal3v1alpha1.ProvisioningState{
+		"available":   metal3v1alpha1.StateAvailable,
+		"external":    metal3v1alpha1.StateExternallyProvisioned,
+		"provisioned": metal3v1alpha1.StateProvisioned,
+	} {
+		bmh := newTestBMH(name, "site-a")
+		bmh.Status.Provisioning.State = state
+		bmhList.Items = append(bmhList.Items, bmh)
+	}
+
+	if filtered := filterAvailableBMHs(bmhList, false); len(filtered.Items) != 1 || filtered.Items[0].Name != "available" {
+		t.Errorf("expected only the available BMH, got %v", filtered.Items)
+	}
+	if filtered := filterAvailableBMHs(bmhList, true); len(filtered.Items) != 2 {
+		t.Errorf("expected the available and externally provisioned BMHs, got %v", filtered.Items)
+	}
+
+	failed := newTestBMH("failed", "site-a")
+	failed.Status.Provisioning.State = metal3v1alpha1.StateAvailable
+	failed.Status.OperationalStatus = metal3v1alpha1.OperationalStatusError
+	bmhList.Items = append(bmhList.Items, failed)
+	if filtered := filterAvailableBMHs(bmhList, false); len(filtered.Items) != 1 || filtered.Items[0].Name != "available" {
+		t.Errorf("expected the BMH in error to be filtered out, got %v", filtered.Items)
+	}
+
+	// A BMH released by the replacement of its node stays out of allocation once it leaves the error state
+	failed.Status.OperationalStatus = metal3v1alpha1.OperationalStatusOK
+	failed.Labels[BmhFailedLabel] = ValueTrue
+	bmhList.Items[len(bmhList.Items)-1] = failed
+	if filtered := filterAvailableBMHs(bmhList, false); len(filtered.Items) != 1 || filtered.Items[0].Name != "available" {
+		t.Errorf("expected the BMH marked failed to be filtered out, got %v", filtered.Items)
+	}
+}
+
+func TestAdoptExternallyProvisioned(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if adoptExternallyProvisioned(hwmgr) {
+		t.Errorf("expected externally provisioned hosts not to be adopted by default")
+	}
+
+	hwmgr.Spec.Metal3Data = &pluginv1alpha1.Metal3Data{
+		ExternallyProvisioned: &pluginv1alpha1.ExternallyProvisionedPolicy{Adopt: true},
+	}
+	if !adoptExternallyProvisioned(hwmgr) {
+		t.Errorf("expected externally provisioned hosts to be adopted")
+	}
+}
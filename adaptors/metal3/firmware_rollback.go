@@ -0,0 +1,403 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// FirmwareRollbackAnnotation records on a Node the rollback of a failed firmware update, as JSON
+const FirmwareRollbackAnnotation = "hwmgr-plugin.oran.openshift.io/firmware-rollback"
+
+// NodeConditionRolledBack is the Node condition reporting the rollback of a failed firmware update
+const NodeConditionRolledBack = "RolledBack"
+
+// NodeOperationFirmwareRollback is the type of the node operation rolling back a failed firmware update
+const NodeOperationFirmwareRollback = "firmware-rollback"
+
+// rollbackPhase is the progress of a firmware rollback
+type rollbackPhase string
+
+const (
+	// rollbackRequested is set once the previous firmware has been requested, until the BMH starts servicing
+	rollbackRequested  rollbackPhase = "Requested"
+	rollbackInProgress rollbackPhase = "InProgress"
+	rollbackCompleted  rollbackPhase = "Completed"
+	rollbackFailed     rollbackPhase = "Failed"
+)
+
+// rollbackComponent is a firmware component being rolled back
+type rollbackComponent struct {
+	Component string `json:"component"`
+	// FromVersion is the version of the failed update
+	FromVersion string `json:"fromVersion"`
+	// ToVersion is the version of the previous hardware profile
+	ToVersion string `json:"toVersion"`
+}
+
+// firmwareRollback is the rollback of a failed firmware update, as recorded on the Node
+type firmwareRollback struct {
+	FromProfile string              `json:"fromProfile"`
+	ToProfile   string              `json:"toProfile"`
+	Reason      string              `json:"reason"`
+	Components  []rollbackComponent `json:"components"`
+	Phase       rollbackPhase       `json:"phase"`
+	StartTime   string              `json:"startTime"`
+	Message     string              `json:"message,omitempty"`
+}
+
+// firmwareRollbackEnabled returns true if the HardwareManager enables the rollback of failed firmware updates
+func firmwareRollbackEnabled(hwmgr *pluginv1alpha1.HardwareManager) bool {
+	return hwmgr != nil && hwmgr.Spec.Metal3Data != nil && hwmgr.Spec.Metal3Data.FirmwareRollback != nil &&
+		hwmgr.Spec.Metal3Data.FirmwareRollback.Enabled
+}
+
+// planFirmwareRollback returns the firmware updates restoring the previous hardware profile, for each component changed
+// by the failed update and not already at its previous version
+func planFirmwareRollback(status *metal3v1alpha1.HostFirmwareComponentsStatus,
+	failed, previous pluginv1alpha1.HardwareProfileSpec) ([]metal3v1alpha1.FirmwareUpdate, []rollbackComponent) {
+	currentVersions := make(map[string]string, len(status.Components))
+	for _, component := range status.Components {
+		currentVersions[component.Component] = component.CurrentVersion
+	}
+
+	var updates []metal3v1alpha1.FirmwareUpdate
+	var components []rollbackComponent
+	for _, fw := range []struct {
+		component        string
+		failed, previous pluginv1alpha1.Firmware
+	}{
+		{"bios", failed.BiosFirmware, previous.BiosFirmware},
+		{"bmc", failed.BmcFirmware, previous.BmcFirmware},
+	} {
+		if fw.failed.IsEmpty() || fw.previous.IsEmpty() || fw.failed.Version == fw.previous.Version {
+			continue
+		}
+		if current, exists := currentVersions[fw.component]; exists && current == fw.previous.Version {
+			continue
+		}
+		updates = append(updates, metal3v1alpha1.FirmwareUpdate{
+			Component: fw.component,
+			URL:       fw.previous.URL,
+		})
+		components = append(components, rollbackComponent{
+			Component:   fw.component,
+			FromVersion: fw.failed.Version,
+			ToVersion:   fw.previous.Version,
+		})
+	}
+	return updates, components
+}
+
+// versions describes the versions of the components being rolled back
+func (r *firmwareRollback) versions() string {
+	descriptions := make([]string, 0, len(r.Components))
+	for _, component := range r.Components {
+		descriptions = append(descriptions,
+			fmt.Sprintf("%s %s -> %s", component.Component, component.FromVersion, component.ToVersion))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// outcomeError returns the error reporting the outcome of an ended rollback
+func (r *firmwareRollback) outcomeError(nodeName string) error {
+	if r.Phase == rollbackCompleted {
+		return fmt.Errorf("firmware update of node %s was rolled back: %s", nodeName, r.Message)
+	}
+	return fmt.Errorf("firmware rollback of node %s failed: %s", nodeName, r.Message)
+}
+
+// firmwareVerificationFailure returns a description of the components whose version does not match the hardware
+// profile, or an empty string if all components are at their expected version
+func firmwareVerificationFailure(status *metal3v1alpha1.HostFirmwareComponentsStatus, spec pluginv1alpha1.HardwareProfileSpec) string {
+	expected := map[string]pluginv1alpha1.Firmware{
+		"bios": spec.BiosFirmware,
+		"bmc":  spec.BmcFirmware,
+	}
+
+	var mismatches []string
+	for _, component := range status.Components {
+		if fw, exists := expected[component.Component]; exists && !fw.IsEmpty() && component.CurrentVersion != fw.Version {
+			mismatches = append(mismatches, fmt.Sprintf("%s is at version %s, expected %s",
+				component.Component, component.CurrentVersion, fw.Version))
+		}
+	}
+	return strings.Join(mismatches, ", ")
+}
+
+// operationStartTime returns the start time of the in-progress operation of the node, if there is one
+func operationStartTime(node *hwmgmtv1alpha1.Node) (time.Time, bool) {
+	history := utils.GetNodeOperationHistory(node)
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Outcome != utils.NodeOperationInProgress {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, history[i].StartTime)
+		return start, err == nil
+	}
+	return time.Time{}, false
+}
+
+// operationTimedOut checks whether the in-progress operation of the node has exceeded the timeout
+func operationTimedOut(node *hwmgmtv1alpha1.Node, timeout time.Duration, now time.Time) bool {
+	if timeout <= 0 {
+		return false
+	}
+	start, exists := operationStartTime(node)
+	return exists && now.Sub(start) > timeout
+}
+
+// firmwareVersionsStale checks whether the firmware versions of the HostFirmwareComponents were last reported before
+// the in-progress operation of the node started, and so do not reflect its firmware updates yet
+func firmwareVersionsStale(node *hwmgmtv1alpha1.Node, status *metal3v1alpha1.HostFirmwareComponentsStatus) bool {
+	start, exists := operationStartTime(node)
+	if !exists {
+		return false
+	}
+	return status.LastUpdated == nil || status.LastUpdated.Time.Before(start)
+}
+
+// getFirmwareRollback returns the firmware rollback recorded on the node, or nil if none is recorded
+func getFirmwareRollback(node *hwmgmtv1alpha1.Node) (*firmwareRollback, error) {
+	value, exists := node.GetAnnotations()[FirmwareRollbackAnnotation]
+	if !exists {
+		return nil, nil
+	}
+	rollback := &firmwareRollback{}
+	if err := json.Unmarshal([]byte(value), rollback); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", FirmwareRollbackAnnotation, err)
+	}
+	return rollback, nil
+}
+
+// setFirmwareRollback records the firmware rollback on the node. When an operation outcome is given, the in-progress
+// operation is ended with it and a rollback operation started.
+func (a *Adaptor) setFirmwareRollback(ctx context.Context, node *hwmgmtv1alpha1.Node, rollback *firmwareRollback,
+	outcome utils.NodeOperationOutcome) error {
+	data, err := json.Marshal(rollback)
+	if err != nil {
+		return fmt.Errorf("failed to marshal firmware rollback: %w", err)
+	}
+
+	// nolint: wrapcheck
+	return retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+		updatedNode := &hwmgmtv1alpha1.Node{}
+		if err := a.Get(ctx, types.NamespacedName{Name: node.Name, Namespace: node.Namespace}, updatedNode); err != nil {
+			return fmt.Errorf("failed to fetch Node: %w", err)
+		}
+
+		annotations := updatedNode.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[FirmwareRollbackAnnotation] = string(data)
+		updatedNode.SetAnnotations(annotations)
+		if outcome != "" {
+			utils.EndNodeOperation(updatedNode, outcome, rollback.Reason, a.clock())
+			utils.StartNodeOperation(updatedNode, NodeOperationFirmwareRollback, "", a.clock())
+		}
+
+		if err := a.Client.Update(ctx, updatedNode); err != nil {
+			return fmt.Errorf("failed to record firmware rollback on node %s: %w", updatedNode.Name, err)
+		}
+		node.SetAnnotations(updatedNode.GetAnnotations())
+		return nil
+	})
+}
+
+// verifyFirmwareUpdate checks the firmware versions of the node against its hardware profile, returning a description
+// of the mismatches, if any. Mismatches are only reported once the HostFirmwareComponents have reported the versions
+// since the update started, or the firmware job timeout has passed; until then, the verification is pending.
+func (a *Adaptor) verifyFirmwareUpdate(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, node *hwmgmtv1alpha1.Node,
+	bmh *metal3v1alpha1.BareMetalHost) (failure string, pending bool, err error) {
+	hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, node.Spec.HwProfile, a.Namespace)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", node.Spec.HwProfile, err)
+	}
+	if hwProfile.Spec.BiosFirmware.IsEmpty() && hwProfile.Spec.BmcFirmware.IsEmpty() {
+		return "", false, nil
+	}
+	hfc, err := a.getHostFirmwareComponents(ctx, bmh.Name, bmh.Namespace)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get HostFirmwareComponents %s/%s: %w", bmh.Namespace, bmh.Name, err)
+	}
+	failure = firmwareVerificationFailure(&hfc.Status, hwProfile.Spec)
+	if failure != "" && firmwareVersionsStale(node, &hfc.Status) &&
+		!operationTimedOut(node, utils.GetOperationTimeout(hwmgr, utils.OperationFirmwareJob), a.clock().Now()) {
+		return "", true, nil
+	}
+	return failure, false, nil
+}
+
+// startFirmwareRollback rolls back a failed firmware update of the node to the firmware of its previous hardware
+// profile. It returns false if the rollback is disabled, or there is no previous firmware to roll back to.
+func (a *Adaptor) startFirmwareRollback(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	node *hwmgmtv1alpha1.Node, bmh *metal3v1alpha1.BareMetalHost, reason string, outcome utils.NodeOperationOutcome) (bool, error) {
+	if !firmwareRollbackEnabled(hwmgr) || node.Status.HwProfile == "" || node.Status.HwProfile == node.Spec.HwProfile {
+		return false, nil
+	}
+
+	failedProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, node.Spec.HwProfile, a.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", node.Spec.HwProfile, err)
+	}
+	previousProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, node.Status.HwProfile, a.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", node.Status.HwProfile, err)
+	}
+	hfc, err := a.getHostFirmwareComponents(ctx, bmh.Name, bmh.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("failed to get HostFirmwareComponents %s/%s: %w", bmh.Namespace, bmh.Name, err)
+	}
+
+	updates, components := planFirmwareRollback(&hfc.Status, failedProfile.Spec, previousProfile.Spec)
+	if len(updates) == 0 {
+		a.Logger.InfoContext(ctx, "No firmware to roll back", slog.String("node", node.Name))
+		return false, nil
+	}
+
+	rollback := &firmwareRollback{
+		FromProfile: node.Spec.HwProfile,
+		ToProfile:   node.Status.HwProfile,
+		Reason:      reason,
+		Components:  components,
+		Phase:       rollbackRequested,
+		StartTime:   a.clock().Now().UTC().Format(time.RFC3339),
+	}
+	a.Logger.InfoContext(ctx, "Rolling back failed firmware update",
+		slog.String("node", node.Name),
+		slog.String("reason", reason),
+		slog.String("versions", rollback.versions()))
+
+	if err := a.updateHostFirmwareComponents(ctx, types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}, updates); err != nil {
+		return false, fmt.Errorf("failed to request firmware rollback for BMH %s/%s: %w", bmh.Namespace, bmh.Name, err)
+	}
+	if err := a.addRebootAnnotation(ctx, bmh); err != nil {
+		return false, err
+	}
+	if err := a.setFirmwareRollback(ctx, node, rollback, outcome); err != nil {
+		return false, err
+	}
+
+	message := fmt.Sprintf("Rolling back firmware to profile %s after %s: %s", rollback.ToProfile, reason, rollback.versions())
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		NodeConditionRolledBack, metav1.ConditionFalse, string(hwmgmtv1alpha1.InProgress), message); err != nil {
+		return true, fmt.Errorf("failed to update node status: %w", err)
+	}
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse, string(hwmgmtv1alpha1.InProgress), message); err != nil {
+		return true, fmt.Errorf("failed to update node status: %w", err)
+	}
+	return true, nil
+}
+
+// handleFirmwareRollback tracks the rollback of a failed firmware update. Once the rollback has ended, the node
+// remains failed, as it is not at the hardware profile requested for it.
+func (a *Adaptor) handleFirmwareRollback(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	node *hwmgmtv1alpha1.Node, bmh *metal3v1alpha1.BareMetalHost, rollback *firmwareRollback) (ctrl.Result, bool, error) {
+
+	if rollback.Phase == rollbackCompleted || rollback.Phase == rollbackFailed {
+		return ctrl.Result{}, false, rollback.outcomeError(node.Name)
+	}
+
+	timedOut := operationTimedOut(node, utils.GetOperationTimeout(hwmgr, utils.OperationFirmwareJob), a.clock().Now())
+
+	if rollback.Phase == rollbackRequested {
+		if bmh.Status.OperationalStatus != metal3v1alpha1.OperationalStatusServicing {
+			if timedOut {
+				return a.endFirmwareRollback(ctx, node, rollback, "the BMH did not start servicing within the firmware job timeout")
+			}
+			a.Logger.InfoContext(ctx, "Waiting for BMH to start servicing the firmware rollback", slog.String("BMH", bmh.Name))
+			return utils.RequeueWithShortInterval(), true, nil
+		}
+		rollback.Phase = rollbackInProgress
+		if err := a.setFirmwareRollback(ctx, node, rollback, ""); err != nil {
+			return ctrl.Result{}, true, err
+		}
+	}
+
+	switch bmh.Status.OperationalStatus {
+	case metal3v1alpha1.OperationalStatusOK:
+		previousProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, rollback.ToProfile, a.Namespace)
+		if err != nil {
+			return ctrl.Result{}, true, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", rollback.ToProfile, err)
+		}
+		hfc, err := a.getHostFirmwareComponents(ctx, bmh.Name, bmh.Namespace)
+		if err != nil {
+			return ctrl.Result{}, true, fmt.Errorf("failed to get HostFirmwareComponents %s/%s: %w", bmh.Namespace, bmh.Name, err)
+		}
+		if failure := firmwareVerificationFailure(&hfc.Status, previousProfile.Spec); failure != "" {
+			return a.endFirmwareRollback(ctx, node, rollback, "verification failed: "+failure)
+		}
+		return a.endFirmwareRollback(ctx, node, rollback, "")
+	case metal3v1alpha1.OperationalStatusError:
+		return a.endFirmwareRollback(ctx, node, rollback, BmhServicingErr)
+	}
+
+	if timedOut {
+		return a.endFirmwareRollback(ctx, node, rollback, "exceeded the firmware job timeout")
+	}
+	a.Logger.InfoContext(ctx, "Firmware rollback in progress", slog.String("BMH", bmh.Name))
+	return utils.RequeueWithMediumInterval(), true, nil
+}
+
+// endFirmwareRollback records the outcome of a firmware rollback, which failed if a failure is given
+func (a *Adaptor) endFirmwareRollback(ctx context.Context, node *hwmgmtv1alpha1.Node, rollback *firmwareRollback,
+	failure string) (ctrl.Result, bool, error) {
+	status := metav1.ConditionTrue
+	reason := string(hwmgmtv1alpha1.Completed)
+	outcome := utils.NodeOperationSucceeded
+	rollback.Phase = rollbackCompleted
+	rollback.Message = fmt.Sprintf("firmware update to profile %s failed (%s), rolled back to profile %s: %s",
+		rollback.FromProfile, rollback.Reason, rollback.ToProfile, rollback.versions())
+	if failure != "" {
+		status = metav1.ConditionFalse
+		reason = string(hwmgmtv1alpha1.Failed)
+		outcome = utils.NodeOperationFailed
+		rollback.Phase = rollbackFailed
+		rollback.Message = fmt.Sprintf("rollback of firmware update to profile %s to profile %s (%s) failed: %s",
+			rollback.FromProfile, rollback.ToProfile, rollback.versions(), failure)
+	}
+	a.Logger.InfoContext(ctx, "Firmware rollback ended",
+		slog.String("node", node.Name), slog.String("phase", string(rollback.Phase)), slog.String("message", rollback.Message))
+
+	if err := a.setFirmwareRollback(ctx, node, rollback, ""); err != nil {
+		return ctrl.Result{}, true, err
+	}
+	if err := utils.ClearNodeProgress(ctx, a.Client, node.Name, node.Namespace, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to write node progress", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		NodeConditionRolledBack, status, reason, rollback.Message); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse, string(hwmgmtv1alpha1.Failed), rollback.Message); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.FinishNodeOperation(ctx, a.Client, node.Name, node.Namespace, outcome, failure, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to record operation outcome", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+
+	return ctrl.Result{}, false, rollback.outcomeError(node.Name)
+}
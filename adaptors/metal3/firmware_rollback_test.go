@@ -0,0 +1,162 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func firmwareProfileSpec(biosVersion, bmcVersion string) pluginv1alpha1.HardwareProfileSpec {
+	spec := pluginv1alpha1.HardwareProfileSpec{}
+	if biosVersion != "" {
+		spec.BiosFirmware = pluginv1alpha1.Firmware{Version: biosVersion, URL: "https://fw.example.com/bios-" + biosVersion}
+	}
+	if bmcVersion != "" {
+		spec.BmcFirmware = pluginv1alpha1.Firmware{Version: bmcVersion, URL: "https://fw.example.com/bmc-" + bmcVersion}
+	}
+	return spec
+}
+
+func firmwareStatus(biosVersion, bmcVersion string) *metal3v1alpha1.HostFirmwareComponentsStatus {
+	return &metal3v1alpha1.HostFirmwareComponentsStatus{
+		Components: []metal3v1alpha1.FirmwareComponentStatus{
+			{Component: "bios", CurrentVersion: biosVersion},
+			{Component: "bmc", CurrentVersion: bmcVersion},
+		},
+	}
+}
+
+func TestPlanFirmwareRollback(t *testing.T) {
+	testcases := []struct {
+		name       string
+		status     *metal3v1alpha1.HostFirmwareComponentsStatus
+		failed     pluginv1alpha1.HardwareProfileSpec
+		previous   pluginv1alpha1.HardwareProfileSpec
+		components []string
+	}{
+		{
+			name:       "all updated components",
+			status:     firmwareStatus("2.0", "6.0"),
+			failed:     firmwareProfileSpec("2.0", "6.0"),
+			previous:   firmwareProfileSpec("1.0", "5.0"),
+			components: []string{"bios", "bmc"},
+		},
+		{
+			name:       "component still at its previous version",
+			status:     firmwareStatus("2.0", "5.0"),
+			failed:     firmwareProfileSpec("2.0", "6.0"),
+			previous:   firmwareProfileSpec("1.0", "5.0"),
+			components: []string{"bios"},
+		},
+		{
+			name:       "component unchanged by the update",
+			status:     firmwareStatus("2.0", "5.0"),
+			failed:     firmwareProfileSpec("2.0", "5.0"),
+			previous:   firmwareProfileSpec("1.0", "5.0"),
+			components: []string{"bios"},
+		},
+		{
+			name:     "no previous firmware",
+			status:   firmwareStatus("2.0", "6.0"),
+			failed:   firmwareProfileSpec("2.0", "6.0"),
+			previous: firmwareProfileSpec("", ""),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			updates, components := planFirmwareRollback(tc.status, tc.failed, tc.previous)
+			if len(updates) != len(tc.components) || len(components) != len(tc.components) {
+				t.Fatalf("expected rollback of %v, got updates %v", tc.components, updates)
+			}
+			for i, name := range tc.components {
+				if updates[i].Component != name || components[i].Component != name {
+					t.Errorf("expected rollback of %s, got %v", name, updates[i])
+				}
+			}
+		})
+	}
+
+	updates, components := planFirmwareRollback(firmwareStatus("2.0", "5.0"),
+		firmwareProfileSpec("2.0", ""), firmwareProfileSpec("1.0", ""))
+	if updates[0].URL != "https://fw.example.com/bios-1.0" {
+		t.Errorf("expected rollback to the previous firmware URL, got %s", updates[0].URL)
+	}
+	rollback := &firmwareRollback{Components: components}
+	if versions := rollback.versions(); versions != "bios 2.0 -> 1.0" {
+		t.Errorf("unexpected versions: %s", versions)
+	}
+}
+
+func TestFirmwareVerificationFailure(t *testing.T) {
+	if failure := firmwareVerificationFailure(firmwareStatus("2.0", "6.0"), firmwareProfileSpec("2.0", "6.0")); failure != "" {
+		t.Errorf("expected verification to pass, got %s", failure)
+	}
+	if failure := firmwareVerificationFailure(firmwareStatus("2.0", "5.0"), firmwareProfileSpec("2.0", "")); failure != "" {
+		t.Errorf("expected components absent from the profile to be ignored, got %s", failure)
+	}
+	failure := firmwareVerificationFailure(firmwareStatus("1.0", "6.0"), firmwareProfileSpec("2.0", "6.0"))
+	if failure != "bios is at version 1.0, expected 2.0" {
+		t.Errorf("unexpected verification failure: %s", failure)
+	}
+}
+
+func TestOperationTimedOut(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	node := &hwmgmtv1alpha1.Node{}
+	history, _ := json.Marshal([]utils.NodeOperation{
+		{Type: UpdateReasonFirmware, StartTime: start.Format(time.RFC3339), Outcome: utils.NodeOperationInProgress},
+	})
+	node.SetAnnotations(map[string]string{utils.OperationHistoryAnnotation: string(history)})
+
+	if operationTimedOut(node, 0, start.Add(24*time.Hour)) {
+		t.Errorf("expected no timeout when the timeout is unset")
+	}
+	if operationTimedOut(node, time.Hour, start.Add(30*time.Minute)) {
+		t.Errorf("expected the operation not to have timed out")
+	}
+	if !operationTimedOut(node, time.Hour, start.Add(2*time.Hour)) {
+		t.Errorf("expected the operation to have timed out")
+	}
+	if operationTimedOut(&hwmgmtv1alpha1.Node{}, time.Hour, start.Add(2*time.Hour)) {
+		t.Errorf("expected no timeout without an operation in progress")
+	}
+}
+
+func TestFirmwareVersionsStale(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	node := &hwmgmtv1alpha1.Node{}
+	history, _ := json.Marshal([]utils.NodeOperation{
+		{Type: UpdateReasonFirmware, StartTime: start.Format(time.RFC3339), Outcome: utils.NodeOperationInProgress},
+	})
+	node.SetAnnotations(map[string]string{utils.OperationHistoryAnnotation: string(history)})
+
+	status := firmwareStatus("1.0", "6.0")
+	if !firmwareVersionsStale(node, status) {
+		t.Errorf("expected versions never reported to be stale")
+	}
+	status.LastUpdated = &metav1.Time{Time: start.Add(-time.Hour)}
+	if !firmwareVersionsStale(node, status) {
+		t.Errorf("expected versions reported before the update to be stale")
+	}
+	status.LastUpdated = &metav1.Time{Time: start.Add(time.Hour)}
+	if firmwareVersionsStale(node, status) {
+		t.Errorf("expected versions reported after the update to be current")
+	}
+	if firmwareVersionsStale(&hwmgmtv1alpha1.Node{}, firmwareStatus("1.0", "6.0")) {
+		t.Errorf("expected versions not to be stale without an operation in progress")
+	}
+}
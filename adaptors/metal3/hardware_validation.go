@@ -0,0 +1,179 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// HardwareValidationAnnotation is set on a NodePool to validate the hardware of each node group once provisioned,
+// before the NodePool is reported as Provisioned. The value is a JSON map of node group name to validation spec, e.g.
+// {"worker": {"minMemoryMiB": 131072, "minCores": 32, "minNics": 2, "requiredNicLabels": ["data"], "bmcReachable": true}}
+const HardwareValidationAnnotation = "hwmgr-plugin.oran.openshift.io/hardware-validation"
+
+// NodeConditionHardwareValidated is the Node condition reporting the result of the hardware validation
+const NodeConditionHardwareValidated = "HardwareValidated"
+
+// HardwareValidationSpec defines the hardware checks run against each node of a node group
+type HardwareValidationSpec struct {
+	// MinMemoryMiB is the minimum amount of memory, in MiB
+	MinMemoryMiB int `json:"minMemoryMiB,omitempty"`
+	// MinCores is the minimum number of CPU cores
+	MinCores int `json:"minCores,omitempty"`
+	// MinNics is the minimum number of network interfaces
+	MinNics int `json:"minNics,omitempty"`
+	// RequiredNicLabels lists the interface labels that must be present
+	RequiredNicLabels []string `json:"requiredNicLabels,omitempty"`
+	// BmcReachable requires that the BMC is reachable
+	BmcReachable bool `json:"bmcReachable,omitempty"`
+}
+
+// getHardwareValidationSpecs parses the hardware validation annotation from the NodePool
+func getHardwareValidationSpecs(nodepool *hwmgmtv1alpha1.NodePool) (map[string]HardwareValidationSpec, error) {
+	specs := make(map[string]HardwareValidationSpec)
+
+	value, exists := nodepool.GetAnnotations()[HardwareValidationAnnotation]
+	if !exists || value == "" {
+		return specs, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &specs); err != nil {
+		return nil, typederrors.NewInputError("unable to parse %s annotation: %s: %s", HardwareValidationAnnotation, value, err.Error())
+	}
+
+	groups := make(map[string]bool)
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		groups[nodeGroup.NodePoolData.Name] = true
+	}
+
+	for groupName, spec := range specs {
+		if !groups[groupName] {
+			return nil, typederrors.NewInputError("%s annotation references unknown nodegroup=%s", HardwareValidationAnnotation, groupName)
+		}
+		if spec.MinMemoryMiB < 0 || spec.MinCores < 0 || spec.MinNics < 0 {
+			return nil, typederrors.NewInputError("invalid hardware validation for nodegroup=%s: minimums must not be negative", groupName)
+		}
+	}
+
+	return specs, nil
+}
+
+// validateNodeHardware runs the hardware checks against a node and its BMH, returning a description of each failure
+func validateNodeHardware(spec HardwareValidationSpec, node *hwmgmtv1alpha1.Node, bmh *metal3v1alpha1.BareMetalHost) []string {
+	var failures []string
+
+	if bmh.Status.HardwareDetails == nil {
+		if spec.MinMemoryMiB > 0 || spec.MinCores > 0 || spec.MinNics > 0 {
+			failures = append(failures, "hardware details not available")
+		}
+	} else {
+		if memory := bmh.Status.HardwareDetails.RAMMebibytes; memory < spec.MinMemoryMiB {
+			failures = append(failures, fmt.Sprintf("memory %dMiB is less than required %dMiB", memory, spec.MinMemoryMiB))
+		}
+		if cores := bmh.Status.HardwareDetails.CPU.Count; cores < spec.MinCores {
+			failures = append(failures, fmt.Sprintf("found %d CPU cores, required %d", cores, spec.MinCores))
+		}
+		if nics := len(bmh.Status.HardwareDetails.NIC); nics < spec.MinNics {
+			failures = append(failures, fmt.Sprintf("found %d NICs, required %d", nics, spec.MinNics))
+		}
+	}
+
+	if len(spec.RequiredNicLabels) > 0 {
+		labels := make(map[string]bool)
+		for _, iface := range node.Status.Interfaces {
+			if iface != nil && iface.Label != "" {
+				labels[iface.Label] = true
+			}
+		}
+		var missing []string
+		for _, label := range spec.RequiredNicLabels {
+			if !labels[label] {
+				missing = append(missing, label)
+			}
+		}
+		if len(missing) > 0 {
+			failures = append(failures, fmt.Sprintf("missing NIC labels: %s", strings.Join(missing, ", ")))
+		}
+	}
+
+	if spec.BmcReachable {
+		switch bmh.Status.ErrorType {
+		case metal3v1alpha1.RegistrationError, metal3v1alpha1.ProvisionedRegistrationError, metal3v1alpha1.PowerManagementError:
+			failures = append(failures, fmt.Sprintf("BMC unreachable: %s: %s", bmh.Status.ErrorType, bmh.Status.ErrorMessage))
+		}
+	}
+
+	return failures
+}
+
+// validateNodePoolHardware validates the hardware of each node in the NodePool against the validation annotation,
+// recording the result on each validated Node. An error is returned describing all nodes that failed validation.
+func (a *Adaptor) validateNodePoolHardware(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) error {
+	specs, err := getHardwareValidationSpecs(nodepool)
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
+	if err != nil {
+		return fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	var failedNodes []string
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		spec, exists := specs[node.Spec.GroupName]
+		if !exists {
+			continue
+		}
+
+		bmh, err := a.getBMHForNode(ctx, node)
+		if err != nil {
+			return fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
+		}
+
+		status := metav1.ConditionTrue
+		reason := string(hwmgmtv1alpha1.Completed)
+		message := "Hardware validation passed"
+		if failures := validateNodeHardware(spec, node, bmh); len(failures) > 0 {
+			status = metav1.ConditionFalse
+			reason = string(hwmgmtv1alpha1.Failed)
+			message = "Hardware validation failed: " + strings.Join(failures, "; ")
+			failedNodes = append(failedNodes, fmt.Sprintf("%s (bmh=%s/%s): %s",
+				node.Name, bmh.Namespace, bmh.Name, strings.Join(failures, "; ")))
+			a.Logger.WarnContext(ctx, "Node failed hardware validation",
+				slog.String("node", node.Name), slog.String("bmh", bmh.Name), slog.String("failures", strings.Join(failures, "; ")))
+		}
+
+		if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+			NodeConditionHardwareValidated, status, reason, message); err != nil {
+			return fmt.Errorf("failed to update hardware validation condition for node %s: %w", node.Name, err)
+		}
+	}
+
+	if len(failedNodes) > 0 {
+		sort.Strings(failedNodes)
+		return fmt.Errorf("hardware validation failed for %d node(s): %s", len(failedNodes), strings.Join(failedNodes, "; "))
+	}
+
+	return nil
+}
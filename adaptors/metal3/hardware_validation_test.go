@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestValidateNodeHardware(t *testing.T) {
+	bmh := &metal3v1alpha1.BareMetalHost{
+		Status: metal3v1alpha1.BareMetalHostStatus{
+			HardwareDetails: &metal3v1alpha1.HardwareDetails{
+				RAMMebibytes: 65536,
+				CPU:          metal3v1alpha1.CPU{Count: 32},
+				NIC:          []metal3v1alpha1.NIC{{Name: "eno1"}, {Name: "eno2"}},
+			},
+		},
+	}
+	node := &hwmgmtv1alpha1.Node{
+		Status: hwmgmtv1alpha1.NodeStatus{
+			Interfaces: []*hwmgmtv1alpha1.Interface{{Name: "eno1", Label: "boot"}, {Name: "eno2"}},
+		},
+	}
+	unreachable := bmh.DeepCopy()
+	unreachable.Status.ErrorType = metal3v1alpha1.PowerManagementError
+
+	tests := []struct {
+		name     string
+		spec     HardwareValidationSpec
+		bmh      *metal3v1alpha1.BareMetalHost
+		failures int
+	}{
+		{name: "all checks pass",
+			spec: HardwareValidationSpec{MinMemoryMiB: 65536, MinNics: 2, RequiredNicLabels: []string{"boot"}, BmcReachable: true},
+			bmh:  bmh},
+		{name: "insufficient memory and nics",
+			spec:     HardwareValidationSpec{MinMemoryMiB: 131072, MinNics: 4},
+			bmh:      bmh,
+			failures: 2},
+		{name: "insufficient cores",
+			spec:     HardwareValidationSpec{MinCores: 64},
+			bmh:      bmh,
+			failures: 1},
+		{name: "missing nic label",
+			spec:     HardwareValidationSpec{RequiredNicLabels: []string{"boot", "data"}},
+			bmh:      bmh,
+			failures: 1},
+		{name: "bmc unreachable",
+			spec:     HardwareValidationSpec{BmcReachable: true},
+			bmh:      unreachable,
+			failures: 1},
+		{name: "no hardware details",
+			spec:     HardwareValidationSpec{MinMemoryMiB: 1},
+			bmh:      &metal3v1alpha1.BareMetalHost{},
+			failures: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if failures := validateNodeHardware(tt.spec, node, tt.bmh); len(failures) != tt.failures {
+				t.Errorf("expected %d failures, got %v", tt.failures, failures)
+			}
+		})
+	}
+}
+
+func TestGetHardwareValidationSpecs(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			NodeGroup: []hwmgmtv1alpha1.NodeGroup{{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}, Size: 1}},
+		},
+	}
+
+	nodepool.Annotations[HardwareValidationAnnotation] = `{"worker": {"minMemoryMiB": 1024}}`
+	specs, err := getHardwareValidationSpecs(nodepool)
+	if err != nil || specs["worker"].MinMemoryMiB != 1024 {
+		t.Errorf("unexpected result: specs=%v, err=%v", specs, err)
+	}
+
+	nodepool.Annotations[HardwareValidationAnnotation] = `{"controller": {"minNics": 1}}`
+	if _, err := getHardwareValidationSpecs(nodepool); err == nil {
+		t.Error("expected error for unknown nodegroup")
+	}
+
+	nodepool.Annotations[HardwareValidationAnnotation] = `not-json`
+	if _, err := getHardwareValidationSpecs(nodepool); err == nil {
+		t.Error("expected error for invalid annotation")
+	}
+}
@@ -0,0 +1,203 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// The candidate BMHs of a node group are scored before placement, so that the hosts best fitting the group are
+// allocated first rather than the first ones listed. Each criterion rates a host from 0 to 1:
+//   - resource fit: how closely the memory and CPU cores of the host fit the minimums of the hardware validation of the
+//     group, a host below them being rated 0
+//   - firmware match: the share of the firmware components of the hardware profile already at their target version
+//   - pending repairs: the fewer errors reported for the host and operations left pending on it, the higher
+//
+// The score of a host is the weighted average of its ratings, from 0 to 100, and is recorded in the allocation outcome
+// of the host. Placement policies are applied to the candidates in score order.
+
+// scoringWeights are the weights of the host scoring criteria
+type scoringWeights struct {
+	resourceFit    float64
+	firmwareMatch  float64
+	pendingRepairs float64
+}
+
+// getScoringWeights returns the weights of the host scoring criteria configured for the hardware manager, each
+// defaulting to 1
+func getScoringWeights(hwmgr *pluginv1alpha1.HardwareManager) scoringWeights {
+	weights := scoringWeights{resourceFit: 1, firmwareMatch: 1, pendingRepairs: 1}
+	if hwmgr == nil || hwmgr.Spec.Metal3Data == nil || hwmgr.Spec.Metal3Data.AllocationScoring == nil {
+		return weights
+	}
+	policy := hwmgr.Spec.Metal3Data.AllocationScoring
+	if policy.ResourceFit != nil {
+		weights.resourceFit = float64(*policy.ResourceFit)
+	}
+	if policy.FirmwareMatch != nil {
+		weights.firmwareMatch = float64(*policy.FirmwareMatch)
+	}
+	if policy.PendingRepairs != nil {
+		weights.pendingRepairs = float64(*policy.PendingRepairs)
+	}
+	return weights
+}
+
+// hostRatings are the ratings of a host for each scoring criterion, from 0 to 1
+type hostRatings struct {
+	resourceFit    float64
+	firmwareMatch  float64
+	pendingRepairs float64
+}
+
+// score returns the weighted average of the ratings, from 0 to 100. Hosts are all scored 0 when all weights are 0.
+func (r hostRatings) score(weights scoringWeights) int {
+	total := weights.resourceFit + weights.firmwareMatch + weights.pendingRepairs
+	if total <= 0 {
+		return 0
+	}
+	sum := weights.resourceFit*r.resourceFit + weights.firmwareMatch*r.firmwareMatch +
+		weights.pendingRepairs*r.pendingRepairs
+	return int(math.Round(100 * sum / total))
+}
+
+// fitRatio rates how closely an amount fits a minimum: 1 for an exact fit, decreasing with the excess, and 0 below
+func fitRatio(actual, minimum int) float64 {
+	if actual < minimum || actual <= 0 {
+		return 0
+	}
+	return float64(minimum) / float64(actual)
+}
+
+// rateResourceFit rates how closely the memory and CPU cores of the BMH fit the minimums of the hardware validation of
+// its node group. Every host fits a group without minimums.
+func rateResourceFit(spec HardwareValidationSpec, bmh *metal3v1alpha1.BareMetalHost) float64 {
+	var ratios []float64
+	if spec.MinMemoryMiB > 0 {
+		memory := 0
+		if bmh.Status.HardwareDetails != nil {
+			memory = bmh.Status.HardwareDetails.RAMMebibytes
+		}
+		ratios = append(ratios, fitRatio(memory, spec.MinMemoryMiB))
+	}
+	if spec.MinCores > 0 {
+		cores := 0
+		if bmh.Status.HardwareDetails != nil {
+			cores = bmh.Status.HardwareDetails.CPU.Count
+		}
+		ratios = append(ratios, fitRatio(cores, spec.MinCores))
+	}
+	if len(ratios) == 0 {
+		return 1
+	}
+
+	rating := 0.0
+	for _, ratio := range ratios {
+		if ratio == 0 {
+			return 0
+		}
+		rating += ratio
+	}
+	return rating / float64(len(ratios))
+}
+
+// rateFirmwareMatch rates the share of the firmware components of the hardware profile already at their target version
+// on the host, given the status of its HostFirmwareComponents, if known. Every host matches a profile without firmware.
+func rateFirmwareMatch(status *metal3v1alpha1.HostFirmwareComponentsStatus, spec pluginv1alpha1.HardwareProfileSpec) float64 {
+	if spec.BiosFirmware.IsEmpty() && spec.BmcFirmware.IsEmpty() {
+		return 1
+	}
+	if status == nil {
+		return 0
+	}
+	updated, total := firmwareUpdateProgress(status, spec)
+	if total == 0 {
+		return 0
+	}
+	return float64(updated) / float64(total)
+}
+
+// countPendingRepairs counts the errors reported for the BMH by the baremetal-operator, and the operations requested on
+// it that were never run
+func countPendingRepairs(bmh *metal3v1alpha1.BareMetalHost) int {
+	repairs := 0
+	if bmh.Status.ErrorType != "" {
+		repairs = max(bmh.Status.ErrorCount, 1)
+	}
+	for _, annotation := range pendingOperationAnnotations {
+		if _, exists := bmh.Annotations[annotation]; exists {
+			repairs++
+		}
+	}
+	return repairs
+}
+
+// ratePendingRepairs rates the host from 1 without pending repairs, halving with each one
+func ratePendingRepairs(bmh *metal3v1alpha1.BareMetalHost) float64 {
+	return math.Pow(0.5, float64(countPendingRepairs(bmh)))
+}
+
+// scoreBMHs scores the candidate BMHs of a node group, by BMH name
+func (a *Adaptor) scoreBMHs(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool,
+	nodePoolData hwmgmtv1alpha1.NodePoolData, candidates []metal3v1alpha1.BareMetalHost) (map[string]int, error) {
+	weights := getScoringWeights(hwmgr)
+	scores := make(map[string]int, len(candidates))
+
+	validationSpecs, err := getHardwareValidationSpecs(nodepool)
+	if err != nil {
+		return nil, err
+	}
+
+	var profileSpec pluginv1alpha1.HardwareProfileSpec
+	if weights.firmwareMatch > 0 && nodePoolData.HwProfile != "" {
+		hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, nodePoolData.HwProfile, a.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", nodePoolData.HwProfile, err)
+		}
+		profileSpec = hwProfile.Spec
+	}
+	checkFirmware := !profileSpec.BiosFirmware.IsEmpty() || !profileSpec.BmcFirmware.IsEmpty()
+
+	for i := range candidates {
+		bmh := &candidates[i]
+		ratings := hostRatings{
+			resourceFit:    rateResourceFit(validationSpecs[nodePoolData.Name], bmh),
+			firmwareMatch:  1,
+			pendingRepairs: ratePendingRepairs(bmh),
+		}
+		if checkFirmware {
+			var status *metal3v1alpha1.HostFirmwareComponentsStatus
+			hfc, err := a.getHostFirmwareComponents(ctx, bmh.Name, bmh.Namespace)
+			switch {
+			case err == nil:
+				status = &hfc.Status
+			case !errors.IsNotFound(err):
+				return nil, err
+			}
+			ratings.firmwareMatch = rateFirmwareMatch(status, profileSpec)
+		}
+		scores[bmh.Name] = ratings.score(weights)
+	}
+	return scores, nil
+}
+
+// sortBMHsByScore orders the BMHs by decreasing score, keeping the order of the BMHs with the same score
+func sortBMHsByScore(bmhs []metal3v1alpha1.BareMetalHost, scores map[string]int) {
+	sort.SliceStable(bmhs, func(i, j int) bool {
+		return scores[bmhs[i].Name] > scores[bmhs[j].Name]
+	})
+}
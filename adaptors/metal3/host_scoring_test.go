@@ -0,0 +1,130 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func newScoringBMH(name string, memory, cores int) metal3v1alpha1.BareMetalHost {
+	bmh := metal3v1alpha1.BareMetalHost{}
+	bmh.Name = name
+	bmh.Status.HardwareDetails = &metal3v1alpha1.HardwareDetails{
+		RAMMebibytes: memory,
+		CPU:          metal3v1alpha1.CPU{Count: cores},
+	}
+	return bmh
+}
+
+func TestRateResourceFit(t *testing.T) {
+	spec := HardwareValidationSpec{MinMemoryMiB: 65536, MinCores: 16}
+
+	tests := []struct {
+		name     string
+		spec     HardwareValidationSpec
+		bmh      metal3v1alpha1.BareMetalHost
+		expected float64
+	}{
+		{name: "exact fit", spec: spec, bmh: newScoringBMH("exact", 65536, 16), expected: 1},
+		{name: "twice the minimums", spec: spec, bmh: newScoringBMH("large", 131072, 32), expected: 0.5},
+		{name: "below a minimum", spec: spec, bmh: newScoringBMH("small", 131072, 8), expected: 0},
+		{name: "no hardware details", spec: spec, bmh: metal3v1alpha1.BareMetalHost{}, expected: 0},
+		{name: "no minimums", bmh: newScoringBMH("any", 1024, 2), expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rating := rateResourceFit(tt.spec, &tt.bmh); rating != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, rating)
+			}
+		})
+	}
+}
+
+func TestRateFirmwareMatch(t *testing.T) {
+	spec := pluginv1alpha1.HardwareProfileSpec{
+		BiosFirmware: pluginv1alpha1.Firmware{Version: "2.1", URL: "https://example.com/bios.bin"},
+		BmcFirmware:  pluginv1alpha1.Firmware{Version: "5.0", URL: "https://example.com/bmc.bin"},
+	}
+	status := &metal3v1alpha1.HostFirmwareComponentsStatus{
+		Components: []metal3v1alpha1.FirmwareComponentStatus{
+			{Component: "bios", CurrentVersion: "2.1"},
+			{Component: "bmc", CurrentVersion: "4.8"},
+		},
+	}
+
+	if rating := rateFirmwareMatch(status, spec); rating != 0.5 {
+		t.Errorf("expected half of the firmware to match, got %v", rating)
+	}
+	if rating := rateFirmwareMatch(nil, spec); rating != 0 {
+		t.Errorf("expected no match without firmware status, got %v", rating)
+	}
+	if rating := rateFirmwareMatch(nil, pluginv1alpha1.HardwareProfileSpec{}); rating != 1 {
+		t.Errorf("expected a full match for a profile without firmware, got %v", rating)
+	}
+}
+
+func TestRatePendingRepairs(t *testing.T) {
+	bmh := newScoringBMH("host", 1024, 2)
+	if rating := ratePendingRepairs(&bmh); rating != 1 {
+		t.Errorf("expected a healthy host to be rated 1, got %v", rating)
+	}
+
+	bmh.Status.ErrorType = metal3v1alpha1.PowerManagementError
+	bmh.Status.ErrorCount = 2
+	bmh.Annotations = map[string]string{BiosUpdateNeededAnnotation: "true"}
+	if repairs := countPendingRepairs(&bmh); repairs != 3 {
+		t.Errorf("expected 3 pending repairs, got %d", repairs)
+	}
+	if rating := ratePendingRepairs(&bmh); rating != 0.125 {
+		t.Errorf("expected the rating to halve with each repair, got %v", rating)
+	}
+}
+
+func TestHostScore(t *testing.T) {
+	ratings := hostRatings{resourceFit: 0.5, firmwareMatch: 1, pendingRepairs: 0}
+
+	weight := func(value int32) *int32 { return &value }
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if score := ratings.score(getScoringWeights(hwmgr)); score != 50 {
+		t.Errorf("expected the default weights to average the ratings, got %d", score)
+	}
+
+	hwmgr.Spec.Metal3Data = &pluginv1alpha1.Metal3Data{
+		AllocationScoring: &pluginv1alpha1.AllocationScoringPolicy{FirmwareMatch: weight(3), PendingRepairs: weight(0)},
+	}
+	if score := ratings.score(getScoringWeights(hwmgr)); score != 88 {
+		t.Errorf("expected the configured weights to be applied, got %d", score)
+	}
+
+	hwmgr.Spec.Metal3Data.AllocationScoring.ResourceFit = weight(0)
+	hwmgr.Spec.Metal3Data.AllocationScoring.FirmwareMatch = weight(0)
+	if score := ratings.score(getScoringWeights(hwmgr)); score != 0 {
+		t.Errorf("expected no score with all weights disabled, got %d", score)
+	}
+}
+
+func TestSortBMHsByScore(t *testing.T) {
+	bmhs := []metal3v1alpha1.BareMetalHost{
+		newScoringBMH("host-0", 1024, 2),
+		newScoringBMH("host-1", 1024, 2),
+		newScoringBMH("host-2", 1024, 2),
+		newScoringBMH("host-3", 1024, 2),
+	}
+	sortBMHsByScore(bmhs, map[string]int{"host-0": 40, "host-1": 90, "host-2": 40, "host-3": 75})
+
+	expected := []string{"host-1", "host-3", "host-0", "host-2"}
+	for i, bmh := range bmhs {
+		if bmh.Name != expected[i] {
+			t.Fatalf("expected order %v, got %s at %d", expected, bmh.Name, i)
+		}
+	}
+}
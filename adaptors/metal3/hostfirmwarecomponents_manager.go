@@ -15,6 +15,7 @@ import (
 	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -129,6 +130,51 @@ func isVersionChangeDetected(ctx context.Context, logger *slog.Logger, status *m
 	return updates, updateRequired
 }
 
+// firmwareUpdateProgress counts the firmware components of a hardware profile, and those already at their target version
+func firmwareUpdateProgress(status *metal3v1alpha1.HostFirmwareComponentsStatus, spec pluginv1alpha1.HardwareProfileSpec) (updated, total int) {
+	firmwareMap := map[string]pluginv1alpha1.Firmware{
+		"bios": spec.BiosFirmware,
+		"bmc":  spec.BmcFirmware,
+	}
+
+	for _, component := range status.Components {
+		if fw, exists := firmwareMap[component.Component]; exists && !fw.IsEmpty() {
+			total++
+			if component.CurrentVersion == fw.Version {
+				updated++
+			}
+		}
+	}
+	return updated, total
+}
+
+// reportFirmwareUpdateProgress reports the progress of the firmware updates of a node being configured. Progress is
+// only reported when the hardware profile includes firmware updates, and failures are only logged.
+func (a *Adaptor) reportFirmwareUpdateProgress(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost, node *hwmgmtv1alpha1.Node) {
+	hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, node.Spec.HwProfile, a.Namespace)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "Unable to resolve hardware profile for progress", slog.String("error", err.Error()))
+		return
+	}
+	hfc, err := a.getHostFirmwareComponents(ctx, bmh.Name, bmh.Namespace)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "Unable to get firmware components for progress", slog.String("error", err.Error()))
+		return
+	}
+
+	updated, total := firmwareUpdateProgress(&hfc.Status, hwProfile.Spec)
+	if total == 0 {
+		return
+	}
+	progress := utils.NodeProgress{
+		Percent: updated * 100 / total,
+		Message: fmt.Sprintf("Firmware update in progress, %d of %d components updated", updated, total),
+	}
+	if err := utils.ReportNodeProgress(ctx, a.Client, node.Name, node.Namespace, progress, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to report node progress", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+}
+
 func (a *Adaptor) createHostFirmwareComponents(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost,
 	spec pluginv1alpha1.HardwareProfileSpec) (*metal3v1alpha1.HostFirmwareComponents, error) {
 
@@ -172,9 +218,10 @@ func (a *Adaptor) IsFirmwareUpdateRequired(ctx context.Context, bmh *metal3v1alp
 	if err != nil {
 		return false, err
 	}
-	// If the resource was just created, we assume an update is needed
+	// If the resource was just created, its versions are not known yet, so an update is needed if the profile has
+	// firmware to apply
 	if created {
-		return true, nil
+		return len(convertToFirmwareUpdates(spec)) > 0, nil
 	}
 
 	updates, updateRequired := isVersionChangeDetected(ctx, a.Logger, &existingHFC.Status, spec)
@@ -194,6 +241,20 @@ func (a *Adaptor) IsFirmwareUpdateRequired(ctx context.Context, bmh *metal3v1alp
 	return true, nil
 }
 
+// isFirmwareUpdatePending checks whether the firmware of the profile differs from the versions reported for the BMH,
+// without creating or updating its HostFirmwareComponents
+func (a *Adaptor) isFirmwareUpdatePending(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost, spec pluginv1alpha1.HardwareProfileSpec) (bool, error) {
+	hfc, err := a.getHostFirmwareComponents(ctx, bmh.Name, bmh.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return len(convertToFirmwareUpdates(spec)) > 0, nil
+		}
+		return false, err
+	}
+	_, updateRequired := isVersionChangeDetected(ctx, a.Logger, &hfc.Status, spec)
+	return updateRequired, nil
+}
+
 // Retrieves existing HostFirmwareComponents or creates a new one if not found.
 func (a *Adaptor) getOrCreateHostFirmwareComponents(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost,
 	spec pluginv1alpha1.HardwareProfileSpec) (*metal3v1alpha1.HostFirmwareComponents, bool, error) {
@@ -9,12 +9,16 @@ package metal3
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"log/slog"
 
 	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +41,13 @@ func convertBiosSettingsToHostFirmware(bmh metal3v1alpha1.BareMetalHost, biosSet
 	}
 }
 
+// getProfileBiosAttributes returns the BIOS attributes a hardware profile applies to a BMH, including those setting its
+// boot order, which depend on the vendor of the host
+func getProfileBiosAttributes(bmh *metal3v1alpha1.BareMetalHost, spec pluginv1alpha1.HardwareProfileSpec) (
+	map[string]intstr.IntOrString, error) {
+	return utils.ProfileBiosAttributes(spec, getResourceInfoVendor(*bmh)) // nolint: wrapcheck
+}
+
 func (a *Adaptor) createHostFirmwareSettings(ctx context.Context, hfs *metal3v1alpha1.HostFirmwareSettings) error {
 	if err := a.Client.Create(ctx, hfs); err != nil {
 		a.Logger.InfoContext(ctx, "Failed to create HostFirmwareSettings", slog.String("HFS", hfs.Name))
@@ -95,6 +106,128 @@ func (a *Adaptor) isFirmwareSettingsChangeDetectedAndValid(ctx context.Context,
 	return changeDetected && valid && observed, nil
 }
 
+// firmwareSettingsValidation returns whether the baremetal-operator has validated the current settings of the
+// HostFirmwareSettings against the FirmwareSchema of the host, and the reason they were rejected, if they were
+func firmwareSettingsValidation(hfs *metal3v1alpha1.HostFirmwareSettings) (bool, string) {
+	validCond := meta.FindStatusCondition(hfs.Status.Conditions, string(metal3v1alpha1.FirmwareSettingsValid))
+	if validCond == nil || validCond.ObservedGeneration < hfs.Generation {
+		return false, ""
+	}
+	if validCond.Status != metav1.ConditionTrue {
+		return false, fmt.Sprintf("%s: %s", validCond.Reason, validCond.Message)
+	}
+	return true, ""
+}
+
+// waitForFirmwareSettingsValidation holds the BIOS update of a node until its settings are validated against the
+// FirmwareSchema of the host, reporting the progress in the Configured condition of the node. It returns whether the
+// settings are validated, and an error if they were rejected, failing the node.
+func (a *Adaptor) waitForFirmwareSettingsValidation(ctx context.Context, node *hwmgmtv1alpha1.Node,
+	bmh *metal3v1alpha1.BareMetalHost, postInstall bool) (bool, error) {
+	hfs, err := a.getHostFirmwareSettings(ctx, bmh.Name, bmh.Namespace)
+	if err != nil {
+		return false, err
+	}
+
+	validated, failure := firmwareSettingsValidation(hfs)
+	if failure != "" {
+		message := fmt.Sprintf("%s: %s", BiosSettingsRejected, failure)
+		condType := hwmgmtv1alpha1.Provisioned
+		if postInstall {
+			condType = hwmgmtv1alpha1.Configured
+		}
+		if err := a.SetNodeFailedStatus(ctx, node, string(condType), message); err != nil {
+			a.Logger.ErrorContext(ctx, "failed to set node failed status", slog.String("node", node.Name), slog.String("error", err.Error()))
+		}
+		return false, typederrors.NewInputError("HostFirmwareSettings %s/%s: %s", hfs.Namespace, hfs.Name, message)
+	}
+
+	message := BiosSettingsValidationPending
+	if validated {
+		message = BiosSettingsApplying
+	}
+	// Updates of a provisioned node are reported with the reason of configuration updates
+	reason := hwmgmtv1alpha1.InProgress
+	if postInstall {
+		reason = hwmgmtv1alpha1.ConfigUpdate
+	}
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse, string(reason), message); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if !validated {
+		a.Logger.InfoContext(ctx, "Waiting for HostFirmwareSettings to be validated", slog.String("HFS", hfs.Name))
+	}
+	return validated, nil
+}
+
+// firmwareSettingsMismatches returns a description of the BIOS settings whose value reported by the
+// HostFirmwareSettings differs from the requested one, or an empty string if all settings have their requested value
+func firmwareSettingsMismatches(status map[string]string, desired map[string]intstr.IntOrString) string {
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var mismatches []string
+	for _, name := range names {
+		value := desired[name]
+		current, exists := status[name]
+		if !exists {
+			mismatches = append(mismatches, fmt.Sprintf("%s is not reported, expected %s", name, value.String()))
+		} else if current != value.String() {
+			mismatches = append(mismatches, fmt.Sprintf("%s is %s, expected %s", name, current, value.String()))
+		}
+	}
+	return strings.Join(mismatches, ", ")
+}
+
+// firmwareSettingsStale checks whether the settings of the HostFirmwareSettings were last reported before the
+// in-progress operation of the node started, and so do not reflect its BIOS updates yet
+func firmwareSettingsStale(node *hwmgmtv1alpha1.Node, status *metal3v1alpha1.HostFirmwareSettingsStatus) bool {
+	start, exists := operationStartTime(node)
+	if !exists {
+		return false
+	}
+	return status.LastUpdated == nil || status.LastUpdated.Time.Before(start)
+}
+
+// verifyFirmwareSettings reads back the BIOS settings reported by the HostFirmwareSettings of the node, returning a
+// description of the settings that differ from its hardware profile, if any. As with firmware versions, mismatches
+// are only reported once the settings have been reported since the update started, or the firmware job timeout has
+// passed; until then, the verification is pending.
+func (a *Adaptor) verifyFirmwareSettings(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, node *hwmgmtv1alpha1.Node,
+	bmh *metal3v1alpha1.BareMetalHost) (failure string, pending bool, err error) {
+	hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, node.Spec.HwProfile, a.Namespace)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", node.Spec.HwProfile, err)
+	}
+	biosAttributes, err := getProfileBiosAttributes(bmh, hwProfile.Spec)
+	if err != nil {
+		if typederrors.IsInputError(err) {
+			return err.Error(), false, nil
+		}
+		return "", false, err
+	}
+	if len(biosAttributes) == 0 {
+		return "", false, nil
+	}
+	hfs, err := a.getHostFirmwareSettings(ctx, bmh.Name, bmh.Namespace)
+	if err != nil {
+		return "", false, err
+	}
+	if _, rejected := firmwareSettingsValidation(hfs); rejected != "" {
+		return fmt.Sprintf("%s: %s", BiosSettingsRejected, rejected), false, nil
+	}
+	failure = firmwareSettingsMismatches(hfs.Status.Settings, biosAttributes)
+	if failure != "" && firmwareSettingsStale(node, &hfs.Status) &&
+		!operationTimedOut(node, utils.GetOperationTimeout(hwmgr, utils.OperationFirmwareJob), a.clock().Now()) {
+		return "", true, nil
+	}
+	return failure, false, nil
+}
+
 // Retrieves existing HostFirmwareSettings or creates a new one if not found.
 func (a *Adaptor) getOrCreateHostFirmwareSettings(ctx context.Context, hfs *metal3v1alpha1.HostFirmwareSettings) (*metal3v1alpha1.HostFirmwareSettings, error) {
 	existingHFS, err := a.getHostFirmwareSettings(ctx, hfs.Name, hfs.Namespace)
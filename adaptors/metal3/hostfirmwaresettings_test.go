@@ -0,0 +1,97 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestFirmwareSettingsValidation(t *testing.T) {
+	newHFS := func(generation int64, conditions ...metav1.Condition) *metal3v1alpha1.HostFirmwareSettings {
+		return &metal3v1alpha1.HostFirmwareSettings{
+			ObjectMeta: metav1.ObjectMeta{Name: "bmh-0", Generation: generation},
+			Status:     metal3v1alpha1.HostFirmwareSettingsStatus{Conditions: conditions},
+		}
+	}
+	validCond := func(status metav1.ConditionStatus, observed int64) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(metal3v1alpha1.FirmwareSettingsValid),
+			Status:             status,
+			Reason:             "Success",
+			Message:            "ProcAmpMode: value Fast is not one of the allowed values",
+			ObservedGeneration: observed,
+		}
+	}
+
+	if validated, failure := firmwareSettingsValidation(newHFS(2)); validated || failure != "" {
+		t.Errorf("expected settings without a validation to be pending, got %v, %q", validated, failure)
+	}
+	if validated, failure := firmwareSettingsValidation(newHFS(2, validCond(metav1.ConditionTrue, 1))); validated || failure != "" {
+		t.Errorf("expected settings validated at an older generation to be pending, got %v, %q", validated, failure)
+	}
+	if validated, failure := firmwareSettingsValidation(newHFS(2, validCond(metav1.ConditionTrue, 2))); !validated || failure != "" {
+		t.Errorf("expected settings to be validated, got %v, %q", validated, failure)
+	}
+	validated, failure := firmwareSettingsValidation(newHFS(2, validCond(metav1.ConditionFalse, 2)))
+	if validated || !strings.Contains(failure, "ProcAmpMode") {
+		t.Errorf("expected settings to be rejected, got %v, %q", validated, failure)
+	}
+}
+
+func TestFirmwareSettingsMismatches(t *testing.T) {
+	desired := map[string]intstr.IntOrString{
+		"LogicalProc":   intstr.FromString("Disabled"),
+		"ProcCStates":   intstr.FromString("Enabled"),
+		"WorkloadLimit": intstr.FromInt32(4),
+	}
+
+	status := map[string]string{"LogicalProc": "Disabled", "ProcCStates": "Enabled", "WorkloadLimit": "4"}
+	if mismatches := firmwareSettingsMismatches(status, desired); mismatches != "" {
+		t.Errorf("expected no mismatches, got %q", mismatches)
+	}
+
+	status = map[string]string{"LogicalProc": "Enabled", "ProcCStates": "Enabled"}
+	expected := "LogicalProc is Enabled, expected Disabled, WorkloadLimit is not reported, expected 4"
+	if mismatches := firmwareSettingsMismatches(status, desired); mismatches != expected {
+		t.Errorf("expected %q, got %q", expected, mismatches)
+	}
+}
+
+func TestFirmwareSettingsStale(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	node := &hwmgmtv1alpha1.Node{}
+	history, _ := json.Marshal([]utils.NodeOperation{
+		{Type: UpdateReasonBIOSSettings, StartTime: start.Format(time.RFC3339), Outcome: utils.NodeOperationInProgress},
+	})
+	node.SetAnnotations(map[string]string{utils.OperationHistoryAnnotation: string(history)})
+
+	status := &metal3v1alpha1.HostFirmwareSettingsStatus{}
+	if !firmwareSettingsStale(node, status) {
+		t.Errorf("expected settings never reported to be stale")
+	}
+	status.LastUpdated = &metav1.Time{Time: start.Add(-time.Hour)}
+	if !firmwareSettingsStale(node, status) {
+		t.Errorf("expected settings reported before the update to be stale")
+	}
+	status.LastUpdated = &metav1.Time{Time: start.Add(time.Hour)}
+	if firmwareSettingsStale(node, status) {
+		t.Errorf("expected settings reported after the update to be current")
+	}
+	if firmwareSettingsStale(&hwmgmtv1alpha1.Node{}, &metal3v1alpha1.HostFirmwareSettingsStatus{}) {
+		t.Errorf("expected settings not to be stale without an operation in progress")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/version"
+)
+
+const bmoModule = "github.com/metal3-io/baremetal-operator/apis"
+
+// GetAdaptorInfo reports the baremetal-operator API version the adaptor is built against, and the range of firmware
+// versions reported by HostFirmwareComponents for each component
+func (a *Adaptor) GetAdaptorInfo(ctx context.Context) invserver.AdaptorInfo {
+	info := invserver.AdaptorInfo{
+		Version: version.Version,
+		Backend: &invserver.BackendInfo{Name: "baremetal-operator"},
+	}
+	if bmoVersion := version.ModuleVersion(bmoModule); bmoVersion != "" {
+		info.Backend.Version = &bmoVersion
+	}
+
+	var hfcList metal3v1alpha1.HostFirmwareComponentsList
+	if err := a.Client.List(ctx, &hfcList); err != nil {
+		a.Logger.WarnContext(ctx, "unable to list HostFirmwareComponents", slog.String("error", err.Error()))
+		return info
+	}
+	if ranges := firmwareRanges(hfcList.Items); len(ranges) > 0 {
+		info.Firmware = &ranges
+	}
+	return info
+}
+
+// firmwareRanges finds the lowest and highest current version of each firmware component across hosts
+func firmwareRanges(hfcs []metal3v1alpha1.HostFirmwareComponents) []invserver.FirmwareRange {
+	byComponent := make(map[string]*invserver.FirmwareRange)
+	for _, hfc := range hfcs {
+		for _, component := range hfc.Status.Components {
+			if component.CurrentVersion == "" {
+				continue
+			}
+			name := strings.ToLower(component.Component)
+			entry, exists := byComponent[name]
+			if !exists {
+				byComponent[name] = &invserver.FirmwareRange{
+					Component:  name,
+					MinVersion: component.CurrentVersion,
+					MaxVersion: component.CurrentVersion,
+					Hosts:      1,
+				}
+				continue
+			}
+			entry.Hosts++
+			if version.Compare(component.CurrentVersion, entry.MinVersion) < 0 {
+				entry.MinVersion = component.CurrentVersion
+			}
+			if version.Compare(component.CurrentVersion, entry.MaxVersion) > 0 {
+				entry.MaxVersion = component.CurrentVersion
+			}
+		}
+	}
+
+	ranges := make([]invserver.FirmwareRange, 0, len(byComponent))
+	for _, entry := range byComponent {
+		ranges = append(ranges, *entry)
+	}
+	slices.SortFunc(ranges, func(a, b invserver.FirmwareRange) int {
+		return strings.Compare(a.Component, b.Component)
+	})
+	return ranges
+}
@@ -0,0 +1,46 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"reflect"
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestFirmwareRanges(t *testing.T) {
+	newHFC := func(bios, bmc string) metal3v1alpha1.HostFirmwareComponents {
+		return metal3v1alpha1.HostFirmwareComponents{
+			Status: metal3v1alpha1.HostFirmwareComponentsStatus{
+				Components: []metal3v1alpha1.FirmwareComponentStatus{
+					{Component: "bios", CurrentVersion: bios},
+					{Component: "BMC", CurrentVersion: bmc},
+				},
+			},
+		}
+	}
+
+	ranges := firmwareRanges([]metal3v1alpha1.HostFirmwareComponents{
+		newHFC("2.10.2", "7.00.00.00"),
+		newHFC("2.9.4", "6.10.30.00"),
+		newHFC("", "7.10.50.00"),
+	})
+	expected := []invserver.FirmwareRange{
+		{Component: "bios", MinVersion: "2.9.4", MaxVersion: "2.10.2", Hosts: 2},
+		{Component: "bmc", MinVersion: "6.10.30.00", MaxVersion: "7.10.50.00", Hosts: 3},
+	}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("unexpected firmware ranges: %+v", ranges)
+	}
+
+	if ranges := firmwareRanges(nil); len(ranges) != 0 {
+		t.Errorf("expected no ranges, got %+v", ranges)
+	}
+}
@@ -10,13 +10,16 @@ import (
 	"regexp"
 
 	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	"github.com/samber/lo"
 )
 
 const (
 	LabelPrefixResources = "resources.oran.openshift.io/"
 	LabelResourcePoolID  = LabelPrefixResources + "resourcePoolId"
 	LabelSiteID          = LabelPrefixResources + "siteId"
+	LabelRack            = LabelPrefixResources + "rack"
 
 	LabelPrefixResourceSelector = "resourceselector.oran.openshift.io/"
 
@@ -31,8 +34,18 @@ var REPatternResourceSelectorLabel = regexp.MustCompile(`^` + LabelPrefixResourc
 
 var emptyString = ""
 
+// getResourceInfoAdminState derives the administrative state of a BMH: a host that is detached or paused for
+// maintenance is locked, and one that is being deleted, or powered off while still on, is shutting down
 func getResourceInfoAdminState(bmh metal3v1alpha1.BareMetalHost) invserver.ResourceInfoAdminState {
-	return invserver.ResourceInfoAdminStateUNKNOWN
+	_, detached := bmh.Annotations[BmhDetachedAnnotation]
+	_, paused := bmh.Annotations[BmhPausedAnnotation]
+	switch {
+	case detached || paused || bmh.Status.OperationalStatus == metal3v1alpha1.OperationalStatusDetached:
+		return invserver.ResourceInfoAdminStateLOCKED
+	case !bmh.DeletionTimestamp.IsZero() || (!bmh.Spec.Online && bmh.Status.PoweredOn):
+		return invserver.ResourceInfoAdminStateSHUTTINGDOWN
+	}
+	return invserver.ResourceInfoAdminStateUNLOCKED
 }
 
 func getResourceInfoDescription(bmh metal3v1alpha1.BareMetalHost) string {
@@ -77,7 +90,17 @@ func getResourceInfoName(bmh metal3v1alpha1.BareMetalHost) string {
 	return bmh.Name
 }
 
+// getResourceInfoOperationalState derives the operational state of a BMH from its operational status. A host in error,
+// detached, or discovered without usable BMC credentials is disabled.
 func getResourceInfoOperationalState(bmh metal3v1alpha1.BareMetalHost) invserver.ResourceInfoOperationalState {
+	switch bmh.Status.OperationalStatus {
+	case metal3v1alpha1.OperationalStatusOK, metal3v1alpha1.OperationalStatusServicing,
+		metal3v1alpha1.OperationalStatusDelayed:
+		return invserver.ResourceInfoOperationalStateENABLED
+	case metal3v1alpha1.OperationalStatusError, metal3v1alpha1.OperationalStatusDetached,
+		metal3v1alpha1.OperationalStatusDiscovered:
+		return invserver.ResourceInfoOperationalStateDISABLED
+	}
 	return invserver.ResourceInfoOperationalStateUNKNOWN
 }
 
@@ -134,6 +157,13 @@ func getResourceInfoProcessors(bmh metal3v1alpha1.BareMetalHost) []invserver.Pro
 	return processors
 }
 
+func getResourceInfoCpuArchitecture(bmh metal3v1alpha1.BareMetalHost) *string {
+	if arch := getBMHCPUArchitecture(bmh); arch != "" {
+		return &arch
+	}
+	return nil
+}
+
 func getResourceInfoResourceId(bmh metal3v1alpha1.BareMetalHost) string {
 	return emptyString
 }
@@ -153,11 +183,73 @@ func getResourceInfoSerialNumber(bmh metal3v1alpha1.BareMetalHost) string {
 	return emptyString
 }
 
+// getStorageInfoType returns the type of a storage device reported by inspection, falling back on its rotational
+// flag for devices reported without a type
+func getStorageInfoType(storage metal3v1alpha1.Storage) *invserver.StorageInfoType {
+	var storageType invserver.StorageInfoType
+	switch {
+	case storage.Type == metal3v1alpha1.HDD:
+		storageType = invserver.HDD
+	case storage.Type == metal3v1alpha1.SSD:
+		storageType = invserver.SSD
+	case storage.Type == metal3v1alpha1.NVME:
+		storageType = invserver.NVME
+	case storage.Rotational:
+		storageType = invserver.HDD
+	default:
+		return nil
+	}
+	return &storageType
+}
+
+// getResourceInfoStorage returns the storage devices reported by the inspection of the BMH, if any
+func getResourceInfoStorage(bmh metal3v1alpha1.BareMetalHost) *[]invserver.StorageInfo {
+	if bmh.Status.HardwareDetails == nil || len(bmh.Status.HardwareDetails.Storage) == 0 {
+		return nil
+	}
+
+	storage := make([]invserver.StorageInfo, 0, len(bmh.Status.HardwareDetails.Storage))
+	for _, device := range bmh.Status.HardwareDetails.Storage {
+		storage = append(storage, invserver.StorageInfo{
+			Name:         device.Name,
+			Type:         getStorageInfoType(device),
+			SizeBytes:    int64(device.SizeBytes),
+			Model:        lo.EmptyableToPtr(device.Model),
+			Vendor:       lo.EmptyableToPtr(device.Vendor),
+			SerialNumber: lo.EmptyableToPtr(device.SerialNumber),
+		})
+	}
+	return &storage
+}
+
 func getResourceInfoTags(bmh metal3v1alpha1.BareMetalHost) *[]string {
 	return nil
 }
 
+// getResourceInfoUsageState derives the usage state of a BMH from its allocation and provisioning state. An allocated
+// or consumed host is active once provisioned and busy until then, while a free host is idle once available, and busy
+// while it is registered, inspected, cleaned or deleted.
 func getResourceInfoUsageState(bmh metal3v1alpha1.BareMetalHost) invserver.ResourceInfoUsageState {
+	if isExternallyProvisioned(&bmh) {
+		return invserver.EXTERNALLYPROVISIONED
+	}
+
+	state := bmh.Status.Provisioning.State
+	if bmh.Labels[BmhAllocatedLabel] == ValueTrue || bmh.Spec.ConsumerRef != nil {
+		if state == metal3v1alpha1.StateProvisioned {
+			return invserver.ACTIVE
+		}
+		return invserver.BUSY
+	}
+
+	switch state {
+	case metal3v1alpha1.StateAvailable, metal3v1alpha1.StateReady:
+		return invserver.IDLE
+	case metal3v1alpha1.StateRegistering, metal3v1alpha1.StateInspecting, metal3v1alpha1.StatePreparing,
+		metal3v1alpha1.StateProvisioning, metal3v1alpha1.StateProvisioned, metal3v1alpha1.StateDeprovisioning,
+		metal3v1alpha1.StatePoweringOffBeforeDelete, metal3v1alpha1.StateDeleting:
+		return invserver.BUSY
+	}
 	return invserver.UNKNOWN
 }
 
@@ -171,6 +263,7 @@ func getResourceInfoVendor(bmh metal3v1alpha1.BareMetalHost) string {
 func getResourceInfo(bmh metal3v1alpha1.BareMetalHost) invserver.ResourceInfo {
 	return invserver.ResourceInfo{
 		AdminState:       getResourceInfoAdminState(bmh),
+		CpuArchitecture:  getResourceInfoCpuArchitecture(bmh),
 		Description:      getResourceInfoDescription(bmh),
 		GlobalAssetId:    getResourceInfoGlobalAssetId(bmh),
 		Groups:           getResourceInfoGroups(bmh),
@@ -186,6 +279,7 @@ func getResourceInfo(bmh metal3v1alpha1.BareMetalHost) invserver.ResourceInfo {
 		ResourceId:       getResourceInfoResourceId(bmh),
 		ResourcePoolId:   getResourceInfoResourcePoolId(bmh),
 		SerialNumber:     getResourceInfoSerialNumber(bmh),
+		Storage:          getResourceInfoStorage(bmh),
 		Tags:             getResourceInfoTags(bmh),
 		UsageState:       getResourceInfoUsageState(bmh),
 		Vendor:           getResourceInfoVendor(bmh),
@@ -202,8 +296,23 @@ func includeInInventory(bmh metal3v1alpha1.BareMetalHost) bool {
 	case metal3v1alpha1.StateAvailable,
 		metal3v1alpha1.StateProvisioning,
 		metal3v1alpha1.StateProvisioned,
-		metal3v1alpha1.StatePreparing:
+		metal3v1alpha1.StatePreparing,
+		metal3v1alpha1.StateExternallyProvisioned:
 		return true
 	}
 	return false
 }
+
+// getBMHNodeSelectionInfo returns the attributes of the BMH to be published as labels on the allocated Node
+func getBMHNodeSelectionInfo(bmh metal3v1alpha1.BareMetalHost) utils.NodeSelectionInfo {
+	info := utils.NodeSelectionInfo{
+		SiteID:          bmh.Labels[LabelSiteID],
+		ResourcePoolID:  bmh.Labels[LabelResourcePoolID],
+		CPUArchitecture: getBMHCPUArchitecture(bmh),
+	}
+	if bmh.Status.HardwareDetails != nil {
+		info.Vendor = bmh.Status.HardwareDetails.SystemVendor.Manufacturer
+		info.Model = bmh.Status.HardwareDetails.SystemVendor.ProductName
+	}
+	return info
+}
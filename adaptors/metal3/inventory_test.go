@@ -0,0 +1,141 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestGetResourceInfoAdminState(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name     string
+		bmh      metal3v1alpha1.BareMetalHost
+		expected invserver.ResourceInfoAdminState
+	}{
+		{name: "online",
+			bmh: metal3v1alpha1.BareMetalHost{Spec: metal3v1alpha1.BareMetalHostSpec{Online: true},
+				Status: metal3v1alpha1.BareMetalHostStatus{PoweredOn: true}},
+			expected: invserver.ResourceInfoAdminStateUNLOCKED},
+		{name: "offline",
+			expected: invserver.ResourceInfoAdminStateUNLOCKED},
+		{name: "powering off",
+			bmh:      metal3v1alpha1.BareMetalHost{Status: metal3v1alpha1.BareMetalHostStatus{PoweredOn: true}},
+			expected: invserver.ResourceInfoAdminStateSHUTTINGDOWN},
+		{name: "deleting",
+			bmh:      metal3v1alpha1.BareMetalHost{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			expected: invserver.ResourceInfoAdminStateSHUTTINGDOWN},
+		{name: "paused for maintenance",
+			bmh:      metal3v1alpha1.BareMetalHost{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{BmhPausedAnnotation: ""}}},
+			expected: invserver.ResourceInfoAdminStateLOCKED},
+		{name: "detached",
+			bmh: metal3v1alpha1.BareMetalHost{Status: metal3v1alpha1.BareMetalHostStatus{
+				OperationalStatus: metal3v1alpha1.OperationalStatusDetached}},
+			expected: invserver.ResourceInfoAdminStateLOCKED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if state := getResourceInfoAdminState(tt.bmh); state != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, state)
+			}
+		})
+	}
+}
+
+func TestGetResourceInfoOperationalState(t *testing.T) {
+	tests := []struct {
+		status   metal3v1alpha1.OperationalStatus
+		expected invserver.ResourceInfoOperationalState
+	}{
+		{status: metal3v1alpha1.OperationalStatusOK, expected: invserver.ResourceInfoOperationalStateENABLED},
+		{status: metal3v1alpha1.OperationalStatusServicing, expected: invserver.ResourceInfoOperationalStateENABLED},
+		{status: metal3v1alpha1.OperationalStatusError, expected: invserver.ResourceInfoOperationalStateDISABLED},
+		{status: metal3v1alpha1.OperationalStatusDiscovered, expected: invserver.ResourceInfoOperationalStateDISABLED},
+		{status: "", expected: invserver.ResourceInfoOperationalStateUNKNOWN},
+	}
+
+	for _, tt := range tests {
+		bmh := metal3v1alpha1.BareMetalHost{Status: metal3v1alpha1.BareMetalHostStatus{OperationalStatus: tt.status}}
+		if state := getResourceInfoOperationalState(bmh); state != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.status, tt.expected, state)
+		}
+	}
+}
+
+func TestGetResourceInfoUsageState(t *testing.T) {
+	newBMH := func(state metal3v1alpha1.ProvisioningState, allocated bool) metal3v1alpha1.BareMetalHost {
+		bmh := metal3v1alpha1.BareMetalHost{}
+		bmh.Status.Provisioning.State = state
+		if allocated {
+			bmh.Labels = map[string]string{BmhAllocatedLabel: ValueTrue}
+		}
+		return bmh
+	}
+	consumed := newBMH(metal3v1alpha1.StateProvisioned, false)
+	consumed.Spec.ConsumerRef = &corev1.ObjectReference{Kind: "Machine", Name: "worker-0"}
+
+	tests := []struct {
+		name     string
+		bmh      metal3v1alpha1.BareMetalHost
+		expected invserver.ResourceInfoUsageState
+	}{
+		{name: "available", bmh: newBMH(metal3v1alpha1.StateAvailable, false), expected: invserver.IDLE},
+		{name: "inspecting", bmh: newBMH(metal3v1alpha1.StateInspecting, false), expected: invserver.BUSY},
+		{name: "allocated", bmh: newBMH(metal3v1alpha1.StateAvailable, true), expected: invserver.BUSY},
+		{name: "provisioned", bmh: newBMH(metal3v1alpha1.StateProvisioned, true), expected: invserver.ACTIVE},
+		{name: "consumed", bmh: consumed, expected: invserver.ACTIVE},
+		{name: "externally provisioned", bmh: newBMH(metal3v1alpha1.StateExternallyProvisioned, true),
+			expected: invserver.EXTERNALLYPROVISIONED},
+		{name: "unmanaged", bmh: newBMH(metal3v1alpha1.StateUnmanaged, false), expected: invserver.UNKNOWN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if state := getResourceInfoUsageState(tt.bmh); state != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, state)
+			}
+		})
+	}
+}
+
+func TestGetResourceInfoStorage(t *testing.T) {
+	bmh := metal3v1alpha1.BareMetalHost{}
+	if storage := getResourceInfoStorage(bmh); storage != nil {
+		t.Errorf("expected no storage without hardware details, got %+v", *storage)
+	}
+
+	bmh.Status.HardwareDetails = &metal3v1alpha1.HardwareDetails{Storage: []metal3v1alpha1.Storage{
+		{Name: "/dev/nvme0n1", Type: metal3v1alpha1.NVME, SizeBytes: 960197124096, Model: "Dell Ent NVMe",
+			SerialNumber: "S6CKNE0T"},
+		{Name: "/dev/sda", Rotational: true, SizeBytes: 1200243695616, Vendor: "SEAGATE"},
+		{Name: "/dev/sdb", SizeBytes: 479559942144},
+	}}
+	storage := getResourceInfoStorage(bmh)
+	if storage == nil || len(*storage) != 3 {
+		t.Fatalf("expected 3 storage devices, got %v", storage)
+	}
+
+	nvme := (*storage)[0]
+	if nvme.Name != "/dev/nvme0n1" || nvme.SizeBytes != 960197124096 || nvme.Type == nil || *nvme.Type != invserver.NVME ||
+		nvme.Model == nil || *nvme.Model != "Dell Ent NVMe" || nvme.SerialNumber == nil || *nvme.SerialNumber != "S6CKNE0T" ||
+		nvme.Vendor != nil {
+		t.Errorf("unexpected NVMe device %+v", nvme)
+	}
+	if hdd := (*storage)[1]; hdd.Type == nil || *hdd.Type != invserver.HDD || hdd.Vendor == nil || *hdd.Vendor != "SEAGATE" {
+		t.Errorf("expected a rotational device to be reported as an HDD, got %+v", hdd)
+	}
+	if untyped := (*storage)[2]; untyped.Type != nil || untyped.Model != nil {
+		t.Errorf("expected no type or model for an untyped device, got %+v", untyped)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// BmhLeaseExpiryAnnotation holds the expiry of the lease on an allocated BMH, in RFC 3339 format. It is set when the
+// BMH is allocated and renewed by the NodePool controller while the hardware manager enables allocation leases.
+const BmhLeaseExpiryAnnotation = "hwmgr-plugin.oran.openshift.io/lease-expiry"
+
+// allocationLeaseDuration returns the duration of allocation leases for the hardware manager, or zero if disabled
+func allocationLeaseDuration(hwmgr *pluginv1alpha1.HardwareManager) time.Duration {
+	if hwmgr == nil || hwmgr.Spec.Metal3Data == nil || hwmgr.Spec.Metal3Data.AllocationLease == nil {
+		return 0
+	}
+	return max(hwmgr.Spec.Metal3Data.AllocationLease.Duration.Duration, 0)
+}
+
+// getLeaseExpiry returns the expiry of the lease on the BMH, or nil if it has none or the annotation is invalid
+func getLeaseExpiry(bmh *metal3v1alpha1.BareMetalHost) *time.Time {
+	value, exists := bmh.Annotations[BmhLeaseExpiryAnnotation]
+	if !exists {
+		return nil
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &expiry
+}
+
+// leaseNeedsRenewal returns true if the BMH has no lease, or less than half of the lease duration remains
+func leaseNeedsRenewal(bmh *metal3v1alpha1.BareMetalHost, duration time.Duration, now time.Time) bool {
+	expiry := getLeaseExpiry(bmh)
+	return expiry == nil || expiry.Sub(now) < duration/2
+}
+
+// grantBMHLease sets the expiry of the lease on the BMH to the lease duration from now, if leases are enabled. The
+// lease is granted before the BMH is marked allocated, so that an allocation interrupted by a crash is still bounded.
+func (a *Adaptor) grantBMHLease(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, bmh *metal3v1alpha1.BareMetalHost) error {
+	duration := allocationLeaseDuration(hwmgr)
+	if duration == 0 {
+		return nil
+	}
+	expiry := a.clock().Now().Add(duration).UTC().Format(time.RFC3339)
+	name := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
+	if err := a.updateBMHMetaWithRetry(ctx, name, MetaTypeAnnotation, BmhLeaseExpiryAnnotation, expiry, OpAdd); err != nil {
+		return fmt.Errorf("failed to set lease expiry annotation on BMH (%s): %w", bmh.Name, err)
+	}
+	return nil
+}
+
+// clearBMHLease removes the lease from a released BMH
+func (a *Adaptor) clearBMHLease(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) error {
+	name := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
+	return a.updateBMHMetaWithRetry(ctx, name, MetaTypeAnnotation, BmhLeaseExpiryAnnotation, "", OpRemove)
+}
+
+// RenewLeases renews the leases of the BMHs allocated to the NodePool once half of the lease duration has elapsed.
+// BMHs allocated before leases were enabled are granted a lease on their first renewal.
+func (a *Adaptor) RenewLeases(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (time.Duration, error) {
+	duration := allocationLeaseDuration(hwmgr)
+	if duration == 0 {
+		return 0, nil
+	}
+
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
+	if err != nil {
+		return duration / 2, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	now := a.clock().Now()
+	for _, node := range nodelist.Items {
+		bmh, err := a.getBMHForNode(ctx, &node)
+		if err != nil {
+			return duration / 2, fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
+		}
+		if !a.isBMHAllocated(bmh) || !leaseNeedsRenewal(bmh, duration, now) {
+			continue
+		}
+		if err := a.grantBMHLease(ctx, hwmgr, bmh); err != nil {
+			return duration / 2, err
+		}
+		a.Logger.DebugContext(ctx, "Renewed BMH allocation lease", slog.String("bmh", bmh.Name))
+	}
+
+	return duration / 2, nil
+}
+
+// ReleaseExpiredAllocation releases an allocated BMH without a Node once its lease has expired. The BMH is checked
+// again before it is released, as its lease may have been renewed or a Node created since it was listed.
+func (a *Adaptor) ReleaseExpiredAllocation(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	allocation adaptorinterface.BackendAllocation) error {
+	var bmh metal3v1alpha1.BareMetalHost
+	name := types.NamespacedName{Name: allocation.HwMgrNodeId, Namespace: allocation.HwMgrNodeNs}
+	if err := a.Client.Get(ctx, name, &bmh); err != nil {
+		return fmt.Errorf("unable to find BMH (%v): %w", name, err)
+	}
+
+	expiry := getLeaseExpiry(&bmh)
+	if !a.isBMHAllocated(&bmh) || expiry == nil || !expiry.Before(a.clock().Now()) {
+		return nil
+	}
+
+	var nodes hwmgmtv1alpha1.NodeList
+	if err := a.Client.List(ctx, &nodes, client.InNamespace(a.Namespace)); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	for _, node := range nodes.Items {
+		if node.Spec.HwMgrNodeId == bmh.Name && node.Spec.HwMgrNodeNs == bmh.Namespace && !utils.IsNodeReplaced(&node) {
+			return nil
+		}
+	}
+
+	a.Logger.InfoContext(ctx, "Releasing BMH with expired allocation lease", slog.String("bmh", bmh.Name),
+		slog.String("hwmgr", hwmgr.Name), slog.Time("leaseExpiry", *expiry))
+	if err := a.releaseBMH(ctx, &bmh); err != nil {
+		return err
+	}
+	// Forget the name of the node that was being allocated, so that the BMH is allocated afresh
+	if err := a.updateBMHMetaWithRetry(ctx, name, MetaTypeAnnotation, NodeNameAnnotation, "", OpRemove); err != nil {
+		return fmt.Errorf("failed to clear node name annotation from BMH (%s): %w", bmh.Name, err)
+	}
+	return nil
+}
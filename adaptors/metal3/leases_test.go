@@ -0,0 +1,53 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func TestAllocationLeaseDuration(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if duration := allocationLeaseDuration(hwmgr); duration != 0 {
+		t.Errorf("expected leases to be disabled by default, got %s", duration)
+	}
+
+	hwmgr.Spec.Metal3Data = &pluginv1alpha1.Metal3Data{
+		AllocationLease: &pluginv1alpha1.AllocationLeasePolicy{Duration: metav1.Duration{Duration: time.Hour}},
+	}
+	if duration := allocationLeaseDuration(hwmgr); duration != time.Hour {
+		t.Errorf("expected a one hour lease, got %s", duration)
+	}
+}
+
+func TestLeaseNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	bmh := newTestBMH("bmh-1", "site-a")
+	if !leaseNeedsRenewal(&bmh, time.Hour, now) {
+		t.Errorf("expected a BMH without a lease to need one")
+	}
+
+	bmh.Annotations = map[string]string{BmhLeaseExpiryAnnotation: now.Add(45 * time.Minute).Format(time.RFC3339)}
+	if leaseNeedsRenewal(&bmh, time.Hour, now) {
+		t.Errorf("expected a lease with more than half remaining not to need renewal")
+	}
+
+	bmh.Annotations[BmhLeaseExpiryAnnotation] = now.Add(15 * time.Minute).Format(time.RFC3339)
+	if !leaseNeedsRenewal(&bmh, time.Hour, now) {
+		t.Errorf("expected a lease with less than half remaining to need renewal")
+	}
+
+	bmh.Annotations[BmhLeaseExpiryAnnotation] = "invalid"
+	if getLeaseExpiry(&bmh) != nil {
+		t.Errorf("expected an invalid lease expiry to be ignored")
+	}
+}
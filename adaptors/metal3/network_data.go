@@ -0,0 +1,263 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// The network data used by the ramdisk of a host while it is configured, referenced by the preprovisioningNetworkDataName
+// of its BMH, is either a pre-created secret or, when the NodePool extensions set networkDataTemplate, a secret rendered
+// for the host from the template when it is allocated. The rendered secret is deleted once the reference is cleared,
+// after the configuration of the host or on its release.
+
+// NetworkDataSecretKey is the key of the nmstate network data, in the template and in the rendered secret
+const NetworkDataSecretKey = "nmstate"
+
+// NetworkDataNodePoolLabel labels the network data secrets rendered for the hosts of a NodePool
+const NetworkDataNodePoolLabel = "hwmgr-plugin.oran.openshift.io/network-data-nodepool"
+
+// NetworkDataTemplateData holds the variables available to a network data template
+type NetworkDataTemplateData struct {
+	// NodeName is the name of the Node CR
+	NodeName string
+	// NodePool is the name of the NodePool
+	NodePool string
+	// GroupName is the node group of the node
+	GroupName string
+	// BMHName is the name of the BMH
+	BMHName string
+	// Hostname is the hostname of the host reported by its inspection, or the name of the BMH if none was reported
+	Hostname string
+	// BootMACAddress is the MAC address of the boot interface of the host
+	BootMACAddress string
+	// Interfaces are the network interfaces of the host reported by its inspection
+	Interfaces []utils.NodeManifestInterface
+	// MACAddresses maps the label of each interface, or its name when unlabeled, to its MAC address
+	MACAddresses map[string]string
+}
+
+// networkDataSecretName returns the name of the network data secret rendered for a BMH
+func networkDataSecretName(bmhName string) string {
+	return bmhName + "-network-data"
+}
+
+// newNetworkDataTemplateData returns the variables of the network data template of a host
+func newNetworkDataTemplateData(nodepool *hwmgmtv1alpha1.NodePool, nodeName, groupName string,
+	bmh *metal3v1alpha1.BareMetalHost, interfaces []*hwmgmtv1alpha1.Interface) NetworkDataTemplateData {
+	data := NetworkDataTemplateData{
+		NodeName:       nodeName,
+		NodePool:       nodepool.Name,
+		GroupName:      groupName,
+		BMHName:        bmh.Name,
+		Hostname:       bmh.Name,
+		BootMACAddress: bmh.Spec.BootMACAddress,
+		MACAddresses:   make(map[string]string),
+	}
+	if bmh.Status.HardwareDetails != nil && bmh.Status.HardwareDetails.Hostname != "" {
+		data.Hostname = bmh.Status.HardwareDetails.Hostname
+	}
+	if mac, err := utils.NormalizeMACAddress(bmh.Spec.BootMACAddress); err == nil {
+		data.BootMACAddress = mac
+	}
+	for _, iface := range interfaces {
+		if iface == nil {
+			continue
+		}
+		data.Interfaces = append(data.Interfaces, utils.NodeManifestInterface{
+			Name:       iface.Name,
+			Label:      iface.Label,
+			MACAddress: iface.MACAddress,
+		})
+		key := iface.Label
+		if key == "" {
+			key = iface.Name
+		}
+		data.MACAddresses[key] = iface.MACAddress
+	}
+	return data
+}
+
+// renderNetworkData executes the network data template of a host
+func renderNetworkData(text string, data NetworkDataTemplateData) (string, error) {
+	tmpl, err := template.New(NetworkDataSecretKey).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", typederrors.NewInputError("invalid network data template: %s", err.Error())
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", typederrors.NewInputError("failed to render network data template for BMH %s: %s",
+			data.BMHName, err.Error())
+	}
+	return out.String(), nil
+}
+
+// getNetworkDataTemplate reads the network data template referenced by the NodePool extensions
+func (a *Adaptor) getNetworkDataTemplate(ctx context.Context, ref pluginv1alpha1.ManifestTemplateRef) (string, error) {
+	var text string
+	var found bool
+	if ref.Kind == "Secret" {
+		secret, err := utils.GetSecret(ctx, a.Client, ref.Name, a.Namespace)
+		if err != nil {
+			return "", fmt.Errorf("failed to get network data template: %w", err)
+		}
+		value, exists := secret.Data[NetworkDataSecretKey]
+		text, found = string(value), exists
+	} else {
+		cm, err := utils.GetConfigmap(ctx, a.Client, ref.Name, a.Namespace)
+		if err != nil {
+			return "", fmt.Errorf("failed to get network data template: %w", err)
+		}
+		text, found = cm.Data[NetworkDataSecretKey]
+	}
+	if !found {
+		return "", typederrors.NewInputError("network data template %s/%s has no %s key", ref.Kind, ref.Name,
+			NetworkDataSecretKey)
+	}
+	return text, nil
+}
+
+// applyNetworkDataTemplate renders the network data template set by the NodePool extensions for the node group of a
+// host into a secret next to its BMH, and references it as the preprovisioning network data of the BMH. Nothing is done
+// if no template is set, leaving any pre-created network data in place.
+func (a *Adaptor) applyNetworkDataTemplate(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, nodeName string,
+	group hwmgmtv1alpha1.NodeGroup, bmh *metal3v1alpha1.BareMetalHost) error {
+	extensions, err := utils.GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return err
+	}
+	value := extensions.GetNetworkDataTemplate(group.NodePoolData.Name)
+	if value == "" {
+		return nil
+	}
+	ref, err := pluginv1alpha1.ParseManifestTemplateRef(value)
+	if err != nil {
+		return typederrors.NewInputError("invalid nodepool extensions: %s", err.Error())
+	}
+
+	text, err := a.getNetworkDataTemplate(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	// The network data of a cordoned node is left as is
+	if err := utils.CheckNodeNotCordoned(ctx, a.NoncachedClient, nodeName, a.Namespace); err != nil {
+		return err // nolint: wrapcheck
+	}
+	rendered, err := renderNetworkData(text, newNetworkDataTemplateData(nodepool, nodeName, group.NodePoolData.Name, bmh,
+		a.buildInterfacesFromBMH(ctx, nodepool, *bmh)))
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      networkDataSecretName(bmh.Name),
+			Namespace: bmh.Namespace,
+			Labels:    map[string]string{NetworkDataNodePoolLabel: nodepool.Name},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{NetworkDataSecretKey: []byte(rendered)},
+	}
+	if err := a.applyNetworkDataSecret(ctx, secret); err != nil {
+		return err
+	}
+
+	bmhName := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
+	// nolint: wrapcheck
+	if err := retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+		updatedBmh := &metal3v1alpha1.BareMetalHost{}
+		if err := a.Get(ctx, bmhName, updatedBmh); err != nil {
+			return fmt.Errorf("failed to fetch BMH %s/%s: %w", bmhName.Namespace, bmhName.Name, err)
+		}
+		if updatedBmh.Spec.PreprovisioningNetworkDataName == secret.Name {
+			return nil
+		}
+		updatedBmh.Spec.PreprovisioningNetworkDataName = secret.Name
+		return a.Client.Update(ctx, updatedBmh)
+	}); err != nil {
+		return fmt.Errorf("failed to set network data of BMH %s/%s: %w", bmh.Namespace, bmh.Name, err)
+	}
+
+	a.Logger.InfoContext(ctx, "Rendered network data for BMH", slog.String("BMH", bmh.Name),
+		slog.String("template", value))
+	return nil
+}
+
+// isRenderedNetworkData checks whether a network data secret was rendered by the plugin, rather than pre-created
+func isRenderedNetworkData(secret *corev1.Secret) bool {
+	_, rendered := secret.GetLabels()[NetworkDataNodePoolLabel]
+	return rendered
+}
+
+// applyNetworkDataSecret writes a rendered network data secret, skipping the write when it is unchanged. An existing
+// secret of the same name that was not rendered by the plugin is left untouched, and reported as an input error, so
+// that the NodePool reports the conflict in its Provisioned condition.
+func (a *Adaptor) applyNetworkDataSecret(ctx context.Context, secret *corev1.Secret) error {
+	existing := &corev1.Secret{}
+	err := a.Client.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, existing)
+	switch {
+	case errors.IsNotFound(err):
+		if err := a.Client.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create network data secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get network data secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	if !isRenderedNetworkData(existing) {
+		return typederrors.NewInputError("network data secret %s/%s exists and was not rendered by the plugin, "+
+			"missing the %s label", secret.Namespace, secret.Name, NetworkDataNodePoolLabel)
+	}
+
+	if equality.Semantic.DeepEqual(existing.Data, secret.Data) && equality.Semantic.DeepEqual(existing.Labels, secret.Labels) {
+		return nil
+	}
+	existing.Labels = secret.Labels
+	existing.Data = secret.Data
+	if err := a.Client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update network data secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// deleteRenderedNetworkData deletes the network data secret rendered for a BMH, if any. Pre-created secrets, which do
+// not carry the NodePool label, are left in place.
+func (a *Adaptor) deleteRenderedNetworkData(ctx context.Context, name types.NamespacedName) error {
+	secret := &corev1.Secret{}
+	if err := a.Client.Get(ctx, types.NamespacedName{Name: networkDataSecretName(name.Name), Namespace: name.Namespace},
+		secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get network data secret of BMH %s/%s: %w", name.Namespace, name.Name, err)
+	}
+	if !isRenderedNetworkData(secret) {
+		return nil
+	}
+	if err := a.Client.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete network data secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestRenderNetworkData(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Name = "np1"
+	bmh := &metal3v1alpha1.BareMetalHost{}
+	bmh.Name = "host-0"
+	bmh.Spec.BootMACAddress = "AA:BB:CC:DD:EE:01"
+	interfaces := []*hwmgmtv1alpha1.Interface{
+		{Name: "eno1", Label: "bootable-interface", MACAddress: "aa:bb:cc:dd:ee:01"},
+		{Name: "eno2", MACAddress: "aa:bb:cc:dd:ee:02"},
+	}
+
+	data := newNetworkDataTemplateData(nodepool, "node-0", "controller", bmh, interfaces)
+	if data.Hostname != "host-0" {
+		t.Errorf("expected the BMH name as hostname without inspection data, got %s", data.Hostname)
+	}
+	if data.BootMACAddress != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("expected the boot MAC address to be normalized, got %s", data.BootMACAddress)
+	}
+
+	bmh.Status.HardwareDetails = &metal3v1alpha1.HardwareDetails{Hostname: "edge-0.example.com"}
+	data = newNetworkDataTemplateData(nodepool, "node-0", "controller", bmh, interfaces)
+	rendered, err := renderNetworkData(`hostname: {{ .Hostname }}
+interfaces:
+{{- range .Interfaces }}
+- name: {{ .Name }}
+  mac-address: {{ .MACAddress }}
+{{- end }}
+boot: {{ index .MACAddresses "bootable-interface" }}
+`, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `hostname: edge-0.example.com
+interfaces:
+- name: eno1
+  mac-address: aa:bb:cc:dd:ee:01
+- name: eno2
+  mac-address: aa:bb:cc:dd:ee:02
+boot: aa:bb:cc:dd:ee:01
+`
+	if rendered != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, rendered)
+	}
+
+	if _, err := renderNetworkData("{{ .Hostname", data); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for an invalid template, got %v", err)
+	}
+	if _, err := renderNetworkData("{{ .Rack }}", data); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for an unknown variable, got %v", err)
+	}
+}
+
+func TestNetworkDataSecretName(t *testing.T) {
+	if name := networkDataSecretName("host-0"); name != "host-0-network-data" {
+		t.Errorf("unexpected network data secret name %s", name)
+	}
+}
+
+// secretClient serves a single existing secret, recording updates
+type secretClient struct {
+	client.Client
+	secret  *corev1.Secret
+	updated bool
+}
+
+func (c *secretClient) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	c.secret.DeepCopyInto(obj.(*corev1.Secret))
+	return nil
+}
+
+func (c *secretClient) Update(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+	c.updated = true
+	return nil
+}
+
+func TestApplyNetworkDataSecret(t *testing.T) {
+	rendered := &corev1.Secret{Data: map[string][]byte{NetworkDataSecretKey: []byte("rendered")}}
+	rendered.Name = networkDataSecretName("host-0")
+	rendered.Labels = map[string]string{NetworkDataNodePoolLabel: "np1"}
+
+	// A pre-created secret of the same name is left untouched
+	precreated := &corev1.Secret{Data: map[string][]byte{NetworkDataSecretKey: []byte("precreated")}}
+	c := &secretClient{secret: precreated}
+	a := &Adaptor{Client: c}
+	if err := a.applyNetworkDataSecret(context.Background(), rendered.DeepCopy()); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for a pre-created secret, got %v", err)
+	}
+	if c.updated {
+		t.Errorf("expected the pre-created secret not to be updated")
+	}
+
+	// A secret rendered by the plugin is updated
+	c = &secretClient{secret: &corev1.Secret{Data: precreated.Data}}
+	c.secret.Labels = map[string]string{NetworkDataNodePoolLabel: "np1"}
+	a = &Adaptor{Client: c}
+	if err := a.applyNetworkDataSecret(context.Background(), rendered.DeepCopy()); err != nil || !c.updated {
+		t.Errorf("expected the rendered secret to be updated, got %v, %v", c.updated, err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -31,56 +32,26 @@ func (a *Adaptor) GetNodeList(ctx context.Context) (*hwmgmtv1alpha1.NodeList, er
 }
 
 // CreateNode creates a Node CR with specified attributes
-func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, cloudID, nodename, nodeId, nodeNs, groupname, hwprofile string) error {
+func (a *Adaptor) CreateNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, cloudID, nodename, nodeId, nodeNs, groupname, hwprofile string,
+	labels map[string]string) error {
 	a.Logger.InfoContext(ctx, "Ensuring node exists",
 		slog.String("nodegroup name", groupname),
 		slog.String("nodename", nodename),
 		slog.String("nodeId", nodeId))
 
-	nodeKey := types.NamespacedName{
-		Name:      nodename,
-		Namespace: a.Namespace,
-	}
-
-	existing := &hwmgmtv1alpha1.Node{}
-	err := a.Client.Get(ctx, nodeKey, existing)
-	if err == nil {
-		a.Logger.InfoContext(ctx, "Node already exists, skipping create", slog.String("nodename", nodename))
-		return nil
-	}
-
-	if !errors.IsNotFound(err) {
-		return fmt.Errorf("failed to check if node exists: %w", err)
-	}
-
-	blockDeletion := true
-	node := &hwmgmtv1alpha1.Node{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      nodename,
-			Namespace: a.Namespace,
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion:         nodepool.APIVersion,
-				Kind:               nodepool.Kind,
-				Name:               nodepool.Name,
-				UID:                nodepool.UID,
-				BlockOwnerDeletion: &blockDeletion,
-			}},
-		},
-		Spec: hwmgmtv1alpha1.NodeSpec{
-			NodePool:    cloudID,
-			GroupName:   groupname,
-			HwProfile:   hwprofile,
-			HwMgrId:     nodepool.Spec.HwMgrId,
-			HwMgrNodeNs: nodeNs,
-			HwMgrNodeId: nodeId,
-		},
-	}
-
-	if err := a.Client.Create(ctx, node); err != nil {
+	node := utils.NewNode(nodepool, a.Namespace, nodename, labels, hwmgmtv1alpha1.NodeSpec{
+		NodePool:    cloudID,
+		GroupName:   groupname,
+		HwProfile:   hwprofile,
+		HwMgrId:     nodepool.Spec.HwMgrId,
+		HwMgrNodeNs: nodeNs,
+		HwMgrNodeId: nodeId,
+	})
+	if err := utils.ApplyNode(ctx, a.Client, nodepool, node); err != nil {
 		return fmt.Errorf("failed to create Node: %w", err)
 	}
 
-	a.Logger.InfoContext(ctx, "Node created", slog.String("nodename", nodename))
+	a.Logger.InfoContext(ctx, "Node applied", slog.String("nodename", nodename))
 	return nil
 }
 
@@ -142,6 +113,7 @@ func (a *Adaptor) ApplyPostConfigUpdates(ctx context.Context, bmhName types.Name
 		}
 
 		utils.RemoveConfigAnnotation(updatedNode)
+		utils.EndNodeOperation(updatedNode, utils.NodeOperationSucceeded, "", a.clock())
 		if err := a.Client.Update(ctx, updatedNode); err != nil {
 			return fmt.Errorf("failed to remove annotation for node %s/%s: %w", updatedNode.Name, updatedNode.Namespace, err)
 		}
@@ -151,6 +123,15 @@ func (a *Adaptor) ApplyPostConfigUpdates(ctx context.Context, bmhName types.Name
 			string(hwmgmtv1alpha1.Completed),
 			metav1.ConditionTrue,
 			"Provisioned")
+		// Complete the progress of the BIOS settings reported while the node was provisioned
+		if configured := meta.FindStatusCondition(updatedNode.Status.Conditions, string(hwmgmtv1alpha1.Configured)); configured != nil &&
+			configured.Reason == string(hwmgmtv1alpha1.InProgress) {
+			utils.SetStatusCondition(&updatedNode.Status.Conditions,
+				string(hwmgmtv1alpha1.Configured),
+				string(hwmgmtv1alpha1.ConfigApplied),
+				metav1.ConditionTrue,
+				string(hwmgmtv1alpha1.ConfigSuccess))
+		}
 		if err := a.Client.Status().Update(ctx, updatedNode); err != nil {
 			return fmt.Errorf("failed to update node status: %w", err)
 		}
@@ -182,3 +163,26 @@ func (a *Adaptor) SetNodeFailedStatus(
 		slog.String("reason", string(hwmgmtv1alpha1.Failed)))
 	return nil
 }
+
+// recordAppliedConfig records the configuration applied from the hardware profile on the node
+func (a *Adaptor) recordAppliedConfig(ctx context.Context, nodename, namespace, profileName string) error {
+	hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, profileName, a.Namespace)
+	if err != nil {
+		return fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", profileName, err)
+	}
+
+	config, err := utils.NewAppliedConfig(hwProfile)
+	if err != nil {
+		return fmt.Errorf("failed to build applied config for node %s: %w", nodename, err)
+	}
+
+	if err := utils.SetAppliedConfig(ctx, a.Client, nodename, namespace, config); err != nil {
+		return fmt.Errorf("failed to record applied config for node %s: %w", nodename, err)
+	}
+
+	a.Logger.InfoContext(ctx, "Recorded applied config",
+		slog.String("nodename", nodename),
+		slog.String("hwProfile", profileName),
+		slog.String("hash", config.Hash))
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
@@ -29,7 +30,7 @@ func contains(slice []string, value string) bool {
 }
 
 // AllocateBMH assigns a BareMetalHost to a NodePool.
-func (a *Adaptor) allocateBMHToNodePool(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost, nodepool *hwmgmtv1alpha1.NodePool, group hwmgmtv1alpha1.NodeGroup) error {
+func (a *Adaptor) allocateBMHToNodePool(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, bmh *metal3v1alpha1.BareMetalHost, nodepool *hwmgmtv1alpha1.NodePool, group hwmgmtv1alpha1.NodeGroup) error {
 
 	bmhName := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
 	nodeName := bmh.Annotations[NodeNameAnnotation]
@@ -40,17 +41,39 @@ func (a *Adaptor) allocateBMHToNodePool(ctx context.Context, bmh *metal3v1alpha1
 		}
 	}
 
+	// Record the NodePool of the BMH, so that its transitions trigger reconciles of the NodePool
+	if bmh.Annotations[BmhNodePoolAnnotation] != nodepool.Name {
+		if err := a.updateBMHMetaWithRetry(ctx, bmhName, MetaTypeAnnotation, BmhNodePoolAnnotation, nodepool.Name, OpAdd); err != nil {
+			return fmt.Errorf("failed to save nodepool annotation to BMH (%s): %w", bmh.Name, err)
+		}
+	}
+
+	if isExternallyProvisioned(bmh) {
+		// Adopted hosts must never be wiped, so ensure cleaning is disabled before allocating
+		if err := a.disableBMHCleaning(ctx, bmh); err != nil {
+			return fmt.Errorf("failed to disable cleaning for BMH (%s): %w", bmh.Name, err)
+		}
+	}
+
 	nodeId := bmh.Name
 	nodeNs := bmh.Namespace
 	cloudID := nodepool.Spec.CloudID // cluster name
 
 	// Ensure node is created
-	if err := a.CreateNode(ctx, nodepool, cloudID, nodeName, nodeId, nodeNs, group.NodePoolData.Name, group.NodePoolData.HwProfile); err != nil {
+	if err := a.CreateNode(ctx, nodepool, cloudID, nodeName, nodeId, nodeNs, group.NodePoolData.Name, group.NodePoolData.HwProfile,
+		getBMHNodeSelectionInfo(*bmh).Labels()); err != nil {
 		return fmt.Errorf("failed to create allocated node (%s): %w", nodeName, err)
 	}
 
+	// Render the network data used while the host is configured, before any configuration starts
+	if !isExternallyProvisioned(bmh) {
+		if err := a.applyNetworkDataTemplate(ctx, nodepool, nodeName, group, bmh); err != nil {
+			return fmt.Errorf("failed to apply network data template for BMH (%s): %w", bmh.Name, err)
+		}
+	}
+
 	// Process HW profile
-	updating, err := a.processHwProfileWithHandledError(ctx, bmh, nodeName, a.Namespace, group.NodePoolData.HwProfile, false)
+	updating, err := a.processHwProfileWithHandledError(ctx, hwmgr, bmh, nodeName, a.Namespace, group.NodePoolData.HwProfile, false)
 	if err != nil {
 		return fmt.Errorf("failed to process hw profile for node (%s): %w", nodeName, err)
 	}
@@ -62,7 +85,7 @@ func (a *Adaptor) allocateBMHToNodePool(ctx context.Context, bmh *metal3v1alpha1
 	}
 
 	// Update node status
-	bmhInterface := a.buildInterfacesFromBMH(nodepool, *bmh)
+	bmhInterface := a.buildInterfacesFromBMH(ctx, nodepool, *bmh)
 	nodeInfo := bmhNodeInfo{
 		ResourcePoolID: group.NodePoolData.ResourcePoolId,
 		BMC: &bmhBmcInfo{
@@ -75,7 +98,9 @@ func (a *Adaptor) allocateBMHToNodePool(ctx context.Context, bmh *metal3v1alpha1
 		return fmt.Errorf("failed to update node status (%s): %w", nodeName, err)
 	}
 
-	if !updating {
+	// No configuration is applied to a host that needs no update, so its applied config is only recorded once a
+	// configuration of the host reports success
+	if !updating && !isExternallyProvisioned(bmh) {
 		if err := a.clearBMHNetworkData(ctx, types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}); err != nil {
 			return fmt.Errorf("failed to clear network data for BMH (%s/%s): %w", bmh.Name, bmh.Namespace, err)
 		}
@@ -95,46 +120,90 @@ func (a *Adaptor) allocateBMHToNodePool(ctx context.Context, bmh *metal3v1alpha1
 }
 
 // ProcessNodePoolAllocation allocates BareMetalHosts to a NodePool while ensuring all BMHs are in the same namespace.
-func (a *Adaptor) ProcessNodePoolAllocation(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) error {
+func (a *Adaptor) ProcessNodePoolAllocation(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) error {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var allocationErr error
+	var outcomes []allocationOutcome
+
+	policy, err := getPartialAllocationPolicy(nodepool)
+	if err != nil {
+		return err
+	}
 
 	// Get the BMH namespace from an already allocated node in this pool
-	bmhNamespace, err := a.getNodePoolBMHNamespace(ctx, nodepool)
+	bmhNamespace, err := a.getNodePoolBMHNamespace(ctx, hwmgr, nodepool)
 	if err != nil {
 		return fmt.Errorf("unable to determine BMH namespace for pool %s: %w", nodepool.Name, err)
 	}
 
+	placementPolicies, err := getPlacementPolicies(nodepool)
+	if err != nil {
+		return err
+	}
+
+	sizes, err := utils.GetEffectiveNodeGroupSizes(nodepool)
+	if err != nil {
+		return err
+	}
+
+	// Placement across nodes does not apply to a single node, so any candidate will do
+	singleNode := utils.IsSingleNodePool(nodepool)
+
 	// Process allocation for each NodeGroup
 	for _, nodeGroup := range nodepool.Spec.NodeGroup {
-		if nodeGroup.Size == 0 {
+		if sizes[nodeGroup.NodePoolData.Name] == 0 {
 			continue // Skip groups with size 0
 		}
 
 		// Retrieve only unallocated BMHs for the current site, resourcePoolId, and namespace
-		unallocatedBMHs, err := a.FetchBMHList(ctx, nodepool.Spec.Site, nodeGroup.NodePoolData, UnallocatedBMHs, bmhNamespace)
+		unallocatedBMHs, err := a.FetchBMHList(ctx, hwmgr, nodepool.Spec.Site, nodeGroup.NodePoolData, UnallocatedBMHs, bmhNamespace,
+			adoptExternallyProvisioned(hwmgr))
 		if err != nil {
 			return fmt.Errorf("unable to fetch unallocated BMHs for site=%s, nodegroup=%s: %w",
 				nodepool.Spec.Site, nodeGroup.NodePoolData.Name, err)
 		}
 
-		if len(unallocatedBMHs.Items) == 0 {
+		arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodeGroup.NodePoolData.Name)
+		if err != nil {
+			return err
+		}
+		candidates := filterBMHsByCPUArchitecture(unallocatedBMHs.Items, arch)
+
+		if len(candidates) == 0 {
+			if arch != "" {
+				return fmt.Errorf("no available nodes for site=%s, nodegroup=%s, cpuArchitecture=%s",
+					nodepool.Spec.Site, nodeGroup.NodePoolData.Name, arch)
+			}
 			return fmt.Errorf("no available nodes for site=%s, nodegroup=%s",
 				nodepool.Spec.Site, nodeGroup.NodePoolData.Name)
 		}
 
 		// Calculate pending nodes for the group
-		pendingNodes := nodeGroup.Size - a.countNodesInGroup(ctx, nodepool.Status.Properties.NodeNames, nodeGroup.NodePoolData.Name)
+		pendingNodes := sizes[nodeGroup.NodePoolData.Name] - a.countNodesInGroup(ctx, nodepool.Status.Properties.NodeNames, nodeGroup.NodePoolData.Name)
 		if pendingNodes <= 0 {
 			continue
 		}
 
+		// Consider the best fitting hosts first
+		scores, err := a.scoreBMHs(ctx, hwmgr, nodepool, nodeGroup.NodePoolData, candidates)
+		if err != nil {
+			return fmt.Errorf("unable to score BMHs for nodegroup=%s: %w", nodeGroup.NodePoolData.Name, err)
+		}
+		sortBMHsByScore(candidates, scores)
+
+		if policy, exists := placementPolicies[nodeGroup.NodePoolData.Name]; exists && !singleNode {
+			if candidates, err = a.placeNodeGroupBMHs(ctx, nodepool, nodeGroup.NodePoolData.Name, policy, candidates,
+				pendingNodes); err != nil {
+				return err
+			}
+		}
+
 		// Shared counter to track remaining nodes needed
 		nodeCounter := pendingNodes
 
 		// Allocate multiple nodes concurrently within the group
-		for _, bmh := range unallocatedBMHs.Items {
+		for _, bmh := range candidates {
 			mu.Lock()
 			if nodeCounter <= 0 {
 				mu.Unlock()
@@ -148,45 +217,64 @@ func (a *Adaptor) ProcessNodePoolAllocation(ctx context.Context, nodepool *hwmgm
 			go func(bmh *metal3v1alpha1.BareMetalHost) {
 				defer wg.Done()
 
-				// Allocate BMH to NodePool
-				err := a.allocateBMHToNodePool(ctx, bmh, nodepool, nodeGroup)
+				// Lease and allocate BMH to NodePool
+				err := a.grantBMHLease(ctx, hwmgr, bmh)
+				if err == nil {
+					err = a.allocateBMHToNodePool(ctx, hwmgr, bmh, nodepool, nodeGroup)
+				}
+				outcome := allocationOutcome{BMH: bmh.Name, NodeGroup: nodeGroup.NodePoolData.Name, Allocated: err == nil,
+					Score: scores[bmh.Name]}
+				mu.Lock()
+				defer mu.Unlock()
 				if err != nil {
-					mu.Lock()
+					outcome.Error = err.Error()
 					if typederrors.IsInputError(err) {
 						allocationErr = err
-					} else {
-						allocationErr = fmt.Errorf("failed to allocate BMH %s: %w", bmh.Name, err)
 					}
-					mu.Unlock()
 				}
+				outcomes = append(outcomes, outcome)
 			}(&bmh)
 		}
 	}
 
 	wg.Wait()
 
-	// Check if any error occurred in goroutines
+	// Invalid input is reported as is, regardless of the policy, as retrying cannot succeed
 	if allocationErr != nil {
 		return allocationErr
 	}
 
-	// Update node pool properties after all allocations are complete
+	if len(outcomes) > 0 {
+		if err := a.recordAllocationOutcomes(ctx, nodepool, outcomes); err != nil {
+			a.Logger.ErrorContext(ctx, "failed to record allocation outcomes", slog.String("error", err.Error()))
+		}
+	}
+
+	partialErr := newPartialAllocationError(policy, nodepool, sizes, outcomes)
+	if len(partialErr.failures) > 0 && policy == PartialAllocationFailAndRollback {
+		if err := a.rollbackNodePoolAllocation(ctx, nodepool); err != nil {
+			return fmt.Errorf("failed to roll back allocation after %s: %w", partialErr.Error(), err)
+		}
+		return partialErr
+	}
+
+	// Update node pool properties after all allocations are complete, keeping any partial allocation
 	if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
 		return fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
 	}
 
+	if len(partialErr.failures) > 0 {
+		return partialErr
+	}
 	return nil
 }
 
 // getNodePoolBMHNamespace retrieves the namespace of an already allocated BMH in the given NodePool.
-func (a *Adaptor) getNodePoolBMHNamespace(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (string, error) {
+func (a *Adaptor) getNodePoolBMHNamespace(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (string, error) {
 	for _, nodeGroup := range nodepool.Spec.NodeGroup {
-		if nodeGroup.Size == 0 {
-			continue // Skip groups with size 0
-		}
-
-		// Fetch only allocated BMHs that match site and resourcePoolId
-		bmhList, err := a.FetchBMHList(ctx, nodepool.Spec.Site, nodeGroup.NodePoolData, AllocatedBMHs, "")
+		// Fetch only allocated BMHs that match site and resourcePoolId, including adopted externally provisioned hosts
+		bmhList, err := a.FetchBMHList(ctx, hwmgr, nodepool.Spec.Site, nodeGroup.NodePoolData, AllocatedBMHs, "", true)
 		if err != nil {
 			return "", fmt.Errorf("unable to fetch allocated BMHs for nodegroup=%s: %w", nodeGroup.NodePoolData.Name, err)
 		}
@@ -0,0 +1,211 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// When node replacement is enabled, the BMHs of the nodes of a provisioned NodePool are checked for errors. A node whose
+// BMH remains in error for the grace period is marked Failed, and kept as a record of the failure, while its BMH is
+// released. The NodePool then returns to the provisioning state, where a replacement BMH is allocated from the same
+// resource pool to a new node, as when scaling out.
+
+// defaultReplacementGracePeriod is how long a BMH must remain in error before its node is replaced, by default
+const defaultReplacementGracePeriod = 10 * time.Minute
+
+// HostFailedSinceAnnotation records on a node when its BMH was first seen in error, to time the grace period before
+// the node is replaced
+const HostFailedSinceAnnotation = "hwmgr-plugin.oran.openshift.io/host-failed-since"
+
+// BmhFailedLabel marks a BMH released by the replacement of its node, keeping it out of allocation until an operator
+// has repaired it and removed the label
+const BmhFailedLabel = "hwmgr-plugin.oran.openshift.io/host-failed"
+
+// getReplacementGracePeriod returns the grace period before the nodes of failed BMHs are replaced, and whether node
+// replacement is enabled for the HardwareManager
+func getReplacementGracePeriod(hwmgr *pluginv1alpha1.HardwareManager) (time.Duration, bool) {
+	if hwmgr == nil || hwmgr.Spec.Metal3Data == nil || hwmgr.Spec.Metal3Data.NodeReplacement == nil ||
+		!hwmgr.Spec.Metal3Data.NodeReplacement.Enabled {
+		return 0, false
+	}
+	if gracePeriod := hwmgr.Spec.Metal3Data.NodeReplacement.GracePeriod; gracePeriod != nil {
+		return gracePeriod.Duration, true
+	}
+	return defaultReplacementGracePeriod, true
+}
+
+// isBMHMarkedFailed checks whether the BMH was released by the replacement of its node and not yet repaired
+func isBMHMarkedFailed(bmh *metal3v1alpha1.BareMetalHost) bool {
+	_, exists := bmh.GetLabels()[BmhFailedLabel]
+	return exists
+}
+
+// isBMHFailed checks whether the BMH reports an error
+func isBMHFailed(bmh *metal3v1alpha1.BareMetalHost) bool {
+	return bmh.Status.OperationalStatus == metal3v1alpha1.OperationalStatusError
+}
+
+// getHostFailedSince returns when the BMH of the node was first seen in error, if recorded. An unparsable time is
+// treated as unrecorded, restarting the grace period.
+func getHostFailedSince(node *hwmgmtv1alpha1.Node) (time.Time, bool) {
+	value, exists := node.GetAnnotations()[HostFailedSinceAnnotation]
+	if !exists {
+		return time.Time{}, false
+	}
+	failedSince, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return failedSince, true
+}
+
+// replacementDueIn returns how long until a node whose BMH failed at the given time is due for replacement, zero or
+// less meaning it is due
+func replacementDueIn(failedSince time.Time, gracePeriod time.Duration, now time.Time) time.Duration {
+	return failedSince.Add(gracePeriod).Sub(now)
+}
+
+// getActiveChildNodes returns the nodes of the NodePool, leaving out the replaced nodes, which no longer hold their BMH
+func (a *Adaptor) getActiveChildNodes(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) (*hwmgmtv1alpha1.NodeList, error) {
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return nil, err // nolint: wrapcheck
+	}
+	nodelist.Items = slices.DeleteFunc(nodelist.Items, func(node hwmgmtv1alpha1.Node) bool {
+		return utils.IsNodeReplaced(&node)
+	})
+	return nodelist, nil
+}
+
+// setHostFailedSince records or, given a zero time, clears when the BMH of the node was first seen in error
+func (a *Adaptor) setHostFailedSince(ctx context.Context, node *hwmgmtv1alpha1.Node, failedSince time.Time) error {
+	patch := client.MergeFrom(node.DeepCopy())
+	if failedSince.IsZero() {
+		delete(node.Annotations, HostFailedSinceAnnotation)
+	} else {
+		if node.Annotations == nil {
+			node.Annotations = make(map[string]string)
+		}
+		node.Annotations[HostFailedSinceAnnotation] = failedSince.UTC().Format(time.RFC3339)
+	}
+	if err := a.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to patch node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+// replaceNode marks the node of a failed BMH Failed and releases the BMH, removing the node from the NodePool so that
+// a replacement is allocated
+func (a *Adaptor) replaceNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, node *hwmgmtv1alpha1.Node,
+	bmh *metal3v1alpha1.BareMetalHost) error {
+	reason := fmt.Sprintf("BareMetalHost %s/%s failed: %s: %s", bmh.Namespace, bmh.Name, bmh.Status.ErrorType,
+		bmh.Status.ErrorMessage)
+
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Provisioned), metav1.ConditionFalse, string(hwmgmtv1alpha1.Failed),
+		"Replaced after its hardware failed: "+reason); err != nil {
+		return fmt.Errorf("failed to update node status (%s): %w", node.Name, err)
+	}
+
+	// The BMH is marked failed before it is released, so that it is not allocated again should it leave the error
+	// state without being repaired, and released before the node is marked replaced, so that an interrupted
+	// replacement is retried
+	name := types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}
+	if err := a.updateBMHMetaWithRetry(ctx, name, MetaTypeLabel, BmhFailedLabel, ValueTrue, OpAdd); err != nil {
+		return fmt.Errorf("failed to mark BMH %s failed: %w", name, err)
+	}
+	if err := a.releaseBMH(ctx, bmh); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[utils.NodeReplacedAnnotation] = reason
+	delete(node.Annotations, HostFailedSinceAnnotation)
+	if err := a.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to mark node %s replaced: %w", node.Name, err)
+	}
+
+	nodepool.Status.Properties.NodeNames = slices.DeleteFunc(nodepool.Status.Properties.NodeNames,
+		func(name string) bool { return name == node.Name })
+	a.Logger.WarnContext(ctx, "Replacing node of failed BMH", slog.String("node", node.Name),
+		slog.String("nodegroup", node.Spec.GroupName), slog.String("bmh", bmh.Name), slog.String("reason", reason))
+	return nil
+}
+
+// replaceFailedNodes replaces the nodes whose BMH has been in error for the grace period, if node replacement is
+// enabled, leaving out the cordoned nodes, which operators hold for investigation, and returning the names of the replaced nodes, and how long until the next of the other failed nodes is due
+// for replacement, if any
+func (a *Adaptor) replaceFailedNodes(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool, nodes []hwmgmtv1alpha1.Node) ([]string, time.Duration, error) {
+	gracePeriod, enabled := getReplacementGracePeriod(hwmgr)
+	if !enabled {
+		return nil, 0, nil
+	}
+
+	var replaced []string
+	var recheckIn time.Duration
+	now := a.clock().Now()
+	for i := range nodes {
+		node := &nodes[i]
+		if utils.IsNodeCordoned(node) {
+			continue
+		}
+		bmh, err := a.getBMHForNode(ctx, node)
+		if err != nil {
+			return replaced, 0, fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
+		}
+
+		failedSince, recorded := getHostFailedSince(node)
+		switch {
+		case !isBMHFailed(bmh):
+			if recorded {
+				a.Logger.InfoContext(ctx, "BMH of node recovered", slog.String("node", node.Name),
+					slog.String("bmh", bmh.Name))
+				if err := a.setHostFailedSince(ctx, node, time.Time{}); err != nil {
+					return replaced, 0, err
+				}
+			}
+			continue
+		case !recorded:
+			a.Logger.WarnContext(ctx, "BMH of node failed, replacing the node unless it recovers",
+				slog.String("node", node.Name), slog.String("bmh", bmh.Name), slog.Duration("gracePeriod", gracePeriod))
+			if err := a.setHostFailedSince(ctx, node, now); err != nil {
+				return replaced, 0, err
+			}
+			failedSince = now
+		}
+
+		if dueIn := replacementDueIn(failedSince, gracePeriod, now); dueIn > 0 {
+			if recheckIn == 0 || dueIn < recheckIn {
+				recheckIn = dueIn
+			}
+			continue
+		}
+		if err := a.replaceNode(ctx, nodepool, node, bmh); err != nil {
+			return replaced, 0, err
+		}
+		replaced = append(replaced, node.Name)
+	}
+	return replaced, recheckIn, nil
+}
@@ -0,0 +1,84 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+func TestGetReplacementGracePeriod(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if _, enabled := getReplacementGracePeriod(hwmgr); enabled {
+		t.Errorf("expected node replacement to be disabled by default")
+	}
+
+	hwmgr.Spec.Metal3Data = &pluginv1alpha1.Metal3Data{NodeReplacement: &pluginv1alpha1.NodeReplacementPolicy{}}
+	if _, enabled := getReplacementGracePeriod(hwmgr); enabled {
+		t.Errorf("expected node replacement to be disabled unless enabled")
+	}
+
+	hwmgr.Spec.Metal3Data.NodeReplacement.Enabled = true
+	if gracePeriod, enabled := getReplacementGracePeriod(hwmgr); !enabled || gracePeriod != defaultReplacementGracePeriod {
+		t.Errorf("expected the default grace period, got %v, %v", gracePeriod, enabled)
+	}
+
+	hwmgr.Spec.Metal3Data.NodeReplacement.GracePeriod = &metav1.Duration{Duration: time.Minute}
+	if gracePeriod, _ := getReplacementGracePeriod(hwmgr); gracePeriod != time.Minute {
+		t.Errorf("expected the configured grace period, got %v", gracePeriod)
+	}
+}
+
+func TestReplacementDue(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	node := &hwmgmtv1alpha1.Node{}
+	if _, recorded := getHostFailedSince(node); recorded {
+		t.Errorf("expected no failure to be recorded")
+	}
+	node.Annotations = map[string]string{HostFailedSinceAnnotation: "yesterday"}
+	if _, recorded := getHostFailedSince(node); recorded {
+		t.Errorf("expected an unparsable failure time to be ignored")
+	}
+
+	node.Annotations[HostFailedSinceAnnotation] = now.Add(-4 * time.Minute).Format(time.RFC3339)
+	failedSince, recorded := getHostFailedSince(node)
+	if !recorded {
+		t.Fatalf("expected the failure time to be recorded")
+	}
+	if dueIn := replacementDueIn(failedSince, 10*time.Minute, now); dueIn != 6*time.Minute {
+		t.Errorf("expected the node to be due in 6m, got %v", dueIn)
+	}
+	if dueIn := replacementDueIn(failedSince, 4*time.Minute, now); dueIn > 0 {
+		t.Errorf("expected the node to be due once the grace period has passed, got %v", dueIn)
+	}
+}
+
+func TestReplaceFailedNodesSkipsCordoned(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	hwmgr.Spec.Metal3Data = &pluginv1alpha1.Metal3Data{NodeReplacement: &pluginv1alpha1.NodeReplacementPolicy{Enabled: true}}
+	node := hwmgmtv1alpha1.Node{}
+	node.Name = "node-0"
+	node.Annotations = map[string]string{utils.CordonAnnotation: "investigation"}
+
+	// The cordoned node is left out before its BMH is looked up, so the adaptor needs no client
+	a := &Adaptor{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	replaced, recheckIn, err := a.replaceFailedNodes(context.Background(), hwmgr, &hwmgmtv1alpha1.NodePool{},
+		[]hwmgmtv1alpha1.Node{node})
+	if err != nil || len(replaced) != 0 || recheckIn != 0 {
+		t.Errorf("expected the cordoned node to be skipped, got %v, %v, %v", replaced, recheckIn, err)
+	}
+}
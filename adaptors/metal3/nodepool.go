@@ -8,6 +8,7 @@ package metal3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -34,15 +35,19 @@ func (a *Adaptor) CheckNodePoolProgress(
 		return false, err
 	}
 	if !full {
-		return false, a.ProcessNodePoolAllocation(ctx, nodepool)
+		return false, a.ProcessNodePoolAllocation(ctx, hwmgr, nodepool)
 	}
 	// Node is fully allocated
 	// check if there are any pending work such as bios configuring
-	if updating, err := a.checkForPendingUpdate(ctx, nodepool); err != nil {
+	if updating, err := a.checkForPendingUpdate(ctx, hwmgr, nodepool); err != nil {
 		return false, err
 	} else if updating {
 		return false, nil
 	}
+	// Gate completion on the hardware validation, if requested
+	if err := a.validateNodePoolHardware(ctx, nodepool); err != nil {
+		return false, err
+	}
 	return true, nil
 }
 
@@ -65,6 +70,15 @@ func (a *Adaptor) HandleNodePoolCreate(
 		conditionReason = hwmgmtv1alpha1.InProgress
 		conditionStatus = metav1.ConditionFalse
 		message = "Handling creation"
+
+		// Warn of other NodePools contending for the same free hosts, as not all may be satisfied
+		warning, err := a.checkAllocationContention(ctx, hwmgr, nodepool)
+		if err != nil {
+			a.Logger.InfoContext(ctx, "Unable to check allocation contention", slog.String("error", err.Error()))
+		} else if warning != "" {
+			a.Logger.WarnContext(ctx, "NodePool contends for hosts with other NodePools", slog.String("warning", warning))
+			message += ", warning: " + warning
+		}
 	}
 
 	if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
@@ -87,6 +101,15 @@ func (a *Adaptor) HandleNodePoolProcessing(
 
 	var result ctrl.Result
 	full, err := a.CheckNodePoolProgress(ctx, hwmgr, nodepool)
+	if isAllocationRetry(err) {
+		a.Logger.InfoContext(ctx, "NodePool partially allocated, retrying", slog.String("reason", err.Error()))
+		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool, hwmgmtv1alpha1.Provisioned,
+			hwmgmtv1alpha1.InProgress, metav1.ConditionFalse, err.Error()); err != nil {
+			return utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+		return utils.RequeueWithMediumInterval(), nil
+	}
 	if err != nil {
 		reason := hwmgmtv1alpha1.Failed
 		if typederrors.IsInputError(err) {
@@ -117,7 +140,7 @@ func (a *Adaptor) HandleNodePoolProcessing(
 			return utils.RequeueWithMediumInterval(),
 				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
 		}
-		result = utils.RequeueWithShortInterval()
+		result = utils.RequeueWhileInProgress(nodepool)
 	}
 
 	return result, nil
@@ -130,22 +153,53 @@ func (a *Adaptor) ProcessNewNodePool(ctx context.Context,
 
 	a.Logger.InfoContext(ctx, "Processing ProcessNewNodePool request")
 
+	placementPolicies, err := getPlacementPolicies(nodepool)
+	if err != nil {
+		return err
+	}
+
+	if _, err := getHardwareValidationSpecs(nodepool); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateNodePoolExtensions(nodepool); err != nil {
+		return err
+	}
+
+	if _, err := getPartialAllocationPolicy(nodepool); err != nil {
+		return err
+	}
+
+	sizes, err := utils.GetEffectiveNodeGroupSizes(nodepool)
+	if err != nil {
+		return err
+	}
+
 	// Check if enough resources are available for each NodeGroup
 	for _, nodeGroup := range nodepool.Spec.NodeGroup {
-		if nodeGroup.Size == 0 {
+		size := sizes[nodeGroup.NodePoolData.Name]
+		if size == 0 {
 			continue // Skip groups with size 0
 		}
 
-		// Fetch unallocated BMHs for the specific site and poolID
-		bmhListForGroup, err := a.FetchBMHList(ctx, nodepool.Spec.Site, nodeGroup.NodePoolData, UnallocatedBMHs, "")
+		// Fetch the unallocated BMHs of the requested architecture for the specific site and poolID
+		candidates, err := a.getNodeGroupCandidates(ctx, hwmgr, nodepool, nodeGroup)
 		if err != nil {
-			return fmt.Errorf("unable to fetch BMHs for nodegroup=%s: %w", nodeGroup.NodePoolData.Name, err)
+			return err
 		}
 
 		// Ensure enough resources exist in the requested pool
-		if len(bmhListForGroup.Items) < nodeGroup.Size {
-			return fmt.Errorf("not enough free resources matching nodegroup=%s criteria: freenodes=%d, required=%d",
-				nodeGroup.NodePoolData.Name, len(bmhListForGroup.Items), nodeGroup.Size)
+		if len(candidates) < size {
+			return a.insufficientResourcesError(ctx, hwmgr, nodepool, nodeGroup, len(candidates), size)
+		}
+
+		// Ensure the placement constraint can be satisfied. It always is for a single node.
+		if policy, exists := placementPolicies[nodeGroup.NodePoolData.Name]; exists && size > 1 {
+			if _, err := selectBMHsForPlacement(candidates, spreadTopologyLabel(policy.SpreadBy), nil, policy.Mode,
+				size); err != nil {
+				return fmt.Errorf("unable to place nodegroup=%s by %s (%s): %w",
+					nodeGroup.NodePoolData.Name, policy.SpreadBy, policy.Mode, err)
+			}
 		}
 	}
 
@@ -157,9 +211,19 @@ func (a *Adaptor) IsNodePoolFullyAllocated(ctx context.Context,
 	hwmgr *pluginv1alpha1.HardwareManager,
 	nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
 
+	// A single-node NodePool is fully allocated once it has a node, without looking up the group of the node
+	if utils.IsSingleNodePool(nodepool) {
+		return len(nodepool.Status.Properties.NodeNames) > 0, nil
+	}
+
+	sizes, err := utils.GetEffectiveNodeGroupSizes(nodepool)
+	if err != nil {
+		return false, err
+	}
+
 	for _, nodeGroup := range nodepool.Spec.NodeGroup {
 		allocatedNodes := a.countNodesInGroup(ctx, nodepool.Status.Properties.NodeNames, nodeGroup.NodePoolData.Name)
-		if allocatedNodes < nodeGroup.Size {
+		if allocatedNodes < sizes[nodeGroup.NodePoolData.Name] {
 			return false, nil // At least one group is not fully allocated
 		}
 	}
@@ -170,7 +234,8 @@ func (a *Adaptor) IsNodePoolFullyAllocated(ctx context.Context,
 // If a node is found and its associated BMH status indicates that the update has completed,
 // it updates the node status, clears the annotation, applies the post-change annotation, and
 // requeues immediately.
-func (a *Adaptor) handleInProgressUpdate(ctx context.Context, nodelist *hwmgmtv1alpha1.NodeList) (ctrl.Result, bool, error) {
+func (a *Adaptor) handleInProgressUpdate(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodelist *hwmgmtv1alpha1.NodeList) (ctrl.Result, bool, error) {
 	node := utils.FindNodeConfigInProgress(nodelist)
 	if node == nil {
 		a.Logger.InfoContext(ctx, "No node found that is in progress")
@@ -182,10 +247,63 @@ func (a *Adaptor) handleInProgressUpdate(ctx context.Context, nodelist *hwmgmtv1
 		return ctrl.Result{}, true, fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
 	}
 
+	rollback, err := getFirmwareRollback(node)
+	if err != nil {
+		return ctrl.Result{}, true, err
+	}
+	if rollback != nil {
+		return a.handleFirmwareRollback(ctx, hwmgr, node, bmh, rollback)
+	}
+
 	// Check if the update is complete by examining the BMH operational status.
 	if bmh.Status.OperationalStatus == metal3v1alpha1.OperationalStatusOK {
 		a.Logger.InfoContext(ctx, "BMH update complete", slog.String("BMH", bmh.Name))
 
+		// The firmware versions are verified before the update is reported as complete
+		failure, pending, err := a.verifyFirmwareUpdate(ctx, hwmgr, node, bmh)
+		if err != nil {
+			return ctrl.Result{}, true, err
+		}
+		if pending {
+			a.Logger.InfoContext(ctx, "Waiting for firmware versions to be reported", slog.String("BMH", bmh.Name))
+			a.reportFirmwareUpdateProgress(ctx, bmh, node)
+			return utils.RequeueWithShortInterval(), true, nil
+		}
+		if failure != "" {
+			a.Logger.InfoContext(ctx, "Firmware verification failed", slog.String("BMH", bmh.Name), slog.String("failure", failure))
+			started, err := a.startFirmwareRollback(ctx, hwmgr, node, bmh, "verification failure: "+failure, utils.NodeOperationFailed)
+			if err != nil {
+				return ctrl.Result{}, true, err
+			}
+			if started {
+				return utils.RequeueWithShortInterval(), true, nil
+			}
+			return a.failConfigVerification(ctx, node, FirmwareVerificationFailed, failure)
+		}
+
+		// The BIOS settings are read back, so that the configuration is only reported as applied once the hardware
+		// reports the requested values
+		failure, pending, err = a.verifyFirmwareSettings(ctx, hwmgr, node, bmh)
+		if err != nil {
+			return ctrl.Result{}, true, err
+		}
+		if pending {
+			a.Logger.InfoContext(ctx, "Waiting for BIOS settings to be reported", slog.String("BMH", bmh.Name))
+			return utils.RequeueWithShortInterval(), true, nil
+		}
+		if failure != "" {
+			a.Logger.InfoContext(ctx, "BIOS settings verification failed", slog.String("BMH", bmh.Name), slog.String("failure", failure))
+			return a.failConfigVerification(ctx, node, BiosSettingsVerificationFailed, failure)
+		}
+
+		// The last progress skipped is written before the final status, which then refers to the latest node
+		if err := utils.ClearNodeProgress(ctx, a.Client, node.Name, node.Namespace, a.clock()); err != nil {
+			return ctrl.Result{}, true, fmt.Errorf("failed to write progress of node %s: %w", node.Name, err)
+		}
+		if err := a.Client.Get(ctx, client.ObjectKeyFromObject(node), node); err != nil {
+			return ctrl.Result{}, true, fmt.Errorf("failed to get node %s: %w", node.Name, err)
+		}
+
 		// Update the node's status to reflect the new hardware profile.
 		node.Status.HwProfile = node.Spec.HwProfile
 		utils.SetStatusCondition(&node.Status.Conditions,
@@ -197,9 +315,13 @@ func (a *Adaptor) handleInProgressUpdate(ctx context.Context, nodelist *hwmgmtv1
 			return ctrl.Result{}, true, fmt.Errorf("failed to update status for node %s: %w", node.Name, err)
 		}
 		utils.RemoveConfigAnnotation(node)
+		utils.EndNodeOperation(node, utils.NodeOperationSucceeded, "", a.clock())
 		if err := utils.CreateOrUpdateK8sCR(ctx, a.Client, node, nil, utils.PATCH); err != nil {
 			return ctrl.Result{}, true, fmt.Errorf("failed to clear annotation from node %s: %w", node.Name, err)
 		}
+		if err := a.recordAppliedConfig(ctx, node.Name, node.Namespace, node.Spec.HwProfile); err != nil {
+			return ctrl.Result{}, true, err
+		}
 
 		// Apply the post-change annotation to indicate completion.
 		if err := a.removePreChangeAnnotation(ctx, bmh); err != nil {
@@ -211,20 +333,64 @@ func (a *Adaptor) handleInProgressUpdate(ctx context.Context, nodelist *hwmgmtv1
 
 	if bmh.Status.OperationalStatus == metal3v1alpha1.OperationalStatusError {
 		a.Logger.InfoContext(ctx, "BMH update failed", slog.String("BMH", bmh.Name))
+		started, err := a.startFirmwareRollback(ctx, hwmgr, node, bmh, BmhServicingErr, utils.NodeOperationFailed)
+		if err != nil {
+			return ctrl.Result{}, true, err
+		}
+		if started {
+			return utils.RequeueWithShortInterval(), true, nil
+		}
+		if err := utils.ClearNodeProgress(ctx, a.Client, node.Name, node.Namespace, a.clock()); err != nil {
+			a.Logger.ErrorContext(ctx, "failed to write node progress", slog.String("node", node.Name), slog.String("error", err.Error()))
+		}
 		if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
 			string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse,
 			string(hwmgmtv1alpha1.Failed), BmhServicingErr); err != nil {
 			a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
 		}
+		if err := utils.FinishNodeOperation(ctx, a.Client, node.Name, node.Namespace, utils.NodeOperationFailed, BmhServicingErr, a.clock()); err != nil {
+			a.Logger.ErrorContext(ctx, "failed to record operation outcome", slog.String("node", node.Name), slog.String("error", err.Error()))
+		}
 		return ctrl.Result{}, false, fmt.Errorf("failed to apply changes for BMH %s/%s", bmh.Namespace, bmh.Name)
 	}
 
+	if firmwareRollbackEnabled(hwmgr) &&
+		operationTimedOut(node, utils.GetOperationTimeout(hwmgr, utils.OperationFirmwareJob), a.clock().Now()) {
+		a.Logger.InfoContext(ctx, "BMH config timed out", slog.String("bmh", bmh.Name))
+		started, err := a.startFirmwareRollback(ctx, hwmgr, node, bmh, "firmware job timeout", utils.NodeOperationTimedOut)
+		if err != nil {
+			return ctrl.Result{}, true, err
+		}
+		if started {
+			return utils.RequeueWithShortInterval(), true, nil
+		}
+	}
+
 	a.Logger.InfoContext(ctx, "BMH config in progress", slog.String("bmh", bmh.Name))
+	a.reportFirmwareUpdateProgress(ctx, bmh, node)
 	return utils.RequeueWithMediumInterval(), true, nil
 }
 
+// failConfigVerification fails the configuration of a node whose firmware versions or BIOS settings do not match its
+// hardware profile once its update has completed
+func (a *Adaptor) failConfigVerification(ctx context.Context, node *hwmgmtv1alpha1.Node, reason, failure string) (ctrl.Result, bool, error) {
+	message := fmt.Sprintf("%s: %s", reason, failure)
+	if err := utils.ClearNodeProgress(ctx, a.Client, node.Name, node.Namespace, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to write node progress", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse,
+		string(hwmgmtv1alpha1.Failed), message); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.FinishNodeOperation(ctx, a.Client, node.Name, node.Namespace, utils.NodeOperationFailed, message, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to record operation outcome", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	return ctrl.Result{}, false, fmt.Errorf("failed to configure node %s: %s", node.Name, message)
+}
+
 // initiateNodeUpdate starts the update process for the given node by processing the new hardware profile,
-func (a *Adaptor) initiateNodeUpdate(ctx context.Context, node *hwmgmtv1alpha1.Node,
+func (a *Adaptor) initiateNodeUpdate(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, node *hwmgmtv1alpha1.Node,
 	newHwProfile string) (ctrl.Result, error) {
 
 	bmh, err := a.getBMHForNode(ctx, node)
@@ -241,7 +407,15 @@ func (a *Adaptor) initiateNodeUpdate(ctx context.Context, node *hwmgmtv1alpha1.N
 		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to apply pre-change annotation for BMH %s/%s: %w", bmh.Namespace, bmh.Name, err)
 	}
 
-	updateRequired, err := a.processHwProfileWithHandledError(ctx, bmh, node.Name, node.Namespace, newHwProfile, true)
+	updateRequired, err := a.processHwProfileWithHandledError(ctx, hwmgr, bmh, node.Name, node.Namespace, newHwProfile, true)
+	if errors.Is(err, utils.ErrNodeCordoned) {
+		// The node was cordoned since it was selected for update, so it is held back like the other cordoned nodes
+		a.Logger.InfoContext(ctx, "Profile update held for cordoned node", slog.String("node", node.Name))
+		if err := a.removePreChangeAnnotation(ctx, bmh); err != nil {
+			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to remove pre-change annotation for BMH %s/%s: %w", bmh.Namespace, bmh.Name, err)
+		}
+		return utils.RequeueWithMediumInterval(), nil
+	}
 	if err != nil {
 		return utils.DoNotRequeue(), err
 	}
@@ -250,8 +424,9 @@ func (a *Adaptor) initiateNodeUpdate(ctx context.Context, node *hwmgmtv1alpha1.N
 	// Copy the current node object for patching
 	patch := client.MergeFrom(node.DeepCopy())
 
-	// Set the new profile in the spec
+	// Set the new profile in the spec, discarding the rollback of any earlier update
 	node.Spec.HwProfile = newHwProfile
+	delete(node.Annotations, FirmwareRollbackAnnotation)
 
 	if err = a.Client.Patch(ctx, node, patch); err != nil {
 		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to patch Node %s in namespace %s: %w", node.Name, node.Namespace, err)
@@ -276,6 +451,9 @@ func (a *Adaptor) initiateNodeUpdate(ctx context.Context, node *hwmgmtv1alpha1.N
 			string(hwmgmtv1alpha1.ConfigApplied), string(hwmgmtv1alpha1.ConfigSuccess)); err != nil {
 			a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
 		}
+		if err := a.recordAppliedConfig(ctx, node.Name, node.Namespace, newHwProfile); err != nil {
+			return ctrl.Result{}, err
+		}
 		// No update required, so we can remove the pre-change annotation
 		if err := a.removePreChangeAnnotation(ctx, bmh); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to remove pre-change annotation for BMH %s/%s: %w", bmh.Namespace, bmh.Name, err)
@@ -286,12 +464,13 @@ func (a *Adaptor) initiateNodeUpdate(ctx context.Context, node *hwmgmtv1alpha1.N
 
 func (a *Adaptor) handleNodePoolConfiguring(
 	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
 	nodepool *hwmgmtv1alpha1.NodePool,
 ) (ctrl.Result, *hwmgmtv1alpha1.NodeList, error) {
 
 	a.Logger.InfoContext(ctx, "Handling Node Pool Configuring")
 
-	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
 	if err != nil {
 		return ctrl.Result{}, nil, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
 	}
@@ -306,7 +485,7 @@ func (a *Adaptor) handleNodePoolConfiguring(
 		}
 
 		// Initiate the update process for the selected node.
-		res, err := a.initiateNodeUpdate(ctx, node, newHwProfile)
+		res, err := a.initiateNodeUpdate(ctx, hwmgr, node, newHwProfile)
 		return res, nodelist, err
 	}
 
@@ -321,7 +500,7 @@ func (a *Adaptor) handleNodePoolConfiguring(
 	}
 
 	// STEP 3: Process any node that is already in the update-in-progress state.
-	res, handled, err := a.handleInProgressUpdate(ctx, nodelist)
+	res, handled, err := a.handleInProgressUpdate(ctx, hwmgr, nodelist)
 	if err != nil {
 		if !handled {
 			a.Logger.InfoContext(ctx, "Not handled", slog.String("error", err.Error()))
@@ -354,9 +533,17 @@ func (a *Adaptor) HandleNodePoolSpecChanged(
 		}
 	}
 
-	result, nodelist, err := a.handleNodePoolConfiguring(ctx, nodepool)
+	result, nodelist, err := a.handleNodePoolConfiguring(ctx, hwmgr, nodepool)
 	if nodelist != nil {
 		status, reason, message := utils.DeriveNodePoolStatusFromNodes(ctx, a.NoncachedClient, a.Logger, nodelist)
+		// Cordoned nodes are left with their current profile, holding back the update until they are uncordoned
+		if held := utils.FindCordonedNodesPendingUpdate(nodepool, nodelist); len(held) > 0 && status == metav1.ConditionTrue {
+			a.Logger.InfoContext(ctx, "Profile update held for cordoned nodes", slog.Any("nodes", held))
+			status, reason, message = metav1.ConditionFalse, string(hwmgmtv1alpha1.ConfigUpdate), utils.CordonedNodesMessage(held)
+			if err == nil && result.IsZero() {
+				result = utils.RequeueWithMediumInterval()
+			}
+		}
 
 		if updateErr := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
 			hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.ConditionReason(reason), status, message); updateErr != nil {
@@ -414,7 +601,7 @@ func (a *Adaptor) ReleaseNodePool(ctx context.Context,
 	)
 
 	// remove the allocated label from BMHs and finalizer from the corresponding PreprovisioningImage resources
-	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
 	if err != nil {
 		return fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
 	}
@@ -423,13 +610,43 @@ func (a *Adaptor) ReleaseNodePool(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
 		}
-		if err = a.unmarkBMHAllocated(ctx, bmh); err != nil {
+		if err = a.releaseBMH(ctx, bmh); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseBMH removes the allocated label and lease from the BMH and the finalizer from the corresponding PreprovisioningImage
+func (a *Adaptor) releaseBMH(ctx context.Context, bmh *metal3v1alpha1.BareMetalHost) error {
+	if isExternallyProvisioned(bmh) {
+		// Adopted hosts are released without cleaning, and have no PreprovisioningImage
+		if err := a.disableBMHCleaning(ctx, bmh); err != nil {
+			return fmt.Errorf("failed to disable cleaning for BMH %s: %w", bmh.Name, err)
+		}
+		if err := a.unmarkBMHAllocated(ctx, bmh); err != nil {
 			return fmt.Errorf("failed to unmarkBMHAllocated: %w", err)
 		}
-		if err = a.removeMetal3Finalizer(ctx, bmh.Name, bmh.Namespace); err != nil {
-			return fmt.Errorf("failed to remove finalizer: %w", err)
+		if err := a.clearBMHLease(ctx, bmh); err != nil {
+			return fmt.Errorf("failed to clear lease: %w", err)
 		}
+		return nil
+	}
+	if err := a.unmarkBMHAllocated(ctx, bmh); err != nil {
+		return fmt.Errorf("failed to unmarkBMHAllocated: %w", err)
+	}
+	if err := a.clearBMHLease(ctx, bmh); err != nil {
+		return fmt.Errorf("failed to clear lease: %w", err)
+	}
+	if err := a.clearBMHNetworkData(ctx, client.ObjectKeyFromObject(bmh)); err != nil {
+		return fmt.Errorf("failed to clear network data: %w", err)
+	}
+	if err := a.clearBMHRAIDConfig(ctx, client.ObjectKeyFromObject(bmh)); err != nil {
+		return fmt.Errorf("failed to clear RAID configuration: %w", err)
+	}
+	if err := a.removeMetal3Finalizer(ctx, bmh.Name, bmh.Namespace); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
 	}
-
 	return nil
 }
@@ -0,0 +1,166 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// PartialAllocationPolicyAnnotation is set on a NodePool to select how a partially successful allocation is handled,
+// when some of the nodes of an allocation pass are allocated and others fail
+const PartialAllocationPolicyAnnotation = "hwmgr-plugin.oran.openshift.io/partial-allocation-policy"
+
+// AllocationOutcomesAnnotation records the outcome of each BMH of the last allocation pass on the NodePool, as the
+// NodePool status properties are defined by the O2IMS API
+const AllocationOutcomesAnnotation = "hwmgr-plugin.oran.openshift.io/allocation-outcomes"
+
+// PartialAllocationPolicy defines how a partially successful allocation is handled
+type PartialAllocationPolicy string
+
+const (
+	// PartialAllocationFailAndRollback releases all nodes of the NodePool and fails the NodePool
+	PartialAllocationFailAndRollback PartialAllocationPolicy = "fail-all-and-rollback"
+	// PartialAllocationKeepAndRetry keeps the allocated nodes and retries the failed ones
+	PartialAllocationKeepAndRetry PartialAllocationPolicy = "keep-partial-and-retry"
+	// PartialAllocationKeepAndReport keeps the allocated nodes and fails the NodePool. This is the default.
+	PartialAllocationKeepAndReport PartialAllocationPolicy = "keep-partial-and-report"
+)
+
+// getPartialAllocationPolicy parses the partial allocation policy annotation from the NodePool
+func getPartialAllocationPolicy(nodepool *hwmgmtv1alpha1.NodePool) (PartialAllocationPolicy, error) {
+	value, exists := nodepool.GetAnnotations()[PartialAllocationPolicyAnnotation]
+	if !exists || value == "" {
+		return PartialAllocationKeepAndReport, nil
+	}
+
+	switch policy := PartialAllocationPolicy(value); policy {
+	case PartialAllocationFailAndRollback, PartialAllocationKeepAndRetry, PartialAllocationKeepAndReport:
+		return policy, nil
+	default:
+		return "", typederrors.NewInputError("invalid %s annotation: %s", PartialAllocationPolicyAnnotation, value)
+	}
+}
+
+// allocationOutcome is the outcome of the allocation of a BMH to a NodePool
+type allocationOutcome struct {
+	BMH       string `json:"bmh"`
+	NodeGroup string `json:"nodeGroup"`
+	Allocated bool   `json:"allocated"`
+	Score     int    `json:"score"`
+	Error     string `json:"error,omitempty"`
+}
+
+// partialAllocationError is returned when some of the nodes of an allocation pass could not be allocated
+type partialAllocationError struct {
+	policy    PartialAllocationPolicy
+	allocated int
+	requested int
+	failures  []allocationOutcome
+}
+
+func (e *partialAllocationError) Error() string {
+	failures := make([]string, 0, len(e.failures))
+	for _, outcome := range e.failures {
+		failures = append(failures, fmt.Sprintf("%s: %s", outcome.BMH, outcome.Error))
+	}
+
+	action := "keeping allocated nodes"
+	switch e.policy {
+	case PartialAllocationFailAndRollback:
+		action = "rolled back all nodes"
+	case PartialAllocationKeepAndRetry:
+		action = "retrying failed nodes"
+	}
+	return fmt.Sprintf("allocated %d of %d nodes, %s; failed to allocate %d: %s",
+		e.allocated, e.requested, action, len(e.failures), strings.Join(failures, "; "))
+}
+
+// isAllocationRetry returns true if the error is a partial allocation to be retried
+func isAllocationRetry(err error) bool {
+	var partialErr *partialAllocationError
+	return errors.As(err, &partialErr) && partialErr.policy == PartialAllocationKeepAndRetry
+}
+
+// newPartialAllocationError builds the error reporting the failed outcomes, sorted by BMH name
+func newPartialAllocationError(policy PartialAllocationPolicy, nodepool *hwmgmtv1alpha1.NodePool, sizes map[string]int,
+	outcomes []allocationOutcome) *partialAllocationError {
+	partialErr := &partialAllocationError{
+		policy:    policy,
+		allocated: len(nodepool.Status.Properties.NodeNames),
+	}
+	for _, size := range sizes {
+		partialErr.requested += size
+	}
+	for _, outcome := range outcomes {
+		if !outcome.Allocated {
+			partialErr.failures = append(partialErr.failures, outcome)
+		}
+	}
+	sort.Slice(partialErr.failures, func(i, j int) bool { return partialErr.failures[i].BMH < partialErr.failures[j].BMH })
+	return partialErr
+}
+
+// recordAllocationOutcomes records the outcomes of the allocation pass on the NodePool
+func (a *Adaptor) recordAllocationOutcomes(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, outcomes []allocationOutcome) error {
+	sort.Slice(outcomes, func(i, j int) bool { return outcomes[i].BMH < outcomes[j].BMH })
+	data, err := json.Marshal(outcomes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal allocation outcomes: %w", err)
+	}
+
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	if nodepool.Annotations == nil {
+		nodepool.Annotations = make(map[string]string)
+	}
+	nodepool.Annotations[AllocationOutcomesAnnotation] = string(data)
+	if err := a.Client.Patch(ctx, nodepool, patch); err != nil {
+		return fmt.Errorf("failed to record allocation outcomes on NodePool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
+
+// rollbackNodePoolAllocation releases the BMHs of all nodes of the NodePool, including those of failed allocations,
+// and deletes the nodes
+func (a *Adaptor) rollbackNodePoolAllocation(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) error {
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
+	if err != nil {
+		return fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	for _, node := range nodelist.Items {
+		bmh, err := a.getBMHForNode(ctx, &node)
+		if err != nil {
+			return fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
+		}
+		if err := a.releaseBMH(ctx, bmh); err != nil {
+			return err
+		}
+		if err := a.Client.Delete(ctx, &node); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete node %s: %w", node.Name, err)
+		}
+		a.Logger.InfoContext(ctx, "Rolled back node allocation", slog.String("node", node.Name), slog.String("bmh", bmh.Name))
+	}
+
+	nodepool.Status.Properties.NodeNames = nil
+	if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
+		return fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestGetPartialAllocationPolicy(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected PartialAllocationPolicy
+		invalid  bool
+	}{
+		{value: "", expected: PartialAllocationKeepAndReport},
+		{value: "fail-all-and-rollback", expected: PartialAllocationFailAndRollback},
+		{value: "keep-partial-and-retry", expected: PartialAllocationKeepAndRetry},
+		{value: "keep-partial-and-report", expected: PartialAllocationKeepAndReport},
+		{value: "best-effort", invalid: true},
+	}
+
+	for _, tt := range tests {
+		nodepool := &hwmgmtv1alpha1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PartialAllocationPolicyAnnotation: tt.value}},
+		}
+		policy, err := getPartialAllocationPolicy(nodepool)
+		if tt.invalid {
+			if !typederrors.IsInputError(err) {
+				t.Errorf("%q: expected input error, got %v", tt.value, err)
+			}
+			continue
+		}
+		if err != nil || policy != tt.expected {
+			t.Errorf("%q: expected %s, got %s (%v)", tt.value, tt.expected, policy, err)
+		}
+	}
+}
+
+func TestPartialAllocationError(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		Spec: hwmgmtv1alpha1.NodePoolSpec{NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+			{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}, Size: 3},
+			{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}, Size: 7},
+		}},
+	}
+	var outcomes []allocationOutcome
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("bmh-%d", i)
+		nodepool.Status.Properties.NodeNames = append(nodepool.Status.Properties.NodeNames, name)
+		outcomes = append(outcomes, allocationOutcome{BMH: name, NodeGroup: "worker", Allocated: true})
+	}
+	outcomes = append(outcomes,
+		allocationOutcome{BMH: "bmh-9", NodeGroup: "worker", Error: "bmc unreachable"},
+		allocationOutcome{BMH: "bmh-8", NodeGroup: "worker", Error: "profile not found"})
+	sizes := map[string]int{"controller": 3, "worker": 7}
+
+	partialErr := newPartialAllocationError(PartialAllocationKeepAndRetry, nodepool, sizes, outcomes)
+	expected := "allocated 8 of 10 nodes, retrying failed nodes; failed to allocate 2: bmh-8: profile not found; bmh-9: bmc unreachable"
+	if partialErr.Error() != expected {
+		t.Errorf("unexpected error message: %s", partialErr.Error())
+	}
+	if !isAllocationRetry(fmt.Errorf("wrapped: %w", partialErr)) {
+		t.Errorf("expected keep-partial-and-retry error to be retried")
+	}
+
+	partialErr = newPartialAllocationError(PartialAllocationFailAndRollback, nodepool, sizes, outcomes)
+	if isAllocationRetry(partialErr) {
+		t.Errorf("expected fail-all-and-rollback error not to be retried")
+	}
+}
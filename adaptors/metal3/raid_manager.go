@@ -0,0 +1,127 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// The RAID configuration of a hardware profile is set in the RAID spec of the BMH, which the baremetal-operator applies
+// while preparing the host. As preparing reconfigures the disks of the host, RAID is only configured before the host is
+// provisioned, and the node is only provisioned once the RAID configuration is reported as applied by the BMH.
+
+// raidLevelDisks is the minimum number of physical disks of each hardware RAID level
+var raidLevelDisks = map[string]int{
+	"0":   1,
+	"1":   2,
+	"2":   3,
+	"5":   3,
+	"6":   4,
+	"1+0": 4,
+	"5+0": 6,
+	"6+0": 8,
+}
+
+// validateRAIDSpec checks the RAID levels of the volumes, and that they have enough disks for their level
+func validateRAIDSpec(raid *pluginv1alpha1.RAID) error {
+	for i, volume := range raid.HardwareVolumes {
+		minDisks, exists := raidLevelDisks[volume.Level]
+		if !exists {
+			return typederrors.NewInputError("invalid RAID level %q for volume %d", volume.Level, i)
+		}
+		disks := len(volume.PhysicalDisks)
+		if volume.NumberOfPhysicalDisks != nil {
+			disks = *volume.NumberOfPhysicalDisks
+		}
+		if disks > 0 && disks < minDisks {
+			return typederrors.NewInputError("RAID level %s of volume %d requires at least %d physical disks, got %d",
+				volume.Level, i, minDisks, disks)
+		}
+	}
+	return nil
+}
+
+// convertToRAIDConfig translates the RAID configuration of a hardware profile into the RAID spec of a BMH
+func convertToRAIDConfig(raid *pluginv1alpha1.RAID) *metal3v1alpha1.RAIDConfig {
+	volumes := make([]metal3v1alpha1.HardwareRAIDVolume, 0, len(raid.HardwareVolumes))
+	for _, volume := range raid.HardwareVolumes {
+		volume := volume.DeepCopy()
+		volumes = append(volumes, metal3v1alpha1.HardwareRAIDVolume{
+			Name:                  volume.Name,
+			Level:                 volume.Level,
+			SizeGibibytes:         volume.SizeGibibytes,
+			NumberOfPhysicalDisks: volume.NumberOfPhysicalDisks,
+			Rotational:            volume.Rotational,
+			Controller:            volume.Controller,
+			PhysicalDisks:         volume.PhysicalDisks,
+		})
+	}
+	return &metal3v1alpha1.RAIDConfig{HardwareRAIDVolumes: volumes}
+}
+
+// isRAIDConfigApplied checks whether the BMH reports the RAID configuration as applied by its last preparation
+func isRAIDConfigApplied(bmh *metal3v1alpha1.BareMetalHost, raid *metal3v1alpha1.RAIDConfig) bool {
+	return equality.Semantic.DeepEqual(bmh.Status.Provisioning.RAID, raid)
+}
+
+// raidConfigFailure returns the reason the RAID configuration requested on the BMH failed to be applied, or an empty
+// string if it did not fail
+func raidConfigFailure(bmh *metal3v1alpha1.BareMetalHost) string {
+	if bmh.Spec.RAID == nil || isRAIDConfigApplied(bmh, bmh.Spec.RAID) ||
+		bmh.Status.OperationalStatus != metal3v1alpha1.OperationalStatusError {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", RAIDConfigurationFailed, bmh.Status.ErrorMessage)
+}
+
+// IsRAIDUpdateRequired checks whether the RAID configuration of the hardware profile differs from the one applied to
+// the BMH. The check does not change the BMH: the RAID configuration is requested with setBMHRAIDConfig.
+func (a *Adaptor) IsRAIDUpdateRequired(bmh *metal3v1alpha1.BareMetalHost, raid *pluginv1alpha1.RAID) (bool, error) {
+	if err := validateRAIDSpec(raid); err != nil {
+		return false, err
+	}
+	return !isRAIDConfigApplied(bmh, convertToRAIDConfig(raid)), nil
+}
+
+// setBMHRAIDConfig requests the RAID configuration of the hardware profile in the RAID spec of the BMH
+func (a *Adaptor) setBMHRAIDConfig(ctx context.Context, name types.NamespacedName, raid *pluginv1alpha1.RAID) error {
+	return a.updateBMHRAIDConfig(ctx, name, convertToRAIDConfig(raid))
+}
+
+// clearBMHRAIDConfig removes the RAID spec of the BMH, so that the RAID configuration of a released host is not
+// applied again the next time it is prepared
+func (a *Adaptor) clearBMHRAIDConfig(ctx context.Context, name types.NamespacedName) error {
+	return a.updateBMHRAIDConfig(ctx, name, nil)
+}
+
+// updateBMHRAIDConfig sets the RAID spec of the BMH, removing it if nil
+func (a *Adaptor) updateBMHRAIDConfig(ctx context.Context, name types.NamespacedName, raid *metal3v1alpha1.RAIDConfig) error {
+	// nolint: wrapcheck
+	return retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+		bmh := &metal3v1alpha1.BareMetalHost{}
+		if err := a.Client.Get(ctx, name, bmh); err != nil {
+			return fmt.Errorf("failed to fetch BMH %s/%s: %w", name.Namespace, name.Name, err)
+		}
+		if equality.Semantic.DeepEqual(bmh.Spec.RAID, raid) {
+			return nil
+		}
+		bmh.Spec.RAID = raid
+		if err := a.Client.Update(ctx, bmh); err != nil {
+			return fmt.Errorf("failed to update RAID configuration of BMH %s/%s: %w", name.Namespace, name.Name, err)
+		}
+		return nil
+	})
+}
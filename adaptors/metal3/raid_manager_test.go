@@ -0,0 +1,135 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestValidateRAIDSpec(t *testing.T) {
+	testcases := []struct {
+		name    string
+		volume  pluginv1alpha1.RAIDVolume
+		invalid bool
+	}{
+		{name: "mirror", volume: pluginv1alpha1.RAIDVolume{Level: "1"}},
+		{name: "mirror with disks", volume: pluginv1alpha1.RAIDVolume{Level: "1", PhysicalDisks: []string{"disk-0", "disk-1"}}},
+		{name: "unknown level", volume: pluginv1alpha1.RAIDVolume{Level: "3"}, invalid: true},
+		{name: "too few disks", volume: pluginv1alpha1.RAIDVolume{Level: "5", NumberOfPhysicalDisks: ptr.To(2)}, invalid: true},
+	}
+
+	for _, tc := range testcases {
+		err := validateRAIDSpec(&pluginv1alpha1.RAID{HardwareVolumes: []pluginv1alpha1.RAIDVolume{tc.volume}})
+		if tc.invalid != typederrors.IsInputError(err) {
+			t.Errorf("%s: unexpected result %v", tc.name, err)
+		}
+	}
+}
+
+func TestRAIDConfig(t *testing.T) {
+	raid := &pluginv1alpha1.RAID{HardwareVolumes: []pluginv1alpha1.RAIDVolume{
+		{Name: "root", Level: "1", SizeGibibytes: ptr.To(200), Rotational: ptr.To(false)},
+	}}
+	desired := convertToRAIDConfig(raid)
+	if len(desired.HardwareRAIDVolumes) != 1 || desired.HardwareRAIDVolumes[0].Name != "root" ||
+		*desired.HardwareRAIDVolumes[0].SizeGibibytes != 200 {
+		t.Fatalf("unexpected RAID config: %+v", desired)
+	}
+	if desired.HardwareRAIDVolumes[0].SizeGibibytes == raid.HardwareVolumes[0].SizeGibibytes {
+		t.Errorf("expected the RAID config not to share the profile fields")
+	}
+
+	bmh := &metal3v1alpha1.BareMetalHost{}
+	if isRAIDConfigApplied(bmh, desired) {
+		t.Errorf("expected the RAID config not to be applied")
+	}
+	bmh.Spec.RAID = desired
+	bmh.Status.OperationalStatus = metal3v1alpha1.OperationalStatusError
+	bmh.Status.ErrorMessage = "controller RAID.Integrated.1-1 does not support level 1"
+	if failure := raidConfigFailure(bmh); !strings.HasPrefix(failure, RAIDConfigurationFailed) {
+		t.Errorf("expected a RAID failure, got %q", failure)
+	}
+
+	bmh.Status.Provisioning.RAID = convertToRAIDConfig(raid)
+	if !isRAIDConfigApplied(bmh, desired) {
+		t.Errorf("expected the RAID config to be applied")
+	}
+	if failure := raidConfigFailure(bmh); failure != "" {
+		t.Errorf("expected no RAID failure once applied, got %q", failure)
+	}
+}
+
+// bmhClient serves and updates a single BMH
+type bmhClient struct {
+	client.Client
+	bmh     *metal3v1alpha1.BareMetalHost
+	updates int
+}
+
+func (c *bmhClient) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	c.bmh.DeepCopyInto(obj.(*metal3v1alpha1.BareMetalHost))
+	return nil
+}
+
+func (c *bmhClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.updates++
+	c.bmh = obj.(*metal3v1alpha1.BareMetalHost).DeepCopy()
+	return nil
+}
+
+func TestRAIDUpdate(t *testing.T) {
+	ctx := context.Background()
+	raid := &pluginv1alpha1.RAID{HardwareVolumes: []pluginv1alpha1.RAIDVolume{{Name: "root", Level: "1"}}}
+	bmh := &metal3v1alpha1.BareMetalHost{ObjectMeta: metav1.ObjectMeta{Name: "bmh-0", Namespace: "edge"}}
+	c := &bmhClient{bmh: bmh.DeepCopy()}
+	a := &Adaptor{Client: c}
+
+	// The check does not change the BMH
+	required, err := a.IsRAIDUpdateRequired(bmh, raid)
+	if err != nil || !required {
+		t.Fatalf("expected a RAID update to be required, got %v, %v", required, err)
+	}
+	if c.updates != 0 {
+		t.Errorf("expected the check not to update the BMH, got %d updates", c.updates)
+	}
+	if _, err := a.IsRAIDUpdateRequired(bmh, &pluginv1alpha1.RAID{HardwareVolumes: []pluginv1alpha1.RAIDVolume{{Level: "3"}}}); !typederrors.IsInputError(err) {
+		t.Errorf("expected an invalid RAID spec to be rejected, got %v", err)
+	}
+
+	name := client.ObjectKeyFromObject(bmh)
+	if err := a.setBMHRAIDConfig(ctx, name, raid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.setBMHRAIDConfig(ctx, name, raid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.updates != 1 || !equality.Semantic.DeepEqual(c.bmh.Spec.RAID, convertToRAIDConfig(raid)) {
+		t.Errorf("expected the RAID spec to be set once, got %d updates, %+v", c.updates, c.bmh.Spec.RAID)
+	}
+
+	// The RAID spec is removed on release
+	if err := a.clearBMHRAIDConfig(ctx, name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.updates != 2 || c.bmh.Spec.RAID != nil {
+		t.Errorf("expected the RAID spec to be removed, got %+v", c.bmh.Spec.RAID)
+	}
+	if err := a.clearBMHRAIDConfig(ctx, name); err != nil || c.updates != 2 {
+		t.Errorf("expected no update without a RAID spec, got %d updates, %v", c.updates, err)
+	}
+}
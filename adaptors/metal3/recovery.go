@@ -0,0 +1,152 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// getFailureRecovery returns the failure recovery configuration of the HardwareManager, if any
+func getFailureRecovery(hwmgr *pluginv1alpha1.HardwareManager) *pluginv1alpha1.FailureRecovery {
+	if hwmgr.Spec.Metal3Data == nil {
+		return nil
+	}
+	return hwmgr.Spec.Metal3Data.FailureRecovery
+}
+
+// HandleNodePoolFailed handles a NodePool that failed provisioning. Processing resumes when a retry is requested via
+// annotation or, if the recovery probe is enabled, once the failure cause has cleared.
+func (a *Adaptor) HandleNodePoolFailed(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+
+	if _, exists := nodepool.GetAnnotations()[utils.RetryAnnotation]; exists {
+		a.Logger.InfoContext(ctx, "Retry requested for failed NodePool")
+		return a.resumeFailedNodePool(ctx, nodepool, "Retrying after failure")
+	}
+
+	recovery := getFailureRecovery(hwmgr)
+	if recovery == nil || recovery.ProbeInterval == nil || recovery.ProbeInterval.Duration <= 0 {
+		// Recovery probe is disabled, so wait for an explicit retry
+		return utils.DoNotRequeue(), nil
+	}
+	interval := recovery.ProbeInterval.Duration
+
+	cleared, reason, err := a.probeNodePoolRecovery(ctx, hwmgr, nodepool)
+	if err != nil {
+		a.Logger.InfoContext(ctx, "NodePool recovery probe failed", slog.String("error", err.Error()))
+		return utils.RequeueWithCustomInterval(interval), nil
+	}
+	if !cleared {
+		a.Logger.InfoContext(ctx, "NodePool failure cause has not cleared", slog.String("reason", reason))
+		return utils.RequeueWithCustomInterval(interval), nil
+	}
+
+	if recovery.RequireRetryAnnotation {
+		a.Logger.InfoContext(ctx, "NodePool failure cause has cleared, awaiting retry annotation",
+			slog.String("annotation", utils.RetryAnnotation))
+		return utils.RequeueWithCustomInterval(interval), nil
+	}
+
+	a.Logger.InfoContext(ctx, "NodePool failure cause has cleared, resuming processing")
+	return a.resumeFailedNodePool(ctx, nodepool, "Recovered from failure")
+}
+
+// resumeFailedNodePool clears the retry annotation and moves the NodePool back to the in-progress state
+func (a *Adaptor) resumeFailedNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, message string) (ctrl.Result, error) {
+	if _, exists := nodepool.GetAnnotations()[utils.RetryAnnotation]; exists {
+		patch := client.MergeFrom(nodepool.DeepCopy())
+		delete(nodepool.Annotations, utils.RetryAnnotation)
+		if err := a.Client.Patch(ctx, nodepool, patch); err != nil {
+			return utils.RequeueWithShortInterval(),
+				fmt.Errorf("failed to remove retry annotation from NodePool %s: %w", nodepool.Name, err)
+		}
+	}
+
+	if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+		hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse, message); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+
+	return utils.RequeueImmediately(), nil
+}
+
+// probeNodePoolRecovery checks whether the NodePool could now be fully allocated, returning the reason if not
+func (a *Adaptor) probeNodePoolRecovery(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (bool, string, error) {
+	placementPolicies, err := getPlacementPolicies(nodepool)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+
+	bmhNamespace, err := a.getNodePoolBMHNamespace(ctx, hwmgr, nodepool)
+	if err != nil {
+		return false, "", fmt.Errorf("unable to determine BMH namespace for pool %s: %w", nodepool.Name, err)
+	}
+
+	sizes, err := utils.GetEffectiveNodeGroupSizes(nodepool)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		if sizes[nodeGroup.NodePoolData.Name] == 0 {
+			continue
+		}
+
+		if _, _, err := utils.ResolveHardwareProfile(ctx, a.Client, nodeGroup.NodePoolData.HwProfile, a.Namespace); err != nil {
+			return false, fmt.Sprintf("unable to get HardwareProfile %s for nodegroup=%s: %s",
+				nodeGroup.NodePoolData.HwProfile, nodeGroup.NodePoolData.Name, err.Error()), nil
+		}
+
+		pendingNodes := sizes[nodeGroup.NodePoolData.Name] - a.countNodesInGroup(ctx, nodepool.Status.Properties.NodeNames, nodeGroup.NodePoolData.Name)
+		if pendingNodes <= 0 {
+			continue
+		}
+
+		unallocatedBMHs, err := a.FetchBMHList(ctx, hwmgr, nodepool.Spec.Site, nodeGroup.NodePoolData, UnallocatedBMHs, bmhNamespace,
+			adoptExternallyProvisioned(hwmgr))
+		if err != nil {
+			return false, "", fmt.Errorf("unable to fetch unallocated BMHs for nodegroup=%s: %w", nodeGroup.NodePoolData.Name, err)
+		}
+		arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodeGroup.NodePoolData.Name)
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		candidates := filterBMHsByCPUArchitecture(unallocatedBMHs.Items, arch)
+		if len(candidates) < pendingNodes {
+			return false, fmt.Sprintf("not enough free resources matching nodegroup=%s criteria: freenodes=%d, required=%d",
+				nodeGroup.NodePoolData.Name, len(candidates), pendingNodes), nil
+		}
+
+		if policy, exists := placementPolicies[nodeGroup.NodePoolData.Name]; exists {
+			label := spreadTopologyLabel(policy.SpreadBy)
+			usedDomains, err := a.getGroupDomains(ctx, nodepool, nodeGroup.NodePoolData.Name, label)
+			if err != nil {
+				return false, "", fmt.Errorf("unable to determine %s of nodegroup=%s: %w", label, nodeGroup.NodePoolData.Name, err)
+			}
+			if _, err := selectBMHsForPlacement(candidates, label, usedDomains, policy.Mode, pendingNodes); err != nil {
+				return false, fmt.Sprintf("unable to place nodegroup=%s by %s (%s): %s",
+					nodeGroup.NodePoolData.Name, policy.SpreadBy, policy.Mode, err.Error()), nil
+			}
+		}
+	}
+
+	return true, "", nil
+}
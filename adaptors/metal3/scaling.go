@@ -0,0 +1,163 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// selectScaleInNodes returns the nodes to release from the node groups that have more nodes than their effective
+// size, starting with the most recently allocated nodes of each group
+func selectScaleInNodes(nodepool *hwmgmtv1alpha1.NodePool, nodes []hwmgmtv1alpha1.Node, sizes map[string]int) []hwmgmtv1alpha1.Node {
+	// Nodes missing from the NodePool properties were allocated last, by an interrupted allocation
+	order := func(node *hwmgmtv1alpha1.Node) int {
+		if index := slices.Index(nodepool.Status.Properties.NodeNames, node.Name); index >= 0 {
+			return index
+		}
+		return len(nodepool.Status.Properties.NodeNames)
+	}
+
+	sorted := slices.Clone(nodes)
+	sort.SliceStable(sorted, func(i, j int) bool { return order(&sorted[i]) > order(&sorted[j]) })
+
+	allocated := make(map[string]int)
+	for _, node := range sorted {
+		allocated[node.Spec.GroupName]++
+	}
+
+	var surplus []hwmgmtv1alpha1.Node
+	for _, node := range sorted {
+		group := node.Spec.GroupName
+		if size, exists := sizes[group]; exists && allocated[group] > size {
+			surplus = append(surplus, node)
+			allocated[group]--
+		}
+	}
+	return surplus
+}
+
+// scaleOutMessage describes the node groups with fewer nodes than their effective size
+func scaleOutMessage(nodepool *hwmgmtv1alpha1.NodePool, nodes []hwmgmtv1alpha1.Node, sizes map[string]int) string {
+	allocated := make(map[string]int)
+	for _, node := range nodes {
+		allocated[node.Spec.GroupName]++
+	}
+
+	var descriptions []string
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		name := nodeGroup.NodePoolData.Name
+		if allocated[name] < sizes[name] {
+			descriptions = append(descriptions, fmt.Sprintf("%s from %d to %d nodes", name, allocated[name], sizes[name]))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// releaseScaledInNode releases the BMH of a node removed from its node group and deletes the node
+func (a *Adaptor) releaseScaledInNode(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, node *hwmgmtv1alpha1.Node) error {
+	bmh, err := a.getBMHForNode(ctx, node)
+	if err != nil {
+		return fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
+	}
+	if err := a.releaseBMH(ctx, bmh); err != nil {
+		return err
+	}
+	if err := a.Client.Delete(ctx, node); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete node %s: %w", node.Name, err)
+	}
+
+	nodepool.Status.Properties.NodeNames = slices.DeleteFunc(nodepool.Status.Properties.NodeNames,
+		func(name string) bool { return name == node.Name })
+	a.Logger.InfoContext(ctx, "Released node from node group", slog.String("node", node.Name),
+		slog.String("nodegroup", node.Spec.GroupName), slog.String("bmh", bmh.Name))
+	return nil
+}
+
+// HandleNodePoolScaling adjusts a provisioned NodePool to the effective size of its node groups, which an autoscaler
+// can change with the desired size annotation. Surplus nodes are released immediately, while missing nodes, including
+// the replacements of failed nodes, are allocated by returning the NodePool to the provisioning state.
+func (a *Adaptor) HandleNodePoolScaling(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+
+	sizes, err := utils.GetEffectiveNodeGroupSizes(nodepool)
+	if err != nil {
+		// The NodePool remains provisioned at its current size until the sizing is corrected
+		a.Logger.WarnContext(ctx, "Ignoring invalid node group sizing", slog.String("error", err.Error()))
+		return utils.DoNotRequeue(), nil
+	}
+
+	nodelist, err := a.getActiveChildNodes(ctx, nodepool)
+	if err != nil {
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	// Nodes already being deleted no longer count towards the size of their group
+	nodes := slices.DeleteFunc(nodelist.Items, func(node hwmgmtv1alpha1.Node) bool { return !node.DeletionTimestamp.IsZero() })
+
+	// Failed nodes are replaced before the size of their group is checked, so that their replacements are allocated
+	replaced, recheckIn, replaceErr := a.replaceFailedNodes(ctx, hwmgr, nodepool, nodes)
+	if len(replaced) > 0 {
+		nodes = slices.DeleteFunc(nodes, func(node hwmgmtv1alpha1.Node) bool { return slices.Contains(replaced, node.Name) })
+		if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
+			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+	}
+	if replaceErr != nil {
+		return utils.RequeueWithShortInterval(), replaceErr
+	}
+
+	if surplus := selectScaleInNodes(nodepool, nodes, sizes); len(surplus) > 0 {
+		for _, node := range surplus {
+			if err := a.releaseScaledInNode(ctx, nodepool, &node); err != nil {
+				return utils.RequeueWithShortInterval(), err
+			}
+			nodes = slices.DeleteFunc(nodes, func(n hwmgmtv1alpha1.Node) bool { return n.Name == node.Name })
+		}
+		if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
+			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+	}
+
+	if err := utils.UpdateNodePoolEffectiveSizes(ctx, a.Client, nodepool, sizes); err != nil {
+		return utils.RequeueWithShortInterval(), err
+	}
+
+	if message := scaleOutMessage(nodepool, nodes, sizes); message != "" {
+		a.Logger.InfoContext(ctx, "Scaling out node groups", slog.String("nodeGroups", message))
+		status := "Scaling out node groups: " + message
+		if len(replaced) > 0 {
+			status = fmt.Sprintf("Replacing failed nodes %s, scaling out node groups: %s", strings.Join(replaced, ","), message)
+		}
+		if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool, hwmgmtv1alpha1.Provisioned,
+			hwmgmtv1alpha1.InProgress, metav1.ConditionFalse, status); err != nil {
+			return utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+		return utils.RequeueWithShortInterval(), nil
+	}
+
+	if recheckIn > 0 {
+		// Check again once the failed nodes are due for replacement
+		return utils.RequeueWithCustomInterval(recheckIn), nil
+	}
+	return utils.DoNotRequeue(), nil
+}
@@ -0,0 +1,49 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestSelectScaleInNodes(t *testing.T) {
+	newNode := func(name, group string) hwmgmtv1alpha1.Node {
+		return hwmgmtv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       hwmgmtv1alpha1.NodeSpec{GroupName: group},
+		}
+	}
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		Spec: hwmgmtv1alpha1.NodePoolSpec{NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+			{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}, Size: 1},
+			{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}, Size: 3},
+		}},
+	}
+	nodepool.Status.Properties.NodeNames = []string{"c1", "w1", "w2", "w3"}
+	nodes := []hwmgmtv1alpha1.Node{
+		newNode("w2", "worker"), newNode("c1", "controller"), newNode("w1", "worker"),
+		newNode("w3", "worker"), newNode("w4", "worker"),
+	}
+
+	surplus := selectScaleInNodes(nodepool, nodes, map[string]int{"controller": 1, "worker": 2})
+	if len(surplus) != 2 || surplus[0].Name != "w4" || surplus[1].Name != "w3" {
+		t.Errorf("expected the most recently allocated workers to be released, got %v", surplus)
+	}
+
+	if surplus := selectScaleInNodes(nodepool, nodes, map[string]int{"controller": 1, "worker": 6}); len(surplus) != 0 {
+		t.Errorf("expected no nodes to be released, got %v", surplus)
+	}
+
+	message := scaleOutMessage(nodepool, nodes, map[string]int{"controller": 2, "worker": 6})
+	if message != "controller from 1 to 2 nodes, worker from 4 to 6 nodes" {
+		t.Errorf("unexpected scale out message: %s", message)
+	}
+}
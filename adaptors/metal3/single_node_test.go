@@ -0,0 +1,36 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestSingleNodePoolFullyAllocated(t *testing.T) {
+	// The fast path decides from the status of the NodePool alone, so the adaptor needs no clients
+	a := &Adaptor{}
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		Spec: hwmgmtv1alpha1.NodePoolSpec{NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+			{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}, Size: 1},
+			{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}, Size: 0},
+		}},
+	}
+
+	full, err := a.IsNodePoolFullyAllocated(context.Background(), nil, nodepool)
+	if err != nil || full {
+		t.Errorf("expected the pool without nodes not to be fully allocated, got %v (%v)", full, err)
+	}
+
+	nodepool.Status.Properties.NodeNames = []string{"sno-0"}
+	full, err = a.IsNodePoolFullyAllocated(context.Background(), nil, nodepool)
+	if err != nil || !full {
+		t.Errorf("expected the pool with its node to be fully allocated, got %v (%v)", full, err)
+	}
+}
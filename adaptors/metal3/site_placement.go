@@ -0,0 +1,71 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"encoding/json"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// SitePlacementAnnotation is set on a NodePool to constrain the site placement of the nodes in each node group.
+// The value is a JSON map of node group name to placement policy, e.g. {"controller": "spread", "worker": "colocate"}
+const SitePlacementAnnotation = "hwmgr-plugin.oran.openshift.io/site-placement"
+
+// SitePlacementPolicy defines how the nodes of a node group are placed across sites
+type SitePlacementPolicy string
+
+const (
+	// SitePlacementSpread requires each node of the group to come from a different site
+	SitePlacementSpread SitePlacementPolicy = "spread"
+	// SitePlacementColocate requires all nodes of the group to come from the same site
+	SitePlacementColocate SitePlacementPolicy = "colocate"
+)
+
+// getSitePlacementPolicies parses the site placement annotation from the NodePool, returning the placement of each node
+// group it sets across sites: spreading the nodes is a required spread by site, and colocating them places them all in
+// a single site
+func getSitePlacementPolicies(nodepool *hwmgmtv1alpha1.NodePool) (map[string]pluginv1alpha1.SpreadPolicy, error) {
+	policies := make(map[string]pluginv1alpha1.SpreadPolicy)
+
+	value, exists := nodepool.GetAnnotations()[SitePlacementAnnotation]
+	if !exists || value == "" {
+		return policies, nil
+	}
+
+	var sitePolicies map[string]SitePlacementPolicy
+	if err := json.Unmarshal([]byte(value), &sitePolicies); err != nil {
+		return nil, typederrors.NewInputError("unable to parse %s annotation: %s: %s", SitePlacementAnnotation, value, err.Error())
+	}
+
+	groups := make(map[string]bool)
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		groups[nodeGroup.NodePoolData.Name] = true
+	}
+
+	for groupName, policy := range sitePolicies {
+		if !groups[groupName] {
+			return nil, typederrors.NewInputError("%s annotation references unknown nodegroup=%s", SitePlacementAnnotation, groupName)
+		}
+		switch policy {
+		case SitePlacementSpread:
+			if nodepool.Spec.Site != "" {
+				return nil, typederrors.NewInputError("site placement policy %s for nodegroup=%s cannot be used with a fixed site=%s",
+					policy, groupName, nodepool.Spec.Site)
+			}
+			policies[groupName] = pluginv1alpha1.SpreadPolicy{SpreadBy: "site", Mode: pluginv1alpha1.SpreadModeRequired}
+		case SitePlacementColocate:
+			policies[groupName] = pluginv1alpha1.SpreadPolicy{SpreadBy: "site", Mode: placementColocate}
+		default:
+			return nil, typederrors.NewInputError("invalid site placement policy %s for nodegroup=%s", policy, groupName)
+		}
+	}
+
+	return policies, nil
+}
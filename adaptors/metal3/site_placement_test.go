@@ -0,0 +1,141 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func newTestBMH(name, site string) metal3v1alpha1.BareMetalHost {
+	return metal3v1alpha1.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{LabelSiteID: site},
+		},
+	}
+}
+
+func TestSelectBMHsForSitePlacement(t *testing.T) {
+	candidates := []metal3v1alpha1.BareMetalHost{
+		newTestBMH("a1", "site-a"),
+		newTestBMH("a2", "site-a"),
+		newTestBMH("b1", "site-b"),
+		newTestBMH("c1", "site-c"),
+		newTestBMH("c2", "site-c"),
+		newTestBMH("c3", "site-c"),
+	}
+
+	tests := []struct {
+		name      string
+		usedSites map[string]int
+		mode      pluginv1alpha1.SpreadMode
+		count     int
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "spread picks one host per site",
+			mode:     pluginv1alpha1.SpreadModeRequired,
+			count:    3,
+			expected: []string{"a1", "b1", "c1"},
+		},
+		{
+			name:      "spread skips sites already in use",
+			usedSites: map[string]int{"site-a": 1},
+			mode:      pluginv1alpha1.SpreadModeRequired,
+			count:     2,
+			expected:  []string{"b1", "c1"},
+		},
+		{
+			name:      "spread fails with too few sites",
+			mode:      pluginv1alpha1.SpreadModeRequired,
+			count:     4,
+			expectErr: true,
+		},
+		{
+			name:     "colocate picks the site with most free hosts",
+			mode:     placementColocate,
+			count:    3,
+			expected: []string{"c1", "c2", "c3"},
+		},
+		{
+			name:      "colocate sticks to the site in use",
+			usedSites: map[string]int{"site-a": 1},
+			mode:      placementColocate,
+			count:     1,
+			expected:  []string{"a1", "a2"},
+		},
+		{
+			name:      "colocate fails when the site in use is exhausted",
+			usedSites: map[string]int{"site-b": 1},
+			mode:      placementColocate,
+			count:     2,
+			expectErr: true,
+		},
+		{
+			name:      "colocate fails when no site is large enough",
+			mode:      placementColocate,
+			count:     4,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectBMHsForPlacement(candidates, LabelSiteID, tt.usedSites, tt.mode, tt.count)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got selection %v", selected)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("expected %d hosts, got %d", len(tt.expected), len(selected))
+			}
+			for i, bmh := range selected {
+				if bmh.Name != tt.expected[i] {
+					t.Errorf("expected host %s at index %d, got %s", tt.expected[i], i, bmh.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSitePlacementPolicies(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Spec.NodeGroup = []hwmgmtv1alpha1.NodeGroup{
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}},
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}},
+	}
+	nodepool.Annotations = map[string]string{SitePlacementAnnotation: `{"controller": "spread", "worker": "colocate"}`}
+
+	policies, err := getSitePlacementPolicies(nodepool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (pluginv1alpha1.SpreadPolicy{SpreadBy: "site", Mode: pluginv1alpha1.SpreadModeRequired}); policies["controller"] != expected {
+		t.Errorf("expected spread to be a required spread by site, got %+v", policies["controller"])
+	}
+	if expected := (pluginv1alpha1.SpreadPolicy{SpreadBy: "site", Mode: placementColocate}); policies["worker"] != expected {
+		t.Errorf("expected colocate to place the nodes in a single site, got %+v", policies["worker"])
+	}
+
+	nodepool.Spec.Site = "site-a"
+	if _, err := getSitePlacementPolicies(nodepool); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for a spread with a fixed site, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// The placement of the nodes of a node group across the domains of a topology, such as sites or racks, is set either
+// by the site placement annotation or by the spread extensions of the NodePool. Both are resolved to a spread policy,
+// and the candidate BMHs of the group are selected for it by the same mechanism.
+
+// placementColocate is the placement mode of the site placement annotation that places all the nodes of the group in
+// a single domain
+const placementColocate pluginv1alpha1.SpreadMode = "colocate"
+
+// spreadTopologyLabel returns the BMH label holding the domain of a spread topology. The "site" and "rack" topologies
+// map to the resource labels of the BMH, and any other value is used as the label key itself.
+func spreadTopologyLabel(spreadBy string) string {
+	switch spreadBy {
+	case "site", "siteId":
+		return LabelSiteID
+	case "rack":
+		return LabelRack
+	}
+	return spreadBy
+}
+
+// getPlacementPolicies returns the placement policy of each node group of the NodePool that is placed across domains,
+// set either through the site placement annotation or the NodePool extensions, but not both
+func getPlacementPolicies(nodepool *hwmgmtv1alpha1.NodePool) (map[string]pluginv1alpha1.SpreadPolicy, error) {
+	policies, err := getSitePlacementPolicies(nodepool)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions, err := utils.GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		groupName := nodeGroup.NodePoolData.Name
+		policy := extensions.GetSpreadPolicy(groupName)
+		if policy.SpreadBy == "" {
+			continue
+		}
+		if _, exists := policies[groupName]; exists {
+			return nil, typederrors.NewInputError("%s extension for nodegroup=%s cannot be used with the %s annotation",
+				pluginv1alpha1.SpreadByExtensionKey, groupName, SitePlacementAnnotation)
+		}
+		policies[groupName] = policy
+	}
+	return policies, nil
+}
+
+// selectBMHsForPlacement orders the candidate BMHs so that allocating up to count of them, in order, places the nodes
+// across the domains of the topology label as set by the mode, given the number of nodes of the group already in each
+// domain.
+//
+// With the required mode, each node must land in a domain not yet used by the group, so a single BMH is kept per free
+// domain. With the preferred mode, the BMHs are ordered by taking one from the least used domain in turn, so the
+// nodes only share a domain when there are not enough domains, and the BMHs without the label come last. With the
+// colocate mode, the BMHs of the domain already used by the group are kept, or those of the domain with the most BMHs
+// when none is used yet.
+func selectBMHsForPlacement(
+	candidates []metal3v1alpha1.BareMetalHost,
+	label string,
+	usedDomains map[string]int,
+	mode pluginv1alpha1.SpreadMode,
+	count int) ([]metal3v1alpha1.BareMetalHost, error) {
+
+	// Group the candidates by domain, keeping the order of the original list
+	byDomain := make(map[string][]metal3v1alpha1.BareMetalHost)
+	var domains []string
+	var unlabeled []metal3v1alpha1.BareMetalHost
+	for _, bmh := range candidates {
+		domain := bmh.Labels[label]
+		if domain == "" {
+			unlabeled = append(unlabeled, bmh)
+			continue
+		}
+		if _, exists := byDomain[domain]; !exists {
+			domains = append(domains, domain)
+		}
+		byDomain[domain] = append(byDomain[domain], bmh)
+	}
+	sort.Strings(domains)
+
+	switch mode {
+	case pluginv1alpha1.SpreadModeRequired:
+		var selected []metal3v1alpha1.BareMetalHost
+		for _, domain := range domains {
+			if usedDomains[domain] > 0 {
+				continue
+			}
+			selected = append(selected, byDomain[domain][0])
+		}
+		if len(selected) < count {
+			return nil, fmt.Errorf("not enough distinct values of label %s to spread nodes: available=%d, required=%d",
+				label, len(selected), count)
+		}
+		return selected, nil
+
+	case placementColocate:
+		var used []string
+		for domain, nodes := range usedDomains {
+			if nodes > 0 {
+				used = append(used, domain)
+			}
+		}
+		sort.Strings(used)
+		if len(used) > 1 {
+			return nil, fmt.Errorf("nodes already allocated across multiple values of label %s: %v", label, used)
+		}
+		if len(used) == 1 {
+			selected := byDomain[used[0]]
+			if len(selected) < count {
+				return nil, fmt.Errorf("not enough free resources with label %s=%s: freenodes=%d, required=%d",
+					label, used[0], len(selected), count)
+			}
+			return selected, nil
+		}
+		// No nodes allocated yet, so pick the domain with the most free resources
+		best := ""
+		for _, domain := range domains {
+			if best == "" || len(byDomain[domain]) > len(byDomain[best]) {
+				best = domain
+			}
+		}
+		if len(byDomain[best]) < count {
+			return nil, fmt.Errorf("no single value of label %s has enough free resources: freenodes=%d, required=%d",
+				label, len(byDomain[best]), count)
+		}
+		return byDomain[best], nil
+	}
+
+	used := make(map[string]int, len(domains))
+	for _, domain := range domains {
+		used[domain] = usedDomains[domain]
+	}
+	selected := make([]metal3v1alpha1.BareMetalHost, 0, len(candidates))
+	for {
+		// Take the next BMH from the least used domain with free BMHs left, the first by name on a tie
+		best := ""
+		for _, domain := range domains {
+			if len(byDomain[domain]) == 0 {
+				continue
+			}
+			if best == "" || used[domain] < used[best] {
+				best = domain
+			}
+		}
+		if best == "" {
+			break
+		}
+		selected = append(selected, byDomain[best][0])
+		byDomain[best] = byDomain[best][1:]
+		used[best]++
+	}
+	return append(selected, unlabeled...), nil
+}
+
+// placeNodeGroupBMHs orders the candidate BMHs of a node group for its placement policy, given the domains of the BMHs
+// already allocated to the group
+func (a *Adaptor) placeNodeGroupBMHs(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, groupName string,
+	policy pluginv1alpha1.SpreadPolicy, candidates []metal3v1alpha1.BareMetalHost,
+	count int) ([]metal3v1alpha1.BareMetalHost, error) {
+	label := spreadTopologyLabel(policy.SpreadBy)
+	usedDomains, err := a.getGroupDomains(ctx, nodepool, groupName, label)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine %s of nodegroup=%s: %w", label, groupName, err)
+	}
+	selected, err := selectBMHsForPlacement(candidates, label, usedDomains, policy.Mode, count)
+	if err != nil {
+		return nil, fmt.Errorf("unable to place nodegroup=%s by %s (%s): %w", groupName, policy.SpreadBy, policy.Mode, err)
+	}
+	return selected, nil
+}
+
+// getGroupDomains returns the number of BMHs already allocated to the given node group for each value of the given
+// label, leaving out the BMHs without the label
+func (a *Adaptor) getGroupDomains(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, groupName, label string) (map[string]int, error) {
+	domains := make(map[string]int)
+	for _, nodeName := range nodepool.Status.Properties.NodeNames {
+		node, err := utils.GetNode(ctx, a.Logger, a.NoncachedClient, a.Namespace, nodeName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				// The node has been released
+				continue
+			}
+			return nil, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+		}
+		if node.Spec.GroupName != groupName {
+			continue
+		}
+		bmh, err := a.getBMHForNode(ctx, node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get BMH for node %s: %w", node.Name, err)
+		}
+		if domain := bmh.Labels[label]; domain != "" {
+			domains[domain]++
+		}
+	}
+	return domains, nil
+}
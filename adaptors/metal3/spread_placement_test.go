@@ -0,0 +1,164 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metal3
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestRackBMH(name, rack string) metal3v1alpha1.BareMetalHost {
+	bmh := newTestBMH(name, "site-a")
+	if rack != "" {
+		bmh.Labels[LabelRack] = rack
+	}
+	return bmh
+}
+
+func TestSelectBMHsForPlacement(t *testing.T) {
+	candidates := []metal3v1alpha1.BareMetalHost{
+		newTestRackBMH("a1", "rack-a"),
+		newTestRackBMH("a2", "rack-a"),
+		newTestRackBMH("a3", "rack-a"),
+		newTestRackBMH("b1", "rack-b"),
+		newTestRackBMH("c1", "rack-c"),
+		newTestRackBMH("none", ""),
+	}
+
+	tests := []struct {
+		name        string
+		usedDomains map[string]int
+		mode        pluginv1alpha1.SpreadMode
+		count       int
+		expected    []string
+		expectErr   bool
+	}{
+		{
+			name:     "required picks one host per rack",
+			mode:     pluginv1alpha1.SpreadModeRequired,
+			count:    3,
+			expected: []string{"a1", "b1", "c1"},
+		},
+		{
+			name:        "required skips racks already in use",
+			usedDomains: map[string]int{"rack-b": 1},
+			mode:        pluginv1alpha1.SpreadModeRequired,
+			count:       2,
+			expected:    []string{"a1", "c1"},
+		},
+		{
+			name:      "required fails with too few racks",
+			mode:      pluginv1alpha1.SpreadModeRequired,
+			count:     4,
+			expectErr: true,
+		},
+		{
+			name:     "preferred balances hosts across racks",
+			mode:     pluginv1alpha1.SpreadModePreferred,
+			count:    5,
+			expected: []string{"a1", "b1", "c1", "a2", "a3", "none"},
+		},
+		{
+			name:        "preferred starts with the least used racks",
+			usedDomains: map[string]int{"rack-a": 1, "rack-b": 2},
+			mode:        pluginv1alpha1.SpreadModePreferred,
+			count:       2,
+			expected:    []string{"c1", "a1", "a2", "b1", "a3", "none"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectBMHsForPlacement(candidates, spreadTopologyLabel("rack"), tt.usedDomains, tt.mode, tt.count)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got selection %v", selected)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("expected %d hosts, got %d", len(tt.expected), len(selected))
+			}
+			for i, bmh := range selected {
+				if bmh.Name != tt.expected[i] {
+					t.Errorf("expected host %s at index %d, got %s", tt.expected[i], i, bmh.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPlacementPolicies(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "np"},
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+				{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}},
+				{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}},
+			},
+			Extensions: map[string]string{
+				"controller." + pluginv1alpha1.SpreadByExtensionKey: "rack",
+			},
+		},
+	}
+
+	policies, err := getPlacementPolicies(nodepool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := pluginv1alpha1.SpreadPolicy{SpreadBy: "rack", Mode: pluginv1alpha1.SpreadModePreferred}
+	if len(policies) != 1 || policies["controller"] != expected {
+		t.Errorf("unexpected placement policies: %+v", policies)
+	}
+
+	nodepool.Annotations = map[string]string{SitePlacementAnnotation: `{"controller": "spread"}`}
+	if _, err := getPlacementPolicies(nodepool); !typederrors.IsInputError(err) {
+		t.Errorf("expected input error when combined with site placement, got %v", err)
+	}
+}
+
+// failingNodeReader fails every lookup with the given error
+type failingNodeReader struct {
+	client.Reader
+	err error
+}
+
+func (r *failingNodeReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.err
+}
+
+func TestGetGroupDomainsLookupErrors(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Status.Properties.NodeNames = []string{"node-1"}
+	resource := schema.GroupResource{Group: hwmgmtv1alpha1.GroupVersion.Group, Resource: "nodes"}
+
+	a := &Adaptor{Logger: slog.Default(), Namespace: "hwmgr",
+		NoncachedClient: &failingNodeReader{err: errors.NewForbidden(resource, "node-1", nil)}}
+	if _, err := a.getGroupDomains(context.Background(), nodepool, "worker", LabelRack); err == nil {
+		t.Errorf("expected the failed node lookup to be returned")
+	}
+
+	// A released node no longer counts towards the domains of its group
+	a.NoncachedClient = &failingNodeReader{err: errors.NewNotFound(resource, "node-1")}
+	domains, err := a.getGroupDomains(context.Background(), nodepool, "worker", LabelRack)
+	if err != nil || len(domains) != 0 {
+		t.Errorf("expected no domains for a released node, got %v, %v", domains, err)
+	}
+}
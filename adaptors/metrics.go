@@ -0,0 +1,94 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+var allocationDiscrepancies = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "hwmgr_plugin_allocation_discrepancies",
+	Help: "Number of persisting discrepancies between the Nodes of a hardware manager and the hardware allocated in its backend",
+}, []string{"hwmgr", "type"})
+
+// lifecycleBuckets spans the durations of the NodePool lifecycle stages, from 30 seconds to about 8.5 hours
+var lifecycleBuckets = prometheus.ExponentialBuckets(30, 2, 11)
+
+var nodePoolProvisioningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "hwmgr_plugin_nodepool_provisioning_duration_seconds",
+	Help:    "Time from the creation of a NodePool to its Provisioned condition becoming true, by NodePool size",
+	Buckets: lifecycleBuckets,
+}, []string{"hwmgr", "size"})
+
+var nodePoolConfigurationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "hwmgr_plugin_nodepool_configuration_duration_seconds",
+	Help:    "Time from the start of a day-2 configuration of a NodePool to its Configured condition becoming true",
+	Buckets: lifecycleBuckets,
+}, []string{"hwmgr"})
+
+var nodePoolReleaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "hwmgr_plugin_nodepool_release_duration_seconds",
+	Help:    "Time from the deletion request of a NodePool to the release of its hardware",
+	Buckets: lifecycleBuckets,
+}, []string{"hwmgr"})
+
+var nodePoolProvisioningFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "hwmgr_plugin_nodepool_provisioning_failures_total",
+	Help: "Number of NodePools whose provisioning failed or timed out, by reason",
+}, []string{"hwmgr", "reason"})
+
+var nodePoolOldestOperationAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "hwmgr_plugin_nodepool_oldest_operation_age_seconds",
+	Help: "Age of the oldest NodePool operation in progress, by operation",
+}, []string{"hwmgr", "operation"})
+
+var discrepancyTypes = []invserver.AllocationDiscrepancyType{
+	invserver.BackendAllocationWithoutNode,
+	invserver.NodeWithoutBackendAllocation,
+}
+
+func init() {
+	metrics.Registry.MustRegister(allocationDiscrepancies)
+	metrics.Registry.MustRegister(nodePoolProvisioningDuration)
+	metrics.Registry.MustRegister(nodePoolConfigurationDuration)
+	metrics.Registry.MustRegister(nodePoolReleaseDuration)
+	metrics.Registry.MustRegister(nodePoolProvisioningFailures)
+	metrics.Registry.MustRegister(nodePoolOldestOperationAge)
+}
+
+// recordAllocationDiscrepancies sets the discrepancy metric of a hardware manager, by type of discrepancy
+func recordAllocationDiscrepancies(hwmgr string, discrepancies []invserver.AllocationDiscrepancy) {
+	counts := make(map[invserver.AllocationDiscrepancyType]int, len(discrepancyTypes))
+	for _, discrepancy := range discrepancies {
+		counts[discrepancy.Type]++
+	}
+	for _, discrepancyType := range discrepancyTypes {
+		allocationDiscrepancies.WithLabelValues(hwmgr, string(discrepancyType)).Set(float64(counts[discrepancyType]))
+	}
+}
+
+// forgetAllocationDiscrepancies removes the discrepancy metric of a hardware manager that no longer exists
+func forgetAllocationDiscrepancies(hwmgr string) {
+	for _, discrepancyType := range discrepancyTypes {
+		allocationDiscrepancies.DeleteLabelValues(hwmgr, string(discrepancyType))
+	}
+}
+
+// observeLifecycleDuration records a NodePool lifecycle duration, with the trace ID of the NodePool as an exemplar
+// when it has one
+func observeLifecycleDuration(observer prometheus.Observer, duration time.Duration, traceId string) {
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && traceId != "" {
+		exemplarObserver.ObserveWithExemplar(duration.Seconds(), prometheus.Labels{"trace_id": traceId})
+		return
+	}
+	observer.Observe(duration.Seconds())
+}
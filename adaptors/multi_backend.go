@@ -0,0 +1,355 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// memberStatus is the status of a NodePool spanning several HardwareManagers, aggregated from that of its members
+type memberStatus struct {
+	// failed is the first member that failed to allocate its node groups, if any
+	failed *hwmgmtv1alpha1.NodePool
+	// provisioned are the HardwareManagers whose member has allocated its node groups
+	provisioned []string
+	// nodeNames are the nodes allocated to the members
+	nodeNames []string
+	// configured is the Configured condition aggregated from the members, or nil if none is configured yet
+	configured *metav1.Condition
+	// observed is set once each member has processed its current generation
+	observed bool
+}
+
+// aggregateMemberStatus aggregates the status of the members of a NodePool. A failed member determines the status,
+// followed by one in progress.
+func aggregateMemberStatus(members []hwmgmtv1alpha1.NodePool) memberStatus {
+	status := memberStatus{observed: true}
+	for i := range members {
+		member := &members[i]
+		status.nodeNames = append(status.nodeNames, member.Status.Properties.NodeNames...)
+		if member.Status.HwMgrPlugin.ObservedGeneration != member.Generation {
+			status.observed = false
+		}
+
+		switch {
+		case utils.IsNodePoolProvisionedCompleted(member):
+			status.provisioned = append(status.provisioned, member.Spec.HwMgrId)
+		case utils.IsNodePoolProvisionedFailed(member) && status.failed == nil:
+			status.failed = member
+		}
+
+		configured := meta.FindStatusCondition(member.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+		if configured == nil || !configuredTakesPrecedence(configured, status.configured) {
+			continue
+		}
+		status.configured = &metav1.Condition{
+			Type:    configured.Type,
+			Status:  configured.Status,
+			Reason:  configured.Reason,
+			Message: configured.Message,
+		}
+		if configured.Status != metav1.ConditionTrue {
+			status.configured.Message = fmt.Sprintf("Hardware manager %s: %s", member.Spec.HwMgrId, configured.Message)
+		}
+	}
+	return status
+}
+
+// configuredTakesPrecedence checks whether the Configured condition of a member takes precedence over the one
+// aggregated so far: a failure over one in progress, and one in progress over a success
+func configuredTakesPrecedence(condition, current *metav1.Condition) bool {
+	rank := func(condition *metav1.Condition) int {
+		switch {
+		case condition == nil:
+			return 0
+		case condition.Reason == string(hwmgmtv1alpha1.Failed):
+			return 3
+		case condition.Status != metav1.ConditionTrue:
+			return 2
+		}
+		return 1
+	}
+	return rank(condition) > rank(current)
+}
+
+// handleMultiBackendNodePool processes a NodePool whose node groups are allocated from several HardwareManagers,
+// through a member NodePool for each, and aggregates the status of the members into that of the NodePool. The
+// allocation is all or nothing: if a HardwareManager fails to allocate its node groups before the NodePool is
+// provisioned, all the members are deleted, releasing what the other HardwareManagers allocated, and the NodePool
+// remains failed until its spec is changed.
+func (c *HwMgrAdaptorController) handleMultiBackendNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	backends map[string][]string) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(nodepool, utils.NodepoolFinalizer) {
+		c.Logger.InfoContext(ctx, "Adding finalizer to NodePool")
+		if err := utils.NodepoolAddFinalizer(ctx, c.Client, nodepool); err != nil {
+			return utils.RequeueImmediately(), fmt.Errorf("failed to add finalizer to nodepool: %w", err)
+		}
+	}
+
+	if utils.IsRolledBack(nodepool) {
+		remaining, err := c.deleteMemberNodePools(ctx, nodepool, nil)
+		if err != nil || remaining > 0 {
+			return utils.RequeueWithShortInterval(), err
+		}
+		return utils.DoNotRequeue(), nil
+	}
+
+	// Each HardwareManager is checked before allocating from any of them
+	hwMgrIds := utils.SortedBackends(backends)
+	for _, hwMgrId := range hwMgrIds {
+		hwmgr, _, err := c.getHwMgr(ctx, hwMgrId)
+		if err != nil {
+			c.Logger.ErrorContext(ctx, "failed to get adaptor instance", slog.String("hwmgr", hwMgrId), slog.String("error", err.Error()))
+			return c.failMultiBackendNodePool(ctx, nodepool, "Unable to find HardwareManager instance: "+hwMgrId)
+		}
+		if _, exists := c.adaptors[string(hwmgr.Spec.AdaptorID)]; !exists {
+			return c.failMultiBackendNodePool(ctx, nodepool,
+				fmt.Sprintf("Unsupported adaptor ID specified by HardwareManager %s: %s", hwMgrId, hwmgr.Spec.AdaptorID))
+		}
+		if _, err := c.getMemberNodePool(ctx, nodepool, hwMgrId); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return c.failMultiBackendNodePool(ctx, nodepool, err.Error())
+			}
+			return utils.RequeueWithShortInterval(), err
+		}
+	}
+
+	members := make([]hwmgmtv1alpha1.NodePool, 0, len(hwMgrIds))
+	for _, hwMgrId := range hwMgrIds {
+		member, err := c.applyMemberNodePool(ctx, nodepool, hwMgrId, backends[hwMgrId])
+		if err != nil {
+			return utils.RequeueWithShortInterval(), err
+		}
+		members = append(members, *member)
+	}
+
+	// Members of HardwareManagers no longer used by any node group are released
+	if _, err := c.deleteMemberNodePools(ctx, nodepool, func(member *hwmgmtv1alpha1.NodePool) bool {
+		_, used := backends[member.Spec.HwMgrId]
+		return used
+	}); err != nil {
+		return utils.RequeueWithShortInterval(), err
+	}
+
+	status := aggregateMemberStatus(members)
+	if !utils.IsNodePoolProvisionedCompleted(nodepool) {
+		if status.failed != nil {
+			return c.rollbackMultiBackendNodePool(ctx, nodepool, status.failed, hwMgrIds)
+		}
+		if len(status.provisioned) < len(members) {
+			message := fmt.Sprintf("Allocated from %d of %d hardware managers", len(status.provisioned), len(members))
+			if err := utils.UpdateNodePoolStatusCondition(ctx, c.Client, nodepool,
+				hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse, message); err != nil {
+				return utils.RequeueWithMediumInterval(),
+					fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+			}
+			return utils.RequeueWithMediumInterval(), nil
+		}
+	}
+
+	nodepool.Status.Properties.NodeNames = status.nodeNames
+	if err := utils.UpdateNodePoolProperties(ctx, c.Client, nodepool); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	if err := utils.UpdateNodePoolStatusCondition(ctx, c.Client, nodepool,
+		hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Completed, metav1.ConditionTrue,
+		"Created from hardware managers "+strings.Join(hwMgrIds, ", ")); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+
+	if configured := status.configured; configured != nil {
+		if err := utils.UpdateNodePoolStatusCondition(ctx, c.Client, nodepool,
+			hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.ConditionReason(configured.Reason), configured.Status,
+			configured.Message); err != nil {
+			return utils.RequeueWithMediumInterval(),
+				fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+		}
+	}
+
+	if status.observed && (status.configured == nil || status.configured.Status == metav1.ConditionTrue) {
+		if err := utils.UpdateNodePoolPluginStatus(ctx, c.Client, nodepool); err != nil {
+			return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update hwMgrPlugin observedGeneration Status: %w", err)
+		}
+	}
+
+	// The NodePool is requeued as its members change
+	return utils.DoNotRequeue(), nil
+}
+
+// failMultiBackendNodePool fails the provisioning of the NodePool, before allocating from any HardwareManager. Once
+// the NodePool is provisioned, the failure is only retried later, leaving its allocations in place.
+func (c *HwMgrAdaptorController) failMultiBackendNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	message string) (ctrl.Result, error) {
+	if utils.IsNodePoolProvisionedCompleted(nodepool) {
+		c.Logger.WarnContext(ctx, "Unable to process provisioned NodePool", slog.String("reason", message))
+		return utils.RequeueWithLongInterval(), nil
+	}
+	if err := utils.UpdateNodePoolStatusCondition(ctx, c.Client, nodepool,
+		hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse, message); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	return utils.DoNotRequeue(), nil
+}
+
+// rollbackMultiBackendNodePool fails the provisioning of the NodePool after a HardwareManager failed to allocate its
+// node groups, and deletes all its members so that the allocations from the other HardwareManagers are released
+func (c *HwMgrAdaptorController) rollbackMultiBackendNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	failed *hwmgmtv1alpha1.NodePool, hwMgrIds []string) (ctrl.Result, error) {
+	var others []string
+	for _, hwMgrId := range hwMgrIds {
+		if hwMgrId != failed.Spec.HwMgrId {
+			others = append(others, hwMgrId)
+		}
+	}
+
+	reason := "unknown"
+	if condition := utils.GetNodePoolProvisionedCondition(failed); condition != nil {
+		reason = condition.Message
+	}
+	c.Logger.WarnContext(ctx, "Allocation from a hardware manager failed, rolling back NodePool",
+		slog.String("hwmgr", failed.Spec.HwMgrId), slog.String("reason", reason), slog.Any("rolledBack", others))
+
+	if err := utils.UpdateNodePoolStatusCondition(ctx, c.Client, nodepool,
+		hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
+		fmt.Sprintf("Allocation from hardware manager %s failed: %s. Rolled back the allocations from: %s",
+			failed.Spec.HwMgrId, reason, strings.Join(others, ", "))); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	utils.SetRolledBack(nodepool)
+	if err := c.Client.Patch(ctx, nodepool, patch); err != nil {
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to record rollback of nodepool %s: %w", nodepool.Name, err)
+	}
+
+	if _, err := c.deleteMemberNodePools(ctx, nodepool, nil); err != nil {
+		return utils.RequeueWithShortInterval(), err
+	}
+	return utils.RequeueWithShortInterval(), nil
+}
+
+// applyMemberNodePool creates the member NodePool allocating the node groups from the HardwareManager, or updates its
+// spec to that of the NodePool, and returns it
+func (c *HwMgrAdaptorController) applyMemberNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	hwMgrId string, groups []string) (*hwmgmtv1alpha1.NodePool, error) {
+	desired, err := utils.NewMemberNodePool(nodepool, hwMgrId, groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build member of nodepool %s for %s: %w", nodepool.Name, hwMgrId, err)
+	}
+
+	member, err := c.getMemberNodePool(ctx, nodepool, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		if err := controllerutil.SetControllerReference(nodepool, desired, c.Scheme); err != nil {
+			return nil, fmt.Errorf("failed to set owner of member nodepool %s: %w", desired.Name, err)
+		}
+		c.Logger.InfoContext(ctx, "Creating member NodePool", slog.String("member", desired.Name),
+			slog.String("hwmgr", hwMgrId), slog.Any("nodeGroups", groups))
+		if err := c.Client.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("failed to create member nodepool %s: %w", desired.Name, err)
+		}
+		return desired, nil
+	}
+
+	if !equality.Semantic.DeepEqual(member.Spec, desired.Spec) {
+		patch := client.MergeFrom(member.DeepCopy())
+		member.Spec = desired.Spec
+		if err := c.Client.Patch(ctx, member, patch); err != nil {
+			return nil, fmt.Errorf("failed to update member nodepool %s: %w", member.Name, err)
+		}
+	}
+	return member, nil
+}
+
+// getMemberNodePool returns the member NodePool of the HardwareManager, or nil if it does not exist. A NodePool of the
+// same name that is not controlled by the NodePool is not adopted, and an AlreadyExists error is returned for it.
+func (c *HwMgrAdaptorController) getMemberNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	hwMgrId string) (*hwmgmtv1alpha1.NodePool, error) {
+	name := utils.MemberNodePoolName(nodepool, hwMgrId)
+	member := &hwmgmtv1alpha1.NodePool{}
+	if err := c.Client.Get(ctx, client.ObjectKey{Namespace: nodepool.Namespace, Name: name}, member); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get member nodepool %s: %w", name, err)
+	}
+	if !metav1.IsControlledBy(member, nodepool) {
+		return nil, errors.NewAlreadyExists(hwmgmtv1alpha1.GroupVersion.WithResource("nodepools").GroupResource(), name)
+	}
+	return member, nil
+}
+
+// releaseMemberNodePools deletes the members left from when the node groups of the NodePool were allocated from
+// several HardwareManagers, and returns true once none remain. The status aggregated from the members is cleared, so
+// that the NodePool is then allocated from its HardwareManager as a new NodePool.
+func (c *HwMgrAdaptorController) releaseMemberNodePools(ctx context.Context,
+	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, bool, error) {
+	remaining, err := c.deleteMemberNodePools(ctx, nodepool, nil)
+	if err != nil {
+		return utils.RequeueWithShortInterval(), false, err
+	}
+	if remaining == 0 {
+		return ctrl.Result{}, true, nil
+	}
+
+	c.Logger.InfoContext(ctx, "Releasing the members of a NodePool now allocated from a single hardware manager",
+		slog.Int("members", remaining))
+	if err := utils.ResetNodePoolStatus(ctx, c.Client, nodepool); err != nil {
+		return utils.RequeueWithShortInterval(), false,
+			fmt.Errorf("failed to reset status for NodePool %s: %w", nodepool.Name, err)
+	}
+	return utils.RequeueWithShortInterval(), false, nil
+}
+
+// deleteMemberNodePools deletes the members of the NodePool, other than those kept, and returns how many of them
+// remain, as their deletion completes once their HardwareManager has released their hardware
+func (c *HwMgrAdaptorController) deleteMemberNodePools(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool,
+	keep func(*hwmgmtv1alpha1.NodePool) bool) (int, error) {
+	members := &hwmgmtv1alpha1.NodePoolList{}
+	if err := c.Client.List(ctx, members, client.InNamespace(nodepool.Namespace),
+		client.MatchingLabels{utils.ParentNodePoolLabel: nodepool.Name}); err != nil {
+		return 0, fmt.Errorf("failed to list members of nodepool %s: %w", nodepool.Name, err)
+	}
+
+	remaining := 0
+	for i := range members.Items {
+		member := &members.Items[i]
+		if !metav1.IsControlledBy(member, nodepool) || (keep != nil && keep(member)) {
+			continue
+		}
+		remaining++
+		if !member.DeletionTimestamp.IsZero() {
+			continue
+		}
+		c.Logger.InfoContext(ctx, "Deleting member NodePool", slog.String("member", member.Name),
+			slog.String("hwmgr", member.Spec.HwMgrId))
+		if err := c.Client.Delete(ctx, member); err != nil && !errors.IsNotFound(err) {
+			return remaining, fmt.Errorf("failed to delete member nodepool %s: %w", member.Name, err)
+		}
+	}
+	return remaining, nil
+}
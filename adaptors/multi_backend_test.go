@@ -0,0 +1,350 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// nodePoolsClient serves HardwareManagers, and stores NodePools in memory. Deleted NodePools are removed at once, as
+// if their finalizers had completed.
+type nodePoolsClient struct {
+	client.Client
+	hwmgrs    []pluginv1alpha1.HardwareManager
+	nodepools map[string]*hwmgmtv1alpha1.NodePool
+}
+
+func newNodePoolsClient(nodepools ...*hwmgmtv1alpha1.NodePool) *nodePoolsClient {
+	c := &nodePoolsClient{nodepools: make(map[string]*hwmgmtv1alpha1.NodePool)}
+	for _, hwMgrId := range []string{"dell-1", "metal3-1"} {
+		c.hwmgrs = append(c.hwmgrs, pluginv1alpha1.HardwareManager{
+			ObjectMeta: metav1.ObjectMeta{Name: hwMgrId},
+			Spec:       pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+		})
+	}
+	for _, nodepool := range nodepools {
+		c.nodepools[nodepool.Name] = nodepool.DeepCopy()
+	}
+	return c
+}
+
+func (c *nodePoolsClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	switch obj := obj.(type) {
+	case *pluginv1alpha1.HardwareManager:
+		for i := range c.hwmgrs {
+			if c.hwmgrs[i].Name == key.Name {
+				c.hwmgrs[i].DeepCopyInto(obj)
+				return nil
+			}
+		}
+	case *hwmgmtv1alpha1.NodePool:
+		if nodepool, exists := c.nodepools[key.Name]; exists {
+			nodepool.DeepCopyInto(obj)
+			return nil
+		}
+	}
+	return errors.NewNotFound(hwmgmtv1alpha1.GroupVersion.WithResource("nodepools").GroupResource(), key.Name)
+}
+
+func (c *nodePoolsClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	nodepools, ok := list.(*hwmgmtv1alpha1.NodePoolList)
+	if !ok {
+		return nil
+	}
+	options := &client.ListOptions{}
+	options.ApplyOptions(opts)
+	for _, nodepool := range c.nodepools {
+		if options.LabelSelector == nil || options.LabelSelector.Matches(labels.Set(nodepool.Labels)) {
+			nodepools.Items = append(nodepools.Items, *nodepool.DeepCopy())
+		}
+	}
+	return nil
+}
+
+func (c *nodePoolsClient) store(obj client.Object) error {
+	c.nodepools[obj.GetName()] = obj.(*hwmgmtv1alpha1.NodePool).DeepCopy()
+	return nil
+}
+
+func (c *nodePoolsClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	if _, exists := c.nodepools[obj.GetName()]; exists {
+		return errors.NewAlreadyExists(hwmgmtv1alpha1.GroupVersion.WithResource("nodepools").GroupResource(), obj.GetName())
+	}
+	return c.store(obj)
+}
+
+func (c *nodePoolsClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return c.store(obj)
+}
+
+func (c *nodePoolsClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return c.store(obj)
+}
+
+func (c *nodePoolsClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	delete(c.nodepools, obj.GetName())
+	return nil
+}
+
+func (c *nodePoolsClient) Status() client.SubResourceWriter {
+	return &nodePoolsStatusWriter{client: c}
+}
+
+type nodePoolsStatusWriter struct {
+	client.SubResourceWriter
+	client *nodePoolsClient
+}
+
+func (w *nodePoolsStatusWriter) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	return w.client.store(obj)
+}
+
+// newMultiBackendController returns a controller processing NodePools through the client, with a fake adaptor for
+// the HardwareManagers
+func newMultiBackendController(t *testing.T, c *nodePoolsClient) (*HwMgrAdaptorController, *testsupport.FakeAdaptor) {
+	scheme := runtime.NewScheme()
+	if err := hwmgmtv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	controller := &HwMgrAdaptorController{Client: c, Scheme: scheme, Logger: slog.Default()}
+	fake := testsupport.NewFakeAdaptor()
+	controller.RegisterAdaptor(LoopbackAdaptorID, fake)
+	return controller, fake
+}
+
+// newMultiBackendNodePool returns a NodePool allocating its controllers from metal3-1 and its workers from dell-1
+func newMultiBackendNodePool() *hwmgmtv1alpha1.NodePool {
+	nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np1", UID: types.UID("np1-uid"), Generation: 1}}
+	nodepool.Spec.HwMgrId = "dell-1"
+	nodepool.Spec.Extensions = map[string]string{"controller." + pluginv1alpha1.HwMgrIdExtensionKey: "metal3-1"}
+	nodepool.Spec.NodeGroup = []hwmgmtv1alpha1.NodeGroup{
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller", HwProfile: "profile-1"}, Size: 3},
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker", HwProfile: "profile-2"}, Size: 2},
+	}
+	return nodepool
+}
+
+func TestAggregateMemberStatus(t *testing.T) {
+	member := func(hwMgrId string, nodes []string, conditions ...metav1.Condition) hwmgmtv1alpha1.NodePool {
+		nodepool := hwmgmtv1alpha1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "np1-" + hwMgrId, Generation: 1},
+			Spec:       hwmgmtv1alpha1.NodePoolSpec{HwMgrId: hwMgrId},
+		}
+		nodepool.Status.Properties.NodeNames = nodes
+		nodepool.Status.Conditions = conditions
+		nodepool.Status.HwMgrPlugin.ObservedGeneration = 1
+		return nodepool
+	}
+	condition := func(conditionType hwmgmtv1alpha1.ConditionType, reason hwmgmtv1alpha1.ConditionReason,
+		status metav1.ConditionStatus, message string) metav1.Condition {
+		return metav1.Condition{Type: string(conditionType), Reason: string(reason), Status: status, Message: message}
+	}
+	provisioned := condition(hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Completed, metav1.ConditionTrue, "Created")
+
+	// One member allocated, the other in progress
+	status := aggregateMemberStatus([]hwmgmtv1alpha1.NodePool{
+		member("dell-1", []string{"w1", "w2"}, provisioned),
+		member("metal3-1", nil, condition(hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse, "In progress")),
+	})
+	if status.failed != nil || !reflect.DeepEqual(status.provisioned, []string{"dell-1"}) || status.configured != nil {
+		t.Errorf("unexpected status with a member in progress: %+v", status)
+	}
+
+	// One member failed to allocate
+	status = aggregateMemberStatus([]hwmgmtv1alpha1.NodePool{
+		member("dell-1", []string{"w1", "w2"}, provisioned),
+		member("metal3-1", nil, condition(hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse, "not enough hosts")),
+	})
+	if status.failed == nil || status.failed.Spec.HwMgrId != "metal3-1" {
+		t.Errorf("expected the metal3-1 member to have failed, got %+v", status)
+	}
+
+	// Both allocated, with a configuration failure taking precedence over one in progress and one applied
+	configured := condition(hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.ConfigApplied, metav1.ConditionTrue, string(hwmgmtv1alpha1.ConfigSuccess))
+	members := []hwmgmtv1alpha1.NodePool{
+		member("dell-1", []string{"w1", "w2"}, provisioned, configured),
+		member("metal3-1", []string{"c1"}, provisioned,
+			condition(hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse, "Updating")),
+		member("dell-2", []string{"w3"}, provisioned,
+			condition(hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.Failed, metav1.ConditionFalse, "firmware update failed")),
+	}
+	status = aggregateMemberStatus(members)
+	if len(status.provisioned) != 3 || !reflect.DeepEqual(status.nodeNames, []string{"w1", "w2", "c1", "w3"}) || !status.observed {
+		t.Errorf("unexpected status with all members allocated: %+v", status)
+	}
+	if status.configured == nil || status.configured.Reason != string(hwmgmtv1alpha1.Failed) ||
+		status.configured.Message != "Hardware manager dell-2: firmware update failed" {
+		t.Errorf("expected the configuration failure, got %+v", status.configured)
+	}
+
+	members[2].Status.Conditions[1] = configured
+	members[1].Generation = 2
+	status = aggregateMemberStatus(members)
+	if status.configured == nil || status.configured.Reason != string(hwmgmtv1alpha1.InProgress) || status.observed {
+		t.Errorf("expected the configuration in progress, got %+v", status)
+	}
+}
+
+func TestMultiBackendRollback(t *testing.T) {
+	ctx := context.Background()
+	nodepool := newMultiBackendNodePool()
+	c := newNodePoolsClient(nodepool)
+	controller, fake := newMultiBackendController(t, c)
+
+	// A member is created for each HardwareManager, owned by the NodePool
+	if _, err := controller.HandleNodePool(ctx, nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"np1-dell-1", "np1-metal3-1"} {
+		member, exists := c.nodepools[name]
+		if !exists || !metav1.IsControlledBy(member, nodepool) {
+			t.Fatalf("expected member %s owned by the NodePool, got %+v", name, member)
+		}
+	}
+	if fake.CallCount("HandleNodePool") != 0 {
+		t.Errorf("expected the NodePool to be processed through its members, got %v", fake.Calls())
+	}
+
+	// A member fails to allocate, so all the members are deleted
+	utils.SetStatusCondition(&c.nodepools["np1-dell-1"].Status.Conditions, string(hwmgmtv1alpha1.Provisioned),
+		string(hwmgmtv1alpha1.Completed), metav1.ConditionTrue, "Created")
+	utils.SetStatusCondition(&c.nodepools["np1-metal3-1"].Status.Conditions, string(hwmgmtv1alpha1.Provisioned),
+		string(hwmgmtv1alpha1.Failed), metav1.ConditionFalse, "not enough hosts")
+	nodepool = c.nodepools["np1"].DeepCopy()
+	if _, err := controller.HandleNodePool(ctx, nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, exists := c.nodepools["np1-dell-1"]; exists {
+		t.Error("expected the allocation from dell-1 to be rolled back")
+	}
+	if _, exists := c.nodepools["np1-metal3-1"]; exists {
+		t.Error("expected the failed member to be deleted")
+	}
+	nodepool = c.nodepools["np1"].DeepCopy()
+	condition := utils.GetNodePoolProvisionedCondition(nodepool)
+	if !utils.IsRolledBack(nodepool) || condition == nil || condition.Reason != string(hwmgmtv1alpha1.Failed) ||
+		!strings.Contains(condition.Message, "Rolled back the allocations from: dell-1") {
+		t.Errorf("expected the NodePool to be rolled back, got %+v", condition)
+	}
+
+	// The members are not created again until the spec of the NodePool changes
+	if _, err := controller.HandleNodePool(ctx, nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.nodepools) != 1 {
+		t.Errorf("expected no members once rolled back, got %d NodePools", len(c.nodepools))
+	}
+}
+
+func TestMultiBackendMemberConflict(t *testing.T) {
+	ctx := context.Background()
+	nodepool := newMultiBackendNodePool()
+	unrelated := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np1-dell-1",
+		Labels: map[string]string{utils.ParentNodePoolLabel: "np1"}}}
+	unrelated.Spec.HwMgrId = "other"
+	c := newNodePoolsClient(nodepool, unrelated)
+	controller, _ := newMultiBackendController(t, c)
+
+	if _, err := controller.HandleNodePool(ctx, nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if condition := utils.GetNodePoolProvisionedCondition(c.nodepools["np1"]); condition == nil ||
+		condition.Reason != string(hwmgmtv1alpha1.Failed) || !strings.Contains(condition.Message, "np1-dell-1") {
+		t.Errorf("expected the NodePool to fail on the existing np1-dell-1, got %+v", condition)
+	}
+	if _, exists := c.nodepools["np1-metal3-1"]; exists {
+		t.Error("expected no allocation once a member conflicts")
+	}
+	if !reflect.DeepEqual(c.nodepools["np1-dell-1"], unrelated) {
+		t.Errorf("expected the existing NodePool to be left as is, got %+v", c.nodepools["np1-dell-1"])
+	}
+
+	// A NodePool not owned by the NodePool is not deleted with its members
+	if completed, err := controller.HandleNodePoolDeletion(ctx, nodepool); err != nil || !completed {
+		t.Fatalf("expected the deletion to complete, got %v, %v", completed, err)
+	}
+	if _, exists := c.nodepools["np1-dell-1"]; !exists {
+		t.Error("expected the existing NodePool to be kept")
+	}
+}
+
+func TestMultiBackendSwitchToSingleBackend(t *testing.T) {
+	ctx := context.Background()
+	nodepool := newMultiBackendNodePool()
+	c := newNodePoolsClient(nodepool)
+	controller, fake := newMultiBackendController(t, c)
+
+	if _, err := controller.HandleNodePool(ctx, nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nodepool = c.nodepools["np1"]
+	utils.SetStatusCondition(&nodepool.Status.Conditions, string(hwmgmtv1alpha1.Provisioned),
+		string(hwmgmtv1alpha1.Completed), metav1.ConditionTrue, "Created from hardware managers dell-1, metal3-1")
+	nodepool.Status.Properties.NodeNames = []string{"c1", "w1"}
+
+	// All the node groups are moved to dell-1, so the members are released before allocating from it
+	nodepool.Spec.Extensions = nil
+	nodepool.Generation = 2
+	if _, err := controller.HandleNodePool(ctx, nodepool.DeepCopy()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.nodepools) != 1 {
+		t.Errorf("expected the members to be deleted, got %d NodePools", len(c.nodepools))
+	}
+	if status := c.nodepools["np1"].Status; len(status.Conditions) != 0 || len(status.Properties.NodeNames) != 0 {
+		t.Errorf("expected the status aggregated from the members to be cleared, got %+v", status)
+	}
+	if fake.CallCount("HandleNodePool") != 0 {
+		t.Errorf("expected no allocation before the members are deleted, got %v", fake.Calls())
+	}
+
+	if _, err := controller.HandleNodePool(ctx, c.nodepools["np1"].DeepCopy()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.CallCount("HandleNodePool") != 1 {
+		t.Errorf("expected the NodePool to be allocated from dell-1, got %v", fake.Calls())
+	}
+}
+
+func TestInvalidNodePoolExtensions(t *testing.T) {
+	ctx := context.Background()
+	nodepool := newMultiBackendNodePool()
+	nodepool.Spec.Extensions[pluginv1alpha1.ExtensionsVersionKey] = "v0"
+	c := newNodePoolsClient(nodepool)
+	controller, fake := newMultiBackendController(t, c)
+
+	if _, err := controller.HandleNodePool(ctx, nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if condition := utils.GetNodePoolProvisionedCondition(c.nodepools["np1"]); condition == nil ||
+		condition.Reason != string(hwmgmtv1alpha1.Failed) {
+		t.Errorf("expected the NodePool to fail on its extensions, got %+v", condition)
+	}
+	if len(c.nodepools) != 1 || fake.CallCount("HandleNodePool") != 0 {
+		t.Errorf("expected no allocation, got %d NodePools and %v", len(c.nodepools), fake.Calls())
+	}
+
+	if _, err := controller.HandleNodePoolDeletion(ctx, nodepool); err == nil {
+		t.Error("expected the deletion to fail on the extensions")
+	}
+}
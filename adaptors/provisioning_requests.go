@@ -0,0 +1,402 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// Provisioning requests are a thin API over NodePools, for clients that do not create NodePool CRs directly. Each
+// request is fulfilled by a NodePool named after the request identifier, which is processed as any other NodePool. A
+// request created with an idempotency key gets an identifier derived from the key, so that a retried request finds the
+// NodePool created by the first one instead of provisioning more hardware.
+
+// ProvisioningRequestLabel marks the NodePools created for provisioning requests with the request identifier
+const ProvisioningRequestLabel = "hwmgr-plugin.oran.openshift.io/provisioning-request"
+
+// ProvisioningRequestHashAnnotation records the hash of the payload of a provisioning request created with an
+// idempotency key, to tell a retried request from another request reusing the key
+const ProvisioningRequestHashAnnotation = "hwmgr-plugin.oran.openshift.io/provisioning-request-hash"
+
+// provisioningRequestKeySpace is the UUID namespace of the identifiers derived from idempotency keys
+var provisioningRequestKeySpace = uuid.MustParse("5b0f8c9e-2d4a-4e1b-9c7f-3a6d8e2b1f40")
+
+// errProvisioningRequestNotFound is returned when no NodePool was created for the provisioning request
+var errProvisioningRequestNotFound = errors.New("provisioning request not found")
+
+// provisioningStatusURL returns the path to poll for the status of a provisioning request
+func provisioningStatusURL(hwMgrId string, id uuid.UUID) string {
+	return fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/provisioningRequests/%s", hwMgrId, id)
+}
+
+// provisioningRequestId returns the identifier of a new provisioning request, derived from the idempotency key of the
+// request if any, or else random
+func provisioningRequestId(hwMgrId string, idempotencyKey *string) uuid.UUID {
+	if idempotencyKey == nil {
+		return uuid.New()
+	}
+	return uuid.NewSHA1(provisioningRequestKeySpace, []byte(hwMgrId+"/"+*idempotencyKey))
+}
+
+// provisioningRequestHash returns the hash of the payload of a provisioning request
+func provisioningRequestHash(request *invserver.ProvisioningRequest) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal provisioning request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// validateProvisioningRequest checks the constraints of a provisioning request that the API schema cannot express
+func validateProvisioningRequest(request *invserver.ProvisioningRequest) error {
+	if request == nil {
+		return fmt.Errorf("a provisioning request body is required")
+	}
+	if request.CloudId == "" || request.Site == "" {
+		return fmt.Errorf("cloudId and site are required")
+	}
+	if len(request.NodeGroups) == 0 {
+		return fmt.Errorf("at least one node group is required")
+	}
+
+	names := make(map[string]bool, len(request.NodeGroups))
+	for _, group := range request.NodeGroups {
+		if names[group.Name] {
+			return fmt.Errorf("node group %s is defined more than once", group.Name)
+		}
+		names[group.Name] = true
+		if group.Size < 0 {
+			return fmt.Errorf("node group %s has a negative size", group.Name)
+		}
+	}
+	return nil
+}
+
+// nodeGroupsFromRequest converts the node groups of a provisioning request to NodePool node groups
+func nodeGroupsFromRequest(request *invserver.ProvisioningRequest) []hwmgmtv1alpha1.NodeGroup {
+	groups := make([]hwmgmtv1alpha1.NodeGroup, 0, len(request.NodeGroups))
+	for _, group := range request.NodeGroups {
+		data := hwmgmtv1alpha1.NodePoolData{
+			Name:      group.Name,
+			Role:      string(group.Role),
+			HwProfile: group.HwProfile,
+		}
+		if group.ResourcePoolId != nil {
+			data.ResourcePoolId = *group.ResourcePoolId
+		}
+		if group.ResourceSelector != nil {
+			data.ResourceSelector = *group.ResourceSelector
+		}
+		groups = append(groups, hwmgmtv1alpha1.NodeGroup{NodePoolData: data, Size: group.Size})
+	}
+	return groups
+}
+
+// nodePoolFromRequest builds the NodePool fulfilling a provisioning request
+func (c *HwMgrAdaptorController) nodePoolFromRequest(hwMgrId string, id uuid.UUID,
+	request *invserver.ProvisioningRequest) *hwmgmtv1alpha1.NodePool {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      id.String(),
+			Namespace: c.Namespace,
+			Labels:    map[string]string{ProvisioningRequestLabel: id.String()},
+		},
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			CloudID:   request.CloudId,
+			HwMgrId:   hwMgrId,
+			NodeGroup: nodeGroupsFromRequest(request),
+		},
+	}
+	nodepool.Spec.Site = request.Site
+	if request.Location != nil {
+		nodepool.Spec.Location = *request.Location
+	}
+	if request.Extensions != nil {
+		nodepool.Spec.Extensions = extensionsFromRequest(request.Extensions)
+	}
+	return nodepool
+}
+
+// extensionsFromRequest converts the typed extensions of a provisioning request to the NodePool extensions map
+func extensionsFromRequest(extensions *invserver.NodePoolExtensions) map[string]string {
+	result := make(map[string]string, len(extensions.AdditionalProperties))
+	for key, value := range extensions.AdditionalProperties {
+		result[key] = value
+	}
+	settings := map[string]*string{
+		pluginv1alpha1.ResourceTypeIdExtensionKey:      extensions.ResourceTypeId,
+		pluginv1alpha1.CPUArchitectureExtensionKey:     extensions.CpuArchitecture,
+		pluginv1alpha1.HostnameTemplateExtensionKey:    extensions.HostnameTemplate,
+		pluginv1alpha1.SiteExtensionKey:                extensions.Site,
+		pluginv1alpha1.RackExtensionKey:                extensions.Rack,
+		pluginv1alpha1.TagsExtensionKey:                extensions.Tags,
+		pluginv1alpha1.SpreadByExtensionKey:            extensions.SpreadBy,
+		pluginv1alpha1.ManifestTemplateExtensionKey:    extensions.ManifestTemplate,
+		pluginv1alpha1.NetworkDataTemplateExtensionKey: extensions.NetworkDataTemplate,
+	}
+	for key, value := range settings {
+		if value != nil {
+			result[key] = *value
+		}
+	}
+	if extensions.ExtensionsVersion != nil {
+		result[pluginv1alpha1.ExtensionsVersionKey] = string(*extensions.ExtensionsVersion)
+	}
+	if extensions.SpreadMode != nil {
+		result[pluginv1alpha1.SpreadModeExtensionKey] = string(*extensions.SpreadMode)
+	}
+	return result
+}
+
+// provisioningRequestStatus reports the progress of a provisioning request from its NodePool
+func provisioningRequestStatus(hwMgrId string, id uuid.UUID, nodepool *hwmgmtv1alpha1.NodePool) invserver.ProvisioningRequestStatus {
+	status := invserver.ProvisioningRequestStatus{
+		ProvisioningRequestId: id,
+		NodePool:              nodepool.Name,
+		Phase:                 invserver.ProvisioningRequestStatusPhase(utils.GetNodePoolPhase(nodepool)),
+		StatusUrl:             provisioningStatusURL(hwMgrId, id),
+	}
+
+	var latest *metav1.Condition
+	for i := range nodepool.Status.Conditions {
+		condition := &nodepool.Status.Conditions[i]
+		if latest == nil || condition.LastTransitionTime.After(latest.LastTransitionTime.Time) {
+			latest = condition
+		}
+	}
+	if latest != nil && latest.Message != "" {
+		status.Message = &latest.Message
+	}
+	if len(nodepool.Status.Properties.NodeNames) > 0 {
+		nodeNames := append([]string(nil), nodepool.Status.Properties.NodeNames...)
+		status.NodeNames = &nodeNames
+	}
+	return status
+}
+
+// getProvisioningNodePool gets the NodePool created for the provisioning request of the hardware manager
+func (c *HwMgrAdaptorController) getProvisioningNodePool(ctx context.Context, hwMgrId string, id uuid.UUID) (*hwmgmtv1alpha1.NodePool, error) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	if err := c.Client.Get(ctx, types.NamespacedName{Name: id.String(), Namespace: c.Namespace}, nodepool); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errProvisioningRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to get nodepool %s: %w", id, err)
+	}
+	if nodepool.Labels[ProvisioningRequestLabel] != id.String() || nodepool.Spec.HwMgrId != hwMgrId {
+		return nil, errProvisioningRequestNotFound
+	}
+	return nodepool, nil
+}
+
+// CreateProvisioningRequest creates the NodePool fulfilling a provisioning request
+func (c *HwMgrAdaptorController) CreateProvisioningRequest(ctx context.Context, request invserver.CreateProvisioningRequestRequestObject) (invserver.CreateProvisioningRequestResponseObject, error) {
+	if err := validateProvisioningRequest(request.Body); err != nil {
+		return invserver.CreateProvisioningRequest400ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusBadRequest,
+			Detail: fmt.Sprintf("Invalid provisioning request: %s", err.Error()),
+		}), nil
+	}
+
+	if _, _, err := c.getHwMgr(ctx, request.HwMgrId); err != nil {
+		return invserver.CreateProvisioningRequest404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusNotFound,
+			Detail: fmt.Sprintf("Hardware Manager %s not found", request.HwMgrId),
+		}), nil
+	}
+
+	id := provisioningRequestId(request.HwMgrId, request.Params.IdempotencyKey)
+	nodepool := c.nodePoolFromRequest(request.HwMgrId, id, request.Body)
+	var hash string
+	err := c.setProvisioningRequestHash(nodepool, request)
+	if err == nil {
+		hash = nodepool.Annotations[ProvisioningRequestHashAnnotation]
+		err = c.Client.Create(ctx, nodepool)
+	}
+	if k8serrors.IsAlreadyExists(err) && hash != "" {
+		return c.replayProvisioningRequest(ctx, request.HwMgrId, id, hash)
+	}
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "unable to create nodepool for provisioning request",
+			slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
+		return invserver.CreateProvisioningRequest500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to create provisioning request: %s", err.Error()),
+		}), nil
+	}
+
+	c.Logger.InfoContext(ctx, "Created nodepool for provisioning request", slog.String("hwMgrId", request.HwMgrId),
+		slog.String("nodepool", nodepool.Name))
+	return invserver.CreateProvisioningRequest202JSONResponse(provisioningRequestStatus(request.HwMgrId, id, nodepool)), nil
+}
+
+// setProvisioningRequestHash records the hash of the payload of a provisioning request created with an idempotency key
+// on its NodePool
+func (c *HwMgrAdaptorController) setProvisioningRequestHash(nodepool *hwmgmtv1alpha1.NodePool,
+	request invserver.CreateProvisioningRequestRequestObject) error {
+	if request.Params.IdempotencyKey == nil {
+		return nil
+	}
+	hash, err := provisioningRequestHash(request.Body)
+	if err != nil {
+		return err
+	}
+	nodepool.Annotations = map[string]string{ProvisioningRequestHashAnnotation: hash}
+	return nil
+}
+
+// replayProvisioningRequest responds to a provisioning request repeating the idempotency key of an existing request
+// with the status of the existing request, unless its payload differs
+func (c *HwMgrAdaptorController) replayProvisioningRequest(ctx context.Context, hwMgrId string, id uuid.UUID,
+	hash string) (invserver.CreateProvisioningRequestResponseObject, error) {
+	nodepool, err := c.getProvisioningNodePool(ctx, hwMgrId, id)
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "unable to get nodepool for repeated provisioning request",
+			slog.String("hwMgrId", hwMgrId), slog.String("error", err.Error()))
+		return invserver.CreateProvisioningRequest500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to get provisioning request %s: %s", id, err.Error()),
+		}), nil
+	}
+	if nodepool.Annotations[ProvisioningRequestHashAnnotation] != hash {
+		return invserver.CreateProvisioningRequest409ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusConflict,
+			Detail: fmt.Sprintf("The Idempotency-Key was used by provisioning request %s with a different payload", id),
+		}), nil
+	}
+
+	c.Logger.InfoContext(ctx, "Provisioning request already created", slog.String("hwMgrId", hwMgrId),
+		slog.String("nodepool", nodepool.Name))
+	return invserver.CreateProvisioningRequest202JSONResponse(provisioningRequestStatus(hwMgrId, id, nodepool)), nil
+}
+
+// GetProvisioningRequest reports the progress of a provisioning request
+func (c *HwMgrAdaptorController) GetProvisioningRequest(ctx context.Context, request invserver.GetProvisioningRequestRequestObject) (invserver.GetProvisioningRequestResponseObject, error) {
+	nodepool, err := c.getProvisioningNodePool(ctx, request.HwMgrId, request.ProvisioningRequestId)
+	switch {
+	case errors.Is(err, errProvisioningRequestNotFound):
+		return invserver.GetProvisioningRequest404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusNotFound,
+			Detail: fmt.Sprintf("Provisioning request %s not found", request.ProvisioningRequestId),
+		}), nil
+	case err != nil:
+		return invserver.GetProvisioningRequest500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to get provisioning request: %s", err.Error()),
+		}), nil
+	}
+
+	return invserver.GetProvisioningRequest200JSONResponse(
+		provisioningRequestStatus(request.HwMgrId, request.ProvisioningRequestId, nodepool)), nil
+}
+
+// UpdateProvisioningRequest updates the node groups and extensions of the NodePool fulfilling a provisioning request
+func (c *HwMgrAdaptorController) UpdateProvisioningRequest(ctx context.Context, request invserver.UpdateProvisioningRequestRequestObject) (invserver.UpdateProvisioningRequestResponseObject, error) {
+	if err := validateProvisioningRequest(request.Body); err != nil {
+		return invserver.UpdateProvisioningRequest400ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusBadRequest,
+			Detail: fmt.Sprintf("Invalid provisioning request: %s", err.Error()),
+		}), nil
+	}
+
+	var nodepool *hwmgmtv1alpha1.NodePool
+	var response invserver.UpdateProvisioningRequestResponseObject
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		nodepool, err = c.getProvisioningNodePool(ctx, request.HwMgrId, request.ProvisioningRequestId)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !nodepool.DeletionTimestamp.IsZero():
+			response = invserver.UpdateProvisioningRequest409ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+				Status: http.StatusConflict,
+				Detail: fmt.Sprintf("Provisioning request %s is being released", request.ProvisioningRequestId),
+			})
+			return nil
+		case nodepool.Spec.CloudID != request.Body.CloudId || nodepool.Spec.Site != request.Body.Site:
+			response = invserver.UpdateProvisioningRequest400ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+				Status: http.StatusBadRequest,
+				Detail: "The cloudId and site of a provisioning request cannot be changed",
+			})
+			return nil
+		}
+
+		updated := c.nodePoolFromRequest(request.HwMgrId, request.ProvisioningRequestId, request.Body)
+		nodepool.Spec.NodeGroup = updated.Spec.NodeGroup
+		nodepool.Spec.Extensions = updated.Spec.Extensions
+		nodepool.Spec.Location = updated.Spec.Location
+		return c.Client.Update(ctx, nodepool) // nolint: wrapcheck
+	})
+	switch {
+	case errors.Is(err, errProvisioningRequestNotFound):
+		return invserver.UpdateProvisioningRequest404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusNotFound,
+			Detail: fmt.Sprintf("Provisioning request %s not found", request.ProvisioningRequestId),
+		}), nil
+	case err != nil:
+		c.Logger.ErrorContext(ctx, "unable to update nodepool for provisioning request",
+			slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
+		return invserver.UpdateProvisioningRequest500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to update provisioning request: %s", err.Error()),
+		}), nil
+	case response != nil:
+		return response, nil
+	}
+
+	return invserver.UpdateProvisioningRequest202JSONResponse(
+		provisioningRequestStatus(request.HwMgrId, request.ProvisioningRequestId, nodepool)), nil
+}
+
+// DeleteProvisioningRequest deletes the NodePool fulfilling a provisioning request, releasing its hardware
+func (c *HwMgrAdaptorController) DeleteProvisioningRequest(ctx context.Context, request invserver.DeleteProvisioningRequestRequestObject) (invserver.DeleteProvisioningRequestResponseObject, error) {
+	nodepool, err := c.getProvisioningNodePool(ctx, request.HwMgrId, request.ProvisioningRequestId)
+	if err == nil {
+		err = c.Client.Delete(ctx, nodepool)
+		if k8serrors.IsNotFound(err) {
+			err = errProvisioningRequestNotFound
+		}
+	}
+
+	switch {
+	case errors.Is(err, errProvisioningRequestNotFound):
+		return invserver.DeleteProvisioningRequest404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusNotFound,
+			Detail: fmt.Sprintf("Provisioning request %s not found", request.ProvisioningRequestId),
+		}), nil
+	case err != nil:
+		c.Logger.ErrorContext(ctx, "unable to delete nodepool for provisioning request",
+			slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
+		return invserver.DeleteProvisioningRequest500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to delete provisioning request: %s", err.Error()),
+		}), nil
+	}
+
+	return invserver.DeleteProvisioningRequest202Response{}, nil
+}
@@ -0,0 +1,190 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func testProvisioningRequest() *invserver.ProvisioningRequest {
+	pool := "pool-1"
+	return &invserver.ProvisioningRequest{
+		CloudId: "cloud-1",
+		Site:    "site-1",
+		NodeGroups: []invserver.ProvisioningNodeGroup{
+			{Name: "controller", Role: invserver.Master, HwProfile: "profile-1", ResourcePoolId: &pool, Size: 3},
+			{Name: "worker", Role: invserver.Worker, HwProfile: "profile-2", ResourcePoolId: &pool, Size: 2},
+		},
+	}
+}
+
+func TestValidateProvisioningRequest(t *testing.T) {
+	if err := validateProvisioningRequest(testProvisioningRequest()); err != nil {
+		t.Fatalf("unexpected error for valid request: %v", err)
+	}
+
+	tests := map[string]func(*invserver.ProvisioningRequest){
+		"missing cloudId": func(r *invserver.ProvisioningRequest) { r.CloudId = "" },
+		"missing site":    func(r *invserver.ProvisioningRequest) { r.Site = "" },
+		"no node groups":  func(r *invserver.ProvisioningRequest) { r.NodeGroups = nil },
+		"duplicate group": func(r *invserver.ProvisioningRequest) { r.NodeGroups[1].Name = "controller" },
+		"negative size":   func(r *invserver.ProvisioningRequest) { r.NodeGroups[0].Size = -1 },
+	}
+	for name, mutate := range tests {
+		request := testProvisioningRequest()
+		mutate(request)
+		if err := validateProvisioningRequest(request); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if err := validateProvisioningRequest(nil); err == nil {
+		t.Errorf("expected an error for a missing body")
+	}
+}
+
+func TestNodePoolFromRequest(t *testing.T) {
+	c := &HwMgrAdaptorController{Namespace: "hwmgr"}
+	id := uuid.New()
+	location := "rack-1"
+	request := testProvisioningRequest()
+	request.Location = &location
+	cpuArchitecture := "aarch64"
+	spreadMode := invserver.Required
+	request.Extensions = &invserver.NodePoolExtensions{
+		CpuArchitecture:      &cpuArchitecture,
+		SpreadMode:           &spreadMode,
+		AdditionalProperties: map[string]string{"controller.minSize": "3", "key": "value"},
+	}
+
+	nodepool := c.nodePoolFromRequest("hwmgr-1", id, request)
+
+	if nodepool.Name != id.String() || nodepool.Namespace != "hwmgr" {
+		t.Errorf("unexpected nodepool name %s/%s", nodepool.Namespace, nodepool.Name)
+	}
+	if nodepool.Labels[ProvisioningRequestLabel] != id.String() {
+		t.Errorf("missing provisioning request label: %v", nodepool.Labels)
+	}
+	if nodepool.Spec.HwMgrId != "hwmgr-1" || nodepool.Spec.CloudID != "cloud-1" ||
+		nodepool.Spec.Site != "site-1" || nodepool.Spec.Location != "rack-1" {
+		t.Errorf("unexpected nodepool spec: %+v", nodepool.Spec)
+	}
+	if nodepool.Spec.Extensions["key"] != "value" || nodepool.Spec.Extensions["controller.minSize"] != "3" ||
+		nodepool.Spec.Extensions["cpuArchitecture"] != "aarch64" || nodepool.Spec.Extensions["spreadMode"] != "required" ||
+		len(nodepool.Spec.Extensions) != 4 {
+		t.Errorf("unexpected extensions: %v", nodepool.Spec.Extensions)
+	}
+	if len(nodepool.Spec.NodeGroup) != 2 {
+		t.Fatalf("expected 2 node groups, got %d", len(nodepool.Spec.NodeGroup))
+	}
+	group := nodepool.Spec.NodeGroup[0]
+	if group.NodePoolData.Name != "controller" || group.NodePoolData.Role != "master" ||
+		group.NodePoolData.HwProfile != "profile-1" || group.NodePoolData.ResourcePoolId != "pool-1" || group.Size != 3 {
+		t.Errorf("unexpected node group: %+v", group)
+	}
+}
+
+func TestProvisioningRequestStatus(t *testing.T) {
+	id := uuid.New()
+	now := time.Now()
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: id.String()},
+		Status: hwmgmtv1alpha1.NodePoolStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(hwmgmtv1alpha1.Provisioned),
+					Status:             metav1.ConditionTrue,
+					Reason:             string(hwmgmtv1alpha1.Completed),
+					Message:            "Provisioned",
+					LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+				},
+				{
+					Type:               string(hwmgmtv1alpha1.Configured),
+					Status:             metav1.ConditionTrue,
+					Reason:             string(hwmgmtv1alpha1.ConfigApplied),
+					Message:            "Configuration applied",
+					LastTransitionTime: metav1.NewTime(now),
+				},
+			},
+			Properties: hwmgmtv1alpha1.Properties{NodeNames: []string{"node-1", "node-2"}},
+		},
+	}
+
+	status := provisioningRequestStatus("hwmgr-1", id, nodepool)
+
+	if status.Phase != invserver.Provisioned {
+		t.Errorf("expected phase Provisioned, got %s", status.Phase)
+	}
+	if status.Message == nil || *status.Message != "Configuration applied" {
+		t.Errorf("expected the message of the latest condition, got %v", status.Message)
+	}
+	if status.NodeNames == nil || len(*status.NodeNames) != 2 {
+		t.Errorf("unexpected node names: %v", status.NodeNames)
+	}
+	if status.StatusUrl != "/hardware-manager/inventory/v1/manager/hwmgr-1/provisioningRequests/"+id.String() {
+		t.Errorf("unexpected status url %s", status.StatusUrl)
+	}
+
+	nodepool.DeletionTimestamp = &metav1.Time{Time: now}
+	if status := provisioningRequestStatus("hwmgr-1", id, nodepool); status.Phase != invserver.Releasing {
+		t.Errorf("expected phase Releasing, got %s", status.Phase)
+	}
+}
+
+func TestCreateProvisioningRequestIdempotency(t *testing.T) {
+	c := newNodePoolsClient()
+	controller := &HwMgrAdaptorController{Client: c, Logger: slog.Default()}
+	key := "cluster-1"
+	create := func(hwMgrId string, key *string, body *invserver.ProvisioningRequest) invserver.CreateProvisioningRequestResponseObject {
+		response, err := controller.CreateProvisioningRequest(context.Background(), invserver.CreateProvisioningRequestRequestObject{
+			HwMgrId: hwMgrId,
+			Params:  invserver.CreateProvisioningRequestParams{IdempotencyKey: key},
+			Body:    body,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return response
+	}
+
+	first, ok := create("dell-1", &key, testProvisioningRequest()).(invserver.CreateProvisioningRequest202JSONResponse)
+	if !ok {
+		t.Fatalf("expected the provisioning request to be accepted")
+	}
+	retried, ok := create("dell-1", &key, testProvisioningRequest()).(invserver.CreateProvisioningRequest202JSONResponse)
+	if !ok || retried.ProvisioningRequestId != first.ProvisioningRequestId || len(c.nodepools) != 1 {
+		t.Errorf("expected the retried request to return %s, got %v with %d nodepools",
+			first.ProvisioningRequestId, retried, len(c.nodepools))
+	}
+
+	changed := testProvisioningRequest()
+	changed.NodeGroups[1].Size = 5
+	if response, ok := create("dell-1", &key, changed).(invserver.CreateProvisioningRequest409ApplicationProblemPlusJSONResponse); !ok ||
+		response.Status != http.StatusConflict {
+		t.Errorf("expected a conflict for a different payload with the same key, got %v", response)
+	}
+
+	if _, ok := create("metal3-1", &key, testProvisioningRequest()).(invserver.CreateProvisioningRequest202JSONResponse); !ok ||
+		len(c.nodepools) != 2 {
+		t.Errorf("expected the key of another hardware manager to create a nodepool, got %d nodepools", len(c.nodepools))
+	}
+	create("dell-1", nil, testProvisioningRequest())
+	create("dell-1", nil, testProvisioningRequest())
+	if len(c.nodepools) != 4 {
+		t.Errorf("expected each request without a key to create a nodepool, got %d nodepools", len(c.nodepools))
+	}
+}
@@ -0,0 +1,298 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// resourceTypeNamespace is the namespace of the name-based UUIDs identifying resource types, so that the ID of a type
+// is stable across restarts and replicas
+var resourceTypeNamespace = uuid.MustParse("8f0c7a4e-6d0b-4d67-9b39-2f1f4b0e6c51")
+
+// All resources managed by the plugin are servers
+const (
+	defaultResourceKind  = invserver.ResourceTypeInfoResourceKindPHYSICAL
+	defaultResourceClass = invserver.ResourceTypeInfoResourceClassCOMPUTE
+)
+
+// resourceAttribute describes an attribute of ResourceInfo reported in the resource type schema
+type resourceAttribute struct {
+	name        string
+	attrType    invserver.ResourceTypeAttributeType
+	description string
+	present     func(invserver.ResourceInfo) bool
+}
+
+var resourceAttributes = []resourceAttribute{
+	{
+		name:        "cpuArchitecture",
+		attrType:    invserver.String,
+		description: "The CPU architecture of the resource",
+		present:     func(r invserver.ResourceInfo) bool { return r.CpuArchitecture != nil && *r.CpuArchitecture != "" },
+	},
+	{
+		name:        "globalAssetId",
+		attrType:    invserver.String,
+		description: "Identifier or serial number of the resource",
+		present:     func(r invserver.ResourceInfo) bool { return r.GlobalAssetId != nil && *r.GlobalAssetId != "" },
+	},
+	{
+		name:        "memory",
+		attrType:    invserver.Integer,
+		description: "The total physical memory in MiB",
+		present:     func(r invserver.ResourceInfo) bool { return r.Memory > 0 },
+	},
+	{
+		name:        "partNumber",
+		attrType:    invserver.String,
+		description: "The vendor part number of the resource",
+		present:     func(r invserver.ResourceInfo) bool { return r.PartNumber != "" },
+	},
+	{
+		name:        "powerState",
+		attrType:    invserver.String,
+		description: "The power state of the resource",
+		present:     func(r invserver.ResourceInfo) bool { return r.PowerState != nil },
+	},
+	{
+		name:        "processors",
+		attrType:    invserver.Array,
+		description: "The processors of the resource",
+		present:     func(r invserver.ResourceInfo) bool { return len(r.Processors) > 0 },
+	},
+	{
+		name:        "serialNumber",
+		attrType:    invserver.String,
+		description: "The vendor serial number of the resource",
+		present:     func(r invserver.ResourceInfo) bool { return r.SerialNumber != "" },
+	},
+}
+
+// resourceTypeId returns the ID of the resource type for the given vendor, model and class
+func resourceTypeId(vendor, model string, class invserver.ResourceTypeInfoResourceClass) uuid.UUID {
+	return uuid.NewSHA1(resourceTypeNamespace, []byte(strings.Join([]string{vendor, model, string(class)}, "/")))
+}
+
+// resourceTypeName returns the human readable name of the resource type
+func resourceTypeName(vendor, model string) string {
+	if name := strings.TrimSpace(vendor + " " + model); name != "" {
+		return name
+	}
+	return "Unknown"
+}
+
+// getResourceTypeAttributes returns the schema of the attributes reported for the resources of a type. An attribute is
+// required if it is reported for every resource of the type.
+func getResourceTypeAttributes(resources []invserver.ResourceInfo) []invserver.ResourceTypeAttribute {
+	attributes := []invserver.ResourceTypeAttribute{}
+	for _, attr := range resourceAttributes {
+		count := 0
+		for _, resource := range resources {
+			if attr.present(resource) {
+				count++
+			}
+		}
+		if count > 0 {
+			attributes = append(attributes, invserver.ResourceTypeAttribute{
+				Name:        attr.name,
+				Type:        attr.attrType,
+				Description: attr.description,
+				Required:    count == len(resources),
+			})
+		}
+	}
+
+	labelCounts := make(map[string]int)
+	for _, resource := range resources {
+		if resource.Labels != nil {
+			for key := range *resource.Labels {
+				labelCounts[key]++
+			}
+		}
+	}
+	keys := make([]string, 0, len(labelCounts))
+	for key := range labelCounts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attributes = append(attributes, invserver.ResourceTypeAttribute{
+			Name:        "labels." + key,
+			Type:        invserver.String,
+			Description: fmt.Sprintf("The value of the %s label", key),
+			Required:    labelCounts[key] == len(resources),
+		})
+	}
+
+	return attributes
+}
+
+// getResourceTypes derives the resource types from the inventory, with a type for each combination of vendor, model and
+// resource class, sorted by name
+func getResourceTypes(resources []invserver.ResourceInfo) []invserver.ResourceTypeInfo {
+	byId := make(map[uuid.UUID][]invserver.ResourceInfo)
+	for _, resource := range resources {
+		id := resourceTypeId(resource.Vendor, resource.Model, defaultResourceClass)
+		byId[id] = append(byId[id], resource)
+	}
+
+	resourceTypes := []invserver.ResourceTypeInfo{}
+	for id, members := range byId {
+		pools := []string{}
+		for _, resource := range members {
+			if resource.ResourcePoolId != "" && !slices.Contains(pools, resource.ResourcePoolId) {
+				pools = append(pools, resource.ResourcePoolId)
+			}
+		}
+		sort.Strings(pools)
+
+		resourceTypes = append(resourceTypes, invserver.ResourceTypeInfo{
+			ResourceTypeId:  id,
+			Name:            resourceTypeName(members[0].Vendor, members[0].Model),
+			Vendor:          members[0].Vendor,
+			Model:           members[0].Model,
+			ResourceKind:    defaultResourceKind,
+			ResourceClass:   defaultResourceClass,
+			ResourceCount:   len(members),
+			ResourcePoolIds: pools,
+			Attributes:      getResourceTypeAttributes(members),
+		})
+	}
+
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		if resourceTypes[i].Name != resourceTypes[j].Name {
+			return resourceTypes[i].Name < resourceTypes[j].Name
+		}
+		return resourceTypes[i].ResourceTypeId.String() < resourceTypes[j].ResourceTypeId.String()
+	})
+	return resourceTypes
+}
+
+// getInventoryAdaptor returns the hardware manager and its adaptor for an inventory query, returning the problem details
+// to respond with on failure
+func (c *HwMgrAdaptorController) getInventoryAdaptor(ctx context.Context, hwMgrId string) (
+	*pluginv1alpha1.HardwareManager, adaptorinterface.HwMgrAdaptorIntf, *invserver.ProblemDetails, error) {
+	if !c.IsInventoryReady() {
+		return nil, nil, &invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: "Inventory is not yet available, warm-up in progress",
+		}, nil
+	}
+
+	hwmgr, statusCode, err := c.getHwMgr(ctx, hwMgrId)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, nil, &invserver.ProblemDetails{
+				Status: statusCode,
+				Detail: fmt.Sprintf("Hardware Manager %s not found", hwMgrId),
+			}, fmt.Errorf("hardware manager %s not found: %w", hwMgrId, err)
+		}
+		return nil, nil, &invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: fmt.Sprintf("Hardware Manager %s unavailable: %s", hwMgrId, err.Error()),
+		}, fmt.Errorf("unable to get hardware manager %s: %w", hwMgrId, err)
+	}
+
+	adaptorID := string(hwmgr.Spec.AdaptorID)
+	adaptor, exists := c.adaptors[adaptorID]
+	if !exists {
+		// We should never get here, as the adaptor ID is validated in getHwMgr
+		c.Logger.ErrorContext(ctx, "unsupported adaptor ID", slog.String("adaptorID", adaptorID))
+		return nil, nil, &invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Hardware Manager %s specifies invalid adaptorId: %s", hwMgrId, adaptorID),
+		}, fmt.Errorf("hardware manager %s specifies invalid adaptorId: %s", hwMgrId, adaptorID)
+	}
+
+	if reason := adaptorDisabledReason(adaptor); reason != "" {
+		return nil, nil, &invserver.ProblemDetails{
+			Status: http.StatusServiceUnavailable,
+			Detail: fmt.Sprintf("Adaptor %s of Hardware Manager %s is disabled: %s", adaptorID, hwMgrId, reason),
+		}, nil
+	}
+
+	return hwmgr, adaptor, nil, nil
+}
+
+// queryResources gets the resources of the hardware manager, returning the problem details to respond with on failure
+func (c *HwMgrAdaptorController) queryResources(ctx context.Context, hwMgrId string) ([]invserver.ResourceInfo, *invserver.ProblemDetails, error) {
+	hwmgr, adaptor, problem, err := c.getInventoryAdaptor(ctx, hwMgrId)
+	if problem != nil {
+		return nil, problem, err
+	}
+
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
+
+	resources, _, err := adaptor.GetResources(opCtx, hwmgr)
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "unable to get resources from hardware manager", slog.String("hwMgrId", hwMgrId), slog.String("error", err.Error()))
+		return nil, &invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Resource query failed for %s: %s", hwMgrId, err.Error()),
+		}, fmt.Errorf("unable to query resources from hardware manager %s: %w", hwMgrId, err)
+	}
+
+	return resources, nil, nil
+}
+
+// GetResourceTypes derives the resource types of the hardware manager from its inventory
+func (c *HwMgrAdaptorController) GetResourceTypes(ctx context.Context, request invserver.GetResourceTypesRequestObject) (invserver.GetResourceTypesResponseObject, error) {
+	resources, problem, err := c.queryResources(ctx, request.HwMgrId)
+	if problem != nil {
+		switch problem.Status {
+		case http.StatusNotFound:
+			return invserver.GetResourceTypes404ApplicationProblemPlusJSONResponse(*problem), err
+		case http.StatusServiceUnavailable:
+			return invserver.GetResourceTypes503ApplicationProblemPlusJSONResponse(*problem), err
+		default:
+			return invserver.GetResourceTypes500ApplicationProblemPlusJSONResponse(*problem), err
+		}
+	}
+
+	return invserver.GetResourceTypes200JSONResponse(getResourceTypes(resources)), nil
+}
+
+// GetResourceType returns a resource type of the hardware manager, derived from its inventory
+func (c *HwMgrAdaptorController) GetResourceType(ctx context.Context, request invserver.GetResourceTypeRequestObject) (invserver.GetResourceTypeResponseObject, error) {
+	resources, problem, err := c.queryResources(ctx, request.HwMgrId)
+	if problem != nil {
+		switch problem.Status {
+		case http.StatusNotFound:
+			return invserver.GetResourceType404ApplicationProblemPlusJSONResponse(*problem), err
+		case http.StatusServiceUnavailable:
+			return invserver.GetResourceType503ApplicationProblemPlusJSONResponse(*problem), err
+		default:
+			return invserver.GetResourceType500ApplicationProblemPlusJSONResponse(*problem), err
+		}
+	}
+
+	for _, resourceType := range getResourceTypes(resources) {
+		if resourceType.ResourceTypeId == request.ResourceTypeId {
+			return invserver.GetResourceType200JSONResponse(resourceType), nil
+		}
+	}
+
+	return invserver.GetResourceType404ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+		Status: http.StatusNotFound,
+		Detail: fmt.Sprintf("Resource type %s not found", request.ResourceTypeId),
+	}), nil
+}
@@ -0,0 +1,106 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestGetResourceTypes(t *testing.T) {
+	arch := "x86_64"
+	resources := []invserver.ResourceInfo{
+		{ResourceId: "r1", ResourcePoolId: "pool-b", Vendor: "Dell Inc.", Model: "PowerEdge XR8620t", Memory: 1024,
+			CpuArchitecture: &arch, Labels: &map[string]string{"rack": "r1"}},
+		{ResourceId: "r2", ResourcePoolId: "pool-a", Vendor: "Dell Inc.", Model: "PowerEdge XR8620t", Memory: 1024},
+		{ResourceId: "r3", ResourcePoolId: "pool-a", Vendor: "HPE", Model: "ProLiant DL110"},
+	}
+
+	resourceTypes := getResourceTypes(resources)
+	if len(resourceTypes) != 2 {
+		t.Fatalf("expected 2 resource types, got %d", len(resourceTypes))
+	}
+
+	dell := resourceTypes[0]
+	if dell.Name != "Dell Inc. PowerEdge XR8620t" || dell.ResourceCount != 2 ||
+		dell.ResourceKind != invserver.ResourceTypeInfoResourceKindPHYSICAL ||
+		dell.ResourceClass != invserver.ResourceTypeInfoResourceClassCOMPUTE {
+		t.Errorf("unexpected resource type: %+v", dell)
+	}
+	if len(dell.ResourcePoolIds) != 2 || dell.ResourcePoolIds[0] != "pool-a" || dell.ResourcePoolIds[1] != "pool-b" {
+		t.Errorf("unexpected resource pools: %v", dell.ResourcePoolIds)
+	}
+	if dell.ResourceTypeId != resourceTypeId("Dell Inc.", "PowerEdge XR8620t", invserver.ResourceTypeInfoResourceClassCOMPUTE) {
+		t.Errorf("expected stable resource type ID, got %s", dell.ResourceTypeId)
+	}
+
+	expected := map[string]bool{"cpuArchitecture": false, "memory": true, "labels.rack": false}
+	if len(dell.Attributes) != len(expected) {
+		t.Fatalf("unexpected attributes: %+v", dell.Attributes)
+	}
+	for _, attr := range dell.Attributes {
+		required, ok := expected[attr.Name]
+		if !ok || attr.Required != required {
+			t.Errorf("unexpected attribute: %+v", attr)
+		}
+	}
+
+	if hpe := resourceTypes[1]; hpe.Name != "HPE ProLiant DL110" || hpe.ResourceCount != 1 || len(hpe.Attributes) != 0 {
+		t.Errorf("unexpected resource type: %+v", hpe)
+	}
+}
+
+func TestGetResourceTypeWithFakeAdaptor(t *testing.T) {
+	fake := testsupport.NewFakeAdaptor()
+	fake.Resources = []invserver.ResourceInfo{{ResourceId: "node-1", Vendor: "Dell Inc.", Model: "PowerEdge R750"}}
+
+	c := &HwMgrAdaptorController{
+		Client: &hwmgrClient{hwmgr: &pluginv1alpha1.HardwareManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1"},
+			Spec:       pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+		}},
+		Logger: slog.Default(),
+	}
+	c.RegisterAdaptor(LoopbackAdaptorID, fake)
+
+	resp, _ := c.GetResourceTypes(context.Background(), invserver.GetResourceTypesRequestObject{HwMgrId: "hwmgr-1"})
+	if _, ok := resp.(invserver.GetResourceTypes503ApplicationProblemPlusJSONResponse); !ok {
+		t.Fatalf("expected 503 response before warm-up, got %T", resp)
+	}
+
+	c.MarkInventoryReady()
+	resp, err := c.GetResourceTypes(context.Background(), invserver.GetResourceTypesRequestObject{HwMgrId: "hwmgr-1"})
+	resourceTypes, ok := resp.(invserver.GetResourceTypes200JSONResponse)
+	if err != nil || !ok || len(resourceTypes) != 1 {
+		t.Fatalf("unexpected response: %#v, %v", resp, err)
+	}
+
+	single, err := c.GetResourceType(context.Background(), invserver.GetResourceTypeRequestObject{
+		HwMgrId: "hwmgr-1", ResourceTypeId: resourceTypes[0].ResourceTypeId})
+	if _, ok := single.(invserver.GetResourceType200JSONResponse); err != nil || !ok {
+		t.Errorf("unexpected response: %#v, %v", single, err)
+	}
+
+	single, _ = c.GetResourceType(context.Background(), invserver.GetResourceTypeRequestObject{
+		HwMgrId: "hwmgr-1", ResourceTypeId: uuid.New()})
+	if _, ok := single.(invserver.GetResourceType404ApplicationProblemPlusJSONResponse); !ok {
+		t.Errorf("expected 404 response for unknown resource type, got %T", single)
+	}
+
+	resp, _ = c.GetResourceTypes(context.Background(), invserver.GetResourceTypesRequestObject{HwMgrId: "missing"})
+	if _, ok := resp.(invserver.GetResourceTypes404ApplicationProblemPlusJSONResponse); !ok {
+		t.Errorf("expected 404 response for unknown hardware manager, got %T", resp)
+	}
+}
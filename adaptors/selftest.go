@@ -0,0 +1,213 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// Names of the self-test checks run for all adaptors
+const (
+	SelfTestCheckAdaptor          = "Adaptor"
+	SelfTestCheckInventoryRead    = "InventoryRead"
+	SelfTestCheckDryRunAllocation = "DryRunAllocation"
+)
+
+// selfTestReconciler runs the self-test of a HardwareManager requested with the self-test annotation, and publishes
+// its report in the HardwareManager status
+type selfTestReconciler struct {
+	controller *HwMgrAdaptorController
+}
+
+// dryRunAllocation checks that the inventory has idle resources in a resource pool, which a NodePool could be
+// allocated from, without allocating them. The allocation is only simulated from the inventory read by the self-test:
+// the hardware manager is not asked to validate it, as the backends offer no dry-run of an allocation, so the check
+// cannot catch an allocation rejected by the backend, such as for quota or resource profile constraints.
+func dryRunAllocation(pools []invserver.ResourcePoolInfo, resources []invserver.ResourceInfo) pluginv1alpha1.SelfTestCheck {
+	known := make(map[string]bool, len(pools))
+	for _, pool := range pools {
+		known[pool.ResourcePoolId] = true
+	}
+
+	idle := make(map[string]int)
+	count := 0
+	for _, resource := range resources {
+		if resource.UsageState == invserver.IDLE && known[resource.ResourcePoolId] {
+			idle[resource.ResourcePoolId]++
+			count++
+		}
+	}
+
+	if count == 0 {
+		return pluginv1alpha1.SelfTestCheck{
+			Name:    SelfTestCheckDryRunAllocation,
+			Result:  pluginv1alpha1.SelfTestResults.Failed,
+			Message: fmt.Sprintf("No idle resources to allocate among %d resources", len(resources)),
+		}
+	}
+
+	poolIds := make([]string, 0, len(idle))
+	for poolId := range idle {
+		poolIds = append(poolIds, poolId)
+	}
+	sort.Strings(poolIds)
+	counts := make([]string, 0, len(poolIds))
+	for _, poolId := range poolIds {
+		counts = append(counts, fmt.Sprintf("%s=%d", poolId, idle[poolId]))
+	}
+	message := fmt.Sprintf("%d idle resources available for allocation, not validated by the backend: %s",
+		count, strings.Join(counts, ", "))
+	return pluginv1alpha1.SelfTestCheck{
+		Name:    SelfTestCheckDryRunAllocation,
+		Result:  pluginv1alpha1.SelfTestResults.Passed,
+		Message: message,
+	}
+}
+
+// runSelfTest runs the checks of the self-test of the HardwareManager: the checks of its adaptor, if any, followed by
+// an inventory read and a dry-run allocation simulated from the inventory. None of the checks change the backend.
+func (c *HwMgrAdaptorController) runSelfTest(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) []pluginv1alpha1.SelfTestCheck {
+	adaptor, exists := c.adaptors[string(hwmgr.Spec.AdaptorID)]
+	if !exists {
+		return []pluginv1alpha1.SelfTestCheck{{
+			Name:    SelfTestCheckAdaptor,
+			Result:  pluginv1alpha1.SelfTestResults.Failed,
+			Message: fmt.Sprintf("Unsupported adaptor ID: %s", hwmgr.Spec.AdaptorID),
+		}}
+	}
+	if reason := adaptorDisabledReason(adaptor); reason != "" {
+		return []pluginv1alpha1.SelfTestCheck{{
+			Name:    SelfTestCheckAdaptor,
+			Result:  pluginv1alpha1.SelfTestResults.Failed,
+			Message: reason,
+		}}
+	}
+
+	var checks []pluginv1alpha1.SelfTestCheck
+	if tester, ok := adaptor.(adaptorinterface.HwMgrAdaptorSelfTestIntf); ok {
+		checks = append(checks, tester.SelfTest(ctx, hwmgr)...)
+	}
+
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
+
+	pools, _, err := adaptor.GetResourcePools(opCtx, hwmgr)
+	var resources []invserver.ResourceInfo
+	if err == nil {
+		resources, _, err = adaptor.GetResources(opCtx, hwmgr)
+	}
+	if err != nil {
+		return append(checks,
+			pluginv1alpha1.SelfTestCheck{
+				Name:    SelfTestCheckInventoryRead,
+				Result:  pluginv1alpha1.SelfTestResults.Failed,
+				Message: err.Error(),
+			},
+			pluginv1alpha1.SelfTestCheck{
+				Name:    SelfTestCheckDryRunAllocation,
+				Result:  pluginv1alpha1.SelfTestResults.Skipped,
+				Message: "The inventory could not be read",
+			})
+	}
+
+	return append(checks,
+		pluginv1alpha1.SelfTestCheck{
+			Name:    SelfTestCheckInventoryRead,
+			Result:  pluginv1alpha1.SelfTestResults.Passed,
+			Message: fmt.Sprintf("Read %d resource pools and %d resources", len(pools), len(resources)),
+		},
+		dryRunAllocation(pools, resources))
+}
+
+// selfTestPassed checks whether none of the checks failed
+func selfTestPassed(checks []pluginv1alpha1.SelfTestCheck) bool {
+	for _, check := range checks {
+		if check.Result == pluginv1alpha1.SelfTestResults.Failed {
+			return false
+		}
+	}
+	return true
+}
+
+// Reconcile runs the self-test of the HardwareManager if the value of its self-test annotation differs from that of
+// the last report
+func (r *selfTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	c := r.controller
+
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if err := c.Client.Get(ctx, req.NamespacedName, hwmgr); err != nil {
+		if errors.IsNotFound(err) {
+			return utils.DoNotRequeue(), nil
+		}
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to get HardwareManager %s: %w", req.Name, err)
+	}
+
+	request, requested := hwmgr.GetAnnotations()[utils.SelfTestAnnotation]
+	if !requested || (hwmgr.Status.SelfTest != nil && hwmgr.Status.SelfTest.Request == request) {
+		return utils.DoNotRequeue(), nil
+	}
+
+	ctx = logging.AppendCtx(ctx, slog.String("hwmgr", hwmgr.Name))
+	c.Logger.InfoContext(ctx, "Running self-test", slog.String("request", request))
+
+	report := &pluginv1alpha1.SelfTestReport{
+		Request:   request,
+		StartTime: metav1.Now(),
+	}
+	report.Checks = c.runSelfTest(ctx, hwmgr)
+	report.CompletionTime = metav1.Now()
+	report.Passed = selfTestPassed(report.Checks)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &pluginv1alpha1.HardwareManager{}
+		if err := c.Client.Get(ctx, req.NamespacedName, latest); err != nil {
+			return err // nolint: wrapcheck
+		}
+		latest.Status.SelfTest = report
+		return c.Client.Status().Update(ctx, latest) // nolint: wrapcheck
+	})
+	if err != nil {
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update self-test report of HardwareManager %s: %w", hwmgr.Name, err)
+	}
+
+	c.Logger.InfoContext(ctx, "Completed self-test", slog.Bool("passed", report.Passed))
+	return utils.DoNotRequeue(), nil
+}
+
+// setupSelfTest registers the controller running the self-tests requested on HardwareManagers with the manager
+func (c *HwMgrAdaptorController) setupSelfTest(mgr ctrl.Manager) error {
+	hasSelfTest := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		_, exists := object.GetAnnotations()[utils.SelfTestAnnotation]
+		return exists
+	})
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("hardwaremanager-selftest").
+		For(&pluginv1alpha1.HardwareManager{}, builder.WithPredicates(hasSelfTest, predicate.AnnotationChangedPredicate{})).
+		Complete(&selfTestReconciler{controller: c}); err != nil {
+		return fmt.Errorf("failed to setup self-test controller: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestDryRunAllocation(t *testing.T) {
+	pools := []invserver.ResourcePoolInfo{{ResourcePoolId: "pool-1"}, {ResourcePoolId: "pool-2"}}
+	resources := []invserver.ResourceInfo{
+		{ResourceId: "node-1", ResourcePoolId: "pool-1", UsageState: invserver.IDLE},
+		{ResourceId: "node-2", ResourcePoolId: "pool-1", UsageState: invserver.BUSY},
+		{ResourceId: "node-3", ResourcePoolId: "pool-2", UsageState: invserver.IDLE},
+		{ResourceId: "node-4", ResourcePoolId: "unknown", UsageState: invserver.IDLE},
+	}
+
+	check := dryRunAllocation(pools, resources)
+	if check.Result != pluginv1alpha1.SelfTestResults.Passed {
+		t.Errorf("expected the dry-run allocation to pass, got %+v", check)
+	}
+	if expected := "2 idle resources available for allocation, not validated by the backend: pool-1=1, pool-2=1"; check.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, check.Message)
+	}
+
+	check = dryRunAllocation(pools, resources[1:2])
+	if check.Result != pluginv1alpha1.SelfTestResults.Failed {
+		t.Errorf("expected the dry-run allocation to fail without idle resources, got %+v", check)
+	}
+}
+
+func TestRunSelfTestWithFakeAdaptor(t *testing.T) {
+	fake := testsupport.NewFakeAdaptor()
+	fake.SelfTestChecks = []pluginv1alpha1.SelfTestCheck{
+		{Name: "Authentication", Result: pluginv1alpha1.SelfTestResults.Passed},
+	}
+	fake.ResourcePools = []invserver.ResourcePoolInfo{{ResourcePoolId: "pool-1"}}
+	fake.Resources = []invserver.ResourceInfo{{ResourceId: "node-1", ResourcePoolId: "pool-1", UsageState: invserver.IDLE}}
+
+	c := &HwMgrAdaptorController{Logger: slog.Default()}
+	c.RegisterAdaptor(LoopbackAdaptorID, fake)
+	hwmgr := &pluginv1alpha1.HardwareManager{
+		Spec: pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+	}
+	ctx := context.Background()
+
+	checks := c.runSelfTest(ctx, hwmgr)
+	names := []string{"Authentication", SelfTestCheckInventoryRead, SelfTestCheckDryRunAllocation}
+	if len(checks) != len(names) {
+		t.Fatalf("expected %d checks, got %+v", len(names), checks)
+	}
+	for i, name := range names {
+		if checks[i].Name != name || checks[i].Result != pluginv1alpha1.SelfTestResults.Passed {
+			t.Errorf("expected check %s to pass, got %+v", name, checks[i])
+		}
+	}
+	if !selfTestPassed(checks) {
+		t.Errorf("expected the self-test to pass")
+	}
+
+	fake.ResourcesErr = errors.New("connection refused")
+	checks = c.runSelfTest(ctx, hwmgr)
+	if checks[1].Result != pluginv1alpha1.SelfTestResults.Failed || checks[2].Result != pluginv1alpha1.SelfTestResults.Skipped {
+		t.Errorf("expected a failed inventory read and a skipped dry-run allocation, got %+v", checks)
+	}
+	if selfTestPassed(checks) {
+		t.Errorf("expected the self-test to fail")
+	}
+
+	fake.Disabled = "BareMetalHost CRD is not installed"
+	checks = c.runSelfTest(ctx, hwmgr)
+	if len(checks) != 1 || checks[0].Name != SelfTestCheckAdaptor || checks[0].Result != pluginv1alpha1.SelfTestResults.Failed {
+		t.Errorf("expected a disabled adaptor to fail the self-test, got %+v", checks)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// getSites derives the sites from the inventory, from the site of each resource pool, with the aggregate capacity of
+// the resources of their pools, sorted by ID. Resource pools without a site are not reported.
+func getSites(pools []invserver.ResourcePoolInfo, resources []invserver.ResourceInfo) []invserver.SiteInfo {
+	bySite := make(map[string]*invserver.SiteInfo)
+	poolSites := make(map[string]*invserver.SiteInfo)
+	for _, pool := range pools {
+		if pool.SiteId == nil || *pool.SiteId == "" {
+			continue
+		}
+		site, exists := bySite[*pool.SiteId]
+		if !exists {
+			site = &invserver.SiteInfo{SiteId: *pool.SiteId, ResourcePoolIds: []string{}}
+			bySite[*pool.SiteId] = site
+		}
+		if _, counted := poolSites[pool.ResourcePoolId]; !counted {
+			site.ResourcePoolIds = append(site.ResourcePoolIds, pool.ResourcePoolId)
+			poolSites[pool.ResourcePoolId] = site
+		}
+	}
+
+	for _, resource := range resources {
+		site, exists := poolSites[resource.ResourcePoolId]
+		if !exists {
+			continue
+		}
+		site.ResourceCount++
+		if resource.UsageState == invserver.IDLE {
+			site.IdleResourceCount++
+		}
+		site.Memory += resource.Memory
+		for _, processor := range resource.Processors {
+			if processor.Cores != nil {
+				site.Cores += *processor.Cores
+			}
+		}
+	}
+
+	sites := make([]invserver.SiteInfo, 0, len(bySite))
+	for _, site := range bySite {
+		sort.Strings(site.ResourcePoolIds)
+		site.ResourcePoolCount = len(site.ResourcePoolIds)
+		sites = append(sites, *site)
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i].SiteId < sites[j].SiteId })
+	return sites
+}
+
+// GetSites derives the sites of the hardware manager from its inventory
+func (c *HwMgrAdaptorController) GetSites(ctx context.Context, request invserver.GetSitesRequestObject) (invserver.GetSitesResponseObject, error) {
+	hwmgr, adaptor, problem, err := c.getInventoryAdaptor(ctx, request.HwMgrId)
+	if problem != nil {
+		switch problem.Status {
+		case http.StatusNotFound:
+			return invserver.GetSites404ApplicationProblemPlusJSONResponse(*problem), err
+		case http.StatusServiceUnavailable:
+			return invserver.GetSites503ApplicationProblemPlusJSONResponse(*problem), err
+		default:
+			return invserver.GetSites500ApplicationProblemPlusJSONResponse(*problem), err
+		}
+	}
+
+	opCtx, cancel := utils.WithOperationTimeout(ctx, hwmgr, utils.OperationInventoryQuery)
+	defer cancel()
+
+	pools, _, err := adaptor.GetResourcePools(opCtx, hwmgr)
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "unable to get resource pools from hardware manager", slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
+		return invserver.GetSites500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Resource Pool query failed for %s: %s", request.HwMgrId, err.Error()),
+		}), fmt.Errorf("unable to query pools from hardware manager %s: %w", request.HwMgrId, err)
+	}
+
+	resources, _, err := adaptor.GetResources(opCtx, hwmgr)
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "unable to get resources from hardware manager", slog.String("hwMgrId", request.HwMgrId), slog.String("error", err.Error()))
+		return invserver.GetSites500ApplicationProblemPlusJSONResponse(invserver.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Resource query failed for %s: %s", request.HwMgrId, err.Error()),
+		}), fmt.Errorf("unable to query resources from hardware manager %s: %w", request.HwMgrId, err)
+	}
+
+	return invserver.GetSites200JSONResponse(getSites(pools, resources)), nil
+}
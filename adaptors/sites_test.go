@@ -0,0 +1,79 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/testsupport"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestGetSites(t *testing.T) {
+	site := func(id string) *string { return &id }
+	cores := func(count int) *int { return &count }
+	pools := []invserver.ResourcePoolInfo{
+		{ResourcePoolId: "pool-b", SiteId: site("rdu3")},
+		{ResourcePoolId: "pool-a", SiteId: site("rdu3")},
+		{ResourcePoolId: "pool-c", SiteId: site("bos2")},
+		{ResourcePoolId: "pool-d"},
+	}
+	resources := []invserver.ResourceInfo{
+		{ResourceId: "r1", ResourcePoolId: "pool-a", UsageState: invserver.IDLE, Memory: 1024,
+			Processors: []invserver.ProcessorInfo{{Cores: cores(16)}, {Cores: cores(16)}}},
+		{ResourceId: "r2", ResourcePoolId: "pool-b", UsageState: invserver.BUSY, Memory: 2048,
+			Processors: []invserver.ProcessorInfo{{}}},
+		{ResourceId: "r3", ResourcePoolId: "pool-d", UsageState: invserver.IDLE, Memory: 512},
+	}
+
+	sites := getSites(pools, resources)
+	if len(sites) != 2 {
+		t.Fatalf("expected 2 sites, got %+v", sites)
+	}
+
+	if bos := sites[0]; bos.SiteId != "bos2" || bos.ResourcePoolCount != 1 || bos.ResourceCount != 0 {
+		t.Errorf("unexpected site: %+v", bos)
+	}
+	rdu := sites[1]
+	if rdu.SiteId != "rdu3" || rdu.ResourcePoolCount != 2 || rdu.ResourcePoolIds[0] != "pool-a" || rdu.ResourcePoolIds[1] != "pool-b" {
+		t.Errorf("unexpected site pools: %+v", rdu)
+	}
+	if rdu.ResourceCount != 2 || rdu.IdleResourceCount != 1 || rdu.Memory != 3072 || rdu.Cores != 32 {
+		t.Errorf("unexpected site capacity: %+v", rdu)
+	}
+}
+
+func TestGetSitesWithFakeAdaptor(t *testing.T) {
+	siteId := "rdu3"
+	fake := testsupport.NewFakeAdaptor()
+	fake.ResourcePools = []invserver.ResourcePoolInfo{{ResourcePoolId: "pool-a", SiteId: &siteId}}
+	fake.Resources = []invserver.ResourceInfo{{ResourceId: "node-1", ResourcePoolId: "pool-a"}}
+
+	c := &HwMgrAdaptorController{
+		Client: &hwmgrClient{hwmgr: &pluginv1alpha1.HardwareManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "hwmgr-1"},
+			Spec:       pluginv1alpha1.HardwareManagerSpec{AdaptorID: pluginv1alpha1.SupportedAdaptors.Loopback},
+		}},
+		Logger: slog.Default(),
+	}
+	c.RegisterAdaptor(LoopbackAdaptorID, fake)
+	c.MarkInventoryReady()
+
+	resp, err := c.GetSites(context.Background(), invserver.GetSitesRequestObject{HwMgrId: "hwmgr-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sites, ok := resp.(invserver.GetSites200JSONResponse)
+	if !ok || len(sites) != 1 || sites[0].SiteId != siteId || sites[0].ResourceCount != 1 {
+		t.Errorf("unexpected response: %#v", resp)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/supermicro/controller"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+type Adaptor struct {
+	client.Client
+	NoncachedClient client.Reader
+	Scheme          *runtime.Scheme
+	Logger          *slog.Logger
+	Namespace       string
+	AdaptorID       pluginv1alpha1.HardwareManagerAdaptorID
+	// Clock is the source of time of the profile update timeouts and status timestamps of the adaptor, the real
+	// clock if not set
+	Clock utils.Clock
+}
+
+func NewAdaptor(client client.Client, noncachedClient client.Reader, scheme *runtime.Scheme, logger *slog.Logger, namespace string) *Adaptor {
+	return &Adaptor{
+		Client:          client,
+		NoncachedClient: noncachedClient,
+		Scheme:          scheme,
+		Logger:          logger.With(slog.String("adaptor", "supermicro")),
+		Namespace:       namespace,
+	}
+}
+
+// clock returns the clock of the adaptor
+func (a *Adaptor) clock() utils.Clock {
+	return utils.ClockOrReal(a.Clock)
+}
+
+// SetupAdaptor sets up the Supermicro adaptor
+func (a *Adaptor) SetupAdaptor(mgr ctrl.Manager) error {
+	a.Logger.Info("SetupAdaptor called for Supermicro")
+
+	if err := (&controller.HardwareManagerReconciler{
+		Client:    a.Client,
+		Scheme:    a.Scheme,
+		Logger:    a.Logger,
+		Namespace: a.Namespace,
+		Clock:     a.Clock,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup supermicro adaptor: %w", err)
+	}
+
+	return nil
+}
+
+// Supermicro Adaptor FSM
+type fsmAction int
+
+const (
+	NodePoolFSMCreate = iota
+	NodePoolFSMProcessing
+	NodePoolFSMSpecChanged
+	NodePoolFSMNoop
+)
+
+func (a *Adaptor) determineAction(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) fsmAction {
+	if len(nodepool.Status.Conditions) == 0 {
+		a.Logger.InfoContext(ctx, "Handling Create NodePool request")
+		return NodePoolFSMCreate
+	}
+
+	provisionedCondition := meta.FindStatusCondition(
+		nodepool.Status.Conditions,
+		string(hwmgmtv1alpha1.Provisioned))
+	if provisionedCondition != nil {
+		if provisionedCondition.Status == metav1.ConditionTrue {
+			// Check if the generation has changed
+			if nodepool.ObjectMeta.Generation != nodepool.Status.HwMgrPlugin.ObservedGeneration {
+				a.Logger.InfoContext(ctx, "Handling NodePool Spec change")
+				return NodePoolFSMSpecChanged
+			}
+			a.Logger.InfoContext(ctx, "NodePool request in Provisioned state")
+			return NodePoolFSMNoop
+		}
+
+		if provisionedCondition.Reason == string(hwmgmtv1alpha1.Failed) {
+			a.Logger.InfoContext(ctx, "NodePool request in Failed state")
+			return NodePoolFSMNoop
+		}
+
+		return NodePoolFSMProcessing
+	}
+
+	return NodePoolFSMNoop
+}
+
+func (a *Adaptor) HandleNodePool(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+	result := utils.DoNotRequeue()
+
+	if hwmgr.Spec.SupermicroData == nil {
+		return result, fmt.Errorf("required config data missing from HardwareManager: name=%s", hwmgr.Name)
+	}
+
+	switch a.determineAction(ctx, nodepool) {
+	case NodePoolFSMCreate:
+		return a.HandleNodePoolCreate(ctx, hwmgr, nodepool)
+	case NodePoolFSMProcessing:
+		return a.HandleNodePoolProcessing(ctx, hwmgr, nodepool)
+	case NodePoolFSMSpecChanged:
+		return a.HandleNodePoolSpecChanged(ctx, hwmgr, nodepool)
+	case NodePoolFSMNoop:
+		// Nothing to do
+		return result, nil
+	}
+
+	return result, nil
+}
+
+func (a *Adaptor) HandleNodePoolDeletion(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+	a.Logger.InfoContext(ctx, "Finalizing nodepool")
+
+	if err := a.ReleaseNodePool(ctx, hwmgr, nodepool); err != nil {
+		return false, fmt.Errorf("failed to release nodepool %s: %w", nodepool.Name, err)
+	}
+
+	return true, nil
+}
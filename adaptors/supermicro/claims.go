@@ -0,0 +1,115 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// The servers claimed by the NodePools of a HardwareManager are recorded in a ConfigMap, mapping each server to the
+// NodePool claiming it. A server is claimed before its Node is created, and each claim is written conditioned on the
+// resourceVersion of the ConfigMap read when selecting the servers, so that two NodePools allocated concurrently cannot
+// claim the same server: the second write fails with a conflict, and its allocation is retried on the next pass.
+
+// claimsConfigMapName returns the name of the ConfigMap holding the server claims of a HardwareManager
+func claimsConfigMapName(hwmgr *pluginv1alpha1.HardwareManager) string {
+	return hwmgr.Name + "-supermicro-claims"
+}
+
+// getServerClaims reads the server claims of a HardwareManager. A ConfigMap without a resourceVersion is returned if
+// no server has been claimed yet.
+func (a *Adaptor) getServerClaims(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: claimsConfigMapName(hwmgr), Namespace: a.Namespace}
+	if err := a.NoncachedClient.Get(ctx, key, cm); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get server claims %s: %w", key.Name, err)
+		}
+		cm.Name = key.Name
+		cm.Namespace = key.Namespace
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	return cm, nil
+}
+
+// claimServers records the claims of a NodePool on servers, failing with a conflict if the claims have changed since
+// they were read
+func (a *Adaptor) claimServers(ctx context.Context, cm *corev1.ConfigMap, nodepool *hwmgmtv1alpha1.NodePool,
+	servers []*pluginv1alpha1.SupermicroServer) error {
+	for _, server := range servers {
+		if owner, claimed := cm.Data[server.Name]; claimed && owner != nodepool.Name {
+			return fmt.Errorf("server %s is claimed by NodePool %s", server.Name, owner)
+		}
+		cm.Data[server.Name] = nodepool.Name
+	}
+
+	var err error
+	if cm.ResourceVersion == "" {
+		err = a.Client.Create(ctx, cm)
+	} else {
+		err = a.Client.Update(ctx, cm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim servers for NodePool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
+
+// releaseServerClaims removes the claims of a NodePool
+func (a *Adaptor) releaseServerClaims(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := a.getServerClaims(ctx, hwmgr)
+		if err != nil || cm.ResourceVersion == "" {
+			return err
+		}
+
+		released := 0
+		for server, owner := range cm.Data {
+			if owner == nodepool.Name {
+				delete(cm.Data, server)
+				released++
+			}
+		}
+		if released == 0 {
+			return nil
+		}
+		a.Logger.InfoContext(ctx, "Releasing server claims", slog.Int("servers", released))
+		return a.Client.Update(ctx, cm) // nolint: wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release server claims of NodePool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
+
+// unavailableServers returns the servers that cannot be allocated to a NodePool: those allocated to a Node, mapped to
+// the Node name, and those claimed by another NodePool, mapped to the NodePool name. The servers claimed by the
+// NodePool itself but not yet allocated to a Node, as after an interrupted pass, are available to it.
+func unavailableServers(allocated map[string]string, claims *corev1.ConfigMap, nodepool *hwmgmtv1alpha1.NodePool) map[string]string {
+	unavailable := make(map[string]string, len(allocated)+len(claims.Data))
+	for server, owner := range claims.Data {
+		if owner != nodepool.Name {
+			unavailable[server] = owner
+		}
+	}
+	for server, nodename := range allocated {
+		unavailable[server] = nodename
+	}
+	return unavailable
+}
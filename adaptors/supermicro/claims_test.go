@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// claimsClient stores the claims ConfigMap, rejecting writes conditioned on a stale resourceVersion as the API server
+// does
+type claimsClient struct {
+	client.Client
+	cm      *corev1.ConfigMap
+	version int
+}
+
+func (c *claimsClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	if c.cm == nil {
+		return errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	c.cm.DeepCopyInto(obj.(*corev1.ConfigMap))
+	return nil
+}
+
+func (c *claimsClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	if c.cm != nil {
+		return errors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, obj.GetName())
+	}
+	return c.store(obj)
+}
+
+func (c *claimsClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	if c.cm == nil || obj.GetResourceVersion() != c.cm.ResourceVersion {
+		return errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), nil)
+	}
+	return c.store(obj)
+}
+
+func (c *claimsClient) store(obj client.Object) error {
+	c.version++
+	obj.SetResourceVersion(strconv.Itoa(c.version))
+	c.cm = obj.(*corev1.ConfigMap).DeepCopy()
+	return nil
+}
+
+func TestClaimServers(t *testing.T) {
+	c := &claimsClient{}
+	a := &Adaptor{Client: c, NoncachedClient: c, Logger: slog.Default(), Namespace: "hwmgr"}
+	hwmgr := &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{Name: "supermicro"}}
+	ctx := context.Background()
+
+	first := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "first"}}
+	second := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "second"}}
+	edge1, edge2 := testServer("edge-1", "pool-a", ""), testServer("edge-2", "pool-a", "")
+
+	// Both NodePools read the claims before either writes them
+	firstClaims, err := a.getServerClaims(ctx, hwmgr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secondClaims, err := a.getServerClaims(ctx, hwmgr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := a.claimServers(ctx, firstClaims, first, []*pluginv1alpha1.SupermicroServer{&edge1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.claimServers(ctx, secondClaims, second, []*pluginv1alpha1.SupermicroServer{&edge1}); !errors.IsAlreadyExists(err) {
+		t.Fatalf("expected the concurrent claim to fail, got %v", err)
+	}
+	if c.cm.Name != "supermicro-supermicro-claims" || c.cm.Data["edge-1"] != "first" {
+		t.Fatalf("unexpected claims %+v", c.cm)
+	}
+
+	// Claims read before the last write are stale
+	stale := c.cm.DeepCopy()
+	if err := a.claimServers(ctx, firstClaims, first, []*pluginv1alpha1.SupermicroServer{&edge2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.claimServers(ctx, stale, second, []*pluginv1alpha1.SupermicroServer{&edge2}); !errors.IsConflict(err) {
+		t.Errorf("expected a conflict for stale claims, got %v", err)
+	}
+
+	// The servers claimed by another NodePool are unavailable, while those of the NodePool itself can be reused
+	secondClaims, _ = a.getServerClaims(ctx, hwmgr)
+	if err := a.claimServers(ctx, secondClaims, second, []*pluginv1alpha1.SupermicroServer{&edge1}); err == nil {
+		t.Errorf("expected a failure to claim a server claimed by another NodePool")
+	}
+	unavailable := unavailableServers(map[string]string{"edge-3": "node-3"}, c.cm, second)
+	if len(unavailable) != 3 || unavailable["edge-1"] != "first" || unavailable["edge-3"] != "node-3" {
+		t.Errorf("unexpected unavailable servers for another NodePool: %v", unavailable)
+	}
+	if unavailable := unavailableServers(nil, c.cm, first); len(unavailable) != 0 {
+		t.Errorf("expected the claims of the NodePool to be available to it, got %v", unavailable)
+	}
+
+	if err := a.releaseServerClaims(ctx, hwmgr, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.cm.Data) != 0 {
+		t.Errorf("expected the claims to be released, got %v", c.cm.Data)
+	}
+}
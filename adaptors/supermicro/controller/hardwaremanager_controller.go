@@ -0,0 +1,208 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// HardwareManagerReconciler reconciles a HardwareManager object
+type HardwareManagerReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Logger    *slog.Logger
+	Namespace string
+	AdaptorID pluginv1alpha1.HardwareManagerAdaptorID
+	Clock     utils.Clock
+}
+
+// clock returns the clock of the reconciler
+func (r *HardwareManagerReconciler) clock() utils.Clock {
+	return utils.ClockOrReal(r.Clock)
+}
+
+//+kubebuilder:rbac:groups=hwmgr-plugin.oran.openshift.io,resources=hardwaremanagers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=hwmgr-plugin.oran.openshift.io,resources=hardwaremanagers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=hwmgr-plugin.oran.openshift.io,resources=hardwaremanagers/finalizers,verbs=update
+
+// validateSupermicroData checks the structure of the config data, returning a description of each problem found
+func validateSupermicroData(data *pluginv1alpha1.SupermicroData) []string {
+	if data == nil {
+		return []string{"supermicroData is required"}
+	}
+	if len(data.Servers) == 0 {
+		return []string{"at least one server is required"}
+	}
+
+	var problems []string
+	names := make(map[string]bool)
+	for i, server := range data.Servers {
+		if server.Name == "" {
+			problems = append(problems, fmt.Sprintf("server %d has no name", i))
+		} else if names[server.Name] {
+			problems = append(problems, fmt.Sprintf("server %s is listed more than once", server.Name))
+		}
+		names[server.Name] = true
+
+		// The address is not reported, as it may include credentials
+		if !utils.IsValidURL(server.BMCAddress) {
+			problems = append(problems, fmt.Sprintf("server %s has an invalid BMC address", server.Name))
+		} else if address, err := url.Parse(server.BMCAddress); err == nil && address.User != nil {
+			problems = append(problems, fmt.Sprintf("server %s has credentials in its BMC address, which must be set in its credentials secret", server.Name))
+		}
+		if server.CredentialsName == "" {
+			problems = append(problems, fmt.Sprintf("server %s has no credentials", server.Name))
+		}
+		if server.ResourcePoolId == "" {
+			problems = append(problems, fmt.Sprintf("server %s has no resource pool", server.Name))
+		}
+	}
+	return problems
+}
+
+// validateCredentials checks that the credentials secret of each server exists and holds a username and password
+func (r *HardwareManagerReconciler) validateCredentials(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) []string {
+	var problems []string
+	for _, server := range hwmgr.Spec.SupermicroData.Servers {
+		secret, err := utils.GetSecret(ctx, r.Client, server.CredentialsName, hwmgr.Namespace)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("server %s: %s", server.Name, err.Error()))
+			continue
+		}
+		for _, key := range []string{"username", "password"} {
+			if _, err := utils.GetSecretField(secret, key); err != nil {
+				problems = append(problems, fmt.Sprintf("server %s: %s", server.Name, err.Error()))
+			}
+		}
+	}
+	return problems
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.3/pkg/reconcile
+func (r *HardwareManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	_ = log.FromContext(ctx)
+	result = utils.DoNotRequeue()
+
+	// Fetch the CR:
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if err = r.Client.Get(ctx, req.NamespacedName, hwmgr); err != nil {
+		if errors.IsNotFound(err) {
+			// The HardwareManager has likely been deleted
+			err = nil
+			return
+		}
+		r.Logger.ErrorContext(
+			ctx,
+			"Unable to fetch HardwareManager",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	// Make sure this is an instance for this adaptor
+	if hwmgr.Spec.AdaptorID != r.AdaptorID {
+		// Skip this CR
+		return
+	}
+
+	ctx = logging.AppendCtx(ctx, slog.String("hwmgr", hwmgr.Name))
+
+	// Once this generation has been validated, only the failed BMC requests are refreshed
+	if hwmgr.Status.ObservedGeneration == hwmgr.Generation && utils.IsHardwareManagerValidationCompleted(hwmgr) {
+		result = utils.RequeueWithLongInterval()
+		lastErrors := utils.GetSouthboundErrors(hwmgr, r.clock())
+		if equality.Semantic.DeepEqual(hwmgr.Status.LastErrors, lastErrors) {
+			return
+		}
+		hwmgr.Status.LastErrors = lastErrors
+		if updateErr := utils.UpdateK8sCRStatus(ctx, r.Client, hwmgr); updateErr != nil {
+			err = fmt.Errorf("failed to update status for hardware manager (%s) with last errors: %w", hwmgr.Name, updateErr)
+		}
+		return
+	}
+
+	hwmgr.Status.ObservedGeneration = hwmgr.Generation
+
+	problems := validateSupermicroData(hwmgr.Spec.SupermicroData)
+	if len(problems) == 0 {
+		problems = r.validateCredentials(ctx, hwmgr)
+	}
+
+	if len(problems) > 0 {
+		r.Logger.InfoContext(ctx, "Validation failed", slog.Any("problems", problems))
+		if updateErr := utils.UpdateHardwareManagerStatusCondition(ctx, r.Client, hwmgr,
+			pluginv1alpha1.ConditionTypes.Validation,
+			pluginv1alpha1.ConditionReasons.Failed,
+			metav1.ConditionFalse,
+			"Invalid configuration: "+strings.Join(problems, "; ")); updateErr != nil {
+			err = fmt.Errorf("failed to update status for hardware manager (%s) with validation failure: %w", hwmgr.Name, updateErr)
+			return
+		}
+
+		// The credentials secrets may be created after the HardwareManager, so retry the validation
+		result = utils.RequeueWithMediumInterval()
+		return
+	}
+
+	hwmgr.Status.LastErrors = utils.GetSouthboundErrors(hwmgr, r.clock())
+	if updateErr := utils.UpdateHardwareManagerStatusCondition(ctx, r.Client, hwmgr,
+		pluginv1alpha1.ConditionTypes.Validation,
+		pluginv1alpha1.ConditionReasons.Completed,
+		metav1.ConditionTrue,
+		"Validated"); updateErr != nil {
+		err = fmt.Errorf("failed to update status for hardware manager (%s) with validation success: %w", hwmgr.Name, updateErr)
+		return
+	}
+
+	r.Logger.InfoContext(ctx, "[Supermicro HardwareManager]", slog.Int("servers", len(hwmgr.Spec.SupermicroData.Servers)))
+
+	result = utils.RequeueWithLongInterval()
+	return
+}
+
+func filterEvents(adaptorID pluginv1alpha1.HardwareManagerAdaptorID) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		hwmgr := object.(*pluginv1alpha1.HardwareManager)
+		return hwmgr.Spec.AdaptorID == adaptorID
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HardwareManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.AdaptorID = pluginv1alpha1.SupportedAdaptors.Supermicro
+	r.Logger.Info("Setting up Supermicro controller", slog.String("adaptorId", string(r.AdaptorID)))
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named(string(r.AdaptorID)).
+		For(&pluginv1alpha1.HardwareManager{}).
+		WithEventFilter(filterEvents(r.AdaptorID)).
+		Complete(r); err != nil {
+		return fmt.Errorf("failed to setup controller for %s: %w", r.AdaptorID, err)
+	}
+
+	return nil
+}
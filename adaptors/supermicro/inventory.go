@@ -0,0 +1,313 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/redfish"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// serverState holds the details of a server read from its BMC
+type serverState struct {
+	SystemPath string
+	System     redfishSystem
+	Processors []redfishProcessor
+	Manager    *redfishManager
+}
+
+// getServerState reads the system, processor and manager details of a server from its BMC
+func getServerState(ctx context.Context, rf *redfish.Client, server *pluginv1alpha1.SupermicroServer) (*serverState, error) {
+	systemPath, err := rf.SystemPath(ctx, server.BMCAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find Redfish system of server %s: %w", server.Name, err)
+	}
+
+	state := &serverState{SystemPath: systemPath}
+	if err := rf.Get(ctx, systemPath, &state.System); err != nil {
+		return nil, fmt.Errorf("failed to get Redfish system of server %s: %w", server.Name, err)
+	}
+
+	if state.System.Processors.ODataID != "" {
+		if state.Processors, err = getMembers[redfishProcessor](ctx, rf, state.System.Processors.ODataID); err != nil {
+			return nil, fmt.Errorf("failed to get processors of server %s: %w", server.Name, err)
+		}
+	}
+
+	if len(state.System.Links.ManagedBy) > 0 {
+		state.Manager = &redfishManager{}
+		if err := rf.Get(ctx, state.System.Links.ManagedBy[0].ODataID, state.Manager); err != nil {
+			return nil, fmt.Errorf("failed to get BMC details of server %s: %w", server.Name, err)
+		}
+	}
+
+	return state, nil
+}
+
+// bmcFirmwareVersion returns the firmware version of the BMC, if known
+func (s *serverState) bmcFirmwareVersion() string {
+	if s.Manager == nil {
+		return ""
+	}
+	return s.Manager.FirmwareVersion
+}
+
+// bmcTarget returns the Redfish path of the BMC, as targeted by firmware updates
+func (s *serverState) bmcTarget() string {
+	if s.Manager != nil && s.Manager.ODataID != "" {
+		return s.Manager.ODataID
+	}
+	if len(s.System.Links.ManagedBy) > 0 {
+		return s.System.Links.ManagedBy[0].ODataID
+	}
+	return ""
+}
+
+// cpus returns the central processors of the server, excluding accelerators
+func (s *serverState) cpus() []redfishProcessor {
+	var cpus []redfishProcessor
+	for _, processor := range s.Processors {
+		if processor.ProcessorType == "" || strings.EqualFold(processor.ProcessorType, "CPU") {
+			cpus = append(cpus, processor)
+		}
+	}
+	return cpus
+}
+
+// cpuArchitecture returns the normalized CPU architecture of the server, preferring the instruction set, as the
+// Redfish processor architecture does not distinguish 32-bit from 64-bit
+func (s *serverState) cpuArchitecture() string {
+	for _, processor := range s.cpus() {
+		if arch := utils.NormalizeCPUArchitecture(processor.InstructionSet); arch != "" {
+			return arch
+		}
+		if strings.EqualFold(processor.ProcessorArchitecture, "ARM") {
+			return utils.CPUArchitectureAArch64
+		}
+		if strings.EqualFold(processor.ProcessorArchitecture, "x86") {
+			return utils.CPUArchitectureX86_64
+		}
+	}
+	return ""
+}
+
+// nodeSelectionInfo returns the attributes of the server published as Node labels
+func (s *serverState) nodeSelectionInfo(server *pluginv1alpha1.SupermicroServer) utils.NodeSelectionInfo {
+	return utils.NodeSelectionInfo{
+		SiteID:          server.Site,
+		ResourcePoolID:  server.ResourcePoolId,
+		Vendor:          s.System.Manufacturer,
+		Model:           s.System.Model,
+		CPUArchitecture: s.cpuArchitecture(),
+	}
+}
+
+// getServerInterfaces reads the network interfaces of a server from its BMC
+func getServerInterfaces(ctx context.Context, rf *redfish.Client, state *serverState) ([]*hwmgmtv1alpha1.Interface, error) {
+	if state.System.EthernetInterfaces.ODataID == "" {
+		return nil, nil
+	}
+
+	nics, err := getMembers[redfishEthernetInterface](ctx, rf, state.System.EthernetInterfaces.ODataID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
+	}
+
+	var interfaces []*hwmgmtv1alpha1.Interface
+	for _, nic := range nics {
+		mac := nic.MACAddress
+		if mac == "" {
+			mac = nic.PermanentMACAddress
+		}
+		if mac == "" {
+			continue
+		}
+		interfaces = append(interfaces, &hwmgmtv1alpha1.Interface{
+			Name:       nic.ID,
+			Label:      nic.Name,
+			MACAddress: strings.ToLower(mac),
+		})
+	}
+	return interfaces, nil
+}
+
+func convertProcessorInfo(processors []redfishProcessor) []invserver.ProcessorInfo {
+	result := make([]invserver.ProcessorInfo, 0, len(processors))
+	for _, processor := range processors {
+		info := invserver.ProcessorInfo{}
+		if arch := utils.NormalizeCPUArchitecture(processor.InstructionSet); arch != "" {
+			info.Architecture = &arch
+		}
+		if processor.TotalCores > 0 {
+			cores := processor.TotalCores
+			info.Cores = &cores
+		}
+		if processor.Manufacturer != "" {
+			manufacturer := processor.Manufacturer
+			info.Manufacturer = &manufacturer
+		}
+		if processor.Model != "" {
+			model := processor.Model
+			info.Model = &model
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// getResourceInfo maps the state of a server to its inventory resource. A server that is unreachable is reported with
+// an unknown operational state, so that it remains visible in the inventory.
+func getResourceInfo(server *pluginv1alpha1.SupermicroServer, state *serverState, allocated bool) invserver.ResourceInfo {
+	usageState := invserver.IDLE
+	if allocated {
+		usageState = invserver.ACTIVE
+	}
+
+	var labels *map[string]string
+	if len(server.Labels) > 0 {
+		labels = &server.Labels
+	}
+
+	resource := invserver.ResourceInfo{
+		AdminState:       invserver.ResourceInfoAdminStateUNKNOWN,
+		Description:      fmt.Sprintf("Supermicro server %s", server.Name),
+		HwProfile:        "",
+		Labels:           labels,
+		Name:             server.Name,
+		OperationalState: invserver.ResourceInfoOperationalStateUNKNOWN,
+		Processors:       []invserver.ProcessorInfo{},
+		ResourceId:       server.Name,
+		ResourcePoolId:   server.ResourcePoolId,
+		UsageState:       usageState,
+		Vendor:           "Supermicro",
+	}
+	if state == nil {
+		return resource
+	}
+
+	system := state.System
+	resource.AdminState = invserver.ResourceInfoAdminStateUNLOCKED
+	resource.OperationalState = invserver.ResourceInfoOperationalStateDISABLED
+	if strings.EqualFold(system.Status.State, "Enabled") {
+		resource.OperationalState = invserver.ResourceInfoOperationalStateENABLED
+	}
+	if system.Manufacturer != "" {
+		resource.Vendor = system.Manufacturer
+	}
+	resource.Model = system.Model
+	resource.SerialNumber = system.SerialNumber
+	resource.PartNumber = system.PartNumber
+	if resource.PartNumber == "" {
+		resource.PartNumber = system.SKU
+	}
+	if system.UUID != "" {
+		resource.GlobalAssetId = &system.UUID
+	} else if system.SerialNumber != "" {
+		resource.GlobalAssetId = &system.SerialNumber
+	}
+	resource.Memory = int(system.MemorySummary.TotalSystemMemoryGiB * 1024)
+	resource.Processors = convertProcessorInfo(state.cpus())
+	if arch := state.cpuArchitecture(); arch != "" {
+		resource.CpuArchitecture = &arch
+	}
+	switch {
+	case strings.EqualFold(system.PowerState, "On"):
+		powerState := invserver.ON
+		resource.PowerState = &powerState
+	case strings.EqualFold(system.PowerState, "Off"):
+		powerState := invserver.OFF
+		resource.PowerState = &powerState
+	}
+
+	return resource
+}
+
+// getResourcePools returns the resource pools of the configured servers
+func getResourcePools(servers []pluginv1alpha1.SupermicroServer) []invserver.ResourcePoolInfo {
+	type poolKey struct {
+		pool string
+		site string
+	}
+
+	seen := make(map[poolKey]bool)
+	var pools []invserver.ResourcePoolInfo
+	for _, server := range servers {
+		key := poolKey{pool: server.ResourcePoolId, site: server.Site}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		site := server.Site
+		if site == "" {
+			site = "n/a"
+		}
+		pools = append(pools, invserver.ResourcePoolInfo{
+			ResourcePoolId: server.ResourcePoolId,
+			Description:    server.ResourcePoolId,
+			Name:           server.ResourcePoolId,
+			SiteId:         &site,
+		})
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		if pools[i].ResourcePoolId != pools[j].ResourcePoolId {
+			return pools[i].ResourcePoolId < pools[j].ResourcePoolId
+		}
+		return *pools[i].SiteId < *pools[j].SiteId
+	})
+	return pools
+}
+
+// GetResourcePools returns the resource pools of the servers configured for the HardwareManager
+func (a *Adaptor) GetResourcePools(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourcePoolInfo, int, error) {
+	if hwmgr.Spec.SupermicroData == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("required config data missing from HardwareManager: name=%s", hwmgr.Name)
+	}
+	return getResourcePools(hwmgr.Spec.SupermicroData.Servers), http.StatusOK, nil
+}
+
+// GetResources queries the BMC of each configured server for its inventory details
+func (a *Adaptor) GetResources(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) ([]invserver.ResourceInfo, int, error) {
+	if hwmgr.Spec.SupermicroData == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("required config data missing from HardwareManager: name=%s", hwmgr.Name)
+	}
+
+	allocated, err := a.getAllocatedServers(ctx, hwmgr)
+	if err != nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("unable to get allocated servers: %w", err)
+	}
+
+	resources := make([]invserver.ResourceInfo, 0, len(hwmgr.Spec.SupermicroData.Servers))
+	for i := range hwmgr.Spec.SupermicroData.Servers {
+		server := &hwmgr.Spec.SupermicroData.Servers[i]
+
+		var state *serverState
+		rf, err := a.newRedfishClient(ctx, hwmgr, server)
+		if err == nil {
+			state, err = getServerState(ctx, rf, server)
+		}
+		if err != nil {
+			a.Logger.WarnContext(ctx, "Unable to query server", slog.String("server", server.Name), slog.String("error", err.Error()))
+		}
+
+		_, isAllocated := allocated[server.Name]
+		resources = append(resources, getResourceInfo(server, state, isAllocated))
+	}
+
+	return resources, http.StatusOK, nil
+}
@@ -0,0 +1,299 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// nodeBMCAddress returns the BMC address published on a Node, using the Redfish virtual media driver expected by the
+// installer for Supermicro BMCs
+func nodeBMCAddress(bmcAddress, systemPath string) string {
+	host := bmcAddress
+	if parsed, err := url.Parse(bmcAddress); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	return (&url.URL{Scheme: "redfish-virtualmedia", Host: host, Path: systemPath}).String()
+}
+
+// findServer returns the configured server with the given name
+func findServer(hwmgr *pluginv1alpha1.HardwareManager, name string) *pluginv1alpha1.SupermicroServer {
+	for i := range hwmgr.Spec.SupermicroData.Servers {
+		if hwmgr.Spec.SupermicroData.Servers[i].Name == name {
+			return &hwmgr.Spec.SupermicroData.Servers[i]
+		}
+	}
+	return nil
+}
+
+// getAllocatedServers returns the names of the servers allocated to a Node, mapped to the Node name. The Nodes are the
+// record of the allocations, while the server claims only guard the allocations in progress.
+func (a *Adaptor) getAllocatedServers(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) (map[string]string, error) {
+	nodelist := &hwmgmtv1alpha1.NodeList{}
+	if err := a.NoncachedClient.List(ctx, nodelist, client.InNamespace(a.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Nodes: %w", err)
+	}
+
+	allocated := make(map[string]string)
+	for _, node := range nodelist.Items {
+		if node.Spec.HwMgrId == hwmgr.Name {
+			allocated[node.Spec.HwMgrNodeId] = node.Name
+		}
+	}
+	return allocated, nil
+}
+
+// getFreeServers returns the servers in the resource pool that are not allocated, excluding servers at another site
+// than the requested one
+func getFreeServers(servers []pluginv1alpha1.SupermicroServer, allocated map[string]string, pool, site string) []*pluginv1alpha1.SupermicroServer {
+	var free []*pluginv1alpha1.SupermicroServer
+	for i := range servers {
+		server := &servers[i]
+		if server.ResourcePoolId != pool {
+			continue
+		}
+		if site != "" && server.Site != "" && server.Site != site {
+			continue
+		}
+		if _, isAllocated := allocated[server.Name]; isAllocated {
+			continue
+		}
+		free = append(free, server)
+	}
+	return free
+}
+
+// selectServers returns up to count free servers for a node group. When a CPU architecture is requested, each
+// candidate is queried for its architecture, and servers that cannot be queried are skipped.
+func (a *Adaptor) selectServers(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	allocated map[string]string,
+	nodegroup hwmgmtv1alpha1.NodeGroup,
+	count int) ([]*pluginv1alpha1.SupermicroServer, error) {
+
+	arch, err := utils.GetRequestedCPUArchitecture(nodepool, nodegroup.NodePoolData.Name)
+	if err != nil {
+		return nil, err // nolint: wrapcheck
+	}
+
+	candidates := getFreeServers(hwmgr.Spec.SupermicroData.Servers, allocated, nodegroup.NodePoolData.ResourcePoolId, nodepool.Spec.Site)
+	if arch == "" {
+		if len(candidates) > count {
+			candidates = candidates[:count]
+		}
+		return candidates, nil
+	}
+
+	var selected []*pluginv1alpha1.SupermicroServer
+	for _, server := range candidates {
+		if len(selected) == count {
+			break
+		}
+		rf, err := a.newRedfishClient(ctx, hwmgr, server)
+		if err != nil {
+			return nil, err
+		}
+		state, err := getServerState(ctx, rf, server)
+		if err != nil {
+			a.Logger.WarnContext(ctx, "Unable to query server architecture", slog.String("server", server.Name), slog.String("error", err.Error()))
+			continue
+		}
+		if state.cpuArchitecture() == arch {
+			selected = append(selected, server)
+		}
+	}
+	return selected, nil
+}
+
+// allocateNode allocates a server to a node group, creating its Node and bmc-secret
+func (a *Adaptor) allocateNode(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	nodegroup hwmgmtv1alpha1.NodeGroup,
+	server *pluginv1alpha1.SupermicroServer) error {
+
+	rf, err := a.newRedfishClient(ctx, hwmgr, server)
+	if err != nil {
+		return err
+	}
+	state, err := getServerState(ctx, rf, server)
+	if err != nil {
+		return err
+	}
+	interfaces, err := getServerInterfaces(ctx, rf, state)
+	if err != nil {
+		return fmt.Errorf("failed to get network interfaces of server %s: %w", server.Name, err)
+	}
+
+	nodename := utils.GenerateNodeName()
+	a.Logger.InfoContext(ctx, "Allocating server",
+		slog.String("nodegroup name", nodegroup.NodePoolData.Name),
+		slog.String("nodename", nodename),
+		slog.String("server", server.Name))
+
+	bmcSecret, err := utils.GetBMCSecretKey(hwmgr, nodepool, nodename, a.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get bmc-secret name for node %s: %w", nodename, err)
+	}
+
+	if err := a.createBMCSecret(ctx, nodepool, nodename, bmcSecret, server); err != nil {
+		return fmt.Errorf("failed to create bmc-secret when allocating node %s, server %s: %w", nodename, server.Name, err)
+	}
+
+	node := utils.NewNode(nodepool, a.Namespace, nodename, state.nodeSelectionInfo(server).Labels(), hwmgmtv1alpha1.NodeSpec{
+		NodePool:    nodepool.Spec.CloudID,
+		GroupName:   nodegroup.NodePoolData.Name,
+		HwProfile:   nodegroup.NodePoolData.HwProfile,
+		HwMgrId:     nodepool.Spec.HwMgrId,
+		HwMgrNodeId: server.Name,
+	})
+	utils.SetNodeBMCSecretNamespace(node, bmcSecret)
+	if err := utils.ApplyNode(ctx, a.Client, nodepool, node); err != nil {
+		return fmt.Errorf("failed to create Node %s: %w", nodename, err)
+	}
+
+	if err := a.updateNodeStatus(ctx, nodename, bmcSecret.Name, nodeBMCAddress(server.BMCAddress, state.SystemPath), interfaces); err != nil {
+		return fmt.Errorf("failed to update node status (%s): %w", nodename, err)
+	}
+
+	return nil
+}
+
+// createBMCSecret creates the bmc-secret for a node, from the credentials of its server
+func (a *Adaptor) createBMCSecret(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool, nodename string, key types.NamespacedName,
+	server *pluginv1alpha1.SupermicroServer) error {
+	a.Logger.InfoContext(ctx, "Creating bmc-secret:", slog.String("nodename", nodename))
+
+	credentials, err := utils.GetSecret(ctx, a.Client, server.CredentialsName, a.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get BMC credentials for server %s: %w", server.Name, err)
+	}
+
+	bmcSecret := utils.NewBMCSecret(key, nodepool, nodename, credentials.Data["username"], credentials.Data["password"])
+
+	if err := utils.CreateOrUpdateK8sCR(ctx, a.Client, bmcSecret, nil, utils.UPDATE); err != nil {
+		return fmt.Errorf("failed to create bmc-secret for node %s: %w", nodename, err)
+	}
+
+	return nil
+}
+
+// updateNodeStatus sets the BMC and network interface details of a newly allocated Node. The Node is provisioned once
+// its hardware profile has been applied.
+func (a *Adaptor) updateNodeStatus(ctx context.Context, nodename, credentialsName, bmcAddress string, interfaces []*hwmgmtv1alpha1.Interface) error {
+	node := &hwmgmtv1alpha1.Node{}
+	if err := utils.RetryOnConflictOrRetriableOrNotFound(retry.DefaultRetry, func() error {
+		return a.Get(ctx, types.NamespacedName{Name: nodename, Namespace: a.Namespace}, node)
+	}); err != nil {
+		return fmt.Errorf("failed to get Node for update: %w", err)
+	}
+
+	node.Status.BMC = &hwmgmtv1alpha1.BMC{
+		Address:         bmcAddress,
+		CredentialsName: credentialsName,
+	}
+	node.Status.Interfaces = interfaces
+
+	utils.SetStatusCondition(&node.Status.Conditions,
+		string(hwmgmtv1alpha1.Provisioned),
+		string(hwmgmtv1alpha1.InProgress),
+		metav1.ConditionFalse,
+		"Applying hardware profile")
+	if err := utils.UpdateK8sCRStatus(ctx, a.Client, node); err != nil {
+		return fmt.Errorf("failed to update status for node %s: %w", nodename, err)
+	}
+
+	return nil
+}
+
+// completeProfileUpdate records that the hardware profile in the spec of a node has been applied
+func (a *Adaptor) completeProfileUpdate(ctx context.Context, node *hwmgmtv1alpha1.Node) error {
+	a.Logger.InfoContext(ctx, "Hardware profile applied", slog.String("nodename", node.Name), slog.String("hwProfile", node.Spec.HwProfile))
+
+	// The last progress skipped is written before the final status, which then refers to the latest node
+	if err := utils.ClearNodeProgress(ctx, a.Client, node.Name, node.Namespace, a.clock()); err != nil {
+		return fmt.Errorf("failed to write progress of node %s: %w", node.Name, err)
+	}
+	if err := a.Client.Get(ctx, client.ObjectKeyFromObject(node), node); err != nil {
+		return fmt.Errorf("failed to get node %s: %w", node.Name, err)
+	}
+
+	node.Status.HwProfile = node.Spec.HwProfile
+	utils.SetStatusCondition(&node.Status.Conditions,
+		string(hwmgmtv1alpha1.Provisioned),
+		string(hwmgmtv1alpha1.Completed),
+		metav1.ConditionTrue,
+		"Provisioned")
+	utils.SetStatusCondition(&node.Status.Conditions,
+		string(hwmgmtv1alpha1.Configured),
+		string(hwmgmtv1alpha1.ConfigApplied),
+		metav1.ConditionTrue,
+		string(hwmgmtv1alpha1.ConfigSuccess))
+	if err := utils.UpdateK8sCRStatus(ctx, a.Client, node); err != nil {
+		return fmt.Errorf("failed to update status for node %s: %w", node.Name, err)
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	clearProfileUpdate(node)
+	utils.EndNodeOperation(node, utils.NodeOperationSucceeded, "", a.clock())
+	if err := a.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to clear profile update from node %s: %w", node.Name, err)
+	}
+
+	hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, node.Spec.HwProfile, a.Namespace)
+	if err != nil {
+		return fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", node.Spec.HwProfile, err)
+	}
+	config, err := utils.NewAppliedConfig(hwProfile)
+	if err != nil {
+		return fmt.Errorf("failed to build applied config for node %s: %w", node.Name, err)
+	}
+	if err := utils.SetAppliedConfig(ctx, a.Client, node.Name, node.Namespace, config); err != nil {
+		return fmt.Errorf("failed to record applied config for node %s: %w", node.Name, err)
+	}
+
+	return nil
+}
+
+// failProfileUpdate records that the hardware profile in the spec of a node could not be applied. The profile update
+// record is kept, for troubleshooting.
+func (a *Adaptor) failProfileUpdate(ctx context.Context, node *hwmgmtv1alpha1.Node, conditionType hwmgmtv1alpha1.ConditionType,
+	result profileUpdateResult) {
+
+	a.Logger.InfoContext(ctx, "Hardware profile update failed", slog.String("nodename", node.Name), slog.String("failure", result.Failure))
+
+	reason, outcome := hwmgmtv1alpha1.Failed, utils.NodeOperationFailed
+	if result.TimedOut {
+		reason, outcome = hwmgmtv1alpha1.TimedOut, utils.NodeOperationTimedOut
+	}
+	if err := utils.ClearNodeProgress(ctx, a.Client, node.Name, node.Namespace, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to write node progress", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.SetNodeConditionStatus(ctx, a.Client, node.Name, node.Namespace,
+		string(conditionType), metav1.ConditionFalse, string(reason),
+		fmt.Sprintf("Profile update to %s failed: %s", node.Spec.HwProfile, result.Failure)); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to update node status", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+	if err := utils.FinishNodeOperation(ctx, a.Client, node.Name, node.Namespace, outcome, result.Failure, a.clock()); err != nil {
+		a.Logger.ErrorContext(ctx, "failed to record operation outcome", slog.String("node", node.Name), slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,78 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"testing"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func testServer(name, pool, site string) pluginv1alpha1.SupermicroServer {
+	return pluginv1alpha1.SupermicroServer{
+		Name:            name,
+		BMCAddress:      "https://10.1.2.3",
+		CredentialsName: name + "-bmc",
+		ResourcePoolId:  pool,
+		Site:            site,
+	}
+}
+
+func TestGetFreeServers(t *testing.T) {
+	servers := []pluginv1alpha1.SupermicroServer{
+		testServer("edge-1", "pool-a", "site-1"),
+		testServer("edge-2", "pool-a", "site-2"),
+		testServer("edge-3", "pool-a", ""),
+		testServer("edge-4", "pool-b", "site-1"),
+		testServer("edge-5", "pool-a", "site-1"),
+	}
+	allocated := map[string]string{"edge-5": "node-5"}
+
+	names := func(free []*pluginv1alpha1.SupermicroServer) []string {
+		var result []string
+		for _, server := range free {
+			result = append(result, server.Name)
+		}
+		return result
+	}
+
+	if free := names(getFreeServers(servers, allocated, "pool-a", "site-1")); len(free) != 2 || free[0] != "edge-1" || free[1] != "edge-3" {
+		t.Errorf("unexpected free servers at site-1: %v", free)
+	}
+	if free := names(getFreeServers(servers, allocated, "pool-a", "")); len(free) != 3 {
+		t.Errorf("unexpected free servers without a site: %v", free)
+	}
+	if free := getFreeServers(servers, allocated, "pool-c", ""); len(free) != 0 {
+		t.Errorf("expected no free servers in an unknown pool, got %v", names(free))
+	}
+}
+
+func TestNodeBMCAddress(t *testing.T) {
+	if address := nodeBMCAddress("https://10.1.2.3:8443", "/redfish/v1/Systems/1"); address != "redfish-virtualmedia://10.1.2.3:8443/redfish/v1/Systems/1" {
+		t.Errorf("unexpected BMC address: %s", address)
+	}
+}
+
+func TestGetResourcePools(t *testing.T) {
+	pools := getResourcePools([]pluginv1alpha1.SupermicroServer{
+		testServer("edge-1", "pool-b", "site-1"),
+		testServer("edge-2", "pool-a", ""),
+		testServer("edge-3", "pool-b", "site-1"),
+	})
+	if len(pools) != 2 || pools[0].ResourcePoolId != "pool-a" || *pools[0].SiteId != "n/a" ||
+		pools[1].ResourcePoolId != "pool-b" || *pools[1].SiteId != "site-1" {
+		t.Errorf("unexpected pools: %+v", pools)
+	}
+}
+
+func TestGetResourceInfoUnreachable(t *testing.T) {
+	server := testServer("edge-1", "pool-a", "")
+	resource := getResourceInfo(&server, nil, false)
+	if resource.OperationalState != "UNKNOWN" || resource.UsageState != "IDLE" || resource.ResourceId != "edge-1" {
+		t.Errorf("unexpected resource for an unreachable server: %+v", resource)
+	}
+}
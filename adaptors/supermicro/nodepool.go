@@ -0,0 +1,381 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+func (a *Adaptor) HandleNodePoolCreate(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+
+	conditionType := hwmgmtv1alpha1.Provisioned
+	var conditionReason hwmgmtv1alpha1.ConditionReason
+	var conditionStatus metav1.ConditionStatus
+	var message string
+
+	if err := a.ProcessNewNodePool(ctx, hwmgr, nodepool); err != nil {
+		a.Logger.InfoContext(ctx, "failed ProcessNewNodePool", slog.String("err", err.Error()))
+		conditionReason = hwmgmtv1alpha1.Failed
+		conditionStatus = metav1.ConditionFalse
+		message = "Creation request failed: " + err.Error()
+	} else {
+		conditionReason = hwmgmtv1alpha1.InProgress
+		conditionStatus = metav1.ConditionFalse
+		message = "Handling creation"
+	}
+
+	if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+		conditionType, conditionReason, conditionStatus, message); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	// Update the Node Pool hwMgrPlugin status
+	if err := utils.UpdateNodePoolPluginStatus(ctx, a.Client, nodepool); err != nil {
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to update hwMgrPlugin observedGeneration Status: %w", err)
+	}
+
+	return utils.DoNotRequeue(), nil
+}
+
+// ProcessNewNodePool processes a new NodePool CR, verifying that there are enough free servers to satisfy the request
+func (a *Adaptor) ProcessNewNodePool(ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) error {
+
+	a.Logger.InfoContext(ctx, "Processing ProcessNewNodePool request:",
+		slog.String("cloudID", nodepool.Spec.CloudID),
+	)
+
+	if err := utils.ValidateNodePoolExtensions(nodepool); err != nil {
+		return err // nolint: wrapcheck
+	}
+
+	allocated, err := a.getAllocatedServers(ctx, hwmgr)
+	if err != nil {
+		return err
+	}
+	claims, err := a.getServerClaims(ctx, hwmgr)
+	if err != nil {
+		return err
+	}
+	allocated = unavailableServers(allocated, claims, nodepool)
+
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		servers, err := a.selectServers(ctx, hwmgr, nodepool, allocated, nodegroup, nodegroup.Size)
+		if err != nil {
+			return err
+		}
+		if nodegroup.Size > len(servers) {
+			return fmt.Errorf("not enough free resources in resource pool %s: freenodes=%d",
+				nodegroup.NodePoolData.ResourcePoolId, len(servers))
+		}
+		// Reserve the servers, so that node groups sharing a resource pool are not counted against the same servers
+		for _, server := range servers {
+			allocated[server.Name] = ""
+		}
+	}
+
+	return nil
+}
+
+// allocateNodePool allocates servers to the node groups that are not fully allocated. The servers of each node group
+// are claimed before their Nodes are created, so that a concurrent allocation of the same servers fails.
+func (a *Adaptor) allocateNodePool(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool,
+	nodelist *hwmgmtv1alpha1.NodeList) error {
+
+	used := make(map[string]int)
+	for _, node := range nodelist.Items {
+		used[node.Spec.GroupName]++
+	}
+
+	allocated, err := a.getAllocatedServers(ctx, hwmgr)
+	if err != nil {
+		return err
+	}
+	claims, err := a.getServerClaims(ctx, hwmgr)
+	if err != nil {
+		return err
+	}
+	allocated = unavailableServers(allocated, claims, nodepool)
+
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		remaining := nodegroup.Size - used[nodegroup.NodePoolData.Name]
+		if remaining <= 0 {
+			continue
+		}
+
+		servers, err := a.selectServers(ctx, hwmgr, nodepool, allocated, nodegroup, remaining)
+		if err != nil {
+			return err
+		}
+		if remaining > len(servers) {
+			return fmt.Errorf("not enough free resources remaining in resource pool %s", nodegroup.NodePoolData.ResourcePoolId)
+		}
+		if err := a.claimServers(ctx, claims, nodepool, servers); err != nil {
+			return err
+		}
+
+		for _, server := range servers {
+			if err := a.allocateNode(ctx, hwmgr, nodepool, nodegroup, server); err != nil {
+				return err
+			}
+			allocated[server.Name] = ""
+		}
+	}
+
+	return nil
+}
+
+func (a *Adaptor) HandleNodePoolProcessing(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	if err := a.allocateNodePool(ctx, hwmgr, nodepool, nodelist); err != nil {
+		return utils.RequeueWithShortInterval(), fmt.Errorf("failed to allocate nodes for %s: %w", nodepool.Name, err)
+	}
+
+	if nodelist, err = utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	nodepool.Status.Properties.NodeNames = nil
+	for _, node := range nodelist.Items {
+		nodepool.Status.Properties.NodeNames = append(nodepool.Status.Properties.NodeNames, node.Name)
+	}
+	if err := utils.UpdateNodePoolProperties(ctx, a.Client, nodepool); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+
+	// Apply the hardware profile to each newly allocated server. The servers are independent, so they are all
+	// progressed in each pass.
+	pending := 0
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		if node.Status.HwProfile == node.Spec.HwProfile {
+			continue
+		}
+
+		result, err := a.progressProfileUpdate(ctx, hwmgr, node)
+		if err != nil {
+			a.Logger.InfoContext(ctx, "Hardware profile update pass failed",
+				slog.String("nodename", node.Name), slog.String("error", err.Error()))
+			pending++
+			continue
+		}
+		switch {
+		case result.Failure != "":
+			a.failProfileUpdate(ctx, node, hwmgmtv1alpha1.Provisioned, result)
+			if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+				hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
+				fmt.Sprintf("Failed to apply hardware profile to node %s: %s", node.Name, result.Failure)); err != nil {
+				return utils.RequeueWithMediumInterval(),
+					fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+			}
+			return utils.DoNotRequeue(), nil
+		case result.Done:
+			if err := a.completeProfileUpdate(ctx, node); err != nil {
+				return utils.RequeueWithShortInterval(), err
+			}
+		default:
+			pending++
+		}
+	}
+
+	if pending > 0 {
+		a.Logger.InfoContext(ctx, "NodePool request in progress", slog.Int("pendingNodes", pending))
+		return utils.RequeueWithMediumInterval(), nil
+	}
+
+	a.Logger.InfoContext(ctx, "NodePool request is fully allocated")
+	if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+		hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Completed, metav1.ConditionTrue, "Created"); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+
+	return utils.DoNotRequeue(), nil
+}
+
+// profileUpdateFailed checks whether the last profile update of a node failed
+func profileUpdateFailed(node *hwmgmtv1alpha1.Node) bool {
+	condition := meta.FindStatusCondition(node.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+	return condition != nil && (condition.Reason == string(hwmgmtv1alpha1.Failed) || condition.Reason == string(hwmgmtv1alpha1.TimedOut))
+}
+
+func (a *Adaptor) handleNodePoolConfiguring(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+
+	a.Logger.InfoContext(ctx, "Handling Node Pool Configuring")
+
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	// Progress the node being updated, if any. Nodes are updated one at a time, so that a bad profile is caught
+	// before it is applied to the whole pool.
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		if node.Status.HwProfile == node.Spec.HwProfile || profileUpdateFailed(node) {
+			continue
+		}
+
+		result, err := a.progressProfileUpdate(ctx, hwmgr, node)
+		if err != nil {
+			return utils.RequeueWithMediumInterval(), fmt.Errorf("failed to update hardware profile of node %s: %w", node.Name, err)
+		}
+		switch {
+		case result.Failure != "":
+			a.failProfileUpdate(ctx, node, hwmgmtv1alpha1.Configured, result)
+			if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+				hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
+				fmt.Sprintf("Profile update failed on node %s: %s", node.Name, result.Failure)); err != nil {
+				return utils.RequeueWithMediumInterval(),
+					fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+			}
+			return utils.DoNotRequeue(), nil
+		case result.Done:
+			if err := a.completeProfileUpdate(ctx, node); err != nil {
+				return utils.RequeueWithShortInterval(), err
+			}
+			return utils.RequeueImmediately(), nil
+		default:
+			return utils.RequeueWithMediumInterval(), nil
+		}
+	}
+
+	// Start the update of the next node with a stale profile
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		newHwProfile := nodegroup.NodePoolData.HwProfile
+		node := utils.FindNextNodeToUpdate(nodelist, nodegroup.NodePoolData.Name, newHwProfile)
+		if node == nil {
+			continue
+		}
+
+		a.Logger.InfoContext(ctx, "Issuing profile update to node",
+			slog.String("hwMgrNodeId", node.Spec.HwMgrNodeId),
+			slog.String("curHwProfile", node.Spec.HwProfile),
+			slog.String("newHwProfile", newHwProfile))
+
+		// The profile update operation is started by the first pass of the update
+		if err := utils.RequestNodeProfileUpdate(ctx, a.Client, node, newHwProfile, "", a.clock()); err != nil {
+			return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
+		}
+
+		return utils.RequeueImmediately(), nil
+	}
+
+	// Cordoned nodes are left with their current profile, holding back the update until they are uncordoned
+	if held, err := utils.HoldUpdateForCordonedNodes(ctx, a.Client, a.Logger, nodepool, nodelist); err != nil {
+		return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
+	} else if held {
+		return utils.RequeueWithMediumInterval(), nil
+	}
+
+	// A node that failed its update is left with a stale profile until the NodePool is changed again
+	for _, node := range nodelist.Items {
+		if node.Status.HwProfile != node.Spec.HwProfile {
+			if err := utils.UpdateNodePoolStatusCondition(ctx, a.Client, nodepool,
+				hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.Failed, metav1.ConditionFalse,
+				fmt.Sprintf("Profile update failed on node %s", node.Name)); err != nil {
+				return utils.RequeueWithMediumInterval(),
+					fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+			}
+			return utils.DoNotRequeue(), nil
+		}
+	}
+
+	// All nodes have been updated
+	a.Logger.InfoContext(ctx, "All nodes have been updated to new profile")
+	if err := utils.CompleteNodePoolConfiguration(ctx, a.Client, nodepool); err != nil {
+		return utils.RequeueWithShortInterval(), err // nolint: wrapcheck
+	}
+
+	return utils.DoNotRequeue(), nil
+}
+
+func (a *Adaptor) HandleNodePoolSpecChanged(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+
+	if err := utils.UpdateNodePoolStatusCondition(
+		ctx,
+		a.Client,
+		nodepool,
+		hwmgmtv1alpha1.Configured,
+		hwmgmtv1alpha1.ConfigUpdate,
+		metav1.ConditionFalse,
+		string(hwmgmtv1alpha1.AwaitConfig)); err != nil {
+		return utils.RequeueWithMediumInterval(),
+			fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+
+	return a.handleNodePoolConfiguring(ctx, hwmgr, nodepool)
+}
+
+// ReleaseNodePool frees the servers allocated to a NodePool by deleting its Nodes, which are the record of the
+// allocations, and its server claims. The servers are left as configured by their last hardware profile.
+func (a *Adaptor) ReleaseNodePool(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) error {
+	a.Logger.InfoContext(ctx, "Processing ReleaseNodePool request:",
+		slog.String("cloudID", nodepool.Spec.CloudID),
+	)
+
+	nodelist, err := utils.GetChildNodes(ctx, a.Logger, a.Client, nodepool)
+	if err != nil {
+		return fmt.Errorf("failed to get child nodes for Node Pool %s: %w", nodepool.Name, err)
+	}
+
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		a.Logger.InfoContext(ctx, "Releasing server", slog.String("nodename", node.Name), slog.String("server", node.Spec.HwMgrNodeId))
+		if err := a.Client.Delete(ctx, node); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Node %s: %w", node.Name, err)
+		}
+		if err := utils.ClearNodeProgress(ctx, a.Client, node.Name, node.Namespace, a.clock()); err != nil {
+			return fmt.Errorf("failed to clear progress of Node %s: %w", node.Name, err)
+		}
+	}
+
+	// The bmc-secrets are owned by the NodePool, unless created in the cluster namespace
+	if err := utils.DeleteBMCSecrets(ctx, a.Client, nodepool, a.Namespace); err != nil {
+		return err
+	}
+
+	// The claims are released last, once the Nodes recording the allocations are deleted
+	if err := a.releaseServerClaims(ctx, hwmgr, nodepool); err != nil {
+		return err
+	}
+
+	return nil
+}
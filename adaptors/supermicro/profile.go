@@ -0,0 +1,301 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// ProfileUpdateAnnotation records the progress of applying a hardware profile to a node, across reconciles
+const ProfileUpdateAnnotation = "hwmgr-plugin.oran.openshift.io/supermicro-profile-update"
+
+// profileVerifyGracePeriod is how long the profile is given to take effect after its last step, as the BMC restarts
+// after a firmware update, and the BIOS firmware and attributes are only applied during the boot following the reset
+const profileVerifyGracePeriod = 15 * time.Minute
+
+type firmwareComponent string
+
+const (
+	firmwareComponentBIOS firmwareComponent = "bios"
+	firmwareComponentBMC  firmwareComponent = "bmc"
+)
+
+// profileStep is a step of applying a hardware profile to a server
+type profileStep string
+
+const (
+	profileStepBMCFirmware  profileStep = "bmc-firmware"
+	profileStepBIOSFirmware profileStep = "bios-firmware"
+	profileStepBIOSSettings profileStep = "bios-settings"
+	profileStepReset        profileStep = "reset"
+	profileStepVerify       profileStep = "verify"
+)
+
+// profileUpdate tracks the steps issued to apply a hardware profile to a node
+type profileUpdate struct {
+	Profile      string        `json:"profile"`
+	StartTime    string        `json:"startTime"`
+	Issued       []profileStep `json:"issued,omitempty"`
+	TaskPath     string        `json:"taskPath,omitempty"`
+	LastStepTime string        `json:"lastStepTime,omitempty"`
+}
+
+func (u *profileUpdate) issued(step profileStep) bool {
+	return slices.Contains(u.Issued, step)
+}
+
+// getProfileUpdate returns the profile update recorded on the node, if any. A malformed record is discarded, which
+// restarts the update.
+func getProfileUpdate(node *hwmgmtv1alpha1.Node) *profileUpdate {
+	value, exists := node.GetAnnotations()[ProfileUpdateAnnotation]
+	if !exists {
+		return nil
+	}
+	update := &profileUpdate{}
+	if err := json.Unmarshal([]byte(value), update); err != nil {
+		return nil
+	}
+	return update
+}
+
+func setProfileUpdate(node *hwmgmtv1alpha1.Node, update *profileUpdate) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile update: %w", err)
+	}
+	annotations := node.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ProfileUpdateAnnotation] = string(data)
+	node.SetAnnotations(annotations)
+	return nil
+}
+
+func clearProfileUpdate(node *hwmgmtv1alpha1.Node) {
+	annotations := node.GetAnnotations()
+	if annotations != nil {
+		delete(annotations, ProfileUpdateAnnotation)
+	}
+}
+
+// firmwareNeedsUpdate checks whether the firmware of the profile differs from the current version
+func firmwareNeedsUpdate(firmware pluginv1alpha1.Firmware, current string) bool {
+	return firmware.URL != "" && firmware.Version != current
+}
+
+// nextProfileStep returns the next step of applying a profile to a server. Each step is issued once: the firmware
+// updates first, as the BIOS firmware update may reset the BIOS attributes, then the BIOS attributes, then a reset of
+// the system to apply the BIOS changes, and finally the verification of the profile.
+func nextProfileStep(update *profileUpdate, spec pluginv1alpha1.HardwareProfileSpec, state *serverState,
+	biosAttributes map[string]any) profileStep {
+
+	if firmwareNeedsUpdate(spec.BmcFirmware, state.bmcFirmwareVersion()) && !update.issued(profileStepBMCFirmware) {
+		return profileStepBMCFirmware
+	}
+	if firmwareNeedsUpdate(spec.BiosFirmware, state.System.BiosVersion) && !update.issued(profileStepBIOSFirmware) {
+		return profileStepBIOSFirmware
+	}
+	if len(utils.PendingBiosAttributes(spec.Bios.Attributes, biosAttributes)) > 0 && !update.issued(profileStepBIOSSettings) {
+		return profileStepBIOSSettings
+	}
+	if (update.issued(profileStepBIOSFirmware) || update.issued(profileStepBIOSSettings)) && !update.issued(profileStepReset) {
+		return profileStepReset
+	}
+	return profileStepVerify
+}
+
+// profileMismatch describes how the server differs from the profile, returning an empty string if it matches
+func profileMismatch(spec pluginv1alpha1.HardwareProfileSpec, state *serverState, biosAttributes map[string]any) string {
+	var mismatches []string
+	if spec.BmcFirmware.Version != "" && spec.BmcFirmware.Version != state.bmcFirmwareVersion() {
+		mismatches = append(mismatches, fmt.Sprintf("BMC firmware is at version %s, expected %s",
+			state.bmcFirmwareVersion(), spec.BmcFirmware.Version))
+	}
+	if spec.BiosFirmware.Version != "" && spec.BiosFirmware.Version != state.System.BiosVersion {
+		mismatches = append(mismatches, fmt.Sprintf("BIOS firmware is at version %s, expected %s",
+			state.System.BiosVersion, spec.BiosFirmware.Version))
+	}
+
+	mismatches = append(mismatches, utils.BiosAttributeMismatches(spec.Bios.Attributes, biosAttributes)...)
+
+	return strings.Join(mismatches, "; ")
+}
+
+// profileUpdateResult is the outcome of a pass of applying a hardware profile to a node
+type profileUpdateResult struct {
+	// Done is set once the profile has been applied and verified
+	Done bool
+	// Failure is set if the profile cannot be applied
+	Failure string
+	// TimedOut is set if the failure is due to the firmware job timeout
+	TimedOut bool
+}
+
+// progressProfileUpdate advances the application of the hardware profile in the spec of a node to its server by one
+// step. Errors are transient, such as the BMC being unreachable while it restarts, and the pass is retried.
+func (a *Adaptor) progressProfileUpdate(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	node *hwmgmtv1alpha1.Node) (profileUpdateResult, error) {
+
+	server := findServer(hwmgr, node.Spec.HwMgrNodeId)
+	if server == nil {
+		return profileUpdateResult{Failure: fmt.Sprintf("server %s is no longer configured", node.Spec.HwMgrNodeId)}, nil
+	}
+
+	hwProfile, _, err := utils.ResolveHardwareProfile(ctx, a.Client, node.Spec.HwProfile, a.Namespace)
+	if err != nil {
+		if typederrors.IsInputError(err) {
+			return profileUpdateResult{Failure: err.Error()}, nil
+		}
+		return profileUpdateResult{}, fmt.Errorf("unable to resolve HardwareProfile CR (%s): %w", node.Spec.HwProfile, err)
+	}
+	spec := hwProfile.Spec
+
+	// The boot order is applied through the BIOS attributes, along with those of the profile
+	if spec.Bios.Attributes, err = utils.ProfileBiosAttributes(spec, utils.VendorSupermicro); err != nil {
+		return profileUpdateResult{Failure: err.Error()}, nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	update := getProfileUpdate(node)
+	if update == nil || update.Profile != node.Spec.HwProfile {
+		update = &profileUpdate{Profile: node.Spec.HwProfile, StartTime: a.clock().Now().UTC().Format(time.RFC3339)}
+		utils.StartNodeOperation(node, utils.NodeOperationProfileUpdate, "", a.clock())
+	}
+	save := func() error {
+		if err := setProfileUpdate(node, update); err != nil {
+			return err
+		}
+		if err := a.Client.Patch(ctx, node, patch); err != nil {
+			return fmt.Errorf("failed to patch Node %s: %w", node.Name, err)
+		}
+		return nil
+	}
+
+	if timeout := utils.GetOperationTimeout(hwmgr, utils.OperationFirmwareJob); timeout > 0 {
+		if start, err := time.Parse(time.RFC3339, update.StartTime); err == nil && a.clock().Since(start) > timeout {
+			return profileUpdateResult{Failure: fmt.Sprintf("timed out after %s", timeout), TimedOut: true}, nil
+		}
+	}
+
+	rf, err := a.newRedfishClient(ctx, hwmgr, server)
+	if err != nil {
+		return profileUpdateResult{}, err
+	}
+
+	// Wait for an in-flight firmware update to finish before checking the server
+	if update.TaskPath != "" {
+		task := &redfishTask{}
+		if err := rf.Get(ctx, update.TaskPath, task); err != nil {
+			return profileUpdateResult{}, fmt.Errorf("failed to get firmware update task of server %s: %w", server.Name, err)
+		}
+		done, failure := taskOutcome(task)
+		if failure != "" {
+			return profileUpdateResult{Failure: "firmware update failed: " + failure}, nil
+		}
+		if !done {
+			if task.PercentComplete != nil {
+				if err := utils.ReportNodeProgress(ctx, a.Client, node.Name, node.Namespace, utils.NodeProgress{
+					Percent: *task.PercentComplete,
+					Message: "Updating firmware",
+				}, a.clock()); err != nil {
+					a.Logger.WarnContext(ctx, "failed to report node progress", slog.String("error", err.Error()))
+				}
+			}
+			return profileUpdateResult{}, nil
+		}
+		update.TaskPath = ""
+		update.LastStepTime = a.clock().Now().UTC().Format(time.RFC3339)
+		if err := save(); err != nil {
+			return profileUpdateResult{}, err
+		}
+		patch = client.MergeFrom(node.DeepCopy())
+	}
+
+	state, err := getServerState(ctx, rf, server)
+	if err != nil {
+		return profileUpdateResult{}, err
+	}
+
+	var bios *redfishBios
+	biosAttributes := map[string]any{}
+	if len(spec.Bios.Attributes) > 0 {
+		if bios, err = getBios(ctx, rf, &state.System); err != nil {
+			return profileUpdateResult{}, fmt.Errorf("failed to get BIOS attributes of server %s: %w", server.Name, err)
+		}
+		biosAttributes = bios.Attributes
+	}
+
+	step := nextProfileStep(update, spec, state, biosAttributes)
+	if step == profileStepBMCFirmware || step == profileStepBIOSFirmware {
+		// Refuse firmware artifacts that fail signature verification before they are handed to the BMC
+		if err := utils.VerifyFirmwareArtifacts(ctx, a.Client, hwmgr, node.Spec.HwProfile, spec); err != nil {
+			if typederrors.IsInputError(err) {
+				return profileUpdateResult{Failure: err.Error()}, nil
+			}
+			return profileUpdateResult{}, err // nolint: wrapcheck
+		}
+	}
+	a.Logger.InfoContext(ctx, "Applying hardware profile",
+		slog.String("node", node.Name),
+		slog.String("server", server.Name),
+		slog.String("hwProfile", node.Spec.HwProfile),
+		slog.String("step", string(step)))
+
+	switch step {
+	case profileStepBMCFirmware:
+		if update.TaskPath, err = updateFirmware(ctx, rf, firmwareComponentBMC, spec.BmcFirmware.URL, state.bmcTarget()); err != nil {
+			return profileUpdateResult{}, fmt.Errorf("failed to start BMC firmware update of server %s: %w", server.Name, err)
+		}
+	case profileStepBIOSFirmware:
+		target := state.System.Bios.ODataID
+		if target == "" {
+			target = state.SystemPath + "/Bios"
+		}
+		if update.TaskPath, err = updateFirmware(ctx, rf, firmwareComponentBIOS, spec.BiosFirmware.URL, target); err != nil {
+			return profileUpdateResult{}, fmt.Errorf("failed to start BIOS firmware update of server %s: %w", server.Name, err)
+		}
+	case profileStepBIOSSettings:
+		pending := utils.PendingBiosAttributes(spec.Bios.Attributes, biosAttributes)
+		if err := setBiosAttributes(ctx, rf, biosSettingsPath(&state.System, bios), pending); err != nil {
+			return profileUpdateResult{}, fmt.Errorf("failed to set BIOS attributes of server %s: %w", server.Name, err)
+		}
+	case profileStepReset:
+		if err := resetSystem(ctx, rf, state.SystemPath, state.System.PowerState); err != nil {
+			return profileUpdateResult{}, fmt.Errorf("failed to reset server %s: %w", server.Name, err)
+		}
+	case profileStepVerify:
+		mismatch := profileMismatch(spec, state, biosAttributes)
+		if mismatch == "" {
+			return profileUpdateResult{Done: true}, nil
+		}
+		if last, err := time.Parse(time.RFC3339, update.LastStepTime); err == nil && a.clock().Since(last) < profileVerifyGracePeriod {
+			// The changes may not have taken effect yet
+			return profileUpdateResult{}, save()
+		}
+		return profileUpdateResult{Failure: "profile verification failed: " + mismatch}, nil
+	}
+
+	update.Issued = append(update.Issued, step)
+	update.LastStepTime = a.clock().Now().UTC().Format(time.RFC3339)
+	return profileUpdateResult{}, save()
+}
@@ -0,0 +1,122 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func testServerState(biosVersion, bmcVersion string) *serverState {
+	state := &serverState{SystemPath: "/redfish/v1/Systems/1", Manager: &redfishManager{FirmwareVersion: bmcVersion}}
+	state.System.BiosVersion = biosVersion
+	return state
+}
+
+func TestNextProfileStep(t *testing.T) {
+	spec := pluginv1alpha1.HardwareProfileSpec{
+		Bios:         pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{"SMTControl": intstr.FromString("Enabled")}},
+		BiosFirmware: pluginv1alpha1.Firmware{Version: "2.1", URL: "https://fw.example.com/bios-2.1.bin"},
+		BmcFirmware:  pluginv1alpha1.Firmware{Version: "1.5", URL: "https://fw.example.com/bmc-1.5.bin"},
+	}
+	stale := map[string]any{"SMTControl": "Disabled"}
+	applied := map[string]any{"SMTControl": "Enabled"}
+
+	testcases := []struct {
+		name   string
+		issued []profileStep
+		state  *serverState
+		bios   map[string]any
+		step   profileStep
+	}{
+		{
+			name:  "BMC firmware first",
+			state: testServerState("2.0", "1.4"),
+			bios:  stale,
+			step:  profileStepBMCFirmware,
+		},
+		{
+			name:   "BIOS firmware after BMC firmware",
+			issued: []profileStep{profileStepBMCFirmware},
+			state:  testServerState("2.0", "1.5"),
+			bios:   stale,
+			step:   profileStepBIOSFirmware,
+		},
+		{
+			name:   "BIOS attributes after firmware",
+			issued: []profileStep{profileStepBMCFirmware, profileStepBIOSFirmware},
+			state:  testServerState("2.0", "1.5"),
+			bios:   stale,
+			step:   profileStepBIOSSettings,
+		},
+		{
+			name:   "reset after BIOS changes",
+			issued: []profileStep{profileStepBMCFirmware, profileStepBIOSFirmware, profileStepBIOSSettings},
+			state:  testServerState("2.0", "1.5"),
+			bios:   stale,
+			step:   profileStepReset,
+		},
+		{
+			name:   "verify after reset",
+			issued: []profileStep{profileStepBIOSFirmware, profileStepBIOSSettings, profileStepReset},
+			state:  testServerState("2.0", "1.5"),
+			bios:   stale,
+			step:   profileStepVerify,
+		},
+		{
+			name:  "server already matches the profile",
+			state: testServerState("2.1", "1.5"),
+			bios:  applied,
+			step:  profileStepVerify,
+		},
+		{
+			name:  "no reset for a BMC update alone",
+			state: testServerState("2.1", "1.4"),
+			bios:  applied,
+			step:  profileStepBMCFirmware,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			update := &profileUpdate{Issued: tc.issued}
+			if step := nextProfileStep(update, spec, tc.state, tc.bios); step != tc.step {
+				t.Errorf("expected step %s, got %s", tc.step, step)
+			}
+		})
+	}
+
+	update := &profileUpdate{Issued: []profileStep{profileStepBMCFirmware}}
+	if step := nextProfileStep(update, spec, testServerState("2.1", "1.4"), applied); step != profileStepVerify {
+		t.Errorf("expected verification after BMC update, got %s", step)
+	}
+}
+
+func TestProfileMismatch(t *testing.T) {
+	spec := pluginv1alpha1.HardwareProfileSpec{
+		Bios: pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{
+			"SMTControl": intstr.FromString("Enabled"),
+			"SecureBoot": intstr.FromString("Enabled"),
+		}},
+		BiosFirmware: pluginv1alpha1.Firmware{Version: "2.1"},
+	}
+
+	if mismatch := profileMismatch(spec, testServerState("2.1", ""),
+		map[string]any{"SMTControl": "Enabled", "SecureBoot": "Enabled"}); mismatch != "" {
+		t.Errorf("expected no mismatch, got %s", mismatch)
+	}
+
+	mismatch := profileMismatch(spec, testServerState("2.0", ""), map[string]any{"SMTControl": "Disabled"})
+	expected := "BIOS firmware is at version 2.0, expected 2.1; BIOS attribute SMTControl is Disabled, expected Enabled; " +
+		"BIOS attribute SecureBoot is not reported"
+	if mismatch != expected {
+		t.Errorf("unexpected mismatch: %s", mismatch)
+	}
+}
@@ -0,0 +1,278 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/redfish"
+)
+
+const redfishSimpleUpdatePath = "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+
+// Redfish task states, as defined by the TaskState enumeration of the Task schema
+const (
+	redfishTaskCompleted   = "Completed"
+	redfishTaskException   = "Exception"
+	redfishTaskKilled      = "Killed"
+	redfishTaskCancelled   = "Cancelled"
+	redfishTaskInterrupted = "Interrupted"
+)
+
+type redfishStatus struct {
+	State  string `json:"State"`
+	Health string `json:"Health"`
+}
+
+// redfishSystem is the subset of a ComputerSystem resource used by the adaptor
+type redfishSystem struct {
+	ID               string        `json:"Id"`
+	Manufacturer     string        `json:"Manufacturer"`
+	Model            string        `json:"Model"`
+	SerialNumber     string        `json:"SerialNumber"`
+	PartNumber       string        `json:"PartNumber"`
+	SKU              string        `json:"SKU"`
+	UUID             string        `json:"UUID"`
+	PowerState       string        `json:"PowerState"`
+	BiosVersion      string        `json:"BiosVersion"`
+	Status           redfishStatus `json:"Status"`
+	ProcessorSummary struct {
+		Count int    `json:"Count"`
+		Model string `json:"Model"`
+	} `json:"ProcessorSummary"`
+	MemorySummary struct {
+		TotalSystemMemoryGiB float64 `json:"TotalSystemMemoryGiB"`
+	} `json:"MemorySummary"`
+	Bios               redfish.ODataID `json:"Bios"`
+	Processors         redfish.ODataID `json:"Processors"`
+	EthernetInterfaces redfish.ODataID `json:"EthernetInterfaces"`
+	Links              struct {
+		ManagedBy []redfish.ODataID `json:"ManagedBy"`
+	} `json:"Links"`
+}
+
+type redfishProcessor struct {
+	ProcessorType         string `json:"ProcessorType"`
+	ProcessorArchitecture string `json:"ProcessorArchitecture"`
+	InstructionSet        string `json:"InstructionSet"`
+	Manufacturer          string `json:"Manufacturer"`
+	Model                 string `json:"Model"`
+	TotalCores            int    `json:"TotalCores"`
+}
+
+type redfishManager struct {
+	ODataID         string `json:"@odata.id"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+}
+
+type redfishEthernetInterface struct {
+	ID                  string `json:"Id"`
+	Name                string `json:"Name"`
+	MACAddress          string `json:"MACAddress"`
+	PermanentMACAddress string `json:"PermanentMACAddress"`
+}
+
+type redfishBios struct {
+	Attributes map[string]any `json:"Attributes"`
+	Settings   struct {
+		SettingsObject redfish.ODataID `json:"SettingsObject"`
+	} `json:"@Redfish.Settings"`
+}
+
+type redfishTask struct {
+	TaskState       string            `json:"TaskState"`
+	TaskStatus      string            `json:"TaskStatus"`
+	PercentComplete *int              `json:"PercentComplete"`
+	Messages        []redfish.Message `json:"Messages"`
+}
+
+// newRedfishClient returns a client of the BMC of a server, authenticated with the credentials from its secret
+func (a *Adaptor) newRedfishClient(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	server *pluginv1alpha1.SupermicroServer) (*redfish.Client, error) {
+
+	secret := &corev1.Secret{}
+	if err := a.Client.Get(ctx, types.NamespacedName{Name: server.CredentialsName, Namespace: a.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get BMC credentials for server %s: %w", server.Name, err)
+	}
+
+	tr, err := a.newTransport(ctx, hwmgr)
+	if err != nil {
+		return nil, err
+	}
+
+	rf, err := redfish.NewClient(&http.Client{Transport: tr}, server.BMCAddress, redfish.CredentialsFromSecret(secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Redfish client for server %s: %w", server.Name, err)
+	}
+	return rf, nil
+}
+
+// newTransport returns the transport for BMC requests, trusting the CA bundle configured for the HardwareManager
+func (a *Adaptor) newTransport(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager) (http.RoundTripper, error) {
+	var caBundle string
+	if hwmgr.Spec.SupermicroData.CaBundleName != nil {
+		cm, err := utils.GetConfigmap(ctx, a.Client, *hwmgr.Spec.SupermicroData.CaBundleName, hwmgr.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap: %w", err)
+		}
+
+		caBundle, err = utils.GetConfigMapField(cm, "ca-bundle.pem")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get certificate bundle from configmap: %w", err)
+		}
+	}
+
+	tr, err := utils.GetTransportWithCaBundle(utils.OAuthClientConfig{CaBundle: []byte(caBundle)},
+		hwmgr.Spec.SupermicroData.InsecureSkipTLSVerify, utils.IsHardwareManagerLogMessagesEnabled(hwmgr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get http transport: %w", err)
+	}
+	return redfish.NewTransport(tr, hwmgr, a.clock()), nil
+}
+
+// getMembers fetches each member of a collection
+func getMembers[T any](ctx context.Context, rf *redfish.Client, path string) ([]T, error) {
+	var collection redfish.Collection
+	if err := rf.Get(ctx, path, &collection); err != nil {
+		return nil, err
+	}
+
+	members := make([]T, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		var item T
+		if err := rf.Get(ctx, member.ODataID, &item); err != nil {
+			return nil, err
+		}
+		members = append(members, item)
+	}
+	return members, nil
+}
+
+// getBios returns the BIOS resource of a system
+func getBios(ctx context.Context, rf *redfish.Client, system *redfishSystem) (*redfishBios, error) {
+	if system.Bios.ODataID == "" {
+		return nil, fmt.Errorf("system %s does not report a BIOS resource", system.ID)
+	}
+	bios := &redfishBios{}
+	if err := rf.Get(ctx, system.Bios.ODataID, bios); err != nil {
+		return nil, err
+	}
+	return bios, nil
+}
+
+// biosSettingsPath returns the path that pending BIOS attributes are written to. Supermicro BMCs advertise a settings
+// object, such as /redfish/v1/Systems/1/Bios/SD, while older BMCs accept the attributes on the BIOS resource itself.
+func biosSettingsPath(system *redfishSystem, bios *redfishBios) string {
+	if bios.Settings.SettingsObject.ODataID != "" {
+		return bios.Settings.SettingsObject.ODataID
+	}
+	return system.Bios.ODataID
+}
+
+// setBiosAttributes writes pending BIOS attributes, which are applied on the next reset of the system
+func setBiosAttributes(ctx context.Context, rf *redfish.Client, path string, attributes map[string]any) error {
+	_, err := rf.Do(ctx, http.MethodPatch, path, map[string]any{"Attributes": attributes}, nil)
+	return err
+}
+
+// resetSystem restarts the system, or powers it on if it is off
+func resetSystem(ctx context.Context, rf *redfish.Client, systemPath, powerState string) error {
+	resetType := "ForceRestart"
+	if strings.EqualFold(powerState, redfish.PowerStateOff) {
+		resetType = redfish.PowerStateOn
+	}
+	return rf.Reset(ctx, systemPath, resetType) // nolint: wrapcheck
+}
+
+// firmwareUpdateRequest builds a SimpleUpdate request for the firmware of a component. The Supermicro OEM options
+// preserve the BMC configuration and the BIOS settings across the update, so that the update does not undo the BIOS
+// attributes of the profile.
+func firmwareUpdateRequest(component firmwareComponent, imageURI, target string) map[string]any {
+	var oem map[string]any
+	switch component {
+	case firmwareComponentBIOS:
+		oem = map[string]any{"BIOS": map[string]bool{
+			"PreserveME":     true,
+			"PreserveNVRAM":  true,
+			"PreserveSMBIOS": true,
+			"BackupBIOS":     false,
+		}}
+	case firmwareComponentBMC:
+		oem = map[string]any{"BMC": map[string]bool{
+			"PreserveCfg": true,
+			"PreserveSdr": true,
+			"PreserveSsl": true,
+			"BackupBMC":   true,
+		}}
+	}
+
+	protocol := "HTTP"
+	if strings.HasPrefix(strings.ToLower(imageURI), "https://") {
+		protocol = "HTTPS"
+	}
+
+	return map[string]any{
+		"ImageURI":         imageURI,
+		"TransferProtocol": protocol,
+		"Targets":          []string{target},
+		"Oem":              map[string]any{"Supermicro": oem},
+	}
+}
+
+// updateFirmware starts a firmware update of a component, returning the path of the task tracking it
+func updateFirmware(ctx context.Context, rf *redfish.Client, component firmwareComponent, imageURI, target string) (string, error) {
+	var task redfish.ODataID
+	header, err := rf.Do(ctx, http.MethodPost, redfishSimpleUpdatePath, firmwareUpdateRequest(component, imageURI, target), &task)
+	if err != nil {
+		return "", err
+	}
+
+	if location := header.Get("Location"); location != "" {
+		if parsed, err := url.Parse(location); err == nil {
+			return parsed.Path, nil
+		}
+	}
+	if task.ODataID != "" {
+		return task.ODataID, nil
+	}
+	return "", fmt.Errorf("firmware update of %s did not report a task", component)
+}
+
+// taskOutcome reports whether a task has finished, and the reason it failed, if it did
+func taskOutcome(task *redfishTask) (done bool, failure string) {
+	switch task.TaskState {
+	case redfishTaskCompleted:
+		if strings.EqualFold(task.TaskStatus, "Critical") {
+			return true, taskMessage(task, "task completed with critical status")
+		}
+		return true, ""
+	case redfishTaskException, redfishTaskKilled, redfishTaskCancelled, redfishTaskInterrupted:
+		return true, taskMessage(task, "task ended in state "+task.TaskState)
+	}
+	return false, ""
+}
+
+// taskMessage returns the last message reported by a task, or the fallback if there is none
+func taskMessage(task *redfishTask, fallback string) string {
+	for i := len(task.Messages) - 1; i >= 0; i-- {
+		if task.Messages[i].Message != "" {
+			return task.Messages[i].Message
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,197 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package supermicro
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/redfish"
+)
+
+// newTestRedfishClient returns a client of a fake BMC serving the given resources, recording the requests it receives
+func newTestRedfishClient(t *testing.T, resources map[string]any, requests *[]*http.Request,
+	bodies *[]map[string]any) *redfish.Client {
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if requests != nil {
+			*requests = append(*requests, r)
+		}
+		if r.Method != http.MethodGet {
+			body := map[string]any{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if bodies != nil {
+				*bodies = append(*bodies, body)
+			}
+			if r.URL.Path == redfishSimpleUpdatePath {
+				w.Header().Set("Location", "https://"+r.Host+"/redfish/v1/TaskService/Tasks/7")
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		resource, exists := resources[r.URL.Path]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"Not found","@Message.ExtendedInfo":[{"Message":"The resource was not found."}]}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resource)
+	}))
+	t.Cleanup(server.Close)
+
+	rf, err := redfish.NewClient(server.Client(), server.URL, redfish.Credentials{Username: "admin", Password: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rf
+}
+
+func TestGetServerState(t *testing.T) {
+	resources := map[string]any{
+		"/redfish/v1/Systems": map[string]any{"Members": []any{map[string]string{"@odata.id": "/redfish/v1/Systems/1"}}},
+		"/redfish/v1/Systems/1": map[string]any{
+			"Id":            "1",
+			"Manufacturer":  "Supermicro",
+			"Model":         "SYS-E403-13E",
+			"SerialNumber":  "S123",
+			"UUID":          "0a1b2c3d-0000-0000-0000-000000000001",
+			"PowerState":    "On",
+			"BiosVersion":   "2.0",
+			"Status":        map[string]string{"State": "Enabled", "Health": "OK"},
+			"MemorySummary": map[string]any{"TotalSystemMemoryGiB": 256},
+			"Bios":          map[string]string{"@odata.id": "/redfish/v1/Systems/1/Bios"},
+			"Processors":    map[string]string{"@odata.id": "/redfish/v1/Systems/1/Processors"},
+			"Links":         map[string]any{"ManagedBy": []any{map[string]string{"@odata.id": "/redfish/v1/Managers/1"}}},
+		},
+		"/redfish/v1/Systems/1/Processors": map[string]any{"Members": []any{
+			map[string]string{"@odata.id": "/redfish/v1/Systems/1/Processors/1"},
+			map[string]string{"@odata.id": "/redfish/v1/Systems/1/Processors/GPU1"},
+		}},
+		"/redfish/v1/Systems/1/Processors/1": map[string]any{
+			"ProcessorType": "CPU", "InstructionSet": "x86-64", "Manufacturer": "Intel(R) Corporation",
+			"Model": "Xeon", "TotalCores": 32,
+		},
+		"/redfish/v1/Systems/1/Processors/GPU1": map[string]any{"ProcessorType": "GPU", "Model": "Accelerator"},
+		"/redfish/v1/Managers/1":                map[string]any{"@odata.id": "/redfish/v1/Managers/1", "FirmwareVersion": "1.4"},
+	}
+	rf := newTestRedfishClient(t, resources, nil, nil)
+
+	server := testServer("edge-1", "pool-a", "")
+	state, err := getServerState(context.Background(), rf, &server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.SystemPath != "/redfish/v1/Systems/1" {
+		t.Errorf("unexpected system path: %s", state.SystemPath)
+	}
+	if state.bmcFirmwareVersion() != "1.4" || state.bmcTarget() != "/redfish/v1/Managers/1" {
+		t.Errorf("unexpected BMC details: %+v", state.Manager)
+	}
+	if arch := state.cpuArchitecture(); arch != "x86_64" {
+		t.Errorf("unexpected architecture: %s", arch)
+	}
+
+	resource := getResourceInfo(&server, state, true)
+	if resource.Memory != 256*1024 || len(resource.Processors) != 1 || *resource.Processors[0].Cores != 32 {
+		t.Errorf("unexpected resource: %+v", resource)
+	}
+	if resource.Vendor != "Supermicro" || resource.Model != "SYS-E403-13E" || *resource.GlobalAssetId != "0a1b2c3d-0000-0000-0000-000000000001" {
+		t.Errorf("unexpected resource identity: %+v", resource)
+	}
+	if resource.OperationalState != "ENABLED" || resource.UsageState != "ACTIVE" || *resource.PowerState != "ON" {
+		t.Errorf("unexpected resource states: %+v", resource)
+	}
+}
+
+func TestSystemPathFromBMCAddress(t *testing.T) {
+	rf := newTestRedfishClient(t, map[string]any{}, nil, nil)
+	path, err := rf.SystemPath(context.Background(), "https://10.1.2.3/redfish/v1/Systems/1/")
+	if err != nil || path != "/redfish/v1/Systems/1" {
+		t.Errorf("expected system path from the BMC address, got %s, %v", path, err)
+	}
+
+	if _, err := rf.SystemPath(context.Background(), "https://10.1.2.3"); err == nil ||
+		!strings.Contains(err.Error(), "The resource was not found.") {
+		t.Errorf("expected the Redfish error message, got %v", err)
+	}
+}
+
+func TestUpdateFirmware(t *testing.T) {
+	var requests []*http.Request
+	var bodies []map[string]any
+	rf := newTestRedfishClient(t, map[string]any{}, &requests, &bodies)
+
+	task, err := updateFirmware(context.Background(), rf, firmwareComponentBIOS, "https://fw.example.com/bios.bin", "/redfish/v1/Systems/1/Bios")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task != "/redfish/v1/TaskService/Tasks/7" {
+		t.Errorf("unexpected task: %s", task)
+	}
+
+	body := bodies[0]
+	if body["ImageURI"] != "https://fw.example.com/bios.bin" || body["TransferProtocol"] != "HTTPS" {
+		t.Errorf("unexpected request: %v", body)
+	}
+	oem := body["Oem"].(map[string]any)["Supermicro"].(map[string]any)["BIOS"].(map[string]any)
+	if oem["PreserveNVRAM"] != true || oem["PreserveSMBIOS"] != true {
+		t.Errorf("expected the BIOS settings to be preserved, got %v", oem)
+	}
+}
+
+func TestBiosSettingsPath(t *testing.T) {
+	system := &redfishSystem{}
+	system.Bios.ODataID = "/redfish/v1/Systems/1/Bios"
+
+	bios := &redfishBios{}
+	if path := biosSettingsPath(system, bios); path != "/redfish/v1/Systems/1/Bios" {
+		t.Errorf("expected the BIOS resource, got %s", path)
+	}
+	bios.Settings.SettingsObject.ODataID = "/redfish/v1/Systems/1/Bios/SD"
+	if path := biosSettingsPath(system, bios); path != "/redfish/v1/Systems/1/Bios/SD" {
+		t.Errorf("expected the settings object, got %s", path)
+	}
+}
+
+func TestTaskOutcome(t *testing.T) {
+	testcases := []struct {
+		name    string
+		task    redfishTask
+		done    bool
+		failure string
+	}{
+		{name: "running", task: redfishTask{TaskState: "Running"}},
+		{name: "completed", task: redfishTask{TaskState: "Completed", TaskStatus: "OK"}, done: true},
+		{
+			name:    "completed with critical status",
+			task:    redfishTask{TaskState: "Completed", TaskStatus: "Critical", Messages: []redfish.Message{{Message: "Image verification failed"}}},
+			done:    true,
+			failure: "Image verification failed",
+		},
+		{name: "exception", task: redfishTask{TaskState: "Exception"}, done: true, failure: "task ended in state Exception"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			done, failure := taskOutcome(&tc.task)
+			if done != tc.done || failure != tc.failure {
+				t.Errorf("expected done=%v failure=%q, got done=%v failure=%q", tc.done, tc.failure, done, failure)
+			}
+		})
+	}
+}
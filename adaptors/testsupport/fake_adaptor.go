@@ -0,0 +1,172 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package testsupport provides test doubles for unit testing code that depends on hardware manager adaptors.
+package testsupport
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	adaptorinterface "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/adaptor-interface"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	invserver "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// FakeAdaptor is a programmable implementation of the adaptor interface. Each method returns the canned response
+// from the corresponding field, unless the matching func field is set, in which case it is called instead. All calls
+// are recorded by method name.
+type FakeAdaptor struct {
+	mu    sync.Mutex
+	calls []string
+
+	SetupAdaptorErr error
+
+	HandleNodePoolResult ctrl.Result
+	HandleNodePoolErr    error
+	HandleNodePoolFunc   func(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error)
+
+	HandleNodePoolDeletionCompleted bool
+	HandleNodePoolDeletionErr       error
+	HandleNodePoolDeletionFunc      func(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (bool, error)
+
+	ResourcePools           []invserver.ResourcePoolInfo
+	ResourcePoolsStatusCode int
+	ResourcePoolsErr        error
+
+	Resources           []invserver.ResourceInfo
+	ResourcesStatusCode int
+	ResourcesErr        error
+
+	Allocations    []adaptorinterface.BackendAllocation
+	AllocationsErr error
+
+	RefreshInventoryErr error
+
+	SelfTestChecks []pluginv1alpha1.SelfTestCheck
+
+	Disabled string
+
+	StartErr  error
+	StartFunc func(ctx context.Context) error
+	StopErr   error
+	StopFunc  func(ctx context.Context) error
+}
+
+var (
+	_ adaptorinterface.HwMgrAdaptorIntf               = (*FakeAdaptor)(nil)
+	_ adaptorinterface.HwMgrAdaptorAllocationsIntf    = (*FakeAdaptor)(nil)
+	_ adaptorinterface.HwMgrAdaptorInventoryCacheIntf = (*FakeAdaptor)(nil)
+	_ adaptorinterface.HwMgrAdaptorLifecycleIntf      = (*FakeAdaptor)(nil)
+	_ adaptorinterface.HwMgrAdaptorSelfTestIntf       = (*FakeAdaptor)(nil)
+	_ adaptorinterface.HwMgrAdaptorStatusIntf         = (*FakeAdaptor)(nil)
+)
+
+// NewFakeAdaptor returns a FakeAdaptor that succeeds with empty responses
+func NewFakeAdaptor() *FakeAdaptor {
+	return &FakeAdaptor{
+		HandleNodePoolDeletionCompleted: true,
+		ResourcePoolsStatusCode:         http.StatusOK,
+		ResourcesStatusCode:             http.StatusOK,
+	}
+}
+
+func (f *FakeAdaptor) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+// Calls returns the names of the methods called so far, in order
+func (f *FakeAdaptor) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.calls...)
+}
+
+// CallCount returns the number of times the named method was called
+func (f *FakeAdaptor) CallCount(method string) int {
+	count := 0
+	for _, call := range f.Calls() {
+		if call == method {
+			count++
+		}
+	}
+	return count
+}
+
+func (f *FakeAdaptor) SetupAdaptor(_ ctrl.Manager) error {
+	f.record("SetupAdaptor")
+	return f.SetupAdaptorErr
+}
+
+func (f *FakeAdaptor) HandleNodePool(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (ctrl.Result, error) {
+	f.record("HandleNodePool")
+	if f.HandleNodePoolFunc != nil {
+		return f.HandleNodePoolFunc(ctx, hwmgr, nodepool)
+	}
+	return f.HandleNodePoolResult, f.HandleNodePoolErr
+}
+
+func (f *FakeAdaptor) HandleNodePoolDeletion(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool) (bool, error) {
+	f.record("HandleNodePoolDeletion")
+	if f.HandleNodePoolDeletionFunc != nil {
+		return f.HandleNodePoolDeletionFunc(ctx, hwmgr, nodepool)
+	}
+	return f.HandleNodePoolDeletionCompleted, f.HandleNodePoolDeletionErr
+}
+
+func (f *FakeAdaptor) GetResourcePools(_ context.Context, _ *pluginv1alpha1.HardwareManager) ([]invserver.ResourcePoolInfo, int, error) {
+	f.record("GetResourcePools")
+	return f.ResourcePools, f.ResourcePoolsStatusCode, f.ResourcePoolsErr
+}
+
+func (f *FakeAdaptor) GetResources(_ context.Context, _ *pluginv1alpha1.HardwareManager) ([]invserver.ResourceInfo, int, error) {
+	f.record("GetResources")
+	return f.Resources, f.ResourcesStatusCode, f.ResourcesErr
+}
+
+func (f *FakeAdaptor) GetBackendAllocations(_ context.Context, _ *pluginv1alpha1.HardwareManager) ([]adaptorinterface.BackendAllocation, error) {
+	f.record("GetBackendAllocations")
+	return f.Allocations, f.AllocationsErr
+}
+
+func (f *FakeAdaptor) RefreshInventory(_ context.Context, _ *pluginv1alpha1.HardwareManager) (int, int, error) {
+	f.record("RefreshInventory")
+	if f.RefreshInventoryErr != nil {
+		return 0, 0, f.RefreshInventoryErr
+	}
+	return len(f.ResourcePools), len(f.Resources), nil
+}
+
+func (f *FakeAdaptor) SelfTest(_ context.Context, _ *pluginv1alpha1.HardwareManager) []pluginv1alpha1.SelfTestCheck {
+	f.record("SelfTest")
+	return f.SelfTestChecks
+}
+
+func (f *FakeAdaptor) DisabledReason() string {
+	return f.Disabled
+}
+
+func (f *FakeAdaptor) Start(ctx context.Context) error {
+	f.record("Start")
+	if f.StartFunc != nil {
+		return f.StartFunc(ctx)
+	}
+	return f.StartErr
+}
+
+func (f *FakeAdaptor) Stop(ctx context.Context) error {
+	f.record("Stop")
+	if f.StopFunc != nil {
+		return f.StopFunc(ctx)
+	}
+	return f.StopErr
+}
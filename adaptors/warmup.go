@@ -0,0 +1,131 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// inventoryWarmupTimeout bounds how long the warm-up may block the inventory API
+const inventoryWarmupTimeout = 5 * time.Minute
+
+// inventoryWarmup pre-populates the informer caches used by the inventory API. It runs on every replica, as the
+// inventory API is served regardless of leader election.
+type inventoryWarmup struct {
+	controller *HwMgrAdaptorController
+	mgr        ctrl.Manager
+
+	// timeout bounds the warm-up, inventoryWarmupTimeout if zero
+	timeout time.Duration
+}
+
+func (w *inventoryWarmup) NeedLeaderElection() bool {
+	return false
+}
+
+func (w *inventoryWarmup) Start(ctx context.Context) error {
+	c := w.controller
+	start := time.Now()
+	c.Logger.InfoContext(ctx, "Starting inventory warm-up")
+
+	timeout := w.timeout
+	if timeout == 0 {
+		timeout = inventoryWarmupTimeout
+	}
+	warmupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := w.warmup(warmupCtx); err != nil {
+		if ctx.Err() != nil {
+			// The manager is shutting down
+			return nil
+		}
+		// Serve the inventory anyway, as queries fall back to lazily populating the caches
+		c.Logger.ErrorContext(ctx, "Inventory warm-up failed", slog.String("error", err.Error()))
+	}
+
+	c.inventoryReady.Store(true)
+	c.Logger.InfoContext(ctx, "Inventory warm-up completed", slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+func (w *inventoryWarmup) warmup(ctx context.Context) error {
+	c := w.controller
+	cache := w.mgr.GetCache()
+
+	if !cache.WaitForCacheSync(ctx) {
+		return errors.New("timed out waiting for cache sync")
+	}
+
+	objects := []client.Object{
+		&pluginv1alpha1.HardwareManager{},
+		&hwmgmtv1alpha1.Node{},
+		&metal3v1alpha1.BareMetalHost{},
+	}
+	for _, obj := range objects {
+		// GetInformer starts the informer, if needed, and blocks until it has synced
+		if _, err := cache.GetInformer(ctx, obj); err != nil {
+			if meta.IsNoMatchError(err) {
+				// The CRD is not installed, so there is nothing to warm up
+				c.Logger.InfoContext(ctx, "Skipping inventory warm-up for missing kind", slog.String("kind", fmt.Sprintf("%T", obj)))
+				continue
+			}
+			return fmt.Errorf("failed to get informer for %T: %w", obj, err)
+		}
+	}
+
+	return nil
+}
+
+// setupInventoryWarmup registers the indexes used by the inventory and the warm-up runnable with the manager
+func (c *HwMgrAdaptorController) setupInventoryWarmup(mgr ctrl.Manager) error {
+	// Index Nodes by their hwmgr node ID, mapping BMHs and backend resources back to their Node
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &hwmgmtv1alpha1.Node{}, utils.NodeSpecHwMgrNodeIdKey,
+		func(obj client.Object) []string {
+			return []string{obj.(*hwmgmtv1alpha1.Node).Spec.HwMgrNodeId}
+		}); err != nil {
+		return fmt.Errorf("failed to setup node hwMgrNodeId indexer: %w", err)
+	}
+
+	if err := mgr.Add(&inventoryWarmup{controller: c, mgr: mgr}); err != nil {
+		return fmt.Errorf("failed to add inventory warm-up runnable: %w", err)
+	}
+	return nil
+}
+
+// IsInventoryReady returns true once the inventory caches have been warmed up
+func (c *HwMgrAdaptorController) IsInventoryReady() bool {
+	return c.inventoryReady.Load()
+}
+
+// MarkInventoryReady marks the inventory as available without a warm-up, for use when the controller is not run
+// by a manager, such as in unit tests
+func (c *HwMgrAdaptorController) MarkInventoryReady() {
+	c.inventoryReady.Store(true)
+}
+
+// InventoryReadyCheck is a readiness check that fails until the inventory caches have been warmed up
+func (c *HwMgrAdaptorController) InventoryReadyCheck(_ *http.Request) error {
+	if !c.IsInventoryReady() {
+		return errors.New("inventory warm-up in progress")
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adaptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	metal3v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// warmupCache serves the informers of the warm-up, failing or blocking them as configured
+type warmupCache struct {
+	cache.Cache
+	// synced is returned by WaitForCacheSync
+	synced bool
+	// informerErr fails the informer of the given kind
+	informerErr map[string]error
+	// block blocks every informer until the context is done
+	block bool
+
+	mu        sync.Mutex
+	informers []string
+}
+
+func (c *warmupCache) WaitForCacheSync(ctx context.Context) bool {
+	return c.synced
+}
+
+func (c *warmupCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	kind := fmt.Sprintf("%T", obj)
+	c.mu.Lock()
+	c.informers = append(c.informers, kind)
+	c.mu.Unlock()
+
+	if c.block {
+		<-ctx.Done()
+		return nil, fmt.Errorf("failed to sync informer: %w", ctx.Err())
+	}
+	return nil, c.informerErr[kind]
+}
+
+// warmupManager serves the cache of the warm-up
+type warmupManager struct {
+	ctrl.Manager
+	cache *warmupCache
+}
+
+func (m *warmupManager) GetCache() cache.Cache {
+	return m.cache
+}
+
+func TestInventoryWarmup(t *testing.T) {
+	bmhKind := fmt.Sprintf("%T", &metal3v1alpha1.BareMetalHost{})
+	noMatch := &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "metal3.io", Kind: "BareMetalHost"}}
+
+	testcases := []struct {
+		name      string
+		cache     *warmupCache
+		informers int
+	}{
+		{
+			name:      "success",
+			cache:     &warmupCache{synced: true},
+			informers: 3,
+		},
+		{
+			name:      "missing BareMetalHost CRD",
+			cache:     &warmupCache{synced: true, informerErr: map[string]error{bmhKind: noMatch}},
+			informers: 3,
+		},
+		{
+			name:      "cache sync failure",
+			cache:     &warmupCache{synced: false},
+			informers: 0,
+		},
+		{
+			name:      "failed informer",
+			cache:     &warmupCache{synced: true, informerErr: map[string]error{bmhKind: errors.New("forbidden")}},
+			informers: 3,
+		},
+		{
+			name:      "timeout",
+			cache:     &warmupCache{synced: true, block: true},
+			informers: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &HwMgrAdaptorController{Logger: slog.Default()}
+			w := &inventoryWarmup{controller: c, mgr: &warmupManager{cache: tc.cache}, timeout: 50 * time.Millisecond}
+
+			if err := w.Start(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// The inventory is served once the warm-up ends, even if it failed, as queries populate the caches lazily
+			if !c.IsInventoryReady() {
+				t.Errorf("expected the inventory to be ready")
+			}
+			if err := c.InventoryReadyCheck(nil); err != nil {
+				t.Errorf("unexpected readiness failure: %v", err)
+			}
+			if len(tc.cache.informers) != tc.informers {
+				t.Errorf("expected %d informers, got %v", tc.informers, tc.cache.informers)
+			}
+		})
+	}
+}
+
+func TestInventoryWarmupShutdown(t *testing.T) {
+	c := &HwMgrAdaptorController{Logger: slog.Default()}
+	w := &inventoryWarmup{controller: c, mgr: &warmupManager{cache: &warmupCache{synced: true, block: true}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.IsInventoryReady() {
+		t.Errorf("expected the inventory not to be served while the manager shuts down")
+	}
+	if err := c.InventoryReadyCheck(nil); err == nil {
+		t.Errorf("expected the readiness check to fail")
+	}
+}
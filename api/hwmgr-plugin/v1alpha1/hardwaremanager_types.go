@@ -15,13 +15,15 @@ type HardwareManagerAdaptorID string
 
 // SupportedAdaptors defines the string values for valid stages
 var SupportedAdaptors = struct {
-	Loopback HardwareManagerAdaptorID
-	Dell     HardwareManagerAdaptorID
-	Metal3   HardwareManagerAdaptorID
+	Loopback   HardwareManagerAdaptorID
+	Dell       HardwareManagerAdaptorID
+	Metal3     HardwareManagerAdaptorID
+	Supermicro HardwareManagerAdaptorID
 }{
-	Loopback: "loopback",
-	Dell:     "dell-hwmgr",
-	Metal3:   "metal3",
+	Loopback:   "loopback",
+	Dell:       "dell-hwmgr",
+	Metal3:     "metal3",
+	Supermicro: "supermicro",
 }
 
 // ConditionType is a string representing the condition's type
@@ -29,9 +31,13 @@ type ConditionType string
 
 // ConditionTypes define the different types of conditions that will be set
 var ConditionTypes = struct {
-	Validation ConditionType
+	Validation          ConditionType
+	Degraded            ConditionType
+	InventoryConsistent ConditionType
 }{
-	Validation: "Validation",
+	Validation:          "Validation",
+	Degraded:            "Degraded",
+	InventoryConsistent: "InventoryConsistent",
 }
 
 // ConditionReason is a string representing the condition's reason
@@ -93,6 +99,397 @@ type DellData struct {
 	// This is insecure and is not recommended.
 	// +optional
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures the TLS connection to the hardware manager, including a client certificate. When set, it takes
+	// precedence over caBundleName and insecureSkipTLSVerify.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Proxy configures the HTTP proxy used to reach the hardware manager. Settings left unset fall back to the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment of the plugin, which reflects the cluster-wide proxy.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// RedfishSystemPath overrides the Redfish path of the system in the BMC address of each node, such as
+	// /redfish/v1/Systems/System.Embedded.1. When unset, a BMC address without a system path is completed with the
+	// member of the Systems collection reported by the BMC.
+	// +optional
+	RedfishSystemPath *string `json:"redfishSystemPath,omitempty"`
+
+	// Relay routes the requests to the hardware manager through a relay agent, for hardware managers that are not
+	// reachable from the hub network. When set, the CA bundle and TLS verification settings apply to the relay.
+	// +optional
+	Relay *RelayConfig `json:"relay,omitempty"`
+
+	// JobPollInterval is the interval at which the status of the jobs run by the hardware manager, such as resource
+	// group creation and profile updates, is polled. Defaults to 15s.
+	// +optional
+	JobPollInterval *metav1.Duration `json:"jobPollInterval,omitempty"`
+
+	// Notifications subscribes the resources allocated to NodePools to the notifications of the hardware manager, so
+	// that resource state changes and job completions are processed as they are received. Jobs are still polled, at the
+	// fallback interval, in case a notification is lost.
+	// +optional
+	Notifications *NotificationConfig `json:"notifications,omitempty"`
+
+	// Timeouts overrides the global operation timeouts for this adaptor instance
+	// +optional
+	Timeouts *OperationTimeouts `json:"timeouts,omitempty"`
+
+	// HostnameTemplate is a Go template for the hostname of each allocated node, such as
+	// {{.Site}}-{{.NodeGroup}}-{{.Index}}. It can be overridden for a NodePool with the hostnameTemplate extension.
+	// When unset, the hostname is the name of the resource on the hardware manager.
+	// +optional
+	HostnameTemplate *string `json:"hostnameTemplate,omitempty"`
+
+	// AllocationConcurrency is the number of nodes of a NodePool that are allocated concurrently once its resource group
+	// has been created. Defaults to 8.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AllocationConcurrency *int `json:"allocationConcurrency,omitempty"`
+
+	// InventoryCacheTTL is how long the resource pools and resources queried from the hardware manager are served from
+	// memory to inventory API requests before being queried again. Concurrent requests for expired entries share a
+	// single query. The cache can be refreshed on demand through the inventory API. Inventory is not cached when unset.
+	// +optional
+	InventoryCacheTTL *metav1.Duration `json:"inventoryCacheTTL,omitempty"`
+
+	// Decommission configures the steps run on the resources of a NodePool when it is deleted, before its resource group
+	// is deleted. By default, the resource group is deleted without any other step.
+	// +optional
+	Decommission *DecommissionConfig `json:"decommission,omitempty"`
+
+	// ScaleInPolicy selects the nodes released when the size of a node group of a NodePool is decreased, among the
+	// nodes added by a scale-out. Nodes annotated with hwmgr-plugin.oran.openshift.io/scale-in-candidate are released
+	// first, then the newest (NewestFirst) or oldest (OldestFirst) nodes. Defaults to NewestFirst.
+	// +kubebuilder:validation:Enum=NewestFirst;OldestFirst
+	// +optional
+	ScaleInPolicy ScaleInPolicy `json:"scaleInPolicy,omitempty"`
+}
+
+// ScaleInPolicy defines the order in which the nodes of a node group are released by a scale-in
+type ScaleInPolicy string
+
+// ScaleInPolicies define the supported scale-in policies
+var ScaleInPolicies = struct {
+	NewestFirst ScaleInPolicy
+	OldestFirst ScaleInPolicy
+}{
+	NewestFirst: "NewestFirst",
+	OldestFirst: "OldestFirst",
+}
+
+// DecommissionConfig defines the decommissioning of the resources of a deleted NodePool
+type DecommissionConfig struct {
+	// PowerOff powers off the resources through the Redfish API of their BMC before the resource group is deleted. A
+	// resource that fails to power off is reported in the Decommissioned condition of the NodePool, and does not block
+	// its deletion.
+	// +optional
+	PowerOff bool `json:"powerOff,omitempty"`
+
+	// PowerOffTimeout bounds how long the resources may take to power off. Defaults to 10m.
+	// +optional
+	PowerOffTimeout *metav1.Duration `json:"powerOffTimeout,omitempty"`
+}
+
+// TLSConfig defines the TLS settings used to connect to a hardware manager
+type TLSConfig struct {
+	// CaBundle references the CA certificates trusted to validate the certificate of the hardware manager, in addition
+	// to the default CA bundles
+	// +optional
+	CaBundle *CaBundleReference `json:"caBundle,omitempty"`
+
+	// ClientCertificateSecret is the name of a kubernetes.io/tls secret holding the client certificate and key presented
+	// to the hardware manager, in the tls.crt and tls.key keys
+	// +optional
+	ClientCertificateSecret *string `json:"clientCertificateSecret,omitempty"`
+
+	// InsecureSkipVerify indicates that the plugin should not confirm the validity of the TLS certificate of the
+	// hardware manager. This is insecure and is not recommended.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// CaBundleReference references a set of PEM encoded CA certificates held in either a config map or a secret
+type CaBundleReference struct {
+	// ConfigMapName is the name of the config map holding the CA bundle
+	// +optional
+	ConfigMapName *string `json:"configMapName,omitempty"`
+
+	// SecretName is the name of the secret holding the CA bundle
+	// +optional
+	SecretName *string `json:"secretName,omitempty"`
+
+	// Key is the key holding the CA bundle. Defaults to ca-bundle.pem.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// NotificationConfig defines the subscription to the notifications of a hardware manager
+type NotificationConfig struct {
+	// SubscriptionId is the identifier of the resource subscription on the hardware manager whose destination is the
+	// callback of the plugin, /hardware-events/dell/<namespace>/<name> on the hardware event listener. The resources
+	// of the pools selected for a NodePool are added to the subscription when it is created, and its resources are
+	// removed once it is released.
+	// +kubebuilder:validation:Required
+	// +required
+	SubscriptionId string `json:"subscriptionId"`
+
+	// AuthSecret is the name of the secret holding, in the token key, the bearer token that the notifications
+	// must present. Notifications are refused without it.
+	// +kubebuilder:validation:Required
+	// +required
+	AuthSecret string `json:"authSecret"`
+
+	// FallbackPollInterval is the interval at which jobs are polled when notifications are enabled. Defaults to 5m.
+	// +optional
+	FallbackPollInterval *metav1.Duration `json:"fallbackPollInterval,omitempty"`
+}
+
+// ProxyConfig defines the HTTP proxy settings used to connect to a hardware manager
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for http requests, such as http://proxy.example.com:3128
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for https requests
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hostnames, domain suffixes, IP addresses and CIDRs that are reached
+	// directly, in the NO_PROXY format. A * disables the proxy for all hosts.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// RelayConfig defines how to reach a hardware manager through a relay agent
+type RelayConfig struct {
+	// URL is the URL of the relay agent
+	// +kubebuilder:validation:Required
+	// +required
+	URL string `json:"url"`
+
+	// AuthSecret is the name of the secret holding the token authenticating the plugin to the relay, in the token key
+	// +kubebuilder:validation:Required
+	// +required
+	AuthSecret string `json:"authSecret"`
+}
+
+// FailureRecovery defines how NodePools that failed provisioning are recovered
+type FailureRecovery struct {
+	// ProbeInterval enables a periodic probe that re-checks whether the cause of a NodePool provisioning failure has
+	// cleared, such as hosts being freed up. When unset, failed NodePools are only retried on request.
+	// +optional
+	ProbeInterval *metav1.Duration `json:"probeInterval,omitempty"`
+
+	// RequireRetryAnnotation prevents processing from resuming automatically once the failure cause has cleared.
+	// The NodePool must instead be annotated with hwmgr-plugin.oran.openshift.io/retry.
+	// +optional
+	RequireRetryAnnotation bool `json:"requireRetryAnnotation,omitempty"`
+}
+
+// Metal3Data defines configuration data for metal3 adaptor instance
+type Metal3Data struct {
+	// FailureRecovery configures the recovery of NodePools that failed provisioning
+	// +optional
+	FailureRecovery *FailureRecovery `json:"failureRecovery,omitempty"`
+
+	// Timeouts overrides the global operation timeouts for this adaptor instance
+	// +optional
+	Timeouts *OperationTimeouts `json:"timeouts,omitempty"`
+
+	// ExternallyProvisioned configures the handling of BareMetalHosts that were provisioned outside of metal3
+	// +optional
+	ExternallyProvisioned *ExternallyProvisionedPolicy `json:"externallyProvisioned,omitempty"`
+
+	// FirmwareRollback configures the automated rollback of failed firmware updates
+	// +optional
+	FirmwareRollback *FirmwareRollbackPolicy `json:"firmwareRollback,omitempty"`
+
+	// AllocationLease bounds the allocation of BareMetalHosts with a lease that is renewed while their NodePool exists
+	// +optional
+	AllocationLease *AllocationLeasePolicy `json:"allocationLease,omitempty"`
+
+	// AllocationScoring configures the weights of the criteria scoring the candidate BareMetalHosts of a node group
+	// +optional
+	AllocationScoring *AllocationScoringPolicy `json:"allocationScoring,omitempty"`
+
+	// BMHScope restricts the BareMetalHosts of the adaptor instance, in its inventory and for allocation. All the
+	// BareMetalHosts of the cluster are considered by default.
+	// +optional
+	BMHScope *BMHScope `json:"bmhScope,omitempty"`
+
+	// NodeReplacement configures the automatic replacement of the nodes of provisioned NodePools whose BareMetalHost
+	// fails
+	// +optional
+	NodeReplacement *NodeReplacementPolicy `json:"nodeReplacement,omitempty"`
+}
+
+// NodeReplacementPolicy defines the automatic replacement of failed nodes. A node whose BareMetalHost remains in error
+// for the grace period is marked Failed and its host released, and a replacement host is allocated from the same
+// resource pool, keeping the NodePool at its requested size.
+type NodeReplacementPolicy struct {
+	// Enabled turns on the automatic replacement of failed nodes
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// GracePeriod is how long a BareMetalHost must remain in error before its node is replaced, so that transient
+	// errors, such as a BMC briefly unreachable, are not remediated. Defaults to 10 minutes.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// BMHScope selects the BareMetalHosts of a metal3 adaptor instance. Restricting them to a few namespaces also spares
+// listing all the BareMetalHosts of large clusters.
+type BMHScope struct {
+	// Namespaces lists the namespaces of the BareMetalHosts. All namespaces are considered if empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector selects the BareMetalHosts by their labels. All BareMetalHosts are considered if not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// AllocationScoringPolicy defines the weights of the criteria scoring the candidate BareMetalHosts of a node group, the
+// hosts with the highest score being allocated first. Each criterion rates a host from 0 to 1, and the score of a host
+// is the weighted average of its ratings. A weight of 0 disables its criterion, and each weight defaults to 1.
+type AllocationScoringPolicy struct {
+	// ResourceFit weighs how closely the memory and CPU cores of a host fit the minimums of the hardware validation of
+	// its node group, favoring the smallest hosts that meet them
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ResourceFit *int32 `json:"resourceFit,omitempty"`
+
+	// FirmwareMatch weighs the share of the firmware components of the hardware profile of the node group that are
+	// already at their target version on a host
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	FirmwareMatch *int32 `json:"firmwareMatch,omitempty"`
+
+	// PendingRepairs weighs the number of errors reported for a host and of operations left pending on it, favoring
+	// the hosts with the fewest
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PendingRepairs *int32 `json:"pendingRepairs,omitempty"`
+}
+
+// AllocationLeasePolicy defines the leases held on allocated BareMetalHosts. The lease of each host is renewed by the
+// NodePool controller, and a host left allocated without a Node once its lease expires, such as after a crash during
+// allocation or release, is released automatically.
+type AllocationLeasePolicy struct {
+	// Duration is how long an allocation is held without renewal. Leases are renewed once half of the duration has
+	// elapsed.
+	// +kubebuilder:validation:Required
+	// +required
+	Duration metav1.Duration `json:"duration"`
+}
+
+// FirmwareRollbackPolicy defines the handling of firmware updates that fail, either because the BareMetalHost reports a
+// servicing error, the updated versions do not match the hardware profile, or the update exceeds the firmware job
+// timeout. A failed update is rolled back to the firmware of the previous hardware profile of the node.
+type FirmwareRollbackPolicy struct {
+	// Enabled turns on the automated rollback of failed firmware updates
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// ExternallyProvisionedPolicy defines how BareMetalHosts in the externally provisioned state are handled. Such hosts
+// are always reported in the inventory, with the EXTERNALLY_PROVISIONED usage state.
+type ExternallyProvisionedPolicy struct {
+	// Adopt allows externally provisioned hosts to be allocated to NodePools. Adopted hosts are used as is, without
+	// re-imaging or applying hardware profile changes, and are never cleaned on release.
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
+}
+
+// SupermicroData defines configuration data for supermicro adaptor instance
+type SupermicroData struct {
+	// Servers lists the Supermicro servers managed by this instance, which are reached through the Redfish API of
+	// their BMC
+	// +kubebuilder:validation:Required
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Servers []SupermicroServer `json:"servers"`
+
+	// CaBundleName references a config map that contains a set of custom CA certificates to be used when communicating
+	// with BMCs that have their TLS certificate signed by a non-public CA certificate.
+	// +optional
+	CaBundleName *string `json:"caBundleName,omitempty"`
+
+	// insecureSkipTLSVerify indicates that the plugin should not confirm the validity of the TLS certificate of the BMCs.
+	// This is insecure and is not recommended.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// Timeouts overrides the global operation timeouts for this adaptor instance
+	// +optional
+	Timeouts *OperationTimeouts `json:"timeouts,omitempty"`
+}
+
+// SupermicroServer defines a server managed by the supermicro adaptor
+type SupermicroServer struct {
+	// Name identifies the server, and is reported as its resource ID
+	// +kubebuilder:validation:Required
+	// +required
+	Name string `json:"name"`
+
+	// BMCAddress is the URL of the BMC, such as https://10.1.2.3. The Redfish system of the server is discovered
+	// from the BMC unless the address includes its path, such as https://10.1.2.3/redfish/v1/Systems/1.
+	// +kubebuilder:validation:Required
+	// +required
+	BMCAddress string `json:"bmcAddress"`
+
+	// CredentialsName is the name of the secret holding the BMC credentials, in the username and password keys
+	// +kubebuilder:validation:Required
+	// +required
+	CredentialsName string `json:"credentialsName"`
+
+	// ResourcePoolId is the resource pool of the server
+	// +kubebuilder:validation:Required
+	// +required
+	ResourcePoolId string `json:"resourcePoolId"`
+
+	// Site is the site of the server
+	// +optional
+	Site string `json:"site,omitempty"`
+
+	// Labels are reported as the labels of the server in the inventory
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// OperationTimeouts defines the maximum duration of hardware manager operations. Unset values use the defaults.
+type OperationTimeouts struct {
+	// Allocate bounds each pass of NodePool allocation and configuration processing
+	// +optional
+	Allocate *metav1.Duration `json:"allocate,omitempty"`
+
+	// Release bounds each pass of NodePool release processing
+	// +optional
+	Release *metav1.Duration `json:"release,omitempty"`
+
+	// FirmwareJob bounds how long a firmware or profile update job may run on the hardware manager before it is
+	// reported as failed. There is no limit by default.
+	// +optional
+	FirmwareJob *metav1.Duration `json:"firmwareJob,omitempty"`
+
+	// ResourceGroupJob bounds how long a resource group creation or deletion job may run on the hardware manager
+	// before it is reported as failed. There is no limit by default.
+	// +optional
+	ResourceGroupJob *metav1.Duration `json:"resourceGroupJob,omitempty"`
+
+	// InventoryQuery bounds inventory queries made to the hardware manager
+	// +optional
+	InventoryQuery *metav1.Duration `json:"inventoryQuery,omitempty"`
+
+	// JobCancellation bounds how long the release of a deleted NodePool waits for the jobs still in flight on the
+	// hardware manager to be cancelled, before releasing its hardware regardless. Defaults to 10 minutes.
+	// +optional
+	JobCancellation *metav1.Duration `json:"jobCancellation,omitempty"`
 }
 
 // HardwareManagerSpec defines the desired state of HardwareManager
@@ -101,7 +498,7 @@ type HardwareManagerSpec struct {
 
 	// The adaptor ID
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=loopback;dell-hwmgr;metal3
+	// +kubebuilder:validation:Enum=loopback;dell-hwmgr;metal3;supermicro
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	AdaptorID HardwareManagerAdaptorID `json:"adaptorId"`
 
@@ -112,11 +509,162 @@ type HardwareManagerSpec struct {
 	// Config data for an instance of the dell-hwmgr adaptor
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	DellData *DellData `json:"dellData,omitempty"`
+
+	// Config data for an instance of the metal3 adaptor
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Metal3Data *Metal3Data `json:"metal3Data,omitempty"`
+
+	// Config data for an instance of the supermicro adaptor
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SupermicroData *SupermicroData `json:"supermicroData,omitempty"`
+
+	// Timeouts defines the operation timeouts for all adaptors, unless overridden in the adaptor config data
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeouts *OperationTimeouts `json:"timeouts,omitempty"`
+
+	// ArtifactVerification enables the verification of the signatures of firmware artifacts referenced by hardware
+	// profiles before they are applied by the metal3 and supermicro adaptors
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ArtifactVerification *ArtifactVerificationPolicy `json:"artifactVerification,omitempty"`
+
+	// BMCSecrets configures the namespace and names of the BMC secrets created for allocated nodes by the dell-hwmgr,
+	// loopback and supermicro adaptors. By default, they are created in the plugin namespace as <node>-bmc-secret.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BMCSecrets *BMCSecretPolicy `json:"bmcSecrets,omitempty"`
+
+	// RateLimit limits the rate of the requests sent to the hardware manager by the dell-hwmgr and supermicro adaptors,
+	// shared by all the NodePools of the HardwareManager. Requests are not limited by default.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RateLimit *RateLimitPolicy `json:"rateLimit,omitempty"`
+}
+
+// RateLimitPolicy defines the client-side rate limit of the requests sent to a hardware manager. Requests above the
+// limit wait for their turn, within the timeout of their operation.
+type RateLimitPolicy struct {
+	// QPS is the sustained number of requests per second sent to the hardware manager
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	// +required
+	QPS int32 `json:"qps"`
+
+	// Burst is the number of requests that may be sent at once, above the sustained rate. Defaults to QPS.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// BMCSecretNamespace defines where the BMC secrets of allocated nodes are created
+type BMCSecretNamespace string
+
+// BMCSecretNamespaces define the supported locations of BMC secrets
+var BMCSecretNamespaces = struct {
+	Plugin  BMCSecretNamespace
+	Cluster BMCSecretNamespace
+}{
+	Plugin:  "Plugin",
+	Cluster: "Cluster",
+}
+
+// BMCSecretPolicy defines the namespace and names of the BMC secrets of allocated nodes. The CredentialsName reported
+// in the BMC status of each Node is the name of its secret.
+type BMCSecretPolicy struct {
+	// Namespace selects where the secrets are created. Plugin creates them in the plugin namespace, owned by their
+	// NodePool. Cluster creates them in the namespace of the cluster being installed, named after the cloud ID of the
+	// NodePool, which must exist. Defaults to Plugin.
+	// +kubebuilder:validation:Enum=Plugin;Cluster
+	// +optional
+	Namespace BMCSecretNamespace `json:"namespace,omitempty"`
+
+	// NameTemplate is a Go template for the name of the secret of each node, with the NodeName, NodePool and CloudID
+	// variables, such as {{.CloudID}}-{{.NodeName}}-bmc. Defaults to {{.NodeName}}-bmc-secret.
+	// +optional
+	NameTemplate *string `json:"nameTemplate,omitempty"`
+}
+
+// ArtifactVerificationPolicy defines how the signatures of firmware artifacts are verified
+type ArtifactVerificationPolicy struct {
+	// TrustRoots is the name of a config map holding the PEM encoded ECDSA or RSA public keys trusted to sign firmware
+	// artifacts. Every key of the config map is read, and may hold several public keys.
+	// +kubebuilder:validation:Required
+	// +required
+	TrustRoots string `json:"trustRoots"`
+
+	// RequireSignatures refuses firmware artifacts without a signature URL. Otherwise, only the artifacts with a
+	// signature URL are verified.
+	// +optional
+	RequireSignatures bool `json:"requireSignatures,omitempty"`
 }
 
 type ResourcePoolList []string
 type PerSiteResourcePoolList map[string]ResourcePoolList
 
+// SouthboundError describes a failed request from the plugin to the hardware manager or BMCs of a HardwareManager
+type SouthboundError struct {
+	// Time is when the request failed
+	Time metav1.Time `json:"time"`
+
+	// Operation identifies the request, such as GET /v1/resource-pools
+	Operation string `json:"operation"`
+
+	// HTTPStatus is the HTTP status code of the response, if one was received
+	// +optional
+	HTTPStatus int `json:"httpStatus,omitempty"`
+
+	// Message describes the failure, with credentials and query parameters removed
+	Message string `json:"message"`
+}
+
+// SelfTestResult is the result of a check of a HardwareManager self-test
+type SelfTestResult string
+
+// SelfTestResults define the results of the checks of a HardwareManager self-test
+var SelfTestResults = struct {
+	Passed  SelfTestResult
+	Failed  SelfTestResult
+	Skipped SelfTestResult
+}{
+	Passed:  "Passed",
+	Failed:  "Failed",
+	Skipped: "Skipped",
+}
+
+// SelfTestCheck is the outcome of a non-destructive check of a HardwareManager self-test
+type SelfTestCheck struct {
+	// Name identifies the check, such as Authentication or InventoryRead
+	Name string `json:"name"`
+
+	// Result is Passed, Failed or Skipped, for checks that do not apply to the HardwareManager configuration
+	// +kubebuilder:validation:Enum=Passed;Failed;Skipped
+	Result SelfTestResult `json:"result"`
+
+	// Message describes what was checked, or why the check failed or was skipped
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SelfTestReport is the report of the last self-test of a HardwareManager
+type SelfTestReport struct {
+	// Request is the value of the self-test annotation that requested the self-test
+	Request string `json:"request"`
+
+	// StartTime is when the self-test started
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the self-test completed
+	CompletionTime metav1.Time `json:"completionTime"`
+
+	// Passed is set if none of the checks failed
+	Passed bool `json:"passed"`
+
+	// Checks are the outcomes of the checks, in the order they were run
+	// +optional
+	Checks []SelfTestCheck `json:"checks,omitempty"`
+}
+
 // HardwareManagerStatus defines the observed state of HardwareManager
 type HardwareManagerStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status
@@ -132,6 +680,18 @@ type HardwareManagerStatus struct {
 	// ResourcePools provides a per-site list of resource pools
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	ResourcePools PerSiteResourcePoolList `json:"resourcePools,omitempty"`
+
+	// LastErrors lists the most recent failed requests to the hardware manager or BMCs, newest first
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastErrors []SouthboundError `json:"lastErrors,omitempty"`
+
+	// SelfTest is the report of the last self-test requested with the hwmgr-plugin.oran.openshift.io/self-test
+	// annotation
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SelfTest *SelfTestReport `json:"selfTest,omitempty"`
 }
 
 // +operator-sdk:csv:customresourcedefinitions:resources={{Service,v1,policy-engine-service}}
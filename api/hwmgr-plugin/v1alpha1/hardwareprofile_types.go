@@ -23,12 +23,78 @@ type Firmware struct {
 	Version string `json:"version,omitempty"`
 	// URL points to the firmware file
 	URL string `json:"url,omitempty"`
+	// SignatureURL points to the detached signature of the firmware file, a base64 encoded signature of its SHA-256
+	// digest, as produced by cosign sign-blob. It is verified when the hardware manager enables artifact verification.
+	SignatureURL string `json:"signatureURL,omitempty"`
+}
+
+// RAIDVolume defines a hardware RAID volume
+type RAIDVolume struct {
+	// Name of the volume, generated if not set
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name,omitempty"`
+	// Level is the RAID level of the volume
+	// +kubebuilder:validation:Enum="0";"1";"2";"5";"6";"1+0";"5+0";"6+0"
+	Level string `json:"level"`
+	// SizeGibibytes is the size of the volume, using the full capacity of its disks if not set
+	// +kubebuilder:validation:Minimum=0
+	SizeGibibytes *int `json:"sizeGibibytes,omitempty"`
+	// NumberOfPhysicalDisks is the number of disks of the volume, the minimum for its level if not set
+	// +kubebuilder:validation:Minimum=1
+	NumberOfPhysicalDisks *int `json:"numberOfPhysicalDisks,omitempty"`
+	// Rotational restricts the volume to rotational disks if true, or solid-state disks if false
+	Rotational *bool `json:"rotational,omitempty"`
+	// Controller is the name of the RAID controller of the volume
+	Controller string `json:"controller,omitempty"`
+	// PhysicalDisks are the names of the disks of the volume, in the format of the RAID controller
+	PhysicalDisks []string `json:"physicalDisks,omitempty"`
+}
+
+// RAID defines the RAID configuration of a node
+type RAID struct {
+	// HardwareVolumes are the hardware RAID volumes to create, the first one being the root volume. An empty list
+	// clears the hardware RAID configuration.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	HardwareVolumes []RAIDVolume `json:"hardwareVolumes"`
+}
+
+// BootDevice is a class of device a server boots from
+// +kubebuilder:validation:Enum=Pxe;Disk;VirtualMedia
+type BootDevice string
+
+const (
+	BootDevicePxe          BootDevice = "Pxe"
+	BootDeviceDisk         BootDevice = "Disk"
+	BootDeviceVirtualMedia BootDevice = "VirtualMedia"
+)
+
+// BootOrder defines the boot device priority of a node
+type BootOrder struct {
+	// Devices are the classes of boot devices, highest priority first. The devices that are not listed follow the
+	// listed ones, in the order Pxe, Disk, VirtualMedia.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=3
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Devices []BootDevice `json:"devices"`
+
+	// DellFqdds overrides the FQDDs of the boot devices of Dell servers, which depend on their hardware, for example
+	// NIC.Integrated.1-1-1 for a PXE boot from an integrated NIC, or AHCI.SL.6-1 for a disk behind a BOSS controller.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DellFqdds map[BootDevice]string `json:"dellFqdds,omitempty"`
 }
 
 // HardwareProfileSpec defines the desired state of HardwareProfile
 type HardwareProfileSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// BaseProfile is the name of a HardwareProfile in the same namespace that this profile inherits from. The BIOS
+	// attributes of this profile are merged over those of the base profile, and the firmware settings of this profile
+	// replace those of the base profile when set.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Base Profile",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	BaseProfile string `json:"baseProfile,omitempty"`
+
 	// Bios defines a set of bios attributes
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	Bios Bios `json:"bios"`
@@ -40,6 +106,37 @@ type HardwareProfileSpec struct {
 	// BMC firmware information
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="BMC Firmware",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	BmcFirmware Firmware `json:"bmcFirmware,omitempty"`
+
+	// RAID defines the RAID configuration applied to the node before it is provisioned
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RAID *RAID `json:"raid,omitempty"`
+
+	// BootOrder sets the boot device priority of the node, applied through the BIOS attributes of its vendor. A
+	// profile with a boot order is refused for servers whose vendor does not support it.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	BootOrder *BootOrder `json:"bootOrder,omitempty"`
+}
+
+// ArtifactVerificationResult is the outcome of the signature verification of a firmware artifact
+type ArtifactVerificationResult struct {
+	// HwMgrId is the hardware manager the artifact was verified for
+	HwMgrId string `json:"hwMgrId"`
+	// Component is the firmware component, bios or bmc
+	Component string `json:"component"`
+	// URL is the URL of the verified artifact
+	URL string `json:"url"`
+	// SignatureURL is the URL of the signature of the artifact, if any
+	SignatureURL string `json:"signatureURL,omitempty"`
+	// Digest is the SHA-256 digest of the artifact when it was verified
+	Digest string `json:"digest,omitempty"`
+	// Verified is true if the signature of the artifact was verified against a trust root
+	Verified bool `json:"verified"`
+	// KeyFingerprint is the SHA-256 fingerprint of the public key that verified the signature
+	KeyFingerprint string `json:"keyFingerprint,omitempty"`
+	// Message describes why the artifact was refused
+	Message string `json:"message,omitempty"`
+	// Time is the time of the verification
+	Time metav1.Time `json:"time"`
 }
 
 // HardwareProfileStatus defines the observed state of HardwareProfile
@@ -47,6 +144,19 @@ type HardwareProfileStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// EffectiveSpec is the profile resolved from its chain of base profiles, as applied to nodes
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	EffectiveSpec *HardwareProfileSpec `json:"effectiveSpec,omitempty"`
+
+	// ProfileChain lists the profiles the effective spec is resolved from, starting with this profile
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ProfileChain []string `json:"profileChain,omitempty"`
+
+	// ArtifactVerifications records the outcome of the latest signature verification of each firmware artifact of the
+	// profile, by hardware manager
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ArtifactVerifications []ArtifactVerificationResult `json:"artifactVerifications,omitempty"`
+
 	// Represents the observations of a HardwareProfile's current state
 	// +patchMergeKey=type
 	// +patchStrategy=merge
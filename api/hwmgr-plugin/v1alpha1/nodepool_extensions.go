@@ -0,0 +1,565 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The NodePool extensions are a map of strings, owned by the O2IMS NodePool CRD, through which a hardware request
+// carries settings beyond the NodePool spec. NodePoolExtensions is the typed form of the extensions consumed by the
+// plugin, shared with the producers of NodePools so that both sides agree on the keys and their format.
+const (
+	// ExtensionsVersionKey holds the version of the extensions format. Extensions without a version are treated as the
+	// current version.
+	ExtensionsVersionKey = "extensionsVersion"
+	// ResourceTypeIdExtensionKey holds the resource type requested for the nodes
+	ResourceTypeIdExtensionKey = "resourceTypeId"
+	// CPUArchitectureExtensionKey holds the CPU architecture requested for the nodes. It can be set for a single node
+	// group with "<group>.cpuArchitecture", which takes precedence.
+	CPUArchitectureExtensionKey = "cpuArchitecture"
+	// HostnameTemplateExtensionKey holds the Go template of the hostnames of the nodes, overriding the template
+	// configured on the hardware manager
+	HostnameTemplateExtensionKey = "hostnameTemplate"
+	// MinSizeExtensionKey holds the minimum number of nodes of a node group, set with "<group>.minSize"
+	MinSizeExtensionKey = "minSize"
+	// MaxSizeExtensionKey holds the maximum number of nodes of a node group, set with "<group>.maxSize"
+	MaxSizeExtensionKey = "maxSize"
+	// HwMgrIdExtensionKey holds the HardwareManager a node group is allocated from, set with "<group>.hwMgrId", when
+	// it differs from the HardwareManager of the NodePool
+	HwMgrIdExtensionKey = "hwMgrId"
+	// SiteExtensionKey holds the site the resource pools of the nodes must belong to. It can be set for a single node
+	// group with "<group>.site", which takes precedence.
+	SiteExtensionKey = "site"
+	// RackExtensionKey holds the rack the nodes must be located in. It can be set for a single node group with
+	// "<group>.rack", which takes precedence.
+	RackExtensionKey = "rack"
+	// TagsExtensionKey holds the tags the nodes must carry, as comma-separated "key=value" pairs. It can be set for a
+	// single node group with "<group>.tags", whose tags are added to those of the NodePool.
+	TagsExtensionKey = "tags"
+	// SpreadByExtensionKey holds the topology the nodes are spread across: "site", "rack" or the key of a label of the
+	// hardware. It can be set for a single node group with "<group>.spreadBy", which takes precedence.
+	SpreadByExtensionKey = "spreadBy"
+	// SpreadModeExtensionKey holds how strictly the nodes are spread, "preferred" or "required". It can be set for a
+	// single node group with "<group>.spreadMode", which takes precedence.
+	SpreadModeExtensionKey = "spreadMode"
+	// ManifestTemplateExtensionKey holds the manifest template bundle rendered for each node, as "configmap/<name>" or
+	// "secret/<name>" in the namespace of the plugin, a bare name referring to a ConfigMap. It can be set for a single
+	// node group with "<group>.manifestTemplate", which takes precedence.
+	ManifestTemplateExtensionKey = "manifestTemplate"
+	// NetworkDataTemplateExtensionKey holds the template of the network data of the hosts of the nodes, as
+	// "configmap/<name>" or "secret/<name>" in the namespace of the plugin, a bare name referring to a ConfigMap. It can
+	// be set for a single node group with "<group>.networkDataTemplate", which takes precedence. Only the metal3
+	// adaptor renders network data.
+	NetworkDataTemplateExtensionKey = "networkDataTemplate"
+
+	// ExtensionsVersionV1 is the current version of the extensions format
+	ExtensionsVersionV1 = "v1"
+)
+
+// SpreadMode defines how strictly the nodes of a node group are spread across the domains of a topology
+type SpreadMode string
+
+const (
+	// SpreadModePreferred spreads the nodes as evenly as possible across the domains, placing several nodes in the
+	// same domain when there are not enough domains
+	SpreadModePreferred SpreadMode = "preferred"
+	// SpreadModeRequired places each node in a different domain, failing the allocation when there are not enough
+	// domains
+	SpreadModeRequired SpreadMode = "required"
+)
+
+// ManifestTemplateRef references a manifest template bundle
+// +kubebuilder:object:generate=false
+type ManifestTemplateRef struct {
+	// Kind is the kind of the bundle, ConfigMap or Secret. The manifests rendered from the bundle are stored in an
+	// object of the same kind.
+	Kind string
+	// Name is the name of the bundle
+	Name string
+}
+
+// ParseManifestTemplateRef parses a reference to a manifest template bundle, "configmap/<name>", "secret/<name>" or a
+// bare ConfigMap name
+func ParseManifestTemplateRef(value string) (ManifestTemplateRef, error) {
+	kind, name, found := strings.Cut(value, "/")
+	if !found {
+		kind, name = "configmap", value
+	}
+	if name == "" {
+		return ManifestTemplateRef{}, fmt.Errorf("%s is not a valid manifest template reference, the name is empty", value)
+	}
+	switch strings.ToLower(kind) {
+	case "configmap":
+		return ManifestTemplateRef{Kind: "ConfigMap", Name: name}, nil
+	case "secret":
+		return ManifestTemplateRef{Kind: "Secret", Name: name}, nil
+	}
+	return ManifestTemplateRef{}, fmt.Errorf("%s is not a valid manifest template reference, expected configmap/<name> or secret/<name>", value)
+}
+
+// NodeGroupExtensions holds the extensions set for a single node group
+// +kubebuilder:object:generate=false
+type NodeGroupExtensions struct {
+	// CPUArchitecture is the CPU architecture requested for the nodes of the group
+	CPUArchitecture string
+	// MinSize is the minimum number of nodes of the group, if bounded
+	MinSize *int
+	// MaxSize is the maximum number of nodes of the group, if bounded
+	MaxSize *int
+	// HwMgrId is the HardwareManager the nodes of the group are allocated from, if not that of the NodePool
+	HwMgrId string
+	// Site is the site the resource pools of the nodes of the group must belong to
+	Site string
+	// Rack is the rack the nodes of the group must be located in
+	Rack string
+	// Tags are the tags the nodes of the group must carry
+	Tags map[string]string
+	// SpreadBy is the topology the nodes of the group are spread across
+	SpreadBy string
+	// SpreadMode is how strictly the nodes of the group are spread
+	SpreadMode SpreadMode
+	// ManifestTemplate is the manifest template bundle rendered for each node of the group
+	ManifestTemplate string
+	// NetworkDataTemplate is the template of the network data of the hosts of the group
+	NetworkDataTemplate string
+}
+
+// ResourceFilters holds the constraints on the hardware a node group is allocated from
+// +kubebuilder:object:generate=false
+type ResourceFilters struct {
+	// Site is the site the resource pools must belong to, if constrained
+	Site string
+	// Rack is the rack the resources must be located in, if constrained
+	Rack string
+	// Tags are the tags the resources must carry
+	Tags map[string]string
+}
+
+// SpreadPolicy holds how the nodes of a node group are spread across the domains of a topology
+// +kubebuilder:object:generate=false
+type SpreadPolicy struct {
+	// SpreadBy is the topology the nodes are spread across, or empty if they are not spread
+	SpreadBy string
+	// Mode is how strictly the nodes are spread
+	Mode SpreadMode
+}
+
+// NodePoolExtensions is the typed form of the NodePool extensions
+// +kubebuilder:object:generate=false
+type NodePoolExtensions struct {
+	// Version is the version of the extensions format
+	Version string
+	// ResourceTypeId is the resource type requested for the nodes
+	ResourceTypeId string
+	// CPUArchitecture is the CPU architecture requested for the nodes of all groups
+	CPUArchitecture string
+	// HostnameTemplate is the Go template of the hostnames of the nodes
+	HostnameTemplate string
+	// Site is the site the resource pools of the nodes of all groups must belong to
+	Site string
+	// Rack is the rack the nodes of all groups must be located in
+	Rack string
+	// Tags are the tags the nodes of all groups must carry
+	Tags map[string]string
+	// SpreadBy is the topology the nodes of all groups are spread across
+	SpreadBy string
+	// SpreadMode is how strictly the nodes of all groups are spread
+	SpreadMode SpreadMode
+	// ManifestTemplate is the manifest template bundle rendered for each node of all groups
+	ManifestTemplate string
+	// NetworkDataTemplate is the template of the network data of the hosts of all groups
+	NetworkDataTemplate string
+	// NodeGroups holds the extensions set for specific node groups, by group name
+	NodeGroups map[string]NodeGroupExtensions
+	// Other holds the extensions not consumed by the plugin, which are preserved as is
+	Other map[string]string
+}
+
+// ParseNodePoolExtensions converts the NodePool extensions to their typed form
+func ParseNodePoolExtensions(extensions map[string]string) (*NodePoolExtensions, error) {
+	parsed := &NodePoolExtensions{Version: ExtensionsVersionV1}
+
+	for key, value := range extensions {
+		switch key {
+		case ExtensionsVersionKey:
+			if value != ExtensionsVersionV1 {
+				return nil, fmt.Errorf("unsupported %s: %s", ExtensionsVersionKey, value)
+			}
+		case ResourceTypeIdExtensionKey:
+			parsed.ResourceTypeId = value
+		case CPUArchitectureExtensionKey:
+			parsed.CPUArchitecture = value
+		case HostnameTemplateExtensionKey:
+			parsed.HostnameTemplate = value
+		case SiteExtensionKey:
+			parsed.Site = value
+		case RackExtensionKey:
+			parsed.Rack = value
+		case TagsExtensionKey:
+			tags, err := parseTags(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid extension %s: %w", key, err)
+			}
+			parsed.Tags = tags
+		case SpreadByExtensionKey:
+			parsed.SpreadBy = value
+		case SpreadModeExtensionKey:
+			mode, err := parseSpreadMode(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid extension %s: %w", key, err)
+			}
+			parsed.SpreadMode = mode
+		case ManifestTemplateExtensionKey:
+			if _, err := ParseManifestTemplateRef(value); err != nil {
+				return nil, fmt.Errorf("invalid extension %s: %w", key, err)
+			}
+			parsed.ManifestTemplate = value
+		case NetworkDataTemplateExtensionKey:
+			if _, err := ParseManifestTemplateRef(value); err != nil {
+				return nil, fmt.Errorf("invalid extension %s: %w", key, err)
+			}
+			parsed.NetworkDataTemplate = value
+		default:
+			group, setting, scoped := strings.Cut(key, ".")
+			if scoped && isNodeGroupSetting(setting) {
+				if group == "" {
+					return nil, fmt.Errorf("extension %s does not name a node group", key)
+				}
+				if parsed.NodeGroups == nil {
+					parsed.NodeGroups = make(map[string]NodeGroupExtensions)
+				}
+				groupExtensions := parsed.NodeGroups[group]
+				if err := groupExtensions.set(setting, value); err != nil {
+					return nil, fmt.Errorf("invalid extension %s: %w", key, err)
+				}
+				parsed.NodeGroups[group] = groupExtensions
+				continue
+			}
+			if parsed.Other == nil {
+				parsed.Other = make(map[string]string)
+			}
+			parsed.Other[key] = value
+		}
+	}
+
+	return parsed, nil
+}
+
+// isNodeGroupSetting checks whether a setting can be set for a single node group, as "<group>.<setting>"
+func isNodeGroupSetting(setting string) bool {
+	switch setting {
+	case CPUArchitectureExtensionKey, MinSizeExtensionKey, MaxSizeExtensionKey, HwMgrIdExtensionKey,
+		SiteExtensionKey, RackExtensionKey, TagsExtensionKey, SpreadByExtensionKey, SpreadModeExtensionKey,
+		ManifestTemplateExtensionKey, NetworkDataTemplateExtensionKey:
+		return true
+	}
+	return false
+}
+
+// parseTags parses tags set as comma-separated "key=value" pairs
+func parseTags(value string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, tagValue, found := strings.Cut(strings.TrimSpace(pair), "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("%s is not a key=value pair", strings.TrimSpace(pair))
+		}
+		if _, exists := tags[key]; exists {
+			return nil, fmt.Errorf("tag %s is set more than once", key)
+		}
+		tags[key] = strings.TrimSpace(tagValue)
+	}
+	return tags, nil
+}
+
+// parseSpreadMode parses a spread mode
+func parseSpreadMode(value string) (SpreadMode, error) {
+	switch mode := SpreadMode(value); mode {
+	case SpreadModePreferred, SpreadModeRequired:
+		return mode, nil
+	}
+	return "", fmt.Errorf("%s is not a valid spread mode, expected %s or %s", value, SpreadModePreferred, SpreadModeRequired)
+}
+
+// formatTags formats tags as comma-separated "key=value" pairs, sorted by key
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+tags[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// set sets a node group setting from its extension value
+func (e *NodeGroupExtensions) set(setting, value string) error {
+	switch setting {
+	case CPUArchitectureExtensionKey:
+		e.CPUArchitecture = value
+		return nil
+	case HwMgrIdExtensionKey:
+		e.HwMgrId = value
+		return nil
+	case SiteExtensionKey:
+		e.Site = value
+		return nil
+	case RackExtensionKey:
+		e.Rack = value
+		return nil
+	case TagsExtensionKey:
+		tags, err := parseTags(value)
+		if err != nil {
+			return err
+		}
+		e.Tags = tags
+		return nil
+	case SpreadByExtensionKey:
+		e.SpreadBy = value
+		return nil
+	case SpreadModeExtensionKey:
+		mode, err := parseSpreadMode(value)
+		if err != nil {
+			return err
+		}
+		e.SpreadMode = mode
+		return nil
+	case ManifestTemplateExtensionKey:
+		if _, err := ParseManifestTemplateRef(value); err != nil {
+			return err
+		}
+		e.ManifestTemplate = value
+		return nil
+	case NetworkDataTemplateExtensionKey:
+		if _, err := ParseManifestTemplateRef(value); err != nil {
+			return err
+		}
+		e.NetworkDataTemplate = value
+		return nil
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 0 {
+		return fmt.Errorf("%s is not a valid size", value)
+	}
+	if setting == MinSizeExtensionKey {
+		e.MinSize = &size
+	} else {
+		e.MaxSize = &size
+	}
+	return nil
+}
+
+// ToMap converts the typed extensions back to the NodePool extensions
+func (e *NodePoolExtensions) ToMap() map[string]string {
+	extensions := make(map[string]string, len(e.Other)+len(e.NodeGroups)+10)
+	for key, value := range e.Other {
+		extensions[key] = value
+	}
+	if e.Version != "" {
+		extensions[ExtensionsVersionKey] = e.Version
+	}
+	if e.ResourceTypeId != "" {
+		extensions[ResourceTypeIdExtensionKey] = e.ResourceTypeId
+	}
+	if e.CPUArchitecture != "" {
+		extensions[CPUArchitectureExtensionKey] = e.CPUArchitecture
+	}
+	if e.HostnameTemplate != "" {
+		extensions[HostnameTemplateExtensionKey] = e.HostnameTemplate
+	}
+	if e.Site != "" {
+		extensions[SiteExtensionKey] = e.Site
+	}
+	if e.Rack != "" {
+		extensions[RackExtensionKey] = e.Rack
+	}
+	if len(e.Tags) > 0 {
+		extensions[TagsExtensionKey] = formatTags(e.Tags)
+	}
+	if e.SpreadBy != "" {
+		extensions[SpreadByExtensionKey] = e.SpreadBy
+	}
+	if e.SpreadMode != "" {
+		extensions[SpreadModeExtensionKey] = string(e.SpreadMode)
+	}
+	if e.ManifestTemplate != "" {
+		extensions[ManifestTemplateExtensionKey] = e.ManifestTemplate
+	}
+	if e.NetworkDataTemplate != "" {
+		extensions[NetworkDataTemplateExtensionKey] = e.NetworkDataTemplate
+	}
+	for group, groupExtensions := range e.NodeGroups {
+		if groupExtensions.CPUArchitecture != "" {
+			extensions[group+"."+CPUArchitectureExtensionKey] = groupExtensions.CPUArchitecture
+		}
+		if groupExtensions.MinSize != nil {
+			extensions[group+"."+MinSizeExtensionKey] = strconv.Itoa(*groupExtensions.MinSize)
+		}
+		if groupExtensions.MaxSize != nil {
+			extensions[group+"."+MaxSizeExtensionKey] = strconv.Itoa(*groupExtensions.MaxSize)
+		}
+		if groupExtensions.HwMgrId != "" {
+			extensions[group+"."+HwMgrIdExtensionKey] = groupExtensions.HwMgrId
+		}
+		if groupExtensions.Site != "" {
+			extensions[group+"."+SiteExtensionKey] = groupExtensions.Site
+		}
+		if groupExtensions.Rack != "" {
+			extensions[group+"."+RackExtensionKey] = groupExtensions.Rack
+		}
+		if len(groupExtensions.Tags) > 0 {
+			extensions[group+"."+TagsExtensionKey] = formatTags(groupExtensions.Tags)
+		}
+		if groupExtensions.SpreadBy != "" {
+			extensions[group+"."+SpreadByExtensionKey] = groupExtensions.SpreadBy
+		}
+		if groupExtensions.SpreadMode != "" {
+			extensions[group+"."+SpreadModeExtensionKey] = string(groupExtensions.SpreadMode)
+		}
+		if groupExtensions.ManifestTemplate != "" {
+			extensions[group+"."+ManifestTemplateExtensionKey] = groupExtensions.ManifestTemplate
+		}
+		if groupExtensions.NetworkDataTemplate != "" {
+			extensions[group+"."+NetworkDataTemplateExtensionKey] = groupExtensions.NetworkDataTemplate
+		}
+	}
+	return extensions
+}
+
+// GetCPUArchitecture returns the CPU architecture requested for the node group, along with the extension key it was
+// set by, or empty strings if none was requested
+func (e *NodePoolExtensions) GetCPUArchitecture(group string) (string, string) {
+	if arch := e.NodeGroups[group].CPUArchitecture; arch != "" {
+		return arch, group + "." + CPUArchitectureExtensionKey
+	}
+	if e.CPUArchitecture != "" {
+		return e.CPUArchitecture, CPUArchitectureExtensionKey
+	}
+	return "", ""
+}
+
+// GetHwMgrId returns the HardwareManager the node group is allocated from, given the HardwareManager of the NodePool
+func (e *NodePoolExtensions) GetHwMgrId(group, nodePoolHwMgrId string) string {
+	if hwMgrId := e.NodeGroups[group].HwMgrId; hwMgrId != "" {
+		return hwMgrId
+	}
+	return nodePoolHwMgrId
+}
+
+// GetManifestTemplate returns the manifest template bundle rendered for each node of the node group, or an empty
+// string if none is set
+func (e *NodePoolExtensions) GetManifestTemplate(group string) string {
+	if manifestTemplate := e.NodeGroups[group].ManifestTemplate; manifestTemplate != "" {
+		return manifestTemplate
+	}
+	return e.ManifestTemplate
+}
+
+// GetNetworkDataTemplate returns the template of the network data of the hosts of the node group, or an empty string if
+// none is set
+func (e *NodePoolExtensions) GetNetworkDataTemplate(group string) string {
+	if networkDataTemplate := e.NodeGroups[group].NetworkDataTemplate; networkDataTemplate != "" {
+		return networkDataTemplate
+	}
+	return e.NetworkDataTemplate
+}
+
+// GetResourceFilters returns the constraints on the hardware the node group is allocated from. The site and rack of
+// the node group take precedence over those of the NodePool, and its tags are added to those of the NodePool.
+func (e *NodePoolExtensions) GetResourceFilters(group string) ResourceFilters {
+	groupExtensions := e.NodeGroups[group]
+	filters := ResourceFilters{Site: e.Site, Rack: e.Rack}
+	if groupExtensions.Site != "" {
+		filters.Site = groupExtensions.Site
+	}
+	if groupExtensions.Rack != "" {
+		filters.Rack = groupExtensions.Rack
+	}
+	if len(e.Tags)+len(groupExtensions.Tags) > 0 {
+		filters.Tags = make(map[string]string, len(e.Tags)+len(groupExtensions.Tags))
+		for key, value := range e.Tags {
+			filters.Tags[key] = value
+		}
+		for key, value := range groupExtensions.Tags {
+			filters.Tags[key] = value
+		}
+	}
+	return filters
+}
+
+// GetSpreadPolicy returns how the nodes of the node group are spread. The settings of the node group take precedence
+// over those of the NodePool, and the mode defaults to preferred when the nodes are spread.
+func (e *NodePoolExtensions) GetSpreadPolicy(group string) SpreadPolicy {
+	groupExtensions := e.NodeGroups[group]
+	policy := SpreadPolicy{SpreadBy: e.SpreadBy, Mode: e.SpreadMode}
+	if groupExtensions.SpreadBy != "" {
+		policy.SpreadBy = groupExtensions.SpreadBy
+	}
+	if groupExtensions.SpreadMode != "" {
+		policy.Mode = groupExtensions.SpreadMode
+	}
+	if policy.SpreadBy == "" {
+		return SpreadPolicy{}
+	}
+	if policy.Mode == "" {
+		policy.Mode = SpreadModePreferred
+	}
+	return policy
+}
+
+// ClampSize bounds the size of a node group by its minimum and maximum sizes, if set
+func (e *NodePoolExtensions) ClampSize(group string, size int) int {
+	groupExtensions := e.NodeGroups[group]
+	if groupExtensions.MinSize != nil && size < *groupExtensions.MinSize {
+		size = *groupExtensions.MinSize
+	}
+	if groupExtensions.MaxSize != nil && size > *groupExtensions.MaxSize {
+		size = *groupExtensions.MaxSize
+	}
+	return size
+}
+
+// Validate checks that the extensions only set node group extensions for the node groups of the NodePool, and that
+// the size bounds of each node group are consistent
+func (e *NodePoolExtensions) Validate(groups []string) error {
+	known := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		known[group] = true
+	}
+
+	var unknown []string
+	for group := range e.NodeGroups {
+		if !known[group] {
+			unknown = append(unknown, group)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("extensions reference unknown node groups: %s", strings.Join(unknown, ", "))
+	}
+
+	for _, group := range groups {
+		groupExtensions := e.NodeGroups[group]
+		if groupExtensions.MinSize != nil && groupExtensions.MaxSize != nil && *groupExtensions.MinSize > *groupExtensions.MaxSize {
+			return fmt.Errorf("%s.%s=%d exceeds %s.%s=%d", group, MinSizeExtensionKey, *groupExtensions.MinSize,
+				group, MaxSizeExtensionKey, *groupExtensions.MaxSize)
+		}
+		if policy := e.GetSpreadPolicy(group); policy.SpreadBy == "" && (e.SpreadMode != "" || groupExtensions.SpreadMode != "") {
+			return fmt.Errorf("%s is set for node group %s without %s", SpreadModeExtensionKey, group, SpreadByExtensionKey)
+		}
+	}
+	return nil
+}
@@ -16,6 +16,128 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationLeasePolicy) DeepCopyInto(out *AllocationLeasePolicy) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationLeasePolicy.
+func (in *AllocationLeasePolicy) DeepCopy() *AllocationLeasePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationLeasePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationScoringPolicy) DeepCopyInto(out *AllocationScoringPolicy) {
+	*out = *in
+	if in.ResourceFit != nil {
+		in, out := &in.ResourceFit, &out.ResourceFit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FirmwareMatch != nil {
+		in, out := &in.FirmwareMatch, &out.FirmwareMatch
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PendingRepairs != nil {
+		in, out := &in.PendingRepairs, &out.PendingRepairs
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationScoringPolicy.
+func (in *AllocationScoringPolicy) DeepCopy() *AllocationScoringPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationScoringPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactVerificationPolicy) DeepCopyInto(out *ArtifactVerificationPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactVerificationPolicy.
+func (in *ArtifactVerificationPolicy) DeepCopy() *ArtifactVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactVerificationResult) DeepCopyInto(out *ArtifactVerificationResult) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactVerificationResult.
+func (in *ArtifactVerificationResult) DeepCopy() *ArtifactVerificationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactVerificationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BMCSecretPolicy) DeepCopyInto(out *BMCSecretPolicy) {
+	*out = *in
+	if in.NameTemplate != nil {
+		in, out := &in.NameTemplate, &out.NameTemplate
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCSecretPolicy.
+func (in *BMCSecretPolicy) DeepCopy() *BMCSecretPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BMCSecretPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BMHScope) DeepCopyInto(out *BMHScope) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMHScope.
+func (in *BMHScope) DeepCopy() *BMHScope {
+	if in == nil {
+		return nil
+	}
+	out := new(BMHScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bios) DeepCopyInto(out *Bios) {
 	*out = *in
@@ -38,6 +160,78 @@ func (in *Bios) DeepCopy() *Bios {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootOrder) DeepCopyInto(out *BootOrder) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]BootDevice, len(*in))
+		copy(*out, *in)
+	}
+	if in.DellFqdds != nil {
+		in, out := &in.DellFqdds, &out.DellFqdds
+		*out = make(map[BootDevice]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootOrder.
+func (in *BootOrder) DeepCopy() *BootOrder {
+	if in == nil {
+		return nil
+	}
+	out := new(BootOrder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaBundleReference) DeepCopyInto(out *CaBundleReference) {
+	*out = *in
+	if in.ConfigMapName != nil {
+		in, out := &in.ConfigMapName, &out.ConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretName != nil {
+		in, out := &in.SecretName, &out.SecretName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaBundleReference.
+func (in *CaBundleReference) DeepCopy() *CaBundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CaBundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DecommissionConfig) DeepCopyInto(out *DecommissionConfig) {
+	*out = *in
+	if in.PowerOffTimeout != nil {
+		in, out := &in.PowerOffTimeout, &out.PowerOffTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DecommissionConfig.
+func (in *DecommissionConfig) DeepCopy() *DecommissionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DecommissionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DellData) DeepCopyInto(out *DellData) {
 	*out = *in
@@ -51,6 +245,61 @@ func (in *DellData) DeepCopyInto(out *DellData) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
+	if in.RedfishSystemPath != nil {
+		in, out := &in.RedfishSystemPath, &out.RedfishSystemPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.Relay != nil {
+		in, out := &in.Relay, &out.Relay
+		*out = new(RelayConfig)
+		**out = **in
+	}
+	if in.JobPollInterval != nil {
+		in, out := &in.JobPollInterval, &out.JobPollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(OperationTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostnameTemplate != nil {
+		in, out := &in.HostnameTemplate, &out.HostnameTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllocationConcurrency != nil {
+		in, out := &in.AllocationConcurrency, &out.AllocationConcurrency
+		*out = new(int)
+		**out = **in
+	}
+	if in.InventoryCacheTTL != nil {
+		in, out := &in.InventoryCacheTTL, &out.InventoryCacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Decommission != nil {
+		in, out := &in.Decommission, &out.Decommission
+		*out = new(DecommissionConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DellData.
@@ -63,6 +312,41 @@ func (in *DellData) DeepCopy() *DellData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternallyProvisionedPolicy) DeepCopyInto(out *ExternallyProvisionedPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternallyProvisionedPolicy.
+func (in *ExternallyProvisionedPolicy) DeepCopy() *ExternallyProvisionedPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternallyProvisionedPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureRecovery) DeepCopyInto(out *FailureRecovery) {
+	*out = *in
+	if in.ProbeInterval != nil {
+		in, out := &in.ProbeInterval, &out.ProbeInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureRecovery.
+func (in *FailureRecovery) DeepCopy() *FailureRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Firmware) DeepCopyInto(out *Firmware) {
 	*out = *in
@@ -78,6 +362,21 @@ func (in *Firmware) DeepCopy() *Firmware {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareRollbackPolicy) DeepCopyInto(out *FirmwareRollbackPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareRollbackPolicy.
+func (in *FirmwareRollbackPolicy) DeepCopy() *FirmwareRollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareRollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareManager) DeepCopyInto(out *HardwareManager) {
 	*out = *in
@@ -150,6 +449,36 @@ func (in *HardwareManagerSpec) DeepCopyInto(out *HardwareManagerSpec) {
 		*out = new(DellData)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Metal3Data != nil {
+		in, out := &in.Metal3Data, &out.Metal3Data
+		*out = new(Metal3Data)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SupermicroData != nil {
+		in, out := &in.SupermicroData, &out.SupermicroData
+		*out = new(SupermicroData)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(OperationTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArtifactVerification != nil {
+		in, out := &in.ArtifactVerification, &out.ArtifactVerification
+		*out = new(ArtifactVerificationPolicy)
+		**out = **in
+	}
+	if in.BMCSecrets != nil {
+		in, out := &in.BMCSecrets, &out.BMCSecrets
+		*out = new(BMCSecretPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareManagerSpec.
@@ -188,6 +517,18 @@ func (in *HardwareManagerStatus) DeepCopyInto(out *HardwareManagerStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.LastErrors != nil {
+		in, out := &in.LastErrors, &out.LastErrors
+		*out = make([]SouthboundError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SelfTest != nil {
+		in, out := &in.SelfTest, &out.SelfTest
+		*out = new(SelfTestReport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareManagerStatus.
@@ -265,6 +606,16 @@ func (in *HardwareProfileSpec) DeepCopyInto(out *HardwareProfileSpec) {
 	in.Bios.DeepCopyInto(&out.Bios)
 	out.BiosFirmware = in.BiosFirmware
 	out.BmcFirmware = in.BmcFirmware
+	if in.RAID != nil {
+		in, out := &in.RAID, &out.RAID
+		*out = new(RAID)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootOrder != nil {
+		in, out := &in.BootOrder, &out.BootOrder
+		*out = new(BootOrder)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfileSpec.
@@ -280,6 +631,23 @@ func (in *HardwareProfileSpec) DeepCopy() *HardwareProfileSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareProfileStatus) DeepCopyInto(out *HardwareProfileStatus) {
 	*out = *in
+	if in.EffectiveSpec != nil {
+		in, out := &in.EffectiveSpec, &out.EffectiveSpec
+		*out = new(HardwareProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProfileChain != nil {
+		in, out := &in.ProfileChain, &out.ProfileChain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ArtifactVerifications != nil {
+		in, out := &in.ArtifactVerifications, &out.ArtifactVerifications
+		*out = make([]ArtifactVerificationResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -314,6 +682,146 @@ func (in *LoopbackData) DeepCopy() *LoopbackData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metal3Data) DeepCopyInto(out *Metal3Data) {
+	*out = *in
+	if in.FailureRecovery != nil {
+		in, out := &in.FailureRecovery, &out.FailureRecovery
+		*out = new(FailureRecovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(OperationTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternallyProvisioned != nil {
+		in, out := &in.ExternallyProvisioned, &out.ExternallyProvisioned
+		*out = new(ExternallyProvisionedPolicy)
+		**out = **in
+	}
+	if in.FirmwareRollback != nil {
+		in, out := &in.FirmwareRollback, &out.FirmwareRollback
+		*out = new(FirmwareRollbackPolicy)
+		**out = **in
+	}
+	if in.AllocationLease != nil {
+		in, out := &in.AllocationLease, &out.AllocationLease
+		*out = new(AllocationLeasePolicy)
+		**out = **in
+	}
+	if in.AllocationScoring != nil {
+		in, out := &in.AllocationScoring, &out.AllocationScoring
+		*out = new(AllocationScoringPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BMHScope != nil {
+		in, out := &in.BMHScope, &out.BMHScope
+		*out = new(BMHScope)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeReplacement != nil {
+		in, out := &in.NodeReplacement, &out.NodeReplacement
+		*out = new(NodeReplacementPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metal3Data.
+func (in *Metal3Data) DeepCopy() *Metal3Data {
+	if in == nil {
+		return nil
+	}
+	out := new(Metal3Data)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeReplacementPolicy) DeepCopyInto(out *NodeReplacementPolicy) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeReplacementPolicy.
+func (in *NodeReplacementPolicy) DeepCopy() *NodeReplacementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeReplacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationConfig) DeepCopyInto(out *NotificationConfig) {
+	*out = *in
+	if in.FallbackPollInterval != nil {
+		in, out := &in.FallbackPollInterval, &out.FallbackPollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationConfig.
+func (in *NotificationConfig) DeepCopy() *NotificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationTimeouts) DeepCopyInto(out *OperationTimeouts) {
+	*out = *in
+	if in.Allocate != nil {
+		in, out := &in.Allocate, &out.Allocate
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Release != nil {
+		in, out := &in.Release, &out.Release
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FirmwareJob != nil {
+		in, out := &in.FirmwareJob, &out.FirmwareJob
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.InventoryQuery != nil {
+		in, out := &in.InventoryQuery, &out.InventoryQuery
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.JobCancellation != nil {
+		in, out := &in.JobCancellation, &out.JobCancellation
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ResourceGroupJob != nil {
+		in, out := &in.ResourceGroupJob, &out.ResourceGroupJob
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationTimeouts.
+func (in *OperationTimeouts) DeepCopy() *OperationTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PerSiteResourcePoolList) DeepCopyInto(out *PerSiteResourcePoolList) {
 	{
@@ -344,6 +852,113 @@ func (in PerSiteResourcePoolList) DeepCopy() PerSiteResourcePoolList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAID) DeepCopyInto(out *RAID) {
+	*out = *in
+	if in.HardwareVolumes != nil {
+		in, out := &in.HardwareVolumes, &out.HardwareVolumes
+		*out = make([]RAIDVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAID.
+func (in *RAID) DeepCopy() *RAID {
+	if in == nil {
+		return nil
+	}
+	out := new(RAID)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAIDVolume) DeepCopyInto(out *RAIDVolume) {
+	*out = *in
+	if in.SizeGibibytes != nil {
+		in, out := &in.SizeGibibytes, &out.SizeGibibytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.NumberOfPhysicalDisks != nil {
+		in, out := &in.NumberOfPhysicalDisks, &out.NumberOfPhysicalDisks
+		*out = new(int)
+		**out = **in
+	}
+	if in.Rotational != nil {
+		in, out := &in.Rotational, &out.Rotational
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PhysicalDisks != nil {
+		in, out := &in.PhysicalDisks, &out.PhysicalDisks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAIDVolume.
+func (in *RAIDVolume) DeepCopy() *RAIDVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(RAIDVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitPolicy) DeepCopyInto(out *RateLimitPolicy) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicy.
+func (in *RateLimitPolicy) DeepCopy() *RateLimitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelayConfig) DeepCopyInto(out *RelayConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelayConfig.
+func (in *RelayConfig) DeepCopy() *RelayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RelayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ResourcePoolList) DeepCopyInto(out *ResourcePoolList) {
 	{
@@ -362,3 +977,135 @@ func (in ResourcePoolList) DeepCopy() ResourcePoolList {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfTestCheck) DeepCopyInto(out *SelfTestCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfTestCheck.
+func (in *SelfTestCheck) DeepCopy() *SelfTestCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfTestCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfTestReport) DeepCopyInto(out *SelfTestReport) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]SelfTestCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfTestReport.
+func (in *SelfTestReport) DeepCopy() *SelfTestReport {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfTestReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SouthboundError) DeepCopyInto(out *SouthboundError) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SouthboundError.
+func (in *SouthboundError) DeepCopy() *SouthboundError {
+	if in == nil {
+		return nil
+	}
+	out := new(SouthboundError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupermicroData) DeepCopyInto(out *SupermicroData) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]SupermicroServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CaBundleName != nil {
+		in, out := &in.CaBundleName, &out.CaBundleName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(OperationTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupermicroData.
+func (in *SupermicroData) DeepCopy() *SupermicroData {
+	if in == nil {
+		return nil
+	}
+	out := new(SupermicroData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupermicroServer) DeepCopyInto(out *SupermicroServer) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupermicroServer.
+func (in *SupermicroServer) DeepCopy() *SupermicroServer {
+	if in == nil {
+		return nil
+	}
+	out := new(SupermicroServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.CaBundle != nil {
+		in, out := &in.CaBundle, &out.CaBundle
+		*out = new(CaBundleReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificateSecret != nil {
+		in, out := &in.ClientCertificateSecret, &out.ClientCertificateSecret
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
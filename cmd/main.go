@@ -7,16 +7,25 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/hwevents"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -27,14 +36,22 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/metal3"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/cachewatchdog"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/collect"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/features"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/relay"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/retention"
 
 	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 
@@ -46,6 +63,9 @@ import (
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 )
 
+// nodePoolEventQueueSize is the number of NodePool reconciles from backend changes that can be queued
+const nodePoolEventQueueSize = 256
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -66,15 +86,78 @@ func _main() int {
 	var probeAddr string
 	var enableHTTP2 bool
 	var apiServerAddr string
+	var subscriptionStoreKind string
+	var notificationMaxAttempts int
+	var hwEventAddr, hwEventSecretFile string
+	var cacheWatchdogInterval time.Duration
+	var retentionInterval time.Duration
+	var nodeHistoryRetention, deadLetterRetention retention.Policy
+	var accessLogFormat, accessLogFile string
+	var apiRateLimitKey string
+	var apiRateLimitQPS float64
+	var apiRateLimitBurst int
+	var callbackAllowedSchemes, callbackAllowedHosts, callbackDeniedHosts string
+	var callbackAllowedCIDRs, callbackDeniedCIDRs string
+	var bmhCacheNamespaces, bmhCacheSelector string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&tlsCertDir, "tls-cert-dir", "", "The path to the directory containing the TLS certificate and private key.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&apiServerAddr, "api-bind-address", ":8082", "The address the API server binds to.")
+	flag.StringVar(&subscriptionStoreKind, "subscription-store", subscriptions.StoreKindConfigMap,
+		"The store used to persist inventory subscriptions: configmap or memory.")
+	flag.IntVar(&notificationMaxAttempts, "notification-max-attempts", subscriptions.DefaultMaxDeliveryAttempts,
+		"The number of failed delivery attempts after which a notification is moved to the dead-letter queue.")
+	flag.StringVar(&callbackAllowedSchemes, "callback-allowed-schemes", "https,http",
+		"Comma-separated list of the URL schemes allowed for subscription callbacks.")
+	flag.StringVar(&callbackAllowedHosts, "callback-allowed-hosts", "",
+		"Comma-separated list of the hosts allowed for subscription callbacks, such as smo.example.com or *.example.com. "+
+			"Any host is allowed if empty.")
+	flag.StringVar(&callbackDeniedHosts, "callback-denied-hosts", "",
+		"Comma-separated list of the hosts denied for subscription callbacks, such as *.svc.cluster.local.")
+	flag.StringVar(&callbackAllowedCIDRs, "callback-allowed-cidrs", "",
+		"Comma-separated list of the networks subscription callbacks may reach. Any network is allowed if empty.")
+	flag.StringVar(&callbackDeniedCIDRs, "callback-denied-cidrs", strings.Join(subscriptions.DefaultDeniedCallbackCIDRs, ","),
+		"Comma-separated list of the networks subscription callbacks may not reach.")
+	flag.StringVar(&hwEventAddr, "hw-event-bind-address", "",
+		"The address the hardware event listener binds to, receiving Redfish events from node BMCs. "+
+			"The listener is disabled if empty. It requires --tls-cert-dir and --hw-event-secret-file.")
+	flag.StringVar(&hwEventSecretFile, "hw-event-secret-file", "",
+		"The path to the file holding the shared secret the event tokens presented by node BMCs are derived from.")
+	flag.DurationVar(&cacheWatchdogInterval, "cache-watchdog-interval", cachewatchdog.DefaultInterval,
+		"The interval at which the informer caches are checked for staleness. The check is disabled if 0.")
+	flag.StringVar(&bmhCacheNamespaces, "bmh-cache-namespaces", "",
+		"Comma-separated list of the namespaces of the BareMetalHosts held in the cache. All namespaces if empty.")
+	flag.StringVar(&bmhCacheSelector, "bmh-cache-selector", "",
+		"The label selector of the BareMetalHosts held in the cache, such as hwmgr-plugin.oran.openshift.io/managed=true. "+
+			"All BareMetalHosts if empty.")
+	flag.DurationVar(&retentionInterval, "retention-interval", retention.DefaultInterval,
+		"The interval at which the items beyond their retention policy are purged. Items are not purged if 0.")
+	flag.DurationVar(&nodeHistoryRetention.MaxAge, "node-history-max-age", 0,
+		"The age after which the completed operations in the history of a node are purged. Not purged by age if 0.")
+	flag.IntVar(&nodeHistoryRetention.MaxCount, "node-history-max-count", 0,
+		fmt.Sprintf("The number of completed operations kept in the history of a node, up to %d. Not purged by count if 0.",
+			utils.MaxNodeOperationHistory))
+	flag.DurationVar(&deadLetterRetention.MaxAge, "dead-letter-max-age", retention.DefaultDeadLetterMaxAge,
+		"The age after which the dead-lettered notifications of a subscription are purged. Not purged by age if 0.")
+	flag.IntVar(&deadLetterRetention.MaxCount, "dead-letter-max-count", retention.DefaultDeadLetterMaxCount,
+		"The number of dead-lettered notifications kept for a subscription. Not purged by count if 0.")
+	flag.StringVar(&accessLogFormat, "access-log-format", string(api.AccessLogFormatNone),
+		"The format of the inventory API access log: none, combined or otlp.")
+	flag.StringVar(&accessLogFile, "access-log-file", "",
+		"The path to the file the inventory API access log is appended to. The access log is written to stdout if empty.")
+	flag.StringVar(&apiRateLimitKey, "api-rate-limit-key", string(api.ClientRateLimitKeySubject),
+		"The key identifying the inventory API clients limited separately: subject, the authenticated user, or address, "+
+			"the source IP.")
+	flag.Float64Var(&apiRateLimitQPS, "api-rate-limit-qps", 0,
+		"The number of inventory API requests per second allowed for each client. Requests are not limited if 0.")
+	flag.IntVar(&apiRateLimitBurst, "api-rate-limit-burst", 0,
+		"The number of inventory API requests a client may send at once. Defaults to the QPS if 0.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	features.AddFlag(flag.CommandLine)
 	opts := zap.Options{
 		Development: true,
 	}
@@ -121,7 +204,39 @@ func _main() int {
 		return 1
 	}
 
+	// The watchdog is created ahead of the manager, as it handles the watch errors of the informers
+	var watchdog *cachewatchdog.Watchdog
+	var cacheOptions cache.Options
+	if cacheWatchdogInterval > 0 {
+		watchdog = cachewatchdog.NewWatchdog(slog.New(logging.NewLoggingContextHandler(slog.LevelInfo)),
+			cacheWatchdogInterval, []cachewatchdog.WatchedType{
+				{Name: "NodePool", Object: &hwmgmtv1alpha1.NodePool{}, List: &hwmgmtv1alpha1.NodePoolList{}},
+				{Name: "Node", Object: &hwmgmtv1alpha1.Node{}, List: &hwmgmtv1alpha1.NodeList{}},
+				{Name: "HardwareManager", Object: &pluginv1alpha1.HardwareManager{}, List: &pluginv1alpha1.HardwareManagerList{}},
+				{Name: "HardwareProfile", Object: &pluginv1alpha1.HardwareProfile{}, List: &pluginv1alpha1.HardwareProfileList{}},
+				{Name: "BareMetalHost", Object: &bmhv1alpha1.BareMetalHost{}, List: &bmhv1alpha1.BareMetalHostList{},
+					Restartable: true},
+			})
+		cacheOptions.DefaultWatchErrorHandler = watchdog.WatchErrorHandler
+	}
+
+	// The BareMetalHosts cached by the manager, and so seen by the metal3 adaptor, can be restricted so that large clusters
+	// do not hold all their hosts in memory
+	var cacheNamespaces []string
+	if bmhCacheNamespaces != "" {
+		cacheNamespaces = strings.Split(bmhCacheNamespaces, ",")
+	}
+	bmhCache, err := metal3.BMHCacheOptions(cacheNamespaces, bmhCacheSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid BareMetalHost cache options")
+		return 1
+	}
+	if bmhCache != nil {
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{&bmhv1alpha1.BareMetalHost{}: *bmhCache}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Cache:  cacheOptions,
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
 			BindAddress:    metricsAddr,
@@ -129,6 +244,14 @@ func _main() int {
 			TLSOpts:        tlsOpts,
 			CertDir:        tlsCertDir,
 			FilterProvider: filters.WithAuthenticationAndAuthorization,
+			// The default endpoint only serves the text format, which does not carry the exemplars of the
+			// lifecycle metrics
+			ExtraHandlers: map[string]http.Handler{
+				"/metrics/openmetrics": promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{
+					ErrorHandling:     promhttp.HTTPErrorOnError,
+					EnableOpenMetrics: true,
+				}),
+			},
 		},
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -157,12 +280,16 @@ func _main() int {
 		return 1
 	}
 
+	// Changes of the BareMetalHosts watched by the metal3 adaptor trigger reconciles of the NodePools in progress
+	nodePoolEvents := make(chan event.GenericEvent, nodePoolEventQueueSize)
+
 	hwmgrAdaptor := &adaptors.HwMgrAdaptorController{
 		Client:          mgr.GetClient(),
 		NoncachedClient: mgr.GetAPIReader(),
 		Scheme:          mgr.GetScheme(),
 		Logger:          slog.New(logging.NewLoggingContextHandler(slog.LevelInfo)).With(slog.String("controller", "adaptors")),
 		Namespace:       myNamespace,
+		NodePoolEvents:  nodePoolEvents,
 	}
 	if err = hwmgrAdaptor.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to setup adaptor controller")
@@ -177,6 +304,7 @@ func _main() int {
 		Logger:          slog.New(logging.NewLoggingContextHandler(slog.LevelInfo)).With(slog.String("controller", "NodePool")),
 		Namespace:       myNamespace,
 		HwMgrAdaptor:    hwmgrAdaptor,
+		BackendEvents:   nodePoolEvents,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodePool")
 		return 1
@@ -191,6 +319,84 @@ func _main() int {
 		setupLog.Error(err, "unable to set up ready check")
 		return 1
 	}
+	if err := mgr.AddReadyzCheck("inventory", hwmgrAdaptor.InventoryReadyCheck); err != nil {
+		setupLog.Error(err, "unable to set up inventory ready check")
+		return 1
+	}
+	if watchdog != nil {
+		if err := watchdog.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to set up cache watchdog")
+			return 1
+		}
+	}
+
+	subscriptionStore, err := subscriptions.NewStore(subscriptionStoreKind, mgr.GetClient(), mgr.GetAPIReader(), myNamespace)
+	if err != nil {
+		setupLog.Error(err, "unable to setup subscription store")
+		return 1
+	}
+
+	if retentionInterval > 0 {
+		janitor := retention.NewJanitor(mgr.GetClient(), subscriptionStore,
+			slog.New(logging.NewLoggingContextHandler(slog.LevelInfo)), myNamespace, retentionInterval)
+		janitor.NodeHistory = nodeHistoryRetention
+		janitor.DeadLetters = deadLetterRetention
+		if err := janitor.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to set up retention janitor")
+			return 1
+		}
+	}
+
+	callbackPolicy, err := subscriptions.NewCallbackPolicy(
+		strings.Split(callbackAllowedSchemes, ","),
+		strings.Split(callbackAllowedHosts, ","),
+		strings.Split(callbackDeniedHosts, ","),
+		strings.Split(callbackAllowedCIDRs, ","),
+		strings.Split(callbackDeniedCIDRs, ","))
+	if err != nil {
+		setupLog.Error(err, "invalid subscription callback policy")
+		return 1
+	}
+
+	notifier := subscriptions.NewNotifier(subscriptionStore, slog.New(logging.NewLoggingContextHandler(slog.LevelInfo)))
+	notifier.MaxAttempts = notificationMaxAttempts
+	notifier.CallbackPolicy = callbackPolicy
+	if err := mgr.Add(notifier); err != nil {
+		setupLog.Error(err, "unable to setup notifier")
+		return 1
+	}
+
+	if hwEventAddr != "" {
+		if tlsCertDir == "" || hwEventSecretFile == "" {
+			setupLog.Error(nil, "hardware event listener requires --tls-cert-dir and --hw-event-secret-file")
+			return 1
+		}
+		eventSecret, err := os.ReadFile(hwEventSecretFile)
+		if err != nil || len(bytes.TrimSpace(eventSecret)) == 0 {
+			setupLog.Error(err, "unable to read hardware event secret", "file", hwEventSecretFile)
+			return 1
+		}
+		listener := hwevents.NewListener(mgr.GetClient(), slog.New(logging.NewLoggingContextHandler(slog.LevelInfo)),
+			hwEventAddr, tlsCertDir, bytes.TrimSpace(eventSecret), subscriptionStore)
+		if err := mgr.Add(listener); err != nil {
+			setupLog.Error(err, "unable to setup hardware event listener")
+			return 1
+		}
+	}
+
+	accessLog, err := api.NewAccessLog(api.AccessLogFormat(accessLogFormat), accessLogFile)
+	if err != nil {
+		setupLog.Error(err, "unable to setup access log")
+		return 1
+	}
+	defer accessLog.Close() // nolint: errcheck
+
+	rateLimiter, err := api.NewClientRateLimiter(api.ClientRateLimitKey(apiRateLimitKey), float32(apiRateLimitQPS),
+		apiRateLimitBurst)
+	if err != nil {
+		setupLog.Error(err, "unable to setup API rate limit")
+		return 1
+	}
 
 	serverErrors := make(chan error, 1)
 
@@ -198,7 +404,8 @@ func _main() int {
 	defer cancel()
 	go func() {
 		setupLog.Info("starting API server")
-		err = server.RunServer(ctx, apiServerAddr, tlsCertDir, hwmgrAdaptor)
+		err = server.RunServer(ctx, apiServerAddr, tlsCertDir, hwmgrAdaptor, subscriptionStore, callbackPolicy, accessLog,
+			rateLimiter)
 		if err != nil {
 			setupLog.Error(err, "unable to start API server")
 			serverErrors <- err
@@ -227,5 +434,13 @@ func _main() int {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case collect.Command:
+			os.Exit(collect.Main(os.Args[2:]))
+		case relay.Command:
+			os.Exit(relay.Main(os.Args[2:]))
+		}
+	}
 	os.Exit(_main())
 }
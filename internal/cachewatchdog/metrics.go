@@ -0,0 +1,36 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cachewatchdog
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var informerCacheStale = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "hwmgr_plugin_informer_cache_stale",
+	Help: "Whether the informer cache of a type was found stale at the last check",
+}, []string{"type"})
+
+var informerResourceVersionAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "hwmgr_plugin_informer_resource_version_age_seconds",
+	Help: "Time since the resource version synced by the informer of a type last advanced",
+}, []string{"type"})
+
+var informerRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "hwmgr_plugin_informer_restarts_total",
+	Help: "Number of informers restarted after their cache was found stale",
+}, []string{"type"})
+
+var informerWatchErrors = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "hwmgr_plugin_informer_watch_errors_total",
+	Help: "Number of watch errors reported by the informers",
+})
+
+func init() {
+	metrics.Registry.MustRegister(informerCacheStale, informerResourceVersionAge, informerRestarts, informerWatchErrors)
+}
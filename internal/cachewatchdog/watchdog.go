@@ -0,0 +1,373 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cachewatchdog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// DefaultInterval is the default interval between checks of the informer caches
+const DefaultInterval = 5 * time.Minute
+
+// watchErrorCheckDelay is the delay before the check triggered by a watch error, as watch errors often come in bursts
+// and a healthy informer needs a moment to relist
+const watchErrorCheckDelay = 30 * time.Second
+
+// WatchedType is a type whose informer cache is checked by the watchdog
+type WatchedType struct {
+	Name   string
+	Object client.Object
+	// List is an empty list of the type, used to read the cache
+	List client.ObjectList
+	// Restartable is set for types whose informer has no event handlers or field indexes, and can therefore be
+	// restarted in place. A stale cache of any other type fails the health check instead, so that the pod is restarted.
+	Restartable bool
+}
+
+// typeState is the outcome of the previous checks of a type
+type typeState struct {
+	resourceVersion string
+	lastAdvance     time.Time
+	// differences maps the objects whose cached resource version differed from the API server at the previous check
+	// to their resource version on the API server
+	differences map[types.NamespacedName]string
+	stale       bool
+}
+
+// resourceVersioner is implemented by the client-go informers underlying the cache
+type resourceVersioner interface {
+	LastSyncResourceVersion() string
+}
+
+// Watchdog detects informer caches that silently stopped following the API server, such as after a watch failure that
+// was not recovered. Each watched type is checked periodically, and after watch errors, by comparing the resource
+// versions of the cached objects with those on the API server. A type is stale when an object still differs at two
+// consecutive checks without having changed on the API server in between. The informer of a stale type is restarted
+// when possible, otherwise the health check fails so that the pod is restarted.
+type Watchdog struct {
+	Cache    cache.Cache
+	Reader   client.Reader
+	Scheme   *runtime.Scheme
+	Logger   *slog.Logger
+	Interval time.Duration
+	Types    []WatchedType
+
+	mutex    sync.Mutex
+	states   map[string]*typeState
+	checkNow chan struct{}
+	now      func() time.Time
+}
+
+// NewWatchdog creates a Watchdog for the types. The cache and readers are set by SetupWithManager.
+func NewWatchdog(logger *slog.Logger, interval time.Duration, watched []WatchedType) *Watchdog {
+	return &Watchdog{
+		Logger:   logger.With(slog.String("module", "cachewatchdog")),
+		Interval: interval,
+		Types:    watched,
+		states:   make(map[string]*typeState),
+		checkNow: make(chan struct{}, 1),
+		now:      time.Now,
+	}
+}
+
+// SetupWithManager adds the watchdog and its health check to the manager
+func (w *Watchdog) SetupWithManager(mgr ctrl.Manager) error {
+	w.Cache = mgr.GetCache()
+	w.Reader = mgr.GetAPIReader()
+	w.Scheme = mgr.GetScheme()
+
+	if err := mgr.Add(w); err != nil {
+		return fmt.Errorf("failed to add cache watchdog: %w", err)
+	}
+	if err := mgr.AddHealthzCheck("informer-cache", w.Check); err != nil {
+		return fmt.Errorf("failed to add cache watchdog health check: %w", err)
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, as each replica has its own cache
+func (w *Watchdog) NeedLeaderElection() bool {
+	return false
+}
+
+// WatchErrorHandler is set as the watch error handler of the informers. Watch errors are logged as done by default,
+// counted, and trigger an early check of the caches.
+func (w *Watchdog) WatchErrorHandler(r *toolscache.Reflector, err error) {
+	toolscache.DefaultWatchErrorHandler(r, err)
+	informerWatchErrors.Inc()
+	select {
+	case w.checkNow <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the checks until the context is cancelled
+func (w *Watchdog) Start(ctx context.Context) error {
+	if !w.Cache.WaitForCacheSync(ctx) {
+		return nil
+	}
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.CheckAll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-w.checkNow:
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(watchErrorCheckDelay):
+			}
+			// Any watch error received during the delay is covered by this check
+			select {
+			case <-w.checkNow:
+			default:
+			}
+		}
+	}
+}
+
+// CheckAll checks the cache of each watched type
+func (w *Watchdog) CheckAll(ctx context.Context) {
+	for _, watched := range w.Types {
+		if err := w.checkType(ctx, watched); err != nil {
+			w.Logger.InfoContext(ctx, "Unable to check informer cache", slog.String("type", watched.Name),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// Check is the health check of the watchdog, failing while the cache of a type that cannot be restarted is stale
+func (w *Watchdog) Check(_ *http.Request) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var stale []string
+	for name, state := range w.states {
+		if state.stale {
+			stale = append(stale, name)
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		return fmt.Errorf("stale informer cache for %s", strings.Join(stale, ", "))
+	}
+	return nil
+}
+
+// state returns the state of a type, creating it on first use. The caller holds the mutex.
+func (w *Watchdog) state(name string) *typeState {
+	state, exists := w.states[name]
+	if !exists {
+		state = &typeState{lastAdvance: w.now()}
+		w.states[name] = state
+	}
+	return state
+}
+
+// checkType checks the cache of a type, healing it if stale
+func (w *Watchdog) checkType(ctx context.Context, watched WatchedType) error {
+	// Getting the informer fails for a type unknown to the API server, such as an optional CRD that is not installed
+	informer, err := w.Cache.GetInformer(ctx, watched.Object, cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("failed to get informer: %w", err)
+	}
+
+	if versioner, ok := informer.(resourceVersioner); ok {
+		w.recordResourceVersion(watched.Name, versioner.LastSyncResourceVersion())
+	}
+
+	if informer.IsStopped() {
+		return w.heal(ctx, watched, "informer is stopped")
+	}
+	if !informer.HasSynced() {
+		return nil
+	}
+
+	cached, err := w.cachedVersions(ctx, watched)
+	if err != nil {
+		return err
+	}
+	live, err := w.liveVersions(ctx, watched)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	state := w.state(watched.Name)
+	differences := diffResourceVersions(cached, live)
+	persistent := persistentDifferences(state.differences, differences)
+	state.differences = differences
+	recovered := len(persistent) == 0 && state.stale
+	if recovered {
+		state.stale = false
+	}
+	w.mutex.Unlock()
+
+	if recovered {
+		informerCacheStale.WithLabelValues(watched.Name).Set(0)
+		w.Logger.InfoContext(ctx, "Informer cache has recovered", slog.String("type", watched.Name))
+	}
+	if len(persistent) == 0 {
+		return nil
+	}
+
+	return w.heal(ctx, watched, fmt.Sprintf("%d objects not updated in the cache, such as %s",
+		len(persistent), firstKey(persistent)))
+}
+
+// recordResourceVersion records the last resource version synced by the informer of a type, and exports the time since
+// it last advanced. Resource versions only advance with changes and watch bookmarks, so this is not a staleness signal
+// by itself.
+func (w *Watchdog) recordResourceVersion(name, resourceVersion string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	state := w.state(name)
+	now := w.now()
+	if resourceVersion != state.resourceVersion {
+		state.resourceVersion = resourceVersion
+		state.lastAdvance = now
+	}
+	informerResourceVersionAge.WithLabelValues(name).Set(now.Sub(state.lastAdvance).Seconds())
+}
+
+// heal restarts the informer of a stale type when possible, or marks it stale to fail the health check
+func (w *Watchdog) heal(ctx context.Context, watched WatchedType, reason string) error {
+	informerCacheStale.WithLabelValues(watched.Name).Set(1)
+
+	if !watched.Restartable {
+		w.mutex.Lock()
+		w.state(watched.Name).stale = true
+		w.mutex.Unlock()
+		w.Logger.ErrorContext(ctx, "Informer cache is stale, reporting unhealthy", slog.String("type", watched.Name),
+			slog.String("reason", reason))
+		return nil
+	}
+
+	w.Logger.WarnContext(ctx, "Informer cache is stale, restarting informer", slog.String("type", watched.Name),
+		slog.String("reason", reason))
+	informerRestarts.WithLabelValues(watched.Name).Inc()
+	if err := w.Cache.RemoveInformer(ctx, watched.Object); err != nil {
+		return fmt.Errorf("failed to remove informer: %w", err)
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, w.Interval)
+	defer cancel()
+	if _, err := w.Cache.GetInformer(syncCtx, watched.Object); err != nil {
+		return fmt.Errorf("failed to restart informer: %w", err)
+	}
+
+	w.mutex.Lock()
+	w.state(watched.Name).differences = nil
+	w.mutex.Unlock()
+	informerCacheStale.WithLabelValues(watched.Name).Set(0)
+	return nil
+}
+
+// cachedVersions returns the resource versions of the cached objects of a type
+func (w *Watchdog) cachedVersions(ctx context.Context, watched WatchedType) (map[types.NamespacedName]string, error) {
+	list, ok := watched.List.DeepCopyObject().(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("invalid list type for %s", watched.Name)
+	}
+	if err := w.Cache.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list cached objects: %w", err)
+	}
+	return resourceVersions(list)
+}
+
+// liveVersions returns the resource versions of the objects of a type on the API server, reading only their metadata
+func (w *Watchdog) liveVersions(ctx context.Context, watched WatchedType) (map[types.NamespacedName]string, error) {
+	gvk, err := apiutil.GVKForObject(watched.Object, w.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kind: %w", err)
+	}
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := w.Reader.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	return resourceVersions(list)
+}
+
+// resourceVersions maps the objects of a list to their resource version
+func resourceVersions(list client.ObjectList) (map[types.NamespacedName]string, error) {
+	versions := make(map[types.NamespacedName]string)
+	err := meta.EachListItem(list, func(obj runtime.Object) error {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return fmt.Errorf("failed to access object metadata: %w", err)
+		}
+		versions[types.NamespacedName{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}] = accessor.GetResourceVersion()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read list: %w", err)
+	}
+	return versions, nil
+}
+
+// diffResourceVersions returns the objects whose cached resource version differs from the API server, mapped to their
+// resource version on the API server, which is empty for objects that were deleted
+func diffResourceVersions(cached, live map[types.NamespacedName]string) map[types.NamespacedName]string {
+	differences := make(map[types.NamespacedName]string)
+	for key, version := range live {
+		if cached[key] != version {
+			differences[key] = version
+		}
+	}
+	for key := range cached {
+		if _, exists := live[key]; !exists {
+			differences[key] = ""
+		}
+	}
+	return differences
+}
+
+// persistentDifferences returns the differences that were already present at the previous check, for objects that have
+// not changed on the API server since. A healthy informer catches up with such changes well within a check interval.
+func persistentDifferences(previous, current map[types.NamespacedName]string) map[types.NamespacedName]string {
+	persistent := make(map[types.NamespacedName]string)
+	for key, version := range current {
+		if previousVersion, exists := previous[key]; exists && previousVersion == version {
+			persistent[key] = version
+		}
+	}
+	return persistent
+}
+
+// firstKey returns the first object of the differences, in name order, for logging
+func firstKey(differences map[types.NamespacedName]string) string {
+	keys := make([]string, 0, len(differences))
+	for key := range differences {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
@@ -0,0 +1,88 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cachewatchdog
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func key(name string) types.NamespacedName {
+	return types.NamespacedName{Namespace: "hwmgr", Name: name}
+}
+
+func TestDiffResourceVersions(t *testing.T) {
+	cached := map[types.NamespacedName]string{key("same"): "10", key("behind"): "11", key("deleted"): "12"}
+	live := map[types.NamespacedName]string{key("same"): "10", key("behind"): "15", key("created"): "16"}
+
+	differences := diffResourceVersions(cached, live)
+	expected := map[types.NamespacedName]string{key("behind"): "15", key("created"): "16", key("deleted"): ""}
+	if len(differences) != len(expected) {
+		t.Fatalf("expected differences %v, got %v", expected, differences)
+	}
+	for k, version := range expected {
+		if actual, exists := differences[k]; !exists || actual != version {
+			t.Errorf("%s: expected live version %q, got %q", k, version, actual)
+		}
+	}
+}
+
+func TestPersistentDifferences(t *testing.T) {
+	previous := map[types.NamespacedName]string{key("stuck"): "15", key("changed"): "16", key("deleted"): ""}
+	current := map[types.NamespacedName]string{key("stuck"): "15", key("changed"): "17", key("deleted"): "", key("new"): "18"}
+
+	persistent := persistentDifferences(previous, current)
+	if len(persistent) != 2 || persistent[key("stuck")] != "15" {
+		t.Errorf("expected stuck and deleted objects to persist, got %v", persistent)
+	}
+	if _, exists := persistent[key("deleted")]; !exists {
+		t.Errorf("expected the deleted object to persist, got %v", persistent)
+	}
+	if firstKey(persistent) != "hwmgr/deleted" {
+		t.Errorf("unexpected first key %s", firstKey(persistent))
+	}
+
+	if persistent := persistentDifferences(nil, current); len(persistent) != 0 {
+		t.Errorf("expected no persistent differences on the first check, got %v", persistent)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	w := NewWatchdog(slog.Default(), time.Minute, nil)
+	if err := w.Check(nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	w.state("Node").stale = true
+	w.state("NodePool").stale = true
+	w.state("HardwareManager")
+	err := w.Check(nil)
+	if err == nil || err.Error() != "stale informer cache for Node, NodePool" {
+		t.Errorf("unexpected health check result: %v", err)
+	}
+}
+
+func TestRecordResourceVersion(t *testing.T) {
+	now := time.Now()
+	w := NewWatchdog(slog.Default(), time.Minute, nil)
+	w.now = func() time.Time { return now }
+
+	w.recordResourceVersion("Node", "10")
+	now = now.Add(time.Minute)
+	w.recordResourceVersion("Node", "10")
+	if state := w.state("Node"); now.Sub(state.lastAdvance) != time.Minute {
+		t.Errorf("expected the resource version age to grow, got %s", now.Sub(state.lastAdvance))
+	}
+
+	w.recordResourceVersion("Node", "11")
+	if state := w.state("Node"); !state.lastAdvance.Equal(now) || state.resourceVersion != "11" {
+		t.Errorf("expected the resource version to advance, got %+v", state)
+	}
+}
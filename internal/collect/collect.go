@@ -0,0 +1,373 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package collect
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/version"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods;pods/log,verbs=get;list
+
+// The support bundle gathers what is asked for on escalation into a fixed layout, under a single top-level directory:
+//
+//	collection.json              time of collection, plugin version and any collection errors
+//	hardwaremanagers.yaml        HardwareManager CRs
+//	nodepools.yaml               NodePool CRs
+//	nodes.yaml                   Node CRs
+//	operation-history/<node>.json
+//	inventory-snapshots/<hwmgr>.json
+//	logs/<pod>/<container>.log   logs of the plugin pods, and <container>.previous.log for restarted containers
+const (
+	BundleDir = "oran-hwmgr-plugin-must-gather"
+
+	// PluginPodSelector selects the plugin pods whose logs are collected
+	PluginPodSelector = "control-plane=controller-manager"
+
+	// DefaultLogsSince bounds the age of the collected logs
+	DefaultLogsSince = 24 * time.Hour
+
+	inventorySnapshotSuffix = "-inventory-snapshot"
+	inventorySnapshotKey    = "snapshot.json.gz"
+
+	redacted = "<redacted>"
+)
+
+// sensitiveKeys are the substrings of field names whose values are redacted from backend snapshots
+var sensitiveKeys = []string{"password", "secret", "token", "credential"}
+
+// Options configures the collection
+type Options struct {
+	Namespace string
+	LogsSince time.Duration
+}
+
+// Summary records the context of a collection, along with the items that could not be collected
+type Summary struct {
+	CollectedAt time.Time `json:"collectedAt"`
+	Namespace   string    `json:"namespace"`
+	Version     string    `json:"version"`
+	GitSHA      string    `json:"gitSha"`
+	Errors      []string  `json:"errors,omitempty"`
+}
+
+type collector struct {
+	c         client.Client
+	clientset kubernetes.Interface
+	opts      Options
+	tw        *tar.Writer
+	now       time.Time
+	summary   Summary
+}
+
+// Run collects the support bundle as a gzip-compressed tar archive. Collection is best effort: an item that cannot be
+// collected is recorded in collection.json, and an error is only returned if the archive cannot be written.
+func Run(ctx context.Context, c client.Client, clientset kubernetes.Interface, opts Options, out io.Writer) error {
+	if opts.LogsSince <= 0 {
+		opts.LogsSince = DefaultLogsSince
+	}
+
+	gz := gzip.NewWriter(out)
+	col := &collector{
+		c:         c,
+		clientset: clientset,
+		opts:      opts,
+		tw:        tar.NewWriter(gz),
+		now:       time.Now(),
+		summary: Summary{
+			Namespace: opts.Namespace,
+			Version:   version.Version,
+			GitSHA:    version.GetGitSHA(),
+		},
+	}
+	col.summary.CollectedAt = col.now.UTC()
+
+	steps := []func(context.Context) error{
+		col.collectHardwareManagers,
+		col.collectNodePools,
+		col.collectNodes,
+		col.collectInventorySnapshots,
+		col.collectLogs,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(col.summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode collection summary: %w", err)
+	}
+	if err := col.writeFile("collection.json", data); err != nil {
+		return err
+	}
+
+	if err := col.tw.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to compress archive: %w", err)
+	}
+	return nil
+}
+
+// failed records an item that could not be collected
+func (col *collector) failed(format string, args ...any) {
+	col.summary.Errors = append(col.summary.Errors, fmt.Sprintf(format, args...))
+}
+
+func (col *collector) writeFile(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    path.Join(BundleDir, name),
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: col.now,
+	}
+	if err := col.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	if _, err := col.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// writeList writes a list of CRs as YAML, without their managed fields
+func (col *collector) writeList(name string, list client.ObjectList, items []client.Object) error {
+	// The type of objects read with a typed client is not set, so it is restored to make the bundle self-describing
+	if gvk, err := apiutil.GVKForObject(list, col.c.Scheme()); err == nil {
+		list.GetObjectKind().SetGroupVersionKind(gvk)
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		for _, item := range items {
+			item.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+	}
+	for _, item := range items {
+		item.SetManagedFields(nil)
+	}
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		col.failed("failed to encode %s: %v", name, err)
+		return nil
+	}
+	return col.writeFile(name, data)
+}
+
+func (col *collector) collectHardwareManagers(ctx context.Context) error {
+	list := &pluginv1alpha1.HardwareManagerList{}
+	if err := col.c.List(ctx, list, client.InNamespace(col.opts.Namespace)); err != nil {
+		col.failed("failed to list HardwareManagers: %v", err)
+		return nil
+	}
+	items := make([]client.Object, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	return col.writeList("hardwaremanagers.yaml", list, items)
+}
+
+func (col *collector) collectNodePools(ctx context.Context) error {
+	list := &hwmgmtv1alpha1.NodePoolList{}
+	if err := col.c.List(ctx, list, client.InNamespace(col.opts.Namespace)); err != nil {
+		col.failed("failed to list NodePools: %v", err)
+		return nil
+	}
+	items := make([]client.Object, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	return col.writeList("nodepools.yaml", list, items)
+}
+
+// collectNodes writes the Node CRs, along with the operation history of each node in a readable form
+func (col *collector) collectNodes(ctx context.Context) error {
+	list := &hwmgmtv1alpha1.NodeList{}
+	if err := col.c.List(ctx, list, client.InNamespace(col.opts.Namespace)); err != nil {
+		col.failed("failed to list Nodes: %v", err)
+		return nil
+	}
+	items := make([]client.Object, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	if err := col.writeList("nodes.yaml", list, items); err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		node := &list.Items[i]
+		history := utils.GetNodeOperationHistory(node)
+		if len(history) == 0 {
+			continue
+		}
+		data, err := json.MarshalIndent(history, "", "  ")
+		if err != nil {
+			col.failed("failed to encode operation history of node %s: %v", node.Name, err)
+			continue
+		}
+		if err := col.writeFile(path.Join("operation-history", node.Name+".json"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectInventorySnapshots writes the last-known inventory persisted for each hardware manager, decompressed and
+// sanitized
+func (col *collector) collectInventorySnapshots(ctx context.Context) error {
+	list := &corev1.ConfigMapList{}
+	if err := col.c.List(ctx, list, client.InNamespace(col.opts.Namespace)); err != nil {
+		col.failed("failed to list ConfigMaps: %v", err)
+		return nil
+	}
+
+	for _, cm := range list.Items {
+		hwmgrName, found := strings.CutSuffix(cm.Name, inventorySnapshotSuffix)
+		if !found {
+			continue
+		}
+		data, err := decodeSnapshot(cm.BinaryData[inventorySnapshotKey])
+		if err != nil {
+			col.failed("failed to decode inventory snapshot %s: %v", cm.Name, err)
+			continue
+		}
+		if err := col.writeFile(path.Join("inventory-snapshots", hwmgrName+".json"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeSnapshot decompresses a backend snapshot and redacts any sensitive fields
+func decodeSnapshot(data []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshot: %w", err)
+	}
+	defer gz.Close() // nolint: errcheck
+
+	var snapshot any
+	if err := json.NewDecoder(gz).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	sanitized, err := json.MarshalIndent(sanitize(snapshot), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return sanitized, nil
+}
+
+// sanitize redacts the values of fields whose names suggest they hold secrets
+func sanitize(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isSensitiveKey(key) {
+				v[key] = redacted
+			} else {
+				v[key] = sanitize(field)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = sanitize(v[i])
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectLogs writes the logs of the containers of the plugin pods, including the previous instance of restarted
+// containers
+func (col *collector) collectLogs(ctx context.Context) error {
+	pods, err := col.clientset.CoreV1().Pods(col.opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: PluginPodSelector})
+	if err != nil {
+		col.failed("failed to list plugin pods: %v", err)
+		return nil
+	}
+
+	sinceSeconds := int64(col.opts.LogsSince.Seconds())
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if err := col.collectContainerLog(ctx, pod.Name, status.Name, sinceSeconds, false); err != nil {
+				return err
+			}
+			if status.RestartCount > 0 {
+				if err := col.collectContainerLog(ctx, pod.Name, status.Name, sinceSeconds, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (col *collector) collectContainerLog(ctx context.Context, pod, container string, sinceSeconds int64, previous bool) error {
+	name := path.Join("logs", pod, container+".log")
+	if previous {
+		name = path.Join("logs", pod, container+".previous.log")
+	}
+
+	req := col.clientset.CoreV1().Pods(col.opts.Namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container:    container,
+		SinceSeconds: &sinceSeconds,
+		Previous:     previous,
+	})
+	data, err := req.DoRaw(ctx)
+	if err != nil {
+		col.failed("failed to get logs of %s/%s: %v", pod, container, err)
+		return nil
+	}
+	return col.writeFile(name, data)
+}
+
+// Scheme returns the scheme of the CRs collected in the bundle
+func Scheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		hwmgmtv1alpha1.AddToScheme,
+		pluginv1alpha1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			return nil, fmt.Errorf("failed to build scheme: %w", err)
+		}
+	}
+	return scheme, nil
+}
@@ -0,0 +1,74 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package collect
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeSnapshot(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(`{
+		"timestamp": "2024-10-21T21:38:42Z",
+		"resources": [{
+			"name": "server-1",
+			"bmc": {"address": "idrac-virtualmedia://192.0.2.1", "password": "notreal", "credentialsName": "server-1-bmc"},
+			"labels": {"accessToken": "abc", "rack": "r1"}
+		}]
+	}`)); err != nil {
+		t.Fatalf("failed to compress snapshot: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to compress snapshot: %v", err)
+	}
+
+	data, err := decodeSnapshot(buf.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var snapshot struct {
+		Resources []struct {
+			Name   string            `json:"name"`
+			Bmc    map[string]string `json:"bmc"`
+			Labels map[string]string `json:"labels"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatalf("failed to parse decoded snapshot: %v", err)
+	}
+	if len(snapshot.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(snapshot.Resources))
+	}
+
+	resource := snapshot.Resources[0]
+	checks := []struct {
+		field, got, want string
+	}{
+		{"name", resource.Name, "server-1"},
+		{"bmc.address", resource.Bmc["address"], "idrac-virtualmedia://192.0.2.1"},
+		{"bmc.password", resource.Bmc["password"], redacted},
+		{"bmc.credentialsName", resource.Bmc["credentialsName"], redacted},
+		{"labels.accessToken", resource.Labels["accessToken"], redacted},
+		{"labels.rack", resource.Labels["rack"], "r1"},
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s: expected %q, got %q", check.field, check.want, check.got)
+		}
+	}
+}
+
+func TestDecodeSnapshotInvalid(t *testing.T) {
+	if _, err := decodeSnapshot([]byte("not gzip")); err == nil {
+		t.Errorf("expected error for invalid snapshot")
+	}
+}
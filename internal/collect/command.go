@@ -0,0 +1,103 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package collect
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Command is the manager subcommand that gathers a support bundle
+const Command = "collect"
+
+// Main runs the collect subcommand, writing the support bundle to a file, or to stdout if the output is "-",
+// so that it can be run in the plugin pod and streamed out with oc exec
+func Main(args []string) int {
+	var namespace, output string
+	var since time.Duration
+
+	fs := flag.NewFlagSet(Command, flag.ContinueOnError)
+	fs.StringVar(&namespace, "namespace", os.Getenv("MY_POD_NAMESPACE"),
+		"The namespace of the plugin. Defaults to the namespace of the pod when run in the plugin pod.")
+	fs.StringVar(&output, "output", "",
+		"The file to write the bundle to, or - for stdout. Defaults to oran-hwmgr-plugin-must-gather-<time>.tar.gz.")
+	fs.DurationVar(&since, "since", DefaultLogsSince, "The age of the oldest plugin logs to collect.")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if namespace == "" {
+		namespace = "oran-hwmgr-plugin"
+	}
+	if output == "" {
+		output = fmt.Sprintf("%s-%s.tar.gz", BundleDir, time.Now().UTC().Format("20060102-150405"))
+	}
+
+	if err := runCollect(namespace, output, since); err != nil {
+		fmt.Fprintf(os.Stderr, "collect failed: %v\n", err)
+		return 1
+	}
+	if output != "-" {
+		fmt.Fprintf(os.Stderr, "Support bundle written to %s\n", output)
+	}
+	return 0
+}
+
+func runCollect(namespace, output string, since time.Duration) error {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get cluster config: %w", err)
+	}
+	scheme, err := Scheme()
+	if err != nil {
+		return err // nolint: wrapcheck
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	opts := Options{Namespace: namespace, LogsSince: since}
+	if output == "-" {
+		return writeBundle(c, clientset, opts, os.Stdout)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+	if err := writeBundle(c, clientset, opts, f); err != nil {
+		f.Close() // nolint: errcheck,gosec
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return nil
+}
+
+func writeBundle(c client.Client, clientset kubernetes.Interface, opts Options, out io.Writer) error {
+	if err := Run(context.Background(), c, clientset, opts, out); err != nil {
+		return fmt.Errorf("failed to collect support bundle: %w", err)
+	}
+	return nil
+}
@@ -14,12 +14,20 @@ import (
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	adaptors "github.com/openshift-kni/oran-hwmgr-plugin/adaptors"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 )
@@ -33,7 +41,9 @@ type NodePoolReconciler struct {
 	Logger          *slog.Logger
 	Namespace       string
 	HwMgrAdaptor    *adaptors.HwMgrAdaptorController
-	indexerEnabled  bool
+	// BackendEvents triggers reconciles from the changes of the hardware seen by the adaptors
+	BackendEvents  <-chan event.GenericEvent
+	indexerEnabled bool
 }
 
 func (r *NodePoolReconciler) SetupIndexer(ctx context.Context) error {
@@ -51,13 +61,13 @@ func (r *NodePoolReconciler) SetupIndexer(ctx context.Context) error {
 
 //+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
 //+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
-//+kubebuilder:rbac:groups=o2ims-hardwaremanagement.oran.openshift.io,resources=nodepools,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=o2ims-hardwaremanagement.oran.openshift.io,resources=nodepools,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=o2ims-hardwaremanagement.oran.openshift.io,resources=nodepools/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=o2ims-hardwaremanagement.oran.openshift.io,resources=nodepools/finalizers,verbs=update
 //+kubebuilder:rbac:groups=o2ims-hardwaremanagement.oran.openshift.io,resources=nodes,verbs=get;create;list;watch;update;patch;delete
 //+kubebuilder:rbac:groups=o2ims-hardwaremanagement.oran.openshift.io,resources=nodes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=o2ims-hardwaremanagement.oran.openshift.io,resources=nodes/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;create;update;patch;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;create;update;patch;watch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;create;update;patch;watch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -129,14 +139,75 @@ func (r *NodePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return result, fmt.Errorf("failed HandleNodePool: %w", err)
 	}
 
+	// Render the per-node manifests requested by the NodePool extensions, whatever the adaptor of the NodePool
+	if utils.HasNodeManifestTemplates(nodepool) {
+		if err := utils.ReconcileNodeManifests(ctx, r.Logger, r.Client, nodepool, r.Namespace); err != nil {
+			r.Logger.InfoContext(ctx, "Unable to render node manifests, requeueing", slog.String("error", err.Error()))
+			return utils.RequeueWithMediumInterval(), nil
+		}
+	}
+
 	return result, nil
 }
 
+// mapHardwareManagerToNodePools returns a reconcile request for each NodePool bound to the HardwareManager, so that
+// in-flight pools pick up a change in the backend configuration without waiting for their next event
+func (r *NodePoolReconciler) mapHardwareManagerToNodePools(ctx context.Context, obj client.Object) []reconcile.Request {
+	nodepools := &hwmgmtv1alpha1.NodePoolList{}
+	if err := r.Client.List(ctx, nodepools, client.InNamespace(r.Namespace)); err != nil {
+		r.Logger.ErrorContext(ctx, "Unable to list NodePools for HardwareManager change",
+			slog.String("hwmgr", obj.GetName()), slog.String("error", err.Error()))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, nodepool := range nodepools.Items {
+		if nodepool.Spec.HwMgrId == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&nodepool)})
+		}
+	}
+
+	if len(requests) > 0 {
+		r.Logger.InfoContext(ctx, "HardwareManager changed, requeueing bound NodePools",
+			slog.String("hwmgr", obj.GetName()), slog.Int("count", len(requests)))
+	}
+	return requests
+}
+
+// mapMemberToParentNodePool returns a reconcile request for the NodePool that a member NodePool was split from, so
+// that the status of its members is aggregated as it changes
+func (r *NodePoolReconciler) mapMemberToParentNodePool(_ context.Context, obj client.Object) []reconcile.Request {
+	parent := utils.GetParentNodePool(obj)
+	if parent == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: parent}}}
+}
+
+// hardwareManagerChanged filters HardwareManager events down to spec changes and deletions. Creation is skipped, as
+// NodePools are reconciled on startup anyway, and status updates by the adaptor controllers are ignored.
+func hardwareManagerChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		UpdateFunc:  predicate.GenerationChangedPredicate{}.Update,
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *NodePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&hwmgmtv1alpha1.NodePool{}).
-		Complete(r); err != nil {
+		Watches(&hwmgmtv1alpha1.NodePool{},
+			handler.EnqueueRequestsFromMapFunc(r.mapMemberToParentNodePool)).
+		Watches(&pluginv1alpha1.HardwareManager{},
+			handler.EnqueueRequestsFromMapFunc(r.mapHardwareManagerToNodePools),
+			builder.WithPredicates(hardwareManagerChanged()))
+	if r.BackendEvents != nil {
+		b = b.WatchesRawSource(source.Channel(r.BackendEvents, &handler.EnqueueRequestForObject{}))
+	}
+	if err := b.Complete(r); err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
 	}
 
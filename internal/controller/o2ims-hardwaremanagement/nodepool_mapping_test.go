@@ -0,0 +1,183 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package o2imshardwaremanagement
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// nodePoolListClient serves a fixed list of NodePools, or fails the list
+type nodePoolListClient struct {
+	client.Client
+	nodepools []hwmgmtv1alpha1.NodePool
+	err       error
+	namespace string
+}
+
+func (c *nodePoolListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if c.err != nil {
+		return c.err
+	}
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	c.namespace = listOpts.Namespace
+	list.(*hwmgmtv1alpha1.NodePoolList).Items = c.nodepools
+	return nil
+}
+
+func testNodePool(name, hwMgrId string) hwmgmtv1alpha1.NodePool {
+	return hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "hwmgr"},
+		Spec:       hwmgmtv1alpha1.NodePoolSpec{HwMgrId: hwMgrId},
+	}
+}
+
+func TestMapHardwareManagerToNodePools(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{Name: "dell-1", Namespace: "hwmgr"}}
+
+	testcases := []struct {
+		name      string
+		nodepools []hwmgmtv1alpha1.NodePool
+		err       error
+		expected  []reconcile.Request
+	}{
+		{
+			name:      "no nodepools",
+			nodepools: nil,
+			expected:  nil,
+		},
+		{
+			name: "bound nodepools",
+			nodepools: []hwmgmtv1alpha1.NodePool{
+				testNodePool("np-1", "dell-1"),
+				testNodePool("np-2", "dell-1"),
+			},
+			expected: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "hwmgr", Name: "np-1"}},
+				{NamespacedName: types.NamespacedName{Namespace: "hwmgr", Name: "np-2"}},
+			},
+		},
+		{
+			name: "nodepools of other hardware managers",
+			nodepools: []hwmgmtv1alpha1.NodePool{
+				testNodePool("np-1", "loopback-1"),
+				testNodePool("np-2", "dell-1"),
+				testNodePool("np-3", "dell-10"),
+			},
+			expected: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "hwmgr", Name: "np-2"}},
+			},
+		},
+		{
+			name:     "list failure",
+			err:      errors.New("unavailable"),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &nodePoolListClient{nodepools: tc.nodepools, err: tc.err}
+			r := &NodePoolReconciler{Client: c, Logger: slog.Default(), Namespace: "hwmgr"}
+
+			requests := r.mapHardwareManagerToNodePools(context.Background(), hwmgr)
+			if len(requests) != len(tc.expected) {
+				t.Fatalf("expected requests %v, got %v", tc.expected, requests)
+			}
+			for i := range requests {
+				if requests[i] != tc.expected[i] {
+					t.Errorf("expected requests %v, got %v", tc.expected, requests)
+				}
+			}
+			if tc.err == nil && c.namespace != "hwmgr" {
+				t.Errorf("expected NodePools listed in namespace hwmgr, got %q", c.namespace)
+			}
+		})
+	}
+}
+
+func TestHardwareManagerChanged(t *testing.T) {
+	hwmgr := func(generation int64, status pluginv1alpha1.HardwareManagerStatus) *pluginv1alpha1.HardwareManager {
+		return &pluginv1alpha1.HardwareManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "dell-1", Namespace: "hwmgr", Generation: generation},
+			Status:     status,
+		}
+	}
+	withCondition := pluginv1alpha1.HardwareManagerStatus{
+		Conditions: []metav1.Condition{{Type: "Validation", Status: metav1.ConditionTrue}},
+	}
+
+	testcases := []struct {
+		name     string
+		event    any
+		expected bool
+	}{
+		{
+			name:     "create",
+			event:    event.CreateEvent{Object: hwmgr(1, pluginv1alpha1.HardwareManagerStatus{})},
+			expected: false,
+		},
+		{
+			name: "spec update",
+			event: event.UpdateEvent{
+				ObjectOld: hwmgr(1, pluginv1alpha1.HardwareManagerStatus{}),
+				ObjectNew: hwmgr(2, pluginv1alpha1.HardwareManagerStatus{}),
+			},
+			expected: true,
+		},
+		{
+			name: "status update",
+			event: event.UpdateEvent{
+				ObjectOld: hwmgr(1, pluginv1alpha1.HardwareManagerStatus{}),
+				ObjectNew: hwmgr(1, withCondition),
+			},
+			expected: false,
+		},
+		{
+			name:     "delete",
+			event:    event.DeleteEvent{Object: hwmgr(1, pluginv1alpha1.HardwareManagerStatus{})},
+			expected: true,
+		},
+		{
+			name:     "generic",
+			event:    event.GenericEvent{Object: hwmgr(1, pluginv1alpha1.HardwareManagerStatus{})},
+			expected: false,
+		},
+	}
+
+	p := hardwareManagerChanged()
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result bool
+			switch e := tc.event.(type) {
+			case event.CreateEvent:
+				result = p.Create(e)
+			case event.UpdateEvent:
+				result = p.Update(e)
+			case event.DeleteEvent:
+				result = p.Delete(e)
+			case event.GenericEvent:
+				result = p.Generic(e)
+			}
+			if result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The O2IMS hardware management API is moving from NodePool to NodeAllocationRequest, renaming the fields of the
+// request and its status along the way (cloudID to clusterId, nodePoolData to nodeGroupData, selectedPools to
+// selectedGroups). AllocationRequest is the view of a request common to both APIs, so that the status of a request is
+// read and written the same way whichever API it was made through, during the transition window where the plugin
+// serves both. Each API implements the interface, translating the common status to and from its own fields.
+
+// AllocationRequest is a request to allocate nodes from a hardware manager, independent of the API it was made through
+type AllocationRequest interface {
+	// Object returns the CR of the request, for the client
+	Object() client.Object
+	// Kind returns the kind of the CR of the request
+	Kind() string
+	// New returns an empty request of the same API, to read the latest version of the CR into
+	New() AllocationRequest
+
+	GetClusterId() string
+	GetHardwareManagerId() string
+	GetSite() string
+	GetNodeGroups() []AllocationNodeGroup
+
+	// GetAllocationStatus returns the status of the request
+	GetAllocationStatus() AllocationStatus
+	// SetAllocationStatus sets the status of the request, translated to the fields of its API
+	SetAllocationStatus(status AllocationStatus)
+	// TranslateCondition maps a condition type and reason reported by the plugin to those of the API of the request
+	TranslateCondition(conditionType, reason string) (string, string)
+}
+
+// AllocationNodeGroup is a group of nodes of an allocation request
+type AllocationNodeGroup struct {
+	Name             string
+	Role             string
+	HwProfile        string
+	ResourcePoolId   string
+	ResourceSelector string
+	Size             int
+}
+
+// AllocationStatus is the status of an allocation request
+type AllocationStatus struct {
+	Conditions []metav1.Condition
+	NodeNames  []string
+	// SelectedGroups are the resource pools selected for each node group
+	SelectedGroups map[string]string
+	// ObservedGeneration is the generation of the request last processed by the plugin
+	ObservedGeneration int64
+}
+
+// nodePoolRequest is an allocation request made through the NodePool API
+type nodePoolRequest struct {
+	nodepool *hwmgmtv1alpha1.NodePool
+}
+
+// NewNodePoolRequest returns the allocation request of a NodePool. Changes to the status of the request are made to
+// the NodePool.
+func NewNodePoolRequest(nodepool *hwmgmtv1alpha1.NodePool) AllocationRequest {
+	return &nodePoolRequest{nodepool: nodepool}
+}
+
+func (r *nodePoolRequest) Object() client.Object {
+	return r.nodepool
+}
+
+func (r *nodePoolRequest) Kind() string {
+	return "NodePool"
+}
+
+func (r *nodePoolRequest) New() AllocationRequest {
+	return &nodePoolRequest{nodepool: &hwmgmtv1alpha1.NodePool{}}
+}
+
+func (r *nodePoolRequest) GetClusterId() string {
+	return r.nodepool.Spec.CloudID
+}
+
+func (r *nodePoolRequest) GetHardwareManagerId() string {
+	return r.nodepool.Spec.HwMgrId
+}
+
+func (r *nodePoolRequest) GetSite() string {
+	return r.nodepool.Spec.Site
+}
+
+func (r *nodePoolRequest) GetNodeGroups() []AllocationNodeGroup {
+	nodegroups := make([]AllocationNodeGroup, 0, len(r.nodepool.Spec.NodeGroup))
+	for _, nodegroup := range r.nodepool.Spec.NodeGroup {
+		nodegroups = append(nodegroups, AllocationNodeGroup{
+			Name:             nodegroup.NodePoolData.Name,
+			Role:             nodegroup.NodePoolData.Role,
+			HwProfile:        nodegroup.NodePoolData.HwProfile,
+			ResourcePoolId:   nodegroup.NodePoolData.ResourcePoolId,
+			ResourceSelector: nodegroup.NodePoolData.ResourceSelector,
+			Size:             nodegroup.Size,
+		})
+	}
+	return nodegroups
+}
+
+func (r *nodePoolRequest) GetAllocationStatus() AllocationStatus {
+	return AllocationStatus{
+		Conditions:         r.nodepool.Status.Conditions,
+		NodeNames:          r.nodepool.Status.Properties.NodeNames,
+		SelectedGroups:     r.nodepool.Status.SelectedPools,
+		ObservedGeneration: r.nodepool.Status.HwMgrPlugin.ObservedGeneration,
+	}
+}
+
+func (r *nodePoolRequest) SetAllocationStatus(status AllocationStatus) {
+	r.nodepool.Status.Conditions = status.Conditions
+	r.nodepool.Status.Properties.NodeNames = status.NodeNames
+	r.nodepool.Status.SelectedPools = status.SelectedGroups
+	r.nodepool.Status.HwMgrPlugin.ObservedGeneration = status.ObservedGeneration
+}
+
+// TranslateCondition keeps the conditions as is, as the plugin reports the conditions of the NodePool API
+func (r *nodePoolRequest) TranslateCondition(conditionType, reason string) (string, string) {
+	return conditionType, reason
+}
+
+// updateAllocationStatus applies the update to the status of the latest version of the request, writing it if changed
+func updateAllocationStatus(ctx context.Context, c client.Client, request AllocationRequest,
+	update func(latest AllocationRequest, status *AllocationStatus) bool) error {
+	// nolint: wrapcheck
+	return RetryOnConflictOrRetriable(retry.DefaultRetry, func() error {
+		latest := request.New()
+		if err := c.Get(ctx, client.ObjectKeyFromObject(request.Object()), latest.Object()); err != nil {
+			return err
+		}
+		status := latest.GetAllocationStatus()
+		if !update(latest, &status) {
+			return nil
+		}
+		latest.SetAllocationStatus(status)
+		return c.Status().Update(ctx, latest.Object())
+	})
+}
+
+// UpdateAllocationRequestCondition sets a condition of an allocation request, translated to its API, in the request
+// and in its CR
+func UpdateAllocationRequestCondition(
+	ctx context.Context,
+	c client.Client,
+	request AllocationRequest,
+	conditionType hwmgmtv1alpha1.ConditionType,
+	conditionReason hwmgmtv1alpha1.ConditionReason,
+	conditionStatus metav1.ConditionStatus,
+	message string) error {
+
+	translatedType, translatedReason := request.TranslateCondition(string(conditionType), string(conditionReason))
+
+	status := request.GetAllocationStatus()
+	SetStatusCondition(&status.Conditions, translatedType, translatedReason, conditionStatus, message)
+	request.SetAllocationStatus(status)
+
+	object := request.Object()
+	key := conditionWriteKey(request.Kind(), object.GetNamespace(), object.GetName(), translatedType)
+
+	var written client.Object
+	var staleVersion string
+	err := updateAllocationStatus(ctx, c, request, func(latest AllocationRequest, status *AllocationStatus) bool {
+		written = nil
+		if !SetStatusCondition(&status.Conditions, translatedType, translatedReason, conditionStatus, message) ||
+			recentConditionWrites.isDuplicate(key, latest.Object(), conditionStatus, translatedReason, message) {
+			return false
+		}
+		written, staleVersion = latest.Object(), latest.Object().GetResourceVersion()
+		return true
+	})
+	if err != nil {
+		recentConditionWrites.forget(key)
+		return fmt.Errorf("failed to update %s condition: %s, %w", strings.ToLower(request.Kind()), object.GetName(), err)
+	}
+
+	if written != nil {
+		recentConditionWrites.record(key, written, staleVersion, conditionStatus, translatedReason, message)
+	}
+	return nil
+}
+
+// UpdateAllocationRequestNodeNames writes the node names of the allocation request to its CR
+func UpdateAllocationRequestNodeNames(ctx context.Context, c client.Client, request AllocationRequest) error {
+	nodenames := request.GetAllocationStatus().NodeNames
+	err := updateAllocationStatus(ctx, c, request, func(_ AllocationRequest, status *AllocationStatus) bool {
+		if equality.Semantic.DeepEqual(status.NodeNames, nodenames) {
+			return false
+		}
+		status.NodeNames = nodenames
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %s properties: %w", strings.ToLower(request.Kind()), err)
+	}
+	return nil
+}
+
+// UpdateAllocationRequestSelectedGroups writes the resource pools selected for the node groups of the allocation
+// request to its CR
+func UpdateAllocationRequestSelectedGroups(ctx context.Context, c client.Client, request AllocationRequest) error {
+	selected := request.GetAllocationStatus().SelectedGroups
+	err := updateAllocationStatus(ctx, c, request, func(_ AllocationRequest, status *AllocationStatus) bool {
+		if equality.Semantic.DeepEqual(status.SelectedGroups, selected) {
+			return false
+		}
+		status.SelectedGroups = selected
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %s selected groups: %w", strings.ToLower(request.Kind()), err)
+	}
+	return nil
+}
+
+// UpdateAllocationRequestObservedGeneration records that the plugin has processed the current generation of the
+// allocation request in its CR
+func UpdateAllocationRequestObservedGeneration(ctx context.Context, c client.Client, request AllocationRequest) error {
+	err := updateAllocationStatus(ctx, c, request, func(latest AllocationRequest, status *AllocationStatus) bool {
+		generation := latest.Object().GetGeneration()
+		if status.ObservedGeneration == generation {
+			return false
+		}
+		status.ObservedGeneration = generation
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %s observed generation: %w", strings.ToLower(request.Kind()), err)
+	}
+	return nil
+}
+
+// ResetAllocationRequestStatus clears the status of the allocation request, in the request and in its CR, so that it
+// is processed again as a new request
+func ResetAllocationRequestStatus(ctx context.Context, c client.Client, request AllocationRequest) error {
+	request.SetAllocationStatus(AllocationStatus{})
+	err := updateAllocationStatus(ctx, c, request, func(_ AllocationRequest, status *AllocationStatus) bool {
+		if equality.Semantic.DeepEqual(*status, AllocationStatus{}) {
+			return false
+		}
+		*status = AllocationStatus{}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset %s status: %w", strings.ToLower(request.Kind()), err)
+	}
+	return nil
+}
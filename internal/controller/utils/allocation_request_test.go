@@ -0,0 +1,144 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nodePoolClient serves and updates the status of a single NodePool
+type nodePoolClient struct {
+	client.Client
+	nodepool *hwmgmtv1alpha1.NodePool
+	updates  int
+}
+
+func (c *nodePoolClient) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	c.nodepool.DeepCopyInto(obj.(*hwmgmtv1alpha1.NodePool))
+	return nil
+}
+
+func (c *nodePoolClient) Status() client.SubResourceWriter {
+	return &nodePoolStatusWriter{client: c}
+}
+
+type nodePoolStatusWriter struct {
+	client.SubResourceWriter
+	client *nodePoolClient
+}
+
+func (w *nodePoolStatusWriter) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	w.client.updates++
+	w.client.nodepool = obj.(*hwmgmtv1alpha1.NodePool).DeepCopy()
+	return nil
+}
+
+// renamedConditionsRequest is a request whose API names the Provisioned condition Allocated, as a successor API may
+type renamedConditionsRequest struct {
+	AllocationRequest
+}
+
+func (r *renamedConditionsRequest) New() AllocationRequest {
+	return &renamedConditionsRequest{AllocationRequest: r.AllocationRequest.New()}
+}
+
+func (r *renamedConditionsRequest) TranslateCondition(conditionType, reason string) (string, string) {
+	if conditionType == string(hwmgmtv1alpha1.Provisioned) {
+		return "Allocated", reason
+	}
+	return conditionType, reason
+}
+
+func newTestNodePool() *hwmgmtv1alpha1.NodePool {
+	nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np-1", Namespace: "hwmgr", Generation: 3}}
+	nodepool.Spec.CloudID = "cluster-1"
+	nodepool.Spec.HwMgrId = "dell-1"
+	nodepool.Spec.Site = "site-a"
+	nodepool.Spec.NodeGroup = []hwmgmtv1alpha1.NodeGroup{
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller", Role: "master", HwProfile: "profile-1"}, Size: 3},
+	}
+	return nodepool
+}
+
+func TestNodePoolRequest(t *testing.T) {
+	nodepool := newTestNodePool()
+	request := NewNodePoolRequest(nodepool)
+
+	if request.GetClusterId() != "cluster-1" || request.GetHardwareManagerId() != "dell-1" || request.GetSite() != "site-a" {
+		t.Errorf("unexpected request identifiers: %s, %s, %s",
+			request.GetClusterId(), request.GetHardwareManagerId(), request.GetSite())
+	}
+	expected := []AllocationNodeGroup{{Name: "controller", Role: "master", HwProfile: "profile-1", Size: 3}}
+	if nodegroups := request.GetNodeGroups(); !reflect.DeepEqual(nodegroups, expected) {
+		t.Errorf("unexpected node groups: %+v", nodegroups)
+	}
+
+	request.SetAllocationStatus(AllocationStatus{
+		NodeNames:          []string{"node-1"},
+		SelectedGroups:     map[string]string{"controller": "pool-1"},
+		ObservedGeneration: 2,
+	})
+	if !reflect.DeepEqual(nodepool.Status.Properties.NodeNames, []string{"node-1"}) ||
+		nodepool.Status.SelectedPools["controller"] != "pool-1" || nodepool.Status.HwMgrPlugin.ObservedGeneration != 2 {
+		t.Errorf("expected the status to be set in the NodePool, got %+v", nodepool.Status)
+	}
+}
+
+func TestUpdateAllocationRequestStatus(t *testing.T) {
+	ctx := context.Background()
+	c := &nodePoolClient{nodepool: newTestNodePool()}
+
+	local := newTestNodePool()
+	local.Name = "np-status"
+	local.Status.Properties.NodeNames = []string{"node-1", "node-2"}
+	local.Status.SelectedPools = map[string]string{"controller": "pool-1"}
+	request := NewNodePoolRequest(local)
+
+	if err := UpdateAllocationRequestNodeNames(ctx, c, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := UpdateAllocationRequestSelectedGroups(ctx, c, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := UpdateAllocationRequestObservedGeneration(ctx, c, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := c.nodepool.Status
+	if !reflect.DeepEqual(status.Properties.NodeNames, []string{"node-1", "node-2"}) ||
+		status.SelectedPools["controller"] != "pool-1" || status.HwMgrPlugin.ObservedGeneration != 3 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// Unchanged values are not written again
+	updates := c.updates
+	if err := UpdateAllocationRequestObservedGeneration(ctx, c, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.updates != updates {
+		t.Errorf("expected no update for an unchanged generation")
+	}
+
+	// Conditions are translated to the API of the request, both locally and in the CR
+	renamed := &renamedConditionsRequest{AllocationRequest: request}
+	if err := UpdateAllocationRequestCondition(ctx, c, renamed, hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Completed,
+		metav1.ConditionTrue, "Created"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, conditions := range [][]metav1.Condition{local.Status.Conditions, c.nodepool.Status.Conditions} {
+		if meta.FindStatusCondition(conditions, "Allocated") == nil ||
+			meta.FindStatusCondition(conditions, string(hwmgmtv1alpha1.Provisioned)) != nil {
+			t.Errorf("expected the translated condition, got %+v", conditions)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"strings"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// The CPU architecture of the nodes can be requested by the NodePool extensions, either for all node groups or for a
+// specific group with "<group>.cpuArchitecture", which takes precedence. The architecture of each allocated node is
+// published by the CPUArchitectureLabel.
+const (
+	CPUArchitectureExtension = pluginv1alpha1.CPUArchitectureExtensionKey
+	CPUArchitectureLabel     = "hwmgr-plugin.oran.openshift.io/cpu-architecture"
+)
+
+// Supported CPU architectures, using the names reported by the hardware inspection
+const (
+	CPUArchitectureX86_64  = "x86_64"
+	CPUArchitectureAArch64 = "aarch64"
+)
+
+var cpuArchitectureAliases = map[string]string{
+	"x86_64":  CPUArchitectureX86_64,
+	"x86-64":  CPUArchitectureX86_64,
+	"amd64":   CPUArchitectureX86_64,
+	"aarch64": CPUArchitectureAArch64,
+	"arm64":   CPUArchitectureAArch64,
+	// Redfish instruction set names
+	"arm-a64": CPUArchitectureAArch64,
+}
+
+// NormalizeCPUArchitecture maps the common names of an architecture to a single name, returning an empty string if
+// the architecture is not recognized
+func NormalizeCPUArchitecture(arch string) string {
+	return cpuArchitectureAliases[strings.ToLower(strings.TrimSpace(arch))]
+}
+
+// GetRequestedCPUArchitecture returns the CPU architecture requested for the node group, or an empty string if any
+// architecture is acceptable
+func GetRequestedCPUArchitecture(nodepool *hwmgmtv1alpha1.NodePool, groupName string) (string, error) {
+	extensions, err := GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return "", err
+	}
+
+	value, key := extensions.GetCPUArchitecture(groupName)
+	if value == "" {
+		return "", nil
+	}
+
+	arch := NormalizeCPUArchitecture(value)
+	if arch == "" {
+		return "", typederrors.NewInputError("unsupported CPU architecture in %s extension: %s", key, value)
+	}
+	return arch, nil
+}
+
+// ValidateNodePoolExtensions checks that the extensions of the NodePool are well-formed, only reference its node
+// groups, and that the architectures requested for each node group are supported and the hostname template parses
+func ValidateNodePoolExtensions(nodepool *hwmgmtv1alpha1.NodePool) error {
+	extensions, err := GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return err
+	}
+
+	groups := make([]string, 0, len(nodepool.Spec.NodeGroup))
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		groups = append(groups, nodeGroup.NodePoolData.Name)
+	}
+	if err := extensions.Validate(groups); err != nil {
+		return typederrors.NewInputError("invalid nodepool extensions: %s", err.Error())
+	}
+	if extensions.HostnameTemplate != "" {
+		if err := ValidateHostnameTemplate(extensions.HostnameTemplate); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range groups {
+		if _, err := GetRequestedCPUArchitecture(nodepool, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestGetRequestedCPUArchitecture(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			Extensions: map[string]string{
+				CPUArchitectureExtension:             "amd64",
+				"worker." + CPUArchitectureExtension: "ARM64",
+			},
+		},
+	}
+
+	tests := []struct {
+		group    string
+		expected string
+	}{
+		{group: "controller", expected: CPUArchitectureX86_64},
+		{group: "worker", expected: CPUArchitectureAArch64},
+	}
+
+	for _, tt := range tests {
+		arch, err := GetRequestedCPUArchitecture(nodepool, tt.group)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.group, err)
+		}
+		if arch != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.group, tt.expected, arch)
+		}
+	}
+
+	nodepool.Spec.Extensions["worker."+CPUArchitectureExtension] = "sparc"
+	if _, err := GetRequestedCPUArchitecture(nodepool, "worker"); err == nil {
+		t.Error("expected error for unsupported architecture")
+	}
+
+	if arch, err := GetRequestedCPUArchitecture(&hwmgmtv1alpha1.NodePool{}, "worker"); err != nil || arch != "" {
+		t.Errorf("expected no architecture when not requested, got %q, %v", arch, err)
+	}
+}
@@ -0,0 +1,324 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+const (
+	// maxSignatureSize bounds the size of a detached signature downloaded from a signature URL
+	maxSignatureSize = 64 * 1024
+
+	// artifactDownloadTimeout bounds the download of an artifact to compute its digest
+	artifactDownloadTimeout = 30 * time.Minute
+)
+
+// artifactHTTPClient downloads the artifacts and signatures to verify, through the proxy set in the environment
+var artifactHTTPClient = &http.Client{Timeout: artifactDownloadTimeout}
+
+// TrustRoot is a public key trusted to sign artifacts
+type TrustRoot struct {
+	Fingerprint string
+	Key         crypto.PublicKey
+}
+
+// keyFingerprint returns the SHA-256 fingerprint of a DER encoded public key
+func keyFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return "SHA256:" + hex.EncodeToString(sum[:])
+}
+
+// ParseTrustRoots parses the PEM encoded ECDSA and RSA public keys of each entry of a trust roots config map
+func ParseTrustRoots(data map[string]string) ([]TrustRoot, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var roots []TrustRoot
+	for _, key := range keys {
+		rest := []byte(data[key])
+		for {
+			var block *pem.Block
+			if block, rest = pem.Decode(rest); block == nil {
+				break
+			}
+			if block.Type != "PUBLIC KEY" {
+				continue
+			}
+			pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse public key in %s: %w", key, err)
+			}
+			switch pub.(type) {
+			case *ecdsa.PublicKey, *rsa.PublicKey:
+			default:
+				return nil, fmt.Errorf("unsupported public key type %T in %s", pub, key)
+			}
+			roots = append(roots, TrustRoot{Fingerprint: keyFingerprint(block.Bytes), Key: pub})
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no public keys found")
+	}
+	return roots, nil
+}
+
+// verifyDigestSignature checks the signature of a SHA-256 digest against each trust root, returning the fingerprint of
+// the key that verified it
+func verifyDigestSignature(roots []TrustRoot, digest, signature []byte) (string, bool) {
+	for _, root := range roots {
+		switch key := root.Key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest, signature) {
+				return root.Fingerprint, true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil ||
+				rsa.VerifyPSS(key, crypto.SHA256, digest, signature, nil) == nil {
+				return root.Fingerprint, true
+			}
+		}
+	}
+	return "", false
+}
+
+// download issues a GET for the URL, returning the response body to be closed by the caller
+func download(ctx context.Context, httpClient *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// artifactDigest downloads the artifact and returns its SHA-256 digest
+func artifactDigest(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	body, err := download(ctx, httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, body); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return hash.Sum(nil), nil
+}
+
+// artifactSignature downloads a detached signature, decoding it from base64 unless it is in binary form
+func artifactSignature(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	body, err := download(ctx, httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(body, maxSignatureSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw))); err == nil {
+		return decoded, nil
+	}
+	return raw, nil
+}
+
+// firmwareArtifacts returns the firmware of the profile spec to verify, by component
+func firmwareArtifacts(spec pluginv1alpha1.HardwareProfileSpec) map[string]pluginv1alpha1.Firmware {
+	artifacts := make(map[string]pluginv1alpha1.Firmware)
+	if spec.BiosFirmware.URL != "" {
+		artifacts["bios"] = spec.BiosFirmware
+	}
+	if spec.BmcFirmware.URL != "" {
+		artifacts["bmc"] = spec.BmcFirmware
+	}
+	return artifacts
+}
+
+// verifyArtifact verifies the signature of a firmware artifact. The artifact is downloaded and verified again on every
+// call, rather than reusing an earlier verification of the same URL, as the BMC downloads the artifact from its URL
+// when applying it: an artifact replaced at the same URL after an earlier verification must not be applied unchecked.
+// Failures to download the artifact or signature are returned as errors, while an artifact that fails verification is
+// reported in the returned result.
+func verifyArtifact(ctx context.Context, httpClient *http.Client, policy *pluginv1alpha1.ArtifactVerificationPolicy,
+	roots []TrustRoot, hwMgrId, component string, firmware pluginv1alpha1.Firmware) (*pluginv1alpha1.ArtifactVerificationResult, error) {
+	result := &pluginv1alpha1.ArtifactVerificationResult{
+		HwMgrId:      hwMgrId,
+		Component:    component,
+		URL:          firmware.URL,
+		SignatureURL: firmware.SignatureURL,
+		Time:         metav1.Now(),
+	}
+
+	if firmware.SignatureURL == "" {
+		if !policy.RequireSignatures {
+			return nil, nil
+		}
+		result.Message = "the artifact is not signed, and signatures are required"
+		return result, nil
+	}
+
+	signature, err := artifactSignature(ctx, httpClient, firmware.SignatureURL)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := artifactDigest(ctx, httpClient, firmware.URL)
+	if err != nil {
+		return nil, err
+	}
+	result.Digest = "sha256:" + hex.EncodeToString(digest)
+
+	fingerprint, verified := verifyDigestSignature(roots, digest, signature)
+	if !verified {
+		result.Message = "the signature does not match any trust root"
+		return result, nil
+	}
+	result.Verified = true
+	result.KeyFingerprint = fingerprint
+	return result, nil
+}
+
+// VerifyFirmwareArtifacts verifies the signatures of the firmware artifacts of the effective spec of a hardware
+// profile against the trust roots of the hardware manager, if it enables artifact verification. It is called right
+// before the firmware is handed to the BMC, for every update of a server. The outcome is recorded
+// in the status of the profile, and an artifact that is unsigned while signatures are required, or whose signature
+// cannot be verified, is reported as an input error.
+func VerifyFirmwareArtifacts(ctx context.Context, c client.Client, hwmgr *pluginv1alpha1.HardwareManager,
+	profileName string, spec pluginv1alpha1.HardwareProfileSpec) error {
+	return verifyFirmwareArtifacts(ctx, c, artifactHTTPClient, hwmgr, profileName, spec)
+}
+
+func verifyFirmwareArtifacts(ctx context.Context, c client.Client, httpClient *http.Client,
+	hwmgr *pluginv1alpha1.HardwareManager, profileName string, spec pluginv1alpha1.HardwareProfileSpec) error {
+	policy := hwmgr.Spec.ArtifactVerification
+	artifacts := firmwareArtifacts(spec)
+	if policy == nil || len(artifacts) == 0 {
+		return nil
+	}
+
+	cm, err := GetConfigmap(ctx, c, policy.TrustRoots, hwmgr.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get trust roots configmap %s: %w", policy.TrustRoots, err)
+	}
+	roots, err := ParseTrustRoots(cm.Data)
+	if err != nil {
+		return typederrors.NewInputError("invalid trust roots in configmap %s: %s", policy.TrustRoots, err.Error())
+	}
+
+	profile := &pluginv1alpha1.HardwareProfile{}
+	if err := c.Get(ctx, client.ObjectKey{Name: profileName, Namespace: hwmgr.Namespace}, profile); err != nil {
+		return fmt.Errorf("failed to get HardwareProfile %s: %w", profileName, err)
+	}
+
+	components := make([]string, 0, len(artifacts))
+	for component := range artifacts {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	var results []pluginv1alpha1.ArtifactVerificationResult
+	var refused []string
+	for _, component := range components {
+		result, err := verifyArtifact(ctx, httpClient, policy, roots, hwmgr.Name, component, artifacts[component])
+		if err != nil {
+			return fmt.Errorf("failed to verify %s firmware of HardwareProfile %s: %w", component, profileName, err)
+		}
+		if result == nil {
+			continue
+		}
+		results = append(results, *result)
+		if !result.Verified {
+			refused = append(refused, fmt.Sprintf("%s firmware %s: %s", component, result.URL, result.Message))
+		}
+	}
+
+	if err := recordArtifactVerifications(ctx, c, profile, hwmgr.Name, results); err != nil {
+		return err
+	}
+	if len(refused) > 0 {
+		return typederrors.NewInputError("firmware artifact verification failed: %s", strings.Join(refused, "; "))
+	}
+	return nil
+}
+
+// recordArtifactVerifications replaces the verification results of the hardware manager in the status of the profile
+func recordArtifactVerifications(ctx context.Context, c client.Client, profile *pluginv1alpha1.HardwareProfile,
+	hwMgrId string, results []pluginv1alpha1.ArtifactVerificationResult) error {
+	// nolint: wrapcheck
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := &pluginv1alpha1.HardwareProfile{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(profile), current); err != nil {
+			return err
+		}
+
+		updated := make([]pluginv1alpha1.ArtifactVerificationResult, 0, len(current.Status.ArtifactVerifications)+len(results))
+		for _, result := range current.Status.ArtifactVerifications {
+			if result.HwMgrId != hwMgrId {
+				updated = append(updated, result)
+			}
+		}
+		updated = append(updated, results...)
+		if artifactVerificationsEqual(current.Status.ArtifactVerifications, updated) {
+			return nil
+		}
+
+		current.Status.ArtifactVerifications = updated
+		return c.Status().Update(ctx, current)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record artifact verifications of HardwareProfile %s: %w", profile.Name, err)
+	}
+	return nil
+}
+
+// artifactVerificationsEqual compares two lists of results, ignoring the verification times
+func artifactVerificationsEqual(a, b []pluginv1alpha1.ArtifactVerificationResult) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		x, y := a[i], b[i]
+		x.Time, y.Time = metav1.Time{}, metav1.Time{}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func testSigningKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func signArtifact(t *testing.T, key *ecdsa.PrivateKey, artifact []byte) string {
+	t.Helper()
+	digest := sha256.Sum256(artifact)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign artifact: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestParseTrustRoots(t *testing.T) {
+	_, first := testSigningKey(t)
+	_, second := testSigningKey(t)
+
+	roots, err := ParseTrustRoots(map[string]string{"vendor.pem": first + second, "README": "not a key"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(roots) != 2 || roots[0].Fingerprint == roots[1].Fingerprint {
+		t.Errorf("expected 2 distinct trust roots, got %+v", roots)
+	}
+
+	if _, err := ParseTrustRoots(map[string]string{"README": "not a key"}); err == nil {
+		t.Errorf("expected an error without public keys")
+	}
+}
+
+func TestVerifyArtifact(t *testing.T) {
+	key, publicKey := testSigningKey(t)
+	otherKey, _ := testSigningKey(t)
+	roots, err := ParseTrustRoots(map[string]string{"vendor.pem": publicKey})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	artifact := []byte("firmware image")
+	files := map[string]string{
+		"/bios.bin":        string(artifact),
+		"/bios.bin.sig":    signArtifact(t, key, artifact),
+		"/bios.bin.forged": signArtifact(t, otherKey, artifact),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, exists := files[r.URL.Path]
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	optional := &pluginv1alpha1.ArtifactVerificationPolicy{TrustRoots: "roots"}
+	required := &pluginv1alpha1.ArtifactVerificationPolicy{TrustRoots: "roots", RequireSignatures: true}
+	firmware := func(signature string) pluginv1alpha1.Firmware {
+		fw := pluginv1alpha1.Firmware{Version: "2.1", URL: server.URL + "/bios.bin"}
+		if signature != "" {
+			fw.SignatureURL = server.URL + signature
+		}
+		return fw
+	}
+	ctx := context.Background()
+
+	result, err := verifyArtifact(ctx, server.Client(), optional, roots, "hwmgr-1", "bios", firmware("/bios.bin.sig"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Verified || result.KeyFingerprint != roots[0].Fingerprint || result.Digest == "" {
+		t.Errorf("expected a verified artifact, got %+v", result)
+	}
+
+	// An artifact replaced at the same URL after its verification is verified again, rather than applied unchecked
+	files["/bios.bin"] = "replaced firmware image"
+	result, err = verifyArtifact(ctx, server.Client(), optional, roots, "hwmgr-1", "bios", firmware("/bios.bin.sig"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Verified {
+		t.Errorf("expected a replaced artifact to be refused, got %+v", result)
+	}
+	files["/bios.bin"] = string(artifact)
+
+	result, err = verifyArtifact(ctx, server.Client(), optional, roots, "hwmgr-1", "bios", firmware("/bios.bin.forged"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Verified || result.Message == "" {
+		t.Errorf("expected a forged signature to be refused, got %+v", result)
+	}
+
+	result, err = verifyArtifact(ctx, server.Client(), optional, roots, "hwmgr-1", "bios", firmware(""))
+	if err != nil || result != nil {
+		t.Errorf("expected an unsigned artifact to be skipped when signatures are optional, got %+v, %v", result, err)
+	}
+
+	result, err = verifyArtifact(ctx, server.Client(), required, roots, "hwmgr-1", "bios", firmware(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Verified {
+		t.Errorf("expected an unsigned artifact to be refused when signatures are required, got %+v", result)
+	}
+
+	if _, err := verifyArtifact(ctx, server.Client(), optional, roots, "hwmgr-1", "bios", firmware("/missing.sig")); err == nil {
+		t.Errorf("expected an error for a missing signature")
+	}
+}
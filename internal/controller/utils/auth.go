@@ -34,6 +34,10 @@ type OAuthClientConfig struct {
 	// Defines a PEM encoded set of CA certificates used to validate server certificates.  If not provided then the
 	// default root CA bundle will be used.
 	CaBundle []byte
+	// Defines the client certificate presented to remote servers, if any
+	ClientCert *tls.Certificate
+	// Selects the proxy for each request. If not provided then the proxy is taken from the environment.
+	Proxy func(*http.Request) (*url.URL, error)
 	// Defines the OAuth client-id attribute to be used when acquiring a token.  If not provided (for debug/testing)
 	// then a normal HTTP client without OAuth capabilities will be created
 	ClientId     string
@@ -168,11 +172,15 @@ func GetTransportWithCaBundle(config OAuthClientConfig, insecureSkipTLSVerify, l
 		}
 	}
 
+	if config.ClientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*config.ClientCert}
+	}
+
 	if logMessages {
-		return LoggingRoundTripper{TLSClientConfig: tlsConfig}, nil
+		return LoggingRoundTripper{TLSClientConfig: tlsConfig, Proxy: config.Proxy}, nil
 	}
 
-	return net.SetTransportDefaults(&http.Transport{TLSClientConfig: tlsConfig}), nil
+	return net.SetTransportDefaults(&http.Transport{TLSClientConfig: tlsConfig, Proxy: config.Proxy}), nil
 }
 
 // TODO: Determine whether to remove the message tracing altogether.
@@ -180,6 +188,7 @@ func GetTransportWithCaBundle(config OAuthClientConfig, insecureSkipTLSVerify, l
 // setting the loglevel of the utilsLog logger, so this needs some work here.
 type LoggingRoundTripper struct {
 	TLSClientConfig *tls.Config
+	Proxy           func(*http.Request) (*url.URL, error)
 }
 
 func redactObject(object interface{}) interface{} {
@@ -240,8 +249,13 @@ func (t LoggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 	}
 
 	// Do work before the request is sent
+	proxy := t.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
 	rt := http.Transport{
-		TLSClientConfig: t.TLSClientConfig}
+		TLSClientConfig: t.TLSClientConfig,
+		Proxy:           proxy}
 	resp, err := rt.RoundTrip(req)
 	if err != nil {
 		return resp, err // nolint: wrapcheck
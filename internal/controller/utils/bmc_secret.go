@@ -0,0 +1,183 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// BMC secret labels, identifying the NodePool and Node a secret was created for so that it can be cleaned up on release
+const (
+	BMCSecretNodePoolLabel = "hwmgr-plugin.oran.openshift.io/bmc-secret-nodepool"
+	BMCSecretNodeLabel     = "hwmgr-plugin.oran.openshift.io/bmc-secret-node"
+)
+
+// BMCSecretNamespaceAnnotation reports on a Node the namespace of the BMC secret named by the credentialsName of its BMC
+// status, which only holds the name of the secret
+const BMCSecretNamespaceAnnotation = "hwmgr-plugin.oran.openshift.io/bmc-secret-namespace"
+
+// DefaultBMCSecretNameTemplate is the name template of the BMC secrets when the hardware manager does not set one
+const DefaultBMCSecretNameTemplate = "{{.NodeName}}-bmc-secret"
+
+// BMCSecretTemplateData holds the variables available to a BMC secret name template
+type BMCSecretTemplateData struct {
+	// NodeName is the name of the Node CR
+	NodeName string
+	// NodePool is the name of the NodePool
+	NodePool string
+	// CloudID is the cloud ID of the NodePool
+	CloudID string
+}
+
+// renderBMCSecretName executes a BMC secret name template. The result must be a valid secret name.
+func renderBMCSecretName(text string, data BMCSecretTemplateData) (string, error) {
+	tmpl, err := template.New("bmcSecret").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", typederrors.NewInputError("invalid BMC secret name template %q: %s", text, err.Error())
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", typederrors.NewInputError("failed to render BMC secret name template %q: %s", text, err.Error())
+	}
+
+	name := strings.ToLower(strings.TrimSpace(out.String()))
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", typederrors.NewInputError("BMC secret name %q rendered from template %q is invalid: %s",
+			name, text, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// bmcSecretNamespace returns the namespace of the BMC secrets of a NodePool according to the policy
+func bmcSecretNamespace(policy *pluginv1alpha1.BMCSecretPolicy, nodepool *hwmgmtv1alpha1.NodePool, pluginNamespace string) (string, error) {
+	if policy == nil || policy.Namespace != pluginv1alpha1.BMCSecretNamespaces.Cluster {
+		return pluginNamespace, nil
+	}
+	if nodepool.Spec.CloudID == "" {
+		return "", typederrors.NewInputError("NodePool %s has no cloud ID to select the namespace of its BMC secrets", nodepool.Name)
+	}
+	return nodepool.Spec.CloudID, nil
+}
+
+// GetBMCSecretKey returns the namespace and name of the BMC secret of a node, according to the BMC secret policy of the
+// hardware manager
+func GetBMCSecretKey(hwmgr *pluginv1alpha1.HardwareManager, nodepool *hwmgmtv1alpha1.NodePool, nodename, pluginNamespace string) (types.NamespacedName, error) {
+	var policy *pluginv1alpha1.BMCSecretPolicy
+	if hwmgr != nil {
+		policy = hwmgr.Spec.BMCSecrets
+	}
+
+	namespace, err := bmcSecretNamespace(policy, nodepool, pluginNamespace)
+	if err != nil {
+		return types.NamespacedName{}, err
+	}
+
+	text := DefaultBMCSecretNameTemplate
+	if policy != nil && policy.NameTemplate != nil && *policy.NameTemplate != "" {
+		text = *policy.NameTemplate
+	}
+	name, err := renderBMCSecretName(text, BMCSecretTemplateData{
+		NodeName: nodename,
+		NodePool: nodepool.Name,
+		CloudID:  nodepool.Spec.CloudID,
+	})
+	if err != nil {
+		return types.NamespacedName{}, err
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// SetNodeBMCSecretNamespace reports the namespace of the BMC secret of a node on the Node
+func SetNodeBMCSecretNamespace(node *hwmgmtv1alpha1.Node, key types.NamespacedName) {
+	annotations := node.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[BMCSecretNamespaceAnnotation] = key.Namespace
+	node.SetAnnotations(annotations)
+}
+
+// GetNodeBMCSecretKey returns the namespace and name of the BMC secret reported by a node, so that the secret is found
+// after the BMC secret policy of the hardware manager changes. A node without the namespace annotation predates it, and
+// its secret is in the plugin namespace. It returns false if the node reports no BMC secret.
+func GetNodeBMCSecretKey(node *hwmgmtv1alpha1.Node, pluginNamespace string) (types.NamespacedName, bool) {
+	if node.Status.BMC == nil || node.Status.BMC.CredentialsName == "" {
+		return types.NamespacedName{}, false
+	}
+	namespace := node.GetAnnotations()[BMCSecretNamespaceAnnotation]
+	if namespace == "" {
+		namespace = pluginNamespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: node.Status.BMC.CredentialsName}, true
+}
+
+// NewBMCSecret returns the BMC secret of a node holding the credentials, labeled with its NodePool and Node. A secret in
+// the namespace of the NodePool is also owned by it, as owner references cannot cross namespaces.
+func NewBMCSecret(key types.NamespacedName, nodepool *hwmgmtv1alpha1.NodePool, nodename string, username, password []byte) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				BMCSecretNodePoolLabel: nodepool.Name,
+				BMCSecretNodeLabel:     nodename,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": username,
+			"password": password,
+		},
+	}
+
+	if key.Namespace == nodepool.Namespace {
+		blockDeletion := true
+		secret.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion:         nodepool.APIVersion,
+			Kind:               nodepool.Kind,
+			Name:               nodepool.Name,
+			UID:                nodepool.UID,
+			BlockOwnerDeletion: &blockDeletion,
+		}}
+	}
+	return secret
+}
+
+// DeleteBMCSecrets deletes the BMC secrets of the nodes of a NodePool. The secrets are deleted by their NodePool label
+// from the plugin namespace and the cluster namespace alike, regardless of the current BMC secret policy of the hardware
+// manager, so that secrets created before the policy changed are not left behind.
+func DeleteBMCSecrets(ctx context.Context, c client.Client, nodepool *hwmgmtv1alpha1.NodePool, pluginNamespace string) error {
+	namespaces := []string{pluginNamespace}
+	if nodepool.Spec.CloudID != "" && nodepool.Spec.CloudID != pluginNamespace {
+		namespaces = append(namespaces, nodepool.Spec.CloudID)
+	}
+
+	for _, namespace := range namespaces {
+		if err := c.DeleteAllOf(ctx, &corev1.Secret{},
+			client.InNamespace(namespace),
+			client.MatchingLabels{BMCSecretNodePoolLabel: nodepool.Name}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete bmc-secrets for nodepool %s in namespace %s: %w", nodepool.Name, namespace, err)
+		}
+	}
+	return nil
+}
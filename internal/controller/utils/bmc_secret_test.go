@@ -0,0 +1,151 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestGetBMCSecretKey(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "np1", Namespace: "hwmgr"},
+		Spec:       hwmgmtv1alpha1.NodePoolSpec{CloudID: "cluster-1"},
+	}
+	template := func(text string) *string { return &text }
+
+	testcases := []struct {
+		name      string
+		policy    *pluginv1alpha1.BMCSecretPolicy
+		noCloudID bool
+		expected  types.NamespacedName
+		invalid   bool
+	}{
+		{name: "default", expected: types.NamespacedName{Namespace: "hwmgr", Name: "node-abc-bmc-secret"}},
+		{
+			name:     "plugin namespace with template",
+			policy:   &pluginv1alpha1.BMCSecretPolicy{NameTemplate: template("{{.NodePool}}-{{.NodeName}}")},
+			expected: types.NamespacedName{Namespace: "hwmgr", Name: "np1-node-abc"},
+		},
+		{
+			name:     "cluster namespace",
+			policy:   &pluginv1alpha1.BMCSecretPolicy{Namespace: pluginv1alpha1.BMCSecretNamespaces.Cluster},
+			expected: types.NamespacedName{Namespace: "cluster-1", Name: "node-abc-bmc-secret"},
+		},
+		{
+			name: "cluster namespace with template",
+			policy: &pluginv1alpha1.BMCSecretPolicy{
+				Namespace:    pluginv1alpha1.BMCSecretNamespaces.Cluster,
+				NameTemplate: template("{{.CloudID}}-{{.NodeName}}-BMC"),
+			},
+			expected: types.NamespacedName{Namespace: "cluster-1", Name: "cluster-1-node-abc-bmc"},
+		},
+		{
+			name:      "cluster namespace without cloud ID",
+			policy:    &pluginv1alpha1.BMCSecretPolicy{Namespace: pluginv1alpha1.BMCSecretNamespaces.Cluster},
+			noCloudID: true,
+			invalid:   true,
+		},
+		{name: "unknown variable", policy: &pluginv1alpha1.BMCSecretPolicy{NameTemplate: template("{{.Site}}")}, invalid: true},
+		{name: "invalid name", policy: &pluginv1alpha1.BMCSecretPolicy{NameTemplate: template("{{.NodeName}}_bmc")}, invalid: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			np := nodepool.DeepCopy()
+			if tc.noCloudID {
+				np.Spec.CloudID = ""
+			}
+			hwmgr := &pluginv1alpha1.HardwareManager{Spec: pluginv1alpha1.HardwareManagerSpec{BMCSecrets: tc.policy}}
+
+			key, err := GetBMCSecretKey(hwmgr, np, "node-abc", "hwmgr")
+			if tc.invalid {
+				if !typederrors.IsInputError(err) {
+					t.Errorf("expected input error, got %s, %v", key, err)
+				}
+				return
+			}
+			if err != nil || key != tc.expected {
+				t.Errorf("expected %s, got %s, %v", tc.expected, key, err)
+			}
+		})
+	}
+}
+
+func TestNewBMCSecretOwnership(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np1", Namespace: "hwmgr", UID: "uid-1"}}
+
+	secret := NewBMCSecret(types.NamespacedName{Namespace: "hwmgr", Name: "s1"}, nodepool, "node-abc", []byte("u"), []byte("p"))
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != "uid-1" {
+		t.Errorf("expected a secret in the NodePool namespace to be owned by it, got %v", secret.OwnerReferences)
+	}
+	if secret.Labels[BMCSecretNodePoolLabel] != "np1" || secret.Labels[BMCSecretNodeLabel] != "node-abc" {
+		t.Errorf("unexpected labels %v", secret.Labels)
+	}
+
+	secret = NewBMCSecret(types.NamespacedName{Namespace: "cluster-1", Name: "s1"}, nodepool, "node-abc", []byte("u"), []byte("p"))
+	if len(secret.OwnerReferences) != 0 {
+		t.Errorf("expected no owner reference across namespaces, got %v", secret.OwnerReferences)
+	}
+}
+
+func TestGetNodeBMCSecretKey(t *testing.T) {
+	node := &hwmgmtv1alpha1.Node{}
+	if key, ok := GetNodeBMCSecretKey(node, "hwmgr"); ok {
+		t.Errorf("expected no key without a BMC status, got %s", key)
+	}
+
+	node.Status.BMC = &hwmgmtv1alpha1.BMC{CredentialsName: "node-abc-bmc-secret"}
+	if key, ok := GetNodeBMCSecretKey(node, "hwmgr"); !ok || key != (types.NamespacedName{Namespace: "hwmgr", Name: "node-abc-bmc-secret"}) {
+		t.Errorf("expected the plugin namespace for a node without the annotation, got %s", key)
+	}
+
+	SetNodeBMCSecretNamespace(node, types.NamespacedName{Namespace: "cluster-1", Name: "node-abc-bmc-secret"})
+	if key, ok := GetNodeBMCSecretKey(node, "hwmgr"); !ok || key != (types.NamespacedName{Namespace: "cluster-1", Name: "node-abc-bmc-secret"}) {
+		t.Errorf("expected the annotated namespace, got %s", key)
+	}
+}
+
+// deleteAllOfClient records the namespaces of DeleteAllOf requests
+type deleteAllOfClient struct {
+	client.Client
+	namespaces []string
+}
+
+func (c *deleteAllOfClient) DeleteAllOf(_ context.Context, _ client.Object, opts ...client.DeleteAllOfOption) error {
+	options := &client.DeleteAllOfOptions{}
+	options.ApplyOptions(opts)
+	c.namespaces = append(c.namespaces, options.Namespace)
+	return nil
+}
+
+func TestDeleteBMCSecrets(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np1", Namespace: "hwmgr"}}
+
+	// The secrets are deleted from both namespaces, whichever policy they were created under
+	nodepool.Spec.CloudID = "cluster-1"
+	c := &deleteAllOfClient{}
+	if err := DeleteBMCSecrets(context.Background(), c, nodepool, "hwmgr"); err != nil ||
+		!slices.Equal(c.namespaces, []string{"hwmgr", "cluster-1"}) {
+		t.Errorf("expected deletion in both namespaces, got %v, %v", c.namespaces, err)
+	}
+
+	nodepool.Spec.CloudID = ""
+	c = &deleteAllOfClient{}
+	if err := DeleteBMCSecrets(context.Background(), c, nodepool, "hwmgr"); err != nil || !slices.Equal(c.namespaces, []string{"hwmgr"}) {
+		t.Errorf("expected deletion in the plugin namespace only, got %v, %v", c.namespaces, err)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// Vendors of the servers, as matched against the manufacturer reported by the server
+const (
+	VendorDell       = "Dell"
+	VendorSupermicro = "Supermicro"
+)
+
+// bootOrderCapability describes how the boot order is set through the BIOS attributes of a vendor
+type bootOrderCapability struct {
+	// attributes are the BIOS attributes holding the boot devices, highest priority first
+	attributes []string
+	// devices are the supported classes of boot devices, in the order the devices that are not listed in a boot order
+	// follow the listed ones
+	devices []pluginv1alpha1.BootDevice
+	// values maps the supported classes of boot devices to their default value of the attributes
+	values map[pluginv1alpha1.BootDevice]string
+}
+
+// bootOrderCapabilities are the boot order capabilities of the vendors that support it, keyed by vendor
+var bootOrderCapabilities = map[string]bootOrderCapability{
+	VendorDell: {
+		attributes: []string{"SetBootOrderFqdd1", "SetBootOrderFqdd2", "SetBootOrderFqdd3", "SetBootOrderFqdd4"},
+		devices: []pluginv1alpha1.BootDevice{
+			pluginv1alpha1.BootDevicePxe, pluginv1alpha1.BootDeviceDisk, pluginv1alpha1.BootDeviceVirtualMedia},
+		values: map[pluginv1alpha1.BootDevice]string{
+			pluginv1alpha1.BootDevicePxe:          "NIC.PxeDevice.1-1",
+			pluginv1alpha1.BootDeviceDisk:         "RAID.Integrated.1-1",
+			pluginv1alpha1.BootDeviceVirtualMedia: "Optical.iDRACVirtual.1-1",
+		},
+	},
+	VendorSupermicro: {
+		attributes: []string{"BootOption#1", "BootOption#2", "BootOption#3"},
+		devices: []pluginv1alpha1.BootDevice{
+			pluginv1alpha1.BootDevicePxe, pluginv1alpha1.BootDeviceDisk, pluginv1alpha1.BootDeviceVirtualMedia},
+		values: map[pluginv1alpha1.BootDevice]string{
+			pluginv1alpha1.BootDevicePxe:          "UEFI Network",
+			pluginv1alpha1.BootDeviceDisk:         "UEFI Hard Disk",
+			pluginv1alpha1.BootDeviceVirtualMedia: "UEFI USB CD/DVD",
+		},
+	},
+}
+
+// deviceValue returns the value of the attributes for a boot device, the FQDD set in the boot order overriding the
+// default on Dell servers
+func (c bootOrderCapability) deviceValue(vendor string, bootOrder *pluginv1alpha1.BootOrder,
+	device pluginv1alpha1.BootDevice) string {
+	if fqdd := bootOrder.DellFqdds[device]; vendor == VendorDell && fqdd != "" {
+		return fqdd
+	}
+	return c.values[device]
+}
+
+// bootOrderVendor returns the vendor with boot order capabilities matching the manufacturer reported by a server, for
+// example Dell for "Dell Inc.", or an empty string if there is none
+func bootOrderVendor(manufacturer string) string {
+	for vendor := range bootOrderCapabilities {
+		if strings.Contains(strings.ToLower(manufacturer), strings.ToLower(vendor)) {
+			return vendor
+		}
+	}
+	return ""
+}
+
+// BootOrderBiosAttributes returns the BIOS attributes setting the boot order on a server of the given manufacturer.
+// The full order is written, the listed devices being followed by the other supported devices, and the attributes
+// beyond the supported devices being cleared, so that no value of a previous boot order remains. An input error is
+// returned if the vendor does not support setting the boot order, or one of its devices.
+func BootOrderBiosAttributes(manufacturer string, bootOrder *pluginv1alpha1.BootOrder) (map[string]intstr.IntOrString, error) {
+	if bootOrder == nil || len(bootOrder.Devices) == 0 {
+		return nil, nil
+	}
+
+	vendor := bootOrderVendor(manufacturer)
+	if vendor == "" {
+		return nil, typederrors.NewInputError("setting the boot order is not supported for servers of vendor %q", manufacturer)
+	}
+	capability := bootOrderCapabilities[vendor]
+	if len(bootOrder.DellFqdds) > 0 && vendor != VendorDell {
+		return nil, typederrors.NewInputError("boot device FQDDs are only supported for Dell servers, not %s", vendor)
+	}
+
+	order := make([]pluginv1alpha1.BootDevice, 0, len(capability.devices))
+	seen := make(map[pluginv1alpha1.BootDevice]bool, len(capability.devices))
+	for _, device := range bootOrder.Devices {
+		if seen[device] {
+			return nil, typederrors.NewInputError("boot device %s is listed more than once in the boot order", device)
+		}
+		if !slices.Contains(capability.devices, device) {
+			return nil, typederrors.NewInputError("boot device %s is not supported for servers of vendor %s", device, vendor)
+		}
+		seen[device] = true
+		order = append(order, device)
+	}
+	for _, device := range capability.devices {
+		if !seen[device] {
+			order = append(order, device)
+		}
+	}
+	if len(order) > len(capability.attributes) {
+		return nil, typederrors.NewInputError("boot order of %d devices exceeds the %d supported for servers of vendor %s",
+			len(order), len(capability.attributes), vendor)
+	}
+
+	attributes := make(map[string]intstr.IntOrString, len(capability.attributes))
+	for i, name := range capability.attributes {
+		value := ""
+		if i < len(order) {
+			value = capability.deviceValue(vendor, bootOrder, order[i])
+		}
+		attributes[name] = intstr.FromString(value)
+	}
+	return attributes, nil
+}
+
+// ProfileBiosAttributes returns the BIOS attributes applied by a hardware profile to a server of the given
+// manufacturer: the BIOS attributes of the profile, along with those setting its boot order. A boot order that is not
+// supported for the vendor, or that conflicts with the BIOS attributes of the profile, is reported as an input error.
+func ProfileBiosAttributes(spec pluginv1alpha1.HardwareProfileSpec, manufacturer string) (map[string]intstr.IntOrString, error) {
+	bootAttributes, err := BootOrderBiosAttributes(manufacturer, spec.BootOrder)
+	if err != nil {
+		return nil, err
+	}
+	if len(bootAttributes) == 0 {
+		return spec.Bios.Attributes, nil
+	}
+
+	attributes := make(map[string]intstr.IntOrString, len(spec.Bios.Attributes)+len(bootAttributes))
+	for name, value := range spec.Bios.Attributes {
+		attributes[name] = value
+	}
+	for name, value := range bootAttributes {
+		if existing, exists := attributes[name]; exists && existing.String() != value.String() {
+			return nil, typederrors.NewInputError("BIOS attribute %s of the profile conflicts with its boot order", name)
+		}
+		attributes[name] = value
+	}
+	return attributes, nil
+}
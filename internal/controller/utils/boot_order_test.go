@@ -0,0 +1,119 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestBootOrderBiosAttributes(t *testing.T) {
+	pxeFirst := &pluginv1alpha1.BootOrder{Devices: []pluginv1alpha1.BootDevice{
+		pluginv1alpha1.BootDevicePxe, pluginv1alpha1.BootDeviceDisk}}
+
+	testcases := []struct {
+		name         string
+		manufacturer string
+		bootOrder    *pluginv1alpha1.BootOrder
+		expected     map[string]intstr.IntOrString
+		inputError   bool
+	}{
+		{name: "no boot order", manufacturer: "HPE"},
+		{name: "dell", manufacturer: "Dell Inc.", bootOrder: pxeFirst,
+			expected: map[string]intstr.IntOrString{
+				"SetBootOrderFqdd1": intstr.FromString("NIC.PxeDevice.1-1"),
+				"SetBootOrderFqdd2": intstr.FromString("RAID.Integrated.1-1"),
+				"SetBootOrderFqdd3": intstr.FromString("Optical.iDRACVirtual.1-1"),
+				"SetBootOrderFqdd4": intstr.FromString(""),
+			}},
+		{name: "dell fqdds", manufacturer: "Dell Inc.", bootOrder: &pluginv1alpha1.BootOrder{
+			Devices:   []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceDisk},
+			DellFqdds: map[pluginv1alpha1.BootDevice]string{pluginv1alpha1.BootDeviceDisk: "AHCI.SL.6-1"}},
+			expected: map[string]intstr.IntOrString{
+				"SetBootOrderFqdd1": intstr.FromString("AHCI.SL.6-1"),
+				"SetBootOrderFqdd2": intstr.FromString("NIC.PxeDevice.1-1"),
+				"SetBootOrderFqdd3": intstr.FromString("Optical.iDRACVirtual.1-1"),
+				"SetBootOrderFqdd4": intstr.FromString(""),
+			}},
+		{name: "supermicro", manufacturer: "Supermicro", bootOrder: &pluginv1alpha1.BootOrder{
+			Devices: []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceVirtualMedia}},
+			expected: map[string]intstr.IntOrString{
+				"BootOption#1": intstr.FromString("UEFI USB CD/DVD"),
+				"BootOption#2": intstr.FromString("UEFI Network"),
+				"BootOption#3": intstr.FromString("UEFI Hard Disk"),
+			}},
+		{name: "fqdds on supermicro", manufacturer: "Supermicro", bootOrder: &pluginv1alpha1.BootOrder{
+			Devices:   []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceDisk},
+			DellFqdds: map[pluginv1alpha1.BootDevice]string{pluginv1alpha1.BootDeviceDisk: "AHCI.SL.6-1"}},
+			inputError: true},
+		{name: "unsupported vendor", manufacturer: "HPE", bootOrder: pxeFirst, inputError: true},
+		{name: "duplicate device", manufacturer: "Dell Inc.", bootOrder: &pluginv1alpha1.BootOrder{
+			Devices: []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceDisk, pluginv1alpha1.BootDeviceDisk}},
+			inputError: true},
+		{name: "unknown device", manufacturer: "Dell Inc.", bootOrder: &pluginv1alpha1.BootOrder{
+			Devices: []pluginv1alpha1.BootDevice{"Floppy"}}, inputError: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes, err := BootOrderBiosAttributes(tc.manufacturer, tc.bootOrder)
+			if tc.inputError {
+				if !typederrors.IsInputError(err) {
+					t.Errorf("expected an input error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(attributes) != len(tc.expected) || (len(tc.expected) > 0 && !reflect.DeepEqual(attributes, tc.expected)) {
+				t.Errorf("expected attributes %v, got %v", tc.expected, attributes)
+			}
+		})
+	}
+}
+
+func TestProfileBiosAttributes(t *testing.T) {
+	spec := pluginv1alpha1.HardwareProfileSpec{
+		Bios: pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{
+			"ProcCStates": intstr.FromString("Disabled"),
+		}},
+	}
+	attributes, err := ProfileBiosAttributes(spec, "HPE")
+	if err != nil || !reflect.DeepEqual(attributes, spec.Bios.Attributes) {
+		t.Errorf("expected the profile attributes without a boot order, got %v, %v", attributes, err)
+	}
+
+	spec.BootOrder = &pluginv1alpha1.BootOrder{Devices: []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceDisk}}
+	attributes, err = ProfileBiosAttributes(spec, "Dell Inc.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]intstr.IntOrString{
+		"ProcCStates":       intstr.FromString("Disabled"),
+		"SetBootOrderFqdd1": intstr.FromString("RAID.Integrated.1-1"),
+		"SetBootOrderFqdd2": intstr.FromString("NIC.PxeDevice.1-1"),
+		"SetBootOrderFqdd3": intstr.FromString("Optical.iDRACVirtual.1-1"),
+		"SetBootOrderFqdd4": intstr.FromString(""),
+	}
+	if !reflect.DeepEqual(attributes, expected) {
+		t.Errorf("expected attributes %v, got %v", expected, attributes)
+	}
+	if len(spec.Bios.Attributes) != 1 {
+		t.Errorf("profile attributes were modified")
+	}
+
+	spec.Bios.Attributes["SetBootOrderFqdd1"] = intstr.FromString("NIC.PxeDevice.1-1")
+	if _, err := ProfileBiosAttributes(spec, "Dell Inc."); !typederrors.IsInputError(err) {
+		t.Errorf("expected an input error for a conflicting attribute, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"k8s.io/utils/clock"
+)
+
+// Clock is the source of time of the timeouts, grace periods and polling of the adaptors. The adaptors run with the
+// real clock, while unit tests inject a fake clock, such as the one of k8s.io/utils/clock/testing, to simulate the
+// passage of time without waiting for it.
+type Clock = clock.WithTicker
+
+// ClockOrReal returns the injected clock, or the real clock if none is injected
+func ClockOrReal(c Clock) Clock {
+	if c == nil {
+		return clock.RealClock{}
+	}
+	return c
+}
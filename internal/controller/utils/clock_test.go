@@ -0,0 +1,29 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestClockOrReal(t *testing.T) {
+	if _, isReal := ClockOrReal(nil).(clock.RealClock); !isReal {
+		t.Errorf("expected the real clock when none is injected")
+	}
+
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	fake := clocktesting.NewFakeClock(start)
+	c := ClockOrReal(fake)
+	fake.Step(time.Hour)
+	if elapsed := c.Since(start); elapsed != time.Hour {
+		t.Errorf("expected the injected clock to be used, got %s elapsed", elapsed)
+	}
+}
@@ -11,16 +11,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// SetStatusCondition is a convenience wrapper for meta.SetStatusCondition that takes in the types defined here and converts them to strings
-func SetStatusCondition(existingConditions *[]metav1.Condition, conditionType, conditionReason string, conditionStatus metav1.ConditionStatus, message string) {
+// SetStatusCondition is a convenience wrapper for meta.SetStatusCondition that takes in the types defined here and converts them to strings.
+// It returns true if the content of the conditions changed, ignoring timestamps.
+func SetStatusCondition(existingConditions *[]metav1.Condition, conditionType, conditionReason string, conditionStatus metav1.ConditionStatus, message string) bool {
+	removed := false
 	conditions := *existingConditions
 	condition := meta.FindStatusCondition(*existingConditions, conditionType)
 	if condition != nil &&
 		condition.Status != conditionStatus &&
 		conditions[len(conditions)-1].Type != conditionType {
-		meta.RemoveStatusCondition(existingConditions, conditionType)
+		removed = meta.RemoveStatusCondition(existingConditions, conditionType)
 	}
-	meta.SetStatusCondition(
+	changed := meta.SetStatusCondition(
 		existingConditions,
 		metav1.Condition{
 			Type:               conditionType,
@@ -30,4 +32,5 @@ func SetStatusCondition(existingConditions *[]metav1.Condition, conditionType, c
 			LastTransitionTime: metav1.Now(),
 		},
 	)
+	return removed || changed
 }
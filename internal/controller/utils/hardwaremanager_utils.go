@@ -19,6 +19,8 @@ import (
 const (
 	LogMessagesAnnotation = "hwmgr-plugin.oran.openshift.io/logMessages"
 	LogMessagesEnabled    = "enabled"
+	// SelfTestAnnotation requests a self-test of the HardwareManager whenever its value changes, such as to a timestamp
+	SelfTestAnnotation = "hwmgr-plugin.oran.openshift.io/self-test"
 )
 
 func GetHardwareManagerValidationCondition(hwmgr *pluginv1alpha1.HardwareManager) *metav1.Condition {
@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// HostnameTemplateData holds the variables available to a hostname template
+type HostnameTemplateData struct {
+	// NodePool is the name of the NodePool
+	NodePool string
+	// NodeGroup is the name of the node group of the node
+	NodeGroup string
+	// NodeName is the name of the Node CR
+	NodeName string
+	// ResourceName is the name of the resource on the hardware manager
+	ResourceName string
+	// Site is the site of the resource
+	Site string
+	// ResourcePool is the resource pool of the resource
+	ResourcePool string
+	// Index is the position of the node in its node group, starting from 0
+	Index int
+}
+
+// parseHostnameTemplate parses a hostname template, failing on references to unknown variables when executed
+func parseHostnameTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("hostname").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, typederrors.NewInputError("invalid hostname template %q: %s", text, err.Error())
+	}
+	return tmpl, nil
+}
+
+// ValidateHostnameTemplate checks that a hostname template renders a valid hostname from the known variables, and that
+// it renders distinct hostnames for the nodes of a node group, so that an invalid template is rejected when it is
+// configured rather than when nodes are allocated
+func ValidateHostnameTemplate(text string) error {
+	if _, err := parseHostnameTemplate(text); err != nil {
+		return err
+	}
+
+	var hostnames []string
+	for index := range 2 {
+		hostname, err := RenderHostname(text, HostnameTemplateData{
+			NodePool:     "nodepool",
+			NodeGroup:    "nodegroup",
+			NodeName:     fmt.Sprintf("node-%d", index),
+			ResourceName: fmt.Sprintf("resource-%d", index),
+			Site:         "site",
+			ResourcePool: "pool",
+			Index:        index,
+		})
+		if err != nil {
+			return err
+		}
+		hostnames = append(hostnames, hostname)
+	}
+	if hostnames[0] == hostnames[1] {
+		return typederrors.NewInputError("hostname template %q renders the same hostname for every node of a node group", text)
+	}
+	return nil
+}
+
+// GetHostnameTemplate returns the hostname template for the nodes of a NodePool: the hostnameTemplate extension of the
+// NodePool if set, or else the given default of the hardware manager
+func GetHostnameTemplate(nodepool *hwmgmtv1alpha1.NodePool, defaultTemplate *string) (string, error) {
+	extensions, err := GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return "", err
+	}
+	if extensions.HostnameTemplate != "" {
+		return extensions.HostnameTemplate, nil
+	}
+	if defaultTemplate != nil {
+		return *defaultTemplate, nil
+	}
+	return "", nil
+}
+
+// RenderHostname executes a hostname template, returning the resource name if the template is empty. The result must
+// be a valid DNS subdomain.
+func RenderHostname(text string, data HostnameTemplateData) (string, error) {
+	if text == "" {
+		return data.ResourceName, nil
+	}
+
+	tmpl, err := parseHostnameTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", typederrors.NewInputError("failed to render hostname template %q: %s", text, err.Error())
+	}
+
+	hostname := strings.ToLower(strings.TrimSpace(out.String()))
+	if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+		return "", typederrors.NewInputError("hostname %q rendered from template %q is invalid: %s",
+			hostname, text, strings.Join(errs, ", "))
+	}
+	return hostname, nil
+}
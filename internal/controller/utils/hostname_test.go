@@ -0,0 +1,97 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestRenderHostname(t *testing.T) {
+	data := HostnameTemplateData{
+		NodePool:     "np1",
+		NodeGroup:    "controller",
+		NodeName:     "node-abc",
+		ResourceName: "R740-1",
+		Site:         "Site-A",
+		ResourcePool: "pool-1",
+		Index:        2,
+	}
+
+	testcases := []struct {
+		name     string
+		template string
+		hostname string
+		invalid  bool
+	}{
+		{name: "default to resource name", template: "", hostname: "R740-1"},
+		{name: "site, group and index", template: "{{.Site}}-{{.NodeGroup}}-{{.Index}}", hostname: "site-a-controller-2"},
+		{name: "domain", template: "{{.NodeGroup}}{{.Index}}.{{.ResourcePool}}.example.com", hostname: "controller2.pool-1.example.com"},
+		{name: "parse error", template: "{{.Site", invalid: true},
+		{name: "unknown variable", template: "{{.Rack}}", invalid: true},
+		{name: "invalid hostname", template: "{{.NodeGroup}}_{{.Index}}", invalid: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			hostname, err := RenderHostname(tc.template, data)
+			if tc.invalid {
+				if !typederrors.IsInputError(err) {
+					t.Errorf("expected input error, got %q, %v", hostname, err)
+				}
+				return
+			}
+			if err != nil || hostname != tc.hostname {
+				t.Errorf("expected %q, got %q, %v", tc.hostname, hostname, err)
+			}
+		})
+	}
+}
+
+func TestGetHostnameTemplate(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	defaultTemplate := "{{.Site}}-{{.Index}}"
+
+	if text, err := GetHostnameTemplate(nodepool, nil); err != nil || text != "" {
+		t.Errorf("expected no template, got %q, %v", text, err)
+	}
+	if text, err := GetHostnameTemplate(nodepool, &defaultTemplate); err != nil || text != defaultTemplate {
+		t.Errorf("expected the hardware manager template, got %q, %v", text, err)
+	}
+
+	nodepool.Spec.Extensions = map[string]string{pluginv1alpha1.HostnameTemplateExtensionKey: "{{.NodeName}}"}
+	if text, err := GetHostnameTemplate(nodepool, &defaultTemplate); err != nil || text != "{{.NodeName}}" {
+		t.Errorf("expected the NodePool template, got %q, %v", text, err)
+	}
+}
+
+func TestValidateHostnameTemplate(t *testing.T) {
+	testcases := []struct {
+		template string
+		valid    bool
+	}{
+		{template: "{{.NodeGroup}}-{{.Index}}", valid: true},
+		{template: "{{.ResourceName}}.example.com", valid: true},
+		{template: "{{.Site", valid: false},
+		{template: "{{.Rack}}-{{.Index}}", valid: false},
+		{template: "{{.NodeGroup}}_{{.Index}}", valid: false},
+		{template: "{{.Site}}-{{.NodeGroup}}", valid: false},
+	}
+
+	for _, tc := range testcases {
+		err := ValidateHostnameTemplate(tc.template)
+		if tc.valid && err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.template, err)
+		}
+		if !tc.valid && !typederrors.IsInputError(err) {
+			t.Errorf("%q: expected an input error, got %v", tc.template, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// MaxHardwareProfileDepth is the number of profiles a chain of base profiles may hold, including the profile itself
+const MaxHardwareProfileDepth = 8
+
+// MergeHardwareProfileSpec layers a profile spec over the spec of its base profile. The BIOS attributes of the
+// override are merged over the base attributes, and each firmware, the RAID configuration and the boot order of the
+// override replace those of the base when set.
+func MergeHardwareProfileSpec(base, override pluginv1alpha1.HardwareProfileSpec) pluginv1alpha1.HardwareProfileSpec {
+	merged := *base.DeepCopy()
+	merged.BaseProfile = ""
+
+	if len(override.Bios.Attributes) > 0 {
+		if merged.Bios.Attributes == nil {
+			merged.Bios.Attributes = make(map[string]intstr.IntOrString, len(override.Bios.Attributes))
+		}
+		for key, value := range override.Bios.Attributes {
+			merged.Bios.Attributes[key] = value
+		}
+	}
+	if !override.BiosFirmware.IsEmpty() {
+		merged.BiosFirmware = override.BiosFirmware
+	}
+	if !override.BmcFirmware.IsEmpty() {
+		merged.BmcFirmware = override.BmcFirmware
+	}
+	if override.RAID != nil {
+		merged.RAID = override.RAID.DeepCopy()
+	}
+	if override.BootOrder != nil {
+		merged.BootOrder = override.BootOrder.DeepCopy()
+	}
+	return merged
+}
+
+// ResolveHardwareProfile fetches the named HardwareProfile and resolves its effective spec from its chain of base
+// profiles. The returned profile holds the effective spec, along with the names of the profiles in the chain, starting
+// with the named profile. A missing base profile, a cyclic chain or a chain longer than MaxHardwareProfileDepth is
+// reported as an input error.
+func ResolveHardwareProfile(ctx context.Context, c client.Reader, name, namespace string) (
+	*pluginv1alpha1.HardwareProfile, []string, error) {
+	var profiles []*pluginv1alpha1.HardwareProfile
+	var chain []string
+	for current := name; current != ""; {
+		for _, seen := range chain {
+			if seen == current {
+				return nil, nil, typederrors.NewInputError("HardwareProfile %s has a cyclic base profile chain: %s",
+					name, strings.Join(append(chain, current), " -> "))
+			}
+		}
+		if len(chain) >= MaxHardwareProfileDepth {
+			return nil, nil, typederrors.NewInputError("HardwareProfile %s has more than %d profiles in its base profile chain",
+				name, MaxHardwareProfileDepth)
+		}
+
+		profile := &pluginv1alpha1.HardwareProfile{}
+		if err := c.Get(ctx, types.NamespacedName{Name: current, Namespace: namespace}, profile); err != nil {
+			if len(chain) > 0 && errors.IsNotFound(err) {
+				return nil, nil, typederrors.NewInputError("base profile %s of HardwareProfile %s not found",
+					current, chain[len(chain)-1])
+			}
+			return nil, nil, fmt.Errorf("failed to get HardwareProfile %s: %w", current, err)
+		}
+		profiles = append(profiles, profile)
+		chain = append(chain, current)
+		current = profile.Spec.BaseProfile
+	}
+
+	effective := MergeHardwareProfileSpec(profiles[len(profiles)-1].Spec, pluginv1alpha1.HardwareProfileSpec{})
+	for i := len(profiles) - 2; i >= 0; i-- {
+		effective = MergeHardwareProfileSpec(effective, profiles[i].Spec)
+	}
+
+	resolved := profiles[0].DeepCopy()
+	resolved.Spec = effective
+	return resolved, chain, nil
+}
+
+// BiosAttributeMatches checks whether the current value of a BIOS attribute matches the value in the profile. BIOS
+// attributes are reported as strings, numbers or booleans once decoded from JSON, while the profile holds strings or
+// integers.
+func BiosAttributeMatches(desired intstr.IntOrString, current any) bool {
+	switch value := current.(type) {
+	case string:
+		return value == desired.String()
+	case float64:
+		if desired.Type == intstr.Int {
+			return value == float64(desired.IntValue())
+		}
+		parsed, err := strconv.ParseFloat(desired.StrVal, 64)
+		return err == nil && parsed == value
+	case bool:
+		return desired.Type == intstr.String && strings.EqualFold(desired.StrVal, strconv.FormatBool(value))
+	}
+	return false
+}
+
+// BiosAttributeValue converts a profile value to the value written to the BIOS settings
+func BiosAttributeValue(value intstr.IntOrString, current any) any {
+	if value.Type == intstr.Int {
+		return value.IntValue()
+	}
+	if _, isBool := current.(bool); isBool {
+		if parsed, err := strconv.ParseBool(value.StrVal); err == nil {
+			return parsed
+		}
+	}
+	return value.StrVal
+}
+
+// PendingBiosAttributes returns the attributes of the profile that differ from the current BIOS attributes, with the
+// values to be written
+func PendingBiosAttributes(desired map[string]intstr.IntOrString, current map[string]any) map[string]any {
+	pending := make(map[string]any)
+	for name, value := range desired {
+		if currentValue, exists := current[name]; !exists || !BiosAttributeMatches(value, currentValue) {
+			pending[name] = BiosAttributeValue(value, current[name])
+		}
+	}
+	return pending
+}
+
+// BiosAttributeMismatches describes each attribute of the profile that differs from the current BIOS attributes,
+// sorted by attribute name
+func BiosAttributeMismatches(desired map[string]intstr.IntOrString, current map[string]any) []string {
+	names := make([]string, 0, len(desired))
+	for name, value := range desired {
+		if currentValue, exists := current[name]; !exists || !BiosAttributeMatches(value, currentValue) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	mismatches := make([]string, 0, len(names))
+	for _, name := range names {
+		currentValue, exists := current[name]
+		if !exists {
+			mismatches = append(mismatches, fmt.Sprintf("BIOS attribute %s is not reported", name))
+			continue
+		}
+		expected := desired[name]
+		mismatches = append(mismatches, fmt.Sprintf("BIOS attribute %s is %v, expected %s", name, currentValue, expected.String()))
+	}
+	return mismatches
+}
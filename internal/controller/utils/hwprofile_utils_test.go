@@ -0,0 +1,188 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// profileReader serves HardwareProfiles from a map, keyed by name
+type profileReader map[string]pluginv1alpha1.HardwareProfileSpec
+
+func (r profileReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	spec, exists := r[key.Name]
+	if !exists {
+		return errors.NewNotFound(schema.GroupResource{Resource: "hardwareprofiles"}, key.Name)
+	}
+	profile := obj.(*pluginv1alpha1.HardwareProfile)
+	profile.Name = key.Name
+	profile.Namespace = key.Namespace
+	profile.Spec = *spec.DeepCopy()
+	return nil
+}
+
+func (r profileReader) List(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+	return nil
+}
+
+func TestResolveHardwareProfile(t *testing.T) {
+	reader := profileReader{
+		"baseline": {
+			Bios: pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{
+				"SriovGlobalEnable": intstr.FromString("Enabled"),
+				"ProcCStates":       intstr.FromString("Enabled"),
+			}},
+			BiosFirmware: pluginv1alpha1.Firmware{Version: "2.1", URL: "http://fw/bios-2.1"},
+			BmcFirmware:  pluginv1alpha1.Firmware{Version: "7.0", URL: "http://fw/bmc-7.0"},
+			RAID:         &pluginv1alpha1.RAID{HardwareVolumes: []pluginv1alpha1.RAIDVolume{{Name: "root", Level: "1"}}},
+			BootOrder:    &pluginv1alpha1.BootOrder{Devices: []pluginv1alpha1.BootDevice{pluginv1alpha1.BootDeviceDisk}},
+		},
+		"du": {
+			BaseProfile: "baseline",
+			Bios: pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{
+				"ProcCStates": intstr.FromString("Disabled"),
+			}},
+			BootOrder: &pluginv1alpha1.BootOrder{Devices: []pluginv1alpha1.BootDevice{
+				pluginv1alpha1.BootDevicePxe, pluginv1alpha1.BootDeviceDisk}},
+		},
+		"du-new-bmc": {
+			BaseProfile: "du",
+			BmcFirmware: pluginv1alpha1.Firmware{Version: "7.1", URL: "http://fw/bmc-7.1"},
+		},
+		"orphan": {BaseProfile: "missing"},
+		"loop-a": {BaseProfile: "loop-b"},
+		"loop-b": {BaseProfile: "loop-a"},
+	}
+
+	resolved, chain, err := ResolveHardwareProfile(context.Background(), reader, "du-new-bmc", "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(chain, []string{"du-new-bmc", "du", "baseline"}) {
+		t.Errorf("unexpected profile chain: %v", chain)
+	}
+	expected := pluginv1alpha1.HardwareProfileSpec{
+		Bios: pluginv1alpha1.Bios{Attributes: map[string]intstr.IntOrString{
+			"SriovGlobalEnable": intstr.FromString("Enabled"),
+			"ProcCStates":       intstr.FromString("Disabled"),
+		}},
+		BiosFirmware: pluginv1alpha1.Firmware{Version: "2.1", URL: "http://fw/bios-2.1"},
+		BmcFirmware:  pluginv1alpha1.Firmware{Version: "7.1", URL: "http://fw/bmc-7.1"},
+		RAID:         &pluginv1alpha1.RAID{HardwareVolumes: []pluginv1alpha1.RAIDVolume{{Name: "root", Level: "1"}}},
+		BootOrder: &pluginv1alpha1.BootOrder{Devices: []pluginv1alpha1.BootDevice{
+			pluginv1alpha1.BootDevicePxe, pluginv1alpha1.BootDeviceDisk}},
+	}
+	if resolved.Name != "du-new-bmc" || !reflect.DeepEqual(resolved.Spec, expected) {
+		t.Errorf("unexpected effective profile %s: %+v", resolved.Name, resolved.Spec)
+	}
+	if reader["baseline"].Bios.Attributes["ProcCStates"] != intstr.FromString("Enabled") {
+		t.Errorf("base profile was modified by resolution")
+	}
+
+	for _, name := range []string{"orphan", "loop-a"} {
+		if _, _, err := ResolveHardwareProfile(context.Background(), reader, name, "ns"); !typederrors.IsInputError(err) {
+			t.Errorf("%s: expected input error, got %v", name, err)
+		}
+	}
+	if _, _, err := ResolveHardwareProfile(context.Background(), reader, "missing", "ns"); !errors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestBiosAttributeMatches(t *testing.T) {
+	testcases := []struct {
+		name    string
+		desired intstr.IntOrString
+		current any
+		matches bool
+	}{
+		{name: "string", desired: intstr.FromString("Enabled"), current: "Enabled", matches: true},
+		{name: "different string", desired: intstr.FromString("Enabled"), current: "Disabled"},
+		{name: "integer", desired: intstr.FromInt32(3), current: float64(3), matches: true},
+		{name: "different integer", desired: intstr.FromInt32(3), current: float64(4)},
+		{name: "integer as string", desired: intstr.FromInt32(3), current: "3", matches: true},
+		{name: "number as string", desired: intstr.FromString("3"), current: float64(3), matches: true},
+		{name: "boolean", desired: intstr.FromString("true"), current: true, matches: true},
+		{name: "different boolean", desired: intstr.FromString("false"), current: true},
+		{name: "missing", desired: intstr.FromString("Enabled"), current: nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := BiosAttributeMatches(tc.desired, tc.current); matches != tc.matches {
+				t.Errorf("expected match=%v, got %v", tc.matches, matches)
+			}
+		})
+	}
+}
+
+func TestPendingBiosAttributes(t *testing.T) {
+	desired := map[string]intstr.IntOrString{
+		"SMTControl":   intstr.FromString("Enabled"),
+		"BootTimeout":  intstr.FromInt32(5),
+		"SRIOVSupport": intstr.FromString("true"),
+		"SecureBoot":   intstr.FromString("Enabled"),
+	}
+	current := map[string]any{
+		"SMTControl":   "Enabled",
+		"BootTimeout":  float64(1),
+		"SRIOVSupport": false,
+	}
+
+	pending := PendingBiosAttributes(desired, current)
+	if len(pending) != 3 {
+		t.Fatalf("expected 3 pending attributes, got %v", pending)
+	}
+	if pending["BootTimeout"] != 5 {
+		t.Errorf("expected integer value, got %#v", pending["BootTimeout"])
+	}
+	if pending["SRIOVSupport"] != true {
+		t.Errorf("expected boolean value, got %#v", pending["SRIOVSupport"])
+	}
+	if pending["SecureBoot"] != "Enabled" {
+		t.Errorf("expected string value, got %#v", pending["SecureBoot"])
+	}
+}
+
+func TestBiosAttributeMismatches(t *testing.T) {
+	desired := map[string]intstr.IntOrString{
+		"WorkloadProfile":     intstr.FromString("TelcoOptimizedProfile"),
+		"BootMode":            intstr.FromString("Uefi"),
+		"AcPwrRcvryUserDelay": intstr.FromInt32(60),
+		"SriovGlobalEnable":   intstr.FromString("Enabled"),
+	}
+	current := map[string]any{
+		"WorkloadProfile":     "NotAvailable",
+		"BootMode":            "Uefi",
+		"AcPwrRcvryUserDelay": float64(60),
+	}
+
+	mismatches := BiosAttributeMismatches(desired, current)
+	expected := []string{
+		"BIOS attribute SriovGlobalEnable is not reported",
+		"BIOS attribute WorkloadProfile is NotAvailable, expected TelcoOptimizedProfile",
+	}
+	if !reflect.DeepEqual(mismatches, expected) {
+		t.Errorf("expected %v, got %v", expected, mismatches)
+	}
+
+	current["WorkloadProfile"] = "TelcoOptimizedProfile"
+	current["SriovGlobalEnable"] = "Enabled"
+	if mismatches := BiosAttributeMismatches(desired, current); len(mismatches) != 0 {
+		t.Errorf("expected no mismatches, got %v", mismatches)
+	}
+}
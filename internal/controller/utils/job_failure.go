@@ -0,0 +1,93 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// JobFailureAnnotation records the details of the last failed job of a NodePool, as JSON. The NodePool CRD is owned by
+// O2IMS, so the details are published as metadata rather than as status properties.
+const JobFailureAnnotation = "hwmgr-plugin.oran.openshift.io/job-failure"
+
+// JobFailure describes a job that failed on the hardware manager
+type JobFailure struct {
+	JobId     string `json:"jobId"`
+	Operation string `json:"operation,omitempty"`
+	Reason    string `json:"reason"`
+	// ResourceIds are the hardware manager resources the job failed on
+	ResourceIds []string `json:"resourceIds,omitempty"`
+}
+
+// String formats the failure for a condition message, as the reason followed by the resources it failed on
+func (f JobFailure) String() string {
+	if len(f.ResourceIds) == 0 {
+		return f.Reason
+	}
+	return fmt.Sprintf("%s (resourceIds=%s)", f.Reason, strings.Join(f.ResourceIds, ","))
+}
+
+// GetJobFailure returns the job failure recorded on the object, if any
+func GetJobFailure(object client.Object) (*JobFailure, bool) {
+	value, exists := object.GetAnnotations()[JobFailureAnnotation]
+	if !exists {
+		return nil, false
+	}
+	failure := &JobFailure{}
+	if err := json.Unmarshal([]byte(value), failure); err != nil {
+		return nil, false
+	}
+	return failure, true
+}
+
+// SetJobFailure records the job failure on the object
+func SetJobFailure(object client.Object, failure JobFailure) error {
+	value, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job failure: %w", err)
+	}
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[JobFailureAnnotation] = string(value)
+	object.SetAnnotations(annotations)
+	return nil
+}
+
+// ClearJobFailure removes the job failure recorded on the object
+func ClearJobFailure(object client.Object) {
+	annotations := object.GetAnnotations()
+	if annotations != nil {
+		delete(annotations, JobFailureAnnotation)
+	}
+}
+
+// UpdateNodePoolJobFailure publishes the job failure of the NodePool, or clears it if nil, if changed
+func UpdateNodePoolJobFailure(ctx context.Context, c client.Client, nodepool *hwmgmtv1alpha1.NodePool, failure *JobFailure) error {
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	current := nodepool.GetAnnotations()[JobFailureAnnotation]
+	if failure == nil {
+		ClearJobFailure(nodepool)
+	} else if err := SetJobFailure(nodepool, *failure); err != nil {
+		return err
+	}
+	if nodepool.GetAnnotations()[JobFailureAnnotation] == current {
+		return nil
+	}
+	if err := c.Patch(ctx, nodepool, patch); err != nil {
+		return fmt.Errorf("failed to patch job failure of nodepool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestJobFailure(t *testing.T) {
+	failure := JobFailure{JobId: "job-1", Reason: "hardware fault"}
+	if message := failure.String(); message != "hardware fault" {
+		t.Errorf("expected only the reason without details, got %q", message)
+	}
+
+	failure.ResourceIds = []string{"r1", "r2"}
+	expected := "hardware fault (resourceIds=r1,r2)"
+	if message := failure.String(); message != expected {
+		t.Errorf("expected %q, got %q", expected, message)
+	}
+
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	if _, exists := GetJobFailure(nodepool); exists {
+		t.Error("expected no job failure")
+	}
+	if err := SetJobFailure(nodepool, failure); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recorded, exists := GetJobFailure(nodepool)
+	if !exists || !reflect.DeepEqual(*recorded, failure) {
+		t.Errorf("expected %+v, got %+v", failure, recorded)
+	}
+	ClearJobFailure(nodepool)
+	if _, exists := GetJobFailure(nodepool); exists {
+		t.Error("expected the job failure to be cleared")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// REPatternUnseparatedMAC matches a MAC address given as 12 hex digits without separators
+var REPatternUnseparatedMAC = regexp.MustCompile(`^[0-9a-fA-F]{12}$`)
+
+// NormalizeMACAddress validates a 48-bit MAC address and returns it in lowercase, colon-separated form. Colon, hyphen
+// and dot separators are accepted, as well as 12 hex digits without separators.
+func NormalizeMACAddress(mac string) (string, error) {
+	value := strings.TrimSpace(mac)
+	if REPatternUnseparatedMAC.MatchString(value) {
+		parts := make([]string, 0, 6)
+		for i := 0; i < len(value); i += 2 {
+			parts = append(parts, value[i:i+2])
+		}
+		value = strings.Join(parts, ":")
+	}
+
+	hwAddr, err := net.ParseMAC(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid MAC address %q: %w", mac, err)
+	}
+	if len(hwAddr) != 6 {
+		return "", fmt.Errorf("invalid MAC address %q: expected a 48-bit address", mac)
+	}
+	return hwAddr.String(), nil
+}
+
+// NormalizeInterfaceMACs normalizes the MAC addresses of the interfaces in place, returning an error if any MAC
+// address is malformed or is used by more than one interface
+func NormalizeInterfaceMACs(interfaces []*hwmgmtv1alpha1.Interface) error {
+	seen := make(map[string]string)
+	for _, intf := range interfaces {
+		mac, err := NormalizeMACAddress(intf.MACAddress)
+		if err != nil {
+			return fmt.Errorf("interface %s: %w", intf.Name, err)
+		}
+		if other, exists := seen[mac]; exists {
+			return fmt.Errorf("duplicate MAC address %s on interfaces %s and %s", mac, other, intf.Name)
+		}
+		seen[mac] = intf.Name
+		intf.MACAddress = mac
+	}
+	return nil
+}
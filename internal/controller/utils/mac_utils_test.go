@@ -0,0 +1,70 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestNormalizeMACAddress(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  string
+		expectErr bool
+	}{
+		{input: "AA:BB:CC:DD:EE:FF", expected: "aa:bb:cc:dd:ee:ff"},
+		{input: "aa-bb-cc-dd-ee-ff", expected: "aa:bb:cc:dd:ee:ff"},
+		{input: "aabb.ccdd.eeff", expected: "aa:bb:cc:dd:ee:ff"},
+		{input: "AABBCCDDEEFF", expected: "aa:bb:cc:dd:ee:ff"},
+		{input: " aa:bb:cc:dd:ee:ff ", expected: "aa:bb:cc:dd:ee:ff"},
+		{input: "", expectErr: true},
+		{input: "aa:bb:cc:dd:ee", expectErr: true},
+		{input: "zz:bb:cc:dd:ee:ff", expectErr: true},
+		{input: "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := NormalizeMACAddress(tt.input)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeInterfaceMACs(t *testing.T) {
+	interfaces := []*hwmgmtv1alpha1.Interface{
+		{Name: "eno1", MACAddress: "AA-BB-CC-DD-EE-01"},
+		{Name: "eno2", MACAddress: "aa:bb:cc:dd:ee:02"},
+	}
+	if err := NormalizeInterfaceMACs(interfaces); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if interfaces[0].MACAddress != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("expected normalized MAC, got %q", interfaces[0].MACAddress)
+	}
+
+	duplicates := []*hwmgmtv1alpha1.Interface{
+		{Name: "eno1", MACAddress: "aa:bb:cc:dd:ee:01"},
+		{Name: "eno2", MACAddress: "AA:BB:CC:DD:EE:01"},
+	}
+	if err := NormalizeInterfaceMACs(duplicates); err == nil {
+		t.Error("expected error for duplicate MAC addresses")
+	}
+}
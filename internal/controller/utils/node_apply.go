@@ -0,0 +1,91 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// NodeFieldOwner is the field manager of the Node fields set on creation
+const NodeFieldOwner = "oran-hwmgr-plugin"
+
+// NewNode builds the Node CR for hardware allocated to a NodePool, owned by the NodePool
+func NewNode(nodepool *hwmgmtv1alpha1.NodePool, namespace, nodename string, labels map[string]string,
+	spec hwmgmtv1alpha1.NodeSpec) *hwmgmtv1alpha1.Node {
+	blockDeletion := true
+	return &hwmgmtv1alpha1.Node{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: hwmgmtv1alpha1.GroupVersion.String(),
+			Kind:       "Node",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodename,
+			Namespace: namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         hwmgmtv1alpha1.GroupVersion.String(),
+				Kind:               "NodePool",
+				Name:               nodepool.Name,
+				UID:                nodepool.UID,
+				BlockOwnerDeletion: &blockDeletion,
+			}},
+		},
+		Spec: spec,
+	}
+}
+
+// checkNodeAdoption checks whether an existing Node can be adopted as the desired one. A Node can only be adopted if
+// it represents the same hardware and is not owned by another NodePool.
+func checkNodeAdoption(existing, desired *hwmgmtv1alpha1.Node, nodepool *hwmgmtv1alpha1.NodePool) error {
+	if existing.DeletionTimestamp != nil {
+		return typederrors.NewConflictError(nil, "node %s is being deleted", existing.Name)
+	}
+	for _, owner := range existing.OwnerReferences {
+		if owner.Kind == "NodePool" && owner.UID != nodepool.UID {
+			return typederrors.NewConflictError(nil, "node %s is owned by another nodepool: %s", existing.Name, owner.Name)
+		}
+	}
+	if existing.Spec.HwMgrId != desired.Spec.HwMgrId ||
+		existing.Spec.HwMgrNodeId != desired.Spec.HwMgrNodeId ||
+		existing.Spec.HwMgrNodeNs != desired.Spec.HwMgrNodeNs {
+		return typederrors.NewConflictError(nil, "node %s is backed by different hardware: hwMgrId=%s, hwMgrNodeId=%s",
+			existing.Name, existing.Spec.HwMgrId, existing.Spec.HwMgrNodeId)
+	}
+	return nil
+}
+
+// ApplyNode creates a Node CR with server-side apply, so that re-running the creation after a partial failure
+// converges on the desired Node. An existing Node for the same hardware is adopted by the NodePool, keeping its
+// hardware profile, which is changed by the update flow rather than on creation. A ConflictError is returned if the
+// Node exists for different hardware or another NodePool.
+func ApplyNode(ctx context.Context, c client.Client, nodepool *hwmgmtv1alpha1.NodePool, node *hwmgmtv1alpha1.Node) error {
+	existing := &hwmgmtv1alpha1.Node{}
+	err := c.Get(ctx, types.NamespacedName{Name: node.Name, Namespace: node.Namespace}, existing)
+	switch {
+	case err == nil:
+		if err := checkNodeAdoption(existing, node, nodepool); err != nil {
+			return err
+		}
+		node.Spec.HwProfile = existing.Spec.HwProfile
+	case !errors.IsNotFound(err):
+		return fmt.Errorf("failed to check if node %s exists: %w", node.Name, err)
+	}
+
+	if err := c.Patch(ctx, node, client.Apply, client.FieldOwner(NodeFieldOwner), client.ForceOwnership); err != nil {
+		return fmt.Errorf("failed to apply node %s: %w", node.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+func TestCheckNodeAdoption(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np1", UID: "uid-1"}}
+	other := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np2", UID: "uid-2"}}
+	spec := hwmgmtv1alpha1.NodeSpec{HwMgrId: "hwmgr", HwMgrNodeId: "server-1", HwProfile: "profile-a"}
+	desired := NewNode(nodepool, "ns", "node-1", nil, spec)
+
+	orphan := desired.DeepCopy()
+	orphan.OwnerReferences = nil
+	orphan.Spec.HwProfile = "profile-b"
+
+	deleting := desired.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+
+	otherHardware := desired.DeepCopy()
+	otherHardware.Spec.HwMgrNodeId = "server-2"
+
+	tests := []struct {
+		description string
+		existing    *hwmgmtv1alpha1.Node
+		conflict    bool
+	}{
+		{description: "same node", existing: desired},
+		{description: "node without owner", existing: orphan},
+		{description: "node owned by another nodepool", existing: NewNode(other, "ns", "node-1", nil, spec), conflict: true},
+		{description: "node being deleted", existing: deleting, conflict: true},
+		{description: "node for other hardware", existing: otherHardware, conflict: true},
+	}
+	for _, test := range tests {
+		err := checkNodeAdoption(test.existing, desired, nodepool)
+		if test.conflict != typederrors.IsConflictError(err) {
+			t.Errorf("%s: expected conflict=%t, got %v", test.description, test.conflict, err)
+		}
+	}
+}
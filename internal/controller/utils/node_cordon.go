@@ -0,0 +1,72 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// CordonAnnotation cordons a Node, freezing its hardware configuration: the adaptors skip the profile, firmware and
+// BIOS updates of a cordoned node, while still reporting its status. The value is an optional reason, such as the
+// reference of an investigation. The Node CRD is owned by O2IMS, so the cordon is requested as metadata.
+const CordonAnnotation = "hwmgr-plugin.oran.openshift.io/cordon"
+
+// IsNodeCordoned checks whether the node is cordoned
+func IsNodeCordoned(node *hwmgmtv1alpha1.Node) bool {
+	_, exists := node.GetAnnotations()[CordonAnnotation]
+	return exists
+}
+
+// ErrNodeCordoned is returned by CheckNodeNotCordoned for a cordoned node
+var ErrNodeCordoned = errors.New("node is cordoned")
+
+// CheckNodeNotCordoned reads the node and returns ErrNodeCordoned if it is cordoned. Adaptors check it before writing
+// the hardware configuration of a node, so that a cordon set since the node was listed is honored.
+func CheckNodeNotCordoned(ctx context.Context, reader client.Reader, nodename, namespace string) error {
+	node := &hwmgmtv1alpha1.Node{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: nodename, Namespace: namespace}, node); err != nil {
+		return fmt.Errorf("failed to get node %s: %w", nodename, err)
+	}
+	if IsNodeCordoned(node) {
+		return fmt.Errorf("configuration of node %s skipped: %w", nodename, ErrNodeCordoned)
+	}
+	return nil
+}
+
+// FindCordonedNodesPendingUpdate returns the names of the cordoned nodes whose hardware profile differs from that of
+// their nodegroup, which are held back from the update of the NodePool until they are uncordoned
+func FindCordonedNodesPendingUpdate(nodepool *hwmgmtv1alpha1.NodePool, nodelist *hwmgmtv1alpha1.NodeList) []string {
+	hwProfiles := make(map[string]string, len(nodepool.Spec.NodeGroup))
+	for _, nodegroup := range nodepool.Spec.NodeGroup {
+		hwProfiles[nodegroup.NodePoolData.Name] = nodegroup.NodePoolData.HwProfile
+	}
+
+	var held []string
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		if !IsNodeCordoned(node) {
+			continue
+		}
+		if hwProfile, exists := hwProfiles[node.Spec.GroupName]; exists && hwProfile != node.Spec.HwProfile {
+			held = append(held, node.Name)
+		}
+	}
+	return held
+}
+
+// CordonedNodesMessage formats the condition message of a NodePool whose update is held back by cordoned nodes
+func CordonedNodesMessage(nodenames []string) string {
+	return fmt.Sprintf("Configuration update held for cordoned nodes: %s", strings.Join(nodenames, ", "))
+}
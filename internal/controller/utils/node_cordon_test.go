@@ -0,0 +1,85 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNodeCordon(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{}
+	nodepool.Spec.NodeGroup = []hwmgmtv1alpha1.NodeGroup{
+		{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller", HwProfile: "profile-v2"}, Size: 3},
+	}
+
+	nodelist := &hwmgmtv1alpha1.NodeList{}
+	for _, node := range []struct {
+		name, hwProfile string
+		cordoned        bool
+	}{
+		{"node-1", "profile-v1", true},
+		{"node-2", "profile-v1", false},
+		{"node-3", "profile-v2", true},
+	} {
+		item := hwmgmtv1alpha1.Node{}
+		item.Name = node.name
+		item.Spec.GroupName = "controller"
+		item.Spec.HwProfile = node.hwProfile
+		if node.cordoned {
+			item.SetAnnotations(map[string]string{CordonAnnotation: "INC-1234"})
+		}
+		nodelist.Items = append(nodelist.Items, item)
+	}
+
+	if node := FindNextNodeToUpdate(nodelist, "controller", "profile-v2"); node == nil || node.Name != "node-2" {
+		t.Errorf("expected the cordoned node to be skipped, got %v", node)
+	}
+
+	held := FindCordonedNodesPendingUpdate(nodepool, nodelist)
+	if !reflect.DeepEqual(held, []string{"node-1"}) {
+		t.Errorf("expected only the stale cordoned node to be held, got %v", held)
+	}
+	if message := CordonedNodesMessage(held); message != "Configuration update held for cordoned nodes: node-1" {
+		t.Errorf("unexpected message: %s", message)
+	}
+
+	nodelist.Items[1].Spec.HwProfile = "profile-v2"
+	if node := FindNextNodeToUpdate(nodelist, "controller", "profile-v2"); node != nil && node.Name == "node-1" {
+		t.Errorf("expected the cordoned node not to be updated, got %s", node.Name)
+	}
+}
+
+// nodeReader serves a single Node
+type nodeReader struct {
+	client.Reader
+	node *hwmgmtv1alpha1.Node
+}
+
+func (r *nodeReader) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	r.node.DeepCopyInto(obj.(*hwmgmtv1alpha1.Node))
+	return nil
+}
+
+func TestCheckNodeNotCordoned(t *testing.T) {
+	node := &hwmgmtv1alpha1.Node{}
+	node.Name = "node-1"
+	reader := &nodeReader{node: node}
+	if err := CheckNodeNotCordoned(context.Background(), reader, "node-1", "hwmgr"); err != nil {
+		t.Errorf("unexpected error for an uncordoned node: %v", err)
+	}
+
+	node.SetAnnotations(map[string]string{CordonAnnotation: "investigating BMC resets"})
+	if err := CheckNodeNotCordoned(context.Background(), reader, "node-1", "hwmgr"); !errors.Is(err, ErrNodeCordoned) {
+		t.Errorf("expected ErrNodeCordoned for a cordoned node, got %v", err)
+	}
+}
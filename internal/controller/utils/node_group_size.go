@@ -0,0 +1,113 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// The size of a node group can be adjusted by an autoscaler, within the minSize and maxSize bounds set in the NodePool
+// extensions, without changing the NodePool spec
+const (
+	// DesiredSizeAnnotation is set on a NodePool by an autoscaler to request the size of its node groups. The value is
+	// a JSON map of node group name to size, e.g. {"worker": 5}. Node groups not listed keep their spec size.
+	DesiredSizeAnnotation = "hwmgr-plugin.oran.openshift.io/desired-size"
+)
+
+// NodePoolConditionSized is the NodePool condition reporting the size in effect for each node group
+const NodePoolConditionSized hwmgmtv1alpha1.ConditionType = "Sized"
+
+// GetDesiredNodeGroupSizes parses the desired size annotation of the NodePool
+func GetDesiredNodeGroupSizes(nodepool *hwmgmtv1alpha1.NodePool) (map[string]int, error) {
+	sizes := make(map[string]int)
+
+	value, exists := nodepool.GetAnnotations()[DesiredSizeAnnotation]
+	if !exists || value == "" {
+		return sizes, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &sizes); err != nil {
+		return nil, typederrors.NewInputError("unable to parse %s annotation: %s: %s", DesiredSizeAnnotation, value, err.Error())
+	}
+
+	groups := make(map[string]bool)
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		groups[nodeGroup.NodePoolData.Name] = true
+	}
+	for groupName, size := range sizes {
+		if !groups[groupName] {
+			return nil, typederrors.NewInputError("%s annotation references unknown nodegroup=%s", DesiredSizeAnnotation, groupName)
+		}
+		if size < 0 {
+			return nil, typederrors.NewInputError("%s annotation requests invalid size=%d for nodegroup=%s",
+				DesiredSizeAnnotation, size, groupName)
+		}
+	}
+	return sizes, nil
+}
+
+// GetEffectiveNodeGroupSizes returns the number of nodes to allocate to each node group of the NodePool: the size
+// requested by the desired size annotation, or else the spec size, bounded by the minSize and maxSize extensions
+func GetEffectiveNodeGroupSizes(nodepool *hwmgmtv1alpha1.NodePool) (map[string]int, error) {
+	if err := ValidateNodePoolExtensions(nodepool); err != nil {
+		return nil, err
+	}
+	extensions, err := GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := GetDesiredNodeGroupSizes(nodepool)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(map[string]int, len(nodepool.Spec.NodeGroup))
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		name := nodeGroup.NodePoolData.Name
+		size, requested := desired[name]
+		if !requested {
+			size = nodeGroup.Size
+		}
+		sizes[name] = extensions.ClampSize(name, size)
+	}
+	return sizes, nil
+}
+
+// EffectiveSizesMessage formats the effective size of each node group, ordered by node group name
+func EffectiveSizesMessage(sizes map[string]int) string {
+	groups := make([]string, 0, len(sizes))
+	for group := range sizes {
+		groups = append(groups, group)
+	}
+	slices.Sort(groups)
+
+	entries := make([]string, 0, len(groups))
+	for _, group := range groups {
+		entries = append(entries, fmt.Sprintf("%s=%d", group, sizes[group]))
+	}
+	return "Effective node group sizes: " + strings.Join(entries, ", ")
+}
+
+// UpdateNodePoolEffectiveSizes reports the effective size of each node group in the Sized condition of the NodePool
+func UpdateNodePoolEffectiveSizes(ctx context.Context, c client.Client, nodepool *hwmgmtv1alpha1.NodePool,
+	sizes map[string]int) error {
+	if err := UpdateNodePoolStatusCondition(ctx, c, nodepool, NodePoolConditionSized,
+		hwmgmtv1alpha1.Completed, metav1.ConditionTrue, EffectiveSizesMessage(sizes)); err != nil {
+		return fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
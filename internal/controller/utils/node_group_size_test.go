@@ -0,0 +1,92 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"maps"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestGetEffectiveNodeGroupSizes(t *testing.T) {
+	newNodePool := func(extensions, annotations map[string]string) *hwmgmtv1alpha1.NodePool {
+		return &hwmgmtv1alpha1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: hwmgmtv1alpha1.NodePoolSpec{
+				NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+					{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}, Size: 3},
+					{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}, Size: 2},
+				},
+				Extensions: extensions,
+			},
+		}
+	}
+	bounds := map[string]string{
+		"worker." + pluginv1alpha1.MinSizeExtensionKey: "1",
+		"worker." + pluginv1alpha1.MaxSizeExtensionKey: "4",
+	}
+
+	testcases := []struct {
+		name       string
+		extensions map[string]string
+		desired    string
+		sizes      map[string]int
+		invalid    bool
+	}{
+		{name: "spec sizes", sizes: map[string]int{"controller": 3, "worker": 2}},
+		{name: "desired size", extensions: bounds, desired: `{"worker": 3}`, sizes: map[string]int{"controller": 3, "worker": 3}},
+		{name: "clamped to max", extensions: bounds, desired: `{"worker": 8}`, sizes: map[string]int{"controller": 3, "worker": 4}},
+		{name: "clamped to min", extensions: bounds, desired: `{"worker": 0}`, sizes: map[string]int{"controller": 3, "worker": 1}},
+		{
+			name:       "spec size clamped",
+			extensions: map[string]string{"worker." + pluginv1alpha1.MaxSizeExtensionKey: "1"},
+			sizes:      map[string]int{"controller": 3, "worker": 1},
+		},
+		{name: "unknown group", desired: `{"storage": 2}`, invalid: true},
+		{name: "negative size", desired: `{"worker": -1}`, invalid: true},
+		{name: "malformed", desired: `worker=2`, invalid: true},
+		{
+			name: "inconsistent bounds",
+			extensions: map[string]string{
+				"worker." + pluginv1alpha1.MinSizeExtensionKey: "5",
+				"worker." + pluginv1alpha1.MaxSizeExtensionKey: "4",
+			},
+			invalid: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var annotations map[string]string
+			if tc.desired != "" {
+				annotations = map[string]string{DesiredSizeAnnotation: tc.desired}
+			}
+			sizes, err := GetEffectiveNodeGroupSizes(newNodePool(tc.extensions, annotations))
+			if tc.invalid {
+				if !typederrors.IsInputError(err) {
+					t.Errorf("expected input error, got %v, %v", sizes, err)
+				}
+				return
+			}
+			if err != nil || !maps.Equal(sizes, tc.sizes) {
+				t.Errorf("expected %v, got %v, %v", tc.sizes, sizes, err)
+			}
+		})
+	}
+}
+
+func TestEffectiveSizesMessage(t *testing.T) {
+	message := EffectiveSizesMessage(map[string]int{"worker": 5, "master": 3})
+	if expected := "Effective node group sizes: master=3, worker=5"; message != expected {
+		t.Errorf("expected %q, got %q", expected, message)
+	}
+}
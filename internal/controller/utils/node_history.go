@@ -0,0 +1,193 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The Node status is defined by the O2IMS API, so the operation history is kept in an annotation
+const (
+	OperationHistoryAnnotation = "hwmgr-plugin.oran.openshift.io/operation-history"
+
+	// MaxNodeOperationHistory is the number of operations kept in the history of a node
+	MaxNodeOperationHistory = 10
+)
+
+// NodeOperationProfileUpdate is the type of a hardware profile update operation run by a backend job
+const NodeOperationProfileUpdate = "profile-update"
+
+// NodeOperationOutcome is the outcome of an operation run on a node
+type NodeOperationOutcome string
+
+const (
+	NodeOperationInProgress NodeOperationOutcome = "InProgress"
+	NodeOperationSucceeded  NodeOperationOutcome = "Succeeded"
+	NodeOperationFailed     NodeOperationOutcome = "Failed"
+	NodeOperationTimedOut   NodeOperationOutcome = "TimedOut"
+)
+
+// NodeOperation is an entry of the operation history of a node
+type NodeOperation struct {
+	Type      string               `json:"type"`
+	StartTime string               `json:"startTime"`
+	EndTime   string               `json:"endTime,omitempty"`
+	Outcome   NodeOperationOutcome `json:"outcome"`
+	JobId     string               `json:"jobId,omitempty"`
+	Message   string               `json:"message,omitempty"`
+}
+
+// GetNodeOperationHistory returns the operation history recorded on the node, oldest first. A malformed history is
+// ignored, as it is only used for troubleshooting.
+func GetNodeOperationHistory(object client.Object) []NodeOperation {
+	value, exists := object.GetAnnotations()[OperationHistoryAnnotation]
+	if !exists {
+		return nil
+	}
+	var history []NodeOperation
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+func setNodeOperationHistory(object client.Object, history []NodeOperation) {
+	if len(history) > MaxNodeOperationHistory {
+		history = history[len(history)-MaxNodeOperationHistory:]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[OperationHistoryAnnotation] = string(data)
+	object.SetAnnotations(annotations)
+}
+
+// PruneNodeOperationHistory drops the completed operations of the node that ended before the given time, unless zero,
+// and then the oldest completed operations beyond the given count, unless zero, returning the number of operations
+// dropped. Operations in progress are always kept. The caller is responsible for updating the node.
+func PruneNodeOperationHistory(object client.Object, before time.Time, keep int) int {
+	history := GetNodeOperationHistory(object)
+	if len(history) == 0 {
+		return 0
+	}
+
+	expired := func(op NodeOperation) bool {
+		if before.IsZero() || op.Outcome == NodeOperationInProgress {
+			return false
+		}
+		end, err := time.Parse(time.RFC3339, op.EndTime)
+		return err == nil && end.Before(before)
+	}
+
+	kept := make([]NodeOperation, 0, len(history))
+	for _, op := range history {
+		if !expired(op) {
+			kept = append(kept, op)
+		}
+	}
+	if keep > 0 {
+		excess := len(kept) - keep
+		for i := 0; i < len(kept) && excess > 0; {
+			if kept[i].Outcome == NodeOperationInProgress {
+				i++
+				continue
+			}
+			kept = append(kept[:i], kept[i+1:]...)
+			excess--
+		}
+	}
+
+	dropped := len(history) - len(kept)
+	if dropped == 0 {
+		return 0
+	}
+	if len(kept) == 0 {
+		annotations := object.GetAnnotations()
+		delete(annotations, OperationHistoryAnnotation)
+		object.SetAnnotations(annotations)
+		return dropped
+	}
+	setNodeOperationHistory(object, kept)
+	return dropped
+}
+
+// StartNodeOperation records the start of an operation in the history of the node, dropping the oldest operations
+// beyond MaxNodeOperationHistory. Any operation still in progress is considered superseded and marked as failed. The
+// caller is responsible for updating the node.
+func StartNodeOperation(object client.Object, opType, jobId string, clock Clock) {
+	now := clock.Now().UTC().Format(time.RFC3339)
+	history := GetNodeOperationHistory(object)
+	for i := range history {
+		if history[i].Outcome == NodeOperationInProgress {
+			history[i].Outcome = NodeOperationFailed
+			history[i].EndTime = now
+			history[i].Message = "Superseded by a new operation"
+		}
+	}
+	history = append(history, NodeOperation{
+		Type:      opType,
+		StartTime: now,
+		Outcome:   NodeOperationInProgress,
+		JobId:     jobId,
+	})
+	setNodeOperationHistory(object, history)
+}
+
+// EndNodeOperation records the outcome of the operation in progress on the node, returning false if there is none.
+// The caller is responsible for updating the node.
+func EndNodeOperation(object client.Object, outcome NodeOperationOutcome, message string, clock Clock) bool {
+	history := GetNodeOperationHistory(object)
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Outcome == NodeOperationInProgress {
+			history[i].Outcome = outcome
+			history[i].EndTime = clock.Now().UTC().Format(time.RFC3339)
+			history[i].Message = message
+			setNodeOperationHistory(object, history)
+			return true
+		}
+	}
+	return false
+}
+
+// FinishNodeOperation records the outcome of the operation in progress on the node and updates the node, for failure
+// paths where the node is not otherwise updated
+func FinishNodeOperation(
+	ctx context.Context,
+	c client.Client,
+	nodename, namespace string,
+	outcome NodeOperationOutcome,
+	message string,
+	clock Clock) error {
+
+	// nolint: wrapcheck
+	return retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+		node := &hwmgmtv1alpha1.Node{}
+		if err := c.Get(ctx, types.NamespacedName{Name: nodename, Namespace: namespace}, node); err != nil {
+			return fmt.Errorf("failed to fetch Node: %w", err)
+		}
+
+		if !EndNodeOperation(node, outcome, message, clock) {
+			return nil
+		}
+		return c.Update(ctx, node)
+	})
+}
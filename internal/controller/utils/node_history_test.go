@@ -0,0 +1,106 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestNodeOperationHistory(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	clock := clocktesting.NewFakeClock(start)
+	node := &hwmgmtv1alpha1.Node{}
+	if history := GetNodeOperationHistory(node); history != nil {
+		t.Fatalf("expected no history, got %v", history)
+	}
+	if EndNodeOperation(node, NodeOperationSucceeded, "", clock) {
+		t.Errorf("expected no operation in progress")
+	}
+
+	StartNodeOperation(node, NodeOperationProfileUpdate, "job-1", clock)
+	StartNodeOperation(node, NodeOperationProfileUpdate, "job-2", clock)
+	clock.Step(time.Hour)
+	if !EndNodeOperation(node, NodeOperationTimedOut, "Timed out after 1h", clock) {
+		t.Errorf("expected an operation in progress")
+	}
+
+	history := GetNodeOperationHistory(node)
+	if len(history) != 2 {
+		t.Fatalf("expected 2 operations, got %v", history)
+	}
+	if history[0].JobId != "job-1" || history[0].Outcome != NodeOperationFailed || history[0].EndTime == "" {
+		t.Errorf("expected the first operation to be superseded, got %+v", history[0])
+	}
+	if history[1].JobId != "job-2" || history[1].Outcome != NodeOperationTimedOut || history[1].Message != "Timed out after 1h" {
+		t.Errorf("unexpected operation: %+v", history[1])
+	}
+	if history[1].StartTime != "2024-05-01T10:00:00Z" || history[1].EndTime != "2024-05-01T11:00:00Z" {
+		t.Errorf("expected the operation to be timed with the clock, got %+v", history[1])
+	}
+}
+
+func TestNodeOperationHistoryBounded(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	node := &hwmgmtv1alpha1.Node{}
+	for i := 0; i < MaxNodeOperationHistory+5; i++ {
+		StartNodeOperation(node, NodeOperationProfileUpdate, fmt.Sprintf("job-%d", i), clock)
+		EndNodeOperation(node, NodeOperationSucceeded, "", clock)
+	}
+
+	history := GetNodeOperationHistory(node)
+	if len(history) != MaxNodeOperationHistory || history[0].JobId != "job-5" {
+		t.Errorf("expected the last %d operations, got %v", MaxNodeOperationHistory, history)
+	}
+}
+
+func TestNodeOperationHistoryMalformed(t *testing.T) {
+	node := &hwmgmtv1alpha1.Node{}
+	node.SetAnnotations(map[string]string{OperationHistoryAnnotation: "not json"})
+	StartNodeOperation(node, "firmware-update", "", clocktesting.NewFakeClock(time.Now()))
+	if history := GetNodeOperationHistory(node); len(history) != 1 || history[0].Outcome != NodeOperationInProgress {
+		t.Errorf("expected malformed history to be replaced, got %v", history)
+	}
+}
+
+func TestPruneNodeOperationHistory(t *testing.T) {
+	now := time.Now().UTC()
+	ended := func(age time.Duration) string { return now.Add(-age).Format(time.RFC3339) }
+
+	node := &hwmgmtv1alpha1.Node{}
+	setNodeOperationHistory(node, []NodeOperation{
+		{JobId: "job-1", Outcome: NodeOperationSucceeded, EndTime: ended(72 * time.Hour)},
+		{JobId: "job-2", Outcome: NodeOperationFailed, EndTime: ended(36 * time.Hour)},
+		{JobId: "job-3", Outcome: NodeOperationSucceeded, EndTime: ended(time.Hour)},
+		{JobId: "job-4", Outcome: NodeOperationSucceeded, EndTime: ended(time.Minute)},
+		{JobId: "job-5", Outcome: NodeOperationInProgress},
+	})
+
+	if dropped := PruneNodeOperationHistory(node, now.Add(-48*time.Hour), 0); dropped != 1 {
+		t.Errorf("expected the operation past the maximum age to be dropped, got %d", dropped)
+	}
+	if dropped := PruneNodeOperationHistory(node, time.Time{}, 2); dropped != 2 {
+		t.Errorf("expected the oldest completed operations beyond the count to be dropped, got %d", dropped)
+	}
+	history := GetNodeOperationHistory(node)
+	if len(history) != 2 || history[0].JobId != "job-4" || history[1].JobId != "job-5" {
+		t.Errorf("unexpected history: %+v", history)
+	}
+
+	if dropped := PruneNodeOperationHistory(node, now, 0); dropped != 1 {
+		t.Errorf("expected the operation in progress to be kept, got %d dropped", dropped)
+	}
+	EndNodeOperation(node, NodeOperationSucceeded, "", clocktesting.NewFakeClock(now))
+	PruneNodeOperationHistory(node, now.Add(time.Hour), 0)
+	if _, exists := node.GetAnnotations()[OperationHistoryAnnotation]; exists {
+		t.Errorf("expected an empty history to be removed")
+	}
+}
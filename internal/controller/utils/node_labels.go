@@ -0,0 +1,73 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"strings"
+)
+
+// Labels set on created Nodes, so that other controllers and users can select Nodes by the hardware backing them
+const (
+	NodeSiteIDLabel         = "hwmgr-plugin.oran.openshift.io/site-id"
+	NodeResourcePoolIDLabel = "hwmgr-plugin.oran.openshift.io/resource-pool-id"
+	NodeVendorLabel         = "hwmgr-plugin.oran.openshift.io/vendor"
+	NodeModelLabel          = "hwmgr-plugin.oran.openshift.io/model"
+)
+
+const maxLabelValueLength = 63
+
+// NodeSelectionInfo holds the attributes of the hardware backing a Node that are published as labels
+type NodeSelectionInfo struct {
+	SiteID          string
+	ResourcePoolID  string
+	Vendor          string
+	Model           string
+	CPUArchitecture string
+}
+
+// Labels returns the labels for the known attributes, with the values sanitized to be valid label values
+func (info NodeSelectionInfo) Labels() map[string]string {
+	labels := make(map[string]string)
+	for key, value := range map[string]string{
+		NodeSiteIDLabel:         info.SiteID,
+		NodeResourcePoolIDLabel: info.ResourcePoolID,
+		NodeVendorLabel:         info.Vendor,
+		NodeModelLabel:          info.Model,
+		CPUArchitectureLabel:    info.CPUArchitecture,
+	} {
+		if value = SanitizeLabelValue(value); value != "" {
+			labels[key] = value
+		}
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// SanitizeLabelValue converts a string to a valid label value, replacing invalid characters with '-', and trimming
+// it to the maximum length. For example, "Dell Inc." becomes "Dell-Inc".
+func SanitizeLabelValue(value string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if isAlphanumeric(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(value))
+
+	if len(sanitized) > maxLabelValueLength {
+		sanitized = sanitized[:maxLabelValueLength]
+	}
+
+	// Label values must begin and end with an alphanumeric character
+	return strings.TrimFunc(sanitized, func(r rune) bool { return !isAlphanumeric(r) })
+}
@@ -0,0 +1,59 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestSanitizeLabelValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "Dell Inc.", expected: "Dell-Inc"},
+		{value: "PowerEdge XR8620t", expected: "PowerEdge-XR8620t"},
+		{value: " site-1 ", expected: "site-1"},
+		{value: "(none)", expected: "none"},
+		{value: "", expected: ""},
+		{value: strings.Repeat("a", 70), expected: strings.Repeat("a", 63)},
+	}
+
+	for _, tt := range tests {
+		sanitized := SanitizeLabelValue(tt.value)
+		if sanitized != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.value, tt.expected, sanitized)
+		}
+		if errs := validation.IsValidLabelValue(sanitized); len(errs) != 0 {
+			t.Errorf("%q: invalid label value %q: %v", tt.value, sanitized, errs)
+		}
+	}
+}
+
+func TestNodeSelectionInfoLabels(t *testing.T) {
+	labels := NodeSelectionInfo{SiteID: "site-1", Vendor: "Dell Inc.", CPUArchitecture: CPUArchitectureX86_64}.Labels()
+	expected := map[string]string{
+		NodeSiteIDLabel:      "site-1",
+		NodeVendorLabel:      "Dell-Inc",
+		CPUArchitectureLabel: CPUArchitectureX86_64,
+	}
+	if len(labels) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, labels)
+	}
+	for key, value := range expected {
+		if labels[key] != value {
+			t.Errorf("%s: expected %q, got %q", key, value, labels[key])
+		}
+	}
+
+	if labels := (NodeSelectionInfo{}).Labels(); labels != nil {
+		t.Errorf("expected no labels, got %v", labels)
+	}
+}
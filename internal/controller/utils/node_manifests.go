@@ -0,0 +1,305 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// Node manifest labels, identifying the NodePool and Node the manifests were rendered for so that they are removed
+// along with the node
+const (
+	NodeManifestsNodePoolLabel = "hwmgr-plugin.oran.openshift.io/manifests-nodepool"
+	NodeManifestsNodeLabel     = "hwmgr-plugin.oran.openshift.io/manifests-node"
+)
+
+// NodeManifestsName returns the name of the ConfigMap or Secret holding the manifests rendered for a node
+func NodeManifestsName(nodename string) string {
+	return nodename + "-manifests"
+}
+
+// NodeManifestInterface holds the variables of a network interface available to a manifest template
+type NodeManifestInterface struct {
+	Name       string
+	Label      string
+	MACAddress string
+}
+
+// NodeManifestTemplateData holds the variables available to a manifest template
+type NodeManifestTemplateData struct {
+	// NodeName is the name of the Node CR
+	NodeName string
+	// NodePool is the name of the NodePool
+	NodePool string
+	// CloudID is the cloud ID of the NodePool
+	CloudID string
+	// Site is the site of the NodePool
+	Site string
+	// GroupName is the node group of the node
+	GroupName string
+	// HwProfile is the hardware profile of the node
+	HwProfile string
+	// Hostname is the hostname of the node
+	Hostname string
+	// BMCAddress is the address of the BMC of the node
+	BMCAddress string
+	// BMCCredentialsName is the name of the secret holding the BMC credentials of the node
+	BMCCredentialsName string
+	// BMCCredentialsNamespace is the namespace of the secret holding the BMC credentials of the node
+	BMCCredentialsNamespace string
+	// Interfaces are the network interfaces of the node
+	Interfaces []NodeManifestInterface
+	// MACAddresses maps the label of each interface, or its name when unlabeled, to its MAC address
+	MACAddresses map[string]string
+}
+
+// newNodeManifestTemplateData returns the variables of the manifest templates of a node
+func newNodeManifestTemplateData(nodepool *hwmgmtv1alpha1.NodePool, node *hwmgmtv1alpha1.Node) NodeManifestTemplateData {
+	data := NodeManifestTemplateData{
+		NodeName:     node.Name,
+		NodePool:     nodepool.Name,
+		CloudID:      nodepool.Spec.CloudID,
+		Site:         nodepool.Spec.Site,
+		GroupName:    node.Spec.GroupName,
+		HwProfile:    node.Spec.HwProfile,
+		Hostname:     node.Status.Hostname,
+		MACAddresses: make(map[string]string),
+	}
+	if node.Status.BMC != nil {
+		data.BMCAddress = node.Status.BMC.Address
+		data.BMCCredentialsName = node.Status.BMC.CredentialsName
+	}
+	if key, ok := GetNodeBMCSecretKey(node, node.Namespace); ok {
+		data.BMCCredentialsNamespace = key.Namespace
+	}
+	for _, iface := range node.Status.Interfaces {
+		if iface == nil {
+			continue
+		}
+		data.Interfaces = append(data.Interfaces, NodeManifestInterface{
+			Name:       iface.Name,
+			Label:      iface.Label,
+			MACAddress: iface.MACAddress,
+		})
+		key := iface.Label
+		if key == "" {
+			key = iface.Name
+		}
+		data.MACAddresses[key] = iface.MACAddress
+	}
+	return data
+}
+
+// renderNodeManifests executes each template of a bundle, returning the rendered manifests under the same keys
+func renderNodeManifests(bundle map[string]string, data NodeManifestTemplateData) (map[string]string, error) {
+	rendered := make(map[string]string, len(bundle))
+	for key, text := range bundle {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, typederrors.NewInputError("invalid manifest template %s: %s", key, err.Error())
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, typederrors.NewInputError("failed to render manifest template %s for node %s: %s",
+				key, data.NodeName, err.Error())
+		}
+		rendered[key] = out.String()
+	}
+	return rendered, nil
+}
+
+// getManifestTemplateBundle reads the templates of a manifest template bundle
+func getManifestTemplateBundle(ctx context.Context, c client.Client, ref pluginv1alpha1.ManifestTemplateRef, namespace string) (map[string]string, error) {
+	if ref.Kind == "Secret" {
+		secret, err := GetSecret(ctx, c, ref.Name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		bundle := make(map[string]string, len(secret.Data))
+		for key, value := range secret.Data {
+			bundle[key] = string(value)
+		}
+		return bundle, nil
+	}
+
+	cm, err := GetConfigmap(ctx, c, ref.Name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return cm.Data, nil
+}
+
+// newNodeManifests returns the ConfigMap or Secret holding the manifests rendered for a node, labeled with its
+// NodePool and Node, and owned by the NodePool
+func newNodeManifests(kind string, nodepool *hwmgmtv1alpha1.NodePool, nodename, namespace string, rendered map[string]string) client.Object {
+	blockDeletion := true
+	objectMeta := metav1.ObjectMeta{
+		Name:      NodeManifestsName(nodename),
+		Namespace: namespace,
+		Labels: map[string]string{
+			NodeManifestsNodePoolLabel: nodepool.Name,
+			NodeManifestsNodeLabel:     nodename,
+		},
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion:         hwmgmtv1alpha1.GroupVersion.String(),
+			Kind:               "NodePool",
+			Name:               nodepool.Name,
+			UID:                nodepool.UID,
+			BlockOwnerDeletion: &blockDeletion,
+		}},
+	}
+
+	if kind == "Secret" {
+		data := make(map[string][]byte, len(rendered))
+		for key, value := range rendered {
+			data[key] = []byte(value)
+		}
+		return &corev1.Secret{ObjectMeta: objectMeta, Type: corev1.SecretTypeOpaque, Data: data}
+	}
+	return &corev1.ConfigMap{ObjectMeta: objectMeta, Data: rendered}
+}
+
+// nodeManifestsChanged checks whether the existing manifests of a node differ from the desired ones
+func nodeManifestsChanged(existing, desired client.Object) bool {
+	switch desired := desired.(type) {
+	case *corev1.Secret:
+		return !equality.Semantic.DeepEqual(existing.(*corev1.Secret).Data, desired.Data)
+	case *corev1.ConfigMap:
+		return !equality.Semantic.DeepEqual(existing.(*corev1.ConfigMap).Data, desired.Data)
+	}
+	return true
+}
+
+// applyNodeManifests writes the manifests of a node, skipping the write when they are unchanged
+func applyNodeManifests(ctx context.Context, c client.Client, desired client.Object) error {
+	var existing client.Object = &corev1.ConfigMap{}
+	if _, isSecret := desired.(*corev1.Secret); isSecret {
+		existing = &corev1.Secret{}
+	}
+	err := c.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case err == nil:
+		if !nodeManifestsChanged(existing, desired) {
+			return nil
+		}
+	case !errors.IsNotFound(err):
+		return fmt.Errorf("failed to get manifests %s: %w", desired.GetName(), err)
+	}
+	return CreateOrUpdateK8sCR(ctx, c, desired, nil, UPDATE)
+}
+
+// deleteStaleNodeManifests deletes the manifests of a NodePool rendered for nodes that no longer have any, such as
+// nodes removed from the NodePool, or rendered in an object of another kind than desired
+func deleteStaleNodeManifests(ctx context.Context, c client.Client, nodepool *hwmgmtv1alpha1.NodePool, namespace string,
+	desired map[string]string) error {
+	lists := map[string]client.ObjectList{"ConfigMap": &corev1.ConfigMapList{}, "Secret": &corev1.SecretList{}}
+	for kind, list := range lists {
+		if err := c.List(ctx, list, client.InNamespace(namespace),
+			client.MatchingLabels{NodeManifestsNodePoolLabel: nodepool.Name}); err != nil {
+			return fmt.Errorf("failed to list manifests of nodepool %s: %w", nodepool.Name, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("failed to list manifests of nodepool %s: %w", nodepool.Name, err)
+		}
+		for _, item := range items {
+			object := item.(client.Object)
+			if desired[object.GetLabels()[NodeManifestsNodeLabel]] == kind {
+				continue
+			}
+			if err := c.Delete(ctx, object); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete manifests %s: %w", object.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// ReconcileNodeManifests renders the manifest template bundle set by the NodePool extensions for each provisioned node
+// of the NodePool, storing the manifests of each node in a ConfigMap, or a Secret when the bundle is a Secret, named
+// after the node. Downstream installers read the manifests of a node from there, instead of generating them from the
+// Node CR. Nodes are rendered once their status is complete, and their manifests are deleted when they leave the
+// NodePool.
+func ReconcileNodeManifests(ctx context.Context, logger *slog.Logger, c client.Client, nodepool *hwmgmtv1alpha1.NodePool,
+	namespace string) error {
+	extensions, err := GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return err
+	}
+
+	nodelist, err := GetChildNodes(ctx, logger, c, nodepool)
+	if err != nil {
+		return err
+	}
+
+	bundles := make(map[string]map[string]string)
+	// desired maps the name of each node with manifests to the kind of the object holding them
+	desired := make(map[string]string)
+	for i := range nodelist.Items {
+		node := &nodelist.Items[i]
+		value := extensions.GetManifestTemplate(node.Spec.GroupName)
+		if value == "" {
+			continue
+		}
+		ref, err := pluginv1alpha1.ParseManifestTemplateRef(value)
+		if err != nil {
+			return typederrors.NewInputError("invalid nodepool extensions: %s", err.Error())
+		}
+		desired[node.Name] = ref.Kind
+
+		if !meta.IsStatusConditionTrue(node.Status.Conditions, string(hwmgmtv1alpha1.Provisioned)) {
+			continue
+		}
+
+		bundle, exists := bundles[value]
+		if !exists {
+			if bundle, err = getManifestTemplateBundle(ctx, c, ref, namespace); err != nil {
+				return err
+			}
+			bundles[value] = bundle
+		}
+
+		rendered, err := renderNodeManifests(bundle, newNodeManifestTemplateData(nodepool, node))
+		if err != nil {
+			return err
+		}
+		if err := applyNodeManifests(ctx, c, newNodeManifests(ref.Kind, nodepool, node.Name, namespace, rendered)); err != nil {
+			return fmt.Errorf("failed to write manifests of node %s: %w", node.Name, err)
+		}
+	}
+
+	if err := deleteStaleNodeManifests(ctx, c, nodepool, namespace, desired); err != nil {
+		return err
+	}
+	return nil
+}
+
+// HasNodeManifestTemplates checks whether the NodePool extensions set a manifest template bundle for any node group
+func HasNodeManifestTemplates(nodepool *hwmgmtv1alpha1.NodePool) bool {
+	for key, value := range nodepool.Spec.Extensions {
+		if value != "" && (key == pluginv1alpha1.ManifestTemplateExtensionKey ||
+			strings.HasSuffix(key, "."+pluginv1alpha1.ManifestTemplateExtensionKey)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestRenderNodeManifests(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "np1", Namespace: "hwmgr"},
+		Spec:       hwmgmtv1alpha1.NodePoolSpec{CloudID: "cluster-1", LocationSpec: hwmgmtv1alpha1.LocationSpec{Site: "site-a"}},
+	}
+	node := &hwmgmtv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-abc"},
+		Spec:       hwmgmtv1alpha1.NodeSpec{GroupName: "controller", HwProfile: "profile-1"},
+		Status: hwmgmtv1alpha1.NodeStatus{
+			Hostname: "ctl-0.example.com",
+			BMC:      &hwmgmtv1alpha1.BMC{Address: "idrac-virtualmedia+https://10.0.0.1", CredentialsName: "node-abc-bmc-secret"},
+			Interfaces: []*hwmgmtv1alpha1.Interface{
+				{Name: "eno1", Label: "bootable-interface", MACAddress: "aa:bb:cc:dd:ee:01"},
+				{Name: "eno2", MACAddress: "aa:bb:cc:dd:ee:02"},
+			},
+		},
+	}
+
+	bundle := map[string]string{
+		"host.yaml": "name: {{.Hostname}}\nbmc: {{.BMCAddress}}\nboot: {{index .MACAddresses \"bootable-interface\"}}\n",
+		"nics.txt":  "{{range .Interfaces}}{{.Name}}={{.MACAddress}};{{end}}",
+	}
+	rendered, err := renderNodeManifests(bundle, newNodeManifestTemplateData(nodepool, node))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"host.yaml": "name: ctl-0.example.com\nbmc: idrac-virtualmedia+https://10.0.0.1\nboot: aa:bb:cc:dd:ee:01\n",
+		"nics.txt":  "eno1=aa:bb:cc:dd:ee:01;eno2=aa:bb:cc:dd:ee:02;",
+	}
+	if !reflect.DeepEqual(rendered, expected) {
+		t.Errorf("expected %v, got %v", expected, rendered)
+	}
+
+	if _, err := renderNodeManifests(map[string]string{"bad": "{{.Rack}}"}, newNodeManifestTemplateData(nodepool, node)); !typederrors.IsInputError(err) {
+		t.Errorf("expected input error for unknown variable, got %v", err)
+	}
+	if _, err := renderNodeManifests(map[string]string{"bad": "{{.Hostname"}, newNodeManifestTemplateData(nodepool, node)); !typederrors.IsInputError(err) {
+		t.Errorf("expected input error for invalid template, got %v", err)
+	}
+
+	secret, ok := newNodeManifests("Secret", nodepool, node.Name, "hwmgr", rendered).(*corev1.Secret)
+	if !ok || secret.Name != "node-abc-manifests" || string(secret.Data["nics.txt"]) != expected["nics.txt"] ||
+		secret.Labels[NodeManifestsNodeLabel] != node.Name {
+		t.Errorf("unexpected manifests secret: %+v", secret)
+	}
+}
+
+func TestParseManifestTemplateRef(t *testing.T) {
+	testcases := []struct {
+		value    string
+		expected pluginv1alpha1.ManifestTemplateRef
+		invalid  bool
+	}{
+		{value: "extra-manifests", expected: pluginv1alpha1.ManifestTemplateRef{Kind: "ConfigMap", Name: "extra-manifests"}},
+		{value: "configmap/extra-manifests", expected: pluginv1alpha1.ManifestTemplateRef{Kind: "ConfigMap", Name: "extra-manifests"}},
+		{value: "Secret/extra-manifests", expected: pluginv1alpha1.ManifestTemplateRef{Kind: "Secret", Name: "extra-manifests"}},
+		{value: "deployment/extra-manifests", invalid: true},
+		{value: "secret/", invalid: true},
+	}
+
+	for _, tc := range testcases {
+		ref, err := pluginv1alpha1.ParseManifestTemplateRef(tc.value)
+		if tc.invalid {
+			if err == nil {
+				t.Errorf("%s: expected error, got %+v", tc.value, ref)
+			}
+			continue
+		}
+		if err != nil || ref != tc.expected {
+			t.Errorf("%s: expected %+v, got %+v (%v)", tc.value, tc.expected, ref, err)
+		}
+	}
+
+	nodepool := &hwmgmtv1alpha1.NodePool{Spec: hwmgmtv1alpha1.NodePoolSpec{Extensions: map[string]string{
+		"worker." + pluginv1alpha1.ManifestTemplateExtensionKey: "secret/worker-manifests",
+	}}}
+	if !HasNodeManifestTemplates(nodepool) {
+		t.Error("expected the nodepool to have manifest templates")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Progress updates are only written when they advance by ProgressMinDelta percentage points, or when the last write of
+// the Configured condition of the node is at least ProgressMinInterval old, so that backends reporting progress every
+// few seconds do not cause a status write each time. The latest update skipped is kept, and written by a later report
+// once the interval has passed, or when the operation completes.
+const (
+	ProgressMinDelta    = 10
+	ProgressMinInterval = 30 * time.Second
+)
+
+// NodeProgress is the progress of an operation on a Node
+type NodeProgress struct {
+	Percent int
+	Message string
+}
+
+func (p NodeProgress) String() string {
+	return fmt.Sprintf("%s (%d%%)", p.Message, p.Percent)
+}
+
+type progressEntry struct {
+	written   NodeProgress
+	writtenAt time.Time
+	pending   *NodeProgress
+}
+
+// progressThrottle caches the last progress written, and the latest progress skipped since, for each node
+type progressThrottle struct {
+	mu      sync.Mutex
+	entries map[string]progressEntry
+}
+
+func newProgressThrottle() *progressThrottle {
+	return &progressThrottle{entries: make(map[string]progressEntry)}
+}
+
+// due checks whether a progress update for a node should be written at the given time
+func (t *progressThrottle) due(key string, progress NodeProgress, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, exists := t.entries[key]
+	switch {
+	case !exists:
+		return true
+	case entry.written == progress:
+		return false
+	case progress.Percent >= 100 || progress.Percent < entry.written.Percent:
+		// Completion, or an operation that restarted
+		return true
+	case progress.Percent-entry.written.Percent >= ProgressMinDelta:
+		return true
+	}
+	return now.Sub(entry.writtenAt) >= ProgressMinInterval
+}
+
+func (t *progressThrottle) record(key string, progress NodeProgress, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries[key] = progressEntry{written: progress, writtenAt: now}
+}
+
+// skip keeps a progress update that was not written, replacing any update skipped before
+func (t *progressThrottle) skip(key string, progress NodeProgress) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if entry, exists := t.entries[key]; exists {
+		entry.pending = nil
+		if progress != entry.written {
+			entry.pending = &progress
+		}
+		t.entries[key] = entry
+	}
+}
+
+// pending returns the latest progress update skipped for a node, if any
+func (t *progressThrottle) pending(key string) (NodeProgress, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if entry, exists := t.entries[key]; exists && entry.pending != nil {
+		return *entry.pending, true
+	}
+	return NodeProgress{}, false
+}
+
+// touch records a write of the Configured condition of a node by other than a progress report, so that the interval is
+// measured from the last write of the condition
+func (t *progressThrottle) touch(key string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if entry, exists := t.entries[key]; exists {
+		entry.writtenAt = now
+		t.entries[key] = entry
+	}
+}
+
+func (t *progressThrottle) forget(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, key)
+}
+
+var nodeProgress = newProgressThrottle()
+
+// ReportNodeProgress reports the progress of a configuration update in the message of the Configured condition of a
+// Node. Updates that do not advance the progress enough since the last write are kept as pending, and written by a
+// later report once ProgressMinInterval has passed by the clock, or by ClearNodeProgress.
+func ReportNodeProgress(ctx context.Context, c client.Client, nodename, namespace string, progress NodeProgress,
+	clock Clock) error {
+	key := nodeProgressKey(nodename, namespace)
+	if !nodeProgress.due(key, progress, clock.Now()) {
+		nodeProgress.skip(key, progress)
+		return nil
+	}
+	return writeNodeProgress(ctx, c, key, nodename, namespace, progress, clock)
+}
+
+// ClearNodeProgress writes the latest progress skipped for a Node, if any, and then drops the progress cached for it.
+// It is called once the operation of the node has completed, before its final status is written. The cached progress
+// is kept if the write fails, so that it is retried by the next call.
+func ClearNodeProgress(ctx context.Context, c client.Client, nodename, namespace string, clock Clock) error {
+	key := nodeProgressKey(nodename, namespace)
+	if progress, exists := nodeProgress.pending(key); exists {
+		err := writeNodeProgress(ctx, c, key, nodename, namespace, progress, clock)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	nodeProgress.forget(key)
+	return nil
+}
+
+func writeNodeProgress(ctx context.Context, c client.Client, key, nodename, namespace string, progress NodeProgress,
+	clock Clock) error {
+	if err := SetNodeConditionStatus(ctx, c, nodename, namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse,
+		string(hwmgmtv1alpha1.ConfigUpdate), progress.String()); err != nil {
+		return err
+	}
+	nodeProgress.record(key, progress, clock.Now())
+	return nil
+}
+
+func nodeProgressKey(nodename, namespace string) string {
+	return namespace + "/" + nodename
+}
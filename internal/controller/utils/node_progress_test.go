@@ -0,0 +1,169 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestProgressThrottle(t *testing.T) {
+	now := time.Now()
+	throttle := newProgressThrottle()
+
+	report := func(percent int, message string) bool {
+		progress := NodeProgress{Percent: percent, Message: message}
+		if !throttle.due("ns/node", progress, now) {
+			return false
+		}
+		throttle.record("ns/node", progress, now)
+		return true
+	}
+
+	steps := []struct {
+		advance time.Duration
+		percent int
+		written bool
+	}{
+		{0, 0, true},
+		{time.Second, 5, false},
+		{time.Second, 10, true},
+		{time.Second, 10, false},
+		{time.Second, 15, false},
+		{ProgressMinInterval, 15, true},
+		{time.Second, 100, true},
+		{time.Second, 0, true},
+	}
+	for i, step := range steps {
+		now = now.Add(step.advance)
+		if written := report(step.percent, "Updating"); written != step.written {
+			t.Errorf("step %d (%d%%): expected written=%t", i, step.percent, step.written)
+		}
+	}
+
+	throttle.forget("ns/node")
+	if !report(5, "Updating") {
+		t.Errorf("expected progress to be written after the node was cleared")
+	}
+}
+
+// nodeStatusClient serves and updates the status of a single Node
+type nodeStatusClient struct {
+	client.Client
+	node    *hwmgmtv1alpha1.Node
+	updates int
+}
+
+func (c *nodeStatusClient) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	c.node.DeepCopyInto(obj.(*hwmgmtv1alpha1.Node))
+	return nil
+}
+
+func (c *nodeStatusClient) Status() client.SubResourceWriter {
+	return &nodeStatusWriter{client: c}
+}
+
+type nodeStatusWriter struct {
+	client.SubResourceWriter
+	client *nodeStatusClient
+}
+
+func (w *nodeStatusWriter) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	w.client.updates++
+	w.client.node = obj.(*hwmgmtv1alpha1.Node).DeepCopy()
+	w.client.node.ResourceVersion = strconv.Itoa(w.client.updates)
+	return nil
+}
+
+func TestReportNodeProgress(t *testing.T) {
+	// The interval is also restarted by condition writes, timed with the clock of the status updates
+	clock := clocktesting.NewFakeClock(time.Now())
+	saved := recentConditionWrites.clock
+	recentConditionWrites.clock = clock
+	defer func() { recentConditionWrites.clock = saved }()
+	defer nodeProgress.forget(nodeProgressKey("node-progress", "hwmgr"))
+
+	c := &nodeStatusClient{node: &hwmgmtv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-progress", Namespace: "hwmgr"}}}
+	ctx := context.Background()
+	report := func(percent int) {
+		if err := ReportNodeProgress(ctx, c, "node-progress", "hwmgr", NodeProgress{Percent: percent, Message: "Updating"},
+			clock); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	report(10)
+	report(15)
+	if c.updates != 1 {
+		t.Fatalf("expected the progress that did not advance enough to be suppressed, got %d writes", c.updates)
+	}
+
+	// A write of the condition by other than a progress report restarts the interval
+	clock.Step(ProgressMinInterval / 2)
+	if err := SetNodeConditionStatus(ctx, c, "node-progress", "hwmgr", string(hwmgmtv1alpha1.Configured),
+		metav1.ConditionFalse, string(hwmgmtv1alpha1.ConfigUpdate), "Waiting for the host"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Step(ProgressMinInterval / 2)
+	report(15)
+	if c.updates != 2 {
+		t.Errorf("expected the progress to be suppressed within the interval of the last write, got %d writes", c.updates)
+	}
+
+	clock.Step(ProgressMinInterval / 2)
+	report(15)
+	if c.updates != 3 {
+		t.Errorf("expected the progress to be written once the interval passed, got %d writes", c.updates)
+	}
+}
+
+func TestClearNodeProgress(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	saved := recentConditionWrites.clock
+	recentConditionWrites.clock = clock
+	defer func() { recentConditionWrites.clock = saved }()
+	defer nodeProgress.forget(nodeProgressKey("node-clear", "hwmgr"))
+
+	c := &nodeStatusClient{node: &hwmgmtv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-clear", Namespace: "hwmgr"}}}
+	ctx := context.Background()
+	for _, percent := range []int{10, 12, 15} {
+		if err := ReportNodeProgress(ctx, c, "node-clear", "hwmgr", NodeProgress{Percent: percent, Message: "Updating"},
+			clock); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if c.updates != 1 {
+		t.Fatalf("expected the progress that did not advance enough to be skipped, got %d writes", c.updates)
+	}
+
+	// The latest progress skipped is written when the operation completes
+	if err := ClearNodeProgress(ctx, c, "node-clear", "hwmgr", clock); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	condition := meta.FindStatusCondition(c.node.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+	if c.updates != 2 || condition == nil || condition.Message != "Updating (15%)" {
+		t.Errorf("expected the latest skipped progress to be written, got %d writes and condition %v", c.updates, condition)
+	}
+	if _, exists := nodeProgress.entries[nodeProgressKey("node-clear", "hwmgr")]; exists {
+		t.Errorf("expected the progress of the node to be dropped")
+	}
+
+	if err := ClearNodeProgress(ctx, c, "node-clear", "hwmgr", clock); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.updates != 2 {
+		t.Errorf("expected no write without a skipped progress, got %d writes", c.updates)
+	}
+}
@@ -0,0 +1,22 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// NodeReplacedAnnotation marks a Node whose hardware failed and was replaced by a node on other hardware. The Node is
+// kept, with a Failed Provisioned condition, as a record of the failure, but no longer holds its hardware nor counts
+// towards the size of its NodePool. The value is the reason for the replacement.
+const NodeReplacedAnnotation = "hwmgr-plugin.oran.openshift.io/replaced"
+
+// IsNodeReplaced checks whether the node was replaced after a hardware failure
+func IsNodeReplaced(node *hwmgmtv1alpha1.Node) bool {
+	_, exists := node.GetAnnotations()[NodeReplacedAnnotation]
+	return exists
+}
@@ -8,10 +8,13 @@ package utils
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 
 	"github.com/google/uuid"
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -22,8 +25,9 @@ import (
 )
 
 const (
-	HwMgrNodeId         = "hwmgrNodeId"
-	NodeSpecNodePoolKey = "spec.nodePool"
+	HwMgrNodeId            = "hwmgrNodeId"
+	NodeSpecNodePoolKey    = "spec.nodePool"
+	NodeSpecHwMgrNodeIdKey = "spec.hwMgrNodeId"
 )
 
 // GetNode get a node resource for a provided name
@@ -93,10 +97,10 @@ func FindNodeUpdateInProgress(nodelist *hwmgmtv1alpha1.NodeList) *hwmgmtv1alpha1
 	return nil
 }
 
-// FindNextNodeToUpdate scans the nodelist to find the first node with stale HwProfile
+// FindNextNodeToUpdate scans the nodelist to find the first node with stale HwProfile, skipping cordoned nodes
 func FindNextNodeToUpdate(nodelist *hwmgmtv1alpha1.NodeList, groupname, newHwProfile string) *hwmgmtv1alpha1.Node {
 	for _, node := range nodelist.Items {
-		if groupname != node.Spec.GroupName {
+		if groupname != node.Spec.GroupName || IsNodeCordoned(&node) {
 			continue
 		}
 
@@ -140,7 +144,9 @@ func FindNodeConfigInProgress(nodelist *hwmgmtv1alpha1.NodeList) *hwmgmtv1alpha1
 	return nil
 }
 
-// SetNodeConditionStatus sets a condition on the node status with the provided condition type
+// SetNodeConditionStatus sets a condition on the node status with the provided condition type. The update is skipped if
+// the condition content is unchanged, or identical to one written within StatusUpdateDedupWindow from the version of the
+// node read.
 func SetNodeConditionStatus(
 	ctx context.Context,
 	c client.Client,
@@ -149,21 +155,133 @@ func SetNodeConditionStatus(
 	conditionStatus metav1.ConditionStatus,
 	reason, message string,
 ) error {
+	key := conditionWriteKey("Node", namespace, nodename, conditionType)
+
 	// nolint: wrapcheck
-	return retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+	err := retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
 		node := &hwmgmtv1alpha1.Node{}
 		if err := c.Get(ctx, types.NamespacedName{Name: nodename, Namespace: namespace}, node); err != nil {
 			return fmt.Errorf("failed to fetch Node: %w", err)
 		}
 
-		SetStatusCondition(
+		if !SetStatusCondition(
 			&node.Status.Conditions,
 			conditionType,
 			reason,
 			conditionStatus,
 			message,
-		)
+		) {
+			return nil
+		}
+		if recentConditionWrites.isDuplicate(key, node, conditionStatus, reason, message) {
+			return nil
+		}
+
+		staleVersion := node.ResourceVersion
+		if err := c.Status().Update(ctx, node); err != nil {
+			return err
+		}
+		recentConditionWrites.record(key, node, staleVersion, conditionStatus, reason, message)
+		if conditionType == string(hwmgmtv1alpha1.Configured) {
+			nodeProgress.touch(nodeProgressKey(nodename, namespace), recentConditionWrites.clock.Now())
+		}
+		return nil
+	})
+	if err != nil {
+		recentConditionWrites.forget(key)
+		return err // nolint: wrapcheck
+	}
+	return nil
+}
+
+// AppliedConfig captures the hardware configuration applied to a node from its HardwareProfile
+type AppliedConfig struct {
+	HwProfile           string            `json:"hwProfile"`
+	BiosAttributes      map[string]string `json:"biosAttributes,omitempty"`
+	BiosFirmwareVersion string            `json:"biosFirmwareVersion,omitempty"`
+	BmcFirmwareVersion  string            `json:"bmcFirmwareVersion,omitempty"`
+	BootOrder           []string          `json:"bootOrder,omitempty"`
+	Hash                string            `json:"hash"`
+}
+
+// NewAppliedConfig builds the AppliedConfig for a HardwareProfile. The hash covers the applied settings only, not the
+// profile name, so a node can be checked against any profile with the same content.
+func NewAppliedConfig(hwProfile *pluginv1alpha1.HardwareProfile) (*AppliedConfig, error) {
+	config := &AppliedConfig{
+		HwProfile:           hwProfile.Name,
+		BiosFirmwareVersion: hwProfile.Spec.BiosFirmware.Version,
+		BmcFirmwareVersion:  hwProfile.Spec.BmcFirmware.Version,
+	}
+	if len(hwProfile.Spec.Bios.Attributes) > 0 {
+		config.BiosAttributes = make(map[string]string, len(hwProfile.Spec.Bios.Attributes))
+		for key, value := range hwProfile.Spec.Bios.Attributes {
+			config.BiosAttributes[key] = value.String()
+		}
+	}
+	if hwProfile.Spec.BootOrder != nil {
+		for _, device := range hwProfile.Spec.BootOrder.Devices {
+			config.BootOrder = append(config.BootOrder, string(device))
+		}
+	}
+
+	// json.Marshal sorts map keys, so the hash is stable
+	data, err := json.Marshal(AppliedConfig{
+		BiosAttributes:      config.BiosAttributes,
+		BiosFirmwareVersion: config.BiosFirmwareVersion,
+		BmcFirmwareVersion:  config.BmcFirmwareVersion,
+		BootOrder:           config.BootOrder,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal applied config: %w", err)
+	}
+	config.Hash = fmt.Sprintf("%x", sha256.Sum256(data))
+
+	return config, nil
+}
+
+// GetAppliedConfig returns the AppliedConfig recorded on the node, or nil if none is recorded
+func GetAppliedConfig(node *hwmgmtv1alpha1.Node) (*AppliedConfig, error) {
+	value, exists := node.GetAnnotations()[AppliedConfigAnnotation]
+	if !exists {
+		return nil, nil
+	}
+	config := &AppliedConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", AppliedConfigAnnotation, err)
+	}
+	return config, nil
+}
+
+// SetAppliedConfig records the AppliedConfig and its hash as annotations on the node
+func SetAppliedConfig(
+	ctx context.Context,
+	c client.Client,
+	nodename, namespace string,
+	config *AppliedConfig) error {
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal applied config: %w", err)
+	}
+
+	// nolint: wrapcheck
+	return retry.OnError(retry.DefaultRetry, errors.IsConflict, func() error {
+		node := &hwmgmtv1alpha1.Node{}
+		if err := c.Get(ctx, types.NamespacedName{Name: nodename, Namespace: namespace}, node); err != nil {
+			return fmt.Errorf("failed to fetch Node: %w", err)
+		}
+
+		annotations := node.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		if annotations[AppliedConfigAnnotation] == string(data) {
+			return nil
+		}
+		annotations[AppliedConfigAnnotation] = string(data)
+		annotations[AppliedConfigHashAnnotation] = config.Hash
+		node.SetAnnotations(annotations)
 
-		return c.Status().Update(ctx, node)
+		return c.Update(ctx, node)
 	})
 }
@@ -0,0 +1,131 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestNodePoolExtensionsRoundTrip(t *testing.T) {
+	raw := map[string]string{
+		pluginv1alpha1.ExtensionsVersionKey:                        "v1",
+		pluginv1alpha1.ResourceTypeIdExtensionKey:                  "dell-r740",
+		pluginv1alpha1.CPUArchitectureExtensionKey:                 "x86_64",
+		"worker." + pluginv1alpha1.CPUArchitectureExtensionKey:     "aarch64",
+		pluginv1alpha1.HostnameTemplateExtensionKey:                "{{.Site}}-{{.Index}}",
+		"worker." + pluginv1alpha1.MinSizeExtensionKey:             "2",
+		"worker." + pluginv1alpha1.MaxSizeExtensionKey:             "6",
+		"worker." + pluginv1alpha1.HwMgrIdExtensionKey:             "dell-1",
+		pluginv1alpha1.SiteExtensionKey:                            "site-a",
+		"worker." + pluginv1alpha1.RackExtensionKey:                "r1",
+		pluginv1alpha1.TagsExtensionKey:                            "model=r740,zone=east",
+		"worker." + pluginv1alpha1.TagsExtensionKey:                "zone=west",
+		pluginv1alpha1.SpreadByExtensionKey:                        "rack",
+		"controller." + pluginv1alpha1.SpreadModeExtensionKey:      "required",
+		pluginv1alpha1.NetworkDataTemplateExtensionKey:             "network-data",
+		"worker." + pluginv1alpha1.NetworkDataTemplateExtensionKey: "secret/worker-network-data",
+		"vendor.setting": "value",
+	}
+
+	extensions, err := pluginv1alpha1.ParseNodePoolExtensions(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if extensions.ResourceTypeId != "dell-r740" || extensions.NodeGroups["worker"].CPUArchitecture != "aarch64" {
+		t.Errorf("unexpected extensions: %+v", extensions)
+	}
+	if hwMgrId := extensions.GetHwMgrId("worker", "metal3-1"); hwMgrId != "dell-1" {
+		t.Errorf("expected the node group hardware manager, got %s", hwMgrId)
+	}
+	if hwMgrId := extensions.GetHwMgrId("controller", "metal3-1"); hwMgrId != "metal3-1" {
+		t.Errorf("expected the nodepool hardware manager, got %s", hwMgrId)
+	}
+	if extensions.Other["vendor.setting"] != "value" {
+		t.Errorf("expected unconsumed extensions to be preserved, got %+v", extensions.Other)
+	}
+	if arch, key := extensions.GetCPUArchitecture("controller"); arch != "x86_64" || key != pluginv1alpha1.CPUArchitectureExtensionKey {
+		t.Errorf("expected the nodepool architecture for controller, got %s from %s", arch, key)
+	}
+	filters := extensions.GetResourceFilters("worker")
+	expectedFilters := pluginv1alpha1.ResourceFilters{
+		Site: "site-a",
+		Rack: "r1",
+		Tags: map[string]string{"model": "r740", "zone": "west"},
+	}
+	if !reflect.DeepEqual(filters, expectedFilters) {
+		t.Errorf("expected resource filters %+v, got %+v", expectedFilters, filters)
+	}
+	if policy := extensions.GetSpreadPolicy("controller"); policy != (pluginv1alpha1.SpreadPolicy{SpreadBy: "rack", Mode: pluginv1alpha1.SpreadModeRequired}) {
+		t.Errorf("unexpected spread policy for controller: %+v", policy)
+	}
+	if policy := extensions.GetSpreadPolicy("worker"); policy != (pluginv1alpha1.SpreadPolicy{SpreadBy: "rack", Mode: pluginv1alpha1.SpreadModePreferred}) {
+		t.Errorf("unexpected spread policy for worker: %+v", policy)
+	}
+	if template := extensions.GetNetworkDataTemplate("worker"); template != "secret/worker-network-data" {
+		t.Errorf("expected the node group network data template, got %s", template)
+	}
+	if template := extensions.GetNetworkDataTemplate("controller"); template != "network-data" {
+		t.Errorf("expected the nodepool network data template, got %s", template)
+	}
+	if result := extensions.ToMap(); !reflect.DeepEqual(result, raw) {
+		t.Errorf("expected %v, got %v", raw, result)
+	}
+
+	if _, err := pluginv1alpha1.ParseNodePoolExtensions(map[string]string{pluginv1alpha1.ExtensionsVersionKey: "v2"}); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+	if _, err := pluginv1alpha1.ParseNodePoolExtensions(map[string]string{"worker." + pluginv1alpha1.MaxSizeExtensionKey: "-1"}); err == nil {
+		t.Error("expected error for invalid size")
+	}
+	if _, err := pluginv1alpha1.ParseNodePoolExtensions(map[string]string{pluginv1alpha1.TagsExtensionKey: "model"}); err == nil {
+		t.Error("expected error for invalid tags")
+	}
+	if _, err := pluginv1alpha1.ParseNodePoolExtensions(map[string]string{pluginv1alpha1.SpreadModeExtensionKey: "strict"}); err == nil {
+		t.Error("expected error for invalid spread mode")
+	}
+	if _, err := pluginv1alpha1.ParseNodePoolExtensions(map[string]string{pluginv1alpha1.NetworkDataTemplateExtensionKey: "pod/nmstate"}); err == nil {
+		t.Error("expected error for invalid network data template")
+	}
+}
+
+func TestValidateNodePoolExtensions(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+				{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}},
+			},
+			Extensions: map[string]string{
+				"controller." + CPUArchitectureExtension: "x86_64",
+			},
+		},
+	}
+	if err := ValidateNodePoolExtensions(nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	nodepool.Spec.Extensions["worker."+CPUArchitectureExtension] = "x86_64"
+	if err := ValidateNodePoolExtensions(nodepool); !typederrors.IsInputError(err) {
+		t.Errorf("expected input error for unknown node group, got %v", err)
+	}
+	delete(nodepool.Spec.Extensions, "worker."+CPUArchitectureExtension)
+
+	nodepool.Spec.Extensions[pluginv1alpha1.HostnameTemplateExtensionKey] = "{{.Site"
+	if err := ValidateNodePoolExtensions(nodepool); !typederrors.IsInputError(err) {
+		t.Errorf("expected input error for invalid hostname template, got %v", err)
+	}
+	delete(nodepool.Spec.Extensions, pluginv1alpha1.HostnameTemplateExtensionKey)
+
+	nodepool.Spec.Extensions["controller."+pluginv1alpha1.SpreadModeExtensionKey] = "required"
+	if err := ValidateNodePoolExtensions(nodepool); !typederrors.IsInputError(err) {
+		t.Errorf("expected input error for spread mode without spread topology, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// A NodePool whose node groups are allocated from more than one HardwareManager, with the "<group>.hwMgrId"
+// extension, is split into a member NodePool for each HardwareManager. The members are owned by the NodePool and
+// labelled with its name, and each is processed by the adaptor of its HardwareManager like any other NodePool.
+const (
+	ParentNodePoolLabel = "hwmgr-plugin.oran.openshift.io/parent-nodepool"
+	// RolledBackAnnotation records the generation of a NodePool whose allocation was rolled back, after one of its
+	// HardwareManagers failed to allocate its node groups
+	RolledBackAnnotation = "hwmgr-plugin.oran.openshift.io/rolled-back-generation"
+)
+
+// GetNodePoolBackends returns the names of the node groups of the NodePool, by the HardwareManager they are allocated
+// from
+func GetNodePoolBackends(nodepool *hwmgmtv1alpha1.NodePool) (map[string][]string, error) {
+	extensions, err := GetNodePoolExtensions(nodepool)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make(map[string][]string)
+	for _, nodeGroup := range nodepool.Spec.NodeGroup {
+		hwMgrId := extensions.GetHwMgrId(nodeGroup.NodePoolData.Name, nodepool.Spec.HwMgrId)
+		backends[hwMgrId] = append(backends[hwMgrId], nodeGroup.NodePoolData.Name)
+	}
+	return backends, nil
+}
+
+// SortedBackends returns the HardwareManagers of the backends in a stable order
+func SortedBackends(backends map[string][]string) []string {
+	hwMgrIds := make([]string, 0, len(backends))
+	for hwMgrId := range backends {
+		hwMgrIds = append(hwMgrIds, hwMgrId)
+	}
+	slices.Sort(hwMgrIds)
+	return hwMgrIds
+}
+
+// MemberNodePoolName returns the name of the member NodePool of the HardwareManager
+func MemberNodePoolName(parent *hwmgmtv1alpha1.NodePool, hwMgrId string) string {
+	return fmt.Sprintf("%s-%s", parent.Name, hwMgrId)
+}
+
+// GetParentNodePool returns the name of the NodePool the object is a member of, or an empty string
+func GetParentNodePool(object client.Object) string {
+	return object.GetLabels()[ParentNodePoolLabel]
+}
+
+// NewMemberNodePool returns the member NodePool allocating the given node groups of the parent NodePool from the
+// HardwareManager. The extensions of the other node groups, and those selecting HardwareManagers, are left out.
+func NewMemberNodePool(parent *hwmgmtv1alpha1.NodePool, hwMgrId string, groups []string) (*hwmgmtv1alpha1.NodePool, error) {
+	extensions, err := GetNodePoolExtensions(parent)
+	if err != nil {
+		return nil, err
+	}
+	for group, groupExtensions := range extensions.NodeGroups {
+		if !slices.Contains(groups, group) {
+			delete(extensions.NodeGroups, group)
+			continue
+		}
+		groupExtensions.HwMgrId = ""
+		extensions.NodeGroups[group] = groupExtensions
+	}
+
+	member := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MemberNodePoolName(parent, hwMgrId),
+			Namespace: parent.Namespace,
+			Labels:    map[string]string{ParentNodePoolLabel: parent.Name},
+		},
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			CloudID:      parent.Spec.CloudID,
+			LocationSpec: parent.Spec.LocationSpec,
+			HwMgrId:      hwMgrId,
+			Extensions:   extensions.ToMap(),
+		},
+	}
+	for _, nodeGroup := range parent.Spec.NodeGroup {
+		if slices.Contains(groups, nodeGroup.NodePoolData.Name) {
+			member.Spec.NodeGroup = append(member.Spec.NodeGroup, nodeGroup)
+		}
+	}
+	return member, nil
+}
+
+// IsRolledBack checks whether the allocation of the current generation of the NodePool was rolled back
+func IsRolledBack(nodepool *hwmgmtv1alpha1.NodePool) bool {
+	return nodepool.GetAnnotations()[RolledBackAnnotation] == strconv.FormatInt(nodepool.Generation, 10)
+}
+
+// SetRolledBack records that the allocation of the current generation of the NodePool was rolled back
+func SetRolledBack(nodepool *hwmgmtv1alpha1.NodePool) {
+	annotations := nodepool.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[RolledBackAnnotation] = strconv.FormatInt(nodepool.Generation, 10)
+	nodepool.SetAnnotations(annotations)
+}
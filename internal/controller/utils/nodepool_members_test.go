@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestNodePoolMembers(t *testing.T) {
+	nodepool := &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "np1", Namespace: "hwmgr", Generation: 2},
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			CloudID:      "cloud-1",
+			LocationSpec: hwmgmtv1alpha1.LocationSpec{Site: "site-1"},
+			HwMgrId:      "metal3-1",
+			NodeGroup: []hwmgmtv1alpha1.NodeGroup{
+				{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "controller"}, Size: 3},
+				{NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: "worker"}, Size: 2},
+			},
+			Extensions: map[string]string{
+				"worker." + pluginv1alpha1.HwMgrIdExtensionKey:         "dell-1",
+				"worker." + pluginv1alpha1.CPUArchitectureExtensionKey: "aarch64",
+				"controller." + pluginv1alpha1.MaxSizeExtensionKey:     "3",
+			},
+		},
+	}
+
+	backends, err := GetNodePoolBackends(nodepool)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string][]string{"metal3-1": {"controller"}, "dell-1": {"worker"}}
+	if !reflect.DeepEqual(backends, expected) {
+		t.Errorf("expected %v, got %v", expected, backends)
+	}
+	if hwMgrIds := SortedBackends(backends); !reflect.DeepEqual(hwMgrIds, []string{"dell-1", "metal3-1"}) {
+		t.Errorf("unexpected backend order: %v", hwMgrIds)
+	}
+
+	member, err := NewMemberNodePool(nodepool, "dell-1", backends["dell-1"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.Name != "np1-dell-1" || member.Namespace != "hwmgr" || GetParentNodePool(member) != "np1" {
+		t.Errorf("unexpected member metadata: %+v", member.ObjectMeta)
+	}
+	if member.Spec.HwMgrId != "dell-1" || member.Spec.CloudID != "cloud-1" || member.Spec.Site != "site-1" ||
+		len(member.Spec.NodeGroup) != 1 || member.Spec.NodeGroup[0].NodePoolData.Name != "worker" {
+		t.Errorf("unexpected member spec: %+v", member.Spec)
+	}
+	expectedExtensions := map[string]string{
+		pluginv1alpha1.ExtensionsVersionKey:                    pluginv1alpha1.ExtensionsVersionV1,
+		"worker." + pluginv1alpha1.CPUArchitectureExtensionKey: "aarch64",
+	}
+	if !reflect.DeepEqual(member.Spec.Extensions, expectedExtensions) {
+		t.Errorf("expected member extensions %v, got %v", expectedExtensions, member.Spec.Extensions)
+	}
+	if backends, err := GetNodePoolBackends(member); err != nil || len(backends) != 1 {
+		t.Errorf("expected the member to use a single backend, got %v, %v", backends, err)
+	}
+
+	if IsRolledBack(nodepool) {
+		t.Error("expected the nodepool not to be rolled back")
+	}
+	SetRolledBack(nodepool)
+	if !IsRolledBack(nodepool) {
+		t.Error("expected the nodepool to be rolled back")
+	}
+	nodepool.Generation++
+	if IsRolledBack(nodepool) {
+		t.Error("expected a new generation not to be rolled back")
+	}
+}
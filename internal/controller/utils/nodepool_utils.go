@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"log/slog"
 
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,7 +25,7 @@ import (
 
 const (
 	NodepoolFinalizer = "oran-hwmgr-plugin/nodepool-finalizer"
-	ResourceTypeIdKey = "resourceTypeId"
+	ResourceTypeIdKey = pluginv1alpha1.ResourceTypeIdExtensionKey
 )
 
 var nodepoolGVK schema.GroupVersionKind
@@ -56,6 +58,15 @@ func GetNodePool(ctx context.Context, client client.Reader, key client.ObjectKey
 	return nil
 }
 
+// GetNodePoolExtensions returns the typed form of the extensions of the NodePool
+func GetNodePoolExtensions(nodepool *hwmgmtv1alpha1.NodePool) (*pluginv1alpha1.NodePoolExtensions, error) {
+	extensions, err := pluginv1alpha1.ParseNodePoolExtensions(nodepool.Spec.Extensions)
+	if err != nil {
+		return nil, typederrors.NewInputError("invalid nodepool extensions: %s", err.Error())
+	}
+	return extensions, nil
+}
+
 func GetResourceTypeId(nodepool *hwmgmtv1alpha1.NodePool) string {
 	return nodepool.Spec.Extensions[ResourceTypeIdKey]
 }
@@ -92,110 +103,36 @@ func UpdateNodePoolStatusCondition(
 	conditionReason hwmgmtv1alpha1.ConditionReason,
 	conditionStatus metav1.ConditionStatus,
 	message string) error {
-
-	SetStatusCondition(&nodepool.Status.Conditions,
-		string(conditionType),
-		string(conditionReason),
-		conditionStatus,
-		message)
-
-	// nolint: wrapcheck
-	err := RetryOnConflictOrRetriable(retry.DefaultRetry, func() error {
-		newNodepool := &hwmgmtv1alpha1.NodePool{}
-		if err := c.Get(ctx, client.ObjectKeyFromObject(nodepool), newNodepool); err != nil {
-			return err
-		}
-		SetStatusCondition(&newNodepool.Status.Conditions,
-			string(conditionType),
-			string(conditionReason),
-			conditionStatus,
-			message)
-		if err := c.Status().Update(ctx, newNodepool); err != nil {
-			return err
-		}
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to update nodepool condition: %s, %w", nodepool.Name, err)
-	}
-
-	return nil
+	return UpdateAllocationRequestCondition(ctx, c, NewNodePoolRequest(nodepool),
+		conditionType, conditionReason, conditionStatus, message)
 }
 
 func UpdateNodePoolProperties(
 	ctx context.Context,
 	c client.Client,
 	nodepool *hwmgmtv1alpha1.NodePool) error {
-
-	// nolint: wrapcheck
-	err := RetryOnConflictOrRetriable(retry.DefaultRetry, func() error {
-		newNodepool := &hwmgmtv1alpha1.NodePool{}
-		if err := c.Get(ctx, client.ObjectKeyFromObject(nodepool), newNodepool); err != nil {
-			return err
-		}
-		newNodepool.Status.Properties = nodepool.Status.Properties
-		if err := c.Status().Update(ctx, newNodepool); err != nil {
-			return err
-		}
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to update nodepool properties: %w", err)
-	}
-
-	return nil
+	return UpdateAllocationRequestNodeNames(ctx, c, NewNodePoolRequest(nodepool))
 }
 
 func UpdateNodePoolSelectedPools(
 	ctx context.Context,
 	c client.Client,
 	nodepool *hwmgmtv1alpha1.NodePool) error {
+	return UpdateAllocationRequestSelectedGroups(ctx, c, NewNodePoolRequest(nodepool))
+}
 
-	// nolint: wrapcheck
-	err := RetryOnConflictOrRetriable(retry.DefaultRetry, func() error {
-		newNodepool := &hwmgmtv1alpha1.NodePool{}
-		if err := c.Get(ctx, client.ObjectKeyFromObject(nodepool), newNodepool); err != nil {
-			return err
-		}
-		newNodepool.Status.SelectedPools = nodepool.Status.SelectedPools
-		if err := c.Status().Update(ctx, newNodepool); err != nil {
-			return err
-		}
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to update nodepool selectedPools: %w", err)
-	}
-
-	return nil
+func ResetNodePoolStatus(
+	ctx context.Context,
+	c client.Client,
+	nodepool *hwmgmtv1alpha1.NodePool) error {
+	return ResetAllocationRequestStatus(ctx, c, NewNodePoolRequest(nodepool))
 }
 
 func UpdateNodePoolPluginStatus(
 	ctx context.Context,
 	c client.Client,
 	nodepool *hwmgmtv1alpha1.NodePool) error {
-
-	// nolint: wrapcheck
-	err := RetryOnConflictOrRetriable(retry.DefaultRetry, func() error {
-		newNodepool := &hwmgmtv1alpha1.NodePool{}
-		if err := c.Get(ctx, client.ObjectKeyFromObject(nodepool), newNodepool); err != nil {
-			return err
-		}
-		newNodepool.Status.HwMgrPlugin.ObservedGeneration = newNodepool.ObjectMeta.Generation
-		if err := c.Status().Update(ctx, newNodepool); err != nil {
-			return err
-		}
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to update nodepool condition: %w", err)
-	}
-
-	return nil
+	return UpdateAllocationRequestObservedGeneration(ctx, c, NewNodePoolRequest(nodepool))
 }
 
 // DeriveNodePoolStatusFromNodes evaluates all child nodes and returns an appropriate
@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The adaptors roll a new hardware profile out to the nodes of a NodePool one node at a time: the node being updated is
+// progressed until done, then the next node with a stale profile is requested to update, while cordoned nodes hold the
+// rollout back. Once no node is left to update, the configuration of the NodePool is complete.
+
+// RequestNodeProfileUpdate sets the new hardware profile in the spec of a node and reports the update as requested. A
+// backend job tracking the update, if any, is recorded on the node along with the start of the profile update, timed
+// with the clock.
+func RequestNodeProfileUpdate(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node, hwProfile, jobId string,
+	clock Clock) error {
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.HwProfile = hwProfile
+	if jobId != "" {
+		SetJobId(node, jobId, clock)
+		StartNodeOperation(node, NodeOperationProfileUpdate, jobId, clock)
+	}
+	if err := c.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to patch Node %s in namespace %s: %w", node.Name, node.Namespace, err)
+	}
+
+	if err := SetNodeConditionStatus(ctx, c, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse,
+		string(hwmgmtv1alpha1.ConfigUpdate), "Update Requested"); err != nil {
+		return fmt.Errorf("failed to update node status (%s): %w", node.Name, err)
+	}
+	return nil
+}
+
+// FailNodeProfileUpdate reports the profile update of a node as failed on its Configured condition
+func FailNodeProfileUpdate(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node, message string) error {
+	if err := SetNodeConditionStatus(ctx, c, node.Name, node.Namespace,
+		string(hwmgmtv1alpha1.Configured), metav1.ConditionFalse, string(hwmgmtv1alpha1.Failed), message); err != nil {
+		return fmt.Errorf("failed to update node status (%s): %w", node.Name, err)
+	}
+	return nil
+}
+
+// CompleteNodeProfileUpdate records the hardware profile of a node as applied in its status, reporting the update as
+// done on its Configured condition
+func CompleteNodeProfileUpdate(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node) error {
+	node.Status.HwProfile = node.Spec.HwProfile
+	SetStatusCondition(&node.Status.Conditions,
+		string(hwmgmtv1alpha1.Configured),
+		string(hwmgmtv1alpha1.ConfigApplied),
+		metav1.ConditionTrue,
+		string(hwmgmtv1alpha1.ConfigSuccess))
+	if err := UpdateK8sCRStatus(ctx, c, node); err != nil {
+		return fmt.Errorf("failed to update status for node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+// HoldUpdateForCordonedNodes reports the update of the NodePool as held while cordoned nodes have a stale hardware
+// profile, returning true if it is held
+func HoldUpdateForCordonedNodes(ctx context.Context, c client.Client, logger *slog.Logger,
+	nodepool *hwmgmtv1alpha1.NodePool, nodelist *hwmgmtv1alpha1.NodeList) (bool, error) {
+	held := FindCordonedNodesPendingUpdate(nodepool, nodelist)
+	if len(held) == 0 {
+		return false, nil
+	}
+
+	logger.InfoContext(ctx, "Profile update held for cordoned nodes", slog.Any("nodes", held))
+	if err := UpdateNodePoolStatusCondition(ctx, c, nodepool,
+		hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.ConfigUpdate, metav1.ConditionFalse,
+		CordonedNodesMessage(held)); err != nil {
+		return true, fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	return true, nil
+}
+
+// CompleteNodePoolConfiguration reports the configuration of the NodePool as applied, and records the generation of
+// the NodePool handled by the plugin
+func CompleteNodePoolConfiguration(ctx context.Context, c client.Client, nodepool *hwmgmtv1alpha1.NodePool) error {
+	if err := UpdateNodePoolStatusCondition(ctx, c, nodepool,
+		hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.ConfigApplied, metav1.ConditionTrue, string(hwmgmtv1alpha1.ConfigSuccess)); err != nil {
+		return fmt.Errorf("failed to update status for NodePool %s: %w", nodepool.Name, err)
+	}
+	if err := UpdateNodePoolPluginStatus(ctx, c, nodepool); err != nil {
+		return fmt.Errorf("failed to update hwMgrPlugin observedGeneration Status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nodePatchClient records the patches of a node, along with the status updates recorded by nodeStatusClient
+type nodePatchClient struct {
+	*nodeStatusClient
+}
+
+func (c *nodePatchClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.node = obj.(*hwmgmtv1alpha1.Node).DeepCopy()
+	return nil
+}
+
+func TestRequestNodeProfileUpdate(t *testing.T) {
+	testcases := []struct {
+		name     string
+		nodename string
+		jobId    string
+	}{
+		{name: "tracked by a backend job", nodename: "node-job", jobId: "job-1"},
+		{name: "progressed by the adaptor", nodename: "node-adaptor"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &hwmgmtv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Name: tc.nodename, Namespace: "hwmgr"}}
+			node.Spec.HwProfile = "profile-v1"
+			c := &nodePatchClient{nodeStatusClient: &nodeStatusClient{node: node.DeepCopy()}}
+
+			start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+			if err := RequestNodeProfileUpdate(context.Background(), c, node, "profile-v2", tc.jobId,
+				clocktesting.NewFakeClock(start)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.node.Spec.HwProfile != "profile-v2" {
+				t.Errorf("expected the new profile in the spec, got %s", c.node.Spec.HwProfile)
+			}
+			if jobId := GetJobId(c.node); jobId != tc.jobId {
+				t.Errorf("expected jobId %q, got %q", tc.jobId, jobId)
+			}
+			if jobStart, exists := GetJobStartTime(c.node); exists != (tc.jobId != "") || exists && !jobStart.Equal(start) {
+				t.Errorf("expected the job to start at %v by the clock, got %v", start, jobStart)
+			}
+			condition := meta.FindStatusCondition(c.node.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+			if condition == nil || condition.Reason != string(hwmgmtv1alpha1.ConfigUpdate) || condition.Message != "Update Requested" {
+				t.Errorf("expected the update to be reported as requested, got %+v", condition)
+			}
+		})
+	}
+}
+
+func TestNodeProfileUpdateConfiguredCondition(t *testing.T) {
+	testcases := []struct {
+		name     string
+		nodename string
+		update   func(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node) error
+		status   metav1.ConditionStatus
+		reason   string
+		message  string
+		profile  string
+	}{
+		{
+			name:     "requested",
+			nodename: "node-configured-requested",
+			update: func(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node) error {
+				return RequestNodeProfileUpdate(ctx, c, node, "profile-v2", "job-1", clocktesting.NewFakeClock(time.Now()))
+			},
+			status:  metav1.ConditionFalse,
+			reason:  string(hwmgmtv1alpha1.ConfigUpdate),
+			message: "Update Requested",
+			profile: "profile-v1",
+		},
+		{
+			name:     "failed",
+			nodename: "node-configured-failed",
+			update: func(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node) error {
+				return FailNodeProfileUpdate(ctx, c, node, "Profile update to profile-v2 failed: job failed")
+			},
+			status:  metav1.ConditionFalse,
+			reason:  string(hwmgmtv1alpha1.Failed),
+			message: "Profile update to profile-v2 failed: job failed",
+			profile: "profile-v1",
+		},
+		{
+			name:     "timed out",
+			nodename: "node-configured-timeout",
+			update: func(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node) error {
+				return FailNodeProfileUpdate(ctx, c, node, "Profile update to profile-v2 timed out after 1h0m0s, jobId=job-1")
+			},
+			status:  metav1.ConditionFalse,
+			reason:  string(hwmgmtv1alpha1.Failed),
+			message: "Profile update to profile-v2 timed out after 1h0m0s, jobId=job-1",
+			profile: "profile-v1",
+		},
+		{
+			name:     "completed",
+			nodename: "node-configured-completed",
+			update: func(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node) error {
+				return CompleteNodeProfileUpdate(ctx, c, node)
+			},
+			status:  metav1.ConditionTrue,
+			reason:  string(hwmgmtv1alpha1.ConfigApplied),
+			message: string(hwmgmtv1alpha1.ConfigSuccess),
+			profile: "profile-v2",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &hwmgmtv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Name: tc.nodename, Namespace: "hwmgr"}}
+			node.Spec.HwProfile = "profile-v2"
+			node.Status.HwProfile = "profile-v1"
+			SetStatusCondition(&node.Status.Conditions, string(hwmgmtv1alpha1.Configured),
+				string(hwmgmtv1alpha1.ConfigUpdate), metav1.ConditionFalse, "In progress")
+			c := &nodePatchClient{nodeStatusClient: &nodeStatusClient{node: node.DeepCopy()}}
+
+			if err := tc.update(context.Background(), c, node); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			condition := meta.FindStatusCondition(c.node.Status.Conditions, string(hwmgmtv1alpha1.Configured))
+			if condition == nil || condition.Status != tc.status || condition.Reason != tc.reason || condition.Message != tc.message {
+				t.Errorf("expected Configured %s/%s %q, got %+v", tc.status, tc.reason, tc.message, condition)
+			}
+			if c.node.Status.HwProfile != tc.profile {
+				t.Errorf("expected the status profile %s, got %s", tc.profile, c.node.Status.HwProfile)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"net/http"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+// rateLimiter is the rate limiter shared by all clients of a HardwareManager, along with the settings it was created
+// with, so that it is replaced when the settings change
+type rateLimiter struct {
+	qps     int32
+	burst   int32
+	limiter flowcontrol.RateLimiter
+}
+
+var (
+	rateLimitersMu sync.Mutex
+	rateLimiters   = make(map[types.UID]*rateLimiter)
+)
+
+// GetRateLimit returns the QPS and burst of the rate limit of a HardwareManager, and whether its requests are limited
+func GetRateLimit(hwmgr *pluginv1alpha1.HardwareManager) (int32, int32, bool) {
+	policy := hwmgr.Spec.RateLimit
+	if policy == nil || policy.QPS <= 0 {
+		return 0, 0, false
+	}
+	burst := policy.QPS
+	if policy.Burst != nil && *policy.Burst > 0 {
+		burst = *policy.Burst
+	}
+	return policy.QPS, burst, true
+}
+
+// rateLimiterFor returns the rate limiter of a HardwareManager, or nil if its requests are not limited
+func rateLimiterFor(hwmgr *pluginv1alpha1.HardwareManager) flowcontrol.RateLimiter {
+	qps, burst, limited := GetRateLimit(hwmgr)
+
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+
+	if !limited {
+		delete(rateLimiters, hwmgr.UID)
+		return nil
+	}
+	current, exists := rateLimiters[hwmgr.UID]
+	if !exists || current.qps != qps || current.burst != burst {
+		current = &rateLimiter{qps: qps, burst: burst, limiter: flowcontrol.NewTokenBucketRateLimiter(float32(qps), int(burst))}
+		rateLimiters[hwmgr.UID] = current
+	}
+	return current.limiter
+}
+
+// rateLimitTransport holds back the requests above the rate limit of the HardwareManager until their turn
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter flowcontrol.RateLimiter
+	name    string
+}
+
+// NewRateLimitTransport wraps the transport used for requests to the backend of a HardwareManager, limiting their rate
+// as configured in its spec. The limit is shared by all the clients of the HardwareManager, so that a burst of
+// reconciles, such as when many NodePools are created at once, does not overload the backend.
+func NewRateLimitTransport(base http.RoundTripper, hwmgr *pluginv1alpha1.HardwareManager) http.RoundTripper {
+	limiter := rateLimiterFor(hwmgr)
+	if limiter == nil {
+		return base
+	}
+	return &rateLimitTransport{base: base, limiter: limiter, name: hwmgr.Name}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The wait fails immediately if the request would not get its turn before the deadline of its context
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, typederrors.NewRetriableError(err, "request to hardware manager %s held back by its rate limit", t.name)
+	}
+	return t.base.RoundTrip(req) // nolint: wrapcheck
+}
@@ -0,0 +1,84 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	typederrors "github.com/openshift-kni/oran-hwmgr-plugin/internal/typed-errors"
+)
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	t.requests++
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestGetRateLimit(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if _, _, limited := GetRateLimit(hwmgr); limited {
+		t.Errorf("expected no rate limit by default")
+	}
+
+	hwmgr.Spec.RateLimit = &pluginv1alpha1.RateLimitPolicy{QPS: 5}
+	if qps, burst, limited := GetRateLimit(hwmgr); !limited || qps != 5 || burst != 5 {
+		t.Errorf("expected the burst to default to the QPS, got %d, %d, %t", qps, burst, limited)
+	}
+
+	hwmgr.Spec.RateLimit.Burst = ptr.To(int32(20))
+	if qps, burst, _ := GetRateLimit(hwmgr); qps != 5 || burst != 20 {
+		t.Errorf("expected the configured burst, got %d, %d", qps, burst)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	base := &countingTransport{}
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	hwmgr.Name = "hwmgr-rate-limit"
+	hwmgr.UID = "hwmgr-rate-limit-uid"
+
+	if tr := NewRateLimitTransport(base, hwmgr); tr != base {
+		t.Errorf("expected the transport to be unchanged without a rate limit")
+	}
+
+	hwmgr.Spec.RateLimit = &pluginv1alpha1.RateLimitPolicy{QPS: 1, Burst: ptr.To(int32(2))}
+	first := NewRateLimitTransport(base, hwmgr).(*rateLimitTransport)
+	second := NewRateLimitTransport(base, hwmgr).(*rateLimitTransport)
+	if first.limiter != second.limiter {
+		t.Errorf("expected the clients of the HardwareManager to share the rate limiter")
+	}
+
+	// The burst is sent at once, while the next request cannot get its turn before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://hwmgr.example.com/v1/resources", nil)
+	for i := 0; i < 2; i++ {
+		if _, err := first.RoundTrip(req); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+	if _, err := second.RoundTrip(req); err == nil || !typederrors.IsRetriableError(err) {
+		t.Errorf("expected the request above the limit to be held back, got %v", err)
+	}
+	if base.requests != 2 {
+		t.Errorf("expected 2 requests to be sent, got %d", base.requests)
+	}
+
+	hwmgr.Spec.RateLimit.QPS = 10
+	if third := NewRateLimitTransport(base, hwmgr).(*rateLimitTransport); third.limiter == first.limiter {
+		t.Errorf("expected the rate limiter to be replaced when the rate limit changes")
+	}
+}
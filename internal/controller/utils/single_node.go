@@ -0,0 +1,45 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// Single-node NodePools, used for single-node OpenShift at the far edge, are by far the most common. Their allocation
+// needs none of the placement logic across nodes, and a single node to wait on, so they take a faster path: placement
+// policies are trivially met and skipped, allocation is checked from the status of the NodePool alone, and their
+// progress is polled more often.
+
+// SingleNodePollInterval is the interval at which the progress of a single-node NodePool is polled
+const SingleNodePollInterval = 5 * time.Second
+
+// IsSingleNodePool checks whether the NodePool allocates a single node across all its node groups
+func IsSingleNodePool(nodepool *hwmgmtv1alpha1.NodePool) bool {
+	sizes, err := GetEffectiveNodeGroupSizes(nodepool)
+	if err != nil {
+		return false
+	}
+	total := 0
+	for _, size := range sizes {
+		total += size
+	}
+	return total == 1
+}
+
+// RequeueWhileInProgress returns the result requeueing a NodePool whose allocation or configuration is in progress,
+// polling single-node NodePools more often
+func RequeueWhileInProgress(nodepool *hwmgmtv1alpha1.NodePool) ctrl.Result {
+	if IsSingleNodePool(nodepool) {
+		return RequeueWithCustomInterval(SingleNodePollInterval)
+	}
+	return RequeueWithShortInterval()
+}
@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsSingleNodePool(t *testing.T) {
+	newNodePool := func(sizes ...int) *hwmgmtv1alpha1.NodePool {
+		nodepool := &hwmgmtv1alpha1.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "np1"}}
+		for i, size := range sizes {
+			nodepool.Spec.NodeGroup = append(nodepool.Spec.NodeGroup, hwmgmtv1alpha1.NodeGroup{
+				NodePoolData: hwmgmtv1alpha1.NodePoolData{Name: []string{"controller", "worker"}[i]},
+				Size:         size,
+			})
+		}
+		return nodepool
+	}
+
+	if !IsSingleNodePool(newNodePool(1)) {
+		t.Error("expected a pool of one node to be single-node")
+	}
+	if !IsSingleNodePool(newNodePool(1, 0)) {
+		t.Error("expected a pool of one node and an empty group to be single-node")
+	}
+	if IsSingleNodePool(newNodePool(3, 2)) {
+		t.Error("expected a pool of five nodes not to be single-node")
+	}
+
+	scaled := newNodePool(1, 0)
+	scaled.Annotations = map[string]string{DesiredSizeAnnotation: `{"worker": 2}`}
+	if IsSingleNodePool(scaled) {
+		t.Error("expected the desired size to be taken into account")
+	}
+
+	if result := RequeueWhileInProgress(newNodePool(1)); result.RequeueAfter != SingleNodePollInterval {
+		t.Errorf("expected single-node pools to be polled every %s, got %s", SingleNodePollInterval, result.RequeueAfter)
+	}
+	if result := RequeueWhileInProgress(newNodePool(3)); result != RequeueWithShortInterval() {
+		t.Errorf("expected other pools to be polled at the short interval, got %s", result.RequeueAfter)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+const (
+	// MaxSouthboundErrors is the number of failed requests kept for each HardwareManager
+	MaxSouthboundErrors = 5
+
+	// SouthboundErrorMaxAge is how long a failed request is kept, so that the errors of a backend that recovered, or
+	// of a deleted HardwareManager, do not linger
+	SouthboundErrorMaxAge = time.Hour
+
+	// southboundErrorBodyLimit bounds the portion of an error response body included in the message
+	southboundErrorBodyLimit = 512
+
+	// southboundErrorMessageLimit bounds the length of the sanitized message
+	southboundErrorMessageLimit = 256
+)
+
+var (
+	// sensitiveValuePattern matches key/value pairs, in JSON or form encoding, whose key suggests a secret value
+	sensitiveValuePattern = regexp.MustCompile(
+		`(?i)("?[a-z_-]*(?:password|secret|token|credential)[a-z_-]*"?\s*[:=]\s*)("[^"]*"|[^\s&,"}]+)`)
+
+	// bearerPattern matches the credentials of an Authorization header
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+
+	urlPattern = regexp.MustCompile(`https?://[^\s"']+`)
+)
+
+var (
+	southboundErrorsMu sync.Mutex
+	// southboundErrors holds the most recent failed requests to the backend of each HardwareManager, newest first
+	southboundErrors = make(map[types.UID][]pluginv1alpha1.SouthboundError)
+)
+
+// pruneSouthboundErrors drops the failed requests older than SouthboundErrorMaxAge, and the HardwareManagers left
+// without any. The caller must hold southboundErrorsMu.
+func pruneSouthboundErrors(now time.Time) {
+	for uid, entries := range southboundErrors {
+		kept := 0
+		for kept < len(entries) && now.Sub(entries[kept].Time.Time) < SouthboundErrorMaxAge {
+			kept++
+		}
+		if kept == 0 {
+			delete(southboundErrors, uid)
+		} else if kept < len(entries) {
+			southboundErrors[uid] = entries[:kept]
+		}
+	}
+}
+
+// recordSouthboundError records a failed request to the backend of a HardwareManager, pruning the log at the given time
+func recordSouthboundError(uid types.UID, entry pluginv1alpha1.SouthboundError, now time.Time) {
+	southboundErrorsMu.Lock()
+	defer southboundErrorsMu.Unlock()
+
+	entries := append([]pluginv1alpha1.SouthboundError{entry}, southboundErrors[uid]...)
+	if len(entries) > MaxSouthboundErrors {
+		entries = entries[:MaxSouthboundErrors]
+	}
+	southboundErrors[uid] = entries
+	pruneSouthboundErrors(now)
+}
+
+// GetSouthboundErrors returns the most recent failed requests to the backend of a HardwareManager, newest first, for
+// reporting in its status. The failed requests older than SouthboundErrorMaxAge by the clock are dropped.
+func GetSouthboundErrors(hwmgr *pluginv1alpha1.HardwareManager, clock Clock) []pluginv1alpha1.SouthboundError {
+	southboundErrorsMu.Lock()
+	defer southboundErrorsMu.Unlock()
+
+	pruneSouthboundErrors(clock.Now())
+	entries := southboundErrors[hwmgr.UID]
+	if len(entries) == 0 {
+		return nil
+	}
+	return append([]pluginv1alpha1.SouthboundError(nil), entries...)
+}
+
+// southboundErrorTransport records the requests that fail with a transport error or an error response
+type southboundErrorTransport struct {
+	base  http.RoundTripper
+	uid   types.UID
+	clock Clock
+}
+
+// NewSouthboundErrorTransport wraps the transport used for requests to the backend of a HardwareManager, recording the
+// failed requests so that they can be reported in its status, timed with the clock
+func NewSouthboundErrorTransport(base http.RoundTripper, hwmgr *pluginv1alpha1.HardwareManager, clock Clock) http.RoundTripper {
+	return &southboundErrorTransport{base: base, uid: hwmgr.UID, clock: clock}
+}
+
+func (t *southboundErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	now := t.clock.Now()
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// Cancelled by the caller, which says nothing about the backend
+	case err != nil:
+		recordSouthboundError(t.uid, newSouthboundError(req, 0, err.Error(), now), now)
+	case resp.StatusCode >= http.StatusBadRequest:
+		recordSouthboundError(t.uid, newSouthboundError(req, resp.StatusCode, errorResponseMessage(resp), now), now)
+	}
+	return resp, err // nolint: wrapcheck
+}
+
+func newSouthboundError(req *http.Request, status int, message string, now time.Time) pluginv1alpha1.SouthboundError {
+	return pluginv1alpha1.SouthboundError{
+		Time:       metav1.NewTime(now).Rfc3339Copy(),
+		Operation:  fmt.Sprintf("%s %s", req.Method, req.URL.Path),
+		HTTPStatus: status,
+		Message:    SanitizeSouthboundMessage(message),
+	}
+}
+
+// errorResponseMessage builds a message from the status and the start of the body of an error response, leaving the
+// body intact for the caller
+func errorResponseMessage(resp *http.Response) string {
+	if resp.Body == nil {
+		return resp.Status
+	}
+
+	head, _ := io.ReadAll(io.LimitReader(resp.Body, southboundErrorBodyLimit))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+
+	if len(bytes.TrimSpace(head)) == 0 {
+		return resp.Status
+	}
+	return resp.Status + ": " + string(head)
+}
+
+// SanitizeSouthboundMessage removes credentials, URL user info and query parameters from an error message, and bounds
+// its length
+func SanitizeSouthboundMessage(message string) string {
+	message = urlPattern.ReplaceAllStringFunc(message, func(raw string) string {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return "<url>"
+		}
+		parsed.User = nil
+		parsed.RawQuery = ""
+		parsed.Fragment = ""
+		return parsed.String()
+	})
+	message = bearerPattern.ReplaceAllString(message, "$1 <redacted>")
+	message = sensitiveValuePattern.ReplaceAllString(message, "${1}<redacted>")
+	message = strings.Join(strings.Fields(message), " ")
+
+	if len(message) > southboundErrorMessageLimit {
+		message = strings.ToValidUTF8(message[:southboundErrorMessageLimit], "") + "..."
+	}
+	return message
+}
@@ -0,0 +1,149 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSanitizeSouthboundMessage(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected string
+	}{
+		{
+			message:  `Get "https://user:pw@hwmgr/v1/pools?token=abc": dial tcp: connection refused`,
+			expected: `Get "https://hwmgr/v1/pools": dial tcp: connection refused`,
+		},
+		{
+			message:  `401 Unauthorized: {"error": "invalid", "access_token": "abc123"}`,
+			expected: `401 Unauthorized: {"error": "invalid", "access_token": <redacted>}`,
+		},
+		{
+			message:  "rejected header Authorization: Bearer eyJhbGciOi.abc",
+			expected: "rejected header Authorization: Bearer <redacted>",
+		},
+		{
+			message:  "grant_type=password&client_secret=s3cret&password=hunter2",
+			expected: "grant_type=password&client_secret=<redacted>&password=<redacted>",
+		},
+		{
+			message:  "500 Internal Server Error:\n  {\n  \"message\": \"db down\"\n}",
+			expected: `500 Internal Server Error: { "message": "db down" }`,
+		},
+	}
+
+	for _, test := range tests {
+		if actual := SanitizeSouthboundMessage(test.message); actual != test.expected {
+			t.Errorf("SanitizeSouthboundMessage(%q): expected %q, got %q", test.message, test.expected, actual)
+		}
+	}
+
+	long := SanitizeSouthboundMessage(strings.Repeat("x", 1000))
+	if len(long) != southboundErrorMessageLimit+len("...") {
+		t.Errorf("expected message to be truncated, got %d characters", len(long))
+	}
+}
+
+func TestSouthboundErrorTransport(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{UID: "southbound-errors-test"}}
+	clock := clocktesting.NewFakeClock(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+
+	responses := []func() (*http.Response, error){
+		func() (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		},
+		func() (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	for i := 0; i < MaxSouthboundErrors; i++ {
+		responses = append(responses, func() (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable",
+				Body: io.NopCloser(strings.NewReader(`{"message": "maintenance"}`))}, nil
+		})
+	}
+
+	next := 0
+	tr := NewSouthboundErrorTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		next++
+		return responses[next-1]()
+	}), hwmgr, clock)
+
+	for range responses {
+		req := httptest.NewRequest(http.MethodGet, "https://hwmgr/v1/pools?page=2", nil)
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			continue
+		}
+		// The body must still be readable by the caller
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK && string(body) != `{"message": "maintenance"}` {
+			t.Errorf("unexpected body passed to the caller: %q", body)
+		}
+	}
+
+	lastErrors := GetSouthboundErrors(hwmgr, clock)
+	if len(lastErrors) != MaxSouthboundErrors {
+		t.Fatalf("expected %d errors, got %d", MaxSouthboundErrors, len(lastErrors))
+	}
+	latest := lastErrors[0]
+	if latest.Operation != "GET /v1/pools" || latest.HTTPStatus != http.StatusServiceUnavailable ||
+		latest.Message != `503 Service Unavailable: {"message": "maintenance"}` || !latest.Time.Time.Equal(clock.Now()) {
+		t.Errorf("unexpected error recorded: %+v", latest)
+	}
+}
+
+func TestSouthboundErrorExpiry(t *testing.T) {
+	active := &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{UID: "southbound-errors-active"}}
+	deleted := &pluginv1alpha1.HardwareManager{ObjectMeta: metav1.ObjectMeta{UID: "southbound-errors-deleted"}}
+	clock := clocktesting.NewFakeClock(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+
+	fail := func(hwmgr *pluginv1alpha1.HardwareManager, path string) {
+		tr := NewSouthboundErrorTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}), hwmgr, clock)
+		_, _ = tr.RoundTrip(httptest.NewRequest(http.MethodGet, "https://hwmgr"+path, nil))
+	}
+
+	fail(deleted, "/v1/pools")
+	fail(active, "/v1/pools")
+	clock.Step(SouthboundErrorMaxAge)
+	fail(active, "/v1/jobs")
+
+	lastErrors := GetSouthboundErrors(active, clock)
+	if len(lastErrors) != 1 || lastErrors[0].Operation != "GET /v1/jobs" {
+		t.Errorf("expected only the recent error to be kept, got %+v", lastErrors)
+	}
+	if lastErrors := GetSouthboundErrors(deleted, clock); lastErrors != nil {
+		t.Errorf("expected the expired errors to be dropped, got %+v", lastErrors)
+	}
+
+	southboundErrorsMu.Lock()
+	_, exists := southboundErrors[deleted.UID]
+	southboundErrorsMu.Unlock()
+	if exists {
+		t.Errorf("expected the HardwareManager without recent errors to be removed")
+	}
+}
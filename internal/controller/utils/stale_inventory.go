@@ -0,0 +1,53 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StaleMarker records whether an inventory response was served from a snapshot rather than a live query, so that the
+// inventory server can flag the response as stale
+type StaleMarker struct {
+	mu    sync.Mutex
+	stale bool
+	asOf  time.Time
+}
+
+type staleMarkerKey struct{}
+
+// WithStaleMarker returns a context carrying a new StaleMarker, for the handling of an inventory request
+func WithStaleMarker(ctx context.Context) (context.Context, *StaleMarker) {
+	marker := &StaleMarker{}
+	return context.WithValue(ctx, staleMarkerKey{}, marker), marker
+}
+
+// MarkInventoryStale flags the response to the request being handled with ctx as served from a snapshot taken at asOf.
+// It does nothing if the context carries no StaleMarker.
+func MarkInventoryStale(ctx context.Context, asOf time.Time) {
+	marker, ok := ctx.Value(staleMarkerKey{}).(*StaleMarker)
+	if !ok {
+		return
+	}
+
+	marker.mu.Lock()
+	defer marker.mu.Unlock()
+	// Report the oldest snapshot, if several were used for the response
+	if !marker.stale || asOf.Before(marker.asOf) {
+		marker.asOf = asOf
+	}
+	marker.stale = true
+}
+
+// Stale returns true, with the time of the snapshot, if the response was served from a snapshot
+func (m *StaleMarker) Stale() (time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.asOf, m.stale
+}
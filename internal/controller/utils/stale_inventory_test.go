@@ -0,0 +1,31 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMarkInventoryStale(t *testing.T) {
+	// Marking a context without a marker is a no-op
+	MarkInventoryStale(context.Background(), time.Now())
+
+	ctx, marker := WithStaleMarker(context.Background())
+	if _, stale := marker.Stale(); stale {
+		t.Fatalf("expected response not to be stale")
+	}
+
+	older := time.Now().Add(-time.Hour)
+	MarkInventoryStale(ctx, time.Now())
+	MarkInventoryStale(ctx, older)
+	asOf, stale := marker.Stale()
+	if !stale || !asOf.Equal(older) {
+		t.Errorf("expected stale response as of %s, got %s, %v", older, asOf, stale)
+	}
+}
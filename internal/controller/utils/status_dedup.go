@@ -0,0 +1,103 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StatusUpdateDedupWindow is the period during which a condition identical to the last one written for the same
+// object is not written again while the object read is still the version the write was made from, as the informer
+// cache may not yet reflect the write and steady-state polling tends to repeat the same update on each pass
+const StatusUpdateDedupWindow = 5 * time.Second
+
+// conditionWrite is a condition written by this process, with the versions of the object before and after the write
+type conditionWrite struct {
+	uid            types.UID
+	status         metav1.ConditionStatus
+	reason         string
+	message        string
+	staleVersion   string
+	writtenVersion string
+	at             time.Time
+}
+
+// conditionWriteCache tracks the last condition written for each object and condition type. The cache is shared by
+// the adaptors of the process, so it keeps its own clock, which unit tests replace.
+type conditionWriteCache struct {
+	mu      sync.Mutex
+	window  time.Duration
+	clock   Clock
+	entries map[string]conditionWrite
+}
+
+var recentConditionWrites = newConditionWriteCache(StatusUpdateDedupWindow, nil)
+
+func newConditionWriteCache(window time.Duration, clock Clock) *conditionWriteCache {
+	return &conditionWriteCache{
+		window:  window,
+		clock:   ClockOrReal(clock),
+		entries: make(map[string]conditionWrite),
+	}
+}
+
+// conditionWriteKey identifies a condition of an object
+func conditionWriteKey(kind, namespace, name, conditionType string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", kind, namespace, name, conditionType)
+}
+
+// isDuplicate returns true if the same condition was written for the key within the window, from or to the version of
+// the object read. An object updated by another writer since, or recreated with the same name, is never a duplicate.
+func (c *conditionWriteCache) isDuplicate(key string, object metav1.Object, status metav1.ConditionStatus,
+	reason, message string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last, exists := c.entries[key]
+	if !exists || c.clock.Since(last.at) >= c.window || last.uid != object.GetUID() {
+		return false
+	}
+	version := object.GetResourceVersion()
+	return (version == last.staleVersion || version == last.writtenVersion) &&
+		last.status == status && last.reason == reason && last.message == message
+}
+
+// record saves the condition written for the key and the versions of the object before and after the write, dropping
+// expired entries
+func (c *conditionWriteCache) record(key string, object metav1.Object, staleVersion string,
+	status metav1.ConditionStatus, reason, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for k, entry := range c.entries {
+		if now.Sub(entry.at) >= c.window {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = conditionWrite{
+		uid:            object.GetUID(),
+		status:         status,
+		reason:         reason,
+		message:        message,
+		staleVersion:   staleVersion,
+		writtenVersion: object.GetResourceVersion(),
+		at:             now,
+	}
+}
+
+// forget drops the entry for the key, so that the next update is not skipped
+func (c *conditionWriteCache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
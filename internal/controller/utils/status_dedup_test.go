@@ -0,0 +1,89 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestSetStatusConditionChanged(t *testing.T) {
+	var conditions []metav1.Condition
+
+	if !SetStatusCondition(&conditions, "Provisioned", "InProgress", metav1.ConditionFalse, "Allocating") {
+		t.Errorf("expected new condition to be a change")
+	}
+	if SetStatusCondition(&conditions, "Provisioned", "InProgress", metav1.ConditionFalse, "Allocating") {
+		t.Errorf("expected identical condition not to be a change")
+	}
+	if !SetStatusCondition(&conditions, "Provisioned", "InProgress", metav1.ConditionFalse, "Configuring") {
+		t.Errorf("expected new message to be a change")
+	}
+	if !SetStatusCondition(&conditions, "Provisioned", "Completed", metav1.ConditionTrue, "Configuring") {
+		t.Errorf("expected new status to be a change")
+	}
+}
+
+func TestConditionWriteCache(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	cache := newConditionWriteCache(50*time.Millisecond, clock)
+	key := conditionWriteKey("NodePool", "ns", "np1", "Provisioned")
+	other := conditionWriteKey("NodePool", "ns", "np2", "Provisioned")
+
+	stale := &metav1.ObjectMeta{UID: "uid-1", ResourceVersion: "1"}
+	written := &metav1.ObjectMeta{UID: "uid-1", ResourceVersion: "2"}
+	if cache.isDuplicate(key, stale, metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected no duplicate before any write")
+	}
+
+	cache.record(key, written, "1", metav1.ConditionFalse, "InProgress", "Allocating")
+	if !cache.isDuplicate(key, stale, metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected duplicate within window while the write is not yet visible")
+	}
+	if !cache.isDuplicate(key, written, metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected duplicate within window for the written version")
+	}
+	if cache.isDuplicate(key, written, metav1.ConditionFalse, "InProgress", "Configuring") {
+		t.Errorf("expected different message not to be a duplicate")
+	}
+	if cache.isDuplicate(key, written, metav1.ConditionTrue, "InProgress", "Allocating") {
+		t.Errorf("expected different status not to be a duplicate")
+	}
+	if cache.isDuplicate(key, written, metav1.ConditionFalse, "Failed", "Allocating") {
+		t.Errorf("expected different reason not to be a duplicate")
+	}
+	if cache.isDuplicate(other, written, metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected different object not to be a duplicate")
+	}
+	if cache.isDuplicate(key, &metav1.ObjectMeta{UID: "uid-1", ResourceVersion: "3"},
+		metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected an object updated by another writer not to be a duplicate")
+	}
+	if cache.isDuplicate(key, &metav1.ObjectMeta{UID: "uid-2", ResourceVersion: "2"},
+		metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected a recreated object not to be a duplicate")
+	}
+
+	cache.forget(key)
+	if cache.isDuplicate(key, written, metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected no duplicate after forget")
+	}
+
+	cache.record(key, written, "1", metav1.ConditionFalse, "InProgress", "Allocating")
+	clock.Step(60 * time.Millisecond)
+	if cache.isDuplicate(key, written, metav1.ConditionFalse, "InProgress", "Allocating") {
+		t.Errorf("expected no duplicate after window")
+	}
+
+	cache.record(other, written, "1", metav1.ConditionTrue, "Completed", "Done")
+	if _, exists := cache.entries[key]; exists {
+		t.Errorf("expected expired entry to be pruned")
+	}
+}
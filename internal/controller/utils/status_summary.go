@@ -0,0 +1,144 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// The Node and NodePool CRDs are owned by O2IMS, so the plugin publishes a status summary of each as metadata.
+// The phase is a label, so that it can be shown with "kubectl get -L", while the other fields are annotations.
+const (
+	PhaseLabel            = "hwmgr-plugin.oran.openshift.io/phase"
+	PhaseSinceAnnotation  = "hwmgr-plugin.oran.openshift.io/phase-since"
+	HardwareRefAnnotation = "hwmgr-plugin.oran.openshift.io/hardware-ref"
+	HwProfileAnnotation   = "hwmgr-plugin.oran.openshift.io/hw-profile"
+)
+
+// Phase summarizes the provisioning progress of a Node or NodePool
+type Phase string
+
+const (
+	PhaseProvisioning Phase = "Provisioning"
+	PhaseConfiguring  Phase = "Configuring"
+	PhaseProvisioned  Phase = "Provisioned"
+	PhaseFailed       Phase = "Failed"
+	PhaseReleasing    Phase = "Releasing"
+)
+
+// conditionsPhase derives the phase from the Provisioned and Configured conditions
+func conditionsPhase(conditions []metav1.Condition) Phase {
+	provisioned := meta.FindStatusCondition(conditions, string(hwmgmtv1alpha1.Provisioned))
+	configured := meta.FindStatusCondition(conditions, string(hwmgmtv1alpha1.Configured))
+
+	switch {
+	case provisioned != nil && provisioned.Reason == string(hwmgmtv1alpha1.Failed),
+		configured != nil && configured.Reason == string(hwmgmtv1alpha1.Failed):
+		return PhaseFailed
+	case configured != nil && configured.Status == metav1.ConditionFalse:
+		return PhaseConfiguring
+	case provisioned != nil && provisioned.Status == metav1.ConditionTrue:
+		return PhaseProvisioned
+	}
+	return PhaseProvisioning
+}
+
+// GetNodePhase returns the phase of a Node
+func GetNodePhase(node *hwmgmtv1alpha1.Node) Phase {
+	if !node.DeletionTimestamp.IsZero() {
+		return PhaseReleasing
+	}
+	if GetConfigAnnotation(node) != "" || GetJobId(node) != "" {
+		return PhaseConfiguring
+	}
+	return conditionsPhase(node.Status.Conditions)
+}
+
+// GetNodePoolPhase returns the phase of a NodePool
+func GetNodePoolPhase(nodepool *hwmgmtv1alpha1.NodePool) Phase {
+	if !nodepool.DeletionTimestamp.IsZero() {
+		return PhaseReleasing
+	}
+	return conditionsPhase(nodepool.Status.Conditions)
+}
+
+// setSummaryMetadata sets the summary labels and annotations on the object, with the phase change timed with the clock,
+// returning true if anything changed
+func setSummaryMetadata(object client.Object, phase Phase, annotations map[string]string, clock Clock) bool {
+	changed := false
+
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	current := object.GetAnnotations()
+	if current == nil {
+		current = make(map[string]string)
+	}
+
+	if labels[PhaseLabel] != string(phase) || current[PhaseSinceAnnotation] == "" {
+		labels[PhaseLabel] = string(phase)
+		current[PhaseSinceAnnotation] = clock.Now().UTC().Format(time.RFC3339)
+		changed = true
+	}
+
+	for key, value := range annotations {
+		if value == "" || current[key] == value {
+			continue
+		}
+		current[key] = value
+		changed = true
+	}
+
+	object.SetLabels(labels)
+	object.SetAnnotations(current)
+	return changed
+}
+
+// UpdateNodeStatusSummary publishes the phase, hardware reference and profile of the Node, if changed
+func UpdateNodeStatusSummary(ctx context.Context, c client.Client, node *hwmgmtv1alpha1.Node, clock Clock) error {
+	hardwareRef := node.Spec.HwMgrNodeId
+	if node.Spec.HwMgrNodeNs != "" {
+		hardwareRef = node.Spec.HwMgrNodeNs + "/" + node.Spec.HwMgrNodeId
+	}
+	profile := node.Status.HwProfile
+	if profile == "" {
+		profile = node.Spec.HwProfile
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	if !setSummaryMetadata(node, GetNodePhase(node), map[string]string{
+		HardwareRefAnnotation: hardwareRef,
+		HwProfileAnnotation:   profile,
+	}, clock) {
+		return nil
+	}
+	if err := c.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to patch status summary of node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+// UpdateNodePoolStatusSummary publishes the phase of the NodePool, if changed
+func UpdateNodePoolStatusSummary(ctx context.Context, c client.Client, nodepool *hwmgmtv1alpha1.NodePool, clock Clock) error {
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	if !setSummaryMetadata(nodepool, GetNodePoolPhase(nodepool), nil, clock) {
+		return nil
+	}
+	if err := c.Patch(ctx, nodepool, patch); err != nil {
+		return fmt.Errorf("failed to patch status summary of nodepool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestGetNodePhase(t *testing.T) {
+	condition := func(conditionType hwmgmtv1alpha1.ConditionType, reason hwmgmtv1alpha1.ConditionReason,
+		status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{Type: string(conditionType), Reason: string(reason), Status: status}
+	}
+
+	tests := []struct {
+		name        string
+		conditions  []metav1.Condition
+		annotations map[string]string
+		deleting    bool
+		expected    Phase
+	}{
+		{name: "no conditions", expected: PhaseProvisioning},
+		{name: "provisioned",
+			conditions: []metav1.Condition{condition(hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Completed, metav1.ConditionTrue)},
+			expected:   PhaseProvisioned},
+		{name: "provisioning failed",
+			conditions: []metav1.Condition{condition(hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Failed, metav1.ConditionFalse)},
+			expected:   PhaseFailed},
+		{name: "configuring",
+			conditions: []metav1.Condition{
+				condition(hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Completed, metav1.ConditionTrue),
+				condition(hwmgmtv1alpha1.Configured, hwmgmtv1alpha1.InProgress, metav1.ConditionFalse),
+			},
+			expected: PhaseConfiguring},
+		{name: "config annotation",
+			conditions:  []metav1.Condition{condition(hwmgmtv1alpha1.Provisioned, hwmgmtv1alpha1.Completed, metav1.ConditionTrue)},
+			annotations: map[string]string{ConfigAnnotation: "bios-update"},
+			expected:    PhaseConfiguring},
+		{name: "deleting", deleting: true, expected: PhaseReleasing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &hwmgmtv1alpha1.Node{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Status:     hwmgmtv1alpha1.NodeStatus{Conditions: tt.conditions},
+			}
+			if tt.deleting {
+				now := metav1.Now()
+				node.DeletionTimestamp = &now
+			}
+			if phase := GetNodePhase(node); phase != tt.expected {
+				t.Errorf("expected phase %s, got %s", tt.expected, phase)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+// Operation identifies a hardware manager operation with a configurable timeout
+type Operation string
+
+const (
+	OperationAllocate         Operation = "allocate"
+	OperationRelease          Operation = "release"
+	OperationFirmwareJob      Operation = "firmwareJob"
+	OperationResourceGroupJob Operation = "resourceGroupJob"
+	OperationInventoryQuery   Operation = "inventoryQuery"
+	OperationJobCancellation  Operation = "jobCancellation"
+)
+
+// Default operation timeouts. A zero value means no limit.
+const (
+	DefaultAllocateTimeout         = 5 * time.Minute
+	DefaultReleaseTimeout          = 5 * time.Minute
+	DefaultFirmwareJobTimeout      = time.Duration(0)
+	DefaultResourceGroupJobTimeout = time.Duration(0)
+	DefaultInventoryQueryTimeout   = 30 * time.Second
+	DefaultJobCancellationTimeout  = 10 * time.Minute
+)
+
+// timeoutFor returns the configured timeout for the operation, if set
+func timeoutFor(timeouts *pluginv1alpha1.OperationTimeouts, op Operation) *metav1.Duration {
+	if timeouts == nil {
+		return nil
+	}
+	switch op {
+	case OperationAllocate:
+		return timeouts.Allocate
+	case OperationRelease:
+		return timeouts.Release
+	case OperationFirmwareJob:
+		return timeouts.FirmwareJob
+	case OperationResourceGroupJob:
+		return timeouts.ResourceGroupJob
+	case OperationInventoryQuery:
+		return timeouts.InventoryQuery
+	case OperationJobCancellation:
+		return timeouts.JobCancellation
+	}
+	return nil
+}
+
+// GetOperationTimeout returns the timeout of an operation for the HardwareManager. The adaptor config data takes
+// precedence over the global timeouts, which take precedence over the defaults. A zero value means no limit.
+func GetOperationTimeout(hwmgr *pluginv1alpha1.HardwareManager, op Operation) time.Duration {
+	var adaptorTimeouts *pluginv1alpha1.OperationTimeouts
+	switch {
+	case hwmgr.Spec.DellData != nil && hwmgr.Spec.AdaptorID == pluginv1alpha1.SupportedAdaptors.Dell:
+		adaptorTimeouts = hwmgr.Spec.DellData.Timeouts
+	case hwmgr.Spec.Metal3Data != nil && hwmgr.Spec.AdaptorID == pluginv1alpha1.SupportedAdaptors.Metal3:
+		adaptorTimeouts = hwmgr.Spec.Metal3Data.Timeouts
+	case hwmgr.Spec.SupermicroData != nil && hwmgr.Spec.AdaptorID == pluginv1alpha1.SupportedAdaptors.Supermicro:
+		adaptorTimeouts = hwmgr.Spec.SupermicroData.Timeouts
+	}
+
+	for _, timeouts := range []*pluginv1alpha1.OperationTimeouts{adaptorTimeouts, hwmgr.Spec.Timeouts} {
+		if timeout := timeoutFor(timeouts, op); timeout != nil {
+			return timeout.Duration
+		}
+	}
+
+	switch op {
+	case OperationAllocate:
+		return DefaultAllocateTimeout
+	case OperationRelease:
+		return DefaultReleaseTimeout
+	case OperationFirmwareJob:
+		return DefaultFirmwareJobTimeout
+	case OperationResourceGroupJob:
+		return DefaultResourceGroupJobTimeout
+	case OperationInventoryQuery:
+		return DefaultInventoryQueryTimeout
+	case OperationJobCancellation:
+		return DefaultJobCancellationTimeout
+	}
+	return 0
+}
+
+// WithOperationTimeout returns a context bounded by the timeout of the operation for the HardwareManager
+func WithOperationTimeout(
+	ctx context.Context,
+	hwmgr *pluginv1alpha1.HardwareManager,
+	op Operation) (context.Context, context.CancelFunc) {
+
+	if timeout := GetOperationTimeout(hwmgr, op); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+)
+
+func TestGetOperationTimeout(t *testing.T) {
+	hwmgr := &pluginv1alpha1.HardwareManager{
+		Spec: pluginv1alpha1.HardwareManagerSpec{
+			AdaptorID: pluginv1alpha1.SupportedAdaptors.Dell,
+			DellData: &pluginv1alpha1.DellData{
+				Timeouts: &pluginv1alpha1.OperationTimeouts{
+					Allocate: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			Timeouts: &pluginv1alpha1.OperationTimeouts{
+				Allocate: &metav1.Duration{Duration: time.Hour},
+				Release:  &metav1.Duration{Duration: 2 * time.Minute},
+			},
+		},
+	}
+
+	tests := []struct {
+		op       Operation
+		expected time.Duration
+	}{
+		{op: OperationAllocate, expected: time.Minute},
+		{op: OperationRelease, expected: 2 * time.Minute},
+		{op: OperationInventoryQuery, expected: DefaultInventoryQueryTimeout},
+		{op: OperationFirmwareJob, expected: DefaultFirmwareJobTimeout},
+		{op: OperationJobCancellation, expected: DefaultJobCancellationTimeout},
+	}
+
+	for _, tt := range tests {
+		if timeout := GetOperationTimeout(hwmgr, tt.op); timeout != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.op, tt.expected, timeout)
+		}
+	}
+}
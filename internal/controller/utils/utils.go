@@ -40,9 +40,14 @@ const (
 )
 
 const (
-	JobIdAnnotation         = "hwmgr-plugin.oran.openshift.io/jobId"
-	DeletionJobIdAnnotation = "hwmgr-plugin.oran.openshift.io/deletionJobId"
-	ConfigAnnotation        = "hwmgr-plugin.oran.openshift.io/config-in-progress"
+	JobIdAnnotation                = "hwmgr-plugin.oran.openshift.io/jobId"
+	JobStartTimeAnnotation         = "hwmgr-plugin.oran.openshift.io/jobStartTime"
+	DeletionJobIdAnnotation        = "hwmgr-plugin.oran.openshift.io/deletionJobId"
+	DeletionJobStartTimeAnnotation = "hwmgr-plugin.oran.openshift.io/deletionJobStartTime"
+	ConfigAnnotation               = "hwmgr-plugin.oran.openshift.io/config-in-progress"
+	AppliedConfigAnnotation        = "hwmgr-plugin.oran.openshift.io/applied-config"
+	AppliedConfigHashAnnotation    = "hwmgr-plugin.oran.openshift.io/applied-config-hash"
+	RetryAnnotation                = "hwmgr-plugin.oran.openshift.io/retry"
 )
 
 func UpdateK8sCRStatus(ctx context.Context, c client.Client, object client.Object) error {
@@ -223,13 +228,15 @@ func GetJobId(object client.Object) string {
 	return annotations[JobIdAnnotation]
 }
 
-func SetJobId(object client.Object, jobId string) {
+// SetJobId records the backend job of the object, along with its start time taken from the clock
+func SetJobId(object client.Object, jobId string, clock Clock) {
 	annotations := object.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
 
 	annotations[JobIdAnnotation] = jobId
+	annotations[JobStartTimeAnnotation] = clock.Now().UTC().Format(time.RFC3339)
 	object.SetAnnotations(annotations)
 }
 
@@ -237,9 +244,32 @@ func ClearJobId(object client.Object) {
 	annotations := object.GetAnnotations()
 	if annotations != nil {
 		delete(annotations, JobIdAnnotation)
+		delete(annotations, JobStartTimeAnnotation)
 	}
 }
 
+// GetJobStartTime returns the time the job recorded with SetJobId was started, if known
+func GetJobStartTime(object client.Object) (time.Time, bool) {
+	return getTimeAnnotation(object, JobStartTimeAnnotation)
+}
+
+// GetDeletionJobStartTime returns the time the job recorded with SetDeletionJobId was started, if known
+func GetDeletionJobStartTime(object client.Object) (time.Time, bool) {
+	return getTimeAnnotation(object, DeletionJobStartTimeAnnotation)
+}
+
+func getTimeAnnotation(object client.Object, annotation string) (time.Time, bool) {
+	value, exists := object.GetAnnotations()[annotation]
+	if !exists {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
 func GetDeletionJobId(object client.Object) string {
 	annotations := object.GetAnnotations()
 	if annotations == nil {
@@ -249,13 +279,15 @@ func GetDeletionJobId(object client.Object) string {
 	return annotations[DeletionJobIdAnnotation]
 }
 
-func SetDeletionJobId(object client.Object, jobId string) {
+// SetDeletionJobId records the backend job deleting the object, along with its start time taken from the clock
+func SetDeletionJobId(object client.Object, jobId string, clock Clock) {
 	annotations := object.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
 
 	annotations[DeletionJobIdAnnotation] = jobId
+	annotations[DeletionJobStartTimeAnnotation] = clock.Now().UTC().Format(time.RFC3339)
 	object.SetAnnotations(annotations)
 }
 
@@ -263,6 +295,7 @@ func ClearDeletionJobId(object client.Object) {
 	annotations := object.GetAnnotations()
 	if annotations != nil {
 		delete(annotations, DeletionJobIdAnnotation)
+		delete(annotations, DeletionJobStartTimeAnnotation)
 	}
 }
 
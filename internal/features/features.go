@@ -0,0 +1,47 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package features
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// FailureInjection enables the inventory server middleware that injects delays, errors and truncated responses
+	// as requested by headers on each request. It is intended for resilience testing of API consumers only, and must
+	// not be enabled in production.
+	FailureInjection featuregate.Feature = "FailureInjection"
+
+	// ProvisioningAPI enables the inventory server endpoints that accept provisioning requests, which are fulfilled by
+	// NodePools created on behalf of the client.
+	ProvisioningAPI featuregate.Feature = "ProvisioningAPI"
+)
+
+var gate = featuregate.NewFeatureGate()
+
+// Gate holds the state of the feature gates, as set by the --feature-gates flag
+var Gate featuregate.FeatureGate = gate
+
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	FailureInjection: {Default: false, PreRelease: featuregate.Alpha},
+	ProvisioningAPI:  {Default: false, PreRelease: featuregate.Alpha},
+}
+
+func init() {
+	utilruntime.Must(gate.Add(defaultFeatureGates))
+}
+
+// AddFlag adds the --feature-gates flag to the flag set
+func AddFlag(fs *flag.FlagSet) {
+	fs.Var(gate, "feature-gates", fmt.Sprintf("A set of key=value pairs that describe feature gates for alpha/experimental "+
+		"features. Options are:\n%s", strings.Join(gate.KnownFeatures(), "\n")))
+}
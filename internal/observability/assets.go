@@ -0,0 +1,236 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observability
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/yaml"
+)
+
+// Files of the generated assets
+const (
+	DashboardFile      = "grafana-dashboard.json"
+	PrometheusRuleFile = "prometheusrule.yaml"
+)
+
+const (
+	dashboardUid      = "oran-hwmgr-plugin"
+	dashboardTitle    = "O-RAN Hardware Manager Plugin"
+	datasourceRef     = "${datasource}"
+	panelsPerRow      = 4
+	gridWidth         = 24
+	panelHeight       = 8
+	rowHeight         = 1
+	alertGroupName    = "oran-hwmgr-plugin"
+	prometheusRuleRef = "controller-manager-rules"
+)
+
+// referencedMetrics returns the metrics referenced by the dashboard and alerts, sorted by name
+func referencedMetrics() []Metric {
+	seen := make(map[Metric]bool)
+	for _, row := range DashboardRows {
+		for _, panel := range row.Panels {
+			for _, metric := range panel.Metrics {
+				seen[metric] = true
+			}
+		}
+	}
+	for _, rule := range AlertRules {
+		for _, metric := range rule.Metrics {
+			seen[metric] = true
+		}
+	}
+
+	metrics := make([]Metric, 0, len(seen))
+	for metric := range seen {
+		metrics = append(metrics, metric)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i] < metrics[j] })
+	return metrics
+}
+
+// isRegistered checks whether a metric is registered. As the registry cannot be listed, a probe collector is
+// registered with the name of the metric, which is rejected if a metric with that name is already registered.
+func isRegistered(registerer prometheus.Registerer, metric Metric) bool {
+	probe := prometheus.NewGauge(prometheus.GaugeOpts{Name: string(metric), Help: "probe"})
+	if err := registerer.Register(probe); err != nil {
+		return true
+	}
+	registerer.Unregister(probe)
+	return false
+}
+
+// CheckRegistered checks that the metrics referenced by the dashboard and alerts are registered
+func CheckRegistered(registerer prometheus.Registerer) error {
+	var errs []error
+	for _, metric := range referencedMetrics() {
+		if !isRegistered(registerer, metric) {
+			errs = append(errs, fmt.Errorf("metric %s is not registered", metric))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RenderDashboard renders the Grafana dashboard, with a row of panels for each row of the definitions, and a variable
+// selecting the hardware managers
+func RenderDashboard() ([]byte, error) {
+	datasource := map[string]any{"type": "prometheus", "uid": datasourceRef}
+	panels := []any{}
+	id, y := 1, 0
+	for _, row := range DashboardRows {
+		panels = append(panels, map[string]any{
+			"id":        id,
+			"type":      "row",
+			"title":     row.Title,
+			"collapsed": false,
+			"gridPos":   map[string]int{"x": 0, "y": y, "w": gridWidth, "h": rowHeight},
+			"panels":    []any{},
+		})
+		id++
+		y += rowHeight
+
+		width := gridWidth / panelsPerRow
+		for i, panel := range row.Panels {
+			if i > 0 && i%panelsPerRow == 0 {
+				y += panelHeight
+			}
+			targets := make([]any, 0, len(panel.Queries))
+			for j, query := range panel.Queries {
+				targets = append(targets, map[string]any{
+					"datasource":   datasource,
+					"expr":         query.Expr,
+					"legendFormat": query.Legend,
+					"refId":        string(rune('A' + j)),
+				})
+			}
+			panels = append(panels, map[string]any{
+				"id":          id,
+				"type":        "timeseries",
+				"title":       panel.Title,
+				"description": panel.Description,
+				"datasource":  datasource,
+				"gridPos":     map[string]int{"x": (i % panelsPerRow) * width, "y": y, "w": width, "h": panelHeight},
+				"fieldConfig": map[string]any{"defaults": map[string]any{"unit": panel.Unit}, "overrides": []any{}},
+				"targets":     targets,
+			})
+			id++
+		}
+		y += panelHeight
+	}
+
+	dashboard := map[string]any{
+		"uid":           dashboardUid,
+		"title":         dashboardTitle,
+		"tags":          []string{"oran", "hardware-manager"},
+		"editable":      true,
+		"schemaVersion": 39,
+		"refresh":       "1m",
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"templating": map[string]any{
+			"list": []any{
+				map[string]any{
+					"name":  "datasource",
+					"label": "Data source",
+					"type":  "datasource",
+					"query": "prometheus",
+				},
+				map[string]any{
+					"name":       "hwmgr",
+					"label":      "Hardware manager",
+					"type":       "query",
+					"datasource": datasource,
+					"query":      fmt.Sprintf("label_values(%s, hwmgr)", allocationDiscrepancies),
+					"refresh":    2,
+					"includeAll": true,
+					"allValue":   ".*",
+					"multi":      true,
+				},
+			},
+		},
+		"panels": panels,
+	}
+
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dashboard: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// RenderPrometheusRule renders the PrometheusRule holding the alerts, in the namespace of the plugin
+func RenderPrometheusRule() ([]byte, error) {
+	rules := make([]any, 0, len(AlertRules))
+	for _, rule := range AlertRules {
+		alert := map[string]any{
+			"alert":  rule.Name,
+			"expr":   rule.Expr,
+			"labels": map[string]string{"severity": rule.Severity},
+			"annotations": map[string]string{
+				"summary":     rule.Summary,
+				"description": rule.Description,
+			},
+		}
+		if rule.For != "" {
+			alert["for"] = rule.For
+		}
+		rules = append(rules, alert)
+	}
+
+	prometheusRule := map[string]any{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "PrometheusRule",
+		"metadata": map[string]any{
+			"name":      prometheusRuleRef,
+			"namespace": "system",
+			"labels": map[string]string{
+				"app.kubernetes.io/name":       "prometheusrule",
+				"app.kubernetes.io/component":  "metrics",
+				"app.kubernetes.io/created-by": "oran-hwmgr-plugin",
+				"app.kubernetes.io/part-of":    "oran-hwmgr-plugin",
+				"app.kubernetes.io/managed-by": "kustomize",
+			},
+		},
+		"spec": map[string]any{
+			"groups": []any{
+				map[string]any{"name": alertGroupName, "rules": rules},
+			},
+		},
+	}
+
+	data, err := yaml.Marshal(prometheusRule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PrometheusRule: %w", err)
+	}
+	return append([]byte("# Generated by internal/observability, DO NOT EDIT.\n"), data...), nil
+}
+
+// WriteAssets renders the dashboard and alerts into the directory
+func WriteAssets(dir string) error {
+	dashboard, err := RenderDashboard()
+	if err != nil {
+		return err
+	}
+	prometheusRule, err := RenderPrometheusRule()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, DashboardFile), dashboard, 0o644); err != nil { // nolint: gosec
+		return fmt.Errorf("failed to write dashboard: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, PrometheusRuleFile), prometheusRule, 0o644); err != nil { // nolint: gosec
+		return fmt.Errorf("failed to write PrometheusRule: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observability
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/adaptors"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/cachewatchdog"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/retention"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/hwevents"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
+)
+
+func TestCheckRegistered(t *testing.T) {
+	if err := CheckRegistered(metrics.Registry); err != nil {
+		t.Errorf("expected the referenced metrics to be registered: %v", err)
+	}
+	if err := CheckRegistered(prometheus.NewRegistry()); err == nil {
+		t.Errorf("expected an error for an empty registry")
+	}
+}
+
+func TestDefinitionsReferenceTheirMetrics(t *testing.T) {
+	for _, row := range DashboardRows {
+		for _, panel := range row.Panels {
+			var exprs []string
+			for _, query := range panel.Queries {
+				exprs = append(exprs, query.Expr)
+			}
+			for _, metric := range panel.Metrics {
+				if !strings.Contains(strings.Join(exprs, "\n"), string(metric)) {
+					t.Errorf("panel %q does not query metric %s", panel.Title, metric)
+				}
+			}
+		}
+	}
+	for _, rule := range AlertRules {
+		for _, metric := range rule.Metrics {
+			if !strings.Contains(rule.Expr, string(metric)) {
+				t.Errorf("alert %s does not query metric %s", rule.Name, metric)
+			}
+		}
+	}
+}
+
+func TestAssetsUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "config", "observability")
+	dashboard, err := RenderDashboard()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prometheusRule, err := RenderPrometheusRule()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for file, expected := range map[string][]byte{DashboardFile: dashboard, PrometheusRuleFile: prometheusRule} {
+		actual, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("%s is out of date, run \"make go-generate\"", file)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observability
+
+import "strconv"
+
+// The dashboard and alerts shipped with the plugin are generated from the definitions below, which reference the
+// metrics registered by the plugin. Generation fails when a referenced metric is not registered, so that the assets
+// follow the metrics as they are renamed or removed. Run "make go-generate" to regenerate the assets after changing
+// the definitions or the metrics.
+
+// Thresholds of the alerts on stuck NodePool operations
+const (
+	stuckProvisioningSeconds  = 4 * 3600
+	stuckConfigurationSeconds = 2 * 3600
+	stuckReleaseSeconds       = 3600
+)
+
+// Metric is a metric of the plugin, referenced by the dashboard and alerts
+type Metric string
+
+const (
+	nodePoolProvisioningDuration  Metric = "hwmgr_plugin_nodepool_provisioning_duration_seconds"
+	nodePoolConfigurationDuration Metric = "hwmgr_plugin_nodepool_configuration_duration_seconds"
+	nodePoolReleaseDuration       Metric = "hwmgr_plugin_nodepool_release_duration_seconds"
+	nodePoolProvisioningFailures  Metric = "hwmgr_plugin_nodepool_provisioning_failures_total"
+	nodePoolOldestOperationAge    Metric = "hwmgr_plugin_nodepool_oldest_operation_age_seconds"
+	allocationDiscrepancies       Metric = "hwmgr_plugin_allocation_discrepancies"
+	dellApiRequests               Metric = "hwmgr_plugin_dell_api_requests_total"
+	dellApiRequestDuration        Metric = "hwmgr_plugin_dell_api_request_duration_seconds"
+	notificationsDelivered        Metric = "hwmgr_plugin_notifications_delivered_total"
+	notificationDeliveryFailures  Metric = "hwmgr_plugin_notification_delivery_failures_total"
+	notificationsDeadLettered     Metric = "hwmgr_plugin_notifications_dead_lettered_total"
+	hardwareAlerts                Metric = "hwmgr_plugin_hardware_alerts_total"
+	informerCacheStale            Metric = "hwmgr_plugin_informer_cache_stale"
+	informerResourceVersionAge    Metric = "hwmgr_plugin_informer_resource_version_age_seconds"
+	informerRestarts              Metric = "hwmgr_plugin_informer_restarts_total"
+)
+
+// Panel is a time series panel of the dashboard
+type Panel struct {
+	Title       string
+	Description string
+	Unit        string
+	Metrics     []Metric
+	Queries     []Query
+}
+
+// Query is a query of a panel, with the legend of its series
+type Query struct {
+	Expr   string
+	Legend string
+}
+
+// Row is a row of panels of the dashboard
+type Row struct {
+	Title  string
+	Panels []Panel
+}
+
+// AlertRule is an alert of the PrometheusRule
+type AlertRule struct {
+	Name        string
+	Metrics     []Metric
+	Expr        string
+	For         string
+	Severity    string
+	Summary     string
+	Description string
+}
+
+// DashboardRows are the rows of the dashboard
+var DashboardRows = []Row{
+	{
+		Title: "NodePool lifecycle",
+		Panels: []Panel{
+			{
+				Title:       "Provisioning duration",
+				Description: "Time from the creation of a NodePool to its provisioning, by NodePool size",
+				Unit:        "s",
+				Metrics:     []Metric{nodePoolProvisioningDuration},
+				Queries: []Query{
+					{
+						Expr:   `histogram_quantile(0.5, sum by (size, le) (rate(hwmgr_plugin_nodepool_provisioning_duration_seconds_bucket{hwmgr=~"$hwmgr"}[$__rate_interval])))`,
+						Legend: "p50 {{size}}",
+					},
+					{
+						Expr:   `histogram_quantile(0.95, sum by (size, le) (rate(hwmgr_plugin_nodepool_provisioning_duration_seconds_bucket{hwmgr=~"$hwmgr"}[$__rate_interval])))`,
+						Legend: "p95 {{size}}",
+					},
+				},
+			},
+			{
+				Title:       "Configuration and release duration",
+				Description: "Duration of the day-2 configurations and of the releases of NodePools",
+				Unit:        "s",
+				Metrics:     []Metric{nodePoolConfigurationDuration, nodePoolReleaseDuration},
+				Queries: []Query{
+					{
+						Expr:   `histogram_quantile(0.95, sum by (le) (rate(hwmgr_plugin_nodepool_configuration_duration_seconds_bucket{hwmgr=~"$hwmgr"}[$__rate_interval])))`,
+						Legend: "p95 configuration",
+					},
+					{
+						Expr:   `histogram_quantile(0.95, sum by (le) (rate(hwmgr_plugin_nodepool_release_duration_seconds_bucket{hwmgr=~"$hwmgr"}[$__rate_interval])))`,
+						Legend: "p95 release",
+					},
+				},
+			},
+			{
+				Title:       "Provisioning failures",
+				Description: "NodePools whose provisioning failed or timed out",
+				Unit:        "short",
+				Metrics:     []Metric{nodePoolProvisioningFailures},
+				Queries: []Query{
+					{
+						Expr:   `sum by (hwmgr, reason) (increase(hwmgr_plugin_nodepool_provisioning_failures_total{hwmgr=~"$hwmgr"}[1h]))`,
+						Legend: "{{hwmgr}} {{reason}}",
+					},
+				},
+			},
+			{
+				Title:       "Oldest operation in progress",
+				Description: "Age of the oldest NodePool provisioning, configuration and release in progress",
+				Unit:        "s",
+				Metrics:     []Metric{nodePoolOldestOperationAge},
+				Queries: []Query{
+					{
+						Expr:   `max by (hwmgr, operation) (hwmgr_plugin_nodepool_oldest_operation_age_seconds{hwmgr=~"$hwmgr"})`,
+						Legend: "{{hwmgr}} {{operation}}",
+					},
+				},
+			},
+		},
+	},
+	{
+		Title: "Hardware manager backends",
+		Panels: []Panel{
+			{
+				Title:       "Backend requests",
+				Description: "Requests sent to the Dell hardware managers, by status code",
+				Unit:        "reqps",
+				Metrics:     []Metric{dellApiRequests},
+				Queries: []Query{
+					{
+						Expr:   `sum by (hwmgr, code) (rate(hwmgr_plugin_dell_api_requests_total{hwmgr=~"$hwmgr"}[$__rate_interval]))`,
+						Legend: "{{hwmgr}} {{code}}",
+					},
+				},
+			},
+			{
+				Title:       "Backend latency",
+				Description: "95th percentile of the latency of the requests sent to the Dell hardware managers, by endpoint",
+				Unit:        "s",
+				Metrics:     []Metric{dellApiRequestDuration},
+				Queries: []Query{
+					{
+						Expr:   `histogram_quantile(0.95, sum by (hwmgr, endpoint, le) (rate(hwmgr_plugin_dell_api_request_duration_seconds_bucket{hwmgr=~"$hwmgr"}[$__rate_interval])))`,
+						Legend: "{{hwmgr}} {{endpoint}}",
+					},
+				},
+			},
+			{
+				Title:       "Allocation discrepancies",
+				Description: "Persisting discrepancies between the Nodes and the hardware allocated in the backends",
+				Unit:        "short",
+				Metrics:     []Metric{allocationDiscrepancies},
+				Queries: []Query{
+					{
+						Expr:   `sum by (hwmgr, type) (hwmgr_plugin_allocation_discrepancies{hwmgr=~"$hwmgr"})`,
+						Legend: "{{hwmgr}} {{type}}",
+					},
+				},
+			},
+		},
+	},
+	{
+		Title: "Notifications and alerts",
+		Panels: []Panel{
+			{
+				Title:       "Notification deliveries",
+				Description: "Notifications delivered to subscribers, failed delivery attempts and dead-lettered notifications",
+				Unit:        "short",
+				Metrics:     []Metric{notificationsDelivered, notificationDeliveryFailures, notificationsDeadLettered},
+				Queries: []Query{
+					{
+						Expr:   `sum by (hwmgr) (increase(hwmgr_plugin_notifications_delivered_total{hwmgr=~"$hwmgr"}[$__rate_interval]))`,
+						Legend: "{{hwmgr}} delivered",
+					},
+					{
+						Expr:   `sum by (hwmgr) (increase(hwmgr_plugin_notification_delivery_failures_total{hwmgr=~"$hwmgr"}[$__rate_interval]))`,
+						Legend: "{{hwmgr}} failed",
+					},
+					{
+						Expr:   `sum by (hwmgr) (increase(hwmgr_plugin_notifications_dead_lettered_total{hwmgr=~"$hwmgr"}[$__rate_interval]))`,
+						Legend: "{{hwmgr}} dead-lettered",
+					},
+				},
+			},
+			{
+				Title:       "Hardware alerts",
+				Description: "Hardware alerts received from the node BMCs, by severity",
+				Unit:        "short",
+				Metrics:     []Metric{hardwareAlerts},
+				Queries: []Query{
+					{
+						Expr:   `sum by (severity) (increase(hwmgr_plugin_hardware_alerts_total[$__rate_interval]))`,
+						Legend: "{{severity}}",
+					},
+				},
+			},
+		},
+	},
+	{
+		Title: "Informer caches",
+		Panels: []Panel{
+			{
+				Title:       "Resource version age",
+				Description: "Time since the resource version synced by each informer last advanced",
+				Unit:        "s",
+				Metrics:     []Metric{informerResourceVersionAge},
+				Queries: []Query{
+					{
+						Expr:   `max by (type) (hwmgr_plugin_informer_resource_version_age_seconds)`,
+						Legend: "{{type}}",
+					},
+				},
+			},
+			{
+				Title:       "Informer restarts",
+				Description: "Informers restarted after their cache was found stale",
+				Unit:        "short",
+				Metrics:     []Metric{informerRestarts},
+				Queries: []Query{
+					{
+						Expr:   `sum by (type) (increase(hwmgr_plugin_informer_restarts_total[$__rate_interval]))`,
+						Legend: "{{type}}",
+					},
+				},
+			},
+		},
+	},
+}
+
+// AlertRules are the alerts of the PrometheusRule
+var AlertRules = []AlertRule{
+	{
+		Name:        "HwmgrPluginProvisioningFailed",
+		Metrics:     []Metric{nodePoolProvisioningFailures},
+		Expr:        `sum by (hwmgr, reason) (increase(hwmgr_plugin_nodepool_provisioning_failures_total[1h])) > 0`,
+		Severity:    "warning",
+		Summary:     "NodePool provisioning failed",
+		Description: "{{ $value | humanize }} NodePools of hardware manager {{ $labels.hwmgr }} failed to be provisioned ({{ $labels.reason }}) in the last hour.",
+	},
+	{
+		Name:        "HwmgrPluginProvisioningStuck",
+		Metrics:     []Metric{nodePoolOldestOperationAge},
+		Expr:        `hwmgr_plugin_nodepool_oldest_operation_age_seconds{operation="provisioning"} > ` + strconv.Itoa(stuckProvisioningSeconds),
+		For:         "5m",
+		Severity:    "warning",
+		Summary:     "NodePool provisioning is stuck",
+		Description: "A NodePool of hardware manager {{ $labels.hwmgr }} has been provisioning for {{ $value | humanizeDuration }}.",
+	},
+	{
+		Name:        "HwmgrPluginConfigurationStuck",
+		Metrics:     []Metric{nodePoolOldestOperationAge},
+		Expr:        `hwmgr_plugin_nodepool_oldest_operation_age_seconds{operation="configuration"} > ` + strconv.Itoa(stuckConfigurationSeconds),
+		For:         "5m",
+		Severity:    "warning",
+		Summary:     "NodePool configuration is stuck",
+		Description: "A day-2 configuration of a NodePool of hardware manager {{ $labels.hwmgr }} has been in progress for {{ $value | humanizeDuration }}.",
+	},
+	{
+		Name:        "HwmgrPluginReleaseStuck",
+		Metrics:     []Metric{nodePoolOldestOperationAge},
+		Expr:        `hwmgr_plugin_nodepool_oldest_operation_age_seconds{operation="release"} > ` + strconv.Itoa(stuckReleaseSeconds),
+		For:         "5m",
+		Severity:    "warning",
+		Summary:     "NodePool release is stuck",
+		Description: "A deleted NodePool of hardware manager {{ $labels.hwmgr }} has been releasing its hardware for {{ $value | humanizeDuration }}.",
+	},
+	{
+		Name:        "HwmgrPluginBackendLatencyHigh",
+		Metrics:     []Metric{dellApiRequestDuration},
+		Expr:        `histogram_quantile(0.95, sum by (hwmgr, le) (rate(hwmgr_plugin_dell_api_request_duration_seconds_bucket[5m]))) > 5`,
+		For:         "15m",
+		Severity:    "warning",
+		Summary:     "Hardware manager backend is slow",
+		Description: "The 95th percentile of the latency of the requests to hardware manager {{ $labels.hwmgr }} is {{ $value | humanizeDuration }}.",
+	},
+	{
+		Name:    "HwmgrPluginBackendErrors",
+		Metrics: []Metric{dellApiRequests},
+		Expr: `sum by (hwmgr) (rate(hwmgr_plugin_dell_api_requests_total{code=~"error|5.."}[5m]))` +
+			` / sum by (hwmgr) (rate(hwmgr_plugin_dell_api_requests_total[5m])) > 0.1`,
+		For:         "15m",
+		Severity:    "warning",
+		Summary:     "Hardware manager backend requests are failing",
+		Description: "{{ $value | humanizePercentage }} of the requests to hardware manager {{ $labels.hwmgr }} fail.",
+	},
+	{
+		Name:        "HwmgrPluginAllocationDiscrepancies",
+		Metrics:     []Metric{allocationDiscrepancies},
+		Expr:        `hwmgr_plugin_allocation_discrepancies > 0`,
+		For:         "30m",
+		Severity:    "warning",
+		Summary:     "Nodes and backend allocations disagree",
+		Description: "Hardware manager {{ $labels.hwmgr }} has {{ $value }} persisting discrepancies of type {{ $labels.type }}.",
+	},
+	{
+		Name:        "HwmgrPluginNotificationsDeadLettered",
+		Metrics:     []Metric{notificationsDeadLettered},
+		Expr:        `sum by (hwmgr) (increase(hwmgr_plugin_notifications_dead_lettered_total[15m])) > 0`,
+		Severity:    "warning",
+		Summary:     "Notifications could not be delivered",
+		Description: "{{ $value | humanize }} notifications of hardware manager {{ $labels.hwmgr }} were moved to the dead-letter queue.",
+	},
+	{
+		Name:        "HwmgrPluginInformerCacheStale",
+		Metrics:     []Metric{informerCacheStale},
+		Expr:        `hwmgr_plugin_informer_cache_stale == 1`,
+		For:         "10m",
+		Severity:    "critical",
+		Summary:     "Informer cache is stale",
+		Description: "The informer cache of {{ $labels.type }} has been stale for 10 minutes, despite being restarted.",
+	},
+}
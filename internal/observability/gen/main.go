@@ -0,0 +1,41 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Command gen generates the Grafana dashboard and the PrometheusRule of the plugin into the given directory, after
+// checking that the metrics they reference are registered by the plugin.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	// The packages registering the metrics of the plugin
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/adaptors"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/cachewatchdog"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/retention"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/hwevents"
+	_ "github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/observability"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <directory>\n", os.Args[0])
+		os.Exit(2)
+	}
+	if err := observability.CheckRegistered(metrics.Registry); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	if err := observability.WriteAssets(os.Args[1]); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
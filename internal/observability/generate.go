@@ -0,0 +1,9 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observability
+
+//go:generate go run ./gen ../../config/observability
@@ -0,0 +1,260 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+const (
+	// SystemsPath is the path of the Systems collection of a BMC
+	SystemsPath = "/redfish/v1/Systems"
+
+	resetActionPath = "/Actions/ComputerSystem.Reset"
+
+	// DefaultTimeout bounds each request sent to a BMC
+	DefaultTimeout = 30 * time.Second
+
+	// maxResponseSize bounds the size of the responses read from a BMC
+	maxResponseSize = 4 << 20
+)
+
+// Power states of a ComputerSystem
+const (
+	PowerStateOff = "Off"
+	PowerStateOn  = "On"
+)
+
+// Credentials are the credentials of a BMC
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialsFromSecret returns the credentials held by a BMC secret
+func CredentialsFromSecret(secret *corev1.Secret) Credentials {
+	return Credentials{
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	}
+}
+
+// String keeps the credentials out of logs and error messages
+func (c Credentials) String() string {
+	return "<redacted>"
+}
+
+// GoString keeps the credentials out of %#v formatting
+func (c Credentials) GoString() string {
+	return c.String()
+}
+
+// ODataID is a reference to a Redfish resource
+type ODataID struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// Collection is a Redfish collection of resources
+type Collection struct {
+	Members []ODataID `json:"Members"`
+}
+
+// Message is a message of a Redfish error or task
+type Message struct {
+	MessageID string `json:"MessageId"`
+	Message   string `json:"Message"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message  string    `json:"message"`
+		Extended []Message `json:"@Message.ExtendedInfo"`
+	} `json:"error"`
+}
+
+type systemPower struct {
+	PowerState string `json:"PowerState"`
+}
+
+// Client is a client of the Redfish API of a BMC. The BMC address scheme identifies the BMC driver, such as
+// idrac-virtualmedia, so the BMC is reached over https on the address host. The credentials of the address, if any, are
+// never used nor reported.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     url.URL
+	credentials Credentials
+
+	// Timeout bounds each request, DefaultTimeout if zero
+	Timeout time.Duration
+}
+
+// NewClient returns a client of the BMC at the given address
+func NewClient(httpClient *http.Client, address string, credentials Credentials) (*Client, error) {
+	parsed, err := url.Parse(address)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid BMC address")
+	}
+	return &Client{
+		httpClient:  httpClient,
+		baseURL:     url.URL{Scheme: "https", Host: parsed.Host},
+		credentials: credentials,
+	}, nil
+}
+
+// NewTransport wraps the transport of the requests to the BMCs of a HardwareManager, recording failed requests for its
+// status at the time of the clock, within the rate limit of the HardwareManager
+func NewTransport(base http.RoundTripper, hwmgr *pluginv1alpha1.HardwareManager, clock utils.Clock) http.RoundTripper {
+	return utils.NewRateLimitTransport(utils.NewSouthboundErrorTransport(base, hwmgr, clock), hwmgr)
+}
+
+// Do sends a request to the BMC, decoding the response into out if set. The response headers are returned, as actions
+// starting a task report the task monitor in the Location header.
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) (http.Header, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	target := c.baseURL
+	target.Path = path
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request to %s: %w", path, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s request to %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", path, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%s request to %s failed with status %s%s", method, path, resp.Status, ErrorMessage(data))
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, fmt.Errorf("failed to parse response from %s: %w", path, err)
+		}
+	}
+
+	return resp.Header, nil
+}
+
+// Get fetches a resource
+func (c *Client) Get(ctx context.Context, path string, out any) error {
+	_, err := c.Do(ctx, http.MethodGet, path, nil, out)
+	return err
+}
+
+// ErrorMessage extracts the messages of a Redfish error response, for inclusion in an error
+func ErrorMessage(data []byte) string {
+	var response errorResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return ""
+	}
+
+	var messages []string
+	for _, info := range response.Error.Extended {
+		if info.Message != "" {
+			messages = append(messages, info.Message)
+		}
+	}
+	if len(messages) == 0 && response.Error.Message != "" {
+		messages = append(messages, response.Error.Message)
+	}
+	if len(messages) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(messages, "; ")
+}
+
+// HasSystemPath checks whether a BMC address includes the path of a member of the Systems collection
+func HasSystemPath(address *url.URL) bool {
+	member, found := strings.CutPrefix(strings.TrimSuffix(address.Path, "/"), SystemsPath+"/")
+	return found && member != ""
+}
+
+// WithSystemPath replaces the path of a BMC address with a system path
+func WithSystemPath(address *url.URL, systemPath string) string {
+	updated := *address
+	updated.Path = "/" + strings.Trim(systemPath, "/")
+	updated.RawPath = ""
+	return updated.String()
+}
+
+// DiscoverSystemPath queries the Systems collection of the BMC for its member
+func (c *Client) DiscoverSystemPath(ctx context.Context) (string, error) {
+	var collection Collection
+	if err := c.Get(ctx, SystemsPath, &collection); err != nil {
+		return "", err
+	}
+	if len(collection.Members) != 1 || collection.Members[0].ODataID == "" {
+		return "", fmt.Errorf("expected a single member in the Systems collection, found %d", len(collection.Members))
+	}
+	return collection.Members[0].ODataID, nil
+}
+
+// SystemPath returns the path of the Redfish system of a BMC address, discovering it from the BMC if the address does
+// not include it
+func (c *Client) SystemPath(ctx context.Context, address string) (string, error) {
+	if parsed, err := url.Parse(address); err == nil && HasSystemPath(parsed) {
+		return "/" + strings.Trim(parsed.Path, "/"), nil
+	}
+	return c.DiscoverSystemPath(ctx)
+}
+
+// Reset requests a reset of a Redfish system of the given reset type, such as ForceOff, through the standard
+// ComputerSystem.Reset action
+func (c *Client) Reset(ctx context.Context, systemPath, resetType string) error {
+	_, err := c.Do(ctx, http.MethodPost, systemPath+resetActionPath, map[string]string{"ResetType": resetType}, nil)
+	return err
+}
+
+// PowerState returns the power state of a Redfish system, such as On or Off
+func (c *Client) PowerState(ctx context.Context, systemPath string) (string, error) {
+	system := &systemPower{}
+	if err := c.Get(ctx, systemPath, system); err != nil {
+		return "", err
+	}
+	return system.PowerState, nil
+}
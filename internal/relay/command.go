@@ -0,0 +1,141 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/logging"
+)
+
+// Command is the manager subcommand that runs the relay agent
+const Command = "relay"
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	idleTimeout       = 120 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// Main runs the relay subcommand until it is signalled to stop
+func Main(args []string) int {
+	var listen, tlsCertDir, backend, tokenFile, backendCAFile string
+	var backendInsecure bool
+
+	fs := flag.NewFlagSet(Command, flag.ContinueOnError)
+	fs.StringVar(&listen, "listen", ":8443", "The address the relay listens on.")
+	fs.StringVar(&tlsCertDir, "tls-cert-dir", "",
+		"The path to the directory containing the TLS certificate (tls.crt) and private key (tls.key) of the relay.")
+	fs.StringVar(&backend, "backend", "", "The URL of the hardware manager API.")
+	fs.StringVar(&tokenFile, "token-file", "", "The path to the file containing the token expected from the hub plugin.")
+	fs.StringVar(&backendCAFile, "backend-ca-file", "",
+		"The path to a PEM bundle of CA certificates trusted for the hardware manager, in addition to the system roots.")
+	fs.BoolVar(&backendInsecure, "backend-insecure-skip-tls-verify", false,
+		"Skip the verification of the TLS certificate of the hardware manager. This is insecure and is not recommended.")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	logger := slog.New(logging.NewLoggingContextHandler(slog.LevelInfo)).With(slog.String("module", "relay"))
+	if err := run(ctrl.SetupSignalHandler(), logger, listen, tlsCertDir, backend, tokenFile, backendCAFile, backendInsecure); err != nil {
+		logger.Error("Relay failed", slog.String("error", err.Error()))
+		return 1
+	}
+	return 0
+}
+
+func run(ctx context.Context, logger *slog.Logger,
+	listen, tlsCertDir, backend, tokenFile, backendCAFile string, backendInsecure bool) error {
+	if tlsCertDir == "" {
+		return errors.New("--tls-cert-dir is required, as the relay only serves TLS")
+	}
+	if tokenFile == "" {
+		return errors.New("--token-file is required")
+	}
+
+	backendURL, err := url.Parse(backend)
+	if err != nil {
+		return fmt.Errorf("invalid --backend URL %s: %w", backend, err)
+	}
+
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("failed to read token file %s: %w", tokenFile, err)
+	}
+
+	config := utils.OAuthClientConfig{}
+	if backendCAFile != "" {
+		if config.CaBundle, err = os.ReadFile(backendCAFile); err != nil {
+			return fmt.Errorf("failed to read backend CA file %s: %w", backendCAFile, err)
+		}
+	}
+	transport, err := utils.GetTransportWithCaBundle(config, backendInsecure, false)
+	if err != nil {
+		return fmt.Errorf("failed to get backend transport: %w", err)
+	}
+
+	handler, err := NewHandler(Options{
+		Backend:   backendURL,
+		Token:     strings.TrimSpace(string(token)),
+		Transport: transport,
+		Logger:    logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := utils.GetServerTLSConfig(ctx,
+		filepath.Join(tlsCertDir, "tls.crt"), filepath.Join(tlsCertDir, "tls.key"))
+	if err != nil {
+		return fmt.Errorf("failed to get relay TLS config: %w", err)
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		Addr:              listen,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: readHeaderTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		logger.InfoContext(ctx, "Relay listening", slog.String("address", listen), slog.String("backend", backendURL.String()))
+		if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErrors:
+		return fmt.Errorf("error starting relay: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down relay: %w", err)
+	}
+	return nil
+}
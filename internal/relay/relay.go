@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package relay implements the relay agent mode of the plugin. A relay runs close to a hardware manager that is not
+// reachable from the hub network, such as at a far-edge site, and forwards the requests of the hub plugin to it. The
+// hub reaches the relay over a single TLS channel, authenticated with a shared token.
+package relay
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+const (
+	// TokenHeader carries the token authenticating the hub to the relay. It is removed before requests are
+	// forwarded, so that it does not interfere with the authentication of the hardware manager.
+	TokenHeader = "X-Hwmgr-Relay-Token"
+
+	// ErrorHeader marks the error responses of the relay itself, such as the rejection of a request without the relay
+	// token, to tell them apart from the responses of the hardware manager forwarded by the relay
+	ErrorHeader = "X-Hwmgr-Relay-Error"
+
+	// HealthPath serves the unauthenticated health check of the relay
+	HealthPath = "/healthz"
+)
+
+// Options configures a relay
+type Options struct {
+	// Backend is the URL of the hardware manager API
+	Backend *url.URL
+	// Token is the token expected from the hub
+	Token string
+	// Transport is used to reach the hardware manager
+	Transport http.RoundTripper
+	Logger    *slog.Logger
+}
+
+// NewHandler returns a handler forwarding authenticated requests to the hardware manager
+func NewHandler(opts Options) (http.Handler, error) {
+	if opts.Backend == nil || opts.Backend.Host == "" {
+		return nil, errors.New("relay backend URL is required")
+	}
+	if opts.Token == "" {
+		return nil, errors.New("relay token is required")
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(opts.Backend)
+			r.Out.Header.Del(TokenHeader)
+		},
+		Transport: opts.Transport,
+		ModifyResponse: func(resp *http.Response) error {
+			// Only the relay marks its own errors
+			resp.Header.Del(ErrorHeader)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			logger.WarnContext(r.Context(), "Failed to forward request to hardware manager",
+				slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.String("error", err.Error()))
+			w.Header().Set(ErrorHeader, "unreachable")
+			http.Error(w, fmt.Sprintf("relay failed to reach hardware manager: %s", err.Error()), http.StatusBadGateway)
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/", authenticate(opts.Token, logger, proxy))
+	return mux, nil
+}
+
+// authenticate rejects requests without the relay token
+func authenticate(token string, logger *slog.Logger, next http.Handler) http.Handler {
+	expected := []byte(token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(TokenHeader)), expected) != 1 {
+			logger.WarnContext(r.Context(), "Rejected unauthenticated relay request",
+				slog.String("remoteAddr", r.RemoteAddr), slog.String("path", r.URL.Path))
+			w.Header().Set(ErrorHeader, "unauthorized")
+			http.Error(w, "missing or invalid relay token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
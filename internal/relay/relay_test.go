@@ -0,0 +1,85 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package relay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestRelayHandler(t *testing.T) {
+	var forwarded *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r
+		// A relay error marker set by the backend is not passed on
+		w.Header().Set(ErrorHeader, "spoofed")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	backendURL, _ := url.Parse(backend.URL + "/gui")
+	handler, err := NewHandler(Options{Backend: backendURL, Token: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	relay := httptest.NewServer(handler)
+	defer relay.Close()
+
+	// Requests without the relay token are rejected
+	resp, err := http.Get(relay.URL + "/v1/tenants/default/resources")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized || forwarded != nil {
+		t.Errorf("expected unauthenticated request to be rejected, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get(ErrorHeader) == "" {
+		t.Errorf("expected the rejection to be marked as a relay error")
+	}
+
+	// Authenticated requests are forwarded without the relay token, keeping the hardware manager credentials
+	req, _ := http.NewRequest(http.MethodGet, relay.URL+"/v1/tenants/default/resources", nil)
+	req.Header.Set(TokenHeader, "secret")
+	req.Header.Set("Authorization", "Bearer hwmgr-token")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || forwarded == nil {
+		t.Fatalf("expected request to be forwarded, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get(ErrorHeader) != "" {
+		t.Errorf("expected the response of the hardware manager not to be marked as a relay error")
+	}
+	if forwarded.URL.Path != "/gui/v1/tenants/default/resources" {
+		t.Errorf("unexpected forwarded path: %s", forwarded.URL.Path)
+	}
+	if forwarded.Header.Get(TokenHeader) != "" || forwarded.Header.Get("Authorization") != "Bearer hwmgr-token" {
+		t.Errorf("unexpected forwarded headers: %v", forwarded.Header)
+	}
+
+	// The health check needs no token
+	resp, err = http.Get(relay.URL + HealthPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected health check to succeed, got %d", resp.StatusCode)
+	}
+}
+
+func TestNewHandlerRequiresToken(t *testing.T) {
+	backendURL, _ := url.Parse("https://hwmgr.example.com")
+	if _, err := NewHandler(Options{Backend: backendURL}); err == nil {
+		t.Error("expected error without a token")
+	}
+}
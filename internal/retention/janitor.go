@@ -0,0 +1,188 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
+)
+
+// DefaultInterval is the default interval between purges
+const DefaultInterval = time.Hour
+
+// Defaults of the retention of dead-lettered notifications, which are otherwise kept until replayed
+const (
+	DefaultDeadLetterMaxAge   = 7 * 24 * time.Hour
+	DefaultDeadLetterMaxCount = 500
+)
+
+// Kinds of purged items, as reported in the purge metric
+const (
+	KindNodeOperationHistory = "node-operation-history"
+	KindDeadLetter           = "dead-letter-notification"
+)
+
+// Policy bounds how long and how many items of a kind are retained. A zero field does not bound retention.
+type Policy struct {
+	MaxAge   time.Duration
+	MaxCount int
+}
+
+// Enabled returns true if the policy bounds retention
+func (p Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxCount > 0
+}
+
+// cutoff returns the time before which items are purged, or zero if items are not purged by age
+func (p Policy) cutoff(now time.Time) time.Time {
+	if p.MaxAge <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-p.MaxAge)
+}
+
+// Janitor periodically purges the completed operations and history that would otherwise accumulate in CR annotations
+// and ConfigMaps over the lifetime of a deployment: the operation history of the Nodes and the dead-lettered
+// notifications of the subscriptions. It only runs on the leader, as it updates the Nodes and subscription ConfigMaps.
+type Janitor struct {
+	Client    client.Client
+	Store     subscriptions.Store
+	Logger    *slog.Logger
+	Namespace string
+	Interval  time.Duration
+
+	NodeHistory Policy
+	DeadLetters Policy
+
+	now func() time.Time
+}
+
+func NewJanitor(c client.Client, store subscriptions.Store, logger *slog.Logger, namespace string, interval time.Duration) *Janitor {
+	return &Janitor{
+		Client:    c,
+		Store:     store,
+		Logger:    logger.With(slog.String("module", "retention")),
+		Namespace: namespace,
+		Interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// SetupWithManager adds the janitor to the manager
+func (j *Janitor) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(j); err != nil {
+		return fmt.Errorf("failed to add retention janitor: %w", err)
+	}
+	return nil
+}
+
+func (j *Janitor) NeedLeaderElection() bool {
+	return true
+}
+
+func (j *Janitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		j.PurgeAll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeAll purges the items of each kind beyond their retention policy
+func (j *Janitor) PurgeAll(ctx context.Context) {
+	now := j.now()
+	if j.NodeHistory.Enabled() {
+		if err := j.purgeNodeHistory(ctx, now); err != nil {
+			j.Logger.WarnContext(ctx, "Failed to purge node operation history", slog.String("error", err.Error()))
+		}
+	}
+	if j.DeadLetters.Enabled() && j.Store != nil {
+		if err := j.purgeDeadLetters(ctx, now); err != nil {
+			j.Logger.WarnContext(ctx, "Failed to purge dead-lettered notifications", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// purgeNodeHistory drops the completed operations beyond the retention policy from the history of each Node
+func (j *Janitor) purgeNodeHistory(ctx context.Context, now time.Time) error {
+	nodes := &hwmgmtv1alpha1.NodeList{}
+	if err := j.Client.List(ctx, nodes, client.InNamespace(j.Namespace)); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	before := j.NodeHistory.cutoff(now)
+	for i := range nodes.Items {
+		// Check the cached Node first, to only update the Nodes with operations to drop
+		if utils.PruneNodeOperationHistory(nodes.Items[i].DeepCopy(), before, j.NodeHistory.MaxCount) == 0 {
+			continue
+		}
+
+		dropped := 0
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			node := &hwmgmtv1alpha1.Node{}
+			if err := j.Client.Get(ctx, client.ObjectKeyFromObject(&nodes.Items[i]), node); err != nil {
+				return err // nolint: wrapcheck
+			}
+			if dropped = utils.PruneNodeOperationHistory(node, before, j.NodeHistory.MaxCount); dropped == 0 {
+				return nil
+			}
+			return j.Client.Update(ctx, node) // nolint: wrapcheck
+		})
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				j.Logger.WarnContext(ctx, "Failed to purge the operation history of node",
+					slog.String("node", nodes.Items[i].Name), slog.String("error", err.Error()))
+			}
+			continue
+		}
+		recordPurged(KindNodeOperationHistory, dropped)
+	}
+	return nil
+}
+
+// purgeDeadLetters purges the dead-lettered notifications beyond the retention policy from each subscription
+func (j *Janitor) purgeDeadLetters(ctx context.Context, now time.Time) error {
+	all, err := j.Store.AllSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	before := j.DeadLetters.cutoff(now)
+	for hwMgrId, subs := range all {
+		for _, subscription := range subs {
+			if subscription.SubscriptionId == nil {
+				continue
+			}
+			purged, err := j.Store.PurgeDeadLetterNotifications(ctx, hwMgrId, *subscription.SubscriptionId, before, j.DeadLetters.MaxCount)
+			if err != nil {
+				j.Logger.WarnContext(ctx, "Failed to purge the dead-lettered notifications of subscription",
+					slog.String("hwMgrId", hwMgrId), slog.String("subscription", subscription.SubscriptionId.String()),
+					slog.String("error", err.Error()))
+				continue
+			}
+			recordPurged(KindDeadLetter, purged)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
+)
+
+// nodesClient serves and updates a fixed set of Nodes
+type nodesClient struct {
+	client.Client
+	nodes   map[string]*hwmgmtv1alpha1.Node
+	updates int
+}
+
+func (c *nodesClient) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	nodeList := list.(*hwmgmtv1alpha1.NodeList)
+	for _, node := range c.nodes {
+		nodeList.Items = append(nodeList.Items, *node.DeepCopy())
+	}
+	return nil
+}
+
+func (c *nodesClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	c.nodes[key.Name].DeepCopyInto(obj.(*hwmgmtv1alpha1.Node))
+	return nil
+}
+
+func (c *nodesClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.updates++
+	c.nodes[obj.GetName()] = obj.(*hwmgmtv1alpha1.Node).DeepCopy()
+	return nil
+}
+
+func TestJanitorPurgeAll(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	clock := clocktesting.NewFakeClock(now)
+
+	old := &hwmgmtv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-old", Namespace: "hwmgr"}}
+	utils.StartNodeOperation(old, utils.NodeOperationProfileUpdate, "job-1", clock)
+	utils.EndNodeOperation(old, utils.NodeOperationSucceeded, "", clock)
+	recent := &hwmgmtv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-recent", Namespace: "hwmgr"}}
+	utils.StartNodeOperation(recent, utils.NodeOperationProfileUpdate, "job-2", clock)
+	c := &nodesClient{nodes: map[string]*hwmgmtv1alpha1.Node{old.Name: old, recent.Name: recent}}
+
+	store := subscriptions.NewMemoryStore()
+	created, err := store.CreateSubscription(ctx, "hwmgr-1", generated.Subscription{Callback: "https://smo.example.com/cb"})
+	if err != nil {
+		t.Fatalf("unexpected error creating subscription: %v", err)
+	}
+	id := *created.SubscriptionId
+	for i := 0; i < 3; i++ {
+		n := subscriptions.Notification{NotificationId: uuid.New()}
+		_ = store.EnqueueNotification(ctx, "hwmgr-1", id, n)
+		_ = store.DeadLetterNotification(ctx, "hwmgr-1", id, n.NotificationId)
+	}
+
+	janitor := NewJanitor(c, store, slog.Default(), "hwmgr", time.Hour)
+	janitor.NodeHistory = Policy{MaxAge: time.Hour}
+	janitor.DeadLetters = Policy{MaxCount: 1}
+	janitor.now = func() time.Time { return now.Add(2 * time.Hour) }
+	janitor.PurgeAll(ctx)
+
+	if history := utils.GetNodeOperationHistory(c.nodes[old.Name]); len(history) != 0 {
+		t.Errorf("expected the completed operation past the maximum age to be purged, got %+v", history)
+	}
+	if history := utils.GetNodeOperationHistory(c.nodes[recent.Name]); len(history) != 1 {
+		t.Errorf("expected the operation in progress to be kept, got %+v", history)
+	}
+	if c.updates != 1 {
+		t.Errorf("expected only the node with operations to purge to be updated, got %d updates", c.updates)
+	}
+	if deadLetters, _ := store.DeadLetterNotifications(ctx, "hwmgr-1", id); len(deadLetters) != 1 {
+		t.Errorf("expected the dead letters beyond the count to be purged, got %d", len(deadLetters))
+	}
+}
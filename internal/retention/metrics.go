@@ -0,0 +1,28 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package retention
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var itemsPurged = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "hwmgr_plugin_retention_purged_total",
+	Help: "Number of items purged by the retention janitor, by kind",
+}, []string{"kind"})
+
+func init() {
+	metrics.Registry.MustRegister(itemsPurged)
+}
+
+// recordPurged counts the items of a kind purged by the janitor
+func recordPurged(kind string, count int) {
+	if count > 0 {
+		itemsPurged.WithLabelValues(kind).Add(float64(count))
+	}
+}
@@ -0,0 +1,312 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AccessLogFormat is the format of the inventory API access log
+type AccessLogFormat string
+
+const (
+	// AccessLogFormatNone disables the access log
+	AccessLogFormatNone AccessLogFormat = "none"
+	// AccessLogFormatCombined is the NCSA combined log format
+	AccessLogFormatCombined AccessLogFormat = "combined"
+	// AccessLogFormatOTLP writes each record as an OTLP/JSON logs export request, one per line, as read by the file
+	// receivers of OpenTelemetry collectors
+	AccessLogFormatOTLP AccessLogFormat = "otlp"
+)
+
+// accessLogScope is the instrumentation scope of the OTLP access log records
+const accessLogScope = "oran-hwmgr-plugin/inventory-api/access"
+
+// AccessLog records each request served by the inventory API, separately from the application logs
+type AccessLog struct {
+	format AccessLogFormat
+	mu     sync.Mutex
+	out    io.Writer
+	closer io.Closer
+}
+
+// NewAccessLog returns an access log in the given format, written to the file at path, or to stdout if path is empty.
+// A nil AccessLog is returned if the format is none.
+func NewAccessLog(format AccessLogFormat, path string) (*AccessLog, error) {
+	switch format {
+	case AccessLogFormatNone, "":
+		return nil, nil
+	case AccessLogFormatCombined, AccessLogFormatOTLP:
+	default:
+		return nil, fmt.Errorf("unsupported access log format: %s", format)
+	}
+
+	if path == "" {
+		return &AccessLog{format: format, out: os.Stdout}, nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open access log file %s: %w", path, err)
+	}
+	return &AccessLog{format: format, out: file, closer: file}, nil
+}
+
+// Close closes the access log file, if any
+func (l *AccessLog) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+	if err := l.closer.Close(); err != nil {
+		return fmt.Errorf("failed to close access log: %w", err)
+	}
+	return nil
+}
+
+// accessLogEntry holds the details of a request recorded in the access log
+type accessLogEntry struct {
+	start      time.Time
+	duration   time.Duration
+	remoteAddr string
+	user       string
+	method     string
+	requestURI string
+	path       string
+	proto      string
+	referer    string
+	userAgent  string
+	status     int
+	bytes      int64
+}
+
+type accessLogUserKey struct{}
+
+// SetAccessLogUser records the authenticated user of a request for the access log
+func SetAccessLogUser(ctx context.Context, user string) {
+	if entry, ok := ctx.Value(accessLogUserKey{}).(*accessLogEntry); ok {
+		entry.user = user
+	}
+}
+
+type accessLogWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *accessLogWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err // nolint: wrapcheck
+}
+
+// GetAccessLogFunc records each request in the access log. It must be the outermost middleware, so that requests
+// rejected by the other middlewares are recorded.
+func GetAccessLogFunc(l *AccessLog) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			entry := &accessLogEntry{
+				start:      time.Now(),
+				remoteAddr: r.RemoteAddr,
+				method:     r.Method,
+				requestURI: r.RequestURI,
+				path:       r.URL.Path,
+				proto:      r.Proto,
+				referer:    r.Referer(),
+				userAgent:  r.UserAgent(),
+			}
+			writer := &accessLogWriter{ResponseWriter: w}
+			next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), accessLogUserKey{}, entry)))
+
+			entry.duration = time.Since(entry.start)
+			entry.status = writer.status
+			if entry.status == 0 {
+				entry.status = http.StatusOK
+			}
+			entry.bytes = writer.bytes
+			l.write(entry)
+		})
+	}
+}
+
+func (l *AccessLog) write(entry *accessLogEntry) {
+	var line []byte
+	switch l.format {
+	case AccessLogFormatCombined:
+		line = []byte(formatCombined(entry))
+	case AccessLogFormatOTLP:
+		data, err := json.Marshal(formatOTLP(entry))
+		if err != nil {
+			slog.Warn("Failed to encode access log record", slog.String("error", err.Error()))
+			return
+		}
+		line = data
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.out.Write(append(line, '\n')); err != nil {
+		slog.Warn("Failed to write access log record", slog.String("error", err.Error()))
+	}
+}
+
+// clientHost returns the host part of the remote address of a request
+func clientHost(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
+
+// combinedField returns the value of a combined log field, or "-" if it is empty
+func combinedField(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// combinedQuoted escapes a value for a quoted combined log field
+func combinedQuoted(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(combinedField(value))
+}
+
+// formatCombined formats an entry in the NCSA combined log format
+func formatCombined(entry *accessLogEntry) string {
+	bytes := "-"
+	if entry.bytes > 0 {
+		bytes = strconv.FormatInt(entry.bytes, 10)
+	}
+	return fmt.Sprintf(`%s - %s [%s] "%s" %d %s "%s" "%s"`,
+		combinedField(clientHost(entry.remoteAddr)),
+		combinedField(strings.ReplaceAll(entry.user, " ", "_")),
+		entry.start.Format("02/Jan/2006:15:04:05 -0700"),
+		combinedQuoted(fmt.Sprintf("%s %s %s", entry.method, entry.requestURI, entry.proto)),
+		entry.status,
+		bytes,
+		combinedQuoted(entry.referer),
+		combinedQuoted(entry.userAgent))
+}
+
+// The OTLP/JSON encoding of the logs data model. 64-bit integers are encoded as strings.
+type otlpLogsData struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string          `json:"timeUnixNano"`
+	ObservedTimeUnixNano string          `json:"observedTimeUnixNano"`
+	SeverityNumber       int             `json:"severityNumber"`
+	SeverityText         string          `json:"severityText"`
+	Body                 otlpAnyValue    `json:"body"`
+	Attributes           []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func otlpString(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpInt(key string, value int64) otlpAttribute {
+	encoded := strconv.FormatInt(value, 10)
+	return otlpAttribute{Key: key, Value: otlpAnyValue{IntValue: &encoded}}
+}
+
+// formatOTLP formats an entry as an OTLP logs record, with attributes following the OpenTelemetry HTTP semantic
+// conventions
+func formatOTLP(entry *accessLogEntry) otlpLogsData {
+	// Severity numbers of the OpenTelemetry logs data model
+	severityNumber, severityText := 9, "INFO"
+	if entry.status >= http.StatusInternalServerError {
+		severityNumber, severityText = 17, "ERROR"
+	} else if entry.status >= http.StatusBadRequest {
+		severityNumber, severityText = 13, "WARN"
+	}
+
+	body := fmt.Sprintf("%s %s %d", entry.method, entry.requestURI, entry.status)
+	seconds := entry.duration.Seconds()
+	attributes := []otlpAttribute{
+		otlpString("http.request.method", entry.method),
+		otlpString("url.path", entry.path),
+		otlpString("network.protocol.version", strings.TrimPrefix(entry.proto, "HTTP/")),
+		otlpInt("http.response.status_code", int64(entry.status)),
+		otlpInt("http.response.body.size", entry.bytes),
+		otlpString("client.address", clientHost(entry.remoteAddr)),
+		{Key: "http.server.request.duration", Value: otlpAnyValue{DoubleValue: &seconds}},
+	}
+	if entry.user != "" {
+		attributes = append(attributes, otlpString("enduser.id", entry.user))
+	}
+	if entry.userAgent != "" {
+		attributes = append(attributes, otlpString("user_agent.original", entry.userAgent))
+	}
+
+	return otlpLogsData{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: otlpResource{Attributes: []otlpAttribute{otlpString("service.name", "oran-hwmgr-plugin")}},
+			ScopeLogs: []otlpScopeLogs{{
+				Scope: otlpScope{Name: accessLogScope},
+				LogRecords: []otlpLogRecord{{
+					TimeUnixNano:         strconv.FormatInt(entry.start.UnixNano(), 10),
+					ObservedTimeUnixNano: strconv.FormatInt(entry.start.Add(entry.duration).UnixNano(), 10),
+					SeverityNumber:       severityNumber,
+					SeverityText:         severityText,
+					Body:                 otlpAnyValue{StringValue: &body},
+					Attributes:           attributes,
+				}},
+			}},
+		}},
+	}
+}
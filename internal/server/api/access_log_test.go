@@ -0,0 +1,85 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func serveAccessLogged(t *testing.T, format AccessLogFormat, status int) string {
+	t.Helper()
+	out := &bytes.Buffer{}
+	accessLog := &AccessLog{format: format, out: out}
+	handler := GetAccessLogFunc(accessLog)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetAccessLogUser(r.Context(), "system:serviceaccount:smo:client")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/hardware-manager/inventory/v1/resources?limit=10", nil)
+	req.RemoteAddr = "192.0.2.10:51234"
+	req.Header.Set("User-Agent", `smo "client"`)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	return out.String()
+}
+
+func TestAccessLogCombined(t *testing.T) {
+	line := serveAccessLogged(t, AccessLogFormatCombined, http.StatusOK)
+	expected := regexp.MustCompile(`^192\.0\.2\.10 - system:serviceaccount:smo:client \[[^\]]+\] ` +
+		`"GET /hardware-manager/inventory/v1/resources\?limit=10 HTTP/1\.1" 200 15 "-" "smo \\"client\\""\n$`)
+	if !expected.MatchString(line) {
+		t.Errorf("unexpected combined log line: %q", line)
+	}
+}
+
+func TestAccessLogOTLP(t *testing.T) {
+	line := serveAccessLogged(t, AccessLogFormatOTLP, http.StatusNotFound)
+
+	data := otlpLogsData{}
+	if err := json.Unmarshal([]byte(line), &data); err != nil {
+		t.Fatalf("failed to parse OTLP record: %v", err)
+	}
+	record := data.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	if record.SeverityText != "WARN" || record.SeverityNumber != 13 {
+		t.Errorf("expected a warning for a client error, got %s (%d)", record.SeverityText, record.SeverityNumber)
+	}
+
+	attributes := make(map[string]otlpAnyValue)
+	for _, attribute := range record.Attributes {
+		attributes[attribute.Key] = attribute.Value
+	}
+	if value := attributes["http.response.status_code"].IntValue; value == nil || *value != "404" {
+		t.Errorf("unexpected status code attribute: %v", value)
+	}
+	if value := attributes["url.path"].StringValue; value == nil || *value != "/hardware-manager/inventory/v1/resources" {
+		t.Errorf("unexpected path attribute: %v", value)
+	}
+	if value := attributes["enduser.id"].StringValue; value == nil || *value != "system:serviceaccount:smo:client" {
+		t.Errorf("unexpected user attribute: %v", value)
+	}
+}
+
+func TestNewAccessLog(t *testing.T) {
+	if accessLog, err := NewAccessLog(AccessLogFormatNone, ""); err != nil || accessLog != nil {
+		t.Errorf("expected no access log, got %v, %v", accessLog, err)
+	}
+	if _, err := NewAccessLog("apache", ""); err == nil {
+		t.Errorf("expected an unsupported format to be rejected")
+	}
+	accessLog, err := NewAccessLog(AccessLogFormatCombined, t.TempDir()+"/access.log")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := accessLog.Close(); err != nil {
+		t.Errorf("unexpected error closing access log: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// gzipResponseWriter buffers the start of a response and switches to gzip compression once it exceeds the minimum
+// size. Once compressing, data is streamed to the client as it is written, using chunked transfer encoding, rather
+// than being held in memory until the response is complete.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minSize     int
+	statusCode  int
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+	passthrough bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(statusCode int) {
+	// Defer writing the header until we know whether the response is compressed
+	g.statusCode = statusCode
+}
+
+func (g *gzipResponseWriter) Write(data []byte) (int, error) {
+	if g.passthrough {
+		return g.ResponseWriter.Write(data) // nolint: wrapcheck
+	}
+	if g.gz != nil {
+		return g.gz.Write(data) // nolint: wrapcheck
+	}
+
+	g.buf.Write(data)
+	if g.buf.Len() < g.minSize {
+		return len(data), nil
+	}
+
+	if err := g.startCompression(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// startCompression sets the compression headers and flushes the buffered data through the gzip writer
+func (g *gzipResponseWriter) startCompression() error {
+	header := g.Header()
+	if header.Get("Content-Encoding") != "" {
+		// The handler has already encoded the response
+		g.passthrough = true
+		g.writeHeader()
+		_, err := g.ResponseWriter.Write(g.buf.Bytes())
+		return err // nolint: wrapcheck
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	g.writeHeader()
+
+	g.gz = gzip.NewWriter(g.ResponseWriter)
+	if _, err := g.gz.Write(g.buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write compressed response: %w", err)
+	}
+	g.buf.Reset()
+	return nil
+}
+
+func (g *gzipResponseWriter) writeHeader() {
+	if g.statusCode == 0 {
+		g.statusCode = http.StatusOK
+	}
+	g.ResponseWriter.WriteHeader(g.statusCode)
+}
+
+// Flush sends any buffered data to the client
+func (g *gzipResponseWriter) Flush() {
+	if g.gz == nil && !g.passthrough {
+		if err := g.startCompression(); err != nil {
+			return
+		}
+	}
+	if g.gz != nil {
+		_ = g.gz.Flush()
+	}
+	if flusher, ok := g.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// close completes the response, sending small responses uncompressed
+func (g *gzipResponseWriter) close() error {
+	if g.gz != nil {
+		return g.gz.Close() // nolint: wrapcheck
+	}
+	if !g.passthrough {
+		g.writeHeader()
+		if g.buf.Len() > 0 {
+			_, err := g.ResponseWriter.Write(g.buf.Bytes())
+			return err // nolint: wrapcheck
+		}
+	}
+	return nil
+}
+
+// acceptsGzip checks whether the request's Accept-Encoding header allows gzip
+func acceptsGzip(r *http.Request) bool {
+	for _, value := range r.Header.Values("Accept-Encoding") {
+		for _, encoding := range strings.Split(value, ",") {
+			name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+			if strings.EqualFold(strings.TrimSpace(name), "gzip") {
+				return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+			}
+		}
+	}
+	return false
+}
+
+// GetCompressionFunc compresses responses of at least minSize bytes with gzip, when accepted by the client.
+func GetCompressionFunc(minSize int) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptsGzip(r) || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			g := &gzipResponseWriter{
+				ResponseWriter: w,
+				minSize:        minSize,
+			}
+			next.ServeHTTP(g, r)
+			if err := g.close(); err != nil {
+				slog.Error("failed to complete compressed response", "url", r.RequestURI, "error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func serveCompressed(t *testing.T, body string, acceptEncoding string) *httptest.ResponseRecorder {
+	t.Helper()
+	handler := GetCompressionFunc(64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/hardware-manager/inventory/v1/resources", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCompressionLargeResponse(t *testing.T) {
+	body := strings.Repeat(`{"resourceId":"abc"},`, 100)
+	rec := serveCompressed(t, body, "deflate, gzip;q=0.8")
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected status %d, got %d", http.StatusCreated, rec.Code)
+	}
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip encoding, got %q", rec.Header().Get("Content-Encoding"))
+	}
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading gzip body: %v", err)
+	}
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("unexpected error decompressing body: %v", err)
+	}
+	if string(data) != body {
+		t.Errorf("decompressed body does not match original")
+	}
+}
+
+func TestCompressionSmallResponse(t *testing.T) {
+	rec := serveCompressed(t, `{"status":"ok"}`, "gzip")
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected status %d, got %d", http.StatusCreated, rec.Code)
+	}
+	if rec.Header().Get("Content-Encoding") != "" {
+		t.Errorf("expected no encoding for small response, got %q", rec.Header().Get("Content-Encoding"))
+	}
+	if rec.Body.String() != `{"status":"ok"}` {
+		t.Errorf("unexpected body %q", rec.Body.String())
+	}
+}
+
+func TestCompressionNotAccepted(t *testing.T) {
+	body := strings.Repeat("x", 1000)
+	for _, acceptEncoding := range []string{"", "deflate", "gzip;q=0"} {
+		rec := serveCompressed(t, body, acceptEncoding)
+		if rec.Header().Get("Content-Encoding") != "" {
+			t.Errorf("Accept-Encoding %q: expected no encoding, got %q", acceptEncoding, rec.Header().Get("Content-Encoding"))
+		}
+		if rec.Body.String() != body {
+			t.Errorf("Accept-Encoding %q: unexpected body", acceptEncoding)
+		}
+	}
+}
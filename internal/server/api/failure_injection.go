@@ -0,0 +1,145 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Request headers controlling the failure injection middleware
+const (
+	// FaultPathHeader limits the injected faults to requests whose path matches the regular expression
+	FaultPathHeader = "X-Fault-Path"
+	// FaultDelayHeader delays the request by the given duration, such as "2s", before it is handled
+	FaultDelayHeader = "X-Fault-Delay"
+	// FaultStatusHeader fails the request with the given 5xx status code, without handling it
+	FaultStatusHeader = "X-Fault-Status"
+	// FaultTruncateHeader truncates the response body after the given number of bytes
+	FaultTruncateHeader = "X-Fault-Truncate"
+)
+
+// maxFaultDelay bounds the injected delay, so that a request cannot hold a connection indefinitely
+const maxFaultDelay = time.Minute
+
+// faultSpec holds the faults requested for a request
+type faultSpec struct {
+	delay    time.Duration
+	status   int
+	truncate int
+}
+
+// parseFaultSpec parses the fault headers of a request, returning nil if no fault applies to the request
+func parseFaultSpec(r *http.Request) (*faultSpec, error) {
+	if pattern := r.Header.Get(FaultPathHeader); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s header: %w", FaultPathHeader, err)
+		}
+		if !re.MatchString(r.URL.Path) {
+			return nil, nil
+		}
+	}
+
+	spec := &faultSpec{truncate: -1}
+	if value := r.Header.Get(FaultDelayHeader); value != "" {
+		delay, err := time.ParseDuration(value)
+		if err != nil || delay < 0 || delay > maxFaultDelay {
+			return nil, fmt.Errorf("invalid %s header: expected a duration of at most %s", FaultDelayHeader, maxFaultDelay)
+		}
+		spec.delay = delay
+	}
+	if value := r.Header.Get(FaultStatusHeader); value != "" {
+		status, err := strconv.Atoi(value)
+		if err != nil || status < 500 || status > 599 {
+			return nil, fmt.Errorf("invalid %s header: expected a 5xx status code", FaultStatusHeader)
+		}
+		spec.status = status
+	}
+	if value := r.Header.Get(FaultTruncateHeader); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid %s header: expected a number of bytes", FaultTruncateHeader)
+		}
+		spec.truncate = size
+	}
+
+	if spec.delay == 0 && spec.status == 0 && spec.truncate < 0 {
+		return nil, nil
+	}
+	return spec, nil
+}
+
+// truncatingResponseWriter discards the response body beyond the given size. The handler is told the whole body was
+// written, so that it completes normally and the client receives a response that is cut short.
+type truncatingResponseWriter struct {
+	http.ResponseWriter
+	remaining int
+}
+
+func (t *truncatingResponseWriter) WriteHeader(statusCode int) {
+	// The declared length would no longer match the body
+	t.Header().Del("Content-Length")
+	t.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (t *truncatingResponseWriter) Write(data []byte) (int, error) {
+	if t.remaining <= 0 {
+		return len(data), nil
+	}
+	n := min(len(data), t.remaining)
+	written, err := t.ResponseWriter.Write(data[:n])
+	t.remaining -= written
+	if err != nil {
+		return written, err // nolint: wrapcheck
+	}
+	return len(data), nil
+}
+
+// GetFailureInjectionFunc injects delays, errors and truncated responses as requested by the X-Fault-* headers of each
+// request, for resilience testing of API consumers. It is only installed when the FailureInjection feature gate is
+// enabled.
+func GetFailureInjectionFunc() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			spec, err := parseFaultSpec(r)
+			if err != nil {
+				ProblemDetails(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if spec == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			slog.Info("Injecting fault", "method", r.Method, "url", r.RequestURI,
+				"delay", spec.delay.String(), "status", spec.status, "truncate", spec.truncate)
+
+			if spec.delay > 0 {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-time.After(spec.delay):
+				}
+			}
+
+			if spec.truncate >= 0 {
+				w = &truncatingResponseWriter{ResponseWriter: w, remaining: spec.truncate}
+			}
+
+			if spec.status != 0 {
+				ProblemDetails(w, fmt.Sprintf("injected fault: %s", http.StatusText(spec.status)), spec.status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const faultTestBody = `{"resourceId":"abc"}`
+
+func serveWithFaults(t *testing.T, path string, headers map[string]string) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	handled := false
+	handler := GetFailureInjectionFunc()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(faultTestBody))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, handled
+}
+
+func TestFailureInjectionNoFault(t *testing.T) {
+	rec, handled := serveWithFaults(t, "/hardware-manager/inventory/v1/manager/hwmgr/resources", nil)
+	if !handled || rec.Code != http.StatusOK || rec.Body.String() != faultTestBody {
+		t.Errorf("expected unmodified response, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestFailureInjectionStatus(t *testing.T) {
+	rec, handled := serveWithFaults(t, "/hardware-manager/inventory/v1/manager/hwmgr/resources",
+		map[string]string{FaultStatusHeader: "503"})
+	if handled || rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected injected 503 without handling the request, got %d", rec.Code)
+	}
+}
+
+func TestFailureInjectionPathMismatch(t *testing.T) {
+	rec, handled := serveWithFaults(t, "/hardware-manager/inventory/v1/manager/hwmgr/resources",
+		map[string]string{FaultStatusHeader: "500", FaultPathHeader: "/resourcePools$"})
+	if !handled || rec.Code != http.StatusOK {
+		t.Errorf("expected fault not to apply to other endpoints, got %d", rec.Code)
+	}
+}
+
+func TestFailureInjectionTruncate(t *testing.T) {
+	rec, handled := serveWithFaults(t, "/hardware-manager/inventory/v1/manager/hwmgr/resources",
+		map[string]string{FaultTruncateHeader: "5", FaultPathHeader: "/resources$"})
+	if !handled || rec.Code != http.StatusOK || rec.Body.String() != faultTestBody[:5] {
+		t.Errorf("expected truncated response, got %d: %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestFailureInjectionDelay(t *testing.T) {
+	start := time.Now()
+	_, handled := serveWithFaults(t, "/hardware-manager/inventory/v1/manager/hwmgr/resources",
+		map[string]string{FaultDelayHeader: "50ms"})
+	if !handled || time.Since(start) < 50*time.Millisecond {
+		t.Errorf("expected the request to be delayed")
+	}
+}
+
+func TestFailureInjectionInvalidHeaders(t *testing.T) {
+	for _, headers := range []map[string]string{
+		{FaultStatusHeader: "404"},
+		{FaultDelayHeader: "forever"},
+		{FaultDelayHeader: "2h"},
+		{FaultTruncateHeader: "-1"},
+		{FaultPathHeader: "("},
+	} {
+		rec, handled := serveWithFaults(t, "/hardware-manager/inventory/v1/manager/hwmgr/resources", headers)
+		if handled || rec.Code != http.StatusBadRequest {
+			t.Errorf("%v: expected bad request, got %d", headers, rec.Code)
+		}
+	}
+}
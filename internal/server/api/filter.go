@@ -0,0 +1,43 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/filter"
+)
+
+// parseFilter parses the filter query parameter of a list request, matching all items if it is not set
+func parseFilter(param *generated.Filter) (*filter.Expression, error) {
+	if param == nil {
+		return &filter.Expression{}, nil
+	}
+	expression, err := filter.Parse(*param)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return expression, nil
+}
+
+// badFilter is the response to a list request with an invalid filter
+func badFilter(err error) generated.ProblemDetails {
+	return generated.ProblemDetails{
+		Status: http.StatusBadRequest,
+		Detail: err.Error(),
+	}
+}
+
+// filterFailed is the response to a list request whose items could not be filtered
+func filterFailed(err error) generated.ProblemDetails {
+	return generated.ProblemDetails{
+		Status: http.StatusInternalServerError,
+		Detail: fmt.Sprintf("Failed to filter items: %s", err.Error()),
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
@@ -23,6 +24,52 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AllocationDiscrepancyType.
+const (
+	BackendAllocationWithoutNode AllocationDiscrepancyType = "BackendAllocationWithoutNode"
+	NodeWithoutBackendAllocation AllocationDiscrepancyType = "NodeWithoutBackendAllocation"
+)
+
+// Defines values for DeadLetterNotificationNotificationEventType.
+const (
+	DeadLetterNotificationNotificationEventTypeN0 DeadLetterNotificationNotificationEventType = 0
+	DeadLetterNotificationNotificationEventTypeN1 DeadLetterNotificationNotificationEventType = 1
+	DeadLetterNotificationNotificationEventTypeN2 DeadLetterNotificationNotificationEventType = 2
+)
+
+// Defines values for NodePoolExtensionsExtensionsVersion.
+const (
+	V1 NodePoolExtensionsExtensionsVersion = "v1"
+)
+
+// Defines values for NodePoolExtensionsSpreadMode.
+const (
+	Preferred NodePoolExtensionsSpreadMode = "preferred"
+	Required  NodePoolExtensionsSpreadMode = "required"
+)
+
+// Defines values for ProvisioningNodeGroupRole.
+const (
+	Master ProvisioningNodeGroupRole = "master"
+	Worker ProvisioningNodeGroupRole = "worker"
+)
+
+// Defines values for ProvisioningRequestStatusPhase.
+const (
+	Configuring  ProvisioningRequestStatusPhase = "Configuring"
+	Failed       ProvisioningRequestStatusPhase = "Failed"
+	Provisioned  ProvisioningRequestStatusPhase = "Provisioned"
+	Provisioning ProvisioningRequestStatusPhase = "Provisioning"
+	Releasing    ProvisioningRequestStatusPhase = "Releasing"
+)
+
+// Defines values for ResourceChangeNotificationNotificationEventType.
+const (
+	ResourceChangeNotificationNotificationEventTypeN0 ResourceChangeNotificationNotificationEventType = 0
+	ResourceChangeNotificationNotificationEventTypeN1 ResourceChangeNotificationNotificationEventType = 1
+	ResourceChangeNotificationNotificationEventTypeN2 ResourceChangeNotificationNotificationEventType = 2
+)
+
 // Defines values for ResourceInfoAdminState.
 const (
 	ResourceInfoAdminStateLOCKED       ResourceInfoAdminState = "LOCKED"
@@ -46,10 +93,40 @@ const (
 
 // Defines values for ResourceInfoUsageState.
 const (
-	ACTIVE  ResourceInfoUsageState = "ACTIVE"
-	BUSY    ResourceInfoUsageState = "BUSY"
-	IDLE    ResourceInfoUsageState = "IDLE"
-	UNKNOWN ResourceInfoUsageState = "UNKNOWN"
+	ACTIVE                ResourceInfoUsageState = "ACTIVE"
+	BUSY                  ResourceInfoUsageState = "BUSY"
+	EXTERNALLYPROVISIONED ResourceInfoUsageState = "EXTERNALLY_PROVISIONED"
+	IDLE                  ResourceInfoUsageState = "IDLE"
+	UNKNOWN               ResourceInfoUsageState = "UNKNOWN"
+)
+
+// Defines values for ResourceTypeAttributeType.
+const (
+	Array   ResourceTypeAttributeType = "array"
+	Integer ResourceTypeAttributeType = "integer"
+	String  ResourceTypeAttributeType = "string"
+)
+
+// Defines values for ResourceTypeInfoResourceClass.
+const (
+	ResourceTypeInfoResourceClassCOMPUTE    ResourceTypeInfoResourceClass = "COMPUTE"
+	ResourceTypeInfoResourceClassNETWORKING ResourceTypeInfoResourceClass = "NETWORKING"
+	ResourceTypeInfoResourceClassSTORAGE    ResourceTypeInfoResourceClass = "STORAGE"
+	ResourceTypeInfoResourceClassUNDEFINED  ResourceTypeInfoResourceClass = "UNDEFINED"
+)
+
+// Defines values for ResourceTypeInfoResourceKind.
+const (
+	ResourceTypeInfoResourceKindLOGICAL   ResourceTypeInfoResourceKind = "LOGICAL"
+	ResourceTypeInfoResourceKindPHYSICAL  ResourceTypeInfoResourceKind = "PHYSICAL"
+	ResourceTypeInfoResourceKindUNDEFINED ResourceTypeInfoResourceKind = "UNDEFINED"
+)
+
+// Defines values for StorageInfoType.
+const (
+	HDD  StorageInfoType = "HDD"
+	NVME StorageInfoType = "NVME"
+	SSD  StorageInfoType = "SSD"
 )
 
 // APIVersion Information about a version of the API.
@@ -63,6 +140,196 @@ type APIVersions struct {
 	UriPrefix   *string       `json:"uriPrefix,omitempty"`
 }
 
+// AdaptorInfo Information about an adaptor.
+type AdaptorInfo struct {
+	// AdaptorId Identifier of the adaptor.
+	AdaptorId string `json:"adaptorId"`
+
+	// Backend Information about the backend used by an adaptor.
+	Backend *BackendInfo `json:"backend,omitempty"`
+
+	// Enabled Whether the adaptor is enabled. An adaptor is disabled while its backend is unavailable, such as the metal3
+	// adaptor when the BareMetalHost CRD is not installed, and is enabled again once it becomes available.
+	Enabled bool `json:"enabled"`
+
+	// Firmware Range of firmware versions found on hardware managed by the adaptor, per component.
+	Firmware *[]FirmwareRange `json:"firmware,omitempty"`
+
+	// StatusMessage The reason the adaptor is disabled.
+	StatusMessage *string `json:"statusMessage,omitempty"`
+
+	// Version Version of the adaptor.
+	Version string `json:"version"`
+}
+
+// AllocationDiscrepancy Hardware that is allocated in the backend without a Node, or a Node without an allocation in the backend.
+type AllocationDiscrepancy struct {
+	// HwMgrNodeId The backend identifier of the hardware
+	HwMgrNodeId string `json:"hwMgrNodeId"`
+
+	// HwMgrNodeNs The backend namespace of the hardware, if any
+	HwMgrNodeNs *string `json:"hwMgrNodeNs,omitempty"`
+
+	// NodeName The name of the Node, for a Node without a backend allocation
+	NodeName *string `json:"nodeName,omitempty"`
+
+	// NodePool The NodePool of the Node, or what the hardware is allocated to in the backend, such as a Dell resource group
+	NodePool *string `json:"nodePool,omitempty"`
+
+	// Type The kind of discrepancy
+	Type AllocationDiscrepancyType `json:"type"`
+}
+
+// AllocationDiscrepancyType The kind of discrepancy
+type AllocationDiscrepancyType string
+
+// AllocationReport The discrepancies between the Nodes of a hardware manager and the hardware allocated in its backend.
+type AllocationReport struct {
+	// AllocationCount The number of hardware allocations in the backend
+	AllocationCount int                     `json:"allocationCount"`
+	Discrepancies   []AllocationDiscrepancy `json:"discrepancies"`
+
+	// GeneratedAt The time of the comparison
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// HwMgrId The hardware manager compared
+	HwMgrId string `json:"hwMgrId"`
+
+	// NodeCount The number of Nodes of the hardware manager
+	NodeCount int `json:"nodeCount"`
+}
+
+// BackendInfo Information about the backend used by an adaptor.
+type BackendInfo struct {
+	// Name Name of the backend.
+	Name string `json:"name"`
+
+	// Version Version of the backend, where known.
+	Version *string `json:"version,omitempty"`
+}
+
+// DeadLetterNotification defines model for DeadLetterNotification.
+type DeadLetterNotification struct {
+	// Attempts The number of failed delivery attempts
+	Attempts int `json:"attempts"`
+
+	// ConsumerSubscriptionId The value provided by the consumer in the subscription
+	ConsumerSubscriptionId *openapi_types.UUID `json:"consumerSubscriptionId,omitempty"`
+
+	// DeadLetteredAt When the notification was moved to the dead-letter queue
+	DeadLetteredAt *time.Time `json:"deadLetteredAt,omitempty"`
+
+	// LastError The reason for the most recent delivery failure
+	LastError *string `json:"lastError,omitempty"`
+
+	// NotificationEventType One of the following values: 0 - create, 1 - modify, 2 - delete
+	NotificationEventType DeadLetterNotificationNotificationEventType `json:"notificationEventType"`
+
+	// NotificationId A unique identifier to represent this notification event
+	NotificationId openapi_types.UUID `json:"notificationId"`
+
+	// Object The changed resource object.
+	Object *map[string]interface{} `json:"object,omitempty"`
+
+	// ObjectRef The URL to the object. This is not required if the notificationEventType is 2 (DELETE).
+	ObjectRef *string `json:"objectRef,omitempty"`
+}
+
+// DeadLetterNotificationNotificationEventType One of the following values: 0 - create, 1 - modify, 2 - delete
+type DeadLetterNotificationNotificationEventType int
+
+// FirmwareRange Range of firmware versions found for a hardware component.
+type FirmwareRange struct {
+	// Component Firmware component.
+	Component string `json:"component"`
+
+	// Hosts Number of hosts reporting a version for the component.
+	Hosts int `json:"hosts"`
+
+	// MaxVersion Highest version found.
+	MaxVersion string `json:"maxVersion"`
+
+	// MinVersion Lowest version found.
+	MinVersion string `json:"minVersion"`
+}
+
+// InventoryRefreshResult The inventory queried from the backend of a hardware manager by a refresh.
+type InventoryRefreshResult struct {
+	// HwMgrId The hardware manager refreshed
+	HwMgrId string `json:"hwMgrId"`
+
+	// RefreshedAt The time of the refresh
+	RefreshedAt time.Time `json:"refreshedAt"`
+
+	// ResourceCount The number of resources queried
+	ResourceCount int `json:"resourceCount"`
+
+	// ResourcePoolCount The number of resource pools queried
+	ResourcePoolCount int `json:"resourcePoolCount"`
+}
+
+// NodePoolExtensions The NodePool extensions, carrying settings beyond the NodePool spec, such as a CPU architecture or site placement
+// policy. The settings below apply to every node group, and most can be set for a single node group with a
+// "<group>.<setting>" key, such as "controller.cpuArchitecture", which takes precedence. Other keys are preserved
+// as is.
+type NodePoolExtensions struct {
+	// CpuArchitecture The CPU architecture requested for the nodes
+	CpuArchitecture *string `json:"cpuArchitecture,omitempty"`
+
+	// ExtensionsVersion The version of the extensions format, the current version if not set
+	ExtensionsVersion *NodePoolExtensionsExtensionsVersion `json:"extensionsVersion,omitempty"`
+
+	// HostnameTemplate The Go template of the hostnames of the nodes, overriding the template configured on the hardware manager
+	HostnameTemplate *string `json:"hostnameTemplate,omitempty"`
+
+	// ManifestTemplate The manifest template bundle rendered for each node, as "configmap/<name>" or "secret/<name>", a bare name
+	// referring to a ConfigMap
+	ManifestTemplate *string `json:"manifestTemplate,omitempty"`
+
+	// NetworkDataTemplate The template of the network data of the hosts of the nodes, as "configmap/<name>" or "secret/<name>", a bare
+	// name referring to a ConfigMap
+	NetworkDataTemplate *string `json:"networkDataTemplate,omitempty"`
+
+	// Rack The rack the nodes must be located in
+	Rack *string `json:"rack,omitempty"`
+
+	// ResourceTypeId The resource type requested for the nodes
+	ResourceTypeId *string `json:"resourceTypeId,omitempty"`
+
+	// Site The site the resource pools of the nodes must belong to
+	Site *string `json:"site,omitempty"`
+
+	// SpreadBy The topology the nodes are spread across, "site", "rack" or the key of a label of the hardware
+	SpreadBy *string `json:"spreadBy,omitempty"`
+
+	// SpreadMode How strictly the nodes are spread across the domains of the topology
+	SpreadMode *NodePoolExtensionsSpreadMode `json:"spreadMode,omitempty"`
+
+	// Tags The tags the nodes must carry, as comma-separated "key=value" pairs
+	Tags                 *string           `json:"tags,omitempty"`
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// NodePoolExtensionsExtensionsVersion The version of the extensions format, the current version if not set
+type NodePoolExtensionsExtensionsVersion string
+
+// NodePoolExtensionsSpreadMode How strictly the nodes are spread across the domains of the topology
+type NodePoolExtensionsSpreadMode string
+
+// PluginInfo Information about the plugin build and its adaptors.
+type PluginInfo struct {
+	Adaptors []AdaptorInfo `json:"adaptors"`
+
+	// GitSha Git commit the plugin was built from.
+	GitSha *string `json:"gitSha,omitempty"`
+
+	// GoVersion Go version the plugin was built with.
+	GoVersion *string `json:"goVersion,omitempty"`
+
+	// Version Version of the plugin.
+	Version string `json:"version"`
+}
+
 // ProblemDetails defines model for ProblemDetails.
 type ProblemDetails struct {
 	// AdditionalAttributes Any number of additional attributes, as defined in a specification or by an implementation.
@@ -103,11 +370,108 @@ type ProcessorInfo struct {
 	Model *string `json:"model,omitempty"`
 }
 
+// ProvisioningNodeGroup A group of nodes with the same role and hardware profile.
+type ProvisioningNodeGroup struct {
+	// HwProfile The hardware profile applied to the nodes of the group
+	HwProfile string `json:"hwProfile"`
+
+	// Name The name of the node group
+	Name string `json:"name"`
+
+	// ResourcePoolId The resource pool the nodes are allocated from
+	ResourcePoolId *string `json:"resourcePoolId,omitempty"`
+
+	// ResourceSelector Selects the resources the nodes are allocated from
+	ResourceSelector *string `json:"resourceSelector,omitempty"`
+
+	// Role The role of the nodes of the group
+	Role ProvisioningNodeGroupRole `json:"role"`
+
+	// Size The number of nodes in the group
+	Size int `json:"size"`
+}
+
+// ProvisioningNodeGroupRole The role of the nodes of the group
+type ProvisioningNodeGroupRole string
+
+// ProvisioningRequest A request for hardware to be provisioned, translated into a NodePool.
+type ProvisioningRequest struct {
+	// CloudId The identifier of the O-Cloud the hardware is provisioned for
+	CloudId string `json:"cloudId"`
+
+	// Extensions The NodePool extensions, carrying settings beyond the NodePool spec, such as a CPU architecture or site placement
+	// policy. The settings below apply to every node group, and most can be set for a single node group with a
+	// "<group>.<setting>" key, such as "controller.cpuArchitecture", which takes precedence. Other keys are preserved
+	// as is.
+	Extensions *NodePoolExtensions `json:"extensions,omitempty"`
+
+	// Location The location of the hardware
+	Location   *string                 `json:"location,omitempty"`
+	NodeGroups []ProvisioningNodeGroup `json:"nodeGroups"`
+
+	// Site The site of the hardware
+	Site string `json:"site"`
+}
+
+// ProvisioningRequestStatus The progress of a provisioning request.
+type ProvisioningRequestStatus struct {
+	// Message The message of the most recent NodePool condition, if any
+	Message *string `json:"message,omitempty"`
+
+	// NodeNames The names of the Nodes allocated to the request
+	NodeNames *[]string `json:"nodeNames,omitempty"`
+
+	// NodePool The name of the NodePool fulfilling the request
+	NodePool string `json:"nodePool"`
+
+	// Phase The provisioning phase of the NodePool
+	Phase ProvisioningRequestStatusPhase `json:"phase"`
+
+	// ProvisioningRequestId The identifier of the provisioning request, allocated by the hardware manager plugin
+	ProvisioningRequestId openapi_types.UUID `json:"provisioningRequestId"`
+
+	// StatusUrl The path to poll for the status of the request
+	StatusUrl string `json:"statusUrl"`
+}
+
+// ProvisioningRequestStatusPhase The provisioning phase of the NodePool
+type ProvisioningRequestStatusPhase string
+
+// ReplayResult The result of replaying dead-lettered notifications
+type ReplayResult struct {
+	// Replayed The number of notifications queued for delivery
+	Replayed int `json:"replayed"`
+}
+
+// ResourceChangeNotification Information about a resource change notification
+type ResourceChangeNotification struct {
+	// ConsumerSubscriptionId The value provided by the consumer in the subscription
+	ConsumerSubscriptionId *openapi_types.UUID `json:"consumerSubscriptionId,omitempty"`
+
+	// NotificationEventType One of the following values: 0 - create, 1 - modify, 2 - delete
+	NotificationEventType ResourceChangeNotificationNotificationEventType `json:"notificationEventType"`
+
+	// NotificationId A unique identifier to represent this notification event
+	NotificationId openapi_types.UUID `json:"notificationId"`
+
+	// Object The changed resource object.
+	Object *map[string]interface{} `json:"object,omitempty"`
+
+	// ObjectRef The URL to the object. This is not required if the notificationEventType is 2 (DELETE).
+	ObjectRef *string `json:"objectRef,omitempty"`
+}
+
+// ResourceChangeNotificationNotificationEventType One of the following values: 0 - create, 1 - modify, 2 - delete
+type ResourceChangeNotificationNotificationEventType int
+
 // ResourceInfo Information about a resource.
 type ResourceInfo struct {
 	// AdminState The administrative state of the resource
 	AdminState ResourceInfoAdminState `json:"adminState"`
 
+	// CpuArchitecture The CPU architecture of the resource, normalized to the names reported by hardware inspection, if known
+	CpuArchitecture *string `json:"cpuArchitecture,omitempty"`
+
 	// Description Human readable description of the resource.
 	Description string `json:"description"`
 
@@ -147,6 +511,9 @@ type ResourceInfo struct {
 	// SerialNumber The vendor serial number of the resource
 	SerialNumber string `json:"serialNumber"`
 
+	// Storage The storage devices of the resource, if reported
+	Storage *[]StorageInfo `json:"storage,omitempty"`
+
 	// Tags Keywords describing or classifying the resource instance
 	Tags       *[]string              `json:"tags,omitempty"`
 	UsageState ResourceInfoUsageState `json:"usageState"`
@@ -182,6 +549,108 @@ type ResourcePoolInfo struct {
 	SiteId *string `json:"siteId,omitempty"`
 }
 
+// ResourceTypeAttribute Schema of an attribute reported for the resources of a resource type.
+type ResourceTypeAttribute struct {
+	// Description Human readable description of the attribute
+	Description string `json:"description"`
+
+	// Name The name of the attribute, with label attributes named "labels.<key>"
+	Name string `json:"name"`
+
+	// Required Whether the attribute is reported for every resource of the type
+	Required bool `json:"required"`
+
+	// Type The type of the attribute value
+	Type ResourceTypeAttributeType `json:"type"`
+}
+
+// ResourceTypeAttributeType The type of the attribute value
+type ResourceTypeAttributeType string
+
+// ResourceTypeInfo Information about a resource type, following the O2IMS information model.
+type ResourceTypeInfo struct {
+	// Attributes The attributes reported for the resources of the type
+	Attributes []ResourceTypeAttribute `json:"attributes"`
+
+	// Model The vendor model name
+	Model string `json:"model"`
+
+	// Name Human readable name of the resource type.
+	Name string `json:"name"`
+
+	// ResourceClass The class of the resources of the type
+	ResourceClass ResourceTypeInfoResourceClass `json:"resourceClass"`
+
+	// ResourceCount The number of resources of the type in the inventory
+	ResourceCount int `json:"resourceCount"`
+
+	// ResourceKind The kind of the resources of the type
+	ResourceKind ResourceTypeInfoResourceKind `json:"resourceKind"`
+
+	// ResourcePoolIds The resource pools with resources of the type
+	ResourcePoolIds []string `json:"resourcePoolIds"`
+
+	// ResourceTypeId Identifier for the resource type, derived from its vendor, model and resource class.
+	ResourceTypeId openapi_types.UUID `json:"resourceTypeId"`
+
+	// Vendor Vendor or manufacturer name
+	Vendor string `json:"vendor"`
+}
+
+// ResourceTypeInfoResourceClass The class of the resources of the type
+type ResourceTypeInfoResourceClass string
+
+// ResourceTypeInfoResourceKind The kind of the resources of the type
+type ResourceTypeInfoResourceKind string
+
+// SiteInfo Information about a site, derived from the resource pools located at it.
+type SiteInfo struct {
+	// Cores The total number of physical cores of the resources of the site, as far as reported
+	Cores int `json:"cores"`
+
+	// IdleResourceCount The number of resources of the site that are not in use
+	IdleResourceCount int `json:"idleResourceCount"`
+
+	// Memory The total physical memory of the resources of the site in MiB
+	Memory int `json:"memory"`
+
+	// ResourceCount The number of resources in the resource pools of the site
+	ResourceCount int `json:"resourceCount"`
+
+	// ResourcePoolCount The number of resource pools at the site
+	ResourcePoolCount int `json:"resourcePoolCount"`
+
+	// ResourcePoolIds The resource pools at the site
+	ResourcePoolIds []string `json:"resourcePoolIds"`
+
+	// SiteId Identifier for the site.
+	SiteId string `json:"siteId"`
+}
+
+// StorageInfo Information about a storage device
+type StorageInfo struct {
+	// Model The model of the device
+	Model *string `json:"model,omitempty"`
+
+	// Name The name of the device, as reported by hardware inspection
+	Name string `json:"name"`
+
+	// SerialNumber The serial number of the device
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// SizeBytes The size of the device in bytes
+	SizeBytes int64 `json:"sizeBytes"`
+
+	// Type The type of the device, if known
+	Type *StorageInfoType `json:"type,omitempty"`
+
+	// Vendor The vendor of the device
+	Vendor *string `json:"vendor,omitempty"`
+}
+
+// StorageInfoType The type of the device, if known
+type StorageInfoType string
+
 // Subscription Information about an inventory subscription.
 type Subscription struct {
 	// Callback The fully qualified URI to a consumer procedure which can process a Post of the
@@ -199,93 +668,413 @@ type Subscription struct {
 	SubscriptionId *openapi_types.UUID `json:"subscriptionId,omitempty"`
 }
 
+// Filter defines model for filter.
+type Filter = string
+
 // HwMgrId defines model for hwMgrId.
 type HwMgrId = string
 
+// IdempotencyKey defines model for idempotencyKey.
+type IdempotencyKey = string
+
+// ProvisioningRequestId defines model for provisioningRequestId.
+type ProvisioningRequestId = openapi_types.UUID
+
 // SubscriptionId defines model for subscriptionId.
 type SubscriptionId = openapi_types.UUID
 
-// CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
-type CreateSubscriptionJSONRequestBody = Subscription
+// CreateProvisioningRequestParams defines parameters for CreateProvisioningRequest.
+type CreateProvisioningRequestParams struct {
+	// IdempotencyKey Unique key chosen by the client to identify a provisioning request, so that a request retried with the same
+	// key is fulfilled only once.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get API versions
-	// (GET /hardware-manager/inventory/api_versions)
-	GetAllVersions(w http.ResponseWriter, r *http.Request)
-	// Get minor API versions
-	// (GET /hardware-manager/inventory/v1/api_versions)
-	GetMinorVersions(w http.ResponseWriter, r *http.Request)
-	// Retrieve the list of resource pools
-	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools)
-	GetResourcePools(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
-	// Retrieve exactly one resource pool
-	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId})
-	GetResourcePool(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourcePoolId string)
-	// Retrieve the list of resources for a given resource pool
-	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId}/resources)
-	GetResourcePoolResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourcePoolId string)
-	// Retrieve the list of resources
-	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resources)
-	GetResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
-	// Retrieve exactly one resource
-	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resources/{resourceId})
-	GetResource(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourceId string)
-	// Retrieve the list of inventory subscriptions
-	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions)
-	GetSubscriptions(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
-	// Create subscription
-	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions)
-	CreateSubscription(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
-	// Delete subscription
-	// (DELETE /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId})
-	DeleteSubscription(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId)
-	// Get subscription
-	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId})
-	GetSubscription(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId)
+// GetResourcePoolsParams defines parameters for GetResourcePools.
+type GetResourcePoolsParams struct {
+	// Filter Criteria for selecting the items of a list, using the filter syntax of the O-RAN O2 IMS API. Each criterion
+	// is formatted as (operator,attribute,value[,value...]), and multiple criteria, separated by semicolons, must all
+	// match. The operators are eq, neq, gt, gte, lt, lte, cont, ncont, in and nin. Nested attributes are separated
+	// by slashes, such as labels/site, and values containing commas, parentheses or semicolons are enclosed in single
+	// quotes.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// GetResourcePoolResourcesParams defines parameters for GetResourcePoolResources.
+type GetResourcePoolResourcesParams struct {
+	// Filter Criteria for selecting the items of a list, using the filter syntax of the O-RAN O2 IMS API. Each criterion
+	// is formatted as (operator,attribute,value[,value...]), and multiple criteria, separated by semicolons, must all
+	// match. The operators are eq, neq, gt, gte, lt, lte, cont, ncont, in and nin. Nested attributes are separated
+	// by slashes, such as labels/site, and values containing commas, parentheses or semicolons are enclosed in single
+	// quotes.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// GetResourcesParams defines parameters for GetResources.
+type GetResourcesParams struct {
+	// Filter Criteria for selecting the items of a list, using the filter syntax of the O-RAN O2 IMS API. Each criterion
+	// is formatted as (operator,attribute,value[,value...]), and multiple criteria, separated by semicolons, must all
+	// match. The operators are eq, neq, gt, gte, lt, lte, cont, ncont, in and nin. Nested attributes are separated
+	// by slashes, such as labels/site, and values containing commas, parentheses or semicolons are enclosed in single
+	// quotes.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
 
-// GetAllVersions operation middleware
-func (siw *ServerInterfaceWrapper) GetAllVersions(w http.ResponseWriter, r *http.Request) {
+// CreateProvisioningRequestJSONRequestBody defines body for CreateProvisioningRequest for application/json ContentType.
+type CreateProvisioningRequestJSONRequestBody = ProvisioningRequest
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAllVersions(w, r)
-	}))
+// UpdateProvisioningRequestJSONRequestBody defines body for UpdateProvisioningRequest for application/json ContentType.
+type UpdateProvisioningRequestJSONRequestBody = ProvisioningRequest
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+// CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
+type CreateSubscriptionJSONRequestBody = Subscription
+
+// Getter for additional properties for NodePoolExtensions. Returns the specified
+// element and whether it was found
+func (a NodePoolExtensions) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
 	}
+	return
+}
 
-	handler.ServeHTTP(w, r)
+// Setter for additional properties for NodePoolExtensions
+func (a *NodePoolExtensions) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
 }
 
-// GetMinorVersions operation middleware
-func (siw *ServerInterfaceWrapper) GetMinorVersions(w http.ResponseWriter, r *http.Request) {
+// Override default JSON handling for NodePoolExtensions to handle AdditionalProperties
+func (a *NodePoolExtensions) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetMinorVersions(w, r)
-	}))
+	if raw, found := object["cpuArchitecture"]; found {
+		err = json.Unmarshal(raw, &a.CpuArchitecture)
+		if err != nil {
+			return fmt.Errorf("error reading 'cpuArchitecture': %w", err)
+		}
+		delete(object, "cpuArchitecture")
+	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	if raw, found := object["extensionsVersion"]; found {
+		err = json.Unmarshal(raw, &a.ExtensionsVersion)
+		if err != nil {
+			return fmt.Errorf("error reading 'extensionsVersion': %w", err)
+		}
+		delete(object, "extensionsVersion")
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	if raw, found := object["hostnameTemplate"]; found {
+		err = json.Unmarshal(raw, &a.HostnameTemplate)
+		if err != nil {
+			return fmt.Errorf("error reading 'hostnameTemplate': %w", err)
+		}
+		delete(object, "hostnameTemplate")
+	}
 
-// GetResourcePools operation middleware
-func (siw *ServerInterfaceWrapper) GetResourcePools(w http.ResponseWriter, r *http.Request) {
+	if raw, found := object["manifestTemplate"]; found {
+		err = json.Unmarshal(raw, &a.ManifestTemplate)
+		if err != nil {
+			return fmt.Errorf("error reading 'manifestTemplate': %w", err)
+		}
+		delete(object, "manifestTemplate")
+	}
 
-	var err error
+	if raw, found := object["networkDataTemplate"]; found {
+		err = json.Unmarshal(raw, &a.NetworkDataTemplate)
+		if err != nil {
+			return fmt.Errorf("error reading 'networkDataTemplate': %w", err)
+		}
+		delete(object, "networkDataTemplate")
+	}
+
+	if raw, found := object["rack"]; found {
+		err = json.Unmarshal(raw, &a.Rack)
+		if err != nil {
+			return fmt.Errorf("error reading 'rack': %w", err)
+		}
+		delete(object, "rack")
+	}
+
+	if raw, found := object["resourceTypeId"]; found {
+		err = json.Unmarshal(raw, &a.ResourceTypeId)
+		if err != nil {
+			return fmt.Errorf("error reading 'resourceTypeId': %w", err)
+		}
+		delete(object, "resourceTypeId")
+	}
+
+	if raw, found := object["site"]; found {
+		err = json.Unmarshal(raw, &a.Site)
+		if err != nil {
+			return fmt.Errorf("error reading 'site': %w", err)
+		}
+		delete(object, "site")
+	}
+
+	if raw, found := object["spreadBy"]; found {
+		err = json.Unmarshal(raw, &a.SpreadBy)
+		if err != nil {
+			return fmt.Errorf("error reading 'spreadBy': %w", err)
+		}
+		delete(object, "spreadBy")
+	}
+
+	if raw, found := object["spreadMode"]; found {
+		err = json.Unmarshal(raw, &a.SpreadMode)
+		if err != nil {
+			return fmt.Errorf("error reading 'spreadMode': %w", err)
+		}
+		delete(object, "spreadMode")
+	}
+
+	if raw, found := object["tags"]; found {
+		err = json.Unmarshal(raw, &a.Tags)
+		if err != nil {
+			return fmt.Errorf("error reading 'tags': %w", err)
+		}
+		delete(object, "tags")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for NodePoolExtensions to handle AdditionalProperties
+func (a NodePoolExtensions) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	if a.CpuArchitecture != nil {
+		object["cpuArchitecture"], err = json.Marshal(a.CpuArchitecture)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'cpuArchitecture': %w", err)
+		}
+	}
+
+	if a.ExtensionsVersion != nil {
+		object["extensionsVersion"], err = json.Marshal(a.ExtensionsVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'extensionsVersion': %w", err)
+		}
+	}
+
+	if a.HostnameTemplate != nil {
+		object["hostnameTemplate"], err = json.Marshal(a.HostnameTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'hostnameTemplate': %w", err)
+		}
+	}
+
+	if a.ManifestTemplate != nil {
+		object["manifestTemplate"], err = json.Marshal(a.ManifestTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'manifestTemplate': %w", err)
+		}
+	}
+
+	if a.NetworkDataTemplate != nil {
+		object["networkDataTemplate"], err = json.Marshal(a.NetworkDataTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'networkDataTemplate': %w", err)
+		}
+	}
+
+	if a.Rack != nil {
+		object["rack"], err = json.Marshal(a.Rack)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'rack': %w", err)
+		}
+	}
+
+	if a.ResourceTypeId != nil {
+		object["resourceTypeId"], err = json.Marshal(a.ResourceTypeId)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'resourceTypeId': %w", err)
+		}
+	}
+
+	if a.Site != nil {
+		object["site"], err = json.Marshal(a.Site)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'site': %w", err)
+		}
+	}
+
+	if a.SpreadBy != nil {
+		object["spreadBy"], err = json.Marshal(a.SpreadBy)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'spreadBy': %w", err)
+		}
+	}
+
+	if a.SpreadMode != nil {
+		object["spreadMode"], err = json.Marshal(a.SpreadMode)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'spreadMode': %w", err)
+		}
+	}
+
+	if a.Tags != nil {
+		object["tags"], err = json.Marshal(a.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'tags': %w", err)
+		}
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get API versions
+	// (GET /hardware-manager/inventory/api_versions)
+	GetAllVersions(w http.ResponseWriter, r *http.Request)
+	// Get plugin information
+	// (GET /hardware-manager/inventory/info)
+	GetPluginInfo(w http.ResponseWriter, r *http.Request)
+	// Get minor API versions
+	// (GET /hardware-manager/inventory/v1/api_versions)
+	GetMinorVersions(w http.ResponseWriter, r *http.Request)
+	// Compare the plugin Nodes with the hardware allocated in the backend
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/allocationReport)
+	GetAllocationReport(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
+	// Refresh the cached inventory of the hardware manager
+	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/inventory/refresh)
+	RefreshInventory(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
+	// Create a provisioning request
+	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests)
+	CreateProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, params CreateProvisioningRequestParams)
+	// Delete a provisioning request
+	// (DELETE /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId})
+	DeleteProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId)
+	// Get the status of a provisioning request
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId})
+	GetProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId)
+	// Update a provisioning request
+	// (PUT /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId})
+	UpdateProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId)
+	// Retrieve the list of resource pools
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools)
+	GetResourcePools(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, params GetResourcePoolsParams)
+	// Retrieve exactly one resource pool
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId})
+	GetResourcePool(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourcePoolId string)
+	// Retrieve the list of resources for a given resource pool
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId}/resources)
+	GetResourcePoolResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourcePoolId string, params GetResourcePoolResourcesParams)
+	// Retrieve the list of resource types
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourceTypes)
+	GetResourceTypes(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
+	// Retrieve exactly one resource type
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourceTypes/{resourceTypeId})
+	GetResourceType(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourceTypeId openapi_types.UUID)
+	// Retrieve the list of resources
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resources)
+	GetResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, params GetResourcesParams)
+	// Retrieve exactly one resource
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resources/{resourceId})
+	GetResource(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourceId string)
+	// Retrieve the list of sites
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/sites)
+	GetSites(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
+	// Retrieve the list of inventory subscriptions
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions)
+	GetSubscriptions(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
+	// Create subscription
+	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions)
+	CreateSubscription(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId)
+	// Delete subscription
+	// (DELETE /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId})
+	DeleteSubscription(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId)
+	// Get subscription
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId})
+	GetSubscription(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId)
+	// Get dead-lettered notifications
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}/dead-letters)
+	GetDeadLetterNotifications(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId)
+	// Replay dead-lettered notifications
+	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}/dead-letters/replay)
+	ReplayDeadLetterNotifications(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetAllVersions operation middleware
+func (siw *ServerInterfaceWrapper) GetAllVersions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAllVersions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPluginInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetPluginInfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPluginInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMinorVersions operation middleware
+func (siw *ServerInterfaceWrapper) GetMinorVersions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMinorVersions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAllocationReport operation middleware
+func (siw *ServerInterfaceWrapper) GetAllocationReport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
 
 	// ------------- Path parameter "hwMgrId" -------------
 	var hwMgrId HwMgrId
@@ -297,7 +1086,7 @@ func (siw *ServerInterfaceWrapper) GetResourcePools(w http.ResponseWriter, r *ht
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetResourcePools(w, r, hwMgrId)
+		siw.Handler.GetAllocationReport(w, r, hwMgrId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -307,8 +1096,8 @@ func (siw *ServerInterfaceWrapper) GetResourcePools(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetResourcePool operation middleware
-func (siw *ServerInterfaceWrapper) GetResourcePool(w http.ResponseWriter, r *http.Request) {
+// RefreshInventory operation middleware
+func (siw *ServerInterfaceWrapper) RefreshInventory(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -321,17 +1110,8 @@ func (siw *ServerInterfaceWrapper) GetResourcePool(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// ------------- Path parameter "resourcePoolId" -------------
-	var resourcePoolId string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "resourcePoolId", r.PathValue("resourcePoolId"), &resourcePoolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourcePoolId", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetResourcePool(w, r, hwMgrId, resourcePoolId)
+		siw.Handler.RefreshInventory(w, r, hwMgrId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -341,8 +1121,8 @@ func (siw *ServerInterfaceWrapper) GetResourcePool(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// GetResourcePoolResources operation middleware
-func (siw *ServerInterfaceWrapper) GetResourcePoolResources(w http.ResponseWriter, r *http.Request) {
+// CreateProvisioningRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreateProvisioningRequest(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -355,17 +1135,32 @@ func (siw *ServerInterfaceWrapper) GetResourcePoolResources(w http.ResponseWrite
 		return
 	}
 
-	// ------------- Path parameter "resourcePoolId" -------------
-	var resourcePoolId string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateProvisioningRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
 
-	err = runtime.BindStyledParameterWithOptions("simple", "resourcePoolId", r.PathValue("resourcePoolId"), &resourcePoolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourcePoolId", Err: err})
-		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetResourcePoolResources(w, r, hwMgrId, resourcePoolId)
+		siw.Handler.CreateProvisioningRequest(w, r, hwMgrId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -375,8 +1170,8 @@ func (siw *ServerInterfaceWrapper) GetResourcePoolResources(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// GetResources operation middleware
-func (siw *ServerInterfaceWrapper) GetResources(w http.ResponseWriter, r *http.Request) {
+// DeleteProvisioningRequest operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProvisioningRequest(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -389,8 +1184,17 @@ func (siw *ServerInterfaceWrapper) GetResources(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// ------------- Path parameter "provisioningRequestId" -------------
+	var provisioningRequestId ProvisioningRequestId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provisioningRequestId", r.PathValue("provisioningRequestId"), &provisioningRequestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provisioningRequestId", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetResources(w, r, hwMgrId)
+		siw.Handler.DeleteProvisioningRequest(w, r, hwMgrId, provisioningRequestId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -400,8 +1204,8 @@ func (siw *ServerInterfaceWrapper) GetResources(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetResource operation middleware
-func (siw *ServerInterfaceWrapper) GetResource(w http.ResponseWriter, r *http.Request) {
+// GetProvisioningRequest operation middleware
+func (siw *ServerInterfaceWrapper) GetProvisioningRequest(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -414,17 +1218,17 @@ func (siw *ServerInterfaceWrapper) GetResource(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// ------------- Path parameter "resourceId" -------------
-	var resourceId string
+	// ------------- Path parameter "provisioningRequestId" -------------
+	var provisioningRequestId ProvisioningRequestId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "resourceId", r.PathValue("resourceId"), &resourceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "provisioningRequestId", r.PathValue("provisioningRequestId"), &provisioningRequestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provisioningRequestId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetResource(w, r, hwMgrId, resourceId)
+		siw.Handler.GetProvisioningRequest(w, r, hwMgrId, provisioningRequestId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -434,8 +1238,8 @@ func (siw *ServerInterfaceWrapper) GetResource(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetSubscriptions operation middleware
-func (siw *ServerInterfaceWrapper) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+// UpdateProvisioningRequest operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProvisioningRequest(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -448,8 +1252,17 @@ func (siw *ServerInterfaceWrapper) GetSubscriptions(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// ------------- Path parameter "provisioningRequestId" -------------
+	var provisioningRequestId ProvisioningRequestId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provisioningRequestId", r.PathValue("provisioningRequestId"), &provisioningRequestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provisioningRequestId", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSubscriptions(w, r, hwMgrId)
+		siw.Handler.UpdateProvisioningRequest(w, r, hwMgrId, provisioningRequestId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -459,8 +1272,8 @@ func (siw *ServerInterfaceWrapper) GetSubscriptions(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// CreateSubscription operation middleware
-func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+// GetResourcePools operation middleware
+func (siw *ServerInterfaceWrapper) GetResourcePools(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -473,8 +1286,19 @@ func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetResourcePoolsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateSubscription(w, r, hwMgrId)
+		siw.Handler.GetResourcePools(w, r, hwMgrId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -484,8 +1308,8 @@ func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteSubscription operation middleware
-func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+// GetResourcePool operation middleware
+func (siw *ServerInterfaceWrapper) GetResourcePool(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -498,17 +1322,17 @@ func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *
 		return
 	}
 
-	// ------------- Path parameter "subscriptionId" -------------
-	var subscriptionId SubscriptionId
+	// ------------- Path parameter "resourcePoolId" -------------
+	var resourcePoolId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", r.PathValue("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "resourcePoolId", r.PathValue("resourcePoolId"), &resourcePoolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourcePoolId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteSubscription(w, r, hwMgrId, subscriptionId)
+		siw.Handler.GetResourcePool(w, r, hwMgrId, resourcePoolId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -518,8 +1342,8 @@ func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetSubscription operation middleware
-func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *http.Request) {
+// GetResourcePoolResources operation middleware
+func (siw *ServerInterfaceWrapper) GetResourcePoolResources(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -532,17 +1356,28 @@ func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// ------------- Path parameter "subscriptionId" -------------
-	var subscriptionId SubscriptionId
+	// ------------- Path parameter "resourcePoolId" -------------
+	var resourcePoolId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", r.PathValue("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "resourcePoolId", r.PathValue("resourcePoolId"), &resourcePoolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourcePoolId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetResourcePoolResourcesParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSubscription(w, r, hwMgrId, subscriptionId)
+		siw.Handler.GetResourcePoolResources(w, r, hwMgrId, resourcePoolId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -552,211 +1387,1011 @@ func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
-
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+// GetResourceTypes operation middleware
+func (siw *ServerInterfaceWrapper) GetResourceTypes(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	var err error
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
 
-func (e *UnmarshalingParamError) Error() string {
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetResourceTypes(w, r, hwMgrId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetResourceType operation middleware
+func (siw *ServerInterfaceWrapper) GetResourceType(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "resourceTypeId" -------------
+	var resourceTypeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resourceTypeId", r.PathValue("resourceTypeId"), &resourceTypeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceTypeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetResourceType(w, r, hwMgrId, resourceTypeId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetResources operation middleware
+func (siw *ServerInterfaceWrapper) GetResources(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetResourcesParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetResources(w, r, hwMgrId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetResource operation middleware
+func (siw *ServerInterfaceWrapper) GetResource(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "resourceId" -------------
+	var resourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resourceId", r.PathValue("resourceId"), &resourceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetResource(w, r, hwMgrId, resourceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSites operation middleware
+func (siw *ServerInterfaceWrapper) GetSites(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSites(w, r, hwMgrId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubscriptions(w, r, hwMgrId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSubscription(w, r, hwMgrId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSubscription operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId SubscriptionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", r.PathValue("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSubscription(w, r, hwMgrId, subscriptionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId SubscriptionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", r.PathValue("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubscription(w, r, hwMgrId, subscriptionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDeadLetterNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetDeadLetterNotifications(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId SubscriptionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", r.PathValue("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDeadLetterNotifications(w, r, hwMgrId, subscriptionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplayDeadLetterNotifications operation middleware
+func (siw *ServerInterfaceWrapper) ReplayDeadLetterNotifications(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "hwMgrId" -------------
+	var hwMgrId HwMgrId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hwMgrId", r.PathValue("hwMgrId"), &hwMgrId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hwMgrId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId SubscriptionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", r.PathValue("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayDeadLetterNotifications(w, r, hwMgrId, subscriptionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
 	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
 }
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/api_versions", wrapper.GetAllVersions)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/info", wrapper.GetPluginInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/api_versions", wrapper.GetMinorVersions)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/allocationReport", wrapper.GetAllocationReport)
+	m.HandleFunc("POST "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/inventory/refresh", wrapper.RefreshInventory)
+	m.HandleFunc("POST "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests", wrapper.CreateProvisioningRequest)
+	m.HandleFunc("DELETE "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId}", wrapper.DeleteProvisioningRequest)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId}", wrapper.GetProvisioningRequest)
+	m.HandleFunc("PUT "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId}", wrapper.UpdateProvisioningRequest)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools", wrapper.GetResourcePools)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId}", wrapper.GetResourcePool)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId}/resources", wrapper.GetResourcePoolResources)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourceTypes", wrapper.GetResourceTypes)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourceTypes/{resourceTypeId}", wrapper.GetResourceType)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resources", wrapper.GetResources)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resources/{resourceId}", wrapper.GetResource)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/sites", wrapper.GetSites)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions", wrapper.GetSubscriptions)
+	m.HandleFunc("POST "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions", wrapper.CreateSubscription)
+	m.HandleFunc("DELETE "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}", wrapper.DeleteSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}", wrapper.GetSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}/dead-letters", wrapper.GetDeadLetterNotifications)
+	m.HandleFunc("POST "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}/dead-letters/replay", wrapper.ReplayDeadLetterNotifications)
+
+	return m
+}
+
+type GetAllVersionsRequestObject struct {
+}
+
+type GetAllVersionsResponseObject interface {
+	VisitGetAllVersionsResponse(w http.ResponseWriter) error
+}
+
+type GetAllVersions200JSONResponse APIVersions
+
+func (response GetAllVersions200JSONResponse) VisitGetAllVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAllVersions400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAllVersions400ApplicationProblemPlusJSONResponse) VisitGetAllVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAllVersions500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAllVersions500ApplicationProblemPlusJSONResponse) VisitGetAllVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPluginInfoRequestObject struct {
+}
+
+type GetPluginInfoResponseObject interface {
+	VisitGetPluginInfoResponse(w http.ResponseWriter) error
+}
+
+type GetPluginInfo200JSONResponse PluginInfo
+
+func (response GetPluginInfo200JSONResponse) VisitGetPluginInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPluginInfo400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetPluginInfo400ApplicationProblemPlusJSONResponse) VisitGetPluginInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPluginInfo500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetPluginInfo500ApplicationProblemPlusJSONResponse) VisitGetPluginInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMinorVersionsRequestObject struct {
+}
+
+type GetMinorVersionsResponseObject interface {
+	VisitGetMinorVersionsResponse(w http.ResponseWriter) error
+}
+
+type GetMinorVersions200JSONResponse APIVersions
+
+func (response GetMinorVersions200JSONResponse) VisitGetMinorVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMinorVersions400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetMinorVersions400ApplicationProblemPlusJSONResponse) VisitGetMinorVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMinorVersions500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetMinorVersions500ApplicationProblemPlusJSONResponse) VisitGetMinorVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAllocationReportRequestObject struct {
+	HwMgrId HwMgrId `json:"hwMgrId"`
+}
+
+type GetAllocationReportResponseObject interface {
+	VisitGetAllocationReportResponse(w http.ResponseWriter) error
+}
+
+type GetAllocationReport200JSONResponse AllocationReport
+
+func (response GetAllocationReport200JSONResponse) VisitGetAllocationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAllocationReport400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAllocationReport400ApplicationProblemPlusJSONResponse) VisitGetAllocationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type RequiredParamError struct {
-	ParamName string
+type GetAllocationReport404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAllocationReport404ApplicationProblemPlusJSONResponse) VisitGetAllocationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+type GetAllocationReport500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAllocationReport500ApplicationProblemPlusJSONResponse) VisitGetAllocationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+type GetAllocationReport501ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAllocationReport501ApplicationProblemPlusJSONResponse) VisitGetAllocationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(501)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+type GetAllocationReport503ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAllocationReport503ApplicationProblemPlusJSONResponse) VisitGetAllocationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+type RefreshInventoryRequestObject struct {
+	HwMgrId HwMgrId `json:"hwMgrId"`
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+type RefreshInventoryResponseObject interface {
+	VisitRefreshInventoryResponse(w http.ResponseWriter) error
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+type RefreshInventory200JSONResponse InventoryRefreshResult
+
+func (response RefreshInventory200JSONResponse) VisitRefreshInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+type RefreshInventory400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RefreshInventory400ApplicationProblemPlusJSONResponse) VisitRefreshInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+type RefreshInventory404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RefreshInventory404ApplicationProblemPlusJSONResponse) VisitRefreshInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+type RefreshInventory500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RefreshInventory500ApplicationProblemPlusJSONResponse) VisitRefreshInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
+type RefreshInventory501ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RefreshInventory501ApplicationProblemPlusJSONResponse) VisitRefreshInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(501)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
+type RefreshInventory503ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RefreshInventory503ApplicationProblemPlusJSONResponse) VisitRefreshInventoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+type CreateProvisioningRequestRequestObject struct {
+	HwMgrId HwMgrId `json:"hwMgrId"`
+	Params  CreateProvisioningRequestParams
+	Body    *CreateProvisioningRequestJSONRequestBody
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
+type CreateProvisioningRequestResponseObject interface {
+	VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+type CreateProvisioningRequest202JSONResponse ProvisioningRequestStatus
+
+func (response CreateProvisioningRequest202JSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+type CreateProvisioningRequest400ApplicationProblemPlusJSONResponse ProblemDetails
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+func (response CreateProvisioningRequest400ApplicationProblemPlusJSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/api_versions", wrapper.GetAllVersions)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/api_versions", wrapper.GetMinorVersions)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools", wrapper.GetResourcePools)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId}", wrapper.GetResourcePool)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId}/resources", wrapper.GetResourcePoolResources)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resources", wrapper.GetResources)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/resources/{resourceId}", wrapper.GetResource)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions", wrapper.GetSubscriptions)
-	m.HandleFunc("POST "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions", wrapper.CreateSubscription)
-	m.HandleFunc("DELETE "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}", wrapper.DeleteSubscription)
-	m.HandleFunc("GET "+options.BaseURL+"/hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}", wrapper.GetSubscription)
+type CreateProvisioningRequest401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CreateProvisioningRequest401ApplicationProblemPlusJSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningRequest403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CreateProvisioningRequest403ApplicationProblemPlusJSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningRequest404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CreateProvisioningRequest404ApplicationProblemPlusJSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningRequest409ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CreateProvisioningRequest409ApplicationProblemPlusJSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningRequest500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CreateProvisioningRequest500ApplicationProblemPlusJSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningRequest501ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CreateProvisioningRequest501ApplicationProblemPlusJSONResponse) VisitCreateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(501)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningRequestRequestObject struct {
+	HwMgrId               HwMgrId               `json:"hwMgrId"`
+	ProvisioningRequestId ProvisioningRequestId `json:"provisioningRequestId"`
+}
+
+type DeleteProvisioningRequestResponseObject interface {
+	VisitDeleteProvisioningRequestResponse(w http.ResponseWriter) error
+}
+
+type DeleteProvisioningRequest202Response struct {
+}
+
+func (response DeleteProvisioningRequest202Response) VisitDeleteProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type DeleteProvisioningRequest401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response DeleteProvisioningRequest401ApplicationProblemPlusJSONResponse) VisitDeleteProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningRequest403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response DeleteProvisioningRequest403ApplicationProblemPlusJSONResponse) VisitDeleteProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningRequest404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response DeleteProvisioningRequest404ApplicationProblemPlusJSONResponse) VisitDeleteProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningRequest500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response DeleteProvisioningRequest500ApplicationProblemPlusJSONResponse) VisitDeleteProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningRequest501ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response DeleteProvisioningRequest501ApplicationProblemPlusJSONResponse) VisitDeleteProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(501)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProvisioningRequestRequestObject struct {
+	HwMgrId               HwMgrId               `json:"hwMgrId"`
+	ProvisioningRequestId ProvisioningRequestId `json:"provisioningRequestId"`
+}
+
+type GetProvisioningRequestResponseObject interface {
+	VisitGetProvisioningRequestResponse(w http.ResponseWriter) error
+}
+
+type GetProvisioningRequest200JSONResponse ProvisioningRequestStatus
+
+func (response GetProvisioningRequest200JSONResponse) VisitGetProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProvisioningRequest401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetProvisioningRequest401ApplicationProblemPlusJSONResponse) VisitGetProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProvisioningRequest403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetProvisioningRequest403ApplicationProblemPlusJSONResponse) VisitGetProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProvisioningRequest404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetProvisioningRequest404ApplicationProblemPlusJSONResponse) VisitGetProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProvisioningRequest500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetProvisioningRequest500ApplicationProblemPlusJSONResponse) VisitGetProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
 
-	return m
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAllVersionsRequestObject struct {
+type GetProvisioningRequest501ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetProvisioningRequest501ApplicationProblemPlusJSONResponse) VisitGetProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(501)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAllVersionsResponseObject interface {
-	VisitGetAllVersionsResponse(w http.ResponseWriter) error
+type UpdateProvisioningRequestRequestObject struct {
+	HwMgrId               HwMgrId               `json:"hwMgrId"`
+	ProvisioningRequestId ProvisioningRequestId `json:"provisioningRequestId"`
+	Body                  *UpdateProvisioningRequestJSONRequestBody
 }
 
-type GetAllVersions200JSONResponse APIVersions
+type UpdateProvisioningRequestResponseObject interface {
+	VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error
+}
 
-func (response GetAllVersions200JSONResponse) VisitGetAllVersionsResponse(w http.ResponseWriter) error {
+type UpdateProvisioningRequest202JSONResponse ProvisioningRequestStatus
+
+func (response UpdateProvisioningRequest202JSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAllVersions400ApplicationProblemPlusJSONResponse ProblemDetails
+type UpdateProvisioningRequest400ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetAllVersions400ApplicationProblemPlusJSONResponse) VisitGetAllVersionsResponse(w http.ResponseWriter) error {
+func (response UpdateProvisioningRequest400ApplicationProblemPlusJSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAllVersions500ApplicationProblemPlusJSONResponse ProblemDetails
+type UpdateProvisioningRequest401ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetAllVersions500ApplicationProblemPlusJSONResponse) VisitGetAllVersionsResponse(w http.ResponseWriter) error {
+func (response UpdateProvisioningRequest401ApplicationProblemPlusJSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(500)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMinorVersionsRequestObject struct {
-}
+type UpdateProvisioningRequest403ApplicationProblemPlusJSONResponse ProblemDetails
 
-type GetMinorVersionsResponseObject interface {
-	VisitGetMinorVersionsResponse(w http.ResponseWriter) error
+func (response UpdateProvisioningRequest403ApplicationProblemPlusJSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMinorVersions200JSONResponse APIVersions
+type UpdateProvisioningRequest404ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetMinorVersions200JSONResponse) VisitGetMinorVersionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (response UpdateProvisioningRequest404ApplicationProblemPlusJSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMinorVersions400ApplicationProblemPlusJSONResponse ProblemDetails
+type UpdateProvisioningRequest409ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetMinorVersions400ApplicationProblemPlusJSONResponse) VisitGetMinorVersionsResponse(w http.ResponseWriter) error {
+func (response UpdateProvisioningRequest409ApplicationProblemPlusJSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMinorVersions500ApplicationProblemPlusJSONResponse ProblemDetails
+type UpdateProvisioningRequest500ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetMinorVersions500ApplicationProblemPlusJSONResponse) VisitGetMinorVersionsResponse(w http.ResponseWriter) error {
+func (response UpdateProvisioningRequest500ApplicationProblemPlusJSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
+type UpdateProvisioningRequest501ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateProvisioningRequest501ApplicationProblemPlusJSONResponse) VisitUpdateProvisioningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(501)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetResourcePoolsRequestObject struct {
 	HwMgrId HwMgrId `json:"hwMgrId"`
+	Params  GetResourcePoolsParams
 }
 
 type GetResourcePoolsResponseObject interface {
@@ -865,6 +2500,7 @@ func (response GetResourcePool500ApplicationProblemPlusJSONResponse) VisitGetRes
 type GetResourcePoolResourcesRequestObject struct {
 	HwMgrId        HwMgrId `json:"hwMgrId"`
 	ResourcePoolId string  `json:"resourcePoolId"`
+	Params         GetResourcePoolResourcesParams
 }
 
 type GetResourcePoolResourcesResponseObject interface {
@@ -898,8 +2534,116 @@ func (response GetResourcePoolResources500ApplicationProblemPlusJSONResponse) Vi
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetResourceTypesRequestObject struct {
+	HwMgrId HwMgrId `json:"hwMgrId"`
+}
+
+type GetResourceTypesResponseObject interface {
+	VisitGetResourceTypesResponse(w http.ResponseWriter) error
+}
+
+type GetResourceTypes200JSONResponse []ResourceTypeInfo
+
+func (response GetResourceTypes200JSONResponse) VisitGetResourceTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceTypes400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceTypes400ApplicationProblemPlusJSONResponse) VisitGetResourceTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceTypes404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceTypes404ApplicationProblemPlusJSONResponse) VisitGetResourceTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceTypes500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceTypes500ApplicationProblemPlusJSONResponse) VisitGetResourceTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceTypes503ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceTypes503ApplicationProblemPlusJSONResponse) VisitGetResourceTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceTypeRequestObject struct {
+	HwMgrId        HwMgrId            `json:"hwMgrId"`
+	ResourceTypeId openapi_types.UUID `json:"resourceTypeId"`
+}
+
+type GetResourceTypeResponseObject interface {
+	VisitGetResourceTypeResponse(w http.ResponseWriter) error
+}
+
+type GetResourceType200JSONResponse ResourceTypeInfo
+
+func (response GetResourceType200JSONResponse) VisitGetResourceTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceType400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceType400ApplicationProblemPlusJSONResponse) VisitGetResourceTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceType404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceType404ApplicationProblemPlusJSONResponse) VisitGetResourceTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceType500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceType500ApplicationProblemPlusJSONResponse) VisitGetResourceTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceType503ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetResourceType503ApplicationProblemPlusJSONResponse) VisitGetResourceTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetResourcesRequestObject struct {
 	HwMgrId HwMgrId `json:"hwMgrId"`
+	Params  GetResourcesParams
 }
 
 type GetResourcesResponseObject interface {
@@ -996,6 +2740,59 @@ func (response GetResource500ApplicationProblemPlusJSONResponse) VisitGetResourc
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetSitesRequestObject struct {
+	HwMgrId HwMgrId `json:"hwMgrId"`
+}
+
+type GetSitesResponseObject interface {
+	VisitGetSitesResponse(w http.ResponseWriter) error
+}
+
+type GetSites200JSONResponse []SiteInfo
+
+func (response GetSites200JSONResponse) VisitGetSitesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSites400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSites400ApplicationProblemPlusJSONResponse) VisitGetSitesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSites404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSites404ApplicationProblemPlusJSONResponse) VisitGetSitesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSites500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSites500ApplicationProblemPlusJSONResponse) VisitGetSitesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSites503ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSites503ApplicationProblemPlusJSONResponse) VisitGetSitesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetSubscriptionsRequestObject struct {
 	HwMgrId HwMgrId `json:"hwMgrId"`
 }
@@ -1171,48 +2968,156 @@ func (response GetSubscription200JSONResponse) VisitGetSubscriptionResponse(w ht
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSubscription400ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSubscription401ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSubscription403ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSubscription404ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetSubscription500ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeadLetterNotificationsRequestObject struct {
+	HwMgrId        HwMgrId        `json:"hwMgrId"`
+	SubscriptionId SubscriptionId `json:"subscriptionId"`
+}
+
+type GetDeadLetterNotificationsResponseObject interface {
+	VisitGetDeadLetterNotificationsResponse(w http.ResponseWriter) error
+}
+
+type GetDeadLetterNotifications200JSONResponse []DeadLetterNotification
+
+func (response GetDeadLetterNotifications200JSONResponse) VisitGetDeadLetterNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeadLetterNotifications401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetDeadLetterNotifications401ApplicationProblemPlusJSONResponse) VisitGetDeadLetterNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeadLetterNotifications403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetDeadLetterNotifications403ApplicationProblemPlusJSONResponse) VisitGetDeadLetterNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeadLetterNotifications404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetDeadLetterNotifications404ApplicationProblemPlusJSONResponse) VisitGetDeadLetterNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeadLetterNotifications500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetDeadLetterNotifications500ApplicationProblemPlusJSONResponse) VisitGetDeadLetterNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayDeadLetterNotificationsRequestObject struct {
+	HwMgrId        HwMgrId        `json:"hwMgrId"`
+	SubscriptionId SubscriptionId `json:"subscriptionId"`
+}
+
+type ReplayDeadLetterNotificationsResponseObject interface {
+	VisitReplayDeadLetterNotificationsResponse(w http.ResponseWriter) error
 }
 
-type GetSubscription400ApplicationProblemPlusJSONResponse ProblemDetails
+type ReplayDeadLetterNotifications200JSONResponse ReplayResult
 
-func (response GetSubscription400ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+func (response ReplayDeadLetterNotifications200JSONResponse) VisitReplayDeadLetterNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSubscription401ApplicationProblemPlusJSONResponse ProblemDetails
+type ReplayDeadLetterNotifications401ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetSubscription401ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+func (response ReplayDeadLetterNotifications401ApplicationProblemPlusJSONResponse) VisitReplayDeadLetterNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSubscription403ApplicationProblemPlusJSONResponse ProblemDetails
+type ReplayDeadLetterNotifications403ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetSubscription403ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+func (response ReplayDeadLetterNotifications403ApplicationProblemPlusJSONResponse) VisitReplayDeadLetterNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSubscription404ApplicationProblemPlusJSONResponse ProblemDetails
+type ReplayDeadLetterNotifications404ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetSubscription404ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+func (response ReplayDeadLetterNotifications404ApplicationProblemPlusJSONResponse) VisitReplayDeadLetterNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSubscription500ApplicationProblemPlusJSONResponse ProblemDetails
+type ReplayDeadLetterNotifications500ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetSubscription500ApplicationProblemPlusJSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+func (response ReplayDeadLetterNotifications500ApplicationProblemPlusJSONResponse) VisitReplayDeadLetterNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(500)
 
@@ -1224,9 +3129,30 @@ type StrictServerInterface interface {
 	// Get API versions
 	// (GET /hardware-manager/inventory/api_versions)
 	GetAllVersions(ctx context.Context, request GetAllVersionsRequestObject) (GetAllVersionsResponseObject, error)
+	// Get plugin information
+	// (GET /hardware-manager/inventory/info)
+	GetPluginInfo(ctx context.Context, request GetPluginInfoRequestObject) (GetPluginInfoResponseObject, error)
 	// Get minor API versions
 	// (GET /hardware-manager/inventory/v1/api_versions)
 	GetMinorVersions(ctx context.Context, request GetMinorVersionsRequestObject) (GetMinorVersionsResponseObject, error)
+	// Compare the plugin Nodes with the hardware allocated in the backend
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/allocationReport)
+	GetAllocationReport(ctx context.Context, request GetAllocationReportRequestObject) (GetAllocationReportResponseObject, error)
+	// Refresh the cached inventory of the hardware manager
+	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/inventory/refresh)
+	RefreshInventory(ctx context.Context, request RefreshInventoryRequestObject) (RefreshInventoryResponseObject, error)
+	// Create a provisioning request
+	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests)
+	CreateProvisioningRequest(ctx context.Context, request CreateProvisioningRequestRequestObject) (CreateProvisioningRequestResponseObject, error)
+	// Delete a provisioning request
+	// (DELETE /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId})
+	DeleteProvisioningRequest(ctx context.Context, request DeleteProvisioningRequestRequestObject) (DeleteProvisioningRequestResponseObject, error)
+	// Get the status of a provisioning request
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId})
+	GetProvisioningRequest(ctx context.Context, request GetProvisioningRequestRequestObject) (GetProvisioningRequestResponseObject, error)
+	// Update a provisioning request
+	// (PUT /hardware-manager/inventory/v1/manager/{hwMgrId}/provisioningRequests/{provisioningRequestId})
+	UpdateProvisioningRequest(ctx context.Context, request UpdateProvisioningRequestRequestObject) (UpdateProvisioningRequestResponseObject, error)
 	// Retrieve the list of resource pools
 	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools)
 	GetResourcePools(ctx context.Context, request GetResourcePoolsRequestObject) (GetResourcePoolsResponseObject, error)
@@ -1236,12 +3162,21 @@ type StrictServerInterface interface {
 	// Retrieve the list of resources for a given resource pool
 	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourcePools/{resourcePoolId}/resources)
 	GetResourcePoolResources(ctx context.Context, request GetResourcePoolResourcesRequestObject) (GetResourcePoolResourcesResponseObject, error)
+	// Retrieve the list of resource types
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourceTypes)
+	GetResourceTypes(ctx context.Context, request GetResourceTypesRequestObject) (GetResourceTypesResponseObject, error)
+	// Retrieve exactly one resource type
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resourceTypes/{resourceTypeId})
+	GetResourceType(ctx context.Context, request GetResourceTypeRequestObject) (GetResourceTypeResponseObject, error)
 	// Retrieve the list of resources
 	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resources)
 	GetResources(ctx context.Context, request GetResourcesRequestObject) (GetResourcesResponseObject, error)
 	// Retrieve exactly one resource
 	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/resources/{resourceId})
 	GetResource(ctx context.Context, request GetResourceRequestObject) (GetResourceResponseObject, error)
+	// Retrieve the list of sites
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/sites)
+	GetSites(ctx context.Context, request GetSitesRequestObject) (GetSitesResponseObject, error)
 	// Retrieve the list of inventory subscriptions
 	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions)
 	GetSubscriptions(ctx context.Context, request GetSubscriptionsRequestObject) (GetSubscriptionsResponseObject, error)
@@ -1254,6 +3189,12 @@ type StrictServerInterface interface {
 	// Get subscription
 	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId})
 	GetSubscription(ctx context.Context, request GetSubscriptionRequestObject) (GetSubscriptionResponseObject, error)
+	// Get dead-lettered notifications
+	// (GET /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}/dead-letters)
+	GetDeadLetterNotifications(ctx context.Context, request GetDeadLetterNotificationsRequestObject) (GetDeadLetterNotificationsResponseObject, error)
+	// Replay dead-lettered notifications
+	// (POST /hardware-manager/inventory/v1/manager/{hwMgrId}/subscriptions/{subscriptionId}/dead-letters/replay)
+	ReplayDeadLetterNotifications(ctx context.Context, request ReplayDeadLetterNotificationsRequestObject) (ReplayDeadLetterNotificationsResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -1309,6 +3250,30 @@ func (sh *strictHandler) GetAllVersions(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// GetPluginInfo operation middleware
+func (sh *strictHandler) GetPluginInfo(w http.ResponseWriter, r *http.Request) {
+	var request GetPluginInfoRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPluginInfo(ctx, request.(GetPluginInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPluginInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPluginInfoResponseObject); ok {
+		if err := validResponse.VisitGetPluginInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetMinorVersions operation middleware
 func (sh *strictHandler) GetMinorVersions(w http.ResponseWriter, r *http.Request) {
 	var request GetMinorVersionsRequestObject
@@ -1333,11 +3298,186 @@ func (sh *strictHandler) GetMinorVersions(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// GetAllocationReport operation middleware
+func (sh *strictHandler) GetAllocationReport(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId) {
+	var request GetAllocationReportRequestObject
+
+	request.HwMgrId = hwMgrId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAllocationReport(ctx, request.(GetAllocationReportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAllocationReport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAllocationReportResponseObject); ok {
+		if err := validResponse.VisitGetAllocationReportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RefreshInventory operation middleware
+func (sh *strictHandler) RefreshInventory(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId) {
+	var request RefreshInventoryRequestObject
+
+	request.HwMgrId = hwMgrId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RefreshInventory(ctx, request.(RefreshInventoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefreshInventory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RefreshInventoryResponseObject); ok {
+		if err := validResponse.VisitRefreshInventoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateProvisioningRequest operation middleware
+func (sh *strictHandler) CreateProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, params CreateProvisioningRequestParams) {
+	var request CreateProvisioningRequestRequestObject
+
+	request.HwMgrId = hwMgrId
+	request.Params = params
+
+	var body CreateProvisioningRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateProvisioningRequest(ctx, request.(CreateProvisioningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateProvisioningRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateProvisioningRequestResponseObject); ok {
+		if err := validResponse.VisitCreateProvisioningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteProvisioningRequest operation middleware
+func (sh *strictHandler) DeleteProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId) {
+	var request DeleteProvisioningRequestRequestObject
+
+	request.HwMgrId = hwMgrId
+	request.ProvisioningRequestId = provisioningRequestId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProvisioningRequest(ctx, request.(DeleteProvisioningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProvisioningRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteProvisioningRequestResponseObject); ok {
+		if err := validResponse.VisitDeleteProvisioningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProvisioningRequest operation middleware
+func (sh *strictHandler) GetProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId) {
+	var request GetProvisioningRequestRequestObject
+
+	request.HwMgrId = hwMgrId
+	request.ProvisioningRequestId = provisioningRequestId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProvisioningRequest(ctx, request.(GetProvisioningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProvisioningRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProvisioningRequestResponseObject); ok {
+		if err := validResponse.VisitGetProvisioningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateProvisioningRequest operation middleware
+func (sh *strictHandler) UpdateProvisioningRequest(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId) {
+	var request UpdateProvisioningRequestRequestObject
+
+	request.HwMgrId = hwMgrId
+	request.ProvisioningRequestId = provisioningRequestId
+
+	var body UpdateProvisioningRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateProvisioningRequest(ctx, request.(UpdateProvisioningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateProvisioningRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateProvisioningRequestResponseObject); ok {
+		if err := validResponse.VisitUpdateProvisioningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetResourcePools operation middleware
-func (sh *strictHandler) GetResourcePools(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId) {
+func (sh *strictHandler) GetResourcePools(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, params GetResourcePoolsParams) {
 	var request GetResourcePoolsRequestObject
 
 	request.HwMgrId = hwMgrId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetResourcePools(ctx, request.(GetResourcePoolsRequestObject))
@@ -1387,11 +3527,12 @@ func (sh *strictHandler) GetResourcePool(w http.ResponseWriter, r *http.Request,
 }
 
 // GetResourcePoolResources operation middleware
-func (sh *strictHandler) GetResourcePoolResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourcePoolId string) {
+func (sh *strictHandler) GetResourcePoolResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourcePoolId string, params GetResourcePoolResourcesParams) {
 	var request GetResourcePoolResourcesRequestObject
 
 	request.HwMgrId = hwMgrId
 	request.ResourcePoolId = resourcePoolId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetResourcePoolResources(ctx, request.(GetResourcePoolResourcesRequestObject))
@@ -1413,11 +3554,65 @@ func (sh *strictHandler) GetResourcePoolResources(w http.ResponseWriter, r *http
 	}
 }
 
+// GetResourceTypes operation middleware
+func (sh *strictHandler) GetResourceTypes(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId) {
+	var request GetResourceTypesRequestObject
+
+	request.HwMgrId = hwMgrId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetResourceTypes(ctx, request.(GetResourceTypesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetResourceTypes")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetResourceTypesResponseObject); ok {
+		if err := validResponse.VisitGetResourceTypesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetResourceType operation middleware
+func (sh *strictHandler) GetResourceType(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, resourceTypeId openapi_types.UUID) {
+	var request GetResourceTypeRequestObject
+
+	request.HwMgrId = hwMgrId
+	request.ResourceTypeId = resourceTypeId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetResourceType(ctx, request.(GetResourceTypeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetResourceType")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetResourceTypeResponseObject); ok {
+		if err := validResponse.VisitGetResourceTypeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetResources operation middleware
-func (sh *strictHandler) GetResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId) {
+func (sh *strictHandler) GetResources(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, params GetResourcesParams) {
 	var request GetResourcesRequestObject
 
 	request.HwMgrId = hwMgrId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetResources(ctx, request.(GetResourcesRequestObject))
@@ -1466,6 +3661,32 @@ func (sh *strictHandler) GetResource(w http.ResponseWriter, r *http.Request, hwM
 	}
 }
 
+// GetSites operation middleware
+func (sh *strictHandler) GetSites(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId) {
+	var request GetSitesRequestObject
+
+	request.HwMgrId = hwMgrId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSites(ctx, request.(GetSitesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSites")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSitesResponseObject); ok {
+		if err := validResponse.VisitGetSitesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSubscriptions operation middleware
 func (sh *strictHandler) GetSubscriptions(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId) {
 	var request GetSubscriptionsRequestObject
@@ -1579,56 +3800,193 @@ func (sh *strictHandler) GetSubscription(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// GetDeadLetterNotifications operation middleware
+func (sh *strictHandler) GetDeadLetterNotifications(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId) {
+	var request GetDeadLetterNotificationsRequestObject
+
+	request.HwMgrId = hwMgrId
+	request.SubscriptionId = subscriptionId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDeadLetterNotifications(ctx, request.(GetDeadLetterNotificationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDeadLetterNotifications")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDeadLetterNotificationsResponseObject); ok {
+		if err := validResponse.VisitGetDeadLetterNotificationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplayDeadLetterNotifications operation middleware
+func (sh *strictHandler) ReplayDeadLetterNotifications(w http.ResponseWriter, r *http.Request, hwMgrId HwMgrId, subscriptionId SubscriptionId) {
+	var request ReplayDeadLetterNotificationsRequestObject
+
+	request.HwMgrId = hwMgrId
+	request.SubscriptionId = subscriptionId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplayDeadLetterNotifications(ctx, request.(ReplayDeadLetterNotificationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplayDeadLetterNotifications")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplayDeadLetterNotificationsResponseObject); ok {
+		if err := validResponse.VisitReplayDeadLetterNotificationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xc63LbNhZ+FQx3Z7adpSQ7cj1e/3PsXDRNHI0vbXciTwciDkW0IMAAoGzVo3ffAUBS",
-	"4MWSnEujZP0rMgUC5/Z95+AAyn0QiTQTHLhWwfF9kGGJU9Ag7V/J7duZHBHzkYCKJM00FTw4Dq45/ZAD",
-	"ogS4pjEFiUSMMEqwJLdYAkoxxzOQ/QkPwgDucJoxCI4DJVLozYETIXtMRNjOFgbUTJlhnQRhwHFqRpYr",
-	"h4GEDzmVQIJjLXMIAxUlkGIjkl5kdlItKZ8Fy2UYqHxaSfkIsf3XmiJjfDQke1Pcwz8B9A7i/bg3haOD",
-	"XjwcHkyf7e8fHkZxtwoNYdZpEguZYh0cB3lOzcimZstysPXKyXj0C0hlVWpqOOJuLio4wlORa4TR3A02",
-	"uuoE0Ml45JTMpMhAagp21vlqypX2+/29/l6HQNUTMf0DIh0sQ08qtZ1YjCptZCoWVhvkwxn1569kfO+J",
-	"Xsi7vAkDqiG1A/8pIQ6Og38MVoE+KIw58Cy5UglLiRfm71zSsYSY3tVtMiijvFdE+YDyOXAt5GIw39/O",
-	"WGMppgzSM9CYMge8urKEUGMszE60lnSa6+bzcW18Y8mwYf4TvkA8T6dFwFeTIFzNHiKsEIGYciCIcoOK",
-	"DCIaU4dSJCSaLhDmiBozpMC1fd4POrQjVq12FJygJE8x70nABE8ZILjLGOZugXI5pAXSCVVIRFEuJfAI",
-	"ysjInNX6NYCeCs4hslNogQjWeIoVIE1TIEjkuu0Qg1alMY+gS8TrixGSEINbWSdYr/hCWTEqSR+WcMJH",
-	"GqV4gRYUGEFxLnUCElEPBjRGBKqFiAv5FRFI2iW40ljnHfi6SgC9vroaIzcARYIAioXcwpLVkpR7tqJc",
-	"wwykhQXVrNNSKhFSh02fqjxNsVw0VkJm3j4aafNWzgjiQqMowXwGKJYi9WXU4mGJwwmHuwgybbXLcpkJ",
-	"BZY6TD5h9C8XlWgU2xURVWhG58AR5gQJ6wSdYI4mgaWh4ynD/M9JEDpDVXBAKsGMIcyUQFO7+JyS0kkt",
-	"r7gHm0IJR5GQhPKZUXD04uolunh5iob/OTpE74c3nZHWMh5VCHgkcolnQNwrZpxZqJBRTXjDIUREeYXX",
-	"IihWU/8A/Vkf5Yry2eurt29+RLcJ8Hpkol/NI2ugFCyJUGX9l0lQwHU44VQrNMcstwbHSuUGfNrarmHp",
-	"Zn5NtM7U8WBQRqRnw34k0o2YWPp59X0JkIqDbrrJNwKlhDRZabtclZWvtNOSjBKqIdK5hG5cVu+i2ljf",
-	"CHdHh73Dg67QioSEB/CuhcbMo/UsWSgaYYbcO978w2dduE4xz2NshZHdK/gjPBxWllgpMOIaWJf8qSDA",
-	"Ns/+L+WZyb6DbBXVWuOHix/RbyC4+feVYAQdHgyH59sl3QtQIpcRbO92WbzRb7udpJRfaqwfcLr9niot",
-	"saZzsLRcUVk5q9GO56kJ2+vzN+9Of35xFoTB5evrq6vR+avfz979ahSrvrg+//ncPLoJN6T7pjyvDR+g",
-	"FR+svmxKVM+slyKtj3ZmsUTg6dASZsbEFLMTpUB3FeEjr/qWSIGktTD25QlNlsRzTJmRvC7dnTw63NN3",
-	"EY/J7NmzTjmkyLMO8PwMi1shiSl3uNCGkN1Iz+FoCkzwmUJamFWrYvIB6l/VjMntWIqYuoS5ElYmvcw9",
-	"72lQujfFikZdMjM8BfYppd67rCjt3EwIZxmjjoybjluJdz9xC/fwJDhGk8BSufkjnJhU6b6b+t9NJ8HS",
-	"T4YrlKWQCrlYR1kVUbmhptp8S5931h5r6MNtIz2y6IJXpeFY3IJ8QWaAfrswcdNle7dva651aaoct0CZ",
-	"O7vhsjkgjRuxc88a6vBGbeSNF+cnz99YdjgbXZYf1xFFhqU+t1hba1Uz7AFMdimWGeuuUcl+v1GZd4bu",
-	"3r182S14mR4sCLba3dXzfAdYSxk2sFTp9ouPdHu5zFgI5paqE4MQrLfmdceQWzhtLZV21q14tp4ezeOp",
-	"IUghUcSwUjRe2ALWmxhVm6nH8GSu8AyqiCkjYHT25kUQBienV6NfzIfn15f/3RDQTve2Fr84mxiG8KuY",
-	"VlVxBoyhEY/6G0tLL1paPvWJv87IBa1Ugpac1vBrDZkVidbCPvSLjg4yqRn1Zk39Y2V+dA2ETJy2C6HP",
-	"VHlUs396+dFN4w1RuhJGhwxbwLON7q2JBJl3TPozD5u90wpXj5ZIUb0tpZVN2G1MQfLh1hipYFEEvy9I",
-	"V2heer3SrcKSo6rp1tG+rYdohBmb4ujPbvKMc8YW6EOOmTENsXtpLRBGkeBmHyvdzoTkEtBtQqMERZiX",
-	"uxWE0Vi4NqYx34SXrj21rY1zoasO2gO9g3KVyw2t6w7nVQKKGIExhkJmO45IDq7cA+TPioyjQOla06e7",
-	"4RwGMWW6K92cSqoNb1khikWdVYiwPQEO1c5fQiakBmJI+JYyZp65eYGg6cL10nwBJ5x7BjP5bE4j6KOr",
-	"BCTEQhb7gWKSVRfCNWfMfBxhxkq5DJhKGR6wvnq81X2TGtGo8s8TqDISGFStdHxdIvttcSrS4QBDTO84",
-	"W5RnA+thVkV0G0tL29505B4JrnGkzcfiTOICCHqNTfWbS+Z1X25vb/sSSIK1bbq0G8jjkTWAdQmftVTy",
-	"0FhSgElYReswaA0fVcNPxiObHBsdfJvfOM5ocBwM+3v9oc2QOrGAXteBxxn9fe6dE8xAt916ATqXXBUo",
-	"MgSnoTqPMLpWZxJVt9sL2SIsbURVWdhET/AK9Alj1TGFTQ6Z4Mrx0LO9vdIrwLU708hYEe2DP5SjvtWp",
-	"0HYnF8r5vLFpySNDT47bxFRj29bvVLdU1eizDIODtUIWXbp/P07YxmlHh7zPMSnpyQjx01cRYsQ1SLvr",
-	"AjkHiUBKIfvFwaJtajsX1yIkKMvo90EKGhOscXBjXll/TPT4OC39lVIu5MNBWjX9U/yHkA+e/bXi9q2Z",
-	"dnci9ykYtw3Gdjx8bEiWD++Lw/flwC/n/ChtRc9FbWBYu0bwvtsUqyGD8rB/efOJcbdVW6C1DWptT9fx",
-	"KSoF3Jn4PNgbfgUhXgo5pYQA7zsZDr6CDFerw1gg7Q3ULXYFYixyTvq7B2Ujz3A3zZZzr+te55wL0JLC",
-	"HGpJqbZv9AmoIpjPwUCD+/r+crktJX08I4Xrm3Ud135aW+DtLzDdfMG022a9b43lvj7D1KJ85+mlG7Vw",
-	"hyNtNgW80e3520A7WO0Qt4Tvhbel/H/A8aPKmO+hhNkh4Dwm2ym728LFDaMvjaat4PKtFN/fR+H9VPQ+",
-	"FlzfYc37JcpdL2tuWeZ+ptTYOs1ekxl3sLp9qmy3FeK85IhvJP921a0e8PyDHPWR4KvPsQZzl7WBu51w",
-	"ayer33zC3f8KQlxznOtESPoXkB3ot32D9XL3Ub1aA98wyITSXcfPgDXUbmq2T//reHWv1GDwaYi14fhc",
-	"kMVny151jNYPe01WXbaIYv8Lrr3mJDGytiStk/tdOjt8IondI4lmPe0wWQuhL5nLB/f1ex5LRywMuu6r",
-	"ntnnqvNnoXVmcSM/D7OEG4c2rqo8VD2sQa/TeA16n4DDd2VfD1xTvfi2eswOD9uiOtx85cH9hks99CPt",
-	"tXX5DkDx78/PtZs+nvWe8vUT7Xy3tPMK9NaVhPs5xbykhMZPp3qnTOSkfbnxZDxCl/a12sXJ48HA/ug4",
-	"EUofH+0duf94oFj7vuMGZXkbx/8d+KqtVt3VMQzUtEO5gfL7/MV7q57j8mb5vwAAAP//Tte2o9BDAAA=",
+	"H4sIAAAAAAAC/+w9+2/cNpP/CqE74Fqcdv3My4fvB8d2EqOJ7bOd9vvQDQquNOvlFy2pkJSdbeD//cCX",
+	"REmUVnacxukt0Ma2HuRwODOct75ECVvkjAKVItr7EuWY4wVI4PqvGckkcPVbCiLhJJeE0WgvOuBEAicY",
+	"zRhHAjJIJKFXSM4BEQkLgdgMYZQRIWNUCHfLjIbEkkr8WT2iLp6OzvdP0Ok2On53gfbPjsfoCCdzlJgZ",
+	"GJ1QItQ0CywlpAgL9BPLgWPJeIyl5GRaSIivcVbA7+bHeDz+8HOMME3RosgkyTNww+EYCVBLVENNl0jA",
+	"giQsY1TEaFEIiXCWTegCy2Q+RpdzQG4qgTAHBJ9iRNU/V1L9DzHKpPofYpQwKmNEzQ9C9eyU0DE6AaHh",
+	"dqCakUooJlSBkWExBxEjUSRztcQMTyETG4KoodVQemFCz4IJVQhN2GKBRYxyzIHKOQgQiHFvRQZimmRM",
+	"QKpAUvuQwYR+KpgEMZ7QKI7gM17kGUR70U/wKeYgWMETOGMsO05jPh/ljGWjhM7Sq+3tn//nJ0LjBUsh",
+	"i/mz3c2YP32y+XMUR4RGe9GnAvgyiiOKF2o4SzlxJJI5LLAiIbnM1R0hOaFX0e1tHM1v3l3x47RNX+8p",
+	"+VQAIilQSWYEuCGoOebpjVrWAlN8Bby5BsEWMLoGmjI+yliC9WgWvhzLeQWemzmOOHwqCIc02pO8gH54",
+	"SQqLnEmgyfIXWHaC/RGWKJkzAVTRmCLyJCNAJZLMLWmJMMo5uyaCML2dCgxQ7CIYknMsEXaXEAfJCaTo",
+	"hsi5Hk3gBUyomkWxRpHNSJZBihjNlojRBFpoIRJGW6MkK4QEPtpyKJkDToFXSDmuljdS6/ORscCf3wK9",
+	"kvNob/vJkzhaEOr+3ooDqPIXd24WcoeNDqGmuarNZCt9gbdhtDt7Nh3t4idPRi/SLRg9mz6dbeKdZBu2",
+	"tsKbH4atjxSM/In2oqIgaRRaryim5brusFD/teYCMX6+k25O8Qg/AbXKrdloCs93R7Odnd3p9tbW06fJ",
+	"LLzABjBfs7Jb97A+D/bPjn8FLvSSmis8pmYswijCU1YoEr42DztZr8S7XmTOlWCVBPSo19WQ1eq3xpvj",
+	"zSCq7RU2/TckMrqNPajEMLDUwaRgshOLFfDhnPjjlzD+7oFu4b39EEf6BFQP/ieHWbQX/cdGdcRuWGRu",
+	"eJisloQ5x0v1d8HJGYcZ+VzHyYYTgCMrADcIvQYqGV9uXG8NRFaKc8m4QssgZFGEzRtBzNjBAgR/XKN0",
+	"hV03To3IFyBxttMGPY6mOPkINF2FyJfmMb2e2zgCiqcZBOD5bQ5yDtyHRIlP+/wY7VP/ckqEvo5u5iQD",
+	"RKRAFh51t6D4GpNMPVGd2Wpgs5oJdSPdzIHqGy8xh3fq5hsmJDo4P1TDUCYRoUJiJb7NOV9BhPAVJlTL",
+	"c0QkmkLCFkp5cBM3pIXha4vEKWMZYE1ZM8IXimLaCDnH9ArU3rhHKnaYsYKq86R54KbuPLPri1EOHJWb",
+	"Mo4GEv8rO6MGIUT/QmJZiHcgBL4KgK40Mw5YMNrcTrdvdSJbjf0QAV53Sbpf61ItSNe7463nHfKrEsa/",
+	"ewxUzVcR8YcQ/2ZOszkkIuGQY5oEVJE3bue0MkEEwuY9owoqsB09K7XCiMUTlkKMGLe/Vneoe1utuv56",
+	"SCpo5UqNcJyG965kpZaMcARX11+AX1u9pbVJ5Vwnon8udTSKHCfQnCpGZIYwXYZGp2pgfaaGhlZDutEM",
+	"7mYB5JUQVDjsmkrp3eGpTuzd+nRawmBZW059ryVr7FclrzA6hCxDTudHV5wV+SQIm7kQgusjUYJihlKP",
+	"FhX9FgtF3VY2VyT7m0GKgj6KI/XDXmk9GX1owdFgHX03rhFbP7+cQ864DK+jgp+AQFOQN2AltxpahM0P",
+	"LbFruK8xmXdoBM/OErADVtAOuGixmBruaM6hBXV9b6utI1TCFXC1/trK1CTD1JOglAlI6iugoA3Z/Y4V",
+	"SFIxiZoIcyI0B5SaZ4oljNRjnfzdJUdaG2ImgLQmPlLIstFWF88NQn5JBDIwawDtDVKtrE0fXf78cYsc",
+	"mjsXom1f9xmgy/lSvxDmNO/X72hQ9p14cs8ReA3jU8xBq0Ij5z/5mvO1lFw3c+CAPlJ2Q+vzXW+OXww4",
+	"bPVqQng8BJy+BSmBnzB1HlkRZJj0dKY1/T5uObcy9GCuFJraGLfxlybfSwmLXIpVNDfDRKmBKWTkGvgS",
+	"ufcmNEBwcZSWawjz4m9OFaUeeOgGC7Rg1+akUHfVMKNMj4M+FVDAZDivZljII84Z79XY1BmpdWWmfRsJ",
+	"UFktUi264DChKzezRGN7Qz/Ejdn364vWKlHCiixV19EU3PwVGqwFPTW4reurd9ekjVpQyo1KYQ6wXHmz",
+	"PY8Do65xe2y3SIIilIkQtZ1UR4t6AHF9PCqHS2W3u70KT7i1HSLEBf7c6SN4Q67mIKQ3ftGUHc/Gm5vm",
+	"v9BaFoR2Dv6W3awY++l4a3O8Ex67QV7VNtQmrS3PoTYkUo6dYX4OMw5ifg6iyDrOmdKIVxynXX0zzhY1",
+	"cR3WP5T8RtxM0KmIDz487UCDT8/y+SFHv314sCxxaumg49k9LBwCgxLS927faVikvOB9Y3ce9z6KQgA0",
+	"1xmiJKf6H32WQEsnFE5TooDG2Vlty9vrESAVV1sd1gQBEGWp1fhjZQtMokmxubmTGBtA/Qpjc8W+ba5N",
+	"InsGy2pcRARitNxnPGXXgBhHC0IvyJ8Qq3DKZ/WbumgxY3wdmC4R0z6Zj7AUYcujxxKCEh8xSjDnSwVM",
+	"udgpLJnVzss3RA6Jb/8cnL1HmCdzIiGRBdcQCiIB5RlOYAFUTmjOMpIsTTDIGzxjNwjnebZEkiHQR1cN",
+	"pTQ151uCqTpfBEh7CLTwb9z6eEKH78FHWFbLmEQJo5KzLAM+TvJi31vQJFJKE0nmSOKPIFCujtsUVIgA",
+	"nZaY14GinIM2s9MJxQIRETya6qOHya2FVOu+h7Q8TdTiRU3IfH7+9I+nuyECqHa5U+6rWRuO5uotGzqM",
+	"9eWk4BxodUSQmVYABEjPaL3eCtieRtQrBfISFnmGZcfqXzMk7QOluWBfLO0HvfwYsWvgnKQuOFq+ljA6",
+	"I1cF1xGdoMERZpUFpmQGQvYD6J6q5psWNM3UNtEUuN0lUCFYqn0MjsRm5GqB8w1DkWo9JTkyjiaRgISD",
+	"DNyOtQeEG2/JhHKYqWWrRTPFgnrgd7jD8UBB3jD+8RBL3L+sJtLtiyjFEvsb0dyEh1rdhKqr6G6r4zj5",
+	"GF6OulOBaULTU0CVc6HvyLxc5p2eN/cMUm/3sGZrcCUXO84XIgFJf2xzXLJZewUZ06gJTpBzwOnLZcf+",
+	"spxl7GrpDanD6PolhBPOhIjVRhGpd2WikWu2T72igqUmJ0FF1nu9jerFbgDfsTSAhzfsBqknE5n1gqjv",
+	"pWyBSRV0ckvzRFBu6AhqobuQUJL4quPUV3ea+NenpKZ5nTwwqlIhJtFHWP5DJxlMIpRjwuvyWRLg/7hi",
+	"WRr/ySj8Aw8LNZ1lxRWhd/FO5PoNNC1Ilpp4iBTOPyF6AlB3cGx54a+QO4vIizlug/uaSI00UoNTWc8K",
+	"VqkV9rqt8QS2MSRBxfmKdZ5lr1l5OAXnUcpCfZ4rtjXe3h4/+RoPi5nmXgGMKmhRbkVIiT3jbJrB4hAk",
+	"JpnepOY+OnV2v0yU6VNzVyiK+3Tp6fHVIF4ajuaDFGaEGm8t1uph5SRg3LrHiEKIUgb19XEUWF2ql9VG",
+	"8z6aFwtMR0oCqIgOgs95hqmZwE1nXA5EIJYY/aQKUeQGa/WNOWCUQqKHkEwfcFMsjLGVIlbIECHoOBdN",
+	"IATi+/Njc27pmU24yMVljAgpIe2GcEKPJVrgJVoSyFI0K7hWL4nH5WSGUignsi7xKv2Ak6DQ1YHAsIh7",
+	"c3l5hswDKGEp2ENsFSbLKQmVQTtREpkFMSXmjMu4uaeiWCwwXzZm0gfsGB1L9ZbzNCXaP2isew9Gyboh",
+	"jicUPieQG/shL3jOhFEllTKQkT8NVaLjmZ4REYGuyDWYBDRmI96YokmkpezeNMP0ozohNaJKdkBijrMM",
+	"4UwwpWjoDJnUbdLAeFCTlHCSMJ5aZej46PIVOn91gHZePH+Kft/5EKS0FvJ0PDxhBdfRZ+mCXWoiC6OY",
+	"0MaGpCwpSn4tNRs39E8wvhqbpMQ3l+/e/mxi9DXKRNZXSgRagBYiNlysjSQq4wlV55I+LtUtLESxML7D",
+	"KTQx3czqmUuZi72NDUeRHg7HCVus5ImG/LUMUsqgDuGbgBB3SPpAuXulfeKutADLd2t2YNPeG4XtvYTx",
+	"LkeGZBJnnljP50tBEpwh8443/k6HY5IWM6yB4Z3GUfmEx4clJqoFHFMJWdAKYylkq0f/L+GhSb+j7aP2",
+	"HD+d/4z+CYyqn69ZlqKnuzs7JwP1Ly/PTTlBXivPQohvjSOCzayyWEs0RJxloKVJaYbmnM2Iy0BpehzP",
+	"zM0VPkc7hPahkMrtTv04m4YqWpFqGHfEqJrx+crhUsNx5TtZPVE9O3aFfaVsoIYlUAWH1QHQZ8Fd6Izq",
+	"UBzF3BE1e0vcfR7WtUHqTt1ya2yGM1EWWJj8XmVmAw9aJ4L8Cat8rGYSQmuTLAglCzXP5kpfq+UavaLY",
+	"Iz87+4cVbGHTP0NM4dJv1QFS0q2sjkc1AqQxkhxTkVm7XJv9zuEY9KFlrEi7qKedD3M6OlAvtDI8PAgU",
+	"gHWa9vJ8e/xpqyylgN9ZxfhYFRxtL8DdDRjYweC7lkjDjbewQDNhoWMzwFYgmazfddGbeaQzp1cHi+ym",
+	"2rlqaxtIgRc9im7O2RUHIVblRtcpbdGXPWdvusX7sVi37yhh1NhNQzKkRLcIFn7SUiM5ycgxw4Ne8mCH",
+	"xlntaX+2VDMxSy/HJso7f2s1a2uyfI5Ft25TIV8/15zHk5H+NkdxdGAdu+avs4qDozh6pYP9URydQwZY",
+	"KadBiTowqz4sS8L1BtV2TJc1RijjgsY7UFNMg7nizlp7zzu2RSWpq03PWZaVerl5pwoTduxKg+O6Uvhp",
+	"tQtmF32gQqx4DnmGl33RWa7vmXigelZhz8uSgLSWXCBafGjegnT1SeiNYnIvjF/WJUcEEz8aeCknC6+1",
+	"M01lkFFQKjfWjvUhbh90jCqriF+sKIzQ0RttSTmjsyyasSM47cCvapgMokcfwCMVY78MGq6nVfhyxrKM",
+	"3agt1jCJPbSJRijhgCXEaAuNlLJOZssYbaOR2hmQJlnF8vxmvBVvfwhZHz4sITzso6JVIiKZojljdBp7",
+	"1B9FxR2pHIYJSwRB7JvdTKvtNQ/XbP+KiMxv5zALD/b+/K2T63YYdKkAN7AjR6vqRGkmI5U7pB7eRj8d",
+	"Hr09ujz6eTwgGaiB3K6d72OK4baxw9M44I1WUW/ZGaXS94mQHEtybUSflyBhRvXOj/cnb08Pfjk6jOLo",
+	"4s37y8vjk9d/HJ7+pqy/8sb7k19O1KXQaXG/eG0DnhhRhYGM/OmZafpUNwlDhl8r3ZQqb2GpNehkvUZV",
+	"E0/mYdu/BlwrxKKcPKhy8lQ3mxDX3aUXbFF/2kUbiPBx3nbSZ2yKs30hQK4qb+FIACc130Qdg2Tm1W3U",
+	"HSH8+dNN+dlWWAbhKFXkOgC/wPKG8VSgFBSx0ytjPQlfTpuAm0CSje+kXdWs+ApYVQ9qro8kCDmaYkGS",
+	"cCKgKl/9Cv/9aW5esoWwdTdBfeMq8L5MzMQjPIn20CTSElz9EU8ocvem/r3pJLoNS7kFLBhf9vmhSu+T",
+	"eRQRit6Rl0GHco9PyBSreh6gkDgoV3jGboAfpVeA/nmu6CYa7A65mDMuzQRO8Qqzy2qCNCm9ent6RJ33",
+	"1Eo5d3Sy//KtlmaHxxfu1z7BlmMuTfpiL1bVYx08GVT7FXZ7lqTvr1zMqRLPp69edenvxud3J5vXc94G",
+	"mNXBsEJKuW0/v+e2t71fdcHgFYqHXjcScsCm9YrS4MiS8U47195EKVyTBERQNLtzbGj12oUZs2s/wjF5",
+	"T1yry1MlsBlHSYaFILNlZZVa0V1G7O4itwtl05cU7Cjy+PDtURRH+weXx7+qX16+v/iXx2BxdPTPy6Pz",
+	"k/23b//1x9n56a/HF8enJ0eHQQI2mxQKKKvrakU1H3rLp61Ljo5pMl6p0nlk3SK+uqPPhyR2DkELqBO+",
+	"DQKsiZBS2tf4M/a1uYDUq2G7T7HUMN9ZudQO5LaG+UAqUjn61+tJ4fOmAUroZAvAMECOrHLC90g8pN5x",
+	"xmTLy+EY7s4QCSKHyt6me7QPFWmxM5hHSrawxO8D0keayigq8y0CSoMWeApYTL1Qcan3NzUJ66Cs5Zg9",
+	"OAmXcET3jgWVQ8Qm1GVywsqrQj+cOpVR2Czcj7B0WX91d3vDygoSrduz3qr0EsNE1JFsEowr+9wswxZA",
+	"tmu+u8s11Z0WFoyrw1NhLOBxqcPG9oj5MKjCyj7UFMyBRLYwRd5DWKrxYs95o0Mn26qjkJ+Boo+CgNVe",
+	"yzgKWO3l/RWk723KIEUizIaBc/0uBkSPwbB9F4thiAR3DB4439GgqcvqB6UGhReoNaTmzE10Vx6Tw6NX",
+	"xyfagDg4fXf2/lIpPCdHl7+dnv9yfPJaeVIuT8/3Xx8FtZt7Fp14sLjjpSzp6a1E+YXQtL+u+q6LPnvz",
+	"r4vjg/232kX0Wv/2YeUpKgbEsm1WwEp6X6mjrspSDhybDTZPgZNrVySlE3E0D8SWCTD1XJmaehqJmdNt",
+	"/DTZgtHz6fNk9ATvzkYvZk9gtJnspNuwNdvFT6dDXKp/vSpsUdat49boqsldTfJuU0Hsi8KQlL4g8g7S",
+	"2XQvq+1WIE3cRZ+wRESOA2GEe+YDdXKPAQsLNMMc4UqmBzmVpBmcf5VUsOnxWOrcDNN0BBUCgtPd3e3U",
+	"t8o+n9Q9RZ0Vb+FUfxv8fvjKOyyHjz9UnNXHHC6/7qDuq0fvodXbGfrrBSuebXJ1m2Q9A9ewU5C3Pa/G",
+	"MPauuVba6Qc9iXDqliOa8nVPazk6P0Bvnj15gl5xRuX9FX0zdozwymBFbf6NFK43RIrv58cKOrACq3w6",
+	"2wXYfL75NNmepjubm9sv0p3pbrK7+SLZSZ7NnkUdGVUvl52aqtBllv6MimGn+gVv6t1nL548efFid3tr",
+	"d7eeD/10N8hfwwwKh20/7GNVlDeHOoh1of49+fXd0Z18S56a243Nw6O3b6OB5kmFxSAjeGHmgR3SqgLu",
+	"dj+/xnGGs2zaWfY1K7JMFYHjTAmSVKc564yyMhSuHVRpwcHWdSaYukRShNEZE9LhaEK7w/0dad1DQ/YB",
+	"UVcCyGYmLC2QDlqnBbigoT9qmbc0RNEa1ITWTmqwkjJ90FIok7JL9mcc3ZAsU9fMuFW+gb93aEJroXZV",
+	"G0tU2ezlHDjMmGsbZQepEsRtCoOcg26a5eDCvIKhA/vi7lj3Ueri7NVTtUZQdo1lW7B3toNqYAOUzXdK",
+	"s2WjqVxX7puj6DYv3erKE3Oe6M61JgPByO7oHFL0BssojgqeeYnxNzc3Yw7pHEudD9+u7Tk7tm2H+bWy",
+	"9ZtL8rix1Fuisqojaj1edmpQ7R+juN3SUTt/Kc5JtBftjDfHO9p9LOeaoftaMuKc/HHtNY68goDicw6y",
+	"4FSUbTYykFA2qFRrdSNUhUgeyVqy1BRVuqgV9USvQe5nWdm3UusJOaPCyKHtzU23K7bViA6xGmrf+Lcw",
+	"oq9qEzqslaUwe97wIhaJEk9GtrGpxLriKrhct1S1nts42u0F0hZQ/PfdgG0UogXgfYlTJ54UEE++CxAq",
+	"95/r2KnufYeAc8bHttOsrjcyW1yjkMgFn37XbTVTLHH0Qb3SR6SOQVcSp61FtJNpM9vUaZrKE6FSCRm9",
+	"qqoHykaWtjBQvVJr4mSK8r0mrBMq50C463oiygZrfECvStei0q21gym8otRvyBPeLHdiCYtkz2u55oXB",
+	"vNBG3r044nrr7pLbSbAFoYx3i+2yQnGB/814Z3vkFtG+U8M+Hlm+JsmhJNmmh/uSpLv4xfbQud3Agc6W",
+	"QUI9MF0RRb2fZTAKWgrvlS0t642PfQ/RhAYai+qPE1Rj1Tryitg6bdUqzHjd/TjlDRujQ/+26jC0VAq9",
+	"rsQhQKXt2lxV4iCiBtE5+17hDOOI63R7SDv4rtU8NK59IKOjLWD1yIbdLN2T7tsxbRPK3iMHOTgeDQ/v",
+	"bu5+ByAuq8p2SAOcgI1JZ9vIPS5RY8DZ+k5Yc/22ndu3G4kpA5f3rQgz0ApZWNTuPE4K8Dq9N8W7laq+",
+	"2nZSL58Ny9BGw157FlQBw/sdBtVt12lv70uUs1Bp4//qPnYi5Mj3Y2fdh0QZeCuPAl12kpSfAHKwTGiC",
+	"k3mgbbz7yIkpPVDqUAoN3NjP5KiWZGiqPS76rh5Q9dEgHERIatt+i8deAPYRiuyO9pCrbIWyn2Adz+O1",
+	"KF+L8r9KlBsOVOxfp78fUYZb7qskS1otqq/d9wMJ7UAVo+iW2+6Jnmr0rhpO07/S8mn9q1G6h2xV86sr",
+	"3dIJZRRNYY6zmUOD+YSV8t8w4ZUkE4Gk6pjmf5+Ka8MY0mDFqeehHqP9CXWXOeR66kb3icY3qezYorOg",
+	"1a3AYWJGuJCIUZhQQoUErNNqam8uGIder9GBHjLUNuDeZ0u88tHGt8bMaaRnfcnS5cM5qgKruq37+CUv",
+	"4LZ1Fm5/SxBsNfyq4xAnCeTSuc466uIfz9H4PYT8e4oLOWdcle8ZKL6HlH7F+JSkKdAfSEfY3XzxncBs",
+	"yjx9iLnvNYSlnmkijFIy012sJMrxMmM4tQzwuLxk31PhqWFP+eVs5NZ9B6xp5GnR34F1TxPwbz+gMrDx",
+	"Jdjo4NYoBxmEsvZNBwlr3/kNl/yGMX6zhZZyUAbLrUVW9qSwHS8KKolubqRObz1ZGcoLmmSHGtK/+PwM",
+	"Ii5k1G0HCh86DxmNdL9zZt+hs5b3j0beA5VELh+3JfhjSUbD1HeWjPGAQPPKdketjD4iRaPrVjvu+0jF",
+	"z+Yj0KNrIeh6Q561gFsLuP+fAk6Fb+v8cHdZlxcBWfc+T7H0ujaWjTto6n+4okf6lZFXhjjopF/sjaTr",
+	"yk2HJqI+F9bq24lMXRUrTI911wWv+uJ4gqn9MJYZJxgeNct4RGJ17Rzp1lsLvVmDhPraVbI+WQadLN/P",
+	"QRL2K7sUjzKnY338fcXxZ8T7X+L58Gua/HS7lg5/XnvwGx4ztuTgq9X1O5WBl41CWlVnP1o6z3eVm+N1",
+	"HPorpNOPGMOVnMA11PJw63ktDxiyrcmqjS/1cszbocLrq2RXX3caovCpilNclfheu0lMXXmNvW1sVvp8",
+	"S29FW+qtkxbvyio1Kn/04iXMtfAZ66+mMdrIRvvLmLa8PVj3OC9f+DH4+JEqPH8HZedRZZINPxeF/Ryt",
+	"+VbUt+Y71bikr47Ga+9iSmVbTUPKiWIX6lYPV98qTdhiSmjZXK27I8yE6pYwfsWBnmBVXx9XidbTDGrR",
+	"EXk4r2Hhu6Wj3rkl1d/DFlnbAffJR/27mQHS8t43kWwbX/w/B5oBl6Zx1kOoDwM7WvXoFGVnqW6dYkWb",
+	"hr/EVqik0loKfTU/6eo3jz3WYumbiqWgneNaeD6wVBpkyPz9HKhrhWWtsPxNFJZvoat4espAHeWB9JPW",
+	"xwV6NJFH6KFcaxxDgThxMuIH8YyEzmSP8fyOXOKezCeI7HF9qIaqqz0e5TWXqaNdHjWnTcufEex2OaF6",
+	"hNKXcXXF4QpLQAnOcaKi/MblQSrVUIzReX0o5X+pGryWXU3r3c1aIkWv9JE7P8r2tmsdYq1D/Lg6hLCs",
+	"9lD6Q10M9qgNF7UHHzmve7D++Py+TpejP2AwJtw2VvRoIHFHabgpU6t9+6/diTZU1Fxjg6/j2IdPv63z",
+	"6JC8261vOHdPqq2rOZfzNt7XQmItJIYmRRierJHQQ5sj/hgbX+o9h3vLSk29lxIxqySLefJhJMtqT2N9",
+	"CZ3aQw/3mhX3cO+acdZlTl/B1YYfhnL1gAJJ23DX1Oys4saGXv4IWPGvP59r9Y0e9tbn9Vrs/G3Fjqpf",
+	"/G6axEYKOB1lII2YGdBB2f/cgrDdAVmRpchWIqaQkWv9uQb7PQk74xQ4wjOpsPB5jgshXRdC+8JyQrGU",
+	"sMil6BCPh4DTtxpS/xsZ4keSlINcHuF13s350Ram5TZDWt/Dtfq0lmMPJcd6yOz7ibUN3fV02d2w7x27",
+	"BrGKTXS/UyfTnMxCnwooIKSixOUHbSQn4WjLuQbrbyDV+oOzapED26ZqbK4UWDqP1+3AWnqtpdfD1Lko",
+	"Or2vALvVH5y7dqxan/90dKB7OLS+aKSKey/0a7WPK+1tbGQswdmcCbn3fPP5puZQO/eXwFeW3PcJGl/U",
+	"sBkb7q6WDE3MOMe2n2xm36vCUe0XzwK1xt6rtVrj2w+3/zcA9WAUpb3CAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
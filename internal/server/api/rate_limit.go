@@ -0,0 +1,178 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ClientRateLimitKey identifies the clients of the inventory API whose requests are limited separately
+type ClientRateLimitKey string
+
+const (
+	// ClientRateLimitKeySubject limits the requests of each authenticated user, as named by its token
+	ClientRateLimitKeySubject ClientRateLimitKey = "subject"
+	// ClientRateLimitKeyAddress limits the requests from each source IP, before they are authenticated
+	ClientRateLimitKeyAddress ClientRateLimitKey = "address"
+)
+
+// clientLimiterIdleTimeout is the time after which the limiter of a client that sent no request is discarded, so that
+// the number of clients tracked stays bounded
+const clientLimiterIdleTimeout = 10 * time.Minute
+
+var (
+	clientRequestsThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hwmgr_plugin_api_requests_throttled_total",
+		Help: "Number of inventory API requests rejected by the per-client rate limit",
+	}, []string{"client"})
+
+	clientsRateLimited = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hwmgr_plugin_api_rate_limited_clients",
+		Help: "Number of inventory API clients tracked by the per-client rate limit",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(clientRequestsThrottled, clientsRateLimited)
+}
+
+// clientLimiter is the rate limiter of a client, along with the time of its last request
+type clientLimiter struct {
+	limiter  flowcontrol.PassiveRateLimiter
+	lastSeen time.Time
+}
+
+// ClientRateLimiter limits the rate of the requests of each client of the inventory API, so that a client polling the
+// inventory in a tight loop cannot starve the others, nor overload the adaptors and the kube-apiserver behind them
+type ClientRateLimiter struct {
+	key   ClientRateLimitKey
+	qps   float32
+	burst int
+	clock clock.PassiveClock
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastPrune time.Time
+}
+
+// NewClientRateLimiter returns a rate limiter allowing each client qps requests per second, with bursts of up to burst
+// requests, which defaults to qps. A nil ClientRateLimiter is returned if qps is not positive, as requests are then not
+// limited.
+func NewClientRateLimiter(key ClientRateLimitKey, qps float32, burst int) (*ClientRateLimiter, error) {
+	switch key {
+	case ClientRateLimitKeySubject, ClientRateLimitKeyAddress:
+	default:
+		return nil, fmt.Errorf("unsupported rate limit key: %s", key)
+	}
+	if qps <= 0 {
+		return nil, nil
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(float64(qps)))
+	}
+	return &ClientRateLimiter{
+		key:     key,
+		qps:     qps,
+		burst:   burst,
+		clock:   clock.RealClock{},
+		clients: make(map[string]*clientLimiter),
+	}, nil
+}
+
+// Key returns the key identifying the clients
+func (l *ClientRateLimiter) Key() ClientRateLimitKey {
+	return l.key
+}
+
+// clientId returns the identifier of the client of a request: the name of the authenticated user, or the source IP
+func (l *ClientRateLimiter) clientId(r *http.Request) string {
+	if l.key == ClientRateLimitKeySubject {
+		if user, ok := request.UserFrom(r.Context()); ok && user.GetName() != "" {
+			return user.GetName()
+		}
+	}
+	return clientHost(r.RemoteAddr)
+}
+
+// metricClient returns the client label of the metrics. Source IPs are not used as labels, as they are unbounded.
+func (l *ClientRateLimiter) metricClient(clientId string) string {
+	if l.key == ClientRateLimitKeySubject {
+		return clientId
+	}
+	return string(ClientRateLimitKeyAddress)
+}
+
+// allow takes a token from the limiter of the client, returning false if its rate limit is exceeded
+func (l *ClientRateLimiter) allow(clientId string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if now.Sub(l.lastPrune) > clientLimiterIdleTimeout {
+		l.prune(now)
+	}
+
+	client, exists := l.clients[clientId]
+	if !exists {
+		client = &clientLimiter{
+			limiter: flowcontrol.NewTokenBucketPassiveRateLimiterWithClock(l.qps, l.burst, l.clock),
+		}
+		l.clients[clientId] = client
+		clientsRateLimited.Set(float64(len(l.clients)))
+	}
+	client.lastSeen = now
+	return client.limiter.TryAccept()
+}
+
+// prune discards the limiters of the clients idle for longer than clientLimiterIdleTimeout. Their bucket has refilled
+// by then, so a client whose limiter is discarded is not granted more requests than it would otherwise have been.
+func (l *ClientRateLimiter) prune(now time.Time) {
+	for clientId, client := range l.clients {
+		if now.Sub(client.lastSeen) > clientLimiterIdleTimeout {
+			delete(l.clients, clientId)
+		}
+	}
+	l.lastPrune = now
+	clientsRateLimited.Set(float64(len(l.clients)))
+}
+
+// retryAfter returns the number of seconds after which a rejected client gets a new token
+func (l *ClientRateLimiter) retryAfter() string {
+	return strconv.Itoa(int(math.Ceil(1 / float64(l.qps))))
+}
+
+// GetClientRateLimitFunc rejects the requests of the clients exceeding their rate limit with a 429 response, telling
+// them when to retry. Limited by subject, it must run after authentication. Limited by address, it should run before,
+// so that unauthenticated requests do not reach the kube-apiserver for token review.
+func GetClientRateLimitFunc(l *ClientRateLimiter) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientId := l.clientId(r)
+			if l.allow(clientId) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			slog.Debug("Request throttled", "client", clientId, "method", r.Method, "url", r.RequestURI)
+			clientRequestsThrottled.WithLabelValues(l.metricClient(clientId)).Inc()
+			w.Header().Set("Retry-After", l.retryAfter())
+			ProblemDetails(w, fmt.Sprintf("rate limit of %g requests per second exceeded", l.qps), http.StatusTooManyRequests)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func newTestRateLimiter(t *testing.T, key ClientRateLimitKey, qps float32, burst int) (*ClientRateLimiter, *clocktesting.FakePassiveClock) {
+	t.Helper()
+	limiter, err := NewClientRateLimiter(key, qps, burst)
+	if err != nil || limiter == nil {
+		t.Fatalf("failed to create rate limiter: %v", err)
+	}
+	clock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	limiter.clock = clock
+	return limiter, clock
+}
+
+func serveRateLimited(handler http.Handler, remoteAddr, username string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/hardware-manager/inventory/v1/manager/hwmgr/resources", nil)
+	req.RemoteAddr = remoteAddr
+	if username != "" {
+		req = req.WithContext(request.WithUser(req.Context(), &user.DefaultInfo{Name: username}))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewClientRateLimiter(t *testing.T) {
+	if limiter, err := NewClientRateLimiter(ClientRateLimitKeySubject, 0, 0); err != nil || limiter != nil {
+		t.Errorf("expected no rate limiter without a QPS, got %v, %v", limiter, err)
+	}
+	if _, err := NewClientRateLimiter("token", 1, 0); err == nil {
+		t.Errorf("expected an error for an unsupported key")
+	}
+	limiter, err := NewClientRateLimiter(ClientRateLimitKeyAddress, 2.5, 0)
+	if err != nil || limiter.burst != 3 {
+		t.Errorf("expected the burst to default to the QPS, got %v, %v", limiter, err)
+	}
+}
+
+func TestClientRateLimitBySubject(t *testing.T) {
+	limiter, clock := newTestRateLimiter(t, ClientRateLimitKeySubject, 1, 2)
+	handler := GetClientRateLimitFunc(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 2; i++ {
+		if rec := serveRateLimited(handler, "192.0.2.10:1234", "poller"); rec.Code != http.StatusOK {
+			t.Fatalf("expected request %d within the burst to be served, got %d", i, rec.Code)
+		}
+	}
+	rec := serveRateLimited(handler, "192.0.2.10:1234", "poller")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected the request above the burst to be throttled, got %d, Retry-After %q",
+			rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// Other clients from the same address are limited separately
+	if rec := serveRateLimited(handler, "192.0.2.10:1234", "other"); rec.Code != http.StatusOK {
+		t.Errorf("expected another subject to be served, got %d", rec.Code)
+	}
+
+	clock.SetTime(clock.Now().Add(time.Second))
+	if rec := serveRateLimited(handler, "192.0.2.10:1234", "poller"); rec.Code != http.StatusOK {
+		t.Errorf("expected the client to be served once its bucket refills, got %d", rec.Code)
+	}
+}
+
+func TestClientRateLimitByAddress(t *testing.T) {
+	limiter, _ := newTestRateLimiter(t, ClientRateLimitKeyAddress, 1, 1)
+	handler := GetClientRateLimitFunc(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	if rec := serveRateLimited(handler, "192.0.2.10:1234", "poller"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the first request to be served, got %d", rec.Code)
+	}
+	if rec := serveRateLimited(handler, "192.0.2.10:5678", "other"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected requests from the same address to share the limit, got %d", rec.Code)
+	}
+	if rec := serveRateLimited(handler, "192.0.2.11:1234", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected another address to be served, got %d", rec.Code)
+	}
+}
+
+func TestClientRateLimitPrune(t *testing.T) {
+	limiter, clock := newTestRateLimiter(t, ClientRateLimitKeyAddress, 1, 1)
+	limiter.allow("192.0.2.10")
+	clock.SetTime(clock.Now().Add(clientLimiterIdleTimeout / 2))
+	limiter.allow("192.0.2.11")
+
+	clock.SetTime(clock.Now().Add(clientLimiterIdleTimeout/2 + time.Second))
+	limiter.allow("192.0.2.11")
+	if _, exists := limiter.clients["192.0.2.10"]; exists {
+		t.Errorf("expected the idle client to be discarded")
+	}
+	if _, exists := limiter.clients["192.0.2.11"]; !exists {
+		t.Errorf("expected the active client to be kept")
+	}
+}
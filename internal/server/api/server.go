@@ -8,13 +8,22 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/features"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/filter"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
 )
 
 type InventoryServer struct {
-	HwMgrAdaptor *adaptors.HwMgrAdaptorController
+	HwMgrAdaptor      *adaptors.HwMgrAdaptorController
+	SubscriptionStore subscriptions.Store
+	CallbackPolicy    *subscriptions.CallbackPolicy
 }
 
 // InventoryServer implements StrictServerInterface. This ensures that we've conformed to the `StrictServerInterface` with a compile-time check
@@ -52,8 +61,28 @@ func (i *InventoryServer) GetMinorVersions(_ context.Context, _ generated.GetMin
 	}), nil
 }
 
+// GetPluginInfo handles an API request to fetch the plugin and adaptor version information
+func (i *InventoryServer) GetPluginInfo(ctx context.Context, request generated.GetPluginInfoRequestObject) (generated.GetPluginInfoResponseObject, error) {
+	return i.HwMgrAdaptor.GetPluginInfo(ctx, request) // nolint: wrapcheck
+}
+
+// GetResourcePools handles an API request to list the resource pools of a hardware manager, selected by the filter
 func (i *InventoryServer) GetResourcePools(ctx context.Context, request generated.GetResourcePoolsRequestObject) (generated.GetResourcePoolsResponseObject, error) {
-	return i.HwMgrAdaptor.GetResourcePools(ctx, request) // nolint: wrapcheck
+	expression, err := parseFilter(request.Params.Filter)
+	if err != nil {
+		return generated.GetResourcePools400ApplicationProblemPlusJSONResponse(badFilter(err)), nil
+	}
+
+	resp, err := i.HwMgrAdaptor.GetResourcePools(ctx, request)
+	pools, ok := resp.(generated.GetResourcePools200JSONResponse)
+	if err != nil || !ok {
+		return resp, err // nolint: wrapcheck
+	}
+	filtered, err := filter.Apply(expression, []generated.ResourcePoolInfo(pools))
+	if err != nil {
+		return generated.GetResourcePools500ApplicationProblemPlusJSONResponse(filterFailed(err)), nil
+	}
+	return generated.GetResourcePools200JSONResponse(filtered), nil
 }
 
 func (i *InventoryServer) GetResourcePool(ctx context.Context, request generated.GetResourcePoolRequestObject) (generated.GetResourcePoolResponseObject, error) {
@@ -62,12 +91,30 @@ func (i *InventoryServer) GetResourcePool(ctx context.Context, request generated
 }
 
 func (i *InventoryServer) GetResourcePoolResources(ctx context.Context, request generated.GetResourcePoolResourcesRequestObject) (generated.GetResourcePoolResourcesResponseObject, error) {
+	if _, err := parseFilter(request.Params.Filter); err != nil {
+		return generated.GetResourcePoolResources400ApplicationProblemPlusJSONResponse(badFilter(err)), nil
+	}
 	// TODO implement me
 	return generated.GetResourcePoolResources200JSONResponse([]generated.ResourceInfo{}), nil
 }
 
+// GetResources handles an API request to list the resources of a hardware manager, selected by the filter
 func (i *InventoryServer) GetResources(ctx context.Context, request generated.GetResourcesRequestObject) (generated.GetResourcesResponseObject, error) {
-	return i.HwMgrAdaptor.GetResources(ctx, request) // nolint: wrapcheck
+	expression, err := parseFilter(request.Params.Filter)
+	if err != nil {
+		return generated.GetResources400ApplicationProblemPlusJSONResponse(badFilter(err)), nil
+	}
+
+	resp, err := i.HwMgrAdaptor.GetResources(ctx, request)
+	resources, ok := resp.(generated.GetResources200JSONResponse)
+	if err != nil || !ok {
+		return resp, err // nolint: wrapcheck
+	}
+	filtered, err := filter.Apply(expression, []generated.ResourceInfo(resources))
+	if err != nil {
+		return generated.GetResources500ApplicationProblemPlusJSONResponse(filterFailed(err)), nil
+	}
+	return generated.GetResources200JSONResponse(filtered), nil
 }
 
 func (i *InventoryServer) GetResource(ctx context.Context, request generated.GetResourceRequestObject) (generated.GetResourceResponseObject, error) {
@@ -75,31 +122,210 @@ func (i *InventoryServer) GetResource(ctx context.Context, request generated.Get
 	return generated.GetResource200JSONResponse{}, nil
 }
 
+func (i *InventoryServer) GetResourceTypes(ctx context.Context, request generated.GetResourceTypesRequestObject) (generated.GetResourceTypesResponseObject, error) {
+	return i.HwMgrAdaptor.GetResourceTypes(ctx, request) // nolint: wrapcheck
+}
+
+// GetSites handles an API request to list the sites of a hardware manager
+func (i *InventoryServer) GetSites(ctx context.Context, request generated.GetSitesRequestObject) (generated.GetSitesResponseObject, error) {
+	return i.HwMgrAdaptor.GetSites(ctx, request) // nolint: wrapcheck
+}
+
+// GetAllocationReport handles an API request to compare the Nodes of a hardware manager with its backend allocations
+func (i *InventoryServer) GetAllocationReport(ctx context.Context, request generated.GetAllocationReportRequestObject) (generated.GetAllocationReportResponseObject, error) {
+	return i.HwMgrAdaptor.GetAllocationReport(ctx, request) // nolint: wrapcheck
+}
+
+// RefreshInventory handles an API request to refresh the cached inventory of a hardware manager
+func (i *InventoryServer) RefreshInventory(ctx context.Context, request generated.RefreshInventoryRequestObject) (generated.RefreshInventoryResponseObject, error) {
+	return i.HwMgrAdaptor.RefreshInventory(ctx, request) // nolint: wrapcheck
+}
+
+func (i *InventoryServer) GetResourceType(ctx context.Context, request generated.GetResourceTypeRequestObject) (generated.GetResourceTypeResponseObject, error) {
+	return i.HwMgrAdaptor.GetResourceType(ctx, request) // nolint: wrapcheck
+}
+
 // GetSubscriptions receives the API request to this endpoint, executes the request, and responds appropriately
 func (i *InventoryServer) GetSubscriptions(ctx context.Context, request generated.GetSubscriptionsRequestObject,
 ) (generated.GetSubscriptionsResponseObject, error) {
-	// TODO
-	objects := make([]generated.Subscription, 1)
+	objects, err := i.SubscriptionStore.ListSubscriptions(ctx, request.HwMgrId)
+	if err != nil {
+		return generated.GetSubscriptions500ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to list subscriptions: %s", err.Error()),
+		}), nil
+	}
 	return generated.GetSubscriptions200JSONResponse(objects), nil
 }
 
 // CreateSubscription receives the API request to this endpoint, executes the request, and responds appropriately
 func (i *InventoryServer) CreateSubscription(ctx context.Context, request generated.CreateSubscriptionRequestObject,
 ) (generated.CreateSubscriptionResponseObject, error) {
-	// TODO
-	return generated.CreateSubscription201JSONResponse(generated.Subscription{}), nil
+	if request.Body == nil || !utils.IsValidURL(request.Body.Callback) {
+		return generated.CreateSubscription400ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+			Status: http.StatusBadRequest,
+			Detail: "A valid callback URL is required",
+		}), nil
+	}
+	if i.CallbackPolicy != nil {
+		if err := i.CallbackPolicy.ValidateURL(ctx, request.Body.Callback); err != nil {
+			return generated.CreateSubscription400ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+				Status: http.StatusBadRequest,
+				Detail: fmt.Sprintf("Callback URL is not allowed: %s", err.Error()),
+			}), nil
+		}
+	}
+
+	subscription, err := i.SubscriptionStore.CreateSubscription(ctx, request.HwMgrId, *request.Body)
+	if err != nil {
+		return generated.CreateSubscription500ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to create subscription: %s", err.Error()),
+		}), nil
+	}
+	return generated.CreateSubscription201JSONResponse(*subscription), nil
 }
 
 // GetSubscription receives the API request to this endpoint, executes the request, and responds appropriately
 func (i *InventoryServer) GetSubscription(ctx context.Context, request generated.GetSubscriptionRequestObject,
 ) (generated.GetSubscriptionResponseObject, error) {
-	// TODO
-	return generated.GetSubscription200JSONResponse(generated.Subscription{}), nil
+	subscription, err := i.SubscriptionStore.GetSubscription(ctx, request.HwMgrId, request.SubscriptionId)
+	if err != nil {
+		if errors.Is(err, subscriptions.ErrNotFound) {
+			return generated.GetSubscription404ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+				Status: http.StatusNotFound,
+				Detail: fmt.Sprintf("Subscription %s not found", request.SubscriptionId),
+			}), nil
+		}
+		return generated.GetSubscription500ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to get subscription %s: %s", request.SubscriptionId, err.Error()),
+		}), nil
+	}
+	return generated.GetSubscription200JSONResponse(*subscription), nil
 }
 
 // DeleteSubscription receives the API request to this endpoint, executes the request, and responds appropriately
 func (i *InventoryServer) DeleteSubscription(ctx context.Context, request generated.DeleteSubscriptionRequestObject,
 ) (generated.DeleteSubscriptionResponseObject, error) {
-	// TODO
+	if err := i.SubscriptionStore.DeleteSubscription(ctx, request.HwMgrId, request.SubscriptionId); err != nil {
+		if errors.Is(err, subscriptions.ErrNotFound) {
+			return generated.DeleteSubscription404ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+				Status: http.StatusNotFound,
+				Detail: fmt.Sprintf("Subscription %s not found", request.SubscriptionId),
+			}), nil
+		}
+		return generated.DeleteSubscription500ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to delete subscription %s: %s", request.SubscriptionId, err.Error()),
+		}), nil
+	}
 	return generated.DeleteSubscription200Response{}, nil
 }
+
+// toDeadLetterNotification converts a stored notification to its API representation
+func toDeadLetterNotification(notification subscriptions.Notification) generated.DeadLetterNotification {
+	result := generated.DeadLetterNotification{
+		Attempts:               notification.Attempts,
+		ConsumerSubscriptionId: notification.ConsumerSubscriptionId,
+		NotificationEventType:  generated.DeadLetterNotificationNotificationEventType(notification.NotificationEventType),
+		NotificationId:         notification.NotificationId,
+	}
+	if notification.LastError != "" {
+		result.LastError = &notification.LastError
+	}
+	if notification.ObjectRef != "" {
+		result.ObjectRef = &notification.ObjectRef
+	}
+	if notification.Object != nil {
+		result.Object = &notification.Object
+	}
+	result.DeadLetteredAt = notification.DeadLetteredAt
+	return result
+}
+
+// GetDeadLetterNotifications receives the API request to this endpoint, executes the request, and responds appropriately
+func (i *InventoryServer) GetDeadLetterNotifications(ctx context.Context, request generated.GetDeadLetterNotificationsRequestObject,
+) (generated.GetDeadLetterNotificationsResponseObject, error) {
+	notifications, err := i.SubscriptionStore.DeadLetterNotifications(ctx, request.HwMgrId, request.SubscriptionId)
+	if err != nil {
+		if errors.Is(err, subscriptions.ErrNotFound) {
+			return generated.GetDeadLetterNotifications404ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+				Status: http.StatusNotFound,
+				Detail: fmt.Sprintf("Subscription %s not found", request.SubscriptionId),
+			}), nil
+		}
+		return generated.GetDeadLetterNotifications500ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to get dead-lettered notifications of subscription %s: %s", request.SubscriptionId, err.Error()),
+		}), nil
+	}
+
+	resp := generated.GetDeadLetterNotifications200JSONResponse{}
+	for _, notification := range notifications {
+		resp = append(resp, toDeadLetterNotification(notification))
+	}
+	return resp, nil
+}
+
+// ReplayDeadLetterNotifications receives the API request to this endpoint, executes the request, and responds appropriately
+func (i *InventoryServer) ReplayDeadLetterNotifications(ctx context.Context, request generated.ReplayDeadLetterNotificationsRequestObject,
+) (generated.ReplayDeadLetterNotificationsResponseObject, error) {
+	count, err := i.SubscriptionStore.ReplayDeadLetterNotifications(ctx, request.HwMgrId, request.SubscriptionId)
+	if err != nil {
+		if errors.Is(err, subscriptions.ErrNotFound) {
+			return generated.ReplayDeadLetterNotifications404ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+				Status: http.StatusNotFound,
+				Detail: fmt.Sprintf("Subscription %s not found", request.SubscriptionId),
+			}), nil
+		}
+		return generated.ReplayDeadLetterNotifications500ApplicationProblemPlusJSONResponse(generated.ProblemDetails{
+			Status: http.StatusInternalServerError,
+			Detail: fmt.Sprintf("Failed to replay dead-lettered notifications of subscription %s: %s", request.SubscriptionId, err.Error()),
+		}), nil
+	}
+	subscriptions.RecordReplay(request.HwMgrId, count)
+	return generated.ReplayDeadLetterNotifications200JSONResponse{Replayed: count}, nil
+}
+
+// provisioningAPIDisabled is the response to provisioning requests while the ProvisioningAPI feature gate is disabled
+var provisioningAPIDisabled = generated.ProblemDetails{
+	Status: http.StatusNotImplemented,
+	Detail: "The provisioning API is disabled. Enable the ProvisioningAPI feature gate to use it.",
+}
+
+// CreateProvisioningRequest receives the API request to this endpoint, executes the request, and responds appropriately
+func (i *InventoryServer) CreateProvisioningRequest(ctx context.Context, request generated.CreateProvisioningRequestRequestObject,
+) (generated.CreateProvisioningRequestResponseObject, error) {
+	if !features.Gate.Enabled(features.ProvisioningAPI) {
+		return generated.CreateProvisioningRequest501ApplicationProblemPlusJSONResponse(provisioningAPIDisabled), nil
+	}
+	return i.HwMgrAdaptor.CreateProvisioningRequest(ctx, request) // nolint: wrapcheck
+}
+
+// GetProvisioningRequest receives the API request to this endpoint, executes the request, and responds appropriately
+func (i *InventoryServer) GetProvisioningRequest(ctx context.Context, request generated.GetProvisioningRequestRequestObject,
+) (generated.GetProvisioningRequestResponseObject, error) {
+	if !features.Gate.Enabled(features.ProvisioningAPI) {
+		return generated.GetProvisioningRequest501ApplicationProblemPlusJSONResponse(provisioningAPIDisabled), nil
+	}
+	return i.HwMgrAdaptor.GetProvisioningRequest(ctx, request) // nolint: wrapcheck
+}
+
+// UpdateProvisioningRequest receives the API request to this endpoint, executes the request, and responds appropriately
+func (i *InventoryServer) UpdateProvisioningRequest(ctx context.Context, request generated.UpdateProvisioningRequestRequestObject,
+) (generated.UpdateProvisioningRequestResponseObject, error) {
+	if !features.Gate.Enabled(features.ProvisioningAPI) {
+		return generated.UpdateProvisioningRequest501ApplicationProblemPlusJSONResponse(provisioningAPIDisabled), nil
+	}
+	return i.HwMgrAdaptor.UpdateProvisioningRequest(ctx, request) // nolint: wrapcheck
+}
+
+// DeleteProvisioningRequest receives the API request to this endpoint, executes the request, and responds appropriately
+func (i *InventoryServer) DeleteProvisioningRequest(ctx context.Context, request generated.DeleteProvisioningRequestRequestObject,
+) (generated.DeleteProvisioningRequestResponseObject, error) {
+	if !features.Gate.Enabled(features.ProvisioningAPI) {
+		return generated.DeleteProvisioningRequest501ApplicationProblemPlusJSONResponse(provisioningAPIDisabled), nil
+	}
+	return i.HwMgrAdaptor.DeleteProvisioningRequest(ctx, request) // nolint: wrapcheck
+}
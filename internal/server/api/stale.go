@@ -0,0 +1,57 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+// Headers flagging a response served from an inventory snapshot
+const (
+	staleWarning            = `110 - "Response is Stale"`
+	InventorySnapshotHeader = "X-Inventory-Snapshot-Time"
+)
+
+// staleResponseWriter adds the stale headers when the response header is written, once the handler has had a chance
+// to mark the response as stale
+type staleResponseWriter struct {
+	http.ResponseWriter
+	marker      *utils.StaleMarker
+	wroteHeader bool
+}
+
+func (s *staleResponseWriter) WriteHeader(statusCode int) {
+	if !s.wroteHeader {
+		s.wroteHeader = true
+		if asOf, stale := s.marker.Stale(); stale {
+			s.Header().Set("Warning", staleWarning)
+			s.Header().Set(InventorySnapshotHeader, asOf.UTC().Format(time.RFC3339))
+		}
+	}
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (s *staleResponseWriter) Write(data []byte) (int, error) {
+	if !s.wroteHeader {
+		s.WriteHeader(http.StatusOK)
+	}
+	return s.ResponseWriter.Write(data) // nolint: wrapcheck
+}
+
+// GetStaleResponseFunc flags responses served from an inventory snapshot, such as while the inventory of a hardware
+// manager is resynced after a restart, with a Warning header and the time of the snapshot.
+func GetStaleResponseFunc() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, marker := utils.WithStaleMarker(r.Context())
+			next.ServeHTTP(&staleResponseWriter{ResponseWriter: w, marker: marker}, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+)
+
+func serveStale(t *testing.T, asOf *time.Time) *httptest.ResponseRecorder {
+	t.Helper()
+	handler := GetStaleResponseFunc()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if asOf != nil {
+			utils.MarkInventoryStale(r.Context(), *asOf)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hardware-manager/inventory/v1/manager/hwmgr/resources", nil))
+	return rec
+}
+
+func TestStaleResponse(t *testing.T) {
+	asOf := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := serveStale(t, &asOf)
+	if rec.Code != http.StatusOK || rec.Header().Get("Warning") != staleWarning ||
+		rec.Header().Get(InventorySnapshotHeader) != "2025-01-02T03:04:05Z" {
+		t.Errorf("expected stale headers, got %d: %v", rec.Code, rec.Header())
+	}
+}
+
+func TestFreshResponse(t *testing.T) {
+	rec := serveStale(t, nil)
+	if rec.Header().Get("Warning") != "" || rec.Header().Get(InventorySnapshotHeader) != "" {
+		t.Errorf("expected no stale headers, got %v", rec.Header())
+	}
+}
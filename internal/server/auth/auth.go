@@ -39,6 +39,7 @@ func Authenticator(handler authenticator.Request) api.Middleware {
 
 			// Load the user details into the context so that the Authorizers have access to it.
 			req = req.WithContext(request.WithUser(req.Context(), response.User))
+			api.SetAccessLogUser(req.Context(), response.User.GetName())
 
 			// Proceed to the next layer of handler
 			next.ServeHTTP(w, req)
@@ -0,0 +1,325 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package filter implements the filter expressions of the O-RAN O2 IMS API, as accepted by oran-o2ims, so that the
+// list endpoints of the inventory API can be queried with the same expressions. An expression is a list of criteria
+// separated by semicolons, all of which must match, each formatted as (operator,attribute,value[,value...]), such as
+// "(eq,resourcePoolId,pool-1);(in,model,r740,r650)". Attributes are the JSON names of the fields of the listed items,
+// with nested fields separated by slashes, such as "labels/site". Values containing commas, parentheses or semicolons
+// are enclosed in single quotes, with a single quote within a quoted value written as two single quotes.
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operator is the operator of a filter criterion
+type Operator string
+
+// The operators of the filter criteria
+const (
+	Eq    Operator = "eq"
+	Neq   Operator = "neq"
+	Gt    Operator = "gt"
+	Gte   Operator = "gte"
+	Lt    Operator = "lt"
+	Lte   Operator = "lte"
+	Cont  Operator = "cont"
+	Ncont Operator = "ncont"
+	In    Operator = "in"
+	Nin   Operator = "nin"
+)
+
+// multiValued are the operators accepting more than one value
+var multiValued = map[Operator]bool{Cont: true, Ncont: true, In: true, Nin: true}
+
+// negations maps the negated operators to the operator they negate
+var negations = map[Operator]Operator{Neq: Eq, Ncont: Cont, Nin: In}
+
+// Criterion is a single criterion of a filter expression
+type Criterion struct {
+	Operator Operator
+	// Path is the path of the attribute, with an element per nesting level
+	Path   []string
+	Values []string
+}
+
+// Expression is a parsed filter expression, matching the items that match all of its criteria
+type Expression struct {
+	Criteria []Criterion
+}
+
+// Parse parses a filter expression. An empty expression matches all items.
+func Parse(text string) (*Expression, error) {
+	expression := &Expression{}
+	if strings.TrimSpace(text) == "" {
+		return expression, nil
+	}
+
+	terms, err := splitTerms(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, term := range terms {
+		criterion, err := parseCriterion(term)
+		if err != nil {
+			return nil, err
+		}
+		expression.Criteria = append(expression.Criteria, criterion)
+	}
+	return expression, nil
+}
+
+// splitTerms splits the expression into its criteria, separated by semicolons outside of quotes and parentheses
+func splitTerms(text string) ([]string, error) {
+	var terms []string
+	depth := 0
+	quoted := false
+	start := 0
+	for i, r := range text {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in filter: %s", text)
+			}
+		case r == ';' && depth == 0:
+			terms = append(terms, text[start:i])
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in filter: %s", text)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in filter: %s", text)
+	}
+	return append(terms, text[start:]), nil
+}
+
+// parseCriterion parses a single criterion, formatted as (operator,attribute,value[,value...])
+func parseCriterion(term string) (Criterion, error) {
+	term = strings.TrimSpace(term)
+	if !strings.HasPrefix(term, "(") || !strings.HasSuffix(term, ")") {
+		return Criterion{}, fmt.Errorf("filter criterion must be enclosed in parentheses: %s", term)
+	}
+
+	fields := splitFields(term[1 : len(term)-1])
+	if len(fields) < 3 {
+		return Criterion{}, fmt.Errorf("filter criterion must have an operator, an attribute and a value: %s", term)
+	}
+
+	criterion := Criterion{
+		Operator: Operator(strings.TrimSpace(fields[0])),
+		Values:   fields[2:],
+	}
+	switch criterion.Operator {
+	case Eq, Neq, Gt, Gte, Lt, Lte, Cont, Ncont, In, Nin:
+	default:
+		return Criterion{}, fmt.Errorf("unsupported filter operator %s: %s", criterion.Operator, term)
+	}
+	if len(criterion.Values) > 1 && !multiValued[criterion.Operator] {
+		return Criterion{}, fmt.Errorf("filter operator %s accepts a single value: %s", criterion.Operator, term)
+	}
+
+	attribute := strings.TrimSpace(fields[1])
+	if attribute == "" {
+		return Criterion{}, fmt.Errorf("filter criterion has an empty attribute: %s", term)
+	}
+	criterion.Path = strings.Split(attribute, "/")
+	return criterion, nil
+}
+
+// splitFields splits the fields of a criterion on the commas outside of quotes, unquoting the quoted fields
+func splitFields(text string) []string {
+	var fields []string
+	var field strings.Builder
+	quoted := false
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' && quoted && i+1 < len(runes) && runes[i+1] == '\'':
+			// An escaped quote within a quoted value
+			field.WriteRune('\'')
+			i++
+		case r == '\'':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	return append(fields, field.String())
+}
+
+// String formats the expression back to the filter syntax
+func (e *Expression) String() string {
+	terms := make([]string, 0, len(e.Criteria))
+	for _, criterion := range e.Criteria {
+		fields := []string{string(criterion.Operator), strings.Join(criterion.Path, "/")}
+		for _, value := range criterion.Values {
+			if strings.ContainsAny(value, ",;()'") {
+				value = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+			}
+			fields = append(fields, value)
+		}
+		terms = append(terms, "("+strings.Join(fields, ",")+")")
+	}
+	return strings.Join(terms, ";")
+}
+
+// Matches checks whether the item matches all the criteria of the expression. The item is evaluated through its
+// JSON representation, so that attributes are named as in the API.
+func (e *Expression) Matches(item any) (bool, error) {
+	if len(e.Criteria) == 0 {
+		return true, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal item: %w", err)
+	}
+	var object any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false, fmt.Errorf("failed to unmarshal item: %w", err)
+	}
+
+	for _, criterion := range e.Criteria {
+		if !criterion.matches(object) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Apply returns the items matching the expression, in their original order
+func Apply[T any](e *Expression, items []T) ([]T, error) {
+	if len(e.Criteria) == 0 {
+		return items, nil
+	}
+
+	matching := make([]T, 0, len(items))
+	for _, item := range items {
+		match, err := e.Matches(item)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			matching = append(matching, item)
+		}
+	}
+	return matching, nil
+}
+
+// matches checks whether the decoded JSON object matches the criterion. A negated operator matches if its
+// counterpart does not, including when the attribute is absent.
+func (c Criterion) matches(object any) bool {
+	if operator, negated := negations[c.Operator]; negated {
+		return !Criterion{Operator: operator, Path: c.Path, Values: c.Values}.matches(object)
+	}
+
+	value, found := lookup(object, c.Path)
+	if !found {
+		return false
+	}
+
+	// An array attribute matches if any of its elements does
+	if elements, ok := value.([]any); ok {
+		for _, element := range elements {
+			if c.matchesValue(element) {
+				return true
+			}
+		}
+		return false
+	}
+	return c.matchesValue(value)
+}
+
+// matchesValue checks whether a scalar attribute value matches the criterion
+func (c Criterion) matchesValue(value any) bool {
+	attribute, ok := formatScalar(value)
+	if !ok {
+		return false
+	}
+
+	for _, expected := range c.Values {
+		var match bool
+		switch c.Operator {
+		case Eq, In:
+			match = attribute == expected
+		case Cont:
+			match = strings.Contains(attribute, expected)
+		case Gt:
+			match = compare(value, attribute, expected) > 0
+		case Gte:
+			match = compare(value, attribute, expected) >= 0
+		case Lt:
+			match = compare(value, attribute, expected) < 0
+		case Lte:
+			match = compare(value, attribute, expected) <= 0
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the value at the path of the decoded JSON object
+func lookup(object any, path []string) (any, bool) {
+	value := object
+	for _, element := range path {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = fields[element]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// formatScalar formats a scalar JSON value as a string, returning false for objects and arrays
+func formatScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// compare compares an attribute value with an expected value, numerically if both are numbers, and as strings
+// otherwise
+func compare(value any, attribute, expected string) int {
+	if number, ok := value.(float64); ok {
+		if expectedNumber, err := strconv.ParseFloat(expected, 64); err == nil {
+			switch {
+			case number < expectedNumber:
+				return -1
+			case number > expectedNumber:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(attribute, expected)
+}
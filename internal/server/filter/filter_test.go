@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	Id     string            `json:"id"`
+	Model  string            `json:"model"`
+	Memory int               `json:"memory"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Tags   []string          `json:"tags,omitempty"`
+}
+
+var testItems = []testItem{
+	{Id: "node-1", Model: "r740", Memory: 256, Labels: map[string]string{"site": "site-a"}, Tags: []string{"gpu"}},
+	{Id: "node-2", Model: "r650", Memory: 512, Labels: map[string]string{"site": "site-b"}},
+	{Id: "node-3", Model: "r740,xd", Memory: 1024},
+}
+
+func TestParse(t *testing.T) {
+	expression, err := Parse("(eq,model,'r740,xd');(in,labels/site,site-a,'it''s')")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Criterion{
+		{Operator: Eq, Path: []string{"model"}, Values: []string{"r740,xd"}},
+		{Operator: In, Path: []string{"labels", "site"}, Values: []string{"site-a", "it's"}},
+	}
+	if !reflect.DeepEqual(expression.Criteria, expected) {
+		t.Errorf("expected %+v, got %+v", expected, expression.Criteria)
+	}
+	if text := expression.String(); text != "(eq,model,'r740,xd');(in,labels/site,site-a,'it''s')" {
+		t.Errorf("unexpected formatted expression: %s", text)
+	}
+
+	for _, invalid := range []string{
+		"eq,model,r740",
+		"(eq,model)",
+		"(like,model,r740)",
+		"(eq,model,r740,r650)",
+		"(eq,,r740)",
+		"(eq,model,'r740)",
+		"(eq,model,r740));(eq,id,node-1",
+	} {
+		if _, err := Parse(invalid); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	testcases := []struct {
+		filter   string
+		expected []string
+	}{
+		{filter: "", expected: []string{"node-1", "node-2", "node-3"}},
+		{filter: "(eq,model,r740)", expected: []string{"node-1"}},
+		{filter: "(neq,model,r740)", expected: []string{"node-2", "node-3"}},
+		{filter: "(in,id,node-1,node-3)", expected: []string{"node-1", "node-3"}},
+		{filter: "(nin,id,node-1,node-3)", expected: []string{"node-2"}},
+		{filter: "(cont,model,r74)", expected: []string{"node-1", "node-3"}},
+		{filter: "(ncont,model,xd)", expected: []string{"node-1", "node-2"}},
+		{filter: "(gt,memory,256)", expected: []string{"node-2", "node-3"}},
+		{filter: "(lte,memory,512)", expected: []string{"node-1", "node-2"}},
+		{filter: "(eq,labels/site,site-b)", expected: []string{"node-2"}},
+		{filter: "(neq,labels/site,site-b)", expected: []string{"node-1", "node-3"}},
+		{filter: "(eq,tags,gpu)", expected: []string{"node-1"}},
+		{filter: "(cont,model,r7);(gte,memory,1024)", expected: []string{"node-3"}},
+		{filter: "(eq,unknown,value)", expected: []string{}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.filter, func(t *testing.T) {
+			expression, err := Parse(tc.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			items, err := Apply(expression, testItems)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids := []string{}
+			for _, item := range items {
+				ids = append(ids, item.Id)
+			}
+			if !reflect.DeepEqual(ids, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, ids)
+			}
+		})
+	}
+}
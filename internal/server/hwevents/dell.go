@@ -0,0 +1,180 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwevents
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// DellNotificationPath is the path the resource subscriptions of a Dell hardware manager post to, followed by the
+// namespace and name of the HardwareManager
+const DellNotificationPath = "/hardware-events/dell/"
+
+// notificationTokenKey is the key of the notification auth secret holding the token
+const notificationTokenKey = "token"
+
+// NotificationAnnotation is set on a NodePool to the time of the latest notification of its hardware manager about it
+const NotificationAnnotation = "hwmgr-plugin.oran.openshift.io/last-notification"
+
+// DellNotification is a notification sent by the hardware manager for a subscribed resource. Only the fields used for
+// routing and logging are decoded.
+type DellNotification struct {
+	SubscriptionId string `json:"SubscriptionId,omitempty"`
+	EventType      string `json:"EventType,omitempty"`
+	ResourceId     string `json:"ResourceId,omitempty"`
+	JobId          string `json:"JobId,omitempty"`
+}
+
+// handleDellNotification processes a notification posted by a Dell hardware manager, triggering a reconcile of the
+// NodePools it concerns so that their progress is checked without waiting for the next poll
+func (l *Listener) handleDellNotification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	key := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("hwmgr")}
+
+	hwmgr := &pluginv1alpha1.HardwareManager{}
+	if err := l.Client.Get(ctx, key, hwmgr); err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(w, "hardware manager not found", http.StatusNotFound)
+			return
+		}
+		l.Logger.ErrorContext(ctx, "Failed to get hardware manager for notification", slog.String("hwmgr", key.String()),
+			slog.String("error", err.Error()))
+		http.Error(w, "failed to get hardware manager", http.StatusInternalServerError)
+		return
+	}
+
+	if hwmgr.Spec.DellData == nil || hwmgr.Spec.DellData.Notifications == nil {
+		http.Error(w, "notifications are not enabled for the hardware manager", http.StatusNotFound)
+		return
+	}
+
+	if err := l.authorizeDellNotification(ctx, hwmgr, r); err != nil {
+		l.Logger.WarnContext(ctx, "Rejected hardware manager notification", slog.String("hwmgr", key.String()),
+			slog.String("error", err.Error()))
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var notification DellNotification
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEventSize)).Decode(&notification); err != nil {
+		http.Error(w, fmt.Sprintf("invalid notification: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	hardwareManagerNotifications.WithLabelValues(hwmgr.Name).Inc()
+
+	nodepools := &hwmgmtv1alpha1.NodePoolList{}
+	if err := l.Client.List(ctx, nodepools, client.InNamespace(hwmgr.Namespace)); err != nil {
+		l.Logger.ErrorContext(ctx, "Failed to list NodePools for notification", slog.String("hwmgr", key.String()),
+			slog.String("error", err.Error()))
+		http.Error(w, "failed to list nodepools", http.StatusInternalServerError)
+		return
+	}
+	nodes := &hwmgmtv1alpha1.NodeList{}
+	if err := l.Client.List(ctx, nodes, client.InNamespace(hwmgr.Namespace)); err != nil {
+		l.Logger.ErrorContext(ctx, "Failed to list Nodes for notification", slog.String("hwmgr", key.String()),
+			slog.String("error", err.Error()))
+		http.Error(w, "failed to list nodes", http.StatusInternalServerError)
+		return
+	}
+
+	notified := notifiedNodePools(hwmgr.Name, notification, nodepools, nodes)
+	l.Logger.InfoContext(ctx, "Received hardware manager notification", slog.String("hwmgr", key.String()),
+		slog.String("eventType", notification.EventType), slog.String("resourceId", notification.ResourceId),
+		slog.String("jobId", notification.JobId), slog.Int("nodepools", len(notified)))
+
+	for _, nodepool := range notified {
+		if err := l.notifyNodePool(ctx, nodepool); err != nil {
+			l.Logger.ErrorContext(ctx, "Failed to notify NodePool", slog.String("nodepool", nodepool.Name),
+				slog.String("error", err.Error()))
+			http.Error(w, "failed to notify nodepool", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeDellNotification checks the bearer token of the notification against the auth secret. Notifications are
+// refused if no auth secret is set.
+func (l *Listener) authorizeDellNotification(ctx context.Context, hwmgr *pluginv1alpha1.HardwareManager, r *http.Request) error {
+	secretName := hwmgr.Spec.DellData.Notifications.AuthSecret
+	if secretName == "" {
+		return fmt.Errorf("no notification auth secret is set")
+	}
+	secret, err := utils.GetSecret(ctx, l.Client, secretName, hwmgr.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get notification secret: %w", err)
+	}
+	token, err := utils.GetSecretField(secret, notificationTokenKey)
+	if err != nil {
+		return fmt.Errorf("failed to get %s from notification secret: %s, %w", notificationTokenKey, secretName, err)
+	}
+
+	presented, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+		return fmt.Errorf("invalid bearer token")
+	}
+	return nil
+}
+
+// notifyNodePool triggers a reconcile of the NodePool by setting its notification annotation. As the NodePool
+// controller only runs on the leader, while any replica may receive the notification, the reconcile is triggered
+// through the API server rather than within the replica.
+func (l *Listener) notifyNodePool(ctx context.Context, nodepool *hwmgmtv1alpha1.NodePool) error {
+	patch := client.MergeFrom(nodepool.DeepCopy())
+	annotations := nodepool.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[NotificationAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+	nodepool.SetAnnotations(annotations)
+	if err := l.Client.Patch(ctx, nodepool, patch); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to annotate NodePool %s: %w", nodepool.Name, err)
+	}
+	return nil
+}
+
+// notifiedNodePools returns the NodePools of the hardware manager to reconcile for a notification: the NodePool of the
+// resource the notification is about, if it is allocated, or else all the NodePools of the hardware manager, as the
+// notification may be about any of their jobs
+func notifiedNodePools(hwmgrName string, notification DellNotification, nodepools *hwmgmtv1alpha1.NodePoolList,
+	nodes *hwmgmtv1alpha1.NodeList) []*hwmgmtv1alpha1.NodePool {
+	owner := ""
+	if notification.ResourceId != "" {
+		for _, node := range nodes.Items {
+			if node.Spec.HwMgrId == hwmgrName && node.Spec.HwMgrNodeId == notification.ResourceId {
+				owner = node.Spec.NodePool
+				break
+			}
+		}
+	}
+
+	var notified []*hwmgmtv1alpha1.NodePool
+	for i := range nodepools.Items {
+		nodepool := &nodepools.Items[i]
+		if nodepool.Spec.HwMgrId != hwmgrName || (owner != "" && nodepool.Name != owner) {
+			continue
+		}
+		notified = append(notified, nodepool)
+	}
+	return notified
+}
@@ -0,0 +1,111 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwevents
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pluginv1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func testNodePool(name, hwmgrId string, conditions ...metav1.Condition) hwmgmtv1alpha1.NodePool {
+	return hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       hwmgmtv1alpha1.NodePoolSpec{HwMgrId: hwmgrId},
+		Status:     hwmgmtv1alpha1.NodePoolStatus{Conditions: conditions},
+	}
+}
+
+func TestNotifiedNodePools(t *testing.T) {
+	provisioned := metav1.Condition{Type: string(hwmgmtv1alpha1.Provisioned), Status: metav1.ConditionTrue,
+		Reason: string(hwmgmtv1alpha1.Completed)}
+	inProgress := metav1.Condition{Type: string(hwmgmtv1alpha1.Provisioned), Status: metav1.ConditionFalse,
+		Reason: string(hwmgmtv1alpha1.InProgress)}
+
+	nodepools := &hwmgmtv1alpha1.NodePoolList{Items: []hwmgmtv1alpha1.NodePool{
+		testNodePool("new", "dell-1"),
+		testNodePool("in-progress", "dell-1", inProgress),
+		testNodePool("provisioned", "dell-1", provisioned),
+		testNodePool("other", "dell-2", inProgress),
+	}}
+	nodes := &hwmgmtv1alpha1.NodeList{Items: []hwmgmtv1alpha1.Node{
+		{Spec: hwmgmtv1alpha1.NodeSpec{NodePool: "provisioned", HwMgrId: "dell-1", HwMgrNodeId: "resource-1"}},
+		{Spec: hwmgmtv1alpha1.NodeSpec{NodePool: "other", HwMgrId: "dell-2", HwMgrNodeId: "resource-2"}},
+	}}
+
+	tests := []struct {
+		name         string
+		notification DellNotification
+		expected     []string
+	}{
+		{name: "job notification", notification: DellNotification{JobId: "job-1"},
+			expected: []string{"new", "in-progress", "provisioned"}},
+		{name: "allocated resource", notification: DellNotification{ResourceId: "resource-1"},
+			expected: []string{"provisioned"}},
+		{name: "resource of another hardware manager", notification: DellNotification{ResourceId: "resource-2"},
+			expected: []string{"new", "in-progress", "provisioned"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, nodepool := range notifiedNodePools("dell-1", tt.notification, nodepools, nodes) {
+				names = append(names, nodepool.Name)
+			}
+			if len(names) != len(tt.expected) {
+				t.Fatalf("expected notified nodepools %v, got %v", tt.expected, names)
+			}
+			for i := range tt.expected {
+				if names[i] != tt.expected[i] {
+					t.Errorf("expected notified nodepools %v, got %v", tt.expected, names)
+					break
+				}
+			}
+		})
+	}
+}
+
+// patchRecorder records the objects patched through it
+type patchRecorder struct {
+	client.Client
+	patched []client.Object
+}
+
+func (c *patchRecorder) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.patched = append(c.patched, obj)
+	return nil
+}
+
+func TestNotifyNodePool(t *testing.T) {
+	recorder := &patchRecorder{}
+	l := &Listener{Client: recorder, Logger: slog.Default()}
+	nodepool := testNodePool("np-1", "dell-1")
+
+	if err := l.notifyNodePool(context.Background(), &nodepool); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorder.patched) != 1 || recorder.patched[0].GetAnnotations()[NotificationAnnotation] == "" {
+		t.Errorf("expected the nodepool to be annotated with the notification time, got %v", recorder.patched)
+	}
+}
+
+func TestAuthorizeDellNotificationRequiresSecret(t *testing.T) {
+	l := &Listener{Logger: slog.Default()}
+	hwmgr := &pluginv1alpha1.HardwareManager{Spec: pluginv1alpha1.HardwareManagerSpec{
+		DellData: &pluginv1alpha1.DellData{Notifications: &pluginv1alpha1.NotificationConfig{SubscriptionId: "sub-1"}},
+	}}
+	req := httptest.NewRequest("POST", DellNotificationPath+"ns/dell-1", nil)
+	if err := l.authorizeDellNotification(context.Background(), hwmgr, req); err == nil {
+		t.Errorf("expected a notification to be refused without an auth secret")
+	}
+}
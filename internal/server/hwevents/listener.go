@@ -0,0 +1,352 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwevents
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+// RedfishEventPath is the path BMC event subscriptions post to, followed by the namespace and name of the Node
+const RedfishEventPath = "/hardware-events/redfish/"
+
+// HardwareHealthyCondition is the Node condition set from the alerts reported by its BMC
+const HardwareHealthyCondition = "HardwareHealthy"
+
+// HardwareHealthyCondition reasons
+const (
+	ReasonHealthy          = "Healthy"
+	ReasonHardwareWarning  = "HardwareWarning"
+	ReasonHardwareCritical = "HardwareCritical"
+)
+
+// AlarmType identifies hardware alert notifications sent to inventory subscribers
+const AlarmType = "HardwareAlert"
+
+// Listener config values
+const (
+	maxEventSize = 1 << 20
+	readTimeout  = 5 * time.Second
+	writeTimeout = 10 * time.Second
+	idleTimeout  = 120 * time.Second
+)
+
+// Listener receives out-of-band hardware alerts pushed by the BMCs of allocated nodes through Redfish EventService
+// subscriptions, catching failures such as disk, power supply or fan failures between polling intervals. Each event
+// sets the HardwareHealthy condition of the Node, and alerts other than OK are queued as alarms to the subscribers of
+// the Node's hardware manager. It also receives the notifications of the hardware managers with resource
+// subscriptions, which trigger a reconcile of the NodePools they concern.
+//
+// The listener only serves TLS. Each Redfish event must carry the event token of its Node, derived from the shared
+// EventSecret, and come from the address of the BMC of the Node.
+type Listener struct {
+	Client            client.Client
+	Logger            *slog.Logger
+	Address           string
+	TLSCertDir        string
+	EventSecret       []byte
+	SubscriptionStore subscriptions.Store
+	// LookupIP resolves the BMC hostnames. The default resolver is used if nil.
+	LookupIP func(ctx context.Context, host string) ([]net.IP, error)
+}
+
+// NewListener creates a Listener
+func NewListener(c client.Client, logger *slog.Logger, address, tlsCertDir string, eventSecret []byte,
+	store subscriptions.Store) *Listener {
+	return &Listener{
+		Client:            c,
+		Logger:            logger.With(slog.String("module", "hwevents")),
+		Address:           address,
+		TLSCertDir:        tlsCertDir,
+		EventSecret:       eventSecret,
+		SubscriptionStore: store,
+	}
+}
+
+// RedfishEventToken returns the bearer token the BMC of a Node presents with its events: the hex-encoded HMAC-SHA256
+// of the namespace and name of the Node, keyed by the shared event secret
+func RedfishEventToken(secret []byte, namespace, node string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(namespace + "/" + node))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NeedLeaderElection returns false, as the BMCs and hardware managers post to the service and any replica may receive
+// an event
+func (l *Listener) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the HTTP handler of the listener
+func (l *Listener) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+RedfishEventPath+"{namespace}/{node}", l.handleRedfishEvent)
+	mux.HandleFunc("POST "+DellNotificationPath+"{namespace}/{hwmgr}", l.handleDellNotification)
+	return mux
+}
+
+// Start runs the listener until the context is cancelled
+func (l *Listener) Start(ctx context.Context) error {
+	if l.TLSCertDir == "" {
+		return fmt.Errorf("hardware event listener requires a TLS certificate")
+	}
+	if len(l.EventSecret) == 0 {
+		return fmt.Errorf("hardware event listener requires an event secret")
+	}
+
+	tlsConfig, err := utils.GetServerTLSConfig(ctx,
+		filepath.Join(l.TLSCertDir, "tls.crt"), filepath.Join(l.TLSCertDir, "tls.key"))
+	if err != nil {
+		return fmt.Errorf("failed to get hardware event listener TLS config: %w", err)
+	}
+	srv := &http.Server{
+		Handler:      l.Handler(),
+		Addr:         l.Address,
+		TLSConfig:    tlsConfig,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  idleTimeout,
+	}
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		l.Logger.InfoContext(ctx, "Hardware event listener listening", slog.String("address", l.Address))
+		if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErrors:
+		return fmt.Errorf("error starting hardware event listener: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down hardware event listener: %w", err)
+	}
+	return nil
+}
+
+// handleRedfishEvent processes an event posted by the BMC of a node
+func (l *Listener) handleRedfishEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	key := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("node")}
+
+	if !l.isEventAuthorized(key, r) {
+		l.Logger.WarnContext(ctx, "Rejected unauthenticated hardware event", slog.String("node", key.String()),
+			slog.String("source", r.RemoteAddr))
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var event RedfishEvent
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEventSize)).Decode(&event); err != nil {
+		http.Error(w, fmt.Sprintf("invalid event: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	node := &hwmgmtv1alpha1.Node{}
+	if err := l.Client.Get(ctx, key, node); err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(w, "node not found", http.StatusNotFound)
+			return
+		}
+		l.Logger.ErrorContext(ctx, "Failed to get node for hardware event", slog.String("node", key.String()),
+			slog.String("error", err.Error()))
+		http.Error(w, "failed to get node", http.StatusInternalServerError)
+		return
+	}
+
+	if !isSourceBMC(ctx, node, r.RemoteAddr, l.lookupIP) {
+		l.Logger.WarnContext(ctx, "Rejected hardware event from unexpected source", slog.String("node", key.String()),
+			slog.String("source", r.RemoteAddr))
+		http.Error(w, "source does not match node BMC", http.StatusForbidden)
+		return
+	}
+
+	// Events for a released node are acknowledged, so that the BMC does not keep retrying them
+	if node.Spec.NodePool == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	alerts := make([]Alert, 0, len(event.Events))
+	for _, record := range event.Events {
+		alert := record.ToAlert()
+		hardwareAlerts.WithLabelValues(alert.Severity, alert.Component).Inc()
+		alerts = append(alerts, alert)
+	}
+
+	if err := l.processAlerts(ctx, node, alerts); err != nil {
+		l.Logger.ErrorContext(ctx, "Failed to process hardware alerts", slog.String("node", key.String()),
+			slog.String("error", err.Error()))
+		http.Error(w, "failed to process event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// processAlerts sets the health condition of the node from the most severe alert, and raises alarms for the others
+// than OK
+func (l *Listener) processAlerts(ctx context.Context, node *hwmgmtv1alpha1.Node, alerts []Alert) error {
+	worst, found := WorstAlert(alerts)
+	if !found {
+		return nil
+	}
+
+	status, reason := metav1.ConditionTrue, ReasonHealthy
+	switch worst.Severity {
+	case SeverityCritical:
+		status, reason = metav1.ConditionFalse, ReasonHardwareCritical
+	case SeverityWarning:
+		status, reason = metav1.ConditionFalse, ReasonHardwareWarning
+	}
+
+	l.Logger.InfoContext(ctx, "Received hardware alerts", slog.String("node", node.Name),
+		slog.Int("alerts", len(alerts)), slog.String("worst", worst.String()))
+
+	if err := utils.SetNodeConditionStatus(ctx, l.Client, node.Name, node.Namespace,
+		HardwareHealthyCondition, status, reason, worst.String()); err != nil {
+		return fmt.Errorf("failed to set %s condition: %w", HardwareHealthyCondition, err)
+	}
+
+	for _, alert := range alerts {
+		if alert.Severity == SeverityOK {
+			continue
+		}
+		if err := l.raiseAlarm(ctx, node, alert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// raiseAlarm queues an alarm notification for the alert to each subscriber of the node's hardware manager
+func (l *Listener) raiseAlarm(ctx context.Context, node *hwmgmtv1alpha1.Node, alert Alert) error {
+	if l.SubscriptionStore == nil || node.Spec.HwMgrId == "" {
+		return nil
+	}
+
+	subs, err := l.SubscriptionStore.ListSubscriptions(ctx, node.Spec.HwMgrId)
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions for %s: %w", node.Spec.HwMgrId, err)
+	}
+
+	for _, sub := range subs {
+		if sub.SubscriptionId == nil {
+			continue
+		}
+		notification := newAlarmNotification(node, alert)
+		notification.ConsumerSubscriptionId = sub.ConsumerSubscriptionId
+		if err := l.SubscriptionStore.EnqueueNotification(ctx, node.Spec.HwMgrId, *sub.SubscriptionId, notification); err != nil {
+			return fmt.Errorf("failed to queue alarm for subscription %s: %w", sub.SubscriptionId.String(), err)
+		}
+	}
+	return nil
+}
+
+// newAlarmNotification builds the alarm notification for an alert, referencing the node's inventory resource
+func newAlarmNotification(node *hwmgmtv1alpha1.Node, alert Alert) subscriptions.Notification {
+	notification := subscriptions.Notification{
+		NotificationId:        uuid.New(),
+		NotificationEventType: subscriptions.NotificationEventModify,
+		Object: map[string]any{
+			"alarmType": AlarmType,
+			"node":      node.Name,
+			"severity":  alert.Severity,
+			"component": alert.Component,
+			"message":   alert.Message,
+			"messageId": alert.MessageId,
+			"origin":    alert.Origin,
+			"timestamp": alert.Timestamp,
+		},
+	}
+	if node.Spec.HwMgrNodeId != "" {
+		notification.ObjectRef = fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resources/%s",
+			node.Spec.HwMgrId, node.Spec.HwMgrNodeId)
+	}
+	return notification
+}
+
+// isEventAuthorized checks that an event carries the event token of its Node
+func (l *Listener) isEventAuthorized(key types.NamespacedName, r *http.Request) bool {
+	if len(l.EventSecret) == 0 {
+		return false
+	}
+	presented, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	expected := RedfishEventToken(l.EventSecret, key.Namespace, key.Name)
+	return found && hmac.Equal([]byte(presented), []byte(expected))
+}
+
+// lookupIP resolves a BMC hostname
+func (l *Listener) lookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	if l.LookupIP != nil {
+		return l.LookupIP(ctx, host)
+	}
+	return net.DefaultResolver.LookupIP(ctx, "ip", host)
+}
+
+// isSourceBMC checks that an event comes from the BMC of the node, resolving a BMC address given as a hostname. Events
+// are rejected for a node without a valid BMC address, or whose BMC hostname cannot be resolved.
+func isSourceBMC(ctx context.Context, node *hwmgmtv1alpha1.Node, remoteAddr string,
+	lookupIP func(ctx context.Context, host string) ([]net.IP, error)) bool {
+	if node.Status.BMC == nil || node.Status.BMC.Address == "" {
+		return false
+	}
+	bmcURL, err := url.Parse(node.Status.BMC.Address)
+	if err != nil || bmcURL.Hostname() == "" {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	sourceIP := net.ParseIP(host)
+	if sourceIP == nil {
+		return false
+	}
+
+	bmcIPs := []net.IP{net.ParseIP(bmcURL.Hostname())}
+	if bmcIPs[0] == nil {
+		if bmcIPs, err = lookupIP(ctx, bmcURL.Hostname()); err != nil {
+			return false
+		}
+	}
+	for _, bmcIP := range bmcIPs {
+		if sourceIP.Equal(bmcIP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwevents
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var hardwareAlerts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "hwmgr_plugin_hardware_alerts_total",
+	Help: "Number of hardware alerts received from node BMCs",
+}, []string{"severity", "component"})
+
+var hardwareManagerNotifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "hwmgr_plugin_hardware_manager_notifications_total",
+	Help: "Number of notifications received from hardware manager resource subscriptions",
+}, []string{"hwmgr"})
+
+func init() {
+	metrics.Registry.MustRegister(hardwareAlerts, hardwareManagerNotifications)
+}
@@ -0,0 +1,153 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwevents
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Redfish event severities, as reported in the MessageSeverity (or deprecated Severity) property of an event record
+const (
+	SeverityOK       = "OK"
+	SeverityWarning  = "Warning"
+	SeverityCritical = "Critical"
+)
+
+// Hardware components that alerts are classified under
+const (
+	ComponentDisk  = "disk"
+	ComponentPSU   = "psu"
+	ComponentFan   = "fan"
+	ComponentOther = "other"
+)
+
+// RedfishEvent is the payload posted by a BMC to the destination of a Redfish EventService subscription
+type RedfishEvent struct {
+	Context string               `json:"Context,omitempty"`
+	Events  []RedfishEventRecord `json:"Events"`
+}
+
+// RedfishEventRecord is a single event reported by a BMC
+type RedfishEventRecord struct {
+	EventType         string          `json:"EventType,omitempty"`
+	EventId           string          `json:"EventId,omitempty"`
+	EventTimestamp    string          `json:"EventTimestamp,omitempty"`
+	Severity          string          `json:"Severity,omitempty"`
+	MessageSeverity   string          `json:"MessageSeverity,omitempty"`
+	Message           string          `json:"Message,omitempty"`
+	MessageId         string          `json:"MessageId,omitempty"`
+	OriginOfCondition *RedfishODataId `json:"OriginOfCondition,omitempty"`
+}
+
+// RedfishODataId is a reference to a Redfish resource
+type RedfishODataId struct {
+	ODataId string `json:"@odata.id"`
+}
+
+// Alert is a hardware alert derived from a Redfish event record
+type Alert struct {
+	Severity  string `json:"severity"`
+	Component string `json:"component"`
+	Message   string `json:"message,omitempty"`
+	MessageId string `json:"messageId,omitempty"`
+	Origin    string `json:"origin,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+// componentKeywords maps keywords of the origin resource path or message ID to the component they identify, checked in
+// order
+var componentKeywords = []struct {
+	component string
+	keywords  []string
+}{
+	{ComponentDisk, []string{"drive", "disk", "storage", "volume", "raid"}},
+	{ComponentPSU, []string{"powersuppl", "psu", "power"}},
+	{ComponentFan, []string{"fan", "thermal", "cooling"}},
+}
+
+// severity normalizes the severity of the record, preferring MessageSeverity. Unknown severities are treated as
+// warnings, so that they are not dropped.
+func (r *RedfishEventRecord) severity() string {
+	value := r.MessageSeverity
+	if value == "" {
+		value = r.Severity
+	}
+	switch {
+	case strings.EqualFold(value, SeverityOK):
+		return SeverityOK
+	case strings.EqualFold(value, SeverityCritical):
+		return SeverityCritical
+	default:
+		return SeverityWarning
+	}
+}
+
+// component classifies the record by the hardware component it relates to
+func (r *RedfishEventRecord) component() string {
+	text := strings.ToLower(r.MessageId)
+	if r.OriginOfCondition != nil {
+		text = strings.ToLower(r.OriginOfCondition.ODataId) + " " + text
+	}
+	for _, entry := range componentKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(text, keyword) {
+				return entry.component
+			}
+		}
+	}
+	return ComponentOther
+}
+
+// ToAlert converts the event record to an alert
+func (r *RedfishEventRecord) ToAlert() Alert {
+	alert := Alert{
+		Severity:  r.severity(),
+		Component: r.component(),
+		Message:   r.Message,
+		MessageId: r.MessageId,
+		Timestamp: r.EventTimestamp,
+	}
+	if r.OriginOfCondition != nil {
+		alert.Origin = r.OriginOfCondition.ODataId
+	}
+	return alert
+}
+
+// String describes the alert for a condition message
+func (a Alert) String() string {
+	if a.MessageId != "" {
+		return fmt.Sprintf("%s %s: %s (%s)", a.Severity, a.Component, a.Message, a.MessageId)
+	}
+	return fmt.Sprintf("%s %s: %s", a.Severity, a.Component, a.Message)
+}
+
+// severityRank orders the severities, so that the worst alert of an event sets the node health
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// WorstAlert returns the most severe of the alerts, the latest one winning a tie
+func WorstAlert(alerts []Alert) (Alert, bool) {
+	if len(alerts) == 0 {
+		return Alert{}, false
+	}
+	worst := alerts[0]
+	for _, alert := range alerts[1:] {
+		if severityRank(alert.Severity) >= severityRank(worst.Severity) {
+			worst = alert
+		}
+	}
+	return worst, true
+}
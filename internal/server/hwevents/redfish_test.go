@@ -0,0 +1,161 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hwevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+)
+
+func TestRedfishEventToAlerts(t *testing.T) {
+	payload := `{
+		"Context": "node-1",
+		"Events": [
+			{"EventType": "Alert", "MessageSeverity": "Critical", "Message": "Drive 0 failed",
+			 "MessageId": "StorageDevice.1.0.DriveFailure",
+			 "OriginOfCondition": {"@odata.id": "/redfish/v1/Systems/1/Storage/RAID.1/Drives/Disk.0"}},
+			{"EventType": "Alert", "Severity": "warning", "Message": "PSU redundancy lost",
+			 "MessageId": "PSU0003", "OriginOfCondition": {"@odata.id": "/redfish/v1/Chassis/1/PowerSubsystem/PowerSupplies/0"}},
+			{"EventType": "Alert", "MessageSeverity": "OK", "Message": "Fan 2 speed normal", "MessageId": "FAN0000"},
+			{"EventType": "Alert", "MessageSeverity": "Bogus", "Message": "Something", "MessageId": "SYS1003"}
+		]
+	}`
+
+	var event RedfishEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatalf("unexpected error parsing event: %v", err)
+	}
+
+	expected := []Alert{
+		{Severity: SeverityCritical, Component: ComponentDisk},
+		{Severity: SeverityWarning, Component: ComponentPSU},
+		{Severity: SeverityOK, Component: ComponentFan},
+		{Severity: SeverityWarning, Component: ComponentOther},
+	}
+	if len(event.Events) != len(expected) {
+		t.Fatalf("expected %d records, got %d", len(expected), len(event.Events))
+	}
+
+	alerts := make([]Alert, 0, len(event.Events))
+	for i, record := range event.Events {
+		alert := record.ToAlert()
+		if alert.Severity != expected[i].Severity || alert.Component != expected[i].Component {
+			t.Errorf("record %d: expected %s %s, got %s %s", i, expected[i].Severity, expected[i].Component,
+				alert.Severity, alert.Component)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	worst, found := WorstAlert(alerts)
+	if !found || worst.Severity != SeverityCritical || worst.Origin != "/redfish/v1/Systems/1/Storage/RAID.1/Drives/Disk.0" {
+		t.Errorf("expected the critical disk alert to be the worst, got %v", worst)
+	}
+	if _, found := WorstAlert(nil); found {
+		t.Errorf("expected no worst alert for no alerts")
+	}
+}
+
+func TestIsSourceBMC(t *testing.T) {
+	lookupIP := func(_ context.Context, host string) ([]net.IP, error) {
+		if host == "bmc-1.example.com" {
+			return []net.IP{net.ParseIP("10.0.0.2")}, nil
+		}
+		return nil, fmt.Errorf("no such host %s", host)
+	}
+
+	tests := []struct {
+		address string
+		source  string
+		allowed bool
+	}{
+		{"", "10.0.0.9:4321", false},
+		{"redfish-virtualmedia+https://10.0.0.1/redfish/v1/Systems/1", "10.0.0.1:4321", true},
+		{"redfish-virtualmedia+https://10.0.0.1/redfish/v1/Systems/1", "10.0.0.9:4321", false},
+		{"idrac-virtualmedia://[fd00::1]/redfish/v1/Systems/System.Embedded.1", "[fd00::1]:4321", true},
+		{"https://bmc-1.example.com/redfish/v1/Systems/1", "10.0.0.2:4321", true},
+		{"https://bmc-1.example.com/redfish/v1/Systems/1", "10.0.0.9:4321", false},
+		{"https://bmc-2.example.com/redfish/v1/Systems/1", "10.0.0.2:4321", false},
+		{"://invalid", "10.0.0.1:4321", false},
+	}
+
+	for _, test := range tests {
+		node := &hwmgmtv1alpha1.Node{}
+		if test.address != "" {
+			node.Status.BMC = &hwmgmtv1alpha1.BMC{Address: test.address}
+		}
+		if allowed := isSourceBMC(context.Background(), node, test.source, lookupIP); allowed != test.allowed {
+			t.Errorf("%q from %s: expected %t, got %t", test.address, test.source, test.allowed, allowed)
+		}
+	}
+}
+
+func TestIsEventAuthorized(t *testing.T) {
+	l := &Listener{EventSecret: []byte("secret")}
+	key := types.NamespacedName{Namespace: "oran-hwmgr-plugin", Name: "node-1"}
+
+	request := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, RedfishEventPath+key.Namespace+"/"+key.Name, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r
+	}
+
+	if !l.isEventAuthorized(key, request(RedfishEventToken([]byte("secret"), key.Namespace, key.Name))) {
+		t.Errorf("expected the token of the node to be accepted")
+	}
+	if l.isEventAuthorized(key, request("")) {
+		t.Errorf("expected an event without a token to be rejected")
+	}
+	if l.isEventAuthorized(key, request(RedfishEventToken([]byte("secret"), key.Namespace, "node-2"))) {
+		t.Errorf("expected the token of another node to be rejected")
+	}
+	if l.isEventAuthorized(key, request(RedfishEventToken([]byte("other"), key.Namespace, key.Name))) {
+		t.Errorf("expected a token from another secret to be rejected")
+	}
+
+	l.EventSecret = nil
+	if l.isEventAuthorized(key, request(RedfishEventToken(nil, key.Namespace, key.Name))) {
+		t.Errorf("expected events to be rejected without an event secret")
+	}
+}
+
+func TestStartRequiresTLSAndSecret(t *testing.T) {
+	l := &Listener{Logger: slog.Default(), EventSecret: []byte("secret")}
+	if err := l.Start(context.Background()); err == nil {
+		t.Errorf("expected an error without a TLS certificate")
+	}
+	l = &Listener{Logger: slog.Default(), TLSCertDir: t.TempDir()}
+	if err := l.Start(context.Background()); err == nil {
+		t.Errorf("expected an error without an event secret")
+	}
+}
+
+func TestNewAlarmNotification(t *testing.T) {
+	node := &hwmgmtv1alpha1.Node{}
+	node.Name = "node-1"
+	node.Spec.HwMgrId = "hwmgr-1"
+	node.Spec.HwMgrNodeId = "server-1"
+
+	notification := newAlarmNotification(node, Alert{Severity: SeverityCritical, Component: ComponentFan})
+	if notification.ObjectRef != "/hardware-manager/inventory/v1/manager/hwmgr-1/resources/server-1" {
+		t.Errorf("unexpected object reference: %s", notification.ObjectRef)
+	}
+	if notification.Object["alarmType"] != AlarmType || notification.Object["severity"] != SeverityCritical {
+		t.Errorf("unexpected alarm object: %v", notification.Object)
+	}
+}
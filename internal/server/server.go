@@ -20,9 +20,11 @@ import (
 
 	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/features"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
 	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/auth"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/subscriptions"
 )
 
 // Server config values
@@ -30,10 +32,15 @@ const (
 	readTimeout  = 5 * time.Second
 	writeTimeout = 10 * time.Second
 	idleTimeout  = 120 * time.Second
+
+	// Responses smaller than this are sent uncompressed, as gzip gains little on them
+	compressionMinSize = 1024
 )
 
 // RunServer starts the API server and blocks until it terminates or context is canceled.
-func RunServer(ctx context.Context, address, tlsCertDir string, hwMgrAdaptor *adaptors.HwMgrAdaptorController) error {
+func RunServer(ctx context.Context, address, tlsCertDir string, hwMgrAdaptor *adaptors.HwMgrAdaptorController,
+	subscriptionStore subscriptions.Store, callbackPolicy *subscriptions.CallbackPolicy, accessLog *api.AccessLog,
+	rateLimiter *api.ClientRateLimiter) error {
 	slog.InfoContext(ctx, "Starting inventory API server")
 	// Channel for shutdown signals
 	shutdown := make(chan os.Signal, 1)
@@ -51,7 +58,9 @@ func RunServer(ctx context.Context, address, tlsCertDir string, hwMgrAdaptor *ad
 	// Init server
 	// Create the handler
 	server := api.InventoryServer{
-		HwMgrAdaptor: hwMgrAdaptor,
+		HwMgrAdaptor:      hwMgrAdaptor,
+		SubscriptionStore: subscriptionStore,
+		CallbackPolicy:    callbackPolicy,
 	}
 
 	serverStrictHandler := generated.NewStrictHandlerWithOptions(&server, nil,
@@ -82,14 +91,38 @@ func RunServer(ctx context.Context, address, tlsCertDir string, hwMgrAdaptor *ad
 		return fmt.Errorf("error setting up authorizer middleware: %w", err)
 	}
 
+	middlewares := []generated.MiddlewareFunc{ // Add middlewares here
+		api.GetStaleResponseFunc(),
+		api.GetOpenAPIValidationFunc(swagger),
+		authz,
+	}
+	// Clients limited by subject are identified once authenticated, and clients limited by address before, so that
+	// their excess requests are not sent to the kube-apiserver for token review
+	if rateLimiter != nil && rateLimiter.Key() == api.ClientRateLimitKeySubject {
+		middlewares = append(middlewares, api.GetClientRateLimitFunc(rateLimiter))
+	}
+	middlewares = append(middlewares, authn)
+	if rateLimiter != nil && rateLimiter.Key() == api.ClientRateLimitKeyAddress {
+		middlewares = append(middlewares, api.GetClientRateLimitFunc(rateLimiter))
+	}
+	middlewares = append(middlewares,
+		api.GetCompressionFunc(compressionMinSize),
+		api.GetLogDurationFunc(),
+	)
+	if features.Gate.Enabled(features.FailureInjection) {
+		// Injected after authorization, so that only authorized clients can request faults, and before compression,
+		// so that truncation applies to the uncompressed body
+		slog.WarnContext(ctx, "Failure injection is enabled on the inventory API server")
+		middlewares = append([]generated.MiddlewareFunc{api.GetFailureInjectionFunc()}, middlewares...)
+	}
+	if accessLog != nil {
+		// Applied last, so that it wraps all other middlewares and records the requests they reject
+		middlewares = append(middlewares, api.GetAccessLogFunc(accessLog))
+	}
+
 	opt := generated.StdHTTPServerOptions{
-		BaseRouter: router,
-		Middlewares: []generated.MiddlewareFunc{ // Add middlewares here
-			api.GetOpenAPIValidationFunc(swagger),
-			authz,
-			authn,
-			api.GetLogDurationFunc(),
-		},
+		BaseRouter:       router,
+		Middlewares:      middlewares,
 		ErrorHandlerFunc: api.GetRequestErrorFunc(),
 	}
 
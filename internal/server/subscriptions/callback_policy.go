@@ -0,0 +1,171 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// DefaultDeniedCallbackCIDRs are the networks that callbacks may not reach by default: loopback, link-local (which
+// includes cloud metadata services) and unspecified addresses
+var DefaultDeniedCallbackCIDRs = []string{
+	"0.0.0.0/8",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"::/128",
+	"::1/128",
+	"fe80::/10",
+}
+
+// CallbackPolicy restricts the destinations of subscription callbacks, to prevent subscribers from using the plugin to
+// reach internal endpoints. It is checked when a subscription is created, when a notification is delivered, and on
+// each connection made for a delivery, so that a callback host that resolves to a different address later is caught.
+//
+// Denied hosts and networks take precedence. When allowed hosts or networks are set, a destination must match them.
+// Host patterns are either a hostname or a wildcard such as *.example.com, matching any subdomain.
+type CallbackPolicy struct {
+	AllowedSchemes []string
+	AllowedHosts   []string
+	DeniedHosts    []string
+	AllowedCIDRs   []*net.IPNet
+	DeniedCIDRs    []*net.IPNet
+	Resolver       *net.Resolver
+}
+
+// NewCallbackPolicy parses the policy settings
+func NewCallbackPolicy(schemes, allowedHosts, deniedHosts, allowedCIDRs, deniedCIDRs []string) (*CallbackPolicy, error) {
+	policy := &CallbackPolicy{
+		AllowedSchemes: lowerAll(schemes),
+		AllowedHosts:   lowerAll(allowedHosts),
+		DeniedHosts:    lowerAll(deniedHosts),
+		Resolver:       net.DefaultResolver,
+	}
+
+	var err error
+	if policy.AllowedCIDRs, err = parseCIDRs(allowedCIDRs); err != nil {
+		return nil, fmt.Errorf("invalid allowed callback network: %w", err)
+	}
+	if policy.DeniedCIDRs, err = parseCIDRs(deniedCIDRs); err != nil {
+		return nil, fmt.Errorf("invalid denied callback network: %w", err)
+	}
+	return policy, nil
+}
+
+func lowerAll(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func parseCIDRs(values []string) ([]*net.IPNet, error) {
+	var result []*net.IPNet
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", value, err)
+		}
+		result = append(result, network)
+	}
+	return result, nil
+}
+
+// matchesHost checks a hostname against host patterns
+func matchesHost(host string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if suffix, found := strings.CutPrefix(pattern, "*"); found {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func inNetworks(ip net.IP, networks []*net.IPNet) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIP checks that an address may be reached by a callback
+func (p *CallbackPolicy) CheckIP(ip net.IP) error {
+	if inNetworks(ip, p.DeniedCIDRs) {
+		return fmt.Errorf("callback address %s is in a denied network", ip)
+	}
+	if len(p.AllowedCIDRs) > 0 && !inNetworks(ip, p.AllowedCIDRs) {
+		return fmt.Errorf("callback address %s is not in an allowed network", ip)
+	}
+	return nil
+}
+
+// ValidateURL checks that a callback URL is allowed, resolving its host to check the addresses it reaches
+func (p *CallbackPolicy) ValidateURL(ctx context.Context, callback string) error {
+	parsed, err := url.Parse(callback)
+	if err != nil {
+		return fmt.Errorf("invalid callback URL: %w", err)
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if len(p.AllowedSchemes) > 0 && !slices.Contains(p.AllowedSchemes, scheme) {
+		return fmt.Errorf("callback scheme %s is not allowed", parsed.Scheme)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" {
+		return fmt.Errorf("callback URL has no host")
+	}
+	if matchesHost(host, p.DeniedHosts) {
+		return fmt.Errorf("callback host %s is denied", host)
+	}
+	if len(p.AllowedHosts) > 0 && !matchesHost(host, p.AllowedHosts) {
+		return fmt.Errorf("callback host %s is not allowed", host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return p.CheckIP(ip)
+	}
+	addrs, err := p.Resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve callback host %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err := p.CheckIP(addr.IP); err != nil {
+			return fmt.Errorf("callback host %s: %w", host, err)
+		}
+	}
+	return nil
+}
+
+// checkDialAddress checks the address of a connection about to be made for a delivery
+func (p *CallbackPolicy) checkDialAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid callback address %s: %w", address, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid callback address %s", address)
+	}
+	return p.CheckIP(ip)
+}
@@ -0,0 +1,158 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestCallbackPolicyValidateURL(t *testing.T) {
+	policy, err := NewCallbackPolicy(
+		[]string{"https"},
+		nil,
+		[]string{"*.svc.cluster.local", "metadata.internal"},
+		[]string{"10.0.0.0/8", "fd00::/8"},
+		DefaultDeniedCallbackCIDRs)
+	if err != nil {
+		t.Fatalf("unexpected error creating policy: %v", err)
+	}
+
+	tests := []struct {
+		callback string
+		allowed  bool
+	}{
+		{"https://10.1.2.3:8443/notify", true},
+		{"https://[fd00::5]/notify", true},
+		{"http://10.1.2.3/notify", false},
+		{"https://192.168.1.1/notify", false},
+		{"https://127.0.0.1/notify", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://smo.oran.svc.cluster.local/notify", false},
+		{"https://METADATA.internal/notify", false},
+		{"https:///notify", false},
+	}
+	for _, test := range tests {
+		err := policy.ValidateURL(context.Background(), test.callback)
+		if (err == nil) != test.allowed {
+			t.Errorf("%s: expected allowed=%t, got error %v", test.callback, test.allowed, err)
+		}
+	}
+
+	hostPolicy, err := NewCallbackPolicy(nil, []string{"*.example.com", "10.1.2.3"}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating policy: %v", err)
+	}
+	if err := hostPolicy.ValidateURL(context.Background(), "http://10.1.2.3/notify"); err != nil {
+		t.Errorf("expected allowed host, got %v", err)
+	}
+	if err := hostPolicy.ValidateURL(context.Background(), "http://10.1.2.4/notify"); err == nil {
+		t.Errorf("expected host not in allowlist to be rejected")
+	}
+
+	if _, err := NewCallbackPolicy(nil, nil, nil, []string{"10.0.0.0/33"}, nil); err == nil {
+		t.Errorf("expected invalid network to be rejected")
+	}
+}
+
+func TestNotifierCallbackPolicy(t *testing.T) {
+	ctx := context.Background()
+	var delivered atomic.Int32
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer subscriber.Close()
+
+	store := NewMemoryStore()
+	notifier := NewNotifier(store, slog.Default())
+	policy, err := NewCallbackPolicy(nil, nil, nil, nil, DefaultDeniedCallbackCIDRs)
+	if err != nil {
+		t.Fatalf("unexpected error creating policy: %v", err)
+	}
+	notifier.CallbackPolicy = policy
+
+	created, err := store.CreateSubscription(ctx, "hwmgr-1", generated.Subscription{Callback: subscriber.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating subscription: %v", err)
+	}
+	id := *created.SubscriptionId
+	if err := store.EnqueueNotification(ctx, "hwmgr-1", id, Notification{NotificationId: uuid.New()}); err != nil {
+		t.Fatalf("unexpected error enqueuing notification: %v", err)
+	}
+
+	// The subscriber listens on loopback, which the policy denies
+	notifier.DeliverAll(ctx)
+	if delivered.Load() != 0 {
+		t.Errorf("expected no delivery to a denied callback, got %d", delivered.Load())
+	}
+	if pending, _ := store.PendingNotifications(ctx, "hwmgr-1", id); len(pending) != 1 || pending[0].Attempts != 1 {
+		t.Fatalf("expected notification to remain queued with a failed attempt, got %v", pending)
+	}
+
+	// The address of each connection is checked too, catching hosts that resolve differently after validation
+	if err := policy.checkDialAddress("127.0.0.1:8080"); err == nil {
+		t.Errorf("expected connection to a denied address to be rejected")
+	}
+	if err := policy.checkDialAddress("[2001:db8::1]:443"); err != nil {
+		t.Errorf("expected connection to an allowed address, got %v", err)
+	}
+
+	notifier.CallbackPolicy = nil
+	notifier.DeliverAll(ctx)
+	if delivered.Load() != 1 {
+		t.Errorf("expected delivery without a policy, got %d", delivered.Load())
+	}
+}
+
+func TestNotifierRedirectLimit(t *testing.T) {
+	ctx := context.Background()
+	var requests atomic.Int32
+	var subscriber *httptest.Server
+	subscriber = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Redirect(w, r, subscriber.URL+r.URL.Path, http.StatusTemporaryRedirect)
+	}))
+	defer subscriber.Close()
+
+	store := NewMemoryStore()
+	notifier := NewNotifier(store, slog.Default())
+	policy, err := NewCallbackPolicy(nil, nil, nil, []string{"127.0.0.0/8"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating policy: %v", err)
+	}
+
+	created, err := store.CreateSubscription(ctx, "hwmgr-1", generated.Subscription{Callback: subscriber.URL + "/notify"})
+	if err != nil {
+		t.Fatalf("unexpected error creating subscription: %v", err)
+	}
+	id := *created.SubscriptionId
+	if err := store.EnqueueNotification(ctx, "hwmgr-1", id, Notification{NotificationId: uuid.New()}); err != nil {
+		t.Fatalf("unexpected error enqueuing notification: %v", err)
+	}
+
+	// The redirects are capped whether a policy is set or not
+	for _, callbackPolicy := range []*CallbackPolicy{nil, policy} {
+		requests.Store(0)
+		notifier.CallbackPolicy = callbackPolicy
+		notifier.DeliverAll(ctx)
+		if requests.Load() != maxDeliveryRedirects {
+			t.Errorf("expected %d requests with policy %v, got %d", maxDeliveryRedirects, callbackPolicy != nil, requests.Load())
+		}
+	}
+	if pending, _ := store.PendingNotifications(ctx, "hwmgr-1", id); len(pending) != 1 || pending[0].Attempts != 2 {
+		t.Fatalf("expected notification to remain queued with failed attempts, got %v", pending)
+	}
+}
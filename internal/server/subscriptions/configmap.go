@@ -0,0 +1,304 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+const (
+	configMapNamePrefix = "hwmgr-subscription-"
+
+	// LabelSubscription marks ConfigMaps holding subscription state
+	LabelSubscription = "hwmgr-plugin.oran.openshift.io/subscription"
+	// LabelHwMgrId identifies the hardware manager a subscription is registered against
+	LabelHwMgrId = "hwmgr-plugin.oran.openshift.io/hwMgrId"
+
+	subscriptionKey  = "subscription"
+	notificationsKey = "notifications"
+	deadLettersKey   = "deadLetters"
+)
+
+// ConfigMapStore persists each subscription, and its queue of undelivered notifications, in a ConfigMap in the
+// plugin namespace, so that subscriptions survive plugin restarts and upgrades.
+type ConfigMapStore struct {
+	client    client.Client
+	reader    client.Reader
+	namespace string
+}
+
+var _ Store = (*ConfigMapStore)(nil)
+
+// NewConfigMapStore creates a ConfigMapStore. Reads go through the given reader, typically a non-cached client,
+// to avoid a cluster-wide ConfigMap informer.
+func NewConfigMapStore(c client.Client, reader client.Reader, namespace string) *ConfigMapStore {
+	return &ConfigMapStore{
+		client:    c,
+		reader:    reader,
+		namespace: namespace,
+	}
+}
+
+func configMapName(id uuid.UUID) string {
+	return configMapNamePrefix + id.String()
+}
+
+func (s *ConfigMapStore) get(ctx context.Context, hwMgrId string, id uuid.UUID) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.reader.Get(ctx, types.NamespacedName{Name: configMapName(id), Namespace: s.namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get subscription configmap %s: %w", configMapName(id), err)
+	}
+	if cm.Labels[LabelHwMgrId] != hwMgrId {
+		return nil, ErrNotFound
+	}
+	return cm, nil
+}
+
+func decodeSubscription(cm *corev1.ConfigMap) (*generated.Subscription, error) {
+	subscription := &generated.Subscription{}
+	if err := json.Unmarshal([]byte(cm.Data[subscriptionKey]), subscription); err != nil {
+		return nil, fmt.Errorf("failed to parse subscription from configmap %s: %w", cm.Name, err)
+	}
+	return subscription, nil
+}
+
+func decodeNotifications(cm *corev1.ConfigMap, key string) ([]Notification, error) {
+	notifications := []Notification{}
+	if data := cm.Data[key]; data != "" {
+		if err := json.Unmarshal([]byte(data), &notifications); err != nil {
+			return nil, fmt.Errorf("failed to parse %s from configmap %s: %w", key, cm.Name, err)
+		}
+	}
+	return notifications, nil
+}
+
+// updateQueues applies the given change to the notification and dead-letter queues of a subscription
+func (s *ConfigMapStore) updateQueues(ctx context.Context, hwMgrId string, id uuid.UUID,
+	update func(pending, deadLetters []Notification) ([]Notification, []Notification, error)) error {
+
+	// nolint: wrapcheck
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.get(ctx, hwMgrId, id)
+		if err != nil {
+			return err
+		}
+		pending, err := decodeNotifications(cm, notificationsKey)
+		if err != nil {
+			return err
+		}
+		deadLetters, err := decodeNotifications(cm, deadLettersKey)
+		if err != nil {
+			return err
+		}
+		if pending, deadLetters, err = update(pending, deadLetters); err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		for key, notifications := range map[string][]Notification{notificationsKey: pending, deadLettersKey: deadLetters} {
+			data, err := json.Marshal(notifications)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s: %w", key, err)
+			}
+			cm.Data[key] = string(data)
+		}
+		return s.client.Update(ctx, cm)
+	})
+}
+
+func (s *ConfigMapStore) AllSubscriptions(ctx context.Context) (map[string][]generated.Subscription, error) {
+	var cmList corev1.ConfigMapList
+	if err := s.reader.List(ctx, &cmList, client.InNamespace(s.namespace),
+		client.MatchingLabels{LabelSubscription: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list subscription configmaps: %w", err)
+	}
+
+	subscriptions := make(map[string][]generated.Subscription)
+	for i := range cmList.Items {
+		subscription, err := decodeSubscription(&cmList.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		hwMgrId := cmList.Items[i].Labels[LabelHwMgrId]
+		subscriptions[hwMgrId] = append(subscriptions[hwMgrId], *subscription)
+	}
+	return subscriptions, nil
+}
+
+func (s *ConfigMapStore) ListSubscriptions(ctx context.Context, hwMgrId string) ([]generated.Subscription, error) {
+	var cmList corev1.ConfigMapList
+	if err := s.reader.List(ctx, &cmList, client.InNamespace(s.namespace),
+		client.MatchingLabels{LabelSubscription: "true", LabelHwMgrId: hwMgrId}); err != nil {
+		return nil, fmt.Errorf("failed to list subscription configmaps: %w", err)
+	}
+
+	subscriptions := []generated.Subscription{}
+	for i := range cmList.Items {
+		subscription, err := decodeSubscription(&cmList.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].SubscriptionId.String() < subscriptions[j].SubscriptionId.String()
+	})
+	return subscriptions, nil
+}
+
+func (s *ConfigMapStore) GetSubscription(ctx context.Context, hwMgrId string, id uuid.UUID) (*generated.Subscription, error) {
+	cm, err := s.get(ctx, hwMgrId, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSubscription(cm)
+}
+
+func (s *ConfigMapStore) CreateSubscription(ctx context.Context, hwMgrId string, subscription generated.Subscription) (*generated.Subscription, error) {
+	id := uuid.New()
+	subscription.SubscriptionId = &id
+
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName(id),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				LabelSubscription: "true",
+				LabelHwMgrId:      hwMgrId,
+			},
+		},
+		Data: map[string]string{
+			subscriptionKey:  string(data),
+			notificationsKey: "[]",
+			deadLettersKey:   "[]",
+		},
+	}
+	if err := s.client.Create(ctx, cm); err != nil {
+		return nil, fmt.Errorf("failed to create subscription configmap %s: %w", cm.Name, err)
+	}
+	return &subscription, nil
+}
+
+func (s *ConfigMapStore) DeleteSubscription(ctx context.Context, hwMgrId string, id uuid.UUID) error {
+	cm, err := s.get(ctx, hwMgrId, id)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Delete(ctx, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete subscription configmap %s: %w", cm.Name, err)
+	}
+	return nil
+}
+
+func (s *ConfigMapStore) EnqueueNotification(ctx context.Context, hwMgrId string, id uuid.UUID, notification Notification) error {
+	return s.updateQueues(ctx, hwMgrId, id, func(pending, deadLetters []Notification) ([]Notification, []Notification, error) {
+		return append(pending, notification), deadLetters, nil
+	})
+}
+
+func (s *ConfigMapStore) PendingNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) ([]Notification, error) {
+	cm, err := s.get(ctx, hwMgrId, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeNotifications(cm, notificationsKey)
+}
+
+func (s *ConfigMapStore) AckNotification(ctx context.Context, hwMgrId string, id, notificationId uuid.UUID) error {
+	return s.updateQueues(ctx, hwMgrId, id, func(pending, deadLetters []Notification) ([]Notification, []Notification, error) {
+		return removeNotification(pending, notificationId), deadLetters, nil
+	})
+}
+
+func (s *ConfigMapStore) RecordDeliveryFailure(ctx context.Context, hwMgrId string, id, notificationId uuid.UUID, reason string) (int, error) {
+	attempts := 0
+	err := s.updateQueues(ctx, hwMgrId, id, func(pending, deadLetters []Notification) ([]Notification, []Notification, error) {
+		i := findNotification(pending, notificationId)
+		if i < 0 {
+			return nil, nil, ErrNotificationNotFound
+		}
+		pending[i].Attempts++
+		pending[i].LastError = reason
+		attempts = pending[i].Attempts
+		return pending, deadLetters, nil
+	})
+	return attempts, err
+}
+
+func (s *ConfigMapStore) DeadLetterNotification(ctx context.Context, hwMgrId string, id, notificationId uuid.UUID) error {
+	return s.updateQueues(ctx, hwMgrId, id, func(pending, deadLetters []Notification) ([]Notification, []Notification, error) {
+		i := findNotification(pending, notificationId)
+		if i < 0 {
+			return nil, nil, ErrNotificationNotFound
+		}
+		deadLetters = append(deadLetters, deadLetter(pending[i], time.Now()))
+		return removeNotification(pending, notificationId), deadLetters, nil
+	})
+}
+
+func (s *ConfigMapStore) DeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) ([]Notification, error) {
+	cm, err := s.get(ctx, hwMgrId, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeNotifications(cm, deadLettersKey)
+}
+
+func (s *ConfigMapStore) ReplayDeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) (int, error) {
+	count := 0
+	err := s.updateQueues(ctx, hwMgrId, id, func(pending, deadLetters []Notification) ([]Notification, []Notification, error) {
+		count = len(deadLetters)
+		return append(pending, resetForReplay(deadLetters)...), []Notification{}, nil
+	})
+	return count, err
+}
+
+func (s *ConfigMapStore) PurgeDeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID, before time.Time, keep int) (int, error) {
+	// Check first, to avoid rewriting the ConfigMap of each subscription when there is nothing to purge
+	deadLetters, err := s.DeadLetterNotifications(ctx, hwMgrId, id)
+	if err != nil {
+		return 0, err
+	}
+	if len(purgeNotifications(deadLetters, before, keep)) == len(deadLetters) {
+		return 0, nil
+	}
+
+	purged := 0
+	err = s.updateQueues(ctx, hwMgrId, id, func(pending, deadLetters []Notification) ([]Notification, []Notification, error) {
+		kept := purgeNotifications(deadLetters, before, keep)
+		purged = len(deadLetters) - len(kept)
+		return pending, kept, nil
+	})
+	return purged, err
+}
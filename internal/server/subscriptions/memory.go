@@ -0,0 +1,225 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+type memoryRecord struct {
+	hwMgrId       string
+	subscription  generated.Subscription
+	notifications []Notification
+	deadLetters   []Notification
+}
+
+// MemoryStore is a non-persistent Store, for testing or deployments that do not need subscriptions to survive a restart
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[uuid.UUID]*memoryRecord
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[uuid.UUID]*memoryRecord),
+	}
+}
+
+func (s *MemoryStore) lookup(hwMgrId string, id uuid.UUID) (*memoryRecord, error) {
+	record, exists := s.records[id]
+	if !exists || record.hwMgrId != hwMgrId {
+		return nil, ErrNotFound
+	}
+	return record, nil
+}
+
+func (s *MemoryStore) AllSubscriptions(_ context.Context) (map[string][]generated.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscriptions := make(map[string][]generated.Subscription)
+	for _, record := range s.records {
+		subscriptions[record.hwMgrId] = append(subscriptions[record.hwMgrId], record.subscription)
+	}
+	return subscriptions, nil
+}
+
+func (s *MemoryStore) ListSubscriptions(_ context.Context, hwMgrId string) ([]generated.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscriptions := []generated.Subscription{}
+	for _, record := range s.records {
+		if record.hwMgrId == hwMgrId {
+			subscriptions = append(subscriptions, record.subscription)
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].SubscriptionId.String() < subscriptions[j].SubscriptionId.String()
+	})
+	return subscriptions, nil
+}
+
+func (s *MemoryStore) GetSubscription(_ context.Context, hwMgrId string, id uuid.UUID) (*generated.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return nil, err
+	}
+	subscription := record.subscription
+	return &subscription, nil
+}
+
+func (s *MemoryStore) CreateSubscription(_ context.Context, hwMgrId string, subscription generated.Subscription) (*generated.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := uuid.New()
+	subscription.SubscriptionId = &id
+	s.records[id] = &memoryRecord{hwMgrId: hwMgrId, subscription: subscription}
+	return &subscription, nil
+}
+
+func (s *MemoryStore) DeleteSubscription(_ context.Context, hwMgrId string, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookup(hwMgrId, id); err != nil {
+		return err
+	}
+	delete(s.records, id)
+	return nil
+}
+
+func (s *MemoryStore) EnqueueNotification(_ context.Context, hwMgrId string, id uuid.UUID, notification Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return err
+	}
+	record.notifications = append(record.notifications, notification)
+	return nil
+}
+
+func (s *MemoryStore) PendingNotifications(_ context.Context, hwMgrId string, id uuid.UUID) ([]Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Notification{}, record.notifications...), nil
+}
+
+func (s *MemoryStore) AckNotification(_ context.Context, hwMgrId string, id, notificationId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return err
+	}
+	record.notifications = removeNotification(record.notifications, notificationId)
+	return nil
+}
+
+func (s *MemoryStore) RecordDeliveryFailure(_ context.Context, hwMgrId string, id, notificationId uuid.UUID, reason string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return 0, err
+	}
+	i := findNotification(record.notifications, notificationId)
+	if i < 0 {
+		return 0, ErrNotificationNotFound
+	}
+	record.notifications[i].Attempts++
+	record.notifications[i].LastError = reason
+	return record.notifications[i].Attempts, nil
+}
+
+func (s *MemoryStore) DeadLetterNotification(_ context.Context, hwMgrId string, id, notificationId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return err
+	}
+	i := findNotification(record.notifications, notificationId)
+	if i < 0 {
+		return ErrNotificationNotFound
+	}
+	record.deadLetters = append(record.deadLetters, deadLetter(record.notifications[i], time.Now()))
+	record.notifications = removeNotification(record.notifications, notificationId)
+	return nil
+}
+
+func (s *MemoryStore) DeadLetterNotifications(_ context.Context, hwMgrId string, id uuid.UUID) ([]Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Notification{}, record.deadLetters...), nil
+}
+
+func (s *MemoryStore) ReplayDeadLetterNotifications(_ context.Context, hwMgrId string, id uuid.UUID) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return 0, err
+	}
+	count := len(record.deadLetters)
+	record.notifications = append(record.notifications, resetForReplay(record.deadLetters)...)
+	record.deadLetters = nil
+	return count, nil
+}
+
+func (s *MemoryStore) PurgeDeadLetterNotifications(_ context.Context, hwMgrId string, id uuid.UUID, before time.Time, keep int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.lookup(hwMgrId, id)
+	if err != nil {
+		return 0, err
+	}
+	kept := purgeNotifications(record.deadLetters, before, keep)
+	purged := len(record.deadLetters) - len(kept)
+	record.deadLetters = kept
+	return purged, nil
+}
+
+func removeNotification(notifications []Notification, notificationId uuid.UUID) []Notification {
+	result := notifications[:0]
+	for _, notification := range notifications {
+		if notification.NotificationId != notificationId {
+			result = append(result, notification)
+		}
+	}
+	return result
+}
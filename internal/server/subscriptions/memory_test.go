@@ -0,0 +1,104 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	created, err := store.CreateSubscription(ctx, "hwmgr-1", generated.Subscription{Callback: "https://smo.example.com/cb"})
+	if err != nil {
+		t.Fatalf("unexpected error creating subscription: %v", err)
+	}
+	if created.SubscriptionId == nil {
+		t.Fatal("expected subscription ID to be allocated")
+	}
+	id := *created.SubscriptionId
+
+	if _, err := store.GetSubscription(ctx, "hwmgr-2", id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for another hardware manager, got %v", err)
+	}
+	if subs, _ := store.ListSubscriptions(ctx, "hwmgr-1"); len(subs) != 1 {
+		t.Errorf("expected 1 subscription, got %d", len(subs))
+	}
+
+	first := Notification{NotificationId: uuid.New(), NotificationEventType: NotificationEventCreate}
+	second := Notification{NotificationId: uuid.New(), NotificationEventType: NotificationEventDelete}
+	for _, n := range []Notification{first, second} {
+		if err := store.EnqueueNotification(ctx, "hwmgr-1", id, n); err != nil {
+			t.Fatalf("unexpected error enqueuing notification: %v", err)
+		}
+	}
+	if err := store.AckNotification(ctx, "hwmgr-1", id, first.NotificationId); err != nil {
+		t.Fatalf("unexpected error acking notification: %v", err)
+	}
+	pending, err := store.PendingNotifications(ctx, "hwmgr-1", id)
+	if err != nil {
+		t.Fatalf("unexpected error getting notifications: %v", err)
+	}
+	if len(pending) != 1 || pending[0].NotificationId != second.NotificationId {
+		t.Errorf("expected only the second notification to be pending, got %v", pending)
+	}
+
+	if err := store.DeleteSubscription(ctx, "hwmgr-1", id); err != nil {
+		t.Fatalf("unexpected error deleting subscription: %v", err)
+	}
+	if err := store.DeleteSubscription(ctx, "hwmgr-1", id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestPurgeDeadLetterNotifications(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	created, err := store.CreateSubscription(ctx, "hwmgr-1", generated.Subscription{Callback: "https://smo.example.com/cb"})
+	if err != nil {
+		t.Fatalf("unexpected error creating subscription: %v", err)
+	}
+	id := *created.SubscriptionId
+
+	for i := 0; i < 3; i++ {
+		n := Notification{NotificationId: uuid.New()}
+		if err := store.EnqueueNotification(ctx, "hwmgr-1", id, n); err != nil {
+			t.Fatalf("unexpected error enqueuing notification: %v", err)
+		}
+		if err := store.DeadLetterNotification(ctx, "hwmgr-1", id, n.NotificationId); err != nil {
+			t.Fatalf("unexpected error dead-lettering notification: %v", err)
+		}
+	}
+	deadLetters, _ := store.DeadLetterNotifications(ctx, "hwmgr-1", id)
+	if len(deadLetters) != 3 || deadLetters[0].DeadLetteredAt == nil {
+		t.Fatalf("expected 3 timestamped dead letters, got %+v", deadLetters)
+	}
+
+	if purged, err := store.PurgeDeadLetterNotifications(ctx, "hwmgr-1", id, time.Time{}, 2); err != nil || purged != 1 {
+		t.Errorf("expected the oldest dead letter beyond the count to be purged, got %d, %v", purged, err)
+	}
+	if purged, _ := store.PurgeDeadLetterNotifications(ctx, "hwmgr-1", id, time.Now().Add(-time.Hour), 0); purged != 0 {
+		t.Errorf("expected recent dead letters to be kept, got %d purged", purged)
+	}
+	if purged, _ := store.PurgeDeadLetterNotifications(ctx, "hwmgr-1", id, time.Now().Add(time.Second), 0); purged != 2 {
+		t.Errorf("expected dead letters past the maximum age to be purged, got %d", purged)
+	}
+
+	legacy := []Notification{{NotificationId: uuid.New()}, {NotificationId: uuid.New()}}
+	if kept := purgeNotifications(legacy, time.Now(), 1); len(kept) != 1 || kept[0].NotificationId != legacy[1].NotificationId {
+		t.Errorf("expected dead letters without a timestamp to only be subject to the count, got %+v", kept)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	notificationsDelivered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hwmgr_plugin_notifications_delivered_total",
+		Help: "Number of notifications delivered to subscribers",
+	}, []string{"hwmgr"})
+
+	notificationDeliveryFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hwmgr_plugin_notification_delivery_failures_total",
+		Help: "Number of failed notification delivery attempts",
+	}, []string{"hwmgr"})
+
+	notificationsDeadLettered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hwmgr_plugin_notifications_dead_lettered_total",
+		Help: "Number of notifications moved to the dead-letter queue after exhausting delivery attempts",
+	}, []string{"hwmgr"})
+
+	notificationsReplayed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hwmgr_plugin_notifications_replayed_total",
+		Help: "Number of dead-lettered notifications replayed on request",
+	}, []string{"hwmgr"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		notificationsDelivered,
+		notificationDeliveryFailures,
+		notificationsDeadLettered,
+		notificationsReplayed,
+	)
+}
+
+// RecordReplay updates the metrics for a replay of dead-lettered notifications
+func RecordReplay(hwMgrId string, count int) {
+	notificationsReplayed.WithLabelValues(hwMgrId).Add(float64(count))
+}
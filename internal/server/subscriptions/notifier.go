@@ -0,0 +1,199 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notifier defaults
+const (
+	DefaultMaxDeliveryAttempts = 5
+	DefaultDeliveryInterval    = 30 * time.Second
+	deliveryTimeout            = 10 * time.Second
+	maxDeliveryRedirects       = 10
+)
+
+// Notifier delivers queued notifications to subscriber callbacks. A notification that fails delivery is retried on
+// each pass, and moved to the dead-letter queue once the attempts are exhausted, so that an unreachable subscriber
+// cannot block the notifications queued behind it indefinitely. When a CallbackPolicy is set, notifications are only
+// delivered to the destinations it allows.
+type Notifier struct {
+	Store          Store
+	Client         *http.Client
+	Logger         *slog.Logger
+	MaxAttempts    int
+	Interval       time.Duration
+	CallbackPolicy *CallbackPolicy
+}
+
+// NewNotifier creates a Notifier with the default settings
+func NewNotifier(store Store, logger *slog.Logger) *Notifier {
+	n := &Notifier{
+		Store:       store,
+		Logger:      logger.With(slog.String("module", "notifier")),
+		MaxAttempts: DefaultMaxDeliveryAttempts,
+		Interval:    DefaultDeliveryInterval,
+	}
+
+	// The policy is checked on the address of each connection and on each redirect, as the callback host may resolve
+	// to a different address than when it was validated
+	dialer := &net.Dialer{
+		Timeout:   deliveryTimeout,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			if n.CallbackPolicy == nil {
+				return nil
+			}
+			return n.CallbackPolicy.checkDialAddress(address)
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	n.Client = &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxDeliveryRedirects {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+			if n.CallbackPolicy != nil {
+				return n.CallbackPolicy.ValidateURL(req.Context(), req.URL.String())
+			}
+			return nil
+		},
+	}
+	return n
+}
+
+// NeedLeaderElection ensures notifications are only delivered by a single replica
+func (n *Notifier) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the delivery loop until the context is cancelled
+func (n *Notifier) Start(ctx context.Context) error {
+	ticker := time.NewTicker(n.Interval)
+	defer ticker.Stop()
+
+	for {
+		n.DeliverAll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverAll makes a delivery pass over the queues of all subscriptions
+func (n *Notifier) DeliverAll(ctx context.Context) {
+	all, err := n.Store.AllSubscriptions(ctx)
+	if err != nil {
+		n.Logger.ErrorContext(ctx, "failed to list subscriptions", slog.String("error", err.Error()))
+		return
+	}
+
+	for hwMgrId, subscriptions := range all {
+		for _, subscription := range subscriptions {
+			if subscription.SubscriptionId == nil {
+				continue
+			}
+			if err := n.deliverPending(ctx, hwMgrId, subscription.Callback, *subscription.SubscriptionId); err != nil {
+				n.Logger.ErrorContext(ctx, "failed to process notifications", slog.String("hwMgrId", hwMgrId),
+					slog.String("subscriptionId", subscription.SubscriptionId.String()), slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// deliverPending delivers the queued notifications of a subscription in order, stopping at the first failure to
+// preserve ordering
+func (n *Notifier) deliverPending(ctx context.Context, hwMgrId, callback string, id uuid.UUID) error {
+	pending, err := n.Store.PendingNotifications(ctx, hwMgrId, id)
+	if err != nil {
+		return fmt.Errorf("failed to get pending notifications: %w", err)
+	}
+
+	for _, notification := range pending {
+		deliveryErr := n.post(ctx, callback, notification)
+		if deliveryErr == nil {
+			notificationsDelivered.WithLabelValues(hwMgrId).Inc()
+			if err := n.Store.AckNotification(ctx, hwMgrId, id, notification.NotificationId); err != nil {
+				return fmt.Errorf("failed to ack notification %s: %w", notification.NotificationId, err)
+			}
+			continue
+		}
+
+		notificationDeliveryFailures.WithLabelValues(hwMgrId).Inc()
+		attempts, err := n.Store.RecordDeliveryFailure(ctx, hwMgrId, id, notification.NotificationId, deliveryErr.Error())
+		if err != nil {
+			return fmt.Errorf("failed to record delivery failure of notification %s: %w", notification.NotificationId, err)
+		}
+		if attempts < n.MaxAttempts {
+			n.Logger.InfoContext(ctx, "Notification delivery failed, will retry", slog.String("hwMgrId", hwMgrId),
+				slog.String("notificationId", notification.NotificationId.String()), slog.Int("attempts", attempts),
+				slog.String("error", deliveryErr.Error()))
+			return nil
+		}
+
+		n.Logger.WarnContext(ctx, "Notification delivery attempts exhausted, moving to dead-letter queue",
+			slog.String("hwMgrId", hwMgrId), slog.String("notificationId", notification.NotificationId.String()),
+			slog.Int("attempts", attempts), slog.String("error", deliveryErr.Error()))
+		if err := n.Store.DeadLetterNotification(ctx, hwMgrId, id, notification.NotificationId); err != nil {
+			return fmt.Errorf("failed to dead-letter notification %s: %w", notification.NotificationId, err)
+		}
+		notificationsDeadLettered.WithLabelValues(hwMgrId).Inc()
+	}
+
+	return nil
+}
+
+// post sends a notification to the subscriber callback
+func (n *Notifier) post(ctx context.Context, callback string, notification Notification) error {
+	// The delivery state is internal to the plugin, so is not sent to the subscriber
+	notification.Attempts = 0
+	notification.LastError = ""
+
+	if n.CallbackPolicy != nil {
+		if err := n.CallbackPolicy.ValidateURL(ctx, callback); err != nil {
+			return fmt.Errorf("callback rejected by policy: %w", err)
+		}
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callback, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("subscriber returned status %d", resp.StatusCode)
+	}
+	return nil
+}
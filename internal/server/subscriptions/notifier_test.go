@@ -0,0 +1,75 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+func TestNotifierDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	var healthy atomic.Bool
+	var delivered atomic.Int32
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		delivered.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer subscriber.Close()
+
+	store := NewMemoryStore()
+	notifier := NewNotifier(store, slog.Default())
+	notifier.MaxAttempts = 2
+
+	created, err := store.CreateSubscription(ctx, "hwmgr-1", generated.Subscription{Callback: subscriber.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating subscription: %v", err)
+	}
+	id := *created.SubscriptionId
+	if err := store.EnqueueNotification(ctx, "hwmgr-1", id, Notification{NotificationId: uuid.New()}); err != nil {
+		t.Fatalf("unexpected error enqueuing notification: %v", err)
+	}
+
+	// The first failure is retried, the second exhausts the attempts
+	notifier.DeliverAll(ctx)
+	if pending, _ := store.PendingNotifications(ctx, "hwmgr-1", id); len(pending) != 1 || pending[0].Attempts != 1 {
+		t.Fatalf("expected notification to remain queued after first failure, got %v", pending)
+	}
+	notifier.DeliverAll(ctx)
+	if pending, _ := store.PendingNotifications(ctx, "hwmgr-1", id); len(pending) != 0 {
+		t.Fatalf("expected no pending notifications, got %v", pending)
+	}
+	deadLetters, _ := store.DeadLetterNotifications(ctx, "hwmgr-1", id)
+	if len(deadLetters) != 1 || deadLetters[0].LastError == "" {
+		t.Fatalf("expected notification to be dead-lettered with an error, got %v", deadLetters)
+	}
+
+	// Replaying requeues the notification with its delivery state reset
+	healthy.Store(true)
+	if count, err := store.ReplayDeadLetterNotifications(ctx, "hwmgr-1", id); err != nil || count != 1 {
+		t.Fatalf("unexpected replay result: count=%d, err=%v", count, err)
+	}
+	notifier.DeliverAll(ctx)
+	if delivered.Load() != 1 {
+		t.Errorf("expected notification to be delivered after replay, got %d deliveries", delivered.Load())
+	}
+	if pending, _ := store.PendingNotifications(ctx, "hwmgr-1", id); len(pending) != 0 {
+		t.Errorf("expected no pending notifications after delivery, got %v", pending)
+	}
+}
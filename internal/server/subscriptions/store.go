@@ -0,0 +1,148 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/server/api/generated"
+)
+
+// Supported store kinds
+const (
+	StoreKindConfigMap = "configmap"
+	StoreKindMemory    = "memory"
+)
+
+// NotificationEventType identifies the type of change reported by a notification
+type NotificationEventType int
+
+const (
+	NotificationEventCreate NotificationEventType = 0
+	NotificationEventModify NotificationEventType = 1
+	NotificationEventDelete NotificationEventType = 2
+)
+
+// Notification is a resource change notification queued for delivery to a subscriber
+type Notification struct {
+	NotificationId         uuid.UUID             `json:"notificationId"`
+	ConsumerSubscriptionId *uuid.UUID            `json:"consumerSubscriptionId,omitempty"`
+	NotificationEventType  NotificationEventType `json:"notificationEventType"`
+	ObjectRef              string                `json:"objectRef,omitempty"`
+	Object                 map[string]any        `json:"object,omitempty"`
+
+	// Attempts is the number of failed delivery attempts
+	Attempts int `json:"attempts,omitempty"`
+	// LastError describes the most recent delivery failure
+	LastError string `json:"lastError,omitempty"`
+	// DeadLetteredAt is when the notification was moved to the dead-letter queue
+	DeadLetteredAt *time.Time `json:"deadLetteredAt,omitempty"`
+}
+
+// ErrNotFound is returned when the requested subscription does not exist
+var ErrNotFound = errors.New("subscription not found")
+
+// ErrNotificationNotFound is returned when the requested notification is not queued for the subscription
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// Store persists inventory subscriptions and their undelivered notifications
+type Store interface {
+	// AllSubscriptions returns the subscriptions of all hardware managers, keyed by hardware manager ID
+	AllSubscriptions(ctx context.Context) (map[string][]generated.Subscription, error)
+	// ListSubscriptions returns the subscriptions registered against the given hardware manager
+	ListSubscriptions(ctx context.Context, hwMgrId string) ([]generated.Subscription, error)
+	// GetSubscription returns a single subscription, or ErrNotFound
+	GetSubscription(ctx context.Context, hwMgrId string, id uuid.UUID) (*generated.Subscription, error)
+	// CreateSubscription allocates an identifier for the subscription and persists it
+	CreateSubscription(ctx context.Context, hwMgrId string, subscription generated.Subscription) (*generated.Subscription, error)
+	// DeleteSubscription removes a subscription along with any undelivered notifications, or returns ErrNotFound
+	DeleteSubscription(ctx context.Context, hwMgrId string, id uuid.UUID) error
+
+	// EnqueueNotification appends a notification to the subscription's delivery queue
+	EnqueueNotification(ctx context.Context, hwMgrId string, id uuid.UUID, notification Notification) error
+	// PendingNotifications returns the undelivered notifications for the subscription, oldest first
+	PendingNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) ([]Notification, error)
+	// AckNotification removes a delivered notification from the subscription's queue
+	AckNotification(ctx context.Context, hwMgrId string, id, notificationId uuid.UUID) error
+	// RecordDeliveryFailure records a failed delivery attempt of a queued notification, returning the number of
+	// failed attempts so far
+	RecordDeliveryFailure(ctx context.Context, hwMgrId string, id, notificationId uuid.UUID, reason string) (int, error)
+
+	// DeadLetterNotification moves a queued notification to the subscription's dead-letter queue
+	DeadLetterNotification(ctx context.Context, hwMgrId string, id, notificationId uuid.UUID) error
+	// DeadLetterNotifications returns the notifications that could not be delivered, oldest first
+	DeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) ([]Notification, error)
+	// ReplayDeadLetterNotifications moves all dead-lettered notifications back to the delivery queue, returning the
+	// number of notifications replayed
+	ReplayDeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) (int, error)
+	// PurgeDeadLetterNotifications removes the notifications dead-lettered before the given time, unless zero, and the
+	// oldest beyond the given count, unless zero, returning the number of notifications removed
+	PurgeDeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID, before time.Time, keep int) (int, error)
+}
+
+// NewStore returns the store implementation for the given kind
+func NewStore(kind string, c client.Client, reader client.Reader, namespace string) (Store, error) {
+	switch kind {
+	case StoreKindConfigMap, "":
+		return NewConfigMapStore(c, reader, namespace), nil
+	case StoreKindMemory:
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported subscription store kind: %s", kind)
+	}
+}
+
+// findNotification returns the index of the notification in the list, or -1
+func findNotification(notifications []Notification, notificationId uuid.UUID) int {
+	for i := range notifications {
+		if notifications[i].NotificationId == notificationId {
+			return i
+		}
+	}
+	return -1
+}
+
+// deadLetter returns the notification marked as dead-lettered at the given time
+func deadLetter(notification Notification, now time.Time) Notification {
+	notification.DeadLetteredAt = &now
+	return notification
+}
+
+// purgeNotifications returns the dead-lettered notifications to keep, dropping those dead-lettered before the given
+// time, unless zero, and then the oldest beyond the given count, unless zero. Notifications dead-lettered before their
+// time was recorded are only subject to the count.
+func purgeNotifications(deadLetters []Notification, before time.Time, keep int) []Notification {
+	kept := make([]Notification, 0, len(deadLetters))
+	for _, notification := range deadLetters {
+		if !before.IsZero() && notification.DeadLetteredAt != nil && notification.DeadLetteredAt.Before(before) {
+			continue
+		}
+		kept = append(kept, notification)
+	}
+	if keep > 0 && len(kept) > keep {
+		kept = kept[len(kept)-keep:]
+	}
+	return kept
+}
+
+// resetForReplay clears the delivery state of dead-lettered notifications so they are retried afresh
+func resetForReplay(notifications []Notification) []Notification {
+	replayed := make([]Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		notification.Attempts = 0
+		notification.LastError = ""
+		notification.DeadLetteredAt = nil
+		replayed = append(replayed, notification)
+	}
+	return replayed
+}
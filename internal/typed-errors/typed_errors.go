@@ -106,6 +106,38 @@ func IsNonRetriableError(target error) bool {
 	return errors.As(target, &e)
 }
 
+// ConflictError type, for resources that exist but cannot be adopted
+type ConflictError struct {
+	GenericError
+}
+
+func NewConflictError(err error, format string, args ...interface{}) error {
+	return ConflictError{
+		GenericError: GenericError{fmt.Sprintf(format, args...), err},
+	}
+}
+
+func IsConflictError(target error) bool {
+	var e ConflictError
+	return errors.As(target, &e)
+}
+
+// UnavailableError type, for backends that are failing and are not queried until they recover
+type UnavailableError struct {
+	GenericError
+}
+
+func NewUnavailableError(err error, format string, args ...interface{}) error {
+	return UnavailableError{
+		GenericError: GenericError{fmt.Sprintf(format, args...), err},
+	}
+}
+
+func IsUnavailableError(target error) bool {
+	var e UnavailableError
+	return errors.As(target, &e)
+}
+
 // InputError wraps a standard error and provides a custom error type for input-related errors
 type InputError struct {
 	err error
@@ -0,0 +1,111 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Version and GitSHA are set at build time, with:
+//
+//	-ldflags "-X github.com/openshift-kni/oran-hwmgr-plugin/internal/version.Version=... \
+//	          -X github.com/openshift-kni/oran-hwmgr-plugin/internal/version.GitSHA=..."
+var (
+	Version = "dev"
+	GitSHA  = ""
+)
+
+// GetGitSHA returns the commit the binary was built from, falling back to the VCS information recorded by the Go
+// toolchain when it was not set at build time
+func GetGitSHA() string {
+	if GitSHA != "" {
+		return GitSHA
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return ""
+}
+
+// GoVersion returns the Go version the binary was built with
+func GoVersion() string {
+	return runtime.Version()
+}
+
+// ModuleVersion returns the version of a dependency module compiled into the binary, or an empty string if unknown
+func ModuleVersion(path string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == path {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
+
+// Compare compares two dotted version strings, such as firmware versions, returning -1, 0 or 1. Numeric fields are
+// compared numerically and other fields lexically, with a missing field ordered before a present one.
+func Compare(a, b string) int {
+	fieldsA := splitVersion(a)
+	fieldsB := splitVersion(b)
+	for i := 0; i < len(fieldsA) || i < len(fieldsB); i++ {
+		if i >= len(fieldsA) {
+			return -1
+		}
+		if i >= len(fieldsB) {
+			return 1
+		}
+		if result := compareField(fieldsA[i], fieldsB[i]); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func splitVersion(v string) []string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if v == "" {
+		return nil
+	}
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+}
+
+func compareField(a, b string) int {
+	numA, errA := strconv.ParseUint(a, 10, 64)
+	numB, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		}
+		return 0
+	case errA == nil:
+		// Numeric fields sort before non-numeric ones
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,31 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"7.00.00.00", "6.10.30.00", 1},
+		{"v0.9.0", "0.9.0", 0},
+		{"2.1", "2.1.1", -1},
+		{"2.1.1", "2.1", 1},
+		{"U46", "U45", 1},
+		{"1.0.0", "1.0.rc1", -1},
+		{"", "1.0", -1},
+	}
+	for _, test := range tests {
+		if result := Compare(test.a, test.b); result != test.expected {
+			t.Errorf("Compare(%q, %q): expected %d, got %d", test.a, test.b, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inventory_client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/pkg/inventory-client/generated"
+)
+
+// Client is a typed client for the inventory API of the hardware manager plugin. It wraps the generated client,
+// converting error responses to an APIError and retrying idempotent requests that fail with a transient error.
+type Client struct {
+	api *generated.ClientWithResponses
+}
+
+// Option configures a Client
+type Option func(*options)
+
+type options struct {
+	httpClient *http.Client
+	token      func(ctx context.Context) (string, error)
+	retries    int
+	backoff    time.Duration
+}
+
+// Client defaults
+const (
+	DefaultRetries = 3
+	DefaultBackoff = 500 * time.Millisecond
+	defaultTimeout = 30 * time.Second
+)
+
+// WithHTTPClient sets the HTTP client used to send requests, e.g. to configure TLS
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithBearerToken sets a function returning the token used to authenticate each request. The function is called for
+// every request, so that a rotated token, such as a projected service account token, is picked up.
+func WithBearerToken(token func(ctx context.Context) (string, error)) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithRetries sets the number of times a failed idempotent request is retried, and the initial backoff between
+// attempts, which doubles on each retry. A retries value of 0 disables retries.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.retries = retries
+		o.backoff = backoff
+	}
+}
+
+// NewClient creates a client for the inventory API served at the given URL
+func NewClient(server string, opts ...Option) (*Client, error) {
+	o := &options{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retries:    DefaultRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	clientOpts := []generated.ClientOption{
+		generated.WithHTTPClient(&retryingDoer{doer: o.httpClient, retries: o.retries, backoff: o.backoff}),
+	}
+	if o.token != nil {
+		clientOpts = append(clientOpts, generated.WithRequestEditorFn(bearerTokenEditor(o.token)))
+	}
+
+	api, err := generated.NewClientWithResponses(server, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory client: %w", err)
+	}
+	return &Client{api: api}, nil
+}
+
+// bearerTokenEditor sets the Authorization header of each request
+func bearerTokenEditor(token func(ctx context.Context) (string, error)) generated.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		value, err := token(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+value)
+		return nil
+	}
+}
+
+// GetPluginInfo returns the plugin version and the versions of its adaptors and their backends
+func (c *Client) GetPluginInfo(ctx context.Context) (*generated.PluginInfo, error) {
+	resp, err := c.api.GetPluginInfoWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin info: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
+
+// ListOption sets an option of a list request
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	filter *string
+}
+
+// WithFilter selects the items of a list matching a filter expression, using the filter syntax of the O-RAN O2 IMS
+// API, such as "(eq,resourcePoolId,pool-1);(in,model,r740,r650)"
+func WithFilter(filter string) ListOption {
+	return func(o *listOptions) {
+		o.filter = &filter
+	}
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	var options listOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// GetResourcePools returns the resource pools of the hardware manager
+func (c *Client) GetResourcePools(ctx context.Context, hwMgrId string, opts ...ListOption) ([]generated.ResourcePoolInfo, error) {
+	options := newListOptions(opts)
+	resp, err := c.api.GetResourcePoolsWithResponse(ctx, hwMgrId, &generated.GetResourcePoolsParams{Filter: options.filter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource pools: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// GetResourcePool returns a resource pool of the hardware manager
+func (c *Client) GetResourcePool(ctx context.Context, hwMgrId, resourcePoolId string) (*generated.ResourcePoolInfo, error) {
+	resp, err := c.api.GetResourcePoolWithResponse(ctx, hwMgrId, resourcePoolId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource pool %s: %w", resourcePoolId, err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
+
+// GetResourcePoolResources returns the resources of a resource pool
+func (c *Client) GetResourcePoolResources(ctx context.Context, hwMgrId, resourcePoolId string,
+	opts ...ListOption) ([]generated.ResourceInfo, error) {
+	options := newListOptions(opts)
+	resp, err := c.api.GetResourcePoolResourcesWithResponse(ctx, hwMgrId, resourcePoolId,
+		&generated.GetResourcePoolResourcesParams{Filter: options.filter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resources of pool %s: %w", resourcePoolId, err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// GetResources returns the resources of the hardware manager
+func (c *Client) GetResources(ctx context.Context, hwMgrId string, opts ...ListOption) ([]generated.ResourceInfo, error) {
+	options := newListOptions(opts)
+	resp, err := c.api.GetResourcesWithResponse(ctx, hwMgrId, &generated.GetResourcesParams{Filter: options.filter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resources: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// GetResource returns a resource of the hardware manager
+func (c *Client) GetResource(ctx context.Context, hwMgrId, resourceId string) (*generated.ResourceInfo, error) {
+	resp, err := c.api.GetResourceWithResponse(ctx, hwMgrId, resourceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource %s: %w", resourceId, err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
+
+// GetResourceTypes returns the resource types derived from the inventory of the hardware manager
+func (c *Client) GetResourceTypes(ctx context.Context, hwMgrId string) ([]generated.ResourceTypeInfo, error) {
+	resp, err := c.api.GetResourceTypesWithResponse(ctx, hwMgrId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource types: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// GetResourceType returns a resource type of the hardware manager
+func (c *Client) GetResourceType(ctx context.Context, hwMgrId string, resourceTypeId uuid.UUID) (*generated.ResourceTypeInfo, error) {
+	resp, err := c.api.GetResourceTypeWithResponse(ctx, hwMgrId, resourceTypeId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource type %s: %w", resourceTypeId, err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
+
+// GetAllocationReport returns the discrepancies between the Nodes of the hardware manager and its backend allocations
+func (c *Client) GetAllocationReport(ctx context.Context, hwMgrId string) (*generated.AllocationReport, error) {
+	resp, err := c.api.GetAllocationReportWithResponse(ctx, hwMgrId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocation report: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
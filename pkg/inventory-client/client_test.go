@@ -0,0 +1,160 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inventory_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/pkg/inventory-client/generated"
+)
+
+const testHwMgrId = "hwmgr-1"
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL,
+		WithRetries(2, time.Millisecond),
+		WithBearerToken(func(ctx context.Context) (string, error) { return "test-token", nil }))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return client
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestGetResourcesRetries(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, []generated.ResourceInfo{{ResourceId: "r1"}, {ResourceId: "r2"}})
+	}))
+
+	var ids []string
+	err := client.ForEachResource(context.Background(), testHwMgrId, func(resource generated.ResourceInfo) error {
+		ids = append(ids, resource.ResourceId)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || calls.Load() != 2 {
+		t.Errorf("expected 2 resources after 2 calls, got %v after %d calls", ids, calls.Load())
+	}
+}
+
+func TestGetResourceNotFound(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(generated.ProblemDetails{Status: http.StatusNotFound, Detail: "resource not found"})
+	}))
+
+	_, err := client.GetResource(context.Background(), testHwMgrId, "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if err.Error() != "inventory server returned status 404: resource not found" {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+}
+
+func TestRegisterSubscription(t *testing.T) {
+	existingId := uuid.New()
+	var created atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, []generated.Subscription{{Callback: "https://existing/cb", SubscriptionId: &existingId}})
+		case http.MethodPost:
+			created.Add(1)
+			subscription := generated.Subscription{}
+			_ = json.NewDecoder(r.Body).Decode(&subscription)
+			id := uuid.New()
+			subscription.SubscriptionId = &id
+			writeJSON(w, http.StatusCreated, subscription)
+		}
+	}))
+
+	subscription, err := client.RegisterSubscription(context.Background(), testHwMgrId,
+		generated.Subscription{Callback: "https://existing/cb"})
+	if err != nil || *subscription.SubscriptionId != existingId || created.Load() != 0 {
+		t.Fatalf("expected existing subscription to be returned, got %v, %v", subscription, err)
+	}
+
+	subscription, err = client.RegisterSubscription(context.Background(), testHwMgrId,
+		generated.Subscription{Callback: "https://new/cb"})
+	if err != nil || subscription.SubscriptionId == nil || created.Load() != 1 {
+		t.Fatalf("expected new subscription to be created, got %v, %v", subscription, err)
+	}
+}
+
+func TestCreateProvisioningRequestRetries(t *testing.T) {
+	testcases := []struct {
+		name           string
+		idempotencyKey string
+		calls          int32
+	}{
+		{name: "with idempotency key", idempotencyKey: "request-1", calls: 2},
+		{name: "without idempotency key", calls: 1},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Idempotency-Key") != tc.idempotencyKey {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if calls.Add(1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				writeJSON(w, http.StatusAccepted, generated.ProvisioningRequestStatus{ProvisioningRequestId: uuid.New()})
+			}))
+
+			_, err := client.CreateProvisioningRequest(context.Background(), testHwMgrId, tc.idempotencyKey,
+				generated.ProvisioningRequest{CloudId: "cloud-1", Site: "site-1"})
+			if (err == nil) != (tc.calls == 2) || calls.Load() != tc.calls {
+				t.Errorf("expected %d calls, got %d calls, %v", tc.calls, calls.Load(), err)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	pages := Paginate([]int{1, 2, 3, 4, 5}, 2)
+	if len(pages) != 3 || len(pages[2]) != 1 || pages[2][0] != 5 {
+		t.Errorf("unexpected pages: %v", pages)
+	}
+	if pages := Paginate([]int{1, 2}, 0); len(pages) != 1 || len(pages[0]) != 2 {
+		t.Errorf("unexpected pages: %v", pages)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inventory_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/pkg/inventory-client/generated"
+)
+
+// APIError is returned when the inventory server responds with an unexpected status code
+type APIError struct {
+	StatusCode int
+	// Problem holds the problem details returned by the server, if any
+	Problem *generated.ProblemDetails
+}
+
+func (e *APIError) Error() string {
+	if e.Problem != nil && e.Problem.Detail != "" {
+		return fmt.Sprintf("inventory server returned status %d: %s", e.StatusCode, e.Problem.Detail)
+	}
+	return fmt.Sprintf("inventory server returned status %d", e.StatusCode)
+}
+
+// newAPIError creates an APIError from the response, decoding the problem details from the body if present
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{}
+	if resp != nil {
+		apiErr.StatusCode = resp.StatusCode
+	}
+
+	problem := &generated.ProblemDetails{}
+	if err := json.Unmarshal(body, problem); err == nil && (problem.Detail != "" || problem.Status != 0) {
+		apiErr.Problem = problem
+	}
+	return apiErr
+}
+
+// IsNotFound returns true if the error is an APIError with a 404 status code
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -15,17 +15,57 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AllocationDiscrepancyType.
+const (
+	BackendAllocationWithoutNode AllocationDiscrepancyType = "BackendAllocationWithoutNode"
+	NodeWithoutBackendAllocation AllocationDiscrepancyType = "NodeWithoutBackendAllocation"
+)
+
+// Defines values for DeadLetterNotificationNotificationEventType.
+const (
+	DeadLetterNotificationNotificationEventTypeN0 DeadLetterNotificationNotificationEventType = 0
+	DeadLetterNotificationNotificationEventTypeN1 DeadLetterNotificationNotificationEventType = 1
+	DeadLetterNotificationNotificationEventTypeN2 DeadLetterNotificationNotificationEventType = 2
+)
+
+// Defines values for NodePoolExtensionsExtensionsVersion.
+const (
+	V1 NodePoolExtensionsExtensionsVersion = "v1"
+)
+
+// Defines values for NodePoolExtensionsSpreadMode.
+const (
+	Preferred NodePoolExtensionsSpreadMode = "preferred"
+	Required  NodePoolExtensionsSpreadMode = "required"
+)
+
+// Defines values for ProvisioningNodeGroupRole.
+const (
+	Master ProvisioningNodeGroupRole = "master"
+	Worker ProvisioningNodeGroupRole = "worker"
+)
+
+// Defines values for ProvisioningRequestStatusPhase.
+const (
+	Configuring  ProvisioningRequestStatusPhase = "Configuring"
+	Failed       ProvisioningRequestStatusPhase = "Failed"
+	Provisioned  ProvisioningRequestStatusPhase = "Provisioned"
+	Provisioning ProvisioningRequestStatusPhase = "Provisioning"
+	Releasing    ProvisioningRequestStatusPhase = "Releasing"
+)
+
 // Defines values for ResourceChangeNotificationNotificationEventType.
 const (
-	N0 ResourceChangeNotificationNotificationEventType = 0
-	N1 ResourceChangeNotificationNotificationEventType = 1
-	N2 ResourceChangeNotificationNotificationEventType = 2
+	ResourceChangeNotificationNotificationEventTypeN0 ResourceChangeNotificationNotificationEventType = 0
+	ResourceChangeNotificationNotificationEventTypeN1 ResourceChangeNotificationNotificationEventType = 1
+	ResourceChangeNotificationNotificationEventTypeN2 ResourceChangeNotificationNotificationEventType = 2
 )
 
 // Defines values for ResourceInfoAdminState.
@@ -51,10 +91,40 @@ const (
 
 // Defines values for ResourceInfoUsageState.
 const (
-	ACTIVE  ResourceInfoUsageState = "ACTIVE"
-	BUSY    ResourceInfoUsageState = "BUSY"
-	IDLE    ResourceInfoUsageState = "IDLE"
-	UNKNOWN ResourceInfoUsageState = "UNKNOWN"
+	ACTIVE                ResourceInfoUsageState = "ACTIVE"
+	BUSY                  ResourceInfoUsageState = "BUSY"
+	EXTERNALLYPROVISIONED ResourceInfoUsageState = "EXTERNALLY_PROVISIONED"
+	IDLE                  ResourceInfoUsageState = "IDLE"
+	UNKNOWN               ResourceInfoUsageState = "UNKNOWN"
+)
+
+// Defines values for ResourceTypeAttributeType.
+const (
+	Array   ResourceTypeAttributeType = "array"
+	Integer ResourceTypeAttributeType = "integer"
+	String  ResourceTypeAttributeType = "string"
+)
+
+// Defines values for ResourceTypeInfoResourceClass.
+const (
+	ResourceTypeInfoResourceClassCOMPUTE    ResourceTypeInfoResourceClass = "COMPUTE"
+	ResourceTypeInfoResourceClassNETWORKING ResourceTypeInfoResourceClass = "NETWORKING"
+	ResourceTypeInfoResourceClassSTORAGE    ResourceTypeInfoResourceClass = "STORAGE"
+	ResourceTypeInfoResourceClassUNDEFINED  ResourceTypeInfoResourceClass = "UNDEFINED"
+)
+
+// Defines values for ResourceTypeInfoResourceKind.
+const (
+	ResourceTypeInfoResourceKindLOGICAL   ResourceTypeInfoResourceKind = "LOGICAL"
+	ResourceTypeInfoResourceKindPHYSICAL  ResourceTypeInfoResourceKind = "PHYSICAL"
+	ResourceTypeInfoResourceKindUNDEFINED ResourceTypeInfoResourceKind = "UNDEFINED"
+)
+
+// Defines values for StorageInfoType.
+const (
+	HDD  StorageInfoType = "HDD"
+	NVME StorageInfoType = "NVME"
+	SSD  StorageInfoType = "SSD"
 )
 
 // APIVersion Information about a version of the API.
@@ -68,6 +138,196 @@ type APIVersions struct {
 	UriPrefix   *string       `json:"uriPrefix,omitempty"`
 }
 
+// AdaptorInfo Information about an adaptor.
+type AdaptorInfo struct {
+	// AdaptorId Identifier of the adaptor.
+	AdaptorId string `json:"adaptorId"`
+
+	// Backend Information about the backend used by an adaptor.
+	Backend *BackendInfo `json:"backend,omitempty"`
+
+	// Enabled Whether the adaptor is enabled. An adaptor is disabled while its backend is unavailable, such as the metal3
+	// adaptor when the BareMetalHost CRD is not installed, and is enabled again once it becomes available.
+	Enabled bool `json:"enabled"`
+
+	// Firmware Range of firmware versions found on hardware managed by the adaptor, per component.
+	Firmware *[]FirmwareRange `json:"firmware,omitempty"`
+
+	// StatusMessage The reason the adaptor is disabled.
+	StatusMessage *string `json:"statusMessage,omitempty"`
+
+	// Version Version of the adaptor.
+	Version string `json:"version"`
+}
+
+// AllocationDiscrepancy Hardware that is allocated in the backend without a Node, or a Node without an allocation in the backend.
+type AllocationDiscrepancy struct {
+	// HwMgrNodeId The backend identifier of the hardware
+	HwMgrNodeId string `json:"hwMgrNodeId"`
+
+	// HwMgrNodeNs The backend namespace of the hardware, if any
+	HwMgrNodeNs *string `json:"hwMgrNodeNs,omitempty"`
+
+	// NodeName The name of the Node, for a Node without a backend allocation
+	NodeName *string `json:"nodeName,omitempty"`
+
+	// NodePool The NodePool of the Node, or what the hardware is allocated to in the backend, such as a Dell resource group
+	NodePool *string `json:"nodePool,omitempty"`
+
+	// Type The kind of discrepancy
+	Type AllocationDiscrepancyType `json:"type"`
+}
+
+// AllocationDiscrepancyType The kind of discrepancy
+type AllocationDiscrepancyType string
+
+// AllocationReport The discrepancies between the Nodes of a hardware manager and the hardware allocated in its backend.
+type AllocationReport struct {
+	// AllocationCount The number of hardware allocations in the backend
+	AllocationCount int                     `json:"allocationCount"`
+	Discrepancies   []AllocationDiscrepancy `json:"discrepancies"`
+
+	// GeneratedAt The time of the comparison
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// HwMgrId The hardware manager compared
+	HwMgrId string `json:"hwMgrId"`
+
+	// NodeCount The number of Nodes of the hardware manager
+	NodeCount int `json:"nodeCount"`
+}
+
+// BackendInfo Information about the backend used by an adaptor.
+type BackendInfo struct {
+	// Name Name of the backend.
+	Name string `json:"name"`
+
+	// Version Version of the backend, where known.
+	Version *string `json:"version,omitempty"`
+}
+
+// DeadLetterNotification defines model for DeadLetterNotification.
+type DeadLetterNotification struct {
+	// Attempts The number of failed delivery attempts
+	Attempts int `json:"attempts"`
+
+	// ConsumerSubscriptionId The value provided by the consumer in the subscription
+	ConsumerSubscriptionId *openapi_types.UUID `json:"consumerSubscriptionId,omitempty"`
+
+	// DeadLetteredAt When the notification was moved to the dead-letter queue
+	DeadLetteredAt *time.Time `json:"deadLetteredAt,omitempty"`
+
+	// LastError The reason for the most recent delivery failure
+	LastError *string `json:"lastError,omitempty"`
+
+	// NotificationEventType One of the following values: 0 - create, 1 - modify, 2 - delete
+	NotificationEventType DeadLetterNotificationNotificationEventType `json:"notificationEventType"`
+
+	// NotificationId A unique identifier to represent this notification event
+	NotificationId openapi_types.UUID `json:"notificationId"`
+
+	// Object The changed resource object.
+	Object *map[string]interface{} `json:"object,omitempty"`
+
+	// ObjectRef The URL to the object. This is not required if the notificationEventType is 2 (DELETE).
+	ObjectRef *string `json:"objectRef,omitempty"`
+}
+
+// DeadLetterNotificationNotificationEventType One of the following values: 0 - create, 1 - modify, 2 - delete
+type DeadLetterNotificationNotificationEventType int
+
+// FirmwareRange Range of firmware versions found for a hardware component.
+type FirmwareRange struct {
+	// Component Firmware component.
+	Component string `json:"component"`
+
+	// Hosts Number of hosts reporting a version for the component.
+	Hosts int `json:"hosts"`
+
+	// MaxVersion Highest version found.
+	MaxVersion string `json:"maxVersion"`
+
+	// MinVersion Lowest version found.
+	MinVersion string `json:"minVersion"`
+}
+
+// InventoryRefreshResult The inventory queried from the backend of a hardware manager by a refresh.
+type InventoryRefreshResult struct {
+	// HwMgrId The hardware manager refreshed
+	HwMgrId string `json:"hwMgrId"`
+
+	// RefreshedAt The time of the refresh
+	RefreshedAt time.Time `json:"refreshedAt"`
+
+	// ResourceCount The number of resources queried
+	ResourceCount int `json:"resourceCount"`
+
+	// ResourcePoolCount The number of resource pools queried
+	ResourcePoolCount int `json:"resourcePoolCount"`
+}
+
+// NodePoolExtensions The NodePool extensions, carrying settings beyond the NodePool spec, such as a CPU architecture or site placement
+// policy. The settings below apply to every node group, and most can be set for a single node group with a
+// "<group>.<setting>" key, such as "controller.cpuArchitecture", which takes precedence. Other keys are preserved
+// as is.
+type NodePoolExtensions struct {
+	// CpuArchitecture The CPU architecture requested for the nodes
+	CpuArchitecture *string `json:"cpuArchitecture,omitempty"`
+
+	// ExtensionsVersion The version of the extensions format, the current version if not set
+	ExtensionsVersion *NodePoolExtensionsExtensionsVersion `json:"extensionsVersion,omitempty"`
+
+	// HostnameTemplate The Go template of the hostnames of the nodes, overriding the template configured on the hardware manager
+	HostnameTemplate *string `json:"hostnameTemplate,omitempty"`
+
+	// ManifestTemplate The manifest template bundle rendered for each node, as "configmap/<name>" or "secret/<name>", a bare name
+	// referring to a ConfigMap
+	ManifestTemplate *string `json:"manifestTemplate,omitempty"`
+
+	// NetworkDataTemplate The template of the network data of the hosts of the nodes, as "configmap/<name>" or "secret/<name>", a bare
+	// name referring to a ConfigMap
+	NetworkDataTemplate *string `json:"networkDataTemplate,omitempty"`
+
+	// Rack The rack the nodes must be located in
+	Rack *string `json:"rack,omitempty"`
+
+	// ResourceTypeId The resource type requested for the nodes
+	ResourceTypeId *string `json:"resourceTypeId,omitempty"`
+
+	// Site The site the resource pools of the nodes must belong to
+	Site *string `json:"site,omitempty"`
+
+	// SpreadBy The topology the nodes are spread across, "site", "rack" or the key of a label of the hardware
+	SpreadBy *string `json:"spreadBy,omitempty"`
+
+	// SpreadMode How strictly the nodes are spread across the domains of the topology
+	SpreadMode *NodePoolExtensionsSpreadMode `json:"spreadMode,omitempty"`
+
+	// Tags The tags the nodes must carry, as comma-separated "key=value" pairs
+	Tags                 *string           `json:"tags,omitempty"`
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// NodePoolExtensionsExtensionsVersion The version of the extensions format, the current version if not set
+type NodePoolExtensionsExtensionsVersion string
+
+// NodePoolExtensionsSpreadMode How strictly the nodes are spread across the domains of the topology
+type NodePoolExtensionsSpreadMode string
+
+// PluginInfo Information about the plugin build and its adaptors.
+type PluginInfo struct {
+	Adaptors []AdaptorInfo `json:"adaptors"`
+
+	// GitSha Git commit the plugin was built from.
+	GitSha *string `json:"gitSha,omitempty"`
+
+	// GoVersion Go version the plugin was built with.
+	GoVersion *string `json:"goVersion,omitempty"`
+
+	// Version Version of the plugin.
+	Version string `json:"version"`
+}
+
 // ProblemDetails defines model for ProblemDetails.
 type ProblemDetails struct {
 	// AdditionalAttributes Any number of additional attributes, as defined in a specification or by an implementation.
@@ -108,6 +368,79 @@ type ProcessorInfo struct {
 	Model *string `json:"model,omitempty"`
 }
 
+// ProvisioningNodeGroup A group of nodes with the same role and hardware profile.
+type ProvisioningNodeGroup struct {
+	// HwProfile The hardware profile applied to the nodes of the group
+	HwProfile string `json:"hwProfile"`
+
+	// Name The name of the node group
+	Name string `json:"name"`
+
+	// ResourcePoolId The resource pool the nodes are allocated from
+	ResourcePoolId *string `json:"resourcePoolId,omitempty"`
+
+	// ResourceSelector Selects the resources the nodes are allocated from
+	ResourceSelector *string `json:"resourceSelector,omitempty"`
+
+	// Role The role of the nodes of the group
+	Role ProvisioningNodeGroupRole `json:"role"`
+
+	// Size The number of nodes in the group
+	Size int `json:"size"`
+}
+
+// ProvisioningNodeGroupRole The role of the nodes of the group
+type ProvisioningNodeGroupRole string
+
+// ProvisioningRequest A request for hardware to be provisioned, translated into a NodePool.
+type ProvisioningRequest struct {
+	// CloudId The identifier of the O-Cloud the hardware is provisioned for
+	CloudId string `json:"cloudId"`
+
+	// Extensions The NodePool extensions, carrying settings beyond the NodePool spec, such as a CPU architecture or site placement
+	// policy. The settings below apply to every node group, and most can be set for a single node group with a
+	// "<group>.<setting>" key, such as "controller.cpuArchitecture", which takes precedence. Other keys are preserved
+	// as is.
+	Extensions *NodePoolExtensions `json:"extensions,omitempty"`
+
+	// Location The location of the hardware
+	Location   *string                 `json:"location,omitempty"`
+	NodeGroups []ProvisioningNodeGroup `json:"nodeGroups"`
+
+	// Site The site of the hardware
+	Site string `json:"site"`
+}
+
+// ProvisioningRequestStatus The progress of a provisioning request.
+type ProvisioningRequestStatus struct {
+	// Message The message of the most recent NodePool condition, if any
+	Message *string `json:"message,omitempty"`
+
+	// NodeNames The names of the Nodes allocated to the request
+	NodeNames *[]string `json:"nodeNames,omitempty"`
+
+	// NodePool The name of the NodePool fulfilling the request
+	NodePool string `json:"nodePool"`
+
+	// Phase The provisioning phase of the NodePool
+	Phase ProvisioningRequestStatusPhase `json:"phase"`
+
+	// ProvisioningRequestId The identifier of the provisioning request, allocated by the hardware manager plugin
+	ProvisioningRequestId openapi_types.UUID `json:"provisioningRequestId"`
+
+	// StatusUrl The path to poll for the status of the request
+	StatusUrl string `json:"statusUrl"`
+}
+
+// ProvisioningRequestStatusPhase The provisioning phase of the NodePool
+type ProvisioningRequestStatusPhase string
+
+// ReplayResult The result of replaying dead-lettered notifications
+type ReplayResult struct {
+	// Replayed The number of notifications queued for delivery
+	Replayed int `json:"replayed"`
+}
+
 // ResourceChangeNotification Information about a resource change notification
 type ResourceChangeNotification struct {
 	// ConsumerSubscriptionId The value provided by the consumer in the subscription
@@ -134,6 +467,9 @@ type ResourceInfo struct {
 	// AdminState The administrative state of the resource
 	AdminState ResourceInfoAdminState `json:"adminState"`
 
+	// CpuArchitecture The CPU architecture of the resource, normalized to the names reported by hardware inspection, if known
+	CpuArchitecture *string `json:"cpuArchitecture,omitempty"`
+
 	// Description Human readable description of the resource.
 	Description string `json:"description"`
 
@@ -173,6 +509,9 @@ type ResourceInfo struct {
 	// SerialNumber The vendor serial number of the resource
 	SerialNumber string `json:"serialNumber"`
 
+	// Storage The storage devices of the resource, if reported
+	Storage *[]StorageInfo `json:"storage,omitempty"`
+
 	// Tags Keywords describing or classifying the resource instance
 	Tags       *[]string              `json:"tags,omitempty"`
 	UsageState ResourceInfoUsageState `json:"usageState"`
@@ -208,6 +547,108 @@ type ResourcePoolInfo struct {
 	SiteId *string `json:"siteId,omitempty"`
 }
 
+// ResourceTypeAttribute Schema of an attribute reported for the resources of a resource type.
+type ResourceTypeAttribute struct {
+	// Description Human readable description of the attribute
+	Description string `json:"description"`
+
+	// Name The name of the attribute, with label attributes named "labels.<key>"
+	Name string `json:"name"`
+
+	// Required Whether the attribute is reported for every resource of the type
+	Required bool `json:"required"`
+
+	// Type The type of the attribute value
+	Type ResourceTypeAttributeType `json:"type"`
+}
+
+// ResourceTypeAttributeType The type of the attribute value
+type ResourceTypeAttributeType string
+
+// ResourceTypeInfo Information about a resource type, following the O2IMS information model.
+type ResourceTypeInfo struct {
+	// Attributes The attributes reported for the resources of the type
+	Attributes []ResourceTypeAttribute `json:"attributes"`
+
+	// Model The vendor model name
+	Model string `json:"model"`
+
+	// Name Human readable name of the resource type.
+	Name string `json:"name"`
+
+	// ResourceClass The class of the resources of the type
+	ResourceClass ResourceTypeInfoResourceClass `json:"resourceClass"`
+
+	// ResourceCount The number of resources of the type in the inventory
+	ResourceCount int `json:"resourceCount"`
+
+	// ResourceKind The kind of the resources of the type
+	ResourceKind ResourceTypeInfoResourceKind `json:"resourceKind"`
+
+	// ResourcePoolIds The resource pools with resources of the type
+	ResourcePoolIds []string `json:"resourcePoolIds"`
+
+	// ResourceTypeId Identifier for the resource type, derived from its vendor, model and resource class.
+	ResourceTypeId openapi_types.UUID `json:"resourceTypeId"`
+
+	// Vendor Vendor or manufacturer name
+	Vendor string `json:"vendor"`
+}
+
+// ResourceTypeInfoResourceClass The class of the resources of the type
+type ResourceTypeInfoResourceClass string
+
+// ResourceTypeInfoResourceKind The kind of the resources of the type
+type ResourceTypeInfoResourceKind string
+
+// SiteInfo Information about a site, derived from the resource pools located at it.
+type SiteInfo struct {
+	// Cores The total number of physical cores of the resources of the site, as far as reported
+	Cores int `json:"cores"`
+
+	// IdleResourceCount The number of resources of the site that are not in use
+	IdleResourceCount int `json:"idleResourceCount"`
+
+	// Memory The total physical memory of the resources of the site in MiB
+	Memory int `json:"memory"`
+
+	// ResourceCount The number of resources in the resource pools of the site
+	ResourceCount int `json:"resourceCount"`
+
+	// ResourcePoolCount The number of resource pools at the site
+	ResourcePoolCount int `json:"resourcePoolCount"`
+
+	// ResourcePoolIds The resource pools at the site
+	ResourcePoolIds []string `json:"resourcePoolIds"`
+
+	// SiteId Identifier for the site.
+	SiteId string `json:"siteId"`
+}
+
+// StorageInfo Information about a storage device
+type StorageInfo struct {
+	// Model The model of the device
+	Model *string `json:"model,omitempty"`
+
+	// Name The name of the device, as reported by hardware inspection
+	Name string `json:"name"`
+
+	// SerialNumber The serial number of the device
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// SizeBytes The size of the device in bytes
+	SizeBytes int64 `json:"sizeBytes"`
+
+	// Type The type of the device, if known
+	Type *StorageInfoType `json:"type,omitempty"`
+
+	// Vendor The vendor of the device
+	Vendor *string `json:"vendor,omitempty"`
+}
+
+// StorageInfoType The type of the device, if known
+type StorageInfoType string
+
 // Subscription Information about an inventory subscription.
 type Subscription struct {
 	// Callback The fully qualified URI to a consumer procedure which can process a Post of the
@@ -225,109 +666,413 @@ type Subscription struct {
 	SubscriptionId *openapi_types.UUID `json:"subscriptionId,omitempty"`
 }
 
+// Filter defines model for filter.
+type Filter = string
+
 // HwMgrId defines model for hwMgrId.
 type HwMgrId = string
 
+// IdempotencyKey defines model for idempotencyKey.
+type IdempotencyKey = string
+
+// ProvisioningRequestId defines model for provisioningRequestId.
+type ProvisioningRequestId = openapi_types.UUID
+
 // SubscriptionId defines model for subscriptionId.
 type SubscriptionId = openapi_types.UUID
 
-// CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
-type CreateSubscriptionJSONRequestBody = Subscription
+// CreateProvisioningRequestParams defines parameters for CreateProvisioningRequest.
+type CreateProvisioningRequestParams struct {
+	// IdempotencyKey Unique key chosen by the client to identify a provisioning request, so that a request retried with the same
+	// key is fulfilled only once.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(ctx context.Context, req *http.Request) error
+// GetResourcePoolsParams defines parameters for GetResourcePools.
+type GetResourcePoolsParams struct {
+	// Filter Criteria for selecting the items of a list, using the filter syntax of the O-RAN O2 IMS API. Each criterion
+	// is formatted as (operator,attribute,value[,value...]), and multiple criteria, separated by semicolons, must all
+	// match. The operators are eq, neq, gt, gte, lt, lte, cont, ncont, in and nin. Nested attributes are separated
+	// by slashes, such as labels/site, and values containing commas, parentheses or semicolons are enclosed in single
+	// quotes.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
 
-// Doer performs HTTP requests.
-//
-// The standard http.Client implements this interface.
-type HttpRequestDoer interface {
-	Do(req *http.Request) (*http.Response, error)
+// GetResourcePoolResourcesParams defines parameters for GetResourcePoolResources.
+type GetResourcePoolResourcesParams struct {
+	// Filter Criteria for selecting the items of a list, using the filter syntax of the O-RAN O2 IMS API. Each criterion
+	// is formatted as (operator,attribute,value[,value...]), and multiple criteria, separated by semicolons, must all
+	// match. The operators are eq, neq, gt, gte, lt, lte, cont, ncont, in and nin. Nested attributes are separated
+	// by slashes, such as labels/site, and values containing commas, parentheses or semicolons are enclosed in single
+	// quotes.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
 }
 
-// Client which conforms to the OpenAPI3 specification for this service.
-type Client struct {
-	// The endpoint of the server conforming to this interface, with scheme,
-	// https://api.deepmap.com for example. This can contain a path relative
-	// to the server, such as https://api.deepmap.com/dev-test, and all the
-	// paths in the swagger spec will be appended to the server.
-	Server string
+// GetResourcesParams defines parameters for GetResources.
+type GetResourcesParams struct {
+	// Filter Criteria for selecting the items of a list, using the filter syntax of the O-RAN O2 IMS API. Each criterion
+	// is formatted as (operator,attribute,value[,value...]), and multiple criteria, separated by semicolons, must all
+	// match. The operators are eq, neq, gt, gte, lt, lte, cont, ncont, in and nin. Nested attributes are separated
+	// by slashes, such as labels/site, and values containing commas, parentheses or semicolons are enclosed in single
+	// quotes.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+}
 
-	// Doer for performing requests, typically a *http.Client with any
-	// customized settings, such as certificate chains.
-	Client HttpRequestDoer
+// CreateProvisioningRequestJSONRequestBody defines body for CreateProvisioningRequest for application/json ContentType.
+type CreateProvisioningRequestJSONRequestBody = ProvisioningRequest
 
-	// A list of callbacks for modifying requests which are generated before sending over
-	// the network.
-	RequestEditors []RequestEditorFn
-}
+// UpdateProvisioningRequestJSONRequestBody defines body for UpdateProvisioningRequest for application/json ContentType.
+type UpdateProvisioningRequestJSONRequestBody = ProvisioningRequest
 
-// ClientOption allows setting custom parameters during construction
-type ClientOption func(*Client) error
+// CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
+type CreateSubscriptionJSONRequestBody = Subscription
 
-// Creates a new Client, with reasonable defaults
-func NewClient(server string, opts ...ClientOption) (*Client, error) {
-	// create a client with sane default values
-	client := Client{
-		Server: server,
-	}
-	// mutate client and add all optional params
-	for _, o := range opts {
-		if err := o(&client); err != nil {
-			return nil, err
-		}
-	}
-	// ensure the server URL always has a trailing slash
-	if !strings.HasSuffix(client.Server, "/") {
-		client.Server += "/"
-	}
-	// create httpClient, if not already present
-	if client.Client == nil {
-		client.Client = &http.Client{}
+// Getter for additional properties for NodePoolExtensions. Returns the specified
+// element and whether it was found
+func (a NodePoolExtensions) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
 	}
-	return &client, nil
+	return
 }
 
-// WithHTTPClient allows overriding the default Doer, which is
-// automatically created using http.Client. This is useful for tests.
-func WithHTTPClient(doer HttpRequestDoer) ClientOption {
-	return func(c *Client) error {
-		c.Client = doer
-		return nil
+// Setter for additional properties for NodePoolExtensions
+func (a *NodePoolExtensions) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
 	}
+	a.AdditionalProperties[fieldName] = value
 }
 
-// WithRequestEditorFn allows setting up a callback function, which will be
-// called right before sending the request. This can be used to mutate the request.
-func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
-	return func(c *Client) error {
-		c.RequestEditors = append(c.RequestEditors, fn)
-		return nil
+// Override default JSON handling for NodePoolExtensions to handle AdditionalProperties
+func (a *NodePoolExtensions) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
 	}
-}
 
-// The interface specification for the client above.
-type ClientInterface interface {
-	// GetAllVersions request
-	GetAllVersions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	if raw, found := object["cpuArchitecture"]; found {
+		err = json.Unmarshal(raw, &a.CpuArchitecture)
+		if err != nil {
+			return fmt.Errorf("error reading 'cpuArchitecture': %w", err)
+		}
+		delete(object, "cpuArchitecture")
+	}
 
-	// GetMinorVersions request
-	GetMinorVersions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	if raw, found := object["extensionsVersion"]; found {
+		err = json.Unmarshal(raw, &a.ExtensionsVersion)
+		if err != nil {
+			return fmt.Errorf("error reading 'extensionsVersion': %w", err)
+		}
+		delete(object, "extensionsVersion")
+	}
 
-	// GetResourcePools request
-	GetResourcePools(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error)
+	if raw, found := object["hostnameTemplate"]; found {
+		err = json.Unmarshal(raw, &a.HostnameTemplate)
+		if err != nil {
+			return fmt.Errorf("error reading 'hostnameTemplate': %w", err)
+		}
+		delete(object, "hostnameTemplate")
+	}
 
-	// GetResourcePool request
+	if raw, found := object["manifestTemplate"]; found {
+		err = json.Unmarshal(raw, &a.ManifestTemplate)
+		if err != nil {
+			return fmt.Errorf("error reading 'manifestTemplate': %w", err)
+		}
+		delete(object, "manifestTemplate")
+	}
+
+	if raw, found := object["networkDataTemplate"]; found {
+		err = json.Unmarshal(raw, &a.NetworkDataTemplate)
+		if err != nil {
+			return fmt.Errorf("error reading 'networkDataTemplate': %w", err)
+		}
+		delete(object, "networkDataTemplate")
+	}
+
+	if raw, found := object["rack"]; found {
+		err = json.Unmarshal(raw, &a.Rack)
+		if err != nil {
+			return fmt.Errorf("error reading 'rack': %w", err)
+		}
+		delete(object, "rack")
+	}
+
+	if raw, found := object["resourceTypeId"]; found {
+		err = json.Unmarshal(raw, &a.ResourceTypeId)
+		if err != nil {
+			return fmt.Errorf("error reading 'resourceTypeId': %w", err)
+		}
+		delete(object, "resourceTypeId")
+	}
+
+	if raw, found := object["site"]; found {
+		err = json.Unmarshal(raw, &a.Site)
+		if err != nil {
+			return fmt.Errorf("error reading 'site': %w", err)
+		}
+		delete(object, "site")
+	}
+
+	if raw, found := object["spreadBy"]; found {
+		err = json.Unmarshal(raw, &a.SpreadBy)
+		if err != nil {
+			return fmt.Errorf("error reading 'spreadBy': %w", err)
+		}
+		delete(object, "spreadBy")
+	}
+
+	if raw, found := object["spreadMode"]; found {
+		err = json.Unmarshal(raw, &a.SpreadMode)
+		if err != nil {
+			return fmt.Errorf("error reading 'spreadMode': %w", err)
+		}
+		delete(object, "spreadMode")
+	}
+
+	if raw, found := object["tags"]; found {
+		err = json.Unmarshal(raw, &a.Tags)
+		if err != nil {
+			return fmt.Errorf("error reading 'tags': %w", err)
+		}
+		delete(object, "tags")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for NodePoolExtensions to handle AdditionalProperties
+func (a NodePoolExtensions) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	if a.CpuArchitecture != nil {
+		object["cpuArchitecture"], err = json.Marshal(a.CpuArchitecture)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'cpuArchitecture': %w", err)
+		}
+	}
+
+	if a.ExtensionsVersion != nil {
+		object["extensionsVersion"], err = json.Marshal(a.ExtensionsVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'extensionsVersion': %w", err)
+		}
+	}
+
+	if a.HostnameTemplate != nil {
+		object["hostnameTemplate"], err = json.Marshal(a.HostnameTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'hostnameTemplate': %w", err)
+		}
+	}
+
+	if a.ManifestTemplate != nil {
+		object["manifestTemplate"], err = json.Marshal(a.ManifestTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'manifestTemplate': %w", err)
+		}
+	}
+
+	if a.NetworkDataTemplate != nil {
+		object["networkDataTemplate"], err = json.Marshal(a.NetworkDataTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'networkDataTemplate': %w", err)
+		}
+	}
+
+	if a.Rack != nil {
+		object["rack"], err = json.Marshal(a.Rack)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'rack': %w", err)
+		}
+	}
+
+	if a.ResourceTypeId != nil {
+		object["resourceTypeId"], err = json.Marshal(a.ResourceTypeId)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'resourceTypeId': %w", err)
+		}
+	}
+
+	if a.Site != nil {
+		object["site"], err = json.Marshal(a.Site)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'site': %w", err)
+		}
+	}
+
+	if a.SpreadBy != nil {
+		object["spreadBy"], err = json.Marshal(a.SpreadBy)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'spreadBy': %w", err)
+		}
+	}
+
+	if a.SpreadMode != nil {
+		object["spreadMode"], err = json.Marshal(a.SpreadMode)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'spreadMode': %w", err)
+		}
+	}
+
+	if a.Tags != nil {
+		object["tags"], err = json.Marshal(a.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'tags': %w", err)
+		}
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAllVersions request
+	GetAllVersions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPluginInfo request
+	GetPluginInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMinorVersions request
+	GetMinorVersions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAllocationReport request
+	GetAllocationReport(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RefreshInventory request
+	RefreshInventory(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateProvisioningRequestWithBody request with any body
+	CreateProvisioningRequestWithBody(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, body CreateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteProvisioningRequest request
+	DeleteProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProvisioningRequest request
+	GetProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateProvisioningRequestWithBody request with any body
+	UpdateProvisioningRequestWithBody(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, body UpdateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetResourcePools request
+	GetResourcePools(ctx context.Context, hwMgrId HwMgrId, params *GetResourcePoolsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetResourcePool request
 	GetResourcePool(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetResourcePoolResources request
-	GetResourcePoolResources(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetResourcePoolResources(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, params *GetResourcePoolResourcesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetResourceTypes request
+	GetResourceTypes(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetResourceType request
+	GetResourceType(ctx context.Context, hwMgrId HwMgrId, resourceTypeId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetResources request
-	GetResources(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetResources(ctx context.Context, hwMgrId HwMgrId, params *GetResourcesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetResource request
 	GetResource(ctx context.Context, hwMgrId HwMgrId, resourceId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetSites request
+	GetSites(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetSubscriptions request
 	GetSubscriptions(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -341,6 +1086,12 @@ type ClientInterface interface {
 
 	// GetSubscription request
 	GetSubscription(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDeadLetterNotifications request
+	GetDeadLetterNotifications(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReplayDeadLetterNotifications request
+	ReplayDeadLetterNotifications(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetAllVersions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -355,8 +1106,8 @@ func (c *Client) GetAllVersions(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetMinorVersions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetMinorVersionsRequest(c.Server)
+func (c *Client) GetPluginInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPluginInfoRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -367,8 +1118,8 @@ func (c *Client) GetMinorVersions(ctx context.Context, reqEditors ...RequestEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetResourcePools(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetResourcePoolsRequest(c.Server, hwMgrId)
+func (c *Client) GetMinorVersions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMinorVersionsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -379,8 +1130,8 @@ func (c *Client) GetResourcePools(ctx context.Context, hwMgrId HwMgrId, reqEdito
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetResourcePool(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetResourcePoolRequest(c.Server, hwMgrId, resourcePoolId)
+func (c *Client) GetAllocationReport(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAllocationReportRequest(c.Server, hwMgrId)
 	if err != nil {
 		return nil, err
 	}
@@ -391,8 +1142,8 @@ func (c *Client) GetResourcePool(ctx context.Context, hwMgrId HwMgrId, resourceP
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetResourcePoolResources(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetResourcePoolResourcesRequest(c.Server, hwMgrId, resourcePoolId)
+func (c *Client) RefreshInventory(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefreshInventoryRequest(c.Server, hwMgrId)
 	if err != nil {
 		return nil, err
 	}
@@ -403,8 +1154,8 @@ func (c *Client) GetResourcePoolResources(ctx context.Context, hwMgrId HwMgrId,
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetResources(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetResourcesRequest(c.Server, hwMgrId)
+func (c *Client) CreateProvisioningRequestWithBody(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProvisioningRequestRequestWithBody(c.Server, hwMgrId, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -415,8 +1166,8 @@ func (c *Client) GetResources(ctx context.Context, hwMgrId HwMgrId, reqEditors .
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetResource(ctx context.Context, hwMgrId HwMgrId, resourceId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetResourceRequest(c.Server, hwMgrId, resourceId)
+func (c *Client) CreateProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, body CreateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProvisioningRequestRequest(c.Server, hwMgrId, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -427,8 +1178,8 @@ func (c *Client) GetResource(ctx context.Context, hwMgrId HwMgrId, resourceId st
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetSubscriptions(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSubscriptionsRequest(c.Server, hwMgrId)
+func (c *Client) DeleteProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteProvisioningRequestRequest(c.Server, hwMgrId, provisioningRequestId)
 	if err != nil {
 		return nil, err
 	}
@@ -439,8 +1190,8 @@ func (c *Client) GetSubscriptions(ctx context.Context, hwMgrId HwMgrId, reqEdito
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateSubscriptionWithBody(ctx context.Context, hwMgrId HwMgrId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateSubscriptionRequestWithBody(c.Server, hwMgrId, contentType, body)
+func (c *Client) GetProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProvisioningRequestRequest(c.Server, hwMgrId, provisioningRequestId)
 	if err != nil {
 		return nil, err
 	}
@@ -451,8 +1202,8 @@ func (c *Client) CreateSubscriptionWithBody(ctx context.Context, hwMgrId HwMgrId
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateSubscription(ctx context.Context, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateSubscriptionRequest(c.Server, hwMgrId, body)
+func (c *Client) UpdateProvisioningRequestWithBody(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProvisioningRequestRequestWithBody(c.Server, hwMgrId, provisioningRequestId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -463,8 +1214,8 @@ func (c *Client) CreateSubscription(ctx context.Context, hwMgrId HwMgrId, body C
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteSubscription(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteSubscriptionRequest(c.Server, hwMgrId, subscriptionId)
+func (c *Client) UpdateProvisioningRequest(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, body UpdateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProvisioningRequestRequest(c.Server, hwMgrId, provisioningRequestId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -475,8 +1226,8 @@ func (c *Client) DeleteSubscription(ctx context.Context, hwMgrId HwMgrId, subscr
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetSubscription(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSubscriptionRequest(c.Server, hwMgrId, subscriptionId)
+func (c *Client) GetResourcePools(ctx context.Context, hwMgrId HwMgrId, params *GetResourcePoolsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetResourcePoolsRequest(c.Server, hwMgrId, params)
 	if err != nil {
 		return nil, err
 	}
@@ -487,77 +1238,184 @@ func (c *Client) GetSubscription(ctx context.Context, hwMgrId HwMgrId, subscript
 	return c.Client.Do(req)
 }
 
-// NewGetAllVersionsRequest generates requests for GetAllVersions
-func NewGetAllVersionsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetResourcePool(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetResourcePoolRequest(c.Server, hwMgrId, resourcePoolId)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/api_versions")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetResourcePoolResources(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, params *GetResourcePoolResourcesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetResourcePoolResourcesRequest(c.Server, hwMgrId, resourcePoolId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetMinorVersionsRequest generates requests for GetMinorVersions
-func NewGetMinorVersionsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetResourceTypes(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetResourceTypesRequest(c.Server, hwMgrId)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/api_versions")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetResourceType(ctx context.Context, hwMgrId HwMgrId, resourceTypeId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetResourceTypeRequest(c.Server, hwMgrId, resourceTypeId)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetResourcePoolsRequest generates requests for GetResourcePools
-func NewGetResourcePoolsRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+func (c *Client) GetResources(ctx context.Context, hwMgrId HwMgrId, params *GetResourcesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetResourcesRequest(c.Server, hwMgrId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	serverURL, err := url.Parse(server)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetResource(ctx context.Context, hwMgrId HwMgrId, resourceId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetResourceRequest(c.Server, hwMgrId, resourceId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourcePools", pathParam0)
+func (c *Client) GetSites(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSitesRequest(c.Server, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSubscriptions(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSubscriptionsRequest(c.Server, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSubscriptionWithBody(ctx context.Context, hwMgrId HwMgrId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSubscriptionRequestWithBody(c.Server, hwMgrId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSubscription(ctx context.Context, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSubscriptionRequest(c.Server, hwMgrId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSubscription(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSubscriptionRequest(c.Server, hwMgrId, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSubscription(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSubscriptionRequest(c.Server, hwMgrId, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDeadLetterNotifications(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDeadLetterNotificationsRequest(c.Server, hwMgrId, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplayDeadLetterNotifications(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplayDeadLetterNotificationsRequest(c.Server, hwMgrId, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAllVersionsRequest generates requests for GetAllVersions
+func NewGetAllVersionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/api_versions")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -575,30 +1433,43 @@ func NewGetResourcePoolsRequest(server string, hwMgrId HwMgrId) (*http.Request,
 	return req, nil
 }
 
-// NewGetResourcePoolRequest generates requests for GetResourcePool
-func NewGetResourcePoolRequest(server string, hwMgrId HwMgrId, resourcePoolId string) (*http.Request, error) {
+// NewGetPluginInfoRequest generates requests for GetPluginInfo
+func NewGetPluginInfoRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam1 string
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/info")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "resourcePoolId", runtime.ParamLocationPath, resourcePoolId)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
 
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMinorVersionsRequest generates requests for GetMinorVersions
+func NewGetMinorVersionsRequest(server string) (*http.Request, error) {
+	var err error
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourcePools/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/api_versions")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -616,8 +1487,8 @@ func NewGetResourcePoolRequest(server string, hwMgrId HwMgrId, resourcePoolId st
 	return req, nil
 }
 
-// NewGetResourcePoolResourcesRequest generates requests for GetResourcePoolResources
-func NewGetResourcePoolResourcesRequest(server string, hwMgrId HwMgrId, resourcePoolId string) (*http.Request, error) {
+// NewGetAllocationReportRequest generates requests for GetAllocationReport
+func NewGetAllocationReportRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -627,9 +1498,36 @@ func NewGetResourcePoolResourcesRequest(server string, hwMgrId HwMgrId, resource
 		return nil, err
 	}
 
-	var pathParam1 string
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "resourcePoolId", runtime.ParamLocationPath, resourcePoolId)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/allocationReport", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRefreshInventoryRequest generates requests for RefreshInventory
+func NewRefreshInventoryRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
 	if err != nil {
 		return nil, err
 	}
@@ -639,7 +1537,7 @@ func NewGetResourcePoolResourcesRequest(server string, hwMgrId HwMgrId, resource
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourcePools/%s/resources", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/inventory/refresh", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -649,7 +1547,7 @@ func NewGetResourcePoolResourcesRequest(server string, hwMgrId HwMgrId, resource
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -657,8 +1555,19 @@ func NewGetResourcePoolResourcesRequest(server string, hwMgrId HwMgrId, resource
 	return req, nil
 }
 
-// NewGetResourcesRequest generates requests for GetResources
-func NewGetResourcesRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
+// NewCreateProvisioningRequestRequest calls the generic CreateProvisioningRequest builder with application/json body
+func NewCreateProvisioningRequestRequest(server string, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, body CreateProvisioningRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateProvisioningRequestRequestWithBody(server, hwMgrId, params, "application/json", bodyReader)
+}
+
+// NewCreateProvisioningRequestRequestWithBody generates requests for CreateProvisioningRequest with any type of body
+func NewCreateProvisioningRequestRequestWithBody(server string, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -673,7 +1582,7 @@ func NewGetResourcesRequest(server string, hwMgrId HwMgrId) (*http.Request, erro
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resources", pathParam0)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/provisioningRequests", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -683,16 +1592,33 @@ func NewGetResourcesRequest(server string, hwMgrId HwMgrId) (*http.Request, erro
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
 	return req, nil
 }
 
-// NewGetResourceRequest generates requests for GetResource
-func NewGetResourceRequest(server string, hwMgrId HwMgrId, resourceId string) (*http.Request, error) {
+// NewDeleteProvisioningRequestRequest generates requests for DeleteProvisioningRequest
+func NewDeleteProvisioningRequestRequest(server string, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -704,7 +1630,7 @@ func NewGetResourceRequest(server string, hwMgrId HwMgrId, resourceId string) (*
 
 	var pathParam1 string
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "resourceId", runtime.ParamLocationPath, resourceId)
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "provisioningRequestId", runtime.ParamLocationPath, provisioningRequestId)
 	if err != nil {
 		return nil, err
 	}
@@ -714,7 +1640,7 @@ func NewGetResourceRequest(server string, hwMgrId HwMgrId, resourceId string) (*
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resources/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/provisioningRequests/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -724,7 +1650,7 @@ func NewGetResourceRequest(server string, hwMgrId HwMgrId, resourceId string) (*
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -732,8 +1658,8 @@ func NewGetResourceRequest(server string, hwMgrId HwMgrId, resourceId string) (*
 	return req, nil
 }
 
-// NewGetSubscriptionsRequest generates requests for GetSubscriptions
-func NewGetSubscriptionsRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
+// NewGetProvisioningRequestRequest generates requests for GetProvisioningRequest
+func NewGetProvisioningRequestRequest(server string, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -743,12 +1669,19 @@ func NewGetSubscriptionsRequest(server string, hwMgrId HwMgrId) (*http.Request,
 		return nil, err
 	}
 
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "provisioningRequestId", runtime.ParamLocationPath, provisioningRequestId)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions", pathParam0)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/provisioningRequests/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -766,19 +1699,19 @@ func NewGetSubscriptionsRequest(server string, hwMgrId HwMgrId) (*http.Request,
 	return req, nil
 }
 
-// NewCreateSubscriptionRequest calls the generic CreateSubscription builder with application/json body
-func NewCreateSubscriptionRequest(server string, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody) (*http.Request, error) {
+// NewUpdateProvisioningRequestRequest calls the generic UpdateProvisioningRequest builder with application/json body
+func NewUpdateProvisioningRequestRequest(server string, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, body UpdateProvisioningRequestJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewCreateSubscriptionRequestWithBody(server, hwMgrId, "application/json", bodyReader)
+	return NewUpdateProvisioningRequestRequestWithBody(server, hwMgrId, provisioningRequestId, "application/json", bodyReader)
 }
 
-// NewCreateSubscriptionRequestWithBody generates requests for CreateSubscription with any type of body
-func NewCreateSubscriptionRequestWithBody(server string, hwMgrId HwMgrId, contentType string, body io.Reader) (*http.Request, error) {
+// NewUpdateProvisioningRequestRequestWithBody generates requests for UpdateProvisioningRequest with any type of body
+func NewUpdateProvisioningRequestRequestWithBody(server string, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -788,12 +1721,19 @@ func NewCreateSubscriptionRequestWithBody(server string, hwMgrId HwMgrId, conten
 		return nil, err
 	}
 
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "provisioningRequestId", runtime.ParamLocationPath, provisioningRequestId)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions", pathParam0)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/provisioningRequests/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -803,7 +1743,7 @@ func NewCreateSubscriptionRequestWithBody(server string, hwMgrId HwMgrId, conten
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -813,8 +1753,8 @@ func NewCreateSubscriptionRequestWithBody(server string, hwMgrId HwMgrId, conten
 	return req, nil
 }
 
-// NewDeleteSubscriptionRequest generates requests for DeleteSubscription
-func NewDeleteSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId SubscriptionId) (*http.Request, error) {
+// NewGetResourcePoolsRequest generates requests for GetResourcePools
+func NewGetResourcePoolsRequest(server string, hwMgrId HwMgrId, params *GetResourcePoolsParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -824,19 +1764,12 @@ func NewDeleteSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId
 		return nil, err
 	}
 
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourcePools", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -846,7 +1779,29 @@ func NewDeleteSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -854,8 +1809,8 @@ func NewDeleteSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId
 	return req, nil
 }
 
-// NewGetSubscriptionRequest generates requests for GetSubscription
-func NewGetSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId SubscriptionId) (*http.Request, error) {
+// NewGetResourcePoolRequest generates requests for GetResourcePool
+func NewGetResourcePoolRequest(server string, hwMgrId HwMgrId, resourcePoolId string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -867,7 +1822,7 @@ func NewGetSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId Su
 
 	var pathParam1 string
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "resourcePoolId", runtime.ParamLocationPath, resourcePoolId)
 	if err != nil {
 		return nil, err
 	}
@@ -877,7 +1832,7 @@ func NewGetSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId Su
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourcePools/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -895,487 +1850,1991 @@ func NewGetSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId Su
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	return nil
-}
+// NewGetResourcePoolResourcesRequest generates requests for GetResourcePoolResources
+func NewGetResourcePoolResourcesRequest(server string, hwMgrId HwMgrId, resourcePoolId string, params *GetResourcePoolResourcesParams) (*http.Request, error) {
+	var err error
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	var pathParam0 string
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
-
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
-	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetAllVersionsWithResponse request
-	GetAllVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllVersionsResponse, error)
+	var pathParam1 string
 
-	// GetMinorVersionsWithResponse request
-	GetMinorVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMinorVersionsResponse, error)
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "resourcePoolId", runtime.ParamLocationPath, resourcePoolId)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetResourcePoolsWithResponse request
-	GetResourcePoolsWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetResourcePoolsResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetResourcePoolWithResponse request
-	GetResourcePoolWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*GetResourcePoolResponse, error)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourcePools/%s/resources", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// GetResourcePoolResourcesWithResponse request
-	GetResourcePoolResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*GetResourcePoolResourcesResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetResourcesWithResponse request
-	GetResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetResourcesResponse, error)
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	// GetResourceWithResponse request
-	GetResourceWithResponse(ctx context.Context, hwMgrId HwMgrId, resourceId string, reqEditors ...RequestEditorFn) (*GetResourceResponse, error)
+		if params.Filter != nil {
 
-	// GetSubscriptionsWithResponse request
-	GetSubscriptionsWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetSubscriptionsResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// CreateSubscriptionWithBodyWithResponse request with any body
-	CreateSubscriptionWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error)
+		}
 
-	CreateSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error)
+		queryURL.RawQuery = queryValues.Encode()
+	}
 
-	// DeleteSubscriptionWithResponse request
-	DeleteSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*DeleteSubscriptionResponse, error)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetSubscriptionWithResponse request
-	GetSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*GetSubscriptionResponse, error)
+	return req, nil
 }
 
-type GetAllVersionsResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *APIVersions
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-}
+// NewGetResourceTypesRequest generates requests for GetResourceTypes
+func NewGetResourceTypesRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
+	var err error
 
-// Status returns HTTPResponse.Status
-func (r GetAllVersionsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetAllVersionsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetMinorVersionsResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *APIVersions
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-}
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourceTypes", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetMinorVersionsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetMinorVersionsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetResourcePoolsResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *[]ResourcePoolInfo
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON403 *ProblemDetails
-	ApplicationProblemJSON404 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-	ApplicationProblemJSON503 *ProblemDetails
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetResourcePoolsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+// NewGetResourceTypeRequest generates requests for GetResourceType
+func NewGetResourceTypeRequest(server string, hwMgrId HwMgrId, resourceTypeId openapi_types.UUID) (*http.Request, error) {
+	var err error
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetResourcePoolsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetResourcePoolResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *ResourcePoolInfo
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON404 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-}
+	var pathParam1 string
 
-// Status returns HTTPResponse.Status
-func (r GetResourcePoolResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "resourceTypeId", runtime.ParamLocationPath, resourceTypeId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetResourcePoolResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetResourcePoolResourcesResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *[]ResourceInfo
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-}
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resourceTypes/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetResourcePoolResourcesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetResourcePoolResourcesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetResourcesResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *[]ResourceInfo
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON404 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-	ApplicationProblemJSON503 *ProblemDetails
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetResourcesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewGetResourcesRequest generates requests for GetResources
+func NewGetResourcesRequest(server string, hwMgrId HwMgrId, params *GetResourcesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetResourcesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetResourceResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *ResourceInfo
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON404 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-}
-
-// Status returns HTTPResponse.Status
-func (r GetResourceResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resources", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetResourceResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetSubscriptionsResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *[]Subscription
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON401 *ProblemDetails
-	ApplicationProblemJSON403 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-// Status returns HTTPResponse.Status
-func (r GetSubscriptionsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSubscriptionsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type CreateSubscriptionResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON201                   *Subscription
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON401 *ProblemDetails
-	ApplicationProblemJSON403 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r CreateSubscriptionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewGetResourceRequest generates requests for GetResource
+func NewGetResourceRequest(server string, hwMgrId HwMgrId, resourceId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CreateSubscriptionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "resourceId", runtime.ParamLocationPath, resourceId)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type DeleteSubscriptionResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	ApplicationProblemJSON401 *ProblemDetails
-	ApplicationProblemJSON403 *ProblemDetails
-	ApplicationProblemJSON404 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
-}
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r DeleteSubscriptionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/resources/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteSubscriptionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetSubscriptionResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *Subscription
-	ApplicationProblemJSON400 *ProblemDetails
-	ApplicationProblemJSON401 *ProblemDetails
-	ApplicationProblemJSON403 *ProblemDetails
-	ApplicationProblemJSON404 *ProblemDetails
-	ApplicationProblemJSON500 *ProblemDetails
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSubscriptionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewGetSitesRequest generates requests for GetSites
+func NewGetSitesRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSubscriptionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-// GetAllVersionsWithResponse request returning *GetAllVersionsResponse
-func (c *ClientWithResponses) GetAllVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllVersionsResponse, error) {
-	rsp, err := c.GetAllVersions(ctx, reqEditors...)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/sites", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetAllVersionsResponse(rsp)
-}
 
-// GetMinorVersionsWithResponse request returning *GetMinorVersionsResponse
-func (c *ClientWithResponses) GetMinorVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMinorVersionsResponse, error) {
-	rsp, err := c.GetMinorVersions(ctx, reqEditors...)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetMinorVersionsResponse(rsp)
+
+	return req, nil
 }
 
-// GetResourcePoolsWithResponse request returning *GetResourcePoolsResponse
-func (c *ClientWithResponses) GetResourcePoolsWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetResourcePoolsResponse, error) {
-	rsp, err := c.GetResourcePools(ctx, hwMgrId, reqEditors...)
+// NewGetSubscriptionsRequest generates requests for GetSubscriptions
+func NewGetSubscriptionsRequest(server string, hwMgrId HwMgrId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetResourcePoolsResponse(rsp)
-}
 
-// GetResourcePoolWithResponse request returning *GetResourcePoolResponse
-func (c *ClientWithResponses) GetResourcePoolWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*GetResourcePoolResponse, error) {
-	rsp, err := c.GetResourcePool(ctx, hwMgrId, resourcePoolId, reqEditors...)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetResourcePoolResponse(rsp)
-}
 
-// GetResourcePoolResourcesWithResponse request returning *GetResourcePoolResourcesResponse
-func (c *ClientWithResponses) GetResourcePoolResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*GetResourcePoolResourcesResponse, error) {
-	rsp, err := c.GetResourcePoolResources(ctx, hwMgrId, resourcePoolId, reqEditors...)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetResourcePoolResourcesResponse(rsp)
-}
 
-// GetResourcesWithResponse request returning *GetResourcesResponse
-func (c *ClientWithResponses) GetResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetResourcesResponse, error) {
-	rsp, err := c.GetResources(ctx, hwMgrId, reqEditors...)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetResourcesResponse(rsp)
+
+	return req, nil
 }
 
-// GetResourceWithResponse request returning *GetResourceResponse
-func (c *ClientWithResponses) GetResourceWithResponse(ctx context.Context, hwMgrId HwMgrId, resourceId string, reqEditors ...RequestEditorFn) (*GetResourceResponse, error) {
-	rsp, err := c.GetResource(ctx, hwMgrId, resourceId, reqEditors...)
+// NewCreateSubscriptionRequest calls the generic CreateSubscription builder with application/json body
+func NewCreateSubscriptionRequest(server string, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetResourceResponse(rsp)
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSubscriptionRequestWithBody(server, hwMgrId, "application/json", bodyReader)
 }
 
-// GetSubscriptionsWithResponse request returning *GetSubscriptionsResponse
-func (c *ClientWithResponses) GetSubscriptionsWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetSubscriptionsResponse, error) {
-	rsp, err := c.GetSubscriptions(ctx, hwMgrId, reqEditors...)
+// NewCreateSubscriptionRequestWithBody generates requests for CreateSubscription with any type of body
+func NewCreateSubscriptionRequestWithBody(server string, hwMgrId HwMgrId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetSubscriptionsResponse(rsp)
-}
 
-// CreateSubscriptionWithBodyWithResponse request with arbitrary body returning *CreateSubscriptionResponse
-func (c *ClientWithResponses) CreateSubscriptionWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error) {
-	rsp, err := c.CreateSubscriptionWithBody(ctx, hwMgrId, contentType, body, reqEditors...)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return ParseCreateSubscriptionResponse(rsp)
-}
 
-func (c *ClientWithResponses) CreateSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error) {
-	rsp, err := c.CreateSubscription(ctx, hwMgrId, body, reqEditors...)
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return ParseCreateSubscriptionResponse(rsp)
-}
 
-// DeleteSubscriptionWithResponse request returning *DeleteSubscriptionResponse
-func (c *ClientWithResponses) DeleteSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*DeleteSubscriptionResponse, error) {
-	rsp, err := c.DeleteSubscription(ctx, hwMgrId, subscriptionId, reqEditors...)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteSubscriptionResponse(rsp)
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// GetSubscriptionWithResponse request returning *GetSubscriptionResponse
-func (c *ClientWithResponses) GetSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*GetSubscriptionResponse, error) {
-	rsp, err := c.GetSubscription(ctx, hwMgrId, subscriptionId, reqEditors...)
-	if err != nil {
-		return nil, err
+// NewDeleteSubscriptionRequest generates requests for DeleteSubscription
+func NewDeleteSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId SubscriptionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSubscriptionRequest generates requests for GetSubscription
+func NewGetSubscriptionRequest(server string, hwMgrId HwMgrId, subscriptionId SubscriptionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDeadLetterNotificationsRequest generates requests for GetDeadLetterNotifications
+func NewGetDeadLetterNotificationsRequest(server string, hwMgrId HwMgrId, subscriptionId SubscriptionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions/%s/dead-letters", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReplayDeadLetterNotificationsRequest generates requests for ReplayDeadLetterNotifications
+func NewReplayDeadLetterNotificationsRequest(server string, hwMgrId HwMgrId, subscriptionId SubscriptionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "hwMgrId", runtime.ParamLocationPath, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/hardware-manager/inventory/v1/manager/%s/subscriptions/%s/dead-letters/replay", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAllVersionsWithResponse request
+	GetAllVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllVersionsResponse, error)
+
+	// GetPluginInfoWithResponse request
+	GetPluginInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPluginInfoResponse, error)
+
+	// GetMinorVersionsWithResponse request
+	GetMinorVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMinorVersionsResponse, error)
+
+	// GetAllocationReportWithResponse request
+	GetAllocationReportWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetAllocationReportResponse, error)
+
+	// RefreshInventoryWithResponse request
+	RefreshInventoryWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*RefreshInventoryResponse, error)
+
+	// CreateProvisioningRequestWithBodyWithResponse request with any body
+	CreateProvisioningRequestWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProvisioningRequestResponse, error)
+
+	CreateProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, body CreateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProvisioningRequestResponse, error)
+
+	// DeleteProvisioningRequestWithResponse request
+	DeleteProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*DeleteProvisioningRequestResponse, error)
+
+	// GetProvisioningRequestWithResponse request
+	GetProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*GetProvisioningRequestResponse, error)
+
+	// UpdateProvisioningRequestWithBodyWithResponse request with any body
+	UpdateProvisioningRequestWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProvisioningRequestResponse, error)
+
+	UpdateProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, body UpdateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProvisioningRequestResponse, error)
+
+	// GetResourcePoolsWithResponse request
+	GetResourcePoolsWithResponse(ctx context.Context, hwMgrId HwMgrId, params *GetResourcePoolsParams, reqEditors ...RequestEditorFn) (*GetResourcePoolsResponse, error)
+
+	// GetResourcePoolWithResponse request
+	GetResourcePoolWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*GetResourcePoolResponse, error)
+
+	// GetResourcePoolResourcesWithResponse request
+	GetResourcePoolResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, params *GetResourcePoolResourcesParams, reqEditors ...RequestEditorFn) (*GetResourcePoolResourcesResponse, error)
+
+	// GetResourceTypesWithResponse request
+	GetResourceTypesWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetResourceTypesResponse, error)
+
+	// GetResourceTypeWithResponse request
+	GetResourceTypeWithResponse(ctx context.Context, hwMgrId HwMgrId, resourceTypeId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetResourceTypeResponse, error)
+
+	// GetResourcesWithResponse request
+	GetResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, params *GetResourcesParams, reqEditors ...RequestEditorFn) (*GetResourcesResponse, error)
+
+	// GetResourceWithResponse request
+	GetResourceWithResponse(ctx context.Context, hwMgrId HwMgrId, resourceId string, reqEditors ...RequestEditorFn) (*GetResourceResponse, error)
+
+	// GetSitesWithResponse request
+	GetSitesWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetSitesResponse, error)
+
+	// GetSubscriptionsWithResponse request
+	GetSubscriptionsWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetSubscriptionsResponse, error)
+
+	// CreateSubscriptionWithBodyWithResponse request with any body
+	CreateSubscriptionWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error)
+
+	CreateSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error)
+
+	// DeleteSubscriptionWithResponse request
+	DeleteSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*DeleteSubscriptionResponse, error)
+
+	// GetSubscriptionWithResponse request
+	GetSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*GetSubscriptionResponse, error)
+
+	// GetDeadLetterNotificationsWithResponse request
+	GetDeadLetterNotificationsWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*GetDeadLetterNotificationsResponse, error)
+
+	// ReplayDeadLetterNotificationsWithResponse request
+	ReplayDeadLetterNotificationsWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*ReplayDeadLetterNotificationsResponse, error)
+}
+
+type GetAllVersionsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *APIVersions
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAllVersionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAllVersionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPluginInfoResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *PluginInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPluginInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPluginInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMinorVersionsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *APIVersions
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMinorVersionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMinorVersionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAllocationReportResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *AllocationReport
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON501 *ProblemDetails
+	ApplicationProblemJSON503 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAllocationReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAllocationReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RefreshInventoryResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *InventoryRefreshResult
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON501 *ProblemDetails
+	ApplicationProblemJSON503 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r RefreshInventoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RefreshInventoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateProvisioningRequestResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON202                   *ProvisioningRequestStatus
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON409 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON501 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateProvisioningRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateProvisioningRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteProvisioningRequestResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON501 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteProvisioningRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteProvisioningRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProvisioningRequestResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ProvisioningRequestStatus
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON501 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProvisioningRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProvisioningRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateProvisioningRequestResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON202                   *ProvisioningRequestStatus
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON409 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON501 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateProvisioningRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateProvisioningRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetResourcePoolsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]ResourcePoolInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON503 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResourcePoolsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResourcePoolsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetResourcePoolResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ResourcePoolInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResourcePoolResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResourcePoolResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetResourcePoolResourcesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]ResourceInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResourcePoolResourcesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResourcePoolResourcesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetResourceTypesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]ResourceTypeInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON503 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResourceTypesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResourceTypesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetResourceTypeResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ResourceTypeInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON503 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResourceTypeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResourceTypeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetResourcesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]ResourceInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON503 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResourcesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResourcesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetResourceResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ResourceInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResourceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResourceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSitesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]SiteInfo
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+	ApplicationProblemJSON503 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSitesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSitesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSubscriptionsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Subscription
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSubscriptionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSubscriptionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSubscriptionResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *Subscription
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSubscriptionResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSubscriptionResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Subscription
+	ApplicationProblemJSON400 *ProblemDetails
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDeadLetterNotificationsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]DeadLetterNotification
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDeadLetterNotificationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDeadLetterNotificationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReplayDeadLetterNotificationsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ReplayResult
+	ApplicationProblemJSON401 *ProblemDetails
+	ApplicationProblemJSON403 *ProblemDetails
+	ApplicationProblemJSON404 *ProblemDetails
+	ApplicationProblemJSON500 *ProblemDetails
+}
+
+// Status returns HTTPResponse.Status
+func (r ReplayDeadLetterNotificationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReplayDeadLetterNotificationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAllVersionsWithResponse request returning *GetAllVersionsResponse
+func (c *ClientWithResponses) GetAllVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllVersionsResponse, error) {
+	rsp, err := c.GetAllVersions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAllVersionsResponse(rsp)
+}
+
+// GetPluginInfoWithResponse request returning *GetPluginInfoResponse
+func (c *ClientWithResponses) GetPluginInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPluginInfoResponse, error) {
+	rsp, err := c.GetPluginInfo(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPluginInfoResponse(rsp)
+}
+
+// GetMinorVersionsWithResponse request returning *GetMinorVersionsResponse
+func (c *ClientWithResponses) GetMinorVersionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMinorVersionsResponse, error) {
+	rsp, err := c.GetMinorVersions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMinorVersionsResponse(rsp)
+}
+
+// GetAllocationReportWithResponse request returning *GetAllocationReportResponse
+func (c *ClientWithResponses) GetAllocationReportWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetAllocationReportResponse, error) {
+	rsp, err := c.GetAllocationReport(ctx, hwMgrId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAllocationReportResponse(rsp)
+}
+
+// RefreshInventoryWithResponse request returning *RefreshInventoryResponse
+func (c *ClientWithResponses) RefreshInventoryWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*RefreshInventoryResponse, error) {
+	rsp, err := c.RefreshInventory(ctx, hwMgrId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefreshInventoryResponse(rsp)
+}
+
+// CreateProvisioningRequestWithBodyWithResponse request with arbitrary body returning *CreateProvisioningRequestResponse
+func (c *ClientWithResponses) CreateProvisioningRequestWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProvisioningRequestResponse, error) {
+	rsp, err := c.CreateProvisioningRequestWithBody(ctx, hwMgrId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProvisioningRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, params *CreateProvisioningRequestParams, body CreateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProvisioningRequestResponse, error) {
+	rsp, err := c.CreateProvisioningRequest(ctx, hwMgrId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProvisioningRequestResponse(rsp)
+}
+
+// DeleteProvisioningRequestWithResponse request returning *DeleteProvisioningRequestResponse
+func (c *ClientWithResponses) DeleteProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*DeleteProvisioningRequestResponse, error) {
+	rsp, err := c.DeleteProvisioningRequest(ctx, hwMgrId, provisioningRequestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteProvisioningRequestResponse(rsp)
+}
+
+// GetProvisioningRequestWithResponse request returning *GetProvisioningRequestResponse
+func (c *ClientWithResponses) GetProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, reqEditors ...RequestEditorFn) (*GetProvisioningRequestResponse, error) {
+	rsp, err := c.GetProvisioningRequest(ctx, hwMgrId, provisioningRequestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProvisioningRequestResponse(rsp)
+}
+
+// UpdateProvisioningRequestWithBodyWithResponse request with arbitrary body returning *UpdateProvisioningRequestResponse
+func (c *ClientWithResponses) UpdateProvisioningRequestWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProvisioningRequestResponse, error) {
+	rsp, err := c.UpdateProvisioningRequestWithBody(ctx, hwMgrId, provisioningRequestId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProvisioningRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateProvisioningRequestWithResponse(ctx context.Context, hwMgrId HwMgrId, provisioningRequestId ProvisioningRequestId, body UpdateProvisioningRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProvisioningRequestResponse, error) {
+	rsp, err := c.UpdateProvisioningRequest(ctx, hwMgrId, provisioningRequestId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProvisioningRequestResponse(rsp)
+}
+
+// GetResourcePoolsWithResponse request returning *GetResourcePoolsResponse
+func (c *ClientWithResponses) GetResourcePoolsWithResponse(ctx context.Context, hwMgrId HwMgrId, params *GetResourcePoolsParams, reqEditors ...RequestEditorFn) (*GetResourcePoolsResponse, error) {
+	rsp, err := c.GetResourcePools(ctx, hwMgrId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResourcePoolsResponse(rsp)
+}
+
+// GetResourcePoolWithResponse request returning *GetResourcePoolResponse
+func (c *ClientWithResponses) GetResourcePoolWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, reqEditors ...RequestEditorFn) (*GetResourcePoolResponse, error) {
+	rsp, err := c.GetResourcePool(ctx, hwMgrId, resourcePoolId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResourcePoolResponse(rsp)
+}
+
+// GetResourcePoolResourcesWithResponse request returning *GetResourcePoolResourcesResponse
+func (c *ClientWithResponses) GetResourcePoolResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, resourcePoolId string, params *GetResourcePoolResourcesParams, reqEditors ...RequestEditorFn) (*GetResourcePoolResourcesResponse, error) {
+	rsp, err := c.GetResourcePoolResources(ctx, hwMgrId, resourcePoolId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResourcePoolResourcesResponse(rsp)
+}
+
+// GetResourceTypesWithResponse request returning *GetResourceTypesResponse
+func (c *ClientWithResponses) GetResourceTypesWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetResourceTypesResponse, error) {
+	rsp, err := c.GetResourceTypes(ctx, hwMgrId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResourceTypesResponse(rsp)
+}
+
+// GetResourceTypeWithResponse request returning *GetResourceTypeResponse
+func (c *ClientWithResponses) GetResourceTypeWithResponse(ctx context.Context, hwMgrId HwMgrId, resourceTypeId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetResourceTypeResponse, error) {
+	rsp, err := c.GetResourceType(ctx, hwMgrId, resourceTypeId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResourceTypeResponse(rsp)
+}
+
+// GetResourcesWithResponse request returning *GetResourcesResponse
+func (c *ClientWithResponses) GetResourcesWithResponse(ctx context.Context, hwMgrId HwMgrId, params *GetResourcesParams, reqEditors ...RequestEditorFn) (*GetResourcesResponse, error) {
+	rsp, err := c.GetResources(ctx, hwMgrId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResourcesResponse(rsp)
+}
+
+// GetResourceWithResponse request returning *GetResourceResponse
+func (c *ClientWithResponses) GetResourceWithResponse(ctx context.Context, hwMgrId HwMgrId, resourceId string, reqEditors ...RequestEditorFn) (*GetResourceResponse, error) {
+	rsp, err := c.GetResource(ctx, hwMgrId, resourceId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResourceResponse(rsp)
+}
+
+// GetSitesWithResponse request returning *GetSitesResponse
+func (c *ClientWithResponses) GetSitesWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetSitesResponse, error) {
+	rsp, err := c.GetSites(ctx, hwMgrId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSitesResponse(rsp)
+}
+
+// GetSubscriptionsWithResponse request returning *GetSubscriptionsResponse
+func (c *ClientWithResponses) GetSubscriptionsWithResponse(ctx context.Context, hwMgrId HwMgrId, reqEditors ...RequestEditorFn) (*GetSubscriptionsResponse, error) {
+	rsp, err := c.GetSubscriptions(ctx, hwMgrId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSubscriptionsResponse(rsp)
+}
+
+// CreateSubscriptionWithBodyWithResponse request with arbitrary body returning *CreateSubscriptionResponse
+func (c *ClientWithResponses) CreateSubscriptionWithBodyWithResponse(ctx context.Context, hwMgrId HwMgrId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error) {
+	rsp, err := c.CreateSubscriptionWithBody(ctx, hwMgrId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSubscriptionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error) {
+	rsp, err := c.CreateSubscription(ctx, hwMgrId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSubscriptionResponse(rsp)
+}
+
+// DeleteSubscriptionWithResponse request returning *DeleteSubscriptionResponse
+func (c *ClientWithResponses) DeleteSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*DeleteSubscriptionResponse, error) {
+	rsp, err := c.DeleteSubscription(ctx, hwMgrId, subscriptionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSubscriptionResponse(rsp)
+}
+
+// GetSubscriptionWithResponse request returning *GetSubscriptionResponse
+func (c *ClientWithResponses) GetSubscriptionWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*GetSubscriptionResponse, error) {
+	rsp, err := c.GetSubscription(ctx, hwMgrId, subscriptionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSubscriptionResponse(rsp)
+}
+
+// GetDeadLetterNotificationsWithResponse request returning *GetDeadLetterNotificationsResponse
+func (c *ClientWithResponses) GetDeadLetterNotificationsWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*GetDeadLetterNotificationsResponse, error) {
+	rsp, err := c.GetDeadLetterNotifications(ctx, hwMgrId, subscriptionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDeadLetterNotificationsResponse(rsp)
+}
+
+// ReplayDeadLetterNotificationsWithResponse request returning *ReplayDeadLetterNotificationsResponse
+func (c *ClientWithResponses) ReplayDeadLetterNotificationsWithResponse(ctx context.Context, hwMgrId HwMgrId, subscriptionId SubscriptionId, reqEditors ...RequestEditorFn) (*ReplayDeadLetterNotificationsResponse, error) {
+	rsp, err := c.ReplayDeadLetterNotifications(ctx, hwMgrId, subscriptionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplayDeadLetterNotificationsResponse(rsp)
+}
+
+// ParseGetAllVersionsResponse parses an HTTP response from a GetAllVersionsWithResponse call
+func ParseGetAllVersionsResponse(rsp *http.Response) (*GetAllVersionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAllVersionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest APIVersions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPluginInfoResponse parses an HTTP response from a GetPluginInfoWithResponse call
+func ParseGetPluginInfoResponse(rsp *http.Response) (*GetPluginInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPluginInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PluginInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMinorVersionsResponse parses an HTTP response from a GetMinorVersionsWithResponse call
+func ParseGetMinorVersionsResponse(rsp *http.Response) (*GetMinorVersionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMinorVersionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest APIVersions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAllocationReportResponse parses an HTTP response from a GetAllocationReportWithResponse call
+func ParseGetAllocationReportResponse(rsp *http.Response) (*GetAllocationReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAllocationReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AllocationReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRefreshInventoryResponse parses an HTTP response from a RefreshInventoryWithResponse call
+func ParseRefreshInventoryResponse(rsp *http.Response) (*RefreshInventoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RefreshInventoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InventoryRefreshResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateProvisioningRequestResponse parses an HTTP response from a CreateProvisioningRequestWithResponse call
+func ParseCreateProvisioningRequestResponse(rsp *http.Response) (*CreateProvisioningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateProvisioningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest ProvisioningRequestStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON501 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteProvisioningRequestResponse parses an HTTP response from a DeleteProvisioningRequestWithResponse call
+func ParseDeleteProvisioningRequestResponse(rsp *http.Response) (*DeleteProvisioningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteProvisioningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON501 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProvisioningRequestResponse parses an HTTP response from a GetProvisioningRequestWithResponse call
+func ParseGetProvisioningRequestResponse(rsp *http.Response) (*GetProvisioningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProvisioningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProvisioningRequestStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON501 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateProvisioningRequestResponse parses an HTTP response from a UpdateProvisioningRequestWithResponse call
+func ParseUpdateProvisioningRequestResponse(rsp *http.Response) (*UpdateProvisioningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateProvisioningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest ProvisioningRequestStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON501 = &dest
+
 	}
-	return ParseGetSubscriptionResponse(rsp)
+
+	return response, nil
 }
 
-// ParseGetAllVersionsResponse parses an HTTP response from a GetAllVersionsWithResponse call
-func ParseGetAllVersionsResponse(rsp *http.Response) (*GetAllVersionsResponse, error) {
+// ParseGetResourcePoolsResponse parses an HTTP response from a GetResourcePoolsWithResponse call
+func ParseGetResourcePoolsResponse(rsp *http.Response) (*GetResourcePoolsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetAllVersionsResponse{
+	response := &GetResourcePoolsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest APIVersions
+		var dest []ResourcePoolInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1388,6 +3847,20 @@ func ParseGetAllVersionsResponse(rsp *http.Response) (*GetAllVersionsResponse, e
 		}
 		response.ApplicationProblemJSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ProblemDetails
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1395,27 +3868,34 @@ func ParseGetAllVersionsResponse(rsp *http.Response) (*GetAllVersionsResponse, e
 		}
 		response.ApplicationProblemJSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON503 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetMinorVersionsResponse parses an HTTP response from a GetMinorVersionsWithResponse call
-func ParseGetMinorVersionsResponse(rsp *http.Response) (*GetMinorVersionsResponse, error) {
+// ParseGetResourcePoolResponse parses an HTTP response from a GetResourcePoolWithResponse call
+func ParseGetResourcePoolResponse(rsp *http.Response) (*GetResourcePoolResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetMinorVersionsResponse{
+	response := &GetResourcePoolResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest APIVersions
+		var dest ResourcePoolInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1428,6 +3908,13 @@ func ParseGetMinorVersionsResponse(rsp *http.Response) (*GetMinorVersionsRespons
 		}
 		response.ApplicationProblemJSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ProblemDetails
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1440,22 +3927,22 @@ func ParseGetMinorVersionsResponse(rsp *http.Response) (*GetMinorVersionsRespons
 	return response, nil
 }
 
-// ParseGetResourcePoolsResponse parses an HTTP response from a GetResourcePoolsWithResponse call
-func ParseGetResourcePoolsResponse(rsp *http.Response) (*GetResourcePoolsResponse, error) {
+// ParseGetResourcePoolResourcesResponse parses an HTTP response from a GetResourcePoolResourcesWithResponse call
+func ParseGetResourcePoolResourcesResponse(rsp *http.Response) (*GetResourcePoolResourcesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetResourcePoolsResponse{
+	response := &GetResourcePoolResourcesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ResourcePoolInfo
+		var dest []ResourceInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1468,12 +3955,45 @@ func ParseGetResourcePoolsResponse(rsp *http.Response) (*GetResourcePoolsRespons
 		}
 		response.ApplicationProblemJSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ProblemDetails
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.ApplicationProblemJSON403 = &dest
+		response.ApplicationProblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetResourceTypesResponse parses an HTTP response from a GetResourceTypesWithResponse call
+func ParseGetResourceTypesResponse(rsp *http.Response) (*GetResourceTypesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetResourceTypesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ResourceTypeInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest ProblemDetails
@@ -1501,22 +4021,22 @@ func ParseGetResourcePoolsResponse(rsp *http.Response) (*GetResourcePoolsRespons
 	return response, nil
 }
 
-// ParseGetResourcePoolResponse parses an HTTP response from a GetResourcePoolWithResponse call
-func ParseGetResourcePoolResponse(rsp *http.Response) (*GetResourcePoolResponse, error) {
+// ParseGetResourceTypeResponse parses an HTTP response from a GetResourceTypeWithResponse call
+func ParseGetResourceTypeResponse(rsp *http.Response) (*GetResourceTypeResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetResourcePoolResponse{
+	response := &GetResourceTypeResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ResourcePoolInfo
+		var dest ResourceTypeInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1543,20 +4063,27 @@ func ParseGetResourcePoolResponse(rsp *http.Response) (*GetResourcePoolResponse,
 		}
 		response.ApplicationProblemJSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON503 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetResourcePoolResourcesResponse parses an HTTP response from a GetResourcePoolResourcesWithResponse call
-func ParseGetResourcePoolResourcesResponse(rsp *http.Response) (*GetResourcePoolResourcesResponse, error) {
+// ParseGetResourcesResponse parses an HTTP response from a GetResourcesWithResponse call
+func ParseGetResourcesResponse(rsp *http.Response) (*GetResourcesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetResourcePoolResourcesResponse{
+	response := &GetResourcesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1576,6 +4103,13 @@ func ParseGetResourcePoolResourcesResponse(rsp *http.Response) (*GetResourcePool
 		}
 		response.ApplicationProblemJSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ProblemDetails
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1583,27 +4117,34 @@ func ParseGetResourcePoolResourcesResponse(rsp *http.Response) (*GetResourcePool
 		}
 		response.ApplicationProblemJSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON503 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetResourcesResponse parses an HTTP response from a GetResourcesWithResponse call
-func ParseGetResourcesResponse(rsp *http.Response) (*GetResourcesResponse, error) {
+// ParseGetResourceResponse parses an HTTP response from a GetResourceWithResponse call
+func ParseGetResourceResponse(rsp *http.Response) (*GetResourceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetResourcesResponse{
+	response := &GetResourceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ResourceInfo
+		var dest ResourceInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1630,34 +4171,27 @@ func ParseGetResourcesResponse(rsp *http.Response) (*GetResourcesResponse, error
 		}
 		response.ApplicationProblemJSON500 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
-		var dest ProblemDetails
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.ApplicationProblemJSON503 = &dest
-
 	}
 
 	return response, nil
 }
 
-// ParseGetResourceResponse parses an HTTP response from a GetResourceWithResponse call
-func ParseGetResourceResponse(rsp *http.Response) (*GetResourceResponse, error) {
+// ParseGetSitesResponse parses an HTTP response from a GetSitesWithResponse call
+func ParseGetSitesResponse(rsp *http.Response) (*GetSitesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetResourceResponse{
+	response := &GetSitesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ResourceInfo
+		var dest []SiteInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1684,6 +4218,13 @@ func ParseGetResourceResponse(rsp *http.Response) (*GetResourceResponse, error)
 		}
 		response.ApplicationProblemJSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON503 = &dest
+
 	}
 
 	return response, nil
@@ -1905,59 +4446,247 @@ func ParseGetSubscriptionResponse(rsp *http.Response) (*GetSubscriptionResponse,
 	return response, nil
 }
 
+// ParseGetDeadLetterNotificationsResponse parses an HTTP response from a GetDeadLetterNotificationsWithResponse call
+func ParseGetDeadLetterNotificationsResponse(rsp *http.Response) (*GetDeadLetterNotificationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDeadLetterNotificationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []DeadLetterNotification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReplayDeadLetterNotificationsResponse parses an HTTP response from a ReplayDeadLetterNotificationsWithResponse call
+func ParseReplayDeadLetterNotificationsResponse(rsp *http.Response) (*ReplayDeadLetterNotificationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReplayDeadLetterNotificationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReplayResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ProblemDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xce3PbtrL/KhjeO3PbuZTkVz0++s+xnUTTxPb40fZM5OlA5FJECwIsAMpWPfruZwDw",
-	"TUii82icHP8VhQKB3cX+fruLhfzoBTxJOQOmpDd+9FIscAIKhPlffP9+Liah/hiCDARJFeHMG3u3jPyV",
-	"ASIhMEUiAgLxCGEUYxHeYwEowQzPQQynzPM9eMBJSsEbe5InMFgAC7kYUB5gM5vvET1lilXs+R7DiR5Z",
-	"rOx7Av7KiIDQGyuRge/JIIYEa5HUMjWTKkHY3FutfE9ms1LKJ4hdf60tMsZH++HODA/wTwCDg2g3Gszg",
-	"6GAQ7e8fzPZ2dw8Pg8itQkuYTZpEXCRYeWMvy4ge2dZsVQw2u3J8OfkFhDQqtTWcMDsX4QzhGc8Uwmhh",
-	"B2tdVQzo+HJilUwFT0EoAmbWRTVlpf3ucGe44xCofMJnf0CgvJVfk0r2E4sSqbRM+cJyi3w4JfX5Sxk/",
-	"1ETP5V3d+R5RkJiB/ysg8sbe/4wqRx/lxhzVLFmphIXAS/3/TJBLARF5aNpkVHj5IPfyEWELYIqL5Wix",
-	"289Yl4LPKCSnoDChFnhNZcOQaGNheqyUILNMtZ9fNsa3lvRb5j9mS8SyZJY7fDkJwuXsPsIShRARBiEi",
-	"TKMihYBExKIUcYFmS4QZItoMCTBlng89h3ahUavrBccozhLMBgJwiGcUEDykFDO7QLEcUhypmEjEgyAT",
-	"AlgAhWek1mrDBkBPOGMQmCkURyFWeIYlIEUSCBHPVHdDNFqlwiwAl4i3VxMkIAK7soqxqvhCGjFKSddL",
-	"OGUThRK8REsCNERRJlQMApEaDEiEQigXCq3LV0QgiEtwqbDKHPi6iQG9vbm5RHYACngIKOKihyXLJQmr",
-	"2YowBXMQBhZEUaelZMyF8tt7KrMkwWLZWgnpeYdoovRbGQ0R4woFMWZzQJHgSV1GxddL7E8ZPASQKqNd",
-	"momUSzDUoeMJJX9br0STyKyIiERzsgCGMAsRN5ugYszQ1DM0NJ5RzP6cer41VAkHJGNMKcJUcjQziy9I",
-	"WGxSZ1fsg22uhIOAi5CwuVZwcnbzGl29PkH7/zo6RB/275ye1jEekQhYwDOB5xDaV/Q4vVAuo5yy1oaE",
-	"PMhKvOZOUU39AwznQ5RJwuZvb96/+xHdx8Canol+1Y+MgRIwJEKk2b9UgASm/CkjSqIFppkxOJYy0+BT",
-	"xnYtS7fja6xUKsejUeGRNRsOA55sxcSqHlc/FAApOejOTb4BSMmFjkr9YlVavNINSyKIiYJAZQLcuCzf",
-	"RY2xdSM8HB0ODg9crhVwAWvwrrjCtEbrabyUJMAU2Xdq8+/vuXCdYJZF2Agj3CvUR9RwWFqiUmDCFFCX",
-	"/AkPgW6f/f9kzUzmHWSyqM4aP1z9iH4DzvS/bzgN0eHB/v55v6B7BZJnIoATQzvnXJXRrZ8TiPz9grdY",
-	"fYa2XwScaRSI6y0pqbaERU5BMjrOaksXM+hobMJObaZWsHBmjb5XF/BMpyg3TqK6YCXLRpxSfq85ysgk",
-	"x2gHDVAgACvw0S4a6M0h0dJHe2iAQqCgwAKaZYk3/rDj7/p7dy5vq8vissMxyjrJueJIQE4yln/qsyDQ",
-	"KvWzRO4ETuvb3Qyr7bWDG1xfOZH9dKVzStdkt1fvbAJTToNutOA5YxZUpaO/HuPcIT14D/1wevbu7Obs",
-	"R2fMabFey7jrdv5uAyj6c2Fhp2GXC8OEsGuF1RomNN8TqQRWZAEmVyk9r5i18iXv9vzdxcnPZ6ee712/",
-	"vb25mZy/+f304leN9vKL2/Ofz/WjO39LDtyW560OkqgKktWXbYma6eY1T5qjrVmMd9Z06Agzp3yG6bGU",
-	"oFzuP6mVpAJJEKTB7XV5fO08eIEJ1ZI3pXsQR4c76iFgUTjf23PKIXiWOiLKz7C85yLUNYB2HjZHdmSd",
-	"92ZAOZtLpLhetayw1uRDVSEV318KHhGbRVbCiniQ2ucDBVINZliSwCUzxTOgn1L/XKR5vWNnQjhNKbEZ",
-	"SnvjKvEep3bhAZ56YzT1DCPq//hTnT/a72b172ZTb+VmjQQSLpab4ngZve1QTfrvyStnQr4hptqzlVoE",
-	"dcGr1PCS34M4C+eAfrvSfuOMIeYwo73WtU797QJFQumGy3aH1NuI7fZsoI7aqK28cXZ+/OqdYYfTyXXx",
-	"cRNRpFioc4O1jVbVw9Zg0qVYqq27QSXz/VZlLjTdXbx+7Ra8yJkMCHodeTSTXwdYCxm2sFSx7Vcfue3F",
-	"MpecU7tUkxg4p4MNr1uG7LFpG6nUWczh+WZ61I9nmiC5QAHFUpJoaaq62sSoPGF4Ck9mEs+h9JjCAyan",
-	"78483zs+uZn8oj+8ur3+9xaHtrp3tfjF2kQzRD2176Tap0ApmrBguDXzqHlLZ0/rxN9k5JxWSkELTmvt",
-	"awOZJYk23N6vJx0OMmkYdVP+Y2R+cg6EtJ92E6HPlHmUs396+uGm8ZYoroDhkKEHPLvo7k0kSL9T1Dzt",
-	"hkKJqydLJInqS2lFZ6KPKcJsvzdGSljkzl8XxOWa9dKxl1syVJ5EO3oarfoUUzrDwZ9u8owySpforwxT",
-	"bZrQHDApjnBVlBoMhpkAdB+TIEYBZkUJjzC65PZsX5tvytYX3msO1PoWz47NKwXkkS0QJTLlY5hBUZjV",
-	"ZzUVGUg17FNFRoQqV7g5EURp3jJC5Itaq4TclH0MyuMwASkXCkJNwveEUv3MzltV/vW9Q1PWKHoliAUJ",
-	"QJeVICDiIq8H8kmqo7n8MEHFwBCmtJBLg6mQYY315dOtXjdpUfFWo4jUEmhUVTq+LZD9Pm8VOjZAE9MF",
-	"o8uiYbYZZqVHd7G0Mmf+ltwDzhS2ZwF5o+4KQvQW6+w3E7R2JHl/fz8UEMZYmZPIblflcmIMYLaEzTsq",
-	"1dBYUIAOWPl5utcZPimHH19OTHBstbVMfGM4Jd7Y2x/uDPdNhFSxAfSmthROye+LWvNsDo6zkCtQmWAy",
-	"R5EmOAVlk07rWjbqyhZQzWVztzQeVUZh7T3eG1DHlJa9OxMcUs6k5aG9nZ1iV4Ap2+hLae7toz+kpb6q",
-	"VdqvnSftnreKlizQ9GS5jc8UNr0up7qFqlqfle8dbBQyP7r+/6cJ22oBOuR9hcOCnrQQP30VISZMgTBV",
-	"F4gFCARCcDHMu+2m02O3uOEhXpFGf/ASUDjECnt3+pXNvdOn+2mxXwlhXKx30rITluA/uFjbEO/47Xs9",
-	"7fPx3Bdn7OuMXX/4WJcsHj7mN1JWo3o6V/fSjvdcNQb6jbs1H9ymqIaMihswq7tP9LtexwKdMqhTnm7i",
-	"U1QI+Gz882Bn/ysI8ZqLGQlDYEMrw8FXkOGmuqEAYbeAusc2QYx4xsLh84Oylmf/eZotY7VT9ybnXIES",
-	"BBbQCEqNurFOQCXBfA4GGj0268tVX0r6eEbyNx/WOe7CdUrg/rf67r5g2O2y3rfGcl+fYRpe/uzpxY1a",
-	"eMCB0kUBa532/GOgHVUVYk/4XtVKyv8GHD8pjfkeUphnBJynRDtpqi2cX7v70mjqBZdvJfn+PhLvl6T3",
-	"qeD6DnPeL5Hu1qJmzzT3M4XGTjd7Q2R8htntS2bbV4jzgiO+kfjryltrwKs3cuRHgq85xwbMXTcGPu+A",
-	"2+isfvMBd/crCHHLcKZiLsjfED6D87ZvMF92t+rlBvj6XsqlcrWfASto3NTsdv+beLWvNGDwaYg17viK",
-	"h8vPFr2aGG02e3VUXXWIYvcLrr2hk2hvx4edzv1z6h2+kMTzI4l2Pm0x2XChLxnLR4/Nex4rSywUXPdV",
-	"T81z6fytdJNZ7MjPwyz+1qGtqyrrsocN6LUab0DvC3DYc6nrgSmilt/WGbPFQ19U+9uvPNgfNsp1f7lg",
-	"Y17+DKD4z8fnxk2fmvVe4vUL7Xy3tPMGVO9Mwv6cYlFQQuunU4MTyrOwe7nx+HKCrs1rjYuT49HI/BI/",
-	"5lKNj3aO7F/jyNd+dNygLG7j1P84QnWsVt7V0QzUtkNRQNXP+fP3qjPH1d3qPwEAAP//tY0if+VGAAA=",
+	"H4sIAAAAAAAC/+w9+2/cNpP/CqE74Fqcdv3My4fvB8d2EqOJ7bOd9vvQDQquNOvlFy2pkJSdbeD//cCX",
+	"REmUVnacxukt0Ma2HuRwODOct75ECVvkjAKVItr7EuWY4wVI4PqvGckkcPVbCiLhJJeE0WgvOuBEAicY",
+	"zRhHAjJIJKFXSM4BEQkLgdgMYZQRIWNUCHfLjIbEkkr8WT2iLp6OzvdP0Ok2On53gfbPjsfoCCdzlJgZ",
+	"GJ1QItQ0CywlpAgL9BPLgWPJeIyl5GRaSIivcVbA7+bHeDz+8HOMME3RosgkyTNww+EYCVBLVENNl0jA",
+	"giQsY1TEaFEIiXCWTegCy2Q+RpdzQG4qgTAHBJ9iRNU/V1L9DzHKpPofYpQwKmNEzQ9C9eyU0DE6AaHh",
+	"dqCakUooJlSBkWExBxEjUSRztcQMTyETG4KoodVQemFCz4IJVQhN2GKBRYxyzIHKOQgQiHFvRQZimmRM",
+	"QKpAUvuQwYR+KpgEMZ7QKI7gM17kGUR70U/wKeYgWMETOGMsO05jPh/ljGWjhM7Sq+3tn//nJ0LjBUsh",
+	"i/mz3c2YP32y+XMUR4RGe9GnAvgyiiOKF2o4SzlxJJI5LLAiIbnM1R0hOaFX0e1tHM1v3l3x47RNX+8p",
+	"+VQAIilQSWYEuCGoOebpjVrWAlN8Bby5BsEWMLoGmjI+yliC9WgWvhzLeQWemzmOOHwqCIc02pO8gH54",
+	"SQqLnEmgyfIXWHaC/RGWKJkzAVTRmCLyJCNAJZLMLWmJMMo5uyaCML2dCgxQ7CIYknMsEXaXEAfJCaTo",
+	"hsi5Hk3gBUyomkWxRpHNSJZBihjNlojRBFpoIRJGW6MkK4QEPtpyKJkDToFXSDmuljdS6/ORscCf3wK9",
+	"kvNob/vJkzhaEOr+3ooDqPIXd24WcoeNDqGmuarNZCt9gbdhtDt7Nh3t4idPRi/SLRg9mz6dbeKdZBu2",
+	"tsKbH4atjxSM/In2oqIgaRRaryim5brusFD/teYCMX6+k25O8Qg/AbXKrdloCs93R7Odnd3p9tbW06fJ",
+	"LLzABjBfs7Jb97A+D/bPjn8FLvSSmis8pmYswijCU1YoEr42DztZr8S7XmTOlWCVBPSo19WQ1eq3xpvj",
+	"zSCq7RU2/TckMrqNPajEMLDUwaRgshOLFfDhnPjjlzD+7oFu4b39EEf6BFQP/ieHWbQX/cdGdcRuWGRu",
+	"eJisloQ5x0v1d8HJGYcZ+VzHyYYTgCMrADcIvQYqGV9uXG8NRFaKc8m4QssgZFGEzRtBzNjBAgR/XKN0",
+	"hV03To3IFyBxttMGPY6mOPkINF2FyJfmMb2e2zgCiqcZBOD5bQ5yDtyHRIlP+/wY7VP/ckqEvo5u5iQD",
+	"RKRAFh51t6D4GpNMPVGd2Wpgs5oJdSPdzIHqGy8xh3fq5hsmJDo4P1TDUCYRoUJiJb7NOV9BhPAVJlTL",
+	"c0QkmkLCFkp5cBM3pIXha4vEKWMZYE1ZM8IXimLaCDnH9ArU3rhHKnaYsYKq86R54KbuPLPri1EOHJWb",
+	"Mo4GEv8rO6MGIUT/QmJZiHcgBL4KgK40Mw5YMNrcTrdvdSJbjf0QAV53Sbpf61ItSNe7463nHfKrEsa/",
+	"ewxUzVcR8YcQ/2ZOszkkIuGQY5oEVJE3bue0MkEEwuY9owoqsB09K7XCiMUTlkKMGLe/Vneoe1utuv56",
+	"SCpo5UqNcJyG965kpZaMcARX11+AX1u9pbVJ5Vwnon8udTSKHCfQnCpGZIYwXYZGp2pgfaaGhlZDutEM",
+	"7mYB5JUQVDjsmkrp3eGpTuzd+nRawmBZW059ryVr7FclrzA6hCxDTudHV5wV+SQIm7kQgusjUYJihlKP",
+	"FhX9FgtF3VY2VyT7m0GKgj6KI/XDXmk9GX1owdFgHX03rhFbP7+cQ864DK+jgp+AQFOQN2AltxpahM0P",
+	"LbFruK8xmXdoBM/OErADVtAOuGixmBruaM6hBXV9b6utI1TCFXC1/trK1CTD1JOglAlI6iugoA3Z/Y4V",
+	"SFIxiZoIcyI0B5SaZ4oljNRjnfzdJUdaG2ImgLQmPlLIstFWF88NQn5JBDIwawDtDVKtrE0fXf78cYsc",
+	"mjsXom1f9xmgy/lSvxDmNO/X72hQ9p14cs8ReA3jU8xBq0Ij5z/5mvO1lFw3c+CAPlJ2Q+vzXW+OXww4",
+	"bPVqQng8BJy+BSmBnzB1HlkRZJj0dKY1/T5uObcy9GCuFJraGLfxlybfSwmLXIpVNDfDRKmBKWTkGvgS",
+	"ufcmNEBwcZSWawjz4m9OFaUeeOgGC7Rg1+akUHfVMKNMj4M+FVDAZDivZljII84Z79XY1BmpdWWmfRsJ",
+	"UFktUi264DChKzezRGN7Qz/Ejdn364vWKlHCiixV19EU3PwVGqwFPTW4reurd9ekjVpQyo1KYQ6wXHmz",
+	"PY8Do65xe2y3SIIilIkQtZ1UR4t6AHF9PCqHS2W3u70KT7i1HSLEBf7c6SN4Q67mIKQ3ftGUHc/Gm5vm",
+	"v9BaFoR2Dv6W3awY++l4a3O8Ex67QV7VNtQmrS3PoTYkUo6dYX4OMw5ifg6iyDrOmdKIVxynXX0zzhY1",
+	"cR3WP5T8RtxM0KmIDz487UCDT8/y+SFHv314sCxxaumg49k9LBwCgxLS927faVikvOB9Y3ce9z6KQgA0",
+	"1xmiJKf6H32WQEsnFE5TooDG2Vlty9vrESAVV1sd1gQBEGWp1fhjZQtMokmxubmTGBtA/Qpjc8W+ba5N",
+	"InsGy2pcRARitNxnPGXXgBhHC0IvyJ8Qq3DKZ/WbumgxY3wdmC4R0z6Zj7AUYcujxxKCEh8xSjDnSwVM",
+	"udgpLJnVzss3RA6Jb/8cnL1HmCdzIiGRBdcQCiIB5RlOYAFUTmjOMpIsTTDIGzxjNwjnebZEkiHQR1cN",
+	"pTQ151uCqTpfBEh7CLTwb9z6eEKH78FHWFbLmEQJo5KzLAM+TvJi31vQJFJKE0nmSOKPIFCujtsUVIgA",
+	"nZaY14GinIM2s9MJxQIRETya6qOHya2FVOu+h7Q8TdTiRU3IfH7+9I+nuyECqHa5U+6rWRuO5uotGzqM",
+	"9eWk4BxodUSQmVYABEjPaL3eCtieRtQrBfISFnmGZcfqXzMk7QOluWBfLO0HvfwYsWvgnKQuOFq+ljA6",
+	"I1cF1xGdoMERZpUFpmQGQvYD6J6q5psWNM3UNtEUuN0lUCFYqn0MjsRm5GqB8w1DkWo9JTkyjiaRgISD",
+	"DNyOtQeEG2/JhHKYqWWrRTPFgnrgd7jD8UBB3jD+8RBL3L+sJtLtiyjFEvsb0dyEh1rdhKqr6G6r4zj5",
+	"GF6OulOBaULTU0CVc6HvyLxc5p2eN/cMUm/3sGZrcCUXO84XIgFJf2xzXLJZewUZ06gJTpBzwOnLZcf+",
+	"spxl7GrpDanD6PolhBPOhIjVRhGpd2WikWu2T72igqUmJ0FF1nu9jerFbgDfsTSAhzfsBqknE5n1gqjv",
+	"pWyBSRV0ckvzRFBu6AhqobuQUJL4quPUV3ea+NenpKZ5nTwwqlIhJtFHWP5DJxlMIpRjwuvyWRLg/7hi",
+	"WRr/ySj8Aw8LNZ1lxRWhd/FO5PoNNC1Ilpp4iBTOPyF6AlB3cGx54a+QO4vIizlug/uaSI00UoNTWc8K",
+	"VqkV9rqt8QS2MSRBxfmKdZ5lr1l5OAXnUcpCfZ4rtjXe3h4/+RoPi5nmXgGMKmhRbkVIiT3jbJrB4hAk",
+	"JpnepOY+OnV2v0yU6VNzVyiK+3Tp6fHVIF4ajuaDFGaEGm8t1uph5SRg3LrHiEKIUgb19XEUWF2ql9VG",
+	"8z6aFwtMR0oCqIgOgs95hqmZwE1nXA5EIJYY/aQKUeQGa/WNOWCUQqKHkEwfcFMsjLGVIlbIECHoOBdN",
+	"IATi+/Njc27pmU24yMVljAgpIe2GcEKPJVrgJVoSyFI0K7hWL4nH5WSGUignsi7xKv2Ak6DQ1YHAsIh7",
+	"c3l5hswDKGEp2ENsFSbLKQmVQTtREpkFMSXmjMu4uaeiWCwwXzZm0gfsGB1L9ZbzNCXaP2isew9Gyboh",
+	"jicUPieQG/shL3jOhFEllTKQkT8NVaLjmZ4REYGuyDWYBDRmI96YokmkpezeNMP0ozohNaJKdkBijrMM",
+	"4UwwpWjoDJnUbdLAeFCTlHCSMJ5aZej46PIVOn91gHZePH+Kft/5EKS0FvJ0PDxhBdfRZ+mCXWoiC6OY",
+	"0MaGpCwpSn4tNRs39E8wvhqbpMQ3l+/e/mxi9DXKRNZXSgRagBYiNlysjSQq4wlV55I+LtUtLESxML7D",
+	"KTQx3czqmUuZi72NDUeRHg7HCVus5ImG/LUMUsqgDuGbgBB3SPpAuXulfeKutADLd2t2YNPeG4XtvYTx",
+	"LkeGZBJnnljP50tBEpwh8443/k6HY5IWM6yB4Z3GUfmEx4clJqoFHFMJWdAKYylkq0f/L+GhSb+j7aP2",
+	"HD+d/4z+CYyqn69ZlqKnuzs7JwP1Ly/PTTlBXivPQohvjSOCzayyWEs0RJxloKVJaYbmnM2Iy0BpehzP",
+	"zM0VPkc7hPahkMrtTv04m4YqWpFqGHfEqJrx+crhUsNx5TtZPVE9O3aFfaVsoIYlUAWH1QHQZ8Fd6Izq",
+	"UBzF3BE1e0vcfR7WtUHqTt1ya2yGM1EWWJj8XmVmAw9aJ4L8Cat8rGYSQmuTLAglCzXP5kpfq+UavaLY",
+	"Iz87+4cVbGHTP0NM4dJv1QFS0q2sjkc1AqQxkhxTkVm7XJv9zuEY9KFlrEi7qKedD3M6OlAvtDI8PAgU",
+	"gHWa9vJ8e/xpqyylgN9ZxfhYFRxtL8DdDRjYweC7lkjDjbewQDNhoWMzwFYgmazfddGbeaQzp1cHi+ym",
+	"2rlqaxtIgRc9im7O2RUHIVblRtcpbdGXPWdvusX7sVi37yhh1NhNQzKkRLcIFn7SUiM5ycgxw4Ne8mCH",
+	"xlntaX+2VDMxSy/HJso7f2s1a2uyfI5Ft25TIV8/15zHk5H+NkdxdGAdu+avs4qDozh6pYP9URydQwZY",
+	"KadBiTowqz4sS8L1BtV2TJc1RijjgsY7UFNMg7nizlp7zzu2RSWpq03PWZaVerl5pwoTduxKg+O6Uvhp",
+	"tQtmF32gQqx4DnmGl33RWa7vmXigelZhz8uSgLSWXCBafGjegnT1SeiNYnIvjF/WJUcEEz8aeCknC6+1",
+	"M01lkFFQKjfWjvUhbh90jCqriF+sKIzQ0RttSTmjsyyasSM47cCvapgMokcfwCMVY78MGq6nVfhyxrKM",
+	"3agt1jCJPbSJRijhgCXEaAuNlLJOZssYbaOR2hmQJlnF8vxmvBVvfwhZHz4sITzso6JVIiKZojljdBp7",
+	"1B9FxR2pHIYJSwRB7JvdTKvtNQ/XbP+KiMxv5zALD/b+/K2T63YYdKkAN7AjR6vqRGkmI5U7pB7eRj8d",
+	"Hr09ujz6eTwgGaiB3K6d72OK4baxw9M44I1WUW/ZGaXS94mQHEtybUSflyBhRvXOj/cnb08Pfjk6jOLo",
+	"4s37y8vjk9d/HJ7+pqy/8sb7k19O1KXQaXG/eG0DnhhRhYGM/OmZafpUNwlDhl8r3ZQqb2GpNehkvUZV",
+	"E0/mYdu/BlwrxKKcPKhy8lQ3mxDX3aUXbFF/2kUbiPBx3nbSZ2yKs30hQK4qb+FIACc130Qdg2Tm1W3U",
+	"HSH8+dNN+dlWWAbhKFXkOgC/wPKG8VSgFBSx0ytjPQlfTpuAm0CSje+kXdWs+ApYVQ9qro8kCDmaYkGS",
+	"cCKgKl/9Cv/9aW5esoWwdTdBfeMq8L5MzMQjPIn20CTSElz9EU8ocvem/r3pJLoNS7kFLBhf9vmhSu+T",
+	"eRQRit6Rl0GHco9PyBSreh6gkDgoV3jGboAfpVeA/nmu6CYa7A65mDMuzQRO8Qqzy2qCNCm9ent6RJ33",
+	"1Eo5d3Sy//KtlmaHxxfu1z7BlmMuTfpiL1bVYx08GVT7FXZ7lqTvr1zMqRLPp69edenvxud3J5vXc94G",
+	"mNXBsEJKuW0/v+e2t71fdcHgFYqHXjcScsCm9YrS4MiS8U47195EKVyTBERQNLtzbGj12oUZs2s/wjF5",
+	"T1yry1MlsBlHSYaFILNlZZVa0V1G7O4itwtl05cU7Cjy+PDtURRH+weXx7+qX16+v/iXx2BxdPTPy6Pz",
+	"k/23b//1x9n56a/HF8enJ0eHQQI2mxQKKKvrakU1H3rLp61Ljo5pMl6p0nlk3SK+uqPPhyR2DkELqBO+",
+	"DQKsiZBS2tf4M/a1uYDUq2G7T7HUMN9ZudQO5LaG+UAqUjn61+tJ4fOmAUroZAvAMECOrHLC90g8pN5x",
+	"xmTLy+EY7s4QCSKHyt6me7QPFWmxM5hHSrawxO8D0keayigq8y0CSoMWeApYTL1Qcan3NzUJ66Cs5Zg9",
+	"OAmXcET3jgWVQ8Qm1GVywsqrQj+cOpVR2Czcj7B0WX91d3vDygoSrduz3qr0EsNE1JFsEowr+9wswxZA",
+	"tmu+u8s11Z0WFoyrw1NhLOBxqcPG9oj5MKjCyj7UFMyBRLYwRd5DWKrxYs95o0Mn26qjkJ+Boo+CgNVe",
+	"yzgKWO3l/RWk723KIEUizIaBc/0uBkSPwbB9F4thiAR3DB4439GgqcvqB6UGhReoNaTmzE10Vx6Tw6NX",
+	"xyfagDg4fXf2/lIpPCdHl7+dnv9yfPJaeVIuT8/3Xx8FtZt7Fp14sLjjpSzp6a1E+YXQtL+u+q6LPnvz",
+	"r4vjg/232kX0Wv/2YeUpKgbEsm1WwEp6X6mjrspSDhybDTZPgZNrVySlE3E0D8SWCTD1XJmaehqJmdNt",
+	"/DTZgtHz6fNk9ATvzkYvZk9gtJnspNuwNdvFT6dDXKp/vSpsUdat49boqsldTfJuU0Hsi8KQlL4g8g7S",
+	"2XQvq+1WIE3cRZ+wRESOA2GEe+YDdXKPAQsLNMMc4UqmBzmVpBmcf5VUsOnxWOrcDNN0BBUCgtPd3e3U",
+	"t8o+n9Q9RZ0Vb+FUfxv8fvjKOyyHjz9UnNXHHC6/7qDuq0fvodXbGfrrBSuebXJ1m2Q9A9ewU5C3Pa/G",
+	"MPauuVba6Qc9iXDqliOa8nVPazk6P0Bvnj15gl5xRuX9FX0zdozwymBFbf6NFK43RIrv58cKOrACq3w6",
+	"2wXYfL75NNmepjubm9sv0p3pbrK7+SLZSZ7NnkUdGVUvl52aqtBllv6MimGn+gVv6t1nL548efFid3tr",
+	"d7eeD/10N8hfwwwKh20/7GNVlDeHOoh1of49+fXd0Z18S56a243Nw6O3b6OB5kmFxSAjeGHmgR3SqgLu",
+	"dj+/xnGGs2zaWfY1K7JMFYHjTAmSVKc564yyMhSuHVRpwcHWdSaYukRShNEZE9LhaEK7w/0dad1DQ/YB",
+	"UVcCyGYmLC2QDlqnBbigoT9qmbc0RNEa1ITWTmqwkjJ90FIok7JL9mcc3ZAsU9fMuFW+gb93aEJroXZV",
+	"G0tU2ezlHDjMmGsbZQepEsRtCoOcg26a5eDCvIKhA/vi7lj3Ueri7NVTtUZQdo1lW7B3toNqYAOUzXdK",
+	"s2WjqVxX7puj6DYv3erKE3Oe6M61JgPByO7oHFL0BssojgqeeYnxNzc3Yw7pHEudD9+u7Tk7tm2H+bWy",
+	"9ZtL8rix1Fuisqojaj1edmpQ7R+juN3SUTt/Kc5JtBftjDfHO9p9LOeaoftaMuKc/HHtNY68goDicw6y",
+	"4FSUbTYykFA2qFRrdSNUhUgeyVqy1BRVuqgV9USvQe5nWdm3UusJOaPCyKHtzU23K7bViA6xGmrf+Lcw",
+	"oq9qEzqslaUwe97wIhaJEk9GtrGpxLriKrhct1S1nts42u0F0hZQ/PfdgG0UogXgfYlTJ54UEE++CxAq",
+	"95/r2KnufYeAc8bHttOsrjcyW1yjkMgFn37XbTVTLHH0Qb3SR6SOQVcSp61FtJNpM9vUaZrKE6FSCRm9",
+	"qqoHykaWtjBQvVJr4mSK8r0mrBMq50C463oiygZrfECvStei0q21gym8otRvyBPeLHdiCYtkz2u55oXB",
+	"vNBG3r044nrr7pLbSbAFoYx3i+2yQnGB/814Z3vkFtG+U8M+Hlm+JsmhJNmmh/uSpLv4xfbQud3Agc6W",
+	"QUI9MF0RRb2fZTAKWgrvlS0t642PfQ/RhAYai+qPE1Rj1Tryitg6bdUqzHjd/TjlDRujQ/+26jC0VAq9",
+	"rsQhQKXt2lxV4iCiBtE5+17hDOOI63R7SDv4rtU8NK59IKOjLWD1yIbdLN2T7tsxbRPK3iMHOTgeDQ/v",
+	"bu5+ByAuq8p2SAOcgI1JZ9vIPS5RY8DZ+k5Yc/22ndu3G4kpA5f3rQgz0ApZWNTuPE4K8Dq9N8W7laq+",
+	"2nZSL58Ny9BGw157FlQBw/sdBtVt12lv70uUs1Bp4//qPnYi5Mj3Y2fdh0QZeCuPAl12kpSfAHKwTGiC",
+	"k3mgbbz7yIkpPVDqUAoN3NjP5KiWZGiqPS76rh5Q9dEgHERIatt+i8deAPYRiuyO9pCrbIWyn2Adz+O1",
+	"KF+L8r9KlBsOVOxfp78fUYZb7qskS1otqq/d9wMJ7UAVo+iW2+6Jnmr0rhpO07/S8mn9q1G6h2xV86sr",
+	"3dIJZRRNYY6zmUOD+YSV8t8w4ZUkE4Gk6pjmf5+Ka8MY0mDFqeehHqP9CXWXOeR66kb3icY3qezYorOg",
+	"1a3AYWJGuJCIUZhQQoUErNNqam8uGIder9GBHjLUNuDeZ0u88tHGt8bMaaRnfcnS5cM5qgKruq37+CUv",
+	"4LZ1Fm5/SxBsNfyq4xAnCeTSuc466uIfz9H4PYT8e4oLOWdcle8ZKL6HlH7F+JSkKdAfSEfY3XzxncBs",
+	"yjx9iLnvNYSlnmkijFIy012sJMrxMmM4tQzwuLxk31PhqWFP+eVs5NZ9B6xp5GnR34F1TxPwbz+gMrDx",
+	"Jdjo4NYoBxmEsvZNBwlr3/kNl/yGMX6zhZZyUAbLrUVW9qSwHS8KKolubqRObz1ZGcoLmmSHGtK/+PwM",
+	"Ii5k1G0HCh86DxmNdL9zZt+hs5b3j0beA5VELh+3JfhjSUbD1HeWjPGAQPPKdketjD4iRaPrVjvu+0jF",
+	"z+Yj0KNrIeh6Q561gFsLuP+fAk6Fb+v8cHdZlxcBWfc+T7H0ujaWjTto6n+4okf6lZFXhjjopF/sjaTr",
+	"yk2HJqI+F9bq24lMXRUrTI911wWv+uJ4gqn9MJYZJxgeNct4RGJ17Rzp1lsLvVmDhPraVbI+WQadLN/P",
+	"QRL2K7sUjzKnY338fcXxZ8T7X+L58Gua/HS7lg5/XnvwGx4ztuTgq9X1O5WBl41CWlVnP1o6z3eVm+N1",
+	"HPorpNOPGMOVnMA11PJw63ktDxiyrcmqjS/1cszbocLrq2RXX3caovCpilNclfheu0lMXXmNvW1sVvp8",
+	"S29FW+qtkxbvyio1Kn/04iXMtfAZ66+mMdrIRvvLmLa8PVj3OC9f+DH4+JEqPH8HZedRZZINPxeF/Ryt",
+	"+VbUt+Y71bikr47Ga+9iSmVbTUPKiWIX6lYPV98qTdhiSmjZXK27I8yE6pYwfsWBnmBVXx9XidbTDGrR",
+	"EXk4r2Hhu6Wj3rkl1d/DFlnbAffJR/27mQHS8t43kWwbX/w/B5oBl6Zx1kOoDwM7WvXoFGVnqW6dYkWb",
+	"hr/EVqik0loKfTU/6eo3jz3WYumbiqWgneNaeD6wVBpkyPz9HKhrhWWtsPxNFJZvoat4espAHeWB9JPW",
+	"xwV6NJFH6KFcaxxDgThxMuIH8YyEzmSP8fyOXOKezCeI7HF9qIaqqz0e5TWXqaNdHjWnTcufEex2OaF6",
+	"hNKXcXXF4QpLQAnOcaKi/MblQSrVUIzReX0o5X+pGryWXU3r3c1aIkWv9JE7P8r2tmsdYq1D/Lg6hLCs",
+	"9lD6Q10M9qgNF7UHHzmve7D++Py+TpejP2AwJtw2VvRoIHFHabgpU6t9+6/diTZU1Fxjg6/j2IdPv63z",
+	"6JC8261vOHdPqq2rOZfzNt7XQmItJIYmRRierJHQQ5sj/hgbX+o9h3vLSk29lxIxqySLefJhJMtqT2N9",
+	"CZ3aQw/3mhX3cO+acdZlTl/B1YYfhnL1gAJJ23DX1Oys4saGXv4IWPGvP59r9Y0e9tbn9Vrs/G3Fjqpf",
+	"/G6axEYKOB1lII2YGdBB2f/cgrDdAVmRpchWIqaQkWv9uQb7PQk74xQ4wjOpsPB5jgshXRdC+8JyQrGU",
+	"sMil6BCPh4DTtxpS/xsZ4keSlINcHuF13s350Ram5TZDWt/Dtfq0lmMPJcd6yOz7ibUN3fV02d2w7x27",
+	"BrGKTXS/UyfTnMxCnwooIKSixOUHbSQn4WjLuQbrbyDV+oOzapED26ZqbK4UWDqP1+3AWnqtpdfD1Lko",
+	"Or2vALvVH5y7dqxan/90dKB7OLS+aKSKey/0a7WPK+1tbGQswdmcCbn3fPP5puZQO/eXwFeW3PcJGl/U",
+	"sBkb7q6WDE3MOMe2n2xm36vCUe0XzwK1xt6rtVrj2w+3/zcA9WAUpb3CAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
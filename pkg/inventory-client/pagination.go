@@ -0,0 +1,70 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inventory_client
+
+import (
+	"context"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/pkg/inventory-client/generated"
+)
+
+// The inventory server currently returns complete lists, so the iterators below process a single page. Using them
+// rather than the list methods keeps callers unchanged if the server starts paging its responses.
+
+// ForEachResourcePool calls fn for each resource pool of the hardware manager, stopping at the first error
+func (c *Client) ForEachResourcePool(ctx context.Context, hwMgrId string, fn func(generated.ResourcePoolInfo) error,
+	opts ...ListOption) error {
+	pools, err := c.GetResourcePools(ctx, hwMgrId, opts...)
+	if err != nil {
+		return err
+	}
+	return forEach(pools, fn)
+}
+
+// ForEachResource calls fn for each resource of the hardware manager, stopping at the first error
+func (c *Client) ForEachResource(ctx context.Context, hwMgrId string, fn func(generated.ResourceInfo) error,
+	opts ...ListOption) error {
+	resources, err := c.GetResources(ctx, hwMgrId, opts...)
+	if err != nil {
+		return err
+	}
+	return forEach(resources, fn)
+}
+
+// ForEachResourcePoolResource calls fn for each resource of a resource pool, stopping at the first error
+func (c *Client) ForEachResourcePoolResource(ctx context.Context, hwMgrId, resourcePoolId string,
+	fn func(generated.ResourceInfo) error, opts ...ListOption) error {
+	resources, err := c.GetResourcePoolResources(ctx, hwMgrId, resourcePoolId, opts...)
+	if err != nil {
+		return err
+	}
+	return forEach(resources, fn)
+}
+
+// Paginate splits items into pages of the given size, for callers presenting results page by page. A size of 0 or
+// less returns all items in a single page.
+func Paginate[T any](items []T, size int) [][]T {
+	if size <= 0 || len(items) <= size {
+		return [][]T{items}
+	}
+
+	pages := make([][]T, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		pages = append(pages, items[start:end])
+	}
+	return pages
+}
+
+func forEach[T any](items []T, fn func(T) error) error {
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inventory_client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/pkg/inventory-client/generated"
+)
+
+// CreateProvisioningRequest requests hardware to be provisioned, returning the status of the request along with the
+// identifier to track it with. A request repeated with the same non-empty idempotency key returns the provisioning
+// request created by the first one, and is retried on transient failures.
+func (c *Client) CreateProvisioningRequest(ctx context.Context, hwMgrId, idempotencyKey string,
+	request generated.ProvisioningRequest) (*generated.ProvisioningRequestStatus, error) {
+	params := &generated.CreateProvisioningRequestParams{}
+	if idempotencyKey != "" {
+		params.IdempotencyKey = &idempotencyKey
+	}
+	resp, err := c.api.CreateProvisioningRequestWithResponse(ctx, hwMgrId, params, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provisioning request: %w", err)
+	}
+	if resp.JSON202 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON202, nil
+}
+
+// GetProvisioningRequest returns the status of a provisioning request
+func (c *Client) GetProvisioningRequest(ctx context.Context, hwMgrId string, id uuid.UUID) (*generated.ProvisioningRequestStatus, error) {
+	resp, err := c.api.GetProvisioningRequestWithResponse(ctx, hwMgrId, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provisioning request %s: %w", id, err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
+
+// UpdateProvisioningRequest updates the node groups and extensions of a provisioning request
+func (c *Client) UpdateProvisioningRequest(ctx context.Context, hwMgrId string, id uuid.UUID,
+	request generated.ProvisioningRequest) (*generated.ProvisioningRequestStatus, error) {
+	resp, err := c.api.UpdateProvisioningRequestWithResponse(ctx, hwMgrId, id, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update provisioning request %s: %w", id, err)
+	}
+	if resp.JSON202 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON202, nil
+}
+
+// DeleteProvisioningRequest releases the hardware provisioned for a request, succeeding if it does not exist
+func (c *Client) DeleteProvisioningRequest(ctx context.Context, hwMgrId string, id uuid.UUID) error {
+	resp, err := c.api.DeleteProvisioningRequestWithResponse(ctx, hwMgrId, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete provisioning request %s: %w", id, err)
+	}
+	if resp.StatusCode() >= 300 {
+		if apiErr := newAPIError(resp.HTTPResponse, resp.Body); !IsNotFound(apiErr) {
+			return apiErr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inventory_client
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// retryingDoer retries idempotent requests that fail with a connection error or a transient server error, with an
+// exponential backoff between attempts. Requests with other methods are sent once, as they may not be safe to repeat.
+type retryingDoer struct {
+	doer    *http.Client
+	retries int
+	backoff time.Duration
+}
+
+// isRetriableStatus returns true for the status codes that indicate a transient failure
+func isRetriableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent returns true if the request can be safely repeated, including a POST carrying an idempotency key
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+	case http.MethodPost:
+		if req.Header.Get("Idempotency-Key") == "" {
+			return false
+		}
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func (d *retryingDoer) Do(req *http.Request) (*http.Response, error) {
+	if d.retries <= 0 || !isIdempotent(req) {
+		return d.doer.Do(req) // nolint: wrapcheck
+	}
+
+	backoff := d.backoff
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err // nolint: wrapcheck
+			}
+			req.Body = body
+		}
+
+		resp, err := d.doer.Do(req)
+		if attempt >= d.retries || (err == nil && !isRetriableStatus(resp.StatusCode)) {
+			return resp, err // nolint: wrapcheck
+		}
+		if err == nil {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close() // nolint: errcheck
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err() // nolint: wrapcheck
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,102 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inventory_client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/openshift-kni/oran-hwmgr-plugin/pkg/inventory-client/generated"
+)
+
+// GetSubscriptions returns the subscriptions registered with the hardware manager
+func (c *Client) GetSubscriptions(ctx context.Context, hwMgrId string) ([]generated.Subscription, error) {
+	resp, err := c.api.GetSubscriptionsWithResponse(ctx, hwMgrId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// GetSubscription returns a subscription registered with the hardware manager
+func (c *Client) GetSubscription(ctx context.Context, hwMgrId string, id uuid.UUID) (*generated.Subscription, error) {
+	resp, err := c.api.GetSubscriptionWithResponse(ctx, hwMgrId, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription %s: %w", id, err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
+
+// RegisterSubscription ensures a subscription exists for the callback, returning the existing subscription if the
+// callback is already registered, so that a consumer can safely register on every start.
+func (c *Client) RegisterSubscription(ctx context.Context, hwMgrId string, subscription generated.Subscription) (*generated.Subscription, error) {
+	existing, err := c.GetSubscriptions(ctx, hwMgrId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range existing {
+		if existing[i].Callback == subscription.Callback {
+			return &existing[i], nil
+		}
+	}
+
+	resp, err := c.api.CreateSubscriptionWithResponse(ctx, hwMgrId, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+	if resp.JSON201 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON201, nil
+}
+
+// UnregisterSubscription deletes a subscription, succeeding if it does not exist
+func (c *Client) UnregisterSubscription(ctx context.Context, hwMgrId string, id uuid.UUID) error {
+	resp, err := c.api.DeleteSubscriptionWithResponse(ctx, hwMgrId, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription %s: %w", id, err)
+	}
+	if resp.StatusCode() >= 300 {
+		if apiErr := newAPIError(resp.HTTPResponse, resp.Body); !IsNotFound(apiErr) {
+			return apiErr
+		}
+	}
+	return nil
+}
+
+// GetDeadLetterNotifications returns the notifications that could not be delivered to the subscription callback
+func (c *Client) GetDeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) ([]generated.DeadLetterNotification, error) {
+	resp, err := c.api.GetDeadLetterNotificationsWithResponse(ctx, hwMgrId, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead-letter notifications of subscription %s: %w", id, err)
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// ReplayDeadLetterNotifications requeues the dead-lettered notifications of the subscription for delivery, returning
+// the number of notifications replayed
+func (c *Client) ReplayDeadLetterNotifications(ctx context.Context, hwMgrId string, id uuid.UUID) (int, error) {
+	resp, err := c.api.ReplayDeadLetterNotificationsWithResponse(ctx, hwMgrId, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay dead-letter notifications of subscription %s: %w", id, err)
+	}
+	if resp.JSON200 == nil {
+		return 0, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Replayed, nil
+}
@@ -8,10 +8,8 @@ SPDX-License-Identifier: Apache-2.0
 package assets
 
 import (
-	"bytes"
 	"embed"
 	"fmt"
-	"html/template"
 
 	hwmgrpluginoranopenshiftiov1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
@@ -56,57 +54,6 @@ func GetConfigmapFromFile(name string) (*corev1.ConfigMap, error) {
 	return configmapObject.(*corev1.ConfigMap), nil
 }
 
-func GetHardwareManagerFromTmpl(url string, tmpl string) (*hwmgrpluginoranopenshiftiov1alpha1.HardwareManager, error) {
-
-	t := template.Must(template.New("dell-hwmgr.tmpl").ParseFS(manifests, tmpl))
-	var data struct {
-		Url string
-	}
-	data.Url = url
-
-	var hardwaremgrBuffer bytes.Buffer
-	err := t.Execute(&hardwaremgrBuffer, data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute template %s with error: (%w)", tmpl, err)
-	}
-
-	hardwaremgrBytes := hardwaremgrBuffer.Bytes()
-	hardwaremgrObject, err := runtime.Decode(cdcs.UniversalDecoder(hwmgrpluginoranopenshiftiov1alpha1.GroupVersion), hardwaremgrBytes)
-	if err != nil {
-		return nil, fmt.Errorf("%s failed with error: (%w)", "decode", err)
-	}
-
-	return hardwaremgrObject.(*hwmgrpluginoranopenshiftiov1alpha1.HardwareManager), nil
-}
-
-func GetHardwareManagerFromFile(name string) (*hwmgrpluginoranopenshiftiov1alpha1.HardwareManager, error) {
-	hardwaremgrBytes, err := manifests.ReadFile(name)
-	if err != nil {
-		return nil, fmt.Errorf("%s failed with error: (%w)", "readfile", err)
-	}
-
-	hardwaremgrObject, err := runtime.Decode(cdcs.UniversalDecoder(hwmgrpluginoranopenshiftiov1alpha1.GroupVersion), hardwaremgrBytes)
-	if err != nil {
-		return nil, fmt.Errorf("%s failed with error: (%w)", "decode", err)
-	}
-
-	return hardwaremgrObject.(*hwmgrpluginoranopenshiftiov1alpha1.HardwareManager), nil
-}
-
-func GetNodePoolFromFile(name string) (*hwmgmtv1alpha1.NodePool, error) {
-	nodepoolBytes, err := manifests.ReadFile(name)
-	if err != nil {
-		return nil, fmt.Errorf("%s failed with error: (%w)", "readfile", err)
-	}
-
-	nodepoolObject, err := runtime.Decode(cdcs.UniversalDecoder(hwmgmtv1alpha1.GroupVersion), nodepoolBytes)
-	if err != nil {
-		return nil, fmt.Errorf("%s failed with error: (%w)", "decode", err)
-	}
-
-	return nodepoolObject.(*hwmgmtv1alpha1.NodePool), nil
-}
-
 func GetNameSpaceFromFile(name string) (*corev1.Namespace, error) {
 	namespaceBytes, err := manifests.ReadFile(name)
 	if err != nil {
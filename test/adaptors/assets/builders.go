@@ -0,0 +1,102 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	hwmgrpluginoranopenshiftiov1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defaults of the objects built by the builders, matching the envtest suites
+const (
+	DefaultNamespace = "default"
+	DefaultCloudID   = "testcloud-1"
+	DefaultSite      = "building-1"
+	DefaultLocation  = "ottawa"
+)
+
+// NodePoolBuilder builds NodePool objects for tests
+type NodePoolBuilder struct {
+	nodepool *hwmgmtv1alpha1.NodePool
+}
+
+// NewNodePool returns a builder for a NodePool with the given name, bound to the given hardware manager, with no node
+// groups
+func NewNodePool(name, hwMgrId string) *NodePoolBuilder {
+	return &NodePoolBuilder{nodepool: &hwmgmtv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DefaultNamespace},
+		Spec: hwmgmtv1alpha1.NodePoolSpec{
+			CloudID: DefaultCloudID,
+			HwMgrId: hwMgrId,
+			LocationSpec: hwmgmtv1alpha1.LocationSpec{
+				Location: DefaultLocation,
+				Site:     DefaultSite,
+			},
+		},
+	}}
+}
+
+// WithNodeGroup adds a node group of the given size
+func (b *NodePoolBuilder) WithNodeGroup(name, role, resourcePoolId, hwProfile string, size int) *NodePoolBuilder {
+	b.nodepool.Spec.NodeGroup = append(b.nodepool.Spec.NodeGroup, hwmgmtv1alpha1.NodeGroup{
+		NodePoolData: hwmgmtv1alpha1.NodePoolData{
+			Name:           name,
+			Role:           role,
+			ResourcePoolId: resourcePoolId,
+			HwProfile:      hwProfile,
+		},
+		Size: size,
+	})
+	return b
+}
+
+// Build returns a new copy of the NodePool, so that the builder can be reused for variations
+func (b *NodePoolBuilder) Build() *hwmgmtv1alpha1.NodePool {
+	return b.nodepool.DeepCopy()
+}
+
+// HardwareManagerBuilder builds HardwareManager objects for tests
+type HardwareManagerBuilder struct {
+	hwmgr *hwmgrpluginoranopenshiftiov1alpha1.HardwareManager
+}
+
+// NewHardwareManager returns a builder for a HardwareManager with the given name, using the loopback adaptor
+func NewHardwareManager(name string) *HardwareManagerBuilder {
+	return &HardwareManagerBuilder{hwmgr: &hwmgrpluginoranopenshiftiov1alpha1.HardwareManager{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DefaultNamespace},
+		Spec: hwmgrpluginoranopenshiftiov1alpha1.HardwareManagerSpec{
+			AdaptorID:    hwmgrpluginoranopenshiftiov1alpha1.SupportedAdaptors.Loopback,
+			LoopbackData: &hwmgrpluginoranopenshiftiov1alpha1.LoopbackData{},
+		},
+	}}
+}
+
+// WithLoopback selects the loopback adaptor
+func (b *HardwareManagerBuilder) WithLoopback(additionalInfo string) *HardwareManagerBuilder {
+	b.hwmgr.Spec.AdaptorID = hwmgrpluginoranopenshiftiov1alpha1.SupportedAdaptors.Loopback
+	b.hwmgr.Spec.LoopbackData = &hwmgrpluginoranopenshiftiov1alpha1.LoopbackData{AddtionalInfo: additionalInfo}
+	b.hwmgr.Spec.DellData = nil
+	return b
+}
+
+// WithDell selects the dell-hwmgr adaptor, skipping TLS verification of the test server
+func (b *HardwareManagerBuilder) WithDell(apiUrl, authSecret string) *HardwareManagerBuilder {
+	b.hwmgr.Spec.AdaptorID = hwmgrpluginoranopenshiftiov1alpha1.SupportedAdaptors.Dell
+	b.hwmgr.Spec.DellData = &hwmgrpluginoranopenshiftiov1alpha1.DellData{
+		AuthSecret:            authSecret,
+		ApiUrl:                apiUrl,
+		InsecureSkipTLSVerify: true,
+	}
+	b.hwmgr.Spec.LoopbackData = nil
+	return b
+}
+
+// Build returns a new copy of the HardwareManager, so that the builder can be reused for variations
+func (b *HardwareManagerBuilder) Build() *hwmgrpluginoranopenshiftiov1alpha1.HardwareManager {
+	return b.hwmgr.DeepCopy()
+}
@@ -39,8 +39,7 @@ var _ = Describe("request an authentication token", func() {
 
 			// create the HardwareManager cr instance
 			url := fmt.Sprintf("http://127.0.0.1:%d", fp)
-			hwmgr, err = assets.GetHardwareManagerFromTmpl(url, "manifests/dell-hwmgr.tmpl")
-			Expect(err).NotTo(HaveOccurred())
+			hwmgr = assets.NewHardwareManager("dell-1").WithDell(url, "dell-1").Build()
 			Expect(k8sClient.Create(ctx, hwmgr)).To(Succeed())
 
 			// create the Dell secret
@@ -0,0 +1,771 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	apiserver "github.com/openshift-kni/oran-hwmgr-plugin/test/adaptors/dell-hwmgr/dell-server/generated"
+)
+
+// Operation identifies an endpoint of the mock server, for scripting failures and latencies
+type Operation string
+
+const (
+	OpGetToken             Operation = "GetToken"
+	OpVerifyRequestStatus  Operation = "VerifyRequestStatus"
+	OpCreateResourceGroup  Operation = "CreateResourceGroup"
+	OpDeleteResourceGroup  Operation = "DeleteResourceGroup"
+	OpGetResourceGroup     Operation = "GetResourceGroup"
+	OpGetResourceGroups    Operation = "GetResourceGroups"
+	OpGetResourcePools     Operation = "GetResourcePools"
+	OpGetResourcePool      Operation = "GetResourcePool"
+	OpGetResources         Operation = "GetResources"
+	OpGetResource          Operation = "GetResource"
+	OpUpdateResource       Operation = "UpdateResource"
+	OpSubscribeResources   Operation = "SubscribeResources"
+	OpUnsubscribeResources Operation = "UnsubscribeResources"
+	OpAbortJob             Operation = "AbortJob"
+)
+
+// Job statuses reported by the mock server, as by the hardware manager
+const (
+	JobPending   = "pending"
+	JobStarted   = "started"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+)
+
+// jobAbortedReason is the failure reason of an aborted job
+const jobAbortedReason = "job aborted"
+
+// roleKey is the label of the resources matched by the role selector of the resource groups
+var roleKey = hwmgrclient.RoleKey
+
+// fault is a scripted failure of an operation
+type fault struct {
+	status    int
+	message   string
+	remaining int
+}
+
+// mockJob is a job run by the mock server. It reports started until it has been polled JobPolls times, and then
+// completes, applying its effect, or fails.
+type mockJob struct {
+	id         string
+	status     string
+	failReason string
+	polls      int
+	startTime  time.Time
+	// apply applies the effect of the job on completion, returning a failure reason if it cannot be applied. It is
+	// called with the server lock held.
+	apply func() string
+	// abort reverts the partial effect of the job when it is aborted, if any. It is called with the server lock held.
+	abort func()
+}
+
+// mockResourceGroup is a resource group and the resources allocated to each of its selectors
+type mockResourceGroup struct {
+	request   hwmgrapi.RhprotoResourceGroupObjectRequest
+	allocated map[string][]string
+}
+
+// MockServer is an in-memory hardware manager implementing the endpoints used by the Dell adaptor: authentication,
+// resource pools, resources, resource groups, resource subscriptions and jobs, which can be aborted. Resource group creation and deletion,
+// and resource updates, run as jobs that complete after being polled. Failures and latencies can be scripted per
+// operation, so that the adaptor logic can be exercised against error responses and slow requests.
+type MockServer struct {
+	DellServer
+
+	// ClientId, Username and Password, when set, are required on token requests
+	ClientId string
+	Username string
+	Password string
+	// TokenLifetime is the lifetime of the tokens, reported in the token responses
+	TokenLifetime time.Duration
+	// JobPolls is the number of status queries for which a job reports started before it completes
+	JobPolls int
+
+	mutex         sync.Mutex
+	server        *httptest.Server
+	nextId        int
+	tokens        map[string]bool
+	pools         []hwmgrapi.ApiprotoResourcePool
+	resources     []hwmgrapi.ApiprotoResource
+	groups        map[string]*mockResourceGroup
+	jobs          map[string]*mockJob
+	subscriptions map[string]map[string]bool
+	faults        map[Operation]*fault
+	jobFaults     map[Operation]string
+	latencies     map[Operation]time.Duration
+	calls         map[Operation]int
+}
+
+// NewMockServer creates a MockServer with no resources
+func NewMockServer() *MockServer {
+	return &MockServer{
+		TokenLifetime: time.Hour,
+		JobPolls:      1,
+		tokens:        make(map[string]bool),
+		groups:        make(map[string]*mockResourceGroup),
+		jobs:          make(map[string]*mockJob),
+		subscriptions: make(map[string]map[string]bool),
+		faults:        make(map[Operation]*fault),
+		jobFaults:     make(map[Operation]string),
+		latencies:     make(map[Operation]time.Duration),
+		calls:         make(map[Operation]int),
+	}
+}
+
+// Start starts the server in-process, returning its URL
+func (s *MockServer) Start() string {
+	// The job abort endpoint is not part of the generated API, and is routed alongside it
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/tenants/{tenant}/jobs/{jobid}", func(w http.ResponseWriter, r *http.Request) {
+		s.AbortJob(w, r, mux.Vars(r)["tenant"], mux.Vars(r)["jobid"])
+	}).Methods(http.MethodDelete)
+	s.server = httptest.NewServer(apiserver.HandlerWithOptions(s, apiserver.GorillaServerOptions{BaseRouter: router}))
+	return s.server.URL
+}
+
+// Close stops the server
+func (s *MockServer) Close() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+// AddResourcePool adds a resource pool
+func (s *MockServer) AddResourcePool(id, name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pools = append(s.pools, hwmgrapi.ApiprotoResourcePool{Id: &id, Name: &name})
+}
+
+// AddResource adds a resource to a resource pool. Resources are matched to the selectors of resource groups by pool
+// and labels, and the role label is set from the role given here.
+func (s *MockServer) AddResource(poolId, role string, resource hwmgrapi.ApiprotoResource) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	resource.ResourcePoolId = &poolId
+	labels := []hwmgrapi.ApiprotoLabel{{Key: &roleKey, Value: &role}}
+	if resource.Labels != nil {
+		labels = append(labels, *resource.Labels...)
+	}
+	resource.Labels = &labels
+	s.resources = append(s.resources, resource)
+}
+
+// FailNext makes the next calls to an operation fail with the status and message
+func (s *MockServer) FailNext(op Operation, status int, message string, times int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults[op] = &fault{status: status, message: message, remaining: times}
+}
+
+// FailNextJob makes the next job started by an operation fail with the reason
+func (s *MockServer) FailNextJob(op Operation, reason string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobFaults[op] = reason
+}
+
+// SetLatency delays the responses to an operation
+func (s *MockServer) SetLatency(op Operation, latency time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latencies[op] = latency
+}
+
+// Calls returns the number of calls made to an operation, including failed calls
+func (s *MockServer) Calls(op Operation) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls[op]
+}
+
+// JobStatus returns the status of a job, or an empty string if it does not exist
+func (s *MockServer) JobStatus(jobId string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if job, exists := s.jobs[jobId]; exists {
+		return job.status
+	}
+	return ""
+}
+
+// Subscribed returns the resources added to a resource subscription, in name order
+func (s *MockServer) Subscribed(subscriptionId string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var resourceIds []string
+	for id := range s.subscriptions[subscriptionId] {
+		resourceIds = append(resourceIds, id)
+	}
+	sort.Strings(resourceIds)
+	return resourceIds
+}
+
+// intercept records the call and applies the scripted latency and failure of the operation, returning true if the
+// response has been written
+func (s *MockServer) intercept(w http.ResponseWriter, op Operation) bool {
+	s.mutex.Lock()
+	s.calls[op]++
+	latency := s.latencies[op]
+	var failure *fault
+	if f, exists := s.faults[op]; exists && f.remaining > 0 {
+		f.remaining--
+		failure = f
+	}
+	s.mutex.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if failure != nil {
+		if op == OpGetToken {
+			writeTokenError(w, failure.status, failure.message)
+		} else {
+			writeError(w, failure.status, failure.message)
+		}
+		return true
+	}
+	return false
+}
+
+// authorize checks the bearer token of the request, returning false if the response has been written
+func (s *MockServer) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mutex.Lock()
+	valid := found && s.tokens[token]
+	s.mutex.Unlock()
+	if !valid {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return false
+	}
+	return true
+}
+
+// begin intercepts and authorizes a request, returning false if the response has been written
+func (s *MockServer) begin(w http.ResponseWriter, r *http.Request, op Operation) bool {
+	return !s.intercept(w, op) && s.authorize(w, r)
+}
+
+// newId returns a new identifier with the prefix. The caller holds the lock.
+func (s *MockServer) newId(prefix string) string {
+	s.nextId++
+	return fmt.Sprintf("%s-%d", prefix, s.nextId)
+}
+
+// startJob starts a job applying its effect on completion, unless a failure is scripted for the operation. The caller
+// holds the lock.
+func (s *MockServer) startJob(op Operation, apply func() string) string {
+	job := &mockJob{id: s.newId("job"), status: JobPending, startTime: time.Now(), apply: apply}
+	if reason, exists := s.jobFaults[op]; exists {
+		delete(s.jobFaults, op)
+		job.apply = func() string { return reason }
+	}
+	s.jobs[job.id] = job
+	return job.id
+}
+
+// findResource returns the resource with the identifier. The caller holds the lock.
+func (s *MockServer) findResource(id string) *hwmgrapi.ApiprotoResource {
+	for i := range s.resources {
+		if s.resources[i].Id != nil && *s.resources[i].Id == id {
+			return &s.resources[i]
+		}
+	}
+	return nil
+}
+
+// isAllocated checks whether the resource belongs to a resource group. The caller holds the lock.
+func (s *MockServer) isAllocated(id string) bool {
+	for _, group := range s.groups {
+		for _, resourceIds := range group.allocated {
+			for _, resourceId := range resourceIds {
+				if resourceId == id {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// matchesSelector checks whether the resource is in the pool of the selector and has all its labels
+func matchesSelector(resource hwmgrapi.ApiprotoResource, selector hwmgrapi.RhprotoResourceSelectorRequest) bool {
+	if selector.RpId != nil && *selector.RpId != "" &&
+		(resource.ResourcePoolId == nil || *resource.ResourcePoolId != *selector.RpId) {
+		return false
+	}
+	if selector.Filters == nil || selector.Filters.Include == nil || selector.Filters.Include.Labels == nil {
+		return true
+	}
+	for _, wanted := range *selector.Filters.Include.Labels {
+		found := false
+		if resource.Labels != nil {
+			for _, label := range *resource.Labels {
+				if label.Key != nil && wanted.Key != nil && *label.Key == *wanted.Key &&
+					label.Value != nil && wanted.Value != nil && *label.Value == *wanted.Value {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// allocate selects free resources for each selector of the resource group, returning a failure reason if there are
+// not enough. The caller holds the lock.
+func (s *MockServer) allocate(group *mockResourceGroup) string {
+	if group.request.ResourceSelectors == nil {
+		return ""
+	}
+	names := make([]string, 0, len(*group.request.ResourceSelectors))
+	for name := range *group.request.ResourceSelectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	taken := make(map[string]bool)
+	allocated := make(map[string][]string)
+	for _, name := range names {
+		selector := (*group.request.ResourceSelectors)[name]
+		wanted := 0
+		if selector.NumResources != nil {
+			wanted = *selector.NumResources
+		}
+		for _, resource := range s.resources {
+			if len(allocated[name]) == wanted {
+				break
+			}
+			if resource.Id == nil || taken[*resource.Id] || s.isAllocated(*resource.Id) || !matchesSelector(resource, selector) {
+				continue
+			}
+			taken[*resource.Id] = true
+			allocated[name] = append(allocated[name], *resource.Id)
+			if selector.ResourceProfileId != nil {
+				profile := *selector.ResourceProfileId
+				s.findResource(*resource.Id).ResourceProfileID = &profile
+			}
+		}
+		if len(allocated[name]) < wanted {
+			return fmt.Sprintf("not enough free resources for %s: requested %d, found %d", name, wanted, len(allocated[name]))
+		}
+	}
+	group.allocated = allocated
+	return ""
+}
+
+// groupResponse builds the response body for a resource group. The caller holds the lock.
+func (s *MockServer) groupResponse(group *mockResourceGroup) hwmgrapi.RhprotoResourceGroupObjectGetResponseBody {
+	request := group.request
+	selectors := make(map[string]hwmgrapi.RhprotoResourceSelectorGetResponse)
+	if request.ResourceSelectors != nil {
+		for name, selector := range *request.ResourceSelectors {
+			numResources := float32(0)
+			if selector.NumResources != nil {
+				numResources = float32(*selector.NumResources)
+			}
+			resources := []hwmgrapi.RhprotoResource{}
+			for _, id := range group.allocated[name] {
+				if resource := s.findResource(id); resource != nil {
+					resources = append(resources, toRhprotoResource(*resource))
+				}
+			}
+			selectors[name] = hwmgrapi.RhprotoResourceSelectorGetResponse{
+				ResourceProfileId: selector.ResourceProfileId,
+				RpId:              selector.RpId,
+				NumResources:      &numResources,
+				Resources:         &resources,
+			}
+		}
+	}
+	return hwmgrapi.RhprotoResourceGroupObjectGetResponseBody{
+		Id:                request.Id,
+		Name:              request.Name,
+		Description:       request.Description,
+		ResourceTypeId:    request.ResourceTypeId,
+		ResourceSelectors: &selectors,
+	}
+}
+
+// toRhprotoResource converts a resource to its representation in a resource group, which shares its fields but the
+// deployment
+func toRhprotoResource(resource hwmgrapi.ApiprotoResource) hwmgrapi.RhprotoResource {
+	resource.Deployment = nil
+	var converted hwmgrapi.RhprotoResource
+	if data, err := json.Marshal(resource); err == nil {
+		_ = json.Unmarshal(data, &converted)
+	}
+	return converted
+}
+
+// page returns the bounds of the page requested by the pagination of a list request
+func page(pagination *hwmgrapi.ApiprotoPagination, total int) (int, int) {
+	start, end := 0, total
+	if pagination != nil {
+		if pagination.Offset != nil {
+			start = min(int(*pagination.Offset), total)
+		}
+		if pagination.Limit != nil && *pagination.Limit > 0 {
+			end = min(start+int(*pagination.Limit), total)
+		}
+	}
+	return start, end
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error in the format of the hardware manager, with the HTTP status in the details
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, hwmgrclient.RespDefault{
+		Code:    status,
+		Message: message,
+		Details: []hwmgrclient.RespDefaultDetails{
+			{
+				Reason:   message,
+				Metadata: hwmgrclient.RespDefaultDetailsMetadata{HTTPErrorCode: fmt.Sprintf("%d", status)},
+			},
+		},
+	})
+}
+
+// writeTokenError writes an error for a token request, whose details are an object rather than a list
+func writeTokenError(w http.ResponseWriter, status int, message string) {
+	code := int32(status)
+	writeJSON(w, status, hwmgrapi.RhprotoGooglerpcStatus{Code: &code, Message: &message})
+}
+
+func (s *MockServer) GetToken(w http.ResponseWriter, r *http.Request) {
+	if s.intercept(w, OpGetToken) {
+		return
+	}
+	var req hwmgrapi.RhprotoGetTokenReqBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTokenError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	matches := func(expected string, actual *string) bool {
+		return expected == "" || (actual != nil && *actual == expected)
+	}
+	if !matches(s.ClientId, req.ClientId) || !matches(s.Username, req.Username) || !matches(s.Password, req.Password) {
+		writeTokenError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	s.mutex.Lock()
+	token := s.newId("token")
+	s.tokens[token] = true
+	s.mutex.Unlock()
+
+	expiresIn := int64(s.TokenLifetime.Seconds())
+	writeJSON(w, http.StatusOK, hwmgrapi.RhprotoGetTokenResponseBody{AccessToken: &token, ExpiresIn: &expiresIn})
+}
+
+func (s *MockServer) VerifyRequestStatus(w http.ResponseWriter, r *http.Request, tenant, jobid string) {
+	if !s.begin(w, r, OpVerifyRequestStatus) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	job, exists := s.jobs[jobid]
+	if !exists {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	if job.status == JobPending || job.status == JobStarted {
+		job.polls++
+		job.status = JobStarted
+		if job.polls > s.JobPolls {
+			if reason := job.apply(); reason != "" {
+				job.status, job.failReason = JobFailed, reason
+			} else {
+				job.status = JobCompleted
+			}
+		}
+	}
+
+	startTime := job.startTime.Format(time.RFC3339)
+	brief := &hwmgrapi.RhprotoJobStatusBrief{Id: &job.id, Status: &job.status, StartTime: &startTime}
+	if job.failReason != "" {
+		brief.FailReason = &job.failReason
+	}
+	writeJSON(w, http.StatusOK, hwmgrapi.RhprotoJobStatus{Brief: brief})
+}
+
+// AbortJob aborts a job in progress, reverting its partial effect. Jobs that have already finished cannot be aborted.
+func (s *MockServer) AbortJob(w http.ResponseWriter, r *http.Request, tenant, jobid string) {
+	if !s.begin(w, r, OpAbortJob) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	job, exists := s.jobs[jobid]
+	if !exists {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	if job.status != JobPending && job.status != JobStarted {
+		writeError(w, http.StatusConflict, "job already finished")
+		return
+	}
+	if job.abort != nil {
+		job.abort()
+	}
+	job.status, job.failReason = JobFailed, jobAbortedReason
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *MockServer) CreateResourceGroup(w http.ResponseWriter, r *http.Request, tenant string) {
+	if !s.begin(w, r, OpCreateResourceGroup) {
+		return
+	}
+	var req hwmgrapi.RhprotoCreateResourceGroupReqBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ResourceGroup == nil || req.ResourceGroup.Id == nil {
+		writeError(w, http.StatusBadRequest, "invalid resource group")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := *req.ResourceGroup.Id
+	if _, exists := s.groups[id]; exists {
+		writeError(w, http.StatusConflict, "resource group already exists")
+		return
+	}
+	group := &mockResourceGroup{request: *req.ResourceGroup}
+	s.groups[id] = group
+	jobId := s.startJob(OpCreateResourceGroup, func() string {
+		if reason := s.allocate(group); reason != "" {
+			delete(s.groups, id)
+			return reason
+		}
+		return ""
+	})
+	s.jobs[jobId].abort = func() { delete(s.groups, id) }
+	writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoResponse{Id: &id, Jobid: &jobId})
+}
+
+func (s *MockServer) DeleteResourceGroup(w http.ResponseWriter, r *http.Request, tenant, resourceGroupId string) {
+	if !s.begin(w, r, OpDeleteResourceGroup) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.groups[resourceGroupId]; !exists {
+		writeError(w, http.StatusNotFound, "resource group not found")
+		return
+	}
+	jobId := s.startJob(OpDeleteResourceGroup, func() string {
+		delete(s.groups, resourceGroupId)
+		return ""
+	})
+	writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoResponse{Id: &resourceGroupId, Jobid: &jobId})
+}
+
+func (s *MockServer) GetResourceGroup(w http.ResponseWriter, r *http.Request, tenant, resourceGroupId string) {
+	if !s.begin(w, r, OpGetResourceGroup) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	group, exists := s.groups[resourceGroupId]
+	if !exists {
+		writeError(w, http.StatusNotFound, "resource group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.groupResponse(group))
+}
+
+func (s *MockServer) GetResourceGroups(w http.ResponseWriter, r *http.Request, tenant string, params apiserver.GetResourceGroupsParams) {
+	if !s.begin(w, r, OpGetResourceGroups) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ids := make([]string, 0, len(s.groups))
+	for id := range s.groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	groups := make([]hwmgrapi.RhprotoResourceGroupObjectGetResponseBody, 0, len(ids))
+	for _, id := range ids {
+		groups = append(groups, s.groupResponse(s.groups[id]))
+	}
+	total := int64(len(groups))
+	writeJSON(w, http.StatusOK, hwmgrapi.RhprotoResourceGroupsResp{
+		ResourceGroups: &groups,
+		Pagination:     &hwmgrapi.ApiprotoPagination{Total: &total},
+	})
+}
+
+func (s *MockServer) GetResourcePools(w http.ResponseWriter, r *http.Request, tenant string) {
+	if !s.begin(w, r, OpGetResourcePools) {
+		return
+	}
+	var req hwmgrapi.GetResourcePoolsJSONBody
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	start, end := page(req.Pagination, len(s.pools))
+	pools := append([]hwmgrapi.ApiprotoResourcePool{}, s.pools[start:end]...)
+	total := int64(len(s.pools))
+	writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoResourcePoolsResp{
+		ResourcePools: &pools,
+		Pagination:    &hwmgrapi.ApiprotoPagination{Total: &total},
+	})
+}
+
+func (s *MockServer) GetResourcePool(w http.ResponseWriter, r *http.Request, tenant, id string) {
+	if !s.begin(w, r, OpGetResourcePool) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, pool := range s.pools {
+		if pool.Id != nil && *pool.Id == id {
+			writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoResourcePoolResp{ResourcePool: &pool})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "resource pool not found")
+}
+
+func (s *MockServer) GetResources(w http.ResponseWriter, r *http.Request, tenant string) {
+	if !s.begin(w, r, OpGetResources) {
+		return
+	}
+	var req hwmgrapi.GetResourcesJSONBody
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	start, end := page(req.Pagination, len(s.resources))
+	resources := append([]hwmgrapi.ApiprotoResource{}, s.resources[start:end]...)
+	total := int64(len(s.resources))
+	writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoGetResourcesResp{
+		Resources:  &resources,
+		Pagination: &hwmgrapi.ApiprotoPagination{Total: &total},
+	})
+}
+
+func (s *MockServer) GetResource(w http.ResponseWriter, r *http.Request, tenant, id string) {
+	if !s.begin(w, r, OpGetResource) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	resource := s.findResource(id)
+	if resource == nil {
+		writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+	found := *resource
+	writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoGetResourceResp{Resource: &found})
+}
+
+func (s *MockServer) UpdateResource(w http.ResponseWriter, r *http.Request, tenant string) {
+	if !s.begin(w, r, OpUpdateResource) {
+		return
+	}
+	var req hwmgrapi.UpdateResourceJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ResourceName == nil {
+		writeError(w, http.StatusBadRequest, "invalid resource update")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := *req.ResourceName
+	if s.findResource(id) == nil {
+		writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+	jobId := s.startJob(OpUpdateResource, func() string {
+		resource := s.findResource(id)
+		if resource == nil {
+			return "resource no longer exists"
+		}
+		if req.Resource == nil {
+			return ""
+		}
+		// Only profile updates change the resource, other updates such as BIOS attributes just complete
+		for _, update := range *req.Resource {
+			if update.Path == nil || *update.Path != "/Resource/ResourceProfileID" || update.Value == nil {
+				continue
+			}
+			for _, value := range *update.Value {
+				if profile, ok := value["resourceProfileID"].(string); ok {
+					resource.ResourceProfileID = &profile
+				}
+			}
+		}
+		return ""
+	})
+	writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoUpdateResourceResp{Response: &hwmgrapi.ApiprotoResponse{Id: &id, Jobid: &jobId}})
+}
+
+func (s *MockServer) SubscribeResources(w http.ResponseWriter, r *http.Request, tenant string) {
+	s.updateSubscription(w, r, OpSubscribeResources, true)
+}
+
+func (s *MockServer) UnsubscribeResources(w http.ResponseWriter, r *http.Request, tenant string) {
+	s.updateSubscription(w, r, OpUnsubscribeResources, false)
+}
+
+// updateSubscription adds the resources of the request to the subscription, or removes them
+func (s *MockServer) updateSubscription(w http.ResponseWriter, r *http.Request, op Operation, subscribe bool) {
+	if !s.begin(w, r, op) {
+		return
+	}
+	var req hwmgrapi.SubscribeResourcesJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Id == nil {
+		writeError(w, http.StatusBadRequest, "invalid subscription request")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	subscribed, exists := s.subscriptions[*req.Id]
+	if !exists {
+		subscribed = make(map[string]bool)
+		s.subscriptions[*req.Id] = subscribed
+	}
+	if req.Resources != nil {
+		for _, id := range *req.Resources {
+			if subscribe {
+				subscribed[id] = true
+			} else {
+				delete(subscribed, id)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, hwmgrapi.ApiprotoSubscribeResourcesResp{Resp: &hwmgrapi.ApiprotoResponse{Id: req.Id}})
+}
@@ -0,0 +1,214 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dellserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	hwmgrapi "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+)
+
+const tenant = "default_tenant"
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// newToken returns a token issued by the mock server
+func newToken(t *testing.T, url string) string {
+	anonymous, err := hwmgrapi.NewClientWithResponses(url)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	response, err := anonymous.GetTokenWithResponse(context.Background(), hwmgrapi.GetTokenJSONRequestBody{})
+	if err != nil || response.JSON200 == nil || response.JSON200.AccessToken == nil {
+		t.Fatalf("failed to get token: %v, %v", response, err)
+	}
+	return *response.JSON200.AccessToken
+}
+
+// newClient starts the mock server and returns a client authenticated against it
+func newClient(t *testing.T, s *MockServer) *hwmgrapi.ClientWithResponses {
+	url := s.Start()
+	t.Cleanup(s.Close)
+	token := newToken(t, url)
+
+	client, err := hwmgrapi.NewClientWithResponses(url, hwmgrapi.WithRequestEditorFn(
+		func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestGetTokenCredentials(t *testing.T) {
+	s := NewMockServer()
+	s.Username, s.Password = "admin", "secret"
+	url := s.Start()
+	defer s.Close()
+
+	client, err := hwmgrapi.NewClientWithResponses(url)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	response, err := client.GetTokenWithResponse(ctx, hwmgrapi.GetTokenJSONRequestBody{Username: ptr("admin"), Password: ptr("wrong")})
+	if err != nil || response.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("expected invalid credentials to be rejected, got %v, %v", response.Status(), err)
+	}
+
+	response, err = client.GetTokenWithResponse(ctx, hwmgrapi.GetTokenJSONRequestBody{Username: ptr("admin"), Password: ptr("secret")})
+	if err != nil || response.JSON200 == nil || response.JSON200.ExpiresIn == nil || *response.JSON200.ExpiresIn != 3600 {
+		t.Errorf("expected a token, got %v, %v", response.Status(), err)
+	}
+
+	resources, err := client.GetResourcesWithResponse(ctx, tenant, hwmgrapi.GetResourcesJSONRequestBody{})
+	if err != nil || resources.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("expected unauthenticated requests to be rejected, got %v, %v", resources.Status(), err)
+	}
+}
+
+func TestGetResourcesPagination(t *testing.T) {
+	s := NewMockServer()
+	s.AddResourcePool("pool-1", "pool")
+	for _, id := range []string{"server-1", "server-2", "server-3"} {
+		s.AddResource("pool-1", "controller", hwmgrapi.ApiprotoResource{Id: ptr(id)})
+	}
+	client := newClient(t, s)
+
+	response, err := client.GetResourcesWithResponse(context.Background(), tenant, hwmgrapi.GetResourcesJSONRequestBody{
+		Pagination: &hwmgrapi.ApiprotoPagination{Offset: ptr(int64(2)), Limit: ptr(int64(2))},
+	})
+	if err != nil || response.JSON200 == nil {
+		t.Fatalf("failed to get resources: %v, %v", response, err)
+	}
+	if resources := *response.JSON200.Resources; len(resources) != 1 || *resources[0].Id != "server-3" {
+		t.Errorf("unexpected page %v", resources)
+	}
+	if total := *response.JSON200.Pagination.Total; total != 3 {
+		t.Errorf("expected a total of 3, got %d", total)
+	}
+}
+
+func TestUpdateResourceJob(t *testing.T) {
+	s := NewMockServer()
+	s.JobPolls = 2
+	s.AddResourcePool("pool-1", "pool")
+	s.AddResource("pool-1", "controller", hwmgrapi.ApiprotoResource{Id: ptr("server-1")})
+	client := newClient(t, s)
+	ctx := context.Background()
+
+	value := []map[string]interface{}{{"resourceProfileID": "profile-2"}}
+	response, err := client.UpdateResourceWithResponse(ctx, tenant, hwmgrapi.UpdateResourceJSONRequestBody{
+		ResourceName: ptr("server-1"),
+		Resource:     &[]hwmgrapi.ApiprotoUpdateResource{{Op: ptr("replace"), Path: ptr("/Resource/ResourceProfileID"), Value: &value}},
+	})
+	if err != nil || response.JSON200 == nil {
+		t.Fatalf("failed to update resource: %v, %v", response, err)
+	}
+	jobId := *response.JSON200.Response.Jobid
+
+	for _, expected := range []string{JobStarted, JobStarted, JobCompleted} {
+		status, err := client.VerifyRequestStatusWithResponse(ctx, tenant, jobId)
+		if err != nil || status.JSON200 == nil {
+			t.Fatalf("failed to get job status: %v, %v", status, err)
+		}
+		if actual := *status.JSON200.Brief.Status; actual != expected {
+			t.Fatalf("expected job status %s, got %s", expected, actual)
+		}
+	}
+
+	resource, err := client.GetResourceWithResponse(ctx, tenant, "server-1")
+	if err != nil || resource.JSON200 == nil {
+		t.Fatalf("failed to get resource: %v, %v", resource, err)
+	}
+	if profile := resource.JSON200.Resource.ResourceProfileID; profile == nil || *profile != "profile-2" {
+		t.Errorf("expected the profile to be updated, got %v", profile)
+	}
+}
+
+func TestScriptedFailuresAndLatency(t *testing.T) {
+	s := NewMockServer()
+	client := newClient(t, s)
+	ctx := context.Background()
+
+	s.FailNext(OpGetResources, http.StatusServiceUnavailable, "unavailable", 1)
+	s.SetLatency(OpGetResources, 50*time.Millisecond)
+
+	start := time.Now()
+	response, err := client.GetResourcesWithResponse(ctx, tenant, hwmgrapi.GetResourcesJSONRequestBody{})
+	if err != nil || response.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("expected the scripted failure, got %v, %v", response.Status(), err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the scripted latency, got %s", elapsed)
+	}
+
+	response, err = client.GetResourcesWithResponse(ctx, tenant, hwmgrapi.GetResourcesJSONRequestBody{})
+	if err != nil || response.StatusCode() != http.StatusOK {
+		t.Errorf("expected the failure to apply once, got %v, %v", response.Status(), err)
+	}
+	if calls := s.Calls(OpGetResources); calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+
+	status, err := client.VerifyRequestStatusWithResponse(ctx, tenant, "unknown")
+	if err != nil || status.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected unknown jobs to be reported missing, got %v, %v", status.Status(), err)
+	}
+}
+
+func TestAbortJob(t *testing.T) {
+	s := NewMockServer()
+	s.JobPolls = 5
+	client := newClient(t, s)
+	ctx := context.Background()
+
+	response, err := client.CreateResourceGroupWithResponse(ctx, tenant, hwmgrapi.CreateResourceGroupJSONRequestBody{
+		ResourceGroup: &hwmgrapi.RhprotoResourceGroupObjectRequest{Id: ptr("group-1")},
+	})
+	if err != nil || response.JSON200 == nil {
+		t.Fatalf("failed to create resource group: %v, %v", response, err)
+	}
+	jobId := *response.JSON200.Jobid
+
+	token := newToken(t, s.server.URL)
+	abort := func() int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.server.URL+"/v1/tenants/"+tenant+"/jobs/"+jobId, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to abort job: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := abort(); status != http.StatusAccepted {
+		t.Fatalf("expected the job to be aborted, got status %d", status)
+	}
+	if status := s.JobStatus(jobId); status != JobFailed {
+		t.Errorf("expected the aborted job to be failed, got %s", status)
+	}
+	group, err := client.GetResourceGroupWithResponse(ctx, tenant, "group-1")
+	if err != nil || group.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected the resource group to be removed, got %v, %v", group.Status(), err)
+	}
+	if status := abort(); status != http.StatusConflict {
+		t.Errorf("expected a finished job not to be aborted, got status %d", status)
+	}
+}
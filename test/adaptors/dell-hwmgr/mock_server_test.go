@@ -0,0 +1,137 @@
+/*
+SPDX-FileCopyrightText: Red Hat
+
+SPDX-License-Identifier: Apache-2.0
+*/
+//nolint:all
+package dellhwmgr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	api "github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/generated"
+	"github.com/openshift-kni/oran-hwmgr-plugin/adaptors/dell-hwmgr/hwmgrclient"
+	hwmgrpluginoranopenshiftiov1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/test/adaptors/assets"
+	dellserver "github.com/openshift-kni/oran-hwmgr-plugin/test/adaptors/dell-hwmgr/dell-server"
+	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("provision a resource group against the mock server", func() {
+	When("the hardware manager is served by the in-process mock server", func() {
+
+		var (
+			mock     *dellserver.MockServer
+			hwmgr    *hwmgrpluginoranopenshiftiov1alpha1.HardwareManager
+			secret   *corev1.Secret
+			nodepool *hwmgmtv1alpha1.NodePool
+			hmc      *hwmgrclient.HardwareManagerClient
+		)
+
+		ctx := context.Background()
+
+		// waitForJob polls the job until it is no longer in progress
+		waitForJob := func(jobId string) (hwmgrclient.JobStatus, string) {
+			var (
+				status     hwmgrclient.JobStatus
+				failReason string
+			)
+			Eventually(func() hwmgrclient.JobStatus {
+				var err error
+				status, failReason, err = hmc.CheckJobStatus(ctx, jobId)
+				Expect(err).NotTo(HaveOccurred())
+				return status
+			}).WithTimeout(5 * time.Second).ShouldNot(Equal(hwmgrclient.JobStatus(hwmgrclient.JobStatusInProgress)))
+			return status, failReason
+		}
+
+		BeforeEach(func() {
+			var err error
+
+			mock = dellserver.NewMockServer()
+			mock.ClientId, mock.Username, mock.Password = "myclient", "admin", "notreal"
+			mock.AddResourcePool("xyz-master", "master pool")
+			for i := 0; i < 3; i++ {
+				id := fmt.Sprintf("server-%d", i)
+				mock.AddResource("xyz-master", "controller", api.ApiprotoResource{Id: &id, Name: &id})
+			}
+			url := mock.Start()
+
+			hwmgr = assets.NewHardwareManager("dell-mock").WithDell(url, "dell-1").Build()
+			Expect(k8sClient.Create(ctx, hwmgr)).To(Succeed())
+
+			secret, err = assets.GetSecretFromFile("manifests/dell-secret.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			nodepool = assets.NewNodePool("np-mock", "dell-mock").
+				WithNodeGroup("controller", "master", "xyz-master", "profile-spr-single-processor-64G", 3).
+				Build()
+
+			hmc, err = hwmgrclient.NewClientWithResponses(ctx, logger, k8sClient, hwmgr)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			mock.Close()
+			Expect(k8sClient.Delete(ctx, hwmgr)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		})
+
+		It("must allocate the requested resources and release them on deletion", func() {
+			By("creating the resource group and waiting for its job")
+			jobId, err := hmc.CreateResourceGroup(ctx, nodepool)
+			Expect(err).NotTo(HaveOccurred())
+			status, _ := waitForJob(jobId)
+			Expect(status).To(Equal(hwmgrclient.JobStatus(hwmgrclient.JobStatusCompleted)))
+
+			rg, err := hmc.GetResourceGroupFromNodePool(ctx, nodepool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*(*rg.ResourceSelectors)["controller"].Resources).To(HaveLen(3))
+
+			By("deleting the resource group and waiting for its job")
+			jobId, err = hmc.DeleteResourceGroup(ctx, nodepool)
+			Expect(err).NotTo(HaveOccurred())
+			status, _ = waitForJob(jobId)
+			Expect(status).To(Equal(hwmgrclient.JobStatus(hwmgrclient.JobStatusCompleted)))
+
+			exists, err := hmc.ResourceGroupExists(ctx, nodepool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("must report a failed job when there are not enough free resources", func() {
+			nodepool = assets.NewNodePool("np-mock", "dell-mock").
+				WithNodeGroup("controller", "master", "xyz-master", "profile-spr-single-processor-64G", 4).
+				Build()
+
+			jobId, err := hmc.CreateResourceGroup(ctx, nodepool)
+			Expect(err).NotTo(HaveOccurred())
+			status, failReason := waitForJob(jobId)
+			Expect(status).To(Equal(hwmgrclient.JobStatus(hwmgrclient.JobStatusFailed)))
+			Expect(failReason).To(ContainSubstring("not enough free resources"))
+		})
+
+		It("must surface scripted failures of the hardware manager", func() {
+			By("failing the resource group creation request")
+			mock.FailNext(dellserver.OpCreateResourceGroup, http.StatusInternalServerError, "internal error", 1)
+			_, err := hmc.CreateResourceGroup(ctx, nodepool)
+			Expect(err).To(HaveOccurred())
+
+			By("failing the job of the next request")
+			mock.FailNextJob(dellserver.OpCreateResourceGroup, "hardware fault")
+			jobId, err := hmc.CreateResourceGroup(ctx, nodepool)
+			Expect(err).NotTo(HaveOccurred())
+			status, failReason := waitForJob(jobId)
+			Expect(status).To(Equal(hwmgrclient.JobStatus(hwmgrclient.JobStatusFailed)))
+			Expect(failReason).To(Equal("hardware fault"))
+			Expect(mock.Calls(dellserver.OpCreateResourceGroup)).To(Equal(2))
+		})
+	})
+})
@@ -12,9 +12,11 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	hwmgrpluginoranopenshiftiov1alpha1 "github.com/openshift-kni/oran-hwmgr-plugin/api/hwmgr-plugin/v1alpha1"
+	"github.com/openshift-kni/oran-hwmgr-plugin/internal/controller/utils"
 	"github.com/openshift-kni/oran-hwmgr-plugin/test/adaptors/assets"
 	hwmgmtv1alpha1 "github.com/openshift-kni/oran-o2ims/api/hardwaremanagement/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -37,13 +39,13 @@ var _ = Describe("reconcile via the loopback adaptor", func() {
 			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
 
 			// create the HardwareManager cr instance
-			hwmgr, err = assets.GetHardwareManagerFromFile("manifests/loopback-hwmgr.yaml")
-			Expect(err).NotTo(HaveOccurred())
+			hwmgr = assets.NewHardwareManager("loopback-1").WithLoopback("This is a test string").Build()
 			Expect(k8sClient.Create(ctx, hwmgr)).To(Succeed())
 
 			// create the Nodepool cr instance
-			np, err = assets.GetNodePoolFromFile("manifests/np1-np.yaml")
-			Expect(err).NotTo(HaveOccurred())
+			np = assets.NewNodePool("np1", "loopback-1").
+				WithNodeGroup("controller", "master", "xyz-master", "profile-spr-single-processor-64G", 1).
+				Build()
 			Expect(k8sClient.Create(ctx, np)).To(Succeed())
 		})
 
@@ -87,6 +89,32 @@ var _ = Describe("reconcile via the loopback adaptor", func() {
 			Expect(DoAllsecretsHaveNpOwnerRef(np.UID)).To(BeTrue())
 		})
 
+		It("must provision a single-node pool", func() {
+			By("reporting the pool provisioned with its only node")
+
+			Expect(utils.IsSingleNodePool(np)).To(BeTrue())
+
+			ns := types.NamespacedName{
+				Name:      "np1",
+				Namespace: "default",
+			}
+			provisioned := &hwmgmtv1alpha1.NodePool{}
+			timeout, interval := 30, 1
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, ns, provisioned); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(provisioned.Status.Conditions, string(hwmgmtv1alpha1.Provisioned))
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(provisioned.Status.Properties.NodeNames).To(HaveLen(1))
+
+			node := &hwmgmtv1alpha1.Node{}
+			Expect(nodeExists("dummy-sp-64g-0", node)()).To(BeTrue())
+			Expect(provisioned.Status.Properties.NodeNames[0]).To(Equal(node.Name))
+			Expect(node.Spec.GroupName).To(Equal("controller"))
+		})
+
 	})
 })
 